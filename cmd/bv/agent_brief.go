@@ -0,0 +1,221 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/export"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/version"
+)
+
+// agentBriefFormat versions the layout of the --agent-brief bundle
+const agentBriefFormat = "1.0.0"
+
+// agentBriefMeta is the bundle's meta.json. Inputs records what each file
+// was generated from (the data hash, or the bv version for files that do
+// not depend on the issues), which is how --refresh tells stale files.
+type agentBriefMeta struct {
+	GeneratedAt string            `json:"generated_at"`
+	DataHash    string            `json:"data_hash"`
+	IssueCount  int               `json:"issue_count"`
+	Version     string            `json:"version"`
+	BVVersion   string            `json:"bv_version"`
+	Files       []string          `json:"files"`
+	Inputs      map[string]string `json:"inputs"`
+}
+
+// agentBriefFile is one file of the bundle and how to generate it
+type agentBriefFile struct {
+	name     string
+	input    string // Data hash or bv version the content depends on
+	generate func() ([]byte, error)
+}
+
+// writeAgentBrief writes the agent brief bundle to dir. With refresh, files
+// whose recorded input matches the current one are left alone. It returns
+// how many files were written.
+func writeAgentBrief(dir string, issues []model.Issue, dataHash, projectDir string, fs *flag.FlagSet, refresh bool, now time.Time, stdout io.Writer) (int, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return 0, fmt.Errorf("creating directory: %w", err)
+	}
+
+	var previous agentBriefMeta
+	if refresh {
+		if raw, err := os.ReadFile(filepath.Join(dir, "meta.json")); err == nil {
+			_ = json.Unmarshal(raw, &previous)
+		}
+	}
+
+	// brief.md is rendered from the triage, so it is computed at most once
+	var triageJSON []byte
+	triage := func() ([]byte, error) {
+		if triageJSON != nil {
+			return triageJSON, nil
+		}
+		var err error
+		triageJSON, err = json.MarshalIndent(analysis.ComputeTriage(issues), "", "  ")
+		return triageJSON, err
+	}
+
+	files := []agentBriefFile{
+		{"triage.json", dataHash, triage},
+		{"insights.json", dataHash, func() ([]byte, error) {
+			stats := analysis.NewAnalyzer(issues).Analyze()
+			return json.MarshalIndent(stats.GenerateInsights(50), "", "  ")
+		}},
+		{"brief.md", dataHash, func() ([]byte, error) {
+			data, err := triage()
+			if err != nil {
+				return nil, err
+			}
+			config := export.DefaultPriorityBriefConfig()
+			config.DataHash = dataHash
+			brief, err := export.GeneratePriorityBriefFromTriageJSON(data, config)
+			return []byte(brief), err
+		}},
+		{"plan.json", dataHash, func() ([]byte, error) {
+			return json.MarshalIndent(buildRobotPlan(issues, dataHash, projectDir, false, now), "", "  ")
+		}},
+		{"graph.dot", dataHash, func() ([]byte, error) {
+			stats := analysis.NewAnalyzer(issues).Analyze()
+			result, err := export.ExportGraph(issues, &stats, export.GraphExportConfig{Format: export.GraphFormatDOT, DataHash: dataHash})
+			if err != nil {
+				return nil, err
+			}
+			return []byte(result.Graph), nil
+		}},
+		{"helpers.md", version.Version, func() ([]byte, error) {
+			return []byte(generateJQHelpers()), nil
+		}},
+		{"capabilities.json", version.Version, func() ([]byte, error) {
+			return json.MarshalIndent(buildCapabilities(fs), "", "  ")
+		}},
+	}
+
+	meta := agentBriefMeta{
+		GeneratedAt: now.UTC().Format(time.RFC3339),
+		DataHash:    dataHash,
+		IssueCount:  len(issues),
+		Version:     agentBriefFormat,
+		BVVersion:   version.Version,
+		Inputs:      map[string]string{},
+	}
+	written := 0
+	for _, f := range files {
+		meta.Files = append(meta.Files, f.name)
+		meta.Inputs[f.name] = f.input
+		path := filepath.Join(dir, f.name)
+		if _, err := os.Stat(path); err == nil && refresh && previous.Inputs[f.name] == f.input {
+			fmt.Fprintf(stdout, "  · %s (up to date)\n", f.name)
+			continue
+		}
+		data, err := f.generate()
+		if err != nil {
+			return written, fmt.Errorf("generating %s: %w", f.name, err)
+		}
+		if err := os.WriteFile(path, data, 0644); err != nil {
+			return written, fmt.Errorf("writing %s: %w", f.name, err)
+		}
+		fmt.Fprintf(stdout, "  → %s\n", f.name)
+		written++
+	}
+
+	// An untouched bundle keeps its meta.json, so generated_at stays the
+	// time the content was last produced
+	metaPath := filepath.Join(dir, "meta.json")
+	if _, err := os.Stat(metaPath); err == nil && written == 0 {
+		return 0, nil
+	}
+	meta.Files = append(meta.Files, "meta.json")
+	metaJSON, err := json.MarshalIndent(meta, "", "  ")
+	if err != nil {
+		return written, err
+	}
+	if err := os.WriteFile(metaPath, metaJSON, 0644); err != nil {
+		return written, fmt.Errorf("writing meta.json: %w", err)
+	}
+	fmt.Fprintln(stdout, "  → meta.json")
+	return written, nil
+}
+
+// agentCapabilities is capabilities.json: the commands and flags of the
+// installed bv, so an agent can check for a feature before relying on it.
+type agentCapabilities struct {
+	BVVersion          string              `json:"bv_version"`
+	SchemaVersion      string              `json:"schema_version"`      // Robot payload version (see --robot-schema)
+	Commands           []capabilityCommand `json:"commands"`            // Subcommands and the flags they stand for
+	StandaloneCommands []string            `json:"standalone_commands"` // bv new, bv claim, ...: see bv <command> --help
+	RobotSchemas       []string            `json:"robot_schemas"`       // Names accepted by --robot-schema
+	Flags              []capabilityFlag    `json:"flags"`
+}
+
+// capabilityCommand is one runnable subcommand, verbs spelled out
+type capabilityCommand struct {
+	Command     string   `json:"command"`
+	Summary     string   `json:"summary"`
+	Arg         string   `json:"arg,omitempty"`
+	ArgOptional bool     `json:"arg_optional,omitempty"`
+	Flags       []string `json:"flags,omitempty"`    // Flags the command sets
+	ArgFlag     string   `json:"arg_flag,omitempty"` // Flag receiving the argument
+	Options     []string `json:"options,omitempty"`
+}
+
+// capabilityFlag is one flag of the flag set
+type capabilityFlag struct {
+	Name     string   `json:"name"`
+	Usage    string   `json:"usage"`
+	TakesArg bool     `json:"takes_arg"`
+	Default  string   `json:"default,omitempty"`
+	Choices  []string `json:"choices,omitempty"`
+}
+
+// buildCapabilities describes the commands and flags of fs
+func buildCapabilities(fs *flag.FlagSet) agentCapabilities {
+	caps := agentCapabilities{
+		BVVersion:          version.Version,
+		SchemaVersion:      robotSchemaVersion,
+		StandaloneCommands: standaloneCommands,
+	}
+	var walk func(prefix string, cmds []cliCommand)
+	walk = func(prefix string, cmds []cliCommand) {
+		for _, c := range cmds {
+			name := prefix + " " + c.Name
+			if len(c.Flags) > 0 || c.ArgFlag != "" {
+				cc := capabilityCommand{
+					Command:     name,
+					Summary:     c.Summary,
+					Arg:         c.Arg,
+					ArgOptional: c.Optional,
+					Flags:       c.Flags,
+					Options:     c.Options,
+				}
+				if c.ArgFlag != "-" {
+					cc.ArgFlag = c.ArgFlag
+				}
+				caps.Commands = append(caps.Commands, cc)
+			}
+			walk(name, c.Verbs)
+		}
+	}
+	walk("bv", cliCommands)
+
+	caps.RobotSchemas = robotSchemaCommands()
+
+	for _, f := range completionFlags(fs) {
+		caps.Flags = append(caps.Flags, capabilityFlag{
+			Name:     f.name,
+			Usage:    f.usage,
+			TakesArg: f.takesArg,
+			Default:  fs.Lookup(f.name).DefValue,
+			Choices:  f.choices,
+		})
+	}
+	return caps
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestWriteAgentBrief_Refresh(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "bundle")
+	fs := flag.NewFlagSet("bv", flag.ContinueOnError)
+	fs.Bool("robot-plan", false, "plan")
+	fs.String("graph-format", "json", "format")
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	issues := []model.Issue{
+		{ID: "bv-1", Title: "Schema", Status: model.StatusOpen, IssueType: model.TypeTask},
+		{ID: "bv-2", Title: "API", Status: model.StatusOpen, IssueType: model.TypeTask,
+			Dependencies: []*model.Dependency{{IssueID: "bv-2", DependsOnID: "bv-1", Type: model.DepBlocks}}},
+	}
+	hash := analysis.ComputeDataHash(issues)
+
+	var out bytes.Buffer
+	written, err := writeAgentBrief(dir, issues, hash, t.TempDir(), fs, false, now, &out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if written != 7 {
+		t.Errorf("first run wrote %d files:\n%s", written, out.String())
+	}
+	for _, name := range []string{"triage.json", "insights.json", "brief.md", "plan.json", "graph.dot", "helpers.md", "capabilities.json", "meta.json"} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+			t.Errorf("missing %s", name)
+		}
+	}
+	dot, _ := os.ReadFile(filepath.Join(dir, "graph.dot"))
+	if !strings.Contains(string(dot), "digraph") || !strings.Contains(string(dot), "bv-2") {
+		t.Errorf("graph.dot = %s", dot)
+	}
+
+	var caps agentCapabilities
+	raw, _ := os.ReadFile(filepath.Join(dir, "capabilities.json"))
+	if err := json.Unmarshal(raw, &caps); err != nil {
+		t.Fatal(err)
+	}
+	if len(caps.Flags) != 2 || caps.Flags[0].Name != "graph-format" || caps.Flags[0].Default != "json" || len(caps.Flags[0].Choices) == 0 {
+		t.Errorf("flags = %+v", caps.Flags)
+	}
+	found := false
+	for _, c := range caps.Commands {
+		if c.Command == "bv sprint show" && c.ArgFlag == "robot-sprint-show" {
+			found = true
+		}
+	}
+	if !found || len(caps.RobotSchemas) == 0 {
+		t.Errorf("commands = %+v", caps.Commands)
+	}
+
+	// Nothing changed: nothing is rewritten, meta.json included
+	metaBefore, _ := os.ReadFile(filepath.Join(dir, "meta.json"))
+	out.Reset()
+	if written, err = writeAgentBrief(dir, issues, hash, t.TempDir(), fs, true, now.Add(time.Hour), &out); err != nil || written != 0 {
+		t.Fatalf("unchanged refresh wrote %d (%v):\n%s", written, err, out.String())
+	}
+	if metaAfter, _ := os.ReadFile(filepath.Join(dir, "meta.json")); !bytes.Equal(metaBefore, metaAfter) {
+		t.Error("unchanged refresh rewrote meta.json")
+	}
+
+	// New data regenerates the data files; a deleted static file comes back
+	issues[1].Status = model.StatusClosed
+	if err := os.Remove(filepath.Join(dir, "helpers.md")); err != nil {
+		t.Fatal(err)
+	}
+	out.Reset()
+	if written, err = writeAgentBrief(dir, issues, analysis.ComputeDataHash(issues), t.TempDir(), fs, true, now, &out); err != nil {
+		t.Fatal(err)
+	}
+	if written != 6 || !strings.Contains(out.String(), "capabilities.json (up to date)") {
+		t.Errorf("refresh after change wrote %d:\n%s", written, out.String())
+	}
+	var meta agentBriefMeta
+	raw, _ = os.ReadFile(filepath.Join(dir, "meta.json"))
+	if err := json.Unmarshal(raw, &meta); err != nil || meta.Inputs["plan.json"] == hash {
+		t.Errorf("meta.json not updated: %s", raw)
+	}
+}
@@ -0,0 +1,170 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/bench"
+)
+
+const benchUsage = "Usage: bv bench [--sizes 1000,10000] [--topologies chain,star,random-dag,clustered] [--runs 3] [--baseline path] [--update-baseline] [--threshold 0.25] [--json]"
+
+// runBench implements the hidden `bv bench` command: time graph analysis on
+// synthetic graphs and compare against a stored baseline. It exits 1 when a
+// metric regressed past the threshold.
+func runBench(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("bench", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() { fmt.Fprintln(stderr, benchUsage) }
+	sizesFlag := flags.String("sizes", "", "Comma-separated issue counts (default 1000,10000)")
+	topologiesFlag := flags.String("topologies", "", "Comma-separated topologies (default all)")
+	runs := flags.Int("runs", 3, "Runs per scenario; the median is reported")
+	baselinePath := flags.String("baseline", "benchmarks/analysis-baseline.json", "Baseline report to compare against")
+	updateBaseline := flags.Bool("update-baseline", false, "Write this run as the new baseline instead of comparing")
+	threshold := flags.Float64("threshold", bench.DefaultThreshold, "Relative slowdown that counts as a regression")
+	asJSON := flags.Bool("json", false, "Print the report and regressions as JSON")
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return exitOK
+		}
+		return exitUsage
+	}
+
+	sizes, err := parseBenchSizes(*sizesFlag)
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return exitUsage
+	}
+	topologies, err := bench.ParseTopologies(*topologiesFlag)
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return exitUsage
+	}
+
+	report, err := bench.Run(topologies, sizes, *runs)
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return exitError
+	}
+
+	if *updateBaseline {
+		if err := report.Save(*baselinePath); err != nil {
+			fmt.Fprintf(stderr, "Error: %v\n", err)
+			return exitError
+		}
+		if !*asJSON {
+			printBenchReport(stdout, report, nil)
+			fmt.Fprintf(stdout, "\nBaseline written to %s\n", *baselinePath)
+			return exitOK
+		}
+	}
+
+	var baseline *bench.Report
+	var regressions []bench.Regression
+	if !*updateBaseline {
+		baseline, err = bench.LoadReport(*baselinePath)
+		switch {
+		case errors.Is(err, fs.ErrNotExist):
+			baseline = nil
+		case err != nil:
+			fmt.Fprintf(stderr, "Error: %v\n", err)
+			return exitError
+		default:
+			regressions = bench.Compare(report, baseline, *threshold, bench.DefaultFloor)
+		}
+	}
+
+	if *asJSON {
+		out := struct {
+			Report      *bench.Report      `json:"report"`
+			Baseline    string             `json:"baseline,omitempty"`
+			Threshold   float64            `json:"threshold"`
+			Regressions []bench.Regression `json:"regressions"`
+		}{report, *baselinePath, *threshold, regressions}
+		if out.Regressions == nil {
+			out.Regressions = []bench.Regression{}
+		}
+		enc := json.NewEncoder(stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(out); err != nil {
+			fmt.Fprintf(stderr, "Error encoding JSON: %v\n", err)
+			return exitError
+		}
+	} else if !*updateBaseline {
+		printBenchReport(stdout, report, baseline)
+		switch {
+		case baseline == nil:
+			fmt.Fprintf(stdout, "\nNo baseline at %s; run with --update-baseline to record one\n", *baselinePath)
+		case len(regressions) == 0:
+			fmt.Fprintf(stdout, "\nNo regressions over %.0f%% against %s\n", *threshold*100, *baselinePath)
+		default:
+			fmt.Fprintf(stdout, "\n%d regression(s) over %.0f%% against %s:\n", len(regressions), *threshold*100, *baselinePath)
+			for _, r := range regressions {
+				fmt.Fprintf(stdout, "  %s\n", r)
+			}
+		}
+	}
+
+	if len(regressions) > 0 {
+		return exitError
+	}
+	return exitOK
+}
+
+func parseBenchSizes(s string) ([]int, error) {
+	if strings.TrimSpace(s) == "" {
+		return bench.DefaultSizes, nil
+	}
+	var sizes []int
+	for _, part := range strings.Split(s, ",") {
+		n, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil || n < 1 {
+			return nil, fmt.Errorf("invalid size %q: want a positive issue count", part)
+		}
+		sizes = append(sizes, n)
+	}
+	return sizes, nil
+}
+
+// printBenchReport prints one row per scenario and metric, with the change
+// against baseline when there is one
+func printBenchReport(w io.Writer, report *bench.Report, baseline *bench.Report) {
+	base := make(map[string]bench.Result)
+	if baseline != nil {
+		for _, r := range baseline.Results {
+			base[r.Key()] = r
+		}
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "SCENARIO\tNODES\tEDGES\tMETRIC\tMS\tBASELINE\tCHANGE\t")
+	for _, r := range report.Results {
+		prev, hasPrev := base[r.Key()]
+		for _, metric := range bench.Metrics {
+			ms := fmt.Sprintf("%.1f", r.Metrics[metric])
+			for _, m := range r.TimedOut {
+				if m == metric {
+					ms += " (timeout)"
+				}
+			}
+			was, change := "-", "-"
+			if hasPrev {
+				if p, ok := prev.Metrics[metric]; ok {
+					was = fmt.Sprintf("%.1f", p)
+					if p > 0 {
+						change = fmt.Sprintf("%+.0f%%", (r.Metrics[metric]/p-1)*100)
+					}
+				}
+			}
+			fmt.Fprintf(tw, "%s\t%d\t%d\t%s\t%s\t%s\t%s\t\n", r.Key(), r.Nodes, r.Edges, metric, ms, was, change)
+		}
+	}
+	tw.Flush()
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/bench"
+)
+
+func TestRunBench(t *testing.T) {
+	baseline := filepath.Join(t.TempDir(), "baseline.json")
+	args := []string{"--sizes", "100", "--topologies", "chain,star", "--runs", "1", "--baseline", baseline}
+	var stdout, stderr bytes.Buffer
+
+	if code := runBench(args, &stdout, &stderr); code != 0 || !strings.Contains(stdout.String(), "No baseline") {
+		t.Fatalf("no baseline: exit %d, stdout %q, stderr %q", code, stdout.String(), stderr.String())
+	}
+	stdout.Reset()
+	if code := runBench(append(args, "--update-baseline"), &stdout, &stderr); code != 0 || !strings.Contains(stdout.String(), "Baseline written") {
+		t.Fatalf("update: exit %d, stdout %q, stderr %q", code, stdout.String(), stderr.String())
+	}
+
+	// Shrink a baseline metric far below anything measurable so the rerun
+	// regresses against it
+	saved, err := bench.LoadReport(baseline)
+	if err != nil {
+		t.Fatal(err)
+	}
+	saved.Results[0].Metrics["analysis_total"] = -100
+	if err := saved.Save(baseline); err != nil {
+		t.Fatal(err)
+	}
+
+	stdout.Reset()
+	if code := runBench(append(args, "--json"), &stdout, &stderr); code != 1 {
+		t.Fatalf("regression: exit %d, stderr %q", code, stderr.String())
+	}
+	var out struct {
+		Report      bench.Report       `json:"report"`
+		Regressions []bench.Regression `json:"regressions"`
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &out); err != nil {
+		t.Fatalf("bad JSON: %v", err)
+	}
+	if len(out.Report.Results) != 2 || len(out.Regressions) != 1 || out.Regressions[0].Scenario != "chain/100" {
+		t.Errorf("report = %d results, regressions %+v", len(out.Report.Results), out.Regressions)
+	}
+
+	if code := runBench([]string{"--sizes", "0"}, &stdout, &stderr); code != 2 {
+		t.Errorf("bad size: exit %d", code)
+	}
+	if code := runBench([]string{"--topologies", "mesh"}, &stdout, &stderr); code != 2 {
+		t.Errorf("bad topology: exit %d", code)
+	}
+	if err := os.WriteFile(baseline, []byte("not json"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if code := runBench(args, &stdout, &stderr); code != 1 {
+		t.Errorf("corrupt baseline: exit %d", code)
+	}
+}
@@ -0,0 +1,311 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"slices"
+	"strings"
+)
+
+// Size budgeting (--max-bytes=N) fits every robot payload under N bytes of
+// output so an agent can ask for a context-window-sized answer. A payload
+// that already fits is left alone. Otherwise optional sections go first,
+// one stage at a time, and then the longest lists are halved until the
+// payload fits; a trailing "truncation" field says what was cut.
+var robotMaxBytes int
+
+// budgetStage is one group of optional keys. A key is dropped wherever it
+// occurs; a dotted path only at that path.
+type budgetStage struct {
+	Name   string
+	Keys   []string
+	Suffix string // Keys ending in it are dropped too
+}
+
+// budgetStages are tried in order, least useful first
+var budgetStages = []budgetStage{
+	{Name: "hints", Keys: []string{"usage_hints", "field_descriptions", "example_config", "triage.commands"}},
+	{Name: "explanations", Keys: []string{"reasons", "reasoning", "explanation", "explanations"}, Suffix: "_explanation"},
+	{Name: "breakdowns", Keys: []string{"breakdown", "risk_signals", "analysis_config"}},
+	{Name: "full_maps", Keys: []string{"full_stats", "Stats", "advanced_insights", "top_what_ifs"}},
+}
+
+// budgetMinItems is how short the list trimming stage cuts a list
+const budgetMinItems = 1
+
+// truncationManifest is the "truncation" field of a trimmed payload
+type truncationManifest struct {
+	MaxBytes      int            `json:"max_bytes"`
+	OriginalBytes int            `json:"original_bytes"`
+	Fits          bool           `json:"fits"` // False when even the trimmed payload is over budget
+	Removed       []removedField `json:"removed,omitempty"`
+	Truncated     []trimmedList  `json:"truncated,omitempty"`
+}
+
+// removedField is an optional key dropped from the payload
+type removedField struct {
+	Stage string `json:"stage"`
+	Field string `json:"field"`
+	Count int    `json:"count"` // Occurrences removed
+}
+
+// trimmedList is a list cut short; its path uses [] for array elements
+type trimmedList struct {
+	Path  string `json:"path"`
+	Kept  int    `json:"kept"`
+	Total int    `json:"total"`
+}
+
+// jsonNode is a decoded JSON value that keeps object field order
+type jsonNode struct {
+	keys   []string
+	fields map[string]*jsonNode
+	items  []*jsonNode
+	kind   byte // '{', '[' or 0 for scalars
+	raw    json.RawMessage
+}
+
+func parseJSONNode(data []byte) (*jsonNode, error) {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 {
+		return nil, io.ErrUnexpectedEOF
+	}
+	switch data[0] {
+	case '{':
+		n := &jsonNode{kind: '{', fields: map[string]*jsonNode{}}
+		dec := json.NewDecoder(bytes.NewReader(data))
+		if _, err := dec.Token(); err != nil {
+			return nil, err
+		}
+		for dec.More() {
+			tok, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+			key, _ := tok.(string)
+			var raw json.RawMessage
+			if err := dec.Decode(&raw); err != nil {
+				return nil, err
+			}
+			child, err := parseJSONNode(raw)
+			if err != nil {
+				return nil, err
+			}
+			if _, dup := n.fields[key]; !dup {
+				n.keys = append(n.keys, key)
+			}
+			n.fields[key] = child
+		}
+		return n, nil
+	case '[':
+		var raws []json.RawMessage
+		if err := json.Unmarshal(data, &raws); err != nil {
+			return nil, err
+		}
+		n := &jsonNode{kind: '['}
+		for _, raw := range raws {
+			child, err := parseJSONNode(raw)
+			if err != nil {
+				return nil, err
+			}
+			n.items = append(n.items, child)
+		}
+		return n, nil
+	default:
+		return &jsonNode{raw: append(json.RawMessage(nil), data...)}, nil
+	}
+}
+
+// appendJSON writes n as compact JSON
+func (n *jsonNode) appendJSON(buf *bytes.Buffer) {
+	switch n.kind {
+	case '{':
+		buf.WriteByte('{')
+		for i, key := range n.keys {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			k, _ := json.Marshal(key)
+			buf.Write(k)
+			buf.WriteByte(':')
+			n.fields[key].appendJSON(buf)
+		}
+		buf.WriteByte('}')
+	case '[':
+		buf.WriteByte('[')
+		for i, item := range n.items {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			item.appendJSON(buf)
+		}
+		buf.WriteByte(']')
+	default:
+		buf.Write(n.raw)
+	}
+}
+
+func (n *jsonNode) bytes() []byte {
+	var buf bytes.Buffer
+	n.appendJSON(&buf)
+	return buf.Bytes()
+}
+
+// removeKeys drops the stage's keys below n, which is at path, counting
+// them by how the stage names them
+func (n *jsonNode) removeKeys(stage budgetStage, path string, counts map[string]int) {
+	switch n.kind {
+	case '{':
+		kept := n.keys[:0]
+		for _, key := range n.keys {
+			p := key
+			if path != "" {
+				p = path + "." + key
+			}
+			if name, ok := stage.match(key, p); ok {
+				counts[name]++
+				delete(n.fields, key)
+				continue
+			}
+			kept = append(kept, key)
+			n.fields[key].removeKeys(stage, p, counts)
+		}
+		n.keys = kept
+	case '[':
+		for _, item := range n.items {
+			item.removeKeys(stage, path+"[]", counts)
+		}
+	}
+}
+
+// match reports whether the key at path belongs to the stage, and the
+// name it is reported under
+func (s budgetStage) match(key, path string) (string, bool) {
+	for _, k := range s.Keys {
+		if k == path || (k == key && !strings.Contains(k, ".")) {
+			return k, true
+		}
+	}
+	if s.Suffix != "" && strings.HasSuffix(key, s.Suffix) {
+		return "*" + s.Suffix, true
+	}
+	return "", false
+}
+
+// largestList finds the list below n, with more than budgetMinItems
+// items, whose JSON is longest
+func (n *jsonNode) largestList(path string) (list *jsonNode, listPath string, size int) {
+	switch n.kind {
+	case '{':
+		for _, key := range n.keys {
+			p := key
+			if path != "" {
+				p = path + "." + key
+			}
+			if l, lp, s := n.fields[key].largestList(p); s > size {
+				list, listPath, size = l, lp, s
+			}
+		}
+	case '[':
+		if len(n.items) > budgetMinItems {
+			list, listPath, size = n, path, len(n.bytes())
+		}
+		for _, item := range n.items {
+			if l, lp, s := item.largestList(path + "[]"); s > size {
+				list, listPath, size = l, lp, s
+			}
+		}
+	}
+	return list, listPath, size
+}
+
+// fitRobotBudget trims the compact payload data until render(data) is at
+// most maxBytes long, adding the truncation manifest to objects. It
+// returns the rendering and whether it fits.
+func fitRobotBudget(data []byte, maxBytes int, render func([]byte) ([]byte, error)) ([]byte, bool, error) {
+	out, err := render(data)
+	if err != nil || len(out) <= maxBytes {
+		return out, true, err
+	}
+	root, err := parseJSONNode(data)
+	if err != nil {
+		return nil, false, err
+	}
+	manifest := &truncationManifest{MaxBytes: maxBytes, OriginalBytes: len(out)}
+	attempt := func() (bool, error) {
+		payload := root.bytes()
+		if root.kind == '{' {
+			manifest.Fits = true
+			payload = appendManifestField(payload, manifest)
+		}
+		out, err = render(payload)
+		if err != nil || len(out) <= maxBytes {
+			return true, err
+		}
+		return false, nil
+	}
+
+	for _, stage := range budgetStages {
+		counts := map[string]int{}
+		root.removeKeys(stage, "", counts)
+		names := make([]string, 0, len(counts))
+		for name := range counts {
+			names = append(names, name)
+		}
+		slices.Sort(names)
+		for _, key := range names {
+			manifest.Removed = append(manifest.Removed, removedField{Stage: stage.Name, Field: key, Count: counts[key]})
+		}
+		if len(counts) == 0 {
+			continue
+		}
+		if done, err := attempt(); done || err != nil {
+			return out, err == nil, err
+		}
+	}
+
+	truncated := map[string]int{} // Path -> index in manifest.Truncated
+	for {
+		list, path, _ := root.largestList("")
+		if list == nil {
+			break
+		}
+		i, seen := truncated[path]
+		if !seen {
+			i = len(manifest.Truncated)
+			truncated[path] = i
+			manifest.Truncated = append(manifest.Truncated, trimmedList{Path: path, Total: len(list.items)})
+		}
+		list.items = list.items[:max(len(list.items)/2, budgetMinItems)]
+		manifest.Truncated[i].Kept = len(list.items)
+		if done, err := attempt(); done || err != nil {
+			return out, err == nil, err
+		}
+	}
+
+	manifest.Fits = false
+	payload := root.bytes()
+	if root.kind == '{' {
+		payload = appendManifestField(payload, manifest)
+	}
+	out, err = render(payload)
+	return out, false, err
+}
+
+// appendManifestField adds "truncation" as the last field of a compact
+// JSON object
+func appendManifestField(data []byte, manifest *truncationManifest) []byte {
+	value, err := json.Marshal(manifest)
+	if err != nil {
+		return data
+	}
+	field := `"truncation":` + string(value)
+	if data[len(data)-2] != '{' {
+		field = "," + field
+	}
+	out := make([]byte, 0, len(data)+len(field))
+	out = append(out, data[:len(data)-1]...)
+	out = append(out, field...)
+	return append(out, '}')
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func compactRender(data []byte) ([]byte, error) {
+	return append(append([]byte(nil), data...), '\n'), nil
+}
+
+func TestFitRobotBudget_FitsUnchanged(t *testing.T) {
+	data := []byte(`{"b":1,"usage_hints":["x"]}`)
+	out, fits, err := fitRobotBudget(data, 100, compactRender)
+	if err != nil || !fits || string(out) != string(data)+"\n" {
+		t.Errorf("out = %s, fits %v, err %v", out, fits, err)
+	}
+}
+
+func TestFitRobotBudget_Stages(t *testing.T) {
+	var items []string
+	for i := 0; i < 20; i++ {
+		items = append(items, fmt.Sprintf(`{"id":"bv-%d","reasons":["a long reason to drop"],"risk_explanation":"also dropped","breakdown":{"pagerank":0.5}}`, i))
+	}
+	data := []byte(`{"triage":{"recommendations":[` + strings.Join(items, ",") + `],"commands":{"claim":"bd update"}},"commands":["kept"],"usage_hints":["hint"]}`)
+
+	// Dropping hints and explanations is enough
+	out, fits, err := fitRobotBudget(data, 1500, compactRender)
+	if err != nil || !fits || len(out) > 1500 {
+		t.Fatalf("len %d, fits %v, err %v", len(out), fits, err)
+	}
+	var got struct {
+		Triage struct {
+			Recommendations []map[string]any `json:"recommendations"`
+			Commands        any              `json:"commands"`
+		} `json:"triage"`
+		Commands   []string           `json:"commands"`
+		UsageHints []string           `json:"usage_hints"`
+		Truncation truncationManifest `json:"truncation"`
+	}
+	if err := json.Unmarshal(out, &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.UsageHints != nil || got.Triage.Commands != nil || len(got.Commands) != 1 {
+		t.Errorf("hints stage: %s", out)
+	}
+	if r := got.Triage.Recommendations; len(r) != 20 || r[0]["reasons"] != nil || r[0]["risk_explanation"] != nil || r[0]["breakdown"] == nil {
+		t.Errorf("explanations stage: %s", out)
+	}
+	m := got.Truncation
+	if !m.Fits || m.MaxBytes != 1500 || m.OriginalBytes != len(data)+1 || len(m.Truncated) != 0 {
+		t.Errorf("manifest = %+v", m)
+	}
+	want := []removedField{
+		{Stage: "hints", Field: "triage.commands", Count: 1},
+		{Stage: "hints", Field: "usage_hints", Count: 1},
+		{Stage: "explanations", Field: "*_explanation", Count: 20},
+		{Stage: "explanations", Field: "reasons", Count: 20},
+	}
+	if fmt.Sprint(m.Removed) != fmt.Sprint(want) {
+		t.Errorf("removed = %+v", m.Removed)
+	}
+
+	// A tighter budget shortens the list
+	out, fits, err = fitRobotBudget(data, 650, compactRender)
+	if err != nil || !fits || len(out) > 650 {
+		t.Fatalf("len %d, fits %v, err %v: %s", len(out), fits, err, out)
+	}
+	got.Truncation = truncationManifest{}
+	if err := json.Unmarshal(out, &got); err != nil {
+		t.Fatal(err)
+	}
+	tr := got.Truncation.Truncated
+	if len(tr) != 1 || tr[0].Path != "triage.recommendations" || tr[0].Total != 20 || tr[0].Kept != len(got.Triage.Recommendations) {
+		t.Errorf("truncated = %+v", tr)
+	}
+	if !bytes.HasPrefix(out, []byte(`{"triage":{"recommendations":[{"id":"bv-0"`)) {
+		t.Errorf("field order or list head lost: %s", out)
+	}
+}
+
+func TestFitRobotBudget_CannotFit(t *testing.T) {
+	out, fits, err := fitRobotBudget([]byte(`{"title":"a title that is longer than the budget"}`), 10, compactRender)
+	if err != nil || fits {
+		t.Fatalf("fits %v, err %v", fits, err)
+	}
+	if !strings.Contains(string(out), `"fits":false`) {
+		t.Errorf("out = %s", out)
+	}
+}
+
+func TestRobotEncoder_MaxBytes(t *testing.T) {
+	prev := robotMaxBytes
+	t.Cleanup(func() { robotMaxBytes = prev })
+	robotMaxBytes = 300
+
+	hints := make([]string, 20)
+	for i := range hints {
+		hints[i] = "a usage hint"
+	}
+	var buf bytes.Buffer
+	enc := newRobotEncoder(&buf)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(map[string]any{"data_hash": "abc", "usage_hints": hints}); err != nil {
+		t.Fatal(err)
+	}
+	if buf.Len() > 300 || !strings.Contains(buf.String(), `"schema_version"`) || strings.Contains(buf.String(), "usage hint") {
+		t.Errorf("output (%d bytes):\n%s", buf.Len(), buf.String())
+	}
+}
@@ -0,0 +1,209 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/claims"
+)
+
+const claimUsage = "Usage: bv claim <id> [--ttl 2h] [--as NAME] [--force] | release <id> [--force] | list"
+
+// runClaim implements `bv claim`: lease an issue in .bv/claims.jsonl so
+// other agents and people see it is taken before its status changes. It
+// returns the process exit code.
+func runClaim(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("claim", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	ttl := fs.Duration("ttl", claims.DefaultTTL, "How long the claim lasts (e.g. 30m, 2h)")
+	as := fs.String("as", "me", "Who holds the claim ('me' = $BD_ACTOR, then $USER)")
+	force := fs.Bool("force", false, "Take over or release someone else's claim")
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, claimUsage)
+		fmt.Fprintln(stderr, "\nLease an issue so concurrent agents don't pick it up.")
+		fs.PrintDefaults()
+	}
+
+	// Flags may follow the issue ID
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			if errors.Is(err, flag.ErrHelp) {
+				return exitOK
+			}
+			return exitUsage
+		}
+		if fs.NArg() == 0 {
+			break
+		}
+		positional = append(positional, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+	if len(positional) == 0 {
+		fmt.Fprintln(stderr, claimUsage)
+		return exitUsage
+	}
+
+	projectDir, err := trackProjectDir()
+	if err != nil {
+		fmt.Fprintf(stderr, "Error getting beads directory: %v\n", err)
+		return exitCodeFor(err)
+	}
+	path := claims.Path(projectDir)
+	actor := analysis.ResolveAssignee(*as)
+	if actor == "" && positional[0] != "list" {
+		fmt.Fprintln(stderr, "Error: cannot resolve \"me\"; set BD_ACTOR or pass --as NAME")
+		return exitUsage
+	}
+	now := time.Now()
+
+	switch {
+	case positional[0] == "list" && len(positional) == 1:
+		ledger, err := claims.Load(path)
+		if err != nil {
+			fmt.Fprintf(stderr, "Error: %v\n", err)
+			return exitError
+		}
+		active := ledger.Active(now)
+		if len(active) == 0 {
+			fmt.Fprintln(stdout, "No active claims")
+			return exitOK
+		}
+		tw := tabwriter.NewWriter(stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "ISSUE\tHELD BY\tUNTIL\tLEFT")
+		for _, c := range active {
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", c.IssueID, c.Actor, c.Until.Local().Format("2006-01-02 15:04"), c.Until.Sub(now).Round(time.Minute))
+		}
+		_ = tw.Flush()
+
+	case positional[0] == "release" && len(positional) == 2:
+		released, err := claims.Release(path, positional[1], actor, *force, now)
+		if errors.Is(err, claims.ErrNotClaimed) {
+			fmt.Fprintf(stdout, "%s is not claimed\n", positional[1])
+			return exitOK
+		}
+		if err != nil {
+			fmt.Fprintf(stderr, "Error: %v\n", err)
+			return exitError
+		}
+		fmt.Fprintf(stdout, "Released %s (was held by %s)\n", released.IssueID, released.Actor)
+
+	case len(positional) == 1:
+		issueID := positional[0]
+		if err := checkIssueExists(issueID); err != nil {
+			fmt.Fprintf(stderr, "Error: %v\n", err)
+			return exitNotFound
+		}
+		claim, err := claims.Acquire(path, issueID, actor, *ttl, *force, now)
+		if err != nil {
+			fmt.Fprintf(stderr, "Error: %v\n", err)
+			var held *claims.HeldError
+			if errors.As(err, &held) {
+				fmt.Fprintln(stderr, "Pick something else, or pass --force to take it over.")
+			}
+			return exitError
+		}
+		fmt.Fprintf(stdout, "Claimed %s for %s until %s\n", issueID, claim.Actor, claim.Until.Local().Format("2006-01-02 15:04"))
+
+	default:
+		fmt.Fprintln(stderr, claimUsage)
+		return exitUsage
+	}
+	return exitOK
+}
+
+// loadClaims returns the project's claims ledger, or nil if there is none
+func loadClaims(projectDir string) *claims.Ledger {
+	ledger, err := claims.Load(claims.Path(projectDir))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: ignoring claims: %v\n", err)
+		return nil
+	}
+	return ledger
+}
+
+// mergeClaimsIntoTriage marks the recommendations and top picks someone has
+// claimed, and returns the active claims for the output
+func mergeClaimsIntoTriage(triage *analysis.TriageResult, ledger *claims.Ledger, now time.Time) []claims.Claim {
+	active := ledger.Active(now)
+	if len(active) == 0 {
+		return nil
+	}
+	mark := func(recs []analysis.Recommendation) {
+		for i := range recs {
+			if c := ledger.Get(recs[i].ID, now); c != nil {
+				recs[i].ClaimedBy, recs[i].ClaimedUntil = c.Actor, &c.Until
+			}
+		}
+	}
+	mark(triage.Recommendations)
+	for _, group := range triage.RecommendationsByTrack {
+		mark(group.Recommendations)
+	}
+	for _, group := range triage.RecommendationsByLabel {
+		mark(group.Recommendations)
+	}
+	for i := range triage.QuickRef.TopPicks {
+		if c := ledger.Get(triage.QuickRef.TopPicks[i].ID, now); c != nil {
+			triage.QuickRef.TopPicks[i].ClaimedBy, triage.QuickRef.TopPicks[i].ClaimedUntil = c.Actor, &c.Until
+		}
+	}
+	return active
+}
+
+// mergeClaimsIntoPlan marks the plan items someone has claimed, and returns
+// the active claims for the output
+func mergeClaimsIntoPlan(plan *analysis.ExecutionPlan, ledger *claims.Ledger, now time.Time) []claims.Claim {
+	active := ledger.Active(now)
+	if len(active) == 0 {
+		return nil
+	}
+	for t := range plan.Tracks {
+		items := plan.Tracks[t].Items
+		for i := range items {
+			if c := ledger.Get(items[i].ID, now); c != nil {
+				items[i].ClaimedBy, items[i].ClaimedUntil = c.Actor, &c.Until
+			}
+		}
+	}
+	return active
+}
+
+// nextUnclaimedPick returns the best pick not claimed by someone other than
+// actor, falling back from the top picks to the full recommendation list.
+// skipped lists the better picks passed over because they are claimed.
+func nextUnclaimedPick(triage analysis.TriageResult, ledger *claims.Ledger, actor string, now time.Time) (pick analysis.TopPick, skipped []string, ok bool) {
+	for _, p := range triage.QuickRef.TopPicks {
+		if !ledger.HeldByOther(p.ID, actor, now) {
+			return p, skipped, true
+		}
+		skipped = append(skipped, p.ID)
+	}
+	seen := make(map[string]bool, len(skipped))
+	for _, id := range skipped {
+		seen[id] = true
+	}
+	for _, rec := range triage.Recommendations {
+		if seen[rec.ID] {
+			continue
+		}
+		if ledger.HeldByOther(rec.ID, actor, now) {
+			skipped = append(skipped, rec.ID)
+			continue
+		}
+		return analysis.TopPick{
+			ID:       rec.ID,
+			Title:    rec.Title,
+			Score:    rec.Score,
+			Reasons:  rec.Reasons,
+			Unblocks: len(rec.UnblocksIDs),
+		}, skipped, true
+	}
+	return analysis.TopPick{}, skipped, false
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/claims"
+)
+
+func TestRunClaim(t *testing.T) {
+	beadsDir := setupNewProject(t)
+	t.Setenv("BD_ACTOR", "ana")
+	var stdout, stderr bytes.Buffer
+
+	if code := runClaim([]string{"app-404"}, &stdout, &stderr); code != exitNotFound || !strings.Contains(stderr.String(), "not found") {
+		t.Errorf("unknown issue: exit %d, stderr %q", code, stderr.String())
+	}
+	if code := runClaim([]string{"app-1", "--ttl", "30m"}, &stdout, &stderr); code != 0 || !strings.HasPrefix(stdout.String(), "Claimed app-1 for ana until") {
+		t.Fatalf("claim: exit %d, stdout %q, stderr %q", code, stdout.String(), stderr.String())
+	}
+	stderr.Reset()
+	if code := runClaim([]string{"app-1", "--as", "bo"}, &stdout, &stderr); code != exitError || !strings.Contains(stderr.String(), "claimed by ana") {
+		t.Errorf("claim held issue: exit %d, stderr %q", code, stderr.String())
+	}
+	stdout.Reset()
+	if code := runClaim([]string{"list"}, &stdout, &stderr); code != 0 || !strings.Contains(stdout.String(), "app-1") || !strings.Contains(stdout.String(), "ana") {
+		t.Errorf("list: exit %d, stdout %q", code, stdout.String())
+	}
+	stdout.Reset()
+	if code := runClaim([]string{"release", "app-1"}, &stdout, &stderr); code != 0 || !strings.HasPrefix(stdout.String(), "Released app-1") {
+		t.Errorf("release: exit %d, stdout %q, stderr %q", code, stdout.String(), stderr.String())
+	}
+
+	ledger, err := claims.Load(claims.Path(filepath.Dir(beadsDir)))
+	if err != nil || len(ledger.Active(time.Now())) != 0 {
+		t.Errorf("ledger after release = %+v, %v", ledger, err)
+	}
+	if code := runClaim(nil, &stdout, &stderr); code != exitUsage {
+		t.Errorf("no arguments: exit %d", code)
+	}
+}
+
+func TestNextUnclaimedPick(t *testing.T) {
+	now := time.Now()
+	path := claims.Path(t.TempDir())
+	if _, err := claims.Acquire(path, "a", "bo", time.Hour, false, now); err != nil {
+		t.Fatal(err)
+	}
+	ledger, err := claims.Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	triage := analysis.TriageResult{
+		QuickRef:        analysis.QuickRef{TopPicks: []analysis.TopPick{{ID: "a"}}},
+		Recommendations: []analysis.Recommendation{{ID: "a"}, {ID: "b", UnblocksIDs: []string{"c"}}},
+	}
+
+	pick, skipped, ok := nextUnclaimedPick(triage, ledger, "ana", now)
+	if !ok || pick.ID != "b" || pick.Unblocks != 1 || strings.Join(skipped, ",") != "a" {
+		t.Errorf("pick = %+v, skipped = %v, ok = %v; want b after skipping a", pick, skipped, ok)
+	}
+	// The holder still gets their own claim
+	if pick, _, _ := nextUnclaimedPick(triage, ledger, "bo", now); pick.ID != "a" {
+		t.Errorf("holder's pick = %s, want a", pick.ID)
+	}
+
+	active := mergeClaimsIntoTriage(&triage, ledger, now)
+	if len(active) != 1 || triage.Recommendations[0].ClaimedBy != "bo" || triage.QuickRef.TopPicks[0].ClaimedUntil == nil || triage.Recommendations[1].ClaimedBy != "" {
+		t.Errorf("merged triage = %+v, active = %+v", triage, active)
+	}
+}
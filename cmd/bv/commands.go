@@ -0,0 +1,285 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Subcommands (bv triage, bv sprint show <id>, ...) are the discoverable
+// face of the flag set: each one expands to the legacy flags it stands for
+// before flag parsing, so `bv sprint show S1` and
+// `bv --robot-sprint-show S1` run the same code and take the same options.
+// New features get a command here rather than another top-level flag.
+
+// cliCommand is one subcommand, or one verb of a subcommand
+type cliCommand struct {
+	Name     string
+	Summary  string
+	Flags    []string     // Boolean flags the command sets
+	ArgFlag  string       // Flag receiving the argument; "-" passes it positionally
+	Arg      string       // Argument name for usage; "" when there is none
+	Optional bool         // The argument may be omitted
+	Options  []string     // Flags listed in `bv help <command>`
+	Verbs    []cliCommand // Sub-verbs; a command with verbs and no Flags needs one
+}
+
+// cliCommands are the subcommands, in help order
+var cliCommands = []cliCommand{
+	{Name: "triage", Summary: "Ranked recommendations, quick wins and blockers to clear", Flags: []string{"robot-triage"},
+		Options: []string{"robot-max-results", "robot-by-label", "robot-by-assignee", "recipe"},
+		Verbs: []cliCommand{
+			{Name: "by-track", Summary: "Triage grouped by parallel execution track", Flags: []string{"robot-triage", "robot-triage-by-track"}},
+			{Name: "by-label", Summary: "Triage grouped by label", Flags: []string{"robot-triage", "robot-triage-by-label"}},
+		}},
+	{Name: "next", Summary: "The single top pick, with the command to claim it", Flags: []string{"robot-next"}},
+	{Name: "summary", Summary: "A prompt-sized briefing: counts, top picks, top risk and trend", Flags: []string{"robot-summary"}},
+	{Name: "partition", Summary: "Disjoint work bundles, one per agent, with claim commands", Flags: []string{"robot-partition"},
+		Options: []string{"agents"}},
+	{Name: "plan", Summary: "Parallel execution tracks and what each unblocks", Flags: []string{"robot-plan"},
+		Options: []string{"label", "force-full-analysis"}},
+	{Name: "priority", Summary: "Priority changes the dependency graph suggests", Flags: []string{"robot-priority"},
+		Options: []string{"robot-min-confidence", "robot-max-results", "label"}},
+	{Name: "insights", Summary: "Graph metrics: bottlenecks, keystones, cycles and full stats", Flags: []string{"robot-insights"},
+		Options: []string{"page-size", "cursor", "stream", "label", "force-full-analysis"}},
+	{Name: "graph", Summary: "Dependency graph as JSON, DOT, Mermaid, GraphML or GEXF", Flags: []string{"robot-graph"},
+		Options: []string{"graph-format", "graph-root", "graph-depth", "graph-cluster"},
+		Verbs: []cliCommand{
+			{Name: "export", Summary: "Render the graph to .html (interactive), .png or .svg", ArgFlag: "export-graph", Arg: "file",
+				Options: []string{"graph-preset", "graph-title", "graph-style"}},
+		}},
+	{Name: "alerts", Summary: "Stale issues, blocking cascades and other drift signals", Flags: []string{"robot-alerts"},
+		Options: []string{"severity", "alert-type", "alert-label"}},
+	{Name: "suggest", Summary: "Suggested duplicates, dependencies, labels and cycle breaks", Flags: []string{"robot-suggest"},
+		Options: []string{"suggest-type", "suggest-confidence", "suggest-bead"}},
+	{Name: "search", Summary: "Semantic search over issues", Flags: []string{"robot-search"}, ArgFlag: "search", Arg: "query",
+		Options: []string{"search-limit", "search-mode", "search-preset", "search-weights"}},
+	{Name: "history", Summary: "Commits correlated with each bead, or with one bead", Flags: []string{"robot-history"}, ArgFlag: "bead-history", Arg: "bead-id", Optional: true,
+		Options: []string{"history-since", "history-limit", "min-confidence", "page-size", "cursor", "stream"}},
+	{Name: "why", Summary: "Which beads motivated a file or line range (git blame)", Flags: []string{"robot-why"}, ArgFlag: "why", Arg: "path[:line[-line]]"},
+	{Name: "impact", Summary: "Open beads touching the files you are about to change", ArgFlag: "robot-impact", Arg: "paths"},
+	{Name: "bus-factor", Summary: "Labels and subsystems one person carries, with the key open work there", Flags: []string{"robot-bus-factor"},
+		Options: []string{"bus-factor-threshold", "history-limit"}},
+	{Name: "blockers", Summary: "Full blocker chain of an issue", ArgFlag: "robot-blocker-chain", Arg: "id"},
+	{Name: "explain", Summary: "Everything known about one issue: scores, why (not) recommended, blockers, forecast", ArgFlag: "robot-explain", Arg: "id",
+		Options: []string{"forecast-agents"}},
+	{Name: "layers", Summary: "Open issues as topological layers: a phased roadmap with durations", Flags: []string{"robot-layers"},
+		Options: []string{"agents", "format"}},
+	{Name: "tree", Summary: "Upstream blocker and downstream unblock trees of an issue, with days per branch", ArgFlag: "robot-tree", Arg: "id"},
+	{Name: "related", Summary: "Beads related to a bead by files, commits and dependencies", ArgFlag: "robot-related", Arg: "id",
+		Options: []string{"related-min-relevance", "related-max-results", "related-include-closed"}},
+	{Name: "forecast", Summary: "ETA forecast for an issue, or all open issues", ArgFlag: "robot-forecast", Arg: "id|all",
+		Options: []string{"forecast-label", "forecast-sprint", "forecast-agents"}},
+	{Name: "capacity", Summary: "Capacity simulation and completion projection", Flags: []string{"robot-capacity"},
+		Options: []string{"agents", "capacity-label", "agent-profiles", "capacity-runs"}},
+	{Name: "goals", Summary: "Chance of closing each goal in .bv/goals.yaml by its target date", Flags: []string{"robot-goals"},
+		Options: []string{"agent-profiles", "capacity-runs"}},
+	{Name: "review", Summary: "Issues waiting in review and review latency", Flags: []string{"robot-review"},
+		Options: []string{"review-window"}},
+	{Name: "sprint", Summary: "Sprints and burndown",
+		Verbs: []cliCommand{
+			{Name: "list", Summary: "All sprints", Flags: []string{"robot-sprint-list"}},
+			{Name: "show", Summary: "One sprint's details", ArgFlag: "robot-sprint-show", Arg: "id"},
+			{Name: "burndown", Summary: "Burndown for a sprint, or the active one", ArgFlag: "robot-burndown", Arg: "id|current"},
+		}},
+	{Name: "labels", Summary: "Label health, flow and attention",
+		Verbs: []cliCommand{
+			{Name: "health", Summary: "Health score per label", Flags: []string{"robot-label-health"}},
+			{Name: "flow", Summary: "Cross-label dependencies and bottleneck labels", Flags: []string{"robot-label-flow"},
+				Options: []string{"format"}},
+			{Name: "attention", Summary: "Labels most in need of attention", Flags: []string{"robot-label-attention"},
+				Options: []string{"attention-limit"}},
+		}},
+	{Name: "diff", Summary: "Changes since a commit, branch, tag or date, or between two points", Flags: []string{"robot-diff"}, ArgFlag: "diff-since", Arg: "since", Optional: true,
+		Options: []string{"diff-from", "diff-to"}},
+	{Name: "replay", Summary: "Weekly metric series across the beads file's git history", Flags: []string{"robot-replay"},
+		Options: []string{"replay-from", "replay-to"}},
+	{Name: "bisect", Summary: "First commit where a metric exceeded a threshold", Flags: []string{"robot-bisect"}, ArgFlag: "bisect-metric", Arg: "metric",
+		Options: []string{"bisect-threshold", "from", "to"}},
+	{Name: "drift", Summary: "Metric drift against a saved baseline",
+		Verbs: []cliCommand{
+			{Name: "check", Summary: "Compare against the baseline (exit 1 critical, 2 warning)", Flags: []string{"check-drift", "robot-drift"}},
+			{Name: "save", Summary: "Save the current metrics as the baseline", ArgFlag: "save-baseline", Arg: "description"},
+		}},
+	{Name: "statusline", Summary: "One-line summary for tmux status bars and shell prompts", Flags: []string{"statusline"},
+		Options: []string{"statusline-format", "statusline-width"}},
+	{Name: "digest", Summary: "Daily or weekly digest of changes, new alerts and forecast slips", ArgFlag: "digest", Arg: "daily|weekly",
+		Options: []string{"digest-format"}},
+	{Name: "pages", Summary: "Static site export (bare: the interactive wizard)", Flags: []string{"pages"},
+		Verbs: []cliCommand{
+			{Name: "export", Summary: "Export the static site to a directory", ArgFlag: "export-pages", Arg: "dir",
+				Options: []string{"pages-title", "pages-include-closed", "pages-include-history", "pages-push", "pages-deploy"}},
+			{Name: "preview", Summary: "Preview an exported site in the browser", ArgFlag: "preview-pages", Arg: "dir"},
+			{Name: "serve", Summary: "Serve an exported site (no browser)", ArgFlag: "serve-pages", Arg: "dir",
+				Options: []string{"serve-bind", "serve-port", "serve-user", "serve-gzip"}},
+		}},
+	{Name: "validate", Summary: "Skipped JSONL lines and labels .bv/labels.yaml aliases or does not know", Flags: []string{"robot-validate"}},
+	{Name: "journal", Summary: "Journaled recommendations and which were acted on", Flags: []string{"robot-journal"},
+		Options: []string{"journal-days"}},
+	{Name: "recipes", Summary: "Available recipes", Flags: []string{"robot-recipes"}},
+	{Name: "capabilities", Summary: "Robot commands, flags and features this bv and project support", Flags: []string{"robot-capabilities"},
+		Options: []string{"force-full-analysis"}},
+	{Name: "schema", Summary: "JSON Schema for robot payloads, or one command's", Flags: []string{"robot-schema"}, ArgFlag: "-", Arg: "command", Optional: true},
+}
+
+// errCommandHelp reports that a command asked for its help
+var errCommandHelp = errors.New("help requested")
+
+// findCommand looks up a subcommand by name
+func findCommand(commands []cliCommand, name string) *cliCommand {
+	for i := range commands {
+		if commands[i].Name == name {
+			return &commands[i]
+		}
+	}
+	return nil
+}
+
+// expandCommand turns `<command> [verb] [arg] [flags...]` into the legacy
+// flags it stands for. It returns the command it resolved, its full name
+// ("sprint show") and, for a usage problem or a help request, an error.
+func expandCommand(args []string) (expanded []string, cmd *cliCommand, name string, err error) {
+	cmd = findCommand(cliCommands, args[0])
+	if cmd == nil {
+		return args, nil, "", nil
+	}
+	name, rest := cmd.Name, args[1:]
+	if len(rest) > 0 {
+		if verb := findCommand(cmd.Verbs, rest[0]); verb != nil {
+			cmd, name, rest = verb, name+" "+verb.Name, rest[1:]
+		}
+	}
+	for _, a := range rest {
+		if a == "-h" || a == "-help" || a == "--help" {
+			return nil, cmd, name, errCommandHelp
+		}
+	}
+	if len(cmd.Flags) == 0 && cmd.ArgFlag == "" {
+		return nil, cmd, name, fmt.Errorf("bv %s needs a command: %s", name, verbNames(cmd))
+	}
+
+	var arg string
+	hasArg := cmd.Arg != "" && len(rest) > 0 && !strings.HasPrefix(rest[0], "-")
+	if hasArg {
+		arg, rest = rest[0], rest[1:]
+	} else if cmd.Arg != "" && !cmd.Optional {
+		return nil, cmd, name, fmt.Errorf("bv %s needs <%s>", name, cmd.Arg)
+	}
+
+	for _, f := range cmd.Flags {
+		expanded = append(expanded, "--"+f)
+	}
+	if hasArg && cmd.ArgFlag != "-" {
+		expanded = append(expanded, "--"+cmd.ArgFlag, arg)
+	}
+	expanded = append(expanded, rest...)
+	if hasArg && cmd.ArgFlag == "-" {
+		// Positional arguments end flag parsing, so they go last
+		expanded = append(expanded, arg)
+	}
+	return expanded, cmd, name, nil
+}
+
+func verbNames(cmd *cliCommand) string {
+	names := make([]string, len(cmd.Verbs))
+	for i, v := range cmd.Verbs {
+		names[i] = v.Name
+	}
+	return strings.Join(names, ", ")
+}
+
+// commandUsage is the usage line of a command
+func commandUsage(cmd *cliCommand, name string) string {
+	usage := "bv " + name
+	if len(cmd.Verbs) > 0 {
+		if len(cmd.Flags) > 0 {
+			usage += " [command]"
+		} else {
+			usage += " <command>"
+		}
+	}
+	if cmd.Arg != "" {
+		if cmd.Optional {
+			usage += " [" + cmd.Arg + "]"
+		} else {
+			usage += " <" + cmd.Arg + ">"
+		}
+	}
+	return usage + " [options]"
+}
+
+// legacyForm is the flag spelling a command expands to
+func legacyForm(cmd *cliCommand) string {
+	var parts []string
+	for _, f := range cmd.Flags {
+		parts = append(parts, "--"+f)
+	}
+	switch {
+	case cmd.ArgFlag == "-":
+		parts = append(parts, "["+cmd.Arg+"]")
+	case cmd.ArgFlag != "":
+		parts = append(parts, "--"+cmd.ArgFlag+" <"+cmd.Arg+">")
+	}
+	return "bv " + strings.Join(parts, " ")
+}
+
+// printCommands lists the subcommands for `bv help`
+func printCommands(w io.Writer) {
+	fmt.Fprintln(w, "Commands:")
+	for _, cmd := range cliCommands {
+		fmt.Fprintf(w, "  %-10s %s\n", cmd.Name, cmd.Summary)
+	}
+	fmt.Fprintln(w, "\nRun 'bv help <command>' for a command's options. Every command also accepts the")
+	fmt.Fprintln(w, "global options (--as-of, --fields, --deterministic, ...).")
+}
+
+// printCommandHelp describes one command, its verbs and its options
+func printCommandHelp(w io.Writer, fs *flag.FlagSet, cmd *cliCommand, name string) {
+	fmt.Fprintf(w, "Usage: %s\n\n%s\n", commandUsage(cmd, name), cmd.Summary)
+	if len(cmd.Flags) > 0 || cmd.ArgFlag != "" {
+		fmt.Fprintf(w, "Same as: %s\n", legacyForm(cmd))
+	}
+	if len(cmd.Verbs) > 0 {
+		fmt.Fprintln(w, "\nCommands:")
+		for _, v := range cmd.Verbs {
+			fmt.Fprintf(w, "  %-10s %s\n", v.Name, v.Summary)
+		}
+	}
+	if len(cmd.Options) > 0 {
+		fmt.Fprintln(w, "\nOptions:")
+		for _, opt := range cmd.Options {
+			if f := fs.Lookup(opt); f != nil {
+				fmt.Fprintf(w, "  --%s\n      %s\n", f.Name, f.Usage)
+			}
+		}
+	}
+}
+
+// runHelp implements `bv help [command [verb]]`. It returns the process exit
+// code.
+func runHelp(args []string, fs *flag.FlagSet, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		fmt.Fprintln(stdout, "Usage: bv <command> [options]   (bv --help for every option)")
+		fmt.Fprintln(stdout)
+		printCommands(stdout)
+		return exitOK
+	}
+	cmd := findCommand(cliCommands, args[0])
+	if cmd == nil {
+		fmt.Fprintf(stderr, "Error: unknown command %q (bv help lists them)\n", args[0])
+		return exitUsage
+	}
+	name := cmd.Name
+	if len(args) > 1 {
+		verb := findCommand(cmd.Verbs, args[1])
+		if verb == nil {
+			fmt.Fprintf(stderr, "Error: unknown command %q for bv %s\n", args[1], name)
+			return exitUsage
+		}
+		cmd, name = verb, name+" "+verb.Name
+	}
+	printCommandHelp(stdout, fs, cmd, name)
+	return exitOK
+}
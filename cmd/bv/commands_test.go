@@ -0,0 +1,99 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestExpandCommand(t *testing.T) {
+	tests := []struct {
+		args []string
+		want string
+		name string
+	}{
+		{[]string{"triage", "--deterministic"}, "--robot-triage --deterministic", "triage"},
+		{[]string{"triage", "by-track"}, "--robot-triage --robot-triage-by-track", "triage by-track"},
+		{[]string{"sprint", "show", "S1", "--fields", "sprint"}, "--robot-sprint-show S1 --fields sprint", "sprint show"},
+		{[]string{"history"}, "--robot-history", "history"},
+		{[]string{"history", "bv-1", "--stream"}, "--robot-history --bead-history bv-1 --stream", "history"},
+		{[]string{"search", "login bug"}, "--robot-search --search login bug", "search"},
+		{[]string{"schema", "triage", "--deterministic"}, "--robot-schema --deterministic triage", "schema"},
+		{[]string{"pages"}, "--pages", "pages"},
+		{[]string{"pages", "export", "out"}, "--export-pages out", "pages export"},
+		// Not a command: left alone for the flag parser
+		{[]string{"--robot-triage"}, "--robot-triage", ""},
+	}
+	for _, tt := range tests {
+		got, _, name, err := expandCommand(tt.args)
+		if err != nil {
+			t.Errorf("expandCommand(%q): %v", tt.args, err)
+			continue
+		}
+		if strings.Join(got, " ") != tt.want || name != tt.name {
+			t.Errorf("expandCommand(%q) = %q (%s), want %q (%s)", tt.args, got, name, tt.want, tt.name)
+		}
+	}
+
+	for _, args := range [][]string{{"sprint"}, {"sprint", "show"}, {"blockers", "--fields", "x"}} {
+		if _, _, _, err := expandCommand(args); err == nil || errors.Is(err, errCommandHelp) {
+			t.Errorf("expandCommand(%q) should be a usage error, got %v", args, err)
+		}
+	}
+	if _, cmd, name, err := expandCommand([]string{"sprint", "show", "--help"}); !errors.Is(err, errCommandHelp) || cmd.Name != "show" || name != "sprint show" {
+		t.Errorf("help request = %v, %v, %q", err, cmd, name)
+	}
+}
+
+func TestCLICommandsWellFormed(t *testing.T) {
+	var walk func(cmds []cliCommand)
+	walk = func(cmds []cliCommand) {
+		for _, cmd := range cmds {
+			if cmd.Arg == "" && cmd.ArgFlag != "" {
+				t.Errorf("%s: ArgFlag without Arg", cmd.Name)
+			}
+			if cmd.Optional && cmd.Arg == "" {
+				t.Errorf("%s: Optional without Arg", cmd.Name)
+			}
+			if len(cmd.Flags) == 0 && cmd.ArgFlag == "" && len(cmd.Verbs) == 0 {
+				t.Errorf("%s: does nothing", cmd.Name)
+			}
+			walk(cmd.Verbs)
+		}
+	}
+	walk(cliCommands)
+}
+
+func TestRunHelp(t *testing.T) {
+	fs := flag.NewFlagSet("bv", flag.ContinueOnError)
+	fs.String("robot-sprint-show", "", "Output specific sprint details as JSON")
+	fs.Int("attention-limit", 5, "Limit number of labels")
+
+	var stdout bytes.Buffer
+	if code := runHelp(nil, fs, &stdout, io.Discard); code != exitOK || !strings.Contains(stdout.String(), "  insights   Graph metrics") {
+		t.Errorf("bv help = %d:\n%s", code, stdout.String())
+	}
+
+	stdout.Reset()
+	runHelp([]string{"sprint", "show"}, fs, &stdout, io.Discard)
+	for _, want := range []string{"Usage: bv sprint show <id> [options]", "Same as: bv --robot-sprint-show <id>"} {
+		if !strings.Contains(stdout.String(), want) {
+			t.Errorf("bv help sprint show lacks %q:\n%s", want, stdout.String())
+		}
+	}
+
+	stdout.Reset()
+	runHelp([]string{"labels", "attention"}, fs, &stdout, io.Discard)
+	if !strings.Contains(stdout.String(), "  --attention-limit\n      Limit number of labels") {
+		t.Errorf("bv help labels attention should list its options:\n%s", stdout.String())
+	}
+
+	for _, args := range [][]string{{"nope"}, {"sprint", "nope"}} {
+		if code := runHelp(args, fs, io.Discard, io.Discard); code != exitUsage {
+			t.Errorf("bv help %v = %d, want %d", args, code, exitUsage)
+		}
+	}
+}
@@ -0,0 +1,311 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/export"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/recipe"
+)
+
+const completionUsage = "Usage: bv completion bash|zsh|fish"
+
+// Shell completion: `bv completion <shell>` prints a script covering every
+// flag and subcommand. Values that depend on the project (recipes, labels,
+// sprints, bead IDs, publish targets) are listed at completion time by the
+// hidden `bv __complete <kind>`, so the script never goes stale.
+
+// standaloneCommands run on their own before the flag set is parsed,
+// outside cliCommands
+var standaloneCommands = []string{"new", "track", "claim", "bench", "doctor", "merge-driver", "verify", "stats", "completion", "help"}
+
+// completionSubcommands are the words accepted before the flag set
+func completionSubcommands() []string {
+	names := append([]string(nil), standaloneCommands...)
+	for _, cmd := range cliCommands {
+		names = append(names, cmd.Name)
+	}
+	return names
+}
+
+// completionDynamic maps flags to the kind of project data that completes
+// their value
+var completionDynamic = map[string]string{
+	"recipe":               "recipes",
+	"r":                    "recipes",
+	"label":                "labels",
+	"robot-by-label":       "labels",
+	"alert-label":          "labels",
+	"forecast-label":       "labels",
+	"capacity-label":       "labels",
+	"robot-sprint-show":    "sprints",
+	"robot-burndown":       "sprints",
+	"forecast-sprint":      "sprints",
+	"bead-history":         "beads",
+	"suggest-bead":         "beads",
+	"graph-root":           "beads",
+	"robot-related":        "beads",
+	"robot-blocker-chain":  "beads",
+	"robot-explain":        "beads",
+	"robot-tree":           "beads",
+	"robot-impact-network": "beads",
+	"robot-causality":      "beads",
+	"robot-forecast":       "beads",
+	"feedback-accept":      "beads",
+	"feedback-ignore":      "beads",
+	"publish":              "publish-targets",
+}
+
+// completionChoices are the fixed values of enumerated flags
+var completionChoices = map[string][]string{
+	"graph-format":      {"json", "dot", "mermaid", "graphml", "gexf"},
+	"format":            {"json", "sankey", "html", "markdown", "mermaid"},
+	"graph-cluster":     {"label", "track"},
+	"graph-preset":      {"compact", "roomy"},
+	"graph-style":       {"grid", "layered"},
+	"queue-by":          {"label", "track"},
+	"script-format":     {"bash", "fish", "zsh"},
+	"statusline-format": {"plain", "ansi", "tmux"},
+	"severity":          {"info", "warning", "critical"},
+	"suggest-type":      {"duplicate", "dependency", "label", "cycle"},
+	"search-mode":       {"text", "hybrid"},
+	"feedback-merge":    {"user", "team", "blend"},
+	"ci-report":         {"github"},
+	"pages-deploy":      {"gitlab", "s3", "netlify", "cloudflare"},
+	"theme":             {"auto", "dark", "light", "solarized", "high-contrast"},
+	"channel":           {"stable", "beta"},
+}
+
+// completionFlag is one flag as the completion scripts see it
+type completionFlag struct {
+	name     string
+	usage    string
+	takesArg bool
+	files    bool     // String values fall back to file names
+	dynamic  string   // Kind for bv __complete, if any
+	choices  []string // Fixed values, if any
+}
+
+// completionFlags lists the flags of fs in name order
+func completionFlags(fs *flag.FlagSet) []completionFlag {
+	var flags []completionFlag
+	fs.VisitAll(func(f *flag.Flag) {
+		b, isBool := f.Value.(interface{ IsBoolFlag() bool })
+		_, isString := f.Value.(flag.Getter).Get().(string)
+		flags = append(flags, completionFlag{
+			name:     f.Name,
+			usage:    f.Usage,
+			takesArg: !isBool || !b.IsBoolFlag(),
+			files:    isString,
+			dynamic:  completionDynamic[f.Name],
+			choices:  completionChoices[f.Name],
+		})
+	})
+	sort.Slice(flags, func(i, j int) bool { return flags[i].name < flags[j].name })
+	return flags
+}
+
+// dashed is the flag as typed: -r for one-letter names, --name otherwise
+func (f completionFlag) dashed() string {
+	if len(f.name) == 1 {
+		return "-" + f.name
+	}
+	return "--" + f.name
+}
+
+// runCompletion implements `bv completion <shell>` for the flags in fs. It
+// returns the process exit code.
+func runCompletion(args []string, fs *flag.FlagSet, stdout, stderr io.Writer) int {
+	if len(args) != 1 {
+		fmt.Fprintln(stderr, completionUsage)
+		return exitUsage
+	}
+	flags := completionFlags(fs)
+	switch args[0] {
+	case "bash":
+		writeBashCompletion(stdout, flags)
+	case "zsh":
+		writeZshCompletion(stdout, flags)
+	case "fish":
+		writeFishCompletion(stdout, flags)
+	default:
+		fmt.Fprintln(stderr, completionUsage)
+		return exitUsage
+	}
+	return exitOK
+}
+
+func writeBashCompletion(w io.Writer, flags []completionFlag) {
+	var names []string
+	for _, f := range flags {
+		names = append(names, f.dashed())
+	}
+	fmt.Fprintln(w, "# bash completion for bv; load with: source <(bv completion bash)")
+	fmt.Fprintln(w, "_bv() {")
+	fmt.Fprintln(w, `	local cur="${COMP_WORDS[COMP_CWORD]}" prev="${COMP_WORDS[COMP_CWORD-1]}"`)
+	fmt.Fprintln(w, `	case "$prev" in`)
+	for _, f := range flags {
+		switch {
+		case f.dynamic != "":
+			fmt.Fprintf(w, "\t%s) COMPREPLY=($(compgen -W \"$(bv __complete %s 2>/dev/null)\" -- \"$cur\")); return ;;\n", f.dashed(), f.dynamic)
+		case f.choices != nil:
+			fmt.Fprintf(w, "\t%s) COMPREPLY=($(compgen -W %q -- \"$cur\")); return ;;\n", f.dashed(), strings.Join(f.choices, " "))
+		case f.files:
+			fmt.Fprintf(w, "\t%s) COMPREPLY=($(compgen -f -- \"$cur\")); return ;;\n", f.dashed())
+		case f.takesArg:
+			fmt.Fprintf(w, "\t%s) return ;;\n", f.dashed())
+		}
+	}
+	fmt.Fprintln(w, "\tcompletion) COMPREPLY=($(compgen -W \"bash zsh fish\" -- \"$cur\")); return ;;")
+	fmt.Fprintln(w, "\tesac")
+	fmt.Fprintln(w, `	if [[ $COMP_CWORD -eq 1 && $cur != -* ]]; then`)
+	fmt.Fprintf(w, "\t\tCOMPREPLY=($(compgen -W %q -- \"$cur\"))\n", strings.Join(completionSubcommands(), " "))
+	fmt.Fprintln(w, "\t\treturn")
+	fmt.Fprintln(w, "\tfi")
+	fmt.Fprintf(w, "\tCOMPREPLY=($(compgen -W %q -- \"$cur\"))\n", strings.Join(names, " "))
+	fmt.Fprintln(w, "}")
+	fmt.Fprintln(w, "complete -F _bv bv")
+}
+
+func writeZshCompletion(w io.Writer, flags []completionFlag) {
+	fmt.Fprintln(w, "#compdef bv")
+	fmt.Fprintln(w, "# zsh completion for bv; load with: source <(bv completion zsh)")
+	fmt.Fprintln(w, "_bv_data() {")
+	fmt.Fprintln(w, `	local -a values`)
+	fmt.Fprintln(w, `	values=(${(f)"$(bv __complete $1 2>/dev/null)"})`)
+	fmt.Fprintln(w, `	compadd -a values`)
+	fmt.Fprintln(w, "}")
+	fmt.Fprintln(w, "_bv() {")
+	fmt.Fprintln(w, "\t_arguments \\")
+	for _, f := range flags {
+		spec := f.dashed() + "[" + zshEscape(f.usage) + "]"
+		switch {
+		case f.dynamic != "":
+			spec += ":" + f.name + ":_bv_data " + f.dynamic
+		case f.choices != nil:
+			spec += ":" + f.name + ":(" + strings.Join(f.choices, " ") + ")"
+		case f.files:
+			spec += ":" + f.name + ":_files"
+		case f.takesArg:
+			spec += ":" + f.name + ": "
+		}
+		fmt.Fprintf(w, "\t\t'%s' \\\n", spec)
+	}
+	fmt.Fprintf(w, "\t\t'1::command:(%s)' \\\n", strings.Join(completionSubcommands(), " "))
+	fmt.Fprintln(w, "\t\t'2::shell:(bash zsh fish)'")
+	fmt.Fprintln(w, "}")
+	fmt.Fprintln(w, "compdef _bv bv")
+}
+
+// zshEscape makes a flag description safe inside a single-quoted
+// _arguments spec
+func zshEscape(s string) string {
+	return strings.NewReplacer("'", `'\''`, "[", `\[`, "]", `\]`, ":", `\:`).Replace(s)
+}
+
+func writeFishCompletion(w io.Writer, flags []completionFlag) {
+	fmt.Fprintln(w, "# fish completion for bv; load with: bv completion fish | source")
+	fmt.Fprintf(w, "complete -c bv -n __fish_use_subcommand -f -a '%s'\n", strings.Join(completionSubcommands(), " "))
+	fmt.Fprintln(w, "complete -c bv -n '__fish_seen_subcommand_from completion' -f -a 'bash zsh fish'")
+	for _, f := range flags {
+		opt := "-l " + f.name
+		if len(f.name) == 1 {
+			opt = "-o " + f.name
+		}
+		line := fmt.Sprintf("complete -c bv %s -d %s", opt, fishQuote(f.usage))
+		switch {
+		case f.dynamic != "":
+			line += fmt.Sprintf(" -x -a '(bv __complete %s 2>/dev/null)'", f.dynamic)
+		case f.choices != nil:
+			line += fmt.Sprintf(" -x -a '%s'", strings.Join(f.choices, " "))
+		case f.files:
+			line += " -r"
+		case f.takesArg:
+			line += " -x"
+		}
+		fmt.Fprintln(w, line)
+	}
+}
+
+// fishQuote single-quotes s for fish
+func fishQuote(s string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, "'", `\'`).Replace(s) + "'"
+}
+
+// runCompleteData implements the hidden `bv __complete <kind>`: the
+// recipes, labels, sprints or bead IDs of the current project, one per line.
+// It prints nothing rather than failing, since a broken completion is worse
+// than an empty one.
+func runCompleteData(args []string, stdout io.Writer) int {
+	if len(args) != 1 {
+		return exitUsage
+	}
+	var values []string
+	switch args[0] {
+	case "recipes":
+		if l, err := recipe.LoadDefault(); err == nil {
+			values = l.Names()
+		}
+	case "labels", "beads":
+		beadsDir, err := loader.GetBeadsDir("")
+		if err != nil {
+			return exitOK
+		}
+		beadsPath, err := loader.FindJSONLPath(beadsDir)
+		if err != nil {
+			return exitOK
+		}
+		issues, err := loader.LoadIssuesFromFile(beadsPath)
+		if err != nil {
+			return exitOK
+		}
+		seen := make(map[string]bool)
+		for _, issue := range issues {
+			candidates := issue.Labels
+			if args[0] == "beads" {
+				candidates = []string{issue.ID}
+			}
+			for _, v := range candidates {
+				if !seen[v] {
+					seen[v] = true
+					values = append(values, v)
+				}
+			}
+		}
+	case "sprints":
+		beadsDir, err := loader.GetBeadsDir("")
+		if err != nil {
+			return exitOK
+		}
+		sprints, err := loader.LoadSprintsFromFile(filepath.Join(beadsDir, loader.SprintsFileName))
+		if err != nil {
+			return exitOK
+		}
+		for _, s := range sprints {
+			values = append(values, s.ID)
+		}
+	case "publish-targets":
+		cwd, _ := os.Getwd()
+		config, err := export.LoadPublishConfig(cwd)
+		if err != nil {
+			return exitOK
+		}
+		values = append(values, "all")
+		for _, t := range config.Targets {
+			values = append(values, t.Name)
+		}
+	default:
+		return exitUsage
+	}
+	sort.Strings(values)
+	for _, v := range values {
+		fmt.Fprintln(stdout, v)
+	}
+	return exitOK
+}
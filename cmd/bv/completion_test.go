@@ -0,0 +1,119 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func testCompletionFlags() *flag.FlagSet {
+	fs := flag.NewFlagSet("bv", flag.ContinueOnError)
+	fs.Bool("robot-triage", false, "Output triage as JSON")
+	fs.String("recipe", "", "Apply named recipe")
+	fs.String("export-md", "", "Export issues to a Markdown file [it's markdown]")
+	fs.Int("graph-depth", 0, "Max depth")
+	fs.String("graph-format", "json", "Graph output format")
+	return fs
+}
+
+func TestRunCompletion(t *testing.T) {
+	tests := map[string][]string{
+		"bash": {
+			"complete -F _bv bv",
+			`--recipe) COMPREPLY=($(compgen -W "$(bv __complete recipes 2>/dev/null)"`,
+			`--graph-format) COMPREPLY=($(compgen -W "json dot mermaid graphml gexf"`,
+			`--export-md) COMPREPLY=($(compgen -f`,
+			"--graph-depth) return ;;",
+			`"new track claim bench doctor merge-driver verify stats completion help triage next`,
+		},
+		"zsh": {
+			"#compdef bv",
+			"'--robot-triage[Output triage as JSON]'",
+			"'--recipe[Apply named recipe]:recipe:_bv_data recipes'",
+			`'--export-md[Export issues to a Markdown file \[it'\''s markdown\]]:export-md:_files'`,
+		},
+		"fish": {
+			"complete -c bv -l robot-triage -d 'Output triage as JSON'\n",
+			"complete -c bv -l recipe -d 'Apply named recipe' -x -a '(bv __complete recipes 2>/dev/null)'",
+			`-l export-md -d 'Export issues to a Markdown file [it\'s markdown]' -r`,
+			"-l graph-depth -d 'Max depth' -x\n",
+		},
+	}
+	for shell, wants := range tests {
+		var stdout bytes.Buffer
+		if code := runCompletion([]string{shell}, testCompletionFlags(), &stdout, io.Discard); code != exitOK {
+			t.Fatalf("%s: exit %d", shell, code)
+		}
+		for _, want := range wants {
+			if !strings.Contains(stdout.String(), want) {
+				t.Errorf("%s completion lacks %q:\n%s", shell, want, stdout.String())
+			}
+		}
+	}
+
+	for _, args := range [][]string{nil, {"tcsh"}, {"bash", "zsh"}} {
+		var stderr bytes.Buffer
+		if code := runCompletion(args, testCompletionFlags(), io.Discard, &stderr); code != exitUsage || !strings.Contains(stderr.String(), completionUsage) {
+			t.Errorf("runCompletion(%v) = %d, %q; want usage error", args, code, stderr.String())
+		}
+	}
+}
+
+func TestRunCompleteData(t *testing.T) {
+	beadsDir := t.TempDir()
+	t.Setenv("BEADS_DIR", beadsDir)
+	beads := `{"id":"B-2","title":"Two","status":"open","priority":1,"issue_type":"task","labels":["ui","api"]}
+{"id":"B-1","title":"One","status":"open","priority":1,"issue_type":"task","labels":["api"]}
+`
+	if err := os.WriteFile(filepath.Join(beadsDir, "beads.jsonl"), []byte(beads), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	sprints := `{"id":"sprint-1","name":"One"}` + "\n"
+	if err := os.WriteFile(filepath.Join(beadsDir, "sprints.jsonl"), []byte(sprints), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	for kind, want := range map[string]string{
+		"beads":   "B-1\nB-2\n",
+		"labels":  "api\nui\n",
+		"sprints": "sprint-1\n",
+	} {
+		var stdout bytes.Buffer
+		if code := runCompleteData([]string{kind}, &stdout); code != exitOK {
+			t.Fatalf("%s: exit %d", kind, code)
+		}
+		if stdout.String() != want {
+			t.Errorf("__complete %s = %q, want %q", kind, stdout.String(), want)
+		}
+	}
+
+	var stdout bytes.Buffer
+	if code := runCompleteData([]string{"recipes"}, &stdout); code != exitOK || !strings.Contains(stdout.String(), "triage\n") {
+		t.Errorf("__complete recipes = %d, %q; want the built-in recipes", code, stdout.String())
+	}
+
+	projectDir := t.TempDir()
+	t.Chdir(projectDir)
+	if err := os.MkdirAll(filepath.Join(projectDir, ".bv"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	publish := "targets:\n  - {name: wiki, type: notion, page_id: abc}\n"
+	if err := os.WriteFile(filepath.Join(projectDir, ".bv", "publish.yaml"), []byte(publish), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	stdout.Reset()
+	if code := runCompleteData([]string{"publish-targets"}, &stdout); code != exitOK || stdout.String() != "all\nwiki\n" {
+		t.Errorf("__complete publish-targets = %d, %q", code, stdout.String())
+	}
+
+	// Missing data completes nothing rather than failing
+	t.Setenv("BEADS_DIR", filepath.Join(beadsDir, "missing"))
+	stdout.Reset()
+	if code := runCompleteData([]string{"beads"}, &stdout); code != exitOK || stdout.Len() != 0 {
+		t.Errorf("__complete beads without data = %d, %q", code, stdout.String())
+	}
+}
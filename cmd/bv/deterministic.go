@@ -0,0 +1,267 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// Deterministic output (--deterministic / BV_DETERMINISTIC=1) makes robot
+// JSON byte-for-byte reproducible for the same data: every clock read by a
+// robot command returns a fixed time derived from the data, generation
+// stamps are rewritten to it, and compute timings are zeroed. Ordering is
+// stable regardless of this mode; JSON objects keep their field order.
+var deterministicOutput bool
+
+// deterministicClock is the fixed "now" in deterministic mode, set once the
+// issues are loaded
+var deterministicClock time.Time
+
+// deterministicStampKeys are JSON keys holding the wall-clock time the output
+// was produced rather than anything in the data
+var deterministicStampKeys = map[string]bool{
+	"generated_at": true,
+	"detected_at":  true,
+	"computed_at":  true,
+}
+
+// deterministicTimingKeys are JSON keys holding how long a computation took
+var deterministicTimingKeys = map[string]bool{
+	"ms":              true,
+	"elapsed_ms":      true,
+	"compute_time_ms": true,
+}
+
+// deterministicEnv reports whether BV_DETERMINISTIC requests deterministic
+// output
+func deterministicEnv() bool {
+	return os.Getenv("BV_DETERMINISTIC") == "1"
+}
+
+// dataClock derives the deterministic clock from the data: the latest
+// created, updated or closed time of any issue, so identical data (the same
+// data_hash) always yields identical timestamps and ages. Data without
+// timestamps uses the Unix epoch.
+func dataClock(issues []model.Issue) time.Time {
+	var latest time.Time
+	for i := range issues {
+		for _, t := range []time.Time{issues[i].CreatedAt, issues[i].UpdatedAt} {
+			if t.After(latest) {
+				latest = t
+			}
+		}
+		if c := issues[i].ClosedAt; c != nil && c.After(latest) {
+			latest = *c
+		}
+	}
+	if latest.IsZero() {
+		return time.Unix(0, 0).UTC()
+	}
+	return latest.UTC().Truncate(time.Second)
+}
+
+// robotNow is the current time as robot commands should see it: the wall
+// clock normally, the data-derived clock in deterministic mode
+func robotNow() time.Time {
+	if !deterministicOutput {
+		return time.Now()
+	}
+	if deterministicClock.IsZero() {
+		return time.Unix(0, 0).UTC()
+	}
+	return deterministicClock
+}
+
+// robotEncoder writes robot JSON, stamping schema_version and, in
+// deterministic mode, normalizing it. It mirrors the parts of json.Encoder
+// the robot commands use.
+type robotEncoder struct {
+	w              io.Writer
+	prefix, indent string
+}
+
+func newRobotEncoder(w io.Writer) *robotEncoder {
+	return &robotEncoder{w: w}
+}
+
+func (e *robotEncoder) SetIndent(prefix, indent string) {
+	e.prefix, e.indent = prefix, indent
+}
+
+// Encode writes v as one JSON document, projected to --fields and fitted
+// to --max-bytes when set. Objects get a leading schema_version field (see
+// --robot-schema) and, when requested, a trailing provenance field; the
+// output is signed with --sign.
+func (e *robotEncoder) Encode(v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	robotJournal.observe(data)
+	if robotFields != nil {
+		if data, err = projectJSON(data, robotFields); err != nil {
+			return err
+		}
+	}
+	var out []byte
+	if robotMaxBytes > 0 {
+		var fits bool
+		out, fits, err = fitRobotBudget(data, robotMaxBytes, e.render)
+		if err == nil && !fits {
+			fmt.Fprintf(os.Stderr, "Warning: output is %d bytes even trimmed, over --max-bytes %d\n", len(out), robotMaxBytes)
+		}
+	} else {
+		out, err = e.render(data)
+	}
+	if err != nil {
+		return err
+	}
+	if _, err := e.w.Write(out); err != nil {
+		return err
+	}
+	return robotSigner.observe(out)
+}
+
+// render turns a compact payload into the bytes written: schema_version
+// and provenance added, normalized with --deterministic, indented, and
+// newline-terminated
+func (e *robotEncoder) render(data []byte) ([]byte, error) {
+	data = withProvenanceField(withSchemaVersionField(data))
+	if deterministicOutput {
+		var err error
+		data, err = normalizeRobotJSON(data, robotNow().Format(time.RFC3339))
+		if err != nil {
+			return nil, err
+		}
+	}
+	var out bytes.Buffer
+	if e.indent != "" || e.prefix != "" {
+		if err := json.Indent(&out, data, e.prefix, e.indent); err != nil {
+			return nil, err
+		}
+	} else {
+		out.Write(data)
+	}
+	out.WriteByte('\n')
+	return out.Bytes(), nil
+}
+
+// withSchemaVersionField prepends schema_version to a compact JSON object,
+// leaving other values alone
+func withSchemaVersionField(data []byte) []byte {
+	if len(data) < 2 || data[0] != '{' {
+		return data
+	}
+	return prependJSONField(data, "schema_version", robotSchemaVersion)
+}
+
+// prependJSONField adds "key":"value" as the first field of a compact JSON
+// object
+func prependJSONField(data []byte, key, value string) []byte {
+	k, _ := json.Marshal(key)
+	v, _ := json.Marshal(value)
+	field := string(k) + ":" + string(v)
+	if data[1] != '}' {
+		field += ","
+	}
+	out := make([]byte, 0, len(data)+len(field))
+	out = append(out, '{')
+	out = append(out, field...)
+	return append(out, data[1:]...)
+}
+
+// normalizeRobotJSON rewrites generation stamps to stamp and zeroes compute
+// timings in compact JSON, keeping object field order
+func normalizeRobotJSON(data []byte, stamp string) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	// One frame per open object or array; for objects, whether the next
+	// token is a key and the key the pending value belongs to
+	type frame struct {
+		object, wantKey bool
+		key             string
+		count           int
+	}
+	var stack []frame
+	var out bytes.Buffer
+
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("normalizing robot JSON: %w", err)
+		}
+
+		var top *frame
+		if len(stack) > 0 {
+			top = &stack[len(stack)-1]
+		}
+
+		if d, ok := tok.(json.Delim); ok && (d == '}' || d == ']') {
+			out.WriteByte(byte(d))
+			stack = stack[:len(stack)-1]
+			if len(stack) > 0 {
+				stack[len(stack)-1].wantKey = stack[len(stack)-1].object
+			}
+			continue
+		}
+
+		if top != nil && top.count > 0 && (!top.object || top.wantKey) {
+			out.WriteByte(',')
+		}
+		if top != nil && top.object && top.wantKey {
+			key, _ := json.Marshal(tok.(string))
+			out.Write(key)
+			out.WriteByte(':')
+			top.key, top.wantKey = tok.(string), false
+			top.count++
+			continue
+		}
+		if top != nil && !top.object {
+			top.count++
+		}
+
+		var key string
+		if top != nil && top.object {
+			key = top.key
+		}
+		switch v := tok.(type) {
+		case json.Delim:
+			out.WriteByte(byte(v))
+			stack = append(stack, frame{object: v == '{', wantKey: v == '{'})
+			continue
+		case string:
+			if deterministicStampKeys[key] {
+				if _, err := time.Parse(time.RFC3339Nano, v); err == nil {
+					v = stamp
+				}
+			}
+			s, _ := json.Marshal(v)
+			out.Write(s)
+		case json.Number:
+			if deterministicTimingKeys[key] {
+				v = "0"
+			}
+			out.WriteString(v.String())
+		case bool:
+			fmt.Fprint(&out, v)
+		case nil:
+			out.WriteString("null")
+		}
+		if top != nil && top.object {
+			top.wantKey = true
+		}
+	}
+	if len(stack) > 0 {
+		return nil, fmt.Errorf("normalizing robot JSON: %w", io.ErrUnexpectedEOF)
+	}
+	return out.Bytes(), nil
+}
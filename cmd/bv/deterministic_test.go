@@ -0,0 +1,123 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestNormalizeRobotJSON(t *testing.T) {
+	in := `{"z":1,"generated_at":"2026-01-02T03:04:05.123Z","status":{"PageRank":{"state":"computed","ms":1234}},` +
+		`"items":[{"generated_at":"2026-01-02T03:04:05Z","created_at":"2020-01-01T00:00:00Z"},{"ms":5}],` +
+		`"triage":{"meta":{"compute_time_ms":77,"detected_at":"not a time"}},"a":[],"b":{},"c":null,"d":true,"e":"x<y"}`
+	want := `{"z":1,"generated_at":"2025-01-01T00:00:00Z","status":{"PageRank":{"state":"computed","ms":0}},` +
+		`"items":[{"generated_at":"2025-01-01T00:00:00Z","created_at":"2020-01-01T00:00:00Z"},{"ms":0}],` +
+		`"triage":{"meta":{"compute_time_ms":0,"detected_at":"not a time"}},"a":[],"b":{},"c":null,"d":true,"e":"x\u003cy"}` // HTML-escaped like json.Encoder
+
+	got, err := normalizeRobotJSON([]byte(in), "2025-01-01T00:00:00Z")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != want {
+		t.Errorf("normalized =\n%s\nwant\n%s", got, want)
+	}
+
+	if _, err := normalizeRobotJSON([]byte(`{"a":`), "x"); err == nil {
+		t.Error("truncated JSON should fail")
+	}
+}
+
+func TestDataClock(t *testing.T) {
+	created := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	closed := time.Date(2025, 4, 2, 10, 30, 15, 500, time.FixedZone("X", 3600))
+	issues := []model.Issue{
+		{ID: "a", CreatedAt: created, UpdatedAt: created.Add(time.Hour)},
+		{ID: "b", CreatedAt: created, ClosedAt: &closed},
+	}
+	if got, want := dataClock(issues), time.Date(2025, 4, 2, 9, 30, 15, 0, time.UTC); !got.Equal(want) || got.Location() != time.UTC {
+		t.Errorf("dataClock = %v, want %v", got, want)
+	}
+	if got := dataClock([]model.Issue{{ID: "x"}}); !got.Equal(time.Unix(0, 0)) {
+		t.Errorf("no timestamps: dataClock = %v, want the epoch", got)
+	}
+}
+
+func TestRobotEncoder_Deterministic(t *testing.T) {
+	defer func(on bool, clock time.Time) { deterministicOutput, deterministicClock = on, clock }(deterministicOutput, deterministicClock)
+
+	payload := map[string]any{"generated_at": time.Now().UTC(), "n": 1}
+	encode := func() string {
+		var buf bytes.Buffer
+		enc := newRobotEncoder(&buf)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(payload); err != nil {
+			t.Fatal(err)
+		}
+		return buf.String()
+	}
+
+	deterministicOutput = true
+	deterministicClock = time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	if got, want := encode(), "{\n  \"schema_version\": \""+robotSchemaVersion+"\",\n  \"generated_at\": \"2025-06-01T00:00:00Z\",\n  \"n\": 1\n}\n"; got != want {
+		t.Errorf("deterministic = %q, want %q", got, want)
+	}
+	if !robotNow().Equal(deterministicClock) {
+		t.Errorf("robotNow = %v, want the fixed clock", robotNow())
+	}
+
+	deterministicOutput = false
+	if got := encode(); bytes.Contains([]byte(got), []byte("2025-06-01")) {
+		t.Errorf("normal mode should keep the wall clock, got %q", got)
+	}
+}
+
+// TestRobotDeterministicOutput runs robot commands twice in deterministic mode
+// (flag and env) and requires byte-identical output
+func TestRobotDeterministicOutput(t *testing.T) {
+	dir := t.TempDir()
+	beadsDir := filepath.Join(dir, ".beads")
+	if err := os.MkdirAll(beadsDir, 0o755); err != nil {
+		t.Fatalf("mkdir beads: %v", err)
+	}
+	beads := `{"id":"TEST-1","title":"Auth login api","status":"open","priority":1,"issue_type":"task","labels":["api"],"created_at":"2025-01-01T00:00:00Z","updated_at":"2025-01-02T00:00:00Z"}
+{"id":"TEST-2","title":"Auth login database","status":"open","priority":2,"issue_type":"task","labels":["database"],"created_at":"2025-01-01T00:00:00Z","updated_at":"2025-01-03T00:00:00Z","dependencies":[{"issue_id":"TEST-2","depends_on_id":"TEST-1","type":"blocks"}]}
+{"id":"TEST-3","title":"Auth login cache","status":"open","priority":2,"issue_type":"task","created_at":"2025-01-01T00:00:00Z","updated_at":"2025-01-03T00:00:00Z","dependencies":[{"issue_id":"TEST-3","depends_on_id":"TEST-1","type":"blocks"}]}
+{"id":"TEST-4","title":"Done","status":"closed","priority":3,"issue_type":"task","created_at":"2025-01-01T00:00:00Z","updated_at":"2025-01-04T00:00:00Z","closed_at":"2025-01-04T00:00:00Z"}
+`
+	if err := os.WriteFile(filepath.Join(beadsDir, "beads.jsonl"), []byte(beads), 0o644); err != nil {
+		t.Fatalf("write beads: %v", err)
+	}
+
+	exe := buildTestBinary(t)
+	run := func(env []string, args ...string) []byte {
+		cmd := exec.Command(exe, args...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(), env...)
+		out, err := cmd.Output()
+		if err != nil {
+			t.Fatalf("%v failed: %v, out=%s", args, err, out)
+		}
+		return out
+	}
+
+	flags := []string{"--robot-triage", "--robot-insights", "--robot-priority", "--robot-suggest", "--robot-label-health"}
+	firsts := make([][]byte, len(flags))
+	for i, flag := range flags {
+		firsts[i] = run(nil, "--deterministic", flag)
+	}
+	time.Sleep(1100 * time.Millisecond) // let any wall-clock second tick over
+	for i, flag := range flags {
+		first, second := firsts[i], run([]string{"BV_DETERMINISTIC=1"}, flag)
+		if !bytes.Equal(first, second) {
+			t.Errorf("%s output differs between runs:\n%s\n---\n%s", flag, first, second)
+		}
+		if !bytes.Contains(first, []byte(`"2025-01-04T00:00:00Z"`)) && bytes.Contains(first, []byte(`"generated_at"`)) {
+			t.Errorf("%s generated_at should be the data clock 2025-01-04:\n%s", flag, first)
+		}
+	}
+}
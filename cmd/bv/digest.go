@@ -0,0 +1,399 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/drift"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// A digest is meant to be run from cron and mailed or posted as is, so each
+// run picks up where the previous digest of the same period left off. What
+// the previous digest reported is kept in .bv/state/digest.json; a re-run
+// within the same day (or week) rebuilds that period's digest from the same
+// starting point instead of reporting an empty window.
+
+// digestStateFile is the state's path inside .bv.
+const digestStateFile = "state/digest.json"
+
+// digestPeriods are the values of --digest.
+var digestPeriods = []string{"daily", "weekly"}
+
+// digestFormats are the values of --digest-format.
+var digestFormats = []string{"markdown", "json"}
+
+// digestSlipDays is how much an issue's remaining estimate must grow to
+// count as a slip; digestGoalSlip is the same for a goal's probability.
+const (
+	digestSlipDays = 1.0
+	digestGoalSlip = 0.1
+)
+
+// digestState is the saved state, one entry per period.
+type digestState struct {
+	Periods map[string]*digestPeriodState `json:"periods"`
+}
+
+// digestPeriodState records the last digest of one period.
+type digestPeriodState struct {
+	Key         string    `json:"key"` // 2026-10-15 (daily) or 2026-W42 (weekly)
+	Since       time.Time `json:"since"`
+	GeneratedAt time.Time `json:"generated_at"`
+	// Baseline is what the digest before this one saw, Current what this one saw
+	Baseline digestMarks `json:"baseline"`
+	Current  digestMarks `json:"current"`
+}
+
+// digestMarks are the alerts and forecasts a digest has reported.
+type digestMarks struct {
+	Alerts []string                 `json:"alerts"`
+	ETAs   map[string]digestETAMark `json:"etas"`
+	Goals  map[string]float64       `json:"goals"` // goal name -> probability
+}
+
+// digestETAMark is an open issue's forecast.
+type digestETAMark struct {
+	Days float64 `json:"days"`           // remaining estimate
+	Late bool    `json:"late,omitempty"` // forecast after the due date
+}
+
+// digestOutput is the digest, as printed by --digest-format json.
+type digestOutput struct {
+	GeneratedAt string `json:"generated_at"`
+	DataHash    string `json:"data_hash"`
+	Period      string `json:"period"`
+	PeriodKey   string `json:"period_key"`
+	Since       string `json:"since"`
+	// Rerun is set when this period's digest was already generated; the
+	// contents cover the same window, so a sender can skip it
+	Rerun     bool               `json:"rerun"`
+	Changes   *digestChanges     `json:"changes,omitempty"` // nil without a beads file committed by Since
+	NewAlerts []drift.Alert      `json:"new_alerts"`
+	Slips     []digestSlip       `json:"slips"`
+	Velocity  *analysis.Velocity `json:"velocity"`
+}
+
+// digestChanges is the diff since the last digest.
+type digestChanges struct {
+	Summary  analysis.DiffSummary `json:"summary"`
+	New      []digestIssue        `json:"new"`
+	Closed   []digestIssue        `json:"closed"`
+	Reopened []digestIssue        `json:"reopened"`
+}
+
+// digestIssue is an issue as listed in a digest.
+type digestIssue struct {
+	ID       string `json:"id"`
+	Title    string `json:"title"`
+	Priority int    `json:"priority"`
+}
+
+// digestSlip is a forecast that got worse since the last digest.
+type digestSlip struct {
+	Kind  string  `json:"kind"` // issue or goal
+	ID    string  `json:"id"`   // issue ID or goal name
+	Title string  `json:"title,omitempty"`
+	From  float64 `json:"from"` // remaining days (issue) or probability (goal)
+	To    float64 `json:"to"`
+	// NowLate is set when an issue's forecast newly passes its due date
+	NowLate bool   `json:"now_late,omitempty"`
+	DueDate string `json:"due_date,omitempty"`
+}
+
+// runDigest builds the digest for the period ending now, records it in the
+// project's digest state and prints it.
+func runDigest(issues []model.Issue, projectDir, period, format string, now time.Time, stdout io.Writer) error {
+	statePath := filepath.Join(projectDir, ".bv", digestStateFile)
+	state, err := readDigestState(statePath)
+	if err != nil {
+		return err
+	}
+
+	key := digestPeriodKey(period, now)
+	last := state.Periods[period]
+	entry := &digestPeriodState{Key: key, GeneratedAt: now}
+	rerun := last != nil && last.Key == key
+	switch {
+	case rerun:
+		entry.Since, entry.Baseline = last.Since, last.Baseline
+	case last != nil:
+		entry.Since, entry.Baseline = last.GeneratedAt, last.Current
+	case period == "weekly":
+		entry.Since = now.AddDate(0, 0, -7)
+	default:
+		entry.Since = now.AddDate(0, 0, -1)
+	}
+
+	alerts, err := computeProjectAlerts(issues, projectDir, now)
+	if err != nil {
+		return err
+	}
+	goals, err := analysis.LoadGoals(projectDir)
+	if err != nil {
+		return fmt.Errorf("loading goals: %w", err)
+	}
+	stats := analysis.NewAnalyzer(issues).Analyze()
+	forecasts := analysis.ForecastGoals(issues, &stats, goals, nil, analysis.CapacitySimOptions{}, now)
+
+	output := digestOutput{
+		GeneratedAt: now.UTC().Format(time.RFC3339),
+		DataHash:    analysis.ComputeDataHash(issues),
+		Period:      period,
+		PeriodKey:   key,
+		Since:       entry.Since.UTC().Format(time.RFC3339),
+		Rerun:       rerun,
+		Velocity:    analysis.ComputeProjectVelocity(issues, now, 4),
+	}
+	if lookup := loader.NewGitLoader(projectDir).SnapshotsSince(entry.Since); lookup != nil {
+		if historical, ok := lookup(entry.Since); ok {
+			output.Changes = newDigestChanges(analysis.CompareSnapshots(
+				analysis.NewSnapshotAt(historical, entry.Since, ""), analysis.NewSnapshot(issues)))
+		}
+	}
+	entry.Current = digestCurrentMarks(issues, &stats, alerts.Alerts, forecasts, now)
+	output.NewAlerts, output.Slips = compareDigestMarks(entry.Baseline, entry.Current, alerts.Alerts, issues)
+
+	if state.Periods == nil {
+		state.Periods = make(map[string]*digestPeriodState)
+	}
+	state.Periods[period] = entry
+	if err := writeDigestState(statePath, state); err != nil {
+		return err
+	}
+
+	if format == "json" {
+		encoder := newRobotEncoder(stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(output)
+	}
+	_, err = io.WriteString(stdout, renderDigestMarkdown(output))
+	return err
+}
+
+// digestPeriodKey names the day or ISO week now falls in.
+func digestPeriodKey(period string, now time.Time) string {
+	if period == "weekly" {
+		year, week := now.ISOWeek()
+		return fmt.Sprintf("%d-W%02d", year, week)
+	}
+	return now.Format("2006-01-02")
+}
+
+func readDigestState(path string) (*digestState, error) {
+	state := &digestState{}
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return state, nil
+		}
+		return nil, fmt.Errorf("reading digest state: %w", err)
+	}
+	if err := json.Unmarshal(raw, state); err != nil {
+		return nil, fmt.Errorf("parsing digest state %s: %w", path, err)
+	}
+	return state, nil
+}
+
+// writeDigestState saves the state atomically. Unlike the status line cache
+// it is not best effort: without it the next digest would repeat this one.
+func writeDigestState(path string, state *digestState) error {
+	raw, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("saving digest state: %w", err)
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, raw, 0o644); err != nil {
+		return fmt.Errorf("saving digest state: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("saving digest state: %w", err)
+	}
+	return nil
+}
+
+func newDigestChanges(diff *analysis.SnapshotDiff) *digestChanges {
+	list := func(issues []model.Issue) []digestIssue {
+		out := make([]digestIssue, 0, len(issues))
+		for _, issue := range issues {
+			out = append(out, digestIssue{ID: issue.ID, Title: issue.Title, Priority: issue.Priority})
+		}
+		return out
+	}
+	return &digestChanges{
+		Summary:  diff.Summary,
+		New:      list(diff.NewIssues),
+		Closed:   list(diff.ClosedIssues),
+		Reopened: list(diff.ReopenedIssues),
+	}
+}
+
+// digestAlertKey identifies an alert across digests. Messages carry counts
+// that change every day, so they are left out.
+func digestAlertKey(a drift.Alert) string {
+	return fmt.Sprintf("%s:%s:%s:%s", a.Type, a.Severity, a.IssueID, a.Label)
+}
+
+// digestCurrentMarks records the alerts and forecasts as of now.
+func digestCurrentMarks(issues []model.Issue, stats *analysis.GraphStats, alerts []drift.Alert, goals []analysis.GoalForecast, now time.Time) digestMarks {
+	marks := digestMarks{Alerts: []string{}, ETAs: make(map[string]digestETAMark), Goals: make(map[string]float64)}
+	for _, a := range alerts {
+		marks.Alerts = append(marks.Alerts, digestAlertKey(a))
+	}
+	sort.Strings(marks.Alerts)
+
+	actuals := trackedActuals()
+	for _, issue := range issues {
+		if issue.Status.IsClosed() || issue.Status.IsTombstone() {
+			continue
+		}
+		eta, err := analysis.EstimateETAForIssueWithActuals(issues, stats, issue.ID, 1, now, actuals)
+		if err != nil {
+			continue
+		}
+		mark := digestETAMark{Days: math.Round(eta.EstimatedDays*10) / 10}
+		if issue.DueDate != nil && eta.ETADate.After(*issue.DueDate) {
+			mark.Late = true
+		}
+		marks.ETAs[issue.ID] = mark
+	}
+	for _, g := range goals {
+		if !g.Done && g.Total > 0 {
+			marks.Goals[g.Name] = g.Probability
+		}
+	}
+	return marks
+}
+
+// compareDigestMarks returns the alerts the previous digest did not report
+// and the forecasts that got worse since. An empty baseline (the first
+// digest) reports every alert and no slips.
+func compareDigestMarks(baseline, current digestMarks, alerts []drift.Alert, issues []model.Issue) ([]drift.Alert, []digestSlip) {
+	seen := make(map[string]bool, len(baseline.Alerts))
+	for _, key := range baseline.Alerts {
+		seen[key] = true
+	}
+	newAlerts := []drift.Alert{}
+	for _, a := range alerts {
+		if !seen[digestAlertKey(a)] {
+			newAlerts = append(newAlerts, a)
+		}
+	}
+
+	slips := []digestSlip{}
+	for _, issue := range issues {
+		before, ok := baseline.ETAs[issue.ID]
+		after, still := current.ETAs[issue.ID]
+		if !ok || !still {
+			continue
+		}
+		nowLate := after.Late && !before.Late
+		if !nowLate && after.Days-before.Days < digestSlipDays {
+			continue
+		}
+		slip := digestSlip{Kind: "issue", ID: issue.ID, Title: issue.Title, From: before.Days, To: after.Days, NowLate: nowLate}
+		if issue.DueDate != nil {
+			slip.DueDate = issue.DueDate.Format("2006-01-02")
+		}
+		slips = append(slips, slip)
+	}
+	names := make([]string, 0, len(current.Goals))
+	for name := range current.Goals {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		before, ok := baseline.Goals[name]
+		if after := current.Goals[name]; ok && before-after >= digestGoalSlip-1e-9 {
+			slips = append(slips, digestSlip{Kind: "goal", ID: name, From: before, To: after})
+		}
+	}
+	return newAlerts, slips
+}
+
+// renderDigestMarkdown renders the digest for email or chat.
+func renderDigestMarkdown(d digestOutput) string {
+	var sb strings.Builder
+	stamp := func(s string) string {
+		if t, err := time.Parse(time.RFC3339, s); err == nil {
+			return t.UTC().Format("2006-01-02 15:04 MST")
+		}
+		return s
+	}
+	fmt.Fprintf(&sb, "# bv %s digest: %s\n\n", d.Period, d.PeriodKey)
+	fmt.Fprintf(&sb, "_Since %s, generated %s_\n\n", stamp(d.Since), stamp(d.GeneratedAt))
+
+	sb.WriteString("## Changes\n\n")
+	switch {
+	case d.Changes == nil:
+		sb.WriteString("No committed beads file from before the digest's start to compare against.\n\n")
+	case d.Changes.Summary.TotalChanges == 0:
+		sb.WriteString("No changes.\n\n")
+	default:
+		s := d.Changes.Summary
+		fmt.Fprintf(&sb, "%d new, %d closed, %d reopened, %d modified (health %s)\n\n",
+			s.IssuesAdded, s.IssuesClosed, s.IssuesReopened, s.IssuesModified, s.HealthTrend)
+		for _, group := range []struct {
+			title  string
+			issues []digestIssue
+		}{{"New", d.Changes.New}, {"Closed", d.Changes.Closed}, {"Reopened", d.Changes.Reopened}} {
+			if len(group.issues) == 0 {
+				continue
+			}
+			fmt.Fprintf(&sb, "**%s**\n\n", group.title)
+			for _, issue := range group.issues {
+				fmt.Fprintf(&sb, "- %s %s (P%d)\n", issue.ID, issue.Title, issue.Priority)
+			}
+			sb.WriteString("\n")
+		}
+	}
+
+	sb.WriteString("## New alerts\n\n")
+	if len(d.NewAlerts) == 0 {
+		sb.WriteString("None.\n\n")
+	} else {
+		for _, a := range d.NewAlerts {
+			fmt.Fprintf(&sb, "- **%s** %s\n", a.Severity, a.Message)
+		}
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString("## Forecast slips\n\n")
+	if len(d.Slips) == 0 {
+		sb.WriteString("None.\n\n")
+	} else {
+		for _, s := range d.Slips {
+			switch {
+			case s.Kind == "goal":
+				fmt.Fprintf(&sb, "- Goal %q: %.0f%% → %.0f%% chance of making its target\n", s.ID, s.From*100, s.To*100)
+			case s.NowLate:
+				fmt.Fprintf(&sb, "- %s %s: now forecast past its %s due date (%.1fd → %.1fd left)\n", s.ID, s.Title, s.DueDate, s.From, s.To)
+			default:
+				fmt.Fprintf(&sb, "- %s %s: %.1fd → %.1fd left\n", s.ID, s.Title, s.From, s.To)
+			}
+		}
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString("## Velocity\n\n")
+	if v := d.Velocity; v != nil {
+		fmt.Fprintf(&sb, "%d closed in the last 7 days, %d in the last 30", v.ClosedLast7Days, v.ClosedLast30Days)
+		if v.AvgDaysToClose > 0 {
+			fmt.Fprintf(&sb, " (%.1f days to close on average)", v.AvgDaysToClose)
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
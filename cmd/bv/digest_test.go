@@ -0,0 +1,90 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestRunDigest_State(t *testing.T) {
+	projectDir := t.TempDir()
+	minutes := func(n int) *int { return &n }
+	stale := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	issues := []model.Issue{
+		{ID: "bv-1", Title: "Parser", Status: model.StatusOpen, Priority: 1, EstimatedMinutes: minutes(480), CreatedAt: stale, UpdatedAt: stale},
+		{ID: "bv-2", Title: "Docs", Status: model.StatusOpen, Priority: 2, EstimatedMinutes: minutes(60), CreatedAt: stale, UpdatedAt: stale},
+	}
+	run := func(at time.Time) digestOutput {
+		t.Helper()
+		var out bytes.Buffer
+		if err := runDigest(issues, projectDir, "daily", "json", at, &out); err != nil {
+			t.Fatal(err)
+		}
+		var d digestOutput
+		if err := json.Unmarshal(out.Bytes(), &d); err != nil {
+			t.Fatalf("decoding %s: %v", out.String(), err)
+		}
+		return d
+	}
+
+	// The first digest covers the last day and reports every alert
+	day1 := time.Date(2026, 10, 15, 9, 0, 0, 0, time.UTC)
+	first := run(day1)
+	if first.PeriodKey != "2026-10-15" || first.Rerun || first.Since != "2026-10-14T09:00:00Z" {
+		t.Fatalf("first digest = %+v", first)
+	}
+	if len(first.NewAlerts) == 0 {
+		t.Fatal("first digest should report the stale issues")
+	}
+	if first.Changes != nil {
+		t.Errorf("no git history, yet changes = %+v", first.Changes)
+	}
+
+	// A re-run the same day rebuilds the same digest
+	again := run(day1.Add(time.Hour))
+	if !again.Rerun || again.Since != first.Since || len(again.NewAlerts) != len(first.NewAlerts) {
+		t.Errorf("re-run = %+v, want the first digest again", again)
+	}
+
+	// The next day starts where the last run left off and repeats nothing
+	issues[0].EstimatedMinutes = minutes(4800)
+	next := run(day1.AddDate(0, 0, 1))
+	if next.Rerun || next.Since != "2026-10-15T10:00:00Z" {
+		t.Errorf("next digest = %+v", next)
+	}
+	if len(next.NewAlerts) != 0 {
+		t.Errorf("alerts already reported came back: %+v", next.NewAlerts)
+	}
+	if len(next.Slips) != 1 || next.Slips[0].ID != "bv-1" || next.Slips[0].To <= next.Slips[0].From {
+		t.Errorf("slips = %+v, want bv-1 growing", next.Slips)
+	}
+}
+
+func TestRenderDigestMarkdown(t *testing.T) {
+	d := digestOutput{
+		Period:      "weekly",
+		PeriodKey:   "2026-W42",
+		Since:       "2026-10-08T09:00:00Z",
+		GeneratedAt: "2026-10-15T09:00:00Z",
+		Slips: []digestSlip{
+			{Kind: "issue", ID: "bv-1", Title: "Parser", From: 1, To: 3.5, NowLate: true, DueDate: "2026-10-20"},
+			{Kind: "goal", ID: "Launch", From: 0.8, To: 0.45},
+		},
+	}
+	got := renderDigestMarkdown(d)
+	for _, want := range []string{
+		"# bv weekly digest: 2026-W42",
+		"No committed beads file",
+		"## New alerts\n\nNone.",
+		"- bv-1 Parser: now forecast past its 2026-10-20 due date (1.0d → 3.5d left)",
+		`- Goal "Launch": 80% → 45% chance of making its target`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("markdown missing %q:\n%s", want, got)
+		}
+	}
+}
@@ -0,0 +1,285 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/hooks"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/search"
+)
+
+// Doctor check statuses
+const (
+	doctorOK   = "ok"
+	doctorWarn = "warn"
+	doctorFail = "fail"
+)
+
+// doctorMaxLineErrors caps how many malformed JSONL lines are listed
+const doctorMaxLineErrors = 10
+
+// doctorCheck is the outcome of one environment check
+type doctorCheck struct {
+	Name    string   `json:"name"`
+	Status  string   `json:"status"`
+	Message string   `json:"message"`
+	Details []string `json:"details,omitempty"`
+	Fix     string   `json:"fix,omitempty"`
+}
+
+// doctorReport is the --json output of bv doctor
+type doctorReport struct {
+	OK     bool          `json:"ok"`
+	Checks []doctorCheck `json:"checks"`
+}
+
+// runDoctor implements `bv doctor`: diagnose the beads data, git, semantic
+// index and hooks of the current project and suggest fixes. It returns the
+// process exit code: 0 when nothing failed, 1 otherwise.
+func runDoctor(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("doctor", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	jsonOut := fs.Bool("json", false, "Output the checks as JSON")
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: bv doctor [--json]")
+		fmt.Fprintln(stderr, "\nDiagnose the environment and print fixes for anything broken.")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return exitOK
+		}
+		return exitUsage
+	}
+	if fs.NArg() > 0 {
+		fs.Usage()
+		return exitUsage
+	}
+
+	beadsDir, err := loader.GetBeadsDir("")
+	if err != nil {
+		fmt.Fprintf(stderr, "Error getting beads directory: %v\n", err)
+		return exitError
+	}
+	projectDir := filepath.Dir(beadsDir)
+
+	checks := doctorBeads(beadsDir)
+	checks = append(checks, doctorGit(projectDir))
+	checks = append(checks, doctorSemanticIndex(projectDir))
+	checks = append(checks, doctorHooks(projectDir))
+
+	report := doctorReport{OK: true, Checks: checks}
+	for _, c := range checks {
+		if c.Status == doctorFail {
+			report.OK = false
+		}
+	}
+
+	if *jsonOut {
+		enc := newRobotEncoder(stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(report); err != nil {
+			fmt.Fprintf(stderr, "Error encoding doctor report: %v\n", err)
+			return exitError
+		}
+	} else {
+		writeDoctorReport(stdout, report)
+	}
+	if !report.OK {
+		return exitError
+	}
+	return exitOK
+}
+
+func writeDoctorReport(w io.Writer, report doctorReport) {
+	marks := map[string]string{doctorOK: "✓", doctorWarn: "!", doctorFail: "✗"}
+	for _, c := range report.Checks {
+		fmt.Fprintf(w, "%s %-8s %s\n", marks[c.Status], c.Name, c.Message)
+		for _, d := range c.Details {
+			fmt.Fprintf(w, "    %s\n", d)
+		}
+		if c.Fix != "" {
+			fmt.Fprintf(w, "    fix: %s\n", c.Fix)
+		}
+	}
+	if report.OK {
+		fmt.Fprintln(w, "\nNo problems found")
+	} else {
+		fmt.Fprintln(w, "\nSome checks failed; see the fixes above")
+	}
+}
+
+// doctorBeads locates the beads directory and JSONL file and reports every
+// line that would be skipped on load
+func doctorBeads(beadsDir string) []doctorCheck {
+	dirCheck := doctorCheck{Name: "beads", Status: doctorOK, Message: "Using " + beadsDir}
+	if os.Getenv(loader.BeadsDirEnvVar) != "" {
+		dirCheck.Message += " (from $" + loader.BeadsDirEnvVar + ")"
+	}
+	if info, err := os.Stat(beadsDir); err != nil || !info.IsDir() {
+		dirCheck.Status = doctorFail
+		dirCheck.Message = "No beads directory at " + beadsDir
+		dirCheck.Fix = "run `bd init` in the project root, or point $" + loader.BeadsDirEnvVar + " at an existing .beads directory"
+		return []doctorCheck{dirCheck}
+	}
+
+	var artifacts []string
+	path, err := loader.FindJSONLPathWithWarnings(beadsDir, func(msg string) { artifacts = append(artifacts, msg) })
+	if err != nil {
+		dirCheck.Status = doctorFail
+		dirCheck.Message = err.Error()
+		dirCheck.Fix = "create issues with `bd create` or `bv new --append`, or restore issues.jsonl from git"
+		return []doctorCheck{dirCheck}
+	}
+	if len(artifacts) > 0 {
+		dirCheck.Status = doctorWarn
+		dirCheck.Details = artifacts
+		dirCheck.Fix = "resolve the merge and delete the leftover files"
+	}
+
+	jsonlCheck := doctorCheck{Name: "jsonl", Status: doctorOK}
+	var lineErrs []loader.LineError
+	issues, err := loader.LoadIssuesFromFileWithOptions(path, loader.ParseOptions{
+		WarningHandler:   func(string) {},
+		LineErrorHandler: func(e loader.LineError) { lineErrs = append(lineErrs, e) },
+	})
+	switch {
+	case err != nil:
+		jsonlCheck.Status = doctorFail
+		jsonlCheck.Message = err.Error()
+		jsonlCheck.Fix = "check the file permissions of " + path
+	case len(lineErrs) > 0:
+		jsonlCheck.Status = doctorFail
+		jsonlCheck.Message = fmt.Sprintf("%s: %d issues loaded, %d lines skipped", filepath.Base(path), len(issues), len(lineErrs))
+		for i, e := range lineErrs {
+			if i == doctorMaxLineErrors {
+				jsonlCheck.Details = append(jsonlCheck.Details, fmt.Sprintf("... and %d more", len(lineErrs)-i))
+				break
+			}
+			jsonlCheck.Details = append(jsonlCheck.Details, fmt.Sprintf("line %d (%s): %s", e.Line, e.Kind, e.Message))
+		}
+		jsonlCheck.Fix = "edit the listed lines of " + path + ", or restore the file with `git checkout -- " + path + "`"
+	default:
+		jsonlCheck.Message = fmt.Sprintf("%s: %d issues loaded", filepath.Base(path), len(issues))
+	}
+	return []doctorCheck{dirCheck, jsonlCheck}
+}
+
+// doctorGit checks that git is installed and that the project is a work
+// tree on a branch; history, drift and time-travel features depend on it
+func doctorGit(projectDir string) doctorCheck {
+	check := doctorCheck{Name: "git", Status: doctorOK}
+	if _, err := exec.LookPath("git"); err != nil {
+		check.Status = doctorWarn
+		check.Message = "git is not installed"
+		check.Fix = "install git to enable --robot-history, --check-drift and time-travel"
+		return check
+	}
+	top, err := doctorGitOutput(projectDir, "rev-parse", "--show-toplevel")
+	if err != nil {
+		check.Status = doctorWarn
+		check.Message = projectDir + " is not in a git repository"
+		check.Fix = "run `git init` and commit .beads to enable history and drift features"
+		return check
+	}
+	check.Message = "Repository at " + top
+
+	branch, err := doctorGitOutput(projectDir, "rev-parse", "--abbrev-ref", "HEAD")
+	switch {
+	case err != nil:
+		check.Status = doctorWarn
+		check.Message += " has no commits"
+		check.Fix = "commit .beads so history and drift have a baseline"
+		return check
+	case branch == "HEAD":
+		check.Status = doctorWarn
+		check.Message += " (detached HEAD)"
+		check.Fix = "check out a branch before recording history or baselines"
+	default:
+		check.Message += " on " + branch
+	}
+
+	if status, err := doctorGitOutput(projectDir, "status", "--porcelain", "--", ".beads"); err == nil && status != "" {
+		for _, line := range strings.Split(status, "\n") {
+			if strings.HasPrefix(line, "UU ") || strings.HasPrefix(line, "AA ") {
+				check.Status = doctorFail
+				check.Details = append(check.Details, "merge conflict: "+strings.TrimSpace(line[3:]))
+				check.Fix = "resolve the conflict markers in .beads (bv merge-driver resolve) and `git add` the result"
+			}
+		}
+		if check.Status != doctorFail {
+			check.Details = append(check.Details, "uncommitted changes in .beads")
+		}
+	}
+	return check
+}
+
+func doctorGitOutput(dir string, args ...string) (string, error) {
+	out, err := exec.Command("git", append([]string{"-C", dir}, args...)...).Output()
+	return strings.TrimSpace(string(out)), err
+}
+
+// doctorSemanticIndex checks that the semantic index, if one has been built,
+// was built with the dimensions of the configured embedder
+func doctorSemanticIndex(projectDir string) doctorCheck {
+	check := doctorCheck{Name: "semantic", Status: doctorOK}
+	cfg := search.EmbeddingConfigFromEnv()
+	embedder, err := search.NewEmbedderFromConfig(cfg)
+	if err != nil {
+		check.Status = doctorFail
+		check.Message = err.Error()
+		check.Fix = "set " + search.EnvSemanticEmbedder + " to a supported provider, or unset it to use the hash embedder"
+		return check
+	}
+	path := search.DefaultIndexPath(projectDir, cfg)
+	idx, err := search.LoadVectorIndex(path)
+	switch {
+	case os.IsNotExist(err):
+		check.Message = fmt.Sprintf("No index yet for %s/%d (built on first --search)", cfg.Provider, embedder.Dim())
+	case err != nil:
+		check.Status = doctorFail
+		check.Message = "Unreadable index " + path + ": " + err.Error()
+		check.Fix = "delete " + path + "; the next --search rebuilds it"
+	case idx.Dim != embedder.Dim():
+		check.Status = doctorFail
+		check.Message = fmt.Sprintf("Index %s has %d dims but the embedder produces %d", filepath.Base(path), idx.Dim, embedder.Dim())
+		check.Fix = "delete " + path + "; the next --search rebuilds it"
+	default:
+		check.Message = fmt.Sprintf("Index %s matches the %s embedder (%d dims, %d docs)", filepath.Base(path), cfg.Provider, idx.Dim, idx.Size())
+	}
+	return check
+}
+
+// doctorHooks checks that .bv/hooks.yaml parses and its hooks are valid
+func doctorHooks(projectDir string) doctorCheck {
+	check := doctorCheck{Name: "hooks", Status: doctorOK}
+	l := hooks.NewLoader(hooks.WithProjectDir(projectDir))
+	if err := l.Load(); err != nil {
+		check.Status = doctorFail
+		check.Message = err.Error()
+		check.Fix = "fix the YAML in .bv/hooks.yaml, or use --no-hooks until it is fixed"
+		return check
+	}
+	if !l.HasHooks() {
+		check.Message = "No hooks configured"
+	} else {
+		n := 0
+		for _, phase := range hooks.Phases {
+			n += len(l.GetHooks(phase))
+		}
+		check.Message = fmt.Sprintf("%d hooks configured", n)
+	}
+	if warnings := l.Warnings(); len(warnings) > 0 {
+		check.Status = doctorWarn
+		check.Details = warnings
+		check.Fix = "edit .bv/hooks.yaml so every hook has a command"
+	}
+	return check
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/search"
+)
+
+func writeDoctorProject(t *testing.T, beads string) string {
+	t.Helper()
+	dir := t.TempDir()
+	beadsDir := filepath.Join(dir, ".beads")
+	if err := os.MkdirAll(beadsDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(beadsDir, "beads.jsonl"), []byte(beads), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("BEADS_DIR", beadsDir)
+	return dir
+}
+
+func doctorChecks(t *testing.T) (int, map[string]doctorCheck) {
+	t.Helper()
+	var stdout bytes.Buffer
+	code := runDoctor([]string{"--json"}, &stdout, io.Discard)
+	var report doctorReport
+	if err := json.Unmarshal(stdout.Bytes(), &report); err != nil {
+		t.Fatalf("doctor --json: %v\n%s", err, stdout.String())
+	}
+	checks := make(map[string]doctorCheck)
+	for _, c := range report.Checks {
+		checks[c.Name] = c
+	}
+	return code, checks
+}
+
+func TestRunDoctorHealthy(t *testing.T) {
+	writeDoctorProject(t, `{"id":"A-1","title":"One","status":"open","priority":1,"issue_type":"task"}`+"\n")
+
+	code, checks := doctorChecks(t)
+	if code != exitOK {
+		t.Errorf("exit = %d, want %d: %+v", code, exitOK, checks)
+	}
+	if c := checks["jsonl"]; c.Status != doctorOK || c.Message != "beads.jsonl: 1 issues loaded" {
+		t.Errorf("jsonl = %+v", c)
+	}
+	for _, name := range []string{"beads", "git", "semantic", "hooks"} {
+		if _, ok := checks[name]; !ok {
+			t.Errorf("missing %s check", name)
+		}
+	}
+}
+
+func TestRunDoctorReportsBadLines(t *testing.T) {
+	writeDoctorProject(t, `{"id":"A-1","title":"One","status":"open","priority":1,"issue_type":"task"}
+{"id":"A-2",
+{"id":"A-3","title":"Three","status":"open","priority":1,"issue_type":"task"}
+`)
+
+	var stdout bytes.Buffer
+	if code := runDoctor(nil, &stdout, io.Discard); code != exitError {
+		t.Errorf("exit = %d, want %d", code, exitError)
+	}
+	for _, want := range []string{"✗ jsonl", "2 issues loaded, 1 lines skipped", "line 2 (malformed_json)", "fix: edit the listed lines"} {
+		if !strings.Contains(stdout.String(), want) {
+			t.Errorf("report lacks %q:\n%s", want, stdout.String())
+		}
+	}
+}
+
+func TestRunDoctorIndexDimMismatch(t *testing.T) {
+	dir := writeDoctorProject(t, `{"id":"A-1","title":"One","status":"open","priority":1,"issue_type":"task"}`+"\n")
+	path := search.DefaultIndexPath(dir, search.EmbeddingConfigFromEnv())
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := search.NewVectorIndex(8).Save(path); err != nil {
+		t.Fatal(err)
+	}
+
+	code, checks := doctorChecks(t)
+	if c := checks["semantic"]; code != exitError || c.Status != doctorFail || !strings.Contains(c.Message, "has 8 dims") {
+		t.Errorf("exit %d, semantic = %+v", code, c)
+	}
+}
+
+func TestRunDoctorMissingBeadsDir(t *testing.T) {
+	t.Setenv("BEADS_DIR", filepath.Join(t.TempDir(), "missing"))
+
+	code, checks := doctorChecks(t)
+	if c := checks["beads"]; code != exitError || c.Status != doctorFail || c.Fix == "" {
+		t.Errorf("exit %d, beads = %+v", code, c)
+	}
+	if _, ok := checks["jsonl"]; ok {
+		t.Error("jsonl should not be checked without a beads directory")
+	}
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"strings"
+)
+
+// Exit codes are a contract with scripts and agent wrappers: every bv
+// command exits with one of these, so callers can branch on the code alone.
+// Commands that report a verdict (--check-drift, --ci-report) keep their
+// documented codes for it.
+const (
+	exitOK       = 0
+	exitError    = 1 // Anything not covered below: I/O, git, encoding failures
+	exitUsage    = 2 // Invalid flags, arguments or flag combinations
+	exitNotFound = 3 // No beads data, or a named issue, sprint or ref doesn't exist
+	exitTimeout  = 4 // Analysis or a lookup ran out of time
+)
+
+// exitCodeNames are the machine-readable names of the exit codes in robot
+// errors
+var exitCodeNames = map[int]string{
+	exitOK:       "ok",
+	exitError:    "error",
+	exitUsage:    "usage",
+	exitNotFound: "not_found",
+	exitTimeout:  "timeout",
+}
+
+// exitCodeFor classifies err: missing files or data are exitNotFound,
+// expired deadlines exitTimeout, anything else exitError
+func exitCodeFor(err error) int {
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		return exitNotFound
+	case errors.Is(err, context.DeadlineExceeded):
+		return exitTimeout
+	default:
+		return exitError
+	}
+}
+
+// robotError is the JSON object written to stderr for a failure in robot
+// mode (BV_ROBOT=1, which every --robot-* flag sets)
+type robotError struct {
+	SchemaVersion string `json:"schema_version"`
+	Error         struct {
+		Code     string `json:"code"`
+		ExitCode int    `json:"exit_code"`
+		Message  string `json:"message"`
+	} `json:"error"`
+}
+
+// writeFailure reports a failure: the message as-is for people, or a
+// robotError line when robot is set
+func writeFailure(w io.Writer, robot bool, code int, msg string) {
+	if !robot {
+		fmt.Fprintln(w, msg)
+		return
+	}
+	var out robotError
+	out.SchemaVersion = robotSchemaVersion
+	out.Error.Code = exitCodeNames[code]
+	out.Error.ExitCode = code
+	out.Error.Message = strings.TrimPrefix(msg, "Error: ")
+	data, _ := json.Marshal(out)
+	fmt.Fprintf(w, "%s\n", data)
+}
+
+// fatalf reports a failure on stderr and exits with code
+func fatalf(code int, format string, args ...any) {
+	writeFailure(os.Stderr, os.Getenv("BV_ROBOT") == "1", code, fmt.Sprintf(format, args...))
+	os.Exit(code)
+}
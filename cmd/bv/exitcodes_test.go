@@ -0,0 +1,80 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"testing"
+)
+
+func TestExitCodeFor(t *testing.T) {
+	tests := []struct {
+		err  error
+		want int
+	}{
+		{errors.New("boom"), exitError},
+		{fmt.Errorf("reading beads: %w", fs.ErrNotExist), exitNotFound},
+		{fmt.Errorf("analysis: %w", context.DeadlineExceeded), exitTimeout},
+	}
+	for _, tt := range tests {
+		if got := exitCodeFor(tt.err); got != tt.want {
+			t.Errorf("exitCodeFor(%v) = %d, want %d", tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestWriteFailure(t *testing.T) {
+	var buf bytes.Buffer
+	writeFailure(&buf, false, exitNotFound, "Error: issue x not found")
+	if buf.String() != "Error: issue x not found\n" {
+		t.Errorf("human failure = %q", buf.String())
+	}
+
+	buf.Reset()
+	writeFailure(&buf, true, exitNotFound, "Error: issue x not found")
+	var got robotError
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("robot failure is not JSON: %v\n%s", err, buf.String())
+	}
+	if got.SchemaVersion != robotSchemaVersion || got.Error.Code != "not_found" || got.Error.ExitCode != exitNotFound || got.Error.Message != "issue x not found" {
+		t.Errorf("robot failure = %+v", got)
+	}
+	if bytes.Count(buf.Bytes(), []byte("\n")) != 1 {
+		t.Errorf("robot failure should be one line: %q", buf.String())
+	}
+}
+
+func TestRobotErrorExitCodes(t *testing.T) {
+	exe := buildTestBinary(t)
+
+	// No .beads directory at all
+	cmd := exec.Command(exe, "--robot-triage")
+	cmd.Dir = t.TempDir()
+	cmd.Env = append(os.Environ(), "BEADS_DIR=")
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	err := cmd.Run()
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) || exitErr.ExitCode() != exitNotFound {
+		t.Fatalf("missing beads data: err = %v, want exit status %d; stderr=%s", err, exitNotFound, stderr.String())
+	}
+	var got robotError
+	if err := json.Unmarshal(stderr.Bytes(), &got); err != nil {
+		t.Fatalf("stderr is not a robot error: %v\n%s", err, stderr.String())
+	}
+	if got.Error.Code != "not_found" || got.Error.Message == "" {
+		t.Errorf("robot error = %+v", got)
+	}
+
+	// Bad usage
+	cmd = exec.Command(exe, "--robot-insights", "--stream", "--page-size", "5")
+	cmd.Dir = t.TempDir()
+	if err := cmd.Run(); !errors.As(err, &exitErr) || exitErr.ExitCode() != exitUsage {
+		t.Errorf("conflicting flags: err = %v, want exit status %d", err, exitUsage)
+	}
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/correlation"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// explainMaxCommits bounds the commits listed in --robot-explain
+const explainMaxCommits = 10
+
+// robotExplainOutput is the --robot-explain payload: the issue's
+// analysis.IssueExplanation plus the commits correlated with it
+type robotExplainOutput struct {
+	GeneratedAt string `json:"generated_at"`
+	DataHash    string `json:"data_hash"`
+	AsOf        string `json:"as_of,omitempty"`
+	AsOfCommit  string `json:"as_of_commit,omitempty"`
+	*analysis.IssueExplanation
+	Commits []explainCommit `json:"commits"` // Empty outside a git repository
+}
+
+// explainCommit is a commit correlated with the explained issue
+type explainCommit struct {
+	SHA        string    `json:"sha"`
+	Message    string    `json:"message"`
+	Author     string    `json:"author"`
+	Timestamp  time.Time `json:"timestamp"`
+	Method     string    `json:"method"`
+	Confidence float64   `json:"confidence"`
+}
+
+// explainCommits returns the newest commits correlated with issueID, or
+// none when cwd is not a git repository or correlation fails: the rest of
+// the explanation doesn't depend on git.
+func explainCommits(cwd string, issues []model.Issue, issueID string, limit int) []explainCommit {
+	commits := []explainCommit{}
+	if err := correlation.ValidateRepository(cwd); err != nil {
+		return commits
+	}
+	beadsDir, err := loader.GetBeadsDir("")
+	if err != nil {
+		return commits
+	}
+	beadsPath, err := loader.FindJSONLPath(beadsDir)
+	if err != nil {
+		return commits
+	}
+	beadInfos := make([]correlation.BeadInfo, len(issues))
+	for i, issue := range issues {
+		beadInfos[i] = correlation.BeadInfo{
+			ID:     issue.ID,
+			Title:  issue.Title,
+			Status: string(issue.Status),
+			Events: issue.Events,
+		}
+	}
+	report, err := correlation.NewIndexedCorrelator(cwd, beadsPath).GenerateReport(beadInfos, correlation.CorrelatorOptions{
+		BeadID: issueID,
+		Limit:  limit,
+	})
+	if err != nil {
+		return commits
+	}
+	history, ok := report.Histories[issueID]
+	if !ok {
+		return commits
+	}
+	for i := len(history.Commits) - 1; i >= 0 && len(commits) < explainMaxCommits; i-- {
+		c := history.Commits[i]
+		commits = append(commits, explainCommit{
+			SHA:        c.ShortSHA,
+			Message:    truncateTitle(c.Message, 120),
+			Author:     c.Author,
+			Timestamp:  c.Timestamp,
+			Method:     string(c.Method),
+			Confidence: c.Confidence,
+		})
+	}
+	return commits
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
+)
+
+// feedbackProfileUser resolves --as to a feedback profile: "" is the team
+// profile, used for "team" and when no user can be resolved
+func feedbackProfileUser(as string) string {
+	user := analysis.ResolveAssignee(as)
+	if strings.EqualFold(user, analysis.TeamProfile) {
+		return ""
+	}
+	return user
+}
+
+// exportFeedbackProfile writes a profile in the feedback.json format, so it
+// can be imported elsewhere with --feedback-import
+func exportFeedbackProfile(feedback *analysis.FeedbackData, path string) error {
+	data, err := json.MarshalIndent(feedback, "", "  ")
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+	if path == "-" {
+		_, err = os.Stdout.Write(data)
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// loadRobotFeedback returns the accept/ignore feedback tuning user's
+// rankings, or nil when none has been recorded, so outputs without feedback
+// are unchanged
+func loadRobotFeedback(user, strategy string) *analysis.FeedbackData {
+	beadsDir, err := loader.GetBeadsDir("")
+	if err != nil {
+		return nil
+	}
+	feedback, err := analysis.LoadActiveFeedback(beadsDir, user, strategy)
+	if err != nil || len(feedback.Events) == 0 {
+		return nil
+	}
+	return feedback
+}
+
+// mergeFeedbackIntoTriage notes the recommendations whose rank the
+// feedback-tuned weights move materially, and returns how many there are
+func mergeFeedbackIntoTriage(triage *analysis.TriageResult, feedback *analysis.FeedbackData) int {
+	ranked := make([]analysis.RankedItem, len(triage.Recommendations))
+	for i, rec := range triage.Recommendations {
+		ranked[i] = analysis.RankedItem{ID: rec.ID, Breakdown: rec.Breakdown}
+	}
+	notes := feedback.RankNotes(ranked)
+	if len(notes) == 0 {
+		return 0
+	}
+	attach := func(recs []analysis.Recommendation) {
+		for i := range recs {
+			recs[i].FeedbackNote = notes[recs[i].ID]
+		}
+	}
+	attach(triage.Recommendations)
+	for _, group := range triage.RecommendationsByTrack {
+		attach(group.Recommendations)
+	}
+	for _, group := range triage.RecommendationsByLabel {
+		attach(group.Recommendations)
+	}
+	return len(notes)
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+)
+
+func TestMergeFeedbackIntoTriage(t *testing.T) {
+	recs := []analysis.Recommendation{
+		{ID: "graph", Breakdown: analysis.ScoreBreakdown{PageRankNorm: 1, BetweennessNorm: 1}},
+		{ID: "mid", Breakdown: analysis.ScoreBreakdown{PageRankNorm: 0.5}},
+		{ID: "urgent", Breakdown: analysis.ScoreBreakdown{UrgencyNorm: 1, PriorityBoostNorm: 1}},
+	}
+	triage := analysis.TriageResult{
+		Recommendations:        recs,
+		RecommendationsByTrack: []analysis.TrackRecommendationGroup{{Recommendations: []analysis.Recommendation{recs[2]}}},
+	}
+
+	feedback := analysis.DefaultFeedbackData()
+	if n := mergeFeedbackIntoTriage(&triage, feedback); n != 0 {
+		t.Errorf("no feedback: %d rank changes", n)
+	}
+	for i := 0; i < 40; i++ {
+		_ = feedback.RecordFeedback("x", "accept", 0.5, analysis.ScoreBreakdown{UrgencyNorm: 1, PriorityBoostNorm: 1})
+		_ = feedback.RecordFeedback("y", "ignore", 0.5, analysis.ScoreBreakdown{PageRankNorm: 1, BetweennessNorm: 1})
+	}
+	if n := mergeFeedbackIntoTriage(&triage, feedback); n == 0 {
+		t.Fatal("expected feedback to re-rank the urgent item")
+	}
+	if triage.Recommendations[2].FeedbackNote == "" || triage.RecommendationsByTrack[0].Recommendations[0].FeedbackNote == "" {
+		t.Errorf("urgent item should carry a feedback note everywhere: %+v", triage)
+	}
+}
+
+func TestFeedbackProfileUser(t *testing.T) {
+	t.Setenv("BD_ACTOR", "ana")
+	for as, want := range map[string]string{"me": "ana", "bob": "bob", "team": "", "Team": ""} {
+		if got := feedbackProfileUser(as); got != want {
+			t.Errorf("feedbackProfileUser(%q) = %q, want %q", as, got, want)
+		}
+	}
+}
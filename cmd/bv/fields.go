@@ -0,0 +1,144 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Field selection (--fields=a.b,c) projects every robot payload down to the
+// named paths before it is written. A path walks object keys; arrays are
+// transparent, so "recommendations.id" keeps the id of every
+// recommendation. A path naming an object or array keeps all of it.
+// schema_version and the stream "record" tag are always kept.
+var robotFields *fieldTree
+
+// fieldTree is a set of selected paths: a node with no children keeps its
+// whole value
+type fieldTree struct {
+	children map[string]*fieldTree
+}
+
+// fieldsAlwaysKept are top-level keys that survive any projection
+var fieldsAlwaysKept = map[string]bool{
+	"schema_version": true,
+	"record":         true,
+}
+
+// parseFieldPaths parses a comma-separated --fields list. An empty list
+// selects nothing, leaving output unprojected.
+func parseFieldPaths(spec string) (*fieldTree, error) {
+	spec = strings.TrimSpace(spec)
+	if spec == "" {
+		return nil, nil
+	}
+	root := &fieldTree{children: make(map[string]*fieldTree)}
+	for _, path := range strings.Split(spec, ",") {
+		path = strings.TrimSpace(path)
+		if path == "" {
+			continue
+		}
+		node := root
+		for _, key := range strings.Split(path, ".") {
+			if key == "" {
+				return nil, fmt.Errorf("empty key in field path %q", path)
+			}
+			if node.children == nil {
+				// An ancestor was already selected whole
+				break
+			}
+			child, ok := node.children[key]
+			if !ok {
+				child = &fieldTree{children: make(map[string]*fieldTree)}
+				node.children[key] = child
+			}
+			node = child
+		}
+		// The path's last key selects the whole value, absorbing any
+		// longer paths under it
+		node.children = nil
+	}
+	if len(root.children) == 0 {
+		return nil, fmt.Errorf("no field paths in %q", spec)
+	}
+	return root, nil
+}
+
+// projectJSON keeps only the selected paths of a compact JSON value,
+// preserving object field order. Scalars reached before a path ends are
+// kept as they are.
+func projectJSON(data []byte, tree *fieldTree) ([]byte, error) {
+	return projectValue(data, tree, true)
+}
+
+func projectValue(data []byte, tree *fieldTree, top bool) ([]byte, error) {
+	data = bytes.TrimSpace(data)
+	if tree == nil || tree.children == nil || len(data) == 0 {
+		return data, nil
+	}
+	switch data[0] {
+	case '{':
+		dec := json.NewDecoder(bytes.NewReader(data))
+		if _, err := dec.Token(); err != nil {
+			return nil, err
+		}
+		var out bytes.Buffer
+		out.WriteByte('{')
+		n := 0
+		for dec.More() {
+			tok, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+			key, _ := tok.(string)
+			var raw json.RawMessage
+			if err := dec.Decode(&raw); err != nil {
+				return nil, err
+			}
+			child, ok := tree.children[key]
+			if !ok && !(top && fieldsAlwaysKept[key]) {
+				continue
+			}
+			value, err := projectValue(raw, child, false)
+			if err != nil {
+				return nil, err
+			}
+			if n > 0 {
+				out.WriteByte(',')
+			}
+			k, _ := json.Marshal(key)
+			out.Write(k)
+			out.WriteByte(':')
+			out.Write(value)
+			n++
+		}
+		if _, err := dec.Token(); err != nil && err != io.EOF {
+			return nil, err
+		}
+		out.WriteByte('}')
+		return out.Bytes(), nil
+	case '[':
+		var items []json.RawMessage
+		if err := json.Unmarshal(data, &items); err != nil {
+			return nil, err
+		}
+		var out bytes.Buffer
+		out.WriteByte('[')
+		for i, item := range items {
+			value, err := projectValue(item, tree, top)
+			if err != nil {
+				return nil, err
+			}
+			if i > 0 {
+				out.WriteByte(',')
+			}
+			out.Write(value)
+		}
+		out.WriteByte(']')
+		return out.Bytes(), nil
+	default:
+		return data, nil
+	}
+}
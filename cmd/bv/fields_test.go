@@ -0,0 +1,108 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseFieldPaths(t *testing.T) {
+	if tree, err := parseFieldPaths("  "); tree != nil || err != nil {
+		t.Errorf("empty spec = %v, %v; want no projection", tree, err)
+	}
+	for _, bad := range []string{"a..b", ".a", ",,"} {
+		if _, err := parseFieldPaths(bad); err == nil {
+			t.Errorf("parseFieldPaths(%q) should fail", bad)
+		}
+	}
+
+	// A shorter path absorbs longer ones under it, in either order
+	for _, spec := range []string{"a,a.b", "a.b,a"} {
+		tree, err := parseFieldPaths(spec)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if tree.children["a"].children != nil {
+			t.Errorf("%q: a should be selected whole", spec)
+		}
+	}
+}
+
+func TestProjectJSON(t *testing.T) {
+	in := `{"schema_version":"1","record":"x","z":1,"a":{"b":[{"id":"1","n":2},{"id":"2","n":3},"s"],"c":true},"d":{"e":1},"s":"str"}`
+	tests := []struct {
+		spec, want string
+	}{
+		{"z", `{"schema_version":"1","record":"x","z":1}`},
+		{"a.b.id,d", `{"schema_version":"1","record":"x","a":{"b":[{"id":"1"},{"id":"2"},"s"]},"d":{"e":1}}`},
+		{"d,a.c", `{"schema_version":"1","record":"x","a":{"c":true},"d":{"e":1}}`},
+		{"s.deeper,missing", `{"schema_version":"1","record":"x","s":"str"}`},
+	}
+	for _, tt := range tests {
+		tree, err := parseFieldPaths(tt.spec)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := projectJSON([]byte(in), tree)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != tt.want {
+			t.Errorf("--fields=%s:\n got %s\nwant %s", tt.spec, got, tt.want)
+		}
+	}
+}
+
+func TestRobotTriageFieldsFlag(t *testing.T) {
+	dir := t.TempDir()
+	beadsDir := filepath.Join(dir, ".beads")
+	if err := os.MkdirAll(beadsDir, 0o755); err != nil {
+		t.Fatalf("mkdir beads: %v", err)
+	}
+	beads := `{"id":"TEST-1","title":"Root","status":"open","priority":1,"issue_type":"task"}
+{"id":"TEST-2","title":"Leaf","status":"open","priority":2,"issue_type":"task","dependencies":[{"issue_id":"TEST-2","depends_on_id":"TEST-1","type":"blocks"}]}
+`
+	if err := os.WriteFile(filepath.Join(beadsDir, "beads.jsonl"), []byte(beads), 0o644); err != nil {
+		t.Fatalf("write beads: %v", err)
+	}
+
+	exe := buildTestBinary(t)
+	cmd := exec.Command(exe, "--robot-triage", "--fields", "data_hash,triage.recommendations.id")
+	cmd.Dir = dir
+	out, err := cmd.Output()
+	if err != nil {
+		t.Fatalf("--robot-triage --fields failed: %v, out=%s", err, out)
+	}
+	var got struct {
+		SchemaVersion string `json:"schema_version"`
+		DataHash      string `json:"data_hash"`
+		Triage        struct {
+			Recommendations []map[string]any `json:"recommendations"`
+		} `json:"triage"`
+	}
+	if err := json.Unmarshal(out, &got); err != nil {
+		t.Fatalf("decode: %v\n%s", err, out)
+	}
+	if got.SchemaVersion == "" || got.DataHash == "" || len(got.Triage.Recommendations) == 0 {
+		t.Fatalf("projection dropped selected fields: %s", out)
+	}
+	for _, rec := range got.Triage.Recommendations {
+		if len(rec) != 1 || rec["id"] == nil {
+			t.Errorf("recommendation = %v, want only id", rec)
+		}
+	}
+	if bytes.Contains(out, []byte("usage_hints")) || bytes.Contains(out, []byte("quick_ref")) {
+		t.Errorf("unselected fields leaked: %s", out)
+	}
+
+	cmd = exec.Command(exe, "--robot-triage", "--fields", "a..b")
+	cmd.Dir = dir
+	if err := cmd.Run(); err == nil {
+		t.Error("malformed --fields should fail")
+	} else if exitErr, ok := err.(*exec.ExitError); !ok || exitErr.ExitCode() != 2 {
+		t.Errorf("malformed --fields: %v, want exit status 2", err)
+	}
+}
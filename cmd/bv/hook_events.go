@@ -0,0 +1,46 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/baseline"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/hooks"
+)
+
+// snapshotHookPayload is the stdin data of pre-snapshot and post-snapshot
+// hooks
+type snapshotHookPayload struct {
+	BaselinePath string              `json:"baseline_path"`
+	Description  string              `json:"description"`
+	Stats        baseline.GraphStats `json:"stats"`
+}
+
+// runEventHooks runs the hooks of phase from .bv/hooks.yaml with data as
+// their stdin payload. A hooks.yaml that fails to load is only a warning, as
+// it is for exports; the returned error is a hook failing with
+// on_error=fail. Warnings and failures go to stderr unless quiet.
+func runEventHooks(projectDir string, phase hooks.HookPhase, data any, stderr io.Writer, quiet bool) error {
+	loader := hooks.NewLoader(hooks.WithProjectDir(projectDir))
+	if err := loader.Load(); err != nil {
+		if !quiet {
+			fmt.Fprintf(stderr, "Warning: failed to load hooks: %v\n", err)
+		}
+		return nil
+	}
+	if !loader.HasHooksFor(phase) {
+		return nil
+	}
+
+	executor := hooks.NewExecutor(loader.Config(), hooks.ExportContext{})
+	err := executor.Run(phase, data)
+	if !quiet {
+		for _, r := range executor.Results() {
+			if !r.Success {
+				fmt.Fprint(stderr, executor.Summary())
+				break
+			}
+		}
+	}
+	return err
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/hooks"
+)
+
+func TestRunEventHooks(t *testing.T) {
+	dir := t.TempDir()
+	if err := runEventHooks(dir, hooks.PreSnapshot, nil, os.Stderr, false); err != nil {
+		t.Fatalf("no hooks.yaml should be a no-op, got %v", err)
+	}
+
+	if err := os.MkdirAll(filepath.Join(dir, ".bv"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	config := "hooks:\n  pre-snapshot:\n    - name: guard\n      command: exit 1\n"
+	if err := os.WriteFile(filepath.Join(dir, ".bv", "hooks.yaml"), []byte(config), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var stderr bytes.Buffer
+	err := runEventHooks(dir, hooks.PreSnapshot, snapshotHookPayload{Description: "x"}, &stderr, false)
+	if err == nil || !strings.Contains(stderr.String(), "[FAIL] guard") {
+		t.Errorf("failing pre-snapshot hook = %v, stderr %q", err, stderr.String())
+	}
+
+	stderr.Reset()
+	if err := runEventHooks(dir, hooks.OnTriage, nil, &stderr, false); err != nil || stderr.Len() != 0 {
+		t.Errorf("phase without hooks = %v, stderr %q", err, stderr.String())
+	}
+}
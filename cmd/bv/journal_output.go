@@ -0,0 +1,145 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/journal"
+)
+
+// robotJournal records the robot invocation in .bv/journal.jsonl when
+// --journal or BV_JOURNAL=1 is set; nil otherwise. The robot encoder hands it
+// the first payload written.
+var robotJournal *journalRecorder
+
+// journalRecorder appends one journal record per invocation
+type journalRecorder struct {
+	path   string
+	record journal.Record
+	done   bool
+}
+
+// newJournalRecorder prepares the record for this invocation from the flags
+// that were set: robot-* flags name the command, the rest are its filters.
+// It returns nil when no robot command was given.
+func newJournalRecorder(path, dataHash string) *journalRecorder {
+	var commands []string
+	filters := make(map[string]string)
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == "journal" {
+			return
+		}
+		value := f.Value.String()
+		if strings.HasPrefix(f.Name, "robot-") {
+			commands = append(commands, f.Name)
+			if value == "true" {
+				return
+			}
+		}
+		filters[f.Name] = value
+	})
+	if len(commands) == 0 {
+		return nil
+	}
+	sort.Strings(commands)
+	if len(filters) == 0 {
+		filters = nil
+	}
+	return &journalRecorder{
+		path: path,
+		record: journal.Record{
+			At:       robotNow(),
+			Command:  strings.Join(commands, " "),
+			Filters:  filters,
+			DataHash: dataHash,
+			Actor:    analysis.CurrentActor(),
+		},
+	}
+}
+
+// observe records the payload's recommendations. Only the first payload of
+// an invocation is journaled; failures to write are warnings.
+func (j *journalRecorder) observe(data []byte) {
+	if j == nil || j.done {
+		return
+	}
+	j.done = true
+	j.record.Picks = journalPicks(data)
+	if len(j.record.Picks) > 0 {
+		j.record.Top = j.record.Picks[0]
+	}
+	if err := journal.Append(j.path, j.record); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: journal: %v\n", err)
+	}
+}
+
+// journalPicks extracts the recommended issue IDs, best first, from a robot
+// payload: triage top picks, the --robot-next pick, a personal queue, the
+// plan's highest-impact item or each partition bundle's first item
+func journalPicks(data []byte) []string {
+	var payload struct {
+		ID       string `json:"id"`
+		ClaimCmd string `json:"claim_command"`
+		Triage   *struct {
+			QuickRef struct {
+				TopPicks []struct {
+					ID string `json:"id"`
+				} `json:"top_picks"`
+			} `json:"quick_ref"`
+		} `json:"triage"`
+		Queue *struct {
+			Items []struct {
+				ID      string `json:"id"`
+				Section string `json:"section"`
+			} `json:"items"`
+		} `json:"queue"`
+		Plan *struct {
+			Summary struct {
+				HighestImpact string `json:"highest_impact"`
+			} `json:"summary"`
+		} `json:"plan"`
+		Partition *struct {
+			Bundles []struct {
+				Items []struct {
+					ID string `json:"id"`
+				} `json:"items"`
+			} `json:"bundles"`
+		} `json:"partition"`
+	}
+	if json.Unmarshal(data, &payload) != nil {
+		return nil
+	}
+
+	var picks []string
+	switch {
+	case payload.Triage != nil:
+		for _, p := range payload.Triage.QuickRef.TopPicks {
+			picks = append(picks, p.ID)
+		}
+	case payload.ID != "" && payload.ClaimCmd != "":
+		picks = append(picks, payload.ID)
+	case payload.Queue != nil:
+		for _, item := range payload.Queue.Items {
+			if item.Section == string(analysis.QueueNext) {
+				picks = append(picks, item.ID)
+			}
+		}
+	case payload.Plan != nil && payload.Plan.Summary.HighestImpact != "":
+		picks = append(picks, payload.Plan.Summary.HighestImpact)
+	case payload.Partition != nil:
+		for _, b := range payload.Partition.Bundles {
+			if len(b.Items) > 0 {
+				picks = append(picks, b.Items[0].ID)
+			}
+		}
+	}
+	if len(picks) > journal.MaxPicks {
+		picks = picks[:journal.MaxPicks]
+	}
+	return picks
+}
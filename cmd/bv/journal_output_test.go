@@ -0,0 +1,27 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestJournalPicks(t *testing.T) {
+	cases := map[string]struct {
+		payload string
+		want    []string
+	}{
+		"triage":    {`{"triage":{"quick_ref":{"top_picks":[{"id":"a"},{"id":"b"}]}}}`, []string{"a", "b"}},
+		"next":      {`{"id":"a","claim_command":"bd update a --status=in_progress"}`, []string{"a"}},
+		"queue":     {`{"queue":{"items":[{"id":"a","section":"in_progress"},{"id":"b","section":"next"}]}}`, []string{"b"}},
+		"plan":      {`{"plan":{"summary":{"highest_impact":"a"}}}`, []string{"a"}},
+		"partition": {`{"partition":{"bundles":[{"items":[{"id":"a"},{"id":"b"}]},{"items":[]},{"items":[{"id":"c"}]}]}}`, []string{"a", "c"}},
+		"many":      {`{"triage":{"quick_ref":{"top_picks":[{"id":"1"},{"id":"2"},{"id":"3"},{"id":"4"},{"id":"5"},{"id":"6"}]}}}`, []string{"1", "2", "3", "4", "5"}},
+		"insights":  {`{"insights":{"Bottlenecks":[]}}`, nil},
+		"invalid":   {`not json`, nil},
+	}
+	for name, tc := range cases {
+		if got := journalPicks([]byte(tc.payload)); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%s: picks = %v, want %v", name, got, tc.want)
+		}
+	}
+}
@@ -3,15 +3,17 @@ package main
 import (
 	"bufio"
 	"bytes"
+	"cmp"
 	"context"
 	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
 	"io"
-	"net/http"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -25,28 +27,86 @@ import (
 	"github.com/Dicklesworthstone/beads_viewer/pkg/drift"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/export"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/hooks"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/i18n"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/journal"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/labels"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/metrics"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/offline"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/plugins"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/policy"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/provenance"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/recipe"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/search"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/tracing"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/ui"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/updater"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/usage"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/version"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/workspace"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/muesli/termenv"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 )
 
 func main() {
+	// Windows consoles only interpret ANSI escapes once asked to; consoles
+	// too old for that get the colorless profile from termenv
+	termenv.EnableVirtualTerminalProcessing(termenv.NewOutput(os.Stdout))
+
+	// The locale applies to the TUI and to Markdown reports alike
+	if err := i18n.Init(os.Getenv); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v; using English\n", err)
+	}
+
+	// Subcommands come before the flag set
+	if len(os.Args) > 1 {
+		if slices.Contains(standaloneCommands, os.Args[1]) {
+			recordUsage(os.Args[1], nil)
+		}
+		switch os.Args[1] {
+		case "new":
+			os.Exit(runNew(os.Args[2:], os.Stdout, os.Stderr))
+		case "track":
+			os.Exit(runTrack(os.Args[2:], os.Stdout, os.Stderr))
+		case "claim":
+			os.Exit(runClaim(os.Args[2:], os.Stdout, os.Stderr))
+		case "bench":
+			os.Exit(runBench(os.Args[2:], os.Stdout, os.Stderr))
+		case "doctor":
+			os.Exit(runDoctor(os.Args[2:], os.Stdout, os.Stderr))
+		case "merge-driver":
+			os.Exit(runMergeDriver(os.Args[2:], os.Stdout, os.Stderr))
+		case "verify":
+			os.Exit(runVerify(os.Args[2:], os.Stdout, os.Stderr))
+		case "stats":
+			os.Exit(runStats(os.Args[2:], os.Stdout, os.Stderr))
+		case "__complete":
+			os.Exit(runCompleteData(os.Args[2:], os.Stdout))
+		}
+	}
+
 	help := flag.Bool("help", false, "Show help")
 	versionFlag := flag.Bool("version", false, "Show version")
 	// Update flags (bv-182)
 	updateFlag := flag.Bool("update", false, "Update bv to the latest version")
 	checkUpdateFlag := flag.Bool("check-update", false, "Check if a new version is available")
 	rollbackFlag := flag.Bool("rollback", false, "Rollback to the previous version (from backup)")
-	yesFlag := flag.Bool("yes", false, "Skip confirmation prompts (use with --update)")
+	offlineFlag := flag.Bool("offline", false, "Make no network calls: skip update checks, use the local semantic embedder, refuse updates, deploys and publishing (also BV_OFFLINE=1)")
+	updateChannel := flag.String("channel", updater.ChannelStable, "Release channel for --update and --check-update: stable, or beta to include pre-releases")
+	yesFlag := flag.Bool("yes", false, "Skip confirmation prompts (use with --update, --fix-graph)")
+	fixGraph := flag.Bool("fix-graph", false, "Repair dependency data (edges to missing IDs, self-edges, duplicates, optionally inverted edges), backing up the beads file first")
 	exportFile := flag.String("export-md", "", "Export issues to a Markdown file (e.g., report.md)")
+	exportMDGraph := flag.String("export-md-graph", "", "With --export-md: also render the dependency graph as svg or png and embed it")
+	exportCSV := flag.String("export-csv", "", "Export issues with computed metrics (pagerank, betweenness, unblocks, triage score, ETA) to a CSV file; honors --recipe")
+	exportICS := flag.String("export-ics", "", "Export forecast completion dates of open issues and sprints, plus sprint dates, as calendar events to an .ics file; honors --recipe, --forecast-label and --forecast-sprint")
+	exportAnnotatedJSONL := flag.String("export-annotated-jsonl", "", "Write issues back out as JSONL with a computed \"bv\" object (scores, ranks, blocked status, forecast) per line; '-' for stdout")
+	exportTemplate := flag.String("export-template", "", "With --export-md: render with a Go template (status, standup, release-notes, .bv/templates/<name>.md.tmpl, or a file path)")
 	robotHelp := flag.Bool("robot-help", false, "Show AI agent help")
+	robotCapabilities := flag.Bool("robot-capabilities", false, "Output the robot commands, flags, schema version and features available in this bv and project as JSON")
+	robotSchemaFlag := flag.Bool("robot-schema", false, "Output JSON Schemas for robot payloads; name a command (e.g. --robot-schema triage) for just its schema")
 	robotInsights := flag.Bool("robot-insights", false, "Output graph analysis and insights as JSON for AI agents")
 	robotPlan := flag.Bool("robot-plan", false, "Output dependency-respecting execution plan as JSON for AI agents")
 	robotPriority := flag.Bool("robot-priority", false, "Output priority recommendations as JSON for AI agents")
@@ -54,10 +114,15 @@ func main() {
 	robotTriageByTrack := flag.Bool("robot-triage-by-track", false, "Group triage recommendations by execution track (bv-87)")
 	robotTriageByLabel := flag.Bool("robot-triage-by-label", false, "Group triage recommendations by label (bv-87)")
 	robotNext := flag.Bool("robot-next", false, "Output only the top pick recommendation as JSON (minimal triage)")
-	robotDiff := flag.Bool("robot-diff", false, "Output diff as JSON (use with --diff-since)")
+	robotSummary := flag.Bool("robot-summary", false, "Output a prompt-sized digest (counts, top 3 picks, top risk, trend; under 1500 characters) as JSON")
+	robotMyQueue := flag.Bool("robot-my-queue", false, "Output a personal worklist (in progress, next up, upcoming unblocks) for --assignee as JSON")
+	robotPartition := flag.Bool("robot-partition", false, "Output the actionable frontier split into --agents disjoint, dependency-consistent work bundles as JSON")
+	assignee := flag.String("assignee", "me", "Assignee for --robot-my-queue and the TUI my-work view ('me' = $BD_ACTOR, then $USER)")
+	robotDiff := flag.Bool("robot-diff", false, "Output diff as JSON (use with --diff-since or --diff-from)")
 	robotRecipes := flag.Bool("robot-recipes", false, "Output available recipes as JSON for AI agents")
 	robotLabelHealth := flag.Bool("robot-label-health", false, "Output label health metrics as JSON for AI agents")
 	robotLabelFlow := flag.Bool("robot-label-flow", false, "Output cross-label dependency flow as JSON for AI agents")
+	labelFlowFormat := flag.String("format", "json", "Output format for --robot-label-flow: json, sankey (Mermaid sankey-beta), or html (standalone Sankey page); for --robot-layers: json, markdown or mermaid")
 	robotLabelAttention := flag.Bool("robot-label-attention", false, "Output attention-ranked labels as JSON for AI agents")
 	attentionLimit := flag.Int("attention-limit", 5, "Limit number of labels in --robot-label-attention output")
 	robotAlerts := flag.Bool("robot-alerts", false, "Output alerts (drift + proactive) as JSON for AI agents")
@@ -66,15 +131,25 @@ func main() {
 	suggestType := flag.String("suggest-type", "", "Filter suggestions by type: duplicate, dependency, label, cycle")
 	suggestConfidence := flag.Float64("suggest-confidence", 0.0, "Minimum confidence for suggestions (0.0-1.0)")
 	suggestBead := flag.String("suggest-bead", "", "Filter suggestions for specific bead ID")
+	robotSuggestDeps := flag.Bool("robot-suggest-deps", false, "Suggest missing blocks/related links between semantically similar open issues as JSON")
+	robotSuggestLabels := flag.Bool("robot-suggest-labels", false, "Predict labels for unlabeled open issues from similar labeled ones as JSON")
+	robotDuplicates := flag.Bool("robot-duplicates", false, "Output near-duplicate issue pairs (title, embedding and label similarity) as JSON")
+	robotPolicies := flag.Bool("robot-policies", false, "Evaluate auto-triage policies from .bv/policies.yaml and output matches as JSON (dry run unless --apply)")
+	applyPolicies := flag.Bool("apply", false, "With --robot-policies, write the planned changes to the beads file")
+	duplicateThreshold := flag.Float64("duplicate-threshold", 0, "Minimum near-duplicate score 0-1 (default: .bv/search.yaml duplicates.threshold, else 0.75)")
 	// Graph export (bv-136)
-	robotGraph := flag.Bool("robot-graph", false, "Output dependency graph as JSON/DOT/Mermaid for AI agents")
-	graphFormat := flag.String("graph-format", "json", "Graph output format: json, dot, mermaid")
+	robotGraph := flag.Bool("robot-graph", false, "Output dependency graph as JSON/DOT/Mermaid/GraphML/GEXF for AI agents")
+	graphFormat := flag.String("graph-format", "json", "Graph output format: json, dot, mermaid, graphml, gexf")
 	graphRoot := flag.String("graph-root", "", "Subgraph from specific root issue ID")
 	graphDepth := flag.Int("graph-depth", 0, "Max depth for subgraph (0 = unlimited)")
+	graphCluster := flag.String("graph-cluster", "", "Mermaid subgraph clusters: label or track")
+	linkTemplate := flag.String("link-template", "", "Mermaid node link URL; {id} is replaced with the issue ID")
+	graphMaxNodes := flag.Int("graph-max-nodes", 0, "Split Mermaid output into diagrams of at most N nodes (0 = 100, -1 = never)")
 	// Graph snapshot export (bv-94)
 	exportGraph := flag.String("export-graph", "", "Export graph: .html for interactive, .png/.svg for static (auto-names if empty)")
 	graphPreset := flag.String("graph-preset", "compact", "Graph layout preset: compact (default) or roomy")
 	graphTitle := flag.String("graph-title", "", "Title for graph export (default: project name)")
+	graphStyle := flag.String("graph-style", "grid", "Static graph layout for --export-graph: grid or layered")
 	// Robot output filters (bv-84)
 	robotMinConf := flag.Float64("robot-min-confidence", 0.0, "Filter robot outputs by minimum confidence (0.0-1.0)")
 	robotMaxResults := flag.Int("robot-max-results", 0, "Limit robot output count (0 = use defaults)")
@@ -94,17 +169,49 @@ func main() {
 	searchPreset := flag.String("search-preset", "", "Hybrid preset name (default: BV_SEARCH_PRESET or default)")
 	searchWeights := flag.String("search-weights", "", "Hybrid weights JSON (overrides preset; keys: text,pagerank,status,impact,priority,recency)")
 	diffSince := flag.String("diff-since", "", "Show changes since historical point (commit SHA, branch, tag, or date)")
+	diffFrom := flag.String("diff-from", "", "Show changes from a revision, REV:PATH, JSONL file or directory (to --diff-to, or the current data)")
+	diffTo := flag.String("diff-to", "", "End point of --diff-from: a revision, REV:PATH, JSONL file or directory")
+	robotReplay := flag.Bool("robot-replay", false, "Output weekly open/actionable/blocked/closed/cycle counts across git history as JSON (use with --replay-from/--replay-to)")
+	replayFrom := flag.String("replay-from", "", "First revision of --robot-replay (default: the first commit of the beads file)")
+	replayTo := flag.String("replay-to", "HEAD", "Last revision of --robot-replay")
+	bisectMetric := flag.String("bisect-metric", "", "Find the first commit where a metric (cycles, blocked, open, actionable, closed, edges, total) exceeded --bisect-threshold")
+	bisectThreshold := flag.Int("bisect-threshold", 0, "Value --bisect-metric must exceed (default 0: the first cycle, blocked issue, ...)")
+	bisectFrom := flag.String("from", "", "First revision of --bisect-metric (default: the first commit of the beads file)")
+	bisectTo := flag.String("to", "HEAD", "Last revision of --bisect-metric")
+	robotBisect := flag.Bool("robot-bisect", false, "Output --bisect-metric results as JSON")
+	robotPRImpact := flag.Bool("robot-pr-impact", false, "Output PR impact analysis (diff vs --base, metric movers, blocked/unblocked, new cycles) as JSON")
+	prBase := flag.String("base", "origin/main", "Base ref for --robot-pr-impact")
 	asOf := flag.String("as-of", "", "View state at point in time (commit SHA, branch, tag, or date)")
+	streamOutput := flag.Bool("stream", false, "With --robot-insights or --robot-history: emit newline-delimited JSON records instead of one document")
+	pageSize := flag.Int("page-size", 0, "Entries per page for --robot-insights full_stats (default 200) and --robot-history histories (default all)")
+	pageCursor := flag.String("cursor", "", "Resume a paginated robot output at the page.next_cursor of the previous call")
+	fieldsSpec := flag.String("fields", "", "Comma-separated JSON paths to keep in robot output (e.g. triage.quick_ref,triage.recommendations.id)")
+	maxBytes := flag.Int("max-bytes", 0, "Fit robot output in this many bytes by dropping hints, explanations and full maps, then shortening lists (0 = no limit)")
+	deterministic := flag.Bool("deterministic", false, "Reproducible robot output: stable ordering, data-derived timestamps, zeroed timings (or BV_DETERMINISTIC=1)")
+	provenanceFlag := flag.Bool("provenance", false, "Embed provenance (data hash, git commit, bv version, flags) in robot output and write it next to exports (or BV_PROVENANCE=1)")
+	signKey := flag.String("sign", "", "Sign exports, and robot output with --signature, using this SSH or minisign private key (or BV_SIGN_KEY); implies --provenance")
+	signaturePath := flag.String("signature", "", "With --sign: write the detached signature of the robot output to this file")
 	forceFullAnalysis := flag.Bool("force-full-analysis", false, "Compute all metrics regardless of graph size (may be slow for large graphs)")
 	profileStartup := flag.Bool("profile-startup", false, "Output detailed startup timing profile for diagnostics")
 	profileJSON := flag.Bool("profile-json", false, "Output profile in JSON format (use with --profile-startup)")
-	noHooks := flag.Bool("no-hooks", false, "Skip running hooks during export")
+	noHooks := flag.Bool("no-hooks", false, "Skip running hooks from .bv/hooks.yaml")
+	journalFlag := flag.Bool("journal", false, "Append this robot invocation (command, data hash, top recommendation, filters) to .bv/journal.jsonl (or set BV_JOURNAL=1)")
+	robotValidate := flag.Bool("robot-validate", false, "Output data validation as JSON: skipped JSONL lines, and labels that .bv/labels.yaml aliases or does not know")
+	robotJournalFlag := flag.Bool("robot-journal", false, "Output a summary of journaled recommendations and which were acted on as JSON")
+	journalDays := flag.Int("journal-days", 0, "Only summarize the last N days of the journal with --robot-journal (0 = all)")
+	noPlugins := flag.Bool("no-plugins", false, "Skip analyzer plugins in .bv/plugins/ (--robot-insights, --robot-triage)")
 	workspaceConfig := flag.String("workspace", "", "Load issues from workspace config file (.bv/workspace.yaml)")
 	repoFilter := flag.String("repo", "", "Filter issues by repository prefix (e.g., 'api-' or 'api')")
 	saveBaseline := flag.String("save-baseline", "", "Save current metrics as baseline with optional description")
 	baselineInfo := flag.Bool("baseline-info", false, "Show information about the current baseline")
 	checkDrift := flag.Bool("check-drift", false, "Check for drift from baseline (exit codes: 0=OK, 1=critical, 2=warning)")
 	robotDriftCheck := flag.Bool("robot-drift", false, "Output drift check as JSON (use with --check-drift)")
+	statusline := flag.Bool("statusline", false, "Print a one-line summary (open/blocked/ready, drift, top pick) for tmux status bars and shell prompts")
+	statuslineFormat := flag.String("statusline-format", "plain", "Colors for --statusline: plain, ansi or tmux")
+	statuslineWidth := flag.Int("statusline-width", 40, "Character budget for --statusline (0 = unlimited)")
+	digest := flag.String("digest", "", "Print a digest of changes, new alerts, forecast slips and velocity since the last digest: daily or weekly (for cron)")
+	digestFormat := flag.String("digest-format", "markdown", "Format for --digest: markdown or json")
+	ciReport := flag.String("ci-report", "", "Emit a CI-native report (github: workflow annotations, job summary, step outputs)")
 	robotHistory := flag.Bool("robot-history", false, "Output bead-to-commit correlations as JSON")
 	beadHistory := flag.String("bead-history", "", "Show history for specific bead ID")
 	historySince := flag.String("history-since", "", "Limit history to commits after this date/ref (e.g., '30 days ago', '2024-01-01')")
@@ -125,8 +232,23 @@ func main() {
 	fileBeadsLimit := flag.Int("file-beads-limit", 20, "Max closed beads to show (use with --robot-file-beads)")
 	fileHotspots := flag.Bool("robot-file-hotspots", false, "Output files touched by most beads as JSON")
 	hotspotsLimit := flag.Int("hotspots-limit", 10, "Max hotspots to show (use with --robot-file-hotspots)")
+	// Code map (file-path to bead heatmap)
+	robotCodeMap := flag.Bool("robot-code-map", false, "Output per-directory/file bead heatmap (beads, churn, open owners) as JSON")
+	codeMapPath := flag.String("code-map-path", "", "Restrict --robot-code-map to a path prefix")
+	codeMapDepth := flag.Int("code-map-depth", 2, "Max directory depth for --robot-code-map (0 = all levels)")
+	codeMapLimit := flag.Int("code-map-limit", 50, "Max directories/files in --robot-code-map (0 = all)")
+	// Bus factor (ownership concentration per label and subsystem)
+	robotBusFactor := flag.Bool("robot-bus-factor", false, "Output per-label and per-subsystem commit ownership, flagging one-person areas with key open work, as JSON")
+	busFactorThreshold := flag.Float64("bus-factor-threshold", 0.8, "Top author commit share that flags an area in --robot-bus-factor (0-1)")
 	// Impact analysis flag (bv-19pq)
 	robotImpact := flag.String("robot-impact", "", "Analyze impact of modifying files (comma-separated paths)")
+	// Blame-style annotation: which beads motivated these lines?
+	whySpec := flag.String("why", "", "Explain which beads motivated code via git blame: <path>[:<line>[-<line>]]")
+	robotWhy := flag.Bool("robot-why", false, "Output --why results as JSON")
+	// Commit trailer suggestions
+	robotSuggestTrailers := flag.Bool("robot-suggest-trailers", false, "Suggest Bead: trailers for staged/changed files as JSON")
+	trailersDiff := flag.String("trailers-diff", "", "Git diff spec for --robot-suggest-trailers (default: staged, else unstaged changes)")
+	trailersLimit := flag.Int("trailers-limit", 5, "Max trailer suggestions (use with --robot-suggest-trailers)")
 	// Co-change detection flag (bv-7a2f)
 	robotFileRelations := flag.String("robot-file-relations", "", "Output files that frequently co-change with the given file path")
 	relationsThreshold := flag.Float64("relations-threshold", 0.5, "Minimum correlation threshold (0.0-1.0) for related files")
@@ -138,6 +260,10 @@ func main() {
 	relatedIncludeClosed := flag.Bool("related-include-closed", false, "Include closed beads in related work results")
 	// Blocker chain analysis flag (bv-nlo0)
 	robotBlockerChain := flag.String("robot-blocker-chain", "", "Output full blocker chain analysis for issue ID as JSON")
+	// Per-issue explanation: scores, recommendation, blockers, forecast, history
+	robotExplain := flag.String("robot-explain", "", "Output everything bv knows about one issue (scores with percentiles, why (not) recommended, blockers, unblocks, forecast, history, similar issues) as JSON")
+	robotLayers := flag.Bool("robot-layers", false, "Output open issues as topological layers (a phased roadmap) with per-layer counts and durations as JSON; --format markdown|mermaid renders it")
+	robotTree := flag.String("robot-tree", "", "Output the full upstream blocker tree and downstream unblock tree of an issue, with estimated days per branch, as JSON")
 	// Impact network graph flag (bv-48kr)
 	robotImpactNetwork := flag.String("robot-impact-network", "", "Output bead impact network as JSON (empty for full, or bead ID for subnetwork)")
 	networkDepth := flag.Int("network-depth", 2, "Depth of subnetwork when querying specific bead (1-3)")
@@ -153,8 +279,17 @@ func main() {
 	forecastAgents := flag.Int("forecast-agents", 1, "Number of parallel agents for capacity calculation")
 	// Capacity simulation flags (bv-160)
 	robotCapacity := flag.Bool("robot-capacity", false, "Output capacity simulation and completion projection as JSON")
-	capacityAgents := flag.Int("agents", 1, "Number of parallel agents for capacity simulation")
+	capacityAgents := flag.Int("agents", 1, "Number of parallel agents for capacity simulation and --robot-partition")
 	capacityLabel := flag.String("capacity-label", "", "Filter capacity simulation by label")
+	agentProfilesPath := flag.String("agent-profiles", "", "Agent profile YAML (skills, hours/day, WIP) for a probabilistic --robot-capacity simulation")
+	capacityRuns := flag.Int("capacity-runs", 500, "Monte Carlo runs for --robot-capacity --agent-profiles")
+	robotGoals := flag.Bool("robot-goals", false, "Output the probability of closing each goal in .bv/goals.yaml by its target date as JSON")
+	robotEstimates := flag.Bool("robot-estimates", false, "Output estimate coverage, per-label totals and largest unestimated issues as JSON")
+	robotQueues := flag.Bool("robot-queues", false, "Output queueing-theory bottleneck analysis (utilization, expected wait, constraint) as JSON")
+	queueBy := flag.String("queue-by", "label", "Queue grouping for --robot-queues: label or track")
+	queueWindow := flag.Int("queue-window", 90, "History window in days for --robot-queues arrival and service rates")
+	robotReview := flag.Bool("robot-review", false, "Output the in_review queue, review latency and per-label review stats as JSON")
+	reviewWindow := flag.Int("review-window", 90, "History window in days for --robot-review latency")
 	// Burndown flags (bv-159)
 	robotBurndown := flag.String("robot-burndown", "", "Output burndown data for sprint ID, or 'current' for active sprint")
 	// Action script emission flags (bv-89)
@@ -166,22 +301,110 @@ func main() {
 	feedbackIgnore := flag.String("feedback-ignore", "", "Record ignore feedback for issue ID (tunes recommendation weights)")
 	feedbackReset := flag.Bool("feedback-reset", false, "Reset all feedback data to defaults")
 	feedbackShow := flag.Bool("feedback-show", false, "Show current feedback status and weight adjustments")
+	feedbackAs := flag.String("as", "me", "Whose feedback profile to record into and rank with ('me' = $BD_ACTOR, then $USER; 'team' = the shared profile)")
+	feedbackMerge := flag.String("feedback-merge", analysis.FeedbackMergeUser, "How your feedback profile combines with the team's: user (yours, team defaults until you have feedback), team, or blend")
+	feedbackExport := flag.String("feedback-export", "", "Write your feedback profile to a file (- for stdout) to move it to another machine")
+	feedbackImport := flag.String("feedback-import", "", "Load an exported feedback profile into yours (replaces it; with --feedback-merge=blend, combines them)")
 	// Priority brief export (bv-96)
 	priorityBrief := flag.String("priority-brief", "", "Export priority brief to Markdown file (e.g., brief.md)")
+	// Wiki publishing
+	publishTargets := flag.String("publish", "", "Update the Confluence/Notion pages configured in .bv/publish.yaml with the Markdown report or priority brief (target names, comma-separated, or 'all')")
 	// Agent brief bundle (bv-131)
-	agentBrief := flag.String("agent-brief", "", "Export agent brief bundle to directory (includes triage.json, insights.json, brief.md, helpers.md)")
+	agentBrief := flag.String("agent-brief", "", "Export agent brief bundle to directory (triage.json, insights.json, brief.md, plan.json, graph.dot, helpers.md, capabilities.json)")
+	agentBriefRefresh := flag.Bool("refresh", false, "With --agent-brief: only regenerate files whose data hash or bv version changed")
 	// Static pages export flags (bv-73f)
 	exportPages := flag.String("export-pages", "", "Export static site to directory (e.g., ./bv-pages)")
 	pagesTitle := flag.String("pages-title", "", "Custom title for static site")
 	pagesIncludeClosed := flag.Bool("pages-include-closed", true, "Include closed issues in export (default: true)")
 	pagesIncludeHistory := flag.Bool("pages-include-history", true, "Include git history for time-travel (default: true)")
+	pagesLabels := flag.String("labels", "", "With --export-pages: only export issues with one of these labels (comma-separated); dependencies on others become placeholders")
+	pagesExcludeLabels := flag.String("exclude-label", "", "With --export-pages: leave out issues with any of these labels (comma-separated), even if matched by --labels")
 	previewPages := flag.String("preview-pages", "", "Preview existing static site bundle")
+	servePages := flag.String("serve-pages", "", "Serve a static site bundle on an internal host (no browser)")
+	serveBind := flag.String("serve-bind", "127.0.0.1", "Bind address for --serve-pages (0.0.0.0 for all interfaces)")
+	servePort := flag.Int("serve-port", export.DefaultPreviewPort, "Port for --serve-pages")
+	serveUser := flag.String("serve-user", "", "Require basic auth with this user for --serve-pages (password from BV_SERVE_PASSWORD)")
+	serveGzip := flag.Bool("serve-gzip", true, "Gzip responses from --serve-pages")
+	metricsListen := flag.String("metrics-listen", "", "Serve Prometheus metrics on this address (e.g. :9464)")
+	metricsTextfile := flag.String("metrics-textfile", "", "Write Prometheus metrics to this file for node_exporter's textfile collector (- for stdout)")
 	pagesWizard := flag.Bool("pages", false, "Launch interactive Pages deployment wizard")
+	pagesPush := flag.Bool("pages-push", false, "With --export-pages: commit changed files to the Pages branch and push")
+	pagesRemote := flag.String("pages-remote", "origin", "Remote name or URL for --pages-push")
+	pagesBranch := flag.String("pages-branch", "", "Branch for --pages-push (default gh-pages) or --pages-deploy gitlab (default main)")
+	pagesDeploy := flag.String("pages-deploy", "", "With --export-pages: deploy non-interactively to gitlab, s3, netlify or cloudflare")
+	pagesProject := flag.String("pages-project", "", "GitLab group/project, Netlify site or Cloudflare project for --pages-deploy")
+	pagesBucket := flag.String("pages-bucket", "", "S3 location (s3://bucket/prefix) for --pages-deploy s3")
+	pagesCloudFront := flag.String("pages-cloudfront", "", "CloudFront distribution ID to invalidate for --pages-deploy s3")
+	keymapCheck := flag.Bool("keymap-check", false, "Show the active TUI key bindings and report keymap conflicts")
+	themeName := flag.String("theme", "", "TUI theme: auto, dark, light, solarized, high-contrast or a custom theme (default: BV_THEME or theme.yaml)")
+	noColor := flag.Bool("no-color", false, "Disable colors and text styling (also: NO_COLOR)")
+	asciiUI := flag.Bool("ascii", false, "ASCII-only TUI: ASCII stand-ins for borders, bars, arrows and emoji (default: on for non-UTF-8 locales and the Linux console)")
+	plainUI := flag.Bool("plain", false, "Screen-reader friendly TUI: issue rows as plain sentences, no box-drawing characters (also: BV_PLAIN)")
 	// Debug rendering flag (for diagnosing TUI issues)
 	debugRender := flag.String("debug-render", "", "Render a view and output to file (views: insights, board)")
 	debugWidth := flag.Int("debug-width", 180, "Width for debug render")
 	debugHeight := flag.Int("debug-height", 50, "Height for debug render")
+
+	// `bv completion` needs the flag set above, so it runs after the other
+	// subcommands
+	if len(os.Args) > 1 && os.Args[1] == "completion" {
+		os.Exit(runCompletion(os.Args[2:], flag.CommandLine, os.Stdout, os.Stderr))
+	}
+	// So do `bv help` and the commands that expand to legacy flags
+	if len(os.Args) > 1 && os.Args[1] == "help" {
+		os.Exit(runHelp(os.Args[2:], flag.CommandLine, os.Stdout, os.Stderr))
+	}
+	usageCommand := "bv"
+	if len(os.Args) > 1 {
+		expanded, cmd, name, err := expandCommand(os.Args[1:])
+		if errors.Is(err, errCommandHelp) {
+			printCommandHelp(os.Stdout, flag.CommandLine, cmd, name)
+			os.Exit(exitOK)
+		}
+		if err != nil {
+			fatalf(exitUsage, "Error: %v\nUsage: %s", err, commandUsage(cmd, name))
+		}
+		if cmd != nil {
+			usageCommand = name
+		}
+		os.Args = append(os.Args[:1], expanded...)
+	}
 	flag.Parse()
+	recordUsage(usageCommand, flag.CommandLine)
+
+	// Offline mode reaches hooks, plugins and git through the environment
+	if *offlineFlag {
+		offline.Enable()
+	}
+	if offline.Enabled() {
+		networkFlags := []struct {
+			set  bool
+			name string
+		}{
+			{*updateFlag, "--update"},
+			{*checkUpdateFlag, "--check-update"},
+			{*pagesDeploy != "", "--pages-deploy"},
+			{*pagesWizard, "--pages"},
+			{*publishTargets != "", "--publish"},
+		}
+		for _, f := range networkFlags {
+			if f.set {
+				fatalf(exitUsage, "Error: %v", offline.Check(f.name))
+			}
+		}
+	}
+
+	// OpenTelemetry tracing is opt-in through the environment (see pkg/tracing);
+	// the local usage log gets phase timings from the same spans
+	var localExporters []sdktrace.SpanExporter
+	if path := usage.Path(); usage.Enabled(path) {
+		localExporters = append(localExporters, usage.PhaseExporter{Path: path})
+	}
+	shutdownTracing, err := tracing.Init(context.Background(), version.Version, localExporters...)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: tracing disabled: %v\n", err)
+	}
+	defer func() { _ = shutdownTracing(context.Background()) }()
 
 	// Ensure static export flags are retained even when build tags strip features in some environments.
 	_ = exportPages
@@ -204,10 +427,41 @@ func main() {
 	_ = agentBrief
 
 	envRobot := os.Getenv("BV_ROBOT") == "1"
+	deterministicOutput = *deterministic || deterministicEnv()
+	if robotFields, err = parseFieldPaths(*fieldsSpec); err != nil {
+		fatalf(exitUsage, "Error: invalid --fields: %v", err)
+	}
+	if *maxBytes < 0 {
+		fatalf(exitUsage, "Error: --max-bytes must not be negative")
+	}
+	if *maxBytes > 0 && *streamOutput {
+		fatalf(exitUsage, "Error: --max-bytes fits one document; it can't be combined with --stream (use head -c or --page-size)")
+	}
+	robotMaxBytes = *maxBytes
+	if *streamOutput && (*pageSize != 0 || *pageCursor != "") {
+		fatalf(exitUsage, "Error: --stream emits every record; it can't be combined with --page-size or --cursor")
+	}
+	outputSignKey = cmp.Or(*signKey, os.Getenv(provenance.SignKeyEnvVar))
+	if outputSignKey != "" {
+		if _, err := provenance.DetectKey(outputSignKey); err != nil {
+			fatalf(exitUsage, "Error: --sign: %v", err)
+		}
+	}
+	if *signaturePath != "" {
+		if outputSignKey == "" {
+			fatalf(exitUsage, "Error: --signature requires --sign <key> (or BV_SIGN_KEY)")
+		}
+		if *streamOutput {
+			fatalf(exitUsage, "Error: --signature signs the whole output; it can't be combined with --stream")
+		}
+		robotSigner = &outputSigner{key: outputSignKey, sigPath: *signaturePath}
+	}
 	stdoutIsTTY := term.IsTerminal(int(os.Stdout.Fd()))
 
 	robotMode := envRobot ||
 		*robotHelp ||
+		*robotSchemaFlag ||
+		*robotCapabilities ||
 		*robotInsights ||
 		*robotPlan ||
 		*robotPriority ||
@@ -215,6 +469,13 @@ func main() {
 		*robotTriageByTrack ||
 		*robotTriageByLabel ||
 		*robotNext ||
+		*robotSummary ||
+		*robotMyQueue ||
+		*robotPartition ||
+		*robotJournalFlag ||
+		*robotValidate ||
+		*robotReplay ||
+		*robotBisect ||
 		*robotDiff ||
 		*robotRecipes ||
 		*robotLabelHealth ||
@@ -222,16 +483,27 @@ func main() {
 		*robotLabelAttention ||
 		*robotAlerts ||
 		*robotSuggest ||
+		*robotSuggestDeps ||
+		*robotDuplicates ||
+		*robotSuggestLabels ||
+		*robotPolicies ||
 		*robotGraph ||
 		*robotSearch ||
 		*robotDriftCheck ||
 		*robotHistory ||
 		*robotFileBeads != "" ||
 		*fileHotspots ||
+		*robotCodeMap ||
+		*robotBusFactor ||
 		*robotImpact != "" ||
+		*robotSuggestTrailers ||
+		*robotWhy ||
 		*robotFileRelations != "" ||
 		*robotRelatedWork != "" ||
 		*robotBlockerChain != "" ||
+		*robotExplain != "" ||
+		*robotTree != "" ||
+		*robotLayers ||
 		*robotImpactNetwork != "" ||
 		*robotCausality != "" ||
 		*robotSprintList ||
@@ -241,9 +513,17 @@ func main() {
 		*robotByLabel != "" ||
 		*robotByAssignee != "" ||
 		*robotCapacity ||
+		*robotGoals ||
+		*robotEstimates ||
+		*robotQueues ||
+		*robotReview ||
+		*robotPRImpact ||
+		*digest != "" ||
+		*exportAnnotatedJSONL == "-" ||
 		// When stdout is non-TTY, --diff-since auto-enables JSON output. Mark this
 		// as robot mode early so parsers keep stdout JSON clean.
-		(*diffSince != "" && !stdoutIsTTY)
+		((*diffSince != "" || *diffFrom != "") && !stdoutIsTTY) ||
+		(*bisectMetric != "" && !stdoutIsTTY)
 
 	// Mark robot mode for downstream packages (e.g., parsers) to keep stdout JSON clean.
 	if robotMode && !envRobot {
@@ -258,6 +538,15 @@ func main() {
 
 	if *help {
 		fmt.Println("Usage: bv [options]")
+		fmt.Println("       bv <command> [options]   (bv help lists the commands)")
+		fmt.Println("       bv new [--template name] [--append] <title>")
+		fmt.Println("       bv track start <id> | stop | status")
+		fmt.Println("       bv claim <id> [--ttl 2h] | release <id> | list")
+		fmt.Println("       bv completion bash|zsh|fish")
+		fmt.Println("       bv doctor [--json]")
+		fmt.Println("       bv merge-driver install | resolve [file]")
+		fmt.Println("       bv verify <file|dir> --key <public key>")
+		fmt.Println("       bv stats [--json] | enable | disable")
 		fmt.Println("\nA TUI viewer for beads issue tracker.")
 		flag.PrintDefaults()
 		os.Exit(0)
@@ -268,6 +557,8 @@ func main() {
 		fmt.Println("====================================")
 		fmt.Println("This tool provides structural analysis of the issue tracker graph (DAG).")
 		fmt.Println("Use these commands to understand project state without parsing raw JSONL.")
+		fmt.Println("Each --robot-* command also has a subcommand alias (bv triage, bv sprint show <id>, ...);")
+		fmt.Println("`bv help` lists them.")
 		fmt.Println("")
 		fmt.Println("Commands:")
 		fmt.Println("  --robot-plan")
@@ -287,6 +578,8 @@ func main() {
 		fmt.Println("      - CriticalPathScore: Heuristic for depth. High score = Blocking a long chain of work.")
 		fmt.Println("      - Hubs/Authorities: HITS algorithm scores for dependency relationships.")
 		fmt.Println("      - Cycles: Lists of circular dependencies (unhealthy state).")
+		fmt.Println("      - Clusters: Workstreams found by Louvain community detection: cluster id")
+		fmt.Println("        per issue (assignments) and the edges where clusters wait on each other.")
 		fmt.Println("")
 		fmt.Println("  --robot-priority")
 		fmt.Println("      Outputs priority recommendations as JSON.")
@@ -313,6 +606,69 @@ func main() {
 		fmt.Println("      Minimal triage: returns only the single top recommendation.")
 		fmt.Println("      Output includes: id, title, score, reasons, claim_command, show_command")
 		fmt.Println("      Use when you just need to know \"what should I work on next?\"")
+		fmt.Println("      Skips picks someone else has leased with `bv claim` (see skipped_claimed).")
+		fmt.Println("")
+		fmt.Println("  bv claim <id> [--ttl 2h] | release <id> | list")
+		fmt.Println("      Leases an issue in .bv/claims.jsonl before its status changes, so concurrent")
+		fmt.Println("      agents don't pick it too. --robot-triage and --robot-plan mark claimed items")
+		fmt.Println("      with claimed_by/claimed_until and list active leases under claims.")
+		fmt.Println("")
+		fmt.Println("  --robot-summary")
+		fmt.Println("      A briefing for prompts: digest is plain text under 1500 characters with the issue")
+		fmt.Println("      counts, the top 3 picks with one reason each, the top risk (cycles, then alerts,")
+		fmt.Println("      then the biggest blocker, then staleness) and the closing trend. The same data")
+		fmt.Println("      always gives the same digest; counts, top_picks, top_risk and trend hold the parts.")
+		fmt.Println("")
+		fmt.Println("  --robot-my-queue [--assignee NAME]")
+		fmt.Println("      Personal daily worklist for one assignee (default: me = $BD_ACTOR, then $USER).")
+		fmt.Println("      Items run in progress, then next (actionable work that is yours or matches")
+		fmt.Println("      labels from your history), then upcoming (blocked only by workable issues),")
+		fmt.Println("      each ordered by triage score. Unassigned picks include a claim_command.")
+		fmt.Println("")
+		fmt.Println("  --robot-replay [--replay-from=REV] [--replay-to=REV]")
+		fmt.Println("      Replays the beads file through git history, one frame per week (the week's")
+		fmt.Println("      last commit), from --replay-from (default: first commit) to --replay-to (HEAD).")
+		fmt.Println("      Key fields: frames[] {revision, timestamp, total, open, actionable, blocked,")
+		fmt.Println("                  closed, edges, cycles}, skipped[] (revisions without beads data).")
+		fmt.Println("      Example: bv --robot-replay --replay-from=v1.0 | jq '.frames[] | [.timestamp, .open]'")
+		fmt.Println("")
+		fmt.Println("  --robot-bisect --bisect-metric=METRIC [--bisect-threshold=N] [--from=REV] [--to=REV]")
+		fmt.Println("      Binary-searches the beads file's git history for the first commit where METRIC")
+		fmt.Println("      (cycles, blocked, open, actionable, closed, edges, total) exceeds N (default 0).")
+		fmt.Println("      Key fields: found, at_start (already exceeded at --from), commit, before,")
+		fmt.Println("                  changes (diff of the commit), culprit_edges[] (blocking deps it added,")
+		fmt.Println("                  in_cycle first), tested/candidates (revisions loaded / in range).")
+		fmt.Println("      Example: bv --robot-bisect --bisect-metric=cycles --from=v1.0 | jq '.culprit_edges'")
+		fmt.Println("")
+		fmt.Println("  --robot-validate")
+		fmt.Println("      Checks the beads data: JSONL lines the loader skips, and labels against")
+		fmt.Println("      .bv/labels.yaml (aliases such as ui: frontend, and the label vocabulary).")
+		fmt.Println("      Key fields:")
+		fmt.Println("        - valid: No skipped lines and no unknown labels")
+		fmt.Println("        - line_errors[]: line, kind, message")
+		fmt.Println("        - labels.unknown[]: Labels outside the vocabulary, with their issues")
+		fmt.Println("        - labels.aliased[]: Old names the loader rewrites, with their canonical name")
+		fmt.Println("")
+		fmt.Println("  --journal (or BV_JOURNAL=1)")
+		fmt.Println("      Appends one line per robot invocation to .bv/journal.jsonl: command, filters,")
+		fmt.Println("      data_hash, actor and the recommended IDs (top first).")
+		fmt.Println("")
+		fmt.Println("  --robot-journal [--journal-days=N]")
+		fmt.Println("      Replays the journal against the current issues. A top recommendation counts as")
+		fmt.Println("      acted on when its issue was started or closed after it was first recommended.")
+		fmt.Println("      Key fields:")
+		fmt.Println("        - items[]: times recommended, outcome (closed|in_progress|changed|pending|missing)")
+		fmt.Println("        - follow_through_pct: Acted-on share of distinct top recommendations")
+		fmt.Println("")
+		fmt.Println("  --robot-partition [--agents=N]")
+		fmt.Println("      Splits open, unclaimed actionable issues into N disjoint bundles, one per agent.")
+		fmt.Println("      Items that unblock the same downstream work stay together; a group is only")
+		fmt.Println("      split at its weakest coupling when there are more agents than groups.")
+		fmt.Println("      Key fields:")
+		fmt.Println("        - bundles[].items: The agent's queue, highest triage score first")
+		fmt.Println("        - bundles[].claim_commands: bd commands claiming the queue as agent-N")
+		fmt.Println("        - shared_downstream: Blocked issues that wait on more than one bundle")
+		fmt.Println("        - cross_bundle_edges: 0 when the bundles never meet")
 		fmt.Println("")
 		fmt.Println("  --search \"query\" [--robot-search]")
 		fmt.Println("      Semantic vector search over issue titles/descriptions.")
@@ -370,6 +726,30 @@ func main() {
 		fmt.Println("      - --hotspots-limit <n>: Max hotspots to show (default: 10)")
 		fmt.Println("      Example: bv --robot-file-hotspots")
 		fmt.Println("")
+		fmt.Println("  --robot-code-map")
+		fmt.Println("      Inverts correlation data into a code-area heatmap.")
+		fmt.Println("      Answers: 'Which open bead does this part of the code belong to?'")
+		fmt.Println("      Key sections:")
+		fmt.Println("      - directories / files: {path, total_beads, open_count, commits, churn, open_beads, bead_ids}")
+		fmt.Println("      - open_beads: Most associated open beads (commits, churn, share of area commits)")
+		fmt.Println("      Flags:")
+		fmt.Println("      - --code-map-path <prefix>: Only include files under this path")
+		fmt.Println("      - --code-map-depth <n>: Max directory depth (default: 2, 0 = all)")
+		fmt.Println("      - --code-map-limit <n>: Max entries per section (default: 50)")
+		fmt.Println("      Example: bv --robot-code-map --code-map-path pkg/ui")
+		fmt.Println("")
+		fmt.Println("  --robot-bus-factor")
+		fmt.Println("      Measures how concentrated commit authorship is per label and per subsystem.")
+		fmt.Println("      Answers: 'Which areas would stall if one person left, and what open work sits there?'")
+		fmt.Println("      Key sections:")
+		fmt.Println("      - areas: {kind, name, commits, authors, top_share, bus_factor, key_open_issues, risk, at_risk}")
+		fmt.Println("      - bus_factor: Fewest authors who made more than half of the area's commits")
+		fmt.Println("      - key_open_issues: Open issues in the area with top-quartile PageRank")
+		fmt.Println("      - at_risk: top_share >= threshold, 3+ commits and key open issues")
+		fmt.Println("      Flags:")
+		fmt.Println("      - --bus-factor-threshold <share>: Top author share that flags an area (default: 0.8)")
+		fmt.Println("      Example: bv --robot-bus-factor | jq '.areas[] | select(.at_risk)'")
+		fmt.Println("")
 		fmt.Println("  --robot-impact <files>")
 		fmt.Println("      Analyzes impact of modifying files - what beads might be affected?")
 		fmt.Println("      Critical for agents: check before making changes to avoid conflicts.")
@@ -382,6 +762,63 @@ func main() {
 		fmt.Println("      Example: bv --robot-impact pkg/auth/token.go")
 		fmt.Println("      Example: bv --robot-impact pkg/auth/token.go,pkg/auth/session.go")
 		fmt.Println("")
+		fmt.Println("  --why <path>[:<start>[-<end>]] [--robot-why]")
+		fmt.Println("      Code archaeology: git blame + commit→bead index for a line range.")
+		fmt.Println("      Answers: 'Which issues motivated this code?'")
+		fmt.Println("      Key sections (with --robot-why or BV_ROBOT=1):")
+		fmt.Println("      - beads: {bead_id, title, status, lines, commits, method, confidence, link}")
+		fmt.Println("      - commits: Blamed commits with line spans and linked bead_ids")
+		fmt.Println("      - unlinked_lines: Lines whose commit maps to no bead")
+		fmt.Println("      Example: bv --why pkg/auth/token.go:40-80 --robot-why")
+		fmt.Println("")
+		fmt.Println("  --robot-suggest-trailers")
+		fmt.Println("      Suggests commit trailers (Bead: <id>) for the changes you are about to commit.")
+		fmt.Println("      Ranks open beads whose past commits touched the changed files.")
+		fmt.Println("      Trailers (Bead: bv-123, Closes-Bead: bv-45) are correlated at confidence 1.0.")
+		fmt.Println("      Key sections:")
+		fmt.Println("      - source: staged, unstaged, or 'diff <spec>'")
+		fmt.Println("      - suggestions: Array of {bead_id, title, status, trailer, score, matched_files, reason}")
+		fmt.Println("      - trailer_block: Ready-to-append trailer lines")
+		fmt.Println("      Flags:")
+		fmt.Println("      - --trailers-diff <spec>: Diff against a revision or range instead (e.g. origin/main...HEAD)")
+		fmt.Println("      - --trailers-limit <n>: Max suggestions (default: 5)")
+		fmt.Println("      Example: bv --robot-suggest-trailers")
+		fmt.Println("      Example: bv --robot-suggest-trailers --trailers-diff HEAD~1")
+		fmt.Println("")
+		fmt.Println("  --robot-suggest-deps [--suggest-confidence=X] [--robot-max-results=N]")
+		fmt.Println("      Finds open issues that read alike (semantic index, as --search) and touched")
+		fmt.Println("      the same files in git history, but have no dependency edge.")
+		fmt.Println("      Key fields per suggestion:")
+		fmt.Println("      - type: blocks (from depends on to) or related")
+		fmt.Println("      - confidence: 70% text similarity + 30% file overlap, +0.1 if one mentions the other")
+		fmt.Println("      - shared_files, reason, command (bd dep add ...)")
+		fmt.Println("      Outside a git repository, suggestions use text similarity alone.")
+		fmt.Println("")
+		fmt.Println("  --robot-duplicates [--duplicate-threshold=X] [--robot-max-results=N]")
+		fmt.Println("      Near-duplicate pairs scored on title keywords (40%), embedding similarity")
+		fmt.Println("      (40%) and label overlap (20%); missing signals are dropped and the rest")
+		fmt.Println("      reweighted. Threshold defaults to duplicates.threshold in .bv/search.yaml,")
+		fmt.Println("      else 0.75. Pairs of two closed issues and linked pairs are skipped.")
+		fmt.Println("      Key fields: pairs[].issue1, issue2, score, title_similarity,")
+		fmt.Println("      semantic_similarity, label_overlap, command")
+		fmt.Println("")
+		fmt.Println("  --robot-suggest-labels [--suggest-confidence=X] [--robot-max-results=N]")
+		fmt.Println("      Predicts labels for unlabeled open issues: the 5 most similar labeled")
+		fmt.Println("      issues (TF-IDF over title and description) vote for their labels.")
+		fmt.Println("      Key fields: labeled_count, unlabeled_count, suggested_count,")
+		fmt.Println("      issues[].suggestions[].label, confidence, neighbors, command")
+		fmt.Println("      In the TUI, unlabeled issues show suggestions in the detail view; L applies them.")
+		fmt.Println("")
+		fmt.Println("  --robot-policies [--apply]")
+		fmt.Println("      Evaluates auto-triage policies in .bv/policies.yaml against open issues.")
+		fmt.Println("      Conditions: no_update_days, blocked_by_closed, no_inbound_refs, status, labels.")
+		fmt.Println("      Actions: mark_for_closure (add a label), deprioritize (lower to a priority floor),")
+		fmt.Println("      ping (comment mentioning the assignee). Dry run by default; --apply writes the")
+		fmt.Println("      changes to the beads file (undo with u in the TUI). Actions already in effect")
+		fmt.Println("      are skipped, so repeated runs are safe.")
+		fmt.Println("      Key fields: dry_run, policies, matches[].policy, issue_id, action, reasons,")
+		fmt.Println("      change, command, applied, error")
+		fmt.Println("")
 		fmt.Println("  --robot-file-relations <path>")
 		fmt.Println("      Outputs files that frequently co-change with the given file.")
 		fmt.Println("      Reveals hidden coupling: what other files typically change together?")
@@ -444,6 +881,7 @@ func main() {
 		fmt.Println("  --robot-forecast <id|all>")
 		fmt.Println("      Outputs ETA forecast for a specific bead or all open issues.")
 		fmt.Println("      Returns estimated completion date, confidence, and factors.")
+		fmt.Println("      Time tracked with `bv track` calibrates estimates (see --robot-estimates).")
 		fmt.Println("      Options:")
 		fmt.Println("        --forecast-label=X    Filter by label")
 		fmt.Println("        --forecast-sprint=Y   Filter by sprint")
@@ -452,6 +890,34 @@ func main() {
 		fmt.Println("      Example: bv --robot-forecast all --forecast-label=backend")
 		fmt.Println("      Example: bv --robot-forecast all --forecast-agents=2")
 		fmt.Println("")
+		fmt.Println("  --robot-explain <id>")
+		fmt.Println("      Everything bv knows about one issue in one document:")
+		fmt.Println("      - scores: triage, impact and graph metrics, each with its percentile rank")
+		fmt.Println("      - recommendation: triage rank and reasons, or why_not when it misses the cut")
+		fmt.Println("      - blockers / unblocks: the blocker chain and the cascade finishing it frees")
+		fmt.Println("      - forecast, history (events, age), commits (in a git repo), similar issues")
+		fmt.Println("      --forecast-agents applies to the forecast. Press E in the TUI detail view for")
+		fmt.Println("      the same explanation.")
+		fmt.Println("      Example: bv --robot-explain bv-123")
+		fmt.Println("")
+		fmt.Println("  --robot-tree <id>")
+		fmt.Println("      The issue's dependency structure as two trees:")
+		fmt.Println("      - upstream: what it waits on, recursively (closed blockers end a branch)")
+		fmt.Println("      - downstream: what waits on it, recursively")
+		fmt.Println("      Each node carries estimated_days (median estimate when it has none)")
+		fmt.Println("      and branch_days, the open work in its subtree; an issue reached twice")
+		fmt.Println("      is expanded once and marked repeat. Press D in the TUI for the tree view.")
+		fmt.Println("      Example: bv --robot-tree bv-123")
+		fmt.Println("")
+		fmt.Println("  --robot-layers [--agents=N] [--format=json|markdown|mermaid]")
+		fmt.Println("      The open issues as a phased roadmap: layer 1 waits on nothing open, and")
+		fmt.Println("      everything in layer N waits only on layers before N. Each layer has its")
+		fmt.Println("      count, work_days, duration_days (shared among --agents, never less than")
+		fmt.Println("      its longest issue) and start/end day. Issues in or behind a cycle are")
+		fmt.Println("      listed as unlayered. --format markdown gives a document with a Mermaid")
+		fmt.Println("      diagram and a table per layer; --format mermaid just the diagram.")
+		fmt.Println("      Example: bv --robot-layers --agents=3 --format=markdown > roadmap.md")
+		fmt.Println("")
 		fmt.Println("  --robot-capacity [--agents=N] [--capacity-label=X]")
 		fmt.Println("      Outputs capacity simulation and completion projection as JSON.")
 		fmt.Println("      Analyzes work remaining, parallelizability, and bottlenecks.")
@@ -464,8 +930,60 @@ func main() {
 		fmt.Println("      Options:")
 		fmt.Println("        --agents=N           Number of parallel agents (default: 1)")
 		fmt.Println("        --capacity-label=X   Filter analysis to label's subgraph")
+		fmt.Println("        --agent-profiles=F   Simulate named agents from a YAML file (replaces --agents)")
+		fmt.Println("        --capacity-runs=N    Monte Carlo runs for the simulation (default: 500)")
+		fmt.Println("      With --agent-profiles, a simulation field assigns ready issues to agents whose")
+		fmt.Println("      labels match, up to each agent's wip_limit at hours_per_day, and reports:")
+		fmt.Println("        - completion: p50/p80/p95 days and dates over all runs")
+		fmt.Println("        - agents: avg issues, busy days and utilization per agent")
+		fmt.Println("        - unassignable/stranded: issues no agent can take, or that wait on them")
 		fmt.Println("      Example: bv --robot-capacity --agents=3")
 		fmt.Println("      Example: bv --robot-capacity --capacity-label=backend")
+		fmt.Println("      Example: bv --robot-capacity --agent-profiles=.bv/agents.yaml")
+		fmt.Println("")
+		fmt.Println("  --robot-goals [--agent-profiles=F] [--capacity-runs=N]")
+		fmt.Println("      Forecasts each goal in .bv/goals.yaml: a milestone label or list of issues")
+		fmt.Println("      with a target date. The goal's open issues and the open issues they wait on")
+		fmt.Println("      are simulated as in --robot-capacity, on the file's agents (default 1) or")
+		fmt.Println("      the --agent-profiles agents.")
+		fmt.Println("      Key fields:")
+		fmt.Println("        - probability: Share of runs closing the goal by the end of its target day")
+		fmt.Println("        - completion: p50/p80/p95 days and dates")
+		fmt.Println("        - blockers: Open issues outside the goal it waits on")
+		fmt.Println("        - next_up: Ready issues, longest remaining chain first")
+		fmt.Println("        - stuck: Issues no agent can take or in a cycle (probability 0)")
+		fmt.Println("      Goals below goal_warning_probability in .bv/drift.yaml raise goal_at_risk")
+		fmt.Println("      in --robot-alerts. Press % in the TUI for the goals panel.")
+		fmt.Println("      Example: bv --robot-goals | jq '.goals[] | {name, probability}'")
+		fmt.Println("")
+		fmt.Println("  --robot-estimates [--robot-max-results=N]")
+		fmt.Println("      Reports how much open work has an explicit estimate. Forecast and capacity")
+		fmt.Println("      use estimated_minutes, then estimated_points (1 point = 240 minutes), and only")
+		fmt.Println("      fall back to heuristics for unestimated issues.")
+		fmt.Println("      Key fields:")
+		fmt.Println("        - coverage_pct: Share of open issues with an estimate")
+		fmt.Println("        - by_label: Coverage and estimated/heuristic minutes per label")
+		fmt.Println("        - largest_unestimated: Biggest heuristic guesses, worth estimating first")
+		fmt.Println("        - actuals: Estimate vs time tracked with `bv track` on closed issues,")
+		fmt.Println("          overall and by_label (variance_pct > 0 means work ran over). Labels")
+		fmt.Println("          with 3+ samples calibrate --robot-forecast.")
+		fmt.Println("")
+		fmt.Println("  --robot-queues [--queue-by=label|track] [--queue-window=DAYS]")
+		fmt.Println("      Models each label (or execution track) as an M/M/c queue: arrivals from")
+		fmt.Println("      created_at, service rate from claim-to-close cycle times, c = people working it.")
+		fmt.Println("      Key fields:")
+		fmt.Println("        - queues[].utilization: Arrival rate / capacity (>= 1 means the backlog grows)")
+		fmt.Println("        - queues[].expected_wait_days: Erlang C wait before work starts")
+		fmt.Println("        - constraint: The most utilized queue, limiting overall throughput")
+		fmt.Println("")
+		fmt.Println("  --robot-review [--review-window=DAYS]")
+		fmt.Println("      Issues in in_review (custom statuses like review or needs_review load as")
+		fmt.Println("      in_review), oldest first, and review latency from issue events.")
+		fmt.Println("      Key fields:")
+		fmt.Println("        - review.queue[]: Issues waiting in review with age_days")
+		fmt.Println("        - review.latency: Mean/median/p90 days from entering review to close")
+		fmt.Println("        - review.review_share: Part of claim-to-close cycle time spent in review")
+		fmt.Println("        - overdue: Issues past the drift review_warning_days (review_overdue alerts)")
 		fmt.Println("")
 		fmt.Println("  --emit-script [--script-limit=N] [--script-format=bash|fish|zsh]")
 		fmt.Println("      Emits a shell script for top-N priority recommendations.")
@@ -484,17 +1002,65 @@ func main() {
 		fmt.Println("")
 		fmt.Println("  --export-md <file>")
 		fmt.Println("      Generates a readable status report with Mermaid.js visualizations.")
+		fmt.Println("      --export-md-graph=svg|png also writes <file>-graph.svg|png (layered layout) and embeds it.")
+		fmt.Println("      --export-template=<name> renders a Go template instead of the fixed report:")
+		fmt.Println("        built-ins: status, standup, release-notes; project templates in .bv/templates/<name>.md.tmpl;")
+		fmt.Println("        or a path to a .tmpl file. .bv/templates/report.md.tmpl, if present, replaces the default report.")
+		fmt.Println("      Example: bv --export-md standup.md --export-template=standup")
 		fmt.Println("      Runs pre-export and post-export hooks if configured in .bv/hooks.yaml")
 		fmt.Println("")
+		fmt.Println("  --publish <name[,name]|all>")
+		fmt.Println("      Replaces the body of the Confluence/Notion pages listed in .bv/publish.yaml")
+		fmt.Println("      with the Markdown report or the priority brief (content: markdown|priority-brief).")
+		fmt.Println("      Tokens: CONFLUENCE_API_TOKEN (+ CONFLUENCE_USER for Cloud), NOTION_TOKEN.")
+		fmt.Println("      Example: bv --publish all")
+		fmt.Println("")
+		fmt.Println("  --export-csv <file>")
+		fmt.Println("      Writes one CSV row per issue with pagerank, betweenness, unblocks_count,")
+		fmt.Println("      triage_score and forecast eta_date/eta_days/eta_confidence columns.")
+		fmt.Println("      --recipe filters and sorts rows; metrics always use the full graph.")
+		fmt.Println("      --forecast-agents sets the parallelism assumed for ETAs.")
+		fmt.Println("      Example: bv --export-csv issues.csv --recipe actionable")
+		fmt.Println("")
+		fmt.Println("  --export-ics <file>")
+		fmt.Println("      Writes an iCalendar file with an all-day event on each open issue's forecast")
+		fmt.Println("      completion date, each sprint's start-to-end span, and each sprint's forecast")
+		fmt.Println("      completion (its last open issue's ETA). Event UIDs are stable, so re-importing")
+		fmt.Println("      or re-subscribing to a regenerated file updates events instead of duplicating them.")
+		fmt.Println("      --recipe and --forecast-label select issues; --forecast-sprint limits the")
+		fmt.Println("      file to one sprint and its issues. --forecast-agents sets the parallelism.")
+		fmt.Println("      Example: bv --export-ics forecast.ics --forecast-sprint=sprint-3")
+		fmt.Println("")
+		fmt.Println("  --export-annotated-jsonl <file|->")
+		fmt.Println("      Writes every issue as bd JSONL plus a \"bv\" object per line:")
+		fmt.Println("      pagerank/betweenness/critical_path with *_rank, triage_score, triage_rank,")
+		fmt.Println("      blocked, blocked_by, unblocks, and forecast (ETA; omitted for closed issues).")
+		fmt.Println("      The output still loads as a beads file. Use '-' to write to stdout.")
+		fmt.Println("      Example: bv --export-annotated-jsonl - | jq 'select(.bv.blocked | not) | .id'")
+		fmt.Println("")
 		fmt.Println("  --no-hooks")
-		fmt.Println("      Skip running hooks during export. Useful for CI or quick exports.")
+		fmt.Println("      Skip running hooks (export, snapshot, drift and triage). Useful for CI or quick exports.")
 		fmt.Println("")
 		fmt.Println("  Hook Configuration (.bv/hooks.yaml)")
-		fmt.Println("      Configure hooks to automate export workflows:")
+		fmt.Println("      Configure hooks to automate workflows:")
 		fmt.Println("      - pre-export: Validation, notifications (failure cancels export)")
 		fmt.Println("      - post-export: Notifications, uploads (failure logged only)")
-		fmt.Println("      Environment variables: BV_EXPORT_PATH, BV_EXPORT_FORMAT,")
-		fmt.Println("        BV_ISSUE_COUNT, BV_TIMESTAMP")
+		fmt.Println("      - pre-snapshot / post-snapshot: Around --save-baseline (pre failure cancels the save)")
+		fmt.Println("      - on-drift-alert: --check-drift found alerts")
+		fmt.Println("      - on-triage: After --robot-triage / --robot-next")
+		fmt.Println("      - post-reload: The TUI reloaded a changed beads file")
+		fmt.Println("      Per hook: timeout (default 30s), on_error (fail|continue), env, and group:")
+		fmt.Println("        consecutive hooks with the same group run in parallel.")
+		fmt.Println("      Environment variables: BV_HOOK_PHASE, BV_TIMESTAMP; export hooks also get")
+		fmt.Println("        BV_EXPORT_PATH, BV_EXPORT_FORMAT, BV_ISSUE_COUNT")
+		fmt.Println("      Stdin: {\"phase\", \"timestamp\", \"data\"} JSON with the phase's context")
+		fmt.Println("")
+		fmt.Println("  Analyzer Plugins (.bv/plugins/)")
+		fmt.Println("      Executables there read {\"protocol\": 1, \"context\", \"issues\"} on stdin and print")
+		fmt.Println("      {\"scores\": {id: {metric: value}}, \"alerts\": [...]} on stdout (10s limit each).")
+		fmt.Println("      Scores and alert types are prefixed with the plugin name (security:risk).")
+		fmt.Println("      --robot-triage adds them to recommendations[].plugin_scores and alerts;")
+		fmt.Println("      --robot-insights reports them under plugins. --no-plugins skips them.")
 		fmt.Println("")
 		fmt.Println("  --diff-since <commit|date>")
 		fmt.Println("      Shows changes since a historical point.")
@@ -508,17 +1074,92 @@ func main() {
 		fmt.Println("      - resolved_cycles: Circular dependencies fixed")
 		fmt.Println("      - summary.health_trend: 'improving', 'degrading', or 'stable'")
 		fmt.Println("")
+		fmt.Println("  --diff-from <source> [--diff-to <source>]")
+		fmt.Println("      Like --diff-since, between any two points: each source is a revision, REV:PATH")
+		fmt.Println("      (a JSONL file in a revision), a JSONL file, or a directory holding one.")
+		fmt.Println("      Without --diff-to, compares against the current data.")
+		fmt.Println("      Example: bv --robot-diff --diff-from origin/main --diff-to pr-branch:.beads/issues.jsonl")
+		fmt.Println("")
 		fmt.Println("  --as-of <commit|date>")
 		fmt.Println("      View issue state at a point in time (works with all robot commands).")
 		fmt.Println("      Useful for historical analysis without modifying the working tree.")
 		fmt.Println("      Robot outputs include 'as_of' and 'as_of_commit' metadata fields.")
 		fmt.Println("      Examples: --as-of HEAD~30, --as-of v1.0.0, --as-of '2024-01-01'")
 		fmt.Println("")
+		fmt.Println("  --deterministic (or BV_DETERMINISTIC=1)")
+		fmt.Println("      Byte-identical robot output for identical data (works with all robot commands).")
+		fmt.Println("      'Now' becomes the latest created/updated/closed time in the data, so generated_at")
+		fmt.Println("      and ages are stable; status ms and compute_time_ms timings are reported as 0.")
+		fmt.Println("      Use for golden-file tests and agent pipelines that diff outputs.")
+		fmt.Println("")
+		fmt.Println("  --provenance (or BV_PROVENANCE=1), --sign <key> [--signature <file>]")
+		fmt.Println("      Adds a provenance object (data_hash, git_commit, git_dirty, bv_version, flags)")
+		fmt.Println("      to every robot payload, and writes <export>.provenance.json with the SHA-256 of")
+		fmt.Println("      each exported file. --sign (SSH or minisign private key, or BV_SIGN_KEY) signs")
+		fmt.Println("      those files (.sig next to them) and, with --signature, the robot output.")
+		fmt.Println("      Check with: bv verify <file> --key <public key>")
+		fmt.Println("")
+		fmt.Println("  Exit codes (all commands)")
+		fmt.Println("      0 ok, 1 error, 2 bad usage, 3 data not found (no beads data, unknown issue,")
+		fmt.Println("      sprint or ref), 4 timeout. --check-drift and --ci-report keep their verdict codes.")
+		fmt.Println("      With BV_ROBOT=1 (set by every --robot-* flag) failures print one JSON line on stderr:")
+		fmt.Println("      {\"schema_version\":\"1\",\"error\":{\"code\":\"not_found\",\"exit_code\":3,\"message\":\"...\"}}")
+		fmt.Println("")
+		fmt.Println("  --fields <path,path,...>")
+		fmt.Println("      Keep only these JSON paths in robot output (works with all robot commands).")
+		fmt.Println("      Paths are dotted object keys; arrays are transparent, so triage.recommendations.id keeps")
+		fmt.Println("      the id of every recommendation. schema_version (and stream record tags) are kept.")
+		fmt.Println("      Example: bv --robot-triage --fields triage.quick_ref,triage.recommendations.id")
+		fmt.Println("")
+		fmt.Println("  --max-bytes <n>")
+		fmt.Println("      Fit robot output in n bytes (works with all robot commands, after --fields). Payloads")
+		fmt.Println("      that fit are unchanged; otherwise usage hints, then explanations, then score breakdowns,")
+		fmt.Println("      then full metric maps are dropped, and the longest lists halved, until it fits.")
+		fmt.Println("      A trailing truncation object lists what went: removed[] {stage, field, count},")
+		fmt.Println("      truncated[] {path, kept, total}, original_bytes, and fits (false if still over).")
+		fmt.Println("      Example: bv --robot-triage --max-bytes 8000")
+		fmt.Println("")
+		fmt.Println("  --page-size <n> / --cursor <token>")
+		fmt.Println("      Paginate the large parts of --robot-insights (full_stats, by PageRank, default 200")
+		fmt.Println("      issues per page) and --robot-history (histories by bead ID, unpaged by default).")
+		fmt.Println("      The payload's page object has offset, size, total, returned and, while more remain,")
+		fmt.Println("      next_cursor; pass it as --cursor. Cursors fail once the data_hash changes.")
+		fmt.Println("      Example: bv --robot-insights --page-size 100 --cursor \"$CURSOR\"")
+		fmt.Println("")
+		fmt.Println("  --stream (with --robot-insights or --robot-history)")
+		fmt.Println("      Newline-delimited JSON instead of one document: a meta record, one record per")
+		fmt.Println("      list entry / issue metric / bead / commit, then an end record with the count.")
+		fmt.Println("      Every line has \"record\" (meta, insight, metric, cycle, what_if, advanced, bead,")
+		fmt.Println("      commit, end); stream metric records cover every issue, not the top 200.")
+		fmt.Println("      Example: bv --robot-insights --stream | jq -c 'select(.record == \"metric\")' | head -20")
+		fmt.Println("")
+		fmt.Println("  --robot-capabilities")
+		fmt.Println("      What this bv supports, for agents that must also work with older versions:")
+		fmt.Println("      commands[] (flag, subcommand, options, schema_version, available, reason),")
+		fmt.Println("      features[] (graph metrics skipped for the graph's size, git_history, drift_baseline,")
+		fmt.Println("      network), flags[] (every flag with its default and choices), and build (go version, tags).")
+		fmt.Println("      Example: bv --robot-capabilities | jq '[.commands[] | select(.available) | .flag]'")
+		fmt.Println("")
+		fmt.Println("  --robot-schema [command]")
+		fmt.Println("      JSON Schema (draft 2020-12) for robot payloads, generated from the Go types.")
+		fmt.Println("      With a command (triage, insights, next, ...) prints that schema; without, all of them.")
+		fmt.Println("      Every robot JSON object starts with schema_version; it changes only when a field")
+		fmt.Println("      is removed, renamed or retyped, so pin it in clients and validate against the schema.")
+		fmt.Println("      Example: bv --robot-schema triage > triage.schema.json")
+		fmt.Println("")
 		fmt.Println("  --robot-diff")
-		fmt.Println("      Output diff as JSON (use with --diff-since).")
-		fmt.Println("      Fields: generated_at, resolved_revision, from_data_hash, to_data_hash, diff{...}")
+		fmt.Println("      Output diff as JSON (use with --diff-since or --diff-from).")
+		fmt.Println("      Fields: generated_at, resolved_revision, to_revision (with --diff-to),")
+		fmt.Println("              from_data_hash, to_data_hash, diff{...}")
 		fmt.Println("      Diff payload includes metric deltas, cycles introduced/resolved, and modified issues.")
 		fmt.Println("")
+		fmt.Println("  --robot-pr-impact [--base origin/main]")
+		fmt.Println("      Diffs the beads file against a base ref and reports PR impact as JSON.")
+		fmt.Println("      Key fields: impact.diff, impact.metric_movers (pagerank/betweenness/critical_path),")
+		fmt.Println("                  impact.newly_blocked, impact.newly_unblocked, impact.cycles_introduced,")
+		fmt.Println("                  impact.verdict (ok|review|attention), comment_markdown (ready to post).")
+		fmt.Println("      Example: bv --robot-pr-impact --base origin/main | jq -r .comment_markdown")
+		fmt.Println("")
 		fmt.Println("  --robot-recipes")
 		fmt.Println("      Lists all available recipes as JSON.")
 		fmt.Println("      Output: {recipes: [{name, description, source}]}")
@@ -534,6 +1175,9 @@ func main() {
 		fmt.Println("      Key fields: labels[], flow_matrix[from][to], dependencies[{from,to,count,issue_ids}],")
 		fmt.Println("                  bottleneck_labels (highest outgoing), total_cross_label_deps.")
 		fmt.Println("      Use when you need to see which labels are blocking others at a glance.")
+		fmt.Println("      --format=sankey prints a Mermaid sankey-beta diagram instead (blocking labels")
+		fmt.Println("      on the left, blocked on the right); --format=html a standalone page drawing it.")
+		fmt.Println("      Example: bv --robot-label-flow --format=html > label-flow.html")
 		fmt.Println("")
 		fmt.Println("  --robot-label-attention [--attention-limit=N]")
 		fmt.Println("      Outputs attention-ranked labels as JSON (default limit: 5).")
@@ -543,52 +1187,65 @@ func main() {
 		fmt.Println("")
 		fmt.Println("  --robot-alerts")
 		fmt.Println("      Outputs drift + proactive alerts as JSON (staleness, cascades, density, cycles).")
+		fmt.Println("      In a git repo it also flags activity anomalies: the last 7 days of created, closed")
+		fmt.Println("      or newly blocked issues far from the trailing 4-week mean (activity_anomaly).")
+		fmt.Println("      Goals in .bv/goals.yaml unlikely to make their target date raise goal_at_risk")
+		fmt.Println("      (see --robot-goals). Issues waiting in in_review past review_warning_days")
+		fmt.Println("      raise review_overdue (see --robot-review).")
 		fmt.Println("      Filters: --severity=<info|warning|critical>, --alert-type=<type>, --alert-label=<label>")
-		fmt.Println("      Fields: type, severity, message, issue_id, label, detected_at, details[].")
+		fmt.Println("      Fields: type, severity, message, issue_id, label, detected_at, details[], series.")
 		fmt.Println("")
-		fmt.Println("  --robot-graph [--graph-format=json|dot|mermaid] [--graph-root=ID] [--graph-depth=N]")
+		fmt.Println("  --robot-graph [--graph-format=json|dot|mermaid|graphml|gexf] [--graph-root=ID] [--graph-depth=N]")
 		fmt.Println("      Outputs dependency graph in specified format (default: JSON adjacency).")
 		fmt.Println("      Formats:")
 		fmt.Println("        - json: Adjacency list with nodes[], edges[], metadata")
 		fmt.Println("        - dot: Graphviz DOT format (render with: dot -Tpng file.dot -o graph.png)")
 		fmt.Println("        - mermaid: Mermaid diagram format (paste into GitHub/markdown)")
+		fmt.Println("        - graphml: GraphML XML with status/priority/pagerank/labels attributes (yEd, Gephi, Cytoscape)")
+		fmt.Println("        - gexf: GEXF 1.3 XML with the same attributes (Gephi)")
 		fmt.Println("      Options:")
 		fmt.Println("        --label LABEL: Filter to issues with specific label")
 		fmt.Println("        --graph-root ID: Extract subgraph starting from root issue")
 		fmt.Println("        --graph-depth N: Limit subgraph depth (0 = unlimited)")
-		fmt.Println("      Fields: format, graph (string for dot/mermaid), nodes, edges, filters_applied, explanation")
+		fmt.Println("      Mermaid options:")
+		fmt.Println("        --graph-cluster label|track: Group nodes into subgraphs by first label or connected work stream")
+		fmt.Println("        --link-template URL: Make nodes clickable, e.g. https://tracker.example.com/issues/{id}")
+		fmt.Println("        --graph-max-nodes N: Split into several diagrams above N nodes (default 100, -1 = never);")
+		fmt.Println("          split output also carries diagrams[] with one entry per diagram")
+		fmt.Println("      Fields: format, graph (string for dot/mermaid/graphml/gexf), nodes, edges, filters_applied, explanation")
 		fmt.Println("      Example: bv --robot-graph --graph-format=dot --label=api > api-deps.dot")
 		fmt.Println("")
-		fmt.Println("  --export-graph <path.png|path.svg> [--graph-style=force|grid] [--graph-preset=compact|roomy]")
+		fmt.Println("  --export-graph <path.png|path.svg|path.html> [--graph-style=grid|layered] [--graph-preset=compact|roomy]")
 		fmt.Println("      Export dependency graph as PNG or SVG image (pure Go, no external dependencies).")
-		fmt.Println("      Format is inferred from file extension (.png or .svg).")
+		fmt.Println("      Format is inferred from file extension (.png or .svg); .html writes the interactive")
+		fmt.Println("      force-directed viewer instead.")
 		fmt.Println("")
 		fmt.Println("      Styles:")
-		fmt.Println("        --graph-style=force (default): Beautiful force-directed layout")
-		fmt.Println("          - Physics-based positioning (Fruchterman-Reingold algorithm)")
-		fmt.Println("          - Dark Dracula-inspired theme with vibrant status colors")
-		fmt.Println("          - Curved bezier edges with glow effects")
-		fmt.Println("          - Node size reflects importance (PageRank + betweenness)")
-		fmt.Println("          - Priority badges, drop shadows, gradients")
-		fmt.Println("")
-		fmt.Println("        --graph-style=grid: Simple hierarchical grid layout")
+		fmt.Println("        --graph-style=grid (default): Simple hierarchical grid layout")
 		fmt.Println("          - Nodes arranged by critical path depth")
 		fmt.Println("          - Light theme with pastel colors")
 		fmt.Println("")
+		fmt.Println("        --graph-style=layered: Layered (Sugiyama) layout, like Graphviz dot")
+		fmt.Println("          - Dependents above their blockers; arrows point down to what they wait on")
+		fmt.Println("          - Crossing-reduced ordering, long edges routed around nodes")
+		fmt.Println("          - Used for the images embedded by --export-pages and --export-md-graph")
+		fmt.Println("")
 		fmt.Println("      Options:")
 		fmt.Println("        --label LABEL: Filter to issues with specific label")
 		fmt.Println("        --graph-preset: Layout spacing - 'compact' (default) or 'roomy'")
 		fmt.Println("        --graph-title: Custom title for the graph header")
 		fmt.Println("")
 		fmt.Println("      Example: bv --export-graph deps.svg --label=api --graph-title='API Dependencies'")
-		fmt.Println("      Example: bv --export-graph full.png --graph-style=force --graph-preset=roomy")
+		fmt.Println("      Example: bv --export-graph full.png --graph-style=layered --graph-preset=roomy")
 		fmt.Println("")
 		fmt.Println("  --robot-insights")
 		fmt.Println("      Graph metrics JSON for agents.")
 		fmt.Println("      Top lists: Bottlenecks (betweenness), Keystones (critical path), Influencers (eigenvector),")
 		fmt.Println("                 Cores (k-core), Articulation points (cut vertices), Slack (parallelism headroom).")
-		fmt.Println("      Full maps (capped by BV_INSIGHTS_MAP_LIMIT): pagerank, betweenness, eigenvector, hubs/authorities, core_number, slack.")
+		fmt.Println("      Full maps (one page of issues, see --page-size): pagerank, betweenness, eigenvector, hubs/authorities, core_number, slack.")
 		fmt.Println("      status captures per-metric state: computed|approx|timeout|skipped with elapsed_ms and reasons.")
+		fmt.Println("      Betweenness status adds mode (exact|approximate), sample and error_bound; tune with")
+		fmt.Println("      BV_BETWEENNESS_MODE=exact|approximate|skip, BV_BETWEENNESS_SAMPLE=<n>, BV_BETWEENNESS_ERROR=<0.05>.")
 		fmt.Println("      Shared fields: data_hash, analysis_config.")
 		fmt.Println("      Quick jq: jq '.full_stats.core_number | to_entries | sort_by(-.value)[:5]'   # top k-core nodes")
 		fmt.Println("                 jq '.Articulation'                                                  # structural cut points")
@@ -642,6 +1299,10 @@ func main() {
 		fmt.Println("      Provides recommendations based on timing analysis.")
 		fmt.Println("      Use with --profile-json for machine-readable output.")
 		fmt.Println("")
+		fmt.Println("      For OpenTelemetry spans of the same phases (loading, analysis, correlation,")
+		fmt.Println("      export), set OTEL_EXPORTER_OTLP_ENDPOINT, OTEL_TRACES_EXPORTER=console")
+		fmt.Println("      or BV_TRACE_FILE=<path>. Tracing is off unless one of these is set.")
+		fmt.Println("")
 		fmt.Println("  --workspace CONFIG")
 		fmt.Println("      Load issues from workspace configuration file.")
 		fmt.Println("      Path: typically .bv/workspace.yaml")
@@ -676,6 +1337,18 @@ func main() {
 		fmt.Println("      Output drift check as JSON (use with --check-drift).")
 		fmt.Println("      Output: {has_drift, exit_code, summary, alerts, baseline}")
 		fmt.Println("")
+		fmt.Println("  --ci-report github")
+		fmt.Println("      GitHub Actions report: ::error/::warning annotations for drift alerts and")
+		fmt.Println("      JSONL validation failures, job summary ($GITHUB_STEP_SUMMARY) with top picks")
+		fmt.Println("      and metric deltas, step outputs ($GITHUB_OUTPUT): has_drift, critical_count.")
+		fmt.Println("      Exit codes: 0 = no critical problems, 1 = critical problems found")
+		fmt.Println("")
+		fmt.Println("  --digest daily|weekly [--digest-format markdown|json]")
+		fmt.Println("      Digest for cron + email/Slack: changes since the last digest of the same")
+		fmt.Println("      period (from git history), new alerts, forecast slips and velocity.")
+		fmt.Println("      The last digest is recorded in .bv/state/digest.json; re-running within the")
+		fmt.Println("      same day or week rebuilds that digest (rerun: true) instead of repeating it.")
+		fmt.Println("")
 		fmt.Println("  Static Site Export & GitHub Pages (bv-7pu):")
 		fmt.Println("      --pages")
 		fmt.Println("          Launch interactive Pages deployment wizard.")
@@ -693,12 +1366,104 @@ func main() {
 		fmt.Println("          Opens http://localhost:9000 in your browser.")
 		fmt.Println("          Example: bv --preview-pages ./bv-pages")
 		fmt.Println("")
+		fmt.Println("      --serve-pages <dir> [--serve-bind <addr>] [--serve-port <n>]")
+		fmt.Println("          Serve an export for a team on an internal host: gzip, graceful")
+		fmt.Println("          shutdown on SIGINT/SIGTERM, unauthenticated /healthz endpoint.")
+		fmt.Println("          Auth (optional):")
+		fmt.Println("            --serve-user <name>   basic auth, password from BV_SERVE_PASSWORD")
+		fmt.Println("            BV_SERVE_TOKEN=<tok>  token via 'Authorization: Bearer' or ?token=")
+		fmt.Println("          --serve-gzip=false disables compression.")
+		fmt.Println("          Example: BV_SERVE_TOKEN=s3cret bv --serve-pages ./bv-pages --serve-bind 0.0.0.0")
+		fmt.Println("")
+		fmt.Println("  Prometheus Metrics:")
+		fmt.Println("      --metrics-listen <addr>")
+		fmt.Println("          Serve project health gauges at http://<addr>/metrics, re-reading the")
+		fmt.Println("          beads file on every scrape: bv_open_issues, bv_blocked_issues,")
+		fmt.Println("          bv_actionable_issues, bv_cycles, bv_critical_path_days,")
+		fmt.Println("          bv_label_health{label=...} and more.")
+		fmt.Println("          Example: bv --metrics-listen :9464")
+		fmt.Println("")
+		fmt.Println("      --metrics-textfile <path>")
+		fmt.Println("          Write the same metrics once, atomically, for node_exporter's")
+		fmt.Println("          textfile collector (run from cron). Use - for stdout.")
+		fmt.Println("          Example: bv --metrics-textfile /var/lib/node_exporter/bv.prom")
+		fmt.Println("")
 		fmt.Println("      --pages-title <title>")
 		fmt.Println("          Custom title for the static site (default: 'Project Issues')")
 		fmt.Println("")
 		fmt.Println("      --pages-include-closed=false")
 		fmt.Println("          Exclude closed issues from export (default: include all)")
 		fmt.Println("")
+		fmt.Println("      --labels <a,b> / --exclude-label <c,d>")
+		fmt.Println("          Export only issues with one of the labels, minus those with an excluded")
+		fmt.Println("          one, for a bundle per audience. Dependencies on issues left out point at")
+		fmt.Println("          opaque placeholders (hidden-1, ...) instead of dangling.")
+		fmt.Println("          Example: bv --export-pages ./api-site --labels api,infra --exclude-label security")
+		fmt.Println("")
+		fmt.Println("      Re-exports are incremental: only files whose content changed are")
+		fmt.Println("      rewritten, and bv-manifest.json records a SHA-256 for every file.")
+		fmt.Println("")
+		fmt.Println("      --pages-push [--pages-remote <name|url>] [--pages-branch <branch>]")
+		fmt.Println("          After --export-pages, commit only the changed files to the Pages")
+		fmt.Println("          branch (default: gh-pages on origin) and push. No force-push;")
+		fmt.Println("          nothing is committed when the site is unchanged.")
+		fmt.Println("          Example: bv --export-pages ./bv-pages --pages-push")
+		fmt.Println("")
+		fmt.Println("      --pages-deploy <gitlab|s3|netlify|cloudflare>")
+		fmt.Println("          After --export-pages, deploy without prompts (for CI):")
+		fmt.Println("            gitlab:     --pages-project group/project [--pages-branch main]")
+		fmt.Println("                        token from GITLAB_TOKEN (else git credentials)")
+		fmt.Println("            s3:         --pages-bucket s3://bucket/prefix [--pages-cloudfront ID]")
+		fmt.Println("                        credentials from the aws CLI")
+		fmt.Println("            netlify:    --pages-project <site>, token from NETLIFY_AUTH_TOKEN")
+		fmt.Println("            cloudflare: --pages-project <name> [--pages-branch main]")
+		fmt.Println("          Example: bv --export-pages ./bv-pages --pages-deploy netlify --pages-project my-issues")
+		fmt.Println("")
+		fmt.Println("  Key Bindings (~/.config/bv/keymap.yaml, then .bv/keymap.yaml)")
+		fmt.Println("      Remap any TUI action; the ? overlay shows the active keys:")
+		fmt.Println("        keys:")
+		fmt.Println("          view.board: B")
+		fmt.Println("          nav.down: [j, down, n]")
+		fmt.Println("      Run 'bv --keymap-check' to list action IDs and find conflicts.")
+		fmt.Println("")
+		fmt.Println("  Themes (~/.config/bv/theme.yaml, then .bv/theme.yaml)")
+		fmt.Println("      Built-in: auto (follows terminal background), dark, light, solarized,")
+		fmt.Println("      high-contrast. Pick one, override colors, or define your own:")
+		fmt.Println("        theme: ocean")
+		fmt.Println("        themes:")
+		fmt.Println("          ocean:")
+		fmt.Println("            base: dark")
+		fmt.Println("            colors: {primary: \"#5FAFFF\", open: \"#00D787\"}")
+		fmt.Println("      --theme <name> or BV_THEME overrides the file; Ctrl+T cycles themes.")
+		fmt.Println("")
+		fmt.Println("  Accessibility")
+		fmt.Println("      --no-color (or NO_COLOR=1) renders without colors or text styling.")
+		fmt.Println("      --plain (or BV_PLAIN=1) reads well with screen readers: list rows are")
+		fmt.Println("      sentences like \"bv-12: Fix login (bug, P1, open)\" and box-drawing")
+		fmt.Println("      characters are left out. Pair with --theme high-contrast for low vision.")
+		fmt.Println("      --ascii swaps emoji, arrows, bars and borders for ASCII on terminals")
+		fmt.Println("      or fonts without them; it is on by default for non-UTF-8 locales and")
+		fmt.Println("      the Linux console (--ascii=false overrides).")
+		fmt.Println("")
+		fmt.Println("  Offline Mode")
+		fmt.Println("      --offline (or BV_OFFLINE=1) makes no network calls, for air-gapped")
+		fmt.Println("      machines: no update check, the local hash embedder for semantic search,")
+		fmt.Println("      no OTLP trace export. --update, --check-update, --pages, --pages-deploy,")
+		fmt.Println("      --pages-push to a remote and --publish fail with exit code 2.")
+		fmt.Println("")
+		fmt.Println("  Updates")
+		fmt.Println("      --update installs the latest release after checking it against the")
+		fmt.Println("      release's checksums.txt; --channel=beta includes pre-releases.")
+		fmt.Println("      To also check signatures, set BV_UPDATE_MINISIGN_KEY (key or key file)")
+		fmt.Println("      or BV_UPDATE_COSIGN_KEY (key file, URL or KMS URI); the update then")
+		fmt.Println("      needs minisign or cosign and a signed checksums.txt.")
+		fmt.Println("")
+		fmt.Println("  Split Layout (.bv/layout.yaml)")
+		fmt.Println("      Pin two panels side by side; written by the | picker and </> keys:")
+		fmt.Println("        left: list        # list, detail, board, graph, insights")
+		fmt.Println("        right: graph")
+		fmt.Println("        ratio: 0.45       # left share of the width, 0.2-0.8")
+		fmt.Println("")
 		fmt.Println("  Drift Detection Configuration (.bv/drift.yaml)")
 		fmt.Println("      Customize drift detection thresholds:")
 		fmt.Println("      - density_warning_pct: 50    # Warn if density +50%")
@@ -707,22 +1472,44 @@ func main() {
 		os.Exit(0)
 	}
 
+	if *robotSchemaFlag {
+		output, err := robotSchemaOutput(flag.Arg(0))
+		if err != nil {
+			fatalf(exitUsage, "Error: %v", err)
+		}
+		encoder := newRobotEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(output); err != nil {
+			fatalf(exitCodeFor(err), "Error encoding robot-schema: %v", err)
+		}
+		os.Exit(0)
+	}
+
 	if *versionFlag {
 		fmt.Printf("bv %s\n", version.Version)
 		os.Exit(0)
 	}
 
+	if *checkUpdateFlag || *updateFlag {
+		if !slices.Contains(updater.Channels, *updateChannel) {
+			fatalf(exitUsage, "Error: --channel must be one of: %s", strings.Join(updater.Channels, ", "))
+		}
+	}
+
 	// Handle --check-update (bv-182)
 	if *checkUpdateFlag {
-		available, newVersion, releaseURL, err := updater.CheckUpdateAvailable()
+		release, err := updater.GetRelease(*updateChannel)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error checking for updates: %v\n", err)
-			os.Exit(1)
+			fatalf(exitCodeFor(err), "Error checking for updates: %v", err)
 		}
-		if available {
-			fmt.Printf("New version available: %s (current: %s)\n", newVersion, version.Version)
-			fmt.Printf("Download: %s\n", releaseURL)
-			fmt.Println("\nRun 'bv --update' to update automatically")
+		if release.IsNewer() {
+			fmt.Printf("New version available: %s (current: %s)\n", release.TagName, version.Version)
+			fmt.Printf("Download: %s\n", release.HTMLURL)
+			if *updateChannel == updater.ChannelStable {
+				fmt.Println("\nRun 'bv --update' to update automatically")
+			} else {
+				fmt.Printf("\nRun 'bv --update --channel=%s' to update automatically\n", *updateChannel)
+			}
 		} else {
 			fmt.Printf("bv is up to date (version %s)\n", version.Version)
 		}
@@ -731,18 +1518,20 @@ func main() {
 
 	// Handle --update (bv-182)
 	if *updateFlag {
-		release, err := updater.GetLatestRelease()
+		release, err := updater.GetRelease(*updateChannel)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error fetching release info: %v\n", err)
-			os.Exit(1)
+			fatalf(exitCodeFor(err), "Error fetching release info: %v", err)
 		}
 
 		// Check if update is needed
-		available, newVersion, _, _ := updater.CheckUpdateAvailable()
-		if !available {
+		if !release.IsNewer() {
 			fmt.Printf("bv is already up to date (version %s)\n", version.Version)
 			os.Exit(0)
 		}
+		newVersion := release.TagName
+		if release.Prerelease {
+			newVersion += " (pre-release)"
+		}
 
 		// Confirm unless --yes is provided
 		if !*yesFlag {
@@ -758,11 +1547,11 @@ func main() {
 
 		result, err := updater.PerformUpdate(release, *yesFlag)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Update failed: %v\n", err)
+			msg := fmt.Sprintf("Update failed: %v", err)
 			if result != nil && result.BackupPath != "" {
-				fmt.Fprintf(os.Stderr, "Backup preserved at: %s\n", result.BackupPath)
+				msg += fmt.Sprintf("\nBackup preserved at: %s", result.BackupPath)
 			}
-			os.Exit(1)
+			fatalf(exitCodeFor(err), "%s", msg)
 		}
 
 		fmt.Println(result.Message)
@@ -776,43 +1565,132 @@ func main() {
 	// Handle --rollback (bv-182)
 	if *rollbackFlag {
 		if err := updater.Rollback(); err != nil {
-			fmt.Fprintf(os.Stderr, "Rollback failed: %v\n", err)
-			os.Exit(1)
+			fatalf(exitCodeFor(err), "Rollback failed: %v", err)
+		}
+		os.Exit(0)
+	}
+
+	// Handle --fix-graph
+	if *fixGraph {
+		beadsDir, err := loader.GetBeadsDir("")
+		if err != nil {
+			fatalf(exitCodeFor(err), "Error getting beads directory: %v", err)
+		}
+		beadsPath, err := loader.FindJSONLPath(beadsDir)
+		if err != nil {
+			fatalf(exitCodeFor(err), "Error: %v", err)
+		}
+
+		// Safe repairs need no confirmation; reversing edges changes meaning, so ask first
+		plan, err := loader.RepairGraph(beadsPath, loader.GraphRepairOptions{DryRun: true})
+		if err != nil {
+			fatalf(exitCodeFor(err), "Error: %v", err)
+		}
+		reverse := false
+		if len(plan.Inverted) > 0 {
+			fmt.Printf("Found %d inverted edge(s), a closed issue blocked by an open one:\n", len(plan.Inverted))
+			for _, fix := range plan.Inverted {
+				fmt.Printf("  %s (closed) blocked by %s (open)\n", fix.IssueID, fix.DependsOnID)
+			}
+			switch {
+			case *yesFlag:
+				reverse = true
+			case term.IsTerminal(int(os.Stdin.Fd())):
+				fmt.Print("Reverse them so the open issue depends on the closed one? [y/N]: ")
+				var response string
+				fmt.Scanln(&response)
+				response = strings.ToLower(strings.TrimSpace(response))
+				reverse = response == "y" || response == "yes"
+			default:
+				fmt.Println("Leaving them as is (rerun with --yes to reverse)")
+			}
+		}
+
+		report, err := loader.RepairGraph(beadsPath, loader.GraphRepairOptions{ReverseInverted: reverse})
+		if err != nil {
+			fatalf(exitCodeFor(err), "Repair failed: %v", err)
+		}
+		if len(report.Fixes) == 0 {
+			if len(report.Inverted) > 0 {
+				fmt.Printf("No other dependency problems in %s\n", beadsPath)
+			} else {
+				fmt.Printf("No dependency problems to fix in %s\n", beadsPath)
+			}
+			os.Exit(0)
+		}
+		for _, fix := range report.Fixes {
+			fmt.Println("  " + fix.String())
 		}
+		fmt.Printf("Fixed %d problem(s) in %d issue(s)\n", len(report.Fixes), report.IssuesChanged)
+		fmt.Printf("Backup saved to: %s\n", report.BackupPath)
 		os.Exit(0)
 	}
 
+	// Feedback profiles are per user, falling back to the shared team profile
+	if !analysis.ValidFeedbackMerge(*feedbackMerge) {
+		fatalf(exitUsage, "Error: --feedback-merge must be user, team or blend, got %q", *feedbackMerge)
+	}
+	feedbackUser := feedbackProfileUser(*feedbackAs)
+
 	// Handle feedback commands (bv-90)
-	if *feedbackAccept != "" || *feedbackIgnore != "" || *feedbackReset || *feedbackShow {
+	if *feedbackAccept != "" || *feedbackIgnore != "" || *feedbackReset || *feedbackShow || *feedbackExport != "" || *feedbackImport != "" {
 		beadsDir, err := loader.GetBeadsDir("")
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error getting beads directory: %v\n", err)
-			os.Exit(1)
+			fatalf(exitCodeFor(err), "Error getting beads directory: %v", err)
 		}
 
-		feedback, err := analysis.LoadFeedback(beadsDir)
+		feedback, err := analysis.LoadFeedbackProfile(beadsDir, feedbackUser)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error loading feedback: %v\n", err)
-			os.Exit(1)
+			fatalf(exitCodeFor(err), "Error loading feedback: %v", err)
 		}
 
 		if *feedbackReset {
 			feedback.Reset()
-			if err := feedback.Save(beadsDir); err != nil {
-				fmt.Fprintf(os.Stderr, "Error saving feedback: %v\n", err)
-				os.Exit(1)
+			if err := feedback.SaveProfile(beadsDir, feedbackUser); err != nil {
+				fatalf(exitCodeFor(err), "Error saving feedback: %v", err)
 			}
-			fmt.Println("Feedback data reset to defaults.")
+			fmt.Printf("Feedback profile %q reset to defaults.\n", feedback.Profile())
 			os.Exit(0)
 		}
 
 		if *feedbackShow {
-			feedbackJSON := feedback.ToJSON()
+			active, err := analysis.LoadActiveFeedback(beadsDir, feedbackUser, *feedbackMerge)
+			if err != nil {
+				fatalf(exitCodeFor(err), "Error loading feedback: %v", err)
+			}
+			feedbackJSON := active.ToJSON()
 			data, _ := json.MarshalIndent(feedbackJSON, "", "  ")
 			fmt.Println(string(data))
 			os.Exit(0)
 		}
 
+		if *feedbackExport != "" {
+			if err := exportFeedbackProfile(feedback, *feedbackExport); err != nil {
+				fatalf(exitCodeFor(err), "Error exporting feedback: %v", err)
+			}
+			if *feedbackExport != "-" {
+				fmt.Printf("Exported feedback profile %q to %s\n", feedback.Profile(), *feedbackExport)
+			}
+			os.Exit(0)
+		}
+
+		if *feedbackImport != "" {
+			if _, err := os.Stat(*feedbackImport); err != nil {
+				fatalf(exitCodeFor(err), "Error importing feedback: %v", err)
+			}
+			imported, err := analysis.ReadFeedbackFile(*feedbackImport)
+			if err != nil {
+				fatalf(exitCodeFor(err), "Error importing feedback: %v", err)
+			}
+			result := feedback.ImportFeedback(imported, *feedbackMerge)
+			if err := result.SaveProfile(beadsDir, feedbackUser); err != nil {
+				fatalf(exitCodeFor(err), "Error saving feedback: %v", err)
+			}
+			fmt.Printf("Imported %s into feedback profile %q\n", *feedbackImport, result.Profile())
+			fmt.Println(result.Summary())
+			os.Exit(0)
+		}
+
 		// For accept/ignore, we need to get the issue's score breakdown
 		if *feedbackAccept != "" || *feedbackIgnore != "" {
 			issueID := *feedbackAccept
@@ -825,8 +1703,7 @@ func main() {
 			// Load issues to get score breakdown
 			issues, err := loader.LoadIssues("")
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "Error loading issues: %v\n", err)
-				os.Exit(1)
+				fatalf(exitCodeFor(err), "Error loading issues: %v", err)
 			}
 
 			// Find the issue
@@ -839,8 +1716,7 @@ func main() {
 			}
 
 			if foundIssue == nil {
-				fmt.Fprintf(os.Stderr, "Issue not found: %s\n", issueID)
-				os.Exit(1)
+				fatalf(exitNotFound, "Issue not found: %s", issueID)
 			}
 
 			// Compute impact score for the issue to get breakdown
@@ -858,16 +1734,14 @@ func main() {
 			}
 
 			if err := feedback.RecordFeedback(issueID, action, score, breakdown); err != nil {
-				fmt.Fprintf(os.Stderr, "Error recording feedback: %v\n", err)
-				os.Exit(1)
+				fatalf(exitCodeFor(err), "Error recording feedback: %v", err)
 			}
 
-			if err := feedback.Save(beadsDir); err != nil {
-				fmt.Fprintf(os.Stderr, "Error saving feedback: %v\n", err)
-				os.Exit(1)
+			if err := feedback.SaveProfile(beadsDir, feedbackUser); err != nil {
+				fatalf(exitCodeFor(err), "Error saving feedback: %v", err)
 			}
 
-			fmt.Printf("Recorded %s feedback for %s (score: %.3f)\n", action, issueID, score)
+			fmt.Printf("Recorded %s feedback for %s in profile %q (score: %.3f)\n", action, issueID, feedback.Profile(), score)
 			fmt.Println(feedback.Summary())
 			os.Exit(0)
 		}
@@ -891,16 +1765,27 @@ func main() {
 			return summaries[i].Name < summaries[j].Name
 		})
 
-		output := struct {
-			Recipes []recipe.RecipeSummary `json:"recipes"`
-		}{
+		output := robotRecipesOutput{
 			Recipes: summaries,
 		}
 
-		encoder := json.NewEncoder(os.Stdout)
+		encoder := newRobotEncoder(os.Stdout)
 		encoder.SetIndent("", "  ")
 		if err := encoder.Encode(output); err != nil {
-			fmt.Fprintf(os.Stderr, "Error encoding recipes: %v\n", err)
+			fatalf(exitCodeFor(err), "Error encoding recipes: %v", err)
+		}
+		os.Exit(0)
+	}
+
+	// Handle --keymap-check (before loading issues)
+	if *keymapCheck {
+		cwd, _ := os.Getwd()
+		km, err := ui.LoadKeymap(ui.DefaultKeymapUserPath(), cwd)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		}
+		printKeymap(km)
+		if err != nil || len(km.Conflicts) > 0 {
 			os.Exit(1)
 		}
 		os.Exit(0)
@@ -919,25 +1804,43 @@ func main() {
 		}
 		bl, err := baseline.Load(baselinePath)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error loading baseline: %v\n", err)
-			os.Exit(1)
+			fatalf(exitCodeFor(err), "Error loading baseline: %v", err)
 		}
 		fmt.Print(bl.Summary())
 		os.Exit(0)
 	}
 
+	// Handle --statusline (before loading issues: a cache hit must stay fast)
+	if *statusline {
+		if !slices.Contains(statuslineFormats, *statuslineFormat) {
+			fatalf(exitUsage, "Error: --statusline-format must be one of %s", strings.Join(statuslineFormats, ", "))
+		}
+		beadsDir, err := loader.GetBeadsDir("")
+		if err != nil {
+			fatalf(exitCodeFor(err), "Error getting beads directory: %v", err)
+		}
+		format := *statuslineFormat
+		if *noColor || os.Getenv("NO_COLOR") != "" {
+			format = "plain"
+		}
+		if err := runStatusline(beadsDir, projectDir, format, *statuslineWidth, time.Now(), os.Stdout); err != nil {
+			fatalf(exitCodeFor(err), "Error building status line: %v", err)
+		}
+		os.Exit(0)
+	}
+
 	// Validate recipe name if provided (before loading issues)
 	var activeRecipe *recipe.Recipe
 	if *recipeName != "" {
 		activeRecipe = recipeLoader.Get(*recipeName)
 		if activeRecipe == nil {
-			fmt.Fprintf(os.Stderr, "Error: Unknown recipe '%s'\n\n", *recipeName)
-			fmt.Fprintln(os.Stderr, "Available recipes:")
+			var msg strings.Builder
+			fmt.Fprintf(&msg, "Error: Unknown recipe '%s'\n\nAvailable recipes:", *recipeName)
 			for _, name := range recipeLoader.Names() {
 				r := recipeLoader.Get(name)
-				fmt.Fprintf(os.Stderr, "  %-15s %s\n", name, r.Description)
+				fmt.Fprintf(&msg, "\n  %-15s %s", name, r.Description)
 			}
-			os.Exit(1)
+			fatalf(exitNotFound, "%s", msg.String())
 		}
 	}
 
@@ -956,14 +1859,12 @@ func main() {
 		}
 		cwd, err := os.Getwd()
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error getting current directory: %v\n", err)
-			os.Exit(1)
+			fatalf(exitCodeFor(err), "Error getting current directory: %v", err)
 		}
 		gitLoader := loader.NewGitLoader(cwd)
 		issues, err = gitLoader.LoadAt(*asOf)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error loading issues at %s: %v\n", *asOf, err)
-			os.Exit(1)
+			fatalf(exitCodeFor(err), "Error loading issues at %s: %v", *asOf, err)
 		}
 		// Resolve to commit SHA for metadata
 		asOfResolved, _ = gitLoader.ResolveRevision(*asOf)
@@ -980,8 +1881,7 @@ func main() {
 		// Load from workspace configuration
 		loadedIssues, results, err := workspace.LoadAllFromConfig(context.Background(), *workspaceConfig)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error loading workspace: %v\n", err)
-			os.Exit(1)
+			fatalf(exitCodeFor(err), "Error loading workspace: %v", err)
 		}
 		issues = loadedIssues
 		summary := workspace.Summarize(results)
@@ -1008,9 +1908,7 @@ func main() {
 		var err error
 		issues, err = loader.LoadIssues("")
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error loading beads: %v\n", err)
-			fmt.Fprintln(os.Stderr, "Make sure you are in a project initialized with 'bd init'.")
-			os.Exit(1)
+			fatalf(exitCodeFor(err), "Error loading beads: %v\nMake sure you are in a project initialized with 'bd init'.", err)
 		}
 		// Get beads file path for live reload (respects BEADS_DIR env var)
 		beadsDir, _ := loader.GetBeadsDir("")
@@ -1033,6 +1931,15 @@ func main() {
 
 	// Stable data hash for robot outputs (after repo filter but before recipes/TUI)
 	dataHash := analysis.ComputeDataHash(issues)
+	if deterministicOutput {
+		deterministicClock = dataClock(issues)
+	}
+	if (*journalFlag || journal.Enabled()) && !*robotJournalFlag {
+		robotJournal = newJournalRecorder(journal.Path(projectDir), dataHash)
+	}
+	if *provenanceFlag || outputSignKey != "" || provenance.Enabled() {
+		outputProvenance = newOutputProvenance(projectDir, dataHash)
+	}
 
 	// Label subgraph scoping (bv-122)
 	// When --label is specified, extract the label's subgraph and use it for all robot analysis.
@@ -1055,7 +1962,7 @@ func main() {
 			issues = subgraphIssues
 			// Compute label health for context
 			cfg := analysis.DefaultLabelHealthConfig()
-			allHealth := analysis.ComputeAllLabelHealth(issues, cfg, time.Now().UTC(), nil)
+			allHealth := analysis.ComputeAllLabelHealth(issues, cfg, robotNow().UTC(), nil)
 			for i := range allHealth.Labels {
 				if allHealth.Labels[i].Label == *labelScope {
 					labelScopeContext = &allHealth.Labels[i]
@@ -1067,38 +1974,32 @@ func main() {
 
 	// Handle semantic search CLI (bv-9gf.3)
 	if *robotSearch && *semanticQuery == "" {
-		fmt.Fprintln(os.Stderr, "Error: --robot-search requires --search \"query\"")
-		os.Exit(1)
+		fatalf(exitUsage, "Error: --robot-search requires --search \"query\"")
 	}
 	if *semanticQuery != "" {
 		embedCfg := search.EmbeddingConfigFromEnv()
 		searchCfg, err := search.SearchConfigFromEnv()
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
+			fatalf(exitUsage, "Error: %v", err)
 		}
 		searchCfg, err = applySearchConfigOverrides(searchCfg, *searchMode, *searchPreset, *searchWeights)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
+			fatalf(exitUsage, "Error: %v", err)
 		}
 
 		embedder, err := search.NewEmbedderFromConfig(embedCfg)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
+			fatalf(exitCodeFor(err), "Error: %v", err)
 		}
 
 		projectDir, err := os.Getwd()
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
+			fatalf(exitCodeFor(err), "Error: %v", err)
 		}
 		indexPath := search.DefaultIndexPath(projectDir, embedCfg)
 		idx, loaded, err := search.LoadOrNewVectorIndex(indexPath, embedder.Dim())
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
+			fatalf(exitCodeFor(err), "Error: %v", err)
 		}
 
 		docs := search.DocumentsFromIssues(issuesForSearch)
@@ -1111,13 +2012,11 @@ func main() {
 
 		syncStats, err := search.SyncVectorIndex(ctx, idx, embedder, docs, 64)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error building semantic index: %v\n", err)
-			os.Exit(1)
+			fatalf(exitCodeFor(err), "Error building semantic index: %v", err)
 		}
 		if !loaded || syncStats.Changed() {
 			if err := idx.Save(indexPath); err != nil {
-				fmt.Fprintf(os.Stderr, "Error saving semantic index: %v\n", err)
-				os.Exit(1)
+				fatalf(exitCodeFor(err), "Error saving semantic index: %v", err)
 			}
 		}
 
@@ -1126,8 +2025,7 @@ func main() {
 			if err == nil {
 				err = fmt.Errorf("embedder returned %d vectors for query", len(qvecs))
 			}
-			fmt.Fprintf(os.Stderr, "Error embedding query: %v\n", err)
-			os.Exit(1)
+			fatalf(exitCodeFor(err), "Error embedding query: %v", err)
 		}
 
 		limit := *searchLimit
@@ -1140,8 +2038,7 @@ func main() {
 		}
 		results, err := idx.SearchTopK(qvecs[0], fetchLimit)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error searching index: %v\n", err)
-			os.Exit(1)
+			fatalf(exitCodeFor(err), "Error searching index: %v", err)
 		}
 		results = search.ApplyShortQueryLexicalBoost(results, *semanticQuery, docs)
 		if isLikelyIssueID(*semanticQuery) {
@@ -1159,8 +2056,7 @@ func main() {
 		if searchCfg.Mode == search.SearchModeHybrid {
 			weights, presetName, err := resolveSearchWeights(searchCfg)
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-				os.Exit(1)
+				fatalf(exitCodeFor(err), "Error: %v", err)
 			}
 			weights = weights.Normalize()
 			weights = search.AdjustWeightsForQuery(weights, *semanticQuery)
@@ -1169,15 +2065,13 @@ func main() {
 
 			cache := search.NewMetricsCache(search.NewAnalyzerMetricsLoader(issuesForSearch))
 			if err := cache.Refresh(); err != nil {
-				fmt.Fprintf(os.Stderr, "Error computing hybrid metrics: %v\n", err)
-				os.Exit(1)
+				fatalf(exitCodeFor(err), "Error computing hybrid metrics: %v", err)
 			}
 
 			scorer := search.NewHybridScorer(weights, cache)
 			hybridResults, err = buildHybridScores(results, scorer)
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "Error scoring hybrid results: %v\n", err)
-				os.Exit(1)
+				fatalf(exitCodeFor(err), "Error scoring hybrid results: %v", err)
 			}
 			if isLikelyIssueID(*semanticQuery) {
 				hybridResults = promoteExactHybridResult(*semanticQuery, hybridResults)
@@ -1189,7 +2083,7 @@ func main() {
 
 		if *robotSearch {
 			out := robotSearchOutput{
-				GeneratedAt: time.Now().UTC().Format(time.RFC3339),
+				GeneratedAt: robotNow().UTC().Format(time.RFC3339),
 				DataHash:    dataHash,
 				Query:       *semanticQuery,
 				Provider:    embedCfg.Provider,
@@ -1236,8 +2130,7 @@ func main() {
 			}
 
 			if err := writeRobotSearchOutput(os.Stdout, out); err != nil {
-				fmt.Fprintf(os.Stderr, "Error encoding robot-search: %v\n", err)
-				os.Exit(1)
+				fatalf(exitCodeFor(err), "Error encoding robot-search: %v", err)
 			}
 			os.Exit(0)
 		}
@@ -1261,8 +2154,7 @@ func main() {
 	// Handle --pages wizard (bv-10g)
 	if *pagesWizard {
 		if err := runPagesWizard(issues, beadsPath); err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
+			fatalf(exitCodeFor(err), "Error: %v", err)
 		}
 		os.Exit(0)
 	}
@@ -1270,19 +2162,104 @@ func main() {
 	// Handle --preview-pages (before export since it doesn't need analysis)
 	if *previewPages != "" {
 		if err := runPreviewServer(*previewPages); err != nil {
-			fmt.Fprintf(os.Stderr, "Error starting preview server: %v\n", err)
-			os.Exit(1)
+			fatalf(exitCodeFor(err), "Error starting preview server: %v", err)
+		}
+		os.Exit(0)
+	}
+
+	// Handle --serve-pages
+	if *servePages != "" {
+		config := export.ServeConfig{
+			BundlePath: *servePages,
+			Bind:       *serveBind,
+			Port:       *servePort,
+			Username:   *serveUser,
+			Password:   os.Getenv("BV_SERVE_PASSWORD"),
+			Token:      os.Getenv("BV_SERVE_TOKEN"),
+			Gzip:       *serveGzip,
+		}
+		if *serveUser != "" && config.Password == "" {
+			fatalf(exitUsage, "Error: --serve-user requires BV_SERVE_PASSWORD")
+		}
+		if err := export.ServePages(context.Background(), config); err != nil {
+			fatalf(exitCodeFor(err), "Error serving pages: %v", err)
+		}
+		os.Exit(0)
+	}
+
+	// Handle --metrics-textfile
+	if *metricsTextfile != "" {
+		snapshot := metrics.Collect(issues, time.Now())
+		if *metricsTextfile == "-" {
+			if _, err := snapshot.WriteTo(os.Stdout); err != nil {
+				fatalf(exitCodeFor(err), "Error writing metrics: %v", err)
+			}
+			os.Exit(0)
+		}
+		if err := metrics.WriteTextfile(*metricsTextfile, snapshot); err != nil {
+			fatalf(exitCodeFor(err), "Error: %v", err)
+		}
+		fmt.Fprintf(os.Stderr, "Wrote metrics to %s\n", *metricsTextfile)
+		os.Exit(0)
+	}
+
+	// Handle --metrics-listen
+	if *metricsListen != "" {
+		load := func() ([]model.Issue, error) {
+			switch {
+			case *asOf != "":
+				return issues, nil // Historical snapshot doesn't change
+			case *workspaceConfig != "":
+				loaded, _, err := workspace.LoadAllFromConfig(context.Background(), *workspaceConfig)
+				return loaded, err
+			default:
+				return loader.LoadIssues("")
+			}
+		}
+		if err := metrics.Serve(context.Background(), *metricsListen, metrics.Handler(load)); err != nil {
+			fatalf(exitCodeFor(err), "Error serving metrics: %v", err)
 		}
 		os.Exit(0)
 	}
 
+	if *pagesPush && *exportPages == "" {
+		fatalf(exitUsage, "Error: --pages-push requires --export-pages <dir>")
+	}
+
+	var pagesDeployConfig *export.WizardConfig
+	if *pagesDeploy != "" {
+		if *exportPages == "" {
+			fatalf(exitUsage, "Error: --pages-deploy requires --export-pages <dir>")
+		}
+		cfg, err := pagesDeployWizardConfig(*pagesDeploy, *pagesProject, *pagesBranch, *pagesBucket, *pagesCloudFront)
+		if err != nil {
+			fatalf(exitUsage, "Error: %v", err)
+		}
+		pagesDeployConfig = cfg
+	}
+
+	pagesScope := export.PagesScope{
+		Labels:        splitLabelList(*pagesLabels),
+		ExcludeLabels: splitLabelList(*pagesExcludeLabels),
+	}
+	if !pagesScope.IsZero() && *exportPages == "" {
+		fatalf(exitUsage, "Error: --labels and --exclude-label scope --export-pages; use --label to scope robot analysis")
+	}
+
 	// Handle --export-pages (bv-73f)
 	if *exportPages != "" {
 		fmt.Println("Exporting static site...")
 		fmt.Printf("  → Loading %d issues\n", len(issues))
 
+		// Scope to the audience's labels; dependencies on the issues left
+		// out point at opaque placeholders
+		scopedIssues, placeholders := export.ScopeIssues(issues, pagesScope)
+		if !pagesScope.IsZero() {
+			fmt.Printf("  → Scoped to %d issues, %d hidden dependencies as placeholders\n", len(scopedIssues)-placeholders, placeholders)
+		}
+
 		// Filter closed issues if not requested
-		exportIssues := issues
+		exportIssues := scopedIssues
 		if !*pagesIncludeClosed {
 			var openIssues []model.Issue
 			for _, issue := range issues {
@@ -1301,7 +2278,7 @@ func main() {
 			hookLoader := hooks.NewLoader(hooks.WithProjectDir(cwd))
 			if err := hookLoader.Load(); err != nil {
 				fmt.Printf("  → Warning: failed to load hooks: %v\n", err)
-			} else if hookLoader.HasHooks() {
+			} else if hookLoader.HasHooksFor(hooks.PreExport, hooks.PostExport) {
 				fmt.Println("  → Running pre-export hooks...")
 				ctx := hooks.ExportContext{
 					ExportPath:   *exportPages,
@@ -1315,8 +2292,7 @@ func main() {
 				})
 
 				if err := pagesExecutor.RunPreExport(); err != nil {
-					fmt.Fprintf(os.Stderr, "Error: pre-export hook failed: %v\n", err)
-					os.Exit(1)
+					fatalf(exitCodeFor(err), "Error: pre-export hook failed: %v", err)
 				}
 			}
 		}
@@ -1357,31 +2333,38 @@ func main() {
 			exporter.Config.Title = *pagesTitle
 		}
 
+		// Build into a staging dir, then sync only changed files into the
+		// output so re-exports (and --pages-push) touch as little as possible
+		stageDir, err := os.MkdirTemp("", "bv-pages-stage-*")
+		if err != nil {
+			fatalf(exitCodeFor(err), "Error creating staging dir: %v", err)
+		}
+
 		// Export SQLite database
 		fmt.Println("  → Writing database and JSON files...")
-		if err := exporter.Export(*exportPages); err != nil {
-			fmt.Fprintf(os.Stderr, "Error exporting: %v\n", err)
-			os.Exit(1)
+		if err := exporter.Export(stageDir); err != nil {
+			os.RemoveAll(stageDir)
+			fatalf(exitCodeFor(err), "Error exporting: %v", err)
 		}
 
 		// Copy viewer assets
 		fmt.Println("  → Copying viewer assets...")
-		if err := copyViewerAssets(*exportPages, *pagesTitle); err != nil {
-			fmt.Fprintf(os.Stderr, "Error copying assets: %v\n", err)
-			os.Exit(1)
+		if err := copyViewerAssets(stageDir, *pagesTitle); err != nil {
+			os.RemoveAll(stageDir)
+			fatalf(exitCodeFor(err), "Error copying assets: %v", err)
 		}
 
 		// Generate README.md with project stats (useful for GitHub Pages deployment)
 		fmt.Println("  → Generating README.md...")
-		if err := generateREADME(*exportPages, *pagesTitle, "", exportIssues, &triage, stats); err != nil {
+		if err := generateREADME(stageDir, *pagesTitle, "", exportIssues, &triage, stats); err != nil {
 			fmt.Printf("  → Warning: failed to generate README: %v\n", err)
 		}
 
 		// Export history data for time-travel feature (bv-z38b)
 		if *pagesIncludeHistory {
 			fmt.Println("  → Generating time-travel history data...")
-			if historyReport, err := generateHistoryForExport(issues); err == nil && historyReport != nil {
-				historyPath := filepath.Join(*exportPages, "data", "history.json")
+			if historyReport, err := generateHistoryForExport(scopedIssues); err == nil && historyReport != nil {
+				historyPath := filepath.Join(stageDir, "data", "history.json")
 				if historyJSON, err := json.MarshalIndent(historyReport, "", "  "); err == nil {
 					if err := os.WriteFile(historyPath, historyJSON, 0644); err != nil {
 						fmt.Printf("  → Warning: failed to write history.json: %v\n", err)
@@ -1394,6 +2377,21 @@ func main() {
 			}
 		}
 
+		// Provenance goes in the staged site, so it is pushed and deployed too
+		if manifest, err := sealExport(stageDir); err != nil {
+			os.RemoveAll(stageDir)
+			fatalf(exitCodeFor(err), "Error writing provenance: %v", err)
+		} else if manifest != "" {
+			fmt.Printf("  → %s (SHA-256 of every file)\n", filepath.Base(manifest))
+		}
+
+		sync, err := export.SyncPagesDir(stageDir, *exportPages)
+		if err != nil {
+			os.RemoveAll(stageDir)
+			fatalf(exitCodeFor(err), "Error writing export: %v", err)
+		}
+		fmt.Printf("  → %d files written, %d unchanged, %d removed\n", len(sync.Written), len(sync.Unchanged), len(sync.Removed))
+
 		// Run post-export hooks (bv-qjc.3)
 		if pagesExecutor != nil {
 			fmt.Println("  → Running post-export hooks...")
@@ -1409,30 +2407,56 @@ func main() {
 			}
 		}
 
-		fmt.Println("")
-		fmt.Printf("✓ Static site exported to: %s\n", *exportPages)
-		fmt.Println("")
-		fmt.Println("To preview locally:")
-		fmt.Printf("  bv --preview-pages %s\n", *exportPages)
-		fmt.Println("")
-		fmt.Println("Or open in browser:")
-		fmt.Printf("  open %s/index.html\n", *exportPages)
-		os.Exit(0)
+		if *pagesPush {
+			fmt.Printf("  → Pushing changes to %s (%s)...\n", cmp.Or(*pagesBranch, export.DefaultPagesBranch), *pagesRemote)
+			pushResult, err := export.PushPagesBranch(*exportPages, export.PagesPushConfig{
+				Remote:  *pagesRemote,
+				Branch:  *pagesBranch,
+				RepoDir: cwd,
+			})
+			if err != nil {
+				os.RemoveAll(stageDir)
+				fatalf(exitCodeFor(err), "Error pushing pages: %v", err)
+			}
+			if pushResult.Pushed {
+				fmt.Printf("  → Pushed %s to %s: %d files changed, %d removed\n",
+					pushResult.Commit[:min(7, len(pushResult.Commit))], pushResult.Branch, len(pushResult.Sync.Written), len(pushResult.Sync.Removed))
+			} else {
+				fmt.Printf("  → %s is already up to date; nothing pushed\n", pushResult.Branch)
+			}
+		}
+
+		if pagesDeployConfig != nil {
+			fmt.Printf("  → Deploying to %s...\n", pagesDeployConfig.DeployTarget)
+			deployResult, err := export.DeployBundle(pagesDeployConfig, *exportPages, false)
+			if err != nil {
+				os.RemoveAll(stageDir)
+				fatalf(exitCodeFor(err), "Error deploying pages: %v", err)
+			}
+			if deployResult.PagesURL != "" {
+				fmt.Printf("  → Live at %s\n", deployResult.PagesURL)
+			}
+		}
+
+		os.RemoveAll(stageDir)
+		fmt.Println("")
+		fmt.Printf("✓ Static site exported to: %s\n", *exportPages)
+		fmt.Println("")
+		fmt.Println("To preview locally:")
+		fmt.Printf("  bv --preview-pages %s\n", *exportPages)
+		fmt.Println("")
+		fmt.Println("Or open in browser:")
+		fmt.Printf("  open %s/index.html\n", *exportPages)
+		os.Exit(0)
 	}
 
 	// Handle --robot-label-health
 	if *robotLabelHealth {
 		cfg := analysis.DefaultLabelHealthConfig()
-		results := analysis.ComputeAllLabelHealth(issues, cfg, time.Now().UTC(), nil)
+		results := analysis.ComputeAllLabelHealth(issues, cfg, robotNow().UTC(), nil)
 
-		output := struct {
-			GeneratedAt    string                       `json:"generated_at"`
-			DataHash       string                       `json:"data_hash"`
-			AnalysisConfig analysis.LabelHealthConfig   `json:"analysis_config"`
-			Results        analysis.LabelAnalysisResult `json:"results"`
-			UsageHints     []string                     `json:"usage_hints"`
-		}{
-			GeneratedAt:    time.Now().UTC().Format(time.RFC3339),
+		output := robotLabelHealthOutput{
+			GeneratedAt:    robotNow().UTC().Format(time.RFC3339),
 			DataHash:       dataHash,
 			AnalysisConfig: cfg,
 			Results:        results,
@@ -1443,11 +2467,10 @@ func main() {
 				"jq '.results.attention_needed' - Labels needing attention",
 			},
 		}
-		encoder := json.NewEncoder(os.Stdout)
+		encoder := newRobotEncoder(os.Stdout)
 		encoder.SetIndent("", "  ")
 		if err := encoder.Encode(output); err != nil {
-			fmt.Fprintf(os.Stderr, "Error encoding label health: %v\n", err)
-			os.Exit(1)
+			fatalf(exitCodeFor(err), "Error encoding label health: %v", err)
 		}
 		os.Exit(0)
 	}
@@ -1456,14 +2479,23 @@ func main() {
 	if *robotLabelFlow {
 		cfg := analysis.DefaultLabelHealthConfig()
 		flow := analysis.ComputeCrossLabelFlow(issues, cfg)
-		output := struct {
-			GeneratedAt string                     `json:"generated_at"`
-			DataHash    string                     `json:"data_hash"`
-			Flow        analysis.CrossLabelFlow    `json:"flow"`
-			Config      analysis.LabelHealthConfig `json:"analysis_config"`
-			UsageHints  []string                   `json:"usage_hints"`
-		}{
-			GeneratedAt: time.Now().UTC().Format(time.RFC3339),
+		switch strings.ToLower(*labelFlowFormat) {
+		case "", "json":
+		case "sankey", "mermaid":
+			fmt.Print(export.LabelFlowSankey(flow))
+			os.Exit(0)
+		case "html":
+			page, err := export.LabelFlowSankeyHTML(flow, "")
+			if err != nil {
+				fatalf(exitCodeFor(err), "Error rendering label flow: %v", err)
+			}
+			fmt.Print(page)
+			os.Exit(0)
+		default:
+			fatalf(exitUsage, "Invalid --format %q for --robot-label-flow (use json, sankey or html)", *labelFlowFormat)
+		}
+		output := robotLabelFlowOutput{
+			GeneratedAt: robotNow().UTC().Format(time.RFC3339),
 			DataHash:    dataHash,
 			Flow:        flow,
 			Config:      cfg,
@@ -1471,13 +2503,13 @@ func main() {
 				"jq '.flow.bottleneck_labels' - labels blocking the most others",
 				"jq '.flow.dependencies[] | select(.issue_count > 0) | {from:.from_label,to:.to_label,count:.issue_count}'",
 				"jq '.flow.flow_matrix' - raw matrix (row=from, col=to, align with .flow.labels)",
+				"--format=sankey or --format=html - the same flow as a Sankey diagram",
 			},
 		}
-		encoder := json.NewEncoder(os.Stdout)
+		encoder := newRobotEncoder(os.Stdout)
 		encoder.SetIndent("", "  ")
 		if err := encoder.Encode(output); err != nil {
-			fmt.Fprintf(os.Stderr, "Error encoding label flow: %v\n", err)
-			os.Exit(1)
+			fatalf(exitCodeFor(err), "Error encoding label flow: %v", err)
 		}
 		os.Exit(0)
 	}
@@ -1485,7 +2517,7 @@ func main() {
 	// Handle --robot-label-attention (bv-121)
 	if *robotLabelAttention {
 		cfg := analysis.DefaultLabelHealthConfig()
-		result := analysis.ComputeLabelAttentionScores(issues, cfg, time.Now().UTC())
+		result := analysis.ComputeLabelAttentionScores(issues, cfg, robotNow().UTC())
 
 		// Apply limit
 		limit := *attentionLimit
@@ -1497,28 +2529,9 @@ func main() {
 		}
 
 		// Build limited output
-		type AttentionOutput struct {
-			GeneratedAt string `json:"generated_at"`
-			DataHash    string `json:"data_hash"`
-			Limit       int    `json:"limit"`
-			TotalLabels int    `json:"total_labels"`
-			Labels      []struct {
-				Rank            int     `json:"rank"`
-				Label           string  `json:"label"`
-				AttentionScore  float64 `json:"attention_score"`
-				NormalizedScore float64 `json:"normalized_score"`
-				Reason          string  `json:"reason"`
-				OpenCount       int     `json:"open_count"`
-				BlockedCount    int     `json:"blocked_count"`
-				StaleCount      int     `json:"stale_count"`
-				PageRankSum     float64 `json:"pagerank_sum"`
-				VelocityFactor  float64 `json:"velocity_factor"`
-			} `json:"labels"`
-			UsageHints []string `json:"usage_hints"`
-		}
 
 		output := AttentionOutput{
-			GeneratedAt: time.Now().UTC().Format(time.RFC3339),
+			GeneratedAt: robotNow().UTC().Format(time.RFC3339),
 			DataHash:    dataHash,
 			Limit:       limit,
 			TotalLabels: result.TotalLabels,
@@ -1558,11 +2571,10 @@ func main() {
 			})
 		}
 
-		encoder := json.NewEncoder(os.Stdout)
+		encoder := newRobotEncoder(os.Stdout)
 		encoder.SetIndent("", "  ")
 		if err := encoder.Encode(output); err != nil {
-			fmt.Fprintf(os.Stderr, "Error encoding label attention: %v\n", err)
-			os.Exit(1)
+			fatalf(exitCodeFor(err), "Error encoding label attention: %v", err)
 		}
 		os.Exit(0)
 	}
@@ -1579,29 +2591,41 @@ func main() {
 			format = export.GraphFormatDOT
 		case "mermaid":
 			format = export.GraphFormatMermaid
+		case "graphml":
+			format = export.GraphFormatGraphML
+		case "gexf":
+			format = export.GraphFormatGEXF
 		default:
 			format = export.GraphFormatJSON
 		}
 
+		switch strings.ToLower(*graphCluster) {
+		case "", export.MermaidClusterLabel, export.MermaidClusterTrack:
+		default:
+			fatalf(exitUsage, "Invalid --graph-cluster %q (use label or track)", *graphCluster)
+		}
+
 		config := export.GraphExportConfig{
 			Format:   format,
 			Label:    *labelScope,
 			Root:     *graphRoot,
 			Depth:    *graphDepth,
 			DataHash: dataHash,
+
+			Cluster:      *graphCluster,
+			LinkTemplate: *linkTemplate,
+			MaxNodes:     *graphMaxNodes,
 		}
 
 		result, err := export.ExportGraph(issues, &stats, config)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error exporting graph: %v\n", err)
-			os.Exit(1)
+			fatalf(exitCodeFor(err), "Error exporting graph: %v", err)
 		}
 
-		encoder := json.NewEncoder(os.Stdout)
+		encoder := newRobotEncoder(os.Stdout)
 		encoder.SetIndent("", "  ")
 		if err := encoder.Encode(result); err != nil {
-			fmt.Fprintf(os.Stderr, "Error encoding graph: %v\n", err)
-			os.Exit(1)
+			fatalf(exitCodeFor(err), "Error encoding graph: %v", err)
 		}
 		os.Exit(0)
 	}
@@ -1627,8 +2651,7 @@ func main() {
 		}
 
 		if len(exportIssues) == 0 {
-			fmt.Fprintf(os.Stderr, "No issues to export (check filters)\n")
-			os.Exit(1)
+			fatalf(exitNotFound, "No issues to export (check filters)")
 		}
 
 		// Get project name from current directory
@@ -1661,10 +2684,10 @@ func main() {
 			}
 			outputPath, err := export.GenerateInteractiveGraphHTML(opts)
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "Error exporting interactive graph: %v\n", err)
-				os.Exit(1)
+				fatalf(exitCodeFor(err), "Error exporting interactive graph: %v", err)
 			}
 			fmt.Printf("✓ Interactive graph exported to %s (%d nodes, %d edges)\n", outputPath, len(exportIssues), stats.EdgeCount)
+			sealExportOrExit(outputPath)
 			os.Exit(0)
 		}
 
@@ -1673,6 +2696,7 @@ func main() {
 			Path:     *exportGraph,
 			Title:    *graphTitle,
 			Preset:   *graphPreset,
+			Layout:   *graphStyle,
 			Issues:   exportIssues,
 			Stats:    &stats,
 			DataHash: dataHash,
@@ -1680,53 +2704,21 @@ func main() {
 
 		err := export.SaveGraphSnapshot(opts)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error exporting graph snapshot: %v\n", err)
-			os.Exit(1)
+			fatalf(exitCodeFor(err), "Error exporting graph snapshot: %v", err)
 		}
 
 		fmt.Printf("✓ Graph exported to %s (%d nodes) - tip: use .html for interactive graphs\n", *exportGraph, len(exportIssues))
+		sealExportOrExit(*exportGraph)
 		os.Exit(0)
 	}
 
 	// Handle --robot-alerts (drift + proactive)
 	if *robotAlerts {
 		projectDir, _ := os.Getwd()
-		driftConfig, err := drift.LoadConfig(projectDir)
+		driftResult, err := computeProjectAlerts(issues, projectDir, robotNow())
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error loading drift config: %v\n", err)
-			os.Exit(1)
-		}
-
-		analyzer := analysis.NewAnalyzer(issues)
-		stats := analyzer.Analyze()
-
-		openCount, closedCount, blockedCount := 0, 0, 0
-		for _, issue := range issues {
-			switch issue.Status {
-			case model.StatusClosed:
-				closedCount++
-			case model.StatusBlocked:
-				blockedCount++
-			default:
-				openCount++
-			}
+			fatalf(exitCodeFor(err), "Error: %v", err)
 		}
-		curStats := baseline.GraphStats{
-			NodeCount:       stats.NodeCount,
-			EdgeCount:       stats.EdgeCount,
-			Density:         stats.Density,
-			OpenCount:       openCount,
-			ClosedCount:     closedCount,
-			BlockedCount:    blockedCount,
-			CycleCount:      len(stats.Cycles()),
-			ActionableCount: len(analyzer.GetActionableIssues()),
-		}
-		bl := &baseline.Baseline{Stats: curStats}
-		cur := &baseline.Baseline{Stats: curStats, Cycles: stats.Cycles()}
-
-		calc := drift.NewCalculator(bl, cur, driftConfig)
-		calc.SetIssues(issues)
-		driftResult := calc.Calculate()
 
 		// Apply optional filters
 		filtered := driftResult.Alerts[:0]
@@ -1753,24 +2745,16 @@ func main() {
 		}
 		driftResult.Alerts = filtered
 
-		output := struct {
-			GeneratedAt string        `json:"generated_at"`
-			DataHash    string        `json:"data_hash"`
-			Alerts      []drift.Alert `json:"alerts"`
-			Summary     struct {
-				Total    int `json:"total"`
-				Critical int `json:"critical"`
-				Warning  int `json:"warning"`
-				Info     int `json:"info"`
-			} `json:"summary"`
-			UsageHints []string `json:"usage_hints"`
-		}{
-			GeneratedAt: time.Now().UTC().Format(time.RFC3339),
+		output := robotAlertsOutput{
+			GeneratedAt: robotNow().UTC().Format(time.RFC3339),
 			DataHash:    dataHash,
 			Alerts:      driftResult.Alerts,
 			UsageHints: []string{
 				"--severity=warning --alert-type=stale_issue   # stale warnings only",
 				"--alert-type=blocking_cascade                 # high-unblock opportunities",
+				"--alert-type=activity_anomaly                 # created/closed/blocked spikes and droughts, with .series",
+				"--alert-type=goal_at_risk                     # goals in .bv/goals.yaml unlikely to make their target",
+				"--alert-type=review_overdue                   # issues waiting too long in review",
 				"jq '.alerts | map(.issue_id)'                # list impacted issues",
 			},
 		}
@@ -1786,11 +2770,25 @@ func main() {
 			output.Summary.Total++
 		}
 
-		encoder := json.NewEncoder(os.Stdout)
+		encoder := newRobotEncoder(os.Stdout)
 		encoder.SetIndent("", "  ")
 		if err := encoder.Encode(output); err != nil {
-			fmt.Fprintf(os.Stderr, "Error encoding alerts: %v\n", err)
-			os.Exit(1)
+			fatalf(exitCodeFor(err), "Error encoding alerts: %v", err)
+		}
+		os.Exit(0)
+	}
+
+	// Handle --digest
+	if *digest != "" {
+		if !slices.Contains(digestPeriods, *digest) {
+			fatalf(exitUsage, "Error: --digest must be one of %s", strings.Join(digestPeriods, ", "))
+		}
+		if !slices.Contains(digestFormats, *digestFormat) {
+			fatalf(exitUsage, "Error: --digest-format must be one of %s", strings.Join(digestFormats, ", "))
+		}
+		projectDir, _ := os.Getwd()
+		if err := runDigest(issues, projectDir, *digest, *digestFormat, robotNow(), os.Stdout); err != nil {
+			fatalf(exitCodeFor(err), "Error: %v", err)
 		}
 		os.Exit(0)
 	}
@@ -1814,17 +2812,230 @@ func main() {
 		case "":
 			// All types
 		default:
-			fmt.Fprintf(os.Stderr, "Invalid suggest-type: %s (use: duplicate, dependency, label, cycle)\n", *suggestType)
-			os.Exit(1)
+			fatalf(exitUsage, "Invalid suggest-type: %s (use: duplicate, dependency, label, cycle)", *suggestType)
 		}
 
 		output := analysis.GenerateRobotSuggestOutput(issues, config, dataHash)
 
-		encoder := json.NewEncoder(os.Stdout)
+		encoder := newRobotEncoder(os.Stdout)
 		encoder.SetIndent("", "  ")
 		if err := encoder.Encode(output); err != nil {
-			fmt.Fprintf(os.Stderr, "Error encoding suggestions: %v\n", err)
-			os.Exit(1)
+			fatalf(exitCodeFor(err), "Error encoding suggestions: %v", err)
+		}
+		os.Exit(0)
+	}
+
+	// Handle --robot-suggest-deps: missing links from semantic similarity and file history
+	if *robotSuggestDeps {
+		cwd, err := os.Getwd()
+		if err != nil {
+			fatalf(exitCodeFor(err), "Error getting current directory: %v", err)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		vectors, err := issueVectors(ctx, cwd, issues)
+		if err != nil {
+			fatalf(exitCodeFor(err), "Error: %v", err)
+		}
+
+		// File history is optional: without git, rank on text alone
+		var files map[string][]string
+		if correlation.ValidateRepository(cwd) == nil {
+			if beadsDir, err := loader.GetBeadsDir(""); err == nil {
+				if beadsPath, err := loader.FindJSONLPath(beadsDir); err == nil {
+					beadInfos := make([]correlation.BeadInfo, len(issues))
+					for i, issue := range issues {
+						beadInfos[i] = correlation.BeadInfo{
+							ID:     issue.ID,
+							Title:  issue.Title,
+							Status: string(issue.Status),
+							Events: issue.Events,
+						}
+					}
+					correlator := correlation.NewIndexedCorrelator(cwd, beadsPath)
+					if report, err := correlator.GenerateReport(beadInfos, correlation.CorrelatorOptions{Limit: *historyLimit}); err == nil {
+						files = report.BeadFiles()
+					}
+				}
+			}
+		}
+
+		config := analysis.DefaultSemanticDepConfig()
+		if *suggestConfidence > 0 {
+			config.MinConfidence = *suggestConfidence
+		}
+		if *robotMaxResults > 0 {
+			config.MaxSuggestions = *robotMaxResults
+		}
+		suggestions := analysis.SuggestSemanticDependencies(issues, vectors, files, config)
+		if suggestions == nil {
+			suggestions = []analysis.SemanticDepSuggestion{}
+		}
+
+		output := robotSuggestDepsOutput{
+			GeneratedAt: robotNow().UTC().Format(time.RFC3339),
+			DataHash:    dataHash,
+			AsOf:        *asOf,
+			AsOfCommit:  asOfResolved,
+			FileHistory: files != nil,
+			Suggestions: suggestions,
+			UsageHints: []string{
+				"jq -r '.suggestions[] | select(.confidence >= 0.7) | .command' - Commands for confident links",
+				"jq '.suggestions[] | select(.shared_files) | {from, to, shared_files}' - Pairs backed by file history",
+				"--suggest-confidence=0.7 - Only report confident suggestions",
+			},
+		}
+		encoder := newRobotEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(output); err != nil {
+			fatalf(exitCodeFor(err), "Error encoding robot-suggest-deps: %v", err)
+		}
+		os.Exit(0)
+	}
+
+	// Handle --robot-suggest-labels
+	if *robotSuggestLabels {
+		config := analysis.DefaultLabelClassifierConfig()
+		if *suggestConfidence > 0 {
+			config.MinConfidence = *suggestConfidence
+		}
+		report := analysis.SuggestLabelsForUnlabeled(issues, config)
+		if *robotMaxResults > 0 && len(report.Issues) > *robotMaxResults {
+			report.Issues = report.Issues[:*robotMaxResults]
+		}
+
+		output := robotSuggestLabelsOutput{
+			GeneratedAt:          robotNow().UTC().Format(time.RFC3339),
+			DataHash:             dataHash,
+			AsOf:                 *asOf,
+			AsOfCommit:           asOfResolved,
+			UnlabeledLabelReport: report,
+			UsageHints: []string{
+				"jq -r '.issues[].suggestions[0] | select(.confidence >= 0.7) | .command' - Apply each issue's confident top label",
+				"jq '.issues[] | {issue_id, labels: [.suggestions[].label]}' - Suggested labels per issue",
+				"--suggest-confidence=0.6 - Only report confident suggestions",
+			},
+		}
+		encoder := newRobotEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(output); err != nil {
+			fatalf(exitCodeFor(err), "Error encoding robot-suggest-labels: %v", err)
+		}
+		os.Exit(0)
+	}
+
+	// Handle --robot-duplicates
+	if *robotDuplicates {
+		cwd, err := os.Getwd()
+		if err != nil {
+			fatalf(exitCodeFor(err), "Error getting current directory: %v", err)
+		}
+		searchCfg, err := search.LoadProjectConfig(cwd)
+		if err != nil {
+			fatalf(exitCodeFor(err), "Error: %v", err)
+		}
+		config := analysis.DefaultNearDuplicateConfig()
+		if searchCfg.Duplicates.Threshold > 0 {
+			config.Threshold = searchCfg.Duplicates.Threshold
+		}
+		if *duplicateThreshold > 0 {
+			config.Threshold = *duplicateThreshold
+		}
+		if *robotMaxResults > 0 {
+			config.MaxPairs = *robotMaxResults
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		vectors, err := issueVectors(ctx, cwd, issues)
+		if err != nil {
+			fatalf(exitCodeFor(err), "Error: %v", err)
+		}
+
+		pairs := analysis.FindNearDuplicates(issues, vectors, config)
+		if pairs == nil {
+			pairs = []analysis.NearDuplicatePair{}
+		}
+
+		output := robotDuplicatesOutput{
+			GeneratedAt: robotNow().UTC().Format(time.RFC3339),
+			DataHash:    dataHash,
+			AsOf:        *asOf,
+			AsOfCommit:  asOfResolved,
+			Threshold:   config.Threshold,
+			Pairs:       pairs,
+			UsageHints: []string{
+				"jq '.pairs[] | {issue1, issue2, score}' - Likely duplicates, best first",
+				"jq -r '.pairs[] | select(.command) | .command' - Link open duplicates as related",
+				"--duplicate-threshold=0.9 - Only near-identical issues (or set duplicates.threshold in .bv/search.yaml)",
+			},
+		}
+		encoder := newRobotEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(output); err != nil {
+			fatalf(exitCodeFor(err), "Error encoding robot-duplicates: %v", err)
+		}
+		os.Exit(0)
+	}
+
+	// Handle --robot-policies
+	if *robotPolicies {
+		cwd, err := os.Getwd()
+		if err != nil {
+			fatalf(exitCodeFor(err), "Error getting current directory: %v", err)
+		}
+		policyCfg, err := policy.LoadConfig(cwd)
+		if err != nil {
+			fatalf(exitCodeFor(err), "Error: %v", err)
+		}
+		if *applyPolicies && beadsPath == "" {
+			fatalf(exitUsage, "Error: --apply needs a single beads file (not available with --as-of or --workspace)")
+		}
+
+		now := robotNow()
+		matches := policy.Evaluate(policyCfg, issues, now)
+		if *applyPolicies && len(matches) > 0 {
+			journal, err := loader.OpenJournal(loader.DefaultJournalPath(cwd), 0)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: changes will not be undoable: %v\n", err)
+				journal = nil
+			}
+			matches = policy.Apply(beadsPath, matches, journal, now)
+		}
+		if matches == nil {
+			matches = []policy.Match{}
+		}
+		// With nothing configured, include a starting point to save as config_path
+		var example string
+		if len(policyCfg.Policies) == 0 {
+			example = policy.ExampleConfig()
+		}
+
+		output := robotPoliciesOutput{
+			GeneratedAt: now.UTC().Format(time.RFC3339),
+			DataHash:    dataHash,
+			AsOf:        *asOf,
+			AsOfCommit:  asOfResolved,
+			DryRun:      !*applyPolicies,
+			ConfigPath:  policy.ConfigPath(cwd),
+			Policies:    policyCfg.Policies,
+			Matches:     matches,
+			Example:     example,
+			UsageHints: []string{
+				"jq '.matches[] | {policy, issue_id, change, reasons}' - What each policy would do",
+				"jq -r '.matches[].command' - Equivalent bd commands",
+				"jq -r .example_config > .bv/policies.yaml - Start from the example policies",
+				"--robot-policies --apply - Write the changes to the beads file",
+			},
+		}
+		if output.Policies == nil {
+			output.Policies = []policy.Policy{}
+		}
+		encoder := newRobotEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(output); err != nil {
+			fatalf(exitCodeFor(err), "Error encoding robot-policies: %v", err)
 		}
 		os.Exit(0)
 	}
@@ -1848,7 +3059,7 @@ func main() {
 		openCount, closedCount, blockedCount := 0, 0, 0
 		for _, issue := range issues {
 			switch issue.Status {
-			case model.StatusOpen, model.StatusInProgress:
+			case model.StatusOpen, model.StatusInProgress, model.StatusInReview:
 				openCount++
 			case model.StatusClosed:
 				closedCount++
@@ -1887,72 +3098,39 @@ func main() {
 
 		bl := baseline.New(graphStats, topMetrics, cycles, *saveBaseline)
 
+		snapshotPayload := snapshotHookPayload{BaselinePath: baselinePath, Description: *saveBaseline, Stats: graphStats}
+		if !*noHooks {
+			if err := runEventHooks(projectDir, hooks.PreSnapshot, snapshotPayload, os.Stderr, envRobot); err != nil {
+				fatalf(exitError, "Error: %v", err)
+			}
+		}
+
 		if err := bl.Save(baselinePath); err != nil {
-			fmt.Fprintf(os.Stderr, "Error saving baseline: %v\n", err)
-			os.Exit(1)
+			fatalf(exitCodeFor(err), "Error saving baseline: %v", err)
 		}
 
 		fmt.Printf("Baseline saved to %s\n", baselinePath)
 		fmt.Print(bl.Summary())
+
+		if !*noHooks {
+			_ = runEventHooks(projectDir, hooks.PostSnapshot, snapshotPayload, os.Stderr, envRobot)
+		}
 		os.Exit(0)
 	}
 
 	// Handle --check-drift
 	if *checkDrift {
 		if !baseline.Exists(baselinePath) {
-			fmt.Fprintln(os.Stderr, "Error: No baseline found.")
-			fmt.Fprintln(os.Stderr, "Create one with: bv --save-baseline \"description\"")
-			os.Exit(1)
+			fatalf(exitNotFound, "Error: No baseline found.\nCreate one with: bv --save-baseline \"description\"")
 		}
 
 		bl, err := baseline.Load(baselinePath)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error loading baseline: %v\n", err)
-			os.Exit(1)
-		}
-
-		// Run analysis on current issues
-		analyzer := analysis.NewAnalyzer(issues)
-		if *forceFullAnalysis {
-			cfg := analysis.FullAnalysisConfig()
-			analyzer.SetConfig(&cfg)
-		}
-		stats := analyzer.Analyze()
-
-		// Compute status counts from issues
-		openCount, closedCount, blockedCount := 0, 0, 0
-		for _, issue := range issues {
-			switch issue.Status {
-			case model.StatusOpen, model.StatusInProgress:
-				openCount++
-			case model.StatusClosed:
-				closedCount++
-			case model.StatusBlocked:
-				blockedCount++
-			}
+			fatalf(exitCodeFor(err), "Error loading baseline: %v", err)
 		}
-		actionableCount := len(analyzer.GetActionableIssues())
-		cycles := stats.Cycles()
 
 		// Build current snapshot as baseline for comparison
-		currentStats := baseline.GraphStats{
-			NodeCount:       stats.NodeCount,
-			EdgeCount:       stats.EdgeCount,
-			Density:         stats.Density,
-			OpenCount:       openCount,
-			ClosedCount:     closedCount,
-			BlockedCount:    blockedCount,
-			CycleCount:      len(cycles),
-			ActionableCount: actionableCount,
-		}
-		currentMetrics := baseline.TopMetrics{
-			PageRank:     buildMetricItems(stats.PageRank(), 10),
-			Betweenness:  buildMetricItems(stats.Betweenness(), 10),
-			CriticalPath: buildMetricItems(stats.CriticalPathScore(), 10),
-			Hubs:         buildMetricItems(stats.Hubs(), 10),
-			Authorities:  buildMetricItems(stats.Authorities(), 10),
-		}
-		current := baseline.New(currentStats, currentMetrics, cycles, "current")
+		current := buildCurrentBaseline(issues, *forceFullAnalysis)
 
 		// Load drift config and run calculator
 		driftConfig, err := drift.LoadConfig(projectDir)
@@ -1966,39 +3144,29 @@ func main() {
 		calc := drift.NewCalculator(bl, current, driftConfig)
 		result := calc.Calculate()
 
+		output := robotDriftCheckOutput{
+			GeneratedAt: robotNow().UTC().Format(time.RFC3339),
+			HasDrift:    result.HasDrift,
+			ExitCode:    result.ExitCode(),
+			Alerts:      result.Alerts,
+		}
+		output.Summary.Critical = result.CriticalCount
+		output.Summary.Warning = result.WarningCount
+		output.Summary.Info = result.InfoCount
+		output.Baseline.CreatedAt = bl.CreatedAt.Format(time.RFC3339)
+		output.Baseline.CommitSHA = bl.CommitSHA
+
+		// Hooks see the same document as --robot-drift
+		if len(result.Alerts) > 0 && !*noHooks {
+			_ = runEventHooks(projectDir, hooks.OnDriftAlert, output, os.Stderr, envRobot)
+		}
+
 		if *robotDriftCheck {
 			// JSON output
-			output := struct {
-				GeneratedAt string `json:"generated_at"`
-				HasDrift    bool   `json:"has_drift"`
-				ExitCode    int    `json:"exit_code"`
-				Summary     struct {
-					Critical int `json:"critical"`
-					Warning  int `json:"warning"`
-					Info     int `json:"info"`
-				} `json:"summary"`
-				Alerts   []drift.Alert `json:"alerts"`
-				Baseline struct {
-					CreatedAt string `json:"created_at"`
-					CommitSHA string `json:"commit_sha,omitempty"`
-				} `json:"baseline"`
-			}{
-				GeneratedAt: time.Now().UTC().Format(time.RFC3339),
-				HasDrift:    result.HasDrift,
-				ExitCode:    result.ExitCode(),
-				Alerts:      result.Alerts,
-			}
-			output.Summary.Critical = result.CriticalCount
-			output.Summary.Warning = result.WarningCount
-			output.Summary.Info = result.InfoCount
-			output.Baseline.CreatedAt = bl.CreatedAt.Format(time.RFC3339)
-			output.Baseline.CommitSHA = bl.CommitSHA
-
-			encoder := json.NewEncoder(os.Stdout)
+			encoder := newRobotEncoder(os.Stdout)
 			encoder.SetIndent("", "  ")
 			if err := encoder.Encode(output); err != nil {
-				fmt.Fprintf(os.Stderr, "Error encoding drift result: %v\n", err)
-				os.Exit(1)
+				fatalf(exitCodeFor(err), "Error encoding drift result: %v", err)
 			}
 		} else {
 			// Human-readable output
@@ -2008,6 +3176,183 @@ func main() {
 		os.Exit(result.ExitCode())
 	}
 
+	// Handle --robot-replay: metric series across git history
+	if *robotReplay {
+		cwd, err := os.Getwd()
+		if err != nil {
+			fatalf(exitCodeFor(err), "Error getting current directory: %v", err)
+		}
+		gitLoader := loader.NewGitLoader(cwd)
+		revisions, err := gitLoader.WeeklyRevisions(*replayFrom, *replayTo)
+		if err != nil {
+			fatalf(exitCodeFor(err), "Error listing history for --robot-replay: %v", err)
+		}
+
+		output := robotReplayOutput{
+			GeneratedAt: robotNow().UTC().Format(time.RFC3339),
+			From:        *replayFrom,
+			To:          *replayTo,
+			Granularity: "week",
+			Frames:      []analysis.ReplayFrame{},
+			UsageHints: []string{
+				"jq '.frames[] | [.timestamp[:10], .open, .blocked] | @tsv' - Open and blocked over time",
+				"jq '[.frames[] | select(.cycles > 0)][0]' - First week with a dependency cycle",
+				"bv --diff-since <revision> - What changed since a frame",
+			},
+		}
+		for _, rev := range revisions {
+			historical, err := gitLoader.LoadAt(rev.SHA)
+			if err != nil {
+				output.Skipped = append(output.Skipped, rev.SHA)
+				continue
+			}
+			output.Frames = append(output.Frames, analysis.NewReplayFrame(historical, rev.SHA, rev.Timestamp, rev.Message))
+		}
+
+		encoder := newRobotEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(output); err != nil {
+			fatalf(exitCodeFor(err), "Error encoding robot-replay: %v", err)
+		}
+		os.Exit(0)
+	}
+
+	// Handle --robot-validate: skipped lines and off-vocabulary labels
+	if *robotCapabilities {
+		output := buildRobotCapabilities(flag.CommandLine, issues, dataHash, projectDir, *forceFullAnalysis, robotNow())
+		encoder := newRobotEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(output); err != nil {
+			fatalf(exitCodeFor(err), "Error encoding robot-capabilities: %v", err)
+		}
+		os.Exit(0)
+	}
+
+	if *robotValidate {
+		labelsDir := projectDir
+		if beadsPath != "" {
+			labelsDir = loader.ProjectDirForJSONL(beadsPath)
+		}
+		output := robotValidateOutput{
+			GeneratedAt: robotNow().UTC().Format(time.RFC3339),
+			DataHash:    dataHash,
+			LineErrors:  []loader.LineError{},
+			UsageHints: []string{
+				"jq '.line_errors[] | \"\\(.line): \\(.message)\"' - Lines the loader skipped",
+				"jq '.labels.unknown[] | {label, issues}' - Labels outside the .bv/labels.yaml vocabulary",
+				"jq '.labels.aliased[] | \"\\(.label) -> \\(.canonical)\"' - Old label names still in the data",
+			},
+		}
+
+		labelConfig, err := labels.Load(labelsDir)
+		if err != nil {
+			output.LabelsConfigError = err.Error()
+		}
+		// Re-parse with labels as written, collecting the skipped lines
+		raw := issues
+		if beadsPath != "" {
+			output.SourceFile = beadsPath
+			if rel, err := filepath.Rel(projectDir, beadsPath); err == nil && !strings.HasPrefix(rel, "..") {
+				output.SourceFile = filepath.ToSlash(rel)
+			}
+			raw, _ = loader.LoadIssuesFromFileWithOptions(beadsPath, loader.ParseOptions{
+				WarningHandler: func(string) {},
+				LineErrorHandler: func(le loader.LineError) {
+					output.LineErrors = append(output.LineErrors, le)
+				},
+				RawLabels: true,
+			})
+		}
+		output.Labels = labelConfig.Check(raw)
+		output.Valid = len(output.LineErrors) == 0 && len(output.Labels.Unknown) == 0 && output.LabelsConfigError == ""
+
+		encoder := newRobotEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(output); err != nil {
+			fatalf(exitCodeFor(err), "Error encoding robot-validate: %v", err)
+		}
+		os.Exit(0)
+	}
+
+	// Handle --ci-report
+	if *ciReport != "" {
+		if *ciReport != "github" {
+			fatalf(exitUsage, "Error: unsupported --ci-report provider %q (supported: github)", *ciReport)
+		}
+
+		// Re-parse the source file to collect line-level validation failures.
+		var validationErrors []export.CIValidationError
+		sourceFile := ""
+		if beadsPath != "" {
+			sourceFile = beadsPath
+			if rel, err := filepath.Rel(projectDir, beadsPath); err == nil && !strings.HasPrefix(rel, "..") {
+				sourceFile = filepath.ToSlash(rel)
+			}
+			_, _ = loader.LoadIssuesFromFileWithOptions(beadsPath, loader.ParseOptions{
+				WarningHandler: func(string) {},
+				LineErrorHandler: func(le loader.LineError) {
+					validationErrors = append(validationErrors, export.CIValidationError{Line: le.Line, Message: le.Message})
+				},
+			})
+		}
+
+		current := buildCurrentBaseline(issues, *forceFullAnalysis)
+		hasBaseline := baseline.Exists(baselinePath)
+		ref := &baseline.Baseline{Stats: current.Stats}
+		if hasBaseline {
+			bl, err := baseline.Load(baselinePath)
+			if err != nil {
+				fatalf(exitCodeFor(err), "Error loading baseline: %v", err)
+			}
+			ref = bl
+		}
+
+		driftConfig, err := drift.LoadConfig(projectDir)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: Error loading drift config: %v\n", err)
+			driftConfig = drift.DefaultConfig()
+		}
+		boardConfig, err := drift.LoadBoardConfig(projectDir)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: Error loading board config: %v\n", err)
+		}
+		calc := drift.NewCalculator(ref, current, driftConfig)
+		calc.SetIssues(issues)
+		calc.SetBoardConfig(boardConfig)
+		result := calc.Calculate()
+
+		var deltas []export.CIMetricDelta
+		if hasBaseline {
+			deltas = []export.CIMetricDelta{
+				{Name: "open_count", Baseline: float64(ref.Stats.OpenCount), Current: float64(current.Stats.OpenCount)},
+				{Name: "blocked_count", Baseline: float64(ref.Stats.BlockedCount), Current: float64(current.Stats.BlockedCount)},
+				{Name: "actionable_count", Baseline: float64(ref.Stats.ActionableCount), Current: float64(current.Stats.ActionableCount)},
+				{Name: "cycle_count", Baseline: float64(ref.Stats.CycleCount), Current: float64(current.Stats.CycleCount)},
+				{Name: "edge_count", Baseline: float64(ref.Stats.EdgeCount), Current: float64(current.Stats.EdgeCount)},
+				{Name: "density", Baseline: ref.Stats.Density, Current: current.Stats.Density},
+			}
+		}
+
+		triage := analysis.ComputeTriageWithOptionsAndTime(issues, analysis.TriageOptions{}, robotNow())
+		report := export.CIReportInput{
+			DataHash:         dataHash,
+			SourceFile:       sourceFile,
+			Drift:            result,
+			HasBaseline:      hasBaseline,
+			ValidationErrors: validationErrors,
+			Triage:           &triage,
+			Deltas:           deltas,
+		}
+		if err := export.WriteGitHubCIReport(os.Stdout, report); err != nil {
+			fatalf(exitCodeFor(err), "Error writing CI report: %v", err)
+		}
+		// Fail the step only on critical problems; warnings surface as annotations.
+		if report.Outputs().CriticalCount > 0 {
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
 	if *robotInsights {
 		analyzer := analysis.NewAnalyzer(issues)
 		if *forceFullAnalysis {
@@ -2019,7 +3364,7 @@ func main() {
 		insights := stats.GenerateInsights(50)
 
 		// Add project-level velocity snapshot (using dedicated helper for efficiency)
-		if v := analysis.ComputeProjectVelocity(issues, time.Now(), 8); v != nil {
+		if v := analysis.ComputeProjectVelocity(issues, robotNow(), 8); v != nil {
 			snap := &analysis.VelocitySnapshot{
 				Closed7:   v.ClosedLast7Days,
 				Closed30:  v.ClosedLast30Days,
@@ -2034,61 +3379,44 @@ func main() {
 			}
 			insights.Velocity = snap
 		}
+		insights.Clusters = analyzer.Clusters()
 
-		// Optional cap for metric maps to avoid overload
-		limitMaps := func(m map[string]float64, limit int) map[string]float64 {
-			if limit <= 0 || limit >= len(m) {
-				return m
-			}
-			type kv struct {
-				k string
-				v float64
-			}
-			var items []kv
-			for k, v := range m {
-				items = append(items, kv{k, v})
-			}
-			sort.Slice(items, func(i, j int) bool {
-				if items[i].v == items[j].v {
-					return items[i].k < items[j].k
-				}
-				return items[i].v > items[j].v
-			})
-			trim := make(map[string]float64, limit)
-			for i := 0; i < limit; i++ {
-				trim[items[i].k] = items[i].v
-			}
-			return trim
+		// full_stats holds every metric for one page of issues, highest
+		// PageRank first (see --page-size / --cursor)
+		ids := make([]string, len(issues))
+		for i := range issues {
+			ids[i] = issues[i].ID
 		}
-
-		limitMapInt := func(m map[string]int, limit int) map[string]int {
-			if limit <= 0 || len(m) <= limit {
-				return m
-			}
-			trim := make(map[string]int, limit)
-			count := 0
-			for k, v := range m {
-				trim[k] = v
-				count++
-				if count >= limit {
-					break
+		pageRank := stats.PageRank()
+		ids = sortByPageRank(ids, pageRank)
+		pageReq, err := parsePageRequest(*pageSize, *pageCursor, dataHash)
+		if err != nil {
+			fatalf(exitUsage, "Error: %v", err)
+		}
+		pageStart, pageEnd, page := pageReq.withDefault(insightsDefaultPageSize()).paginate(len(ids), dataHash)
+		inPage := make(map[string]bool, pageEnd-pageStart)
+		for _, id := range ids[pageStart:pageEnd] {
+			inPage[id] = true
+		}
+		pageMap := func(m map[string]float64) map[string]float64 {
+			out := make(map[string]float64, len(inPage))
+			for id, v := range m {
+				if inPage[id] {
+					out[id] = v
 				}
 			}
-			return trim
+			return out
 		}
-
-		limitSlice := func(s []string, limit int) []string {
-			if limit <= 0 || len(s) <= limit {
-				return s
+		coreNumber := make(map[string]int, len(inPage))
+		for id, v := range stats.CoreNumber() {
+			if inPage[id] {
+				coreNumber[id] = v
 			}
-			return s[:limit]
 		}
-
-		// Default cap to keep payload small; allow override via env
-		mapLimit := 200
-		if v := os.Getenv("BV_INSIGHTS_MAP_LIMIT"); v != "" {
-			if n, err := strconv.Atoi(v); err == nil && n > 0 {
-				mapLimit = n
+		articulation := []string{}
+		for _, id := range stats.ArticulationPoints() {
+			if inPage[id] {
+				articulation = append(articulation, id)
 			}
 		}
 
@@ -2103,15 +3431,15 @@ func main() {
 			Slack             map[string]float64 `json:"slack"`
 			Articulation      []string           `json:"articulation_points"`
 		}{
-			PageRank:          limitMaps(stats.PageRank(), mapLimit),
-			Betweenness:       limitMaps(stats.Betweenness(), mapLimit),
-			Eigenvector:       limitMaps(stats.Eigenvector(), mapLimit),
-			Hubs:              limitMaps(stats.Hubs(), mapLimit),
-			Authorities:       limitMaps(stats.Authorities(), mapLimit),
-			CriticalPathScore: limitMaps(stats.CriticalPathScore(), mapLimit),
-			CoreNumber:        limitMapInt(stats.CoreNumber(), mapLimit),
-			Slack:             limitMaps(stats.Slack(), mapLimit),
-			Articulation:      limitSlice(stats.ArticulationPoints(), mapLimit),
+			PageRank:          pageMap(pageRank),
+			Betweenness:       pageMap(stats.Betweenness()),
+			Eigenvector:       pageMap(stats.Eigenvector()),
+			Hubs:              pageMap(stats.Hubs()),
+			Authorities:       pageMap(stats.Authorities()),
+			CriticalPathScore: pageMap(stats.CriticalPathScore()),
+			CoreNumber:        coreNumber,
+			Slack:             pageMap(stats.Slack()),
+			Articulation:      articulation,
 		}
 
 		// Get top what-if deltas for issues with highest downstream impact (bv-83)
@@ -2120,22 +3448,35 @@ func main() {
 		// Generate advanced insights with canonical structure (bv-181)
 		advancedInsights := analyzer.GenerateAdvancedInsights(analysis.DefaultAdvancedInsightsConfig())
 
-		output := struct {
-			GeneratedAt    string                  `json:"generated_at"`
-			DataHash       string                  `json:"data_hash"`
-			AsOf           string                  `json:"as_of,omitempty"`        // Historical snapshot ref
-			AsOfCommit     string                  `json:"as_of_commit,omitempty"` // Resolved commit SHA
-			AnalysisConfig analysis.AnalysisConfig `json:"analysis_config"`
-			Status         analysis.MetricStatus   `json:"status"`
-			LabelScope     string                  `json:"label_scope,omitempty"`   // bv-122: Label filter applied
-			LabelContext   *analysis.LabelHealth   `json:"label_context,omitempty"` // bv-122: Health context for scoped label
-			analysis.Insights
-			FullStats        interface{}                `json:"full_stats"`
-			TopWhatIfs       []analysis.WhatIfEntry     `json:"top_what_ifs,omitempty"`      // Issues with highest downstream impact (bv-83)
-			AdvancedInsights *analysis.AdvancedInsights `json:"advanced_insights,omitempty"` // bv-181: Canonical advanced features
-			UsageHints       []string                   `json:"usage_hints"`                 // bv-84: Agent-friendly hints
-		}{
-			GeneratedAt:      time.Now().UTC().Format(time.RFC3339),
+		if *streamOutput {
+			meta := robotInsightsStreamMeta{
+				GeneratedAt:    robotNow().UTC().Format(time.RFC3339),
+				DataHash:       dataHash,
+				AsOf:           *asOf,
+				AsOfCommit:     asOfResolved,
+				AnalysisConfig: stats.Config,
+				Status:         stats.Status(),
+				LabelScope:     *labelScope,
+				LabelContext:   labelScopeContext,
+				ClusterDensity: insights.ClusterDensity,
+				Velocity:       insights.Velocity,
+				UsageHints: []string{
+					"jq -c 'select(.record == \"insight\" and .list == \"bottlenecks\")' - Bottleneck entries",
+					"jq -c 'select(.record == \"metric\")' | head -20 - Top 20 issues by PageRank with all metrics",
+					"jq -c 'select(.record == \"metric\" and .articulation_point)' - Structural cut points",
+					"jq -c 'select(.record == \"cycle\") | .members' - Dependency cycles",
+					"jq -c 'select(.record == \"cluster\") | {id, size, labels}' - Workstreams by community detection",
+					"tail -1 | jq .records - Record count (check the end record arrived)",
+				},
+			}
+			if err := streamInsights(os.Stdout, meta, insights, ids, &stats, topWhatIfs, advancedInsights); err != nil {
+				fatalf(exitCodeFor(err), "Error streaming insights: %v", err)
+			}
+			os.Exit(0)
+		}
+
+		output := robotInsightsOutput{
+			GeneratedAt:      robotNow().UTC().Format(time.RFC3339),
 			DataHash:         dataHash,
 			AsOf:             *asOf,
 			AsOfCommit:       asOfResolved,
@@ -2145,10 +3486,12 @@ func main() {
 			LabelContext:     labelScopeContext,
 			Insights:         insights,
 			FullStats:        fullStats,
+			Page:             page,
 			TopWhatIfs:       topWhatIfs,
 			AdvancedInsights: advancedInsights,
 			UsageHints: []string{
 				"jq '.Bottlenecks[:5] | map(.ID)' - Top 5 bottleneck IDs",
+				"jq '.plugins.scores' - Per-issue scores from .bv/plugins analyzers",
 				"jq '.CriticalPath[:3]' - Top 3 critical path items",
 				"jq '.top_what_ifs[] | select(.delta.direct_unblocks > 2)' - High-impact items",
 				"jq '.full_stats.pagerank | to_entries | sort_by(-.value)[:5]' - Top PageRank",
@@ -2156,86 +3499,38 @@ func main() {
 				"jq '.full_stats.articulation_points' - Structural cut points",
 				"jq '.Slack[:5]' - Nodes with slack (good parallel work candidates)",
 				"jq '.Cycles | length' - Count of detected cycles",
+				"jq '.Clusters.clusters[] | {id, size, hub, labels}' - Workstreams by community detection",
+				"jq '.Clusters.assignments[\"bv-123\"]' - Cluster of one issue",
+				"jq '.Clusters.inter_cluster_edges[:5]' - Where workstreams wait on each other",
 				"jq '.advanced_insights.cycle_break' - Cycle break suggestions (bv-181)",
-				"BV_INSIGHTS_MAP_LIMIT=50 bv --robot-insights - Reduce map sizes",
+				"bv --robot-insights --page-size 50 - Smaller full_stats pages",
+				"jq -r '.page.next_cursor' - Pass as --cursor for the next full_stats page",
 			},
 		}
 
-		encoder := json.NewEncoder(os.Stdout)
+		if !*noPlugins {
+			output.Plugins = runPlugins(projectDir, "insights", issues)
+		}
+
+		encoder := newRobotEncoder(os.Stdout)
 		encoder.SetIndent("", "  ")
 		if err := encoder.Encode(output); err != nil {
-			fmt.Fprintf(os.Stderr, "Error encoding insights: %v\n", err)
-			os.Exit(1)
+			fatalf(exitCodeFor(err), "Error encoding insights: %v", err)
 		}
 		os.Exit(0)
 	}
 
 	if *robotPlan {
-		analyzer := analysis.NewAnalyzer(issues)
-		// For --robot-plan we primarily need Phase 1 metrics (degree/topo/density).
-		// However, we still emit a stable status contract for agents. If the user
-		// explicitly asks for full analysis, honor it; otherwise, skip expensive
-		// centrality metrics and record the skip reasons deterministically.
-		cfg := analysis.ConfigForSize(len(issues), countEdges(issues))
-		if *forceFullAnalysis {
-			cfg = analysis.FullAnalysisConfig()
-		} else {
-			const skipReason = "not computed for --robot-plan"
-			cfg.ComputePageRank = false
-			cfg.PageRankSkipReason = skipReason
-			cfg.ComputeBetweenness = false
-			cfg.BetweennessMode = analysis.BetweennessSkip
-			cfg.BetweennessSkipReason = skipReason
-			cfg.ComputeHITS = false
-			cfg.HITSSkipReason = skipReason
-			cfg.ComputeEigenvector = false
-			cfg.ComputeCriticalPath = false
-			cfg.ComputeCycles = false
-			cfg.CyclesSkipReason = skipReason
-		}
-
-		plan := analyzer.GetExecutionPlan()
-
-		stats := analyzer.AnalyzeAsyncWithConfig(context.Background(), cfg)
-		stats.WaitForPhase2()
-		status := stats.Status()
+		output := buildRobotPlan(issues, dataHash, projectDir, *forceFullAnalysis, robotNow())
+		output.AsOf = *asOf
+		output.AsOfCommit = asOfResolved
+		output.LabelScope = *labelScope
+		output.LabelContext = labelScopeContext
 
-		// Wrap with metadata
-		output := struct {
-			GeneratedAt    string                  `json:"generated_at"`
-			DataHash       string                  `json:"data_hash"`
-			AsOf           string                  `json:"as_of,omitempty"`        // Historical snapshot ref
-			AsOfCommit     string                  `json:"as_of_commit,omitempty"` // Resolved commit SHA
-			AnalysisConfig analysis.AnalysisConfig `json:"analysis_config"`
-			Status         analysis.MetricStatus   `json:"status"`
-			LabelScope     string                  `json:"label_scope,omitempty"`   // bv-122: Label filter applied
-			LabelContext   *analysis.LabelHealth   `json:"label_context,omitempty"` // bv-122: Health context for scoped label
-			Plan           analysis.ExecutionPlan  `json:"plan"`
-			UsageHints     []string                `json:"usage_hints"` // bv-84: Agent-friendly hints
-		}{
-			GeneratedAt:    time.Now().UTC().Format(time.RFC3339),
-			DataHash:       dataHash,
-			AsOf:           *asOf,
-			AsOfCommit:     asOfResolved,
-			AnalysisConfig: cfg,
-			Status:         status,
-			LabelScope:     *labelScope,
-			LabelContext:   labelScopeContext,
-			Plan:           plan,
-			UsageHints: []string{
-				"jq '.plan.tracks | length' - Number of parallel execution tracks",
-				"jq '.plan.tracks[0].items | map(.id)' - First track item IDs",
-				"jq '.plan.tracks[].items[] | select(.unblocks | length > 0)' - Items that unblock others",
-				"jq '.plan.summary' - High-level execution summary",
-				"jq '[.plan.tracks[].items[]] | length' - Total items across all tracks",
-			},
-		}
-
-		encoder := json.NewEncoder(os.Stdout)
+		encoder := newRobotEncoder(os.Stdout)
 		encoder.SetIndent("", "  ")
 		if err := encoder.Encode(output); err != nil {
-			fmt.Fprintf(os.Stderr, "Error encoding execution plan: %v\n", err)
-			os.Exit(1)
+			fatalf(exitCodeFor(err), "Error encoding execution plan: %v", err)
 		}
 		os.Exit(0)
 	}
@@ -2252,7 +3547,7 @@ func main() {
 		status := stats.Status()
 
 		// Use enhanced recommendations with what-if deltas and top reasons (bv-83)
-		recommendations := analyzer.GenerateEnhancedRecommendations()
+		recommendations := analyzer.GenerateEnhancedRecommendationsAt(robotNow())
 
 		// Apply robot filters (bv-84)
 		filtered := make([]analysis.EnhancedPriorityRecommendation, 0, len(recommendations))
@@ -2305,6 +3600,25 @@ func main() {
 			recommendations = recommendations[:maxResults]
 		}
 
+		// Explain how feedback history re-ranks the impact scores
+		var feedbackInfo *analysis.FeedbackJSON
+		if feedbackData := loadRobotFeedback(feedbackUser, *feedbackMerge); feedbackData != nil {
+			scores := analyzer.ComputeImpactScoresFromStats(stats, robotNow())
+			ranked := make([]analysis.RankedItem, len(scores))
+			for i, s := range scores {
+				ranked[i] = analysis.RankedItem{ID: s.IssueID, Breakdown: s.Breakdown}
+			}
+			notes := feedbackData.RankNotes(ranked)
+			info := feedbackData.ToJSON()
+			for i := range recommendations {
+				if note, ok := notes[recommendations[i].IssueID]; ok {
+					recommendations[i].FeedbackNote = note
+					info.RankChanges++
+				}
+			}
+			feedbackInfo = &info
+		}
+
 		// Count high confidence recommendations
 		highConfidence := 0
 		for _, rec := range recommendations {
@@ -2314,31 +3628,8 @@ func main() {
 		}
 
 		// Build output with summary
-		output := struct {
-			GeneratedAt       string                                    `json:"generated_at"`
-			DataHash          string                                    `json:"data_hash"`
-			AsOf              string                                    `json:"as_of,omitempty"`        // Historical snapshot ref
-			AsOfCommit        string                                    `json:"as_of_commit,omitempty"` // Resolved commit SHA
-			AnalysisConfig    analysis.AnalysisConfig                   `json:"analysis_config"`
-			Status            analysis.MetricStatus                     `json:"status"`
-			LabelScope        string                                    `json:"label_scope,omitempty"`   // bv-122: Label filter applied
-			LabelContext      *analysis.LabelHealth                     `json:"label_context,omitempty"` // bv-122: Health context for scoped label
-			Recommendations   []analysis.EnhancedPriorityRecommendation `json:"recommendations"`
-			FieldDescriptions map[string]string                         `json:"field_descriptions"`
-			Filters           struct {
-				MinConfidence float64 `json:"min_confidence,omitempty"`
-				MaxResults    int     `json:"max_results"`
-				ByLabel       string  `json:"by_label,omitempty"`
-				ByAssignee    string  `json:"by_assignee,omitempty"`
-			} `json:"filters"`
-			Summary struct {
-				TotalIssues     int `json:"total_issues"`
-				Recommendations int `json:"recommendations"`
-				HighConfidence  int `json:"high_confidence"`
-			} `json:"summary"`
-			Usage []string `json:"usage_hints"` // bv-84: Agent-friendly hints
-		}{
-			GeneratedAt:       time.Now().UTC().Format(time.RFC3339),
+		output := robotPriorityOutput{
+			GeneratedAt:       robotNow().UTC().Format(time.RFC3339),
 			DataHash:          dataHash,
 			AsOf:              *asOf,
 			AsOfCommit:        asOfResolved,
@@ -2347,12 +3638,15 @@ func main() {
 			LabelScope:        *labelScope,
 			LabelContext:      labelScopeContext,
 			Recommendations:   recommendations,
+			Feedback:          feedbackInfo,
 			FieldDescriptions: analysis.DefaultFieldDescriptions(),
 			Usage: []string{
 				"jq '.recommendations[] | select(.confidence > 0.7)' - Filter high confidence",
 				"jq '.recommendations[0].explanation.what_if' - Get top item's impact",
 				"jq '.recommendations | map({id: .issue_id, score: .impact_score})' - Extract IDs and scores",
 				"jq '.recommendations[] | select(.explanation.what_if.parallelization_gain > 0)' - Find items that increase parallel work capacity",
+				"jq '.recommendations[] | select(.feedback_note) | {issue_id, feedback_note}' - Items whose rank accept/ignore feedback moved",
+				"jq '.feedback.weight_drift' - How far feedback has moved each weight from its default",
 				"--robot-min-confidence 0.6 - Pre-filter by confidence",
 				"--robot-max-results 5 - Limit to top N results",
 				"--robot-by-label bug - Filter by specific label",
@@ -2366,73 +3660,79 @@ func main() {
 		output.Summary.Recommendations = len(recommendations)
 		output.Summary.HighConfidence = highConfidence
 
-		encoder := json.NewEncoder(os.Stdout)
+		encoder := newRobotEncoder(os.Stdout)
 		encoder.SetIndent("", "  ")
 		if err := encoder.Encode(output); err != nil {
-			fmt.Fprintf(os.Stderr, "Error encoding priority recommendations: %v\n", err)
-			os.Exit(1)
+			fatalf(exitCodeFor(err), "Error encoding priority recommendations: %v", err)
 		}
 		os.Exit(0)
 	}
 
-	if *robotTriage || *robotNext || *robotTriageByTrack || *robotTriageByLabel {
+	if *robotTriage || *robotNext || *robotSummary || *robotTriageByTrack || *robotTriageByLabel {
 		// bv-87: Support track/label-aware grouping for multi-agent coordination
 		opts := analysis.TriageOptions{
 			GroupByTrack:  *robotTriageByTrack,
 			GroupByLabel:  *robotTriageByLabel,
 			WaitForPhase2: true, // Triage needs full graph metrics
 		}
-		triage := analysis.ComputeTriageWithOptions(issues, opts)
+		triage := analysis.ComputeTriageWithOptionsAndTime(issues, opts, robotNow())
+		var pluginInfo *plugins.Result
+		if !*noPlugins {
+			pluginInfo = mergePluginsIntoTriage(&triage, runPlugins(projectDir, "triage", issues))
+		}
+		claimLedger := loadClaims(projectDir)
+		triageClaims := mergeClaimsIntoTriage(&triage, claimLedger, robotNow())
+		if !*noHooks {
+			_ = runEventHooks(projectDir, hooks.OnTriage, triage, os.Stderr, envRobot)
+		}
 
-		// bv-90: Load feedback data for output
+		// bv-90: Load feedback data for output, noting where it re-ranks
 		var feedbackInfo *analysis.FeedbackJSON
-		if robotTriageBeadsDir, err := loader.GetBeadsDir(""); err == nil {
-			if feedbackData, err := analysis.LoadFeedback(robotTriageBeadsDir); err == nil && len(feedbackData.Events) > 0 {
-				info := feedbackData.ToJSON()
-				feedbackInfo = &info
+		if feedbackData := loadRobotFeedback(feedbackUser, *feedbackMerge); feedbackData != nil {
+			info := feedbackData.ToJSON()
+			info.RankChanges = mergeFeedbackIntoTriage(&triage, feedbackData)
+			feedbackInfo = &info
+		}
+
+		if *robotSummary {
+			output := buildRobotSummary(triage)
+			output.GeneratedAt = robotNow().UTC().Format(time.RFC3339)
+			output.DataHash = dataHash
+			output.AsOf = *asOf
+			output.AsOfCommit = asOfResolved
+			encoder := newRobotEncoder(os.Stdout)
+			encoder.SetIndent("", "  ")
+			if err := encoder.Encode(output); err != nil {
+				fatalf(exitCodeFor(err), "Error encoding robot-summary: %v", err)
 			}
+			os.Exit(0)
 		}
 
 		if *robotNext {
-			// Minimal output: just the top pick
-			if len(triage.QuickRef.TopPicks) == 0 {
-				output := struct {
-					GeneratedAt string `json:"generated_at"`
-					DataHash    string `json:"data_hash"`
-					AsOf        string `json:"as_of,omitempty"`
-					AsOfCommit  string `json:"as_of_commit,omitempty"`
-					Message     string `json:"message"`
-				}{
-					GeneratedAt: time.Now().UTC().Format(time.RFC3339),
+			// Minimal output: just the top pick nobody else has claimed
+			top, skipped, ok := nextUnclaimedPick(triage, claimLedger, analysis.CurrentActor(), robotNow())
+			if !ok {
+				message := "No actionable items available"
+				if len(skipped) > 0 {
+					message = "Every actionable item is claimed by someone else (bv claim list)"
+				}
+				output := robotNextEmptyOutput{
+					GeneratedAt: robotNow().UTC().Format(time.RFC3339),
 					DataHash:    dataHash,
 					AsOf:        *asOf,
 					AsOfCommit:  asOfResolved,
-					Message:     "No actionable items available",
+					Message:     message,
 				}
-				encoder := json.NewEncoder(os.Stdout)
+				encoder := newRobotEncoder(os.Stdout)
 				encoder.SetIndent("", "  ")
 				if err := encoder.Encode(output); err != nil {
-					fmt.Fprintf(os.Stderr, "Error encoding robot-next: %v\n", err)
-					os.Exit(1)
+					fatalf(exitCodeFor(err), "Error encoding robot-next: %v", err)
 				}
 				os.Exit(0)
 			}
 
-			top := triage.QuickRef.TopPicks[0]
-			output := struct {
-				GeneratedAt string   `json:"generated_at"`
-				DataHash    string   `json:"data_hash"`
-				AsOf        string   `json:"as_of,omitempty"`
-				AsOfCommit  string   `json:"as_of_commit,omitempty"`
-				ID          string   `json:"id"`
-				Title       string   `json:"title"`
-				Score       float64  `json:"score"`
-				Reasons     []string `json:"reasons"`
-				Unblocks    int      `json:"unblocks"`
-				ClaimCmd    string   `json:"claim_command"`
-				ShowCmd     string   `json:"show_command"`
-			}{
-				GeneratedAt: time.Now().UTC().Format(time.RFC3339),
+			output := robotNextOutput{
+				GeneratedAt: robotNow().UTC().Format(time.RFC3339),
 				DataHash:    dataHash,
 				AsOf:        *asOf,
 				AsOfCommit:  asOfResolved,
@@ -2443,33 +3743,28 @@ func main() {
 				Unblocks:    top.Unblocks,
 				ClaimCmd:    fmt.Sprintf("bd update %s --status=in_progress", top.ID),
 				ShowCmd:     fmt.Sprintf("bd show %s", top.ID),
+				LeaseCmd:    fmt.Sprintf("bv claim %s --ttl 2h", top.ID),
+				Skipped:     skipped,
 			}
 
-			encoder := json.NewEncoder(os.Stdout)
+			encoder := newRobotEncoder(os.Stdout)
 			encoder.SetIndent("", "  ")
 			if err := encoder.Encode(output); err != nil {
-				fmt.Fprintf(os.Stderr, "Error encoding robot-next: %v\n", err)
-				os.Exit(1)
+				fatalf(exitCodeFor(err), "Error encoding robot-next: %v", err)
 			}
 			os.Exit(0)
 		}
 
 		// Full triage output with usage hints
-		output := struct {
-			GeneratedAt string                 `json:"generated_at"`
-			DataHash    string                 `json:"data_hash"`
-			AsOf        string                 `json:"as_of,omitempty"`        // Historical snapshot ref (e.g., HEAD~30)
-			AsOfCommit  string                 `json:"as_of_commit,omitempty"` // Resolved commit SHA
-			Triage      analysis.TriageResult  `json:"triage"`
-			Feedback    *analysis.FeedbackJSON `json:"feedback,omitempty"` // bv-90: Feedback loop state
-			UsageHints  []string               `json:"usage_hints"`        // bv-84: Agent-friendly hints
-		}{
-			GeneratedAt: time.Now().UTC().Format(time.RFC3339),
+		output := robotTriageOutput{
+			GeneratedAt: robotNow().UTC().Format(time.RFC3339),
 			DataHash:    dataHash,
 			AsOf:        *asOf,
 			AsOfCommit:  asOfResolved,
 			Triage:      triage,
 			Feedback:    feedbackInfo,
+			Plugins:     pluginInfo,
+			Claims:      triageClaims,
 			UsageHints: []string{
 				"jq '.triage.quick_ref.top_picks[:3]' - Top 3 picks for immediate work",
 				"jq '.triage.recommendations[3:10] | map({id,title,score})' - Next candidates after top picks",
@@ -2483,13 +3778,107 @@ func main() {
 				"jq '.triage.recommendations_by_track[].top_pick' - Top pick per track",
 				"jq '.triage.recommendations_by_label[].claim_command' - Claim commands per label",
 				"jq '.feedback.weight_adjustments' - View feedback-adjusted weights (bv-90)",
+				"jq '.triage.recommendations[] | select(.feedback_note) | {id, feedback_note}' - Picks whose rank accept/ignore feedback moved",
+				"jq '.triage.recommendations[] | select(.plugin_scores) | {id, plugin_scores}' - Scores from .bv/plugins analyzers",
+				"jq '.triage.recommendations[] | select(.claimed_by == null) | .id' - Picks nobody has claimed (bv claim)",
 			},
 		}
-		encoder := json.NewEncoder(os.Stdout)
+		encoder := newRobotEncoder(os.Stdout)
 		encoder.SetIndent("", "  ")
 		if err := encoder.Encode(output); err != nil {
-			fmt.Fprintf(os.Stderr, "Error encoding robot-triage: %v\n", err)
-			os.Exit(1)
+			fatalf(exitCodeFor(err), "Error encoding robot-triage: %v", err)
+		}
+		os.Exit(0)
+	}
+
+	// Handle --robot-journal: what was recommended, and what happened to it
+	if *robotJournalFlag {
+		records, err := journal.Load(journal.Path(projectDir))
+		if err != nil {
+			fatalf(exitCodeFor(err), "Error: %v", err)
+		}
+		var since time.Time
+		if *journalDays > 0 {
+			since = robotNow().AddDate(0, 0, -*journalDays)
+		}
+		output := robotJournalOutput{
+			GeneratedAt: robotNow().UTC().Format(time.RFC3339),
+			DataHash:    dataHash,
+			Days:        *journalDays,
+			Journal:     journal.Summarize(records, issues, since),
+			UsageHints: []string{
+				"jq '.journal.items[] | select(.acted_on | not) | {id, times, outcome}' - Recommendations nobody acted on",
+				"jq '.journal.follow_through_pct' - Share of top recommendations that were started or closed",
+				"jq '.journal.by_command' - Robot invocations per command",
+				"--journal-days=N - Only the last N days",
+				"--journal (or BV_JOURNAL=1) - Record robot invocations",
+			},
+		}
+		encoder := newRobotEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(output); err != nil {
+			fatalf(exitCodeFor(err), "Error encoding robot-journal: %v", err)
+		}
+		os.Exit(0)
+	}
+
+	// Handle --robot-partition: disjoint work bundles for a fleet of agents
+	if *robotPartition {
+		if *capacityAgents < 1 {
+			fatalf(exitUsage, "Error: --agents must be at least 1, got %d", *capacityAgents)
+		}
+		analyzer := analysis.NewAnalyzer(issues)
+		stats := analyzer.Analyze()
+		partition := analysis.PartitionWork(analyzer, &stats, issues, *capacityAgents, robotNow())
+
+		output := robotPartitionOutput{
+			GeneratedAt: robotNow().UTC().Format(time.RFC3339),
+			DataHash:    dataHash,
+			AsOf:        *asOf,
+			AsOfCommit:  asOfResolved,
+			Partition:   partition,
+			UsageHints: []string{
+				"jq '.partition.bundles[0].claim_commands[]' - Claim commands for agent-1",
+				"jq '.partition.bundles[] | {agent, total_minutes, n: (.items | length)}' - Load per agent",
+				"jq '.partition.shared_downstream' - Work where bundles meet; coordinate before starting it",
+				"--agents=N - Number of bundles",
+			},
+		}
+		encoder := newRobotEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(output); err != nil {
+			fatalf(exitCodeFor(err), "Error encoding robot-partition: %v", err)
+		}
+		os.Exit(0)
+	}
+
+	// Handle --robot-my-queue: personal worklist for one assignee
+	if *robotMyQueue {
+		me := analysis.ResolveAssignee(*assignee)
+		if me == "" {
+			fatalf(exitUsage, "Error: cannot resolve assignee \"me\"; set BD_ACTOR or pass --assignee NAME")
+		}
+		analyzer := analysis.NewAnalyzer(issues)
+		stats := analyzer.Analyze()
+		queue := analysis.ComputeMyQueue(analyzer, &stats, issues, me, analysis.MyQueueOptions{NextN: *robotMaxResults}, robotNow())
+
+		output := robotMyQueueOutput{
+			GeneratedAt: robotNow().UTC().Format(time.RFC3339),
+			DataHash:    dataHash,
+			AsOf:        *asOf,
+			AsOfCommit:  asOfResolved,
+			Queue:       queue,
+			UsageHints: []string{
+				"jq '.queue.items[] | select(.section == \"next\") | .id' - What to pick up next",
+				"jq '.queue.items[] | select(.claim_command) | .claim_command' - Claim unassigned picks",
+				"jq '.queue.items[] | select(.section == \"upcoming\") | {id, reason}' - Work about to free up",
+				"--assignee NAME - Build the queue for someone else",
+			},
+		}
+		encoder := newRobotEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(output); err != nil {
+			fatalf(exitCodeFor(err), "Error encoding robot-my-queue: %v", err)
 		}
 		os.Exit(0)
 	}
@@ -2502,8 +3891,7 @@ func main() {
 		// Marshal triage to JSON for the export function
 		triageJSON, err := json.Marshal(triage)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error marshaling triage data: %v\n", err)
-			os.Exit(1)
+			fatalf(exitCodeFor(err), "Error marshaling triage data: %v", err)
 		}
 
 		// Generate the brief
@@ -2511,108 +3899,83 @@ func main() {
 		config.DataHash = dataHash
 		brief, err := export.GeneratePriorityBriefFromTriageJSON(triageJSON, config)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error generating priority brief: %v\n", err)
-			os.Exit(1)
+			fatalf(exitCodeFor(err), "Error generating priority brief: %v", err)
 		}
 
 		// Write to file
 		if err := os.WriteFile(*priorityBrief, []byte(brief), 0644); err != nil {
-			fmt.Fprintf(os.Stderr, "Error writing priority brief: %v\n", err)
-			os.Exit(1)
+			fatalf(exitCodeFor(err), "Error writing priority brief: %v", err)
 		}
 
 		fmt.Printf("Done! Priority brief saved to %s\n", *priorityBrief)
 		os.Exit(0)
 	}
 
-	// Handle --agent-brief flag (bv-131)
-	if *agentBrief != "" {
-		fmt.Printf("Generating agent brief bundle to %s/...\n", *agentBrief)
-
-		// Create output directory
-		if err := os.MkdirAll(*agentBrief, 0755); err != nil {
-			fmt.Fprintf(os.Stderr, "Error creating directory: %v\n", err)
-			os.Exit(1)
-		}
-
-		// Generate triage data
-		triage := analysis.ComputeTriage(issues)
-		triageJSON, err := json.MarshalIndent(triage, "", "  ")
+	// Handle --publish flag
+	if *publishTargets != "" {
+		publishConfig, err := export.LoadPublishConfig(projectDir)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error marshaling triage: %v\n", err)
-			os.Exit(1)
+			fatalf(exitCodeFor(err), "Error: %v", err)
 		}
-		if err := os.WriteFile(filepath.Join(*agentBrief, "triage.json"), triageJSON, 0644); err != nil {
-			fmt.Fprintf(os.Stderr, "Error writing triage.json: %v\n", err)
-			os.Exit(1)
+		names := strings.Split(*publishTargets, ",")
+		for i := range names {
+			names[i] = strings.TrimSpace(names[i])
 		}
-		fmt.Println("  → triage.json")
-
-		// Generate insights
-		analyzer := analysis.NewAnalyzer(issues)
-		stats := analyzer.Analyze()
-		insights := stats.GenerateInsights(50)
-		insightsJSON, err := json.MarshalIndent(insights, "", "  ")
+		targets, err := publishConfig.Select(names)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error marshaling insights: %v\n", err)
-			os.Exit(1)
-		}
-		if err := os.WriteFile(filepath.Join(*agentBrief, "insights.json"), insightsJSON, 0644); err != nil {
-			fmt.Fprintf(os.Stderr, "Error writing insights.json: %v\n", err)
-			os.Exit(1)
+			fatalf(exitUsage, "Error: %v", err)
 		}
-		fmt.Println("  → insights.json")
 
-		// Generate priority brief
-		config := export.DefaultPriorityBriefConfig()
-		config.DataHash = dataHash
-		brief, err := export.GeneratePriorityBriefFromTriageJSON(triageJSON, config)
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error generating brief: %v\n", err)
-			os.Exit(1)
+		rendered := make(map[string]string)
+		failed := 0
+		for _, target := range targets {
+			content, ok := rendered[target.Content]
+			if !ok {
+				if content, err = export.RenderPublishContent(target.Content, issues, dataHash); err != nil {
+					fatalf(exitCodeFor(err), "Error rendering %s: %v", target.Content, err)
+				}
+				rendered[target.Content] = content
+			}
+			result, err := export.PublishTo(target, content, export.PublishOptions{})
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "✗ %s (%s): %v\n", target.Name, target.Type, err)
+				failed++
+				continue
+			}
+			fmt.Printf("✓ %s (%s): published %s", target.Name, target.Type, target.Content)
+			if result.URL != "" {
+				fmt.Printf(" to %s", result.URL)
+			}
+			fmt.Println()
 		}
-		if err := os.WriteFile(filepath.Join(*agentBrief, "brief.md"), []byte(brief), 0644); err != nil {
-			fmt.Fprintf(os.Stderr, "Error writing brief.md: %v\n", err)
-			os.Exit(1)
+		if failed > 0 {
+			os.Exit(exitError)
 		}
-		fmt.Println("  → brief.md")
+		os.Exit(0)
+	}
 
-		// Generate jq helpers
-		helpers := generateJQHelpers()
-		if err := os.WriteFile(filepath.Join(*agentBrief, "helpers.md"), []byte(helpers), 0644); err != nil {
-			fmt.Fprintf(os.Stderr, "Error writing helpers.md: %v\n", err)
-			os.Exit(1)
+	// Handle --agent-brief flag (bv-131)
+	if *agentBrief != "" {
+		if *agentBriefRefresh {
+			fmt.Printf("Refreshing agent brief bundle in %s/...\n", *agentBrief)
+		} else {
+			fmt.Printf("Generating agent brief bundle to %s/...\n", *agentBrief)
 		}
-		fmt.Println("  → helpers.md")
-
-		// Generate meta.json with hash and config
-		meta := struct {
-			GeneratedAt string   `json:"generated_at"`
-			DataHash    string   `json:"data_hash"`
-			IssueCount  int      `json:"issue_count"`
-			Version     string   `json:"version"`
-			Files       []string `json:"files"`
-		}{
-			GeneratedAt: time.Now().UTC().Format(time.RFC3339),
-			DataHash:    dataHash,
-			IssueCount:  len(issues),
-			Version:     "1.0.0",
-			Files:       []string{"triage.json", "insights.json", "brief.md", "helpers.md", "meta.json"},
+		written, err := writeAgentBrief(*agentBrief, issues, dataHash, projectDir, flag.CommandLine, *agentBriefRefresh, robotNow(), os.Stdout)
+		if err != nil {
+			fatalf(exitCodeFor(err), "Error: %v", err)
 		}
-		metaJSON, _ := json.MarshalIndent(meta, "", "  ")
-		if err := os.WriteFile(filepath.Join(*agentBrief, "meta.json"), metaJSON, 0644); err != nil {
-			fmt.Fprintf(os.Stderr, "Error writing meta.json: %v\n", err)
-			os.Exit(1)
+		if *agentBriefRefresh && written == 0 {
+			fmt.Printf("\nAgent brief bundle in %s/ is up to date\n", *agentBrief)
+		} else {
+			fmt.Printf("\nDone! Agent brief bundle saved to %s/\n", *agentBrief)
 		}
-		fmt.Println("  → meta.json")
-
-		fmt.Printf("\nDone! Agent brief bundle saved to %s/\n", *agentBrief)
 		os.Exit(0)
 	}
 
 	// Handle --emit-script flag (bv-89)
 	if *emitScript {
-		triage := analysis.ComputeTriage(issues)
+		triage := analysis.ComputeTriageWithOptionsAndTime(issues, analysis.TriageOptions{}, robotNow())
 
 		// Determine script limit
 		limit := *scriptLimit
@@ -2638,7 +4001,7 @@ func main() {
 			sb.WriteString("set -euo pipefail\n")
 		}
 
-		sb.WriteString(fmt.Sprintf("# Generated by bv --emit-script at %s\n", time.Now().UTC().Format(time.RFC3339)))
+		sb.WriteString(fmt.Sprintf("# Generated by bv --emit-script at %s\n", robotNow().UTC().Format(time.RFC3339)))
 		sb.WriteString(fmt.Sprintf("# Data hash: %s\n", dataHash))
 		sb.WriteString(fmt.Sprintf("# Top %d recommendations from %d actionable items\n", len(recs), len(triage.Recommendations)))
 		sb.WriteString("#\n")
@@ -2688,26 +4051,22 @@ func main() {
 	if *robotHistory || *beadHistory != "" {
 		cwd, err := os.Getwd()
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error getting current directory: %v\n", err)
-			os.Exit(1)
+			fatalf(exitCodeFor(err), "Error getting current directory: %v", err)
 		}
 
 		// Validate repository
 		if err := correlation.ValidateRepository(cwd); err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
+			fatalf(exitNotFound, "Error: %v", err)
 		}
 
 		// Resolve beads file path (bv-history fix, respects BEADS_DIR)
 		beadsDir, err := loader.GetBeadsDir("")
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error getting beads directory: %v\n", err)
-			os.Exit(1)
+			fatalf(exitCodeFor(err), "Error getting beads directory: %v", err)
 		}
 		beadsPath, err := loader.FindJSONLPath(beadsDir)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error finding beads file: %v\n", err)
-			os.Exit(1)
+			fatalf(exitCodeFor(err), "Error finding beads file: %v", err)
 		}
 
 		// Build correlator options
@@ -2718,10 +4077,9 @@ func main() {
 
 		// Parse --history-since if provided
 		if *historySince != "" {
-			since, err := recipe.ParseRelativeTime(*historySince, time.Now())
+			since, err := recipe.ParseRelativeTime(*historySince, robotNow())
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "Error parsing --history-since: %v\n", err)
-				os.Exit(1)
+				fatalf(exitUsage, "Error parsing --history-since: %v", err)
 			}
 			if !since.IsZero() {
 				opts.Since = &since
@@ -2735,15 +4093,15 @@ func main() {
 				ID:     issue.ID,
 				Title:  issue.Title,
 				Status: string(issue.Status),
+				Events: issue.Events,
 			}
 		}
 
 		// Generate report with explicit beads path
-		correlator := correlation.NewCorrelator(cwd, beadsPath)
+		correlator := correlation.NewIndexedCorrelator(cwd, beadsPath)
 		report, err := correlator.GenerateReport(beadInfos, opts)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error generating history report: %v\n", err)
-			os.Exit(1)
+			fatalf(exitCodeFor(err), "Error generating history report: %v", err)
 		}
 
 		// Apply confidence filter if specified
@@ -2768,12 +4126,27 @@ func main() {
 			}
 		}
 
+		if *streamOutput {
+			if err := streamHistory(os.Stdout, report); err != nil {
+				fatalf(exitCodeFor(err), "Error streaming history report: %v", err)
+			}
+			os.Exit(0)
+		}
+
+		pageReq, err := parsePageRequest(*pageSize, *pageCursor, report.DataHash)
+		if err != nil {
+			fatalf(exitUsage, "Error: %v", err)
+		}
+		output := robotHistoryOutput{HistoryReport: report}
+		if *pageSize != 0 || *pageCursor != "" {
+			output.Page = paginateHistory(report, pageReq)
+		}
+
 		// Output JSON
-		encoder := json.NewEncoder(os.Stdout)
+		encoder := newRobotEncoder(os.Stdout)
 		encoder.SetIndent("", "  ")
-		if err := encoder.Encode(report); err != nil {
-			fmt.Fprintf(os.Stderr, "Error encoding history report: %v\n", err)
-			os.Exit(1)
+		if err := encoder.Encode(output); err != nil {
+			fatalf(exitCodeFor(err), "Error encoding history report: %v", err)
 		}
 		os.Exit(0)
 	}
@@ -2782,24 +4155,21 @@ func main() {
 	if *robotExplainCorrelation != "" || *robotConfirmCorrelation != "" || *robotRejectCorrelation != "" || *robotCorrelationStats {
 		beadsDir, err := loader.GetBeadsDir("")
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error getting beads directory: %v\n", err)
-			os.Exit(1)
+			fatalf(exitCodeFor(err), "Error getting beads directory: %v", err)
 		}
 
 		feedbackStore := correlation.NewFeedbackStore(beadsDir)
 		if err := feedbackStore.Load(); err != nil {
-			fmt.Fprintf(os.Stderr, "Error loading feedback: %v\n", err)
-			os.Exit(1)
+			fatalf(exitCodeFor(err), "Error loading feedback: %v", err)
 		}
 
 		// Handle --robot-correlation-stats
 		if *robotCorrelationStats {
 			stats := feedbackStore.GetStats()
-			encoder := json.NewEncoder(os.Stdout)
+			encoder := newRobotEncoder(os.Stdout)
 			encoder.SetIndent("", "  ")
 			if err := encoder.Encode(stats); err != nil {
-				fmt.Fprintf(os.Stderr, "Error encoding stats: %v\n", err)
-				os.Exit(1)
+				fatalf(exitCodeFor(err), "Error encoding stats: %v", err)
 			}
 			os.Exit(0)
 		}
@@ -2817,22 +4187,19 @@ func main() {
 		if *robotExplainCorrelation != "" {
 			commitSHA, beadID, err := parseCorrelationArg(*robotExplainCorrelation)
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-				os.Exit(1)
+				fatalf(exitUsage, "Error: %v", err)
 			}
 
 			// Generate history report to find the correlation
 			cwd, err := os.Getwd()
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "Error getting current directory: %v\n", err)
-				os.Exit(1)
+				fatalf(exitCodeFor(err), "Error getting current directory: %v", err)
 			}
 			beadsPath, err := loader.FindJSONLPath(beadsDir)
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "Error finding beads file: %v\n", err)
-				os.Exit(1)
+				fatalf(exitCodeFor(err), "Error finding beads file: %v", err)
 			}
-			correlator := correlation.NewCorrelator(cwd, beadsPath)
+			correlator := correlation.NewIndexedCorrelator(cwd, beadsPath)
 
 			beadInfos := make([]correlation.BeadInfo, len(issues))
 			for i, issue := range issues {
@@ -2840,21 +4207,20 @@ func main() {
 					ID:     issue.ID,
 					Title:  issue.Title,
 					Status: string(issue.Status),
+					Events: issue.Events,
 				}
 			}
 
 			opts := correlation.CorrelatorOptions{BeadID: beadID}
 			report, err := correlator.GenerateReport(beadInfos, opts)
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "Error generating report: %v\n", err)
-				os.Exit(1)
+				fatalf(exitCodeFor(err), "Error generating report: %v", err)
 			}
 
 			// Find the specific commit
 			history, ok := report.Histories[beadID]
 			if !ok {
-				fmt.Fprintf(os.Stderr, "Bead not found: %s\n", beadID)
-				os.Exit(1)
+				fatalf(exitNotFound, "Bead not found: %s", beadID)
 			}
 
 			var targetCommit *correlation.CorrelatedCommit
@@ -2866,8 +4232,7 @@ func main() {
 			}
 
 			if targetCommit == nil {
-				fmt.Fprintf(os.Stderr, "Commit %s not found in bead %s correlations\n", commitSHA, beadID)
-				os.Exit(1)
+				fatalf(exitNotFound, "Commit %s not found in bead %s correlations", commitSHA, beadID)
 			}
 
 			// Generate explanation
@@ -2879,11 +4244,10 @@ func main() {
 				explanation.Recommendation = fmt.Sprintf("Already has feedback: %s", fb.Type)
 			}
 
-			encoder := json.NewEncoder(os.Stdout)
+			encoder := newRobotEncoder(os.Stdout)
 			encoder.SetIndent("", "  ")
 			if err := encoder.Encode(explanation); err != nil {
-				fmt.Fprintf(os.Stderr, "Error encoding explanation: %v\n", err)
-				os.Exit(1)
+				fatalf(exitCodeFor(err), "Error encoding explanation: %v", err)
 			}
 			os.Exit(0)
 		}
@@ -2892,8 +4256,7 @@ func main() {
 		if *robotConfirmCorrelation != "" {
 			commitSHA, beadID, err := parseCorrelationArg(*robotConfirmCorrelation)
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-				os.Exit(1)
+				fatalf(exitUsage, "Error: %v", err)
 			}
 
 			feedbackBy := *correlationFeedbackBy
@@ -2904,26 +4267,23 @@ func main() {
 			// Get original confidence from history
 			cwd, err := os.Getwd()
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "Error getting current directory: %v\n", err)
-				os.Exit(1)
+				fatalf(exitCodeFor(err), "Error getting current directory: %v", err)
 			}
 			beadsPath, err := loader.FindJSONLPath(beadsDir)
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "Error finding beads file: %v\n", err)
-				os.Exit(1)
+				fatalf(exitCodeFor(err), "Error finding beads file: %v", err)
 			}
-			correlator := correlation.NewCorrelator(cwd, beadsPath)
+			correlator := correlation.NewIndexedCorrelator(cwd, beadsPath)
 
 			beadInfos := make([]correlation.BeadInfo, len(issues))
 			for i, issue := range issues {
-				beadInfos[i] = correlation.BeadInfo{ID: issue.ID, Title: issue.Title, Status: string(issue.Status)}
+				beadInfos[i] = correlation.BeadInfo{ID: issue.ID, Title: issue.Title, Status: string(issue.Status), Events: issue.Events}
 			}
 
 			opts := correlation.CorrelatorOptions{BeadID: beadID}
 			report, err := correlator.GenerateReport(beadInfos, opts)
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "Error generating report: %v\n", err)
-				os.Exit(1)
+				fatalf(exitCodeFor(err), "Error generating report: %v", err)
 			}
 
 			var originalConf float64
@@ -2938,23 +4298,21 @@ func main() {
 			}
 
 			if err := feedbackStore.Confirm(commitSHA, beadID, feedbackBy, originalConf, *correlationFeedbackReason); err != nil {
-				fmt.Fprintf(os.Stderr, "Error saving feedback: %v\n", err)
-				os.Exit(1)
+				fatalf(exitCodeFor(err), "Error saving feedback: %v", err)
 			}
 
-			result := map[string]interface{}{
-				"status":    "confirmed",
-				"commit":    commitSHA,
-				"bead":      beadID,
-				"by":        feedbackBy,
-				"reason":    *correlationFeedbackReason,
-				"orig_conf": originalConf,
+			result := robotCorrelationFeedbackOutput{
+				Status:   "confirmed",
+				Commit:   commitSHA,
+				Bead:     beadID,
+				By:       feedbackBy,
+				Reason:   *correlationFeedbackReason,
+				OrigConf: originalConf,
 			}
-			encoder := json.NewEncoder(os.Stdout)
+			encoder := newRobotEncoder(os.Stdout)
 			encoder.SetIndent("", "  ")
 			if err := encoder.Encode(result); err != nil {
-				fmt.Fprintf(os.Stderr, "Error encoding result: %v\n", err)
-				os.Exit(1)
+				fatalf(exitCodeFor(err), "Error encoding result: %v", err)
 			}
 			os.Exit(0)
 		}
@@ -2963,8 +4321,7 @@ func main() {
 		if *robotRejectCorrelation != "" {
 			commitSHA, beadID, err := parseCorrelationArg(*robotRejectCorrelation)
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-				os.Exit(1)
+				fatalf(exitUsage, "Error: %v", err)
 			}
 
 			feedbackBy := *correlationFeedbackBy
@@ -2975,26 +4332,23 @@ func main() {
 			// Get original confidence from history
 			cwd, err := os.Getwd()
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "Error getting current directory: %v\n", err)
-				os.Exit(1)
+				fatalf(exitCodeFor(err), "Error getting current directory: %v", err)
 			}
 			beadsPath, err := loader.FindJSONLPath(beadsDir)
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "Error finding beads file: %v\n", err)
-				os.Exit(1)
+				fatalf(exitCodeFor(err), "Error finding beads file: %v", err)
 			}
-			correlator := correlation.NewCorrelator(cwd, beadsPath)
+			correlator := correlation.NewIndexedCorrelator(cwd, beadsPath)
 
 			beadInfos := make([]correlation.BeadInfo, len(issues))
 			for i, issue := range issues {
-				beadInfos[i] = correlation.BeadInfo{ID: issue.ID, Title: issue.Title, Status: string(issue.Status)}
+				beadInfos[i] = correlation.BeadInfo{ID: issue.ID, Title: issue.Title, Status: string(issue.Status), Events: issue.Events}
 			}
 
 			opts := correlation.CorrelatorOptions{BeadID: beadID}
 			report, err := correlator.GenerateReport(beadInfos, opts)
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "Error generating report: %v\n", err)
-				os.Exit(1)
+				fatalf(exitCodeFor(err), "Error generating report: %v", err)
 			}
 
 			var originalConf float64
@@ -3009,23 +4363,21 @@ func main() {
 			}
 
 			if err := feedbackStore.Reject(commitSHA, beadID, feedbackBy, originalConf, *correlationFeedbackReason); err != nil {
-				fmt.Fprintf(os.Stderr, "Error saving feedback: %v\n", err)
-				os.Exit(1)
+				fatalf(exitCodeFor(err), "Error saving feedback: %v", err)
 			}
 
-			result := map[string]interface{}{
-				"status":    "rejected",
-				"commit":    commitSHA,
-				"bead":      beadID,
-				"by":        feedbackBy,
-				"reason":    *correlationFeedbackReason,
-				"orig_conf": originalConf,
+			result := robotCorrelationFeedbackOutput{
+				Status:   "rejected",
+				Commit:   commitSHA,
+				Bead:     beadID,
+				By:       feedbackBy,
+				Reason:   *correlationFeedbackReason,
+				OrigConf: originalConf,
 			}
-			encoder := json.NewEncoder(os.Stdout)
+			encoder := newRobotEncoder(os.Stdout)
 			encoder.SetIndent("", "  ")
 			if err := encoder.Encode(result); err != nil {
-				fmt.Fprintf(os.Stderr, "Error encoding result: %v\n", err)
-				os.Exit(1)
+				fatalf(exitCodeFor(err), "Error encoding result: %v", err)
 			}
 			os.Exit(0)
 		}
@@ -3035,26 +4387,22 @@ func main() {
 	if *robotOrphans {
 		cwd, err := os.Getwd()
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error getting current directory: %v\n", err)
-			os.Exit(1)
+			fatalf(exitCodeFor(err), "Error getting current directory: %v", err)
 		}
 
 		// Validate repository
 		if err := correlation.ValidateRepository(cwd); err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
+			fatalf(exitNotFound, "Error: %v", err)
 		}
 
 		// Get beads path
 		beadsDir, err := loader.GetBeadsDir("")
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error getting beads directory: %v\n", err)
-			os.Exit(1)
+			fatalf(exitCodeFor(err), "Error getting beads directory: %v", err)
 		}
 		beadsPath, err := loader.FindJSONLPath(beadsDir)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error finding beads file: %v\n", err)
-			os.Exit(1)
+			fatalf(exitCodeFor(err), "Error finding beads file: %v", err)
 		}
 
 		// Convert issues to BeadInfo
@@ -3064,19 +4412,19 @@ func main() {
 				ID:     issue.ID,
 				Title:  issue.Title,
 				Status: string(issue.Status),
+				Events: issue.Events,
 			}
 		}
 
 		// Generate history report first (to get existing correlations)
-		correlator := correlation.NewCorrelator(cwd, beadsPath)
+		correlator := correlation.NewIndexedCorrelator(cwd, beadsPath)
 		correlatorOpts := correlation.CorrelatorOptions{
 			Limit: *historyLimit,
 		}
 
 		report, err := correlator.GenerateReport(beadInfos, correlatorOpts)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error generating history report: %v\n", err)
-			os.Exit(1)
+			fatalf(exitCodeFor(err), "Error generating history report: %v", err)
 		}
 
 		// Detect orphans using OrphanDetector
@@ -3086,8 +4434,7 @@ func main() {
 		}
 		orphanReport, err := detector.DetectOrphans(extractOpts)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error detecting orphans: %v\n", err)
-			os.Exit(1)
+			fatalf(exitCodeFor(err), "Error detecting orphans: %v", err)
 		}
 
 		// Filter by minimum score
@@ -3109,11 +4456,10 @@ func main() {
 			orphanReport.Stats.AvgSuspicion = float64(totalSuspicion) / float64(len(filteredCandidates))
 		}
 
-		encoder := json.NewEncoder(os.Stdout)
+		encoder := newRobotEncoder(os.Stdout)
 		encoder.SetIndent("", "  ")
 		if err := encoder.Encode(orphanReport); err != nil {
-			fmt.Fprintf(os.Stderr, "Error encoding orphan report: %v\n", err)
-			os.Exit(1)
+			fatalf(exitCodeFor(err), "Error encoding orphan report: %v", err)
 		}
 		os.Exit(0)
 	}
@@ -3122,26 +4468,22 @@ func main() {
 	if *robotFileBeads != "" || *fileHotspots {
 		cwd, err := os.Getwd()
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error getting current directory: %v\n", err)
-			os.Exit(1)
+			fatalf(exitCodeFor(err), "Error getting current directory: %v", err)
 		}
 
 		// Validate repository
 		if err := correlation.ValidateRepository(cwd); err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
+			fatalf(exitNotFound, "Error: %v", err)
 		}
 
 		// Resolve beads file path
 		beadsDir, err := loader.GetBeadsDir("")
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error getting beads directory: %v\n", err)
-			os.Exit(1)
+			fatalf(exitCodeFor(err), "Error getting beads directory: %v", err)
 		}
 		beadsPath, err := loader.FindJSONLPath(beadsDir)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error finding beads file: %v\n", err)
-			os.Exit(1)
+			fatalf(exitCodeFor(err), "Error finding beads file: %v", err)
 		}
 
 		// Convert issues to BeadInfo for correlator
@@ -3151,45 +4493,38 @@ func main() {
 				ID:     issue.ID,
 				Title:  issue.Title,
 				Status: string(issue.Status),
+				Events: issue.Events,
 			}
 		}
 
 		// Generate history report first
-		correlator := correlation.NewCorrelator(cwd, beadsPath)
+		correlator := correlation.NewIndexedCorrelator(cwd, beadsPath)
 		report, err := correlator.GenerateReport(beadInfos, correlation.CorrelatorOptions{
 			Limit: *historyLimit,
 		})
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error generating history report: %v\n", err)
-			os.Exit(1)
+			fatalf(exitCodeFor(err), "Error generating history report: %v", err)
 		}
 
 		// Create file lookup
 		fileLookup := correlation.NewFileLookup(report)
 
-		encoder := json.NewEncoder(os.Stdout)
+		encoder := newRobotEncoder(os.Stdout)
 		encoder.SetIndent("", "  ")
 
 		if *fileHotspots {
 			// Output hotspots
-			type HotspotsOutput struct {
-				GeneratedAt time.Time                  `json:"generated_at"`
-				DataHash    string                     `json:"data_hash"`
-				Hotspots    []correlation.FileHotspot  `json:"hotspots"`
-				Stats       correlation.FileIndexStats `json:"stats"`
-			}
 
 			hotspots := fileLookup.GetHotspots(*hotspotsLimit)
 			output := HotspotsOutput{
-				GeneratedAt: time.Now(),
+				GeneratedAt: robotNow(),
 				DataHash:    report.DataHash,
 				Hotspots:    hotspots,
 				Stats:       fileLookup.GetStats(),
 			}
 
 			if err := encoder.Encode(output); err != nil {
-				fmt.Fprintf(os.Stderr, "Error encoding hotspots: %v\n", err)
-				os.Exit(1)
+				fatalf(exitCodeFor(err), "Error encoding hotspots: %v", err)
 			}
 		} else {
 			// Output file-beads lookup
@@ -3200,54 +4535,325 @@ func main() {
 				result.ClosedBeads = result.ClosedBeads[:*fileBeadsLimit]
 			}
 
-			type FileBeadsOutput struct {
-				GeneratedAt time.Time                   `json:"generated_at"`
-				DataHash    string                      `json:"data_hash"`
-				FilePath    string                      `json:"file_path"`
-				TotalBeads  int                         `json:"total_beads"`
-				OpenBeads   []correlation.BeadReference `json:"open_beads"`
-				ClosedBeads []correlation.BeadReference `json:"closed_beads"`
-			}
+			output := FileBeadsOutput{
+				GeneratedAt: robotNow(),
+				DataHash:    report.DataHash,
+				FilePath:    *robotFileBeads,
+				TotalBeads:  result.TotalBeads,
+				OpenBeads:   result.OpenBeads,
+				ClosedBeads: result.ClosedBeads,
+			}
+
+			if err := encoder.Encode(output); err != nil {
+				fatalf(exitCodeFor(err), "Error encoding file beads: %v", err)
+			}
+		}
+		os.Exit(0)
+	}
+
+	// Handle --robot-impact flag (bv-19pq)
+	if *robotImpact != "" {
+		cwd, err := os.Getwd()
+		if err != nil {
+			fatalf(exitCodeFor(err), "Error getting current directory: %v", err)
+		}
+
+		if err := correlation.ValidateRepository(cwd); err != nil {
+			fatalf(exitNotFound, "Error: %v", err)
+		}
+
+		beadsDir, err := loader.GetBeadsDir("")
+		if err != nil {
+			fatalf(exitCodeFor(err), "Error getting beads directory: %v", err)
+		}
+		beadsPath, err := loader.FindJSONLPath(beadsDir)
+		if err != nil {
+			fatalf(exitCodeFor(err), "Error finding beads file: %v", err)
+		}
+
+		beadInfos := make([]correlation.BeadInfo, len(issues))
+		for i, issue := range issues {
+			beadInfos[i] = correlation.BeadInfo{
+				ID:     issue.ID,
+				Title:  issue.Title,
+				Status: string(issue.Status),
+				Events: issue.Events,
+			}
+		}
+
+		correlator := correlation.NewIndexedCorrelator(cwd, beadsPath)
+		report, err := correlator.GenerateReport(beadInfos, correlation.CorrelatorOptions{
+			Limit: *historyLimit,
+		})
+		if err != nil {
+			fatalf(exitCodeFor(err), "Error generating history report: %v", err)
+		}
+
+		fileLookup := correlation.NewFileLookup(report)
+		files := strings.Split(*robotImpact, ",")
+		for i := range files {
+			files[i] = strings.TrimSpace(files[i])
+		}
+
+		impactResult := fileLookup.ImpactAnalysis(files)
+
+		output := ImpactOutput{
+			GeneratedAt:   robotNow(),
+			DataHash:      report.DataHash,
+			Files:         impactResult.Files,
+			RiskLevel:     impactResult.RiskLevel,
+			RiskScore:     impactResult.RiskScore,
+			Summary:       impactResult.Summary,
+			Warnings:      impactResult.Warnings,
+			AffectedBeads: impactResult.AffectedBeads,
+		}
+
+		encoder := newRobotEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(output); err != nil {
+			fatalf(exitCodeFor(err), "Error encoding impact analysis: %v", err)
+		}
+		os.Exit(0)
+	}
+
+	// Handle --robot-code-map flag
+	if *robotCodeMap {
+		cwd, err := os.Getwd()
+		if err != nil {
+			fatalf(exitCodeFor(err), "Error getting current directory: %v", err)
+		}
+
+		if err := correlation.ValidateRepository(cwd); err != nil {
+			fatalf(exitNotFound, "Error: %v", err)
+		}
+
+		beadsDir, err := loader.GetBeadsDir("")
+		if err != nil {
+			fatalf(exitCodeFor(err), "Error getting beads directory: %v", err)
+		}
+		beadsPath, err := loader.FindJSONLPath(beadsDir)
+		if err != nil {
+			fatalf(exitCodeFor(err), "Error finding beads file: %v", err)
+		}
+
+		beadInfos := make([]correlation.BeadInfo, len(issues))
+		for i, issue := range issues {
+			beadInfos[i] = correlation.BeadInfo{
+				ID:     issue.ID,
+				Title:  issue.Title,
+				Status: string(issue.Status),
+				Events: issue.Events,
+			}
+		}
+
+		correlator := correlation.NewIndexedCorrelator(cwd, beadsPath)
+		report, err := correlator.GenerateReport(beadInfos, correlation.CorrelatorOptions{
+			Limit: *historyLimit,
+		})
+		if err != nil {
+			fatalf(exitCodeFor(err), "Error generating history report: %v", err)
+		}
+
+		codeMap := correlation.NewFileLookup(report).BuildCodeMap(correlation.CodeMapOptions{
+			Path:  *codeMapPath,
+			Depth: *codeMapDepth,
+			Limit: *codeMapLimit,
+		})
+
+		output := robotCodeMapOutput{
+			GeneratedAt: robotNow().UTC().Format(time.RFC3339),
+			DataHash:    dataHash,
+			Path:        *codeMapPath,
+			Directories: codeMap.Directories,
+			Files:       codeMap.Files,
+			Stats:       codeMap.Stats,
+			UsageHints: []string{
+				"jq '.directories[] | {path, owner: .open_beads[0].bead_id}' - likely owning bead per area",
+				"jq '.files[] | select(.open_count == 0)' - areas with no open work",
+				"--code-map-path <dir> to zoom in; --code-map-depth 0 for every directory level",
+			},
+		}
+
+		encoder := newRobotEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(output); err != nil {
+			fatalf(exitCodeFor(err), "Error encoding code map: %v", err)
+		}
+		os.Exit(0)
+	}
+
+	// Handle --robot-bus-factor flag
+	if *robotBusFactor {
+		if *busFactorThreshold <= 0 || *busFactorThreshold > 1 {
+			fatalf(exitUsage, "Error: --bus-factor-threshold must be in (0, 1], got %g", *busFactorThreshold)
+		}
+		cwd, err := os.Getwd()
+		if err != nil {
+			fatalf(exitCodeFor(err), "Error getting current directory: %v", err)
+		}
+
+		if err := correlation.ValidateRepository(cwd); err != nil {
+			fatalf(exitNotFound, "Error: %v", err)
+		}
+
+		beadsDir, err := loader.GetBeadsDir("")
+		if err != nil {
+			fatalf(exitCodeFor(err), "Error getting beads directory: %v", err)
+		}
+		beadsPath, err := loader.FindJSONLPath(beadsDir)
+		if err != nil {
+			fatalf(exitCodeFor(err), "Error finding beads file: %v", err)
+		}
+
+		beadInfos := make([]correlation.BeadInfo, len(issues))
+		labels := make(map[string][]string, len(issues))
+		for i, issue := range issues {
+			beadInfos[i] = correlation.BeadInfo{
+				ID:     issue.ID,
+				Title:  issue.Title,
+				Status: string(issue.Status),
+				Events: issue.Events,
+			}
+			labels[issue.ID] = issue.Labels
+		}
+
+		correlator := correlation.NewIndexedCorrelator(cwd, beadsPath)
+		report, err := correlator.GenerateReport(beadInfos, correlation.CorrelatorOptions{
+			Limit: *historyLimit,
+		})
+		if err != nil {
+			fatalf(exitCodeFor(err), "Error generating history report: %v", err)
+		}
+
+		stats := analysis.NewAnalyzer(issues).Analyze()
+		ownership := report.AnalyzeOwnership(correlation.OwnershipOptions{
+			Labels:    labels,
+			PageRank:  stats.PageRank(),
+			Threshold: *busFactorThreshold,
+		})
+
+		output := robotBusFactorOutput{
+			GeneratedAt:     robotNow().UTC().Format(time.RFC3339),
+			DataHash:        dataHash,
+			OwnershipReport: ownership,
+			UsageHints: []string{
+				"jq '.areas[] | select(.at_risk) | {kind, name, owner: .authors[0].author, top_share}' - one-person areas",
+				"jq '[.areas[] | select(.at_risk) | .key_open_issues[].bead_id] | unique' - key issues to pair on",
+				"jq '.areas[] | select(.bus_factor == 1 and .kind == \"subsystem\")' - subsystems one person carries",
+				"--bus-factor-threshold 0.6 to flag less extreme concentration",
+			},
+		}
+
+		encoder := newRobotEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(output); err != nil {
+			fatalf(exitCodeFor(err), "Error encoding bus factor analysis: %v", err)
+		}
+		os.Exit(0)
+	}
+
+	// Handle --why flag (blame-style annotation)
+	if *whySpec != "" {
+		whyPath, whyStart, whyEnd, err := correlation.ParseWhySpec(*whySpec)
+		if err != nil {
+			fatalf(exitUsage, "Error: invalid --why: %v", err)
+		}
+
+		cwd, err := os.Getwd()
+		if err != nil {
+			fatalf(exitCodeFor(err), "Error getting current directory: %v", err)
+		}
+
+		if err := correlation.ValidateRepository(cwd); err != nil {
+			fatalf(exitNotFound, "Error: %v", err)
+		}
+
+		beadsDir, err := loader.GetBeadsDir("")
+		if err != nil {
+			fatalf(exitCodeFor(err), "Error getting beads directory: %v", err)
+		}
+		beadsPath, err := loader.FindJSONLPath(beadsDir)
+		if err != nil {
+			fatalf(exitCodeFor(err), "Error finding beads file: %v", err)
+		}
+
+		beadInfos := make([]correlation.BeadInfo, len(issues))
+		links := make(map[string]string)
+		for i, issue := range issues {
+			beadInfos[i] = correlation.BeadInfo{
+				ID:     issue.ID,
+				Title:  issue.Title,
+				Status: string(issue.Status),
+				Events: issue.Events,
+			}
+			if issue.ExternalRef != nil && strings.Contains(*issue.ExternalRef, "://") {
+				links[issue.ID] = *issue.ExternalRef
+			}
+		}
+
+		correlator := correlation.NewIndexedCorrelator(cwd, beadsPath)
+		report, err := correlator.GenerateReport(beadInfos, correlation.CorrelatorOptions{
+			Limit: *historyLimit,
+		})
+		if err != nil {
+			fatalf(exitCodeFor(err), "Error generating history report: %v", err)
+		}
 
-			output := FileBeadsOutput{
-				GeneratedAt: time.Now(),
-				DataHash:    report.DataHash,
-				FilePath:    *robotFileBeads,
-				TotalBeads:  result.TotalBeads,
-				OpenBeads:   result.OpenBeads,
-				ClosedBeads: result.ClosedBeads,
-			}
+		whyResult, err := correlation.NewReverseLookupWithRepo(report, cwd).Why(whyPath, whyStart, whyEnd)
+		if err != nil {
+			fatalf(exitCodeFor(err), "Error: %v", err)
+		}
+		for i := range whyResult.Beads {
+			whyResult.Beads[i].Link = links[whyResult.Beads[i].BeadID]
+		}
 
-			if err := encoder.Encode(output); err != nil {
-				fmt.Fprintf(os.Stderr, "Error encoding file beads: %v\n", err)
-				os.Exit(1)
-			}
+		if !*robotWhy && !envRobot {
+			writeWhyText(os.Stdout, whyResult)
+			os.Exit(0)
+		}
+
+		output := robotWhyOutput{
+			GeneratedAt: robotNow().UTC(),
+			DataHash:    report.DataHash,
+			WhyResult:   whyResult,
+			UsageHints: []string{
+				"jq '.beads[0]' - bead most responsible for these lines",
+				"jq '.commits[] | select(.bead_ids == [])' - commits with no linked bead",
+				"--history-limit 0 to search the full history for older code",
+			},
+		}
+
+		encoder := newRobotEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(output); err != nil {
+			fatalf(exitCodeFor(err), "Error encoding why result: %v", err)
 		}
 		os.Exit(0)
 	}
 
-	// Handle --robot-impact flag (bv-19pq)
-	if *robotImpact != "" {
+	// Handle --robot-suggest-trailers flag
+	if *robotSuggestTrailers {
 		cwd, err := os.Getwd()
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error getting current directory: %v\n", err)
-			os.Exit(1)
+			fatalf(exitCodeFor(err), "Error getting current directory: %v", err)
 		}
 
 		if err := correlation.ValidateRepository(cwd); err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
+			fatalf(exitNotFound, "Error: %v", err)
+		}
+
+		changed, source, err := correlation.ChangedFiles(cwd, *trailersDiff)
+		if err != nil {
+			fatalf(exitCodeFor(err), "Error listing changed files: %v", err)
 		}
 
 		beadsDir, err := loader.GetBeadsDir("")
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error getting beads directory: %v\n", err)
-			os.Exit(1)
+			fatalf(exitCodeFor(err), "Error getting beads directory: %v", err)
 		}
 		beadsPath, err := loader.FindJSONLPath(beadsDir)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error finding beads file: %v\n", err)
-			os.Exit(1)
+			fatalf(exitCodeFor(err), "Error finding beads file: %v", err)
 		}
 
 		beadInfos := make([]correlation.BeadInfo, len(issues))
@@ -3256,53 +4862,38 @@ func main() {
 				ID:     issue.ID,
 				Title:  issue.Title,
 				Status: string(issue.Status),
+				Events: issue.Events,
 			}
 		}
 
-		correlator := correlation.NewCorrelator(cwd, beadsPath)
+		correlator := correlation.NewIndexedCorrelator(cwd, beadsPath)
 		report, err := correlator.GenerateReport(beadInfos, correlation.CorrelatorOptions{
 			Limit: *historyLimit,
 		})
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error generating history report: %v\n", err)
-			os.Exit(1)
-		}
-
-		fileLookup := correlation.NewFileLookup(report)
-		files := strings.Split(*robotImpact, ",")
-		for i := range files {
-			files[i] = strings.TrimSpace(files[i])
+			fatalf(exitCodeFor(err), "Error generating history report: %v", err)
 		}
 
-		impactResult := fileLookup.ImpactAnalysis(files)
-
-		type ImpactOutput struct {
-			GeneratedAt   time.Time                  `json:"generated_at"`
-			DataHash      string                     `json:"data_hash"`
-			Files         []string                   `json:"files"`
-			RiskLevel     string                     `json:"risk_level"`
-			RiskScore     float64                    `json:"risk_score"`
-			Summary       string                     `json:"summary"`
-			Warnings      []string                   `json:"warnings"`
-			AffectedBeads []correlation.AffectedBead `json:"affected_beads"`
-		}
+		suggestions := correlation.NewFileLookup(report).SuggestTrailers(changed, *trailersLimit)
 
-		output := ImpactOutput{
-			GeneratedAt:   time.Now(),
-			DataHash:      report.DataHash,
-			Files:         impactResult.Files,
-			RiskLevel:     impactResult.RiskLevel,
-			RiskScore:     impactResult.RiskScore,
-			Summary:       impactResult.Summary,
-			Warnings:      impactResult.Warnings,
-			AffectedBeads: impactResult.AffectedBeads,
+		output := robotSuggestTrailersOutput{
+			GeneratedAt:  robotNow().UTC(),
+			DataHash:     report.DataHash,
+			Source:       source,
+			Files:        suggestions.Files,
+			Suggestions:  suggestions.Suggestions,
+			TrailerBlock: suggestions.TrailerBlock,
+			UsageHints: []string{
+				"Append trailer_block after a blank line at the end of the commit message",
+				"Use 'Closes-Bead: <id>' instead of 'Bead: <id>' when the commit finishes the bead",
+				"jq '.suggestions[0].trailer' - top suggestion only",
+			},
 		}
 
-		encoder := json.NewEncoder(os.Stdout)
+		encoder := newRobotEncoder(os.Stdout)
 		encoder.SetIndent("", "  ")
 		if err := encoder.Encode(output); err != nil {
-			fmt.Fprintf(os.Stderr, "Error encoding impact analysis: %v\n", err)
-			os.Exit(1)
+			fatalf(exitCodeFor(err), "Error encoding trailer suggestions: %v", err)
 		}
 		os.Exit(0)
 	}
@@ -3311,30 +4902,25 @@ func main() {
 	if *robotFileRelations != "" {
 		cwd, err := os.Getwd()
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error getting current directory: %v\n", err)
-			os.Exit(1)
+			fatalf(exitCodeFor(err), "Error getting current directory: %v", err)
 		}
 
 		if err := correlation.ValidateRepository(cwd); err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
+			fatalf(exitNotFound, "Error: %v", err)
 		}
 
 		issues, err := loader.LoadIssues(cwd)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error loading beads: %v\n", err)
-			os.Exit(1)
+			fatalf(exitCodeFor(err), "Error loading beads: %v", err)
 		}
 
 		beadsDir, err := loader.GetBeadsDir("")
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error getting beads directory: %v\n", err)
-			os.Exit(1)
+			fatalf(exitCodeFor(err), "Error getting beads directory: %v", err)
 		}
 		beadsPath, err := loader.FindJSONLPath(beadsDir)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error finding beads file: %v\n", err)
-			os.Exit(1)
+			fatalf(exitCodeFor(err), "Error finding beads file: %v", err)
 		}
 
 		beadInfos := make([]correlation.BeadInfo, len(issues))
@@ -3343,32 +4929,23 @@ func main() {
 				ID:     issue.ID,
 				Title:  issue.Title,
 				Status: string(issue.Status),
+				Events: issue.Events,
 			}
 		}
 
-		correlator := correlation.NewCorrelator(cwd, beadsPath)
+		correlator := correlation.NewIndexedCorrelator(cwd, beadsPath)
 		report, err := correlator.GenerateReport(beadInfos, correlation.CorrelatorOptions{
 			Limit: *historyLimit,
 		})
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error generating history report: %v\n", err)
-			os.Exit(1)
+			fatalf(exitCodeFor(err), "Error generating history report: %v", err)
 		}
 
 		fileLookup := correlation.NewFileLookup(report)
 		result := fileLookup.GetRelatedFiles(*robotFileRelations, *relationsThreshold, *relationsLimit)
 
-		type RelationsOutput struct {
-			GeneratedAt  time.Time                   `json:"generated_at"`
-			DataHash     string                      `json:"data_hash"`
-			FilePath     string                      `json:"file_path"`
-			TotalCommits int                         `json:"total_commits"`
-			Threshold    float64                     `json:"threshold"`
-			RelatedFiles []correlation.CoChangeEntry `json:"related_files"`
-		}
-
 		output := RelationsOutput{
-			GeneratedAt:  time.Now(),
+			GeneratedAt:  robotNow(),
 			DataHash:     report.DataHash,
 			FilePath:     result.FilePath,
 			TotalCommits: result.TotalCommits,
@@ -3376,11 +4953,10 @@ func main() {
 			RelatedFiles: result.RelatedFiles,
 		}
 
-		encoder := json.NewEncoder(os.Stdout)
+		encoder := newRobotEncoder(os.Stdout)
 		encoder.SetIndent("", "  ")
 		if err := encoder.Encode(output); err != nil {
-			fmt.Fprintf(os.Stderr, "Error encoding file relations: %v\n", err)
-			os.Exit(1)
+			fatalf(exitCodeFor(err), "Error encoding file relations: %v", err)
 		}
 		os.Exit(0)
 	}
@@ -3389,30 +4965,25 @@ func main() {
 	if *robotRelatedWork != "" {
 		cwd, err := os.Getwd()
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error getting current directory: %v\n", err)
-			os.Exit(1)
+			fatalf(exitCodeFor(err), "Error getting current directory: %v", err)
 		}
 
 		if err := correlation.ValidateRepository(cwd); err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
+			fatalf(exitNotFound, "Error: %v", err)
 		}
 
 		issues, err := loader.LoadIssues(cwd)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error loading beads: %v\n", err)
-			os.Exit(1)
+			fatalf(exitCodeFor(err), "Error loading beads: %v", err)
 		}
 
 		beadsDir, err := loader.GetBeadsDir("")
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error getting beads directory: %v\n", err)
-			os.Exit(1)
+			fatalf(exitCodeFor(err), "Error getting beads directory: %v", err)
 		}
 		beadsPath, err := loader.FindJSONLPath(beadsDir)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error finding beads file: %v\n", err)
-			os.Exit(1)
+			fatalf(exitCodeFor(err), "Error finding beads file: %v", err)
 		}
 
 		beadInfos := make([]correlation.BeadInfo, len(issues))
@@ -3421,16 +4992,16 @@ func main() {
 				ID:     issue.ID,
 				Title:  issue.Title,
 				Status: string(issue.Status),
+				Events: issue.Events,
 			}
 		}
 
-		correlatorObj := correlation.NewCorrelator(cwd, beadsPath)
+		correlatorObj := correlation.NewIndexedCorrelator(cwd, beadsPath)
 		report, err := correlatorObj.GenerateReport(beadInfos, correlation.CorrelatorOptions{
 			Limit: *historyLimit,
 		})
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error generating history report: %v\n", err)
-			os.Exit(1)
+			fatalf(exitCodeFor(err), "Error generating history report: %v", err)
 		}
 
 		// Build dependency graph from issues
@@ -3452,26 +5023,20 @@ func main() {
 
 		result := report.FindRelatedWork(*robotRelatedWork, opts)
 		if result == nil {
-			fmt.Fprintf(os.Stderr, "Bead not found in history: %s\n", *robotRelatedWork)
-			os.Exit(1)
+			fatalf(exitNotFound, "Bead not found in history: %s", *robotRelatedWork)
 		}
 
 		// Add data hash to output
-		type RelatedWorkOutput struct {
-			*correlation.RelatedWorkResult
-			DataHash string `json:"data_hash"`
-		}
 
 		output := RelatedWorkOutput{
 			RelatedWorkResult: result,
 			DataHash:          report.DataHash,
 		}
 
-		encoder := json.NewEncoder(os.Stdout)
+		encoder := newRobotEncoder(os.Stdout)
 		encoder.SetIndent("", "  ")
 		if err := encoder.Encode(output); err != nil {
-			fmt.Fprintf(os.Stderr, "Error encoding related work: %v\n", err)
-			os.Exit(1)
+			fatalf(exitCodeFor(err), "Error encoding related work: %v", err)
 		}
 		os.Exit(0)
 	}
@@ -3480,44 +5045,120 @@ func main() {
 	if *robotBlockerChain != "" {
 		cwd, err := os.Getwd()
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error getting current directory: %v\n", err)
-			os.Exit(1)
+			fatalf(exitCodeFor(err), "Error getting current directory: %v", err)
 		}
 
 		issues, err := loader.LoadIssues(cwd)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error loading beads: %v\n", err)
-			os.Exit(1)
+			fatalf(exitCodeFor(err), "Error loading beads: %v", err)
 		}
 
 		an := analysis.NewAnalyzer(issues)
 		result := an.GetBlockerChain(*robotBlockerChain)
 
 		if result == nil {
-			fmt.Fprintf(os.Stderr, "Issue not found: %s\n", *robotBlockerChain)
-			os.Exit(1)
-		}
-
-		type BlockerChainOutput struct {
-			GeneratedAt time.Time                    `json:"generated_at"`
-			DataHash    string                       `json:"data_hash"`
-			Result      *analysis.BlockerChainResult `json:"result"`
+			fatalf(exitNotFound, "Issue not found: %s", *robotBlockerChain)
 		}
 
 		// Compute data hash for consistency
 		dataHash := analysis.ComputeDataHash(issues)
 
 		output := BlockerChainOutput{
-			GeneratedAt: time.Now(),
+			GeneratedAt: robotNow(),
 			DataHash:    dataHash,
 			Result:      result,
 		}
 
-		encoder := json.NewEncoder(os.Stdout)
+		encoder := newRobotEncoder(os.Stdout)
 		encoder.SetIndent("", "  ")
 		if err := encoder.Encode(output); err != nil {
-			fmt.Fprintf(os.Stderr, "Error encoding blocker chain: %v\n", err)
-			os.Exit(1)
+			fatalf(exitCodeFor(err), "Error encoding blocker chain: %v", err)
+		}
+		os.Exit(0)
+	}
+
+	if *robotExplain != "" {
+		cwd, err := os.Getwd()
+		if err != nil {
+			fatalf(exitCodeFor(err), "Error getting current directory: %v", err)
+		}
+		explanation, err := analysis.ExplainIssue(issues, *robotExplain, analysis.ExplainOptions{
+			Now:     robotNow(),
+			Agents:  *forecastAgents,
+			Actuals: trackedActuals(),
+		})
+		if err != nil {
+			fatalf(exitNotFound, "Issue not found: %s", *robotExplain)
+		}
+		output := robotExplainOutput{
+			GeneratedAt:      robotNow().UTC().Format(time.RFC3339),
+			DataHash:         dataHash,
+			AsOf:             *asOf,
+			AsOfCommit:       asOfResolved,
+			IssueExplanation: explanation,
+			Commits:          explainCommits(cwd, issues, *robotExplain, *historyLimit),
+		}
+		encoder := newRobotEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(output); err != nil {
+			fatalf(exitCodeFor(err), "Error encoding explanation: %v", err)
+		}
+		os.Exit(0)
+	}
+
+	if *robotLayers {
+		if *capacityAgents < 1 {
+			fatalf(exitUsage, "Error: --agents must be at least 1, got %d", *capacityAgents)
+		}
+		roadmap := analysis.NewAnalyzer(issues).Layers(*capacityAgents)
+		switch strings.ToLower(*labelFlowFormat) {
+		case "", "json":
+		case "markdown", "md":
+			fmt.Print(export.LayersMarkdown(roadmap))
+			os.Exit(0)
+		case "mermaid":
+			fmt.Print(export.LayersMermaid(roadmap))
+			os.Exit(0)
+		default:
+			fatalf(exitUsage, "Invalid --format %q for --robot-layers (use json, markdown or mermaid)", *labelFlowFormat)
+		}
+		output := robotLayersOutput{
+			GeneratedAt:  robotNow().UTC().Format(time.RFC3339),
+			DataHash:     dataHash,
+			AsOf:         *asOf,
+			AsOfCommit:   asOfResolved,
+			LayerRoadmap: roadmap,
+			UsageHints: []string{
+				"jq '.layers[] | {layer, count, duration_days}' - Phase summary",
+				"jq '.layers[0].issues[].id' - Ready to start now",
+				"jq '.unlayered' - Issues held up by a dependency cycle",
+				"bv --robot-layers --format markdown > roadmap.md - Roadmap document with a Mermaid diagram",
+			},
+		}
+		encoder := newRobotEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(output); err != nil {
+			fatalf(exitCodeFor(err), "Error encoding layers: %v", err)
+		}
+		os.Exit(0)
+	}
+
+	if *robotTree != "" {
+		tree := analysis.NewAnalyzer(issues).DependencyTree(*robotTree)
+		if tree == nil {
+			fatalf(exitNotFound, "Issue not found: %s", *robotTree)
+		}
+		output := robotTreeOutput{
+			GeneratedAt:    robotNow().UTC().Format(time.RFC3339),
+			DataHash:       dataHash,
+			AsOf:           *asOf,
+			AsOfCommit:     asOfResolved,
+			DependencyTree: tree,
+		}
+		encoder := newRobotEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(output); err != nil {
+			fatalf(exitCodeFor(err), "Error encoding dependency tree: %v", err)
 		}
 		os.Exit(0)
 	}
@@ -3527,27 +5168,23 @@ func main() {
 	if *robotImpactNetwork != "" {
 		cwd, err := os.Getwd()
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error getting current directory: %v\n", err)
-			os.Exit(1)
+			fatalf(exitCodeFor(err), "Error getting current directory: %v", err)
 		}
 
 		// Find beads path
 		beadsDir, err := loader.GetBeadsDir("")
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error getting beads directory: %v\n", err)
-			os.Exit(1)
+			fatalf(exitCodeFor(err), "Error getting beads directory: %v", err)
 		}
 		beadsPath, err := loader.FindJSONLPath(beadsDir)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error finding beads file: %v\n", err)
-			os.Exit(1)
+			fatalf(exitCodeFor(err), "Error finding beads file: %v", err)
 		}
 
 		// Load issues
 		issues, err := loader.LoadIssues(cwd)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error loading beads: %v\n", err)
-			os.Exit(1)
+			fatalf(exitCodeFor(err), "Error loading beads: %v", err)
 		}
 
 		// Convert to BeadInfo slice
@@ -3557,17 +5194,17 @@ func main() {
 				ID:     issue.ID,
 				Title:  issue.Title,
 				Status: string(issue.Status),
+				Events: issue.Events,
 			}
 		}
 
 		// Generate history report
-		correlator := correlation.NewCorrelator(cwd, beadsPath)
+		correlator := correlation.NewIndexedCorrelator(cwd, beadsPath)
 		report, err := correlator.GenerateReport(beadInfos, correlation.CorrelatorOptions{
 			Limit: *historyLimit,
 		})
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error generating history report: %v\n", err)
-			os.Exit(1)
+			fatalf(exitCodeFor(err), "Error generating history report: %v", err)
 		}
 
 		// Build impact network
@@ -3592,11 +5229,10 @@ func main() {
 		// Generate result
 		result := network.ToResult(beadID, depth)
 
-		encoder := json.NewEncoder(os.Stdout)
+		encoder := newRobotEncoder(os.Stdout)
 		encoder.SetIndent("", "  ")
 		if err := encoder.Encode(result); err != nil {
-			fmt.Fprintf(os.Stderr, "Error encoding impact network: %v\n", err)
-			os.Exit(1)
+			fatalf(exitCodeFor(err), "Error encoding impact network: %v", err)
 		}
 		os.Exit(0)
 	}
@@ -3605,30 +5241,25 @@ func main() {
 	if *robotCausality != "" {
 		cwd, err := os.Getwd()
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error getting current directory: %v\n", err)
-			os.Exit(1)
+			fatalf(exitCodeFor(err), "Error getting current directory: %v", err)
 		}
 
 		if err := correlation.ValidateRepository(cwd); err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
+			fatalf(exitNotFound, "Error: %v", err)
 		}
 
 		issues, err := loader.LoadIssues(cwd)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error loading beads: %v\n", err)
-			os.Exit(1)
+			fatalf(exitCodeFor(err), "Error loading beads: %v", err)
 		}
 
 		beadsDir, err := loader.GetBeadsDir("")
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error getting beads directory: %v\n", err)
-			os.Exit(1)
+			fatalf(exitCodeFor(err), "Error getting beads directory: %v", err)
 		}
 		beadsPath, err := loader.FindJSONLPath(beadsDir)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error finding beads file: %v\n", err)
-			os.Exit(1)
+			fatalf(exitCodeFor(err), "Error finding beads file: %v", err)
 		}
 
 		beadInfos := make([]correlation.BeadInfo, len(issues))
@@ -3637,16 +5268,16 @@ func main() {
 				ID:     issue.ID,
 				Title:  issue.Title,
 				Status: string(issue.Status),
+				Events: issue.Events,
 			}
 		}
 
-		correlatorObj := correlation.NewCorrelator(cwd, beadsPath)
+		correlatorObj := correlation.NewIndexedCorrelator(cwd, beadsPath)
 		report, err := correlatorObj.GenerateReport(beadInfos, correlation.CorrelatorOptions{
 			Limit: *historyLimit,
 		})
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error generating history report: %v\n", err)
-			os.Exit(1)
+			fatalf(exitCodeFor(err), "Error generating history report: %v", err)
 		}
 
 		// Build blocker titles map for better descriptions
@@ -3662,15 +5293,13 @@ func main() {
 
 		result := report.BuildCausalityChain(*robotCausality, opts)
 		if result == nil {
-			fmt.Fprintf(os.Stderr, "Bead not found: %s\n", *robotCausality)
-			os.Exit(1)
+			fatalf(exitNotFound, "Bead not found: %s", *robotCausality)
 		}
 
-		encoder := json.NewEncoder(os.Stdout)
+		encoder := newRobotEncoder(os.Stdout)
 		encoder.SetIndent("", "  ")
 		if err := encoder.Encode(result); err != nil {
-			fmt.Fprintf(os.Stderr, "Error encoding causality result: %v\n", err)
-			os.Exit(1)
+			fatalf(exitCodeFor(err), "Error encoding causality result: %v", err)
 		}
 		os.Exit(0)
 	}
@@ -3679,14 +5308,12 @@ func main() {
 	if *robotSprintList || *robotSprintShow != "" {
 		cwd, err := os.Getwd()
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error getting current directory: %v\n", err)
-			os.Exit(1)
+			fatalf(exitCodeFor(err), "Error getting current directory: %v", err)
 		}
 
 		sprints, err := loader.LoadSprints(cwd)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error loading sprints: %v\n", err)
-			os.Exit(1)
+			fatalf(exitCodeFor(err), "Error loading sprints: %v", err)
 		}
 
 		if *robotSprintShow != "" {
@@ -3699,32 +5326,25 @@ func main() {
 				}
 			}
 			if found == nil {
-				fmt.Fprintf(os.Stderr, "Sprint not found: %s\n", *robotSprintShow)
-				os.Exit(1)
+				fatalf(exitNotFound, "Sprint not found: %s", *robotSprintShow)
 			}
 			// Output single sprint as JSON
-			encoder := json.NewEncoder(os.Stdout)
+			encoder := newRobotEncoder(os.Stdout)
 			encoder.SetIndent("", "  ")
 			if err := encoder.Encode(found); err != nil {
-				fmt.Fprintf(os.Stderr, "Error encoding sprint: %v\n", err)
-				os.Exit(1)
+				fatalf(exitCodeFor(err), "Error encoding sprint: %v", err)
 			}
 		} else {
 			// Output all sprints as JSON
-			output := struct {
-				GeneratedAt time.Time      `json:"generated_at"`
-				SprintCount int            `json:"sprint_count"`
-				Sprints     []model.Sprint `json:"sprints"`
-			}{
-				GeneratedAt: time.Now().UTC(),
+			output := robotSprintListOutput{
+				GeneratedAt: robotNow().UTC(),
 				SprintCount: len(sprints),
 				Sprints:     sprints,
 			}
-			encoder := json.NewEncoder(os.Stdout)
+			encoder := newRobotEncoder(os.Stdout)
 			encoder.SetIndent("", "  ")
 			if err := encoder.Encode(output); err != nil {
-				fmt.Fprintf(os.Stderr, "Error encoding sprints: %v\n", err)
-				os.Exit(1)
+				fatalf(exitCodeFor(err), "Error encoding sprints: %v", err)
 			}
 		}
 		os.Exit(0)
@@ -3734,14 +5354,12 @@ func main() {
 	if *robotBurndown != "" {
 		cwd, err := os.Getwd()
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error getting current directory: %v\n", err)
-			os.Exit(1)
+			fatalf(exitCodeFor(err), "Error getting current directory: %v", err)
 		}
 
 		sprints, err := loader.LoadSprints(cwd)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error loading sprints: %v\n", err)
-			os.Exit(1)
+			fatalf(exitCodeFor(err), "Error loading sprints: %v", err)
 		}
 
 		// Find the target sprint
@@ -3755,8 +5373,7 @@ func main() {
 				}
 			}
 			if targetSprint == nil {
-				fmt.Fprintf(os.Stderr, "No active sprint found\n")
-				os.Exit(1)
+				fatalf(exitNotFound, "No active sprint found")
 			}
 		} else {
 			// Find sprint by ID
@@ -3767,13 +5384,12 @@ func main() {
 				}
 			}
 			if targetSprint == nil {
-				fmt.Fprintf(os.Stderr, "Sprint not found: %s\n", *robotBurndown)
-				os.Exit(1)
+				fatalf(exitNotFound, "Sprint not found: %s", *robotBurndown)
 			}
 		}
 
 		// Build burndown data
-		now := time.Now()
+		now := robotNow()
 		burndown := calculateBurndownAt(targetSprint, issues, now)
 		issueMap := make(map[string]model.Issue, len(issues))
 		for _, iss := range issues {
@@ -3783,11 +5399,10 @@ func main() {
 			burndown.ScopeChanges = scopeChanges
 		}
 
-		encoder := json.NewEncoder(os.Stdout)
+		encoder := newRobotEncoder(os.Stdout)
 		encoder.SetIndent("", "  ")
 		if err := encoder.Encode(burndown); err != nil {
-			fmt.Fprintf(os.Stderr, "Error encoding burndown: %v\n", err)
-			os.Exit(1)
+			fatalf(exitCodeFor(err), "Error encoding burndown: %v", err)
 		}
 		os.Exit(0)
 	}
@@ -3796,8 +5411,7 @@ func main() {
 	if *robotForecast != "" {
 		cwd, err := os.Getwd()
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error getting current directory: %v\n", err)
-			os.Exit(1)
+			fatalf(exitCodeFor(err), "Error getting current directory: %v", err)
 		}
 
 		// Build graph stats for depth calculation
@@ -3821,8 +5435,7 @@ func main() {
 				}
 			}
 			if sprintBeadIDs == nil {
-				fmt.Fprintf(os.Stderr, "Sprint not found: %s\n", *forecastSprint)
-				os.Exit(1)
+				fatalf(exitNotFound, "Sprint not found: %s", *forecastSprint)
 			}
 		}
 
@@ -3847,30 +5460,15 @@ func main() {
 			targetIssues = append(targetIssues, iss)
 		}
 
-		now := time.Now()
+		now := robotNow()
 		agents := *forecastAgents
 		if agents <= 0 {
 			agents = 1
 		}
 
-		type ForecastSummary struct {
-			TotalMinutes  int       `json:"total_minutes"`
-			TotalDays     float64   `json:"total_days"`
-			AvgConfidence float64   `json:"avg_confidence"`
-			EarliestETA   time.Time `json:"earliest_eta"`
-			LatestETA     time.Time `json:"latest_eta"`
-		}
-		type ForecastOutput struct {
-			GeneratedAt   time.Time              `json:"generated_at"`
-			Agents        int                    `json:"agents"`
-			Filters       map[string]string      `json:"filters,omitempty"`
-			ForecastCount int                    `json:"forecast_count"`
-			Forecasts     []analysis.ETAEstimate `json:"forecasts"`
-			Summary       *ForecastSummary       `json:"summary,omitempty"`
-		}
-
 		var forecasts []analysis.ETAEstimate
 		var outputErr error
+		actuals := trackedActuals()
 
 		if *robotForecast == "all" {
 			// Forecast all open issues
@@ -3878,7 +5476,7 @@ func main() {
 				if iss.Status == model.StatusClosed {
 					continue
 				}
-				eta, err := analysis.EstimateETAForIssue(issues, &graphStats, iss.ID, agents, now)
+				eta, err := analysis.EstimateETAForIssueWithActuals(issues, &graphStats, iss.ID, agents, now, actuals)
 				if err != nil {
 					continue
 				}
@@ -3886,10 +5484,9 @@ func main() {
 			}
 		} else {
 			// Single issue forecast
-			eta, err := analysis.EstimateETAForIssue(issues, &graphStats, *robotForecast, agents, now)
+			eta, err := analysis.EstimateETAForIssueWithActuals(issues, &graphStats, *robotForecast, agents, now, actuals)
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-				os.Exit(1)
+				fatalf(exitNotFound, "Error: %v", err)
 			}
 			forecasts = append(forecasts, eta)
 		}
@@ -3940,17 +5537,25 @@ func main() {
 			output.Filters = filters
 		}
 
-		encoder := json.NewEncoder(os.Stdout)
+		encoder := newRobotEncoder(os.Stdout)
 		encoder.SetIndent("", "  ")
 		if outputErr = encoder.Encode(output); outputErr != nil {
-			fmt.Fprintf(os.Stderr, "Error encoding forecast: %v\n", outputErr)
-			os.Exit(1)
+			fatalf(exitError, "Error encoding forecast: %v", outputErr)
 		}
 		os.Exit(0)
 	}
 
 	// Handle --robot-capacity flag (bv-160)
 	if *robotCapacity {
+		var profiles *analysis.AgentProfiles
+		if *agentProfilesPath != "" {
+			var err error
+			profiles, err = analysis.LoadAgentProfiles(*agentProfilesPath)
+			if err != nil {
+				fatalf(exitCodeFor(err), "Error: %v", err)
+			}
+		}
+
 		// Build graph stats for analysis
 		analyzer := analysis.NewAnalyzer(issues)
 		graphStats := analyzer.Analyze()
@@ -3980,8 +5585,11 @@ func main() {
 			}
 		}
 
-		now := time.Now()
+		now := robotNow()
 		agents := *capacityAgents
+		if profiles != nil {
+			agents = len(profiles.Agents)
+		}
 		if agents <= 0 {
 			agents = 1
 		}
@@ -4078,12 +5686,6 @@ func main() {
 		estimatedDays := float64(effectiveMinutes) / (60.0 * 8.0) // 8hr workday
 
 		// Find bottlenecks (issues blocking the most other issues)
-		type Bottleneck struct {
-			ID          string   `json:"id"`
-			Title       string   `json:"title"`
-			BlocksCount int      `json:"blocks_count"`
-			Blocks      []string `json:"blocks,omitempty"`
-		}
 		bottlenecks := make([]Bottleneck, 0)
 		for _, iss := range openIssues {
 			if len(blocks[iss.ID]) > 1 {
@@ -4096,67 +5698,261 @@ func main() {
 				})
 			}
 		}
-		// Sort by blocks count descending
-		sort.Slice(bottlenecks, func(i, j int) bool {
-			return bottlenecks[i].BlocksCount > bottlenecks[j].BlocksCount
-		})
-		if len(bottlenecks) > 5 {
-			bottlenecks = bottlenecks[:5]
+		// Sort by blocks count descending
+		sort.Slice(bottlenecks, func(i, j int) bool {
+			return bottlenecks[i].BlocksCount > bottlenecks[j].BlocksCount
+		})
+		if len(bottlenecks) > 5 {
+			bottlenecks = bottlenecks[:5]
+		}
+
+		// Build output
+
+		output := CapacityOutput{
+			GeneratedAt:       now.UTC(),
+			Agents:            agents,
+			OpenIssueCount:    len(openIssues),
+			TotalMinutes:      totalMinutes,
+			TotalDays:         float64(totalMinutes) / (60.0 * 8.0),
+			SerialMinutes:     serialMinutes,
+			ParallelMinutes:   parallelMinutes,
+			ParallelizablePct: parallelizablePct,
+			EstimatedDays:     estimatedDays,
+			CriticalPathLen:   len(longestChain),
+			CriticalPath:      longestChain,
+			ActionableCount:   len(actionable),
+			Actionable:        actionable,
+			Bottlenecks:       bottlenecks,
+		}
+		if *capacityLabel != "" {
+			output.Label = *capacityLabel
+		}
+		if profiles != nil {
+			sim := analysis.SimulateCapacity(targetIssues, &graphStats, profiles, analysis.CapacitySimOptions{Runs: *capacityRuns}, now)
+			output.Simulation = &sim
+		}
+
+		// Suppress unused variable warning
+		_ = medianMinutes
+
+		encoder := newRobotEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(output); err != nil {
+			fatalf(exitCodeFor(err), "Error encoding capacity: %v", err)
+		}
+		os.Exit(0)
+	}
+
+	// Handle --robot-goals: chance of hitting each target date in .bv/goals.yaml
+	if *robotGoals {
+		projectDir, _ := os.Getwd()
+		goals, err := analysis.LoadGoals(projectDir)
+		if err != nil {
+			fatalf(exitCodeFor(err), "Error: %v", err)
+		}
+		var profiles *analysis.AgentProfiles
+		if *agentProfilesPath != "" {
+			profiles, err = analysis.LoadAgentProfiles(*agentProfilesPath)
+			if err != nil {
+				fatalf(exitCodeFor(err), "Error: %v", err)
+			}
+		}
+		stats := analysis.NewAnalyzer(issues).Analyze()
+		forecasts := analysis.ForecastGoals(issues, &stats, goals, profiles, analysis.CapacitySimOptions{Runs: *capacityRuns}, robotNow())
+
+		driftConfig, err := drift.LoadConfig(projectDir)
+		if err != nil {
+			fatalf(exitCodeFor(err), "Error loading drift config: %v", err)
+		}
+		output := robotGoalsOutput{
+			GeneratedAt: robotNow().UTC(),
+			DataHash:    dataHash,
+			Agents:      goals.Agents,
+			Runs:        *capacityRuns,
+			Threshold:   driftConfig.GoalWarningProbability,
+			Goals:       forecasts,
+			UsageHints: []string{
+				"jq '.goals[] | {name, target, probability}' - Chance of hitting each target",
+				"jq '.threshold as $t | .goals[] | select(.probability < $t) | {name, next_up}' - Where to start on goals at risk",
+				"--agent-profiles=.bv/agents.yaml - Simulate named agents instead of the file's agent count",
+				"--robot-alerts --alert-type=goal_at_risk - The same goals as drift alerts",
+			},
+		}
+		if profiles != nil {
+			output.Agents = len(profiles.Agents)
+		}
+		for _, f := range forecasts {
+			if !f.Done && f.Total > 0 && f.Probability < output.Threshold {
+				output.AtRisk++
+			}
+		}
+		if len(goals.Goals) == 0 {
+			output.UsageHints = append([]string{"No goals: create " + analysis.GoalsPath(projectDir)}, output.UsageHints...)
+		}
+
+		encoder := newRobotEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(output); err != nil {
+			fatalf(exitCodeFor(err), "Error encoding robot-goals: %v", err)
+		}
+		os.Exit(0)
+	}
+
+	// Handle --robot-queues: queueing-theory bottleneck analysis
+	if *robotQueues {
+		groupBy := analysis.QueueGroupBy(*queueBy)
+		if groupBy != analysis.QueueByLabel && groupBy != analysis.QueueByTrack {
+			fatalf(exitUsage, "Error: --queue-by must be label or track, got %q", *queueBy)
+		}
+		analyzer := analysis.NewAnalyzer(issues)
+		result := analysis.AnalyzeQueues(issues, analyzer, analysis.QueueOptions{GroupBy: groupBy, WindowDays: *queueWindow}, robotNow())
+
+		output := robotQueuesOutput{
+			GeneratedAt: robotNow().UTC().Format(time.RFC3339),
+			DataHash:    dataHash,
+			AsOf:        *asOf,
+			AsOfCommit:  asOfResolved,
+			Queues:      result,
+			UsageHints: []string{
+				"jq '.queues.constraint' - The queue limiting throughput",
+				"jq '.queues.queues[] | select(.stable | not) | .name' - Queues whose backlog grows",
+				"jq '.queues.queues[] | {name, utilization, expected_wait_days}' - Load and wait per queue",
+				"--queue-by=track - Model execution tracks instead of labels",
+			},
+		}
+		encoder := newRobotEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(output); err != nil {
+			fatalf(exitCodeFor(err), "Error encoding robot-queues: %v", err)
+		}
+		os.Exit(0)
+	}
+
+	// Handle --robot-review: the review queue and review latency
+	if *robotReview {
+		projectDir, _ := os.Getwd()
+		driftConfig, err := drift.LoadConfig(projectDir)
+		if err != nil {
+			fatalf(exitCodeFor(err), "Error loading drift config: %v", err)
+		}
+		review := analysis.ComputeReviewStats(issues, *reviewWindow, robotNow())
+		overdue := 0
+		for _, item := range review.Queue {
+			if item.AgeDays >= float64(driftConfig.ReviewWarningDays) {
+				overdue++
+			}
+		}
+
+		output := robotReviewOutput{
+			GeneratedAt:  robotNow().UTC().Format(time.RFC3339),
+			DataHash:     dataHash,
+			WarningDays:  driftConfig.ReviewWarningDays,
+			CriticalDays: driftConfig.ReviewCriticalDays,
+			Overdue:      overdue,
+			Review:       review,
+			UsageHints: []string{
+				"jq '.review.queue[] | {id, age_days, assignee}' - Who is waiting on review",
+				"jq '.review.latency' - How long reviews take",
+				"jq '.review.by_label[] | select(.waiting > 0)' - Review queues per label",
+				"--robot-alerts --alert-type=review_overdue - Reviews past the drift thresholds",
+			},
+		}
+		encoder := newRobotEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(output); err != nil {
+			fatalf(exitCodeFor(err), "Error encoding robot-review: %v", err)
+		}
+		os.Exit(0)
+	}
+
+	// Handle --robot-estimates: estimate coverage and what to estimate first
+	if *robotEstimates {
+		analyzer := analysis.NewAnalyzer(issues)
+		stats := analyzer.Analyze()
+		report := analysis.ComputeEstimateReport(issues, &stats, *robotMaxResults)
+		variance := analysis.ComputeEstimateVariance(issues, trackedActuals())
+
+		output := robotEstimatesOutput{
+			GeneratedAt: robotNow().UTC().Format(time.RFC3339),
+			DataHash:    dataHash,
+			AsOf:        *asOf,
+			AsOfCommit:  asOfResolved,
+			Estimates:   report,
+			Actuals:     variance,
+			UsageHints: []string{
+				"jq '.estimates.coverage_pct' - Share of open issues with an estimate",
+				"jq '.estimates.by_label[] | select(.coverage_pct < 50) | .label' - Labels that need estimating",
+				"jq '.estimates.largest_unestimated[].id' - Estimate these first",
+				"jq '.actuals.by_label[] | {label, variance_pct, samples}' - Estimate vs tracked time (bv track)",
+				"--robot-forecast all - ETAs that use these estimates",
+			},
+		}
+		encoder := newRobotEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(output); err != nil {
+			fatalf(exitCodeFor(err), "Error encoding robot-estimates: %v", err)
 		}
+		os.Exit(0)
+	}
 
-		// Build output
-		type CapacityOutput struct {
-			GeneratedAt       time.Time    `json:"generated_at"`
-			Agents            int          `json:"agents"`
-			Label             string       `json:"label,omitempty"`
-			OpenIssueCount    int          `json:"open_issue_count"`
-			TotalMinutes      int          `json:"total_minutes"`
-			TotalDays         float64      `json:"total_days"`
-			SerialMinutes     int          `json:"serial_minutes"`
-			ParallelMinutes   int          `json:"parallel_minutes"`
-			ParallelizablePct float64      `json:"parallelizable_pct"`
-			EstimatedDays     float64      `json:"estimated_days"`
-			CriticalPathLen   int          `json:"critical_path_length"`
-			CriticalPath      []string     `json:"critical_path,omitempty"`
-			ActionableCount   int          `json:"actionable_count"`
-			Actionable        []string     `json:"actionable,omitempty"`
-			Bottlenecks       []Bottleneck `json:"bottlenecks,omitempty"`
+	// Handle --robot-pr-impact
+	if *robotPRImpact {
+		cwd, err := os.Getwd()
+		if err != nil {
+			fatalf(exitCodeFor(err), "Error getting current directory: %v", err)
 		}
 
-		output := CapacityOutput{
-			GeneratedAt:       now.UTC(),
-			Agents:            agents,
-			OpenIssueCount:    len(openIssues),
-			TotalMinutes:      totalMinutes,
-			TotalDays:         float64(totalMinutes) / (60.0 * 8.0),
-			SerialMinutes:     serialMinutes,
-			ParallelMinutes:   parallelMinutes,
-			ParallelizablePct: parallelizablePct,
-			EstimatedDays:     estimatedDays,
-			CriticalPathLen:   len(longestChain),
-			CriticalPath:      longestChain,
-			ActionableCount:   len(actionable),
-			Actionable:        actionable,
-			Bottlenecks:       bottlenecks,
+		gitLoader := loader.NewGitLoader(cwd)
+		baseIssues, err := gitLoader.LoadAt(*prBase)
+		if err != nil {
+			fatalf(exitCodeFor(err), "Error loading issues at %s: %v", *prBase, err)
 		}
-		if *capacityLabel != "" {
-			output.Label = *capacityLabel
+		revision, err := gitLoader.ResolveRevision(*prBase)
+		if err != nil {
+			revision = *prBase
 		}
+		baseTime, _ := gitLoader.RevisionTime(revision)
 
-		// Suppress unused variable warning
-		_ = medianMinutes
+		impact := analysis.ComputePRImpact(
+			analysis.NewSnapshotAt(baseIssues, baseTime, revision),
+			analysis.NewSnapshot(issues),
+			analysis.DefaultPRImpactConfig(),
+		)
+
+		output := robotPRImpactOutput{
+			GeneratedAt:      robotNow().UTC().Format(time.RFC3339),
+			Base:             *prBase,
+			ResolvedRevision: revision,
+			FromDataHash:     analysis.ComputeDataHash(baseIssues),
+			ToDataHash:       dataHash,
+			Impact:           impact,
+			CommentMarkdown:  impact.Markdown(),
+			UsageHints: []string{
+				"jq -r '.comment_markdown' | gh pr comment --body-file -   # post as PR comment",
+				"jq '.impact.verdict'                                       # ok | review | attention",
+				"jq '.impact.newly_blocked | map(.id)'                      # work blocked by this PR",
+			},
+		}
 
-		encoder := json.NewEncoder(os.Stdout)
+		encoder := newRobotEncoder(os.Stdout)
 		encoder.SetIndent("", "  ")
 		if err := encoder.Encode(output); err != nil {
-			fmt.Fprintf(os.Stderr, "Error encoding capacity: %v\n", err)
-			os.Exit(1)
+			fatalf(exitCodeFor(err), "Error encoding PR impact: %v", err)
 		}
 		os.Exit(0)
 	}
 
-	// Handle --diff-since flag
-	if *diffSince != "" {
+	// Handle --diff-since / --diff-from flags
+	if *robotDiff && *diffSince == "" && *diffFrom == "" {
+		fatalf(exitUsage, "Error: --robot-diff requires --diff-since or --diff-from")
+	}
+	if *diffTo != "" && *diffFrom == "" {
+		fatalf(exitUsage, "Error: --diff-to requires --diff-from")
+	}
+	if *diffSince != "" && *diffFrom != "" {
+		fatalf(exitUsage, "Error: use either --diff-since or --diff-from, not both")
+	}
+	if *diffSince != "" || *diffFrom != "" {
 		// Auto-enable robot diff for non-interactive/agent contexts
 		if !*robotDiff && (envRobot || !stdoutIsTTY) {
 			*robotDiff = true
@@ -4164,61 +5960,129 @@ func main() {
 
 		cwd, err := os.Getwd()
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error getting current directory: %v\n", err)
-			os.Exit(1)
+			fatalf(exitCodeFor(err), "Error getting current directory: %v", err)
 		}
 
 		gitLoader := loader.NewGitLoader(cwd)
 
-		// Load historical issues
-		historicalIssues, err := gitLoader.LoadAt(*diffSince)
+		// Load historical issues: --diff-since is a revision, --diff-from may
+		// also be a file, a directory or REV:PATH
+		since := *diffSince
+		var historicalIssues []model.Issue
+		var revision string
+		if *diffFrom != "" {
+			since = *diffFrom
+			historicalIssues, revision, err = gitLoader.LoadSource(*diffFrom)
+		} else {
+			historicalIssues, err = gitLoader.LoadAt(*diffSince)
+			revision, _ = gitLoader.ResolveRevision(*diffSince)
+		}
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error loading issues at %s: %v\n", *diffSince, err)
-			os.Exit(1)
+			fatalf(exitCodeFor(err), "Error loading issues at %s: %v", since, err)
+		}
+		if revision == "" {
+			revision = since
 		}
 
-		// Get revision info for timestamp
-		revision, err := gitLoader.ResolveRevision(*diffSince)
-		if err != nil {
-			revision = *diffSince
+		// The other end is the current data unless --diff-to names one
+		toIssues, toDataHash := issues, dataHash
+		var toRevision string
+		if *diffTo != "" {
+			toIssues, toRevision, err = gitLoader.LoadSource(*diffTo)
+			if err != nil {
+				fatalf(exitCodeFor(err), "Error loading issues at %s: %v", *diffTo, err)
+			}
+			if toRevision == "" {
+				toRevision = *diffTo
+			}
+			toDataHash = analysis.ComputeDataHash(toIssues)
+			since = fmt.Sprintf("%s (to %s)", since, *diffTo)
 		}
 
 		// Create snapshots
 		fromSnapshot := analysis.NewSnapshotAt(historicalIssues, time.Time{}, revision)
-		toSnapshot := analysis.NewSnapshot(issues)
+		toSnapshot := analysis.NewSnapshot(toIssues)
+		if toRevision != "" {
+			toSnapshot = analysis.NewSnapshotAt(toIssues, time.Time{}, toRevision)
+		}
 
 		// Compute diff
 		diff := analysis.CompareSnapshots(fromSnapshot, toSnapshot)
 
 		if *robotDiff {
 			// JSON output
-			output := struct {
-				GeneratedAt      string                 `json:"generated_at"`
-				ResolvedRevision string                 `json:"resolved_revision"`
-				AsOf             string                 `json:"as_of,omitempty"`        // "to" snapshot ref (if --as-of used)
-				AsOfCommit       string                 `json:"as_of_commit,omitempty"` // Resolved commit SHA for "to"
-				FromDataHash     string                 `json:"from_data_hash"`
-				ToDataHash       string                 `json:"to_data_hash"`
-				Diff             *analysis.SnapshotDiff `json:"diff"`
-			}{
-				GeneratedAt:      time.Now().UTC().Format(time.RFC3339),
+			output := robotDiffOutput{
+				GeneratedAt:      robotNow().UTC().Format(time.RFC3339),
 				ResolvedRevision: revision,
+				DiffTo:           *diffTo,
+				ToRevision:       toRevision,
 				AsOf:             *asOf,
 				AsOfCommit:       asOfResolved,
 				FromDataHash:     analysis.ComputeDataHash(historicalIssues),
-				ToDataHash:       dataHash,
+				ToDataHash:       toDataHash,
 				Diff:             diff,
 			}
 
-			encoder := json.NewEncoder(os.Stdout)
+			encoder := newRobotEncoder(os.Stdout)
 			encoder.SetIndent("", "  ")
 			if err := encoder.Encode(output); err != nil {
-				fmt.Fprintf(os.Stderr, "Error encoding diff: %v\n", err)
-				os.Exit(1)
+				fatalf(exitCodeFor(err), "Error encoding diff: %v", err)
 			}
 		} else {
 			// Human-readable output
-			printDiffSummary(diff, *diffSince)
+			printDiffSummary(diff, since)
+		}
+		os.Exit(0)
+	}
+
+	// Handle --bisect-metric: first commit where a metric crossed a threshold
+	if *robotBisect && *bisectMetric == "" {
+		fatalf(exitUsage, "Error: --robot-bisect requires --bisect-metric")
+	}
+	if *bisectMetric != "" {
+		if !*robotBisect && (envRobot || !stdoutIsTTY) {
+			*robotBisect = true
+		}
+
+		cwd, err := os.Getwd()
+		if err != nil {
+			fatalf(exitCodeFor(err), "Error getting current directory: %v", err)
+		}
+		gitLoader := loader.NewGitLoader(cwd)
+		revisions, err := gitLoader.RevisionsBetween(*bisectFrom, *bisectTo)
+		if err != nil {
+			fatalf(exitCodeFor(err), "Error listing history for --bisect-metric: %v", err)
+		}
+		candidates := make([]analysis.BisectRevision, len(revisions))
+		for i, rev := range revisions {
+			candidates[i] = analysis.BisectRevision{SHA: rev.SHA, Timestamp: rev.Timestamp, Message: rev.Message}
+		}
+
+		result, err := analysis.BisectMetric(candidates, *bisectMetric, *bisectThreshold, gitLoader.LoadAt)
+		if err != nil {
+			fatalf(exitUsage, "Error: %v", err)
+		}
+
+		if *robotBisect {
+			output := robotBisectOutput{
+				GeneratedAt: robotNow().UTC().Format(time.RFC3339),
+				From:        *bisectFrom,
+				To:          *bisectTo,
+				Result:      result,
+				UsageHints: []string{
+					"jq '.result.commit.revision' - The first commit over the threshold",
+					"jq '.result.culprit_edges[] | select(.in_cycle)' - Dependencies it added that close a cycle",
+					"jq '.result.changes.summary' - What else the commit changed",
+					"bv --robot-replay --replay-from <revision> - The metric week by week from there",
+				},
+			}
+			encoder := newRobotEncoder(os.Stdout)
+			encoder.SetIndent("", "  ")
+			if err := encoder.Encode(output); err != nil {
+				fatalf(exitCodeFor(err), "Error encoding robot-bisect: %v", err)
+			}
+		} else {
+			printBisectResult(result)
 		}
 		os.Exit(0)
 	}
@@ -4248,23 +6112,39 @@ func main() {
 			}
 		}
 		if _, err := p.Run(); err != nil {
-			fmt.Printf("Error running beads viewer: %v\n", err)
-			os.Exit(1)
+			fatalf(exitCodeFor(err), "Error running beads viewer: %v", err)
 		}
 		os.Exit(0)
 	}
 
+	if *exportTemplate != "" && *exportFile == "" {
+		fatalf(exitUsage, "Error: --export-template requires --export-md <file>")
+	}
+
 	if *exportFile != "" {
 		fmt.Printf("Exporting to %s...\n", *exportFile)
 
-		// Load and run pre-export hooks
+		// Resolve the report template before running any hooks
 		cwd, _ := os.Getwd()
+		reportTmpl, err := export.ResolveReportTemplate(cwd, *exportTemplate)
+		if err != nil {
+			fatalf(exitCodeFor(err), "Error: %v", err)
+		}
+		var sprints []model.Sprint
+		if reportTmpl != nil {
+			fmt.Printf("Using %s template %q\n", reportTmpl.Source, reportTmpl.Name)
+			if sprints, err = loader.LoadSprints(cwd); err != nil {
+				fmt.Printf("Warning: failed to load sprints: %v\n", err)
+			}
+		}
+
+		// Load and run pre-export hooks
 		var executor *hooks.Executor
 		if !*noHooks {
 			hookLoader := hooks.NewLoader(hooks.WithProjectDir(cwd))
 			if err := hookLoader.Load(); err != nil {
 				fmt.Printf("Warning: failed to load hooks: %v\n", err)
-			} else if hookLoader.HasHooks() {
+			} else if hookLoader.HasHooksFor(hooks.PreExport, hooks.PostExport) {
 				ctx := hooks.ExportContext{
 					ExportPath:   *exportFile,
 					ExportFormat: "markdown",
@@ -4275,17 +6155,21 @@ func main() {
 
 				// Run pre-export hooks
 				if err := executor.RunPreExport(); err != nil {
-					fmt.Printf("Error: pre-export hook failed: %v\n", err)
-					os.Exit(1)
+					fatalf(exitCodeFor(err), "Error: pre-export hook failed: %v", err)
 				}
 			}
 		}
 
 		// Perform the export
-		if err := export.SaveMarkdownToFile(issues, *exportFile); err != nil {
-			fmt.Printf("Error exporting: %v\n", err)
-			os.Exit(1)
+		mdOpts := export.MarkdownExportOptions{
+			GraphImage: *exportMDGraph,
+			Template:   reportTmpl,
+			Sprints:    sprints,
 		}
+		if err := export.SaveMarkdownToFileWithOptions(issues, *exportFile, mdOpts); err != nil {
+			fatalf(exitCodeFor(err), "Error exporting: %v", err)
+		}
+		sealExportOrExit(*exportFile)
 
 		// Run post-export hooks
 		if executor != nil {
@@ -4304,6 +6188,109 @@ func main() {
 		os.Exit(0)
 	}
 
+	if *exportAnnotatedJSONL != "" {
+		annotateOpts := export.AnnotationOptions{Agents: *forecastAgents}
+		if *exportAnnotatedJSONL == "-" {
+			if err := export.WriteAnnotatedJSONL(os.Stdout, issues, annotateOpts); err != nil {
+				fatalf(exitCodeFor(err), "Error exporting annotated JSONL: %v", err)
+			}
+			os.Exit(0)
+		}
+		if err := export.SaveAnnotatedJSONL(issues, *exportAnnotatedJSONL, annotateOpts); err != nil {
+			fatalf(exitCodeFor(err), "Error exporting annotated JSONL: %v", err)
+		}
+		fmt.Printf("Exported %d annotated issues to %s\n", len(issues), *exportAnnotatedJSONL)
+		sealExportOrExit(*exportAnnotatedJSONL)
+		os.Exit(0)
+	}
+
+	if *exportCSV != "" {
+		rows := issues
+		if activeRecipe != nil {
+			rows = applyRecipeSort(applyRecipeFilters(issues, activeRecipe), activeRecipe)
+		}
+		if rows == nil {
+			rows = []model.Issue{}
+		}
+		csvOpts := export.CSVExportOptions{
+			AnnotationOptions: export.AnnotationOptions{Agents: *forecastAgents},
+			Rows:              rows,
+		}
+		if err := export.SaveIssuesCSV(issues, *exportCSV, csvOpts); err != nil {
+			fatalf(exitCodeFor(err), "Error exporting CSV: %v", err)
+		}
+		fmt.Printf("Exported %d issues to %s\n", len(rows), *exportCSV)
+		sealExportOrExit(*exportCSV)
+		os.Exit(0)
+	}
+
+	if *exportICS != "" {
+		cwd, err := os.Getwd()
+		if err != nil {
+			fatalf(exitCodeFor(err), "Error getting current directory: %v", err)
+		}
+		sprints, err := loader.LoadSprints(cwd)
+		if err != nil {
+			fatalf(exitCodeFor(err), "Error loading sprints: %v", err)
+		}
+
+		rows := issues
+		if activeRecipe != nil {
+			rows = applyRecipeSort(applyRecipeFilters(issues, activeRecipe), activeRecipe)
+		}
+		if *forecastLabel != "" {
+			var labeled []model.Issue
+			for _, issue := range rows {
+				if slices.Contains(issue.Labels, *forecastLabel) {
+					labeled = append(labeled, issue)
+				}
+			}
+			rows = labeled
+		}
+		if *forecastSprint != "" {
+			var sprint *model.Sprint
+			for i := range sprints {
+				if sprints[i].ID == *forecastSprint {
+					sprint = &sprints[i]
+					break
+				}
+			}
+			if sprint == nil {
+				fatalf(exitNotFound, "Sprint not found: %s", *forecastSprint)
+			}
+			inSprint := make(map[string]bool, len(sprint.BeadIDs))
+			for _, id := range sprint.BeadIDs {
+				inSprint[id] = true
+			}
+			var sprintRows []model.Issue
+			for _, issue := range rows {
+				if inSprint[issue.ID] {
+					sprintRows = append(sprintRows, issue)
+				}
+			}
+			rows = sprintRows
+			sprints = []model.Sprint{*sprint}
+		}
+		if rows == nil {
+			rows = []model.Issue{}
+		}
+
+		icsOpts := export.ICSExportOptions{
+			AnnotationOptions: export.AnnotationOptions{Agents: *forecastAgents},
+			Rows:              rows,
+			Sprints:           sprints,
+			CalendarName:      filepath.Base(cwd) + " forecast",
+			Project:           filepath.Base(cwd),
+		}
+		n, err := export.SaveIssuesICS(issues, *exportICS, icsOpts)
+		if err != nil {
+			fatalf(exitCodeFor(err), "Error exporting calendar: %v", err)
+		}
+		fmt.Printf("Exported %d calendar events to %s\n", n, *exportICS)
+		sealExportOrExit(*exportICS)
+		os.Exit(0)
+	}
+
 	if len(issues) == 0 {
 		fmt.Println("No issues found. Create some with 'bd create'!")
 		os.Exit(0)
@@ -4319,6 +6306,74 @@ func main() {
 	m := ui.NewModel(issues, activeRecipe, beadsPath)
 	defer m.Stop() // Clean up file watcher
 
+	// Apply custom key bindings; problems are shown in the status bar
+	if cwd, err := os.Getwd(); err == nil {
+		km, err := ui.LoadKeymap(ui.DefaultKeymapUserPath(), cwd)
+		if err != nil {
+			km.Warnings = append([]string{err.Error()}, km.Warnings...)
+		}
+		m.SetKeymap(km)
+	}
+
+	// Apply the theme: --theme, then BV_THEME, then theme.yaml
+	if cwd, err := os.Getwd(); err == nil {
+		themes, err := ui.LoadThemes(ui.DefaultThemeUserPath(), cwd)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		}
+		if err := m.SetThemes(themes, cmp.Or(*themeName, os.Getenv("BV_THEME"))); err != nil {
+			fatalf(exitUsage, "Error: %v", err)
+		}
+	}
+
+	// Accessibility
+	if *noColor || os.Getenv("NO_COLOR") != "" {
+		m.SetNoColor()
+	}
+	if *plainUI || os.Getenv("BV_PLAIN") != "" {
+		m.SetPlain(true)
+	}
+	// Without --ascii or --ascii=false, probe the terminal
+	asciiSet := false
+	flag.Visit(func(f *flag.Flag) { asciiSet = asciiSet || f.Name == "ascii" })
+	if *asciiUI || (!asciiSet && ui.DetectASCII(os.Getenv)) {
+		m.SetASCII(true)
+	}
+
+	// Local usage log (bv stats enable)
+	if path := usage.Path(); usage.Enabled(path) {
+		m.SetUsageLog(path)
+	}
+
+	// Restore the project's pinned split layout (.bv/layout.yaml)
+	if cwd, err := os.Getwd(); err == nil {
+		layout, err := ui.LoadLayout(cwd)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		}
+		m.SetLayout(layout, cwd)
+
+		// Per-status WIP limits for the board (.bv/board.yaml)
+		boardConfig, err := drift.LoadBoardConfig(cwd)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		}
+		m.SetBoardConfig(boardConfig)
+
+		// post-reload hooks (.bv/hooks.yaml)
+		if !*noHooks {
+			hookLoader := hooks.NewLoader(hooks.WithProjectDir(cwd))
+			if err := hookLoader.Load(); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: failed to load hooks: %v\n", err)
+			} else if hookLoader.HasHooksFor(hooks.PostReload) {
+				m.SetHooks(hookLoader.Config())
+			}
+		}
+	}
+
+	// Whose queue the my-work view (@) shows
+	m.SetAssignee(analysis.ResolveAssignee(*assignee))
+
 	// Enable workspace mode if loading from workspace config
 	if workspaceInfo != nil {
 		m.EnableWorkspaceMode(ui.WorkspaceInfo{
@@ -4354,8 +6409,39 @@ func main() {
 		}
 	}
 	if _, err := p.Run(); err != nil {
-		fmt.Printf("Error running beads viewer: %v\n", err)
-		os.Exit(1)
+		fatalf(exitCodeFor(err), "Error running beads viewer: %v", err)
+	}
+}
+
+// printKeymap prints every action with its active keys, then any conflicts
+// and warnings found while loading the keymap files.
+func printKeymap(km *ui.Keymap) {
+	fmt.Printf("%-22s %-9s %-22s %s\n", "ACTION", "SCOPE", "KEYS", "SOURCE")
+	for _, a := range ui.KeyActions() {
+		keys := km.Keys(a.ID)
+		display := make([]string, len(keys))
+		for i, k := range keys {
+			display[i] = ui.DisplayKey(k)
+		}
+		label := strings.Join(display, " ")
+		if label == "" {
+			label = "(unbound)"
+		}
+		fmt.Printf("%-22s %-9s %-22s %s\n", a.ID, a.Scope, label, km.Source(a.ID))
+	}
+	if len(km.Conflicts) > 0 {
+		fmt.Println("")
+		fmt.Println("Conflicts (overrides reverted to defaults):")
+		for _, c := range km.Conflicts {
+			fmt.Printf("  - %s\n", c)
+		}
+	}
+	if len(km.Warnings) > 0 {
+		fmt.Println("")
+		fmt.Println("Warnings:")
+		for _, w := range km.Warnings {
+			fmt.Printf("  - %s\n", w)
+		}
 	}
 }
 
@@ -4483,6 +6569,66 @@ func printDiffSummary(diff *analysis.SnapshotDiff, since string) {
 	}
 }
 
+// printBisectResult prints the outcome of --bisect-metric
+func printBisectResult(result *analysis.BisectResult) {
+	title := fmt.Sprintf("Bisect: %s > %d", result.Metric, result.Threshold)
+	fmt.Println(title)
+	fmt.Println(repeatChar('=', len(title)))
+	fmt.Println()
+
+	switch {
+	case !result.Found:
+		value, _ := result.Last.Metric(result.Metric)
+		fmt.Printf("Never exceeded in %d revisions (%s is %d at %s).\n",
+			result.Candidates, result.Metric, value, shortRevision(result.Last.Revision))
+		return
+	case result.AtStart:
+		value, _ := result.Commit.Metric(result.Metric)
+		fmt.Printf("Already exceeded at the first revision %s (%s is %d); try an earlier --from.\n",
+			shortRevision(result.Commit.Revision), result.Metric, value)
+		return
+	}
+
+	before, _ := result.Before.Metric(result.Metric)
+	after, _ := result.Commit.Metric(result.Metric)
+	fmt.Printf("First commit: %s %s\n", shortRevision(result.Commit.Revision), result.Commit.Message)
+	fmt.Printf("Date:         %s\n", result.Commit.Timestamp.Format("2006-01-02 15:04"))
+	fmt.Printf("%-14s%d → %d (tested %d of %d revisions)\n\n",
+		result.Metric+":", before, after, result.Tested, result.Candidates)
+
+	if len(result.CulpritEdges) > 0 {
+		fmt.Println("Likely culprit edges (dependencies this commit added):")
+		for _, edge := range result.CulpritEdges {
+			marker := " "
+			if edge.InCycle {
+				marker = "⚠"
+			}
+			fmt.Printf("  %s %s → %s (%s)\n", marker, edge.From, edge.To, edge.Type)
+		}
+		fmt.Println()
+	}
+	printDiffSummary(result.Changes, shortRevision(result.Before.Revision))
+}
+
+// shortRevision abbreviates a commit SHA for display
+func shortRevision(sha string) string {
+	if len(sha) > 8 {
+		return sha[:8]
+	}
+	return sha
+}
+
+// splitLabelList parses a comma-separated label list, skipping blanks
+func splitLabelList(s string) []string {
+	var labels []string
+	for _, label := range strings.Split(s, ",") {
+		if label = strings.TrimSpace(label); label != "" {
+			labels = append(labels, label)
+		}
+	}
+	return labels
+}
+
 // repeatChar creates a string of n repeated characters
 func repeatChar(c rune, n int) string {
 	result := make([]rune, n)
@@ -4550,7 +6696,7 @@ func applyRecipeFilters(issues []model.Issue, r *recipe.Recipe) []model.Issue {
 	}
 
 	f := r.Filters
-	now := time.Now()
+	now := robotNow()
 
 	// Build a set of open blocker IDs for actionable filtering
 	openBlockers := make(map[string]bool)
@@ -4805,7 +6951,7 @@ func runProfileStartup(issues []model.Issue, loadDuration time.Duration, jsonOut
 			TotalWithLoad   string                   `json:"total_with_load"`
 			Recommendations []string                 `json:"recommendations"`
 		}{
-			GeneratedAt:     time.Now().UTC().Format(time.RFC3339),
+			GeneratedAt:     robotNow().UTC().Format(time.RFC3339),
 			DataPath:        dataPath,
 			LoadJSONL:       loadDuration.String(),
 			Profile:         profile,
@@ -4813,11 +6959,10 @@ func runProfileStartup(issues []model.Issue, loadDuration time.Duration, jsonOut
 			Recommendations: generateProfileRecommendations(profile, loadDuration, totalWithLoad),
 		}
 
-		encoder := json.NewEncoder(os.Stdout)
+		encoder := newRobotEncoder(os.Stdout)
 		encoder.SetIndent("", "  ")
 		if err := encoder.Encode(output); err != nil {
-			fmt.Fprintf(os.Stderr, "Error encoding profile: %v\n", err)
-			os.Exit(1)
+			fatalf(exitCodeFor(err), "Error encoding profile: %v", err)
 		}
 	} else {
 		// Human-readable output
@@ -5032,6 +7177,110 @@ func filterByRepo(issues []model.Issue, repoFilter string) []model.Issue {
 	return result
 }
 
+// buildCurrentBaseline snapshots the current graph in baseline form so it can be
+// compared against a saved baseline (or used as its own reference for proactive alerts).
+func buildCurrentBaseline(issues []model.Issue, forceFullAnalysis bool) *baseline.Baseline {
+	analyzer := analysis.NewAnalyzer(issues)
+	if forceFullAnalysis {
+		cfg := analysis.FullAnalysisConfig()
+		analyzer.SetConfig(&cfg)
+	}
+	stats := analyzer.Analyze()
+
+	openCount, closedCount, blockedCount := 0, 0, 0
+	for _, issue := range issues {
+		switch issue.Status {
+		case model.StatusOpen, model.StatusInProgress, model.StatusInReview:
+			openCount++
+		case model.StatusClosed:
+			closedCount++
+		case model.StatusBlocked:
+			blockedCount++
+		}
+	}
+	cycles := stats.Cycles()
+
+	graphStats := baseline.GraphStats{
+		NodeCount:       stats.NodeCount,
+		EdgeCount:       stats.EdgeCount,
+		Density:         stats.Density,
+		OpenCount:       openCount,
+		ClosedCount:     closedCount,
+		BlockedCount:    blockedCount,
+		CycleCount:      len(cycles),
+		ActionableCount: len(analyzer.GetActionableIssues()),
+	}
+	topMetrics := baseline.TopMetrics{
+		PageRank:     buildMetricItems(stats.PageRank(), 10),
+		Betweenness:  buildMetricItems(stats.Betweenness(), 10),
+		CriticalPath: buildMetricItems(stats.CriticalPathScore(), 10),
+		Hubs:         buildMetricItems(stats.Hubs(), 10),
+		Authorities:  buildMetricItems(stats.Authorities(), 10),
+	}
+	return baseline.New(graphStats, topMetrics, cycles, "current")
+}
+
+// computeProjectAlerts runs the --robot-alerts checks: drift against the
+// current graph, staleness and cascades, board WIP limits, activity anomalies
+// from the beads file's git history, and goals at risk.
+func computeProjectAlerts(issues []model.Issue, projectDir string, now time.Time) (*drift.Result, error) {
+	driftConfig, err := drift.LoadConfig(projectDir)
+	if err != nil {
+		return nil, fmt.Errorf("loading drift config: %w", err)
+	}
+
+	analyzer := analysis.NewAnalyzer(issues)
+	stats := analyzer.Analyze()
+
+	openCount, closedCount, blockedCount := 0, 0, 0
+	for _, issue := range issues {
+		switch issue.Status {
+		case model.StatusClosed:
+			closedCount++
+		case model.StatusBlocked:
+			blockedCount++
+		default:
+			openCount++
+		}
+	}
+	curStats := baseline.GraphStats{
+		NodeCount:       stats.NodeCount,
+		EdgeCount:       stats.EdgeCount,
+		Density:         stats.Density,
+		OpenCount:       openCount,
+		ClosedCount:     closedCount,
+		BlockedCount:    blockedCount,
+		CycleCount:      len(stats.Cycles()),
+		ActionableCount: len(analyzer.GetActionableIssues()),
+	}
+	bl := &baseline.Baseline{Stats: curStats}
+	cur := &baseline.Baseline{Stats: curStats, Cycles: stats.Cycles()}
+
+	boardConfig, err := drift.LoadBoardConfig(projectDir)
+	if err != nil {
+		return nil, fmt.Errorf("loading board config: %w", err)
+	}
+
+	// Daily created/closed/blocked counts from the beads file's git history
+	// feed the activity_anomaly check; outside git there is no series
+	since := now.AddDate(0, 0, -analysis.DefaultActivityDays)
+	activity := analysis.ComputeActivitySeries(issues, analysis.DefaultActivityDays, now,
+		loader.NewGitLoader(projectDir).SnapshotsSince(since))
+
+	// Goals in .bv/goals.yaml feed the goal_at_risk check
+	goals, err := analysis.LoadGoals(projectDir)
+	if err != nil {
+		return nil, fmt.Errorf("loading goals: %w", err)
+	}
+
+	calc := drift.NewCalculator(bl, cur, driftConfig)
+	calc.SetIssues(issues)
+	calc.SetBoardConfig(boardConfig)
+	calc.SetActivity(activity)
+	calc.SetGoals(analysis.ForecastGoals(issues, &stats, goals, nil, analysis.CapacitySimOptions{}, now))
+	return calc.Calculate(), nil
+}
+
 // buildMetricItems converts a metrics map to a sorted slice of MetricItems
 func buildMetricItems(metrics map[string]float64, limit int) []baseline.MetricItem {
 	if len(metrics) == 0 {
@@ -5119,6 +7368,7 @@ func copyViewerAssets(outputDir, title string) error {
 		"graph.js",
 		"charts.js",
 		"hybrid_scorer.js",
+		"semantic_search.js",
 		"wasm_loader.js",
 		"coi-serviceworker.js",
 	}
@@ -5286,6 +7536,10 @@ func copyDir(src, dst string) error {
 	return nil
 }
 
+// readmeGraphMaxNodes caps the embedded README graph; larger graphs render
+// too small to read and are better explored in the live viewer.
+const readmeGraphMaxNodes = 300
+
 // generateREADME creates a README.md file for the GitHub Pages repository.
 // It includes actionable insights, graph analysis, and a direct link to the live site.
 func generateREADME(bundlePath, title, pagesURL string, issues []model.Issue, triage *analysis.TriageResult, stats *analysis.GraphStats) error {
@@ -5453,6 +7707,19 @@ func generateREADME(bundlePath, title, pagesURL string, issues []model.Issue, tr
 			b.WriteString("- **Cycles:** None detected ✓\n")
 		}
 		b.WriteString("\n")
+
+		// Static image so the README shows the graph without the viewer
+		if stats.EdgeCount > 0 && len(issues) <= readmeGraphMaxNodes {
+			if err := export.SaveGraphSnapshot(export.GraphSnapshotOptions{
+				Path:   filepath.Join(bundlePath, "graph.svg"),
+				Layout: "layered",
+				Title:  title,
+				Issues: issues,
+				Stats:  stats,
+			}); err == nil {
+				b.WriteString("![Dependency graph](graph.svg)\n\n")
+			}
+		}
 	}
 
 	// QUICK WINS - low effort, high impact
@@ -5552,19 +7819,17 @@ func escapeMarkdownTableCell(s string) string {
 
 // runPreviewServer starts a local HTTP server to preview the static site.
 func runPreviewServer(dir string) error {
-	// Check directory exists
-	if _, err := os.Stat(dir); os.IsNotExist(err) {
-		return fmt.Errorf("directory not found: %s", dir)
+	config := export.ServeConfig{
+		BundlePath: dir,
+		Port:       export.DefaultPreviewPort,
+		NoCache:    true,
+		Quiet:      true,
 	}
-
-	// Check for index.html
-	indexPath := filepath.Join(dir, "index.html")
-	if _, err := os.Stat(indexPath); os.IsNotExist(err) {
-		return fmt.Errorf("index.html not found in %s (did you run --export-pages first?)", dir)
+	if _, err := export.NewServeHandler(config); err != nil {
+		return err
 	}
 
-	port := 9000
-	fmt.Printf("Starting preview server at http://localhost:%d\n", port)
+	fmt.Printf("Starting preview server at http://localhost:%d\n", config.Port)
 	fmt.Printf("Serving files from: %s\n", dir)
 	fmt.Println("Press Ctrl+C to stop")
 	fmt.Println("")
@@ -5572,47 +7837,19 @@ func runPreviewServer(dir string) error {
 	// Try to open browser
 	go func() {
 		time.Sleep(500 * time.Millisecond)
-		openBrowser(fmt.Sprintf("http://localhost:%d", port))
+		openBrowser(fmt.Sprintf("http://localhost:%d", config.Port))
 	}()
 
-	// Start HTTP server
-	http.Handle("/", http.FileServer(http.Dir(dir)))
-	return http.ListenAndServe(fmt.Sprintf(":%d", port), nil)
+	return export.ServePages(context.Background(), config)
 }
 
-// openBrowser opens the default browser to the given URL.
+// openBrowser opens the default browser to the given URL, or asks the
+// user to when there is none.
 // Set BV_NO_BROWSER=1 to suppress browser opening (useful for tests).
 func openBrowser(url string) {
-	// Skip browser opening in test mode or when explicitly disabled
-	if os.Getenv("BV_NO_BROWSER") != "" || os.Getenv("BV_TEST_MODE") != "" {
-		return
-	}
-
-	var cmd string
-	var args []string
-
-	switch {
-	case isCommandAvailable("open"):
-		cmd = "open"
-		args = []string{url}
-	case isCommandAvailable("xdg-open"):
-		cmd = "xdg-open"
-		args = []string{url}
-	case isCommandAvailable("cmd"):
-		cmd = "cmd"
-		args = []string{"/c", "start", url}
-	default:
+	if err := export.OpenInBrowser(url); err != nil {
 		fmt.Printf("Open %s in your browser\n", url)
-		return
 	}
-
-	exec.Command(cmd, args...).Start()
-}
-
-// isCommandAvailable checks if a command is available in PATH.
-func isCommandAvailable(name string) bool {
-	_, err := exec.LookPath(name)
-	return err == nil
 }
 
 // runPagesWizard runs the interactive deployment wizard (bv-10g).
@@ -5757,8 +7994,8 @@ func runPagesWizard(issues []model.Issue, beadsPath string) error {
 	fmt.Printf("  -> Bundle created: %s\n", bundlePath)
 	fmt.Println("")
 
-	// Offer preview and deploy (for GitHub and Cloudflare)
-	if config.DeployTarget == "github" || config.DeployTarget == "cloudflare" {
+	// Offer preview and deploy for every remote target
+	if config.DeployTarget != "local" {
 		action, err := wizard.OfferPreview()
 		if err != nil {
 			return err
@@ -5796,6 +8033,43 @@ func runPagesWizard(issues []model.Issue, beadsPath string) error {
 	return nil
 }
 
+// pagesDeployWizardConfig builds the deploy settings for --pages-deploy,
+// validating that the target's required flags are present.
+func pagesDeployWizardConfig(target, project, branch, bucket, cloudFront string) (*export.WizardConfig, error) {
+	config := &export.WizardConfig{DeployTarget: target}
+	switch target {
+	case "gitlab":
+		if project == "" {
+			return nil, fmt.Errorf("--pages-deploy gitlab requires --pages-project group/project")
+		}
+		config.GitLabProject = project
+		config.GitLabBranch = cmp.Or(branch, "main")
+	case "s3":
+		if _, _, err := export.ParseS3Location(bucket); err != nil {
+			return nil, fmt.Errorf("--pages-deploy s3 requires --pages-bucket s3://bucket[/prefix]")
+		}
+		config.S3Bucket = bucket
+		config.CloudFrontDistribution = cloudFront
+	case "netlify":
+		if project == "" {
+			return nil, fmt.Errorf("--pages-deploy netlify requires --pages-project <site>")
+		}
+		if os.Getenv(export.NetlifyTokenEnv) == "" {
+			return nil, fmt.Errorf("--pages-deploy netlify requires %s", export.NetlifyTokenEnv)
+		}
+		config.NetlifySite = project
+	case "cloudflare":
+		if project == "" {
+			return nil, fmt.Errorf("--pages-deploy cloudflare requires --pages-project <name>")
+		}
+		config.CloudflareProject = project
+		config.CloudflareBranch = cmp.Or(branch, "main")
+	default:
+		return nil, fmt.Errorf("unknown --pages-deploy target %q (want gitlab, s3, netlify or cloudflare)", target)
+	}
+	return config, nil
+}
+
 // BurndownOutput represents the JSON output for --robot-burndown (bv-159)
 type BurndownOutput struct {
 	GeneratedAt       time.Time             `json:"generated_at"`
@@ -6201,6 +8475,54 @@ func generateIdealLine(sprint *model.Sprint, totalIssues int) []model.BurndownPo
 	return points
 }
 
+// buildRobotPlan computes the --robot-plan payload. The plan needs only
+// Phase 1 metrics (degree/topo/density); unless full analysis is asked for,
+// expensive centrality metrics are skipped and the skip reasons recorded
+// deterministically, so the status contract stays stable for agents.
+func buildRobotPlan(issues []model.Issue, dataHash, projectDir string, fullAnalysis bool, now time.Time) robotPlanOutput {
+	analyzer := analysis.NewAnalyzer(issues)
+	cfg := analysis.ConfigForSize(len(issues), countEdges(issues))
+	if fullAnalysis {
+		cfg = analysis.FullAnalysisConfig()
+	} else {
+		const skipReason = "not computed for --robot-plan"
+		cfg.ComputePageRank = false
+		cfg.PageRankSkipReason = skipReason
+		cfg.ComputeBetweenness = false
+		cfg.BetweennessMode = analysis.BetweennessSkip
+		cfg.BetweennessSkipReason = skipReason
+		cfg.ComputeHITS = false
+		cfg.HITSSkipReason = skipReason
+		cfg.ComputeEigenvector = false
+		cfg.ComputeCriticalPath = false
+		cfg.ComputeCycles = false
+		cfg.CyclesSkipReason = skipReason
+	}
+
+	plan := analyzer.GetExecutionPlan()
+	planClaims := mergeClaimsIntoPlan(&plan, loadClaims(projectDir), now)
+
+	stats := analyzer.AnalyzeAsyncWithConfig(context.Background(), cfg)
+	stats.WaitForPhase2()
+
+	return robotPlanOutput{
+		GeneratedAt:    now.UTC().Format(time.RFC3339),
+		DataHash:       dataHash,
+		AnalysisConfig: cfg,
+		Status:         stats.Status(),
+		Plan:           plan,
+		Claims:         planClaims,
+		UsageHints: []string{
+			"jq '.plan.tracks | length' - Number of parallel execution tracks",
+			"jq '.plan.tracks[0].items | map(.id)' - First track item IDs",
+			"jq '.plan.tracks[].items[] | select(.unblocks | length > 0)' - Items that unblock others",
+			"jq '.plan.summary' - High-level execution summary",
+			"jq '[.plan.tracks[].items[]] | length' - Total items across all tracks",
+			"jq '[.plan.tracks[].items[] | select(.claimed_by == null)]' - Items nobody has claimed (bv claim)",
+		},
+	}
+}
+
 // generateJQHelpers creates a markdown document with jq snippets for agent brief
 func generateJQHelpers() string {
 	return `# jq Helper Snippets
@@ -6329,11 +8651,12 @@ func generateHistoryForExport(issues []model.Issue) (*TimeTravelHistory, error)
 			ID:     issue.ID,
 			Title:  issue.Title,
 			Status: string(issue.Status),
+			Events: issue.Events,
 		}
 	}
 
 	// Generate correlation report
-	correlator := correlation.NewCorrelator(cwd, beadsPath)
+	correlator := correlation.NewIndexedCorrelator(cwd, beadsPath)
 	report, err := correlator.GenerateReport(beadInfos, correlation.CorrelatorOptions{
 		Limit: 500, // Reasonable limit for time-travel
 	})
@@ -6392,7 +8715,7 @@ func generateHistoryForExport(issues []model.Issue) (*TimeTravelHistory, error)
 	})
 
 	return &TimeTravelHistory{
-		GeneratedAt: time.Now().UTC().Format(time.RFC3339),
+		GeneratedAt: robotNow().UTC().Format(time.RFC3339),
 		Commits:     commits,
 	}, nil
 }
@@ -268,3 +268,37 @@ func repoRoot(t *testing.T) string {
 		dir = parent
 	}
 }
+
+func TestPagesDeployWizardConfig(t *testing.T) {
+	t.Setenv("NETLIFY_AUTH_TOKEN", "")
+
+	cfg, err := pagesDeployWizardConfig("gitlab", "group/issues", "", "", "")
+	if err != nil || cfg.GitLabProject != "group/issues" || cfg.GitLabBranch != "main" {
+		t.Fatalf("gitlab config = %+v, %v", cfg, err)
+	}
+	cfg, err = pagesDeployWizardConfig("s3", "", "", "s3://bucket/site", "E123")
+	if err != nil || cfg.S3Bucket != "s3://bucket/site" || cfg.CloudFrontDistribution != "E123" {
+		t.Fatalf("s3 config = %+v, %v", cfg, err)
+	}
+	cfg, err = pagesDeployWizardConfig("cloudflare", "issues", "preview", "", "")
+	if err != nil || cfg.CloudflareProject != "issues" || cfg.CloudflareBranch != "preview" {
+		t.Fatalf("cloudflare config = %+v, %v", cfg, err)
+	}
+
+	for _, tc := range []struct{ target, project, bucket string }{
+		{"gitlab", "", ""},
+		{"s3", "", ""},
+		{"netlify", "issues", ""}, // token missing
+		{"ftp", "issues", ""},
+	} {
+		if _, err := pagesDeployWizardConfig(tc.target, tc.project, "", tc.bucket, ""); err == nil {
+			t.Errorf("expected error for %+v", tc)
+		}
+	}
+
+	t.Setenv("NETLIFY_AUTH_TOKEN", "nfp_test")
+	cfg, err = pagesDeployWizardConfig("netlify", "issues", "", "", "")
+	if err != nil || cfg.NetlifySite != "issues" {
+		t.Fatalf("netlify config = %+v, %v", cfg, err)
+	}
+}
@@ -0,0 +1,195 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/ui"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const mergeDriverUsage = "Usage: bv merge-driver <base> <ours> <theirs> [path] | install | resolve [file]"
+
+// mergeDriverAttributes routes beads files to the driver in .gitattributes
+const mergeDriverAttributes = ".beads/*.jsonl merge=beads"
+
+// runMergeDriver implements `bv merge-driver`: a git merge driver that
+// merges beads JSONL by issue ID and field, writing the result over ours as
+// git expects. It exits 1 when conflict blocks remain. `install` registers
+// it in the repository and `resolve` opens the conflict resolver.
+func runMergeDriver(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("merge-driver", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, mergeDriverUsage)
+		fmt.Fprintln(stderr, "\nMerge beads JSONL by issue ID. Register with `bv merge-driver install`;")
+		fmt.Fprintln(stderr, "git then runs `bv merge-driver %O %A %B %P` for .beads/*.jsonl.")
+	}
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		return 2
+	}
+	args = fs.Args()
+
+	switch {
+	case len(args) == 1 && args[0] == "install":
+		return installMergeDriver(stdout, stderr)
+	case len(args) >= 1 && len(args) <= 2 && args[0] == "resolve":
+		return resolveMergeConflicts(args[1:], stdout, stderr)
+	case len(args) == 3 || len(args) == 4:
+	default:
+		fmt.Fprintln(stderr, mergeDriverUsage)
+		return 2
+	}
+
+	name := args[1]
+	if len(args) == 4 {
+		name = args[3]
+	}
+	var sides [3][]byte
+	for i, path := range args[:3] {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			fmt.Fprintf(stderr, "bv merge-driver: %v\n", err)
+			return 2
+		}
+		sides[i] = data
+	}
+	result, err := loader.MergeJSONL(sides[0], sides[1], sides[2])
+	if err != nil {
+		// Leave ours in place; git reports the file as conflicted
+		fmt.Fprintf(stderr, "bv merge-driver: %s: %v\n", name, err)
+		return 2
+	}
+	if err := os.WriteFile(args[1], result.Output, 0o644); err != nil {
+		fmt.Fprintf(stderr, "bv merge-driver: %v\n", err)
+		return 2
+	}
+
+	if result.Merged > 0 {
+		fmt.Fprintf(stderr, "bv merge-driver: %s: merged %d issue(s) changed on both sides\n", name, result.Merged)
+	}
+	if len(result.Conflicts) == 0 {
+		return 0
+	}
+	fmt.Fprintf(stderr, "bv merge-driver: %s: %d conflict(s):\n", name, len(result.Conflicts))
+	for _, c := range result.Conflicts {
+		if len(c.Fields) == 1 && c.Fields[0] == loader.WholeIssue {
+			fmt.Fprintf(stderr, "  %s: deleted on one side, changed on the other\n", c.IssueID)
+		} else {
+			fmt.Fprintf(stderr, "  %s: %s\n", c.IssueID, strings.Join(c.Fields, ", "))
+		}
+	}
+	fmt.Fprintln(stderr, "Resolve with `bv merge-driver resolve`, then `git add` the file.")
+	return 1
+}
+
+// installMergeDriver registers the driver in the repository's git config
+// and routes the beads files to it in .gitattributes
+func installMergeDriver(stdout, stderr io.Writer) int {
+	root, err := exec.Command("git", "rev-parse", "--show-toplevel").Output()
+	if err != nil {
+		fmt.Fprintln(stderr, "Error: not inside a git repository")
+		return 1
+	}
+	repo := strings.TrimSpace(string(root))
+
+	for _, kv := range [][2]string{
+		{"merge.beads.name", "beads JSONL merge by issue ID (bv)"},
+		{"merge.beads.driver", "bv merge-driver %O %A %B %P"},
+	} {
+		if out, err := exec.Command("git", "-C", repo, "config", kv[0], kv[1]).CombinedOutput(); err != nil {
+			fmt.Fprintf(stderr, "Error: git config %s: %v %s\n", kv[0], err, strings.TrimSpace(string(out)))
+			return 1
+		}
+	}
+
+	attrPath := filepath.Join(repo, ".gitattributes")
+	existing, err := os.ReadFile(attrPath)
+	if err != nil && !os.IsNotExist(err) {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return 1
+	}
+	for _, line := range strings.Split(string(existing), "\n") {
+		if strings.TrimSpace(line) == mergeDriverAttributes {
+			fmt.Fprintln(stdout, "Merge driver configured; .gitattributes already routes .beads/*.jsonl to it")
+			return 0
+		}
+	}
+	entry := mergeDriverAttributes + "\n"
+	if len(existing) > 0 && !strings.HasSuffix(string(existing), "\n") {
+		entry = "\n" + entry
+	}
+	f, err := os.OpenFile(attrPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return 1
+	}
+	if _, err := f.WriteString(entry); err != nil {
+		f.Close()
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return 1
+	}
+	if err := f.Close(); err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return 1
+	}
+	fmt.Fprintln(stdout, "Merge driver configured and added to .gitattributes (commit it to share the routing)")
+	return 0
+}
+
+// resolveMergeConflicts opens the conflict resolver on the given file, or
+// the project's beads file
+func resolveMergeConflicts(args []string, stdout, stderr io.Writer) int {
+	var path string
+	if len(args) == 1 {
+		path = args[0]
+	} else {
+		beadsDir, err := loader.GetBeadsDir("")
+		if err != nil {
+			fmt.Fprintf(stderr, "Error getting beads directory: %v\n", err)
+			return 1
+		}
+		if path, err = loader.FindJSONLPath(beadsDir); err != nil {
+			fmt.Fprintf(stderr, "Error: %v\n", err)
+			return 1
+		}
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return 1
+	}
+	blocks, err := loader.ParseConflictBlocks(data)
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %s: %v\n", path, err)
+		return 1
+	}
+	if len(blocks) == 0 {
+		fmt.Fprintf(stdout, "No merge conflicts in %s\n", path)
+		return 0
+	}
+
+	final, err := tea.NewProgram(ui.NewConflictResolver(path, blocks), tea.WithAltScreen()).Run()
+	if err != nil {
+		fmt.Fprintf(stderr, "Error running resolver: %v\n", err)
+		return 1
+	}
+	resolver := final.(ui.ConflictResolver)
+	if !resolver.Saved() {
+		fmt.Fprintf(stderr, "%s left unresolved\n", path)
+		return 1
+	}
+	fmt.Fprintf(stdout, "Resolved %d conflict(s) in %s; `git add` it to mark the merge resolved\n", len(blocks), path)
+	return 0
+}
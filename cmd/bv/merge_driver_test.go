@@ -0,0 +1,90 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRunMergeDriver(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	base := write("base", `{"id":"A","title":"Alpha","status":"open","priority":2}`+"\n")
+	theirs := write("theirs", `{"id":"A","title":"Alpha","status":"open","priority":0}`+"\n")
+	ours := write("ours", `{"id":"A","title":"Alpha","status":"closed","priority":2}`+"\n")
+	var stdout, stderr bytes.Buffer
+
+	if code := runMergeDriver([]string{base, ours, theirs, ".beads/issues.jsonl"}, &stdout, &stderr); code != 0 {
+		t.Fatalf("clean merge: exit %d, stderr %q", code, stderr.String())
+	}
+	merged, _ := os.ReadFile(ours)
+	if !strings.Contains(string(merged), `"status":"closed"`) || !strings.Contains(string(merged), `"priority":0`) {
+		t.Errorf("merged result written to ours = %s", merged)
+	}
+
+	conflicting := write("conflicting", `{"id":"A","title":"Alpha","status":"in_progress","priority":2}`+"\n")
+	stderr.Reset()
+	if code := runMergeDriver([]string{base, conflicting, theirs}, &stdout, &stderr); code != 0 {
+		t.Fatalf("exit %d", code)
+	}
+	theirs2 := write("theirs2", `{"id":"A","title":"Alpha","status":"closed","priority":2}`+"\n")
+	if code := runMergeDriver([]string{base, conflicting, theirs2}, &stdout, &stderr); code != 1 {
+		t.Fatalf("conflicting merge: exit %d, want 1", code)
+	}
+	if !strings.Contains(stderr.String(), "A: status") {
+		t.Errorf("stderr should list the conflict: %q", stderr.String())
+	}
+	if data, _ := os.ReadFile(conflicting); !strings.HasPrefix(string(data), "<<<<<<< ours A [status]") {
+		t.Errorf("conflict markers not written: %s", data)
+	}
+
+	bad := write("bad", "not json\n")
+	if code := runMergeDriver([]string{base, bad, theirs}, &stdout, &stderr); code != 2 {
+		t.Errorf("unparseable ours: exit %d, want 2", code)
+	}
+	if code := runMergeDriver([]string{base}, &stdout, &stderr); code != 2 {
+		t.Errorf("too few arguments: exit %d, want 2", code)
+	}
+}
+
+func TestRunMergeDriver_Install(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	repo := t.TempDir()
+	if out, err := exec.Command("git", "init", "-q", repo).CombinedOutput(); err != nil {
+		t.Fatalf("git init: %v %s", err, out)
+	}
+	t.Chdir(repo)
+	var stdout, stderr bytes.Buffer
+	for i := 0; i < 2; i++ {
+		if code := runMergeDriver([]string{"install"}, &stdout, &stderr); code != 0 {
+			t.Fatalf("install: exit %d, stderr %q", code, stderr.String())
+		}
+	}
+	attrs, _ := os.ReadFile(filepath.Join(repo, ".gitattributes"))
+	if strings.Count(string(attrs), mergeDriverAttributes) != 1 {
+		t.Errorf(".gitattributes = %q, want the routing line once", attrs)
+	}
+	out, _ := exec.Command("git", "-C", repo, "config", "merge.beads.driver").Output()
+	if strings.TrimSpace(string(out)) != "bv merge-driver %O %A %B %P" {
+		t.Errorf("merge.beads.driver = %q", out)
+	}
+}
+
+func TestRunMergeDriver_ResolveWithoutConflicts(t *testing.T) {
+	setupNewProject(t)
+	var stdout, stderr bytes.Buffer
+	if code := runMergeDriver([]string{"resolve"}, &stdout, &stderr); code != 0 || !strings.Contains(stdout.String(), "No merge conflicts") {
+		t.Errorf("resolve: exit %d, stdout %q, stderr %q", code, stdout.String(), stderr.String())
+	}
+}
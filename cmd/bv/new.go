@@ -0,0 +1,129 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/scaffold"
+)
+
+// runNew implements `bv new`: create an issue from a template, printing it
+// as a JSONL line or (with --append) adding it to the beads file. It returns
+// the process exit code.
+func runNew(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("new", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	templateName := fs.String("template", "task", "Template name (built-in: bug, feature, task, chore; project: .bv/templates/<name>.yaml)")
+	title := fs.String("title", "", "Issue title (or pass it as arguments)")
+	assignee := fs.String("assignee", "", "Assignee ('me' = $BD_ACTOR, then $USER)")
+	epic := fs.String("epic", "", "Epic to link to: an ID, auto or none (default: the template's, else auto)")
+	appendFlag := fs.Bool("append", false, "Append the issue to the beads JSONL file instead of printing it")
+	list := fs.Bool("list", false, "List available templates")
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: bv new [--template name] [--append] <title>")
+		fmt.Fprintln(stderr, "\nCreate an issue from a template.")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return exitOK
+		}
+		return exitUsage
+	}
+
+	beadsDir, err := loader.GetBeadsDir("")
+	if err != nil {
+		fmt.Fprintf(stderr, "Error getting beads directory: %v\n", err)
+		return exitCodeFor(err)
+	}
+	projectDir := filepath.Dir(beadsDir)
+	templates, err := scaffold.LoadTemplates(projectDir)
+	if err != nil {
+		fmt.Fprintf(stderr, "Error loading templates: %v\n", err)
+		return exitError
+	}
+
+	if *list {
+		tw := tabwriter.NewWriter(stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "NAME\tTYPE\tPRIORITY\tDESCRIPTION\tSOURCE")
+		for _, name := range scaffold.Names(templates) {
+			t := templates[name]
+			priority := "-"
+			if t.Priority != nil {
+				priority = fmt.Sprintf("P%d", *t.Priority)
+			}
+			source := t.Source
+			if rel, err := filepath.Rel(projectDir, source); err == nil && !strings.HasPrefix(rel, "..") {
+				source = rel
+			}
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", name, t.IssueType, priority, t.Description, source)
+		}
+		_ = tw.Flush()
+		return exitOK
+	}
+
+	tmpl, ok := templates[*templateName]
+	if !ok {
+		fmt.Fprintf(stderr, "Unknown template %q (available: %s)\n", *templateName, strings.Join(scaffold.Names(templates), ", "))
+		return exitNotFound
+	}
+	if *title == "" {
+		*title = strings.Join(fs.Args(), " ")
+	}
+
+	var issues []model.Issue
+	beadsPath, findErr := loader.FindJSONLPath(beadsDir)
+	if findErr == nil {
+		if issues, err = loader.LoadIssuesFromFile(beadsPath); err != nil {
+			fmt.Fprintf(stderr, "Error loading beads: %v\n", err)
+			return exitError
+		}
+	}
+
+	issue, err := scaffold.New(tmpl, issues, scaffold.Options{
+		Title:    *title,
+		Assignee: analysis.ResolveAssignee(*assignee),
+		Epic:     *epic,
+		Actor:    analysis.CurrentActor(),
+	})
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		if errors.Is(err, scaffold.ErrNoTitle) {
+			fs.Usage()
+			return exitUsage
+		}
+		return exitError
+	}
+
+	if !*appendFlag {
+		line, err := json.Marshal(issue)
+		if err != nil {
+			fmt.Fprintf(stderr, "Error encoding issue: %v\n", err)
+			return exitError
+		}
+		fmt.Fprintln(stdout, string(line))
+		return exitOK
+	}
+
+	if findErr != nil {
+		beadsPath = filepath.Join(beadsDir, "issues.jsonl")
+	}
+	if err := loader.AppendIssue(beadsPath, issue); err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return exitError
+	}
+	fmt.Fprintf(stdout, "Created %s: %s\n", issue.ID, issue.Title)
+	if len(issue.Dependencies) > 0 {
+		fmt.Fprintf(stdout, "  Linked to epic %s\n", issue.Dependencies[0].DependsOnID)
+	}
+	return exitOK
+}
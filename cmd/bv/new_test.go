@@ -0,0 +1,79 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func setupNewProject(t *testing.T) string {
+	t.Helper()
+	beadsDir := filepath.Join(t.TempDir(), ".beads")
+	if err := os.MkdirAll(beadsDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	content := `{"id":"app-1","title":"Search epic","status":"open","issue_type":"epic","labels":["bug"]}` + "\n"
+	if err := os.WriteFile(filepath.Join(beadsDir, "issues.jsonl"), []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(loader.BeadsDirEnvVar, beadsDir)
+	return beadsDir
+}
+
+func TestRunNew_Print(t *testing.T) {
+	beadsDir := setupNewProject(t)
+	var stdout, stderr bytes.Buffer
+	if code := runNew([]string{"--template", "bug", "Crash", "on", "save"}, &stdout, &stderr); code != 0 {
+		t.Fatalf("exit %d: %s", code, stderr.String())
+	}
+	var issue model.Issue
+	if err := json.Unmarshal(stdout.Bytes(), &issue); err != nil {
+		t.Fatalf("output should be one JSONL line: %v\n%s", err, stdout.String())
+	}
+	if issue.Title != "Crash on save" || issue.IssueType != model.TypeBug || !strings.HasPrefix(issue.ID, "app-") {
+		t.Errorf("issue = %+v", issue)
+	}
+	if len(issue.Dependencies) != 1 || issue.Dependencies[0].DependsOnID != "app-1" {
+		t.Errorf("should auto-link the bug epic: %+v", issue.Dependencies)
+	}
+	issues, _ := loader.LoadIssuesFromFile(filepath.Join(beadsDir, "issues.jsonl"))
+	if len(issues) != 1 {
+		t.Errorf("printing must not modify the beads file, got %d issues", len(issues))
+	}
+}
+
+func TestRunNew_Append(t *testing.T) {
+	beadsDir := setupNewProject(t)
+	var stdout, stderr bytes.Buffer
+	if code := runNew([]string{"--template", "chore", "--append", "--title", "Bump deps"}, &stdout, &stderr); code != 0 {
+		t.Fatalf("exit %d: %s", code, stderr.String())
+	}
+	if !strings.HasPrefix(stdout.String(), "Created app-") {
+		t.Errorf("stdout = %q", stdout.String())
+	}
+	issues, err := loader.LoadIssuesFromFile(filepath.Join(beadsDir, "issues.jsonl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(issues) != 2 || issues[1].Title != "Bump deps" || issues[1].Priority != 3 {
+		t.Errorf("issues = %+v", issues)
+	}
+}
+
+func TestRunNew_Errors(t *testing.T) {
+	setupNewProject(t)
+	var stdout, stderr bytes.Buffer
+	if code := runNew([]string{"--template", "nope", "x"}, &stdout, &stderr); code != exitNotFound || !strings.Contains(stderr.String(), "available: bug, chore, feature, task") {
+		t.Errorf("unknown template: exit %d, stderr %q", code, stderr.String())
+	}
+	stderr.Reset()
+	if code := runNew([]string{"--template", "bug"}, &stdout, &stderr); code != exitUsage || !strings.Contains(stderr.String(), "title is required") {
+		t.Errorf("missing title: exit %d, stderr %q", code, stderr.String())
+	}
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/correlation"
+)
+
+// Pagination (--page-size / --cursor) splits the large per-issue parts of a
+// robot payload into pages. The payload carries a "page" object describing
+// the slice it holds; when more remain, page.next_cursor is the --cursor
+// value for the next call. Cursors are opaque and bound to the data_hash of
+// the data they were issued for, so paging across a data change fails
+// instead of skipping or repeating entries.
+
+// robotPage describes the slice of a paginated list held by a payload
+type robotPage struct {
+	Offset     int    `json:"offset"`
+	Size       int    `json:"size"`     // Page size requested (0 = unlimited)
+	Total      int    `json:"total"`    // Entries across all pages
+	Returned   int    `json:"returned"` // Entries in this page
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// errCursorStale reports a cursor issued for different data
+var errCursorStale = errors.New("cursor was issued for different data (data_hash changed); restart without --cursor")
+
+// pageRequest is a parsed --page-size / --cursor pair
+type pageRequest struct {
+	size   int
+	offset int
+}
+
+// parsePageRequest validates --page-size and --cursor against the current
+// data hash. A size of 0 means the command's default.
+func parsePageRequest(size int, cursor, dataHash string) (pageRequest, error) {
+	if size < 0 {
+		return pageRequest{}, fmt.Errorf("--page-size must be positive, got %d", size)
+	}
+	req := pageRequest{size: size}
+	if cursor == "" {
+		return req, nil
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return pageRequest{}, fmt.Errorf("malformed cursor %q", cursor)
+	}
+	hash, offset, ok := strings.Cut(string(raw), ":")
+	if !ok {
+		return pageRequest{}, fmt.Errorf("malformed cursor %q", cursor)
+	}
+	if req.offset, err = strconv.Atoi(offset); err != nil || req.offset < 0 {
+		return pageRequest{}, fmt.Errorf("malformed cursor %q", cursor)
+	}
+	if hash != cursorHash(dataHash) {
+		return pageRequest{}, errCursorStale
+	}
+	return req, nil
+}
+
+// cursorHash is the part of the data hash a cursor carries
+func cursorHash(dataHash string) string {
+	if len(dataHash) > 16 {
+		return dataHash[:16]
+	}
+	return dataHash
+}
+
+// encodeCursor makes the cursor that resumes at offset
+func encodeCursor(dataHash string, offset int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(cursorHash(dataHash) + ":" + strconv.Itoa(offset)))
+}
+
+// withDefault returns the request with size defaulted when unset
+func (r pageRequest) withDefault(size int) pageRequest {
+	if r.size == 0 {
+		r.size = size
+	}
+	return r
+}
+
+// paginate returns the [start, end) bounds of the requested page of a list
+// of total entries and the page description for the payload
+func (r pageRequest) paginate(total int, dataHash string) (start, end int, page *robotPage) {
+	start = min(r.offset, total)
+	end = total
+	if r.size > 0 && start+r.size < total {
+		end = start + r.size
+	}
+	page = &robotPage{Offset: start, Size: r.size, Total: total, Returned: end - start}
+	if end < total {
+		page.NextCursor = encodeCursor(dataHash, end)
+	}
+	return start, end, page
+}
+
+// insightsDefaultPageSize is the --robot-insights full_stats page size when
+// --page-size is not given. BV_INSIGHTS_MAP_LIMIT, the older cap on those
+// maps, still sets it.
+func insightsDefaultPageSize() int {
+	if v := os.Getenv("BV_INSIGHTS_MAP_LIMIT"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n > 0 {
+			return n
+		}
+	}
+	return 200
+}
+
+// paginateHistory trims a history report to one page of histories, by bead
+// ID, and the commit_index entries for those beads
+func paginateHistory(report *correlation.HistoryReport, req pageRequest) *robotPage {
+	beadIDs := make([]string, 0, len(report.Histories))
+	for id := range report.Histories {
+		beadIDs = append(beadIDs, id)
+	}
+	sort.Strings(beadIDs)
+	start, end, page := req.paginate(len(beadIDs), report.DataHash)
+
+	histories := make(map[string]correlation.BeadHistory, end-start)
+	for _, id := range beadIDs[start:end] {
+		histories[id] = report.Histories[id]
+	}
+	index := make(correlation.CommitIndex)
+	for sha, beads := range report.CommitIndex {
+		for _, id := range beads {
+			if _, ok := histories[id]; ok {
+				index[sha] = append(index[sha], id)
+			}
+		}
+	}
+	report.Histories, report.CommitIndex = histories, index
+	return page
+}
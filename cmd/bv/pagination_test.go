@@ -0,0 +1,149 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/correlation"
+)
+
+func TestPageRequest_Paginate(t *testing.T) {
+	req, err := parsePageRequest(2, "", "hash")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var seen []int
+	for pages := 0; ; pages++ {
+		if pages > 3 {
+			t.Fatal("pagination did not terminate")
+		}
+		start, end, page := req.paginate(5, "hash")
+		for i := start; i < end; i++ {
+			seen = append(seen, i)
+		}
+		if page.Total != 5 || page.Returned != end-start || page.Offset != start {
+			t.Errorf("page = %+v for [%d,%d)", page, start, end)
+		}
+		if page.NextCursor == "" {
+			break
+		}
+		if req, err = parsePageRequest(2, page.NextCursor, "hash"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if fmt.Sprint(seen) != "[0 1 2 3 4]" {
+		t.Errorf("pages covered %v, want every entry once", seen)
+	}
+
+	// Unlimited pages hold everything
+	if start, end, page := (pageRequest{}).paginate(5, "hash"); start != 0 || end != 5 || page.NextCursor != "" {
+		t.Errorf("unlimited page = [%d,%d) %+v", start, end, page)
+	}
+	// A cursor past the end yields an empty page
+	if start, end, _ := (pageRequest{offset: 9}).paginate(5, "hash"); start != 5 || end != 5 {
+		t.Errorf("past-the-end page = [%d,%d)", start, end)
+	}
+}
+
+func TestParsePageRequest_Errors(t *testing.T) {
+	if _, err := parsePageRequest(-1, "", "hash"); err == nil {
+		t.Error("negative page size should fail")
+	}
+	for _, cursor := range []string{"!!", encodeCursor("hash", 0)[:3], "aGFzaA"} {
+		if _, err := parsePageRequest(0, cursor, "hash"); err == nil {
+			t.Errorf("cursor %q should be rejected", cursor)
+		}
+	}
+	if _, err := parsePageRequest(0, encodeCursor("old", 4), "new"); err != errCursorStale {
+		t.Errorf("cursor from other data: err = %v, want errCursorStale", err)
+	}
+}
+
+func TestPaginateHistory(t *testing.T) {
+	report := &correlation.HistoryReport{
+		DataHash: "h",
+		Histories: map[string]correlation.BeadHistory{
+			"b-1": {BeadID: "b-1"}, "b-2": {BeadID: "b-2"}, "b-3": {BeadID: "b-3"},
+		},
+		CommitIndex: correlation.CommitIndex{"aaa": {"b-1", "b-3"}, "bbb": {"b-2"}},
+	}
+	page := paginateHistory(report, pageRequest{size: 2, offset: 1})
+	if page.Total != 3 || page.Returned != 2 || page.NextCursor != "" {
+		t.Errorf("page = %+v", page)
+	}
+	if _, ok := report.Histories["b-1"]; ok || len(report.Histories) != 2 {
+		t.Errorf("histories = %v, want b-2 and b-3", report.Histories)
+	}
+	if got := fmt.Sprint(report.CommitIndex); got != "map[aaa:[b-3] bbb:[b-2]]" {
+		t.Errorf("commit index = %s, want entries for the page's beads only", got)
+	}
+}
+
+func TestRobotInsightsPaginationFlags(t *testing.T) {
+	dir := t.TempDir()
+	beadsDir := filepath.Join(dir, ".beads")
+	if err := os.MkdirAll(beadsDir, 0o755); err != nil {
+		t.Fatalf("mkdir beads: %v", err)
+	}
+	var beads strings.Builder
+	for i := 1; i <= 5; i++ {
+		fmt.Fprintf(&beads, `{"id":"TEST-%d","title":"Issue %d","status":"open","priority":1,"issue_type":"task"}`+"\n", i, i)
+	}
+	if err := os.WriteFile(filepath.Join(beadsDir, "beads.jsonl"), []byte(beads.String()), 0o644); err != nil {
+		t.Fatalf("write beads: %v", err)
+	}
+
+	exe := buildTestBinary(t)
+	seen := make(map[string]bool)
+	cursor := ""
+	for pages := 0; ; pages++ {
+		if pages > 3 {
+			t.Fatal("pagination did not terminate")
+		}
+		args := []string{"--robot-insights", "--page-size", "2"}
+		if cursor != "" {
+			args = append(args, "--cursor", cursor)
+		}
+		cmd := exec.Command(exe, args...)
+		cmd.Dir = dir
+		out, err := cmd.Output()
+		if err != nil {
+			t.Fatalf("bv %v failed: %v", args, err)
+		}
+		var got struct {
+			FullStats struct {
+				PageRank map[string]float64 `json:"pagerank"`
+			} `json:"full_stats"`
+			Page robotPage `json:"page"`
+		}
+		if err := json.Unmarshal(out, &got); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+		if len(got.FullStats.PageRank) != got.Page.Returned || got.Page.Total != 5 {
+			t.Fatalf("page %+v holds %d pagerank entries", got.Page, len(got.FullStats.PageRank))
+		}
+		for id := range got.FullStats.PageRank {
+			if seen[id] {
+				t.Errorf("%s repeated across pages", id)
+			}
+			seen[id] = true
+		}
+		if cursor = got.Page.NextCursor; cursor == "" {
+			break
+		}
+	}
+	if len(seen) != 5 {
+		t.Errorf("pages covered %d issues, want 5", len(seen))
+	}
+
+	cmd := exec.Command(exe, "--robot-insights", "--cursor", encodeCursor("other-data", 2))
+	cmd.Dir = dir
+	if err := cmd.Run(); err == nil {
+		t.Error("a cursor from other data should fail")
+	}
+}
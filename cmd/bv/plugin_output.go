@@ -0,0 +1,46 @@
+package main
+
+import (
+	"context"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/plugins"
+)
+
+// runPlugins runs the analyzer plugins in .bv/plugins/ for an output. It
+// returns nil when there are none, so outputs without plugins are unchanged.
+func runPlugins(projectDir, reqContext string, issues []model.Issue) *plugins.Result {
+	found, err := plugins.Discover(projectDir)
+	if err != nil {
+		return &plugins.Result{Ran: []string{}, Errors: []plugins.Error{{Message: err.Error()}}}
+	}
+	if len(found) == 0 {
+		return nil
+	}
+	result := plugins.Run(context.Background(), found, reqContext, issues, 0)
+	return &result
+}
+
+// mergePluginsIntoTriage attaches plugin scores to the recommendations they
+// rate and adds plugin alerts to the triage alerts. It returns what is left
+// to report on its own: which plugins ran and which failed.
+func mergePluginsIntoTriage(triage *analysis.TriageResult, result *plugins.Result) *plugins.Result {
+	if result == nil {
+		return nil
+	}
+	attach := func(recs []analysis.Recommendation) {
+		for i := range recs {
+			recs[i].PluginScores = result.Scores[recs[i].ID]
+		}
+	}
+	attach(triage.Recommendations)
+	for _, group := range triage.RecommendationsByTrack {
+		attach(group.Recommendations)
+	}
+	for _, group := range triage.RecommendationsByLabel {
+		attach(group.Recommendations)
+	}
+	triage.Alerts = append(triage.Alerts, result.Alerts...)
+	return &plugins.Result{Ran: result.Ran, Errors: result.Errors}
+}
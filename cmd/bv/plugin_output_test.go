@@ -0,0 +1,105 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/plugins"
+)
+
+func TestMergePluginsIntoTriage(t *testing.T) {
+	triage := analysis.TriageResult{
+		Recommendations:        []analysis.Recommendation{{ID: "A-1"}, {ID: "A-2"}},
+		RecommendationsByTrack: []analysis.TrackRecommendationGroup{{Recommendations: []analysis.Recommendation{{ID: "A-1"}}}},
+	}
+	result := &plugins.Result{
+		Ran:    []string{"security"},
+		Scores: map[string]map[string]float64{"A-1": {"security:risk": 0.5}},
+		Alerts: []analysis.Alert{{Type: "security:cve", Severity: "warning", Message: "m"}},
+		Errors: []plugins.Error{{Plugin: "other", Message: "boom"}},
+	}
+
+	rest := mergePluginsIntoTriage(&triage, result)
+	if triage.Recommendations[0].PluginScores["security:risk"] != 0.5 || triage.Recommendations[1].PluginScores != nil {
+		t.Errorf("recommendations = %+v", triage.Recommendations)
+	}
+	if triage.RecommendationsByTrack[0].Recommendations[0].PluginScores["security:risk"] != 0.5 {
+		t.Error("track groups should get plugin scores too")
+	}
+	if len(triage.Alerts) != 1 || triage.Alerts[0].Type != "security:cve" {
+		t.Errorf("alerts = %+v", triage.Alerts)
+	}
+	if rest.Scores != nil || rest.Alerts != nil || len(rest.Ran) != 1 || len(rest.Errors) != 1 {
+		t.Errorf("leftover = %+v", rest)
+	}
+	if mergePluginsIntoTriage(&triage, nil) != nil {
+		t.Error("no plugins should report nothing")
+	}
+}
+
+func TestRobotTriageRunsPlugins(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("plugins are shell scripts")
+	}
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, ".beads"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	beads := `{"id":"P-1","title":"One","status":"open","priority":1,"issue_type":"task"}` + "\n"
+	if err := os.WriteFile(filepath.Join(dir, ".beads", "beads.jsonl"), []byte(beads), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(plugins.Dir(dir), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	script := "#!/bin/sh\ncat >/dev/null\necho '{\"scores\":{\"P-1\":{\"cost\":3}},\"alerts\":[{\"type\":\"budget\",\"message\":\"over budget\",\"issue_id\":\"P-1\"}]}'\n"
+	if err := os.WriteFile(filepath.Join(plugins.Dir(dir), "finance"), []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	exe := buildTestBinary(t)
+	cmd := exec.Command(exe, "--robot-triage")
+	cmd.Dir = dir
+	out, err := cmd.Output()
+	if err != nil {
+		t.Fatalf("--robot-triage: %v\n%s", err, out)
+	}
+	var payload struct {
+		Triage  analysis.TriageResult `json:"triage"`
+		Plugins *plugins.Result       `json:"plugins"`
+	}
+	if err := json.Unmarshal(out, &payload); err != nil {
+		t.Fatal(err)
+	}
+	if payload.Plugins == nil || len(payload.Plugins.Ran) != 1 || payload.Plugins.Ran[0] != "finance" {
+		t.Fatalf("plugins = %+v", payload.Plugins)
+	}
+	if got := payload.Triage.Recommendations[0].PluginScores["finance:cost"]; got != 3 {
+		t.Errorf("finance:cost = %v", got)
+	}
+	found := false
+	for _, a := range payload.Triage.Alerts {
+		found = found || a.Type == "finance:budget"
+	}
+	if !found {
+		t.Errorf("plugin alert missing from %+v", payload.Triage.Alerts)
+	}
+
+	cmd = exec.Command(exe, "--robot-triage", "--no-plugins")
+	cmd.Dir = dir
+	if out, err = cmd.Output(); err != nil {
+		t.Fatal(err)
+	}
+	var plain map[string]json.RawMessage
+	if err := json.Unmarshal(out, &plain); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := plain["plugins"]; ok {
+		t.Error("--no-plugins should leave plugins out")
+	}
+}
@@ -172,6 +172,19 @@ func TestBuildMetricItems(t *testing.T) {
 	}
 }
 
+func TestBuildCurrentBaselineCountsInReviewAsOpen(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "A", Title: "Open", Status: model.StatusOpen},
+		{ID: "B", Title: "Working", Status: model.StatusInProgress},
+		{ID: "C", Title: "Reviewing", Status: model.StatusInReview},
+		{ID: "D", Title: "Done", Status: model.StatusClosed},
+	}
+	bl := buildCurrentBaseline(issues, false)
+	if bl.Stats.OpenCount != 3 || bl.Stats.ClosedCount != 1 {
+		t.Fatalf("stats = %+v, want 3 open (in_review included) and 1 closed", bl.Stats)
+	}
+}
+
 func TestRepeatChar(t *testing.T) {
 	if got := repeatChar('x', 4); got != "xxxx" {
 		t.Fatalf("repeatChar mismatch: %q", got)
@@ -0,0 +1,143 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/provenance"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/version"
+)
+
+// outputProvenance is embedded as "provenance" in every robot payload and
+// written next to every export when --provenance, --sign or BV_PROVENANCE=1
+// is set; nil otherwise. It is filled in once the issues are loaded.
+var outputProvenance *provenance.Provenance
+
+// outputSignKey is the --sign (or BV_SIGN_KEY) private key exports are
+// signed with; empty when not signing
+var outputSignKey string
+
+// robotSigner writes a detached signature of the robot output when --sign
+// and --signature are set; nil otherwise
+var robotSigner *outputSigner
+
+// outputSigner signs everything the robot encoder writes. Each payload
+// re-signs the output so far, so the signature always covers all of it.
+type outputSigner struct {
+	key     string
+	sigPath string
+	written bytes.Buffer
+}
+
+func (s *outputSigner) observe(out []byte) error {
+	if s == nil {
+		return nil
+	}
+	s.written.Write(out)
+	sig, err := provenance.Sign(s.written.Bytes(), s.key)
+	if err != nil {
+		return fmt.Errorf("signing robot output: %w", err)
+	}
+	return os.WriteFile(s.sigPath, sig, 0o644)
+}
+
+// newOutputProvenance records this invocation: the data hash, the git state
+// of the project and the flags given, other than those about provenance
+// itself
+func newOutputProvenance(projectDir, dataHash string) *provenance.Provenance {
+	commit, dirty := provenance.GitState(projectDir)
+	flags := make(map[string]string)
+	flag.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "provenance", "sign", "signature":
+			return
+		}
+		flags[f.Name] = f.Value.String()
+	})
+	if len(flags) == 0 {
+		flags = nil
+	}
+	return &provenance.Provenance{
+		DataHash:  dataHash,
+		GitCommit: commit,
+		GitDirty:  dirty,
+		Version:   version.Version,
+		Flags:     flags,
+	}
+}
+
+// withProvenanceField appends the provenance object to a compact JSON
+// object, leaving other values alone
+func withProvenanceField(data []byte) []byte {
+	if outputProvenance == nil || len(data) < 2 || data[0] != '{' {
+		return data
+	}
+	value, err := json.Marshal(outputProvenance)
+	if err != nil {
+		return data
+	}
+	field := `"provenance":` + string(value)
+	if data[len(data)-2] != '{' {
+		field = "," + field
+	}
+	out := make([]byte, 0, len(data)+len(field))
+	out = append(out, data[:len(data)-1]...)
+	out = append(out, field...)
+	return append(out, '}')
+}
+
+// sealExport writes the provenance of an export artifact, with the SHA-256
+// of each file it consists of, to <file>.provenance.json (provenance.json
+// inside a directory) and signs it when a key is set. It returns the
+// provenance file written, or "" when provenance is off.
+func sealExport(path string) (string, error) {
+	if outputProvenance == nil {
+		return "", nil
+	}
+	files, err := provenance.HashFiles(path)
+	if err != nil {
+		return "", fmt.Errorf("hashing export: %w", err)
+	}
+	record := *outputProvenance
+	record.Files = files
+	data, err := json.MarshalIndent(record, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	data = append(data, '\n')
+
+	manifest := path + provenance.SidecarSuffix
+	if info, err := os.Stat(path); err == nil && info.IsDir() {
+		manifest = filepath.Join(path, provenance.DirManifest)
+	}
+	if err := os.WriteFile(manifest, data, 0o644); err != nil {
+		return "", err
+	}
+	if outputSignKey == "" {
+		return manifest, nil
+	}
+	sig, err := provenance.Sign(data, outputSignKey)
+	if err != nil {
+		return "", fmt.Errorf("signing provenance: %w", err)
+	}
+	return manifest, os.WriteFile(manifest+provenance.SignatureSuffix, sig, 0o644)
+}
+
+// sealExportOrExit seals an export artifact, reporting the provenance file
+func sealExportOrExit(path string) {
+	manifest, err := sealExport(path)
+	if err != nil {
+		fatalf(exitCodeFor(err), "Error writing provenance: %v", err)
+	}
+	switch {
+	case manifest == "":
+	case outputSignKey != "":
+		fmt.Printf("Provenance written to %s (signature %s)\n", manifest, manifest+provenance.SignatureSuffix)
+	default:
+		fmt.Printf("Provenance written to %s\n", manifest)
+	}
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/provenance"
+)
+
+func TestWithProvenanceField(t *testing.T) {
+	outputProvenance = &provenance.Provenance{DataHash: "abc", Version: "v1"}
+	t.Cleanup(func() { outputProvenance = nil })
+
+	got := string(withProvenanceField([]byte(`{"a":1}`)))
+	if got != `{"a":1,"provenance":{"data_hash":"abc","bv_version":"v1"}}` {
+		t.Errorf("object = %s", got)
+	}
+	if got := string(withProvenanceField([]byte(`{}`))); got != `{"provenance":{"data_hash":"abc","bv_version":"v1"}}` {
+		t.Errorf("empty object = %s", got)
+	}
+	if got := string(withProvenanceField([]byte(`[1]`))); got != `[1]` {
+		t.Errorf("arrays should be left alone, got %s", got)
+	}
+}
+
+func TestSealExportAndVerify(t *testing.T) {
+	if _, err := exec.LookPath("ssh-keygen"); err != nil {
+		t.Skip("ssh-keygen not available")
+	}
+	beadsDir := setupNewProject(t)
+	issues, err := loader.LoadIssuesFromFile(filepath.Join(beadsDir, "issues.jsonl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	key := filepath.Join(dir, "id_ed25519")
+	if out, err := exec.Command("ssh-keygen", "-q", "-t", "ed25519", "-N", "", "-f", key).CombinedOutput(); err != nil {
+		t.Fatalf("ssh-keygen: %v %s", err, out)
+	}
+	outputProvenance = &provenance.Provenance{DataHash: analysis.ComputeDataHash(issues), Version: "test"}
+	outputSignKey = key
+	t.Cleanup(func() { outputProvenance, outputSignKey = nil, "" })
+
+	export := filepath.Join(dir, "report.md")
+	if err := os.WriteFile(export, []byte("# Report\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	manifest, err := sealExport(export)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var record provenance.Provenance
+	data, _ := os.ReadFile(manifest)
+	if err := json.Unmarshal(data, &record); err != nil || record.Files["report.md"] == "" {
+		t.Fatalf("provenance file = %s, %v", data, err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	if code := runVerify([]string{export, "--key", key + ".pub"}, &stdout, &stderr); code != 0 {
+		t.Fatalf("verify: exit %d\n%s%s", code, stdout.String(), stderr.String())
+	}
+	for _, want := range []string{"signature  ok", "files      ok", "data       ok"} {
+		if !strings.Contains(stdout.String(), want) {
+			t.Errorf("verify output missing %q:\n%s", want, stdout.String())
+		}
+	}
+
+	_ = os.WriteFile(export, []byte("# Edited\n"), 0o644)
+	stdout.Reset()
+	if code := runVerify([]string{export, "--key", key + ".pub"}, &stdout, &stderr); code != 1 || !strings.Contains(stdout.String(), "report.md: changed") {
+		t.Errorf("tampered export: exit %d\n%s", code, stdout.String())
+	}
+
+	// A signed robot payload whose data has since changed is stale
+	robotSigner = &outputSigner{key: key, sigPath: filepath.Join(dir, "triage.sig")}
+	t.Cleanup(func() { robotSigner = nil })
+	payload := filepath.Join(dir, "triage.json")
+	var out bytes.Buffer
+	if err := newRobotEncoder(&out).Encode(map[string]string{"generated_at": "now"}); err != nil {
+		t.Fatal(err)
+	}
+	_ = os.WriteFile(payload, out.Bytes(), 0o644)
+	outputProvenance.DataHash = "0000"
+	stdout.Reset()
+	args := []string{payload, "--key", key + ".pub", "--sig", filepath.Join(dir, "triage.sig")}
+	if code := runVerify(args, &stdout, &stderr); code != 0 {
+		t.Fatalf("fresh payload: exit %d\n%s%s", code, stdout.String(), stderr.String())
+	}
+	out.Reset()
+	robotSigner = &outputSigner{key: key, sigPath: filepath.Join(dir, "triage.sig")}
+	_ = newRobotEncoder(&out).Encode(map[string]string{"generated_at": "now"})
+	_ = os.WriteFile(payload, out.Bytes(), 0o644)
+	stdout.Reset()
+	if code := runVerify(args, &stdout, &stderr); code != 1 || !strings.Contains(stdout.String(), "stale") {
+		t.Errorf("stale payload: exit %d\n%s", code, stdout.String())
+	}
+	if code := runVerify(append(args, "--allow-stale"), &stdout, &stderr); code != 0 {
+		t.Errorf("--allow-stale: exit %d", code)
+	}
+}
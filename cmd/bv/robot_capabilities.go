@@ -0,0 +1,237 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"runtime"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/baseline"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/offline"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/version"
+)
+
+// --robot-capabilities lets an agent framework negotiate with whatever bv
+// is installed: which robot commands and flags exist, which payload schema
+// version they speak, and which features this project can actually get
+// (graph metrics skipped for size, git history, a drift baseline).
+
+// robotGitCommands need the project's git history
+var robotGitCommands = map[string]bool{
+	"bisect":              true,
+	"causality":           true,
+	"code-map":            true,
+	"confirm-correlation": true,
+	"correlation-stats":   true,
+	"diff":                true,
+	"explain-correlation": true,
+	"file-beads":          true,
+	"file-hotspots":       true,
+	"file-relations":      true,
+	"history":             true,
+	"impact":              true,
+	"impact-network":      true,
+	"orphans":             true,
+	"pr-impact":           true,
+	"reject-correlation":  true,
+	"related":             true,
+	"replay":              true,
+	"suggest-trailers":    true,
+	"why":                 true,
+}
+
+// capabilityBuild describes the running binary
+type capabilityBuild struct {
+	GoVersion string   `json:"go_version"`
+	OS        string   `json:"os"`
+	Arch      string   `json:"arch"`
+	Tags      []string `json:"tags,omitempty"` // -tags it was built with
+	Revision  string   `json:"revision,omitempty"`
+}
+
+// capabilityGraph is the size the analysis configuration was chosen for
+type capabilityGraph struct {
+	Nodes int `json:"nodes"`
+	Edges int `json:"edges"`
+}
+
+// robotCapabilityCommand is one robot command
+type robotCapabilityCommand struct {
+	Name          string   `json:"name"`
+	Flag          string   `json:"flag"`
+	TakesArg      bool     `json:"takes_arg"`
+	Subcommand    string   `json:"subcommand,omitempty"` // e.g. "bv sprint show"
+	Options       []string `json:"options,omitempty"`
+	SchemaVersion string   `json:"schema_version"`
+	Available     bool     `json:"available"`
+	Reason        string   `json:"reason,omitempty"` // Why it is unavailable
+}
+
+// capabilityFeature is a feature whose availability depends on the build,
+// the graph or the project
+type capabilityFeature struct {
+	Name      string `json:"name"`
+	Available bool   `json:"available"`
+	Mode      string `json:"mode,omitempty"`
+	Reason    string `json:"reason,omitempty"`
+}
+
+// buildRobotCapabilities describes the robot interface of fs for issues in
+// projectDir
+func buildRobotCapabilities(fs *flag.FlagSet, issues []model.Issue, dataHash, projectDir string, fullAnalysis bool, now time.Time) robotCapabilitiesOutput {
+	edges := countEdges(issues)
+	cfg := analysis.ConfigForSize(len(issues), edges)
+	if fullAnalysis {
+		cfg = analysis.FullAnalysisConfig()
+	}
+	_ = analysis.ApplyBetweennessEnv(&cfg, os.Getenv)
+
+	gitReason := ""
+	if _, err := doctorGitOutput(projectDir, "rev-parse", "--verify", "HEAD"); err != nil {
+		gitReason = "no git history in " + projectDir
+	}
+	baselineReason := ""
+	if !baseline.Exists(baseline.DefaultPath(projectDir)) {
+		baselineReason = "no baseline saved (bv drift save)"
+	}
+
+	out := robotCapabilitiesOutput{
+		GeneratedAt: now.UTC().Format(time.RFC3339),
+		DataHash:    dataHash,
+		Version:     version.Version,
+		Build:       currentCapabilityBuild(),
+		Graph:       capabilityGraph{Nodes: len(issues), Edges: edges},
+		Features:    append(capabilityFeatures(cfg, len(issues), gitReason, baselineReason), networkFeature()),
+		Flags:       buildCapabilities(fs).Flags,
+		UsageHints: []string{
+			"jq '[.commands[] | select(.available) | .flag]' - Robot flags usable here",
+			"jq '.flags | map(.name) | index(\"robot-next\") != null' - Check for a flag before passing it",
+			"jq '.features[] | select(.available | not)' - Features unavailable, with reasons",
+			"bv --robot-schema <name> - JSON Schema of a command's payload",
+		},
+	}
+
+	subcommands := robotSubcommands()
+	for _, name := range robotSchemaCommands() {
+		f := fs.Lookup("robot-" + name)
+		if f == nil {
+			continue
+		}
+		b, isBool := f.Value.(interface{ IsBoolFlag() bool })
+		cmd := robotCapabilityCommand{
+			Name:          name,
+			Flag:          "--robot-" + name,
+			TakesArg:      !isBool || !b.IsBoolFlag(),
+			SchemaVersion: robotSchemaVersion,
+			Available:     true,
+		}
+		if sub, ok := subcommands[f.Name]; ok {
+			cmd.Subcommand, cmd.Options = sub.name, sub.options
+		}
+		switch {
+		case robotGitCommands[name] && gitReason != "":
+			cmd.Available, cmd.Reason = false, gitReason
+		case name == "drift" && baselineReason != "":
+			cmd.Available, cmd.Reason = false, baselineReason
+		}
+		out.Commands = append(out.Commands, cmd)
+	}
+	return out
+}
+
+// robotSubcommand is the subcommand standing for a robot flag
+type robotSubcommand struct {
+	name    string
+	options []string
+}
+
+// robotSubcommands maps robot flags to the first subcommand that sets them
+// or takes its argument through them
+func robotSubcommands() map[string]robotSubcommand {
+	subs := map[string]robotSubcommand{}
+	var walk func(prefix string, cmds []cliCommand)
+	walk = func(prefix string, cmds []cliCommand) {
+		for _, c := range cmds {
+			name := prefix + " " + c.Name
+			flags := c.Flags
+			if c.ArgFlag != "" && c.ArgFlag != "-" {
+				flags = append(flags[:len(flags):len(flags)], c.ArgFlag)
+			}
+			// A verb's last flag is the one it adds, e.g. robot-triage-by-track
+			for i := len(flags) - 1; i >= 0; i-- {
+				if _, seen := subs[flags[i]]; !seen && strings.HasPrefix(flags[i], "robot-") {
+					subs[flags[i]] = robotSubcommand{name: name, options: c.Options}
+					break
+				}
+			}
+			walk(name, c.Verbs)
+		}
+	}
+	walk("bv", cliCommands)
+	return subs
+}
+
+// currentCapabilityBuild reads the binary's build information
+func currentCapabilityBuild() capabilityBuild {
+	build := capabilityBuild{GoVersion: runtime.Version(), OS: runtime.GOOS, Arch: runtime.GOARCH}
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return build
+	}
+	for _, s := range info.Settings {
+		switch s.Key {
+		case "-tags":
+			build.Tags = strings.Split(s.Value, ",")
+		case "vcs.revision":
+			build.Revision = s.Value
+		}
+	}
+	return build
+}
+
+// networkFeature reports whether updates, deploys and publishing can reach
+// the network
+func networkFeature() capabilityFeature {
+	if offline.Enabled() {
+		return capabilityFeature{Name: "network", Reason: offline.ErrOffline.Error()}
+	}
+	return capabilityFeature{Name: "network", Available: true}
+}
+
+// capabilityFeatures reports the graph metrics the analysis configuration
+// computes, and the project-dependent features
+func capabilityFeatures(cfg analysis.AnalysisConfig, nodes int, gitReason, baselineReason string) []capabilityFeature {
+	skipped := func(reason string) string {
+		if reason == "" {
+			reason = fmt.Sprintf("skipped for a graph of %d issues", nodes)
+		}
+		return reason + "; --force-full-analysis computes it"
+	}
+	metric := func(name string, on bool, reason string) capabilityFeature {
+		f := capabilityFeature{Name: name, Available: on}
+		if !on {
+			f.Reason = skipped(reason)
+		}
+		return f
+	}
+
+	betweenness := metric("betweenness", cfg.ComputeBetweenness && cfg.BetweennessMode != analysis.BetweennessSkip, cfg.BetweennessSkipReason)
+	if betweenness.Available {
+		betweenness.Mode = string(cfg.BetweennessMode)
+	}
+	return []capabilityFeature{
+		metric("pagerank", cfg.ComputePageRank, cfg.PageRankSkipReason),
+		betweenness,
+		metric("hits", cfg.ComputeHITS, cfg.HITSSkipReason),
+		metric("eigenvector", cfg.ComputeEigenvector, ""),
+		metric("critical_path", cfg.ComputeCriticalPath, ""),
+		metric("cycles", cfg.ComputeCycles, cfg.CyclesSkipReason),
+		{Name: "git_history", Available: gitReason == "", Reason: gitReason},
+		{Name: "drift_baseline", Available: baselineReason == "", Reason: baselineReason},
+	}
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"flag"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/offline"
+)
+
+func TestBuildRobotCapabilities(t *testing.T) {
+	fs := flag.NewFlagSet("bv", flag.ContinueOnError)
+	fs.Bool("robot-triage-by-track", false, "")
+	fs.String("robot-sprint-show", "", "")
+	fs.Bool("robot-history", false, "")
+	fs.Bool("robot-drift", false, "")
+
+	// Not a git repository and no baseline
+	issues := []model.Issue{{ID: "bv-1", Title: "One", Status: model.StatusOpen}}
+	out := buildRobotCapabilities(fs, issues, "hash", t.TempDir(), false, time.Now())
+
+	commands := map[string]robotCapabilityCommand{}
+	for _, c := range out.Commands {
+		commands[c.Name] = c
+	}
+	if len(commands) != 4 {
+		t.Fatalf("commands = %+v", out.Commands)
+	}
+	if c := commands["triage-by-track"]; c.Subcommand != "bv triage by-track" || c.TakesArg || !c.Available || c.SchemaVersion != robotSchemaVersion {
+		t.Errorf("triage-by-track = %+v", c)
+	}
+	if c := commands["sprint-show"]; c.Subcommand != "bv sprint show" || !c.TakesArg {
+		t.Errorf("sprint-show = %+v", c)
+	}
+	if c := commands["history"]; c.Available || !strings.Contains(c.Reason, "git") {
+		t.Errorf("history outside git = %+v", c)
+	}
+	if c := commands["drift"]; c.Available || !strings.Contains(c.Reason, "baseline") {
+		t.Errorf("drift without baseline = %+v", c)
+	}
+}
+
+func TestCapabilityFeatures_SkippedForSize(t *testing.T) {
+	cfg := analysis.ConfigForSize(5000, 40000)
+	features := map[string]capabilityFeature{}
+	for _, f := range capabilityFeatures(cfg, 5000, "", "") {
+		features[f.Name] = f
+	}
+	if f := features["cycles"]; f.Available || !strings.Contains(f.Reason, "--force-full-analysis") {
+		t.Errorf("cycles on an XL graph = %+v", f)
+	}
+	if f := features["betweenness"]; !f.Available || f.Mode != string(analysis.BetweennessApproximate) {
+		t.Errorf("betweenness on an XL graph = %+v", f)
+	}
+
+	features = map[string]capabilityFeature{}
+	for _, f := range capabilityFeatures(analysis.FullAnalysisConfig(), 5000, "", "") {
+		features[f.Name] = f
+	}
+	if !features["cycles"].Available || !features["git_history"].Available {
+		t.Errorf("full analysis = %+v", features)
+	}
+}
+
+func TestNetworkFeature(t *testing.T) {
+	t.Setenv(offline.EnvVar, "")
+	if f := networkFeature(); !f.Available {
+		t.Errorf("online = %+v", f)
+	}
+	t.Setenv(offline.EnvVar, "1")
+	if f := networkFeature(); f.Available || !strings.Contains(f.Reason, "--offline") {
+		t.Errorf("offline = %+v", f)
+	}
+}
@@ -0,0 +1,571 @@
+package main
+
+import (
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/claims"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/correlation"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/drift"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/journal"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/labels"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/plugins"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/policy"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/recipe"
+)
+
+// Robot command payloads. Each is emitted as JSON by its --robot-* flag and
+// described by --robot-schema, so field changes here are API changes.
+
+// robotRecipesOutput is the --robot-recipes payload
+type robotRecipesOutput struct {
+	Recipes []recipe.RecipeSummary `json:"recipes"`
+}
+
+// robotLabelHealthOutput is the --robot-label-health payload
+type robotLabelHealthOutput struct {
+	GeneratedAt    string                       `json:"generated_at"`
+	DataHash       string                       `json:"data_hash"`
+	AnalysisConfig analysis.LabelHealthConfig   `json:"analysis_config"`
+	Results        analysis.LabelAnalysisResult `json:"results"`
+	UsageHints     []string                     `json:"usage_hints"`
+}
+
+// robotLabelFlowOutput is the --robot-label-flow payload
+type robotLabelFlowOutput struct {
+	GeneratedAt string                     `json:"generated_at"`
+	DataHash    string                     `json:"data_hash"`
+	Flow        analysis.CrossLabelFlow    `json:"flow"`
+	Config      analysis.LabelHealthConfig `json:"analysis_config"`
+	UsageHints  []string                   `json:"usage_hints"`
+}
+
+// AttentionOutput is the --robot-label-attention payload
+type AttentionOutput struct {
+	GeneratedAt string `json:"generated_at"`
+	DataHash    string `json:"data_hash"`
+	Limit       int    `json:"limit"`
+	TotalLabels int    `json:"total_labels"`
+	Labels      []struct {
+		Rank            int     `json:"rank"`
+		Label           string  `json:"label"`
+		AttentionScore  float64 `json:"attention_score"`
+		NormalizedScore float64 `json:"normalized_score"`
+		Reason          string  `json:"reason"`
+		OpenCount       int     `json:"open_count"`
+		BlockedCount    int     `json:"blocked_count"`
+		StaleCount      int     `json:"stale_count"`
+		PageRankSum     float64 `json:"pagerank_sum"`
+		VelocityFactor  float64 `json:"velocity_factor"`
+	} `json:"labels"`
+	UsageHints []string `json:"usage_hints"`
+}
+
+// robotAlertsOutput is the --robot-alerts payload
+type robotAlertsOutput struct {
+	GeneratedAt string        `json:"generated_at"`
+	DataHash    string        `json:"data_hash"`
+	Alerts      []drift.Alert `json:"alerts"`
+	Summary     struct {
+		Total    int `json:"total"`
+		Critical int `json:"critical"`
+		Warning  int `json:"warning"`
+		Info     int `json:"info"`
+	} `json:"summary"`
+	UsageHints []string `json:"usage_hints"`
+}
+
+// robotSuggestDepsOutput is the --robot-suggest-deps payload
+type robotSuggestDepsOutput struct {
+	GeneratedAt string                           `json:"generated_at"`
+	DataHash    string                           `json:"data_hash"`
+	AsOf        string                           `json:"as_of,omitempty"`
+	AsOfCommit  string                           `json:"as_of_commit,omitempty"`
+	FileHistory bool                             `json:"file_history"`
+	Suggestions []analysis.SemanticDepSuggestion `json:"suggestions"`
+	UsageHints  []string                         `json:"usage_hints"`
+}
+
+// robotSuggestLabelsOutput is the --robot-suggest-labels payload
+type robotSuggestLabelsOutput struct {
+	GeneratedAt string `json:"generated_at"`
+	DataHash    string `json:"data_hash"`
+	AsOf        string `json:"as_of,omitempty"`
+	AsOfCommit  string `json:"as_of_commit,omitempty"`
+	analysis.UnlabeledLabelReport
+	UsageHints []string `json:"usage_hints"`
+}
+
+// robotDuplicatesOutput is the --robot-duplicates payload
+type robotDuplicatesOutput struct {
+	GeneratedAt string                       `json:"generated_at"`
+	DataHash    string                       `json:"data_hash"`
+	AsOf        string                       `json:"as_of,omitempty"`
+	AsOfCommit  string                       `json:"as_of_commit,omitempty"`
+	Threshold   float64                      `json:"threshold"`
+	Pairs       []analysis.NearDuplicatePair `json:"pairs"`
+	UsageHints  []string                     `json:"usage_hints"`
+}
+
+// robotPoliciesOutput is the --robot-policies payload
+type robotPoliciesOutput struct {
+	GeneratedAt string          `json:"generated_at"`
+	DataHash    string          `json:"data_hash"`
+	AsOf        string          `json:"as_of,omitempty"`
+	AsOfCommit  string          `json:"as_of_commit,omitempty"`
+	DryRun      bool            `json:"dry_run"`
+	ConfigPath  string          `json:"config_path"`
+	Policies    []policy.Policy `json:"policies"`
+	Matches     []policy.Match  `json:"matches"`
+	Example     string          `json:"example_config,omitempty"`
+	UsageHints  []string        `json:"usage_hints"`
+}
+
+// robotDriftCheckOutput is the --robot-drift payload
+type robotDriftCheckOutput struct {
+	GeneratedAt string `json:"generated_at"`
+	HasDrift    bool   `json:"has_drift"`
+	ExitCode    int    `json:"exit_code"`
+	Summary     struct {
+		Critical int `json:"critical"`
+		Warning  int `json:"warning"`
+		Info     int `json:"info"`
+	} `json:"summary"`
+	Alerts   []drift.Alert `json:"alerts"`
+	Baseline struct {
+		CreatedAt string `json:"created_at"`
+		CommitSHA string `json:"commit_sha,omitempty"`
+	} `json:"baseline"`
+}
+
+// robotInsightsOutput is the --robot-insights payload
+type robotInsightsOutput struct {
+	GeneratedAt    string                  `json:"generated_at"`
+	DataHash       string                  `json:"data_hash"`
+	AsOf           string                  `json:"as_of,omitempty"`        // Historical snapshot ref
+	AsOfCommit     string                  `json:"as_of_commit,omitempty"` // Resolved commit SHA
+	AnalysisConfig analysis.AnalysisConfig `json:"analysis_config"`
+	Status         analysis.MetricStatus   `json:"status"`
+	LabelScope     string                  `json:"label_scope,omitempty"`   // bv-122: Label filter applied
+	LabelContext   *analysis.LabelHealth   `json:"label_context,omitempty"` // bv-122: Health context for scoped label
+	analysis.Insights
+	FullStats        interface{}                `json:"full_stats"`
+	Page             *robotPage                 `json:"page,omitempty"`              // The issues full_stats covers
+	TopWhatIfs       []analysis.WhatIfEntry     `json:"top_what_ifs,omitempty"`      // Issues with highest downstream impact (bv-83)
+	AdvancedInsights *analysis.AdvancedInsights `json:"advanced_insights,omitempty"` // bv-181: Canonical advanced features
+	Plugins          *plugins.Result            `json:"plugins,omitempty"`           // Scores and alerts from .bv/plugins
+	UsageHints       []string                   `json:"usage_hints"`                 // bv-84: Agent-friendly hints
+}
+
+// robotHistoryOutput is the --robot-history payload: the history report,
+// plus the page it holds when paginated
+type robotHistoryOutput struct {
+	*correlation.HistoryReport
+	Page *robotPage `json:"page,omitempty"`
+}
+
+// robotPlanOutput is the --robot-plan payload
+type robotPlanOutput struct {
+	GeneratedAt    string                  `json:"generated_at"`
+	DataHash       string                  `json:"data_hash"`
+	AsOf           string                  `json:"as_of,omitempty"`        // Historical snapshot ref
+	AsOfCommit     string                  `json:"as_of_commit,omitempty"` // Resolved commit SHA
+	AnalysisConfig analysis.AnalysisConfig `json:"analysis_config"`
+	Status         analysis.MetricStatus   `json:"status"`
+	LabelScope     string                  `json:"label_scope,omitempty"`   // bv-122: Label filter applied
+	LabelContext   *analysis.LabelHealth   `json:"label_context,omitempty"` // bv-122: Health context for scoped label
+	Plan           analysis.ExecutionPlan  `json:"plan"`
+	Claims         []claims.Claim          `json:"claims,omitempty"` // Active leases from bv claim; also marked on items
+	UsageHints     []string                `json:"usage_hints"`      // bv-84: Agent-friendly hints
+}
+
+// robotPriorityOutput is the --robot-priority payload
+type robotPriorityOutput struct {
+	GeneratedAt       string                                    `json:"generated_at"`
+	DataHash          string                                    `json:"data_hash"`
+	AsOf              string                                    `json:"as_of,omitempty"`        // Historical snapshot ref
+	AsOfCommit        string                                    `json:"as_of_commit,omitempty"` // Resolved commit SHA
+	AnalysisConfig    analysis.AnalysisConfig                   `json:"analysis_config"`
+	Status            analysis.MetricStatus                     `json:"status"`
+	LabelScope        string                                    `json:"label_scope,omitempty"`   // bv-122: Label filter applied
+	LabelContext      *analysis.LabelHealth                     `json:"label_context,omitempty"` // bv-122: Health context for scoped label
+	Recommendations   []analysis.EnhancedPriorityRecommendation `json:"recommendations"`
+	Feedback          *analysis.FeedbackJSON                    `json:"feedback,omitempty"` // Present once accept/ignore feedback exists
+	FieldDescriptions map[string]string                         `json:"field_descriptions"`
+	Filters           struct {
+		MinConfidence float64 `json:"min_confidence,omitempty"`
+		MaxResults    int     `json:"max_results"`
+		ByLabel       string  `json:"by_label,omitempty"`
+		ByAssignee    string  `json:"by_assignee,omitempty"`
+	} `json:"filters"`
+	Summary struct {
+		TotalIssues     int `json:"total_issues"`
+		Recommendations int `json:"recommendations"`
+		HighConfidence  int `json:"high_confidence"`
+	} `json:"summary"`
+	Usage []string `json:"usage_hints"` // bv-84: Agent-friendly hints
+}
+
+// robotNextEmptyOutput is the --robot-next payload when nothing is actionable
+type robotNextEmptyOutput struct {
+	GeneratedAt string `json:"generated_at"`
+	DataHash    string `json:"data_hash"`
+	AsOf        string `json:"as_of,omitempty"`
+	AsOfCommit  string `json:"as_of_commit,omitempty"`
+	Message     string `json:"message"`
+}
+
+// robotNextOutput is the --robot-next payload
+type robotNextOutput struct {
+	GeneratedAt string   `json:"generated_at"`
+	DataHash    string   `json:"data_hash"`
+	AsOf        string   `json:"as_of,omitempty"`
+	AsOfCommit  string   `json:"as_of_commit,omitempty"`
+	ID          string   `json:"id"`
+	Title       string   `json:"title"`
+	Score       float64  `json:"score"`
+	Reasons     []string `json:"reasons"`
+	Unblocks    int      `json:"unblocks"`
+	ClaimCmd    string   `json:"claim_command"`
+	ShowCmd     string   `json:"show_command"`
+	LeaseCmd    string   `json:"lease_command"`             // bv claim: lease it before the status changes
+	Skipped     []string `json:"skipped_claimed,omitempty"` // Higher picks claimed by someone else
+}
+
+// robotTriageOutput is the --robot-triage payload
+type robotTriageOutput struct {
+	GeneratedAt string                 `json:"generated_at"`
+	DataHash    string                 `json:"data_hash"`
+	AsOf        string                 `json:"as_of,omitempty"`        // Historical snapshot ref (e.g., HEAD~30)
+	AsOfCommit  string                 `json:"as_of_commit,omitempty"` // Resolved commit SHA
+	Triage      analysis.TriageResult  `json:"triage"`
+	Feedback    *analysis.FeedbackJSON `json:"feedback,omitempty"` // bv-90: Feedback loop state
+	Plugins     *plugins.Result        `json:"plugins,omitempty"`  // Plugins that ran; their scores and alerts are merged into triage
+	Claims      []claims.Claim         `json:"claims,omitempty"`   // Active leases from bv claim; also marked on recommendations
+	UsageHints  []string               `json:"usage_hints"`        // bv-84: Agent-friendly hints
+}
+
+// robotMyQueueOutput is the --robot-my-queue payload
+type robotMyQueueOutput struct {
+	GeneratedAt string           `json:"generated_at"`
+	DataHash    string           `json:"data_hash"`
+	AsOf        string           `json:"as_of,omitempty"`
+	AsOfCommit  string           `json:"as_of_commit,omitempty"`
+	Queue       analysis.MyQueue `json:"queue"`
+	UsageHints  []string         `json:"usage_hints"`
+}
+
+// robotReplayOutput is the --robot-replay payload
+type robotReplayOutput struct {
+	GeneratedAt string                 `json:"generated_at"`
+	From        string                 `json:"from,omitempty"` // As given; empty for the start of history
+	To          string                 `json:"to"`
+	Granularity string                 `json:"granularity"` // One frame per week
+	Frames      []analysis.ReplayFrame `json:"frames"`
+	Skipped     []string               `json:"skipped,omitempty"` // Revisions with no readable beads file
+	UsageHints  []string               `json:"usage_hints"`
+}
+
+// robotBisectOutput is the --robot-bisect payload
+type robotBisectOutput struct {
+	GeneratedAt string                 `json:"generated_at"`
+	From        string                 `json:"from,omitempty"` // As given; empty for the start of history
+	To          string                 `json:"to"`
+	Result      *analysis.BisectResult `json:"result"`
+	UsageHints  []string               `json:"usage_hints"`
+}
+
+// robotValidateOutput is the --robot-validate payload
+type robotValidateOutput struct {
+	GeneratedAt       string             `json:"generated_at"`
+	DataHash          string             `json:"data_hash"`
+	Valid             bool               `json:"valid"`
+	SourceFile        string             `json:"source_file,omitempty"`
+	LineErrors        []loader.LineError `json:"line_errors"`
+	Labels            labels.Report      `json:"labels"`
+	LabelsConfigError string             `json:"labels_config_error,omitempty"` // .bv/labels.yaml could not be used
+	UsageHints        []string           `json:"usage_hints"`
+}
+
+// robotJournalOutput is the --robot-journal payload
+type robotJournalOutput struct {
+	GeneratedAt string          `json:"generated_at"`
+	DataHash    string          `json:"data_hash"`
+	Days        int             `json:"days,omitempty"` // --journal-days window; 0 is the whole journal
+	Journal     journal.Summary `json:"journal"`
+	UsageHints  []string        `json:"usage_hints"`
+}
+
+// robotCapabilitiesOutput is the --robot-capabilities payload
+type robotCapabilitiesOutput struct {
+	GeneratedAt string                   `json:"generated_at"`
+	DataHash    string                   `json:"data_hash"`
+	Version     string                   `json:"version"` // bv version
+	Build       capabilityBuild          `json:"build"`
+	Graph       capabilityGraph          `json:"graph"`
+	Commands    []robotCapabilityCommand `json:"commands"`
+	Features    []capabilityFeature      `json:"features"`
+	Flags       []capabilityFlag         `json:"flags"`
+	UsageHints  []string                 `json:"usage_hints"`
+}
+
+// robotPartitionOutput is the --robot-partition payload
+type robotPartitionOutput struct {
+	GeneratedAt string                 `json:"generated_at"`
+	DataHash    string                 `json:"data_hash"`
+	AsOf        string                 `json:"as_of,omitempty"`
+	AsOfCommit  string                 `json:"as_of_commit,omitempty"`
+	Partition   analysis.WorkPartition `json:"partition"`
+	UsageHints  []string               `json:"usage_hints"`
+}
+
+// robotCorrelationFeedbackOutput is the --robot-confirm-correlation and
+// --robot-reject-correlation payload. Fields are in the alphabetical order
+// the earlier map-based output used.
+type robotCorrelationFeedbackOutput struct {
+	Bead     string  `json:"bead"`
+	By       string  `json:"by"`
+	Commit   string  `json:"commit"`
+	OrigConf float64 `json:"orig_conf"`
+	Reason   string  `json:"reason"`
+	Status   string  `json:"status"`
+}
+
+// HotspotsOutput is the --robot-file-hotspots payload
+type HotspotsOutput struct {
+	GeneratedAt time.Time                  `json:"generated_at"`
+	DataHash    string                     `json:"data_hash"`
+	Hotspots    []correlation.FileHotspot  `json:"hotspots"`
+	Stats       correlation.FileIndexStats `json:"stats"`
+}
+
+// FileBeadsOutput is the --robot-file-beads payload
+type FileBeadsOutput struct {
+	GeneratedAt time.Time                   `json:"generated_at"`
+	DataHash    string                      `json:"data_hash"`
+	FilePath    string                      `json:"file_path"`
+	TotalBeads  int                         `json:"total_beads"`
+	OpenBeads   []correlation.BeadReference `json:"open_beads"`
+	ClosedBeads []correlation.BeadReference `json:"closed_beads"`
+}
+
+// ImpactOutput is the --robot-impact payload
+type ImpactOutput struct {
+	GeneratedAt   time.Time                  `json:"generated_at"`
+	DataHash      string                     `json:"data_hash"`
+	Files         []string                   `json:"files"`
+	RiskLevel     string                     `json:"risk_level"`
+	RiskScore     float64                    `json:"risk_score"`
+	Summary       string                     `json:"summary"`
+	Warnings      []string                   `json:"warnings"`
+	AffectedBeads []correlation.AffectedBead `json:"affected_beads"`
+}
+
+// robotCodeMapOutput is the --robot-code-map payload
+type robotCodeMapOutput struct {
+	GeneratedAt string                     `json:"generated_at"`
+	DataHash    string                     `json:"data_hash"`
+	Path        string                     `json:"path,omitempty"`
+	Directories []correlation.CodeMapEntry `json:"directories"`
+	Files       []correlation.CodeMapEntry `json:"files"`
+	Stats       correlation.CodeMapStats   `json:"stats"`
+	UsageHints  []string                   `json:"usage_hints"`
+}
+
+// robotBusFactorOutput is the --robot-bus-factor payload
+type robotBusFactorOutput struct {
+	GeneratedAt string `json:"generated_at"`
+	DataHash    string `json:"data_hash"`
+	*correlation.OwnershipReport
+	UsageHints []string `json:"usage_hints"`
+}
+
+// robotGoalsOutput is the --robot-goals payload
+type robotGoalsOutput struct {
+	GeneratedAt time.Time `json:"generated_at"`
+	DataHash    string    `json:"data_hash"`
+	Agents      int       `json:"agents"`
+	Runs        int       `json:"runs"`
+	// Threshold is the drift goal_warning_probability; goals below it are at risk
+	Threshold  float64                 `json:"threshold"`
+	AtRisk     int                     `json:"at_risk"`
+	Goals      []analysis.GoalForecast `json:"goals"`
+	UsageHints []string                `json:"usage_hints"`
+}
+
+// robotWhyOutput is the --robot-why payload
+type robotWhyOutput struct {
+	GeneratedAt time.Time `json:"generated_at"`
+	DataHash    string    `json:"data_hash"`
+	*correlation.WhyResult
+	UsageHints []string `json:"usage_hints"`
+}
+
+// robotSuggestTrailersOutput is the --robot-suggest-trailers payload
+type robotSuggestTrailersOutput struct {
+	GeneratedAt  time.Time                       `json:"generated_at"`
+	DataHash     string                          `json:"data_hash"`
+	Source       string                          `json:"source"`
+	Files        []string                        `json:"files"`
+	Suggestions  []correlation.TrailerSuggestion `json:"suggestions"`
+	TrailerBlock string                          `json:"trailer_block"`
+	UsageHints   []string                        `json:"usage_hints"`
+}
+
+// RelationsOutput is the --robot-file-relations payload
+type RelationsOutput struct {
+	GeneratedAt  time.Time                   `json:"generated_at"`
+	DataHash     string                      `json:"data_hash"`
+	FilePath     string                      `json:"file_path"`
+	TotalCommits int                         `json:"total_commits"`
+	Threshold    float64                     `json:"threshold"`
+	RelatedFiles []correlation.CoChangeEntry `json:"related_files"`
+}
+
+// RelatedWorkOutput is the --robot-related payload
+type RelatedWorkOutput struct {
+	*correlation.RelatedWorkResult
+	DataHash string `json:"data_hash"`
+}
+
+// BlockerChainOutput is the --robot-blocker-chain payload
+type BlockerChainOutput struct {
+	GeneratedAt time.Time                    `json:"generated_at"`
+	DataHash    string                       `json:"data_hash"`
+	Result      *analysis.BlockerChainResult `json:"result"`
+}
+
+// robotSprintListOutput is the --robot-sprint-list payload
+type robotSprintListOutput struct {
+	GeneratedAt time.Time      `json:"generated_at"`
+	SprintCount int            `json:"sprint_count"`
+	Sprints     []model.Sprint `json:"sprints"`
+}
+
+// ForecastSummary totals the forecasts in a --robot-forecast payload
+type ForecastSummary struct {
+	TotalMinutes  int       `json:"total_minutes"`
+	TotalDays     float64   `json:"total_days"`
+	AvgConfidence float64   `json:"avg_confidence"`
+	EarliestETA   time.Time `json:"earliest_eta"`
+	LatestETA     time.Time `json:"latest_eta"`
+}
+
+// ForecastOutput is the --robot-forecast payload
+type ForecastOutput struct {
+	GeneratedAt   time.Time              `json:"generated_at"`
+	Agents        int                    `json:"agents"`
+	Filters       map[string]string      `json:"filters,omitempty"`
+	ForecastCount int                    `json:"forecast_count"`
+	Forecasts     []analysis.ETAEstimate `json:"forecasts"`
+	Summary       *ForecastSummary       `json:"summary,omitempty"`
+}
+
+// Bottleneck is one serializing issue in a --robot-capacity payload
+type Bottleneck struct {
+	ID          string   `json:"id"`
+	Title       string   `json:"title"`
+	BlocksCount int      `json:"blocks_count"`
+	Blocks      []string `json:"blocks,omitempty"`
+}
+
+// CapacityOutput is the --robot-capacity payload
+type CapacityOutput struct {
+	GeneratedAt       time.Time    `json:"generated_at"`
+	Agents            int          `json:"agents"`
+	Label             string       `json:"label,omitempty"`
+	OpenIssueCount    int          `json:"open_issue_count"`
+	TotalMinutes      int          `json:"total_minutes"`
+	TotalDays         float64      `json:"total_days"`
+	SerialMinutes     int          `json:"serial_minutes"`
+	ParallelMinutes   int          `json:"parallel_minutes"`
+	ParallelizablePct float64      `json:"parallelizable_pct"`
+	EstimatedDays     float64      `json:"estimated_days"`
+	CriticalPathLen   int          `json:"critical_path_length"`
+	CriticalPath      []string     `json:"critical_path,omitempty"`
+	ActionableCount   int          `json:"actionable_count"`
+	Actionable        []string     `json:"actionable,omitempty"`
+	Bottlenecks       []Bottleneck `json:"bottlenecks,omitempty"`
+	// Simulation is set with --agent-profiles
+	Simulation *analysis.CapacitySimulation `json:"simulation,omitempty"`
+}
+
+// robotQueuesOutput is the --robot-queues payload
+type robotQueuesOutput struct {
+	GeneratedAt string                 `json:"generated_at"`
+	DataHash    string                 `json:"data_hash"`
+	AsOf        string                 `json:"as_of,omitempty"`
+	AsOfCommit  string                 `json:"as_of_commit,omitempty"`
+	Queues      analysis.QueueAnalysis `json:"queues"`
+	UsageHints  []string               `json:"usage_hints"`
+}
+
+// robotReviewOutput is the --robot-review payload
+type robotReviewOutput struct {
+	GeneratedAt string `json:"generated_at"`
+	DataHash    string `json:"data_hash"`
+	// WarningDays and CriticalDays are the drift review_overdue thresholds
+	WarningDays  int                  `json:"warning_days"`
+	CriticalDays int                  `json:"critical_days"`
+	Overdue      int                  `json:"overdue"`
+	Review       analysis.ReviewStats `json:"review"`
+	UsageHints   []string             `json:"usage_hints"`
+}
+
+// robotEstimatesOutput is the --robot-estimates payload
+type robotEstimatesOutput struct {
+	GeneratedAt string                    `json:"generated_at"`
+	DataHash    string                    `json:"data_hash"`
+	AsOf        string                    `json:"as_of,omitempty"`
+	AsOfCommit  string                    `json:"as_of_commit,omitempty"`
+	Estimates   analysis.EstimateReport   `json:"estimates"`
+	Actuals     analysis.EstimateVariance `json:"actuals"`
+	UsageHints  []string                  `json:"usage_hints"`
+}
+
+// robotPRImpactOutput is the --robot-pr-impact payload
+type robotPRImpactOutput struct {
+	GeneratedAt      string             `json:"generated_at"`
+	Base             string             `json:"base"`
+	ResolvedRevision string             `json:"resolved_revision"`
+	FromDataHash     string             `json:"from_data_hash"`
+	ToDataHash       string             `json:"to_data_hash"`
+	Impact           *analysis.PRImpact `json:"impact"`
+	CommentMarkdown  string             `json:"comment_markdown"`
+	UsageHints       []string           `json:"usage_hints"`
+}
+
+// robotDiffOutput is the --robot-diff payload
+type robotDiffOutput struct {
+	GeneratedAt      string                 `json:"generated_at"`
+	ResolvedRevision string                 `json:"resolved_revision"`      // "from" commit SHA, or the file diffed from
+	DiffTo           string                 `json:"diff_to,omitempty"`      // "to" as given with --diff-to
+	ToRevision       string                 `json:"to_revision,omitempty"`  // "to" commit SHA, or the file diffed to
+	AsOf             string                 `json:"as_of,omitempty"`        // "to" snapshot ref (if --as-of used)
+	AsOfCommit       string                 `json:"as_of_commit,omitempty"` // Resolved commit SHA for "to"
+	FromDataHash     string                 `json:"from_data_hash"`
+	ToDataHash       string                 `json:"to_data_hash"`
+	Diff             *analysis.SnapshotDiff `json:"diff"`
+}
+
+// robotTreeOutput is the --robot-tree payload: an issue's upstream blocker
+// tree and downstream unblock tree
+type robotTreeOutput struct {
+	GeneratedAt string `json:"generated_at"`
+	DataHash    string `json:"data_hash"`
+	AsOf        string `json:"as_of,omitempty"`
+	AsOfCommit  string `json:"as_of_commit,omitempty"`
+	*analysis.DependencyTree
+}
+
+// robotLayersOutput is the --robot-layers payload
+type robotLayersOutput struct {
+	GeneratedAt string `json:"generated_at"`
+	DataHash    string `json:"data_hash"`
+	AsOf        string `json:"as_of,omitempty"`
+	AsOfCommit  string `json:"as_of_commit,omitempty"`
+	*analysis.LayerRoadmap
+	UsageHints []string `json:"usage_hints"`
+}
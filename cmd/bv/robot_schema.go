@@ -0,0 +1,183 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/correlation"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/export"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/jsonschema"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/provenance"
+)
+
+// robotSchemaVersion versions the robot payload shapes. Every robot JSON
+// object carries it as schema_version; bump it when a field is removed,
+// renamed or changes type (adding fields is compatible).
+const robotSchemaVersion = "1"
+
+// robotSchemaTypes maps each robot command (its --robot-* flag without the
+// prefix) to the Go type(s) it emits. A command with several types emits
+// exactly one of them.
+var robotSchemaTypes = map[string][]reflect.Type{
+	"alerts":              {reflect.TypeOf(robotAlertsOutput{})},
+	"bisect":              {reflect.TypeOf(robotBisectOutput{})},
+	"blocker-chain":       {reflect.TypeOf(BlockerChainOutput{})},
+	"burndown":            {reflect.TypeOf(BurndownOutput{})},
+	"bus-factor":          {reflect.TypeOf(robotBusFactorOutput{})},
+	"capabilities":        {reflect.TypeOf(robotCapabilitiesOutput{})},
+	"capacity":            {reflect.TypeOf(CapacityOutput{})},
+	"causality":           {reflect.TypeOf(correlation.CausalityResult{})},
+	"code-map":            {reflect.TypeOf(robotCodeMapOutput{})},
+	"confirm-correlation": {reflect.TypeOf(robotCorrelationFeedbackOutput{})},
+	"correlation-stats":   {reflect.TypeOf(correlation.FeedbackStats{})},
+	"diff":                {reflect.TypeOf(robotDiffOutput{})},
+	"drift":               {reflect.TypeOf(robotDriftCheckOutput{})},
+	"duplicates":          {reflect.TypeOf(robotDuplicatesOutput{})},
+	"estimates":           {reflect.TypeOf(robotEstimatesOutput{})},
+	"explain":             {reflect.TypeOf(robotExplainOutput{})},
+	"explain-correlation": {reflect.TypeOf(correlation.CorrelationExplanation{})},
+	"file-beads":          {reflect.TypeOf(FileBeadsOutput{})},
+	"file-hotspots":       {reflect.TypeOf(HotspotsOutput{})},
+	"file-relations":      {reflect.TypeOf(RelationsOutput{})},
+	"forecast":            {reflect.TypeOf(ForecastOutput{})},
+	"goals":               {reflect.TypeOf(robotGoalsOutput{})},
+	"graph":               {reflect.TypeOf(export.GraphExportResult{})},
+	"history":             {reflect.TypeOf(robotHistoryOutput{})},
+	"impact":              {reflect.TypeOf(ImpactOutput{})},
+	"impact-network":      {reflect.TypeOf(correlation.ImpactNetworkResult{})},
+	"insights":            {reflect.TypeOf(robotInsightsOutput{})},
+	"journal":             {reflect.TypeOf(robotJournalOutput{})},
+	"label-attention":     {reflect.TypeOf(AttentionOutput{})},
+	"label-flow":          {reflect.TypeOf(robotLabelFlowOutput{})},
+	"label-health":        {reflect.TypeOf(robotLabelHealthOutput{})},
+	"layers":              {reflect.TypeOf(robotLayersOutput{})},
+	"my-queue":            {reflect.TypeOf(robotMyQueueOutput{})},
+	"next":                {reflect.TypeOf(robotNextOutput{}), reflect.TypeOf(robotNextEmptyOutput{})},
+	"orphans":             {reflect.TypeOf(correlation.OrphanReport{})},
+	"partition":           {reflect.TypeOf(robotPartitionOutput{})},
+	"plan":                {reflect.TypeOf(robotPlanOutput{})},
+	"policies":            {reflect.TypeOf(robotPoliciesOutput{})},
+	"pr-impact":           {reflect.TypeOf(robotPRImpactOutput{})},
+	"priority":            {reflect.TypeOf(robotPriorityOutput{})},
+	"queues":              {reflect.TypeOf(robotQueuesOutput{})},
+	"recipes":             {reflect.TypeOf(robotRecipesOutput{})},
+	"reject-correlation":  {reflect.TypeOf(robotCorrelationFeedbackOutput{})},
+	"related":             {reflect.TypeOf(RelatedWorkOutput{})},
+	"replay":              {reflect.TypeOf(robotReplayOutput{})},
+	"review":              {reflect.TypeOf(robotReviewOutput{})},
+	"schema":              {reflect.TypeOf(robotSchemaListOutput{})},
+	"search":              {reflect.TypeOf(robotSearchOutput{})},
+	"sprint-list":         {reflect.TypeOf(robotSprintListOutput{})},
+	"sprint-show":         {reflect.TypeOf(model.Sprint{})},
+	"suggest":             {reflect.TypeOf(analysis.RobotSuggestOutput{})},
+	"summary":             {reflect.TypeOf(robotSummaryOutput{})},
+	"suggest-deps":        {reflect.TypeOf(robotSuggestDepsOutput{})},
+	"suggest-labels":      {reflect.TypeOf(robotSuggestLabelsOutput{})},
+	"suggest-trailers":    {reflect.TypeOf(robotSuggestTrailersOutput{})},
+	"tree":                {reflect.TypeOf(robotTreeOutput{})},
+	"triage":              {reflect.TypeOf(robotTriageOutput{})},
+	"triage-by-label":     {reflect.TypeOf(robotTriageOutput{})},
+	"triage-by-track":     {reflect.TypeOf(robotTriageOutput{})},
+	"validate":            {reflect.TypeOf(robotValidateOutput{})},
+	"why":                 {reflect.TypeOf(robotWhyOutput{})},
+}
+
+// robotSchemaListOutput is the --robot-schema payload without a command
+type robotSchemaListOutput struct {
+	Commands []string                      `json:"commands"`
+	Schemas  map[string]*jsonschema.Schema `json:"schemas"`
+}
+
+// robotSchemaCommands lists the commands with schemas, sorted
+func robotSchemaCommands() []string {
+	commands := make([]string, 0, len(robotSchemaTypes))
+	for command := range robotSchemaTypes {
+		commands = append(commands, command)
+	}
+	sort.Strings(commands)
+	return commands
+}
+
+// robotSchema returns the JSON Schema for a robot command's output. The
+// command may be given with or without its --robot- prefix.
+func robotSchema(command string) (*jsonschema.Schema, error) {
+	command = strings.TrimPrefix(strings.TrimPrefix(command, "--"), "robot-")
+	types, ok := robotSchemaTypes[command]
+	if !ok {
+		return nil, fmt.Errorf("unknown robot command %q (known: %s)", command, strings.Join(robotSchemaCommands(), ", "))
+	}
+
+	var s *jsonschema.Schema
+	if len(types) == 1 {
+		s = jsonschema.For(types[0])
+		withSchemaVersion(s)
+	} else {
+		s = &jsonschema.Schema{Schema: jsonschema.Draft, Defs: make(map[string]*jsonschema.Schema)}
+		for _, t := range types {
+			variant := jsonschema.For(t)
+			for name, def := range variant.Defs {
+				s.Defs[name] = def
+			}
+			variant.Schema, variant.Defs = "", nil
+			withSchemaVersion(variant)
+			s.OneOf = append(s.OneOf, variant)
+		}
+		if len(s.Defs) == 0 {
+			s.Defs = nil
+		}
+	}
+
+	s.ID = "bv://robot/" + command
+	s.Title = "bv --robot-" + command
+	if f := flag.Lookup("robot-" + command); f != nil {
+		s.Description = f.Usage
+	}
+	return s, nil
+}
+
+// withSchemaVersion adds the schema_version field every robot object
+// carries, and the provenance and truncation fields added on request (see
+// robotEncoder), to an object schema
+func withSchemaVersion(s *jsonschema.Schema) {
+	if s.Properties == nil {
+		return
+	}
+	props := &jsonschema.Properties{}
+	props.Set("schema_version", &jsonschema.Schema{Type: "string", Const: robotSchemaVersion})
+	for _, name := range s.Properties.Names {
+		props.Set(name, s.Properties.Get(name))
+	}
+	prov := jsonschema.For(reflect.TypeOf(provenance.Provenance{}))
+	prov.Schema = ""
+	props.Set("provenance", prov)
+	trunc := jsonschema.For(reflect.TypeOf(truncationManifest{}))
+	trunc.Schema = ""
+	props.Set("truncation", trunc)
+	s.Properties = props
+	s.Required = append([]string{"schema_version"}, s.Required...)
+}
+
+// robotSchemaOutput is what --robot-schema prints: the named command's
+// schema, or every schema when command is empty
+func robotSchemaOutput(command string) (any, error) {
+	if command != "" {
+		return robotSchema(command)
+	}
+	out := robotSchemaListOutput{
+		Commands: robotSchemaCommands(),
+		Schemas:  make(map[string]*jsonschema.Schema, len(robotSchemaTypes)),
+	}
+	for _, c := range out.Commands {
+		s, err := robotSchema(c)
+		if err != nil {
+			return nil, err
+		}
+		out.Schemas[c] = s
+	}
+	return out, nil
+}
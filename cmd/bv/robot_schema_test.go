@@ -0,0 +1,170 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// robotOptionFlags are --robot-* flags that tune other commands rather than
+// emit a payload of their own
+var robotOptionFlags = map[string]bool{
+	"help":           true,
+	"min-confidence": true,
+	"max-results":    true,
+	"by-label":       true,
+	"by-assignee":    true,
+}
+
+// TestRobotSchemaCoversRobotFlags keeps the schema registry in step with the
+// flags declared in main
+func TestRobotSchemaCoversRobotFlags(t *testing.T) {
+	src, err := os.ReadFile("main.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	flags := make(map[string]bool)
+	for _, m := range regexp.MustCompile(`flag\.\w+\("robot-([a-z-]+)"`).FindAllStringSubmatch(string(src), -1) {
+		flags[m[1]] = true
+		if !robotOptionFlags[m[1]] && robotSchemaTypes[m[1]] == nil {
+			t.Errorf("--robot-%s has no entry in robotSchemaTypes", m[1])
+		}
+	}
+	for command := range robotSchemaTypes {
+		if !flags[command] {
+			t.Errorf("robotSchemaTypes has %q but main declares no --robot-%s", command, command)
+		}
+	}
+}
+
+func TestRobotSchema(t *testing.T) {
+	s, err := robotSchema("--robot-triage")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.ID != "bv://robot/triage" || s.Properties.Names[0] != "schema_version" || s.Required[0] != "schema_version" {
+		t.Errorf("triage schema = id %q, properties %v, required %v", s.ID, s.Properties.Names, s.Required)
+	}
+	if s.Properties.Get("triage") == nil || s.Defs["analysis.TriageResult"] == nil {
+		t.Errorf("triage schema should reference analysis.TriageResult; defs = %v", s.Defs)
+	}
+
+	next, err := robotSchema("next")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(next.OneOf) != 2 {
+		t.Errorf("next should offer the pick and the empty shapes, got %d", len(next.OneOf))
+	}
+
+	if _, err := robotSchema("nope"); err == nil || !strings.Contains(err.Error(), "triage") {
+		t.Errorf("unknown command error = %v, want the known commands listed", err)
+	}
+
+	all, err := robotSchemaOutput("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	list := all.(robotSchemaListOutput)
+	if len(list.Commands) != len(robotSchemaTypes) || len(list.Schemas) != len(robotSchemaTypes) {
+		t.Errorf("listing has %d commands, %d schemas; want %d", len(list.Commands), len(list.Schemas), len(robotSchemaTypes))
+	}
+}
+
+func TestWithSchemaVersionField(t *testing.T) {
+	for in, want := range map[string]string{
+		`{"a":1}`: `{"schema_version":"` + robotSchemaVersion + `","a":1}`,
+		`{}`:      `{"schema_version":"` + robotSchemaVersion + `"}`,
+		`[1]`:     `[1]`,
+		`null`:    `null`,
+	} {
+		if got := string(withSchemaVersionField([]byte(in))); got != want {
+			t.Errorf("withSchemaVersionField(%s) = %s, want %s", in, got, want)
+		}
+	}
+}
+
+// TestRobotOutputsMatchSchemas runs robot commands on a small project and
+// validates each payload against its published schema
+func TestRobotOutputsMatchSchemas(t *testing.T) {
+	dir := t.TempDir()
+	beadsDir := filepath.Join(dir, ".beads")
+	if err := os.MkdirAll(beadsDir, 0o755); err != nil {
+		t.Fatalf("mkdir beads: %v", err)
+	}
+	beads := `{"id":"TEST-1","title":"Auth login api","status":"open","priority":1,"issue_type":"task","labels":["api"],"assignee":"ana","estimated_minutes":60,"created_at":"2025-01-01T00:00:00Z","updated_at":"2025-01-02T00:00:00Z"}
+{"id":"TEST-2","title":"Auth login database","status":"in_progress","priority":2,"issue_type":"task","labels":["database"],"assignee":"ana","created_at":"2025-01-01T00:00:00Z","updated_at":"2025-01-03T00:00:00Z","dependencies":[{"issue_id":"TEST-2","depends_on_id":"TEST-1","type":"blocks"}]}
+{"id":"TEST-3","title":"Auth login cache","status":"blocked","priority":2,"issue_type":"bug","labels":["api"],"created_at":"2025-01-01T00:00:00Z","updated_at":"2025-01-03T00:00:00Z","dependencies":[{"issue_id":"TEST-3","depends_on_id":"TEST-2","type":"blocks"}]}
+{"id":"TEST-4","title":"Done","status":"closed","priority":3,"issue_type":"task","labels":["api"],"created_at":"2025-01-01T00:00:00Z","updated_at":"2025-01-04T00:00:00Z","closed_at":"2025-01-04T00:00:00Z"}
+`
+	if err := os.WriteFile(filepath.Join(beadsDir, "beads.jsonl"), []byte(beads), 0o644); err != nil {
+		t.Fatalf("write beads: %v", err)
+	}
+
+	exe := buildTestBinary(t)
+	for _, args := range [][]string{
+		{"--robot-triage"},
+		{"--robot-triage-by-track"},
+		{"--robot-next"},
+		{"--robot-summary"},
+		{"--robot-insights"},
+		{"--robot-plan"},
+		{"--robot-priority"},
+		{"--robot-my-queue", "--assignee", "ana"},
+		{"--robot-partition", "--agents", "2"},
+		{"--robot-journal"},
+		{"--robot-validate"},
+		{"--robot-suggest"},
+		{"--robot-suggest-deps"},
+		{"--robot-suggest-labels"},
+		{"--robot-duplicates"},
+		{"--robot-label-health"},
+		{"--robot-label-flow"},
+		{"--robot-label-attention"},
+		{"--robot-alerts"},
+		{"--robot-recipes"},
+		{"--robot-graph"},
+		{"--robot-blocker-chain", "TEST-3"},
+		{"--robot-explain", "TEST-3"},
+		{"--robot-tree", "TEST-3"},
+		{"--robot-layers"},
+		{"--robot-sprint-list"},
+		{"--robot-forecast", "all"},
+		{"--robot-capacity"},
+		{"--robot-goals"},
+		{"--robot-estimates"},
+		{"--robot-queues"},
+		{"--robot-review"},
+		{"--robot-schema"},
+		{"--robot-capabilities"},
+	} {
+		command := strings.TrimPrefix(args[0], "--robot-")
+		t.Run(command, func(t *testing.T) {
+			cmd := exec.Command(exe, args...)
+			cmd.Dir = dir
+			out, err := cmd.Output()
+			if err != nil {
+				t.Fatalf("%v failed: %v, out=%s", args, err, out)
+			}
+			if !bytes.HasPrefix(out, []byte("{\n  \"schema_version\": \""+robotSchemaVersion+"\",")) {
+				t.Errorf("output should lead with schema_version:\n%.200s", out)
+			}
+			var payload any
+			if err := json.Unmarshal(out, &payload); err != nil {
+				t.Fatalf("bad JSON: %v\n%s", err, out)
+			}
+			schema, err := robotSchema(command)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := schema.Validate(payload); err != nil {
+				t.Errorf("output does not match its schema: %v", err)
+			}
+		})
+	}
+}
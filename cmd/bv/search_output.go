@@ -1,13 +1,14 @@
 package main
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
 	"io"
 	"regexp"
 	"sort"
 	"strings"
 
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/search"
 )
 
@@ -38,7 +39,7 @@ type robotSearchOutput struct {
 }
 
 func writeRobotSearchOutput(w io.Writer, out robotSearchOutput) error {
-	enc := json.NewEncoder(w)
+	enc := newRobotEncoder(w)
 	enc.SetIndent("", "  ")
 	return enc.Encode(out)
 }
@@ -148,3 +149,35 @@ func promoteExactHybridResult(query string, results []search.HybridScore) []sear
 	}
 	return results
 }
+
+// issueVectors embeds issues through the on-disk semantic index, syncing and
+// saving it the same way --search does, and returns each issue's vector.
+func issueVectors(ctx context.Context, projectDir string, issues []model.Issue) (map[string][]float32, error) {
+	embedCfg := search.EmbeddingConfigFromEnv()
+	embedder, err := search.NewEmbedderFromConfig(embedCfg)
+	if err != nil {
+		return nil, err
+	}
+	indexPath := search.DefaultIndexPath(projectDir, embedCfg)
+	idx, loaded, err := search.LoadOrNewVectorIndex(indexPath, embedder.Dim())
+	if err != nil {
+		return nil, err
+	}
+	stats, err := search.SyncVectorIndex(ctx, idx, embedder, search.DocumentsFromIssues(issues), 64)
+	if err != nil {
+		return nil, fmt.Errorf("building semantic index: %w", err)
+	}
+	if !loaded || stats.Changed() {
+		if err := idx.Save(indexPath); err != nil {
+			return nil, fmt.Errorf("saving semantic index: %w", err)
+		}
+	}
+
+	vectors := make(map[string][]float32, len(issues))
+	for _, issue := range issues {
+		if entry, ok := idx.Get(issue.ID); ok {
+			vectors[issue.ID] = entry.Vector
+		}
+	}
+	return vectors, nil
+}
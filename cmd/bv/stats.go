@@ -0,0 +1,208 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/ui"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/usage"
+)
+
+const statsUsage = "Usage: bv stats [--json] [--top N] | enable | disable"
+
+// statsReport is the --json output of bv stats
+type statsReport struct {
+	Enabled bool          `json:"enabled"`
+	Path    string        `json:"path"`
+	Summary usage.Summary `json:"summary"`
+	Unused  statsUnused   `json:"unused"`
+}
+
+// statsUnused lists what the log never saw
+type statsUnused struct {
+	Commands []string `json:"commands"`
+	Views    []string `json:"views"`
+	Actions  []string `json:"actions"`
+}
+
+// statsSections are the summary sections in print order, with the columns
+// their durations mean
+var statsSections = []struct {
+	kind, title, timing string
+}{
+	{usage.KindCommand, "Commands", ""},
+	{usage.KindFlag, "Flags", ""},
+	{usage.KindView, "Views (visits, avg and longest stay)", "time"},
+	{usage.KindAction, "Key actions", ""},
+	{usage.KindPhase, "Phases (runs, avg, max)", "ms"},
+	{usage.KindRender, "Rendering (frames, avg, max)", "ms"},
+}
+
+// runStats implements `bv stats`: turn the local usage log on or off, or
+// summarize it. It returns the process exit code.
+func runStats(args []string, stdout, stderr io.Writer) int {
+	path := usage.Path()
+	if len(args) > 0 {
+		switch args[0] {
+		case "enable":
+			if err := usage.Enable(path); err != nil {
+				fmt.Fprintf(stderr, "Error: %v\n", err)
+				return exitError
+			}
+			fmt.Fprintf(stdout, "Usage log on: %s\nIt stays on this machine; `bv stats disable` deletes it.\n", path)
+			return exitOK
+		case "disable":
+			if err := usage.Disable(path); err != nil {
+				fmt.Fprintf(stderr, "Error: %v\n", err)
+				return exitError
+			}
+			fmt.Fprintln(stdout, "Usage log off and deleted")
+			return exitOK
+		}
+	}
+
+	fs := flag.NewFlagSet("stats", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	jsonOut := fs.Bool("json", false, "Output the summary as JSON")
+	top := fs.Int("top", 10, "Rows per section (0 for all)")
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, statsUsage)
+		fmt.Fprintln(stderr, "\nSummarize the local usage log, which never leaves this machine.")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return exitOK
+		}
+		return exitUsage
+	}
+	if fs.NArg() > 0 {
+		fs.Usage()
+		return exitUsage
+	}
+
+	events, err := usage.Load(path)
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return exitError
+	}
+	summary := usage.Summarize(events)
+	report := statsReport{
+		Enabled: usage.Enabled(path),
+		Path:    path,
+		Summary: summary,
+		Unused: statsUnused{
+			Commands: summary.Unused(usage.KindCommand, completionSubcommands()),
+			Views:    summary.Unused(usage.KindView, ui.UsageViews()),
+			Actions:  summary.Unused(usage.KindAction, keyActionIDs()),
+		},
+	}
+
+	if *jsonOut {
+		enc := newRobotEncoder(stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(report); err != nil {
+			fmt.Fprintf(stderr, "Error encoding usage stats: %v\n", err)
+			return exitError
+		}
+		return exitOK
+	}
+	if !report.Enabled {
+		fmt.Fprintln(stdout, "The usage log is off. `bv stats enable` records which commands, views and")
+		fmt.Fprintln(stdout, "key actions you use and how long loading, analysis and rendering take,")
+		fmt.Fprintln(stdout, "in a local file that is never sent anywhere.")
+		return exitOK
+	}
+	writeStats(stdout, report, *top)
+	return exitOK
+}
+
+func writeStats(w io.Writer, report statsReport, top int) {
+	s := report.Summary
+	if s.Events == 0 {
+		fmt.Fprintf(w, "Usage log: %s (empty so far)\n", report.Path)
+		return
+	}
+	fmt.Fprintf(w, "Usage log: %s (%d events since %s)\n", report.Path, s.Events, s.Since.Local().Format("2006-01-02"))
+	for _, sec := range statsSections {
+		stats := s.Kinds[sec.kind]
+		if len(stats) == 0 {
+			continue
+		}
+		fmt.Fprintf(w, "\n%s\n", sec.title)
+		width := 0
+		for _, st := range stats {
+			width = max(width, len(st.Name))
+		}
+		for i, st := range stats {
+			if top > 0 && i == top {
+				fmt.Fprintf(w, "  … %d more\n", len(stats)-top)
+				break
+			}
+			switch sec.timing {
+			case "ms":
+				fmt.Fprintf(w, "  %-*s %6d  %9s  %9s\n", width, st.Name, st.Count, formatMillis(st.AvgMS()), formatMillis(st.MaxMS))
+			case "time":
+				fmt.Fprintf(w, "  %-*s %6d  %9s  %9s\n", width, st.Name, st.Count, formatStay(st.AvgMS()), formatStay(st.MaxMS))
+			default:
+				fmt.Fprintf(w, "  %-*s %6d\n", width, st.Name, st.Count)
+			}
+		}
+	}
+
+	fmt.Fprintln(w, "\nNever used")
+	for _, u := range []struct {
+		label string
+		names []string
+	}{
+		{"commands", report.Unused.Commands},
+		{"views", report.Unused.Views},
+		{"key actions", report.Unused.Actions},
+	} {
+		if len(u.names) > 0 {
+			fmt.Fprintf(w, "  %s: %s\n", u.label, strings.Join(u.names, ", "))
+		}
+	}
+}
+
+// keyActionIDs lists the TUI's remappable actions
+func keyActionIDs() []string {
+	actions := ui.KeyActions()
+	ids := make([]string, len(actions))
+	for i, a := range actions {
+		ids[i] = a.ID
+	}
+	return ids
+}
+
+func formatMillis(ms float64) string {
+	if ms >= 1000 {
+		return fmt.Sprintf("%.2fs", ms/1000)
+	}
+	return fmt.Sprintf("%.1fms", ms)
+}
+
+func formatStay(ms float64) string {
+	return time.Duration(ms * float64(time.Millisecond)).Round(time.Second).String()
+}
+
+// recordUsage logs a run of command, and the names of the flags set in fs,
+// to the usage log if it is on. Flag values are never recorded.
+func recordUsage(command string, fs *flag.FlagSet) {
+	path := usage.Path()
+	if !usage.Enabled(path) {
+		return
+	}
+	now := time.Now()
+	events := []usage.Event{{At: now, Kind: usage.KindCommand, Name: command}}
+	if fs != nil {
+		fs.Visit(func(f *flag.Flag) {
+			events = append(events, usage.Event{At: now, Kind: usage.KindFlag, Name: f.Name})
+		})
+	}
+	_ = usage.Record(path, events...)
+}
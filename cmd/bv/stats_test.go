@@ -0,0 +1,71 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/usage"
+)
+
+func TestRunStats(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	var stdout, stderr bytes.Buffer
+
+	if code := runStats(nil, &stdout, &stderr); code != exitOK || !strings.Contains(stdout.String(), "usage log is off") {
+		t.Fatalf("disabled: exit %d, stdout %q", code, stdout.String())
+	}
+	recordUsage("triage", nil)
+	if usage.Enabled(usage.Path()) {
+		t.Fatal("recordUsage created the log")
+	}
+
+	stdout.Reset()
+	if code := runStats([]string{"enable"}, &stdout, &stderr); code != exitOK || !usage.Enabled(usage.Path()) {
+		t.Fatalf("enable: exit %d, stderr %q", code, stderr.String())
+	}
+	fs := flag.NewFlagSet("bv", flag.ContinueOnError)
+	label := fs.String("label", "", "")
+	fs.Bool("robot-triage", false, "")
+	if err := fs.Parse([]string{"--label", "secret-label", "--robot-triage"}); err != nil || *label == "" {
+		t.Fatal(err)
+	}
+	recordUsage("triage", fs)
+	recordUsage("triage", nil)
+	_ = usage.Record(usage.Path(), usage.Event{Kind: usage.KindPhase, Name: "loader.load_issues", MS: 1500})
+
+	stdout.Reset()
+	if code := runStats(nil, &stdout, &stderr); code != exitOK {
+		t.Fatalf("summary: exit %d, stderr %q", code, stderr.String())
+	}
+	out := stdout.String()
+	for _, want := range []string{"Commands", "triage", "label", "loader.load_issues", "1.50s", "Never used", "view.board"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("summary missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "secret-label") {
+		t.Errorf("flag value in the summary:\n%s", out)
+	}
+
+	stdout.Reset()
+	if code := runStats([]string{"--json"}, &stdout, &stderr); code != exitOK {
+		t.Fatalf("json: exit %d", code)
+	}
+	var report statsReport
+	if err := json.Unmarshal(stdout.Bytes(), &report); err != nil {
+		t.Fatalf("json: %v\n%s", err, stdout.String())
+	}
+	if !report.Enabled || report.Summary.Kinds[usage.KindCommand][0].Count != 2 {
+		t.Errorf("report = %+v", report)
+	}
+
+	if code := runStats([]string{"disable"}, &stdout, &stderr); code != exitOK || usage.Enabled(usage.Path()) {
+		t.Errorf("disable: exit %d", code)
+	}
+	if code := runStats([]string{"bogus"}, &stdout, &stderr); code != exitUsage {
+		t.Errorf("unknown argument: exit %d", code)
+	}
+}
@@ -0,0 +1,251 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/baseline"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/claims"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/drift"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/version"
+	"github.com/mattn/go-runewidth"
+)
+
+// The status line is read by tmux every few seconds and by shell prompts on
+// every command, so it is served from .bv/statusline.json while the files
+// it was computed from are unchanged. Only a miss pays for loading and
+// analyzing the graph.
+
+// statuslineCacheFile is the cache's name inside .bv.
+const statuslineCacheFile = "statusline.json"
+
+// statuslineTTL bounds how long a cached status line is trusted with no
+// input changed: staleness and claim expiry move with the clock.
+const statuslineTTL = 5 * time.Minute
+
+// statuslineFormats are the values of --statusline-format.
+var statuslineFormats = []string{"plain", "ansi", "tmux"}
+
+// statuslineData is what the status line shows, as cached.
+type statuslineData struct {
+	Key        string    `json:"key"`
+	ComputedAt time.Time `json:"computed_at"`
+	Open       int       `json:"open"`
+	Blocked    int       `json:"blocked"`
+	Actionable int       `json:"actionable"`
+	Drift      string    `json:"drift,omitempty"` // ok, warning or critical; "" without a baseline
+	TopID      string    `json:"top_id,omitempty"`
+	TopTitle   string    `json:"top_title,omitempty"`
+}
+
+// runStatusline prints the status line for the project. Outside a beads
+// project it prints nothing, so a prompt can call it anywhere.
+func runStatusline(beadsDir, projectDir, format string, width int, now time.Time, stdout io.Writer) error {
+	beadsPath, err := loader.FindJSONLPath(beadsDir)
+	if err != nil {
+		return nil
+	}
+	key := statuslineKey(beadsPath, baseline.DefaultPath(projectDir), drift.ConfigPath(projectDir), claims.Path(projectDir))
+	cachePath := filepath.Join(projectDir, ".bv", statuslineCacheFile)
+
+	data, ok := readStatuslineCache(cachePath, key, now)
+	if !ok {
+		if data, err = computeStatusline(beadsPath, projectDir, now); err != nil {
+			return err
+		}
+		data.Key, data.ComputedAt = key, now
+		writeStatuslineCache(cachePath, data)
+	}
+	fmt.Fprintln(stdout, renderStatusline(data, format, width))
+	return nil
+}
+
+// statuslineKey fingerprints the status line's inputs by size and mtime.
+func statuslineKey(paths ...string) string {
+	parts := []string{version.Version}
+	for _, p := range paths {
+		if info, err := os.Stat(p); err == nil {
+			parts = append(parts, fmt.Sprintf("%s:%d:%d", p, info.Size(), info.ModTime().UnixNano()))
+		} else {
+			parts = append(parts, p+":-")
+		}
+	}
+	return strings.Join(parts, "|")
+}
+
+func readStatuslineCache(path, key string, now time.Time) (statuslineData, bool) {
+	var data statuslineData
+	raw, err := os.ReadFile(path)
+	if err != nil || json.Unmarshal(raw, &data) != nil {
+		return data, false
+	}
+	fresh := data.Key == key && now.Sub(data.ComputedAt) >= 0 && now.Sub(data.ComputedAt) < statuslineTTL
+	return data, fresh
+}
+
+// writeStatuslineCache saves the cache, best effort: a read-only project
+// just recomputes every time.
+func writeStatuslineCache(path string, data statuslineData) {
+	raw, err := json.Marshal(data)
+	if err != nil || os.MkdirAll(filepath.Dir(path), 0o755) != nil {
+		return
+	}
+	tmp := path + ".tmp"
+	if os.WriteFile(tmp, raw, 0o644) == nil {
+		_ = os.Rename(tmp, path)
+	}
+}
+
+// computeStatusline loads and analyzes the project: counts, drift against
+// the saved baseline, and the top pick nobody else has claimed.
+func computeStatusline(beadsPath, projectDir string, now time.Time) (statuslineData, error) {
+	issues, err := loader.LoadIssuesFromFile(beadsPath)
+	if err != nil {
+		return statuslineData{}, err
+	}
+	current := buildCurrentBaseline(issues, false)
+	data := statuslineData{
+		Open:       current.Stats.OpenCount + current.Stats.BlockedCount,
+		Actionable: current.Stats.ActionableCount,
+	}
+	data.Blocked = max(0, data.Open-data.Actionable)
+
+	if baselinePath := baseline.DefaultPath(projectDir); baseline.Exists(baselinePath) {
+		if bl, err := baseline.Load(baselinePath); err == nil {
+			cfg, err := drift.LoadConfig(projectDir)
+			if err != nil {
+				cfg = drift.DefaultConfig()
+			}
+			result := drift.NewCalculator(bl, current, cfg).Calculate()
+			switch {
+			case result.CriticalCount > 0:
+				data.Drift = "critical"
+			case result.WarningCount > 0:
+				data.Drift = "warning"
+			default:
+				data.Drift = "ok"
+			}
+		}
+	}
+
+	triage := analysis.ComputeTriageWithOptionsAndTime(issues, analysis.TriageOptions{WaitForPhase2: true}, now)
+	if top, _, ok := nextUnclaimedPick(triage, loadClaims(projectDir), analysis.CurrentActor(), now); ok {
+		data.TopID, data.TopTitle = top.ID, top.Title
+	}
+	return data, nil
+}
+
+// statuslineSegment is one item of the status line, in a full and a short
+// form. Under a tight budget the lowest keep is dropped first.
+type statuslineSegment struct {
+	full, short string
+	color       string // tmux color name; "" for the default
+	keep        int
+}
+
+// renderStatusline fits the status line into width cells (0 = no limit):
+// full forms with the top pick's title, then without it, then short forms,
+// then dropping the least important segments, and at last truncating.
+func renderStatusline(data statuslineData, format string, width int) string {
+	segments := []statuslineSegment{
+		{full: fmt.Sprintf("%d open", data.Open), short: fmt.Sprintf("%do", data.Open), keep: 1},
+		{full: fmt.Sprintf("%d blocked", data.Blocked), short: fmt.Sprintf("%db", data.Blocked), keep: 2},
+		{full: fmt.Sprintf("%d ready", data.Actionable), short: fmt.Sprintf("%dr", data.Actionable), keep: 4},
+	}
+	if data.Blocked > 0 {
+		segments[1].color = "red"
+	}
+	if data.Actionable > 0 {
+		segments[2].color = "green"
+	}
+	switch data.Drift {
+	case "critical":
+		segments = append(segments, statuslineSegment{full: "drift critical", short: "drift!", color: "red", keep: 3})
+	case "warning":
+		segments = append(segments, statuslineSegment{full: "drift warning", short: "drift", color: "yellow", keep: 3})
+	}
+	if data.TopID != "" {
+		segments = append(segments, statuslineSegment{full: "next " + data.TopID, short: data.TopID, color: "cyan", keep: 5})
+	}
+
+	shortForms := func() []statuslineSegment {
+		out := make([]statuslineSegment, len(segments))
+		for i, s := range segments {
+			s.full = s.short
+			out[i] = s
+		}
+		return out
+	}
+	plainWidth := func(segs []statuslineSegment, sep string) int {
+		w := 0
+		for i, s := range segs {
+			if i > 0 {
+				w += len(sep)
+			}
+			w += runewidth.StringWidth(s.full)
+		}
+		return w
+	}
+	fits := func(segs []statuslineSegment, sep string) bool {
+		return width <= 0 || plainWidth(segs, sep) <= width
+	}
+
+	// Full forms, with as much of the top pick's title as fits
+	if data.TopID != "" && data.TopTitle != "" {
+		withTitle := append([]statuslineSegment(nil), segments...)
+		last := &withTitle[len(withTitle)-1]
+		room := width - plainWidth(withTitle, ", ") - 1
+		switch {
+		case width <= 0:
+			last.full += " " + data.TopTitle
+		case room >= runewidth.StringWidth(data.TopTitle):
+			last.full += " " + data.TopTitle
+		case room >= 8:
+			last.full += " " + runewidth.Truncate(data.TopTitle, room, "...")
+		}
+		if fits(withTitle, ", ") {
+			return formatStatusline(withTitle, ", ", format)
+		}
+	}
+	if fits(segments, ", ") {
+		return formatStatusline(segments, ", ", format)
+	}
+	short := shortForms()
+	for len(short) > 1 && !fits(short, " ") {
+		drop := 0
+		for i, s := range short {
+			if s.keep < short[drop].keep {
+				drop = i
+			}
+		}
+		short = append(short[:drop], short[drop+1:]...)
+	}
+	if !fits(short, " ") {
+		short[0].full = runewidth.Truncate(short[0].full, width, "")
+	}
+	return formatStatusline(short, " ", format)
+}
+
+// formatStatusline joins the segments, colored for the output format.
+func formatStatusline(segs []statuslineSegment, sep, format string) string {
+	ansi := map[string]string{"red": "31", "green": "32", "yellow": "33", "cyan": "36"}
+	parts := make([]string, len(segs))
+	for i, s := range segs {
+		switch {
+		case s.color == "" || format == "plain":
+			parts[i] = s.full
+		case format == "tmux":
+			parts[i] = "#[fg=" + s.color + "]" + s.full + "#[default]"
+		default:
+			parts[i] = "\x1b[" + ansi[s.color] + "m" + s.full + "\x1b[0m"
+		}
+	}
+	return strings.Join(parts, sep)
+}
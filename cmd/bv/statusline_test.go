@@ -0,0 +1,88 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRenderStatusline(t *testing.T) {
+	data := statuslineData{Open: 12, Blocked: 3, Actionable: 9, Drift: "warning", TopID: "bv-42", TopTitle: "Fix the parser"}
+	tests := []struct {
+		width  int
+		format string
+		want   string
+	}{
+		{0, "plain", "12 open, 3 blocked, 9 ready, drift warning, next bv-42 Fix the parser"},
+		{64, "plain", "12 open, 3 blocked, 9 ready, drift warning, next bv-42 Fix th..."},
+		{58, "plain", "12 open, 3 blocked, 9 ready, drift warning, next bv-42"},
+		{50, "plain", "12o 3b 9r drift bv-42"},
+		{30, "plain", "12o 3b 9r drift bv-42"},
+		{14, "plain", "9r drift bv-42"},
+		{4, "plain", "bv-4"},
+		{30, "tmux", "12o #[fg=red]3b#[default] #[fg=green]9r#[default] #[fg=yellow]drift#[default] #[fg=cyan]bv-42#[default]"},
+		{14, "ansi", "\x1b[32m9r\x1b[0m \x1b[33mdrift\x1b[0m \x1b[36mbv-42\x1b[0m"},
+	}
+	for _, tt := range tests {
+		if got := renderStatusline(data, tt.format, tt.width); got != tt.want {
+			t.Errorf("width %d %s:\n got %q\nwant %q", tt.width, tt.format, got, tt.want)
+		}
+	}
+
+	quiet := statuslineData{Open: 2, Actionable: 2, Drift: "ok"}
+	if got := renderStatusline(quiet, "tmux", 40); got != "2 open, 0 blocked, #[fg=green]2 ready#[default]" {
+		t.Errorf("no blockers, no drift, no pick: %q", got)
+	}
+}
+
+func TestRunStatusline_Cache(t *testing.T) {
+	beadsDir := setupNewProject(t)
+	projectDir := filepath.Dir(beadsDir)
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	run := func(at time.Time) string {
+		t.Helper()
+		var out bytes.Buffer
+		if err := runStatusline(beadsDir, projectDir, "plain", 0, at, &out); err != nil {
+			t.Fatal(err)
+		}
+		return strings.TrimSpace(out.String())
+	}
+
+	if got := run(now); got != "1 open, 0 blocked, 1 ready, next app-1 Search epic" {
+		t.Fatalf("status line = %q", got)
+	}
+
+	// A hit is served from the cache without looking at the issues
+	cachePath := filepath.Join(projectDir, ".bv", statuslineCacheFile)
+	raw, err := os.ReadFile(cachePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(cachePath, bytes.Replace(raw, []byte(`"open":1`), []byte(`"open":7`), 1), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if got := run(now.Add(time.Minute)); !strings.HasPrefix(got, "7 open") {
+		t.Errorf("cache hit = %q", got)
+	}
+	if got := run(now.Add(statuslineTTL)); !strings.HasPrefix(got, "1 open") {
+		t.Errorf("expired cache should recompute: %q", got)
+	}
+
+	// Changing the beads file invalidates the cache
+	issues := `{"id":"app-1","title":"Search epic","status":"open","issue_type":"epic"}` + "\n" +
+		`{"id":"app-2","title":"Index","status":"open","issue_type":"task","dependencies":[{"issue_id":"app-2","depends_on_id":"app-1","type":"blocks"}]}` + "\n"
+	if err := os.WriteFile(filepath.Join(beadsDir, "issues.jsonl"), []byte(issues), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if got := run(now.Add(statuslineTTL + time.Second)); !strings.HasPrefix(got, "2 open, 1 blocked, 1 ready") {
+		t.Errorf("after editing issues: %q", got)
+	}
+
+	var out bytes.Buffer
+	if err := runStatusline(filepath.Join(t.TempDir(), ".beads"), t.TempDir(), "plain", 40, now, &out); err != nil || out.Len() != 0 {
+		t.Errorf("outside a project: %q, %v", out.String(), err)
+	}
+}
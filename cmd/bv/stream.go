@@ -0,0 +1,287 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/correlation"
+)
+
+// Streaming output (--stream) writes a large robot payload as
+// newline-delimited JSON: one compact object per line, each tagged with a
+// "record" kind, opening with a "meta" record and closing with an "end"
+// record that counts the lines before it. Consumers can stop early (head -n)
+// or filter with jq -c 'select(.record == "...")' without holding the
+// whole document.
+
+// robotStream writes NDJSON records through the robot encoder, so each line
+// carries schema_version and is normalized in deterministic mode
+type robotStream struct {
+	enc     *robotEncoder
+	records int
+}
+
+func newRobotStream(w io.Writer) *robotStream {
+	return &robotStream{enc: newRobotEncoder(w)}
+}
+
+// Emit writes v, which must encode as a JSON object, as one record of the
+// given kind
+func (s *robotStream) Emit(kind string, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("encoding %s record: %w", kind, err)
+	}
+	if len(data) < 2 || data[0] != '{' {
+		return fmt.Errorf("encoding %s record: not a JSON object", kind)
+	}
+	data = prependJSONField(data, "record", kind)
+	if err := s.enc.Encode(json.RawMessage(data)); err != nil {
+		return fmt.Errorf("writing %s record: %w", kind, err)
+	}
+	s.records++
+	return nil
+}
+
+// Close writes the end record
+func (s *robotStream) Close() error {
+	return s.Emit("end", struct {
+		Records int `json:"records"`
+	}{s.records})
+}
+
+// robotInsightsStreamMeta opens --robot-insights --stream: everything in the
+// document form except the per-issue lists and maps
+type robotInsightsStreamMeta struct {
+	GeneratedAt    string                     `json:"generated_at"`
+	DataHash       string                     `json:"data_hash"`
+	AsOf           string                     `json:"as_of,omitempty"`
+	AsOfCommit     string                     `json:"as_of_commit,omitempty"`
+	AnalysisConfig analysis.AnalysisConfig    `json:"analysis_config"`
+	Status         analysis.MetricStatus      `json:"status"`
+	LabelScope     string                     `json:"label_scope,omitempty"`
+	LabelContext   *analysis.LabelHealth      `json:"label_context,omitempty"`
+	ClusterDensity float64                    `json:"cluster_density"`
+	Velocity       *analysis.VelocitySnapshot `json:"velocity,omitempty"`
+	Cycles         int                        `json:"cycles"`
+	Modularity     float64                    `json:"modularity"`  // Of the clustering; clusters follow as records
+	Unclustered    int                        `json:"unclustered"` // Issues in no cluster
+	UsageHints     []string                   `json:"usage_hints"`
+}
+
+// robotInsightRecord is one ranked entry of an insight list (bottlenecks,
+// keystones, ...). Value is absent for the unscored lists.
+type robotInsightRecord struct {
+	List  string   `json:"list"`
+	Rank  int      `json:"rank"`
+	ID    string   `json:"id"`
+	Value *float64 `json:"value,omitempty"`
+}
+
+// robotMetricRecord holds every computed metric for one issue; metrics that
+// were skipped or timed out are absent
+type robotMetricRecord struct {
+	ID                string   `json:"id"`
+	PageRank          *float64 `json:"pagerank,omitempty"`
+	Betweenness       *float64 `json:"betweenness,omitempty"`
+	Eigenvector       *float64 `json:"eigenvector,omitempty"`
+	Hubs              *float64 `json:"hubs,omitempty"`
+	Authorities       *float64 `json:"authorities,omitempty"`
+	CriticalPathScore *float64 `json:"critical_path_score,omitempty"`
+	CoreNumber        *int     `json:"core_number,omitempty"`
+	Slack             *float64 `json:"slack,omitempty"`
+	Articulation      bool     `json:"articulation_point,omitempty"`
+	Cluster           int      `json:"cluster,omitempty"` // Community ID; absent when unclustered
+}
+
+// robotCycleRecord is one dependency cycle
+type robotCycleRecord struct {
+	Index   int      `json:"index"`
+	Members []string `json:"members"`
+}
+
+// streamInsights writes --robot-insights as records: meta, one insight
+// record per list entry, one metric record per issue (highest PageRank
+// first), cycles, clusters and the edges between them, what-ifs, the
+// advanced insights, then end
+func streamInsights(w io.Writer, meta robotInsightsStreamMeta, insights analysis.Insights, ids []string, stats *analysis.GraphStats, whatIfs []analysis.WhatIfEntry, advanced *analysis.AdvancedInsights) error {
+	s := newRobotStream(w)
+	meta.Cycles = len(insights.Cycles)
+	clusters := insights.Clusters
+	if clusters == nil {
+		clusters = &analysis.Clustering{}
+	}
+	meta.Modularity, meta.Unclustered = clusters.Modularity, clusters.Unclustered
+	if err := s.Emit("meta", meta); err != nil {
+		return err
+	}
+
+	lists := []struct {
+		name  string
+		items []analysis.InsightItem
+	}{
+		{"bottlenecks", insights.Bottlenecks},
+		{"keystones", insights.Keystones},
+		{"influencers", insights.Influencers},
+		{"hubs", insights.Hubs},
+		{"authorities", insights.Authorities},
+		{"cores", insights.Cores},
+		{"slack", insights.Slack},
+	}
+	for _, list := range lists {
+		for i, item := range list.items {
+			value := item.Value
+			if err := s.Emit("insight", robotInsightRecord{List: list.name, Rank: i + 1, ID: item.ID, Value: &value}); err != nil {
+				return err
+			}
+		}
+	}
+	for _, list := range []struct {
+		name string
+		ids  []string
+	}{{"articulation", insights.Articulation}, {"orphans", insights.Orphans}} {
+		for i, id := range list.ids {
+			if err := s.Emit("insight", robotInsightRecord{List: list.name, Rank: i + 1, ID: id}); err != nil {
+				return err
+			}
+		}
+	}
+
+	pageRank := stats.PageRank()
+	betweenness := stats.Betweenness()
+	eigenvector := stats.Eigenvector()
+	hubs := stats.Hubs()
+	authorities := stats.Authorities()
+	criticalPath := stats.CriticalPathScore()
+	coreNumber := stats.CoreNumber()
+	slack := stats.Slack()
+	articulation := make(map[string]bool)
+	for _, id := range stats.ArticulationPoints() {
+		articulation[id] = true
+	}
+	lookup := func(m map[string]float64, id string) *float64 {
+		if v, ok := m[id]; ok {
+			return &v
+		}
+		return nil
+	}
+
+	for _, id := range sortByPageRank(ids, pageRank) {
+		rec := robotMetricRecord{
+			ID:                id,
+			PageRank:          lookup(pageRank, id),
+			Betweenness:       lookup(betweenness, id),
+			Eigenvector:       lookup(eigenvector, id),
+			Hubs:              lookup(hubs, id),
+			Authorities:       lookup(authorities, id),
+			CriticalPathScore: lookup(criticalPath, id),
+			Slack:             lookup(slack, id),
+			Articulation:      articulation[id],
+			Cluster:           clusters.Assignments[id],
+		}
+		if v, ok := coreNumber[id]; ok {
+			rec.CoreNumber = &v
+		}
+		if err := s.Emit("metric", rec); err != nil {
+			return err
+		}
+	}
+
+	for i, cycle := range insights.Cycles {
+		if err := s.Emit("cycle", robotCycleRecord{Index: i, Members: cycle}); err != nil {
+			return err
+		}
+	}
+	for _, c := range clusters.Clusters {
+		if err := s.Emit("cluster", c); err != nil {
+			return err
+		}
+	}
+	for _, e := range clusters.InterClusterEdges {
+		if err := s.Emit("cluster_edge", e); err != nil {
+			return err
+		}
+	}
+	for _, entry := range whatIfs {
+		if err := s.Emit("what_if", entry); err != nil {
+			return err
+		}
+	}
+	if advanced != nil {
+		if err := s.Emit("advanced", advanced); err != nil {
+			return err
+		}
+	}
+	return s.Close()
+}
+
+// sortByPageRank returns ids ordered by PageRank, highest first, ties by ID
+func sortByPageRank(ids []string, pageRank map[string]float64) []string {
+	ids = append([]string(nil), ids...)
+	sort.Slice(ids, func(i, j int) bool {
+		if pageRank[ids[i]] != pageRank[ids[j]] {
+			return pageRank[ids[i]] > pageRank[ids[j]]
+		}
+		return ids[i] < ids[j]
+	})
+	return ids
+}
+
+// robotHistoryStreamMeta opens --robot-history --stream
+type robotHistoryStreamMeta struct {
+	GeneratedAt     time.Time                `json:"generated_at"`
+	DataHash        string                   `json:"data_hash"`
+	GitRange        string                   `json:"git_range"`
+	LatestCommitSHA string                   `json:"latest_commit_sha,omitempty"`
+	Stats           correlation.HistoryStats `json:"stats"`
+}
+
+// robotHistoryCommitRecord is one commit_index entry
+type robotHistoryCommitRecord struct {
+	SHA   string   `json:"sha"`
+	Beads []string `json:"beads"`
+}
+
+// streamHistory writes a history report as records: meta, one "bead"
+// record per bead history (by ID), one "commit" record per indexed commit
+// (by SHA), then end
+func streamHistory(w io.Writer, report *correlation.HistoryReport) error {
+	s := newRobotStream(w)
+	meta := robotHistoryStreamMeta{
+		GeneratedAt:     report.GeneratedAt,
+		DataHash:        report.DataHash,
+		GitRange:        report.GitRange,
+		LatestCommitSHA: report.LatestCommitSHA,
+		Stats:           report.Stats,
+	}
+	if err := s.Emit("meta", meta); err != nil {
+		return err
+	}
+
+	beadIDs := make([]string, 0, len(report.Histories))
+	for id := range report.Histories {
+		beadIDs = append(beadIDs, id)
+	}
+	sort.Strings(beadIDs)
+	for _, id := range beadIDs {
+		if err := s.Emit("bead", report.Histories[id]); err != nil {
+			return err
+		}
+	}
+
+	shas := make([]string, 0, len(report.CommitIndex))
+	for sha := range report.CommitIndex {
+		shas = append(shas, sha)
+	}
+	sort.Strings(shas)
+	for _, sha := range shas {
+		if err := s.Emit("commit", robotHistoryCommitRecord{SHA: sha, Beads: report.CommitIndex[sha]}); err != nil {
+			return err
+		}
+	}
+	return s.Close()
+}
@@ -0,0 +1,173 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/correlation"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// readStream decodes NDJSON output, failing on any line that isn't a
+// record object, and returns the records in order
+func readStream(t *testing.T, out []byte) []map[string]any {
+	t.Helper()
+	var records []map[string]any
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	scanner.Buffer(nil, 1<<20)
+	for scanner.Scan() {
+		var rec map[string]any
+		if err := json.Unmarshal(scanner.Bytes(), &rec); err != nil {
+			t.Fatalf("line %d is not a JSON object: %v\n%s", len(records)+1, err, scanner.Bytes())
+		}
+		if rec["schema_version"] != robotSchemaVersion || rec["record"] == nil {
+			t.Fatalf("line %d lacks schema_version/record: %s", len(records)+1, scanner.Bytes())
+		}
+		records = append(records, rec)
+	}
+	if len(records) < 2 || records[0]["record"] != "meta" {
+		t.Fatalf("stream should open with meta, got %v", records)
+	}
+	end := records[len(records)-1]
+	if end["record"] != "end" || end["records"] != float64(len(records)-1) {
+		t.Fatalf("end record = %v, want a count of %d", end, len(records)-1)
+	}
+	return records
+}
+
+func TestStreamInsights(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "A", Title: "A", Status: model.StatusOpen},
+		{ID: "B", Title: "B", Status: model.StatusOpen, Dependencies: []*model.Dependency{{IssueID: "B", DependsOnID: "A", Type: model.DepBlocks}}},
+		{ID: "C", Title: "C", Status: model.StatusOpen, Dependencies: []*model.Dependency{{IssueID: "C", DependsOnID: "B", Type: model.DepBlocks}}},
+	}
+	analyzer := analysis.NewAnalyzer(issues)
+	stats := analyzer.Analyze()
+	insights := stats.GenerateInsights(10)
+	insights.Clusters = analyzer.Clusters()
+
+	var buf bytes.Buffer
+	meta := robotInsightsStreamMeta{GeneratedAt: "2025-01-01T00:00:00Z", DataHash: "h"}
+	if err := streamInsights(&buf, meta, insights, []string{"C", "B", "A"}, &stats, analyzer.TopWhatIfDeltas(2), nil); err != nil {
+		t.Fatal(err)
+	}
+	records := readStream(t, buf.Bytes())
+
+	var metricIDs []string
+	sawInsight, clusters := false, 0
+	for _, rec := range records {
+		switch rec["record"] {
+		case "metric":
+			metricIDs = append(metricIDs, rec["id"].(string))
+			if _, ok := rec["pagerank"]; !ok {
+				t.Errorf("metric record lacks pagerank: %v", rec)
+			}
+			if rec["cluster"] != float64(1) {
+				t.Errorf("metric record cluster = %v, want 1", rec["cluster"])
+			}
+		case "cluster":
+			clusters++
+		case "insight":
+			sawInsight = true
+			if rec["list"] == nil || rec["rank"] == nil {
+				t.Errorf("insight record = %v", rec)
+			}
+		case "advanced":
+			t.Error("nil advanced insights should not be emitted")
+		}
+	}
+	if !sawInsight {
+		t.Error("expected insight records")
+	}
+	if clusters != 1 {
+		t.Errorf("cluster records = %d, want the one chain", clusters)
+	}
+	// A is the root every other issue depends on, so it ranks first
+	if strings.Join(metricIDs, ",") != "A,B,C" {
+		t.Errorf("metric records = %v, want one per issue by PageRank", metricIDs)
+	}
+	if got := records[0]["data_hash"]; got != "h" {
+		t.Errorf("meta data_hash = %v", got)
+	}
+}
+
+func TestStreamHistory(t *testing.T) {
+	report := &correlation.HistoryReport{
+		GeneratedAt: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		DataHash:    "h",
+		GitRange:    "HEAD~10..HEAD",
+		Histories: map[string]correlation.BeadHistory{
+			"b-2": {BeadID: "b-2", Title: "Second"},
+			"b-1": {BeadID: "b-1", Title: "First"},
+		},
+		CommitIndex: correlation.CommitIndex{"bbb": {"b-2"}, "aaa": {"b-1", "b-2"}},
+	}
+	var buf bytes.Buffer
+	if err := streamHistory(&buf, report); err != nil {
+		t.Fatal(err)
+	}
+	records := readStream(t, buf.Bytes())
+
+	var got []string
+	for _, rec := range records[1 : len(records)-1] {
+		switch rec["record"] {
+		case "bead":
+			got = append(got, rec["bead_id"].(string))
+		case "commit":
+			got = append(got, rec["sha"].(string))
+		}
+	}
+	if strings.Join(got, ",") != "b-1,b-2,aaa,bbb" {
+		t.Errorf("records = %v, want beads then commits, each sorted", got)
+	}
+	if records[0]["git_range"] != "HEAD~10..HEAD" || records[0]["generated_at"] != "2025-01-01T00:00:00Z" {
+		t.Errorf("meta = %v", records[0])
+	}
+}
+
+func TestRobotStream_RejectsNonObjects(t *testing.T) {
+	s := newRobotStream(&bytes.Buffer{})
+	if err := s.Emit("list", []int{1}); err == nil {
+		t.Error("arrays can't carry a record field and should fail")
+	}
+}
+
+func TestRobotInsightsStreamFlag(t *testing.T) {
+	dir := t.TempDir()
+	beadsDir := filepath.Join(dir, ".beads")
+	if err := os.MkdirAll(beadsDir, 0o755); err != nil {
+		t.Fatalf("mkdir beads: %v", err)
+	}
+	beads := `{"id":"TEST-1","title":"Root","status":"open","priority":1,"issue_type":"task"}
+{"id":"TEST-2","title":"Leaf","status":"open","priority":2,"issue_type":"task","dependencies":[{"issue_id":"TEST-2","depends_on_id":"TEST-1","type":"blocks"}]}
+`
+	if err := os.WriteFile(filepath.Join(beadsDir, "beads.jsonl"), []byte(beads), 0o644); err != nil {
+		t.Fatalf("write beads: %v", err)
+	}
+
+	exe := buildTestBinary(t)
+	cmd := exec.Command(exe, "--robot-insights", "--stream")
+	cmd.Dir = dir
+	out, err := cmd.Output()
+	if err != nil {
+		t.Fatalf("--robot-insights --stream failed: %v, out=%s", err, out)
+	}
+	records := readStream(t, out)
+	metrics := 0
+	for _, rec := range records {
+		if rec["record"] == "metric" {
+			metrics++
+		}
+	}
+	if metrics != 2 {
+		t.Errorf("got %d metric records, want one per issue", metrics)
+	}
+}
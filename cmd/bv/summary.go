@@ -0,0 +1,235 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+)
+
+// --robot-summary condenses triage into a prompt-sized briefing for agents
+// that need the gist, not the full payload: counts, the top three picks
+// with one reason each, the top risk and the closing trend. The digest is
+// built only from the triage, so the same data gives the same text.
+
+// summaryMaxChars bounds the digest, in characters
+const summaryMaxChars = 1500
+
+// Truncation limits that keep three picks well inside the budget
+const (
+	summaryTitleChars  = 60
+	summaryReasonChars = 80
+)
+
+// robotSummaryOutput is the --robot-summary payload
+type robotSummaryOutput struct {
+	GeneratedAt string        `json:"generated_at"`
+	DataHash    string        `json:"data_hash"`
+	AsOf        string        `json:"as_of,omitempty"`
+	AsOfCommit  string        `json:"as_of_commit,omitempty"`
+	Digest      string        `json:"digest"` // Plain text, at most 1500 characters
+	Counts      summaryCounts `json:"counts"`
+	TopPicks    []summaryPick `json:"top_picks"`
+	TopRisk     *summaryRisk  `json:"top_risk,omitempty"`
+	Trend       summaryTrend  `json:"trend"`
+}
+
+// summaryCounts are the headline issue counts
+type summaryCounts struct {
+	Total      int `json:"total"`
+	Open       int `json:"open"`
+	Actionable int `json:"actionable"`
+	Blocked    int `json:"blocked"`
+	InProgress int `json:"in_progress"`
+	Closed     int `json:"closed"`
+}
+
+// summaryPick is a top pick with its leading reason
+type summaryPick struct {
+	ID        string  `json:"id"`
+	Title     string  `json:"title"`
+	Score     float64 `json:"score"`
+	Reason    string  `json:"reason,omitempty"`
+	Unblocks  int     `json:"unblocks"`
+	ClaimedBy string  `json:"claimed_by,omitempty"`
+}
+
+// summaryRisk is the single most pressing problem
+type summaryRisk struct {
+	Kind    string `json:"kind"` // cycle, alert, blocker or stale
+	IssueID string `json:"issue_id,omitempty"`
+	Message string `json:"message"`
+}
+
+// summaryTrend compares the last week's closures with the weeks before it
+type summaryTrend struct {
+	Direction       string  `json:"direction"` // up, down, steady or none
+	ClosedLast7Days int     `json:"closed_last_7_days"`
+	PriorWeeklyRate float64 `json:"prior_weekly_rate"` // Closures per week over the 23 days before
+}
+
+// buildRobotSummary condenses triage into the summary payload, leaving the
+// generation stamps to the caller
+func buildRobotSummary(triage analysis.TriageResult) robotSummaryOutput {
+	health := triage.ProjectHealth
+	out := robotSummaryOutput{
+		Counts: summaryCounts{
+			Total:      health.Counts.Total,
+			Open:       triage.QuickRef.OpenCount,
+			Actionable: triage.QuickRef.ActionableCount,
+			Blocked:    triage.QuickRef.BlockedCount,
+			InProgress: triage.QuickRef.InProgressCount,
+			Closed:     health.Counts.Closed,
+		},
+		TopPicks: []summaryPick{},
+		TopRisk:  summaryTopRisk(triage),
+		Trend:    summaryTrendOf(health.Velocity),
+	}
+	for i, p := range triage.QuickRef.TopPicks {
+		if i == 3 {
+			break
+		}
+		pick := summaryPick{
+			ID:        p.ID,
+			Title:     truncateTitle(p.Title, summaryTitleChars),
+			Score:     p.Score,
+			Unblocks:  p.Unblocks,
+			ClaimedBy: p.ClaimedBy,
+		}
+		if len(p.Reasons) > 0 {
+			pick.Reason = truncateTitle(stripReasonIcon(p.Reasons[0]), summaryReasonChars)
+		}
+		out.TopPicks = append(out.TopPicks, pick)
+	}
+	out.Digest = summaryDigest(out)
+	return out
+}
+
+// summaryTopRisk picks, in order: dependency cycles, the most severe
+// triage alert, the blocker holding up the most work, the stalest issue
+func summaryTopRisk(triage analysis.TriageResult) *summaryRisk {
+	health := triage.ProjectHealth
+	if health.Graph.HasCycles {
+		n := max(health.Graph.CycleCount, 1)
+		return &summaryRisk{Kind: "cycle", Message: fmt.Sprintf("%d dependency %s; issues in a cycle can never become ready", n, plural(n, "cycle", "cycles"))}
+	}
+	var worst *analysis.Alert
+	for i, a := range triage.Alerts {
+		if worst == nil || alertRank(a.Severity) > alertRank(worst.Severity) {
+			worst = &triage.Alerts[i]
+		}
+	}
+	if worst != nil && alertRank(worst.Severity) > 0 {
+		return &summaryRisk{Kind: "alert", IssueID: worst.IssueID, Message: truncateTitle(worst.Message, summaryReasonChars)}
+	}
+	for _, b := range triage.BlockersToClear {
+		if b.UnblocksCount == 0 {
+			continue
+		}
+		msg := fmt.Sprintf("%s blocks %d %s", b.ID, b.UnblocksCount, plural(b.UnblocksCount, "issue", "issues"))
+		if !b.Actionable {
+			msg += " and is itself blocked"
+		}
+		return &summaryRisk{Kind: "blocker", IssueID: b.ID, Message: msg}
+	}
+	if s := health.Staleness; s != nil && s.StaleCount > 0 {
+		return &summaryRisk{Kind: "stale", IssueID: s.StalestIssueID, Message: fmt.Sprintf("%d stale %s (no activity in %dd); oldest %s at %dd",
+			s.StaleCount, plural(s.StaleCount, "issue", "issues"), s.ThresholdDays, s.StalestIssueID, s.StalestIssueDays)}
+	}
+	return nil
+}
+
+func alertRank(severity string) int {
+	switch severity {
+	case "error", "critical":
+		return 2
+	case "warning":
+		return 1
+	}
+	return 0
+}
+
+// summaryTrendOf compares the last 7 days' closures with the weekly rate
+// over the 23 days before them; a change of a quarter either way is a trend
+func summaryTrendOf(v *analysis.Velocity) summaryTrend {
+	if v == nil || v.ClosedLast30Days == 0 {
+		return summaryTrend{Direction: "none"}
+	}
+	t := summaryTrend{
+		ClosedLast7Days: v.ClosedLast7Days,
+		PriorWeeklyRate: float64(v.ClosedLast30Days-v.ClosedLast7Days) * 7 / 23,
+	}
+	last := float64(v.ClosedLast7Days)
+	switch {
+	case last > t.PriorWeeklyRate*1.25:
+		t.Direction = "up"
+	case last < t.PriorWeeklyRate*0.75:
+		t.Direction = "down"
+	default:
+		t.Direction = "steady"
+	}
+	return t
+}
+
+// summaryDigest renders the payload as plain text of at most
+// summaryMaxChars characters
+func summaryDigest(s robotSummaryOutput) string {
+	var b strings.Builder
+	c := s.Counts
+	fmt.Fprintf(&b, "%d issues: %d open (%d actionable, %d blocked, %d in progress), %d closed.\n",
+		c.Total, c.Open, c.Actionable, c.Blocked, c.InProgress, c.Closed)
+
+	if len(s.TopPicks) == 0 {
+		b.WriteString("No actionable picks.\n")
+	} else {
+		b.WriteString("Top picks:\n")
+		for i, p := range s.TopPicks {
+			fmt.Fprintf(&b, "%d. %s %q (score %.2f)", i+1, p.ID, p.Title, p.Score)
+			if p.Reason != "" {
+				fmt.Fprintf(&b, ": %s", p.Reason)
+			}
+			if p.Unblocks > 0 && !strings.HasPrefix(p.Reason, "Unblocks") {
+				fmt.Fprintf(&b, "; unblocks %d", p.Unblocks)
+			}
+			if p.ClaimedBy != "" {
+				fmt.Fprintf(&b, "; claimed by %s", p.ClaimedBy)
+			}
+			b.WriteString("\n")
+		}
+	}
+
+	if s.TopRisk != nil {
+		fmt.Fprintf(&b, "Top risk: %s.\n", strings.TrimSuffix(s.TopRisk.Message, "."))
+	} else {
+		b.WriteString("Top risk: none found.\n")
+	}
+
+	t := s.Trend
+	switch t.Direction {
+	case "none":
+		b.WriteString("Trend: nothing closed in the last 30 days.\n")
+	default:
+		fmt.Fprintf(&b, "Trend: %d closed in the last 7 days, %s (%.1f/week before).\n",
+			t.ClosedLast7Days, t.Direction, t.PriorWeeklyRate)
+	}
+
+	if len(s.TopPicks) > 0 {
+		fmt.Fprintf(&b, "Start: bd update %s --status=in_progress\n", s.TopPicks[0].ID)
+	}
+	return truncateTitle(strings.TrimSuffix(b.String(), "\n"), summaryMaxChars)
+}
+
+// stripReasonIcon drops the emoji and symbols triage reasons start with
+func stripReasonIcon(reason string) string {
+	return strings.TrimLeftFunc(reason, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+func plural(n int, one, many string) string {
+	if n == 1 {
+		return one
+	}
+	return many
+}
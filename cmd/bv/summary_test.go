@@ -0,0 +1,98 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+)
+
+func summaryTestTriage() analysis.TriageResult {
+	var triage analysis.TriageResult
+	triage.QuickRef = analysis.QuickRef{OpenCount: 5, ActionableCount: 2, BlockedCount: 3}
+	triage.ProjectHealth.Counts = analysis.HealthCounts{Total: 7, Open: 5, Closed: 2}
+	for _, id := range []string{"bv-1", "bv-2", "bv-3", "bv-4"} {
+		triage.QuickRef.TopPicks = append(triage.QuickRef.TopPicks, analysis.TopPick{
+			ID: id, Title: "Pick " + id, Score: 0.5, Unblocks: 1,
+			Reasons: []string{"🔀 Critical path bottleneck", "📊 High centrality"},
+		})
+	}
+	triage.BlockersToClear = []analysis.BlockerItem{{ID: "bv-9", UnblocksCount: 3}}
+	return triage
+}
+
+func TestBuildRobotSummary(t *testing.T) {
+	out := buildRobotSummary(summaryTestTriage())
+
+	if len(out.TopPicks) != 3 || out.TopPicks[0].Reason != "Critical path bottleneck" {
+		t.Errorf("top picks = %+v", out.TopPicks)
+	}
+	if out.TopRisk == nil || out.TopRisk.Kind != "blocker" || out.TopRisk.Message != "bv-9 blocks 3 issues and is itself blocked" {
+		t.Errorf("top risk = %+v", out.TopRisk)
+	}
+	if out.Trend.Direction != "none" {
+		t.Errorf("trend = %+v", out.Trend)
+	}
+	for _, want := range []string{
+		"7 issues: 5 open (2 actionable, 3 blocked, 0 in progress), 2 closed.",
+		`1. bv-1 "Pick bv-1" (score 0.50): Critical path bottleneck; unblocks 1`,
+		"Top risk: bv-9 blocks 3 issues and is itself blocked.",
+		"Start: bd update bv-1 --status=in_progress",
+	} {
+		if !strings.Contains(out.Digest, want) {
+			t.Errorf("digest lacks %q:\n%s", want, out.Digest)
+		}
+	}
+	if again := buildRobotSummary(summaryTestTriage()); again.Digest != out.Digest {
+		t.Error("digest is not deterministic")
+	}
+}
+
+func TestBuildRobotSummary_RiskOrder(t *testing.T) {
+	triage := summaryTestTriage()
+	triage.Alerts = []analysis.Alert{
+		{Type: "stale", Severity: "info", Message: "minor"},
+		{Type: "velocity_drop", Severity: "warning", Message: "Velocity dropped."},
+	}
+	if r := buildRobotSummary(triage).TopRisk; r.Kind != "alert" || r.Message != "Velocity dropped." {
+		t.Errorf("alert risk = %+v", r)
+	}
+	triage.ProjectHealth.Graph = analysis.GraphHealth{HasCycles: true, CycleCount: 2}
+	if r := buildRobotSummary(triage).TopRisk; r.Kind != "cycle" || !strings.HasPrefix(r.Message, "2 dependency cycles") {
+		t.Errorf("cycle risk = %+v", r)
+	}
+}
+
+func TestSummaryTrend(t *testing.T) {
+	tests := []struct {
+		last7, last30 int
+		want          string
+	}{
+		{0, 0, "none"},
+		{5, 8, "up"},
+		{0, 9, "down"},
+		{2, 9, "steady"},
+	}
+	for _, tt := range tests {
+		v := &analysis.Velocity{ClosedLast7Days: tt.last7, ClosedLast30Days: tt.last30}
+		if got := summaryTrendOf(v).Direction; got != tt.want {
+			t.Errorf("%d/%d: direction %q, want %q", tt.last7, tt.last30, got, tt.want)
+		}
+	}
+}
+
+func TestSummaryDigestFitsBudget(t *testing.T) {
+	triage := summaryTestTriage()
+	long := strings.Repeat("Überlange Beschreibung ", 40)
+	for i := range triage.QuickRef.TopPicks {
+		triage.QuickRef.TopPicks[i].Title = long
+		triage.QuickRef.TopPicks[i].Reasons = []string{long}
+		triage.QuickRef.TopPicks[i].ClaimedBy = strings.Repeat("agent", 50)
+	}
+	triage.Alerts = []analysis.Alert{{Severity: "error", Message: long}}
+	digest := buildRobotSummary(triage).Digest
+	if n := utf8.RuneCountInString(digest); n > summaryMaxChars {
+		t.Errorf("digest is %d characters, over %d", n, summaryMaxChars)
+	}
+}
@@ -0,0 +1,155 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/timetrack"
+)
+
+const trackUsage = "Usage: bv track start <id> | stop | status"
+
+// runTrack implements `bv track`: start and stop work sessions recorded in
+// .bv/time.jsonl. It returns the process exit code.
+func runTrack(args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		fmt.Fprintln(stderr, trackUsage)
+		return exitUsage
+	}
+	projectDir, err := trackProjectDir()
+	if err != nil {
+		fmt.Fprintf(stderr, "Error getting beads directory: %v\n", err)
+		return exitCodeFor(err)
+	}
+	path := timetrack.Path(projectDir)
+	actor := analysis.CurrentActor()
+	now := time.Now()
+
+	switch args[0] {
+	case "start":
+		if len(args) != 2 {
+			fmt.Fprintln(stderr, trackUsage)
+			return exitUsage
+		}
+		issueID := args[1]
+		if err := checkIssueExists(issueID); err != nil {
+			fmt.Fprintf(stderr, "Error: %v\n", err)
+			return exitNotFound
+		}
+		stopped, err := timetrack.Start(path, issueID, actor, now)
+		if err != nil {
+			fmt.Fprintf(stderr, "Error: %v\n", err)
+			return exitError
+		}
+		if stopped != nil {
+			fmt.Fprintf(stdout, "Stopped %s after %s\n", stopped.IssueID, timetrack.FormatDuration(stopped.Duration(now)))
+		}
+		fmt.Fprintf(stdout, "Tracking %s\n", issueID)
+	case "stop":
+		stopped, err := timetrack.Stop(path, actor, now)
+		if errors.Is(err, timetrack.ErrNotTracking) {
+			fmt.Fprintln(stdout, "Not tracking anything")
+			return exitOK
+		}
+		if err != nil {
+			fmt.Fprintf(stderr, "Error: %v\n", err)
+			return exitError
+		}
+		log, err := timetrack.Load(path)
+		if err != nil {
+			fmt.Fprintf(stderr, "Error: %v\n", err)
+			return exitError
+		}
+		fmt.Fprintf(stdout, "Stopped %s after %s (total %s)\n", stopped.IssueID,
+			timetrack.FormatDuration(stopped.Duration(now)), timetrack.FormatDuration(log.Tracked(stopped.IssueID, now)))
+	case "status":
+		log, err := timetrack.Load(path)
+		if err != nil {
+			fmt.Fprintf(stderr, "Error: %v\n", err)
+			return exitError
+		}
+		if active := log.Active(actor); active != nil {
+			fmt.Fprintf(stdout, "Tracking %s for %s\n", active.IssueID, timetrack.FormatDuration(active.Duration(now)))
+		} else {
+			fmt.Fprintln(stdout, "Not tracking anything")
+		}
+		totals := make(map[string]time.Duration)
+		for _, s := range log.Sessions {
+			totals[s.IssueID] += s.Duration(now)
+		}
+		ids := make([]string, 0, len(totals))
+		for id := range totals {
+			ids = append(ids, id)
+		}
+		sort.Slice(ids, func(i, j int) bool {
+			if totals[ids[i]] != totals[ids[j]] {
+				return totals[ids[i]] > totals[ids[j]]
+			}
+			return ids[i] < ids[j]
+		})
+		if len(ids) > 0 {
+			fmt.Fprintln(stdout, "\nTracked time:")
+		}
+		for _, id := range ids {
+			fmt.Fprintf(stdout, "  %-12s %s\n", id, timetrack.FormatDuration(totals[id]))
+		}
+	default:
+		fmt.Fprintln(stderr, trackUsage)
+		return exitUsage
+	}
+	return exitOK
+}
+
+// trackProjectDir is the directory holding .beads (and so .bv)
+func trackProjectDir() (string, error) {
+	beadsDir, err := loader.GetBeadsDir("")
+	if err != nil {
+		return "", err
+	}
+	return filepath.Dir(beadsDir), nil
+}
+
+// checkIssueExists guards against tracking a typo. Without a beads file
+// there is nothing to check against.
+func checkIssueExists(issueID string) error {
+	beadsDir, err := loader.GetBeadsDir("")
+	if err != nil {
+		return nil
+	}
+	beadsPath, err := loader.FindJSONLPath(beadsDir)
+	if err != nil {
+		return nil
+	}
+	issues, err := loader.LoadIssuesFromFile(beadsPath)
+	if err != nil {
+		return nil
+	}
+	for _, issue := range issues {
+		if issue.ID == issueID {
+			return nil
+		}
+	}
+	return fmt.Errorf("issue %s not found", issueID)
+}
+
+// trackedActuals returns actual minutes per issue from the project's time
+// log, or nil if there is none
+func trackedActuals() map[string]int {
+	projectDir, err := trackProjectDir()
+	if err != nil {
+		return nil
+	}
+	log, err := timetrack.Load(timetrack.Path(projectDir))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: ignoring time log: %v\n", err)
+		return nil
+	}
+	return log.ActualMinutes()
+}
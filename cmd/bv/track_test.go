@@ -0,0 +1,42 @@
+package main
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/timetrack"
+)
+
+func TestRunTrack(t *testing.T) {
+	beadsDir := setupNewProject(t)
+	var stdout, stderr bytes.Buffer
+
+	if code := runTrack([]string{"start", "app-404"}, &stdout, &stderr); code != exitNotFound || !strings.Contains(stderr.String(), "not found") {
+		t.Errorf("unknown issue: exit %d, stderr %q", code, stderr.String())
+	}
+	if code := runTrack([]string{"start", "app-1"}, &stdout, &stderr); code != 0 || !strings.Contains(stdout.String(), "Tracking app-1") {
+		t.Fatalf("start: exit %d, stdout %q, stderr %q", code, stdout.String(), stderr.String())
+	}
+	stdout.Reset()
+	if code := runTrack([]string{"status"}, &stdout, &stderr); code != 0 || !strings.HasPrefix(stdout.String(), "Tracking app-1 for") {
+		t.Errorf("status: exit %d, stdout %q", code, stdout.String())
+	}
+	stdout.Reset()
+	if code := runTrack([]string{"stop"}, &stdout, &stderr); code != 0 || !strings.HasPrefix(stdout.String(), "Stopped app-1") {
+		t.Errorf("stop: exit %d, stdout %q", code, stdout.String())
+	}
+	stdout.Reset()
+	if code := runTrack([]string{"stop"}, &stdout, &stderr); code != 0 || !strings.Contains(stdout.String(), "Not tracking") {
+		t.Errorf("second stop: exit %d, stdout %q", code, stdout.String())
+	}
+
+	log, err := timetrack.Load(timetrack.Path(filepath.Dir(beadsDir)))
+	if err != nil || len(log.Sessions) != 1 || log.Sessions[0].Running() {
+		t.Errorf("log = %+v, %v", log, err)
+	}
+	if code := runTrack([]string{"bogus"}, &stdout, &stderr); code != exitUsage {
+		t.Errorf("unknown subcommand: exit %d", code)
+	}
+}
@@ -0,0 +1,192 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/provenance"
+)
+
+const verifyUsage = "Usage: bv verify <file|dir> --key <public key> [--sig <file>] [--allow-stale]"
+
+// runVerify implements `bv verify`: check a signed robot payload (saved
+// stdout) or export against a public key, check an export's files against
+// its provenance, and check the provenance's data hash against the current
+// beads data. It returns the process exit code: 1 if any check fails.
+func runVerify(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("verify", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	key := fs.String("key", "", "SSH or minisign public key the signature must be made with")
+	sigPath := fs.String("sig", "", "Detached signature (default: <file>.sig, or the provenance file's .sig for exports)")
+	allowStale := fs.Bool("allow-stale", false, "Pass even if the beads data changed since the payload was produced")
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, verifyUsage)
+		fmt.Fprintln(stderr, "\nVerify output produced with --sign.")
+		fs.PrintDefaults()
+	}
+
+	// Flags may follow the file
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			if errors.Is(err, flag.ErrHelp) {
+				return 0
+			}
+			return 2
+		}
+		if fs.NArg() == 0 {
+			break
+		}
+		positional = append(positional, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+	if len(positional) != 1 || *key == "" {
+		fmt.Fprintln(stderr, verifyUsage)
+		return 2
+	}
+	target := positional[0]
+
+	// An export has a provenance file next to it (inside, for a directory);
+	// anything else is a robot payload carrying its provenance inline
+	signed, isExport := target, false
+	if info, err := os.Stat(target); err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return 1
+	} else if info.IsDir() {
+		signed, isExport = filepath.Join(target, provenance.DirManifest), true
+	} else if _, err := os.Stat(target + provenance.SidecarSuffix); err == nil {
+		signed, isExport = target+provenance.SidecarSuffix, true
+	}
+	if *sigPath == "" {
+		*sigPath = signed + provenance.SignatureSuffix
+	}
+
+	data, err := os.ReadFile(signed)
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return 1
+	}
+	sig, err := os.ReadFile(*sigPath)
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: reading signature: %v\n", err)
+		return 1
+	}
+
+	ok := true
+	check := func(name string, pass bool, detail string) {
+		mark := "ok"
+		if !pass {
+			mark, ok = "FAIL", false
+		}
+		fmt.Fprintf(stdout, "%-10s %-4s %s\n", name, mark, detail)
+	}
+
+	if err := provenance.Verify(data, sig, *key); err != nil {
+		check("signature", false, err.Error())
+		return 1
+	}
+	check("signature", true, fmt.Sprintf("%s signed with %s", filepath.Base(signed), *key))
+
+	var record *provenance.Provenance
+	if isExport {
+		record = &provenance.Provenance{}
+		if err := json.Unmarshal(data, record); err != nil {
+			check("provenance", false, err.Error())
+			return 1
+		}
+	} else {
+		var payload struct {
+			Provenance *provenance.Provenance `json:"provenance"`
+		}
+		if json.NewDecoder(bytes.NewReader(data)).Decode(&payload) == nil {
+			record = payload.Provenance
+		}
+		if record == nil {
+			check("provenance", false, "payload has no provenance (produce it with --provenance or --sign)")
+			return 1
+		}
+	}
+	check("provenance", true, describeProvenance(record))
+
+	if isExport {
+		problems, err := provenance.CheckFiles(target, record.Files)
+		switch {
+		case err != nil:
+			check("files", false, err.Error())
+		case len(problems) > 0:
+			check("files", false, strings.Join(problems, "; "))
+		default:
+			check("files", true, fmt.Sprintf("%d file(s) match", len(record.Files)))
+		}
+	}
+
+	current, source, err := currentDataHash()
+	switch {
+	case record.DataHash == "":
+		check("data", true, "no data hash recorded")
+	case err != nil:
+		check("data", true, "not checked: "+err.Error())
+	case current == record.DataHash:
+		check("data", true, fmt.Sprintf("data_hash %s matches %s", record.DataHash, source))
+	case *allowStale:
+		check("data", true, fmt.Sprintf("stale (allowed): data_hash %s, %s now %s", record.DataHash, source, current))
+	default:
+		check("data", false, fmt.Sprintf("stale: data_hash %s, %s now %s", record.DataHash, source, current))
+	}
+
+	if !ok {
+		return 1
+	}
+	return 0
+}
+
+// describeProvenance summarizes a provenance record on one line
+func describeProvenance(p *provenance.Provenance) string {
+	parts := []string{"bv " + p.Version}
+	if p.GitCommit != "" {
+		commit := shortRevision(p.GitCommit)
+		if p.GitDirty {
+			commit += " (uncommitted .beads changes)"
+		}
+		parts = append(parts, "commit "+commit)
+	}
+	if len(p.Flags) > 0 {
+		names := make([]string, 0, len(p.Flags))
+		for name, value := range p.Flags {
+			if value != "true" {
+				name += "=" + value
+			}
+			names = append(names, "--"+name)
+		}
+		sort.Strings(names)
+		parts = append(parts, strings.Join(names, " "))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// currentDataHash hashes the project's beads data as robot commands do
+func currentDataHash() (hash, source string, err error) {
+	beadsDir, err := loader.GetBeadsDir("")
+	if err != nil {
+		return "", "", err
+	}
+	path, err := loader.FindJSONLPath(beadsDir)
+	if err != nil {
+		return "", "", err
+	}
+	issues, err := loader.LoadIssuesFromFile(path)
+	if err != nil {
+		return "", "", err
+	}
+	return analysis.ComputeDataHash(issues), path, nil
+}
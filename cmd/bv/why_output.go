@@ -0,0 +1,64 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/correlation"
+)
+
+// writeWhyText renders a --why result for humans.
+func writeWhyText(w io.Writer, res *correlation.WhyResult) {
+	fmt.Fprintf(w, "%s:%d-%d\n\n", res.Path, res.StartLine, res.EndLine)
+
+	if len(res.Beads) == 0 {
+		fmt.Fprintln(w, "No beads linked to these lines.")
+	} else {
+		fmt.Fprintln(w, "Motivated by:")
+		for _, b := range res.Beads {
+			fmt.Fprintf(w, "  %-12s [%s] %s\n", b.BeadID, b.Status, b.Title)
+			fmt.Fprintf(w, "  %-12s %d %s via %s, %.0f%% confidence\n",
+				"", b.Lines, pluralLines(b.Lines), strings.Join(b.Commits, ", "), b.Confidence*100)
+			if b.Link != "" {
+				fmt.Fprintf(w, "  %-12s %s\n", "", b.Link)
+			}
+		}
+	}
+
+	if len(res.Commits) > 0 {
+		fmt.Fprintln(w, "\nCommits:")
+		for _, c := range res.Commits {
+			spans := make([]string, len(c.Lines))
+			for i, s := range c.Lines {
+				if s.Start == s.End {
+					spans[i] = fmt.Sprintf("%d", s.Start)
+				} else {
+					spans[i] = fmt.Sprintf("%d-%d", s.Start, s.End)
+				}
+			}
+			beads := "-"
+			if len(c.BeadIDs) > 0 {
+				beads = strings.Join(c.BeadIDs, ", ")
+			}
+			date := ""
+			if !c.Timestamp.IsZero() {
+				date = c.Timestamp.Format("2006-01-02")
+			}
+			fmt.Fprintf(w, "  %s  %s  %-16s L%-10s %s → %s\n",
+				c.ShortSHA, date, truncateTitle(c.Author, 16), strings.Join(spans, ","),
+				truncateTitle(c.Summary, 50), beads)
+		}
+	}
+
+	if res.UnlinkedLines > 0 {
+		fmt.Fprintf(w, "\n%d %s not linked to any bead.\n", res.UnlinkedLines, pluralLines(res.UnlinkedLines))
+	}
+}
+
+func pluralLines(n int) string {
+	if n == 1 {
+		return "line"
+	}
+	return "lines"
+}
@@ -0,0 +1,170 @@
+package analysis
+
+import (
+	"math"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+const (
+	// ActivityWindowDays is the window whose activity is compared against
+	// the windows before it
+	ActivityWindowDays = 7
+	// ActivityBaselineWindows is how many earlier windows form the baseline
+	ActivityBaselineWindows = 4
+	// DefaultActivityDays covers the current window and its full baseline
+	DefaultActivityDays = ActivityWindowDays * (ActivityBaselineWindows + 1)
+)
+
+// Activity metrics
+const (
+	ActivityCreated = "created"
+	ActivityClosed  = "closed"
+	ActivityBlocked = "blocked"
+)
+
+// ActivitySeries counts tracker activity per day over a trailing window,
+// oldest day first, as read from the beads file's git history
+type ActivitySeries struct {
+	Start string `json:"start"` // First day, YYYY-MM-DD
+	// KnownFrom is the first day with an earlier commit to compare against;
+	// days before it read 0 because the history does not reach them
+	KnownFrom int   `json:"known_from"`
+	Created   []int `json:"created"`
+	Closed    []int `json:"closed"`
+	Blocked   []int `json:"blocked"` // Issues that turned blocked
+}
+
+// MetricSeries is one metric's daily values, oldest day first
+type MetricSeries struct {
+	Metric string `json:"metric"`
+	Start  string `json:"start"` // First day, YYYY-MM-DD
+	Values []int  `json:"values"`
+}
+
+// ActivityWindow compares a metric's last window with the windows before it
+type ActivityWindow struct {
+	Metric    string  `json:"metric"`
+	Current   int     `json:"current"`  // Total over the last window
+	Baseline  []int   `json:"baseline"` // Totals of the earlier windows, oldest first
+	Mean      float64 `json:"mean"`
+	StdDev    float64 `json:"std_dev"`
+	ChangePct float64 `json:"change_pct"` // Current against the mean
+	ZScore    float64 `json:"z_score"`    // 0 when the baseline never varies
+}
+
+// ComputeActivitySeries counts, for each of the days up to and including
+// now's, the issues created, closed and turned blocked that day, by diffing
+// the beads file as committed at the end of each day with the day before.
+// snapshotAt reads those commits (see LabelSnapshotFunc); the last day uses
+// issues, so uncommitted changes count today. It returns nil without a
+// snapshotAt, since there is then no history to diff.
+func ComputeActivitySeries(issues []model.Issue, days int, now time.Time, snapshotAt LabelSnapshotFunc) *ActivitySeries {
+	if snapshotAt == nil {
+		return nil
+	}
+	if days <= 0 {
+		days = DefaultActivityDays
+	}
+	y, mo, d := now.Date()
+	firstDay := time.Date(y, mo, d, 0, 0, 0, 0, now.Location()).AddDate(0, 0, -(days - 1))
+	series := &ActivitySeries{
+		Start:   firstDay.Format("2006-01-02"),
+		Created: make([]int, days),
+		Closed:  make([]int, days),
+		Blocked: make([]int, days),
+	}
+
+	prev, known := snapshotAt(firstDay)
+	for day := 0; day < days; day++ {
+		cur, ok := issues, true
+		if day < days-1 {
+			cur, ok = snapshotAt(firstDay.AddDate(0, 0, day+1))
+		}
+		if !ok {
+			series.KnownFrom = day + 1
+			continue
+		}
+		if !known {
+			// The history starts today: nothing before it to diff against
+			prev, known = cur, true
+			series.KnownFrom = day + 1
+			continue
+		}
+
+		before := make(map[string]model.Status, len(prev))
+		for _, iss := range prev {
+			before[iss.ID] = iss.Status
+		}
+		for _, iss := range cur {
+			status, existed := before[iss.ID]
+			if !existed {
+				series.Created[day]++
+			}
+			if iss.Status.IsClosed() && (!existed || !status.IsClosed()) {
+				series.Closed[day]++
+			}
+			if iss.Status == model.StatusBlocked && (!existed || status != model.StatusBlocked) {
+				series.Blocked[day]++
+			}
+		}
+		prev = cur
+	}
+	return series
+}
+
+// Values returns a metric's daily values
+func (s *ActivitySeries) Values(metric string) MetricSeries {
+	values := map[string][]int{
+		ActivityCreated: s.Created,
+		ActivityClosed:  s.Closed,
+		ActivityBlocked: s.Blocked,
+	}[metric]
+	return MetricSeries{Metric: metric, Start: s.Start, Values: values}
+}
+
+// Windows compares each metric's last windowDays with as many earlier
+// windows as fit in the known history. Metrics get no window unless at
+// least two earlier windows are known.
+func (s *ActivitySeries) Windows(windowDays int) []ActivityWindow {
+	days := len(s.Created)
+	if windowDays <= 0 {
+		windowDays = ActivityWindowDays
+	}
+	baselineWindows := (days - s.KnownFrom - windowDays) / windowDays
+	if baselineWindows < 2 {
+		return nil
+	}
+
+	var windows []ActivityWindow
+	for _, metric := range []string{ActivityCreated, ActivityClosed, ActivityBlocked} {
+		values := s.Values(metric).Values
+		sum := func(end int) int {
+			total := 0
+			for _, v := range values[end-windowDays : end] {
+				total += v
+			}
+			return total
+		}
+		w := ActivityWindow{Metric: metric, Current: sum(days), Baseline: make([]int, baselineWindows)}
+		for i := range w.Baseline {
+			w.Baseline[i] = sum(days - windowDays*(baselineWindows-i))
+			w.Mean += float64(w.Baseline[i])
+		}
+		w.Mean /= float64(baselineWindows)
+		for _, b := range w.Baseline {
+			w.StdDev += (float64(b) - w.Mean) * (float64(b) - w.Mean)
+		}
+		w.StdDev = math.Sqrt(w.StdDev / float64(baselineWindows))
+		if w.Mean > 0 {
+			w.ChangePct = round2((float64(w.Current) - w.Mean) / w.Mean * 100)
+		}
+		if w.StdDev > 0 {
+			w.ZScore = round2((float64(w.Current) - w.Mean) / w.StdDev)
+		}
+		w.Mean, w.StdDev = round2(w.Mean), round2(w.StdDev)
+		windows = append(windows, w)
+	}
+	return windows
+}
@@ -0,0 +1,92 @@
+package analysis
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestComputeActivitySeries(t *testing.T) {
+	now := time.Date(2026, 3, 10, 15, 0, 0, 0, time.UTC)
+	end := func(d int) time.Time { return time.Date(2026, 3, d+1, 0, 0, 0, 0, time.UTC) }
+
+	// Mar 6..10; history starts with the commit in force on Mar 5
+	history := map[time.Time][]model.Issue{
+		end(5): {{ID: "A", Status: model.StatusOpen}},
+		end(6): {{ID: "A", Status: model.StatusOpen}, {ID: "B", Status: model.StatusOpen}},
+		end(7): {{ID: "A", Status: model.StatusClosed}, {ID: "B", Status: model.StatusBlocked}},
+		end(8): {{ID: "A", Status: model.StatusClosed}, {ID: "B", Status: model.StatusBlocked}},
+		end(9): {{ID: "A", Status: model.StatusOpen}, {ID: "B", Status: model.StatusOpen}, {ID: "C", Status: model.StatusClosed}},
+	}
+	snapshotAt := func(at time.Time) ([]model.Issue, bool) {
+		issues, ok := history[at]
+		return issues, ok
+	}
+	today := []model.Issue{{ID: "A", Status: model.StatusClosed}, {ID: "B", Status: model.StatusBlocked}, {ID: "C", Status: model.StatusClosed}}
+
+	s := ComputeActivitySeries(today, 5, now, snapshotAt)
+	if s.Start != "2026-03-06" || s.KnownFrom != 0 {
+		t.Errorf("start = %s, known from %d; want 2026-03-06 from day 0", s.Start, s.KnownFrom)
+	}
+	if want := []int{1, 0, 0, 1, 0}; !reflect.DeepEqual(s.Created, want) {
+		t.Errorf("created = %v, want %v", s.Created, want)
+	}
+	// C is created closed; A closes twice, after a reopen
+	if want := []int{0, 1, 0, 1, 1}; !reflect.DeepEqual(s.Closed, want) {
+		t.Errorf("closed = %v, want %v", s.Closed, want)
+	}
+	if want := []int{0, 1, 0, 0, 1}; !reflect.DeepEqual(s.Blocked, want) {
+		t.Errorf("blocked = %v, want %v", s.Blocked, want)
+	}
+
+	// Without the Mar 5 commit, the first day has nothing to diff against
+	delete(history, end(5))
+	s = ComputeActivitySeries(today, 5, now, snapshotAt)
+	if s.KnownFrom != 1 || s.Created[0] != 0 {
+		t.Errorf("known from %d, created %v; the first commit must not count as created", s.KnownFrom, s.Created)
+	}
+
+	if ComputeActivitySeries(today, 5, now, nil) != nil {
+		t.Error("no history to diff should mean no series")
+	}
+}
+
+func TestActivitySeriesWindows(t *testing.T) {
+	week := func(n int) []int { return []int{n, 0, 0, 0, 0, 0, 0} }
+	var closed []int
+	for _, n := range []int{4, 6, 5, 5, 1} {
+		closed = append(closed, week(n)...)
+	}
+	s := &ActivitySeries{Start: "2026-01-01", Created: make([]int, 35), Closed: closed, Blocked: make([]int, 35)}
+
+	windows := s.Windows(7)
+	if len(windows) != 3 {
+		t.Fatalf("want a window per metric, got %+v", windows)
+	}
+	w := windows[1]
+	if w.Metric != ActivityClosed || w.Current != 1 || !reflect.DeepEqual(w.Baseline, []int{4, 6, 5, 5}) {
+		t.Errorf("closed window = %+v", w)
+	}
+	if w.Mean != 5 || w.ChangePct != -80 || w.StdDev != 0.71 || w.ZScore != -5.66 {
+		t.Errorf("closed stats = mean %v, change %v%%, std %v, z %v", w.Mean, w.ChangePct, w.StdDev, w.ZScore)
+	}
+	if got := s.Values(ActivityClosed); got.Metric != ActivityClosed || got.Start != "2026-01-01" || len(got.Values) != 35 {
+		t.Errorf("values = %+v", got)
+	}
+
+	// Two known baseline weeks are the least worth comparing against
+	s.KnownFrom = 7
+	if got := s.Windows(7); len(got) != 3 || len(got[0].Baseline) != 3 {
+		t.Errorf("known from day 7 should leave 3 baseline weeks, got %+v", got)
+	}
+	s.KnownFrom = 14
+	if got := s.Windows(7); len(got) != 3 || len(got[0].Baseline) != 2 {
+		t.Errorf("known from day 14 should leave 2 baseline weeks, got %+v", got)
+	}
+	s.KnownFrom = 15
+	if got := s.Windows(7); got != nil {
+		t.Errorf("one baseline week is too little, got %+v", got)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"sort"
 
 	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"gonum.org/v1/gonum/graph/simple"
 )
 
 // AdvancedInsightsConfig holds caps and limits for advanced analysis features.
@@ -316,6 +317,153 @@ func (a *Analyzer) generateCycleBreakSuggestions(limit int) *CycleBreakResult {
 	}
 }
 
+// maxCycleBreakSteps caps how many cycles CycleBreakPlan walks through.
+const maxCycleBreakSteps = 50
+
+// CycleBreakCandidate is one dependency edge whose removal breaks a cycle.
+type CycleBreakCandidate struct {
+	From            string `json:"from"`             // Issue that declares the dependency
+	To              string `json:"to"`               // Issue it depends on
+	CyclesBroken    int    `json:"cycles_broken"`    // Detected cycles containing this edge
+	RemainingCycles int    `json:"remaining_cycles"` // Cycles still detected after removal
+	Collateral      int    `json:"collateral"`       // Other issues that depend on To
+	Rationale       string `json:"rationale"`
+}
+
+// CycleBreakStep is one detected cycle with its break candidates, best first.
+type CycleBreakStep struct {
+	Cycle      []string              `json:"cycle"` // Each issue depends on the next; the last depends on the first
+	Candidates []CycleBreakCandidate `json:"candidates"`
+}
+
+// CycleBreakPlan walks each detected cycle and ranks the edges that would
+// break it: fewest cycles remaining after removal first, then most cycles
+// broken, then least collateral. Impact is estimated by re-running cycle
+// detection on a copy of the graph without the edge.
+func (a *Analyzer) CycleBreakPlan() []CycleBreakStep {
+	detected := findCyclesSafe(a.g, maxCycleBreakSteps)
+	if len(detected) == 0 {
+		return nil
+	}
+
+	type edgeKey struct{ from, to int64 }
+	var cycles [][]int64
+	edgeCycles := make(map[edgeKey]int)
+	for _, cycle := range detected {
+		nodes := make([]int64, 0, len(cycle))
+		for i, n := range cycle {
+			if i == len(cycle)-1 && len(cycle) > 1 && n.ID() == cycle[0].ID() {
+				break // Closing repeat of the first node
+			}
+			nodes = append(nodes, n.ID())
+		}
+		nodes = a.orientCycle(nodes)
+		if nodes == nil {
+			continue
+		}
+		cycles = append(cycles, nodes)
+		for i := range nodes {
+			edgeCycles[edgeKey{nodes[i], nodes[(i+1)%len(nodes)]}]++
+		}
+	}
+
+	// Work on a copy so impact estimates never touch the shared graph
+	work := simple.NewDirectedGraph()
+	nodes := a.g.Nodes()
+	for nodes.Next() {
+		work.AddNode(simple.Node(nodes.Node().ID()))
+	}
+	edges := a.g.Edges()
+	for edges.Next() {
+		e := edges.Edge()
+		work.SetEdge(work.NewEdge(simple.Node(e.From().ID()), simple.Node(e.To().ID())))
+	}
+
+	remaining := make(map[edgeKey]int)
+	steps := make([]CycleBreakStep, 0, len(cycles))
+	for _, cycle := range cycles {
+		step := CycleBreakStep{Cycle: make([]string, len(cycle))}
+		for i, id := range cycle {
+			step.Cycle[i] = a.nodeToID[id]
+		}
+		for i := range cycle {
+			key := edgeKey{cycle[i], cycle[(i+1)%len(cycle)]}
+			left, ok := remaining[key]
+			if !ok {
+				work.RemoveEdge(key.from, key.to)
+				left = len(findCyclesSafe(work, maxCycleBreakSteps))
+				work.SetEdge(work.NewEdge(simple.Node(key.from), simple.Node(key.to)))
+				remaining[key] = left
+			}
+			to := a.nodeToID[key.to]
+			cand := CycleBreakCandidate{
+				From:            a.nodeToID[key.from],
+				To:              to,
+				CyclesBroken:    edgeCycles[key],
+				RemainingCycles: left,
+				Collateral:      a.countDependents(to) - 1,
+			}
+			cand.Rationale = cycleBreakRationale(cand, len(cycles))
+			step.Candidates = append(step.Candidates, cand)
+		}
+		sort.SliceStable(step.Candidates, func(i, j int) bool {
+			ci, cj := step.Candidates[i], step.Candidates[j]
+			if ci.RemainingCycles != cj.RemainingCycles {
+				return ci.RemainingCycles < cj.RemainingCycles
+			}
+			if ci.CyclesBroken != cj.CyclesBroken {
+				return ci.CyclesBroken > cj.CyclesBroken
+			}
+			if ci.Collateral != cj.Collateral {
+				return ci.Collateral < cj.Collateral
+			}
+			if ci.From != cj.From {
+				return ci.From < cj.From
+			}
+			return ci.To < cj.To
+		})
+		steps = append(steps, step)
+	}
+	return steps
+}
+
+// orientCycle returns the cycle ordered so each node has an edge to the next
+// (cycle detection may walk edges in reverse), or nil if neither order fits.
+func (a *Analyzer) orientCycle(nodes []int64) []int64 {
+	fits := func(order []int64) bool {
+		for i := range order {
+			if !a.g.HasEdgeFromTo(order[i], order[(i+1)%len(order)]) {
+				return false
+			}
+		}
+		return true
+	}
+	if fits(nodes) {
+		return nodes
+	}
+	reversed := make([]int64, len(nodes))
+	for i, id := range nodes {
+		reversed[len(nodes)-1-i] = id
+	}
+	if fits(reversed) {
+		return reversed
+	}
+	return nil
+}
+
+func cycleBreakRationale(c CycleBreakCandidate, total int) string {
+	switch {
+	case c.RemainingCycles == 0:
+		return "Removing this edge leaves the graph acyclic."
+	case c.CyclesBroken > 1:
+		return "Shared by several cycles; breaks more than one at once."
+	case c.RemainingCycles < total:
+		return "Resolves this cycle; other cycles remain elsewhere."
+	default:
+		return "Breaks this cycle, but an alternate path keeps it cyclic."
+	}
+}
+
 // countDependents returns the number of issues that depend on the given issue.
 func (a *Analyzer) countDependents(issueID string) int {
 	count := 0
@@ -423,7 +571,13 @@ func (a *Analyzer) generateTopKSet(k int) *TopKSetResult {
 func (a *Analyzer) computeMarginalUnblocks(issueID string, alreadyCompleted map[string]bool) []string {
 	var unblocks []string
 
-	for _, issue := range a.issueMap {
+	node, ok := a.idToNode[issueID]
+	if !ok {
+		return nil
+	}
+	// Only issues with a blocking edge to issueID can be unblocked by it
+	for _, dependent := range a.g.to(int32(node)) {
+		issue := a.issueMap[a.nodeToID[dependent]]
 		// Skip closed issues
 		if issue.Status == model.StatusClosed {
 			continue
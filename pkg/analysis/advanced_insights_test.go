@@ -1130,3 +1130,83 @@ func TestParallelCutDiamondNoGain(t *testing.T) {
 		t.Errorf("expected gain 1, got %d", insights.ParallelCut.Suggestions[0].ParallelGain)
 	}
 }
+
+func TestCycleBreakPlan(t *testing.T) {
+	dep := func(id string) []*model.Dependency {
+		return []*model.Dependency{{DependsOnID: id, Type: model.DepBlocks}}
+	}
+	// A -> B -> {C, D} -> A share the A->B edge; E <-> G is a separate cycle
+	issues := []model.Issue{
+		{ID: "A", Status: model.StatusOpen, Dependencies: dep("B")},
+		{ID: "B", Status: model.StatusOpen, Dependencies: []*model.Dependency{
+			{DependsOnID: "C", Type: model.DepBlocks},
+			{DependsOnID: "D", Type: model.DepBlocks},
+		}},
+		{ID: "C", Status: model.StatusOpen, Dependencies: dep("A")},
+		{ID: "D", Status: model.StatusOpen, Dependencies: dep("A")},
+		{ID: "E", Status: model.StatusOpen, Dependencies: dep("G")},
+		{ID: "G", Status: model.StatusOpen, Dependencies: dep("E")},
+		{ID: "F", Status: model.StatusOpen, Dependencies: dep("B")},
+	}
+
+	steps := NewAnalyzer(issues).CycleBreakPlan()
+	if len(steps) != 2 {
+		t.Fatalf("expected 2 steps, got %d: %+v", len(steps), steps)
+	}
+
+	var multi, pair *CycleBreakStep
+	for i := range steps {
+		if len(steps[i].Cycle) == 2 {
+			pair = &steps[i]
+		} else {
+			multi = &steps[i]
+		}
+	}
+	if pair == nil || multi == nil {
+		t.Fatalf("expected a two-node step and a longer step: %+v", steps)
+	}
+	if len(pair.Candidates) != 2 || pair.Candidates[0].RemainingCycles != 1 {
+		t.Errorf("E<->G candidates = %+v", pair.Candidates)
+	}
+
+	// Cycle must follow dependency direction: each issue depends on the next
+	deps := map[string][]string{"A": {"B"}, "B": {"C", "D"}, "C": {"A"}, "D": {"A"}}
+	for i, id := range multi.Cycle {
+		next := multi.Cycle[(i+1)%len(multi.Cycle)]
+		found := false
+		for _, d := range deps[id] {
+			if d == next {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("cycle %v: %s does not depend on %s", multi.Cycle, id, next)
+		}
+	}
+
+	best := multi.Candidates[0]
+	if best.From != "A" || best.To != "B" {
+		t.Fatalf("best candidate = %s -> %s, want A -> B (breaks both paths)", best.From, best.To)
+	}
+	if best.RemainingCycles != 1 {
+		t.Errorf("RemainingCycles = %d, want 1 (only E<->G)", best.RemainingCycles)
+	}
+	if best.Collateral != 1 {
+		t.Errorf("Collateral = %d, want 1 (F also depends on B)", best.Collateral)
+	}
+	for _, c := range multi.Candidates[1:] {
+		if c.RemainingCycles <= best.RemainingCycles {
+			t.Errorf("candidate %s -> %s should leave more cycles than the best", c.From, c.To)
+		}
+	}
+}
+
+func TestCycleBreakPlanNoCycles(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "A", Status: model.StatusOpen, Dependencies: []*model.Dependency{{DependsOnID: "B", Type: model.DepBlocks}}},
+		{ID: "B", Status: model.StatusOpen},
+	}
+	if steps := NewAnalyzer(issues).CycleBreakPlan(); len(steps) != 0 {
+		t.Errorf("expected no steps for a DAG, got %+v", steps)
+	}
+}
@@ -0,0 +1,65 @@
+package analysis_test
+
+import (
+	"runtime"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// ============================================================================
+// Scale Benchmarks (size tiers up to 50k issues)
+// ============================================================================
+//
+// Run with: go test -run xxx -bench Scale -benchtime 3x ./pkg/analysis/
+//
+// Besides time per op these report:
+//   - heapMB:  heap still live after a GC with the analyzer and stats held,
+//     i.e. what the analysis adds to the process' resident set
+//   - allocMB: total bytes allocated by one analysis
+
+func BenchmarkScale_Sparse10000(b *testing.B) {
+	benchScale(b, generateSparseGraph(10000))
+}
+
+func BenchmarkScale_Sparse50000(b *testing.B) {
+	benchScale(b, generateSparseGraph(50000))
+}
+
+func BenchmarkScale_Dense10000(b *testing.B) {
+	benchScale(b, generateDenseGraph(10000))
+}
+
+func BenchmarkScale_Wide50000(b *testing.B) {
+	benchScale(b, generateWideGraph(50000))
+}
+
+func BenchmarkScale_Deep50000(b *testing.B) {
+	benchScale(b, generateDeepGraph(50000))
+}
+
+func benchScale(b *testing.B, issues []model.Issue) {
+	b.ResetTimer()
+
+	var heap, alloc float64
+	for i := 0; i < b.N; i++ {
+		var before, after runtime.MemStats
+		runtime.GC()
+		runtime.ReadMemStats(&before)
+
+		an := analysis.NewAnalyzer(issues)
+		stats := an.Analyze()
+
+		b.StopTimer()
+		runtime.GC()
+		runtime.ReadMemStats(&after)
+		heap += float64(int64(after.HeapAlloc)-int64(before.HeapAlloc)) / 1e6
+		alloc += float64(after.TotalAlloc-before.TotalAlloc) / 1e6
+		runtime.KeepAlive(an)
+		runtime.KeepAlive(&stats)
+		b.StartTimer()
+	}
+	b.ReportMetric(heap/float64(b.N), "heapMB")
+	b.ReportMetric(alloc/float64(b.N), "allocMB")
+}
@@ -1,15 +1,13 @@
 package analysis
 
 import (
+	"math"
 	"math/rand"
-	"sort"
+	"runtime"
+	"sync"
 	"time"
 
 	"gonum.org/v1/gonum/graph"
-	"gonum.org/v1/gonum/graph/network"
-	"gonum.org/v1/gonum/graph/simple"
-	"sync"
-	"runtime"
 )
 
 // BetweennessMode specifies how betweenness centrality should be computed.
@@ -40,6 +38,9 @@ type BetweennessResult struct {
 	// SampleSize is the number of pivot nodes used (only for approximate mode)
 	SampleSize int
 
+	// ErrorBound is the expected relative error of approximate scores, 0 when exact
+	ErrorBound float64
+
 	// TotalNodes is the total number of nodes in the graph
 	TotalNodes int
 
@@ -65,12 +66,15 @@ type BetweennessResult struct {
 // References:
 //   - "A Faster Algorithm for Betweenness Centrality" (Brandes, 2001)
 //   - "Approximating Betweenness Centrality" (Bader et al., 2007)
-func ApproxBetweenness(g *simple.DirectedGraph, sampleSize int, seed int64) BetweennessResult {
+func ApproxBetweenness(g graph.Directed, sampleSize int, seed int64) BetweennessResult {
+	return approxBetweenness(g, sampleSize, seed, 0)
+}
+
+func approxBetweenness(g graph.Directed, sampleSize int, seed int64, workers int) BetweennessResult {
 	start := time.Now()
-	nodes := graph.NodesOf(g.Nodes())
-	n := len(nodes)
-	// Ensure deterministic ordering before sampling; gonum's Nodes may be map-backed.
-	sort.Slice(nodes, func(i, j int) bool { return nodes[i].ID() < nodes[j].ID() })
+	// compactOf orders nodes by ID, keeping sampling deterministic
+	c, ids := compactOf(g)
+	n := len(ids)
 
 	result := BetweennessResult{
 		Scores:     make(map[int64]float64),
@@ -86,68 +90,73 @@ func ApproxBetweenness(g *simple.DirectedGraph, sampleSize int, seed int64) Betw
 
 	// For small graphs or when sample size >= node count, use exact algorithm
 	if sampleSize >= n {
-		exact := network.Betweenness(g)
-		result.Scores = exact
-		result.Mode = BetweennessExact
-		result.SampleSize = n
+		result = ParallelBetweenness(g, workers)
 		result.Elapsed = time.Since(start)
 		return result
 	}
 
 	// Sample k random pivot nodes
-	pivots := sampleNodes(nodes, sampleSize, seed)
+	sources := sampleNodes(n, sampleSize, seed)
 
-	// Compute partial betweenness from sampled pivots in parallel
-	partialBC := make(map[int64]float64)
-	var mu sync.Mutex
-	var wg sync.WaitGroup
-
-	// Limit concurrency to avoid excessive goroutines
-	sem := make(chan struct{}, runtime.NumCPU())
-
-	for _, pivot := range pivots {
-		wg.Add(1)
-		go func(p graph.Node) {
-			defer wg.Done()
-			sem <- struct{}{} // Acquire token
-			defer func() { <-sem }()
+	// Scale up: BC_approx = BC_partial * (n / k)
+	// This extrapolates from the sample to the full graph
+	result.Scores = brandesBetweenness(c, ids, sources, workers, float64(n)/float64(sampleSize))
+	result.ErrorBound = ApproxErrorBound(sampleSize, n)
+	result.Elapsed = time.Since(start)
+	return result
+}
 
-			// Compute local contribution
-			localBC := make(map[int64]float64)
-			singleSourceBetweenness(g, p, localBC)
+// ParallelBetweenness computes exact betweenness centrality with Brandes'
+// algorithm, running the single-source passes on a pool of workers
+// goroutines (GOMAXPROCS when workers <= 0). Scores match
+// network.Betweenness, including leaving out zero scores, and do not depend
+// on the number of workers.
+func ParallelBetweenness(g graph.Directed, workers int) BetweennessResult {
+	start := time.Now()
+	c, ids := compactOf(g)
 
-			// Merge into global result
-			mu.Lock()
-			for id, val := range localBC {
-				partialBC[id] += val
-			}
-			mu.Unlock()
-		}(pivot)
+	sources := make([]int32, len(ids))
+	for i := range sources {
+		sources[i] = int32(i)
 	}
-	wg.Wait()
+	return BetweennessResult{
+		Scores:     brandesBetweenness(c, ids, sources, workers, 1),
+		Mode:       BetweennessExact,
+		SampleSize: len(ids),
+		TotalNodes: len(ids),
+		Elapsed:    time.Since(start),
+	}
+}
 
-	// Scale up: BC_approx = BC_partial * (n / k)
-	// This extrapolates from the sample to the full graph
-	scale := float64(n) / float64(sampleSize)
-	for id := range partialBC {
-		partialBC[id] *= scale
+// ApproxErrorBound is the expected relative error of betweenness sampled
+// from sampleSize of nodeCount pivots: 1/sqrt(k), or 0 when every node is a
+// pivot and the result is exact
+func ApproxErrorBound(sampleSize, nodeCount int) float64 {
+	if sampleSize <= 0 || sampleSize >= nodeCount {
+		return 0
 	}
+	return 1 / math.Sqrt(float64(sampleSize))
+}
 
-	result.Scores = partialBC
-	result.Elapsed = time.Since(start)
-	return result
+// SampleSizeForError returns the number of pivots that keeps the expected
+// relative error within bound (e.g. 0.1 needs 100 pivots)
+func SampleSizeForError(bound float64) int {
+	if bound <= 0 {
+		return 0
+	}
+	return int(math.Ceil(1 / (bound * bound)))
 }
 
-// sampleNodes returns a random sample of k nodes from the input slice.
+// sampleNodes returns a random sample of k of the node indexes 0..n-1.
 // Uses Fisher-Yates shuffle for unbiased sampling.
-func sampleNodes(nodes []graph.Node, k int, seed int64) []graph.Node {
-	if k >= len(nodes) {
-		return nodes
+func sampleNodes(n, k int, seed int64) []int32 {
+	shuffled := make([]int32, n)
+	for i := range shuffled {
+		shuffled[i] = int32(i)
+	}
+	if k >= n {
+		return shuffled
 	}
-
-	// Create a copy to avoid modifying the original
-	shuffled := make([]graph.Node, len(nodes))
-	copy(shuffled, nodes)
 
 	// Fisher-Yates shuffle for first k elements
 	rng := rand.New(rand.NewSource(seed))
@@ -159,84 +168,125 @@ func sampleNodes(nodes []graph.Node, k int, seed int64) []graph.Node {
 	return shuffled[:k]
 }
 
-// singleSourceBetweenness computes the betweenness contribution from a single source node.
-// This is the core of Brandes' algorithm, run once per pivot.
-//
-// The algorithm performs BFS from the source and accumulates dependency scores
-// in a reverse topological order traversal.
-func singleSourceBetweenness(g *simple.DirectedGraph, source graph.Node, bc map[int64]float64) {
-	sourceID := source.ID()
-	nodes := graph.NodesOf(g.Nodes())
-
-	// Data structures for Brandes' algorithm
-	sigma := make(map[int64]float64) // Number of shortest paths through node
-	dist := make(map[int64]int)      // Distance from source
-	delta := make(map[int64]float64) // Dependency of source on node
-	pred := make(map[int64][]int64)  // Predecessors on shortest paths
-
-	// Initialization
-	for _, n := range nodes {
-		nid := n.ID()
-		sigma[nid] = 0
-		dist[nid] = -1 // -1 means unreachable
-		delta[nid] = 0
-		pred[nid] = make([]int64, 0)
+// brandesBlockCount caps how many partial score vectors are kept. Sources
+// are split into this many fixed blocks regardless of the worker count, and
+// the partials are summed in block order, so results are reproducible
+// bit for bit on any machine.
+const brandesBlockCount = 64
+
+// brandesBetweenness runs Brandes' single-source pass from each of sources
+// on a worker pool and returns the summed dependencies times scale, keyed
+// by the node IDs in ids, leaving out zeros
+func brandesBetweenness(c *compactGraph, ids []int64, sources []int32, workers int, scale float64) map[int64]float64 {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	blockSize := (len(sources) + brandesBlockCount - 1) / brandesBlockCount
+	if blockSize < 1 {
+		blockSize = 1
+	}
+	blocks := (len(sources) + blockSize - 1) / blockSize
+	if workers > blocks {
+		workers = blocks
 	}
 
-	sigma[sourceID] = 1
-	dist[sourceID] = 0
-
-	// Queue for BFS
-	queue := []int64{sourceID}
+	partials := make([][]float64, blocks)
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			pass := newBrandesPass(len(ids))
+			for b := range jobs {
+				bc := make([]float64, len(ids))
+				end := min((b+1)*blockSize, len(sources))
+				for _, s := range sources[b*blockSize : end] {
+					pass.run(c, s, bc)
+				}
+				partials[b] = bc
+			}
+		}()
+	}
+	for b := 0; b < blocks; b++ {
+		jobs <- b
+	}
+	close(jobs)
+	wg.Wait()
 
-	// Stack for reverse traversal (accumulation)
-	var stack []int64
+	total := make([]float64, len(ids))
+	for _, bc := range partials {
+		for i, v := range bc {
+			total[i] += v
+		}
+	}
+	scores := make(map[int64]float64)
+	for i, v := range total {
+		if v != 0 {
+			scores[ids[i]] = v * scale
+		}
+	}
+	return scores
+}
 
-	// BFS phase
-	for len(queue) > 0 {
-		v := queue[0]
-		queue = queue[1:]
-		stack = append(stack, v)
+// brandesPass holds one worker's scratch space for single-source passes
+type brandesPass struct {
+	sigma []float64 // Number of shortest paths from the source
+	dist  []int     // Distance from the source, -1 if unreached
+	delta []float64 // Dependency of the source on each node
+	pred  [][]int32 // Predecessors on shortest paths
+	stack []int32   // Nodes in order of discovery, for the accumulation
+}
 
-		to := g.From(v) // Outgoing edges
-		var neighbors []int64
-		for to.Next() {
-			neighbors = append(neighbors, to.Node().ID())
-		}
-		// Sort neighbors to ensure deterministic BFS and predecessor order
-		sort.Slice(neighbors, func(i, j int) bool { return neighbors[i] < neighbors[j] })
+func newBrandesPass(n int) *brandesPass {
+	return &brandesPass{
+		sigma: make([]float64, n),
+		dist:  make([]int, n),
+		delta: make([]float64, n),
+		pred:  make([][]int32, n),
+	}
+}
 
-		for _, w := range neighbors {
+// run adds the betweenness contribution of paths from source to bc. This
+// is the core of Brandes' algorithm: a BFS counting shortest paths, then
+// dependency accumulation in reverse BFS order.
+func (p *brandesPass) run(g *compactGraph, source int32, bc []float64) {
+	for i := range p.dist {
+		p.sigma[i] = 0
+		p.dist[i] = -1
+		p.delta[i] = 0
+		p.pred[i] = p.pred[i][:0]
+	}
+	p.stack = p.stack[:0]
+	p.sigma[source] = 1
+	p.dist[source] = 0
+
+	// BFS phase; the stack doubles as the queue since nodes are pushed in
+	// the order they are dequeued
+	p.stack = append(p.stack, source)
+	for head := 0; head < len(p.stack); head++ {
+		v := p.stack[head]
+		for _, w := range g.from(v) {
 			// Path discovery
-			if dist[w] < 0 {
-				dist[w] = dist[v] + 1
-				queue = append(queue, w)
+			if p.dist[w] < 0 {
+				p.dist[w] = p.dist[v] + 1
+				p.stack = append(p.stack, w)
 			}
-
 			// Path counting
-			if dist[w] == dist[v]+1 {
-				sigma[w] += sigma[v]
-				pred[w] = append(pred[w], v)
+			if p.dist[w] == p.dist[v]+1 {
+				p.sigma[w] += p.sigma[v]
+				p.pred[w] = append(p.pred[w], v)
 			}
 		}
 	}
 
 	// Accumulation phase
-	for i := len(stack) - 1; i >= 0; i-- {
-		w := stack[i]
-		if w == sourceID {
-			continue
-		}
-
-		for _, v := range pred[w] {
-			if sigma[w] > 0 {
-				delta[v] += (sigma[v] / sigma[w]) * (1 + delta[w])
-			}
-		}
-
-		if w != sourceID {
-			bc[w] += delta[w]
+	for i := len(p.stack) - 1; i > 0; i-- {
+		w := p.stack[i]
+		for _, v := range p.pred[w] {
+			p.delta[v] += p.sigma[v] / p.sigma[w] * (1 + p.delta[w])
 		}
+		bc[w] += p.delta[w]
 	}
 }
 
@@ -1,9 +1,15 @@
 package analysis
 
 import (
+	"context"
+	"math"
+	"math/rand"
+	"reflect"
 	"testing"
+	"time"
 
 	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"gonum.org/v1/gonum/graph/network"
 )
 
 func TestApproxBetweenness_SmallGraph(t *testing.T) {
@@ -113,6 +119,103 @@ func TestBetweennessMode_ConfigIntegration(t *testing.T) {
 	}
 }
 
+// randomIssueGraph builds n issues with roughly degree random blocking
+// dependencies each, including some cycles
+func randomIssueGraph(n, degree int, seed int64) []model.Issue {
+	rng := rand.New(rand.NewSource(seed))
+	issues := make([]model.Issue, n)
+	for i := range issues {
+		issues[i] = model.Issue{ID: generateID(i), Status: model.StatusOpen}
+	}
+	for i := range issues {
+		for d := 0; d < degree; d++ {
+			if j := rng.Intn(n); j != i {
+				issues[i].Dependencies = append(issues[i].Dependencies, &model.Dependency{
+					IssueID: issues[i].ID, DependsOnID: issues[j].ID, Type: model.DepBlocks,
+				})
+			}
+		}
+	}
+	return issues
+}
+
+func TestParallelBetweenness_MatchesGonum(t *testing.T) {
+	for _, tc := range []struct{ n, degree int }{{1, 0}, {30, 1}, {120, 2}, {200, 4}} {
+		g := NewAnalyzer(randomIssueGraph(tc.n, tc.degree, int64(tc.n))).g
+		want := network.Betweenness(g)
+		for _, workers := range []int{1, 3, 8} {
+			got := ParallelBetweenness(g, workers)
+			if got.Mode != BetweennessExact || got.ErrorBound != 0 {
+				t.Errorf("n=%d: mode %s, error bound %v", tc.n, got.Mode, got.ErrorBound)
+			}
+			if len(got.Scores) != len(want) {
+				t.Errorf("n=%d workers=%d: %d scores, want %d", tc.n, workers, len(got.Scores), len(want))
+			}
+			for id, w := range want {
+				if math.Abs(got.Scores[id]-w) > 1e-9*math.Max(1, w) {
+					t.Errorf("n=%d workers=%d: node %d = %v, want %v", tc.n, workers, id, got.Scores[id], w)
+				}
+			}
+		}
+	}
+}
+
+func TestBetweenness_IndependentOfWorkers(t *testing.T) {
+	g := NewAnalyzer(randomIssueGraph(300, 3, 7)).g
+	exact := ParallelBetweenness(g, 1).Scores
+	approx := approxBetweenness(g, 40, 1, 1).Scores
+	for _, workers := range []int{2, 5, 16} {
+		if got := ParallelBetweenness(g, workers).Scores; !reflect.DeepEqual(got, exact) {
+			t.Errorf("exact scores with %d workers differ from 1 worker", workers)
+		}
+		if got := approxBetweenness(g, 40, 1, workers).Scores; !reflect.DeepEqual(got, approx) {
+			t.Errorf("approximate scores with %d workers differ from 1 worker", workers)
+		}
+	}
+}
+
+func TestApproxBetweenness_ErrorBound(t *testing.T) {
+	g := NewAnalyzer(randomIssueGraph(400, 2, 3)).g
+	result := ApproxBetweenness(g, 100, 1)
+	if result.ErrorBound != 0.1 {
+		t.Errorf("error bound = %v, want 0.1 for 100 pivots", result.ErrorBound)
+	}
+	if ApproxErrorBound(400, 400) != 0 || ApproxErrorBound(0, 400) != 0 {
+		t.Error("a full or empty sample has no sampling error")
+	}
+
+	tests := map[float64]int{0.1: 100, 0.05: 400, 0.3: 12, 0: 0}
+	for bound, want := range tests {
+		if got := SampleSizeForError(bound); got != want {
+			t.Errorf("SampleSizeForError(%v) = %d, want %d", bound, got, want)
+		}
+	}
+}
+
+func TestAnalyzeStatus_ReportsBetweennessQuality(t *testing.T) {
+	issues := randomIssueGraph(150, 2, 11)
+
+	cfg := ConfigForSize(len(issues), 300)
+	cfg.BetweennessTimeout = time.Minute
+	stats := NewAnalyzer(issues).AnalyzeAsyncWithConfig(context.Background(), cfg)
+	stats.WaitForPhase2()
+	if st := stats.Status().Betweenness; st.State != "computed" || st.Mode != "exact" || st.ErrorBound != 0 {
+		t.Errorf("exact status = %+v", st)
+	}
+
+	cfg.BetweennessMode = BetweennessApproximate
+	cfg.BetweennessErrorBound = 0.2
+	stats = NewAnalyzer(issues).AnalyzeAsyncWithConfig(context.Background(), cfg)
+	stats.WaitForPhase2()
+	st := stats.Status().Betweenness
+	if st.State != "approx" || st.Mode != "approximate" || st.Sample != 25 || st.ErrorBound != 0.2 {
+		t.Errorf("approximate status = %+v", st)
+	}
+	if len(stats.Betweenness()) == 0 {
+		t.Error("approximate scores missing")
+	}
+}
+
 // BenchmarkApproxBetweenness_vs_Exact benchmarks approximate vs exact betweenness
 func BenchmarkApproxBetweenness_500nodes_Exact(b *testing.B) {
 	issues := generateChainGraph(500)
@@ -0,0 +1,193 @@
+package analysis
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// BisectMetrics are the replay frame metrics a bisect can search on
+var BisectMetrics = []string{"cycles", "blocked", "open", "actionable", "closed", "edges", "total"}
+
+// Metric returns the named metric of the frame
+func (f ReplayFrame) Metric(name string) (int, error) {
+	switch name {
+	case "cycles":
+		return f.Cycles, nil
+	case "blocked":
+		return f.Blocked, nil
+	case "open":
+		return f.Open, nil
+	case "actionable":
+		return f.Actionable, nil
+	case "closed":
+		return f.Closed, nil
+	case "edges":
+		return f.Edges, nil
+	case "total":
+		return f.Total, nil
+	}
+	return 0, fmt.Errorf("unknown metric %q (want one of: %s)", name, strings.Join(BisectMetrics, ", "))
+}
+
+// BisectRevision is one candidate commit of a bisect
+type BisectRevision struct {
+	SHA       string
+	Timestamp time.Time
+	Message   string
+}
+
+// BisectLoadFunc returns the issues as committed at a revision. A revision
+// without a readable beads file counts as having no issues.
+type BisectLoadFunc func(sha string) ([]model.Issue, error)
+
+// CulpritEdge is a blocking dependency added by the commit a bisect found
+type CulpritEdge struct {
+	From    string `json:"from"` // Blocked issue
+	To      string `json:"to"`   // Its blocker
+	Type    string `json:"type"`
+	InCycle bool   `json:"in_cycle,omitempty"` // Part of a cycle at the commit
+}
+
+// BisectResult is the first revision at which a metric exceeded a threshold
+type BisectResult struct {
+	Metric    string `json:"metric"`
+	Threshold int    `json:"threshold"`
+	// Found is false when the metric never exceeds the threshold in range
+	Found bool `json:"found"`
+	// AtStart is set when the metric already exceeds the threshold at the
+	// first revision, so the crossing happened before the range
+	AtStart      bool          `json:"at_start,omitempty"`
+	Commit       *ReplayFrame  `json:"commit,omitempty"`
+	Before       *ReplayFrame  `json:"before,omitempty"` // The revision preceding Commit
+	Changes      *SnapshotDiff `json:"changes,omitempty"`
+	CulpritEdges []CulpritEdge `json:"culprit_edges,omitempty"`
+	Candidates   int           `json:"candidates"` // Revisions in range
+	Tested       int           `json:"tested"`     // Revisions loaded
+	Last         *ReplayFrame  `json:"last,omitempty"`
+}
+
+// BisectMetric binary-searches revisions (oldest first) for the first one at
+// which metric exceeds threshold, assuming it stays exceeded once crossed, as
+// git bisect does. Only O(log n) revisions are loaded.
+func BisectMetric(revisions []BisectRevision, metric string, threshold int, load BisectLoadFunc) (*BisectResult, error) {
+	if _, err := (ReplayFrame{}).Metric(metric); err != nil {
+		return nil, err
+	}
+	if len(revisions) == 0 {
+		return nil, fmt.Errorf("no revisions to bisect")
+	}
+	result := &BisectResult{Metric: metric, Threshold: threshold, Candidates: len(revisions)}
+
+	type tested struct {
+		frame  ReplayFrame
+		issues []model.Issue
+	}
+	cache := make(map[int]tested)
+	at := func(i int) tested {
+		if t, ok := cache[i]; ok {
+			return t
+		}
+		rev := revisions[i]
+		issues, err := load(rev.SHA)
+		if err != nil {
+			issues = nil
+		}
+		t := tested{frame: NewReplayFrame(issues, rev.SHA, rev.Timestamp, rev.Message), issues: issues}
+		cache[i] = t
+		result.Tested++
+		return t
+	}
+	crossed := func(i int) bool {
+		value, _ := at(i).frame.Metric(metric)
+		return value > threshold
+	}
+
+	lo, hi := 0, len(revisions)-1
+	if crossed(lo) {
+		first := at(lo).frame
+		result.Found, result.AtStart, result.Commit = true, true, &first
+		return result, nil
+	}
+	if !crossed(hi) {
+		last := at(hi).frame
+		result.Last = &last
+		return result, nil
+	}
+	// Invariant: lo is below the threshold, hi above it
+	for hi-lo > 1 {
+		mid := lo + (hi-lo)/2
+		if crossed(mid) {
+			hi = mid
+		} else {
+			lo = mid
+		}
+	}
+
+	before, commit := at(lo), at(hi)
+	result.Found = true
+	result.Before, result.Commit = &before.frame, &commit.frame
+	result.Changes = CompareSnapshots(
+		NewSnapshotAt(before.issues, before.frame.Timestamp, before.frame.Revision),
+		NewSnapshotAt(commit.issues, commit.frame.Timestamp, commit.frame.Revision),
+	)
+	result.CulpritEdges = AddedBlockingEdges(before.issues, commit.issues)
+	return result, nil
+}
+
+// AddedBlockingEdges returns the blocking dependencies in after that are not
+// in before, those on a dependency cycle first
+func AddedBlockingEdges(before, after []model.Issue) []CulpritEdge {
+	type key struct{ from, to string }
+	existing := make(map[key]bool)
+	for _, issue := range before {
+		for _, dep := range issue.Dependencies {
+			if dep != nil && dep.Type.IsBlocking() {
+				existing[key{issue.ID, dep.DependsOnID}] = true
+			}
+		}
+	}
+
+	var added []CulpritEdge
+	for _, issue := range after {
+		for _, dep := range issue.Dependencies {
+			if dep == nil || !dep.Type.IsBlocking() || existing[key{issue.ID, dep.DependsOnID}] {
+				continue
+			}
+			added = append(added, CulpritEdge{From: issue.ID, To: dep.DependsOnID, Type: string(dep.Type)})
+		}
+	}
+	if len(added) == 0 {
+		return nil
+	}
+
+	stats := NewAnalyzer(after).AnalyzeWithConfig(AnalysisConfig{
+		ComputeCycles:    true,
+		CyclesTimeout:    500 * time.Millisecond,
+		MaxCyclesToStore: 100,
+	})
+	onCycle := make(map[key]bool)
+	for _, cycle := range stats.Cycles() {
+		for i := range cycle {
+			a, b := cycle[i], cycle[(i+1)%len(cycle)]
+			onCycle[key{a, b}] = true
+			onCycle[key{b, a}] = true
+		}
+	}
+	for i := range added {
+		added[i].InCycle = onCycle[key{added[i].From, added[i].To}]
+	}
+	sort.SliceStable(added, func(i, j int) bool {
+		if added[i].InCycle != added[j].InCycle {
+			return added[i].InCycle
+		}
+		if added[i].From != added[j].From {
+			return added[i].From < added[j].From
+		}
+		return added[i].To < added[j].To
+	})
+	return added
+}
@@ -0,0 +1,65 @@
+package analysis
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestBisectMetric(t *testing.T) {
+	blocks := func(id string) []*model.Dependency {
+		return []*model.Dependency{{DependsOnID: id, Type: model.DepBlocks}}
+	}
+	// Revision i has i open issues; revision 6 adds B -> A, closing a cycle
+	// with A -> B added at revision 3
+	var revisions []BisectRevision
+	history := make(map[string][]model.Issue)
+	for i := 0; i < 10; i++ {
+		sha := fmt.Sprintf("rev%d", i)
+		revisions = append(revisions, BisectRevision{SHA: sha, Message: sha})
+		issues := []model.Issue{{ID: "A", Status: model.StatusOpen}, {ID: "B", Status: model.StatusOpen}}
+		if i >= 3 {
+			issues[0].Dependencies = blocks("B")
+		}
+		if i >= 6 {
+			issues[1].Dependencies = blocks("A")
+		}
+		for j := 0; j < i; j++ {
+			issues = append(issues, model.Issue{ID: fmt.Sprintf("N%d", j), Status: model.StatusOpen})
+		}
+		history[sha] = issues
+	}
+	load := func(sha string) ([]model.Issue, error) { return history[sha], nil }
+
+	result, err := BisectMetric(revisions, "cycles", 0, load)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.Found || result.Commit.Revision != "rev6" || result.Before.Revision != "rev5" {
+		t.Fatalf("cycles bisect = %+v", result)
+	}
+	if result.Tested >= len(revisions) {
+		t.Errorf("tested %d of %d revisions, want a binary search", result.Tested, len(revisions))
+	}
+	if len(result.CulpritEdges) != 1 || result.CulpritEdges[0] != (CulpritEdge{From: "B", To: "A", Type: "blocks", InCycle: true}) {
+		t.Errorf("culprit edges = %+v", result.CulpritEdges)
+	}
+	if result.Changes == nil || result.Changes.Summary.IssuesAdded != 1 {
+		t.Errorf("changes = %+v", result.Changes)
+	}
+
+	result, _ = BisectMetric(revisions, "open", 100, load)
+	if result.Found || result.Last == nil || result.Last.Open != 11 {
+		t.Errorf("open > 100 should not be found, got %+v", result)
+	}
+
+	result, _ = BisectMetric(revisions, "total", 1, load)
+	if !result.Found || !result.AtStart {
+		t.Errorf("total > 1 holds from the start, got %+v", result)
+	}
+
+	if _, err := BisectMetric(revisions, "velocity", 0, load); err == nil {
+		t.Error("unknown metric should be an error")
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
+	"hash"
 	"sort"
 	"strconv"
 	"sync"
@@ -135,83 +136,91 @@ func ComputeDataHash(issues []model.Issue) string {
 	})
 
 	h := sha256.New()
-	for _, issue := range sorted {
-		// Core identity
-		h.Write([]byte(issue.ID))
-		h.Write([]byte{0})
-
-		// Important scalar fields
-		h.Write([]byte(issue.Title))
-		h.Write([]byte{0})
-		h.Write([]byte(issue.Description))
-		h.Write([]byte{0})
-		h.Write([]byte(issue.Notes))
-		h.Write([]byte{0})
-		h.Write([]byte(issue.Design))
-		h.Write([]byte{0})
-		h.Write([]byte(issue.AcceptanceCriteria))
-		h.Write([]byte{0})
-		h.Write([]byte(issue.Assignee))
-		h.Write([]byte{0})
-		h.Write([]byte(issue.SourceRepo))
-		h.Write([]byte{0})
-		if issue.ExternalRef != nil {
-			h.Write([]byte(*issue.ExternalRef))
-		}
-		h.Write([]byte{0})
-
-		h.Write([]byte(issue.Status))
-		h.Write([]byte{0})
-		h.Write([]byte(issue.IssueType))
-		h.Write([]byte{0})
-
-		// Numeric fields
-		h.Write([]byte(strconv.Itoa(issue.Priority)))
-		h.Write([]byte{0})
-		if issue.EstimatedMinutes != nil {
-			h.Write([]byte(strconv.Itoa(*issue.EstimatedMinutes)))
-		}
-		h.Write([]byte{0})
-		h.Write([]byte(issue.CreatedAt.UTC().Format(time.RFC3339Nano)))
-		h.Write([]byte{0})
-		h.Write([]byte(issue.UpdatedAt.UTC().Format(time.RFC3339Nano)))
-		h.Write([]byte{0})
-		if issue.ClosedAt != nil {
-			h.Write([]byte(issue.ClosedAt.UTC().Format(time.RFC3339Nano)))
+	for i := range sorted {
+		writeIssueHash(h, &sorted[i])
+		h.Write([]byte{1}) // issue separator
+	}
+
+	return hex.EncodeToString(h.Sum(nil))[:16] // Use first 16 chars for brevity
+}
+
+// writeIssueHash feeds the fields of issue that matter to analysis into h
+func writeIssueHash(h hash.Hash, issue *model.Issue) {
+	// Core identity
+	h.Write([]byte(issue.ID))
+	h.Write([]byte{0})
+
+	// Important scalar fields
+	h.Write([]byte(issue.Title))
+	h.Write([]byte{0})
+	h.Write([]byte(issue.Description))
+	h.Write([]byte{0})
+	h.Write([]byte(issue.Notes))
+	h.Write([]byte{0})
+	h.Write([]byte(issue.Design))
+	h.Write([]byte{0})
+	h.Write([]byte(issue.AcceptanceCriteria))
+	h.Write([]byte{0})
+	h.Write([]byte(issue.Assignee))
+	h.Write([]byte{0})
+	h.Write([]byte(issue.SourceRepo))
+	h.Write([]byte{0})
+	if issue.ExternalRef != nil {
+		h.Write([]byte(*issue.ExternalRef))
+	}
+	h.Write([]byte{0})
+
+	h.Write([]byte(issue.Status))
+	h.Write([]byte{0})
+	h.Write([]byte(issue.IssueType))
+	h.Write([]byte{0})
+
+	// Numeric fields
+	h.Write([]byte(strconv.Itoa(issue.Priority)))
+	h.Write([]byte{0})
+	if issue.EstimatedMinutes != nil {
+		h.Write([]byte(strconv.Itoa(*issue.EstimatedMinutes)))
+	}
+	h.Write([]byte{0})
+	if issue.EstimatedPoints != nil {
+		h.Write([]byte(strconv.FormatFloat(*issue.EstimatedPoints, 'g', -1, 64)))
+	}
+	h.Write([]byte{0})
+	h.Write([]byte(issue.CreatedAt.UTC().Format(time.RFC3339Nano)))
+	h.Write([]byte{0})
+	h.Write([]byte(issue.UpdatedAt.UTC().Format(time.RFC3339Nano)))
+	h.Write([]byte{0})
+	if issue.ClosedAt != nil {
+		h.Write([]byte(issue.ClosedAt.UTC().Format(time.RFC3339Nano)))
+	}
+	h.Write([]byte{0})
+
+	// Labels (sorted for determinism)
+	if len(issue.Labels) > 0 {
+		labels := append([]string(nil), issue.Labels...)
+		sort.Strings(labels)
+		for _, lbl := range labels {
+			h.Write([]byte(lbl))
+			h.Write([]byte{0})
 		}
-		h.Write([]byte{0})
-
-		// Labels (sorted for determinism)
-		if len(issue.Labels) > 0 {
-			labels := append([]string(nil), issue.Labels...)
-			sort.Strings(labels)
-			for _, lbl := range labels {
-				h.Write([]byte(lbl))
-				h.Write([]byte{0})
+	}
+	h.Write([]byte{0})
+
+	// Dependencies (sorted)
+	if len(issue.Dependencies) > 0 {
+		deps := make([]string, 0, len(issue.Dependencies))
+		for _, dep := range issue.Dependencies {
+			if dep == nil {
+				continue
 			}
+			deps = append(deps, dep.DependsOnID+":"+string(dep.Type))
 		}
-		h.Write([]byte{0})
-
-		// Dependencies (sorted)
-		if len(issue.Dependencies) > 0 {
-			deps := make([]string, 0, len(issue.Dependencies))
-			for _, dep := range issue.Dependencies {
-				if dep == nil {
-					continue
-				}
-				deps = append(deps, dep.DependsOnID+":"+string(dep.Type))
-			}
-			sort.Strings(deps)
-			for _, dep := range deps {
-				h.Write([]byte(dep))
-				h.Write([]byte{0})
-			}
+		sort.Strings(deps)
+		for _, dep := range deps {
+			h.Write([]byte(dep))
+			h.Write([]byte{0})
 		}
-
-		h.Write([]byte{1}) // issue separator
 	}
-
-	return hex.EncodeToString(h.Sum(nil))[:16] // Use first 16 chars for brevity
 }
 
 // ComputeConfigHash generates a deterministic hash of the analysis configuration.
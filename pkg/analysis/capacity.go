@@ -0,0 +1,467 @@
+package analysis
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"gopkg.in/yaml.v3"
+)
+
+// AgentProfile describes one agent (or person) for capacity simulation
+type AgentProfile struct {
+	Name string `yaml:"name" json:"name"`
+	// Labels the agent can work on; empty means any issue
+	Labels []string `yaml:"labels,omitempty" json:"labels,omitempty"`
+	// HoursPerDay each in-flight issue progresses per day (default 8)
+	HoursPerDay float64 `yaml:"hours_per_day,omitempty" json:"hours_per_day"`
+	// WIPLimit is how many issues the agent works on at once (default 1)
+	WIPLimit int `yaml:"wip_limit,omitempty" json:"wip_limit"`
+}
+
+// AgentProfiles is the agent profile file used by --robot-capacity --agent-profiles
+type AgentProfiles struct {
+	Agents []AgentProfile `yaml:"agents"`
+}
+
+// LoadAgentProfiles reads and validates an agent profile file
+func LoadAgentProfiles(path string) (*AgentProfiles, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading agent profiles: %w", err)
+	}
+	var profiles AgentProfiles
+	if err := yaml.Unmarshal(data, &profiles); err != nil {
+		return nil, fmt.Errorf("parsing agent profiles: %w", err)
+	}
+	if err := profiles.Validate(); err != nil {
+		return nil, err
+	}
+	return &profiles, nil
+}
+
+// Validate checks the profiles and fills in defaults
+func (p *AgentProfiles) Validate() error {
+	if len(p.Agents) == 0 {
+		return fmt.Errorf("agent profiles: at least one agent is required")
+	}
+	seen := make(map[string]bool)
+	for i := range p.Agents {
+		agent := &p.Agents[i]
+		agent.Name = strings.TrimSpace(agent.Name)
+		if agent.Name == "" {
+			agent.Name = fmt.Sprintf("agent-%d", i+1)
+		}
+		if seen[agent.Name] {
+			return fmt.Errorf("agent profiles: duplicate agent %q", agent.Name)
+		}
+		seen[agent.Name] = true
+		if agent.HoursPerDay < 0 || agent.HoursPerDay > 24 {
+			return fmt.Errorf("agent profiles: %s: hours_per_day must be between 0 and 24", agent.Name)
+		}
+		if agent.HoursPerDay == 0 {
+			agent.HoursPerDay = 8
+		}
+		if agent.WIPLimit < 0 {
+			return fmt.Errorf("agent profiles: %s: wip_limit must be non-negative", agent.Name)
+		}
+		if agent.WIPLimit == 0 {
+			agent.WIPLimit = 1
+		}
+	}
+	return nil
+}
+
+// CanWorkOn reports whether the agent has the skills for an issue.
+// Unlabeled issues can be picked up by anyone.
+func (a AgentProfile) CanWorkOn(issue *model.Issue) bool {
+	if len(a.Labels) == 0 || len(issue.Labels) == 0 {
+		return true
+	}
+	for _, label := range issue.Labels {
+		if hasLabel(a.Labels, label) {
+			return true
+		}
+	}
+	return false
+}
+
+// ExampleAgentProfiles returns a commented sample profile file
+func ExampleAgentProfiles() string {
+	return `# Agent profiles for bv --robot-capacity --agent-profiles
+agents:
+  - name: backend-agent
+    labels: [backend, api]   # Omit to take any issue
+    hours_per_day: 8         # Per in-flight issue (default 8)
+    wip_limit: 2             # Issues worked on at once (default 1)
+  - name: frontend-agent
+    labels: [frontend]
+    hours_per_day: 6
+`
+}
+
+// CapacitySimOptions controls a capacity simulation
+type CapacitySimOptions struct {
+	Runs int   // Monte Carlo runs (default 500)
+	Seed int64 // Random seed; the same seed gives the same result (default 1)
+}
+
+// CompletionDistribution is the spread of simulated completion times
+type CompletionDistribution struct {
+	Mean    float64   `json:"mean_days"`
+	Min     float64   `json:"min_days"`
+	P50     float64   `json:"p50_days"`
+	P80     float64   `json:"p80_days"`
+	P95     float64   `json:"p95_days"`
+	Max     float64   `json:"max_days"`
+	P50Date time.Time `json:"p50_date"`
+	P80Date time.Time `json:"p80_date"`
+	P95Date time.Time `json:"p95_date"`
+}
+
+// AgentUtilization is one agent's simulated load, averaged over runs
+type AgentUtilization struct {
+	AgentProfile
+	AvgIssues   float64 `json:"avg_issues"`    // Issues completed per run
+	AvgBusyDays float64 `json:"avg_busy_days"` // Days with at least one issue in flight
+	// Utilization is the share of the agent's WIP slots in use until the
+	// whole simulation completes (0..1)
+	Utilization float64 `json:"utilization"`
+}
+
+// CapacitySimulation is the result of simulating open work on a set of agents
+type CapacitySimulation struct {
+	Runs           int                    `json:"runs"`
+	Seed           int64                  `json:"seed"`
+	OpenIssueCount int                    `json:"open_issue_count"`
+	SimulatedCount int                    `json:"simulated_count"`
+	TotalMinutes   int                    `json:"total_minutes"`
+	Completion     CompletionDistribution `json:"completion"`
+	Agents         []AgentUtilization     `json:"agents"`
+	// Unassignable issues match no agent's labels
+	Unassignable []string `json:"unassignable"`
+	// Stranded issues wait on unassignable work or a dependency cycle
+	Stranded []string `json:"stranded"`
+}
+
+// simTask is an open issue prepared for simulation
+type simTask struct {
+	issue    *model.Issue
+	minutes  float64
+	sigma    float64 // Spread of the duration multiplier
+	blockers []int
+	rank     float64 // Remaining critical path through this task
+	agents   []int   // Agents that can work on it
+}
+
+// SimulateCapacity runs a Monte Carlo simulation of finishing all open issues
+// with the given agents. Each run draws issue durations around their
+// estimates (wider for heuristic guesses than for explicit estimates), then
+// assigns ready issues to free agents with matching skills, longest remaining
+// critical path first. Issues no agent can take are reported, not simulated.
+func SimulateCapacity(issues []model.Issue, stats *GraphStats, profiles *AgentProfiles, opts CapacitySimOptions, now time.Time) CapacitySimulation {
+	if opts.Runs <= 0 {
+		opts.Runs = 500
+	}
+	if opts.Seed == 0 {
+		opts.Seed = 1
+	}
+
+	sim := CapacitySimulation{
+		Runs:         opts.Runs,
+		Seed:         opts.Seed,
+		Agents:       []AgentUtilization{},
+		Unassignable: []string{},
+		Stranded:     []string{},
+	}
+	if profiles == nil || len(profiles.Agents) == 0 {
+		return sim
+	}
+	agents := profiles.Agents
+	for _, agent := range agents {
+		sim.Agents = append(sim.Agents, AgentUtilization{AgentProfile: agent})
+	}
+
+	tasks := buildSimTasks(issues, stats, agents, &sim)
+	sim.SimulatedCount = len(tasks)
+	for _, task := range tasks {
+		sim.TotalMinutes += int(task.minutes)
+	}
+	if len(tasks) == 0 {
+		sim.Completion = completionDistribution([]float64{0}, now)
+		return sim
+	}
+
+	rng := rand.New(rand.NewSource(opts.Seed))
+	makespans := make([]float64, 0, opts.Runs)
+	issuesDone := make([]float64, len(agents))
+	busyDays := make([]float64, len(agents))
+	for range opts.Runs {
+		run := simulateRun(tasks, agents, rng)
+		makespans = append(makespans, run.makespan)
+		for a := range agents {
+			issuesDone[a] += float64(run.done[a])
+			busyDays[a] += run.busy[a]
+			if run.makespan > 0 {
+				sim.Agents[a].Utilization += run.slotBusy[a] / (run.makespan * float64(agents[a].WIPLimit))
+			}
+		}
+	}
+
+	runs := float64(opts.Runs)
+	for a := range sim.Agents {
+		sim.Agents[a].AvgIssues = round2(issuesDone[a] / runs)
+		sim.Agents[a].AvgBusyDays = round2(busyDays[a] / runs)
+		sim.Agents[a].Utilization = round2(sim.Agents[a].Utilization / runs)
+	}
+	sim.Completion = completionDistribution(makespans, now)
+	return sim
+}
+
+// buildSimTasks turns open issues into tasks, recording unassignable and
+// stranded issues on sim. Stranded issues are dropped so runs can finish.
+func buildSimTasks(issues []model.Issue, stats *GraphStats, agents []AgentProfile, sim *CapacitySimulation) []*simTask {
+	medianMinutes := computeMedianEstimatedMinutes(issues)
+	byID := make(map[string]*simTask)
+	var all []*simTask
+	for i := range issues {
+		issue := &issues[i]
+		if issue.Status.IsClosed() || issue.Status.IsTombstone() {
+			continue
+		}
+		sim.OpenIssueCount++
+		task := &simTask{issue: issue, sigma: 0.5}
+		minutes, _ := estimateComplexityMinutes(*issue, stats, medianMinutes)
+		if _, ok := issue.ExplicitEstimateMinutes(); ok {
+			task.sigma = 0.25
+		}
+		task.minutes = float64(max(minutes, 1))
+		for a := range agents {
+			if agents[a].CanWorkOn(issue) {
+				task.agents = append(task.agents, a)
+			}
+		}
+		if len(task.agents) == 0 {
+			sim.Unassignable = append(sim.Unassignable, issue.ID)
+		}
+		byID[issue.ID] = task
+		all = append(all, task)
+	}
+
+	// Strand everything downstream of unassignable work; whatever never
+	// becomes schedulable after that is stuck in a cycle
+	schedulable := make(map[string]bool)
+	for changed := true; changed; {
+		changed = false
+		for _, task := range all {
+			if schedulable[task.issue.ID] || len(task.agents) == 0 {
+				continue
+			}
+			ready := true
+			for _, dep := range task.issue.Dependencies {
+				if dep == nil || !dep.Type.IsBlocking() {
+					continue
+				}
+				if _, open := byID[dep.DependsOnID]; open && !schedulable[dep.DependsOnID] {
+					ready = false
+					break
+				}
+			}
+			if ready {
+				schedulable[task.issue.ID] = true
+				changed = true
+			}
+		}
+	}
+
+	var tasks []*simTask
+	index := make(map[string]int)
+	for _, task := range all {
+		if !schedulable[task.issue.ID] {
+			if len(task.agents) > 0 {
+				sim.Stranded = append(sim.Stranded, task.issue.ID)
+			}
+			continue
+		}
+		index[task.issue.ID] = len(tasks)
+		tasks = append(tasks, task)
+	}
+	dependents := make([][]int, len(tasks))
+	for i, task := range tasks {
+		for _, dep := range task.issue.Dependencies {
+			if dep == nil || !dep.Type.IsBlocking() {
+				continue
+			}
+			if j, ok := index[dep.DependsOnID]; ok {
+				task.blockers = append(task.blockers, j)
+				dependents[j] = append(dependents[j], i)
+			}
+		}
+	}
+
+	// Rank by the longest chain of work this task holds up. Schedulable
+	// tasks form a DAG, so the recursion terminates.
+	var rank func(i int) float64
+	done := make([]bool, len(tasks))
+	rank = func(i int) float64 {
+		if done[i] {
+			return tasks[i].rank
+		}
+		longest := 0.0
+		for _, j := range dependents[i] {
+			longest = max(longest, rank(j))
+		}
+		tasks[i].rank = tasks[i].minutes + longest
+		done[i] = true
+		return tasks[i].rank
+	}
+	for i := range tasks {
+		rank(i)
+	}
+
+	sort.Strings(sim.Unassignable)
+	sort.Strings(sim.Stranded)
+	return tasks
+}
+
+type simRun struct {
+	makespan float64   // Days until the last issue finishes
+	done     []int     // Issues completed per agent
+	busy     []float64 // Days each agent had work in flight
+	slotBusy []float64 // Slot-days each agent spent working
+}
+
+type simSlot struct {
+	task   int
+	finish float64
+}
+
+// simulateRun plays out one run as a discrete event simulation in days
+func simulateRun(tasks []*simTask, agents []AgentProfile, rng *rand.Rand) simRun {
+	run := simRun{
+		done:     make([]int, len(agents)),
+		busy:     make([]float64, len(agents)),
+		slotBusy: make([]float64, len(agents)),
+	}
+
+	duration := make([]float64, len(tasks))
+	waiting := make([]int, len(tasks))
+	for i, task := range tasks {
+		// Log-normal noise with a median of 1, so estimates are the typical case
+		duration[i] = task.minutes * math.Exp(rng.NormFloat64()*task.sigma)
+		waiting[i] = len(task.blockers)
+	}
+	dependents := make([][]int, len(tasks))
+	var ready []int
+	for i, task := range tasks {
+		for _, b := range task.blockers {
+			dependents[b] = append(dependents[b], i)
+		}
+		if waiting[i] == 0 {
+			ready = append(ready, i)
+		}
+	}
+
+	inFlight := make([][]simSlot, len(agents))
+	now := 0.0
+	remaining := len(tasks)
+	for remaining > 0 {
+		// Hand ready work to free agents, most critical first
+		sort.Slice(ready, func(i, j int) bool {
+			a, b := tasks[ready[i]], tasks[ready[j]]
+			if a.rank != b.rank {
+				return a.rank > b.rank
+			}
+			return a.issue.ID < b.issue.ID
+		})
+		for k := 0; k < len(ready); {
+			t := ready[k]
+			best := -1
+			for _, a := range tasks[t].agents {
+				if len(inFlight[a]) >= agents[a].WIPLimit {
+					continue
+				}
+				if best < 0 || len(inFlight[a]) < len(inFlight[best]) {
+					best = a
+				}
+			}
+			if best < 0 {
+				k++
+				continue
+			}
+			days := duration[t] / (agents[best].HoursPerDay * 60)
+			inFlight[best] = append(inFlight[best], simSlot{task: t, finish: now + days})
+			ready = append(ready[:k], ready[k+1:]...)
+		}
+
+		// Advance to the next completion
+		next := math.Inf(1)
+		for a := range inFlight {
+			for _, slot := range inFlight[a] {
+				next = min(next, slot.finish)
+			}
+		}
+		if math.IsInf(next, 1) {
+			break // Nothing in flight and nothing assignable; cannot happen for schedulable tasks
+		}
+		for a := range inFlight {
+			if len(inFlight[a]) > 0 {
+				run.busy[a] += next - now
+				run.slotBusy[a] += float64(len(inFlight[a])) * (next - now)
+			}
+			kept := inFlight[a][:0]
+			for _, slot := range inFlight[a] {
+				if slot.finish > next {
+					kept = append(kept, slot)
+					continue
+				}
+				run.done[a]++
+				remaining--
+				for _, d := range dependents[slot.task] {
+					waiting[d]--
+					if waiting[d] == 0 {
+						ready = append(ready, d)
+					}
+				}
+			}
+			inFlight[a] = kept
+		}
+		now = next
+	}
+	run.makespan = now
+	return run
+}
+
+func completionDistribution(days []float64, now time.Time) CompletionDistribution {
+	sorted := append([]float64(nil), days...)
+	sort.Float64s(sorted)
+	sum := 0.0
+	for _, d := range sorted {
+		sum += d
+	}
+	percentile := func(p float64) float64 {
+		idx := int(math.Ceil(p*float64(len(sorted)))) - 1
+		return sorted[max(idx, 0)]
+	}
+	dist := CompletionDistribution{
+		Mean: round2(sum / float64(len(sorted))),
+		Min:  round2(sorted[0]),
+		P50:  round2(percentile(0.50)),
+		P80:  round2(percentile(0.80)),
+		P95:  round2(percentile(0.95)),
+		Max:  round2(sorted[len(sorted)-1]),
+	}
+	dist.P50Date = now.Add(durationDays(dist.P50))
+	dist.P80Date = now.Add(durationDays(dist.P80))
+	dist.P95Date = now.Add(durationDays(dist.P95))
+	return dist
+}
+
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
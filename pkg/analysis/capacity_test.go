@@ -0,0 +1,158 @@
+package analysis
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"gopkg.in/yaml.v3"
+)
+
+func capacityTestIssues() []model.Issue {
+	minutes := func(n int) *int { return &n }
+	blockedBy := func(id, on string) []*model.Dependency {
+		return []*model.Dependency{{IssueID: id, DependsOnID: on, Type: model.DepBlocks}}
+	}
+	return []model.Issue{
+		{ID: "be-1", Status: model.StatusOpen, Labels: []string{"backend"}, EstimatedMinutes: minutes(480)},
+		{ID: "be-2", Status: model.StatusOpen, Labels: []string{"backend"}, EstimatedMinutes: minutes(480), Dependencies: blockedBy("be-2", "be-1")},
+		{ID: "fe-1", Status: model.StatusOpen, Labels: []string{"frontend"}, EstimatedMinutes: minutes(240)},
+		{ID: "ops-1", Status: model.StatusOpen, Labels: []string{"ops"}, EstimatedMinutes: minutes(60)},
+		{ID: "ops-2", Status: model.StatusOpen, EstimatedMinutes: minutes(60), Dependencies: blockedBy("ops-2", "ops-1")},
+		{ID: "misc", Status: model.StatusOpen, EstimatedMinutes: minutes(120)},
+		{ID: "done", Status: model.StatusClosed, Labels: []string{"backend"}},
+	}
+}
+
+func testProfiles(t *testing.T, agents ...AgentProfile) *AgentProfiles {
+	t.Helper()
+	profiles := &AgentProfiles{Agents: agents}
+	if err := profiles.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	return profiles
+}
+
+func TestSimulateCapacity_Skills(t *testing.T) {
+	profiles := testProfiles(t,
+		AgentProfile{Name: "be", Labels: []string{"backend"}},
+		AgentProfile{Name: "fe", Labels: []string{"frontend"}},
+	)
+	now := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+	sim := SimulateCapacity(capacityTestIssues(), nil, profiles, CapacitySimOptions{Runs: 200}, now)
+
+	if sim.OpenIssueCount != 6 || sim.SimulatedCount != 4 {
+		t.Errorf("open/simulated = %d/%d, want 6/4", sim.OpenIssueCount, sim.SimulatedCount)
+	}
+	if strings.Join(sim.Unassignable, ",") != "ops-1" || strings.Join(sim.Stranded, ",") != "ops-2" {
+		t.Errorf("unassignable %v, stranded %v; want [ops-1], [ops-2]", sim.Unassignable, sim.Stranded)
+	}
+
+	// The backend chain (two 1-day issues, one agent) bounds completion
+	c := sim.Completion
+	if c.P50 < 1 || c.P50 > 4 {
+		t.Errorf("p50 = %.2f days, want around 2", c.P50)
+	}
+	if !(c.Min <= c.P50 && c.P50 <= c.P80 && c.P80 <= c.P95 && c.P95 <= c.Max) {
+		t.Errorf("percentiles out of order: %+v", c)
+	}
+	if !c.P80Date.After(now) {
+		t.Errorf("p80 date %v should be after now", c.P80Date)
+	}
+
+	be, fe := sim.Agents[0], sim.Agents[1]
+	if be.AvgIssues < 2 || fe.AvgIssues < 1 || be.AvgIssues+fe.AvgIssues != 4 {
+		t.Errorf("avg issues be=%.2f fe=%.2f, want the backend pair on be and the rest shared", be.AvgIssues, fe.AvgIssues)
+	}
+	if be.Utilization <= fe.Utilization || be.Utilization > 1 {
+		t.Errorf("utilization be=%.2f fe=%.2f, want the backend agent busier", be.Utilization, fe.Utilization)
+	}
+}
+
+func TestSimulateCapacity_DeterministicAndScales(t *testing.T) {
+	now := time.Now()
+	issues := capacityTestIssues()
+	one := testProfiles(t, AgentProfile{Name: "solo"})
+	a := SimulateCapacity(issues, nil, one, CapacitySimOptions{Runs: 100, Seed: 7}, now)
+	b := SimulateCapacity(issues, nil, one, CapacitySimOptions{Runs: 100, Seed: 7}, now)
+	if a.Completion.P80 != b.Completion.P80 || a.Completion.Mean != b.Completion.Mean {
+		t.Errorf("same seed should give the same result: %+v vs %+v", a.Completion, b.Completion)
+	}
+	if len(a.Unassignable) != 0 || a.SimulatedCount != 6 {
+		t.Errorf("a generalist should take everything: %+v", a)
+	}
+	// One agent does everything serially: utilization is full
+	if a.Agents[0].Utilization != 1 {
+		t.Errorf("solo utilization = %.2f, want 1", a.Agents[0].Utilization)
+	}
+
+	three := testProfiles(t, AgentProfile{Name: "x"}, AgentProfile{Name: "y"}, AgentProfile{Name: "z", WIPLimit: 2})
+	c := SimulateCapacity(issues, nil, three, CapacitySimOptions{Runs: 100, Seed: 7}, now)
+	if c.Completion.P50 >= a.Completion.P50 {
+		t.Errorf("more agents should finish sooner: p50 %.2f vs %.2f", c.Completion.P50, a.Completion.P50)
+	}
+
+	slow := testProfiles(t, AgentProfile{Name: "solo", HoursPerDay: 4})
+	s := SimulateCapacity(issues, nil, slow, CapacitySimOptions{Runs: 100, Seed: 7}, now)
+	if s.Completion.P50 <= a.Completion.P50 {
+		t.Errorf("fewer hours per day should take longer: p50 %.2f vs %.2f", s.Completion.P50, a.Completion.P50)
+	}
+}
+
+func TestLoadAgentProfiles(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "agents.yaml")
+	if err := os.WriteFile(path, []byte("agents:\n  - name: a\n    labels: [api]\n  - hours_per_day: 4\n    wip_limit: 3\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	profiles, err := LoadAgentProfiles(path)
+	if err != nil {
+		t.Fatalf("LoadAgentProfiles failed: %v", err)
+	}
+	a, b := profiles.Agents[0], profiles.Agents[1]
+	if a.HoursPerDay != 8 || a.WIPLimit != 1 {
+		t.Errorf("defaults not applied: %+v", a)
+	}
+	if b.Name != "agent-2" || b.HoursPerDay != 4 || b.WIPLimit != 3 {
+		t.Errorf("unexpected second agent: %+v", b)
+	}
+
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{"empty", "agents: []\n", "at least one agent"},
+		{"duplicate", "agents:\n  - name: a\n  - name: a\n", "duplicate agent"},
+		{"hours", "agents:\n  - name: a\n    hours_per_day: 30\n", "hours_per_day"},
+		{"wip", "agents:\n  - name: a\n    wip_limit: -1\n", "wip_limit"},
+		{"yaml", "agents: [", "parsing agent profiles"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := os.WriteFile(path, []byte(tt.content), 0644); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := LoadAgentProfiles(path); err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+
+	if _, err := LoadAgentProfiles(filepath.Join(dir, "missing.yaml")); err == nil {
+		t.Error("missing file should be an error")
+	}
+}
+
+func TestExampleAgentProfiles(t *testing.T) {
+	var profiles AgentProfiles
+	if err := yaml.Unmarshal([]byte(ExampleAgentProfiles()), &profiles); err != nil {
+		t.Fatalf("ExampleAgentProfiles() returned invalid YAML: %v", err)
+	}
+	if err := profiles.Validate(); err != nil {
+		t.Errorf("ExampleAgentProfiles() should be valid: %v", err)
+	}
+}
@@ -0,0 +1,319 @@
+package analysis
+
+import (
+	"sort"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// Clustering groups issues into communities of densely linked work with
+// Louvain modularity optimization over the blocking graph (direction
+// ignored), so the workstreams a backlog really has show up even where no
+// label names them.
+type Clustering struct {
+	Method     string    `json:"method"`     // "louvain"
+	Modularity float64   `json:"modularity"` // Near 0: no real structure; above ~0.3: clear workstreams
+	Clusters   []Cluster `json:"clusters"`   // Largest first, IDs from 1
+	// Assignments maps each issue with a blocking link to its cluster
+	Assignments map[string]int `json:"assignments"`
+	// Unclustered counts issues with no blocking link either way
+	Unclustered       int           `json:"unclustered"`
+	InterClusterEdges []ClusterEdge `json:"inter_cluster_edges"` // Most edges first
+}
+
+// Cluster is one community of issues
+type Cluster struct {
+	ID      int      `json:"id"`
+	Size    int      `json:"size"`
+	Open    int      `json:"open"`             // Members not yet closed
+	Hub     string   `json:"hub"`              // Member with the most links inside the cluster
+	Labels  []string `json:"labels,omitempty"` // Most common member labels, at most 3
+	Members []string `json:"members"`          // Sorted by ID
+}
+
+// ClusterEdge counts the blocking edges from one cluster into another
+type ClusterEdge struct {
+	From  int `json:"from"` // Cluster holding the blockers
+	To    int `json:"to"`   // Cluster waiting on them
+	Edges int `json:"edges"`
+}
+
+// clusterMaxLabels bounds the labels reported per cluster
+const clusterMaxLabels = 3
+
+// Clusters detects the communities of the analyzer's blocking graph.
+func (a *Analyzer) Clusters() *Clustering {
+	start, nbr := a.g.undirected()
+	community, q := louvain(start, nbr)
+
+	// Number the communities of linked issues largest first, ties by their
+	// smallest member ID
+	members := make(map[int][]string)
+	unclustered := 0
+	for v, c := range community {
+		if start[v+1] == start[v] {
+			unclustered++
+			continue
+		}
+		members[c] = append(members[c], a.nodeToID[v])
+	}
+	order := make([]int, 0, len(members))
+	for c, ids := range members {
+		sort.Strings(ids)
+		order = append(order, c)
+	}
+	sort.Slice(order, func(i, j int) bool {
+		mi, mj := members[order[i]], members[order[j]]
+		if len(mi) != len(mj) {
+			return len(mi) > len(mj)
+		}
+		return mi[0] < mj[0]
+	})
+
+	result := &Clustering{
+		Method:            "louvain",
+		Modularity:        round2(q),
+		Clusters:          make([]Cluster, 0, len(order)),
+		Assignments:       make(map[string]int),
+		Unclustered:       unclustered,
+		InterClusterEdges: []ClusterEdge{},
+	}
+	clusterOf := make(map[int]int, len(order))
+	for i, c := range order {
+		clusterOf[c] = i + 1
+		for _, id := range members[c] {
+			result.Assignments[id] = i + 1
+		}
+	}
+
+	for i, c := range order {
+		cluster := Cluster{ID: i + 1, Size: len(members[c]), Members: members[c]}
+		labelCount := make(map[string]int)
+		hubLinks := -1
+		for _, id := range members[c] {
+			issue := a.issueMap[id]
+			if issue.Status != model.StatusClosed {
+				cluster.Open++
+			}
+			for _, l := range issue.Labels {
+				labelCount[l]++
+			}
+			v := a.idToNode[id]
+			links := 0
+			for _, u := range nbr[start[v]:start[v+1]] {
+				if community[u] == c {
+					links++
+				}
+			}
+			if links > hubLinks {
+				cluster.Hub, hubLinks = id, links
+			}
+		}
+		cluster.Labels = topLabels(labelCount, clusterMaxLabels)
+		result.Clusters = append(result.Clusters, cluster)
+	}
+
+	// An edge u -> v means u waits on v, so it runs from v's cluster to u's
+	crossing := make(map[[2]int]int)
+	for u := 0; u < a.g.Len(); u++ {
+		for _, v := range a.g.from(int32(u)) {
+			if from, to := clusterOf[community[v]], clusterOf[community[u]]; from != to {
+				crossing[[2]int{from, to}]++
+			}
+		}
+	}
+	for pair, n := range crossing {
+		result.InterClusterEdges = append(result.InterClusterEdges, ClusterEdge{From: pair[0], To: pair[1], Edges: n})
+	}
+	sort.Slice(result.InterClusterEdges, func(i, j int) bool {
+		ei, ej := result.InterClusterEdges[i], result.InterClusterEdges[j]
+		if ei.Edges != ej.Edges {
+			return ei.Edges > ej.Edges
+		}
+		if ei.From != ej.From {
+			return ei.From < ej.From
+		}
+		return ei.To < ej.To
+	})
+	return result
+}
+
+// topLabels returns up to limit labels by count, ties alphabetically
+func topLabels(count map[string]int, limit int) []string {
+	labels := make([]string, 0, len(count))
+	for l := range count {
+		labels = append(labels, l)
+	}
+	sort.Slice(labels, func(i, j int) bool {
+		if count[labels[i]] != count[labels[j]] {
+			return count[labels[i]] > count[labels[j]]
+		}
+		return labels[i] < labels[j]
+	})
+	if len(labels) > limit {
+		labels = labels[:limit]
+	}
+	return labels
+}
+
+// louvainEdge is a weighted link in one level of the Louvain hierarchy
+type louvainEdge struct {
+	to int
+	w  float64
+}
+
+// louvainMaxPasses bounds the local-moving passes per level; later passes
+// only shuffle a handful of boundary nodes
+const louvainMaxPasses = 32
+
+// louvain partitions an undirected CSR adjacency into communities of high
+// modularity and returns each node's community and the modularity reached.
+// Each level moves nodes, in index order, to the neighboring community with
+// the best gain, then merges every community into a single node for the
+// next level, until a level merges nothing. Ties keep a node where it is or
+// go to the lower community, so the result is deterministic.
+func louvain(start, nbr []int32) ([]int, float64) {
+	n := len(start) - 1
+	adj := make([][]louvainEdge, n)
+	for v := 0; v < n; v++ {
+		for _, u := range nbr[start[v]:start[v+1]] {
+			adj[v] = append(adj[v], louvainEdge{to: int(u), w: 1})
+		}
+	}
+	self := make([]float64, n) // Weight of edges folded inside a node
+	membership := make([]int, n)
+	for v := range membership {
+		membership[v] = v
+	}
+
+	for {
+		comm, merged := louvainLevel(adj, self)
+		if !merged {
+			break
+		}
+		// Renumber densely and fold each community into one node
+		dense := make(map[int]int)
+		for _, c := range comm {
+			if _, ok := dense[c]; !ok {
+				dense[c] = len(dense)
+			}
+		}
+		nextSelf := make([]float64, len(dense))
+		weights := make([]map[int]float64, len(dense))
+		for i := range weights {
+			weights[i] = make(map[int]float64)
+		}
+		for i, edges := range adj {
+			ci := dense[comm[i]]
+			nextSelf[ci] += self[i]
+			for _, e := range edges {
+				if cj := dense[comm[e.to]]; cj == ci {
+					nextSelf[ci] += e.w / 2 // Each inner edge is seen from both ends
+				} else {
+					weights[ci][cj] += e.w
+				}
+			}
+		}
+		adj = make([][]louvainEdge, len(dense))
+		for ci, w := range weights {
+			for cj, weight := range w {
+				adj[ci] = append(adj[ci], louvainEdge{to: cj, w: weight})
+			}
+			sort.Slice(adj[ci], func(i, j int) bool { return adj[ci][i].to < adj[ci][j].to })
+		}
+		self = nextSelf
+		for v := range membership {
+			membership[v] = dense[comm[membership[v]]]
+		}
+	}
+
+	// Q = sum over communities of (inner degree / 2m) - (total degree / 2m)^2
+	m2 := float64(len(nbr))
+	if m2 == 0 {
+		return membership, 0
+	}
+	inner := make(map[int]float64)
+	total := make(map[int]float64)
+	for v := 0; v < n; v++ {
+		c := membership[v]
+		total[c] += float64(start[v+1] - start[v])
+		for _, u := range nbr[start[v]:start[v+1]] {
+			if membership[u] == c {
+				inner[c]++
+			}
+		}
+	}
+	q := 0.0
+	for c, tot := range total {
+		q += inner[c]/m2 - (tot/m2)*(tot/m2)
+	}
+	return membership, q
+}
+
+// louvainLevel runs local moving on one level and reports whether any two
+// nodes ended up sharing a community
+func louvainLevel(adj [][]louvainEdge, self []float64) ([]int, bool) {
+	n := len(adj)
+	degree := make([]float64, n)
+	m2 := 0.0
+	for i, edges := range adj {
+		degree[i] = 2 * self[i]
+		for _, e := range edges {
+			degree[i] += e.w
+		}
+		m2 += degree[i]
+	}
+	comm := make([]int, n)
+	tot := make([]float64, n)
+	for i := range comm {
+		comm[i] = i
+		tot[i] = degree[i]
+	}
+	if m2 == 0 {
+		return comm, false
+	}
+
+	links := make(map[int]float64)
+	for pass := 0; pass < louvainMaxPasses; pass++ {
+		moved := false
+		for i := 0; i < n; i++ {
+			if len(adj[i]) == 0 {
+				continue
+			}
+			clear(links)
+			for _, e := range adj[i] {
+				links[comm[e.to]] += e.w
+			}
+			current := comm[i]
+			tot[current] -= degree[i]
+
+			best := current
+			bestGain := links[current] - tot[current]*degree[i]/m2
+			candidates := make([]int, 0, len(links))
+			for c := range links {
+				candidates = append(candidates, c)
+			}
+			sort.Ints(candidates)
+			for _, c := range candidates {
+				if gain := links[c] - tot[c]*degree[i]/m2; gain > bestGain+1e-12 {
+					best, bestGain = c, gain
+				}
+			}
+
+			comm[i] = best
+			tot[best] += degree[i]
+			if best != current {
+				moved = true
+			}
+		}
+		if !moved {
+			break
+		}
+	}
+
+	seen := make(map[int]bool, n)
+	for _, c := range comm {
+		seen[c] = true
+	}
+	return comm, len(seen) < n
+}
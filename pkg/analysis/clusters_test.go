@@ -0,0 +1,73 @@
+package analysis
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestClusters(t *testing.T) {
+	blocks := func(ids ...string) []*model.Dependency {
+		deps := make([]*model.Dependency, len(ids))
+		for i, id := range ids {
+			deps[i] = &model.Dependency{DependsOnID: id, Type: model.DepBlocks}
+		}
+		return deps
+	}
+	// Two tight workstreams joined by a single edge (c2 waits on a3), plus
+	// an issue linked to nothing
+	issues := []model.Issue{
+		{ID: "a1", Status: model.StatusOpen, Labels: []string{"api"}},
+		{ID: "a2", Status: model.StatusOpen, Labels: []string{"api"}, Dependencies: blocks("a1")},
+		{ID: "a3", Status: model.StatusClosed, Dependencies: blocks("a1", "a2")},
+		{ID: "a4", Status: model.StatusOpen, Labels: []string{"api", "db"}, Dependencies: blocks("a1", "a3")},
+		{ID: "c1", Status: model.StatusOpen, Labels: []string{"ui"}},
+		{ID: "c2", Status: model.StatusOpen, Dependencies: blocks("c1", "a3")},
+		{ID: "c3", Status: model.StatusOpen, Dependencies: blocks("c1", "c2")},
+		{ID: "lone", Status: model.StatusOpen},
+	}
+	result := NewAnalyzer(issues).Clusters()
+
+	if len(result.Clusters) != 2 {
+		t.Fatalf("clusters = %+v, want 2", result.Clusters)
+	}
+	api, ui := result.Clusters[0], result.Clusters[1]
+	if !slices.Equal(api.Members, []string{"a1", "a2", "a3", "a4"}) || api.ID != 1 || api.Open != 3 {
+		t.Errorf("first cluster = %+v, want a1..a4 with 3 open", api)
+	}
+	if api.Hub != "a1" && api.Hub != "a3" {
+		t.Errorf("hub = %s, want a1 or a3 (3 inner links each)", api.Hub)
+	}
+	if !slices.Equal(api.Labels, []string{"api", "db"}) {
+		t.Errorf("labels = %v, want api, db", api.Labels)
+	}
+	if !slices.Equal(ui.Members, []string{"c1", "c2", "c3"}) || ui.ID != 2 {
+		t.Errorf("second cluster = %+v, want c1..c3", ui)
+	}
+	if result.Assignments["c2"] != 2 || result.Assignments["a2"] != 1 {
+		t.Errorf("assignments = %v", result.Assignments)
+	}
+	if _, ok := result.Assignments["lone"]; ok || result.Unclustered != 1 {
+		t.Errorf("an unlinked issue should be unclustered, got %v / %d", result.Assignments, result.Unclustered)
+	}
+	if !slices.Equal(result.InterClusterEdges, []ClusterEdge{{From: 1, To: 2, Edges: 1}}) {
+		t.Errorf("inter-cluster edges = %+v, want 1 -> 2 once", result.InterClusterEdges)
+	}
+	if result.Modularity <= 0.2 {
+		t.Errorf("modularity = %v, want clear structure", result.Modularity)
+	}
+
+	// The same input always gives the same clustering
+	again := NewAnalyzer(issues).Clusters()
+	if !slices.Equal(again.Clusters[0].Members, api.Members) || again.Modularity != result.Modularity {
+		t.Error("clustering is not deterministic")
+	}
+}
+
+func TestClusters_NoEdges(t *testing.T) {
+	result := NewAnalyzer([]model.Issue{{ID: "a"}, {ID: "b"}}).Clusters()
+	if len(result.Clusters) != 0 || result.Unclustered != 2 || result.Modularity != 0 {
+		t.Errorf("clustering without edges = %+v", result)
+	}
+}
@@ -0,0 +1,565 @@
+package analysis
+
+import (
+	"math"
+	"sort"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+// compactGraph is the blocking-dependency graph in compressed sparse row
+// (CSR) form. Node IDs are the dense indexes 0..n-1, and each node's out-
+// and in-neighbors are a sorted run of one shared int32 slice, so a 50k
+// issue graph costs a few hundred kilobytes instead of two maps per node.
+//
+// It implements graph.Directed so gonum algorithms and the rest of the
+// package can walk it as before; the analysis hot paths use the slices
+// directly.
+type compactGraph struct {
+	outStart []int32 // out[outStart[u]:outStart[u+1]] are the nodes u depends on
+	out      []int32
+	inStart  []int32 // in[inStart[v]:inStart[v+1]] are the nodes depending on v
+	in       []int32
+}
+
+// compactEdge is a directed edge between dense node indexes
+type compactEdge struct{ from, to int32 }
+
+// newCompactGraph builds a graph of n nodes. Duplicate edges and self
+// loops are dropped.
+func newCompactGraph(n int, edges []compactEdge) *compactGraph {
+	sort.Slice(edges, func(i, j int) bool {
+		if edges[i].from != edges[j].from {
+			return edges[i].from < edges[j].from
+		}
+		return edges[i].to < edges[j].to
+	})
+	kept := edges[:0]
+	for i, e := range edges {
+		if e.from == e.to || (i > 0 && e == edges[i-1]) {
+			continue
+		}
+		kept = append(kept, e)
+	}
+
+	c := &compactGraph{
+		outStart: make([]int32, n+1),
+		out:      make([]int32, len(kept)),
+		inStart:  make([]int32, n+1),
+		in:       make([]int32, len(kept)),
+	}
+	for _, e := range kept {
+		c.outStart[e.from+1]++
+		c.inStart[e.to+1]++
+	}
+	for i := 0; i < n; i++ {
+		c.outStart[i+1] += c.outStart[i]
+		c.inStart[i+1] += c.inStart[i]
+	}
+	// kept is sorted by (from, to), so both runs come out sorted
+	fill := make([]int32, n)
+	for i, e := range kept {
+		c.out[i] = e.to
+		c.in[c.inStart[e.to]+fill[e.to]] = e.from
+		fill[e.to]++
+	}
+	return c
+}
+
+// compactOf returns g as a compactGraph together with the gonum node ID of
+// each dense index, sorted ascending. A compactGraph is returned as is.
+func compactOf(g graph.Directed) (*compactGraph, []int64) {
+	if c, ok := g.(*compactGraph); ok {
+		ids := make([]int64, c.Len())
+		for i := range ids {
+			ids[i] = int64(i)
+		}
+		return c, ids
+	}
+
+	nodes := graph.NodesOf(g.Nodes())
+	ids := make([]int64, len(nodes))
+	for i, n := range nodes {
+		ids[i] = n.ID()
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	index := make(map[int64]int32, len(ids))
+	for i, id := range ids {
+		index[id] = int32(i)
+	}
+	var edges []compactEdge
+	for i, id := range ids {
+		to := g.From(id)
+		for to.Next() {
+			edges = append(edges, compactEdge{from: int32(i), to: index[to.Node().ID()]})
+		}
+	}
+	return newCompactGraph(len(ids), edges), ids
+}
+
+// Len returns the number of nodes
+func (c *compactGraph) Len() int { return len(c.outStart) - 1 }
+
+// EdgeCount returns the number of edges
+func (c *compactGraph) EdgeCount() int { return len(c.out) }
+
+// from returns the sorted nodes u depends on
+func (c *compactGraph) from(u int32) []int32 { return c.out[c.outStart[u]:c.outStart[u+1]] }
+
+// to returns the sorted nodes depending on v
+func (c *compactGraph) to(v int32) []int32 { return c.in[c.inStart[v]:c.inStart[v+1]] }
+
+func (c *compactGraph) has(id int64) bool { return id >= 0 && id < int64(c.Len()) }
+
+// Node implements graph.Graph
+func (c *compactGraph) Node(id int64) graph.Node {
+	if !c.has(id) {
+		return nil
+	}
+	return simple.Node(id)
+}
+
+// Nodes implements graph.Graph
+func (c *compactGraph) Nodes() graph.Nodes {
+	return &compactNodes{n: c.Len(), pos: -1}
+}
+
+// From implements graph.Graph
+func (c *compactGraph) From(id int64) graph.Nodes {
+	if !c.has(id) {
+		return graph.Empty
+	}
+	return &compactNodes{ids: c.from(int32(id)), n: -1, pos: -1}
+}
+
+// To implements graph.Directed
+func (c *compactGraph) To(id int64) graph.Nodes {
+	if !c.has(id) {
+		return graph.Empty
+	}
+	return &compactNodes{ids: c.to(int32(id)), n: -1, pos: -1}
+}
+
+// HasEdgeFromTo implements graph.Directed
+func (c *compactGraph) HasEdgeFromTo(uid, vid int64) bool {
+	if !c.has(uid) || !c.has(vid) {
+		return false
+	}
+	out := c.from(int32(uid))
+	i := sort.Search(len(out), func(i int) bool { return out[i] >= int32(vid) })
+	return i < len(out) && out[i] == int32(vid)
+}
+
+// HasEdgeBetween implements graph.Graph
+func (c *compactGraph) HasEdgeBetween(xid, yid int64) bool {
+	return c.HasEdgeFromTo(xid, yid) || c.HasEdgeFromTo(yid, xid)
+}
+
+// Edge implements graph.Graph
+func (c *compactGraph) Edge(uid, vid int64) graph.Edge {
+	if !c.HasEdgeFromTo(uid, vid) {
+		return nil
+	}
+	return simple.Edge{F: simple.Node(uid), T: simple.Node(vid)}
+}
+
+// Edges returns all edges, ordered by source then target
+func (c *compactGraph) Edges() graph.Edges {
+	return &compactEdges{g: c, pos: -1}
+}
+
+// compactNodes iterates either the node range 0..n-1 or, when n < 0, the
+// nodes in ids
+type compactNodes struct {
+	ids []int32
+	n   int
+	pos int
+}
+
+func (it *compactNodes) size() int {
+	if it.n >= 0 {
+		return it.n
+	}
+	return len(it.ids)
+}
+
+func (it *compactNodes) Next() bool {
+	if it.pos+1 >= it.size() {
+		it.pos = it.size()
+		return false
+	}
+	it.pos++
+	return true
+}
+
+func (it *compactNodes) Len() int {
+	if it.pos >= it.size() {
+		return 0
+	}
+	return it.size() - it.pos - 1
+}
+
+func (it *compactNodes) Reset() { it.pos = -1 }
+
+func (it *compactNodes) Node() graph.Node {
+	if it.pos < 0 || it.pos >= it.size() {
+		return nil
+	}
+	if it.n >= 0 {
+		return simple.Node(it.pos)
+	}
+	return simple.Node(it.ids[it.pos])
+}
+
+// compactEdges iterates the edges of g in CSR order
+type compactEdges struct {
+	g   *compactGraph
+	u   int32 // Source of the edge at pos
+	pos int
+}
+
+func (it *compactEdges) Next() bool {
+	if it.pos+1 >= len(it.g.out) {
+		it.pos = len(it.g.out)
+		return false
+	}
+	it.pos++
+	for it.g.outStart[it.u+1] <= int32(it.pos) {
+		it.u++
+	}
+	return true
+}
+
+func (it *compactEdges) Len() int {
+	if it.pos >= len(it.g.out) {
+		return 0
+	}
+	return len(it.g.out) - it.pos - 1
+}
+
+func (it *compactEdges) Reset() { it.u, it.pos = 0, -1 }
+
+func (it *compactEdges) Edge() graph.Edge {
+	if it.pos < 0 || it.pos >= len(it.g.out) {
+		return nil
+	}
+	return simple.Edge{F: simple.Node(it.u), T: simple.Node(it.g.out[it.pos])}
+}
+
+// dependencyOrder returns the nodes dependencies first (Kahn's algorithm,
+// ties broken by index so the order is deterministic). ok is false when
+// the graph has a cycle.
+func (c *compactGraph) dependencyOrder() (order []int32, ok bool) {
+	n := c.Len()
+	pending := make([]int32, n) // Unordered dependencies of each node
+	order = make([]int32, 0, n)
+	for u := 0; u < n; u++ {
+		pending[u] = c.outStart[u+1] - c.outStart[u]
+		if pending[u] == 0 {
+			order = append(order, int32(u))
+		}
+	}
+	for head := 0; head < len(order); head++ {
+		for _, dependent := range c.to(order[head]) {
+			pending[dependent]--
+			if pending[dependent] == 0 {
+				order = append(order, dependent)
+			}
+		}
+	}
+	if len(order) != n {
+		return nil, false
+	}
+	return order, true
+}
+
+// pageRank is the power iteration behind computePageRank, on dense indexes
+func (c *compactGraph) pageRank(damp, tol float64) []float64 {
+	nodes := c.Len()
+	if nodes == 0 {
+		return nil
+	}
+	if tol <= 0 {
+		tol = 1e-6
+	}
+
+	n := float64(nodes)
+	rank := make([]float64, nodes)
+	uniform := 1.0 / n
+	for i := range rank {
+		rank[i] = uniform
+	}
+	next := make([]float64, nodes)
+
+	base := (1 - damp) / n
+	const maxIterations = 1000
+	for iter := 0; iter < maxIterations; iter++ {
+		for i := range next {
+			next[i] = base
+		}
+
+		dangling := 0.0
+		for j := 0; j < nodes; j++ {
+			out := c.from(int32(j))
+			if len(out) == 0 {
+				dangling += rank[j]
+				continue
+			}
+			share := damp * rank[j] / float64(len(out))
+			for _, i := range out {
+				next[i] += share
+			}
+		}
+		if dangling != 0 {
+			add := damp * dangling / n
+			for i := range next {
+				next[i] += add
+			}
+		}
+
+		diff := 0.0
+		for i := range rank {
+			d := next[i] - rank[i]
+			diff += d * d
+		}
+
+		rank, next = next, rank
+		if math.Sqrt(diff) < tol {
+			break
+		}
+	}
+	return rank
+}
+
+// eigenvector runs a simple power-iteration to estimate eigenvector
+// centrality, summing in-neighbors in index order for determinism
+func (c *compactGraph) eigenvector() []float64 {
+	n := c.Len()
+	if n == 0 {
+		return nil
+	}
+
+	vec := make([]float64, n)
+	for i := range vec {
+		vec[i] = 1.0 / float64(n)
+	}
+	work := make([]float64, n)
+
+	const iterations = 50
+	for iter := 0; iter < iterations; iter++ {
+		for i := range work {
+			work[i] = 0
+			for _, j := range c.to(int32(i)) {
+				work[i] += vec[j]
+			}
+		}
+		sum := 0.0
+		for _, v := range work {
+			sum += v * v
+		}
+		if sum == 0 {
+			break
+		}
+		norm := 1 / math.Sqrt(sum)
+		for i := range work {
+			vec[i] = work[i] * norm
+		}
+	}
+	return vec
+}
+
+// hits computes hub and authority scores the way network.HITS does,
+// iterating until both vectors move less than tol. The graph must have at
+// least one edge.
+func (c *compactGraph) hits(tol float64) (hub, auth []float64) {
+	n := c.Len()
+	hub = make([]float64, n)
+	auth = make([]float64, n)
+	for i := range hub {
+		hub[i] = 1
+		auth[i] = 1
+	}
+
+	prev := make([]float64, n)
+	for {
+		var norm, deltaAuth, deltaHub float64
+		copy(prev, auth)
+		for v := range auth {
+			var a float64
+			for _, u := range c.to(int32(v)) {
+				a += hub[u]
+			}
+			auth[v] = a
+			norm += a * a
+		}
+		norm = math.Sqrt(norm)
+		for i := range auth {
+			auth[i] /= norm
+			d := prev[i] - auth[i]
+			deltaAuth += d * d
+		}
+
+		copy(prev, hub)
+		norm = 0
+		for u := range hub {
+			var h float64
+			for _, v := range c.from(int32(u)) {
+				h += auth[v]
+			}
+			hub[u] = h
+			norm += h * h
+		}
+		norm = math.Sqrt(norm)
+		for i := range hub {
+			hub[i] /= norm
+			d := prev[i] - hub[i]
+			deltaHub += d * d
+		}
+
+		if math.Sqrt(deltaAuth) < tol && math.Sqrt(deltaHub) < tol {
+			return hub, auth
+		}
+	}
+}
+
+// undirected returns the symmetric closure of the graph as CSR adjacency:
+// nbr[start[v]:start[v+1]] are v's neighbors in either direction, sorted
+// and without duplicates
+func (c *compactGraph) undirected() (start, nbr []int32) {
+	n := c.Len()
+	start = make([]int32, n+1)
+	nbr = make([]int32, 0, 2*c.EdgeCount())
+	for v := 0; v < n; v++ {
+		// Merge the two sorted runs, keeping one copy of mutual edges
+		out, in := c.from(int32(v)), c.to(int32(v))
+		i, j := 0, 0
+		for i < len(out) || j < len(in) {
+			switch {
+			case j == len(in) || (i < len(out) && out[i] < in[j]):
+				nbr = append(nbr, out[i])
+				i++
+			case i == len(out) || in[j] < out[i]:
+				nbr = append(nbr, in[j])
+				j++
+			default:
+				nbr = append(nbr, out[i])
+				i++
+				j++
+			}
+		}
+		start[v+1] = int32(len(nbr))
+	}
+	return start, nbr
+}
+
+// kCore returns the core number of every node of an undirected CSR
+// adjacency with the Batagelj-Zaversnik bucket algorithm: nodes are peeled
+// in order of current degree, which is O(V+E) however skewed the degrees.
+func kCore(start, nbr []int32) []int {
+	n := len(start) - 1
+	deg := make([]int, n)
+	maxDeg := 0
+	for v := range deg {
+		deg[v] = int(start[v+1] - start[v])
+		maxDeg = max(maxDeg, deg[v])
+	}
+
+	// Bucket sort the nodes by degree: vert is the order, pos[v] is v's
+	// place in it, bin[d] the first place of degree d
+	bin := make([]int, maxDeg+1)
+	for _, d := range deg {
+		bin[d]++
+	}
+	for d, first := 0, 0; d <= maxDeg; d++ {
+		bin[d], first = first, first+bin[d]
+	}
+	vert := make([]int32, n)
+	pos := make([]int, n)
+	for v, d := range deg {
+		pos[v] = bin[d]
+		vert[pos[v]] = int32(v)
+		bin[d]++
+	}
+	for d := maxDeg; d > 0; d-- {
+		bin[d] = bin[d-1]
+	}
+	bin[0] = 0
+
+	for _, v := range vert {
+		for _, u := range nbr[start[v]:start[v+1]] {
+			if deg[u] <= deg[v] {
+				continue
+			}
+			// Move u to the front of its bucket, then into the one below
+			du, pu := deg[u], pos[u]
+			pw := bin[du]
+			if w := vert[pw]; w != u {
+				vert[pu], vert[pw] = w, u
+				pos[w], pos[u] = pu, pw
+			}
+			bin[du]++
+			deg[u]--
+		}
+	}
+	return deg
+}
+
+// articulationPoints marks the cut vertices of an undirected CSR adjacency
+// with Tarjan's algorithm. The DFS keeps an explicit stack so long
+// dependency chains cannot exhaust the goroutine stack.
+func articulationPoints(start, nbr []int32) []bool {
+	n := len(start) - 1
+	disc := make([]int32, n) // Discovery time, 0 while unvisited
+	low := make([]int32, n)
+	parent := make([]int32, n)
+	next := make([]int32, n) // Next neighbor position to visit
+	children := make([]int32, n)
+	ap := make([]bool, n)
+
+	var timeIdx int32
+	var stack []int32
+	for root := int32(0); root < int32(n); root++ {
+		if disc[root] != 0 {
+			continue
+		}
+		timeIdx++
+		disc[root], low[root] = timeIdx, timeIdx
+		parent[root] = -1
+		next[root] = start[root]
+		stack = append(stack[:0], root)
+
+		for len(stack) > 0 {
+			v := stack[len(stack)-1]
+			if next[v] < start[v+1] {
+				u := nbr[next[v]]
+				next[v]++
+				if disc[u] == 0 {
+					parent[u] = v
+					children[v]++
+					timeIdx++
+					disc[u], low[u] = timeIdx, timeIdx
+					next[u] = start[u]
+					stack = append(stack, u)
+				} else if u != parent[v] {
+					low[v] = min(low[v], disc[u])
+				}
+				continue
+			}
+
+			// v is finished; fold it into its parent
+			stack = stack[:len(stack)-1]
+			p := parent[v]
+			if p < 0 {
+				// Root with >1 child
+				ap[v] = children[v] > 1
+				continue
+			}
+			low[p] = min(low[p], low[v])
+			if parent[p] >= 0 && low[v] >= disc[p] {
+				ap[p] = true
+			}
+		}
+	}
+	return ap
+}
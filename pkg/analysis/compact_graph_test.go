@@ -0,0 +1,163 @@
+package analysis
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"gonum.org/v1/gonum/graph"
+	"gonum.org/v1/gonum/graph/network"
+	"gonum.org/v1/gonum/graph/simple"
+	"gonum.org/v1/gonum/graph/topo"
+)
+
+// simpleOf copies g into a gonum DirectedGraph with the same node IDs
+func simpleOf(g *compactGraph) *simple.DirectedGraph {
+	s := simple.NewDirectedGraph()
+	for i := 0; i < g.Len(); i++ {
+		s.AddNode(simple.Node(i))
+	}
+	edges := g.Edges()
+	for edges.Next() {
+		s.SetEdge(edges.Edge())
+	}
+	return s
+}
+
+func sortedIDs(nodes graph.Nodes) []int64 {
+	var ids []int64
+	for nodes.Next() {
+		ids = append(ids, nodes.Node().ID())
+	}
+	return ids
+}
+
+func TestCompactGraph_MatchesSimpleGraph(t *testing.T) {
+	g := NewAnalyzer(randomIssueGraph(300, 3, 7)).g
+	s := simpleOf(g)
+
+	if g.Edges().Len() != s.Edges().Len() || g.EdgeCount() != s.Edges().Len() {
+		t.Fatalf("edges = %d/%d, want %d", g.Edges().Len(), g.EdgeCount(), s.Edges().Len())
+	}
+	for u := int64(0); u < int64(g.Len()); u++ {
+		for _, dir := range []struct {
+			name      string
+			got, want graph.Nodes
+		}{{"From", g.From(u), s.From(u)}, {"To", g.To(u), s.To(u)}} {
+			if dir.got.Len() != dir.want.Len() {
+				t.Fatalf("%s(%d) has %d nodes, want %d", dir.name, u, dir.got.Len(), dir.want.Len())
+			}
+			for _, v := range sortedIDs(dir.got) {
+				if dir.name == "From" && !s.HasEdgeFromTo(u, v) || dir.name == "To" && !s.HasEdgeFromTo(v, u) {
+					t.Fatalf("%s(%d) has %d, which is not an edge", dir.name, u, v)
+				}
+			}
+		}
+		for v := int64(0); v < int64(g.Len()); v += 17 {
+			if g.HasEdgeFromTo(u, v) != s.HasEdgeFromTo(u, v) || g.HasEdgeBetween(u, v) != s.HasEdgeBetween(u, v) {
+				t.Fatalf("edge %d -> %d disagrees", u, v)
+			}
+		}
+	}
+	if g.Node(int64(g.Len())) != nil || g.From(-1).Len() != 0 || g.Edge(0, 0) != nil {
+		t.Error("out of range nodes and missing edges should be nil or empty")
+	}
+
+	// gonum algorithms run on it unchanged
+	if got, want := len(topo.TarjanSCC(g)), len(topo.TarjanSCC(s)); got != want {
+		t.Errorf("TarjanSCC found %d components, want %d", got, want)
+	}
+}
+
+func TestCompactGraph_DropsDuplicatesAndSelfLoops(t *testing.T) {
+	g := newCompactGraph(3, []compactEdge{{0, 1}, {0, 1}, {1, 1}, {2, 0}})
+	if g.EdgeCount() != 2 {
+		t.Fatalf("edges = %d, want 2", g.EdgeCount())
+	}
+	if got := sortedIDs(g.To(0)); len(got) != 1 || got[0] != 2 {
+		t.Errorf("To(0) = %v, want [2]", got)
+	}
+}
+
+func TestDependencyOrder(t *testing.T) {
+	// 0 depends on 1 and 2; 1 depends on 2
+	g := newCompactGraph(4, []compactEdge{{0, 1}, {0, 2}, {1, 2}})
+	order, ok := g.dependencyOrder()
+	if !ok || fmt.Sprint(order) != "[2 3 1 0]" {
+		t.Errorf("order = %v, %v; want [2 3 1 0]", order, ok)
+	}
+
+	g = newCompactGraph(3, []compactEdge{{0, 1}, {1, 2}, {2, 0}})
+	if order, ok := g.dependencyOrder(); ok || order != nil {
+		t.Errorf("cyclic graph: order = %v, %v; want none", order, ok)
+	}
+}
+
+func TestCompactHITS_MatchesGonum(t *testing.T) {
+	g := NewAnalyzer(randomIssueGraph(200, 2, 3)).g
+	hub, auth := g.hits(1e-3)
+	for id, want := range network.HITS(simpleOf(g), 1e-3) {
+		if math.Abs(hub[id]-want.Hub) > 1e-9 || math.Abs(auth[id]-want.Authority) > 1e-9 {
+			t.Fatalf("node %d = hub %v, authority %v; want %+v", id, hub[id], auth[id], want)
+		}
+	}
+}
+
+func TestArticulationPoints_LongChain(t *testing.T) {
+	// Deep enough that a recursive DFS per node would be costly; every
+	// inner node of a chain is a cut vertex
+	const n = 200000
+	edges := make([]compactEdge, 0, n-1)
+	for i := int32(1); i < n; i++ {
+		edges = append(edges, compactEdge{from: i, to: i - 1})
+	}
+	start, nbr := newCompactGraph(n, edges).undirected()
+	ap := articulationPoints(start, nbr)
+	for v, cut := range ap {
+		if want := v > 0 && v < n-1; cut != want {
+			t.Fatalf("node %d cut = %v, want %v", v, cut, want)
+		}
+	}
+	core := kCore(start, nbr)
+	if core[0] != 1 || core[n/2] != 1 {
+		t.Errorf("chain core numbers = %d, %d; want 1", core[0], core[n/2])
+	}
+}
+
+// peelCores is the textbook k-core decomposition: for k = 1, 2, ...
+// repeatedly remove nodes of degree < k, which have core number k-1
+func peelCores(start, nbr []int32) []int {
+	n := len(start) - 1
+	deg := make([]int, n)
+	for v := range deg {
+		deg[v] = int(start[v+1] - start[v])
+	}
+	core := make([]int, n)
+	removed := make([]bool, n)
+	for left, k := n, 1; left > 0; k++ {
+		for changed := true; changed; {
+			changed = false
+			for v := range deg {
+				if removed[v] || deg[v] >= k {
+					continue
+				}
+				removed[v], core[v], changed = true, k-1, true
+				left--
+				for _, u := range nbr[start[v]:start[v+1]] {
+					deg[u]--
+				}
+			}
+		}
+	}
+	return core
+}
+
+func TestKCore_MatchesPeeling(t *testing.T) {
+	for _, tc := range []struct{ n, degree int }{{1, 0}, {50, 1}, {200, 3}, {300, 8}} {
+		start, nbr := NewAnalyzer(randomIssueGraph(tc.n, tc.degree, int64(tc.degree))).g.undirected()
+		got, want := kCore(start, nbr), peelCores(start, nbr)
+		if fmt.Sprint(got) != fmt.Sprint(want) {
+			t.Errorf("n=%d degree=%d: cores = %v, want %v", tc.n, tc.degree, got, want)
+		}
+	}
+}
@@ -1,6 +1,13 @@
 package analysis
 
-import "time"
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
 
 // AnalysisConfig controls which metrics to compute and their timeouts.
 // This enables size-based algorithm selection for optimal performance.
@@ -11,6 +18,8 @@ type AnalysisConfig struct {
 	BetweennessSkipReason  string          // Set when skipped, explains why
 	BetweennessMode        BetweennessMode // "exact", "approximate", or "skip"
 	BetweennessSampleSize  int             // Sample size for approximate mode
+	BetweennessErrorBound  float64         // Target relative error for approximate mode; sizes the sample when BetweennessSampleSize is 0
+	BetweennessWorkers     int             // Goroutines for Brandes' passes; 0 means GOMAXPROCS
 	BetweennessIsApproximate bool          // True if approximation was used (set after computation)
 
 	// PageRank
@@ -240,3 +249,83 @@ type SkippedMetric struct {
 	Name   string
 	Reason string
 }
+
+// betweennessSampleSize is the number of pivots for approximate mode: the
+// explicit sample size, else the one the error bound needs
+func (c AnalysisConfig) betweennessSampleSize() int {
+	if c.BetweennessSampleSize > 0 {
+		return c.BetweennessSampleSize
+	}
+	return SampleSizeForError(c.BetweennessErrorBound)
+}
+
+// ApplyBetweennessEnv lets the environment override how betweenness is
+// computed, for graphs where the size-based choice times out or is too
+// rough:
+//
+//	BV_BETWEENNESS_MODE=exact|approximate|skip
+//	BV_BETWEENNESS_SAMPLE=<pivots>   (implies approximate)
+//	BV_BETWEENNESS_ERROR=<0..1>      (implies approximate; sizes the sample)
+//	BV_BETWEENNESS_WORKERS=<n>
+//
+// Configs that already skip betweenness (e.g. --robot-plan) are left alone.
+func ApplyBetweennessEnv(c *AnalysisConfig, getenv func(string) string) error {
+	if !c.ComputeBetweenness {
+		return nil
+	}
+	if v := strings.TrimSpace(getenv("BV_BETWEENNESS_WORKERS")); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			return fmt.Errorf("invalid BV_BETWEENNESS_WORKERS %q: want a positive integer", v)
+		}
+		c.BetweennessWorkers = n
+	}
+	if v := strings.TrimSpace(getenv("BV_BETWEENNESS_SAMPLE")); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			return fmt.Errorf("invalid BV_BETWEENNESS_SAMPLE %q: want a positive integer", v)
+		}
+		c.BetweennessMode = BetweennessApproximate
+		c.BetweennessSampleSize = n
+		c.BetweennessErrorBound = 0
+	}
+	if v := strings.TrimSpace(getenv("BV_BETWEENNESS_ERROR")); v != "" {
+		bound, err := strconv.ParseFloat(v, 64)
+		if err != nil || bound <= 0 || bound >= 1 {
+			return fmt.Errorf("invalid BV_BETWEENNESS_ERROR %q: want a fraction between 0 and 1", v)
+		}
+		c.BetweennessMode = BetweennessApproximate
+		c.BetweennessSampleSize = 0
+		c.BetweennessErrorBound = bound
+	}
+	switch mode := BetweennessMode(strings.ToLower(strings.TrimSpace(getenv("BV_BETWEENNESS_MODE")))); mode {
+	case "":
+	case BetweennessExact:
+		c.BetweennessMode = BetweennessExact
+	case BetweennessApproximate:
+		c.BetweennessMode = BetweennessApproximate
+		if c.betweennessSampleSize() == 0 {
+			c.BetweennessErrorBound = 0.1
+		}
+	case BetweennessSkip:
+		c.ComputeBetweenness = false
+		c.BetweennessMode = BetweennessSkip
+		c.BetweennessSkipReason = "disabled by BV_BETWEENNESS_MODE"
+	default:
+		return fmt.Errorf("invalid BV_BETWEENNESS_MODE %q: want exact, approximate or skip", mode)
+	}
+	return nil
+}
+
+var betweennessEnvWarning sync.Once
+
+// withBetweennessEnv applies ApplyBetweennessEnv from the process
+// environment, warning once about and ignoring invalid settings
+func withBetweennessEnv(c AnalysisConfig) AnalysisConfig {
+	applied := c
+	if err := ApplyBetweennessEnv(&applied, os.Getenv); err != nil {
+		betweennessEnvWarning.Do(func() { fmt.Fprintf(os.Stderr, "Warning: %v\n", err) })
+		return c
+	}
+	return applied
+}
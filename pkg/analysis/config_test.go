@@ -214,3 +214,50 @@ func TestFullAnalysisConfig(t *testing.T) {
 		t.Errorf("Expected high max cycles in full config, got %d", cfg.MaxCyclesToStore)
 	}
 }
+
+func TestApplyBetweennessEnv(t *testing.T) {
+	env := func(vars map[string]string) func(string) string {
+		return func(key string) string { return vars[key] }
+	}
+
+	cfg := ConfigForSize(200, 400)
+	if err := ApplyBetweennessEnv(&cfg, env(map[string]string{"BV_BETWEENNESS_ERROR": "0.05", "BV_BETWEENNESS_WORKERS": "4"})); err != nil {
+		t.Fatal(err)
+	}
+	if cfg.BetweennessMode != BetweennessApproximate || cfg.betweennessSampleSize() != 400 || cfg.BetweennessWorkers != 4 {
+		t.Errorf("error bound override: %+v", cfg)
+	}
+
+	cfg = ConfigForSize(3000, 6000)
+	if err := ApplyBetweennessEnv(&cfg, env(map[string]string{"BV_BETWEENNESS_MODE": "exact"})); err != nil || cfg.BetweennessMode != BetweennessExact {
+		t.Errorf("exact override: mode %s, err %v", cfg.BetweennessMode, err)
+	}
+
+	cfg = ConfigForSize(200, 400)
+	if err := ApplyBetweennessEnv(&cfg, env(map[string]string{"BV_BETWEENNESS_MODE": "approximate"})); err != nil || cfg.betweennessSampleSize() != 100 {
+		t.Errorf("approximate without a sample should default to 10%% error: %+v, %v", cfg, err)
+	}
+
+	cfg = ConfigForSize(200, 400)
+	if err := ApplyBetweennessEnv(&cfg, env(map[string]string{"BV_BETWEENNESS_MODE": "skip"})); err != nil || cfg.ComputeBetweenness {
+		t.Errorf("skip override: %+v, %v", cfg, err)
+	}
+
+	// Already skipped (e.g. --robot-plan) stays skipped
+	cfg = ConfigForSize(800, 100000)
+	if err := ApplyBetweennessEnv(&cfg, env(map[string]string{"BV_BETWEENNESS_SAMPLE": "50"})); err != nil || cfg.ComputeBetweenness {
+		t.Errorf("skipped config was re-enabled: %+v, %v", cfg, err)
+	}
+
+	for _, vars := range []map[string]string{
+		{"BV_BETWEENNESS_MODE": "fast"},
+		{"BV_BETWEENNESS_SAMPLE": "0"},
+		{"BV_BETWEENNESS_ERROR": "1.5"},
+		{"BV_BETWEENNESS_WORKERS": "x"},
+	} {
+		cfg := ConfigForSize(200, 400)
+		if err := ApplyBetweennessEnv(&cfg, env(vars)); err == nil {
+			t.Errorf("%v should be rejected", vars)
+		}
+	}
+}
@@ -262,14 +262,21 @@ func findSharedKeys(m1, m2 map[string]bool) []string {
 			shared = append(shared, k)
 		}
 	}
+	sort.Strings(shared)
 	return shared
 }
 
-// sortMatchesByConfidence sorts matches by confidence (highest first)
-// Uses sort.Slice for O(n log n) performance instead of bubble sort O(n²)
+// sortMatchesByConfidence sorts matches by confidence (highest first),
+// then by the issue pair so ties come out in a stable order
 func sortMatchesByConfidence(matches []DependencyMatch) {
 	sort.Slice(matches, func(i, j int) bool {
-		return matches[i].Confidence > matches[j].Confidence
+		if matches[i].Confidence != matches[j].Confidence {
+			return matches[i].Confidence > matches[j].Confidence
+		}
+		if matches[i].From != matches[j].From {
+			return matches[i].From < matches[j].From
+		}
+		return matches[i].To < matches[j].To
 	})
 }
 
@@ -61,7 +61,7 @@ func (s *Snapshot) computeCounts() {
 		case model.StatusBlocked:
 			s.BlockedCount++
 			s.OpenCount++ // Blocked is a type of open
-		case model.StatusOpen, model.StatusInProgress:
+		case model.StatusOpen, model.StatusInProgress, model.StatusInReview:
 			s.OpenCount++
 		}
 	}
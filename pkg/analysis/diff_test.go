@@ -29,6 +29,23 @@ func TestNewSnapshot(t *testing.T) {
 	}
 }
 
+func TestNewSnapshot_InReviewIsOpen(t *testing.T) {
+	from := NewSnapshot([]model.Issue{
+		{ID: "ISSUE-1", Title: "First", Status: model.StatusInProgress},
+		{ID: "ISSUE-2", Title: "Second", Status: model.StatusOpen},
+	})
+	to := NewSnapshot([]model.Issue{
+		{ID: "ISSUE-1", Title: "First", Status: model.StatusInReview},
+		{ID: "ISSUE-2", Title: "Second", Status: model.StatusOpen},
+	})
+	if to.OpenCount != 2 {
+		t.Errorf("expected OpenCount 2 with an in_review issue, got %d", to.OpenCount)
+	}
+	if diff := CompareSnapshots(from, to); diff.MetricDeltas.OpenIssues != 0 {
+		t.Errorf("moving to review should not change open issues, delta %d", diff.MetricDeltas.OpenIssues)
+	}
+}
+
 func TestNewSnapshotAt(t *testing.T) {
 	issues := []model.Issue{
 		{ID: "ISSUE-1", Title: "First", Status: model.StatusOpen},
@@ -248,7 +248,164 @@ func extractKeywords(title, description string) []string {
 	return keywords
 }
 
+// NearDuplicateConfig configures near-duplicate detection (--robot-duplicates)
+type NearDuplicateConfig struct {
+	// Threshold is the minimum combined score (0.0-1.0)
+	// Default: 0.75
+	Threshold float64
+
+	// MaxPairs limits the number of pairs returned (0 = all)
+	MaxPairs int
+}
+
+// DefaultNearDuplicateConfig returns sensible defaults
+func DefaultNearDuplicateConfig() NearDuplicateConfig {
+	return NearDuplicateConfig{Threshold: 0.75}
+}
+
+// NearDuplicatePair is a pair of issues that likely describe the same work
+type NearDuplicatePair struct {
+	Issue1     string  `json:"issue1"`
+	Title1     string  `json:"title1"`
+	Issue2     string  `json:"issue2"`
+	Title2     string  `json:"title2"`
+	Score      float64 `json:"score"`
+	TitleScore float64 `json:"title_similarity"`
+	// Semantic is the embedding cosine similarity; nil without vectors
+	Semantic     *float64 `json:"semantic_similarity,omitempty"`
+	LabelOverlap *float64 `json:"label_overlap,omitempty"` // nil when neither has labels
+	Command      string   `json:"command,omitempty"`
+}
+
+// Near-duplicate signal weights; signals that are unavailable for a pair
+// are dropped and the rest renormalized
+const (
+	nearDupTitleWeight    = 0.4
+	nearDupSemanticWeight = 0.4
+	nearDupLabelWeight    = 0.2
+)
+
+// FindNearDuplicates scores every pair of issues on title keyword similarity,
+// embedding similarity (vectors: issue ID -> embedding, may be nil) and label
+// overlap, returning pairs at or above the threshold, best first. Pairs that
+// are both closed, or already linked by a dependency, are skipped.
+func FindNearDuplicates(issues []model.Issue, vectors map[string][]float32, config NearDuplicateConfig) []NearDuplicatePair {
+	if config.Threshold <= 0 {
+		config.Threshold = DefaultNearDuplicateConfig().Threshold
+	}
+
+	type candidate struct {
+		issue    *model.Issue
+		keywords map[string]bool
+		labels   map[string]bool
+	}
+	var candidates []candidate
+	linked := make(map[[2]string]bool)
+	for i := range issues {
+		issue := &issues[i]
+		for _, dep := range issue.Dependencies {
+			if dep != nil {
+				linked[pairKey(issue.ID, dep.DependsOnID)] = true
+			}
+		}
+		if issue.Status.IsTombstone() {
+			continue
+		}
+		c := candidate{issue: issue, keywords: make(map[string]bool), labels: make(map[string]bool)}
+		for _, kw := range extractKeywords(issue.Title, "") {
+			c.keywords[kw] = true
+		}
+		for _, l := range issue.Labels {
+			c.labels[strings.ToLower(l)] = true
+		}
+		candidates = append(candidates, c)
+	}
+
+	var pairs []NearDuplicatePair
+	for i, a := range candidates {
+		for _, b := range candidates[i+1:] {
+			if a.issue.Status.IsClosed() && b.issue.Status.IsClosed() {
+				continue
+			}
+			if linked[pairKey(a.issue.ID, b.issue.ID)] {
+				continue
+			}
 
+			title := jaccardSets(a.keywords, b.keywords)
+			score, weight := nearDupTitleWeight*title, nearDupTitleWeight
+			pair := NearDuplicatePair{TitleScore: round2(title)}
+			if va, vb := vectors[a.issue.ID], vectors[b.issue.ID]; len(va) > 0 && len(vb) > 0 {
+				sim := max(cosineSimilarity(va, vb), 0)
+				score += nearDupSemanticWeight * sim
+				weight += nearDupSemanticWeight
+				rounded := round2(sim)
+				pair.Semantic = &rounded
+			}
+			if len(a.labels) > 0 || len(b.labels) > 0 {
+				overlap := jaccardSets(a.labels, b.labels)
+				score += nearDupLabelWeight * overlap
+				weight += nearDupLabelWeight
+				rounded := round2(overlap)
+				pair.LabelOverlap = &rounded
+			}
+			score /= weight
+			if score < config.Threshold {
+				continue
+			}
+
+			first, second := a.issue, b.issue
+			if second.ID < first.ID {
+				first, second = second, first
+			}
+			pair.Issue1, pair.Title1 = first.ID, first.Title
+			pair.Issue2, pair.Title2 = second.ID, second.Title
+			pair.Score = round2(score)
+			if !first.Status.IsClosed() && !second.Status.IsClosed() {
+				pair.Command = fmt.Sprintf("bd dep add %s %s --type=related", first.ID, second.ID)
+			}
+			pairs = append(pairs, pair)
+		}
+	}
+
+	sort.Slice(pairs, func(i, j int) bool {
+		a, b := pairs[i], pairs[j]
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		if a.Issue1 != b.Issue1 {
+			return a.Issue1 < b.Issue1
+		}
+		return a.Issue2 < b.Issue2
+	})
+	if config.MaxPairs > 0 && len(pairs) > config.MaxPairs {
+		pairs = pairs[:config.MaxPairs]
+	}
+	return pairs
+}
+
+// NearDuplicatesByIssue indexes pairs by both of their issues, so each issue
+// maps to the IDs of its possible duplicates in score order
+func NearDuplicatesByIssue(pairs []NearDuplicatePair) map[string][]string {
+	byIssue := make(map[string][]string)
+	for _, p := range pairs {
+		byIssue[p.Issue1] = append(byIssue[p.Issue1], p.Issue2)
+		byIssue[p.Issue2] = append(byIssue[p.Issue2], p.Issue1)
+	}
+	return byIssue
+}
+
+func jaccardSets(a, b map[string]bool) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	shared := 0
+	for k := range a {
+		if b[k] {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(a)+len(b)-shared)
+}
 
 // sortPairsBySimilarity sorts duplicate pairs by similarity (highest first)
 // Uses sort.Slice for O(n log n) performance instead of bubble sort O(n²)
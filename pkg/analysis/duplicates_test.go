@@ -622,3 +622,81 @@ func TestDetectDuplicates_LargeIssueSet(t *testing.T) {
 		t.Error("Should find at least one duplicate pair")
 	}
 }
+
+// ============================================================================
+// FindNearDuplicates Tests
+// ============================================================================
+
+func TestFindNearDuplicates_CombinesSignals(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "b", Title: "Login page crashes on submit", Status: model.StatusOpen, Labels: []string{"auth"}},
+		{ID: "a", Title: "Login page crashes on submit", Status: model.StatusOpen, Labels: []string{"auth"}},
+		{ID: "c", Title: "Submit crashes login page", Status: model.StatusOpen, Labels: []string{"ui"}},
+		{ID: "d", Title: "Export reports to CSV", Status: model.StatusOpen, Labels: []string{"auth"}},
+	}
+	vectors := map[string][]float32{
+		"a": {1, 0},
+		"b": {1, 0},
+		"c": {0.8, 0.6},
+		"d": {0, 1},
+	}
+
+	pairs := FindNearDuplicates(issues, vectors, NearDuplicateConfig{Threshold: 0.6})
+	if len(pairs) != 3 {
+		t.Fatalf("expected a/b, a/c and b/c, got %+v", pairs)
+	}
+	top := pairs[0]
+	if top.Issue1 != "a" || top.Issue2 != "b" || top.Score != 1 {
+		t.Errorf("identical issues should rank first with IDs ordered, got %+v", top)
+	}
+	if top.Semantic == nil || *top.Semantic != 1 || top.LabelOverlap == nil || *top.LabelOverlap != 1 {
+		t.Errorf("expected all three signals on the top pair, got %+v", top)
+	}
+	if top.Command != "bd dep add a b --type=related" {
+		t.Errorf("command = %q", top.Command)
+	}
+
+	// Different labels pull a/c below a stricter threshold
+	strict := FindNearDuplicates(issues, vectors, NearDuplicateConfig{Threshold: 0.9})
+	if len(strict) != 1 {
+		t.Errorf("threshold 0.9 should keep only the identical pair, got %+v", strict)
+	}
+
+	byIssue := NearDuplicatesByIssue(pairs)
+	if len(byIssue["a"]) != 2 || len(byIssue["d"]) != 0 {
+		t.Errorf("byIssue = %v", byIssue)
+	}
+}
+
+func TestFindNearDuplicates_MissingSignalsAndSkips(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "x", Title: "Cache invalidation bug", Status: model.StatusOpen},
+		{ID: "y", Title: "Cache invalidation bug", Status: model.StatusClosed},
+		{ID: "z", Title: "Cache invalidation bug", Status: model.StatusClosed},
+		{ID: "w", Title: "Cache invalidation bug", Status: model.StatusOpen,
+			Dependencies: []*model.Dependency{{IssueID: "w", DependsOnID: "x", Type: model.DepRelated}}},
+	}
+
+	// No vectors or labels: title alone decides
+	pairs := FindNearDuplicates(issues, nil, DefaultNearDuplicateConfig())
+	seen := make(map[string]NearDuplicatePair)
+	for _, p := range pairs {
+		seen[p.Issue1+"/"+p.Issue2] = p
+		if p.Semantic != nil || p.LabelOverlap != nil {
+			t.Errorf("unavailable signals should be omitted: %+v", p)
+		}
+	}
+	if _, ok := seen["y/z"]; ok {
+		t.Error("two closed issues should not be flagged")
+	}
+	if _, ok := seen["w/x"]; ok {
+		t.Error("already-linked issues should not be flagged")
+	}
+	if p, ok := seen["x/y"]; !ok || p.Score != 1 || p.Command != "" {
+		t.Errorf("open vs closed duplicate should be flagged without a link command, got %+v", p)
+	}
+
+	if limited := FindNearDuplicates(issues, nil, NearDuplicateConfig{MaxPairs: 1}); len(limited) != 1 {
+		t.Errorf("MaxPairs should cap results, got %d", len(limited))
+	}
+}
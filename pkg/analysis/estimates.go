@@ -0,0 +1,358 @@
+package analysis
+
+import (
+	"math"
+	"sort"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// EstimateReport summarizes how much open work carries an explicit estimate
+// (--robot-estimates).
+type EstimateReport struct {
+	OpenCount      int     `json:"open_count"`
+	EstimatedCount int     `json:"estimated_count"`
+	CoveragePct    float64 `json:"coverage_pct"`
+	FromMinutes    int     `json:"from_minutes"` // Estimated with estimated_minutes
+	FromPoints     int     `json:"from_points"`  // Estimated with estimated_points only
+	// TotalEstimatedMinutes sums explicit estimates of open issues
+	TotalEstimatedMinutes int `json:"total_estimated_minutes"`
+	// HeuristicMinutes is the forecast's guess for the unestimated remainder
+	HeuristicMinutes   int                `json:"heuristic_minutes"`
+	MinutesPerPoint    int                `json:"minutes_per_point"`
+	ByLabel            []LabelEstimate    `json:"by_label"`
+	LargestUnestimated []UnestimatedIssue `json:"largest_unestimated"`
+}
+
+// LabelEstimate is estimate coverage and totals for one label's open issues
+type LabelEstimate struct {
+	Label                 string  `json:"label"`
+	OpenCount             int     `json:"open_count"`
+	EstimatedCount        int     `json:"estimated_count"`
+	CoveragePct           float64 `json:"coverage_pct"`
+	TotalEstimatedMinutes int     `json:"total_estimated_minutes"`
+	HeuristicMinutes      int     `json:"heuristic_minutes"`
+}
+
+// UnestimatedIssue is an open issue without an estimate, sized by the
+// forecast heuristics so the biggest guesses can be replaced first.
+type UnestimatedIssue struct {
+	ID               string   `json:"id"`
+	Title            string   `json:"title"`
+	Type             string   `json:"type"`
+	Priority         int      `json:"priority"`
+	Labels           []string `json:"labels,omitempty"`
+	HeuristicMinutes int      `json:"heuristic_minutes"`
+	Factors          []string `json:"factors"`
+}
+
+// ComputeEstimateReport reports estimate coverage of open issues, totals per
+// label and the largest unestimated issues (up to limit, default 10).
+// stats may be nil; it only sharpens the size guesses for unestimated work.
+func ComputeEstimateReport(issues []model.Issue, stats *GraphStats, limit int) EstimateReport {
+	if limit <= 0 {
+		limit = 10
+	}
+	report := EstimateReport{
+		MinutesPerPoint:    model.MinutesPerPoint,
+		ByLabel:            []LabelEstimate{},
+		LargestUnestimated: []UnestimatedIssue{},
+	}
+
+	medianMinutes := computeMedianEstimatedMinutes(issues)
+	byLabel := make(map[string]*LabelEstimate)
+	var unestimated []UnestimatedIssue
+
+	for i := range issues {
+		issue := &issues[i]
+		if issue.Status.IsClosed() || issue.Status.IsTombstone() {
+			continue
+		}
+		report.OpenCount++
+
+		minutes, estimated := issue.ExplicitEstimateMinutes()
+		if estimated {
+			report.EstimatedCount++
+			report.TotalEstimatedMinutes += minutes
+			if issue.EstimatedMinutes != nil && *issue.EstimatedMinutes > 0 {
+				report.FromMinutes++
+			} else {
+				report.FromPoints++
+			}
+		} else {
+			var factors []string
+			minutes, factors = estimateComplexityMinutes(*issue, stats, medianMinutes)
+			report.HeuristicMinutes += minutes
+			unestimated = append(unestimated, UnestimatedIssue{
+				ID:               issue.ID,
+				Title:            issue.Title,
+				Type:             string(issue.IssueType),
+				Priority:         issue.Priority,
+				Labels:           issue.Labels,
+				HeuristicMinutes: minutes,
+				Factors:          factors,
+			})
+		}
+
+		for _, label := range issue.Labels {
+			le := byLabel[label]
+			if le == nil {
+				le = &LabelEstimate{Label: label}
+				byLabel[label] = le
+			}
+			le.OpenCount++
+			if estimated {
+				le.EstimatedCount++
+				le.TotalEstimatedMinutes += minutes
+			} else {
+				le.HeuristicMinutes += minutes
+			}
+		}
+	}
+
+	report.CoveragePct = coveragePct(report.EstimatedCount, report.OpenCount)
+	for _, le := range byLabel {
+		le.CoveragePct = coveragePct(le.EstimatedCount, le.OpenCount)
+		report.ByLabel = append(report.ByLabel, *le)
+	}
+	// Most remaining work first
+	sort.Slice(report.ByLabel, func(i, j int) bool {
+		a, b := report.ByLabel[i], report.ByLabel[j]
+		if ta, tb := a.TotalEstimatedMinutes+a.HeuristicMinutes, b.TotalEstimatedMinutes+b.HeuristicMinutes; ta != tb {
+			return ta > tb
+		}
+		return a.Label < b.Label
+	})
+
+	// Biggest guesses first; higher priority breaks ties
+	sort.Slice(unestimated, func(i, j int) bool {
+		a, b := unestimated[i], unestimated[j]
+		if a.HeuristicMinutes != b.HeuristicMinutes {
+			return a.HeuristicMinutes > b.HeuristicMinutes
+		}
+		if a.Priority != b.Priority {
+			return a.Priority < b.Priority
+		}
+		return a.ID < b.ID
+	})
+	if len(unestimated) > limit {
+		unestimated = unestimated[:limit]
+	}
+	report.LargestUnestimated = append(report.LargestUnestimated, unestimated...)
+	return report
+}
+
+func coveragePct(estimated, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(estimated) * 100 / float64(total)
+}
+
+// MinCalibrationSamples is how many closed issues with both an estimate and
+// tracked time a label (or the project) needs before its actual-to-estimate
+// ratio is trusted.
+const MinCalibrationSamples = 3
+
+// EstimateVariance compares estimates with time tracked in .bv/time.jsonl,
+// over closed issues that have both.
+type EstimateVariance struct {
+	TrackedIssues  int `json:"tracked_issues"`  // Issues with any tracked time
+	TrackedMinutes int `json:"tracked_minutes"` // All tracked time
+	Samples        int `json:"samples"`         // Closed issues with estimate and actual
+	// EstimatedMinutes and ActualMinutes total the samples
+	EstimatedMinutes int             `json:"estimated_minutes"`
+	ActualMinutes    int             `json:"actual_minutes"`
+	Ratio            float64         `json:"actual_to_estimate"`
+	VariancePct      float64         `json:"variance_pct"` // +25 means work took 25% longer than estimated
+	ByLabel          []LabelVariance `json:"by_label"`
+}
+
+// LabelVariance is estimate vs actual for one label's samples
+type LabelVariance struct {
+	Label            string  `json:"label"`
+	Samples          int     `json:"samples"`
+	EstimatedMinutes int     `json:"estimated_minutes"`
+	ActualMinutes    int     `json:"actual_minutes"`
+	Ratio            float64 `json:"actual_to_estimate"`
+	VariancePct      float64 `json:"variance_pct"`
+	// Calibrated reports whether the forecast scales this label's estimates
+	Calibrated bool `json:"calibrated"`
+}
+
+// ComputeEstimateVariance compares explicit estimates of closed issues with
+// their actual minutes (issue ID -> minutes). Labels are ordered by absolute
+// variance, worst first.
+func ComputeEstimateVariance(issues []model.Issue, actuals map[string]int) EstimateVariance {
+	v := EstimateVariance{ByLabel: []LabelVariance{}}
+	byLabel := make(map[string]*LabelVariance)
+	for i := range issues {
+		issue := &issues[i]
+		actual, tracked := actuals[issue.ID]
+		if !tracked {
+			continue
+		}
+		v.TrackedIssues++
+		v.TrackedMinutes += actual
+		estimate, ok := issue.ExplicitEstimateMinutes()
+		if !ok || issue.Status != model.StatusClosed || actual <= 0 {
+			continue
+		}
+		v.Samples++
+		v.EstimatedMinutes += estimate
+		v.ActualMinutes += actual
+		for _, label := range issue.Labels {
+			lv := byLabel[label]
+			if lv == nil {
+				lv = &LabelVariance{Label: label}
+				byLabel[label] = lv
+			}
+			lv.Samples++
+			lv.EstimatedMinutes += estimate
+			lv.ActualMinutes += actual
+		}
+	}
+
+	v.Ratio, v.VariancePct = actualRatio(v.ActualMinutes, v.EstimatedMinutes)
+	for _, lv := range byLabel {
+		lv.Ratio, lv.VariancePct = actualRatio(lv.ActualMinutes, lv.EstimatedMinutes)
+		lv.Calibrated = lv.Samples >= MinCalibrationSamples
+		v.ByLabel = append(v.ByLabel, *lv)
+	}
+	sort.Slice(v.ByLabel, func(i, j int) bool {
+		a, b := v.ByLabel[i], v.ByLabel[j]
+		if da, db := math.Abs(a.VariancePct), math.Abs(b.VariancePct); da != db {
+			return da > db
+		}
+		return a.Label < b.Label
+	})
+	return v
+}
+
+func actualRatio(actual, estimated int) (ratio, variancePct float64) {
+	if estimated <= 0 {
+		return 0, 0
+	}
+	ratio = float64(actual) / float64(estimated)
+	return round2(ratio), round2((ratio - 1) * 100)
+}
+
+// EstimateCalibration scales estimates by how long similar work actually
+// took. Ratios come from ComputeEstimateVariance; labels and the project
+// need MinCalibrationSamples samples, and ratios are clamped to 0.25-4.
+type EstimateCalibration struct {
+	Global  float64            // 0 when there are too few samples
+	ByLabel map[string]float64 // Only calibrated labels
+}
+
+// NewEstimateCalibration derives a calibration from tracked actuals. It
+// returns nil when there is nothing to calibrate with.
+func NewEstimateCalibration(issues []model.Issue, actuals map[string]int) *EstimateCalibration {
+	if len(actuals) == 0 {
+		return nil
+	}
+	v := ComputeEstimateVariance(issues, actuals)
+	c := &EstimateCalibration{ByLabel: make(map[string]float64)}
+	if v.Samples >= MinCalibrationSamples {
+		c.Global = clampFloat(v.Ratio, 0.25, 4)
+	}
+	for _, lv := range v.ByLabel {
+		if lv.Calibrated {
+			c.ByLabel[lv.Label] = clampFloat(lv.Ratio, 0.25, 4)
+		}
+	}
+	if c.Global == 0 && len(c.ByLabel) == 0 {
+		return nil
+	}
+	return c
+}
+
+// factor returns the ratio to scale issue's estimate by and what it came
+// from. Of several calibrated labels the largest ratio wins, matching the
+// forecast's conservative choice of the slowest label velocity.
+func (c *EstimateCalibration) factor(issue model.Issue) (float64, string) {
+	if c == nil {
+		return 1, ""
+	}
+	best, source := 0.0, ""
+	for _, label := range issue.Labels {
+		if r, ok := c.ByLabel[label]; ok && (r > best || (r == best && label < source)) {
+			best, source = r, label
+		}
+	}
+	if best > 0 {
+		return best, "label=" + source
+	}
+	if c.Global > 0 {
+		return c.Global, "global"
+	}
+	return 1, ""
+}
+
+// CriticalPathMinutes returns the longest chain of open issues linked by
+// blocking dependencies, weighted by each issue's estimate (explicit, else
+// the forecast's heuristic), and its total minutes. The chain runs from the
+// first issue to work on to the last. Edges closing a cycle are ignored.
+func CriticalPathMinutes(issues []model.Issue, stats *GraphStats) ([]string, int) {
+	open := make(map[string]*model.Issue)
+	for i := range issues {
+		if !issues[i].Status.IsClosed() && !issues[i].Status.IsTombstone() {
+			open[issues[i].ID] = &issues[i]
+		}
+	}
+	blocks := make(map[string][]string) // blocker -> open issues waiting on it
+	for _, issue := range open {
+		for _, dep := range issue.Dependencies {
+			if dep == nil || !dep.Type.IsBlocking() || dep.DependsOnID == issue.ID {
+				continue
+			}
+			if _, ok := open[dep.DependsOnID]; ok {
+				blocks[dep.DependsOnID] = append(blocks[dep.DependsOnID], issue.ID)
+			}
+		}
+	}
+
+	medianMinutes := computeMedianEstimatedMinutes(issues)
+	best := make(map[string]int)    // Minutes of the longest chain starting at ID
+	next := make(map[string]string) // Successor on that chain
+	visiting := make(map[string]bool)
+	var longest func(id string) int
+	longest = func(id string) int {
+		if total, ok := best[id]; ok {
+			return total
+		}
+		visiting[id] = true
+		tail := 0
+		followers := blocks[id]
+		sort.Strings(followers)
+		for _, f := range followers {
+			if visiting[f] {
+				continue
+			}
+			if t := longest(f); t > tail {
+				tail, next[id] = t, f
+			}
+		}
+		visiting[id] = false
+		minutes, _ := estimateComplexityMinutes(*open[id], stats, medianMinutes)
+		best[id] = minutes + tail
+		return best[id]
+	}
+
+	ids := make([]string, 0, len(open))
+	for id := range open {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	start, total := "", 0
+	for _, id := range ids {
+		if t := longest(id); t > total {
+			start, total = id, t
+		}
+	}
+	var path []string
+	for id := start; id != ""; id = next[id] {
+		path = append(path, id)
+	}
+	return path, total
+}
@@ -0,0 +1,199 @@
+package analysis
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestComputeEstimateReport(t *testing.T) {
+	minutes := func(n int) *int { return &n }
+	points := func(n float64) *float64 { return &n }
+	issues := []model.Issue{
+		{ID: "a", Status: model.StatusOpen, Labels: []string{"api"}, EstimatedMinutes: minutes(120)},
+		{ID: "b", Status: model.StatusOpen, Labels: []string{"api", "ui"}, EstimatedPoints: points(2)},
+		{ID: "c", Status: model.StatusOpen, IssueType: model.TypeEpic, Labels: []string{"ui"}},
+		{ID: "d", Status: model.StatusOpen, IssueType: model.TypeChore, Priority: 1},
+		{ID: "e", Status: model.StatusClosed, Labels: []string{"api"}, EstimatedMinutes: minutes(60)},
+	}
+
+	report := ComputeEstimateReport(issues, nil, 1)
+
+	if report.OpenCount != 4 || report.EstimatedCount != 2 || report.CoveragePct != 50 {
+		t.Errorf("coverage = %d/%d (%.1f%%), want 2/4 (50%%)", report.EstimatedCount, report.OpenCount, report.CoveragePct)
+	}
+	if report.FromMinutes != 1 || report.FromPoints != 1 {
+		t.Errorf("sources = %d minutes, %d points", report.FromMinutes, report.FromPoints)
+	}
+	if want := 120 + 2*model.MinutesPerPoint; report.TotalEstimatedMinutes != want {
+		t.Errorf("total = %d, want %d", report.TotalEstimatedMinutes, want)
+	}
+
+	labels := make(map[string]LabelEstimate)
+	for _, le := range report.ByLabel {
+		labels[le.Label] = le
+	}
+	if api := labels["api"]; api.OpenCount != 2 || api.EstimatedCount != 2 || api.CoveragePct != 100 {
+		t.Errorf("api = %+v, want fully estimated (closed work excluded)", api)
+	}
+	if ui := labels["ui"]; ui.OpenCount != 2 || ui.EstimatedCount != 1 || ui.HeuristicMinutes == 0 {
+		t.Errorf("ui = %+v, want half estimated with a heuristic remainder", ui)
+	}
+
+	// The epic is the bigger guess, and limit keeps only it
+	if len(report.LargestUnestimated) != 1 || report.LargestUnestimated[0].ID != "c" {
+		t.Fatalf("largest unestimated = %+v, want [c]", report.LargestUnestimated)
+	}
+	if report.LargestUnestimated[0].HeuristicMinutes <= 0 || len(report.LargestUnestimated[0].Factors) == 0 {
+		t.Errorf("unestimated item should carry its heuristic size: %+v", report.LargestUnestimated[0])
+	}
+}
+
+func TestComputeEstimateReport_Empty(t *testing.T) {
+	report := ComputeEstimateReport(nil, nil, 0)
+	if report.OpenCount != 0 || report.CoveragePct != 0 {
+		t.Errorf("empty report = %+v", report)
+	}
+	if report.ByLabel == nil || report.LargestUnestimated == nil {
+		t.Error("slices should be empty, not nil, so JSON has [] instead of null")
+	}
+}
+
+func TestEstimateETAForIssue_PrefersExplicitEstimate(t *testing.T) {
+	now := time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC)
+	points := 1.5
+	issues := []model.Issue{
+		{ID: "x", Status: model.StatusOpen, IssueType: model.TypeEpic, EstimatedPoints: &points},
+	}
+
+	eta, err := EstimateETAForIssue(issues, nil, "x", 1, now)
+	if err != nil {
+		t.Fatalf("EstimateETAForIssue failed: %v", err)
+	}
+	if eta.EstimatedMinutes != 360 {
+		t.Errorf("estimated minutes = %d, want 1.5 points * 240 = 360 (no epic multiplier)", eta.EstimatedMinutes)
+	}
+	if !strings.Contains(strings.Join(eta.Factors, "; "), "1.5 points") {
+		t.Errorf("factors should name the explicit estimate: %v", eta.Factors)
+	}
+}
+
+func TestComputeEstimateVariance(t *testing.T) {
+	minutes := func(n int) *int { return &n }
+	issues := []model.Issue{
+		{ID: "a", Status: model.StatusClosed, Labels: []string{"api"}, EstimatedMinutes: minutes(60)},
+		{ID: "b", Status: model.StatusClosed, Labels: []string{"api", "ui"}, EstimatedMinutes: minutes(60)},
+		{ID: "c", Status: model.StatusClosed, Labels: []string{"api"}, EstimatedMinutes: minutes(60)},
+		{ID: "d", Status: model.StatusClosed, Labels: []string{"ui"}},                              // No estimate
+		{ID: "e", Status: model.StatusOpen, Labels: []string{"ui"}, EstimatedMinutes: minutes(60)}, // Still open
+	}
+	actuals := map[string]int{"a": 120, "b": 60, "c": 90, "d": 30, "e": 10}
+
+	v := ComputeEstimateVariance(issues, actuals)
+	if v.TrackedIssues != 5 || v.TrackedMinutes != 310 {
+		t.Errorf("tracked = %d issues / %d min", v.TrackedIssues, v.TrackedMinutes)
+	}
+	if v.Samples != 3 || v.EstimatedMinutes != 180 || v.ActualMinutes != 270 || v.Ratio != 1.5 || v.VariancePct != 50 {
+		t.Errorf("totals = %+v", v)
+	}
+	if len(v.ByLabel) != 2 || v.ByLabel[0].Label != "api" || !v.ByLabel[0].Calibrated || v.ByLabel[0].VariancePct != 50 {
+		t.Fatalf("by label = %+v, want api (+50%%, calibrated) first", v.ByLabel)
+	}
+	if ui := v.ByLabel[1]; ui.Label != "ui" || ui.Samples != 1 || ui.Calibrated || ui.VariancePct != 0 {
+		t.Errorf("ui = %+v", ui)
+	}
+
+	empty := ComputeEstimateVariance(issues, nil)
+	if empty.Samples != 0 || empty.ByLabel == nil {
+		t.Errorf("no actuals = %+v, want zero samples and [] labels", empty)
+	}
+}
+
+func TestNewEstimateCalibration(t *testing.T) {
+	minutes := func(n int) *int { return &n }
+	issues := []model.Issue{
+		{ID: "a", Status: model.StatusClosed, Labels: []string{"api"}, EstimatedMinutes: minutes(60)},
+		{ID: "b", Status: model.StatusClosed, Labels: []string{"api"}, EstimatedMinutes: minutes(60)},
+		{ID: "c", Status: model.StatusClosed, Labels: []string{"api"}, EstimatedMinutes: minutes(60)},
+	}
+	if c := NewEstimateCalibration(issues, map[string]int{"a": 120}); c != nil {
+		t.Errorf("one sample should not calibrate: %+v", c)
+	}
+
+	c := NewEstimateCalibration(issues, map[string]int{"a": 600, "b": 600, "c": 600})
+	if c == nil || c.Global != 4 || c.ByLabel["api"] != 4 {
+		t.Fatalf("calibration = %+v, want ratios clamped to 4", c)
+	}
+	if f, src := c.factor(model.Issue{Labels: []string{"api"}}); f != 4 || src != "label=api" {
+		t.Errorf("labeled factor = %v (%s)", f, src)
+	}
+	if f, src := c.factor(model.Issue{}); f != 4 || src != "global" {
+		t.Errorf("unlabeled factor = %v (%s)", f, src)
+	}
+	var none *EstimateCalibration
+	if f, _ := none.factor(model.Issue{}); f != 1 {
+		t.Errorf("nil calibration factor = %v, want 1", f)
+	}
+}
+
+func TestEstimateETAForIssueWithActuals_ScalesEstimate(t *testing.T) {
+	now := time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC)
+	minutes := func(n int) *int { return &n }
+	closedAt := now.Add(-24 * time.Hour)
+	issues := []model.Issue{
+		{ID: "x", Status: model.StatusOpen, Labels: []string{"api"}, EstimatedMinutes: minutes(100)},
+	}
+	actuals := make(map[string]int)
+	for _, id := range []string{"d1", "d2", "d3"} {
+		issues = append(issues, model.Issue{ID: id, Status: model.StatusClosed, Labels: []string{"api"}, EstimatedMinutes: minutes(60), ClosedAt: &closedAt})
+		actuals[id] = 120
+	}
+
+	base, err := EstimateETAForIssue(issues, nil, "x", 1, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	calibrated, err := EstimateETAForIssueWithActuals(issues, nil, "x", 1, now, actuals)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if calibrated.EstimatedMinutes != 2*base.EstimatedMinutes {
+		t.Errorf("calibrated minutes = %d, want 2x %d", calibrated.EstimatedMinutes, base.EstimatedMinutes)
+	}
+	if !strings.Contains(strings.Join(calibrated.Factors, "; "), "calibration: label=api ×2.00") {
+		t.Errorf("factors should explain the calibration: %v", calibrated.Factors)
+	}
+}
+
+func TestCriticalPathMinutes(t *testing.T) {
+	minutes := func(n int) *int { return &n }
+	blocks := func(ids ...string) []*model.Dependency {
+		var deps []*model.Dependency
+		for _, id := range ids {
+			deps = append(deps, &model.Dependency{DependsOnID: id, Type: model.DepBlocks})
+		}
+		return deps
+	}
+	issues := []model.Issue{
+		{ID: "a", Status: model.StatusOpen, EstimatedMinutes: minutes(60)},
+		{ID: "b", Status: model.StatusOpen, EstimatedMinutes: minutes(30), Dependencies: blocks("a")},
+		{ID: "c", Status: model.StatusOpen, EstimatedMinutes: minutes(120), Dependencies: blocks("a")},
+		{ID: "d", Status: model.StatusOpen, EstimatedMinutes: minutes(10), Dependencies: blocks("b", "c")},
+		{ID: "x", Status: model.StatusClosed, EstimatedMinutes: minutes(999)},
+		{ID: "y", Status: model.StatusOpen, EstimatedMinutes: minutes(5), Dependencies: blocks("x")},
+		// A two-issue cycle is counted once around
+		{ID: "p", Status: model.StatusOpen, EstimatedMinutes: minutes(50), Dependencies: blocks("q")},
+		{ID: "q", Status: model.StatusOpen, EstimatedMinutes: minutes(50), Dependencies: blocks("p")},
+	}
+
+	path, total := CriticalPathMinutes(issues, nil)
+	if strings.Join(path, ",") != "a,c,d" || total != 190 {
+		t.Errorf("critical path = %v (%dm), want a,c,d (190m)", path, total)
+	}
+
+	if path, total := CriticalPathMinutes(nil, nil); len(path) != 0 || total != 0 {
+		t.Errorf("empty = %v, %d", path, total)
+	}
+}
@@ -29,6 +29,14 @@ type ETAEstimate struct {
 // - Velocity minutes/day: derived from recent closures of issues sharing labels (fallback to global, then default).
 // - ETA days = minutes / (velocity * agents), with a simple confidence interval.
 func EstimateETAForIssue(issues []model.Issue, stats *GraphStats, issueID string, agents int, now time.Time) (ETAEstimate, error) {
+	return EstimateETAForIssueWithActuals(issues, stats, issueID, agents, now, nil)
+}
+
+// EstimateETAForIssueWithActuals is EstimateETAForIssue calibrated with
+// tracked time (issue ID -> actual minutes, see pkg/timetrack): complexity is
+// scaled by how long estimated work actually took (EstimateCalibration), and
+// closed issues count their actual minutes toward velocity.
+func EstimateETAForIssueWithActuals(issues []model.Issue, stats *GraphStats, issueID string, agents int, now time.Time, actuals map[string]int) (ETAEstimate, error) {
 	issueMap := make(map[string]model.Issue, len(issues))
 	for _, iss := range issues {
 		issueMap[iss.ID] = iss
@@ -44,8 +52,12 @@ func EstimateETAForIssue(issues []model.Issue, stats *GraphStats, issueID string
 
 	medianMinutes := computeMedianEstimatedMinutes(issues)
 	complexityMinutes, complexityFactors := estimateComplexityMinutes(issue, stats, medianMinutes)
+	if ratio, source := NewEstimateCalibration(issues, actuals).factor(issue); source != "" {
+		complexityMinutes = int(float64(complexityMinutes) * ratio)
+		complexityFactors = append(complexityFactors, fmt.Sprintf("calibration: %s ×%.2f (tracked time)", source, ratio))
+	}
 
-	velocityPerDay, velocitySamples, velocityFactors := estimateVelocityMinutesPerDay(issues, issue, now, medianMinutes)
+	velocityPerDay, velocitySamples, velocityFactors := estimateVelocityMinutesPerDay(issues, issue, now, medianMinutes, actuals)
 	if velocityPerDay <= 0 {
 		// Conservative default: one median-sized issue per (work) week.
 		velocityPerDay = float64(medianMinutes) / 5.0
@@ -94,12 +106,18 @@ func EstimateETAForIssue(issues []model.Issue, stats *GraphStats, issueID string
 func estimateComplexityMinutes(issue model.Issue, stats *GraphStats, medianMinutes int) (int, []string) {
 	var factors []string
 
+	// An explicit estimate already accounts for the issue's size; scaling it
+	// by the heuristics below would count that twice.
+	if minutes, ok := issue.ExplicitEstimateMinutes(); ok {
+		source := "explicit"
+		if issue.EstimatedMinutes == nil || *issue.EstimatedMinutes <= 0 {
+			source = fmt.Sprintf("%g points", *issue.EstimatedPoints)
+		}
+		return minutes, []string{fmt.Sprintf("estimate: %s (%dm)", source, minutes)}
+	}
+
 	baseMinutes := medianMinutes
 	estimateSource := "median"
-	if issue.EstimatedMinutes != nil && *issue.EstimatedMinutes > 0 {
-		baseMinutes = *issue.EstimatedMinutes
-		estimateSource = "explicit"
-	}
 	if baseMinutes <= 0 {
 		baseMinutes = DefaultEstimatedMinutes
 		estimateSource = "default"
@@ -148,13 +166,13 @@ func estimateComplexityMinutes(issue model.Issue, stats *GraphStats, medianMinut
 	return derived, factors
 }
 
-func estimateVelocityMinutesPerDay(issues []model.Issue, issue model.Issue, now time.Time, medianMinutes int) (float64, int, []string) {
+func estimateVelocityMinutesPerDay(issues []model.Issue, issue model.Issue, now time.Time, medianMinutes int, actuals map[string]int) (float64, int, []string) {
 	const windowDays = 30
 	since := now.Add(-time.Duration(windowDays) * 24 * time.Hour)
 
 	labels := issue.Labels
 	if len(labels) == 0 {
-		v, n := velocityMinutesPerDayForLabel(issues, "", since, medianMinutes)
+		v, n := velocityMinutesPerDayForLabel(issues, "", since, medianMinutes, actuals)
 		return v, n, []string{fmt.Sprintf("velocity: global (%d samples/30d)", n)}
 	}
 
@@ -163,7 +181,7 @@ func estimateVelocityMinutesPerDay(issues []model.Issue, issue model.Issue, now
 	bestV := 0.0
 	bestN := 0
 	for _, label := range labels {
-		v, n := velocityMinutesPerDayForLabel(issues, label, since, medianMinutes)
+		v, n := velocityMinutesPerDayForLabel(issues, label, since, medianMinutes, actuals)
 		if n == 0 || v <= 0 {
 			continue
 		}
@@ -178,11 +196,11 @@ func estimateVelocityMinutesPerDay(issues []model.Issue, issue model.Issue, now
 	}
 
 	// Fallback: global velocity.
-	v, n := velocityMinutesPerDayForLabel(issues, "", since, medianMinutes)
+	v, n := velocityMinutesPerDayForLabel(issues, "", since, medianMinutes, actuals)
 	return v, n, []string{fmt.Sprintf("velocity: global (%d samples/30d)", n)}
 }
 
-func velocityMinutesPerDayForLabel(issues []model.Issue, label string, since time.Time, medianMinutes int) (float64, int) {
+func velocityMinutesPerDayForLabel(issues []model.Issue, label string, since time.Time, medianMinutes int, actuals map[string]int) (float64, int) {
 	total := 0
 	samples := 0
 
@@ -205,8 +223,10 @@ func velocityMinutesPerDayForLabel(issues []model.Issue, label string, since tim
 		}
 
 		minutes := medianMinutes
-		if iss.EstimatedMinutes != nil && *iss.EstimatedMinutes > 0 {
-			minutes = *iss.EstimatedMinutes
+		if actual := actuals[iss.ID]; actual > 0 {
+			minutes = actual
+		} else if explicit, ok := iss.ExplicitEstimateMinutes(); ok {
+			minutes = explicit
 		}
 		if minutes <= 0 {
 			minutes = DefaultEstimatedMinutes
@@ -233,7 +253,7 @@ func hasLabel(labels []string, target string) bool {
 func estimateETAConfidence(issue model.Issue, velocitySamples int) float64 {
 	conf := 0.25
 
-	if issue.EstimatedMinutes != nil && *issue.EstimatedMinutes > 0 {
+	if _, ok := issue.ExplicitEstimateMinutes(); ok {
 		conf += 0.25
 	}
 	switch {
@@ -253,12 +273,12 @@ func estimateETAConfidence(issue model.Issue, velocitySamples int) float64 {
 	return clampFloat(conf, 0.10, 0.90)
 }
 
-// computeMedianEstimatedMinutes calculates the median estimated_minutes from a list of issues
+// computeMedianEstimatedMinutes calculates the median explicit estimate from a list of issues
 func computeMedianEstimatedMinutes(issues []model.Issue) int {
 	var estimates []int
 	for _, issue := range issues {
-		if issue.EstimatedMinutes != nil && *issue.EstimatedMinutes > 0 {
-			estimates = append(estimates, *issue.EstimatedMinutes)
+		if minutes, ok := issue.ExplicitEstimateMinutes(); ok {
+			estimates = append(estimates, minutes)
 		}
 	}
 
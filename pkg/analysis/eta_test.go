@@ -425,7 +425,7 @@ func TestVelocityMinutesPerDayForLabel_EdgeCases(t *testing.T) {
 	openOnly := []model.Issue{
 		{ID: "1", Status: model.StatusOpen},
 	}
-	v, n := velocityMinutesPerDayForLabel(openOnly, "", since, 60)
+	v, n := velocityMinutesPerDayForLabel(openOnly, "", since, 60, nil)
 	if v != 0 || n != 0 {
 		t.Errorf("No closed issues should return 0 velocity: v=%f, n=%d", v, n)
 	}
@@ -435,7 +435,7 @@ func TestVelocityMinutesPerDayForLabel_EdgeCases(t *testing.T) {
 	oldClosures := []model.Issue{
 		{ID: "1", Status: model.StatusClosed, ClosedAt: &oldClosed},
 	}
-	v, n = velocityMinutesPerDayForLabel(oldClosures, "", since, 60)
+	v, n = velocityMinutesPerDayForLabel(oldClosures, "", since, 60, nil)
 	if v != 0 || n != 0 {
 		t.Errorf("Old closures should return 0 velocity: v=%f, n=%d", v, n)
 	}
@@ -446,7 +446,7 @@ func TestVelocityMinutesPerDayForLabel_EdgeCases(t *testing.T) {
 	recentClosures := []model.Issue{
 		{ID: "1", Status: model.StatusClosed, ClosedAt: &recentClosed, EstimatedMinutes: &est120, Labels: []string{"api"}},
 	}
-	v, n = velocityMinutesPerDayForLabel(recentClosures, "api", since, 60)
+	v, n = velocityMinutesPerDayForLabel(recentClosures, "api", since, 60, nil)
 	if n != 1 {
 		t.Errorf("Expected 1 sample, got %d", n)
 	}
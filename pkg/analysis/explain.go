@@ -0,0 +1,423 @@
+package analysis
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// IssueExplanation gathers everything the analysis knows about one issue:
+// how it scores against the rest, why triage does or doesn't recommend it,
+// what blocks it and what finishing it would free, when it should be done,
+// its history and the issues that read most like it (--robot-explain).
+type IssueExplanation struct {
+	ID             string                `json:"id"`
+	Title          string                `json:"title"`
+	Status         string                `json:"status"`
+	Priority       int                   `json:"priority"`
+	Scores         []ExplainedScore      `json:"scores"`
+	Breakdown      *ScoreBreakdown       `json:"breakdown,omitempty"` // nil for closed issues
+	Recommendation ExplainRecommendation `json:"recommendation"`
+	Blockers       *BlockerChainResult   `json:"blockers"`
+	Unblocks       ExplainUnblocks       `json:"unblocks"`
+	Forecast       *ETAEstimate          `json:"forecast,omitempty"` // nil for closed issues
+	History        ExplainHistory        `json:"history"`
+	Similar        []SimilarIssue        `json:"similar"`
+}
+
+// ExplainedScore is one metric of the issue with its percentile rank
+type ExplainedScore struct {
+	Name       string  `json:"name"`
+	Value      float64 `json:"value"`
+	Percentile float64 `json:"percentile"` // Share of the other issues scoring lower, ties counting half, 0-100
+	Of         int     `json:"of"`         // Issues compared (open issues for scores, all for graph metrics)
+}
+
+// ExplainRecommendation says where the issue stands in triage
+type ExplainRecommendation struct {
+	Recommended bool     `json:"recommended"`
+	Rank        int      `json:"rank,omitempty"` // 1-based triage rank among open issues
+	Of          int      `json:"of"`             // Open issues ranked
+	Cutoff      int      `json:"cutoff"`         // How many issues triage recommends
+	Score       float64  `json:"score,omitempty"`
+	CutoffScore float64  `json:"cutoff_score,omitempty"` // Score of the last recommended issue
+	Reasons     []string `json:"reasons,omitempty"`      // Triage reasons for the issue
+	WhyNot      []string `json:"why_not,omitempty"`      // Set when it is not recommended
+	Action      string   `json:"action,omitempty"`
+}
+
+// ExplainUnblocks is what finishing the issue frees up
+type ExplainUnblocks struct {
+	Direct     []string      `json:"direct"`     // Dependents ready once this alone is done
+	Transitive int           `json:"transitive"` // Issues that become ready in the resulting cascade
+	Tree       []UnblockNode `json:"tree"`       // The cascade, each issue under the one that frees it
+}
+
+// UnblockNode is an issue in the unblock cascade
+type UnblockNode struct {
+	ID       string        `json:"id"`
+	Title    string        `json:"title"`
+	Children []UnblockNode `json:"children,omitempty"`
+}
+
+// ExplainHistory is the issue's lifecycle as recorded in the beads data
+type ExplainHistory struct {
+	CreatedAt       time.Time           `json:"created_at"`
+	UpdatedAt       time.Time           `json:"updated_at"`
+	ClosedAt        *time.Time          `json:"closed_at,omitempty"`
+	AgeDays         int                 `json:"age_days"`
+	DaysSinceUpdate int                 `json:"days_since_update"`
+	Comments        int                 `json:"comments"`
+	Events          []*model.IssueEvent `json:"events,omitempty"`
+}
+
+// SimilarIssue is another issue whose title and description share keywords
+// with the explained one
+type SimilarIssue struct {
+	ID         string   `json:"id"`
+	Title      string   `json:"title"`
+	Status     string   `json:"status"`
+	Similarity float64  `json:"similarity"` // Keyword Jaccard similarity, 0-1
+	Keywords   []string `json:"common_keywords"`
+}
+
+// ExplainOptions configures ExplainIssue
+type ExplainOptions struct {
+	Now     time.Time
+	Agents  int            // For the forecast; default 1
+	Actuals map[string]int // Tracked minutes by issue, to calibrate the forecast
+	TopN    int            // How many issues triage recommends; default 10
+}
+
+// Limits on the explanation's lists
+const (
+	explainMaxSimilar       = 5
+	explainMinSimilarity    = 0.2
+	explainMaxUnblockNodes  = 50
+	explainDefaultTriageTop = 10
+)
+
+// ExplainIssue explains issueID against issues. Triage here is the plain
+// ranking; plugin scores, claims and feedback are not applied.
+func ExplainIssue(issues []model.Issue, issueID string, opts ExplainOptions) (*IssueExplanation, error) {
+	if opts.Now.IsZero() {
+		opts.Now = time.Now()
+	}
+	if opts.TopN <= 0 {
+		opts.TopN = explainDefaultTriageTop
+	}
+	analyzer := NewAnalyzer(issues)
+	issue := analyzer.GetIssue(issueID)
+	if issue == nil {
+		return nil, fmt.Errorf("issue %q not found", issueID)
+	}
+	stats := analyzer.Analyze()
+
+	impact := analyzer.ComputeImpactScoresFromStats(&stats, opts.Now)
+	unblocksMap := buildUnblocksMap(analyzer, issues)
+	triage := computeTriageScoresFromImpact(impact, unblocksMap, analyzer, DefaultTriageScoringOptions())
+
+	exp := &IssueExplanation{
+		ID:       issue.ID,
+		Title:    issue.Title,
+		Status:   string(issue.Status),
+		Priority: issue.Priority,
+		Scores:   explainScores(issueID, &stats, impact, triage),
+		Blockers: analyzer.GetBlockerChain(issueID),
+		Unblocks: ExplainUnblocks{
+			Direct:     unblocksMap[issueID],
+			Transitive: analyzer.countTransitiveUnblocks(issueID),
+			Tree:       analyzer.unblockTree(issueID, explainMaxUnblockNodes),
+		},
+		History: explainHistory(issue, opts.Now),
+		Similar: similarIssues(issues, issue),
+	}
+	if exp.Unblocks.Direct == nil {
+		exp.Unblocks.Direct = []string{}
+	}
+	for _, s := range impact {
+		if s.IssueID == issueID {
+			bd := s.Breakdown
+			exp.Breakdown = &bd
+			break
+		}
+	}
+	exp.Recommendation = explainRecommendation(issue, triage, analyzer, unblocksMap, opts.TopN)
+
+	if issue.Status != model.StatusClosed {
+		if eta, err := EstimateETAForIssueWithActuals(issues, &stats, issueID, opts.Agents, opts.Now, opts.Actuals); err == nil {
+			exp.Forecast = &eta
+		}
+	}
+	return exp, nil
+}
+
+// explainScores lists the issue's triage and impact scores and their
+// components against the open issues, then its graph metrics against all
+func explainScores(id string, stats *GraphStats, impact []ImpactScore, triage []TriageScore) []ExplainedScore {
+	var scores []ExplainedScore
+	add := func(name string, values map[string]float64) {
+		if v, ok := values[id]; ok {
+			pct, n := percentileRank(values, v)
+			scores = append(scores, ExplainedScore{Name: name, Value: v, Percentile: pct, Of: n})
+		}
+	}
+
+	triageScores := make(map[string]float64, len(triage))
+	for _, t := range triage {
+		triageScores[t.IssueID] = t.TriageScore
+	}
+	add("triage", triageScores)
+
+	components := []struct {
+		name string
+		get  func(ScoreBreakdown) float64
+	}{
+		{"pagerank", func(b ScoreBreakdown) float64 { return b.PageRankNorm }},
+		{"betweenness", func(b ScoreBreakdown) float64 { return b.BetweennessNorm }},
+		{"blocker_ratio", func(b ScoreBreakdown) float64 { return b.BlockerRatioNorm }},
+		{"staleness", func(b ScoreBreakdown) float64 { return b.StalenessNorm }},
+		{"priority_boost", func(b ScoreBreakdown) float64 { return b.PriorityBoostNorm }},
+		{"time_to_impact", func(b ScoreBreakdown) float64 { return b.TimeToImpactNorm }},
+		{"urgency", func(b ScoreBreakdown) float64 { return b.UrgencyNorm }},
+		{"risk", func(b ScoreBreakdown) float64 { return b.RiskNorm }},
+	}
+	impactScores := make(map[string]float64, len(impact))
+	for _, s := range impact {
+		impactScores[s.IssueID] = s.Score
+	}
+	add("impact", impactScores)
+	for _, c := range components {
+		values := make(map[string]float64, len(impact))
+		for _, s := range impact {
+			values[s.IssueID] = c.get(s.Breakdown)
+		}
+		add("impact."+c.name, values)
+	}
+
+	inDegree := make(map[string]float64, len(stats.InDegree))
+	for k, v := range stats.InDegree {
+		inDegree[k] = float64(v)
+	}
+	outDegree := make(map[string]float64, len(stats.OutDegree))
+	for k, v := range stats.OutDegree {
+		outDegree[k] = float64(v)
+	}
+	// Graph metrics rank over every issue in the graph. Some maps leave out
+	// zero scores (betweenness does), so a missing issue counts as 0; a
+	// metric that was skipped or timed out isn't ranked.
+	status := stats.Status()
+	addGraph := func(name string, values map[string]float64, st statusEntry) {
+		if st.State == "skipped" || st.State == "timeout" {
+			return
+		}
+		all := make(map[string]float64, len(stats.InDegree))
+		for k := range stats.InDegree {
+			all[k] = values[k]
+		}
+		add(name, all)
+	}
+	addGraph("graph.pagerank", stats.PageRank(), status.PageRank)
+	addGraph("graph.betweenness", stats.Betweenness(), status.Betweenness)
+	addGraph("graph.eigenvector", stats.Eigenvector(), status.Eigenvector)
+	addGraph("graph.hubs", stats.Hubs(), status.HITS)
+	addGraph("graph.authorities", stats.Authorities(), status.HITS)
+	addGraph("graph.critical_path", stats.CriticalPathScore(), status.Critical)
+	addGraph("graph.in_degree", inDegree, statusEntry{})
+	addGraph("graph.out_degree", outDegree, statusEntry{})
+	if scores == nil {
+		scores = []ExplainedScore{}
+	}
+	return scores
+}
+
+// percentileTieEpsilon is how close two scores must be to tie; iterative
+// metrics such as hubs leave float noise on scores that are really 0
+const percentileTieEpsilon = 1e-9
+
+// percentileRank is the share of the other values below v, ties counting
+// half, as 0-100 rounded to one decimal, and how many values there are; a
+// lone value ranks 100
+func percentileRank(values map[string]float64, v float64) (float64, int) {
+	n := len(values)
+	if n <= 1 {
+		return 100, n
+	}
+	below, ties := 0.0, -1.0 // v itself is among the ties
+	for _, other := range values {
+		switch {
+		case math.Abs(other-v) <= percentileTieEpsilon:
+			ties++
+		case other < v:
+			below++
+		}
+	}
+	return math.Round(1000*(below+ties/2)/float64(n-1)) / 10, n
+}
+
+// explainRecommendation places the issue in the triage ranking and says
+// why it made the cut or what keeps it out
+func explainRecommendation(issue *model.Issue, triage []TriageScore, analyzer *Analyzer, unblocksMap map[string][]string, topN int) ExplainRecommendation {
+	rec := ExplainRecommendation{Of: len(triage), Cutoff: min(topN, len(triage))}
+	if rec.Cutoff > 0 {
+		rec.CutoffScore = triage[rec.Cutoff-1].TriageScore
+	}
+	if issue.Status == model.StatusClosed {
+		rec.WhyNot = []string{"Closed"}
+		return rec
+	}
+	for i, t := range triage {
+		if t.IssueID != issue.ID {
+			continue
+		}
+		rec.Rank = i + 1
+		rec.Score = t.TriageScore
+		reasons := GenerateTriageReasonsForScore(t, analyzer, unblocksMap)
+		rec.Reasons = reasons.All
+		rec.Action = reasons.ActionHint
+		break
+	}
+	rec.Recommended = rec.Rank > 0 && rec.Rank <= rec.Cutoff
+	if rec.Recommended {
+		return rec
+	}
+
+	if rec.Rank > 0 {
+		rec.WhyNot = append(rec.WhyNot, fmt.Sprintf("Ranks #%d of %d; triage recommends the top %d (score %.3f, needs %.3f)",
+			rec.Rank, rec.Of, rec.Cutoff, rec.Score, rec.CutoffScore))
+	}
+	if blockers := analyzer.GetOpenBlockers(issue.ID); len(blockers) > 0 {
+		rec.WhyNot = append(rec.WhyNot, fmt.Sprintf("Blocked by %s", formatUnblockList(blockers)))
+	}
+	if len(unblocksMap[issue.ID]) == 0 {
+		rec.WhyNot = append(rec.WhyNot, "Finishing it unblocks nothing")
+	}
+	if issue.Priority >= 3 {
+		rec.WhyNot = append(rec.WhyNot, fmt.Sprintf("Low priority (P%d)", issue.Priority))
+	}
+	return rec
+}
+
+// unblockTree simulates finishing issueID and returns the issues that
+// become ready, each under the issue whose completion freed it, stopping
+// after maxNodes issues
+func (a *Analyzer) unblockTree(issueID string, maxNodes int) []UnblockNode {
+	done := map[string]bool{issueID: true}
+	children := map[string][]string{}
+	queue := []string{issueID}
+	count := 0
+	for len(queue) > 0 && count < maxNodes {
+		curr := queue[0]
+		queue = queue[1:]
+		for _, depID := range a.Dependents(curr) {
+			if done[depID] || count >= maxNodes {
+				continue
+			}
+			if dep := a.issueMap[depID]; dep.Status == model.StatusClosed {
+				continue
+			}
+			ready := true
+			for _, blockerID := range a.GetBlockers(depID) {
+				if done[blockerID] {
+					continue
+				}
+				if b, ok := a.issueMap[blockerID]; ok && b.Status == model.StatusClosed {
+					continue
+				}
+				ready = false
+				break
+			}
+			if ready {
+				done[depID] = true
+				children[curr] = append(children[curr], depID)
+				queue = append(queue, depID)
+				count++
+			}
+		}
+	}
+
+	var build func(id string) []UnblockNode
+	build = func(id string) []UnblockNode {
+		nodes := make([]UnblockNode, 0, len(children[id]))
+		for _, child := range children[id] {
+			node := UnblockNode{ID: child, Title: a.issueMap[child].Title}
+			if len(children[child]) > 0 {
+				node.Children = build(child)
+			}
+			nodes = append(nodes, node)
+		}
+		return nodes
+	}
+	return build(issueID)
+}
+
+func explainHistory(issue *model.Issue, now time.Time) ExplainHistory {
+	h := ExplainHistory{
+		CreatedAt: issue.CreatedAt,
+		UpdatedAt: issue.UpdatedAt,
+		ClosedAt:  issue.ClosedAt,
+		Comments:  len(issue.Comments),
+		Events:    issue.Events,
+	}
+	if !issue.CreatedAt.IsZero() {
+		h.AgeDays = int(now.Sub(issue.CreatedAt).Hours() / 24)
+	}
+	if !issue.UpdatedAt.IsZero() {
+		h.DaysSinceUpdate = int(now.Sub(issue.UpdatedAt).Hours() / 24)
+	}
+	return h
+}
+
+// similarIssues ranks the other issues by keyword overlap with issue
+func similarIssues(issues []model.Issue, issue *model.Issue) []SimilarIssue {
+	similar := []SimilarIssue{}
+	target := keywordSet(extractKeywords(issue.Title, issue.Description))
+	if len(target) == 0 {
+		return similar
+	}
+	for _, other := range issues {
+		if other.ID == issue.ID {
+			continue
+		}
+		kws := keywordSet(extractKeywords(other.Title, other.Description))
+		sim := jaccardSets(target, kws)
+		if sim < explainMinSimilarity {
+			continue
+		}
+		var common []string
+		for k := range target {
+			if kws[k] {
+				common = append(common, k)
+			}
+		}
+		sort.Strings(common)
+		similar = append(similar, SimilarIssue{
+			ID:         other.ID,
+			Title:      other.Title,
+			Status:     string(other.Status),
+			Similarity: math.Round(sim*1000) / 1000,
+			Keywords:   common,
+		})
+	}
+	sort.Slice(similar, func(i, j int) bool {
+		if similar[i].Similarity != similar[j].Similarity {
+			return similar[i].Similarity > similar[j].Similarity
+		}
+		return similar[i].ID < similar[j].ID
+	})
+	if len(similar) > explainMaxSimilar {
+		similar = similar[:explainMaxSimilar]
+	}
+	return similar
+}
+
+func keywordSet(words []string) map[string]bool {
+	set := make(map[string]bool, len(words))
+	for _, w := range words {
+		set[w] = true
+	}
+	return set
+}
@@ -0,0 +1,140 @@
+package analysis
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func explainFixture() []model.Issue {
+	blocks := func(id string) []*model.Dependency {
+		return []*model.Dependency{{DependsOnID: id, Type: model.DepBlocks}}
+	}
+	created := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	return []model.Issue{
+		{ID: "A", Title: "Parser rewrite for config files", Status: model.StatusOpen, Priority: 1, CreatedAt: created, UpdatedAt: created},
+		{ID: "B", Title: "Config loader uses new parser", Status: model.StatusOpen, Priority: 2, Dependencies: blocks("A"), CreatedAt: created},
+		{ID: "C", Title: "Document config parser rewrite", Status: model.StatusOpen, Priority: 3, Dependencies: blocks("B"), CreatedAt: created},
+		{ID: "D", Title: "Unrelated logging cleanup", Status: model.StatusOpen, Priority: 4, CreatedAt: created},
+		{ID: "E", Title: "Old parser bug", Status: model.StatusClosed, Priority: 2, CreatedAt: created},
+	}
+}
+
+func TestExplainIssue(t *testing.T) {
+	now := time.Date(2025, 1, 11, 0, 0, 0, 0, time.UTC)
+	exp, err := ExplainIssue(explainFixture(), "A", ExplainOptions{Now: now, TopN: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rec := exp.Recommendation; !rec.Recommended || rec.Rank > 2 || rec.Of != 4 || len(rec.Reasons) == 0 || rec.WhyNot != nil {
+		t.Errorf("recommendation = %+v, want recommended in the top 2 of 4 with reasons", rec)
+	}
+	if !slices.Equal(exp.Unblocks.Direct, []string{"B"}) || exp.Unblocks.Transitive != 2 {
+		t.Errorf("unblocks = %+v, want B directly and 2 in all", exp.Unblocks)
+	}
+	if len(exp.Unblocks.Tree) != 1 || exp.Unblocks.Tree[0].ID != "B" || len(exp.Unblocks.Tree[0].Children) != 1 || exp.Unblocks.Tree[0].Children[0].ID != "C" {
+		t.Errorf("unblock tree = %+v, want A -> B -> C", exp.Unblocks.Tree)
+	}
+	if exp.Forecast == nil || exp.Breakdown == nil {
+		t.Error("an open issue should have a forecast and a breakdown")
+	}
+	if exp.History.AgeDays != 10 {
+		t.Errorf("age = %d days, want 10", exp.History.AgeDays)
+	}
+	if len(exp.Similar) == 0 || exp.Similar[0].ID != "C" {
+		t.Errorf("similar = %+v, want C first", exp.Similar)
+	}
+	var triage *ExplainedScore
+	for i := range exp.Scores {
+		if exp.Scores[i].Name == "triage" {
+			triage = &exp.Scores[i]
+		}
+	}
+	if triage == nil || triage.Percentile < 50 || triage.Of != 4 {
+		t.Errorf("triage score = %+v, want the upper half of 4", triage)
+	}
+}
+
+func TestExplainIssue_NotRecommended(t *testing.T) {
+	issues := explainFixture()
+	now := time.Date(2025, 1, 11, 0, 0, 0, 0, time.UTC)
+
+	exp, err := ExplainIssue(issues, "C", ExplainOptions{Now: now, TopN: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec := exp.Recommendation
+	if rec.Recommended || rec.Rank <= 2 {
+		t.Fatalf("recommendation = %+v, want C below the cut", rec)
+	}
+	why := strings.Join(rec.WhyNot, "\n")
+	for _, want := range []string{"triage recommends the top 2", "Blocked by B", "unblocks nothing", "Low priority (P3)"} {
+		if !strings.Contains(why, want) {
+			t.Errorf("why_not missing %q:\n%s", want, why)
+		}
+	}
+	if !exp.Blockers.IsBlocked || len(exp.Blockers.RootBlockers) != 1 || exp.Blockers.RootBlockers[0].ID != "A" {
+		t.Errorf("blockers = %+v, want a chain rooted at A", exp.Blockers)
+	}
+
+	closed, err := ExplainIssue(issues, "E", ExplainOptions{Now: now})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if closed.Recommendation.Recommended || !slices.Equal(closed.Recommendation.WhyNot, []string{"Closed"}) || closed.Forecast != nil {
+		t.Errorf("closed issue explanation = %+v", closed.Recommendation)
+	}
+
+	if _, err := ExplainIssue(issues, "missing", ExplainOptions{Now: now}); err == nil {
+		t.Error("an unknown issue should be an error")
+	}
+}
+
+func TestPercentileRank(t *testing.T) {
+	values := map[string]float64{"a": 1, "b": 2, "c": 2, "d": 3}
+	for _, tc := range []struct {
+		v    float64
+		want float64
+	}{{1, 0}, {2, 50}, {3, 100}} {
+		if got, n := percentileRank(values, tc.v); got != tc.want || n != 4 {
+			t.Errorf("percentileRank(%v) = %v of %d, want %v of 4", tc.v, got, n, tc.want)
+		}
+	}
+	if got, _ := percentileRank(map[string]float64{"a": 5}, 5); got != 100 {
+		t.Errorf("a lone value ranks %v, want 100", got)
+	}
+	// Float noise still ties
+	if got, _ := percentileRank(map[string]float64{"a": 0, "b": 1e-17, "c": -2e-16, "d": 1}, 0); got != 33.3 {
+		t.Errorf("near-zero values rank %v, want 33.3", got)
+	}
+}
+
+func TestExplainScoresAllZeroMetrics(t *testing.T) {
+	// No edges: every issue scores the same on each graph metric (0 on most),
+	// so they all tie over the same three issues
+	issues := []model.Issue{
+		{ID: "A", Title: "Alpha", Status: model.StatusOpen},
+		{ID: "B", Title: "Beta", Status: model.StatusOpen},
+		{ID: "C", Title: "Gamma", Status: model.StatusOpen},
+	}
+	exp, err := ExplainIssue(issues, "A", ExplainOptions{Now: time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	graph := 0
+	for _, s := range exp.Scores {
+		if !strings.HasPrefix(s.Name, "graph.") {
+			continue
+		}
+		graph++
+		if s.Percentile != 50 || s.Of != 3 {
+			t.Errorf("%s = %+v, want the 50th percentile of 3", s.Name, s)
+		}
+	}
+	if graph != 8 {
+		t.Errorf("ranked %d graph metrics, want all 8", graph)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"math"
 	"os"
 	"path/filepath"
+	"sort"
 	"sync"
 	"time"
 )
@@ -39,6 +40,7 @@ type FeedbackData struct {
 	Adjustments []WeightAdjustment  `json:"adjustments"`
 	Stats       FeedbackStats       `json:"stats"`
 	mu          sync.RWMutex        `json:"-"`
+	profile     string              // Which profile this was loaded from
 }
 
 // FeedbackStats tracks aggregate feedback metrics
@@ -79,30 +81,18 @@ func defaultWeightAdjustments() []WeightAdjustment {
 	return adjustments
 }
 
-// LoadFeedback loads feedback data from the beads directory
+// LoadFeedback loads the team feedback profile from the beads directory
 func LoadFeedback(beadsDir string) (*FeedbackData, error) {
-	path := filepath.Join(beadsDir, FeedbackFile)
-
-	// Return defaults if file doesn't exist
-	if _, err := os.Stat(path); os.IsNotExist(err) {
-		return DefaultFeedbackData(), nil
-	}
-
-	data, err := os.ReadFile(path)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read feedback file: %w", err)
-	}
-
-	var feedback FeedbackData
-	if err := json.Unmarshal(data, &feedback); err != nil {
-		return nil, fmt.Errorf("failed to parse feedback file: %w", err)
-	}
-
-	return &feedback, nil
+	return LoadFeedbackProfile(beadsDir, "")
 }
 
-// Save persists feedback data to the beads directory
+// Save persists feedback data to the beads directory as the team profile
 func (f *FeedbackData) Save(beadsDir string) error {
+	return f.saveTo(filepath.Join(beadsDir, FeedbackFile))
+}
+
+// saveTo writes feedback data to path
+func (f *FeedbackData) saveTo(path string) error {
 	f.mu.Lock()
 	defer f.mu.Unlock()
 
@@ -113,7 +103,6 @@ func (f *FeedbackData) Save(beadsDir string) error {
 		return fmt.Errorf("failed to marshal feedback: %w", err)
 	}
 
-	path := filepath.Join(beadsDir, FeedbackFile)
 	if err := os.WriteFile(path, data, 0644); err != nil {
 		return fmt.Errorf("failed to write feedback file: %w", err)
 	}
@@ -239,16 +228,7 @@ func (f *FeedbackData) GetEffectiveWeights() map[string]float64 {
 
 // getEffectiveWeightsLocked is the internal version that assumes lock is already held
 func (f *FeedbackData) getEffectiveWeightsLocked() map[string]float64 {
-	baseWeights := map[string]float64{
-		"PageRank":      WeightPageRank,
-		"Betweenness":   WeightBetweenness,
-		"BlockerRatio":  WeightBlockerRatio,
-		"Staleness":     WeightStaleness,
-		"PriorityBoost": WeightPriorityBoost,
-		"TimeToImpact":  WeightTimeToImpact,
-		"Urgency":       WeightUrgency,
-		"Risk":          WeightRisk,
-	}
+	baseWeights := defaultWeights()
 
 	effective := make(map[string]float64)
 	adjustments := f.getAdjustedWeightsLocked() // Use internal version to avoid deadlock
@@ -275,6 +255,133 @@ func (f *FeedbackData) getEffectiveWeightsLocked() map[string]float64 {
 	return effective
 }
 
+// defaultWeights returns the composite score weights before feedback
+func defaultWeights() map[string]float64 {
+	return map[string]float64{
+		"PageRank":      WeightPageRank,
+		"Betweenness":   WeightBetweenness,
+		"BlockerRatio":  WeightBlockerRatio,
+		"Staleness":     WeightStaleness,
+		"PriorityBoost": WeightPriorityBoost,
+		"TimeToImpact":  WeightTimeToImpact,
+		"Urgency":       WeightUrgency,
+		"Risk":          WeightRisk,
+	}
+}
+
+// weightDrift returns effective minus default weight per component, and the
+// share of total weight feedback has moved between components (0-1)
+func weightDrift(effective map[string]float64) (map[string]float64, float64) {
+	drift := make(map[string]float64)
+	var moved float64
+	for name, base := range defaultWeights() {
+		d := effective[name] - base
+		drift[name] = math.Round(d*10000) / 10000
+		moved += math.Abs(d)
+	}
+	return drift, math.Round(moved/2*10000) / 10000
+}
+
+// tunedScore re-weights a score breakdown with the feedback-adjusted weights
+func tunedScore(b ScoreBreakdown, weights map[string]float64) float64 {
+	return b.PageRankNorm*weights["PageRank"] +
+		b.BetweennessNorm*weights["Betweenness"] +
+		b.BlockerRatioNorm*weights["BlockerRatio"] +
+		b.StalenessNorm*weights["Staleness"] +
+		b.PriorityBoostNorm*weights["PriorityBoost"] +
+		b.TimeToImpactNorm*weights["TimeToImpact"] +
+		b.UrgencyNorm*weights["Urgency"] +
+		b.RiskNorm*weights["Risk"]
+}
+
+// MaterialRankShift is how many places feedback must move an item before
+// its recommendation carries a feedback note
+const MaterialRankShift = 2
+
+// RankedItem is one entry of a ranking, with the breakdown behind its score
+type RankedItem struct {
+	ID        string
+	Breakdown ScoreBreakdown
+}
+
+// RankNotes re-ranks items, given best first under the default weights,
+// with the feedback-adjusted weights. It returns a note for each item whose
+// rank moved materially: by MaterialRankShift places or more, or into or out
+// of first place. It returns nil when there is no feedback history.
+func (f *FeedbackData) RankNotes(items []RankedItem) map[string]string {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	if len(f.Events) == 0 || len(items) < 2 {
+		return nil
+	}
+	weights := f.getEffectiveWeightsLocked()
+
+	tuned := make([]int, len(items))
+	scores := make([]float64, len(items))
+	for i, item := range items {
+		tuned[i] = i
+		scores[i] = tunedScore(item.Breakdown, weights)
+	}
+	sort.SliceStable(tuned, func(a, b int) bool {
+		return scores[tuned[a]] > scores[tuned[b]]
+	})
+
+	notes := make(map[string]string)
+	for tunedRank, i := range tuned {
+		shift := i - tunedRank
+		if shift == 0 || (abs(float64(shift)) < MaterialRankShift && i != 0 && tunedRank != 0) {
+			continue
+		}
+		direction := "up"
+		if shift < 0 {
+			direction = "down"
+		}
+		notes[items[i].ID] = fmt.Sprintf("Feedback history moves this %s from #%d to #%d (%s)",
+			direction, i+1, tunedRank+1, dominantDrift(items[i].Breakdown, weights, shift > 0))
+	}
+	return notes
+}
+
+// dominantDrift names the re-weighted component that did most to raise (or
+// lower) an item's tuned score
+func dominantDrift(b ScoreBreakdown, weights map[string]float64, raised bool) string {
+	norms := map[string]float64{
+		"PageRank":      b.PageRankNorm,
+		"Betweenness":   b.BetweennessNorm,
+		"BlockerRatio":  b.BlockerRatioNorm,
+		"Staleness":     b.StalenessNorm,
+		"PriorityBoost": b.PriorityBoostNorm,
+		"TimeToImpact":  b.TimeToImpactNorm,
+		"Urgency":       b.UrgencyNorm,
+		"Risk":          b.RiskNorm,
+	}
+	base := defaultWeights()
+	names := make([]string, 0, len(base))
+	for name := range base {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	best, bestEffect := "", 0.0
+	for _, name := range names {
+		effect := norms[name] * (weights[name] - base[name])
+		if !raised {
+			effect = -effect
+		}
+		if effect > bestEffect {
+			best, bestEffect = name, effect
+		}
+	}
+	if best == "" {
+		return "other items were re-weighted past it"
+	}
+	verb := "raised"
+	if weights[best] < base[best] {
+		verb = "lowered"
+	}
+	return fmt.Sprintf("feedback %s the %s weight", verb, best)
+}
+
 // Reset clears all feedback data, returning to defaults
 func (f *FeedbackData) Reset() {
 	f.mu.Lock()
@@ -305,6 +412,7 @@ func (f *FeedbackData) Summary() string {
 
 // FeedbackJSON returns the feedback data formatted for robot output
 type FeedbackJSON struct {
+	Profile          string             `json:"profile,omitempty"` // e.g. "ana", "team", "ana+team"
 	Enabled          bool               `json:"enabled"`
 	TotalEvents      int                `json:"total_events"`
 	AcceptedCount    int                `json:"accepted_count"`
@@ -313,7 +421,15 @@ type FeedbackJSON struct {
 	AvgIgnoreScore   float64            `json:"avg_ignore_score"`
 	WeightAdjustments map[string]float64 `json:"weight_adjustments"`
 	EffectiveWeights map[string]float64 `json:"effective_weights"`
-	UpdatedAt        time.Time          `json:"updated_at"`
+	DefaultWeights   map[string]float64 `json:"default_weights"`
+	// WeightDrift is effective minus default weight per component, and
+	// TotalDrift the share of weight feedback has moved (0-1)
+	WeightDrift map[string]float64 `json:"weight_drift"`
+	TotalDrift  float64            `json:"total_drift"`
+	// RankChanges counts recommendations whose rank feedback moved
+	// materially (see their feedback_note), when the output has any
+	RankChanges int       `json:"rank_changes"`
+	UpdatedAt   time.Time `json:"updated_at"`
 }
 
 // ToJSON returns feedback data formatted for robot output
@@ -321,7 +437,10 @@ func (f *FeedbackData) ToJSON() FeedbackJSON {
 	f.mu.RLock()
 	defer f.mu.RUnlock()
 
+	effective := f.getEffectiveWeightsLocked()
+	drift, totalDrift := weightDrift(effective)
 	return FeedbackJSON{
+		Profile:           f.profile,
 		Enabled:           len(f.Events) > 0,
 		TotalEvents:       len(f.Events),
 		AcceptedCount:     f.Stats.TotalAccepted,
@@ -329,7 +448,10 @@ func (f *FeedbackData) ToJSON() FeedbackJSON {
 		AvgAcceptScore:    f.Stats.AvgAcceptScore,
 		AvgIgnoreScore:    f.Stats.AvgIgnoreScore,
 		WeightAdjustments: f.getAdjustedWeightsLocked(),  // Use internal version to avoid deadlock
-		EffectiveWeights:  f.getEffectiveWeightsLocked(), // Use internal version to avoid deadlock
+		EffectiveWeights:  effective,
+		DefaultWeights:    defaultWeights(),
+		WeightDrift:       drift,
+		TotalDrift:        totalDrift,
 		UpdatedAt:         f.UpdatedAt,
 	}
 }
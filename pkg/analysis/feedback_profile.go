@@ -0,0 +1,230 @@
+package analysis
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// FeedbackProfilesDir holds per-user feedback profiles inside the beads
+// directory, one <user>.json each. FeedbackFile is the team profile.
+const FeedbackProfilesDir = "feedback"
+
+// TeamProfile names the shared profile in FeedbackFile
+const TeamProfile = "team"
+
+// Merge strategies for combining a user's profile with the team profile
+const (
+	FeedbackMergeUser  = "user"  // The user's profile, or the team's until the user has feedback
+	FeedbackMergeTeam  = "team"  // The team profile only
+	FeedbackMergeBlend = "blend" // Per-weight average of both, weighted by samples
+)
+
+// ValidFeedbackMerge reports whether strategy is a known merge strategy
+func ValidFeedbackMerge(strategy string) bool {
+	switch strategy {
+	case FeedbackMergeUser, FeedbackMergeTeam, FeedbackMergeBlend:
+		return true
+	}
+	return false
+}
+
+// FeedbackProfilePath returns where user's profile is stored. The team
+// profile (user "" or "team") is the original FeedbackFile.
+func FeedbackProfilePath(beadsDir, user string) string {
+	if user == "" || user == TeamProfile {
+		return filepath.Join(beadsDir, FeedbackFile)
+	}
+	return filepath.Join(beadsDir, FeedbackProfilesDir, profileFilename(user)+".json")
+}
+
+// profileFilename makes a user name safe to use as a filename
+func profileFilename(user string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_', r == '.':
+			return r
+		}
+		return '_'
+	}, user)
+}
+
+// LoadFeedbackProfile loads user's profile, or defaults if it has none.
+// User "" or "team" loads the team profile.
+func LoadFeedbackProfile(beadsDir, user string) (*FeedbackData, error) {
+	f, err := ReadFeedbackFile(FeedbackProfilePath(beadsDir, user))
+	if err != nil {
+		return nil, err
+	}
+	f.profile = profileName(user)
+	return f, nil
+}
+
+// ReadFeedbackFile loads feedback data from path, or defaults if it is missing
+func ReadFeedbackFile(path string) (*FeedbackData, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return DefaultFeedbackData(), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read feedback file: %w", err)
+	}
+	var feedback FeedbackData
+	if err := json.Unmarshal(data, &feedback); err != nil {
+		return nil, fmt.Errorf("failed to parse feedback file: %w", err)
+	}
+	if len(feedback.Adjustments) == 0 {
+		feedback.Adjustments = defaultWeightAdjustments()
+	}
+	return &feedback, nil
+}
+
+// SaveProfile persists f as user's profile
+func (f *FeedbackData) SaveProfile(beadsDir, user string) error {
+	path := FeedbackProfilePath(beadsDir, user)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create feedback directory: %w", err)
+	}
+	return f.saveTo(path)
+}
+
+// LoadActiveFeedback returns the feedback that tunes user's rankings: their
+// profile combined with the team profile according to strategy
+func LoadActiveFeedback(beadsDir, user, strategy string) (*FeedbackData, error) {
+	if !ValidFeedbackMerge(strategy) {
+		return nil, fmt.Errorf("unknown feedback merge strategy %q (want user, team or blend)", strategy)
+	}
+	team, err := LoadFeedbackProfile(beadsDir, "")
+	if err != nil {
+		return nil, err
+	}
+	if user == "" || user == TeamProfile || strategy == FeedbackMergeTeam {
+		return team, nil
+	}
+	mine, err := LoadFeedbackProfile(beadsDir, user)
+	if err != nil {
+		return nil, err
+	}
+	return MergeFeedback(team, mine, strategy), nil
+}
+
+// MergeFeedback combines the team profile with a user's. With "user" the
+// user's profile wins once it has any feedback; with "blend" each weight
+// adjustment is averaged, weighted by the samples behind it.
+func MergeFeedback(team, user *FeedbackData, strategy string) *FeedbackData {
+	team.mu.RLock()
+	defer team.mu.RUnlock()
+	user.mu.RLock()
+	defer user.mu.RUnlock()
+
+	switch strategy {
+	case FeedbackMergeTeam:
+		return team.cloneLocked()
+	case FeedbackMergeUser:
+		if len(user.Events) > 0 {
+			return user.cloneLocked()
+		}
+		merged := team.cloneLocked()
+		merged.profile = user.profile + " (team defaults)"
+		return merged
+	}
+
+	merged := DefaultFeedbackData()
+	merged.profile = user.profile + "+" + team.profile
+	merged.CreatedAt = team.CreatedAt
+	if user.CreatedAt.Before(merged.CreatedAt) {
+		merged.CreatedAt = user.CreatedAt
+	}
+	merged.UpdatedAt = team.UpdatedAt
+	if user.UpdatedAt.After(merged.UpdatedAt) {
+		merged.UpdatedAt = user.UpdatedAt
+	}
+
+	merged.Events = append(append([]FeedbackEvent{}, team.Events...), user.Events...)
+	sort.SliceStable(merged.Events, func(i, j int) bool {
+		return merged.Events[i].Timestamp.Before(merged.Events[j].Timestamp)
+	})
+
+	merged.Stats = FeedbackStats{
+		TotalAccepted:  team.Stats.TotalAccepted + user.Stats.TotalAccepted,
+		TotalIgnored:   team.Stats.TotalIgnored + user.Stats.TotalIgnored,
+		AvgAcceptScore: weightedMean(team.Stats.AvgAcceptScore, team.Stats.TotalAccepted, user.Stats.AvgAcceptScore, user.Stats.TotalAccepted),
+		AvgIgnoreScore: weightedMean(team.Stats.AvgIgnoreScore, team.Stats.TotalIgnored, user.Stats.AvgIgnoreScore, user.Stats.TotalIgnored),
+	}
+
+	teamAdj := adjustmentsByName(team.Adjustments)
+	userAdj := adjustmentsByName(user.Adjustments)
+	for i := range merged.Adjustments {
+		adj := &merged.Adjustments[i]
+		t, u := teamAdj[adj.Name], userAdj[adj.Name]
+		adj.Samples = t.Samples + u.Samples
+		if adj.Samples > 0 {
+			adj.Adjustment = weightedMean(t.Adjustment, t.Samples, u.Adjustment, u.Samples)
+		}
+		adj.LastUpdated = t.LastUpdated
+		if u.LastUpdated.After(adj.LastUpdated) {
+			adj.LastUpdated = u.LastUpdated
+		}
+	}
+	return merged
+}
+
+// ImportFeedback folds an exported profile into f: it replaces f unless
+// strategy is "blend", which combines the two as MergeFeedback does
+func (f *FeedbackData) ImportFeedback(imported *FeedbackData, strategy string) *FeedbackData {
+	var result *FeedbackData
+	if strategy == FeedbackMergeBlend {
+		result = MergeFeedback(f, imported, FeedbackMergeBlend)
+	} else {
+		imported.mu.RLock()
+		result = imported.cloneLocked()
+		imported.mu.RUnlock()
+	}
+	result.profile = f.profile
+	return result
+}
+
+// Profile names the profile f was loaded from, e.g. "ana", "team" or
+// "ana+team" for a blend
+func (f *FeedbackData) Profile() string {
+	return f.profile
+}
+
+// cloneLocked copies f; the caller holds f's lock
+func (f *FeedbackData) cloneLocked() *FeedbackData {
+	return &FeedbackData{
+		Version:     f.Version,
+		CreatedAt:   f.CreatedAt,
+		UpdatedAt:   f.UpdatedAt,
+		Events:      append([]FeedbackEvent{}, f.Events...),
+		Adjustments: append([]WeightAdjustment{}, f.Adjustments...),
+		Stats:       f.Stats,
+		profile:     f.profile,
+	}
+}
+
+func profileName(user string) string {
+	if user == "" {
+		return TeamProfile
+	}
+	return user
+}
+
+func adjustmentsByName(adjs []WeightAdjustment) map[string]WeightAdjustment {
+	out := make(map[string]WeightAdjustment, len(adjs))
+	for _, adj := range adjs {
+		out[adj.Name] = adj
+	}
+	return out
+}
+
+// weightedMean averages a and b weighted by their counts
+func weightedMean(a float64, na int, b float64, nb int) float64 {
+	if na+nb == 0 {
+		return 0
+	}
+	return (a*float64(na) + b*float64(nb)) / float64(na+nb)
+}
@@ -0,0 +1,93 @@
+package analysis
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestFeedbackProfilePath(t *testing.T) {
+	dir := "/repo/.beads"
+	if got := FeedbackProfilePath(dir, ""); got != filepath.Join(dir, FeedbackFile) {
+		t.Errorf("team path = %s", got)
+	}
+	if got := FeedbackProfilePath(dir, "team"); got != filepath.Join(dir, FeedbackFile) {
+		t.Errorf("team path = %s", got)
+	}
+	if got := FeedbackProfilePath(dir, "../ana smith"); got != filepath.Join(dir, FeedbackProfilesDir, ".._ana_smith.json") {
+		t.Errorf("user path = %s", got)
+	}
+}
+
+func TestFeedbackProfilesAndMerge(t *testing.T) {
+	dir := t.TempDir()
+	urgent := ScoreBreakdown{UrgencyNorm: 1}
+
+	team := DefaultFeedbackData()
+	for i := 0; i < 3; i++ {
+		_ = team.RecordFeedback("t", "ignore", 0.4, urgent)
+	}
+	if err := team.SaveProfile(dir, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	// A user without feedback ranks with the team defaults
+	active, err := LoadActiveFeedback(dir, "ana", FeedbackMergeUser)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(active.Events) != 3 || active.Profile() != "ana (team defaults)" {
+		t.Errorf("fallback profile %q has %d events", active.Profile(), len(active.Events))
+	}
+
+	ana, err := LoadFeedbackProfile(dir, "ana")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = ana.RecordFeedback("a", "accept", 0.9, urgent)
+	if err := ana.SaveProfile(dir, "ana"); err != nil {
+		t.Fatal(err)
+	}
+
+	active, _ = LoadActiveFeedback(dir, "ana", FeedbackMergeUser)
+	if len(active.Events) != 1 || active.GetAdjustedWeights()["Urgency"] <= 1 {
+		t.Errorf("user profile: %d events, weights %v", len(active.Events), active.GetAdjustedWeights())
+	}
+	active, _ = LoadActiveFeedback(dir, "ana", FeedbackMergeTeam)
+	if len(active.Events) != 3 || active.Profile() != TeamProfile {
+		t.Errorf("team strategy: profile %q, %d events", active.Profile(), len(active.Events))
+	}
+
+	// Blending weights each side by its samples: 3 team ignores outweigh 1 accept
+	active, _ = LoadActiveFeedback(dir, "ana", FeedbackMergeBlend)
+	teamUrgency := team.GetAdjustedWeights()["Urgency"]
+	anaUrgency := ana.GetAdjustedWeights()["Urgency"]
+	want := (teamUrgency*3 + anaUrgency) / 4
+	if got := active.GetAdjustedWeights()["Urgency"]; got < want-1e-9 || got > want+1e-9 {
+		t.Errorf("blended urgency = %v, want %v", got, want)
+	}
+	if len(active.Events) != 4 || active.Stats.TotalAccepted != 1 || active.Stats.TotalIgnored != 3 || active.Profile() != "ana+team" {
+		t.Errorf("blend = %q %+v", active.Profile(), active.Stats)
+	}
+
+	if _, err := LoadActiveFeedback(dir, "ana", "average"); err == nil {
+		t.Error("unknown strategy should fail")
+	}
+}
+
+func TestImportFeedback(t *testing.T) {
+	exported := DefaultFeedbackData()
+	_ = exported.RecordFeedback("x", "accept", 0.8, ScoreBreakdown{PageRankNorm: 1})
+
+	bob := DefaultFeedbackData()
+	bob.profile = "bob"
+	_ = bob.RecordFeedback("y", "ignore", 0.2, ScoreBreakdown{RiskNorm: 1})
+
+	replaced := bob.ImportFeedback(exported, FeedbackMergeUser)
+	if len(replaced.Events) != 1 || replaced.Events[0].IssueID != "x" || replaced.Profile() != "bob" {
+		t.Errorf("replace import = %q %+v", replaced.Profile(), replaced.Events)
+	}
+	blended := bob.ImportFeedback(exported, FeedbackMergeBlend)
+	if len(blended.Events) != 2 || blended.Profile() != "bob" {
+		t.Errorf("blend import = %q %+v", blended.Profile(), blended.Events)
+	}
+}
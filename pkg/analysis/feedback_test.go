@@ -3,6 +3,7 @@ package analysis
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -260,3 +261,55 @@ func TestWeightAdjustmentBounds(t *testing.T) {
 		t.Errorf("PageRank weight went below lower bound: %f", weights["PageRank"])
 	}
 }
+
+func TestFeedbackToJSONDrift(t *testing.T) {
+	f := DefaultFeedbackData()
+	if info := f.ToJSON(); info.TotalDrift != 0 || info.WeightDrift["PageRank"] != 0 {
+		t.Errorf("default feedback drift = %v, %v", info.TotalDrift, info.WeightDrift)
+	}
+
+	for i := 0; i < 10; i++ {
+		if err := f.RecordFeedback("test-1", "accept", 0.9, ScoreBreakdown{UrgencyNorm: 1}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	info := f.ToJSON()
+	if info.WeightDrift["Urgency"] <= 0 || info.WeightDrift["PageRank"] >= 0 {
+		t.Errorf("accepting urgent items should move weight to Urgency, drift = %v", info.WeightDrift)
+	}
+	if info.TotalDrift <= 0 || info.TotalDrift > 1 {
+		t.Errorf("total drift = %v", info.TotalDrift)
+	}
+	if info.DefaultWeights["PageRank"] != WeightPageRank {
+		t.Errorf("default weights = %v", info.DefaultWeights)
+	}
+}
+
+func TestFeedbackRankNotes(t *testing.T) {
+	// Ranked best first under the default weights: a graph-heavy item ahead
+	// of urgent ones
+	items := []RankedItem{
+		{ID: "graph", Breakdown: ScoreBreakdown{PageRankNorm: 1, BetweennessNorm: 1}},
+		{ID: "mid", Breakdown: ScoreBreakdown{PageRankNorm: 0.5, UrgencyNorm: 0.3}},
+		{ID: "other", Breakdown: ScoreBreakdown{PageRankNorm: 0.4, UrgencyNorm: 0.2}},
+		{ID: "urgent", Breakdown: ScoreBreakdown{UrgencyNorm: 1, PriorityBoostNorm: 1}},
+	}
+
+	f := DefaultFeedbackData()
+	if notes := f.RankNotes(items); notes != nil {
+		t.Errorf("no feedback should give no notes, got %v", notes)
+	}
+
+	for i := 0; i < 40; i++ {
+		_ = f.RecordFeedback("x", "accept", 0.5, ScoreBreakdown{UrgencyNorm: 1, PriorityBoostNorm: 1})
+		_ = f.RecordFeedback("y", "ignore", 0.5, ScoreBreakdown{PageRankNorm: 1, BetweennessNorm: 1})
+	}
+	notes := f.RankNotes(items)
+	note, ok := notes["urgent"]
+	if !ok || !strings.Contains(note, "up from #4 to #1") || !strings.Contains(note, "raised") {
+		t.Errorf("urgent note = %q (notes %v)", note, notes)
+	}
+	if note, ok := notes["graph"]; !ok || !strings.Contains(note, "down from #1") {
+		t.Errorf("graph note = %q", note)
+	}
+}
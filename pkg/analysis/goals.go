@@ -0,0 +1,268 @@
+package analysis
+
+import (
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"gopkg.in/yaml.v3"
+)
+
+// GoalsFilename is the goals config filename
+const GoalsFilename = "goals.yaml"
+
+// Goal is a target date for a milestone label or a set of issues
+type Goal struct {
+	Name string `yaml:"name" json:"name"`
+	// Label selects the goal's issues by milestone label
+	Label string `yaml:"label,omitempty" json:"label,omitempty"`
+	// Issues lists the goal's issues by ID, alongside or instead of Label
+	Issues []string `yaml:"issues,omitempty" json:"issues,omitempty"`
+	// Target is the date (YYYY-MM-DD) the work should be closed by, inclusive
+	Target string `yaml:"target" json:"target"`
+}
+
+// GoalsConfig is the contents of .bv/goals.yaml
+type GoalsConfig struct {
+	// Agents is how many generic agents work toward the goals when no agent
+	// profiles are given (default 1)
+	Agents int    `yaml:"agents,omitempty" json:"agents"`
+	Goals  []Goal `yaml:"goals" json:"goals"`
+}
+
+// GoalsPath returns the goals config path for a project
+func GoalsPath(projectDir string) string {
+	return filepath.Join(projectDir, ".bv", GoalsFilename)
+}
+
+// LoadGoals loads .bv/goals.yaml.
+// Returns an empty config (no goals) if the file doesn't exist.
+func LoadGoals(projectDir string) (*GoalsConfig, error) {
+	data, err := os.ReadFile(GoalsPath(projectDir))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &GoalsConfig{Agents: 1}, nil
+		}
+		return nil, fmt.Errorf("reading goals config: %w", err)
+	}
+
+	config := &GoalsConfig{}
+	if err := yaml.Unmarshal(data, config); err != nil {
+		return nil, fmt.Errorf("parsing goals config: %w", err)
+	}
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid goals config: %w", err)
+	}
+	return config, nil
+}
+
+// Validate checks every goal and fills in defaults
+func (c *GoalsConfig) Validate() error {
+	if c.Agents < 0 {
+		return fmt.Errorf("agents must be non-negative")
+	}
+	if c.Agents == 0 {
+		c.Agents = 1
+	}
+	seen := make(map[string]bool)
+	for i := range c.Goals {
+		g := &c.Goals[i]
+		g.Name = strings.TrimSpace(g.Name)
+		if g.Name == "" {
+			g.Name = g.Label
+		}
+		if g.Name == "" {
+			g.Name = fmt.Sprintf("goal-%d", i+1)
+		}
+		if seen[g.Name] {
+			return fmt.Errorf("duplicate goal name %q", g.Name)
+		}
+		seen[g.Name] = true
+		if g.Label == "" && len(g.Issues) == 0 {
+			return fmt.Errorf("%s: needs a label or issues", g.Name)
+		}
+		if _, err := time.Parse("2006-01-02", g.Target); err != nil {
+			return fmt.Errorf("%s: target must be a YYYY-MM-DD date, got %q", g.Name, g.Target)
+		}
+	}
+	return nil
+}
+
+// ExampleGoals returns a commented sample goals file
+func ExampleGoals() string {
+	return `# Goals for bv --robot-goals, the goals panel (%) and goal_at_risk alerts
+agents: 2                    # Agents working toward the goals (default 1)
+goals:
+  - name: v1.0 launch
+    label: v1.0              # Every issue carrying the milestone label
+    target: 2026-12-01       # Closed by the end of this day
+  - name: Auth rewrite
+    issues: [bv-12, bv-15]   # Or an explicit set of issues
+    target: 2026-11-15
+`
+}
+
+// GoalForecast is the chance of closing a goal's issues by its target
+type GoalForecast struct {
+	Goal
+	// DaysLeft is the time until the end of the target day (negative once past)
+	DaysLeft float64 `json:"days_left"`
+	Total    int     `json:"total_issues"`
+	Open     int     `json:"open_issues"`
+	Done     bool    `json:"done"`
+	// Blockers are open issues outside the goal that its open issues wait on;
+	// they are simulated as part of the goal
+	Blockers     []string `json:"blockers"`
+	TotalMinutes int      `json:"total_minutes"`
+	// Probability is the share of simulated runs that close every open
+	// issue and blocker by the target (0..1)
+	Probability float64                 `json:"probability"`
+	Completion  *CompletionDistribution `json:"completion,omitempty"`
+	// NextUp are the goal's ready issues, longest remaining chain first
+	NextUp []string `json:"next_up"`
+	// Stuck issues no agent can take or that wait in a dependency cycle;
+	// while there are any the goal cannot finish
+	Stuck []string `json:"stuck"`
+	// Missing lists configured issue IDs that are not in the tracker
+	Missing []string `json:"missing,omitempty"`
+}
+
+// ForecastGoals estimates, for each goal, the probability of closing its
+// open issues and their open blockers by the target date. Like
+// SimulateCapacity it runs a Monte Carlo simulation of the work on the given
+// agents, or on config.Agents generic agents when profiles is nil, and
+// assumes those agents spend their time on the goal.
+func ForecastGoals(issues []model.Issue, stats *GraphStats, config *GoalsConfig, profiles *AgentProfiles, opts CapacitySimOptions, now time.Time) []GoalForecast {
+	forecasts := []GoalForecast{}
+	if config == nil {
+		return forecasts
+	}
+	if opts.Runs <= 0 {
+		opts.Runs = 500
+	}
+	if opts.Seed == 0 {
+		opts.Seed = 1
+	}
+	if profiles == nil {
+		profiles = &AgentProfiles{}
+		for i := range max(config.Agents, 1) {
+			profiles.Agents = append(profiles.Agents, AgentProfile{Name: fmt.Sprintf("agent-%d", i+1)})
+		}
+		_ = profiles.Validate()
+	}
+
+	byID := make(map[string]*model.Issue, len(issues))
+	for i := range issues {
+		byID[issues[i].ID] = &issues[i]
+	}
+
+	for _, goal := range config.Goals {
+		f := GoalForecast{Goal: goal, Blockers: []string{}, NextUp: []string{}, Stuck: []string{}}
+		if target, err := time.ParseInLocation("2006-01-02", goal.Target, now.Location()); err == nil {
+			f.DaysLeft = round2(target.AddDate(0, 0, 1).Sub(now).Hours() / 24)
+		}
+
+		// The goal's issues, then every open issue they transitively wait on
+		members := make(map[string]bool)
+		for _, id := range goal.Issues {
+			if issue, ok := byID[id]; !ok || issue.Status.IsTombstone() {
+				f.Missing = append(f.Missing, id)
+				continue
+			}
+			members[id] = true
+		}
+		if goal.Label != "" {
+			for i := range issues {
+				if !issues[i].Status.IsTombstone() && hasLabel(issues[i].Labels, goal.Label) {
+					members[issues[i].ID] = true
+				}
+			}
+		}
+		f.Total = len(members)
+
+		var work []model.Issue
+		queued := make(map[string]bool)
+		var queue []string
+		for id := range members {
+			queue = append(queue, id)
+			queued[id] = true
+		}
+		sort.Strings(queue)
+		for len(queue) > 0 {
+			issue := byID[queue[0]]
+			queue = queue[1:]
+			if issue.Status.IsClosed() || issue.Status.IsTombstone() {
+				continue
+			}
+			work = append(work, *issue)
+			if members[issue.ID] {
+				f.Open++
+			} else {
+				f.Blockers = append(f.Blockers, issue.ID)
+			}
+			for _, dep := range issue.Dependencies {
+				if dep == nil || !dep.Type.IsBlocking() || queued[dep.DependsOnID] {
+					continue
+				}
+				if _, ok := byID[dep.DependsOnID]; ok {
+					queued[dep.DependsOnID] = true
+					queue = append(queue, dep.DependsOnID)
+				}
+			}
+		}
+		sort.Strings(f.Blockers)
+
+		if len(work) == 0 {
+			f.Done = f.Total > 0
+			if f.Done {
+				f.Probability = 1
+			}
+			forecasts = append(forecasts, f)
+			continue
+		}
+
+		var sim CapacitySimulation
+		tasks := buildSimTasks(work, stats, profiles.Agents, &sim)
+		f.Stuck = append(append(f.Stuck, sim.Unassignable...), sim.Stranded...)
+		sort.Strings(f.Stuck)
+		for _, task := range tasks {
+			f.TotalMinutes += int(task.minutes)
+			if len(task.blockers) == 0 {
+				f.NextUp = append(f.NextUp, task.issue.ID)
+			}
+		}
+		rank := make(map[string]float64, len(tasks))
+		for _, task := range tasks {
+			rank[task.issue.ID] = task.rank
+		}
+		sort.Slice(f.NextUp, func(i, j int) bool {
+			if rank[f.NextUp[i]] != rank[f.NextUp[j]] {
+				return rank[f.NextUp[i]] > rank[f.NextUp[j]]
+			}
+			return f.NextUp[i] < f.NextUp[j]
+		})
+
+		rng := rand.New(rand.NewSource(opts.Seed))
+		makespans := make([]float64, 0, opts.Runs)
+		onTime := 0
+		for range opts.Runs {
+			run := simulateRun(tasks, profiles.Agents, rng)
+			makespans = append(makespans, run.makespan)
+			if run.makespan <= f.DaysLeft {
+				onTime++
+			}
+		}
+		dist := completionDistribution(makespans, now)
+		f.Completion = &dist
+		if len(f.Stuck) == 0 {
+			f.Probability = round2(float64(onTime) / float64(opts.Runs))
+		}
+		forecasts = append(forecasts, f)
+	}
+	return forecasts
+}
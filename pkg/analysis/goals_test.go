@@ -0,0 +1,111 @@
+package analysis
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func goalsTestIssues() []model.Issue {
+	minutes := func(n int) *int { return &n }
+	return []model.Issue{
+		{ID: "v1-1", Status: model.StatusOpen, Labels: []string{"v1"}, EstimatedMinutes: minutes(480),
+			Dependencies: []*model.Dependency{{IssueID: "v1-1", DependsOnID: "infra", Type: model.DepBlocks}}},
+		{ID: "v1-2", Status: model.StatusClosed, Labels: []string{"v1"}},
+		{ID: "infra", Status: model.StatusOpen, EstimatedMinutes: minutes(480)},
+		{ID: "docs", Status: model.StatusClosed},
+		{ID: "side", Status: model.StatusOpen, EstimatedMinutes: minutes(60)},
+	}
+}
+
+func TestForecastGoals(t *testing.T) {
+	now := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	config := &GoalsConfig{Agents: 1, Goals: []Goal{
+		{Name: "roomy", Label: "v1", Target: "2026-03-20"},
+		{Name: "tight", Label: "v1", Target: "2026-03-02"},
+		{Name: "shipped", Issues: []string{"docs", "ghost"}, Target: "2026-03-01"},
+	}}
+	forecasts := ForecastGoals(goalsTestIssues(), nil, config, nil, CapacitySimOptions{Runs: 200}, now)
+	if len(forecasts) != 3 {
+		t.Fatalf("got %d forecasts, want 3", len(forecasts))
+	}
+
+	roomy := forecasts[0]
+	if roomy.Total != 2 || roomy.Open != 1 || strings.Join(roomy.Blockers, ",") != "infra" {
+		t.Errorf("roomy: total %d open %d blockers %v; want 2, 1 and [infra]", roomy.Total, roomy.Open, roomy.Blockers)
+	}
+	if roomy.TotalMinutes != 960 || strings.Join(roomy.NextUp, ",") != "infra" {
+		t.Errorf("roomy: %d minutes next %v; want 960 and [infra] (side is not part of the goal)", roomy.TotalMinutes, roomy.NextUp)
+	}
+	if roomy.DaysLeft != 19 || roomy.Probability < 0.95 {
+		t.Errorf("roomy: %.2f days left, probability %.2f; want 19 and near certain", roomy.DaysLeft, roomy.Probability)
+	}
+	if roomy.Completion == nil || roomy.Completion.P50 < 1 || roomy.Completion.P50 > 4 {
+		t.Errorf("roomy: completion %+v, want a p50 around 2 days", roomy.Completion)
+	}
+
+	// Two days of serial work in one day left
+	if tight := forecasts[1]; tight.DaysLeft != 1 || tight.Probability > 0.1 {
+		t.Errorf("tight: %.2f days left, probability %.2f; want 1 and near zero", tight.DaysLeft, tight.Probability)
+	}
+
+	shipped := forecasts[2]
+	if !shipped.Done || shipped.Probability != 1 || shipped.Completion != nil {
+		t.Errorf("shipped: %+v, want done with probability 1", shipped)
+	}
+	if strings.Join(shipped.Missing, ",") != "ghost" {
+		t.Errorf("shipped: missing %v, want [ghost]", shipped.Missing)
+	}
+}
+
+func TestForecastGoals_StuckWork(t *testing.T) {
+	now := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	config := &GoalsConfig{Goals: []Goal{{Name: "v1", Label: "v1", Target: "2026-12-31"}}}
+	profiles := testProfiles(t, AgentProfile{Name: "fe", Labels: []string{"frontend"}})
+	forecasts := ForecastGoals(goalsTestIssues(), nil, config, profiles, CapacitySimOptions{Runs: 50}, now)
+
+	// infra is unlabeled, so fe takes it, but nobody can take v1-1
+	if got := forecasts[0]; strings.Join(got.Stuck, ",") != "v1-1" || got.Probability != 0 {
+		t.Errorf("stuck %v probability %.2f; want [v1-1] and 0", got.Stuck, got.Probability)
+	}
+}
+
+func TestLoadGoals(t *testing.T) {
+	dir := t.TempDir()
+	config, err := LoadGoals(dir)
+	if err != nil || len(config.Goals) != 0 || config.Agents != 1 {
+		t.Fatalf("missing file: %+v, %v; want no goals and one agent", config, err)
+	}
+
+	if err := os.MkdirAll(filepath.Join(dir, ".bv"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(GoalsPath(dir), []byte(ExampleGoals()), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	config, err = LoadGoals(dir)
+	if err != nil {
+		t.Fatalf("example goals: %v", err)
+	}
+	if config.Agents != 2 || len(config.Goals) != 2 || config.Goals[0].Label != "v1.0" || config.Goals[1].Target != "2026-11-15" {
+		t.Errorf("example goals parsed as %+v", config)
+	}
+
+	bad := map[string]string{
+		"no selector": "goals:\n  - name: x\n    target: 2026-01-01\n",
+		"bad date":    "goals:\n  - label: x\n    target: next week\n",
+		"duplicate":   "goals:\n  - label: x\n    target: 2026-01-01\n  - label: x\n    target: 2026-02-01\n",
+	}
+	for name, body := range bad {
+		if err := os.WriteFile(GoalsPath(dir), []byte(body), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := LoadGoals(dir); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
@@ -3,17 +3,16 @@ package analysis
 import (
 	"context"
 	"fmt"
-	"math"
 	"sort"
 	"sync"
 	"time"
 
 	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/tracing"
 
+	"go.opentelemetry.io/otel/attribute"
 	"gonum.org/v1/gonum/graph"
-	"gonum.org/v1/gonum/graph/network"
 	"gonum.org/v1/gonum/graph/simple"
-	"gonum.org/v1/gonum/graph/topo"
 )
 
 // StartupProfile captures detailed timing information for startup diagnostics.
@@ -115,10 +114,12 @@ type MetricStatus struct {
 
 // statusEntry records computation state for a single metric.
 type statusEntry struct {
-	State   string        `json:"state"`            // computed|approx|timeout|skipped
-	Reason  string        `json:"reason,omitempty"` // explanation when skipped/timeout/approx
-	Sample  int           `json:"sample,omitempty"` // sample size when approximate
-	Elapsed time.Duration `json:"ms,omitempty"`     // elapsed time
+	State      string        `json:"state"`                 // computed|approx|timeout|skipped
+	Reason     string        `json:"reason,omitempty"`      // explanation when skipped/timeout/approx
+	Mode       string        `json:"mode,omitempty"`        // algorithm used, when there is a choice (exact|approximate)
+	Sample     int           `json:"sample,omitempty"`      // sample size when approximate
+	ErrorBound float64       `json:"error_bound,omitempty"` // expected relative error when approximate
+	Elapsed    time.Duration `json:"ms,omitempty"`          // elapsed time
 }
 
 // IsPhase2Ready returns true if Phase 2 metrics have been computed.
@@ -147,11 +148,21 @@ func stateFromTiming(enabled bool, timedOut bool) string {
 	}
 }
 
+// betweennessState is stateFromTiming, plus "approx" when the scores were
+// sampled so agents know they are estimates
+func betweennessState(cfg AnalysisConfig, timedOut, isApprox bool) string {
+	state := stateFromTiming(cfg.ComputeBetweenness, timedOut)
+	if state == "computed" && isApprox {
+		return "approx"
+	}
+	return state
+}
+
 func betweennessReason(cfg AnalysisConfig, isApprox bool) string {
 	if cfg.BetweennessSkipReason != "" {
 		return cfg.BetweennessSkipReason
 	}
-	if cfg.BetweennessMode == BetweennessApproximate || isApprox {
+	if isApprox {
 		return "approximate"
 	}
 	return ""
@@ -508,9 +519,9 @@ func NewGraphStatsForTest(
 
 // Analyzer encapsulates the graph logic
 type Analyzer struct {
-	g        *simple.DirectedGraph
+	g        *compactGraph
 	idToNode map[string]int64
-	nodeToID map[int64]string
+	nodeToID []string // Issue ID of each node; node IDs are dense indexes
 	issueMap map[string]model.Issue
 	config   *AnalysisConfig // Optional custom config, nil means use size-based defaults
 }
@@ -522,25 +533,23 @@ func (a *Analyzer) SetConfig(config *AnalysisConfig) {
 }
 
 func NewAnalyzer(issues []model.Issue) *Analyzer {
-	g := simple.NewDirectedGraph()
-	// Pre-allocate maps for efficiency
+	// Pre-allocate for efficiency
 	idToNode := make(map[string]int64, len(issues))
-	nodeToID := make(map[int64]string, len(issues))
+	nodeToID := make([]string, len(issues))
 	issueMap := make(map[string]model.Issue, len(issues))
 
-	// 1. Add Nodes
-	for _, issue := range issues {
+	// 1. Add Nodes: node i is issues[i]
+	for i, issue := range issues {
 		issueMap[issue.ID] = issue
-		n := g.NewNode()
-		g.AddNode(n)
-		idToNode[issue.ID] = n.ID()
-		nodeToID[n.ID()] = issue.ID
+		idToNode[issue.ID] = int64(i)
+		nodeToID[i] = issue.ID
 	}
 
 	// 2. Add Edges (Dependency Direction)
 	// We only model *blocking* relationships in the analysis graph. Non-blocking
 	// links such as "related" should not influence centrality metrics or cycle
 	// detection because they do not gate execution order.
+	var edges []compactEdge
 	for _, issue := range issues {
 		u, ok := idToNode[issue.ID]
 		if !ok {
@@ -560,14 +569,13 @@ func NewAnalyzer(issues []model.Issue) *Analyzer {
 			v, exists := idToNode[dep.DependsOnID]
 			if exists {
 				// Issue (u) depends on v → edge u -> v
-				// Optimization: Use simple.Node directly to avoid internal map lookups in g.Node()
-				g.SetEdge(g.NewEdge(simple.Node(u), simple.Node(v)))
+				edges = append(edges, compactEdge{from: int32(u), to: int32(v)})
 			}
 		}
 	}
 
 	return &Analyzer{
-		g:        g,
+		g:        newCompactGraph(len(issues), edges),
 		idToNode: idToNode,
 		nodeToID: nodeToID,
 		issueMap: issueMap,
@@ -587,7 +595,7 @@ func (a *Analyzer) AnalyzeAsync(ctx context.Context) *GraphStats {
 		config = *a.config
 	} else {
 		nodeCount := len(a.issueMap)
-		edgeCount := a.g.Edges().Len()
+		edgeCount := a.g.EdgeCount()
 		config = ConfigForSize(nodeCount, edgeCount)
 	}
 	return a.AnalyzeAsyncWithConfig(ctx, config)
@@ -596,8 +604,9 @@ func (a *Analyzer) AnalyzeAsync(ctx context.Context) *GraphStats {
 // AnalyzeAsyncWithConfig performs graph analysis with a custom configuration.
 // This allows callers to override the default size-based algorithm selection.
 func (a *Analyzer) AnalyzeAsyncWithConfig(ctx context.Context, config AnalysisConfig) *GraphStats {
+	config = withBetweennessEnv(config)
 	nodeCount := len(a.issueMap)
-	edgeCount := a.g.Edges().Len()
+	edgeCount := a.g.EdgeCount()
 
 	stats := &GraphStats{
 		OutDegree:         make(map[string]int),
@@ -644,7 +653,9 @@ func (a *Analyzer) AnalyzeAsyncWithConfig(ctx context.Context, config AnalysisCo
 	}
 
 	// Phase 1: Fast metrics (degree centrality, topo sort, density)
+	phase1Start := time.Now()
 	a.computePhase1(stats)
+	tracing.Record(ctx, "analysis.phase1", phase1Start, time.Now(), graphAttrs(stats)...)
 
 	// Phase 2: Expensive metrics in background goroutine
 	go a.computePhase2(ctx, stats, config)
@@ -711,6 +722,7 @@ func (a *Analyzer) AnalyzeWithConfig(config AnalysisConfig) GraphStats {
 // AnalyzeWithProfile performs synchronous graph analysis and returns detailed timing profile.
 // This is intended for diagnostics and the --profile-startup CLI flag.
 func (a *Analyzer) AnalyzeWithProfile(config AnalysisConfig) (*GraphStats, *StartupProfile) {
+	config = withBetweennessEnv(config)
 	profile := &StartupProfile{
 		Config: config,
 	}
@@ -718,7 +730,7 @@ func (a *Analyzer) AnalyzeWithProfile(config AnalysisConfig) (*GraphStats, *Star
 	totalStart := time.Now()
 
 	nodeCount := len(a.issueMap)
-	edgeCount := a.g.Edges().Len()
+	edgeCount := a.g.EdgeCount()
 
 	profile.NodeCount = nodeCount
 	profile.EdgeCount = edgeCount
@@ -750,12 +762,15 @@ func (a *Analyzer) AnalyzeWithProfile(config AnalysisConfig) (*GraphStats, *Star
 	phase1Start := time.Now()
 	a.computePhase1WithProfile(stats, profile)
 	profile.Phase1 = time.Since(phase1Start)
+	tracing.Record(context.Background(), "analysis.phase1", phase1Start, time.Now(), graphAttrs(stats)...)
 
 	profile.Density = stats.Density
 
 	// Phase 2: Expensive metrics synchronously with timing
 	phase2Start := time.Now()
-	a.computePhase2WithProfile(context.Background(), stats, config, profile)
+	ctx, span := tracing.Start(context.Background(), "analysis.phase2", graphAttrs(stats)...)
+	a.computePhase2WithProfile(ctx, stats, config, profile)
+	span.End()
 	profile.Phase2 = time.Since(phase2Start)
 
 	stats.phase2Ready = true
@@ -769,30 +784,20 @@ func (a *Analyzer) AnalyzeWithProfile(config AnalysisConfig) (*GraphStats, *Star
 func (a *Analyzer) computePhase1WithProfile(stats *GraphStats, profile *StartupProfile) {
 	// Degree centrality
 	degreeStart := time.Now()
-	nodes := a.g.Nodes()
-	for nodes.Next() {
-		n := nodes.Node()
-		id := a.nodeToID[n.ID()]
-		to := a.g.To(n.ID())
-		stats.InDegree[id] = to.Len()
-		from := a.g.From(n.ID())
-		stats.OutDegree[id] = from.Len()
+	for v, id := range a.nodeToID {
+		stats.InDegree[id] = len(a.g.to(int32(v)))
+		stats.OutDegree[id] = len(a.g.from(int32(v)))
 	}
 	profile.Degree = time.Since(degreeStart)
 
 	// Topological Sort
 	topoStart := time.Now()
-	sorted, err := topo.Sort(a.g)
-	if err == nil {
-		for i := len(sorted) - 1; i >= 0; i-- {
-			stats.TopologicalOrder = append(stats.TopologicalOrder, a.nodeToID[sorted[i].ID()])
-		}
-	}
+	stats.TopologicalOrder = a.topologicalOrder()
 	profile.TopoSort = time.Since(topoStart)
 
 	// Density
 	n := float64(len(a.issueMap))
-	e := float64(a.g.Edges().Len())
+	e := float64(a.g.EdgeCount())
 	if n > 1 {
 		stats.Density = e / (n * (n - 1))
 	}
@@ -813,27 +818,29 @@ func (a *Analyzer) computePhase2WithProfile(ctx context.Context, stats *GraphSta
 
 	betweennessIsApprox := false
 	actualBetweennessSample := 0
+	betweennessErrorBound := 0.0
+	var betweennessMode BetweennessMode
 	cyclesTruncated := false
 
 	// PageRank
 	if ctx.Err() == nil && config.ComputePageRank {
 		prStart := time.Now()
-		prDone := make(chan map[int64]float64, 1)
+		prDone := make(chan []float64, 1)
 		go func() {
 			defer func() {
 				if r := recover(); r != nil {
 					// Panic -> implicitly causes timeout in parent
 				}
 			}()
-			prDone <- computePageRank(a.g, 0.85, 1e-6)
+			prDone <- a.g.pageRank(0.85, 1e-6)
 		}()
 
 		timer := time.NewTimer(config.PageRankTimeout)
 		select {
 		case pr := <-prDone:
 			timer.Stop()
-			for id, score := range pr {
-				localPageRank[a.nodeToID[id]] = score
+			for v, score := range pr {
+				localPageRank[a.nodeToID[v]] = score
 			}
 		case <-timer.C:
 			profile.PageRankTO = true
@@ -849,6 +856,7 @@ func (a *Analyzer) computePhase2WithProfile(ctx context.Context, stats *GraphSta
 			return
 		}
 		profile.PageRank = time.Since(prStart)
+		traceMetric(ctx, "pagerank", prStart, profile.PageRankTO)
 	}
 
 	// Betweenness
@@ -862,16 +870,11 @@ func (a *Analyzer) computePhase2WithProfile(ctx context.Context, stats *GraphSta
 				}
 			}()
 			// Choose algorithm based on mode
-			if config.BetweennessMode == BetweennessApproximate && config.BetweennessSampleSize > 0 {
-				bwDone <- ApproxBetweenness(a.g, config.BetweennessSampleSize, 1)
+			if sample := config.betweennessSampleSize(); config.BetweennessMode == BetweennessApproximate && sample > 0 {
+				bwDone <- approxBetweenness(a.g, sample, 1, config.BetweennessWorkers)
 			} else {
 				// Exact mode or mode not set (default to exact)
-				exact := network.Betweenness(a.g)
-				bwDone <- BetweennessResult{
-					Scores:     exact,
-					Mode:       BetweennessExact,
-					TotalNodes: a.g.Nodes().Len(),
-				}
+				bwDone <- ParallelBetweenness(a.g, config.BetweennessWorkers)
 			}
 		}()
 
@@ -883,9 +886,11 @@ func (a *Analyzer) computePhase2WithProfile(ctx context.Context, stats *GraphSta
 				localBetweenness[a.nodeToID[id]] = score
 			}
 			// Track if approximation was used
+			betweennessMode = result.Mode
 			if result.Mode == BetweennessApproximate {
 				betweennessIsApprox = true
 				actualBetweennessSample = result.SampleSize
+				betweennessErrorBound = result.ErrorBound
 			}
 		case <-timer.C:
 			profile.BetweennessTO = true
@@ -894,37 +899,40 @@ func (a *Analyzer) computePhase2WithProfile(ctx context.Context, stats *GraphSta
 			return
 		}
 		profile.Betweenness = time.Since(bwStart)
+		traceMetric(ctx, "betweenness", bwStart, profile.BetweennessTO)
 	}
 
 	// Eigenvector
 	if ctx.Err() == nil && config.ComputeEigenvector {
 		evStart := time.Now()
-		for id, score := range computeEigenvector(a.g) {
-			localEigenvector[a.nodeToID[id]] = score
+		for v, score := range a.g.eigenvector() {
+			localEigenvector[a.nodeToID[v]] = score
 		}
 		profile.Eigenvector = time.Since(evStart)
+		traceMetric(ctx, "eigenvector", evStart, false)
 	}
 
 	// HITS
-	if ctx.Err() == nil && config.ComputeHITS && a.g.Edges().Len() > 0 {
+	if ctx.Err() == nil && config.ComputeHITS && a.g.EdgeCount() > 0 {
 		hitsStart := time.Now()
-		hitsDone := make(chan map[int64]network.HubAuthority, 1)
+		hitsDone := make(chan [2][]float64, 1)
 		go func() {
 			defer func() {
 				if r := recover(); r != nil {
 					// Panic -> implicitly causes timeout in parent
 				}
 			}()
-			hitsDone <- network.HITS(a.g, 1e-3)
+			hub, auth := a.g.hits(1e-3)
+			hitsDone <- [2][]float64{hub, auth}
 		}()
 
 		timer := time.NewTimer(config.HITSTimeout)
 		select {
 		case hubAuth := <-hitsDone:
 			timer.Stop()
-			for id, ha := range hubAuth {
-				localHubs[a.nodeToID[id]] = ha.Hub
-				localAuthorities[a.nodeToID[id]] = ha.Authority
+			for v, id := range a.nodeToID {
+				localHubs[id] = hubAuth[0][v]
+				localAuthorities[id] = hubAuth[1][v]
 			}
 		case <-timer.C:
 			profile.HITSTO = true
@@ -933,16 +941,17 @@ func (a *Analyzer) computePhase2WithProfile(ctx context.Context, stats *GraphSta
 			return
 		}
 		profile.HITS = time.Since(hitsStart)
+		traceMetric(ctx, "hits", hitsStart, profile.HITSTO)
 	}
 
 	// Critical Path
 	if ctx.Err() == nil && config.ComputeCriticalPath {
 		cpStart := time.Now()
-		sorted, err := topo.Sort(a.g)
-		if err == nil {
-			localCriticalPath = a.computeHeights(sorted)
+		if order, ok := a.g.dependencyOrder(); ok {
+			localCriticalPath = a.computeHeights(order)
 		}
 		profile.CriticalPath = time.Since(cpStart)
+		traceMetric(ctx, "critical_path", cpStart, false)
 	}
 
 	// Cycles
@@ -953,16 +962,7 @@ func (a *Analyzer) computePhase2WithProfile(ctx context.Context, stats *GraphSta
 			maxCycles = 100
 		}
 
-		sccs := topo.TarjanSCC(a.g)
-		hasCycles := false
-		for _, scc := range sccs {
-			if len(scc) > 1 {
-				hasCycles = true
-				break
-			}
-		}
-
-		if hasCycles {
+		if _, acyclic := a.g.dependencyOrder(); !acyclic {
 			cyclesDone := make(chan [][]graph.Node, 1)
 			go func() {
 				defer func() {
@@ -999,6 +999,7 @@ func (a *Analyzer) computePhase2WithProfile(ctx context.Context, stats *GraphSta
 			}
 		}
 		profile.Cycles = time.Since(cyclesStart)
+		traceMetric(ctx, "cycles", cyclesStart, profile.CyclesTO)
 	}
 
 	// Check cancellation before advanced signals
@@ -1010,11 +1011,13 @@ func (a *Analyzer) computePhase2WithProfile(ctx context.Context, stats *GraphSta
 	kcoreStart := time.Now()
 	localCore, localArticulation = a.computeCoreAndArticulation()
 	profile.KCore = time.Since(kcoreStart)
+	traceMetric(ctx, "kcore", kcoreStart, false)
 	profile.Articulation = 0 // Computed together with k-core
 
 	slackStart := time.Now()
 	localSlack = a.computeSlack()
 	profile.Slack = time.Since(slackStart)
+	traceMetric(ctx, "slack", slackStart, false)
 
 	// Compute ranks (background optimization)
 	localPageRankRank := computeFloatRanks(localPageRank)
@@ -1059,10 +1062,12 @@ func (a *Analyzer) computePhase2WithProfile(ctx context.Context, stats *GraphSta
 	stats.status = MetricStatus{
 		PageRank: statusEntry{State: stateFromTiming(config.ComputePageRank, profile.PageRankTO), Elapsed: profile.PageRank},
 		Betweenness: statusEntry{
-			State:   stateFromTiming(config.ComputeBetweenness, profile.BetweennessTO),
-			Reason:  betweennessReason(config, betweennessIsApprox),
-			Sample:  actualBetweennessSample,
-			Elapsed: profile.Betweenness,
+			State:      betweennessState(config, profile.BetweennessTO, betweennessIsApprox),
+			Reason:     betweennessReason(config, betweennessIsApprox),
+			Mode:       string(betweennessMode),
+			Sample:     actualBetweennessSample,
+			ErrorBound: betweennessErrorBound,
+			Elapsed:    profile.Betweenness,
 		},
 		Eigenvector:  statusEntry{State: stateFromTiming(config.ComputeEigenvector, false), Elapsed: profile.Eigenvector},
 		HITS:         statusEntry{State: stateFromTiming(config.ComputeHITS, profile.HITSTO), Reason: config.HITSSkipReason, Elapsed: profile.HITS},
@@ -1077,36 +1082,22 @@ func (a *Analyzer) computePhase2WithProfile(ctx context.Context, stats *GraphSta
 
 // computePhase1 calculates fast metrics synchronously.
 func (a *Analyzer) computePhase1(stats *GraphStats) {
-	nodes := a.g.Nodes()
-
 	// Basic Degree Centrality
-	for nodes.Next() {
-		n := nodes.Node()
-		id := a.nodeToID[n.ID()]
-
+	for v, id := range a.nodeToID {
 		// Edge direction: dependent -> dependency (A -> B means A depends on B)
-		// To(n) = nodes pointing TO n = issues that depend on n = n blocks them
-		to := a.g.To(n.ID())
-		stats.InDegree[id] = to.Len() // Issues depending on me
+		// to(v) = nodes pointing TO v = issues that depend on v = v blocks them
+		stats.InDegree[id] = len(a.g.to(int32(v))) // Issues depending on me
 
-		// From(n) = nodes n points TO = issues n depends on
-		from := a.g.From(n.ID())
-		stats.OutDegree[id] = from.Len() // Issues I depend on
+		// from(v) = nodes v points TO = issues v depends on
+		stats.OutDegree[id] = len(a.g.from(int32(v))) // Issues I depend on
 	}
 
 	// Topological Sort (execution order)
-	// Note: In our graph model, edge u -> v means u depends on v, so we reverse
-	// topo.Sort's output to get dependencies-first ordering.
-	sorted, err := topo.Sort(a.g)
-	if err == nil {
-		for i := len(sorted) - 1; i >= 0; i-- {
-			stats.TopologicalOrder = append(stats.TopologicalOrder, a.nodeToID[sorted[i].ID()])
-		}
-	}
+	stats.TopologicalOrder = a.topologicalOrder()
 
 	// Density
 	n := float64(len(a.issueMap))
-	e := float64(a.g.Edges().Len())
+	e := float64(a.g.EdgeCount())
 	if n > 1 {
 		stats.Density = e / (n * (n - 1))
 	}
@@ -1147,64 +1138,71 @@ func (a *Analyzer) computePhase2(ctx context.Context, stats *GraphStats, config
 		}
 	}()
 
+	ctx, span := tracing.Start(ctx, "analysis.phase2", graphAttrs(stats)...)
+	defer span.End()
+
 	// Use the profiled version logic to avoid duplication
 	// We discard the profile data as this is the standard run
 	dummyProfile := &StartupProfile{}
 	a.computePhase2WithProfile(ctx, stats, config, dummyProfile)
 }
 
-func (a *Analyzer) computeHeights(sorted []graph.Node) map[string]float64 {
-	heights := make(map[int64]float64)
-	impactScores := make(map[string]float64)
+// graphAttrs describes the analyzed graph on tracing spans
+func graphAttrs(stats *GraphStats) []attribute.KeyValue {
+	return []attribute.KeyValue{attribute.Int("bv.nodes", stats.NodeCount), attribute.Int("bv.edges", stats.EdgeCount)}
+}
 
-	for _, n := range sorted {
-		nid := n.ID()
-		maxParentHeight := 0.0
+// traceMetric records one finished Phase 2 metric as a tracing span
+func traceMetric(ctx context.Context, metric string, start time.Time, timedOut bool) {
+	tracing.Record(ctx, "analysis."+metric, start, time.Now(), attribute.Bool("bv.timed_out", timedOut))
+}
 
-		to := a.g.To(nid)
-		for to.Next() {
-			p := to.Node()
-			if h, ok := heights[p.ID()]; ok {
-				if h > maxParentHeight {
-					maxParentHeight = h
-				}
-			}
-		}
-		heights[nid] = 1.0 + maxParentHeight
-		impactScores[a.nodeToID[nid]] = heights[nid]
+// topologicalOrder returns issue IDs dependencies first, or nil when the
+// blocking graph has a cycle
+func (a *Analyzer) topologicalOrder() []string {
+	order, ok := a.g.dependencyOrder()
+	if !ok {
+		return nil
 	}
-
-	return impactScores
+	ids := make([]string, len(order))
+	for i, v := range order {
+		ids[i] = a.nodeToID[v]
+	}
+	return ids
 }
 
-// computeCoreAndArticulation builds an undirected view to derive k-core numbers and articulation points.
-func (a *Analyzer) computeCoreAndArticulation() (map[string]int, map[string]bool) {
-	u := simple.NewUndirectedGraph()
+// computeHeights scores each issue by the length of the longest chain of
+// dependents above it, walking order (dependencies first) backwards.
+func (a *Analyzer) computeHeights(order []int32) map[string]float64 {
+	heights := make([]float64, a.g.Len())
+	impactScores := make(map[string]float64, len(order))
 
-	// Add nodes
-	nodes := a.g.Nodes()
-	for nodes.Next() {
-		n := nodes.Node()
-		u.AddNode(simple.Node(n.ID()))
+	for i := len(order) - 1; i >= 0; i-- {
+		v := order[i]
+		maxParentHeight := 0.0
+		for _, p := range a.g.to(v) {
+			maxParentHeight = max(maxParentHeight, heights[p])
+		}
+		heights[v] = 1.0 + maxParentHeight
+		impactScores[a.nodeToID[v]] = heights[v]
 	}
 
-	// Add undirected edges for each directed edge
-	edges := a.g.Edges()
-	for edges.Next() {
-		e := edges.Edge()
-		u.SetEdge(u.NewEdge(u.Node(e.From().ID()), u.Node(e.To().ID())))
-	}
+	return impactScores
+}
 
-	core := computeKCore(u)
-	art := findArticulationPoints(u)
+// computeCoreAndArticulation uses an undirected view to derive k-core numbers and articulation points.
+func (a *Analyzer) computeCoreAndArticulation() (map[string]int, map[string]bool) {
+	start, nbr := a.g.undirected()
+	core := kCore(start, nbr)
+	art := articulationPoints(start, nbr)
 
 	coreByID := make(map[string]int, len(core))
-	artByID := make(map[string]bool, len(art))
-	for id, val := range core {
-		coreByID[a.nodeToID[id]] = val
-	}
-	for id := range art {
-		artByID[a.nodeToID[id]] = true
+	artByID := make(map[string]bool)
+	for v, id := range a.nodeToID {
+		coreByID[id] = core[v]
+		if art[v] {
+			artByID[id] = true
+		}
 	}
 	return coreByID, artByID
 }
@@ -1217,181 +1215,66 @@ func (a *Analyzer) computeSlack() map[string]float64 {
 	}
 
 	// Topological order (dependencies first)
-	var order []string
-	sorted, err := topo.Sort(a.g)
-	if err == nil {
-		for i := len(sorted) - 1; i >= 0; i-- {
-			order = append(order, a.nodeToID[sorted[i].ID()])
-		}
-	}
-	if len(order) == 0 {
+	order, ok := a.g.dependencyOrder()
+	if !ok || len(order) == 0 {
 		return nil
 	}
 
-	distFromStart := make(map[string]int, len(order))
-	distToEnd := make(map[string]int, len(order))
-	for _, id := range order {
-		distFromStart[id] = 0
-		distToEnd[id] = 0
-	}
-
-	// Helper for prerequisites (g.From) - items this node depends on
-	prereqDeps := func(id string) []int64 {
-		nID := a.idToNode[id]
-		from := a.g.From(nID)
-		var res []int64
-		for from.Next() {
-			res = append(res, from.Node().ID())
-		}
-		return res
-	}
+	distFromStart := make([]int, a.g.Len())
+	distToEnd := make([]int, a.g.Len())
 
 	// Forward pass: longest distance from any start to each node
 	// Propagate from u to v (u -> v): dist[v] = max(dist[v], dist[u] + 1)
 	for i := len(order) - 1; i >= 0; i-- {
-		id := order[i]
-		for _, dep := range prereqDeps(id) {
-			depID := a.nodeToID[dep]
-			if distFromStart[depID] < distFromStart[id]+1 {
-				distFromStart[depID] = distFromStart[id] + 1
-			}
+		v := order[i]
+		for _, dep := range a.g.from(v) {
+			distFromStart[dep] = max(distFromStart[dep], distFromStart[v]+1)
 		}
 	}
 
 	// Reverse pass: longest distance from node to any end
 	// Propagate from v to u (u -> v): dist[u] = max(dist[u], dist[v] + 1)
-	for _, id := range order {
-		for _, dep := range prereqDeps(id) {
-			depID := a.nodeToID[dep]
-			if distToEnd[id] < distToEnd[depID]+1 {
-				distToEnd[id] = distToEnd[depID] + 1
-			}
+	for _, v := range order {
+		for _, dep := range a.g.from(v) {
+			distToEnd[v] = max(distToEnd[v], distToEnd[dep]+1)
 		}
 	}
 
 	longest := 0
-	for _, id := range order {
-		if d := distFromStart[id] + distToEnd[id]; d > longest {
-			longest = d
-		}
+	for _, v := range order {
+		longest = max(longest, distFromStart[v]+distToEnd[v])
 	}
 
 	slack := make(map[string]float64, len(order))
-	for _, id := range order {
-		slack[id] = float64(longest - distFromStart[id] - distToEnd[id])
+	for _, v := range order {
+		slack[a.nodeToID[v]] = float64(longest - distFromStart[v] - distToEnd[v])
 	}
 	return slack
 }
 
-// computeKCore returns core numbers using iterative k peeling (handles isolated nodes and preserves correct cores).
-func computeKCore(g *simple.UndirectedGraph) map[int64]int {
-	// Build adjacency and degrees
-	deg := make(map[int64]int)
-	adj := make(map[int64][]int64)
-	nodes := g.Nodes()
-	for nodes.Next() {
-		n := nodes.Node()
-		it := g.From(n.ID())
-		for it.Next() {
-			adj[n.ID()] = append(adj[n.ID()], it.Node().ID())
-		}
-		deg[n.ID()] = len(adj[n.ID()])
-	}
-
-	core := make(map[int64]int, len(deg))
-	removed := make(map[int64]bool, len(deg))
-
-	maxDeg := 0
-	for _, d := range deg {
-		if d > maxDeg {
-			maxDeg = d
-		}
-	}
-
-	for k := 1; k <= maxDeg; k++ {
-		queue := make([]int64, 0)
-		for id, d := range deg {
-			if !removed[id] && d < k {
-				queue = append(queue, id)
-			}
-		}
-
-		for len(queue) > 0 {
-			v := queue[len(queue)-1]
-			queue = queue[:len(queue)-1]
-			if removed[v] {
-				continue
-			}
-			removed[v] = true
-			// Highest core they failed to meet is k-1
-			core[v] = k - 1
-			for _, nbr := range adj[v] {
-				if removed[nbr] {
-					continue
-				}
-				deg[nbr]--
-				if deg[nbr] < k {
-					queue = append(queue, nbr)
-				}
-			}
-		}
-	}
-
-	// Any nodes never removed get maxDeg
-	for id := range deg {
-		if !removed[id] {
-			core[id] = maxDeg
-		}
-	}
-
-	return core
-}
-
 // findArticulationPoints runs Tarjan to find cut vertices in an undirected graph.
 func findArticulationPoints(g *simple.UndirectedGraph) map[int64]bool {
-	var timeIdx int
-	disc := make(map[int64]int)
-	low := make(map[int64]int)
-	parent := make(map[int64]int64)
-	ap := make(map[int64]bool)
-
-	const noParent int64 = -1
-
-	var dfs func(v int64)
-	dfs = func(v int64) {
-		timeIdx++
-		disc[v] = timeIdx
-		low[v] = timeIdx
-		childCount := 0
-
-		it := g.From(v)
+	// Index the nodes densely, as in compactOf
+	nodes := graph.NodesOf(g.Nodes())
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].ID() < nodes[j].ID() })
+	index := make(map[int64]int32, len(nodes))
+	for i, n := range nodes {
+		index[n.ID()] = int32(i)
+	}
+	start := make([]int32, len(nodes)+1)
+	var nbr []int32
+	for i, n := range nodes {
+		it := g.From(n.ID())
 		for it.Next() {
-			u := it.Node().ID()
-			if disc[u] == 0 {
-				parent[u] = v
-				childCount++
-				dfs(u)
-				low[v] = min(low[v], low[u])
-
-				// Root with >1 child OR low[u] >= disc[v]
-				if parent[v] == noParent && childCount > 1 {
-					ap[v] = true
-				}
-				if parent[v] != noParent && low[u] >= disc[v] {
-					ap[v] = true
-				}
-			} else if u != parent[v] {
-				low[v] = min(low[v], disc[u])
-			}
+			nbr = append(nbr, index[it.Node().ID()])
 		}
+		start[i+1] = int32(len(nbr))
 	}
 
-	nodes := g.Nodes()
-	for nodes.Next() {
-		id := nodes.Node().ID()
-		if disc[id] == 0 {
-			parent[id] = noParent
-			dfs(id)
+	ap := make(map[int64]bool)
+	for i, cut := range articulationPoints(start, nbr) {
+		if cut {
+			ap[nodes[i].ID()] = true
 		}
 	}
 	return ap
@@ -1415,7 +1298,8 @@ func (a *Analyzer) GetActionableIssues() []model.Issue {
 
 	for _, id := range ids {
 		issue := a.issueMap[id]
-		if issue.Status == model.StatusClosed {
+		// Deferred work is put off, not ready, even without blockers
+		if issue.Status == model.StatusClosed || issue.Status == model.StatusDeferred {
 			continue
 		}
 
@@ -1647,172 +1531,14 @@ func (a *Analyzer) countBlockedBy(issueID string) int {
 // It uses a deterministic power iteration with damping factor damp and terminates
 // when the L2 norm of the delta is below tol (or after a hard iteration cap).
 func computePageRank(g graph.Directed, damp, tol float64) map[int64]float64 {
-	nodes := graph.NodesOf(g.Nodes())
-	sort.Slice(nodes, func(i, j int) bool { return nodes[i].ID() < nodes[j].ID() })
-	if len(nodes) == 0 {
-		return map[int64]float64{}
-	}
-	if tol <= 0 {
-		tol = 1e-6
-	}
-
-	indexOf := make(map[int64]int, len(nodes))
-	for i, n := range nodes {
-		indexOf[n.ID()] = i
-	}
-
-	out := make([][]int, len(nodes))
-	for j, u := range nodes {
-		to := graph.NodesOf(g.From(u.ID()))
-		sort.Slice(to, func(i, j int) bool { return to[i].ID() < to[j].ID() })
-
-		if len(to) == 0 {
-			continue
-		}
-
-		out[j] = make([]int, 0, len(to))
-		for _, v := range to {
-			if idx, ok := indexOf[v.ID()]; ok {
-				out[j] = append(out[j], idx)
-			}
-		}
-	}
-
-	n := float64(len(nodes))
-	rank := make([]float64, len(nodes))
-	uniform := 1.0 / n
-	for i := range rank {
-		rank[i] = uniform
-	}
-	next := make([]float64, len(nodes))
-
-	base := (1 - damp) / n
-	const maxIterations = 1000
-	for iter := 0; iter < maxIterations; iter++ {
-		for i := range next {
-			next[i] = base
-		}
-
-		dangling := 0.0
-		for j := range nodes {
-			outdeg := len(out[j])
-			if outdeg == 0 {
-				dangling += rank[j]
-				continue
-			}
-			share := damp * rank[j] / float64(outdeg)
-			for _, i := range out[j] {
-				next[i] += share
-			}
-		}
-		if dangling != 0 {
-			add := damp * dangling / n
-			for i := range next {
-				next[i] += add
-			}
-		}
-
-		diff := 0.0
-		for i := range rank {
-			d := next[i] - rank[i]
-			diff += d * d
-		}
-
-		rank, next = next, rank
-		if math.Sqrt(diff) < tol {
-			break
-		}
-	}
-
-	ranks := make(map[int64]float64, len(nodes))
-	for i, node := range nodes {
-		ranks[node.ID()] = rank[i]
+	c, ids := compactOf(g)
+	ranks := make(map[int64]float64, len(ids))
+	for i, score := range c.pageRank(damp, tol) {
+		ranks[ids[i]] = score
 	}
-
 	return ranks
 }
 
-// computeEigenvector runs a simple power-iteration to estimate eigenvector centrality.
-func computeEigenvector(g graph.Directed) map[int64]float64 {
-	nodes := g.Nodes()
-	var nodeList []graph.Node
-	for nodes.Next() {
-		nodeList = append(nodeList, nodes.Node())
-	}
-	n := len(nodeList)
-	if n == 0 {
-		return nil
-	}
-
-	// Sort nodes by ID for deterministic iteration order
-	sort.Slice(nodeList, func(i, j int) bool {
-		return nodeList[i].ID() < nodeList[j].ID()
-	})
-
-	// Pre-calculate and sort incoming neighbors for every node
-	// This avoids allocating and sorting inside the hot loop
-	incomingMap := make(map[int64][]int, n)
-	index := make(map[int64]int, n)
-	for i, node := range nodeList {
-		index[node.ID()] = i
-	}
-
-	for _, node := range nodeList {
-		var neighbors []graph.Node
-		incoming := g.To(node.ID())
-		for incoming.Next() {
-			neighbors = append(neighbors, incoming.Node())
-		}
-		// Deterministic order for summation
-		sort.Slice(neighbors, func(a, b int) bool {
-			return neighbors[a].ID() < neighbors[b].ID()
-		})
-
-		// Store indices directly to avoid map lookups in loop
-		indices := make([]int, len(neighbors))
-		for k, neighbor := range neighbors {
-			indices[k] = index[neighbor.ID()]
-		}
-		incomingMap[node.ID()] = indices
-	}
-
-	vec := make([]float64, n)
-	for i := range vec {
-		vec[i] = 1.0 / float64(n)
-	}
-	work := make([]float64, n)
-
-	const iterations = 50
-	for iter := 0; iter < iterations; iter++ {
-		for i := range work {
-			work[i] = 0
-		}
-		for i, node := range nodeList {
-			// Use pre-calculated sorted indices
-			for _, j := range incomingMap[node.ID()] {
-				work[i] += vec[j]
-			}
-		}
-		sum := 0.0
-		for _, v := range work {
-			sum += v * v
-		}
-		if sum == 0 {
-			break
-		}
-		norm := 1 / math.Sqrt(sum)
-		for i := range work {
-			vec[i] = work[i] * norm
-		}
-	}
-
-	res := make(map[int64]float64, n)
-	for i, node := range nodeList {
-		res[node.ID()] = vec[i]
-	}
-	return res
-}
-
 // computeFloatRanks computes rankings for a float map (descending).
 func computeFloatRanks(m map[string]float64) map[string]int {
 	if m == nil {
@@ -0,0 +1,268 @@
+package analysis
+
+import (
+	"crypto/sha256"
+	"sort"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// ReloadDelta is what changed between two loads of the same issues, at the
+// granularity incremental re-analysis needs. File-watcher reloads usually
+// touch a handful of issues, most often only their status or text.
+type ReloadDelta struct {
+	Added    []string // Issue IDs new in the reload
+	Removed  []string // Issue IDs gone from the reload
+	Modified []string // Issue IDs whose data changed
+	// EdgesChanged reports whether a modified issue gained or lost a
+	// blocking dependency
+	EdgesChanged bool
+}
+
+// Empty reports whether the reload changed no issue data
+func (d ReloadDelta) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Modified) == 0
+}
+
+// TopologyChanged reports whether the blocking graph changed. Every graph
+// metric (degrees, PageRank, betweenness, cycles, ...) is a function of that
+// graph alone, so when it is unchanged none of them needs recomputing.
+func (d ReloadDelta) TopologyChanged() bool {
+	return len(d.Added) > 0 || len(d.Removed) > 0 || d.EdgesChanged
+}
+
+// DiffForReload compares the issues before and after a reload by ID. An
+// issue counts as modified when any field ComputeDataHash covers changed.
+func DiffForReload(before, after []model.Issue) ReloadDelta {
+	old := make(map[string]*model.Issue, len(before))
+	for i := range before {
+		old[before[i].ID] = &before[i]
+	}
+
+	var d ReloadDelta
+	seen := make(map[string]bool, len(after))
+	for i := range after {
+		issue := &after[i]
+		seen[issue.ID] = true
+		prev, ok := old[issue.ID]
+		switch {
+		case !ok:
+			d.Added = append(d.Added, issue.ID)
+		case issueFingerprint(prev) != issueFingerprint(issue):
+			d.Modified = append(d.Modified, issue.ID)
+			if !sameStrings(blockingTargets(prev), blockingTargets(issue)) {
+				d.EdgesChanged = true
+			}
+		}
+	}
+	for id := range old {
+		if !seen[id] {
+			d.Removed = append(d.Removed, id)
+		}
+	}
+
+	sort.Strings(d.Added)
+	sort.Strings(d.Removed)
+	sort.Strings(d.Modified)
+	return d
+}
+
+// issueFingerprint hashes the same fields as ComputeDataHash for one issue
+func issueFingerprint(issue *model.Issue) [sha256.Size]byte {
+	h := sha256.New()
+	writeIssueHash(h, issue)
+	var sum [sha256.Size]byte
+	copy(sum[:], h.Sum(nil))
+	return sum
+}
+
+// blockingTargets is the sorted, de-duplicated set of IDs issue has a
+// blocking dependency on, i.e. its out-edges in the analysis graph
+func blockingTargets(issue *model.Issue) []string {
+	var targets []string
+	for _, dep := range issue.Dependencies {
+		if dep != nil && dep.Type.IsBlocking() {
+			targets = append(targets, dep.DependsOnID)
+		}
+	}
+	sort.Strings(targets)
+	out := targets[:0]
+	for i, t := range targets {
+		if i == 0 || t != targets[i-1] {
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+func sameStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// UpdateForReload brings the analysis up to date with issues after a
+// reload described by delta, without rebuilding anything the change cannot
+// have affected. When the blocking graph is unchanged, the returned
+// analyzer shares a's graph and stats (Phase 1 and Phase 2, even if still
+// running) are returned as they are; only the issue data is swapped in.
+// ok is false when the graph changed and the caller must analyze issues
+// from scratch.
+func (a *Analyzer) UpdateForReload(stats *GraphStats, issues []model.Issue, delta ReloadDelta) (updated *Analyzer, updatedStats *GraphStats, ok bool) {
+	if a == nil || stats == nil || delta.TopologyChanged() || len(issues) != len(a.issueMap) {
+		return nil, nil, false
+	}
+
+	issueMap := make(map[string]model.Issue, len(issues))
+	for _, issue := range issues {
+		if _, exists := a.idToNode[issue.ID]; !exists {
+			return nil, nil, false
+		}
+		issueMap[issue.ID] = issue
+	}
+	return &Analyzer{
+		g:        a.g,
+		idToNode: a.idToNode,
+		nodeToID: a.nodeToID,
+		issueMap: issueMap,
+		config:   a.config,
+	}, stats, true
+}
+
+// Dependents returns the IDs of issues with a blocking dependency on id,
+// sorted
+func (a *Analyzer) Dependents(id string) []string {
+	node, ok := a.idToNode[id]
+	if !ok {
+		return nil
+	}
+	var ids []string
+	from := a.g.To(node)
+	for from.Next() {
+		ids = append(ids, a.nodeToID[from.Node().ID()])
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// AffectedByReload returns the issues whose readiness a reload may have
+// changed: those added, removed or modified, plus the direct dependents of
+// each (a blocker appearing, closing or disappearing changes whether they
+// wait on it). before and after are the analyzers of the two loads; after
+// may share before's graph.
+func AffectedByReload(before, after *Analyzer, delta ReloadDelta) []string {
+	set := make(map[string]bool)
+	for _, ids := range [][]string{delta.Added, delta.Removed, delta.Modified} {
+		for _, id := range ids {
+			set[id] = true
+			for _, a := range []*Analyzer{before, after} {
+				if a == nil {
+					continue
+				}
+				for _, dep := range a.Dependents(id) {
+					set[dep] = true
+				}
+			}
+		}
+	}
+
+	ids := make([]string, 0, len(set))
+	for id := range set {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// readiness is how an issue counts in the open/ready/blocked/closed tallies
+type readiness uint8
+
+const (
+	readinessClosed  readiness = iota
+	readinessBlocked           // Open, explicitly marked blocked
+	readinessWaiting           // Open, waiting on an open blocking dependency
+	readinessReady             // Open and actionable
+)
+
+// ReadinessIndex classifies every issue as closed, ready, blocked or
+// waiting on a blocker, and keeps the tallies. Update reclassifies just the
+// issues a reload affected instead of walking every issue again.
+type ReadinessIndex struct {
+	states map[string]readiness
+
+	Open    int // Not closed
+	Ready   int // Open with no open blocking dependency
+	Blocked int // Explicitly marked blocked
+	Closed  int
+}
+
+// NewReadinessIndex classifies every issue in issueMap
+func NewReadinessIndex(issueMap map[string]*model.Issue) *ReadinessIndex {
+	r := &ReadinessIndex{states: make(map[string]readiness, len(issueMap))}
+	for id := range issueMap {
+		r.set(id, classifyReadiness(issueMap, issueMap[id]))
+	}
+	return r
+}
+
+// Update reclassifies ids against issueMap, forgetting IDs no longer in it
+func (r *ReadinessIndex) Update(issueMap map[string]*model.Issue, ids []string) {
+	for _, id := range ids {
+		if old, ok := r.states[id]; ok {
+			r.tally(old, -1)
+			delete(r.states, id)
+		}
+		if issue, ok := issueMap[id]; ok {
+			r.set(id, classifyReadiness(issueMap, issue))
+		}
+	}
+}
+
+// IsReady reports whether id is open with no open blocking dependency
+func (r *ReadinessIndex) IsReady(id string) bool {
+	return r.states[id] == readinessReady
+}
+
+func (r *ReadinessIndex) set(id string, s readiness) {
+	r.states[id] = s
+	r.tally(s, 1)
+}
+
+func (r *ReadinessIndex) tally(s readiness, n int) {
+	switch s {
+	case readinessClosed:
+		r.Closed += n
+		return
+	case readinessBlocked:
+		r.Blocked += n
+	case readinessReady:
+		r.Ready += n
+	}
+	r.Open += n
+}
+
+// classifyReadiness mirrors GetActionableIssues: an open issue is waiting
+// when any blocking dependency that exists is not closed
+func classifyReadiness(issueMap map[string]*model.Issue, issue *model.Issue) readiness {
+	if issue.Status == model.StatusClosed {
+		return readinessClosed
+	}
+	if issue.Status == model.StatusBlocked {
+		return readinessBlocked
+	}
+	for _, dep := range issue.Dependencies {
+		if dep == nil || !dep.Type.IsBlocking() {
+			continue
+		}
+		if blocker, exists := issueMap[dep.DependsOnID]; exists && blocker.Status != model.StatusClosed {
+			return readinessWaiting
+		}
+	}
+	return readinessReady
+}
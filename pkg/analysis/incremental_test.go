@@ -0,0 +1,125 @@
+package analysis
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func reloadIssues(statusB model.Status, cDependsOn string) []model.Issue {
+	issues := []model.Issue{
+		{ID: "A", Title: "Root", Status: model.StatusOpen},
+		{ID: "B", Title: "Middle", Status: statusB, Dependencies: []*model.Dependency{
+			{IssueID: "B", DependsOnID: "A", Type: model.DepBlocks},
+		}},
+		{ID: "C", Title: "Leaf", Status: model.StatusOpen},
+	}
+	if cDependsOn != "" {
+		issues[2].Dependencies = []*model.Dependency{{IssueID: "C", DependsOnID: cDependsOn, Type: model.DepBlocks}}
+	}
+	return issues
+}
+
+func TestDiffForReload(t *testing.T) {
+	before := reloadIssues(model.StatusOpen, "B")
+
+	if d := DiffForReload(before, reloadIssues(model.StatusOpen, "B")); !d.Empty() || d.TopologyChanged() {
+		t.Errorf("identical reload: %+v", d)
+	}
+
+	// Status and text edits change data but not the graph
+	after := reloadIssues(model.StatusClosed, "B")
+	after[0].Title = "Root, renamed"
+	d := DiffForReload(before, after)
+	if !reflect.DeepEqual(d.Modified, []string{"A", "B"}) || d.TopologyChanged() {
+		t.Errorf("status edit: %+v", d)
+	}
+
+	// Related links are not graph edges
+	after = reloadIssues(model.StatusOpen, "B")
+	after[0].Dependencies = []*model.Dependency{{IssueID: "A", DependsOnID: "C", Type: model.DepRelated}}
+	if d := DiffForReload(before, after); len(d.Modified) != 1 || d.TopologyChanged() {
+		t.Errorf("related link: %+v", d)
+	}
+
+	if d := DiffForReload(before, reloadIssues(model.StatusOpen, "A")); !d.EdgesChanged || !reflect.DeepEqual(d.Modified, []string{"C"}) {
+		t.Errorf("rewired dependency: %+v", d)
+	}
+
+	after = append(reloadIssues(model.StatusOpen, "B")[1:], model.Issue{ID: "D", Status: model.StatusOpen})
+	d = DiffForReload(before, after)
+	if !reflect.DeepEqual(d.Added, []string{"D"}) || !reflect.DeepEqual(d.Removed, []string{"A"}) || !d.TopologyChanged() {
+		t.Errorf("add/remove: %+v", d)
+	}
+}
+
+func TestUpdateForReload_ReusesMetricsWhenGraphUnchanged(t *testing.T) {
+	before := reloadIssues(model.StatusOpen, "B")
+	a := NewAnalyzer(before)
+	stats := a.AnalyzeAsync(t.Context())
+
+	after := reloadIssues(model.StatusClosed, "B")
+	updated, reused, ok := a.UpdateForReload(stats, after, DiffForReload(before, after))
+	if !ok || reused != stats {
+		t.Fatalf("status-only reload should carry the stats over (ok=%v)", ok)
+	}
+	if got := updated.GetIssue("B"); got == nil || got.Status != model.StatusClosed {
+		t.Errorf("updated analyzer has stale issue data: %+v", got)
+	}
+	if got := a.GetIssue("B"); got.Status != model.StatusOpen {
+		t.Error("the previous analyzer must not change")
+	}
+	reused.WaitForPhase2()
+	if reused.GetPageRankScore("A") == 0 {
+		t.Error("carried-over stats lost Phase 2 metrics")
+	}
+
+	actionable := updated.GetActionableIssues()
+	if len(actionable) != 2 || actionable[0].ID != "A" || actionable[1].ID != "C" {
+		t.Errorf("actionable after closing B = %v", actionable)
+	}
+
+	rewired := reloadIssues(model.StatusOpen, "A")
+	if _, _, ok := a.UpdateForReload(stats, rewired, DiffForReload(before, rewired)); ok {
+		t.Error("a changed dependency must force a full analysis")
+	}
+}
+
+func TestReadinessIndex_IncrementalMatchesFull(t *testing.T) {
+	index := func(issues []model.Issue) map[string]*model.Issue {
+		m := make(map[string]*model.Issue, len(issues))
+		for i := range issues {
+			m[issues[i].ID] = &issues[i]
+		}
+		return m
+	}
+
+	steps := [][]model.Issue{
+		reloadIssues(model.StatusOpen, "B"),
+		reloadIssues(model.StatusClosed, "B"),  // B closes: C becomes ready
+		reloadIssues(model.StatusBlocked, "B"), // B marked blocked
+		append(reloadIssues(model.StatusOpen, "D")[1:], model.Issue{ID: "D", Status: model.StatusOpen}), // A gone, C waits on new D
+	}
+
+	prev := steps[0]
+	r := NewReadinessIndex(index(prev))
+	for i, next := range steps[1:] {
+		delta := DiffForReload(prev, next)
+		r.Update(index(next), AffectedByReload(NewAnalyzer(prev), NewAnalyzer(next), delta))
+
+		full := NewReadinessIndex(index(next))
+		if r.Open != full.Open || r.Ready != full.Ready || r.Blocked != full.Blocked || r.Closed != full.Closed {
+			t.Errorf("step %d: incremental %+v, full %+v", i+1, *r, *full)
+		}
+		for id := range index(next) {
+			if r.IsReady(id) != full.IsReady(id) {
+				t.Errorf("step %d: %s ready = %v, want %v", i+1, id, r.IsReady(id), full.IsReady(id))
+			}
+		}
+		prev = next
+	}
+	if !r.IsReady("B") || r.IsReady("C") || r.IsReady("A") {
+		t.Errorf("final states wrong: B ready %v, C ready %v", r.IsReady("B"), r.IsReady("C"))
+	}
+}
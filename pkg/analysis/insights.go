@@ -25,6 +25,7 @@ type Insights struct {
 	Cycles         [][]string
 	ClusterDensity float64
 	Velocity       *VelocitySnapshot
+	Clusters       *Clustering // Workstreams found by community detection (set by the caller, like Velocity)
 
 	// Full stats for calculation explanations
 	Stats *GraphStats
@@ -252,11 +252,11 @@ func ComputeCrossLabelFlow(issues []model.Issue, cfg LabelHealthConfig) CrossLab
 			if !cfg.IncludeClosedInFlow && blocker.Status == model.StatusClosed {
 				continue
 			}
-			// Cross-product of labels
-			for _, from := range blocker.Labels {
-				for _, to := range blocked.Labels {
-					if from == "" || to == "" || from == to {
-						continue // skip empty/self
+			// Cross-product of labels, up the hierarchy
+			for _, from := range expandLabels(blocker.Labels) {
+				for _, to := range expandLabels(blocked.Labels) {
+					if from == "" || to == "" || labelWithin(from, to) || labelWithin(to, from) {
+						continue // skip empty/self, and flow within one hierarchy
 					}
 					iFrom, okFrom := index[from]
 					iTo, okTo := index[to]
@@ -464,11 +464,11 @@ func ComputeLabelHealthForLabel(label string, issues []model.Issue, cfg LabelHea
 	health := NewLabelHealth(label)
 	health.Issues = []string{}
 
-	// Collect issues with this label
+	// Collect issues with this label or one under it
 	var labeled []model.Issue
 	for _, iss := range issues {
 		for _, l := range iss.Labels {
-			if l == label {
+			if labelWithin(l, label) {
 				labeled = append(labeled, iss)
 				health.Issues = append(health.Issues, iss.ID)
 				break
@@ -513,14 +513,14 @@ func ComputeLabelHealthForLabel(label string, issues []model.Issue, cfg LabelHea
 			targetLabels := iss.Labels
 			// incoming: other label blocks this
 			for _, bl := range blockerLabels {
-				if bl != label {
+				if !labelWithin(bl, label) {
 					flow.IncomingDeps++
 					seenIn[bl] = struct{}{}
 				}
 			}
 			// outgoing: this label blocks others
 			for _, tl := range targetLabels {
-				if tl == label {
+				if labelWithin(tl, label) {
 					continue
 				}
 				flow.OutgoingDeps++
@@ -823,8 +823,8 @@ func ExtractLabels(issues []model.Issue) LabelExtractionResult {
 			result.UnlabeledCount++
 		}
 
-		// Process each label on the issue
-		for _, label := range issue.Labels {
+		// Process each label on the issue, and each parent of a hierarchical one
+		for _, label := range expandLabels(issue.Labels) {
 			// Skip empty labels
 			if label == "" {
 				continue
@@ -855,7 +855,7 @@ func ExtractLabels(issues []model.Issue) LabelExtractionResult {
 				stats.OpenCount++
 			case model.StatusClosed:
 				stats.ClosedCount++
-			case model.StatusInProgress:
+			case model.StatusInProgress, model.StatusInReview:
 				stats.InProgress++
 			case model.StatusBlocked:
 				stats.Blocked++
@@ -908,12 +908,13 @@ func sortLabelsByCount(stats map[string]*LabelStats) []string {
 	return result
 }
 
-// GetLabelIssues returns all issues that have a specific label
+// GetLabelIssues returns all issues that have a specific label, or a label
+// under it in the hierarchy
 func GetLabelIssues(issues []model.Issue, label string) []model.Issue {
 	var result []model.Issue
 	for _, issue := range issues {
 		for _, l := range issue.Labels {
-			if l == label {
+			if labelWithin(l, label) {
 				result = append(result, issue)
 				break
 			}
@@ -1008,7 +1009,7 @@ func ComputeBlockedByLabel(issues []model.Issue, analyzer *Analyzer) map[string]
 		blockers := analyzer.GetOpenBlockers(issue.ID)
 		if len(blockers) > 0 {
 			// This issue is blocked - count for each of its labels
-			for _, label := range issue.Labels {
+			for _, label := range expandLabels(issue.Labels) {
 				blocked[label]++
 			}
 		}
@@ -1045,7 +1046,7 @@ func ComputeBlockageCascade(issues []model.Issue, flow CrossLabelFlow, cfg Label
 		issueMap[iss.ID] = iss
 		if iss.Status == model.StatusBlocked {
 			blockedIssueIDs[iss.ID] = true // Count each blocked issue once
-			for _, label := range iss.Labels {
+			for _, label := range expandLabels(iss.Labels) {
 				blockedByLabel[label] = append(blockedByLabel[label], iss)
 			}
 		}
@@ -1342,11 +1343,11 @@ func ComputeLabelSubgraph(issues []model.Issue, label string) LabelSubgraph {
 		fullIssueMap[iss.ID] = iss
 	}
 
-	// Find core issues (those with the target label)
+	// Find core issues (those with the target label or one under it)
 	coreSet := make(map[string]bool)
 	for _, iss := range issues {
 		for _, l := range iss.Labels {
-			if l == label {
+			if labelWithin(l, label) {
 				coreSet[iss.ID] = true
 				result.IssueMap[iss.ID] = iss
 				break
@@ -1438,10 +1439,11 @@ func ComputeLabelSubgraph(issues []model.Issue, label string) LabelSubgraph {
 	return result
 }
 
-// HasLabel checks if an issue has a specific label
+// HasLabel checks if an issue has a specific label, or a label under it in
+// the hierarchy (backend/api counts as backend)
 func HasLabel(issue model.Issue, label string) bool {
 	for _, l := range issue.Labels {
-		if l == label {
+		if labelWithin(l, label) {
 			return true
 		}
 	}
@@ -2005,8 +2007,14 @@ func computeLabelAttention(label string, issues []model.Issue, issueMap map[stri
 	sg := ComputeLabelSubgraph(issues, label)
 	if !sg.IsEmpty() {
 		pr := ComputeLabelPageRank(sg)
-		for _, s := range pr.CoreOnly {
-			score.PageRankSum += s
+		// Sum in ID order so the float result doesn't vary with map order
+		ids := make([]string, 0, len(pr.CoreOnly))
+		for id := range pr.CoreOnly {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+		for _, id := range ids {
+			score.PageRankSum += pr.CoreOnly[id]
 		}
 	}
 
@@ -2087,7 +2095,7 @@ func ComputeHistoricalVelocity(issues []model.Issue, label string, numWeeks int,
 	var labeled []model.Issue
 	for _, iss := range issues {
 		for _, l := range iss.Labels {
-			if l == label {
+			if labelWithin(l, label) {
 				labeled = append(labeled, iss)
 				break
 			}
@@ -2529,3 +2529,47 @@ func TestBlockageCascadeAnalysis_GetMostImpactfulCascade(t *testing.T) {
 		t.Errorf("Expected 'high' label, got %s", cascade.SourceLabel)
 	}
 }
+
+func TestLabelHierarchyAggregates(t *testing.T) {
+	now := time.Now().UTC()
+	cfg := DefaultLabelHealthConfig()
+	issues := []model.Issue{
+		{ID: "A", Labels: []string{"backend/api"}, Status: model.StatusOpen},
+		{ID: "B", Labels: []string{"backend/db"}, Status: model.StatusOpen, Dependencies: []*model.Dependency{{DependsOnID: "A", Type: model.DepBlocks}}},
+		{ID: "C", Labels: []string{"frontend"}, Status: model.StatusOpen, Dependencies: []*model.Dependency{{DependsOnID: "A", Type: model.DepBlocks}}},
+	}
+
+	extracted := ExtractLabels(issues)
+	if s := extracted.Stats["backend"]; s == nil || s.TotalCount != 2 {
+		t.Fatalf("backend should aggregate its children, got %+v", s)
+	}
+
+	health := ComputeLabelHealthForLabel("backend", issues, cfg, now, nil)
+	if health.IssueCount != 2 {
+		t.Errorf("backend health covers %d issues, want 2", health.IssueCount)
+	}
+	if !HasLabel(issues[0], "backend") || HasLabel(issues[2], "backend") {
+		t.Error("HasLabel should match labels under a parent")
+	}
+
+	flow := ComputeCrossLabelFlow(issues, cfg)
+	pairs := make(map[string]bool)
+	for _, dep := range flow.Dependencies {
+		pairs[dep.FromLabel+">"+dep.ToLabel] = true
+	}
+	for _, want := range []string{"backend/api>backend/db", "backend/api>frontend", "backend>frontend"} {
+		if !pairs[want] {
+			t.Errorf("missing flow %s in %v", want, pairs)
+		}
+	}
+	for _, within := range []string{"backend>backend/db", "backend/api>backend"} {
+		if pairs[within] {
+			t.Errorf("flow within one hierarchy %s should be skipped", within)
+		}
+	}
+
+	attention := ComputeLabelAttentionScores(issues, cfg, now)
+	if a := attention.GetLabelAttention("backend"); a == nil || a.OpenCount != 2 {
+		t.Errorf("backend attention = %+v", a)
+	}
+}
@@ -0,0 +1,15 @@
+package analysis
+
+import "github.com/Dicklesworthstone/beads_viewer/pkg/labels"
+
+// expandLabels returns an issue's labels plus their hierarchy parents
+// (backend/api also counts toward backend), so label analytics aggregate
+// up the hierarchy
+func expandLabels(issueLabels []string) []string {
+	return labels.WithAncestors(issueLabels)
+}
+
+// labelWithin reports whether label is parent or sits under it
+func labelWithin(label, parent string) bool {
+	return labels.Within(label, parent)
+}
@@ -2,6 +2,7 @@ package analysis
 
 import (
 	"fmt"
+	"math"
 	"sort"
 	"strings"
 
@@ -94,10 +95,10 @@ var builtinLabelMappings = map[string][]string{
 
 // LabelMatch represents a potential label suggestion
 type LabelMatch struct {
-	IssueID      string  `json:"issue_id"`
-	Label        string  `json:"label"`
-	Confidence   float64 `json:"confidence"`
-	Reason       string  `json:"reason"`
+	IssueID      string   `json:"issue_id"`
+	Label        string   `json:"label"`
+	Confidence   float64  `json:"confidence"`
+	Reason       string   `json:"reason"`
 	MatchedWords []string `json:"matched_words,omitempty"`
 }
 
@@ -135,12 +136,8 @@ func SuggestLabels(issues []model.Issue, config LabelSuggestionConfig) []Suggest
 			existingLabels[strings.ToLower(l)] = true
 		}
 
-		// Extract keywords
+		// Extract keywords (unique, in text order)
 		keywords := extractKeywords(issue.Title, issue.Description)
-		keywordSet := make(map[string]bool, len(keywords))
-		for _, k := range keywords {
-			keywordSet[k] = true
-		}
 
 		// Score potential labels
 		labelScores := make(map[string]float64)
@@ -148,7 +145,7 @@ func SuggestLabels(issues []model.Issue, config LabelSuggestionConfig) []Suggest
 
 		// Check builtin mappings
 		if config.BuiltinMappings {
-			for keyword := range keywordSet {
+			for _, keyword := range keywords {
 				if labels, ok := builtinLabelMappings[keyword]; ok {
 					for _, label := range labels {
 						if !existingLabels[label] && allLabels[label] {
@@ -162,9 +159,10 @@ func SuggestLabels(issues []model.Issue, config LabelSuggestionConfig) []Suggest
 
 		// Check learned mappings
 		if config.LearnFromExisting {
-			for keyword := range keywordSet {
+			for _, keyword := range keywords {
 				if labelCounts, ok := learnedMappings[keyword]; ok {
-					for label, count := range labelCounts {
+					for _, label := range sortedLabelKeys(labelCounts) {
+						count := labelCounts[label]
 						if !existingLabels[label] && allLabels[label] {
 							// Weight by frequency (more occurrences = more reliable)
 							bonus := 0.1 + (float64(count) * 0.05)
@@ -179,9 +177,22 @@ func SuggestLabels(issues []model.Issue, config LabelSuggestionConfig) []Suggest
 			}
 		}
 
-		// Convert scores to matches
+		// Convert scores to matches, best first so the per-issue cap keeps
+		// the strongest labels
+		candidates := make([]string, 0, len(labelScores))
+		for label := range labelScores {
+			candidates = append(candidates, label)
+		}
+		sort.Slice(candidates, func(i, j int) bool {
+			si, sj := labelScores[candidates[i]], labelScores[candidates[j]]
+			if si != sj {
+				return si > sj
+			}
+			return candidates[i] < candidates[j]
+		})
 		issueMatches := 0
-		for label, score := range labelScores {
+		for _, label := range candidates {
+			score := labelScores[label]
 			if score < config.MinConfidence {
 				continue
 			}
@@ -267,14 +278,30 @@ func uniqueStrings(s []string) []string {
 	return result
 }
 
-// sortLabelMatchesByConfidence sorts matches by confidence (highest first)
-// Uses sort.Slice for O(n log n) performance instead of bubble sort O(n²)
+// sortLabelMatchesByConfidence sorts matches by confidence (highest first),
+// then by issue ID and label so ties come out in a stable order
 func sortLabelMatchesByConfidence(matches []LabelMatch) {
 	sort.Slice(matches, func(i, j int) bool {
-		return matches[i].Confidence > matches[j].Confidence
+		if matches[i].Confidence != matches[j].Confidence {
+			return matches[i].Confidence > matches[j].Confidence
+		}
+		if matches[i].IssueID != matches[j].IssueID {
+			return matches[i].IssueID < matches[j].IssueID
+		}
+		return matches[i].Label < matches[j].Label
 	})
 }
 
+// sortedLabelKeys returns the labels of a label -> count map in order
+func sortedLabelKeys(counts map[string]int) []string {
+	labels := make([]string, 0, len(counts))
+	for label := range counts {
+		labels = append(labels, label)
+	}
+	sort.Strings(labels)
+	return labels
+}
+
 // LabelSuggestionDetector provides stateful label suggestion detection
 type LabelSuggestionDetector struct {
 	config LabelSuggestionConfig
@@ -291,3 +318,287 @@ func NewLabelSuggestionDetector(config LabelSuggestionConfig) *LabelSuggestionDe
 func (d *LabelSuggestionDetector) Detect(issues []model.Issue) []Suggestion {
 	return SuggestLabels(issues, d.config)
 }
+
+// LabelClassifierConfig configures nearest-neighbor label prediction
+type LabelClassifierConfig struct {
+	// K is the number of most similar labeled issues that vote
+	// Default: 5
+	K int
+
+	// MinSimilarity is the TF-IDF cosine similarity a neighbor needs to vote
+	// Default: 0.1
+	MinSimilarity float64
+
+	// MinConfidence is the minimum confidence to suggest a label
+	// Default: 0.4
+	MinConfidence float64
+
+	// MaxLabelsPerIssue limits suggestions per issue
+	// Default: 3
+	MaxLabelsPerIssue int
+}
+
+// DefaultLabelClassifierConfig returns sensible defaults
+func DefaultLabelClassifierConfig() LabelClassifierConfig {
+	return LabelClassifierConfig{
+		K:                 5,
+		MinSimilarity:     0.1,
+		MinConfidence:     0.4,
+		MaxLabelsPerIssue: 3,
+	}
+}
+
+// LabelPrediction is one label suggested for an issue
+type LabelPrediction struct {
+	Label      string   `json:"label"`
+	Confidence float64  `json:"confidence"`
+	Neighbors  []string `json:"neighbors"` // Similar labeled issues that carry the label
+	Command    string   `json:"command"`
+}
+
+// LabelClassifier predicts labels from the most similar labeled issues,
+// comparing TF-IDF vectors of title (weighted double) and description.
+type LabelClassifier struct {
+	idf  map[string]float64
+	docs []labeledDoc
+}
+
+type labeledDoc struct {
+	id     string
+	labels []string
+	vec    map[string]float64
+}
+
+// TrainLabelClassifier builds a classifier from the labeled issues. Term
+// weights are learned from every issue, labeled or not.
+func TrainLabelClassifier(issues []model.Issue) *LabelClassifier {
+	df := make(map[string]int)
+	total := 0
+	counts := make([]map[string]int, len(issues))
+	for i := range issues {
+		if issues[i].Status.IsTombstone() {
+			continue
+		}
+		total++
+		counts[i] = termCounts(issues[i].Title, issues[i].Description)
+		for term := range counts[i] {
+			df[term]++
+		}
+	}
+
+	c := &LabelClassifier{idf: make(map[string]float64, len(df))}
+	for term, n := range df {
+		c.idf[term] = math.Log(float64(total+1)/float64(n+1)) + 1
+	}
+	for i := range issues {
+		if counts[i] == nil || len(issues[i].Labels) == 0 {
+			continue
+		}
+		labels := make([]string, 0, len(issues[i].Labels))
+		for _, l := range issues[i].Labels {
+			labels = append(labels, strings.ToLower(l))
+		}
+		c.docs = append(c.docs, labeledDoc{
+			id:     issues[i].ID,
+			labels: uniqueStrings(labels),
+			vec:    c.vectorize(counts[i]),
+		})
+	}
+	return c
+}
+
+// TrainingSize returns the number of labeled issues the classifier learned from
+func (c *LabelClassifier) TrainingSize() int {
+	return len(c.docs)
+}
+
+// Predict suggests labels for issue. Each of the K nearest labeled issues
+// votes for its labels with its similarity; a label's confidence is its share
+// of the vote, scaled down when even the nearest neighbor is a weak match
+// (below 0.5 similarity). Labels the issue already has are skipped.
+func (c *LabelClassifier) Predict(issue model.Issue, config LabelClassifierConfig) []LabelPrediction {
+	if c == nil || len(c.docs) == 0 {
+		return nil
+	}
+	if config.K <= 0 {
+		config.K = 5
+	}
+	if config.MaxLabelsPerIssue <= 0 {
+		config.MaxLabelsPerIssue = 3
+	}
+
+	vec := c.vectorize(termCounts(issue.Title, issue.Description))
+	if len(vec) == 0 {
+		return nil
+	}
+	type neighbor struct {
+		doc *labeledDoc
+		sim float64
+	}
+	var neighbors []neighbor
+	for i := range c.docs {
+		d := &c.docs[i]
+		if d.id == issue.ID {
+			continue
+		}
+		if sim := sparseDot(vec, d.vec); sim >= config.MinSimilarity && sim > 0 {
+			neighbors = append(neighbors, neighbor{d, sim})
+		}
+	}
+	if len(neighbors) == 0 {
+		return nil
+	}
+	sort.Slice(neighbors, func(i, j int) bool {
+		if neighbors[i].sim != neighbors[j].sim {
+			return neighbors[i].sim > neighbors[j].sim
+		}
+		return neighbors[i].doc.id < neighbors[j].doc.id
+	})
+	if len(neighbors) > config.K {
+		neighbors = neighbors[:config.K]
+	}
+
+	has := make(map[string]bool, len(issue.Labels))
+	for _, l := range issue.Labels {
+		has[strings.ToLower(l)] = true
+	}
+	votes := make(map[string]float64)
+	voters := make(map[string][]string)
+	totalSim := 0.0
+	for _, n := range neighbors {
+		totalSim += n.sim
+		for _, l := range n.doc.labels {
+			votes[l] += n.sim
+			voters[l] = append(voters[l], n.doc.id)
+		}
+	}
+	strength := math.Min(1, neighbors[0].sim*2)
+
+	var predictions []LabelPrediction
+	for label, v := range votes {
+		if has[label] {
+			continue
+		}
+		confidence := v / totalSim * strength
+		if confidence < config.MinConfidence {
+			continue
+		}
+		predictions = append(predictions, LabelPrediction{
+			Label:      label,
+			Confidence: round2(confidence),
+			Neighbors:  truncateStringSlice(voters[label], 3),
+			Command:    fmt.Sprintf("bd update %s --add-label=%s", issue.ID, label),
+		})
+	}
+	sort.Slice(predictions, func(i, j int) bool {
+		if predictions[i].Confidence != predictions[j].Confidence {
+			return predictions[i].Confidence > predictions[j].Confidence
+		}
+		return predictions[i].Label < predictions[j].Label
+	})
+	if len(predictions) > config.MaxLabelsPerIssue {
+		predictions = predictions[:config.MaxLabelsPerIssue]
+	}
+	return predictions
+}
+
+func (c *LabelClassifier) vectorize(counts map[string]int) map[string]float64 {
+	vec := make(map[string]float64, len(counts))
+	norm := 0.0
+	for term, n := range counts {
+		idf, ok := c.idf[term]
+		if !ok {
+			continue // Unseen terms cannot match any labeled issue
+		}
+		w := float64(n) * idf
+		vec[term] = w
+		norm += w * w
+	}
+	if norm == 0 {
+		return nil
+	}
+	norm = math.Sqrt(norm)
+	for term := range vec {
+		vec[term] /= norm
+	}
+	return vec
+}
+
+// termCounts counts keywords in title (twice, since titles are denser) and
+// description, with the same filtering as extractKeywords
+func termCounts(title, description string) map[string]int {
+	counts := make(map[string]int)
+	add := func(text string, weight int) {
+		text = nonWordRegex.ReplaceAllString(strings.ToLower(text), " ")
+		for _, word := range strings.Fields(text) {
+			if len(word) >= 3 && !stopWords[word] {
+				counts[word] += weight
+			}
+		}
+	}
+	add(title, 2)
+	add(description, 1)
+	return counts
+}
+
+func sparseDot(a, b map[string]float64) float64 {
+	if len(b) < len(a) {
+		a, b = b, a
+	}
+	dot := 0.0
+	for term, w := range a {
+		dot += w * b[term]
+	}
+	return dot
+}
+
+// UnlabeledIssueSuggestion holds label predictions for one unlabeled issue
+type UnlabeledIssueSuggestion struct {
+	IssueID     string            `json:"issue_id"`
+	Title       string            `json:"title"`
+	Suggestions []LabelPrediction `json:"suggestions"`
+}
+
+// UnlabeledLabelReport is the result of classifying unlabeled open issues
+// (--robot-suggest-labels)
+type UnlabeledLabelReport struct {
+	LabeledCount   int                        `json:"labeled_count"`   // Training examples
+	UnlabeledCount int                        `json:"unlabeled_count"` // Open issues without labels
+	SuggestedCount int                        `json:"suggested_count"` // Unlabeled issues with at least one suggestion
+	Issues         []UnlabeledIssueSuggestion `json:"issues"`          // Most confident first
+}
+
+// SuggestLabelsForUnlabeled trains a classifier on the labeled issues and
+// predicts labels for every open issue that has none.
+func SuggestLabelsForUnlabeled(issues []model.Issue, config LabelClassifierConfig) UnlabeledLabelReport {
+	classifier := TrainLabelClassifier(issues)
+	report := UnlabeledLabelReport{
+		LabeledCount: classifier.TrainingSize(),
+		Issues:       []UnlabeledIssueSuggestion{},
+	}
+	for _, issue := range issues {
+		if len(issue.Labels) > 0 || issue.Status.IsClosed() || issue.Status.IsTombstone() {
+			continue
+		}
+		report.UnlabeledCount++
+		predictions := classifier.Predict(issue, config)
+		if len(predictions) == 0 {
+			continue
+		}
+		report.Issues = append(report.Issues, UnlabeledIssueSuggestion{
+			IssueID:     issue.ID,
+			Title:       issue.Title,
+			Suggestions: predictions,
+		})
+	}
+	report.SuggestedCount = len(report.Issues)
+
+	sort.Slice(report.Issues, func(i, j int) bool {
+		a, b := report.Issues[i].Suggestions[0].Confidence, report.Issues[j].Suggestions[0].Confidence
+		if a != b {
+			return a > b
+		}
+		return report.Issues[i].IssueID < report.Issues[j].IssueID
+	})
+	return report
+}
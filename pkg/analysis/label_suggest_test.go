@@ -1,6 +1,7 @@
 package analysis
 
 import (
+	"fmt"
 	"sort"
 	"strings"
 	"testing"
@@ -317,6 +318,40 @@ func TestSuggestLabels_MaxSuggestionsPerIssue(t *testing.T) {
 	}
 }
 
+func TestSuggestLabels_StableOrder(t *testing.T) {
+	// Ties between labels and between issues must not depend on map order,
+	// including which labels survive the per-issue cap
+	issues := []model.Issue{
+		{ID: "MULTI-2", Title: "Fix bug in api auth login database cache", Status: model.StatusOpen},
+		{ID: "MULTI-1", Title: "Fix bug in api auth login database cache", Status: model.StatusOpen},
+		{ID: "L1", Title: "x", Status: model.StatusOpen, Labels: []string{"bug"}},
+		{ID: "L2", Title: "x", Status: model.StatusOpen, Labels: []string{"api"}},
+		{ID: "L3", Title: "x", Status: model.StatusOpen, Labels: []string{"auth"}},
+		{ID: "L4", Title: "x", Status: model.StatusOpen, Labels: []string{"database"}},
+		{ID: "L5", Title: "x", Status: model.StatusOpen, Labels: []string{"cache"}},
+	}
+	config := DefaultLabelSuggestionConfig()
+	config.MinConfidence = 0.1
+	config.MaxSuggestionsPerIssue = 2
+
+	render := func() string {
+		var sb strings.Builder
+		for _, sug := range SuggestLabels(issues, config) {
+			fmt.Fprintf(&sb, "%s %s %s %v\n", sug.TargetBead, sug.Summary, sug.Reason, sug.Confidence)
+		}
+		return sb.String()
+	}
+	want := render()
+	if !strings.HasPrefix(want, "MULTI-1 ") {
+		t.Errorf("ties should order by issue ID, got:\n%s", want)
+	}
+	for i := 0; i < 20; i++ {
+		if got := render(); got != want {
+			t.Fatalf("run %d differs:\n%s\nwant:\n%s", i, got, want)
+		}
+	}
+}
+
 func TestSuggestLabels_MaxTotalSuggestions(t *testing.T) {
 	// Create many issues that could get suggestions
 	var issues []model.Issue
@@ -995,3 +1030,82 @@ func BenchmarkSortLabelMatchesByConfidence(b *testing.B) {
 
 // Ensure sort is imported and used
 var _ = sort.Slice
+
+// ============================================================================
+// LabelClassifier Tests
+// ============================================================================
+
+func labelClassifierTestIssues() []model.Issue {
+	return []model.Issue{
+		{ID: "db-1", Title: "Postgres migration fails on upgrade", Status: model.StatusClosed, Labels: []string{"database"}},
+		{ID: "db-2", Title: "Add index to postgres orders table", Status: model.StatusOpen, Labels: []string{"Database", "performance"}},
+		{ID: "db-3", Title: "Postgres connection pool exhausted", Status: model.StatusOpen, Labels: []string{"database"}},
+		{ID: "ui-1", Title: "Dark mode toggle in settings page", Status: model.StatusOpen, Labels: []string{"frontend"}},
+		{ID: "ui-2", Title: "Settings page layout broken on mobile", Status: model.StatusOpen, Labels: []string{"frontend"}},
+		{ID: "new-1", Title: "Postgres migration for orders table", Status: model.StatusOpen},
+		{ID: "new-2", Title: "Settings page toggle misaligned", Status: model.StatusInProgress},
+		{ID: "new-3", Title: "Quarterly planning offsite", Status: model.StatusOpen},
+		{ID: "new-4", Title: "Postgres upgrade", Status: model.StatusClosed},
+	}
+}
+
+func TestLabelClassifier_Predict(t *testing.T) {
+	issues := labelClassifierTestIssues()
+	c := TrainLabelClassifier(issues)
+	if c.TrainingSize() != 5 {
+		t.Fatalf("training size = %d, want 5", c.TrainingSize())
+	}
+	config := DefaultLabelClassifierConfig()
+
+	db := c.Predict(issues[5], config)
+	if len(db) == 0 || db[0].Label != "database" {
+		t.Fatalf("expected database first for a postgres migration, got %+v", db)
+	}
+	if db[0].Command != "bd update new-1 --add-label=database" || len(db[0].Neighbors) == 0 {
+		t.Errorf("prediction = %+v", db[0])
+	}
+	for _, p := range db {
+		if p.Label == "frontend" {
+			t.Errorf("frontend should not be suggested for a postgres issue: %+v", db)
+		}
+	}
+
+	ui := c.Predict(issues[6], config)
+	if len(ui) != 1 || ui[0].Label != "frontend" {
+		t.Errorf("expected frontend for a settings page issue, got %+v", ui)
+	}
+
+	if got := c.Predict(issues[7], config); len(got) != 0 {
+		t.Errorf("unrelated issue should get no suggestions, got %+v", got)
+	}
+
+	// Labels already present are not suggested again
+	labeled := issues[5]
+	labeled.Labels = []string{"DATABASE"}
+	for _, p := range c.Predict(labeled, config) {
+		if p.Label == "database" {
+			t.Errorf("existing label suggested again: %+v", p)
+		}
+	}
+}
+
+func TestSuggestLabelsForUnlabeled(t *testing.T) {
+	report := SuggestLabelsForUnlabeled(labelClassifierTestIssues(), DefaultLabelClassifierConfig())
+	if report.LabeledCount != 5 || report.UnlabeledCount != 3 || report.SuggestedCount != 2 {
+		t.Errorf("counts = %d labeled, %d unlabeled, %d suggested; want 5, 3, 2",
+			report.LabeledCount, report.UnlabeledCount, report.SuggestedCount)
+	}
+	ids := make([]string, len(report.Issues))
+	for i, s := range report.Issues {
+		ids[i] = s.IssueID
+	}
+	sort.Strings(ids)
+	if strings.Join(ids, ",") != "new-1,new-2" {
+		t.Errorf("issues = %v, want new-1 and new-2 (closed new-4 skipped)", ids)
+	}
+
+	empty := SuggestLabelsForUnlabeled([]model.Issue{{ID: "x", Title: "Lonely", Status: model.StatusOpen}}, DefaultLabelClassifierConfig())
+	if empty.LabeledCount != 0 || empty.UnlabeledCount != 1 || len(empty.Issues) != 0 {
+		t.Errorf("no training data should give no suggestions, got %+v", empty)
+	}
+}
@@ -0,0 +1,118 @@
+package analysis
+
+import (
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// DefaultLabelTrendDays is the window of the label dashboard's trend columns
+const DefaultLabelTrendDays = 30
+
+// LabelTrend is a label's daily history over a trailing window, oldest day
+// first, so its health reads as a direction rather than a single number
+type LabelTrend struct {
+	Label  string `json:"label"`
+	Closed []int  `json:"closed"` // Issues closed each day (velocity)
+	Open   []int  `json:"open"`   // Open issues at the end of each day
+}
+
+// LabelSnapshotFunc returns the issues as they stood at t, or false when
+// there is no record from then
+type LabelSnapshotFunc func(t time.Time) ([]model.Issue, bool)
+
+// ComputeLabelTrends returns per-label daily closed and open counts for the
+// days up to and including now's. Closures come from ClosedAt. Open counts
+// come from snapshotAt (e.g. the beads file in git at the end of each day)
+// where it has one; otherwise, or with a nil snapshotAt, they are rebuilt
+// from created and closed timestamps. The last day always uses issues.
+// Labels aggregate up the hierarchy, as in the rest of label health.
+func ComputeLabelTrends(issues []model.Issue, days int, now time.Time, snapshotAt LabelSnapshotFunc) map[string]*LabelTrend {
+	if days <= 0 {
+		days = DefaultLabelTrendDays
+	}
+	trends := make(map[string]*LabelTrend)
+	trend := func(label string) *LabelTrend {
+		t := trends[label]
+		if t == nil {
+			t = &LabelTrend{Label: label, Closed: make([]int, days), Open: make([]int, days)}
+			trends[label] = t
+		}
+		return t
+	}
+
+	y, mo, d := now.Date()
+	firstDay := time.Date(y, mo, d, 0, 0, 0, 0, now.Location()).AddDate(0, 0, -(days - 1))
+
+	// closedDay is the window day an issue closed on, or -1
+	closedDay := func(iss model.Issue) int {
+		if iss.ClosedAt == nil || iss.ClosedAt.Before(firstDay) || iss.ClosedAt.After(now) {
+			return -1
+		}
+		for day := days - 1; day > 0; day-- {
+			if !iss.ClosedAt.Before(firstDay.AddDate(0, 0, day)) {
+				return day
+			}
+		}
+		return 0
+	}
+	for _, iss := range issues {
+		day := closedDay(iss)
+		for _, label := range expandLabels(iss.Labels) {
+			if label == "" {
+				continue
+			}
+			t := trend(label)
+			if day >= 0 {
+				t.Closed[day]++
+			}
+		}
+	}
+
+	for day := 0; day < days; day++ {
+		end := firstDay.AddDate(0, 0, day+1)
+		if day == days-1 {
+			countOpen(trend, issues, day, notClosed)
+			continue
+		}
+		if snapshotAt != nil {
+			if snapshot, ok := snapshotAt(end); ok {
+				countOpen(trend, snapshot, day, notClosed)
+				continue
+			}
+		}
+		countOpen(trend, issues, day, func(iss model.Issue) bool { return openAt(iss, end) })
+	}
+	return trends
+}
+
+// countOpen adds the issues open per isOpen to each of their labels' day
+func countOpen(trend func(string) *LabelTrend, issues []model.Issue, day int, isOpen func(model.Issue) bool) {
+	for _, iss := range issues {
+		if !isOpen(iss) {
+			continue
+		}
+		for _, label := range expandLabels(iss.Labels) {
+			if label != "" {
+				trend(label).Open[day]++
+			}
+		}
+	}
+}
+
+// openAt reports whether an issue had been created and not yet closed at t.
+// Closed issues without a ClosedAt are taken to have been closed throughout.
+func openAt(iss model.Issue, t time.Time) bool {
+	if iss.CreatedAt.IsZero() || iss.CreatedAt.After(t) {
+		return false
+	}
+	if iss.ClosedAt != nil {
+		return iss.ClosedAt.After(t)
+	}
+	return notClosed(iss)
+}
+
+// notClosed reports whether an issue is still open as it stands
+func notClosed(iss model.Issue) bool {
+	return !iss.Status.IsClosed() && !iss.Status.IsTombstone()
+}
@@ -0,0 +1,48 @@
+package analysis
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestComputeLabelTrends(t *testing.T) {
+	now := time.Date(2026, 3, 10, 15, 0, 0, 0, time.UTC)
+	day := func(d int) time.Time { return time.Date(2026, 3, d, 12, 0, 0, 0, time.UTC) }
+	closedOn := func(d int) *time.Time { t := day(d); return &t }
+
+	issues := []model.Issue{
+		{ID: "A", Labels: []string{"backend/api"}, Status: model.StatusClosed, CreatedAt: day(6), ClosedAt: closedOn(8)},
+		{ID: "B", Labels: []string{"backend/db"}, Status: model.StatusOpen, CreatedAt: day(7)},
+		{ID: "C", Labels: []string{"ui"}, Status: model.StatusClosed, CreatedAt: day(1), ClosedAt: closedOn(10)},
+	}
+
+	trends := ComputeLabelTrends(issues, 5, now, nil) // Mar 6..10
+	backend := trends["backend"]
+	if backend == nil {
+		t.Fatal("trends should aggregate backend/api and backend/db under backend")
+	}
+	if want := []int{0, 0, 1, 0, 0}; !reflect.DeepEqual(backend.Closed, want) {
+		t.Errorf("backend closed = %v, want %v", backend.Closed, want)
+	}
+	if want := []int{1, 2, 1, 1, 1}; !reflect.DeepEqual(backend.Open, want) {
+		t.Errorf("backend open = %v, want %v", backend.Open, want)
+	}
+	if want := []int{1, 1, 1, 1, 0}; !reflect.DeepEqual(trends["ui"].Open, want) {
+		t.Errorf("ui open = %v, want %v", trends["ui"].Open, want)
+	}
+
+	// A recorded snapshot takes precedence over rebuilt history
+	snapshotAt := func(at time.Time) ([]model.Issue, bool) {
+		if !at.Equal(time.Date(2026, 3, 8, 0, 0, 0, 0, time.UTC)) { // End of Mar 7
+			return nil, false
+		}
+		return []model.Issue{{ID: "X", Labels: []string{"ui"}, Status: model.StatusOpen}, {ID: "Y", Labels: []string{"ui"}, Status: model.StatusBlocked}}, true
+	}
+	trends = ComputeLabelTrends(issues, 5, now, snapshotAt)
+	if want := []int{1, 2, 1, 1, 0}; !reflect.DeepEqual(trends["ui"].Open, want) {
+		t.Errorf("ui open with snapshot = %v, want %v", trends["ui"].Open, want)
+	}
+}
@@ -0,0 +1,149 @@
+package analysis
+
+import (
+	"slices"
+	"sort"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// LayerRoadmap organizes the open issues into topological layers, a
+// phased roadmap (--robot-layers): layer 1 waits on nothing open, and every
+// issue in layer N waits only on issues in layers before N. Closed blockers
+// count as done.
+type LayerRoadmap struct {
+	Agents            int            `json:"agents"` // Parallel workers assumed for duration_days
+	Layers            []RoadmapLayer `json:"layers"`
+	TotalIssues       int            `json:"total_issues"`
+	TotalWorkDays     float64        `json:"total_work_days"`
+	TotalDurationDays float64        `json:"total_duration_days"` // Layers run one after another
+	// Unlayered issues sit in a dependency cycle or wait on one, so no
+	// layer can hold them until the cycle is broken
+	Unlayered []string `json:"unlayered"`
+}
+
+// RoadmapLayer is one phase of the roadmap
+type RoadmapLayer struct {
+	Layer    int     `json:"layer"` // From 1
+	Count    int     `json:"count"`
+	WorkDays float64 `json:"work_days"` // Sum of the issues' estimates
+	// DurationDays is how long the layer takes with Agents workers: its
+	// work shared out, but never less than its longest issue
+	DurationDays float64        `json:"duration_days"`
+	StartDay     float64        `json:"start_day"` // Days from now, layers back to back
+	EndDay       float64        `json:"end_day"`
+	Issues       []RoadmapIssue `json:"issues"` // By priority, then ID
+}
+
+// RoadmapIssue is one issue in a layer
+type RoadmapIssue struct {
+	ID            string   `json:"id"`
+	Title         string   `json:"title"`
+	Status        string   `json:"status"`
+	Priority      int      `json:"priority"`
+	EstimatedDays float64  `json:"estimated_days"`
+	Estimated     bool     `json:"estimated"`          // False when the median estimate stands in
+	WaitsOn       []string `json:"waits_on,omitempty"` // Open blockers, all in earlier layers
+}
+
+// Layers builds the roadmap of the open issues for the given number of
+// parallel agents (at least 1).
+func (a *Analyzer) Layers(agents int) *LayerRoadmap {
+	agents = max(agents, 1)
+	median := a.computeMedianEstimatedMinutes()
+
+	// Kahn's algorithm over open issues and their open blockers; an issue's
+	// layer is one past its deepest blocker's
+	waitsOn := make(map[string][]string)
+	pending := make(map[string]int)
+	var open []string
+	for _, id := range a.nodeToID {
+		if a.issueMap[id].Status == model.StatusClosed {
+			continue
+		}
+		open = append(open, id)
+		for _, b := range a.GetBlockers(id) {
+			if a.issueMap[b].Status != model.StatusClosed && !slices.Contains(waitsOn[id], b) {
+				waitsOn[id] = append(waitsOn[id], b)
+			}
+		}
+		pending[id] = len(waitsOn[id])
+	}
+	sort.Strings(open)
+	dependents := make(map[string][]string)
+	for _, id := range open {
+		for _, b := range waitsOn[id] {
+			dependents[b] = append(dependents[b], id)
+		}
+	}
+
+	layer := make(map[string]int, len(open))
+	var queue []string
+	for _, id := range open {
+		if pending[id] == 0 {
+			layer[id] = 1
+			queue = append(queue, id)
+		}
+	}
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		for _, d := range dependents[id] {
+			layer[d] = max(layer[d], layer[id]+1)
+			if pending[d]--; pending[d] == 0 {
+				queue = append(queue, d)
+			}
+		}
+	}
+
+	roadmap := &LayerRoadmap{Agents: agents, Layers: []RoadmapLayer{}, Unlayered: []string{}}
+	for _, id := range open {
+		n, ok := layer[id]
+		if !ok || pending[id] > 0 {
+			roadmap.Unlayered = append(roadmap.Unlayered, id)
+			continue
+		}
+		for len(roadmap.Layers) < n {
+			roadmap.Layers = append(roadmap.Layers, RoadmapLayer{Layer: len(roadmap.Layers) + 1})
+		}
+		issue := a.issueMap[id]
+		item := RoadmapIssue{
+			ID:       id,
+			Title:    issue.Title,
+			Status:   string(issue.Status),
+			Priority: issue.Priority,
+			WaitsOn:  waitsOn[id],
+		}
+		sort.Strings(item.WaitsOn)
+		item.EstimatedDays, item.Estimated = treeIssueDays(issue, median)
+		roadmap.Layers[n-1].Issues = append(roadmap.Layers[n-1].Issues, item)
+	}
+
+	day := 0.0
+	for i := range roadmap.Layers {
+		l := &roadmap.Layers[i]
+		sort.Slice(l.Issues, func(x, y int) bool {
+			if l.Issues[x].Priority != l.Issues[y].Priority {
+				return l.Issues[x].Priority < l.Issues[y].Priority
+			}
+			return l.Issues[x].ID < l.Issues[y].ID
+		})
+		longest := 0.0
+		for _, item := range l.Issues {
+			l.WorkDays += item.EstimatedDays
+			longest = max(longest, item.EstimatedDays)
+		}
+		l.Count = len(l.Issues)
+		l.DurationDays = round2(max(longest, l.WorkDays/float64(agents)))
+		l.WorkDays = round2(l.WorkDays)
+		l.StartDay = round2(day)
+		day += l.DurationDays
+		l.EndDay = round2(day)
+
+		roadmap.TotalIssues += l.Count
+		roadmap.TotalWorkDays += l.WorkDays
+	}
+	roadmap.TotalWorkDays = round2(roadmap.TotalWorkDays)
+	roadmap.TotalDurationDays = round2(day)
+	return roadmap
+}
@@ -0,0 +1,62 @@
+package analysis
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestLayers(t *testing.T) {
+	blocks := func(ids ...string) []*model.Dependency {
+		deps := make([]*model.Dependency, len(ids))
+		for i, id := range ids {
+			deps[i] = &model.Dependency{DependsOnID: id, Type: model.DepBlocks}
+		}
+		return deps
+	}
+	days := func(d int) *int { m := d * 480; return &m }
+	issues := []model.Issue{
+		{ID: "done", Status: model.StatusClosed, EstimatedMinutes: days(1)},
+		{ID: "A", Status: model.StatusOpen, Priority: 2, Dependencies: blocks("done"), EstimatedMinutes: days(2)},
+		{ID: "B", Status: model.StatusOpen, Priority: 1, EstimatedMinutes: days(1)},
+		{ID: "C", Status: model.StatusOpen, Dependencies: blocks("A"), EstimatedMinutes: days(3)},
+		{ID: "D", Status: model.StatusOpen, Dependencies: blocks("A", "C", "B"), EstimatedMinutes: days(1)},
+		{ID: "X", Status: model.StatusOpen, Dependencies: blocks("Y"), EstimatedMinutes: days(1)},
+		{ID: "Y", Status: model.StatusOpen, Dependencies: blocks("X"), EstimatedMinutes: days(1)},
+		{ID: "Z", Status: model.StatusOpen, Dependencies: blocks("X"), EstimatedMinutes: days(1)},
+	}
+	roadmap := NewAnalyzer(issues).Layers(2)
+
+	var got [][]string
+	for _, l := range roadmap.Layers {
+		var ids []string
+		for _, item := range l.Issues {
+			ids = append(ids, item.ID)
+		}
+		got = append(got, ids)
+	}
+	// The closed blocker counts as done; D sits after its deepest blocker C
+	want := [][]string{{"B", "A"}, {"C"}, {"D"}}
+	if !slices.EqualFunc(got, want, slices.Equal[[]string]) {
+		t.Fatalf("layers = %v, want %v", got, want)
+	}
+	if !slices.Equal(roadmap.Unlayered, []string{"X", "Y", "Z"}) {
+		t.Errorf("unlayered = %v, want the cycle X/Y and Z behind it", roadmap.Unlayered)
+	}
+
+	first := roadmap.Layers[0]
+	if first.Count != 2 || first.WorkDays != 3 || first.DurationDays != 2 {
+		t.Errorf("layer 1 = %+v, want 3 work days taking 2 (its longest issue) with 2 agents", first)
+	}
+	if last := roadmap.Layers[2]; last.StartDay != 5 || last.EndDay != 6 || !slices.Equal(last.Issues[0].WaitsOn, []string{"A", "B", "C"}) {
+		t.Errorf("layer 3 = %+v, want days 5 to 6 waiting on A, B and C", last)
+	}
+	if roadmap.TotalIssues != 4 || roadmap.TotalWorkDays != 7 || roadmap.TotalDurationDays != 6 {
+		t.Errorf("totals = %d issues, %v work, %v duration", roadmap.TotalIssues, roadmap.TotalWorkDays, roadmap.TotalDurationDays)
+	}
+
+	if serial := NewAnalyzer(issues).Layers(0); serial.Agents != 1 || serial.Layers[0].DurationDays != 3 {
+		t.Errorf("one agent should do layer 1 in 3 days, got %+v", serial.Layers[0])
+	}
+}
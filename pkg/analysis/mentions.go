@@ -0,0 +1,142 @@
+package analysis
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// mentionCandidateRegex matches tokens shaped like issue IDs: a prefix, one or
+// more hyphenated parts, and optional .N child suffixes (bv-12, api-a1b2.3).
+// Candidates only count as mentions when they name a known issue.
+var mentionCandidateRegex = regexp.MustCompile(`\b[A-Za-z][A-Za-z0-9_]*(?:-[A-Za-z0-9_]+)+(?:\.[0-9]+)*\b`)
+
+// mentionProtectedRegex matches markdown that must not be rewritten into links:
+// fenced code, inline code, existing links, autolinks, and bare URLs.
+var mentionProtectedRegex = regexp.MustCompile("(?s)```.*?```|`[^`\n]*`|!?\\[[^\\]\n]*\\]\\([^)\n]*\\)|<[a-z]+:[^>\n]*>|https?://[^\\s)>]+")
+
+// Mention is one reference to a known issue ID inside a piece of text.
+type Mention struct {
+	ID    string
+	Start int // Byte offset of the ID in the text
+	End   int
+}
+
+// FindMentions returns the references to known issue IDs in text, in order.
+// A candidate that isn't known is retried without its trailing hyphenated
+// parts, so "bv-12-followup" still mentions bv-12.
+func FindMentions(text string, known func(id string) bool) []Mention {
+	var mentions []Mention
+	for _, loc := range mentionCandidateRegex.FindAllStringIndex(text, -1) {
+		// A token inside a path or address (docs/bv-12, me@bv-12) is not a mention
+		if loc[0] > 0 && strings.ContainsAny(text[loc[0]-1:loc[0]], "/@") {
+			continue
+		}
+		candidate := text[loc[0]:loc[1]]
+		for {
+			if known(candidate) {
+				mentions = append(mentions, Mention{ID: candidate, Start: loc[0], End: loc[0] + len(candidate)})
+				break
+			}
+			cut := strings.LastIndexByte(candidate, '-')
+			if cut <= 0 || !strings.Contains(candidate[:cut], "-") {
+				break
+			}
+			candidate = candidate[:cut]
+		}
+	}
+	return mentions
+}
+
+// LinkMentions rewrites references to known issue IDs in markdown into links
+// to href(id), leaving code, existing links and URLs untouched.
+func LinkMentions(markdown string, known func(id string) bool, href func(id string) string) string {
+	var sb strings.Builder
+	link := func(plain string) {
+		last := 0
+		for _, m := range FindMentions(plain, known) {
+			sb.WriteString(plain[last:m.Start])
+			sb.WriteString("[" + m.ID + "](" + href(m.ID) + ")")
+			last = m.End
+		}
+		sb.WriteString(plain[last:])
+	}
+	last := 0
+	for _, loc := range mentionProtectedRegex.FindAllStringIndex(markdown, -1) {
+		link(markdown[last:loc[0]])
+		sb.WriteString(markdown[loc[0]:loc[1]])
+		last = loc[1]
+	}
+	link(markdown[last:])
+	return sb.String()
+}
+
+// MentionText returns the free text of an issue that may mention other
+// issues: description, design, acceptance criteria, notes and comments.
+func MentionText(issue *model.Issue) []string {
+	texts := []string{issue.Description, issue.Design, issue.AcceptanceCriteria, issue.Notes}
+	for _, c := range issue.Comments {
+		if c != nil {
+			texts = append(texts, c.Text)
+		}
+	}
+	return texts
+}
+
+// MentionIndex records which issues mention which others by ID in their free
+// text, in both directions. Self-mentions are ignored.
+type MentionIndex struct {
+	mentions  map[string][]string // issueID -> IDs it mentions, in order of first mention
+	backlinks map[string][]string // issueID -> IDs of issues mentioning it, sorted
+}
+
+// BuildMentionIndex scans every issue's free text for references to the
+// others.
+func BuildMentionIndex(issues []model.Issue) *MentionIndex {
+	ids := make(map[string]bool, len(issues))
+	for i := range issues {
+		ids[issues[i].ID] = true
+	}
+	known := func(id string) bool { return ids[id] }
+
+	idx := &MentionIndex{
+		mentions:  make(map[string][]string),
+		backlinks: make(map[string][]string),
+	}
+	for i := range issues {
+		from := issues[i].ID
+		seen := map[string]bool{from: true}
+		for _, text := range MentionText(&issues[i]) {
+			for _, m := range FindMentions(text, known) {
+				if seen[m.ID] {
+					continue
+				}
+				seen[m.ID] = true
+				idx.mentions[from] = append(idx.mentions[from], m.ID)
+				idx.backlinks[m.ID] = append(idx.backlinks[m.ID], from)
+			}
+		}
+	}
+	for _, from := range idx.backlinks {
+		sort.Strings(from)
+	}
+	return idx
+}
+
+// Mentions returns the IDs an issue mentions, in order of first mention.
+func (idx *MentionIndex) Mentions(id string) []string {
+	if idx == nil {
+		return nil
+	}
+	return idx.mentions[id]
+}
+
+// Backlinks returns the IDs of the issues that mention an issue, sorted.
+func (idx *MentionIndex) Backlinks(id string) []string {
+	if idx == nil {
+		return nil
+	}
+	return idx.backlinks[id]
+}
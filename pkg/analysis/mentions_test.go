@@ -0,0 +1,58 @@
+package analysis
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestFindMentions(t *testing.T) {
+	known := func(id string) bool { return id == "bv-12" || id == "bv-a1.2" || id == "api-v2-3" }
+	text := "Fixes bv-12, see bv-a1.2. Related: api-v2-3 and bv-12-followup; not bv-99 or docs/bv-12"
+
+	var got []string
+	for _, m := range FindMentions(text, known) {
+		if text[m.Start:m.End] != m.ID {
+			t.Errorf("offsets of %s point at %q", m.ID, text[m.Start:m.End])
+		}
+		got = append(got, m.ID)
+	}
+	want := []string{"bv-12", "bv-a1.2", "api-v2-3", "bv-12"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("mentions = %v, want %v", got, want)
+	}
+}
+
+func TestLinkMentions(t *testing.T) {
+	known := func(id string) bool { return id == "bv-1" || id == "bv-2" }
+	href := func(id string) string { return "#" + id }
+	in := "See bv-1 and `bv-2`, [bv-2](https://x/bv-2), https://x/bv-1\n```\nbv-1\n```\nthen bv-2."
+	want := "See [bv-1](#bv-1) and `bv-2`, [bv-2](https://x/bv-2), https://x/bv-1\n```\nbv-1\n```\nthen [bv-2](#bv-2)."
+	if got := LinkMentions(in, known, href); got != want {
+		t.Errorf("LinkMentions =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestMentionIndex(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "bv-1", Description: "Depends on the work in bv-3; bv-1 itself is fine"},
+		{ID: "bv-2", Notes: "dup of bv-3", Comments: []*model.Comment{{Text: "also bv-1"}, nil}},
+		{ID: "bv-3"},
+	}
+	idx := BuildMentionIndex(issues)
+
+	if got := idx.Mentions("bv-2"); !reflect.DeepEqual(got, []string{"bv-3", "bv-1"}) {
+		t.Errorf("Mentions(bv-2) = %v", got)
+	}
+	if got := idx.Backlinks("bv-3"); !reflect.DeepEqual(got, []string{"bv-1", "bv-2"}) {
+		t.Errorf("Backlinks(bv-3) = %v", got)
+	}
+	if got := idx.Backlinks("bv-1"); !reflect.DeepEqual(got, []string{"bv-2"}) {
+		t.Errorf("Backlinks(bv-1) = %v; self-mentions should be ignored", got)
+	}
+	var nilIdx *MentionIndex
+	if nilIdx.Backlinks("bv-1") != nil {
+		t.Error("nil index should have no backlinks")
+	}
+}
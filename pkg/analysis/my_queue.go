@@ -0,0 +1,237 @@
+package analysis
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// QueueSection is the part of a personal queue an item belongs to
+type QueueSection string
+
+const (
+	QueueInProgress QueueSection = "in_progress" // Assigned to you and being worked on
+	QueueNext       QueueSection = "next"        // Actionable work that is yours or in your areas
+	QueueUpcoming   QueueSection = "upcoming"    // Blocked work that frees up once a ready blocker closes
+)
+
+// MyQueue is a personal daily worklist for one assignee (--robot-my-queue)
+type MyQueue struct {
+	Assignee        string        `json:"assignee"`
+	Labels          []string      `json:"labels"` // Labels from the assignee's history, most used first
+	InProgressCount int           `json:"in_progress_count"`
+	NextCount       int           `json:"next_count"`
+	UpcomingCount   int           `json:"upcoming_count"`
+	Items           []MyQueueItem `json:"items"`
+}
+
+// MyQueueItem is one entry in a personal queue
+type MyQueueItem struct {
+	ID           string       `json:"id"`
+	Title        string       `json:"title"`
+	Status       string       `json:"status"`
+	Priority     int          `json:"priority"`
+	Assignee     string       `json:"assignee,omitempty"`
+	Labels       []string     `json:"labels,omitempty"`
+	Section      QueueSection `json:"section"`
+	Score        float64      `json:"score"` // Triage score
+	Reason       string       `json:"reason"`
+	Unblocks     int          `json:"unblocks"`
+	BlockedBy    []string     `json:"blocked_by,omitempty"`
+	ClaimCommand string       `json:"claim_command,omitempty"`
+}
+
+// MyQueueOptions limits the queue's sections. In-progress work is never cut.
+type MyQueueOptions struct {
+	NextN     int // Actionable items to suggest (default 5)
+	UpcomingN int // Upcoming unblocks to list (default 5)
+}
+
+// CurrentActor returns the name bd records for the local user
+// ($BD_ACTOR, then $USER or $USERNAME), or "" if none is set.
+func CurrentActor() string {
+	for _, env := range []string{"BD_ACTOR", "USER", "USERNAME"} {
+		if v := strings.TrimSpace(os.Getenv(env)); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// ResolveAssignee expands "me" to the current actor and trims other names
+func ResolveAssignee(name string) string {
+	name = strings.TrimSpace(name)
+	if strings.EqualFold(name, "me") {
+		return CurrentActor()
+	}
+	return name
+}
+
+// ComputeMyQueue builds a personal worklist: the assignee's in-progress
+// issues, then the best actionable issues that are theirs or carry labels
+// from their history, then blocked issues that free up once a workable
+// blocker closes. Each section is ordered by triage score.
+func ComputeMyQueue(analyzer *Analyzer, stats *GraphStats, issues []model.Issue, assignee string, opts MyQueueOptions, now time.Time) MyQueue {
+	if opts.NextN <= 0 {
+		opts.NextN = 5
+	}
+	if opts.UpcomingN <= 0 {
+		opts.UpcomingN = 5
+	}
+
+	queue := MyQueue{Assignee: assignee, Labels: []string{}, Items: []MyQueueItem{}}
+	if assignee == "" || len(issues) == 0 {
+		return queue
+	}
+
+	mine := func(issue *model.Issue) bool {
+		return strings.EqualFold(issue.Assignee, assignee)
+	}
+
+	// Labels from everything the assignee has touched, closed work included
+	labelUse := make(map[string]int)
+	for i := range issues {
+		if mine(&issues[i]) {
+			for _, label := range issues[i].Labels {
+				labelUse[label]++
+			}
+		}
+	}
+	for label := range labelUse {
+		queue.Labels = append(queue.Labels, label)
+	}
+	sort.Slice(queue.Labels, func(i, j int) bool {
+		a, b := queue.Labels[i], queue.Labels[j]
+		if labelUse[a] != labelUse[b] {
+			return labelUse[a] > labelUse[b]
+		}
+		return a < b
+	})
+	matchingLabels := func(issue *model.Issue) []string {
+		var matched []string
+		for _, label := range issue.Labels {
+			if labelUse[label] > 0 {
+				matched = append(matched, label)
+			}
+		}
+		return matched
+	}
+
+	unblocksMap := buildUnblocksMap(analyzer, issues)
+	scores := make(map[string]float64)
+	for _, ts := range computeTriageScoresFromImpact(analyzer.ComputeImpactScoresFromStats(stats, now), unblocksMap, analyzer, DefaultTriageScoringOptions()) {
+		scores[ts.IssueID] = ts.TriageScore
+	}
+
+	actionable := make(map[string]bool)
+	for _, issue := range analyzer.GetActionableIssues() {
+		actionable[issue.ID] = true
+	}
+
+	newItem := func(issue *model.Issue, section QueueSection, reason string) MyQueueItem {
+		return MyQueueItem{
+			ID:        issue.ID,
+			Title:     issue.Title,
+			Status:    string(issue.Status),
+			Priority:  issue.Priority,
+			Assignee:  issue.Assignee,
+			Labels:    issue.Labels,
+			Section:   section,
+			Score:     scores[issue.ID],
+			Reason:    reason,
+			Unblocks:  len(unblocksMap[issue.ID]),
+			BlockedBy: analyzer.GetOpenBlockers(issue.ID),
+		}
+	}
+
+	var inProgress, next, upcoming []MyQueueItem
+	for i := range issues {
+		issue := &issues[i]
+		if issue.Status.IsClosed() || issue.Status.IsTombstone() {
+			continue
+		}
+		isMine := mine(issue)
+		if !isMine && issue.Assignee != "" {
+			continue // Someone else's work
+		}
+		matched := matchingLabels(issue)
+		if !isMine && len(matched) == 0 && len(labelUse) > 0 {
+			continue // Unassigned, but outside the assignee's areas
+		}
+
+		switch {
+		case isMine && issue.Status == model.StatusInProgress:
+			inProgress = append(inProgress, newItem(issue, QueueInProgress, "In progress"))
+
+		case isMine && issue.Status == model.StatusInReview:
+			inProgress = append(inProgress, newItem(issue, QueueInProgress, "In review"))
+
+		case actionable[issue.ID] && issue.Status != model.StatusBlocked:
+			reason := "Assigned to you"
+			if !isMine {
+				reason = "Unassigned; top triage pick"
+				if len(matched) > 0 {
+					reason = "Unassigned; matches your labels: " + strings.Join(matched, ", ")
+				}
+			}
+			item := newItem(issue, QueueNext, reason)
+			if !isMine {
+				item.ClaimCommand = fmt.Sprintf("bd update %s --status=in_progress --assignee=%s", issue.ID, assignee)
+			}
+			next = append(next, item)
+
+		default:
+			// Upcoming only if every open blocker can be worked on right now
+			item := newItem(issue, QueueUpcoming, "")
+			if len(item.BlockedBy) == 0 {
+				continue
+			}
+			ready := true
+			for _, id := range item.BlockedBy {
+				if !actionable[id] {
+					ready = false
+					break
+				}
+			}
+			if !ready {
+				continue
+			}
+			verb := "closes"
+			if len(item.BlockedBy) > 1 {
+				verb = "close"
+			}
+			item.Reason = fmt.Sprintf("Frees up when %s %s", strings.Join(item.BlockedBy, ", "), verb)
+			upcoming = append(upcoming, item)
+		}
+	}
+
+	byScore := func(items []MyQueueItem) {
+		sort.SliceStable(items, func(i, j int) bool {
+			if items[i].Score != items[j].Score {
+				return items[i].Score > items[j].Score
+			}
+			return items[i].ID < items[j].ID
+		})
+	}
+	byScore(inProgress)
+	byScore(next)
+	byScore(upcoming)
+	if len(next) > opts.NextN {
+		next = next[:opts.NextN]
+	}
+	if len(upcoming) > opts.UpcomingN {
+		upcoming = upcoming[:opts.UpcomingN]
+	}
+
+	queue.InProgressCount = len(inProgress)
+	queue.NextCount = len(next)
+	queue.UpcomingCount = len(upcoming)
+	queue.Items = append(queue.Items, inProgress...)
+	queue.Items = append(queue.Items, next...)
+	queue.Items = append(queue.Items, upcoming...)
+	return queue
+}
@@ -0,0 +1,121 @@
+package analysis
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func myQueueTestIssues() []model.Issue {
+	blockedBy := func(id, on string) []*model.Dependency {
+		return []*model.Dependency{{IssueID: id, DependsOnID: on, Type: model.DepBlocks}}
+	}
+	return []model.Issue{
+		// Ana's history: backend work, one closed
+		{ID: "api-1", Title: "Auth", Status: model.StatusInProgress, Assignee: "ana", Labels: []string{"backend"}},
+		{ID: "api-0", Title: "Schema", Status: model.StatusClosed, Assignee: "ana", Labels: []string{"backend", "db"}},
+		{ID: "api-2", Title: "Rate limit", Status: model.StatusOpen, Assignee: "Ana", Labels: []string{"backend"}},
+		// Unassigned work in and out of Ana's areas
+		{ID: "api-3", Title: "Caching", Status: model.StatusOpen, Labels: []string{"db"}, Priority: 1},
+		{ID: "ui-1", Title: "Login form", Status: model.StatusOpen, Labels: []string{"frontend"}},
+		// Someone else's
+		{ID: "api-4", Title: "Metrics", Status: model.StatusOpen, Assignee: "bo", Labels: []string{"backend"}},
+		// Blocked by ready work vs. by blocked work
+		{ID: "api-5", Title: "Quotas", Status: model.StatusOpen, Assignee: "ana", Dependencies: blockedBy("api-5", "api-2")},
+		{ID: "api-6", Title: "Billing", Status: model.StatusOpen, Labels: []string{"backend"}, Dependencies: blockedBy("api-6", "api-5")},
+	}
+}
+
+func computeTestQueue(t *testing.T, issues []model.Issue, assignee string, opts MyQueueOptions) MyQueue {
+	t.Helper()
+	analyzer := NewAnalyzer(issues)
+	stats := analyzer.Analyze()
+	return ComputeMyQueue(analyzer, &stats, issues, assignee, opts, time.Now())
+}
+
+func TestComputeMyQueue(t *testing.T) {
+	queue := computeTestQueue(t, myQueueTestIssues(), "ana", MyQueueOptions{})
+
+	if strings.Join(queue.Labels, ",") != "backend,db" {
+		t.Errorf("labels = %v, want backend first (most used), then db", queue.Labels)
+	}
+	if queue.InProgressCount != 1 || queue.NextCount != 2 || queue.UpcomingCount != 1 {
+		t.Fatalf("counts = %d/%d/%d, want 1/2/1: %+v", queue.InProgressCount, queue.NextCount, queue.UpcomingCount, queue.Items)
+	}
+
+	sections := make(map[string]QueueSection)
+	for _, item := range queue.Items {
+		sections[item.ID] = item.Section
+	}
+	want := map[string]QueueSection{
+		"api-1": QueueInProgress,
+		"api-2": QueueNext, // Assignee match is case-insensitive
+		"api-3": QueueNext,
+		"api-5": QueueUpcoming,
+	}
+	for id, section := range want {
+		if sections[id] != section {
+			t.Errorf("%s in section %q, want %q", id, sections[id], section)
+		}
+	}
+	for _, id := range []string{"api-0", "ui-1", "api-4", "api-6"} {
+		if _, ok := sections[id]; ok {
+			t.Errorf("%s should not be in the queue", id)
+		}
+	}
+
+	if queue.Items[0].Section != QueueInProgress || queue.Items[len(queue.Items)-1].Section != QueueUpcoming {
+		t.Error("sections should run in progress, next, upcoming")
+	}
+
+	for _, item := range queue.Items {
+		switch item.ID {
+		case "api-3":
+			if !strings.Contains(item.Reason, "matches your labels: db") || !strings.Contains(item.ClaimCommand, "--assignee=ana") {
+				t.Errorf("unassigned pick: reason %q, claim %q", item.Reason, item.ClaimCommand)
+			}
+		case "api-2":
+			if item.ClaimCommand != "" || item.Unblocks != 1 {
+				t.Errorf("own item should not need a claim command and unblocks api-5: %+v", item)
+			}
+		case "api-5":
+			if item.Reason != "Frees up when api-2 closes" {
+				t.Errorf("upcoming reason = %q", item.Reason)
+			}
+		}
+	}
+}
+
+func TestComputeMyQueue_LimitsAndFallback(t *testing.T) {
+	queue := computeTestQueue(t, myQueueTestIssues(), "ana", MyQueueOptions{NextN: 1})
+	if queue.NextCount != 1 || queue.InProgressCount != 1 {
+		t.Errorf("NextN should cap only the next section: %d next, %d in progress", queue.NextCount, queue.InProgressCount)
+	}
+
+	// Someone with no history gets the top unassigned picks
+	queue = computeTestQueue(t, myQueueTestIssues(), "cy", MyQueueOptions{})
+	if len(queue.Labels) != 0 || queue.NextCount == 0 {
+		t.Fatalf("newcomer queue: labels %v, %d next", queue.Labels, queue.NextCount)
+	}
+	for _, item := range queue.Items {
+		if item.Assignee != "" {
+			t.Errorf("newcomer queue should only suggest unassigned work, got %s (%s)", item.ID, item.Assignee)
+		}
+	}
+
+	if queue := computeTestQueue(t, myQueueTestIssues(), "", MyQueueOptions{}); len(queue.Items) != 0 {
+		t.Error("empty assignee should give an empty queue")
+	}
+}
+
+func TestResolveAssignee(t *testing.T) {
+	t.Setenv("BD_ACTOR", "ana")
+	if got := ResolveAssignee("me"); got != "ana" {
+		t.Errorf("ResolveAssignee(me) = %q, want ana", got)
+	}
+	if got := ResolveAssignee(" bo "); got != "bo" {
+		t.Errorf("ResolveAssignee(bo) = %q", got)
+	}
+}
@@ -0,0 +1,349 @@
+package analysis
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// WorkPartition splits the actionable frontier into disjoint bundles, one per
+// agent (--robot-partition). Frontier items whose downstream work converges
+// stay in the same bundle, so agents rarely wait on each other.
+type WorkPartition struct {
+	Agents        int          `json:"agents"`
+	FrontierCount int          `json:"frontier_count"`
+	Bundles       []WorkBundle `json:"bundles"`
+	// CrossBundleEdges counts extra bundles per shared issue: a blocked issue
+	// waiting on k bundles adds k-1. Zero means the bundles never meet.
+	CrossBundleEdges int               `json:"cross_bundle_edges"`
+	SharedDownstream []SharedDependent `json:"shared_downstream"`
+	// InProgress is already claimed and left out of the bundles
+	InProgress []string `json:"in_progress"`
+}
+
+// WorkBundle is one agent's ordered queue
+type WorkBundle struct {
+	Agent        string          `json:"agent"` // agent-1, agent-2, ...
+	Items        []PartitionItem `json:"items"` // Highest triage score first
+	TotalMinutes int             `json:"total_minutes"`
+	// Downstream is blocked work only this bundle feeds
+	Downstream    []string `json:"downstream"`
+	ClaimCommands []string `json:"claim_commands"`
+}
+
+// PartitionItem is one frontier issue in a bundle
+type PartitionItem struct {
+	ID               string   `json:"id"`
+	Title            string   `json:"title"`
+	Priority         int      `json:"priority"`
+	Score            float64  `json:"score"` // Triage score
+	EstimatedMinutes int      `json:"estimated_minutes"`
+	Estimated        bool     `json:"estimated"` // false: heuristic size
+	Unblocks         []string `json:"unblocks,omitempty"`
+	ClaimCommand     string   `json:"claim_command"`
+}
+
+// SharedDependent is a blocked issue fed by more than one bundle
+type SharedDependent struct {
+	ID      string   `json:"id"`
+	Title   string   `json:"title"`
+	Bundles []string `json:"bundles"`
+}
+
+// partitionUnit is a set of frontier items that move between bundles together
+type partitionUnit struct {
+	items   []string
+	minutes int
+}
+
+// PartitionWork splits the open, unclaimed actionable issues into agents
+// bundles. Two frontier items are coupled by the blocked issues both
+// eventually unblock; coupled items are grouped first, and a group is only
+// split (at its weakest coupling) while there are fewer groups than agents.
+// Groups are then packed onto agents largest-first by estimated minutes, so
+// bundles stay disjoint at the cost of some imbalance.
+func PartitionWork(analyzer *Analyzer, stats *GraphStats, issues []model.Issue, agents int, now time.Time) WorkPartition {
+	if agents <= 0 {
+		agents = 1
+	}
+	result := WorkPartition{
+		Agents:           agents,
+		Bundles:          make([]WorkBundle, agents),
+		SharedDownstream: []SharedDependent{},
+		InProgress:       []string{},
+	}
+	for i := range result.Bundles {
+		result.Bundles[i] = WorkBundle{
+			Agent:         fmt.Sprintf("agent-%d", i+1),
+			Items:         []PartitionItem{},
+			Downstream:    []string{},
+			ClaimCommands: []string{},
+		}
+	}
+	if len(issues) == 0 {
+		return result
+	}
+
+	var frontier []string
+	for _, issue := range analyzer.GetActionableIssues() {
+		if issue.Status == model.StatusInProgress || issue.Status == model.StatusInReview {
+			result.InProgress = append(result.InProgress, issue.ID)
+			continue
+		}
+		if issue.Status.IsClosed() || issue.Status.IsTombstone() || issue.Status == model.StatusBlocked {
+			continue
+		}
+		frontier = append(frontier, issue.ID)
+	}
+	sort.Strings(frontier)
+	sort.Strings(result.InProgress)
+	result.FrontierCount = len(frontier)
+	if len(frontier) == 0 {
+		return result
+	}
+
+	// Open issues downstream of each frontier item, and the reverse
+	feeders := make(map[string][]string)
+	for _, id := range frontier {
+		for _, dep := range analyzer.openDownstream(id) {
+			feeders[dep] = append(feeders[dep], id)
+		}
+	}
+
+	// Coupling weight: downstream issues two frontier items share
+	type pair struct{ a, b string }
+	coupling := make(map[pair]int)
+	for _, ids := range feeders {
+		for i := 0; i < len(ids); i++ {
+			for j := i + 1; j < len(ids); j++ {
+				a, b := ids[i], ids[j]
+				if a > b {
+					a, b = b, a
+				}
+				coupling[pair{a, b}]++
+			}
+		}
+	}
+	type edge struct {
+		a, b   string
+		weight int
+	}
+	edges := make([]edge, 0, len(coupling))
+	for p, w := range coupling {
+		edges = append(edges, edge{p.a, p.b, w})
+	}
+	sort.Slice(edges, func(i, j int) bool {
+		if edges[i].weight != edges[j].weight {
+			return edges[i].weight > edges[j].weight
+		}
+		if edges[i].a != edges[j].a {
+			return edges[i].a < edges[j].a
+		}
+		return edges[i].b < edges[j].b
+	})
+
+	// group merges coupled items, strongest first, until at most target
+	// groups remain (target 0: merge everything coupled)
+	group := func(ids []string, target int) [][]string {
+		parent := make(map[string]string, len(ids))
+		for _, id := range ids {
+			parent[id] = id
+		}
+		var find func(string) string
+		find = func(x string) string {
+			if parent[x] != x {
+				parent[x] = find(parent[x])
+			}
+			return parent[x]
+		}
+		groups := len(ids)
+		for _, e := range edges {
+			if groups <= target {
+				break
+			}
+			if _, ok := parent[e.a]; !ok {
+				continue
+			}
+			if _, ok := parent[e.b]; !ok {
+				continue
+			}
+			ra, rb := find(e.a), find(e.b)
+			if ra == rb {
+				continue
+			}
+			if ra > rb {
+				ra, rb = rb, ra
+			}
+			parent[rb] = ra
+			groups--
+		}
+		byRoot := make(map[string][]string)
+		for _, id := range ids {
+			root := find(id)
+			byRoot[root] = append(byRoot[root], id)
+		}
+		out := make([][]string, 0, len(byRoot))
+		for _, members := range byRoot {
+			sort.Strings(members)
+			out = append(out, members)
+		}
+		sort.Slice(out, func(i, j int) bool { return out[i][0] < out[j][0] })
+		return out
+	}
+
+	medianMinutes := computeMedianEstimatedMinutes(issues)
+	minutes := make(map[string]int, len(frontier))
+	estimated := make(map[string]bool, len(frontier))
+	for _, id := range frontier {
+		issue := analyzer.issueMap[id]
+		if m, ok := issue.ExplicitEstimateMinutes(); ok {
+			minutes[id], estimated[id] = m, true
+		} else {
+			minutes[id], _ = estimateComplexityMinutes(issue, stats, medianMinutes)
+		}
+	}
+	newUnit := func(ids []string) partitionUnit {
+		u := partitionUnit{items: ids}
+		for _, id := range ids {
+			u.minutes += minutes[id]
+		}
+		return u
+	}
+
+	var units []partitionUnit
+	for _, ids := range group(frontier, 0) {
+		units = append(units, newUnit(ids))
+	}
+	// Give every agent something: split the heaviest splittable unit at its
+	// weakest coupling until there are enough units
+	for len(units) < agents {
+		heaviest := -1
+		for i, u := range units {
+			if len(u.items) > 1 && (heaviest < 0 || u.minutes > units[heaviest].minutes) {
+				heaviest = i
+			}
+		}
+		if heaviest < 0 {
+			break
+		}
+		split := group(units[heaviest].items, 2)
+		units = append(units[:heaviest], units[heaviest+1:]...)
+		for _, ids := range split {
+			units = append(units, newUnit(ids))
+		}
+	}
+
+	// Longest-processing-time packing onto the least loaded bundle
+	sort.SliceStable(units, func(i, j int) bool {
+		if units[i].minutes != units[j].minutes {
+			return units[i].minutes > units[j].minutes
+		}
+		return units[i].items[0] < units[j].items[0]
+	})
+	bundleOf := make(map[string]int, len(frontier))
+	for _, u := range units {
+		target := 0
+		for i := range result.Bundles {
+			if result.Bundles[i].TotalMinutes < result.Bundles[target].TotalMinutes {
+				target = i
+			}
+		}
+		for _, id := range u.items {
+			bundleOf[id] = target
+		}
+		result.Bundles[target].TotalMinutes += u.minutes
+	}
+
+	unblocksMap := buildUnblocksMap(analyzer, issues)
+	scores := make(map[string]float64)
+	for _, ts := range computeTriageScoresFromImpact(analyzer.ComputeImpactScoresFromStats(stats, now), unblocksMap, analyzer, DefaultTriageScoringOptions()) {
+		scores[ts.IssueID] = ts.TriageScore
+	}
+	for _, id := range frontier {
+		b := &result.Bundles[bundleOf[id]]
+		issue := analyzer.issueMap[id]
+		b.Items = append(b.Items, PartitionItem{
+			ID:               id,
+			Title:            issue.Title,
+			Priority:         issue.Priority,
+			Score:            scores[id],
+			EstimatedMinutes: minutes[id],
+			Estimated:        estimated[id],
+			Unblocks:         unblocksMap[id],
+			ClaimCommand:     fmt.Sprintf("bd update %s --status=in_progress --assignee=%s", id, b.Agent),
+		})
+	}
+	for i := range result.Bundles {
+		b := &result.Bundles[i]
+		sort.SliceStable(b.Items, func(x, y int) bool {
+			if b.Items[x].Score != b.Items[y].Score {
+				return b.Items[x].Score > b.Items[y].Score
+			}
+			return b.Items[x].ID < b.Items[y].ID
+		})
+		for _, item := range b.Items {
+			b.ClaimCommands = append(b.ClaimCommands, item.ClaimCommand)
+		}
+	}
+
+	// Downstream ownership: single-bundle work, or shared across bundles
+	downstream := make([]string, 0, len(feeders))
+	for id := range feeders {
+		downstream = append(downstream, id)
+	}
+	sort.Strings(downstream)
+	for _, id := range downstream {
+		seen := make(map[int]bool)
+		var owners []int
+		for _, f := range feeders[id] {
+			if b := bundleOf[f]; !seen[b] {
+				seen[b] = true
+				owners = append(owners, b)
+			}
+		}
+		if len(owners) == 1 {
+			b := &result.Bundles[owners[0]]
+			b.Downstream = append(b.Downstream, id)
+			continue
+		}
+		sort.Ints(owners)
+		shared := SharedDependent{ID: id, Title: analyzer.issueMap[id].Title}
+		for _, b := range owners {
+			shared.Bundles = append(shared.Bundles, result.Bundles[b].Agent)
+		}
+		result.SharedDownstream = append(result.SharedDownstream, shared)
+		result.CrossBundleEdges += len(owners) - 1
+	}
+	return result
+}
+
+// openDownstream returns the open issues that transitively depend on id
+func (a *Analyzer) openDownstream(id string) []string {
+	start, ok := a.idToNode[id]
+	if !ok {
+		return nil
+	}
+	visited := map[int32]bool{int32(start): true}
+	queue := []int32{int32(start)}
+	var out []string
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+		for _, dep := range a.g.to(node) {
+			if visited[dep] {
+				continue
+			}
+			visited[dep] = true
+			depID := a.nodeToID[dep]
+			if status := a.issueMap[depID].Status; status.IsClosed() || status.IsTombstone() {
+				continue
+			}
+			out = append(out, depID)
+			queue = append(queue, dep)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
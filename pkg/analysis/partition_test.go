@@ -0,0 +1,108 @@
+package analysis
+
+import (
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func partitionTestIssues() []model.Issue {
+	blockedBy := func(id string, on ...string) []*model.Dependency {
+		var deps []*model.Dependency
+		for _, o := range on {
+			deps = append(deps, &model.Dependency{IssueID: id, DependsOnID: o, Type: model.DepBlocks})
+		}
+		return deps
+	}
+	minutes := func(m int) *int { return &m }
+	return []model.Issue{
+		// Stream A: a1 and a2 both feed a3, which feeds a4
+		{ID: "a1", Title: "A1", Status: model.StatusOpen, EstimatedMinutes: minutes(120)},
+		{ID: "a2", Title: "A2", Status: model.StatusOpen, EstimatedMinutes: minutes(120)},
+		{ID: "a3", Title: "A3", Status: model.StatusOpen, Dependencies: blockedBy("a3", "a1", "a2")},
+		{ID: "a4", Title: "A4", Status: model.StatusOpen, Dependencies: blockedBy("a4", "a3")},
+		// Stream B: one frontier item
+		{ID: "b1", Title: "B1", Status: model.StatusOpen, EstimatedMinutes: minutes(60)},
+		{ID: "b2", Title: "B2", Status: model.StatusOpen, Dependencies: blockedBy("b2", "b1")},
+		// Independent and already claimed work
+		{ID: "c1", Title: "C1", Status: model.StatusOpen, EstimatedMinutes: minutes(30)},
+		{ID: "d1", Title: "D1", Status: model.StatusInProgress},
+	}
+}
+
+func computeTestPartition(issues []model.Issue, agents int) WorkPartition {
+	analyzer := NewAnalyzer(issues)
+	stats := analyzer.Analyze()
+	return PartitionWork(analyzer, &stats, issues, agents, time.Now())
+}
+
+func bundleIDs(b WorkBundle) string {
+	var ids []string
+	for _, item := range b.Items {
+		ids = append(ids, item.ID)
+	}
+	sort.Strings(ids) // Queue order is by score; compare membership
+	return strings.Join(ids, ",")
+}
+
+func TestPartitionWorkKeepsCoupledItemsTogether(t *testing.T) {
+	p := computeTestPartition(partitionTestIssues(), 3)
+
+	if p.FrontierCount != 4 || len(p.Bundles) != 3 {
+		t.Fatalf("frontier = %d, bundles = %d; want 4 and 3", p.FrontierCount, len(p.Bundles))
+	}
+	if strings.Join(p.InProgress, ",") != "d1" {
+		t.Errorf("in_progress = %v, want d1", p.InProgress)
+	}
+	// Heaviest unit (a1+a2, 240 min) first, then b1, then c1
+	want := []string{"a1,a2", "b1", "c1"}
+	for i, b := range p.Bundles {
+		if got := bundleIDs(b); got != want[i] {
+			t.Errorf("%s = %s, want %s", b.Agent, got, want[i])
+		}
+	}
+	if p.CrossBundleEdges != 0 || len(p.SharedDownstream) != 0 {
+		t.Errorf("cross edges = %d (%v), want none", p.CrossBundleEdges, p.SharedDownstream)
+	}
+	if strings.Join(p.Bundles[0].Downstream, ",") != "a3,a4" {
+		t.Errorf("agent-1 downstream = %v, want a3,a4", p.Bundles[0].Downstream)
+	}
+	if p.Bundles[0].TotalMinutes != 240 {
+		t.Errorf("agent-1 minutes = %d, want 240", p.Bundles[0].TotalMinutes)
+	}
+	if got := p.Bundles[1].ClaimCommands; len(got) != 1 || got[0] != "bd update b1 --status=in_progress --assignee=agent-2" {
+		t.Errorf("agent-2 claim commands = %v", got)
+	}
+}
+
+func TestPartitionWorkSplitsForMoreAgents(t *testing.T) {
+	issues := partitionTestIssues()[:4] // Only stream A
+	p := computeTestPartition(issues, 2)
+
+	if bundleIDs(p.Bundles[0]) == "" || bundleIDs(p.Bundles[1]) == "" {
+		t.Fatalf("both agents should get work: %s / %s", bundleIDs(p.Bundles[0]), bundleIDs(p.Bundles[1]))
+	}
+	// a3 and a4 now wait on both bundles
+	if p.CrossBundleEdges != 2 || len(p.SharedDownstream) != 2 {
+		t.Errorf("cross edges = %d, shared = %v; want 2 shared issues", p.CrossBundleEdges, p.SharedDownstream)
+	}
+	if got := strings.Join(p.SharedDownstream[0].Bundles, ","); got != "agent-1,agent-2" {
+		t.Errorf("shared bundles = %s", got)
+	}
+}
+
+func TestPartitionWorkFewerItemsThanAgents(t *testing.T) {
+	issues := []model.Issue{{ID: "x", Title: "X", Status: model.StatusOpen}}
+	p := computeTestPartition(issues, 4)
+	if len(p.Bundles) != 4 || len(p.Bundles[0].Items) != 1 {
+		t.Fatalf("bundles = %+v", p.Bundles)
+	}
+	for _, b := range p.Bundles[1:] {
+		if b.Items == nil || len(b.Items) != 0 {
+			t.Errorf("%s should be an empty, non-nil queue: %+v", b.Agent, b.Items)
+		}
+	}
+}
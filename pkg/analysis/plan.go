@@ -2,6 +2,7 @@ package analysis
 
 import (
 	"sort"
+	"time"
 
 	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
 )
@@ -13,6 +14,10 @@ type PlanItem struct {
 	Priority    int      `json:"priority"`
 	Status      string   `json:"status"`
 	UnblocksIDs []string `json:"unblocks"` // Issues that become actionable when this is done
+
+	// Set when the item is leased in .bv/claims.jsonl (bv claim)
+	ClaimedBy    string     `json:"claimed_by,omitempty"`
+	ClaimedUntil *time.Time `json:"claimed_until,omitempty"`
 }
 
 // ExecutionTrack represents a group of related actionable items
@@ -275,6 +280,46 @@ func (a *Analyzer) buildTracks(components map[string][]string, actionableSet map
 	return tracks
 }
 
+// GetTrackMembership maps every issue to the execution-plan track of its work
+// stream, including blocked and closed issues connected to the track's
+// actionable items. Issues in streams with nothing actionable have no track.
+func (a *Analyzer) GetTrackMembership() map[string]string {
+	actionableSet := make(map[string]bool)
+	for _, issue := range a.GetActionableIssues() {
+		actionableSet[issue.ID] = true
+	}
+
+	components := a.findConnectedComponents()
+	roots := make([]string, 0, len(components))
+	for root := range components {
+		roots = append(roots, root)
+	}
+	sort.Strings(roots)
+
+	// Number tracks exactly as buildTracks does
+	membership := make(map[string]string)
+	trackNum := 1
+	for _, root := range roots {
+		members := components[root]
+		hasActionable := false
+		for _, id := range members {
+			if actionableSet[id] {
+				hasActionable = true
+				break
+			}
+		}
+		if !hasActionable {
+			continue
+		}
+		trackID := generateTrackID(trackNum)
+		for _, id := range members {
+			membership[id] = trackID
+		}
+		trackNum++
+	}
+	return membership
+}
+
 // computePlanSummary finds the highest-impact actionable issue
 func (a *Analyzer) computePlanSummary(actionable []model.Issue, unblocksMap map[string][]string) PlanSummary {
 	if len(actionable) == 0 {
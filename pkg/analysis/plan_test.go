@@ -502,4 +502,41 @@ func TestGetExecutionPlanLegacyDependencyGrouping(t *testing.T) {
 	if len(plan.Tracks) != 1 {
 		t.Errorf("Expected 1 track (grouped via legacy dependency), got %d tracks", len(plan.Tracks))
 	}
-}
\ No newline at end of file
+}
+func TestGetTrackMembership(t *testing.T) {
+	// Two chains with actionable heads, plus a closed-only stream
+	issues := []model.Issue{
+		{ID: "A", Title: "Task A", Status: model.StatusOpen, Dependencies: []*model.Dependency{
+			{DependsOnID: "B", Type: model.DepBlocks},
+		}},
+		{ID: "B", Title: "Task B", Status: model.StatusOpen},
+		{ID: "C", Title: "Task C", Status: model.StatusOpen, Dependencies: []*model.Dependency{
+			{DependsOnID: "D", Type: model.DepBlocks},
+		}},
+		{ID: "D", Title: "Task D", Status: model.StatusOpen},
+		{ID: "E", Title: "Done", Status: model.StatusClosed},
+	}
+
+	an := analysis.NewAnalyzer(issues)
+	membership := an.GetTrackMembership()
+	plan := an.GetExecutionPlan()
+
+	// Every actionable item's track matches the plan
+	for _, track := range plan.Tracks {
+		for _, item := range track.Items {
+			if membership[item.ID] != track.TrackID {
+				t.Errorf("%s: membership %q, plan track %q", item.ID, membership[item.ID], track.TrackID)
+			}
+		}
+	}
+	// Blocked issues join their blocker's track
+	if membership["A"] != membership["B"] || membership["C"] != membership["D"] {
+		t.Errorf("blocked issues should share their stream's track: %v", membership)
+	}
+	if membership["A"] == membership["C"] {
+		t.Errorf("independent streams should have different tracks: %v", membership)
+	}
+	if _, ok := membership["E"]; ok {
+		t.Errorf("stream with nothing actionable should have no track: %v", membership)
+	}
+}
@@ -0,0 +1,282 @@
+package analysis
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// PRImpactConfig tunes which metric movements are reported.
+type PRImpactConfig struct {
+	// MinRelativeChange is the minimum relative change (0.2 = 20%) for a metric move to be reported.
+	MinRelativeChange float64
+	// MaxMoversPerMetric caps the number of movers reported per metric.
+	MaxMoversPerMetric int
+}
+
+// DefaultPRImpactConfig returns sensible defaults for PR comments.
+func DefaultPRImpactConfig() PRImpactConfig {
+	return PRImpactConfig{
+		MinRelativeChange:  0.20,
+		MaxMoversPerMetric: 5,
+	}
+}
+
+// PRImpact summarizes how a change to the beads file affects the graph.
+type PRImpact struct {
+	BaseRevision string        `json:"base_revision"`
+	Diff         *SnapshotDiff `json:"diff"`
+
+	MetricMovers     []MetricMover     `json:"metric_movers"`
+	NewlyBlocked     []BlockChangeItem `json:"newly_blocked"`
+	NewlyUnblocked   []BlockChangeItem `json:"newly_unblocked"`
+	CyclesIntroduced [][]string        `json:"cycles_introduced"`
+	CyclesResolved   [][]string        `json:"cycles_resolved"`
+
+	// Verdict is "ok", "review" (blocked work grew or metrics moved), or "attention" (new cycles).
+	Verdict string `json:"verdict"`
+}
+
+// MetricMover is a single issue whose graph metric moved between base and head.
+type MetricMover struct {
+	Metric    string  `json:"metric"` // pagerank, betweenness, critical_path
+	IssueID   string  `json:"issue_id"`
+	Title     string  `json:"title"`
+	Before    float64 `json:"before"`
+	After     float64 `json:"after"`
+	Delta     float64 `json:"delta"`
+	RelChange float64 `json:"relative_change"`
+}
+
+// BlockChangeItem is an issue whose blocked state flipped.
+type BlockChangeItem struct {
+	ID        string   `json:"id"`
+	Title     string   `json:"title"`
+	BlockedBy []string `json:"blocked_by,omitempty"` // Open blockers at head (newly blocked only)
+}
+
+// ComputePRImpact compares a base snapshot (e.g., origin/main) to the working snapshot.
+func ComputePRImpact(base, head *Snapshot, cfg PRImpactConfig) *PRImpact {
+	if cfg.MaxMoversPerMetric <= 0 {
+		cfg.MaxMoversPerMetric = DefaultPRImpactConfig().MaxMoversPerMetric
+	}
+
+	diff := CompareSnapshots(base, head)
+	impact := &PRImpact{
+		BaseRevision:     base.Revision,
+		Diff:             diff,
+		MetricMovers:     []MetricMover{},
+		NewlyBlocked:     []BlockChangeItem{},
+		NewlyUnblocked:   []BlockChangeItem{},
+		CyclesIntroduced: diff.NewCycles,
+		CyclesResolved:   diff.ResolvedCycles,
+	}
+	if impact.CyclesIntroduced == nil {
+		impact.CyclesIntroduced = [][]string{}
+	}
+	if impact.CyclesResolved == nil {
+		impact.CyclesResolved = [][]string{}
+	}
+
+	// Blocked/unblocked transitions (only issues present on both sides and still open).
+	baseBlocked := openBlockersByIssue(base.Issues)
+	headBlocked := openBlockersByIssue(head.Issues)
+	baseByID := make(map[string]model.Issue, len(base.Issues))
+	for _, iss := range base.Issues {
+		baseByID[iss.ID] = iss
+	}
+	for _, iss := range head.Issues {
+		if iss.Status == model.StatusClosed || iss.Status == model.StatusTombstone {
+			continue
+		}
+		_, wasBlocked := baseBlocked[iss.ID]
+		blockers, isBlocked := headBlocked[iss.ID]
+		if _, existed := baseByID[iss.ID]; !existed {
+			// New issues that arrive blocked still count: they add to blocked work.
+			if isBlocked {
+				impact.NewlyBlocked = append(impact.NewlyBlocked, BlockChangeItem{ID: iss.ID, Title: iss.Title, BlockedBy: blockers})
+			}
+			continue
+		}
+		switch {
+		case isBlocked && !wasBlocked:
+			impact.NewlyBlocked = append(impact.NewlyBlocked, BlockChangeItem{ID: iss.ID, Title: iss.Title, BlockedBy: blockers})
+		case !isBlocked && wasBlocked:
+			impact.NewlyUnblocked = append(impact.NewlyUnblocked, BlockChangeItem{ID: iss.ID, Title: iss.Title})
+		}
+	}
+	sort.Slice(impact.NewlyBlocked, func(i, j int) bool { return impact.NewlyBlocked[i].ID < impact.NewlyBlocked[j].ID })
+	sort.Slice(impact.NewlyUnblocked, func(i, j int) bool { return impact.NewlyUnblocked[i].ID < impact.NewlyUnblocked[j].ID })
+
+	// Metric movers
+	if base.Stats != nil && head.Stats != nil {
+		titles := make(map[string]string, len(head.Issues))
+		for _, iss := range head.Issues {
+			titles[iss.ID] = iss.Title
+		}
+		impact.MetricMovers = append(impact.MetricMovers,
+			metricMovers("pagerank", base.Stats.PageRank(), head.Stats.PageRank(), titles, cfg)...)
+		impact.MetricMovers = append(impact.MetricMovers,
+			metricMovers("betweenness", base.Stats.Betweenness(), head.Stats.Betweenness(), titles, cfg)...)
+		impact.MetricMovers = append(impact.MetricMovers,
+			metricMovers("critical_path", base.Stats.CriticalPathScore(), head.Stats.CriticalPathScore(), titles, cfg)...)
+	}
+
+	switch {
+	case len(impact.CyclesIntroduced) > 0:
+		impact.Verdict = "attention"
+	case len(impact.NewlyBlocked) > len(impact.NewlyUnblocked) || len(impact.MetricMovers) > 0:
+		impact.Verdict = "review"
+	default:
+		impact.Verdict = "ok"
+	}
+
+	return impact
+}
+
+// openBlockersByIssue maps each open issue to its open blocking dependencies.
+// Issues without open blockers are absent from the map.
+func openBlockersByIssue(issues []model.Issue) map[string][]string {
+	status := make(map[string]model.Status, len(issues))
+	for _, iss := range issues {
+		status[iss.ID] = iss.Status
+	}
+	result := make(map[string][]string)
+	for _, iss := range issues {
+		if iss.Status == model.StatusClosed || iss.Status == model.StatusTombstone {
+			continue
+		}
+		for _, dep := range iss.Dependencies {
+			if dep == nil || !dep.Type.IsBlocking() {
+				continue
+			}
+			st, ok := status[dep.DependsOnID]
+			if !ok || st == model.StatusClosed || st == model.StatusTombstone {
+				continue
+			}
+			result[iss.ID] = append(result[iss.ID], dep.DependsOnID)
+		}
+	}
+	for id := range result {
+		sort.Strings(result[id])
+	}
+	return result
+}
+
+func metricMovers(metric string, before, after map[string]float64, titles map[string]string, cfg PRImpactConfig) []MetricMover {
+	var movers []MetricMover
+	for id, a := range after {
+		b, ok := before[id]
+		if !ok {
+			continue
+		}
+		delta := a - b
+		if math.Abs(delta) < 1e-9 {
+			continue
+		}
+		denom := math.Max(math.Abs(b), 1e-9)
+		rel := delta / denom
+		if math.Abs(rel) < cfg.MinRelativeChange {
+			continue
+		}
+		movers = append(movers, MetricMover{
+			Metric:    metric,
+			IssueID:   id,
+			Title:     titles[id],
+			Before:    b,
+			After:     a,
+			Delta:     delta,
+			RelChange: rel,
+		})
+	}
+	sort.Slice(movers, func(i, j int) bool {
+		ai, aj := math.Abs(movers[i].Delta), math.Abs(movers[j].Delta)
+		if ai != aj {
+			return ai > aj
+		}
+		return movers[i].IssueID < movers[j].IssueID
+	})
+	if len(movers) > cfg.MaxMoversPerMetric {
+		movers = movers[:cfg.MaxMoversPerMetric]
+	}
+	return movers
+}
+
+// Markdown renders the impact as a PR comment.
+func (p *PRImpact) Markdown() string {
+	var sb strings.Builder
+
+	icon := "✅"
+	switch p.Verdict {
+	case "attention":
+		icon = "🚨"
+	case "review":
+		icon = "⚠️"
+	}
+	base := p.BaseRevision
+	if len(base) > 12 {
+		base = base[:12]
+	}
+	sb.WriteString(fmt.Sprintf("### %s bv tracker impact vs `%s`\n\n", icon, base))
+
+	s := p.Diff.Summary
+	sb.WriteString("| Added | Closed | Reopened | Modified | Removed | Newly blocked | Unblocked | New cycles |\n")
+	sb.WriteString("|---|---|---|---|---|---|---|---|\n")
+	sb.WriteString(fmt.Sprintf("| %d | %d | %d | %d | %d | %d | %d | %d |\n\n",
+		s.IssuesAdded, s.IssuesClosed, s.IssuesReopened, s.IssuesModified, s.IssuesRemoved,
+		len(p.NewlyBlocked), len(p.NewlyUnblocked), len(p.CyclesIntroduced)))
+
+	if len(p.CyclesIntroduced) > 0 {
+		sb.WriteString("**🔄 New dependency cycles**\n\n")
+		for _, c := range p.CyclesIntroduced {
+			sb.WriteString(fmt.Sprintf("- `%s`\n", strings.Join(c, " → ")))
+		}
+		sb.WriteString("\n")
+	}
+
+	if len(p.NewlyBlocked) > 0 {
+		sb.WriteString("**⛔ Newly blocked**\n\n")
+		for _, it := range p.NewlyBlocked {
+			sb.WriteString(fmt.Sprintf("- `%s` %s — blocked by %s\n", it.ID, it.Title, formatIDList(it.BlockedBy)))
+		}
+		sb.WriteString("\n")
+	}
+
+	if len(p.NewlyUnblocked) > 0 {
+		sb.WriteString("**🟢 Newly unblocked**\n\n")
+		for _, it := range p.NewlyUnblocked {
+			sb.WriteString(fmt.Sprintf("- `%s` %s\n", it.ID, it.Title))
+		}
+		sb.WriteString("\n")
+	}
+
+	if len(p.MetricMovers) > 0 {
+		sb.WriteString("**📈 Graph metric movers**\n\n")
+		sb.WriteString("| Metric | Issue | Before | After | Change |\n|---|---|---|---|---|\n")
+		for _, m := range p.MetricMovers {
+			sb.WriteString(fmt.Sprintf("| %s | `%s` | %.4f | %.4f | %+.0f%% |\n",
+				m.Metric, m.IssueID, m.Before, m.After, m.RelChange*100))
+		}
+		sb.WriteString("\n")
+	}
+
+	if p.Verdict == "ok" && s.TotalChanges == 0 {
+		sb.WriteString("_No tracker changes in this PR._\n")
+	}
+
+	return sb.String()
+}
+
+func formatIDList(ids []string) string {
+	if len(ids) == 0 {
+		return "-"
+	}
+	quoted := make([]string, len(ids))
+	for i, id := range ids {
+		quoted[i] = "`" + id + "`"
+	}
+	return strings.Join(quoted, ", ")
+}
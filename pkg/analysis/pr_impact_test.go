@@ -0,0 +1,108 @@
+package analysis
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func prImpactIssue(id string, status model.Status, blockers ...string) model.Issue {
+	iss := model.Issue{ID: id, Title: "Issue " + id, Status: status, IssueType: model.TypeTask}
+	for _, b := range blockers {
+		iss.Dependencies = append(iss.Dependencies, &model.Dependency{IssueID: id, DependsOnID: b, Type: model.DepBlocks})
+	}
+	return iss
+}
+
+func TestComputePRImpact_BlockedUnblockedAndCycles(t *testing.T) {
+	base := []model.Issue{
+		prImpactIssue("A", model.StatusOpen),
+		prImpactIssue("B", model.StatusOpen, "A"), // blocked by A
+		prImpactIssue("C", model.StatusOpen),
+		prImpactIssue("D", model.StatusOpen),
+	}
+	head := []model.Issue{
+		prImpactIssue("A", model.StatusClosed),         // closing A unblocks B
+		prImpactIssue("B", model.StatusOpen, "A"),      // now unblocked
+		prImpactIssue("C", model.StatusOpen, "D"),      // newly blocked by D
+		prImpactIssue("D", model.StatusOpen, "C"),      // cycle C <-> D
+		prImpactIssue("E", model.StatusOpen, "C", "A"), // new issue arriving blocked
+	}
+
+	impact := ComputePRImpact(
+		NewSnapshotAt(base, time.Time{}, "abc123"),
+		NewSnapshot(head),
+		DefaultPRImpactConfig(),
+	)
+
+	if impact.BaseRevision != "abc123" {
+		t.Errorf("BaseRevision = %q", impact.BaseRevision)
+	}
+	if len(impact.NewlyUnblocked) != 1 || impact.NewlyUnblocked[0].ID != "B" {
+		t.Errorf("NewlyUnblocked = %+v, want [B]", impact.NewlyUnblocked)
+	}
+
+	blocked := map[string][]string{}
+	for _, it := range impact.NewlyBlocked {
+		blocked[it.ID] = it.BlockedBy
+	}
+	for _, id := range []string{"C", "D", "E"} {
+		if _, ok := blocked[id]; !ok {
+			t.Errorf("expected %s in NewlyBlocked, got %+v", id, impact.NewlyBlocked)
+		}
+	}
+	if got := blocked["E"]; len(got) != 1 || got[0] != "C" {
+		t.Errorf("E blocked_by = %v, want [C] (closed A must not count)", got)
+	}
+
+	if len(impact.CyclesIntroduced) == 0 {
+		t.Error("expected the C<->D cycle to be reported")
+	}
+	if impact.Verdict != "attention" {
+		t.Errorf("Verdict = %q, want attention", impact.Verdict)
+	}
+
+	md := impact.Markdown()
+	for _, want := range []string{"bv tracker impact vs `abc123`", "New dependency cycles", "Newly blocked", "Newly unblocked"} {
+		if !strings.Contains(md, want) {
+			t.Errorf("markdown missing %q:\n%s", want, md)
+		}
+	}
+}
+
+func TestComputePRImpact_NoChanges(t *testing.T) {
+	issues := []model.Issue{
+		prImpactIssue("A", model.StatusOpen),
+		prImpactIssue("B", model.StatusOpen, "A"),
+	}
+	impact := ComputePRImpact(NewSnapshot(issues), NewSnapshot(issues), DefaultPRImpactConfig())
+
+	if impact.Verdict != "ok" {
+		t.Errorf("Verdict = %q, want ok", impact.Verdict)
+	}
+	if len(impact.MetricMovers) != 0 || len(impact.NewlyBlocked) != 0 || len(impact.NewlyUnblocked) != 0 {
+		t.Errorf("expected empty impact, got %+v", impact)
+	}
+	if !strings.Contains(impact.Markdown(), "No tracker changes") {
+		t.Errorf("expected no-change note, got:\n%s", impact.Markdown())
+	}
+}
+
+func TestMetricMovers_ThresholdAndCap(t *testing.T) {
+	before := map[string]float64{"A": 0.10, "B": 0.10, "C": 0.10, "D": 0.10}
+	after := map[string]float64{"A": 0.11, "B": 0.20, "C": 0.05, "D": 0.40, "NEW": 0.5}
+	titles := map[string]string{"B": "Bee"}
+
+	movers := metricMovers("pagerank", before, after, titles, PRImpactConfig{MinRelativeChange: 0.2, MaxMoversPerMetric: 2})
+	if len(movers) != 2 {
+		t.Fatalf("expected 2 movers (capped), got %d: %+v", len(movers), movers)
+	}
+	if movers[0].IssueID != "D" || movers[1].IssueID != "B" {
+		t.Errorf("movers order = %s,%s; want D,B", movers[0].IssueID, movers[1].IssueID)
+	}
+	if movers[1].Title != "Bee" {
+		t.Errorf("title not propagated: %+v", movers[1])
+	}
+}
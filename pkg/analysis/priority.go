@@ -134,10 +134,14 @@ func (a *Analyzer) ComputeImpactScoresFromStats(stats *GraphStats, now time.Time
 		stalenessNorm := computeStaleness(issue.UpdatedAt, now)
 		priorityNorm := computePriorityBoost(issue.Priority)
 
-		// Compute time-to-impact signal
+		// Compute time-to-impact signal (points count as explicit estimates too)
+		var estimate *int
+		if minutes, ok := issue.ExplicitEstimateMinutes(); ok {
+			estimate = &minutes
+		}
 		timeToImpactNorm, timeToImpactExplanation := computeTimeToImpact(
 			criticalPath[id],
-			issue.EstimatedMinutes,
+			estimate,
 			medianMinutes,
 		)
 
@@ -301,12 +305,12 @@ func findMaxInt(m map[string]int) int {
 	return max
 }
 
-// computeMedianEstimatedMinutes calculates the median estimated_minutes across all issues
+// computeMedianEstimatedMinutes calculates the median explicit estimate across all issues
 func (a *Analyzer) computeMedianEstimatedMinutes() int {
 	var estimates []int
 	for _, issue := range a.issueMap {
-		if issue.EstimatedMinutes != nil && *issue.EstimatedMinutes > 0 {
-			estimates = append(estimates, *issue.EstimatedMinutes)
+		if minutes, ok := issue.ExplicitEstimateMinutes(); ok {
+			estimates = append(estimates, minutes)
 		}
 	}
 
@@ -494,7 +498,12 @@ func (a *Analyzer) GenerateRecommendations() []PriorityRecommendation {
 
 // GenerateRecommendationsWithThresholds generates recommendations with custom thresholds
 func (a *Analyzer) GenerateRecommendationsWithThresholds(thresholds RecommendationThresholds) []PriorityRecommendation {
-	scores := a.ComputeImpactScores()
+	return a.GenerateRecommendationsWithThresholdsAt(thresholds, time.Now())
+}
+
+// GenerateRecommendationsWithThresholdsAt generates recommendations as of a specific time
+func (a *Analyzer) GenerateRecommendationsWithThresholdsAt(thresholds RecommendationThresholds, now time.Time) []PriorityRecommendation {
+	scores := a.ComputeImpactScoresAt(now)
 	if len(scores) == 0 {
 		return nil
 	}
@@ -502,6 +511,7 @@ func (a *Analyzer) GenerateRecommendationsWithThresholds(thresholds Recommendati
 	stats := a.Analyze()
 	coreMap := stats.CoreNumber()
 	slackMap := stats.Slack()
+	criticalPath := stats.CriticalPathScore()
 	artSet := make(map[string]bool)
 	for _, id := range stats.ArticulationPoints() {
 		artSet[id] = true
@@ -535,7 +545,7 @@ func (a *Analyzer) GenerateRecommendationsWithThresholds(thresholds Recommendati
 		if rec != nil {
 			if rec.Confidence >= thresholds.MinConfidence {
 				// Compute what-if delta for this recommendation (bv-83)
-				rec.WhatIf = a.computeWhatIfDelta(score.IssueID)
+				rec.WhatIf = a.computeWhatIfDelta(score.IssueID, criticalPath)
 				recommendations = append(recommendations, *rec)
 			}
 		}
@@ -763,10 +773,10 @@ func abs(x float64) float64 {
 // MaxUnblockedIDsShown caps the number of unblocked issue IDs shown in what-if
 const MaxUnblockedIDsShown = 10
 
-// computeWhatIfDelta calculates the impact of completing an issue (bv-83)
-func (a *Analyzer) computeWhatIfDelta(issueID string) *WhatIfDelta {
-	stats := a.Analyze()
-	criticalPath := stats.CriticalPathScore()
+// computeWhatIfDelta calculates the impact of completing an issue (bv-83).
+// criticalPath is the analysis' CriticalPathScore; callers computing many
+// deltas pass it in rather than re-analyzing the graph for each issue.
+func (a *Analyzer) computeWhatIfDelta(issueID string, criticalPath map[string]float64) *WhatIfDelta {
 
 	// Get direct unblocks using existing method
 	directUnblocks := a.computeUnblocks(issueID)
@@ -901,8 +911,8 @@ func estimateDaysSaved(unblockedIDs []string, issueMap map[string]model.Issue) f
 
 	for _, id := range unblockedIDs {
 		if issue, ok := issueMap[id]; ok {
-			if issue.EstimatedMinutes != nil && *issue.EstimatedMinutes > 0 {
-				totalMinutes += *issue.EstimatedMinutes
+			if minutes, ok := issue.ExplicitEstimateMinutes(); ok {
+				totalMinutes += minutes
 				counted++
 			} else {
 				// Use default estimate for unestimated work
@@ -0,0 +1,220 @@
+package analysis
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// QueueGroupBy selects what a queue is in queue analysis
+type QueueGroupBy string
+
+const (
+	QueueByLabel QueueGroupBy = "label"
+	QueueByTrack QueueGroupBy = "track" // Execution-plan tracks
+)
+
+// QueueOptions controls queue analysis
+type QueueOptions struct {
+	GroupBy    QueueGroupBy // Default label
+	WindowDays int          // History window for rates (default 90)
+	MinSamples int          // Completions needed for a service rate (default 2)
+}
+
+// QueueStats models one label or track as an M/M/c queue: issues arrive
+// when created, are served from claim (or creation) to close, and c is the
+// number of people who closed or are working issues in the queue.
+type QueueStats struct {
+	Name         string  `json:"name"`
+	ArrivalRate  float64 `json:"arrival_rate_per_day"` // λ: issues created per day
+	ServiceRate  float64 `json:"service_rate_per_day"` // μ: issues one worker closes per day
+	Servers      int     `json:"servers"`              // c: concurrent workers
+	CycleDays    float64 `json:"cycle_days"`           // Mean claim-to-close days
+	Arrivals     int     `json:"arrivals"`
+	Completions  int     `json:"completions"`
+	Backlog      int     `json:"backlog"` // Open issues waiting
+	InProgress   int     `json:"in_progress"`
+	Utilization  float64 `json:"utilization"` // ρ = λ / (c·μ); ≥ 1 means the backlog grows
+	Stable       bool    `json:"stable"`
+	Insufficient bool    `json:"insufficient_data,omitempty"`
+	// ExpectedWaitDays is the Erlang C wait before work starts; nil when unstable
+	ExpectedWaitDays *float64 `json:"expected_wait_days,omitempty"`
+	// BacklogGrowthPerDay is λ - c·μ for unstable queues
+	BacklogGrowthPerDay float64 `json:"backlog_growth_per_day,omitempty"`
+}
+
+// QueueAnalysis is the result of queueing-theory bottleneck analysis (--robot-queues)
+type QueueAnalysis struct {
+	GroupBy    QueueGroupBy `json:"group_by"`
+	WindowDays int          `json:"window_days"`
+	Queues     []QueueStats `json:"queues"` // Most utilized first
+	// Constraint is the most utilized queue with enough data: the one that
+	// limits the whole system's throughput
+	Constraint       string `json:"constraint,omitempty"`
+	ConstraintReason string `json:"constraint_reason,omitempty"`
+}
+
+// AnalyzeQueues models each label (or track) as a queue, estimating arrival
+// rates from created_at history and service rates from cycle times within the
+// window, and picks the system constraint.
+func AnalyzeQueues(issues []model.Issue, analyzer *Analyzer, opts QueueOptions, now time.Time) QueueAnalysis {
+	if opts.GroupBy == "" {
+		opts.GroupBy = QueueByLabel
+	}
+	if opts.WindowDays <= 0 {
+		opts.WindowDays = 90
+	}
+	if opts.MinSamples <= 0 {
+		opts.MinSamples = 2
+	}
+	result := QueueAnalysis{GroupBy: opts.GroupBy, WindowDays: opts.WindowDays, Queues: []QueueStats{}}
+	since := now.AddDate(0, 0, -opts.WindowDays)
+
+	var tracks map[string]string
+	if opts.GroupBy == QueueByTrack && analyzer != nil {
+		tracks = analyzer.GetTrackMembership()
+	}
+	queuesOf := func(issue *model.Issue) []string {
+		if opts.GroupBy == QueueByTrack {
+			if track := tracks[issue.ID]; track != "" {
+				return []string{track}
+			}
+			return nil
+		}
+		return issue.Labels
+	}
+
+	type acc struct {
+		stats    QueueStats
+		cycleSum float64
+		workers  map[string]bool
+	}
+	queues := make(map[string]*acc)
+	for i := range issues {
+		issue := &issues[i]
+		if issue.Status.IsTombstone() {
+			continue
+		}
+		tl := issue.Timeline()
+		for _, name := range queuesOf(issue) {
+			q := queues[name]
+			if q == nil {
+				q = &acc{stats: QueueStats{Name: name}, workers: make(map[string]bool)}
+				queues[name] = q
+			}
+			if tl.Opened != nil && !tl.Opened.Before(since) && !tl.Opened.After(now) {
+				q.stats.Arrivals++
+			}
+			worker := strings.ToLower(strings.TrimSpace(issue.Assignee))
+			switch {
+			case issue.Status.IsClosed():
+				if tl.Closed == nil || tl.Closed.Before(since) || tl.Closed.After(now) {
+					continue
+				}
+				start := tl.Opened
+				if tl.Claimed != nil {
+					start = tl.Claimed
+				}
+				if start == nil || tl.Closed.Before(*start) {
+					continue
+				}
+				q.stats.Completions++
+				q.cycleSum += tl.Closed.Sub(*start).Hours() / 24
+			case issue.Status == model.StatusInProgress, issue.Status == model.StatusInReview:
+				q.stats.InProgress++
+			default:
+				q.stats.Backlog++
+				continue
+			}
+			if worker != "" {
+				q.workers[worker] = true
+			}
+		}
+	}
+
+	for _, q := range queues {
+		s := q.stats
+		if s.Arrivals == 0 && s.Completions == 0 && s.Backlog == 0 && s.InProgress == 0 {
+			continue // Only closed work from before the window
+		}
+		s.ArrivalRate = round4(float64(s.Arrivals) / float64(opts.WindowDays))
+		s.Servers = max(len(q.workers), 1)
+		if s.Completions < opts.MinSamples {
+			s.Insufficient = true
+		} else {
+			// Same-day closes still take some time
+			s.CycleDays = max(q.cycleSum/float64(s.Completions), 1.0/24)
+			s.ServiceRate = 1 / s.CycleDays
+			lambda := float64(s.Arrivals) / float64(opts.WindowDays)
+			capacity := float64(s.Servers) * s.ServiceRate
+			s.Utilization = round2(lambda / capacity)
+			if lambda < capacity {
+				s.Stable = true
+				wait := round2(erlangCWait(lambda, s.ServiceRate, s.Servers))
+				s.ExpectedWaitDays = &wait
+			} else {
+				s.BacklogGrowthPerDay = round4(lambda - capacity)
+			}
+			s.CycleDays = round2(s.CycleDays)
+			s.ServiceRate = round4(s.ServiceRate)
+		}
+		result.Queues = append(result.Queues, s)
+	}
+
+	sort.Slice(result.Queues, func(i, j int) bool {
+		a, b := result.Queues[i], result.Queues[j]
+		if a.Insufficient != b.Insufficient {
+			return !a.Insufficient
+		}
+		if a.Utilization != b.Utilization {
+			return a.Utilization > b.Utilization
+		}
+		if a.Backlog != b.Backlog {
+			return a.Backlog > b.Backlog
+		}
+		return a.Name < b.Name
+	})
+
+	if len(result.Queues) > 0 && !result.Queues[0].Insufficient {
+		c := result.Queues[0]
+		result.Constraint = c.Name
+		if c.Stable {
+			result.ConstraintReason = "highest utilization; work waits longest here"
+		} else {
+			result.ConstraintReason = "arrivals outpace completions; its backlog grows without more capacity"
+		}
+	}
+	return result
+}
+
+// erlangCWait returns the M/M/c expected time in queue (Wq) for arrival rate
+// lambda, per-server service rate mu and c servers. Requires lambda < c·mu.
+func erlangCWait(lambda, mu float64, c int) float64 {
+	if lambda <= 0 {
+		return 0
+	}
+	a := lambda / mu // Offered load
+	rho := a / float64(c)
+	sum := 0.0
+	term := 1.0 // a^k / k!
+	for k := 0; k < c; k++ {
+		sum += term
+		term *= a / float64(k+1)
+	}
+	// term is now a^c / c!
+	tail := term / (1 - rho)
+	probWait := tail / (sum + tail)
+	wait := probWait / (float64(c)*mu - lambda)
+	if math.IsNaN(wait) || math.IsInf(wait, 0) {
+		return 0
+	}
+	return wait
+}
+
+// round4 keeps small per-day rates readable without flattening them to zero
+func round4(v float64) float64 {
+	return math.Round(v*10000) / 10000
+}
@@ -0,0 +1,103 @@
+package analysis
+
+import (
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestAnalyzeQueues(t *testing.T) {
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+	closed := func(id, label, who string, created, cycleDays int) model.Issue {
+		createdAt := now.Add(-time.Duration(created) * day)
+		closedAt := createdAt.Add(time.Duration(cycleDays) * day)
+		return model.Issue{ID: id, Status: model.StatusClosed, Labels: []string{label}, Assignee: who, CreatedAt: createdAt, ClosedAt: &closedAt}
+	}
+	open := func(id, label string, created int) model.Issue {
+		return model.Issue{ID: id, Status: model.StatusOpen, Labels: []string{label}, CreatedAt: now.Add(-time.Duration(created) * day)}
+	}
+
+	var issues []model.Issue
+	// api: 30 arrivals in 30 days, one worker taking 3 days each: ρ = 3
+	for i := range 30 {
+		if i < 4 {
+			issues = append(issues, closed(fmt.Sprintf("api-%d", i), "api", "ana", 30-i, 3))
+		} else {
+			issues = append(issues, open(fmt.Sprintf("api-%d", i), "api", 30-i))
+		}
+	}
+	// docs: 3 arrivals, two workers closing in a day: ρ = 0.05
+	issues = append(issues,
+		closed("doc-1", "docs", "bo", 20, 1),
+		closed("doc-2", "docs", "cy", 10, 1),
+		open("doc-3", "docs", 2),
+	)
+	// ux: too little history to model
+	issues = append(issues, open("ux-1", "ux", 5))
+
+	result := AnalyzeQueues(issues, nil, QueueOptions{WindowDays: 30}, now)
+
+	if len(result.Queues) != 3 {
+		t.Fatalf("expected 3 queues, got %+v", result.Queues)
+	}
+	api, docs, ux := result.Queues[0], result.Queues[1], result.Queues[2]
+	if api.Name != "api" || docs.Name != "docs" || ux.Name != "ux" {
+		t.Fatalf("order = %s, %s, %s; want api, docs, ux", api.Name, docs.Name, ux.Name)
+	}
+
+	if api.Arrivals != 30 || api.Completions != 4 || api.Backlog != 26 || api.Servers != 1 {
+		t.Errorf("api counts = %+v", api)
+	}
+	if api.CycleDays != 3 || api.Utilization != 3 || api.Stable || api.ExpectedWaitDays != nil {
+		t.Errorf("api should be unstable at ρ=3: %+v", api)
+	}
+	if math.Abs(api.BacklogGrowthPerDay-0.6667) > 0.0001 {
+		t.Errorf("api backlog growth = %.2f/day, want 1 - 1/3", api.BacklogGrowthPerDay)
+	}
+
+	if docs.Servers != 2 || !docs.Stable || docs.ExpectedWaitDays == nil || docs.Utilization != 0.05 {
+		t.Errorf("docs should be a lightly loaded two-worker queue: %+v", docs)
+	}
+	if !ux.Insufficient || ux.Utilization != 0 {
+		t.Errorf("ux has no completions and should be flagged: %+v", ux)
+	}
+
+	if result.Constraint != "api" || result.ConstraintReason == "" {
+		t.Errorf("constraint = %q (%s), want api", result.Constraint, result.ConstraintReason)
+	}
+}
+
+func TestAnalyzeQueues_ByTrack(t *testing.T) {
+	now := time.Now()
+	closedAt := now.Add(-time.Hour)
+	issues := []model.Issue{
+		{ID: "a", Status: model.StatusOpen, CreatedAt: now.Add(-48 * time.Hour)},
+		{ID: "b", Status: model.StatusClosed, CreatedAt: now.Add(-72 * time.Hour), ClosedAt: &closedAt,
+			Dependencies: []*model.Dependency{{IssueID: "b", DependsOnID: "a", Type: model.DepBlocks}}},
+	}
+	result := AnalyzeQueues(issues, NewAnalyzer(issues), QueueOptions{GroupBy: QueueByTrack}, now)
+	if result.GroupBy != QueueByTrack || len(result.Queues) != 1 {
+		t.Fatalf("expected one track queue, got %+v", result)
+	}
+	if q := result.Queues[0]; q.Arrivals != 2 || q.Backlog != 1 || q.Completions != 1 {
+		t.Errorf("track queue = %+v", q)
+	}
+}
+
+func TestErlangCWait(t *testing.T) {
+	// M/M/1: Wq = ρ / (μ - λ)
+	if got, want := erlangCWait(0.5, 1, 1), 1.0; math.Abs(got-want) > 1e-9 {
+		t.Errorf("M/M/1 wait = %v, want %v", got, want)
+	}
+	// More servers at the same load wait less
+	if erlangCWait(1, 1, 2) >= erlangCWait(0.5, 1, 1) {
+		t.Error("two servers at ρ=0.5 should wait less than one")
+	}
+	if erlangCWait(0, 1, 1) != 0 {
+		t.Error("no arrivals means no wait")
+	}
+}
@@ -0,0 +1,54 @@
+package analysis
+
+import (
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// ReplayFrame measures the tracker as of one revision, one point of a
+// replay's metric series
+type ReplayFrame struct {
+	Revision   string    `json:"revision"`
+	Timestamp  time.Time `json:"timestamp"`
+	Message    string    `json:"message,omitempty"`
+	Total      int       `json:"total"`
+	Open       int       `json:"open"`       // Not closed
+	Actionable int       `json:"actionable"` // Open with no open blocker
+	Blocked    int       `json:"blocked"`    // Status blocked
+	Closed     int       `json:"closed"`
+	Edges      int       `json:"edges"`  // Blocking dependencies between known issues
+	Cycles     int       `json:"cycles"` // Dependency cycles (capped at 100)
+}
+
+// NewReplayFrame measures issues as committed at a revision. Counts match
+// the TUI footer; cycle detection is the only graph analysis run, so frames
+// stay cheap across a long history.
+func NewReplayFrame(issues []model.Issue, revision string, timestamp time.Time, message string) ReplayFrame {
+	frame := ReplayFrame{Revision: revision, Timestamp: timestamp, Message: message, Total: len(issues)}
+
+	issueMap := make(map[string]*model.Issue, len(issues))
+	for i := range issues {
+		issueMap[issues[i].ID] = &issues[i]
+	}
+	readiness := NewReadinessIndex(issueMap)
+	frame.Open, frame.Actionable, frame.Blocked, frame.Closed = readiness.Open, readiness.Ready, readiness.Blocked, readiness.Closed
+
+	for _, issue := range issues {
+		for _, dep := range issue.Dependencies {
+			if dep != nil && dep.Type.IsBlocking() && issueMap[dep.DependsOnID] != nil {
+				frame.Edges++
+			}
+		}
+	}
+
+	if frame.Edges > 0 {
+		stats := NewAnalyzer(issues).AnalyzeWithConfig(AnalysisConfig{
+			ComputeCycles:    true,
+			CyclesTimeout:    500 * time.Millisecond,
+			MaxCyclesToStore: 100,
+		})
+		frame.Cycles = len(stats.Cycles())
+	}
+	return frame
+}
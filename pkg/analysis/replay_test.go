@@ -0,0 +1,36 @@
+package analysis
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestNewReplayFrame(t *testing.T) {
+	blocks := func(id string) []*model.Dependency {
+		return []*model.Dependency{{DependsOnID: id, Type: model.DepBlocks}}
+	}
+	issues := []model.Issue{
+		{ID: "A", Status: model.StatusOpen, Dependencies: blocks("B")},
+		{ID: "B", Status: model.StatusOpen, Dependencies: blocks("A")},
+		{ID: "C", Status: model.StatusBlocked, Dependencies: blocks("gone")},
+		{ID: "D", Status: model.StatusClosed},
+		{ID: "E", Status: model.StatusInProgress},
+	}
+	at := time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC)
+
+	frame := NewReplayFrame(issues, "abc123", at, "week 1")
+	if frame.Total != 5 || frame.Open != 4 || frame.Closed != 1 || frame.Blocked != 1 || frame.Actionable != 1 {
+		t.Errorf("counts = %+v", frame)
+	}
+	if frame.Edges != 2 {
+		t.Errorf("edges = %d, want 2 (the dangling dependency doesn't count)", frame.Edges)
+	}
+	if frame.Cycles != 1 {
+		t.Errorf("cycles = %d, want 1", frame.Cycles)
+	}
+	if frame.Revision != "abc123" || !frame.Timestamp.Equal(at) || frame.Message != "week 1" {
+		t.Errorf("frame metadata = %+v", frame)
+	}
+}
@@ -0,0 +1,193 @@
+package analysis
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// ReviewItem is an issue waiting in review
+type ReviewItem struct {
+	ID       string    `json:"id"`
+	Title    string    `json:"title"`
+	Assignee string    `json:"assignee,omitempty"`
+	Labels   []string  `json:"labels,omitempty"`
+	Since    time.Time `json:"since"`
+	AgeDays  float64   `json:"age_days"`
+}
+
+// ReviewLatency summarizes how long closed issues spent from entering review
+// to closing
+type ReviewLatency struct {
+	Count      int     `json:"count"`
+	MeanDays   float64 `json:"mean_days"`
+	MedianDays float64 `json:"median_days"`
+	P90Days    float64 `json:"p90_days"`
+	MaxDays    float64 `json:"max_days"`
+}
+
+// ReviewLabelStats is the review queue and latency of one label
+type ReviewLabelStats struct {
+	Label      string  `json:"label"`
+	Waiting    int     `json:"waiting"`
+	OldestDays float64 `json:"oldest_days"`
+	MedianDays float64 `json:"median_latency_days"` // 0 without reviewed closes
+	Reviewed   int     `json:"reviewed"`
+}
+
+// ReviewStats is the review queue and review latency (--robot-review)
+type ReviewStats struct {
+	WindowDays int `json:"window_days"`
+	// Queue holds the issues in review, longest waiting first
+	Queue      []ReviewItem  `json:"queue"`
+	OldestDays float64       `json:"oldest_days"`
+	Latency    ReviewLatency `json:"latency"`
+	// ReviewShare is the part of claim-to-close cycle time spent in review,
+	// over the reviewed issues
+	ReviewShare float64            `json:"review_share"`
+	ByLabel     []ReviewLabelStats `json:"by_label"`
+}
+
+// ReviewEnteredAt returns when an issue last moved into in_review, from its
+// recorded events, falling back to its last update for issues in review
+// without events. It returns nil if the issue never was in review.
+func ReviewEnteredAt(issue *model.Issue) *time.Time {
+	var at *time.Time
+	for _, e := range issue.Events {
+		if e != nil && !e.CreatedAt.IsZero() && e.ToStatus() == string(model.StatusInReview) {
+			t := e.CreatedAt
+			at = &t
+		}
+	}
+	if at == nil && issue.Status == model.StatusInReview && !issue.UpdatedAt.IsZero() {
+		t := issue.UpdatedAt
+		at = &t
+	}
+	return at
+}
+
+// ComputeReviewStats lists the issues in review and measures review latency:
+// for issues closed within the window, the time from first entering review
+// (see model.Issue.Timeline) to closing.
+func ComputeReviewStats(issues []model.Issue, windowDays int, now time.Time) ReviewStats {
+	if windowDays <= 0 {
+		windowDays = 90
+	}
+	stats := ReviewStats{WindowDays: windowDays, Queue: []ReviewItem{}, ByLabel: []ReviewLabelStats{}}
+	since := now.AddDate(0, 0, -windowDays)
+
+	type labelAcc struct {
+		stats     ReviewLabelStats
+		latencies []float64
+	}
+	labels := make(map[string]*labelAcc)
+	labelOf := func(name string) *labelAcc {
+		if labels[name] == nil {
+			labels[name] = &labelAcc{stats: ReviewLabelStats{Label: name}}
+		}
+		return labels[name]
+	}
+
+	var latencies []float64
+	var reviewSum, cycleSum float64
+	for i := range issues {
+		issue := &issues[i]
+		switch {
+		case issue.Status == model.StatusInReview:
+			entered := ReviewEnteredAt(issue)
+			if entered == nil {
+				continue
+			}
+			item := ReviewItem{
+				ID:       issue.ID,
+				Title:    issue.Title,
+				Assignee: issue.Assignee,
+				Labels:   issue.Labels,
+				Since:    *entered,
+				AgeDays:  round2(math.Max(now.Sub(*entered).Hours()/24, 0)),
+			}
+			stats.Queue = append(stats.Queue, item)
+			for _, label := range issue.Labels {
+				acc := labelOf(label)
+				acc.stats.Waiting++
+				acc.stats.OldestDays = math.Max(acc.stats.OldestDays, item.AgeDays)
+			}
+
+		case issue.Status.IsClosed():
+			tl := issue.Timeline()
+			if tl.InReview == nil || tl.Closed == nil || tl.Closed.Before(since) || tl.Closed.After(now) || tl.Closed.Before(*tl.InReview) {
+				continue
+			}
+			days := tl.Closed.Sub(*tl.InReview).Hours() / 24
+			latencies = append(latencies, days)
+			for _, label := range issue.Labels {
+				acc := labelOf(label)
+				acc.stats.Reviewed++
+				acc.latencies = append(acc.latencies, days)
+			}
+			start := tl.Opened
+			if tl.Claimed != nil {
+				start = tl.Claimed
+			}
+			if start != nil && !tl.InReview.Before(*start) {
+				reviewSum += days
+				cycleSum += tl.Closed.Sub(*start).Hours() / 24
+			}
+		}
+	}
+
+	sort.Slice(stats.Queue, func(i, j int) bool {
+		if stats.Queue[i].AgeDays != stats.Queue[j].AgeDays {
+			return stats.Queue[i].AgeDays > stats.Queue[j].AgeDays
+		}
+		return stats.Queue[i].ID < stats.Queue[j].ID
+	})
+	if len(stats.Queue) > 0 {
+		stats.OldestDays = stats.Queue[0].AgeDays
+	}
+	stats.Latency = reviewLatency(latencies)
+	if cycleSum > 0 {
+		stats.ReviewShare = round2(reviewSum / cycleSum)
+	}
+
+	for _, acc := range labels {
+		acc.stats.MedianDays = reviewLatency(acc.latencies).MedianDays
+		stats.ByLabel = append(stats.ByLabel, acc.stats)
+	}
+	sort.Slice(stats.ByLabel, func(i, j int) bool {
+		a, b := stats.ByLabel[i], stats.ByLabel[j]
+		if a.OldestDays != b.OldestDays {
+			return a.OldestDays > b.OldestDays
+		}
+		if a.Waiting != b.Waiting {
+			return a.Waiting > b.Waiting
+		}
+		return a.Label < b.Label
+	})
+	return stats
+}
+
+func reviewLatency(days []float64) ReviewLatency {
+	if len(days) == 0 {
+		return ReviewLatency{}
+	}
+	sorted := append([]float64(nil), days...)
+	sort.Float64s(sorted)
+	sum := 0.0
+	for _, d := range sorted {
+		sum += d
+	}
+	percentile := func(p float64) float64 {
+		idx := int(math.Ceil(p*float64(len(sorted)))) - 1
+		return sorted[max(idx, 0)]
+	}
+	return ReviewLatency{
+		Count:      len(sorted),
+		MeanDays:   round2(sum / float64(len(sorted))),
+		MedianDays: round2(percentile(0.50)),
+		P90Days:    round2(percentile(0.90)),
+		MaxDays:    round2(sorted[len(sorted)-1]),
+	}
+}
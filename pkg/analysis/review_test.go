@@ -0,0 +1,53 @@
+package analysis
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestComputeReviewStats(t *testing.T) {
+	now := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+	day := func(n int) time.Time { return now.AddDate(0, 0, -n) }
+	moved := func(status string, at time.Time) *model.IssueEvent {
+		return &model.IssueEvent{EventType: model.EventStatusChanged, NewValue: status, CreatedAt: at}
+	}
+	reviewed := func(id string, claimed, review, closed int) model.Issue {
+		closedAt := day(closed)
+		return model.Issue{ID: id, Status: model.StatusClosed, Labels: []string{"api"}, ClosedAt: &closedAt,
+			Events: []*model.IssueEvent{
+				moved("in_progress", day(claimed)),
+				moved("needs_review", day(review)),
+				{EventType: model.EventClosed, CreatedAt: day(closed)},
+			}}
+	}
+	issues := []model.Issue{
+		// Sent back once: the queue age counts from the latest move into review
+		{ID: "bv-1", Title: "Parser", Status: model.StatusInReview, Labels: []string{"api"},
+			Events: []*model.IssueEvent{moved("in_review", day(9)), moved("in_progress", day(8)), moved("in_review", day(5))}},
+		{ID: "bv-2", Title: "Docs", Status: model.StatusInReview, UpdatedAt: day(1)},
+		{ID: "bv-3", Status: model.StatusOpen},
+		reviewed("bv-4", 10, 6, 4), // 2 days in review of 6
+		reviewed("bv-5", 10, 8, 2), // 6 of 8
+		reviewed("bv-6", 200, 190, 180),
+	}
+
+	stats := ComputeReviewStats(issues, 30, now)
+	if len(stats.Queue) != 2 || stats.Queue[0].ID != "bv-1" || stats.Queue[0].AgeDays != 5 || stats.Queue[1].AgeDays != 1 {
+		t.Fatalf("queue = %+v", stats.Queue)
+	}
+	if stats.OldestDays != 5 {
+		t.Errorf("oldest = %v, want 5", stats.OldestDays)
+	}
+	// bv-6 closed before the window
+	if l := stats.Latency; l.Count != 2 || l.MeanDays != 4 || l.MedianDays != 2 || l.P90Days != 6 || l.MaxDays != 6 {
+		t.Errorf("latency = %+v", l)
+	}
+	if stats.ReviewShare != 0.57 {
+		t.Errorf("review share = %v, want 8/14", stats.ReviewShare)
+	}
+	if len(stats.ByLabel) != 1 || stats.ByLabel[0] != (ReviewLabelStats{Label: "api", Waiting: 1, OldestDays: 5, MedianDays: 2, Reviewed: 2}) {
+		t.Errorf("by label = %+v", stats.ByLabel)
+	}
+}
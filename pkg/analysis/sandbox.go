@@ -0,0 +1,283 @@
+package analysis
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// SandboxChangeKind is a kind of hypothetical edit in the sandbox
+type SandboxChangeKind string
+
+const (
+	SandboxClose  SandboxChangeKind = "close"
+	SandboxDefer  SandboxChangeKind = "defer"
+	SandboxAddDep SandboxChangeKind = "add_dep"
+)
+
+// SandboxChange is one hypothetical edit. For SandboxAddDep, IssueID comes
+// to wait on DependsOnID.
+type SandboxChange struct {
+	Kind        SandboxChangeKind `json:"kind"`
+	IssueID     string            `json:"issue_id"`
+	DependsOnID string            `json:"depends_on_id,omitempty"`
+}
+
+// String describes the change
+func (c SandboxChange) String() string {
+	switch c.Kind {
+	case SandboxClose:
+		return "close " + c.IssueID
+	case SandboxDefer:
+		return "defer " + c.IssueID
+	case SandboxAddDep:
+		return fmt.Sprintf("%s waits on %s", c.IssueID, c.DependsOnID)
+	}
+	return string(c.Kind) + " " + c.IssueID
+}
+
+// Command returns the bd command that makes the change for real, with every
+// ID quoted for the shell
+func (c SandboxChange) Command() string {
+	switch c.Kind {
+	case SandboxClose:
+		return "bd close " + shellQuote(c.IssueID)
+	case SandboxDefer:
+		return fmt.Sprintf("bd update %s --status=deferred", shellQuote(c.IssueID))
+	case SandboxAddDep:
+		return fmt.Sprintf("bd dep add %s %s", shellQuote(c.IssueID), shellQuote(c.DependsOnID))
+	}
+	return ""
+}
+
+// shellQuote wraps s in single quotes, so the shell takes it literally
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// SandboxScript returns the changes as a bd shell script, in the order they
+// were made
+func SandboxScript(changes []SandboxChange, now time.Time) string {
+	// A line break in an ID would end the comment and run the rest
+	oneLine := strings.NewReplacer("\r", " ", "\n", " ")
+	var sb strings.Builder
+	sb.WriteString("#!/usr/bin/env bash\n")
+	sb.WriteString(fmt.Sprintf("# Generated by the bv sandbox at %s\n", now.UTC().Format(time.RFC3339)))
+	sb.WriteString("set -euo pipefail\n\n")
+	for _, c := range changes {
+		sb.WriteString(fmt.Sprintf("%s  # %s\n", c.Command(), oneLine.Replace(c.String())))
+	}
+	return sb.String()
+}
+
+// ApplySandbox returns a copy of issues with the changes made, leaving
+// issues untouched. Changes naming unknown issues are ignored.
+func ApplySandbox(issues []model.Issue, changes []SandboxChange, now time.Time) []model.Issue {
+	out := slices.Clone(issues)
+	index := make(map[string]int, len(out))
+	for i := range out {
+		index[out[i].ID] = i
+	}
+	for _, c := range changes {
+		i, ok := index[c.IssueID]
+		if !ok {
+			continue
+		}
+		issue := &out[i]
+		switch c.Kind {
+		case SandboxClose:
+			closedAt := now
+			issue.Status = model.StatusClosed
+			issue.ClosedAt = &closedAt
+		case SandboxDefer:
+			issue.Status = model.StatusDeferred
+		case SandboxAddDep:
+			if _, ok := index[c.DependsOnID]; !ok || c.DependsOnID == c.IssueID {
+				continue
+			}
+			issue.Dependencies = append(slices.Clone(issue.Dependencies), &model.Dependency{
+				IssueID:     c.IssueID,
+				DependsOnID: c.DependsOnID,
+				Type:        model.DepBlocks,
+				CreatedAt:   now,
+			})
+		}
+	}
+	return out
+}
+
+// SandboxMetrics is the state of the plan, forecast and critical path for
+// one version of the tracker
+type SandboxMetrics struct {
+	Open       int `json:"open"` // Not closed, including deferred
+	Actionable int `json:"actionable"`
+	Blocked    int `json:"blocked"`
+	// Parked issues are deferred or wait on deferred work; the forecast and
+	// critical path leave them out
+	Parked int `json:"parked"`
+	Cycles int `json:"cycles"`
+	// TopPick is the plan's highest-impact actionable issue
+	TopPick          string   `json:"top_pick,omitempty"`
+	CriticalPath     []string `json:"critical_path"`
+	CriticalPathDays float64  `json:"critical_path_days"`
+	// Forecast is the simulated completion of the remaining unparked work
+	Forecast CompletionDistribution `json:"forecast"`
+	// Stuck issues wait on a dependency cycle and never finish
+	Stuck []string `json:"stuck"`
+
+	actionable map[string]bool
+	cycleKeys  map[string][]string
+}
+
+// ComputeSandboxMetrics analyzes issues for the sandbox, simulating the
+// remaining work on the given number of generic agents
+func ComputeSandboxMetrics(issues []model.Issue, agents int, now time.Time) SandboxMetrics {
+	analyzer := NewAnalyzer(issues)
+	stats := analyzer.Analyze()
+	plan := analyzer.GetExecutionPlan()
+
+	m := SandboxMetrics{
+		Actionable:   plan.TotalActionable,
+		TopPick:      plan.Summary.HighestImpact,
+		CriticalPath: []string{},
+		Stuck:        []string{},
+		actionable:   make(map[string]bool),
+		cycleKeys:    make(map[string][]string),
+	}
+	for _, issue := range analyzer.GetActionableIssues() {
+		m.actionable[issue.ID] = true
+	}
+	for _, cycle := range stats.Cycles() {
+		m.cycleKeys[normalizeCycle(cycle)] = cycle
+	}
+	m.Cycles = len(m.cycleKeys)
+
+	// Deferred issues and everything waiting on them, transitively
+	waitingOn := make(map[string][]string)
+	var parked []string
+	for i := range issues {
+		issue := &issues[i]
+		if issue.Status.IsClosed() || issue.Status.IsTombstone() {
+			continue
+		}
+		m.Open++
+		if issue.Status == model.StatusDeferred {
+			parked = append(parked, issue.ID)
+		}
+		for _, dep := range issue.Dependencies {
+			if dep != nil && dep.Type.IsBlocking() {
+				waitingOn[dep.DependsOnID] = append(waitingOn[dep.DependsOnID], issue.ID)
+			}
+		}
+	}
+	isParked := make(map[string]bool)
+	for len(parked) > 0 {
+		id := parked[0]
+		parked = parked[1:]
+		if isParked[id] {
+			continue
+		}
+		isParked[id] = true
+		parked = append(parked, waitingOn[id]...)
+	}
+
+	var active []model.Issue
+	for i := range issues {
+		issue := &issues[i]
+		if issue.Status.IsClosed() || issue.Status.IsTombstone() {
+			continue
+		}
+		if isParked[issue.ID] {
+			m.Parked++
+			continue
+		}
+		active = append(active, *issue)
+	}
+	m.Blocked = m.Open - m.Actionable - m.Parked
+	if m.Blocked < 0 {
+		m.Blocked = 0
+	}
+
+	path, minutes := CriticalPathMinutes(active, &stats)
+	if path != nil {
+		m.CriticalPath = path
+	}
+	m.CriticalPathDays = round2(float64(minutes) / treeMinutesPerDay)
+
+	profiles := &AgentProfiles{}
+	for i := range max(agents, 1) {
+		profiles.Agents = append(profiles.Agents, AgentProfile{Name: fmt.Sprintf("agent-%d", i+1)})
+	}
+	_ = profiles.Validate()
+	sim := SimulateCapacity(active, &stats, profiles, CapacitySimOptions{Runs: 200}, now)
+	m.Forecast = sim.Completion
+	m.Stuck = sim.Stranded
+	return m
+}
+
+// SandboxDiff compares the tracker before and after the sandbox changes
+type SandboxDiff struct {
+	Changes []SandboxChange `json:"changes"`
+	Before  SandboxMetrics  `json:"before"`
+	After   SandboxMetrics  `json:"after"`
+	// Unblocked issues become actionable; NewlyBlocked ones stop being
+	// actionable without being closed or deferred
+	Unblocked      []string   `json:"unblocked"`
+	NewlyBlocked   []string   `json:"newly_blocked"`
+	NewCycles      [][]string `json:"new_cycles"`
+	ResolvedCycles int        `json:"resolved_cycles"`
+}
+
+// CompareSandbox diffs the metrics of the tracker before the changes with
+// the metrics after them. issuesAfter is the result of ApplySandbox.
+func CompareSandbox(before, after SandboxMetrics, issuesAfter []model.Issue, changes []SandboxChange) SandboxDiff {
+	d := SandboxDiff{
+		Changes:      changes,
+		Before:       before,
+		After:        after,
+		Unblocked:    []string{},
+		NewlyBlocked: []string{},
+		NewCycles:    [][]string{},
+	}
+	status := make(map[string]model.Status, len(issuesAfter))
+	for _, issue := range issuesAfter {
+		status[issue.ID] = issue.Status
+	}
+	for id := range after.actionable {
+		if !before.actionable[id] {
+			d.Unblocked = append(d.Unblocked, id)
+		}
+	}
+	for id := range before.actionable {
+		if !after.actionable[id] && !status[id].IsClosed() && status[id] != model.StatusDeferred {
+			d.NewlyBlocked = append(d.NewlyBlocked, id)
+		}
+	}
+	sort.Strings(d.Unblocked)
+	sort.Strings(d.NewlyBlocked)
+
+	for key, cycle := range after.cycleKeys {
+		if _, ok := before.cycleKeys[key]; !ok {
+			d.NewCycles = append(d.NewCycles, cycle)
+		}
+	}
+	sortCycles(d.NewCycles)
+	for key := range before.cycleKeys {
+		if _, ok := after.cycleKeys[key]; !ok {
+			d.ResolvedCycles++
+		}
+	}
+	return d
+}
+
+// SimulateSandbox applies the changes to a copy of issues and compares the
+// result with before, the metrics of the unchanged issues
+func SimulateSandbox(issues []model.Issue, before SandboxMetrics, changes []SandboxChange, agents int, now time.Time) SandboxDiff {
+	changed := ApplySandbox(issues, changes, now)
+	after := ComputeSandboxMetrics(changed, agents, now)
+	return CompareSandbox(before, after, changed, changes)
+}
@@ -0,0 +1,82 @@
+package analysis
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestSimulateSandbox(t *testing.T) {
+	now := time.Date(2026, 10, 15, 9, 0, 0, 0, time.UTC)
+	minutes := func(n int) *int { return &n }
+	dep := func(on string) []*model.Dependency {
+		return []*model.Dependency{{DependsOnID: on, Type: model.DepBlocks}}
+	}
+	// bv-1 → bv-2 → bv-3 is the critical path; bv-4 stands alone
+	issues := []model.Issue{
+		{ID: "bv-1", Title: "Schema", Status: model.StatusOpen, Priority: 1, EstimatedMinutes: minutes(960)},
+		{ID: "bv-2", Title: "Parser", Status: model.StatusOpen, Priority: 1, EstimatedMinutes: minutes(960), Dependencies: dep("bv-1")},
+		{ID: "bv-3", Title: "Docs", Status: model.StatusOpen, Priority: 2, EstimatedMinutes: minutes(480), Dependencies: dep("bv-2")},
+		{ID: "bv-4", Title: "Logo", Status: model.StatusOpen, Priority: 3, EstimatedMinutes: minutes(240)},
+	}
+	before := ComputeSandboxMetrics(issues, 1, now)
+	if before.Actionable != 2 || before.Blocked != 2 || before.CriticalPathDays != 5 || strings.Join(before.CriticalPath, ",") != "bv-1,bv-2,bv-3" {
+		t.Fatalf("before = %+v", before)
+	}
+
+	// Closing bv-1 unblocks bv-2 and shortens the path
+	closed := SimulateSandbox(issues, before, []SandboxChange{{Kind: SandboxClose, IssueID: "bv-1"}}, 1, now)
+	if closed.After.CriticalPathDays != 3 || len(closed.Unblocked) != 1 || closed.Unblocked[0] != "bv-2" {
+		t.Errorf("close: after = %+v, unblocked %v", closed.After, closed.Unblocked)
+	}
+	if !closed.After.Forecast.P50Date.Before(before.Forecast.P50Date) {
+		t.Errorf("closing work should pull the forecast in: %v → %v", before.Forecast.P50Date, closed.After.Forecast.P50Date)
+	}
+	if issues[0].Status != model.StatusOpen {
+		t.Fatal("the sandbox must not modify the issues")
+	}
+
+	// Deferring bv-2 parks it and bv-3 without counting them as blocked
+	deferred := SimulateSandbox(issues, before, []SandboxChange{{Kind: SandboxDefer, IssueID: "bv-2"}}, 1, now)
+	if a := deferred.After; a.Parked != 2 || a.Blocked != 0 || a.CriticalPathDays != 2 || len(deferred.NewlyBlocked) != 0 {
+		t.Errorf("defer: after = %+v, newly blocked %v", a, deferred.NewlyBlocked)
+	}
+
+	// A new edge can block work and close a cycle
+	edges := []SandboxChange{
+		{Kind: SandboxAddDep, IssueID: "bv-4", DependsOnID: "bv-3"},
+		{Kind: SandboxAddDep, IssueID: "bv-1", DependsOnID: "bv-3"},
+	}
+	cyclic := SimulateSandbox(issues, before, edges, 1, now)
+	if len(cyclic.NewlyBlocked) != 2 || len(cyclic.NewCycles) != 1 || len(cyclic.After.Stuck) == 0 {
+		t.Errorf("edges: newly blocked %v, new cycles %v, stuck %v", cyclic.NewlyBlocked, cyclic.NewCycles, cyclic.After.Stuck)
+	}
+	if len(issues[3].Dependencies) != 0 {
+		t.Fatal("adding an edge must not touch the original dependencies")
+	}
+
+	script := SandboxScript(append([]SandboxChange{{Kind: SandboxClose, IssueID: "bv-1"}, {Kind: SandboxDefer, IssueID: "bv-2"}}, edges[0]), now)
+	for _, want := range []string{"#!/usr/bin/env bash", "bd close 'bv-1'", "bd update 'bv-2' --status=deferred", "bd dep add 'bv-4' 'bv-3'  # bv-4 waits on bv-3"} {
+		if !strings.Contains(script, want) {
+			t.Errorf("script missing %q:\n%s", want, script)
+		}
+	}
+}
+
+func TestSandboxScriptQuotesIDs(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	script := SandboxScript([]SandboxChange{
+		{Kind: SandboxClose, IssueID: "bv 1$(rm -rf x)"},
+		{Kind: SandboxAddDep, IssueID: "it's", DependsOnID: "bv-2\nrm -rf x"},
+	}, now)
+	for _, want := range []string{
+		`bd close 'bv 1$(rm -rf x)'  # close bv 1$(rm -rf x)` + "\n",
+		`bd dep add 'it'\''s' 'bv-2` + "\n" + `rm -rf x'  # it's waits on bv-2 rm -rf x` + "\n",
+	} {
+		if !strings.Contains(script, want) {
+			t.Errorf("script missing %q:\n%s", want, script)
+		}
+	}
+}
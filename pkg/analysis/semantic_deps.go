@@ -0,0 +1,228 @@
+package analysis
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// SemanticDepConfig configures semantic dependency suggestions
+type SemanticDepConfig struct {
+	// MinSimilarity is the cosine similarity a pair needs to be considered
+	// Default: 0.5
+	MinSimilarity float64
+
+	// MinConfidence is the minimum combined confidence to report
+	// Default: 0.5
+	MinConfidence float64
+
+	// MaxSuggestions limits the number of suggestions
+	// Default: 20
+	MaxSuggestions int
+}
+
+// DefaultSemanticDepConfig returns sensible defaults
+func DefaultSemanticDepConfig() SemanticDepConfig {
+	return SemanticDepConfig{
+		MinSimilarity:  0.5,
+		MinConfidence:  0.5,
+		MaxSuggestions: 20,
+	}
+}
+
+// SemanticDepSuggestion is a likely missing link between two open issues.
+// For "blocks", From depends on To.
+type SemanticDepSuggestion struct {
+	From        string   `json:"from"`
+	FromTitle   string   `json:"from_title"`
+	To          string   `json:"to"`
+	ToTitle     string   `json:"to_title"`
+	Type        string   `json:"type"` // "blocks" or "related"
+	Confidence  float64  `json:"confidence"`
+	Similarity  float64  `json:"similarity"`
+	FileOverlap float64  `json:"file_overlap"` // Jaccard overlap of touched files
+	SharedFiles []string `json:"shared_files,omitempty"`
+	Reason      string   `json:"reason"`
+	Command     string   `json:"command"`
+}
+
+// SuggestSemanticDependencies finds pairs of open issues with no dependency
+// edge that read alike (vectors: issue ID -> embedding) and, when file
+// history is available (files: issue ID -> touched paths), changed the same
+// files. Confidence weighs similarity 70/30 against file overlap, so pairs
+// with both signals rank first.
+func SuggestSemanticDependencies(issues []model.Issue, vectors map[string][]float32, files map[string][]string, config SemanticDepConfig) []SemanticDepSuggestion {
+	if config.MaxSuggestions <= 0 {
+		config.MaxSuggestions = 20
+	}
+
+	var open []*model.Issue
+	linked := make(map[[2]string]bool)
+	for i := range issues {
+		issue := &issues[i]
+		for _, dep := range issue.Dependencies {
+			if dep != nil {
+				linked[pairKey(issue.ID, dep.DependsOnID)] = true
+			}
+		}
+		if issue.Status.IsClosed() || issue.Status.IsTombstone() || len(vectors[issue.ID]) == 0 {
+			continue
+		}
+		open = append(open, issue)
+	}
+
+	fileSets := make(map[string]map[string]bool, len(files))
+	for id, paths := range files {
+		set := make(map[string]bool, len(paths))
+		for _, p := range paths {
+			set[p] = true
+		}
+		fileSets[id] = set
+	}
+
+	var suggestions []SemanticDepSuggestion
+	for i, a := range open {
+		for _, b := range open[i+1:] {
+			if linked[pairKey(a.ID, b.ID)] {
+				continue
+			}
+			sim := cosineSimilarity(vectors[a.ID], vectors[b.ID])
+			if sim < config.MinSimilarity {
+				continue
+			}
+
+			shared, overlap := fileOverlap(fileSets[a.ID], fileSets[b.ID])
+			mentioned := mentionsID(a, b.ID) || mentionsID(b, a.ID)
+			confidence := 0.7*sim + 0.3*overlap
+			if mentioned {
+				confidence += 0.1
+			}
+			confidence = math.Min(confidence, 0.95)
+			if confidence < config.MinConfidence {
+				continue
+			}
+
+			s := SemanticDepSuggestion{
+				Confidence:  round2(confidence),
+				Similarity:  round2(sim),
+				FileOverlap: round2(overlap),
+				SharedFiles: limitPaths(shared, 5),
+			}
+			from, to, blocks := semanticDepDirection(a, b)
+			s.From, s.FromTitle, s.To, s.ToTitle = from.ID, from.Title, to.ID, to.Title
+			if blocks {
+				s.Type = string(model.DepBlocks)
+				s.Command = fmt.Sprintf("bd dep add %s %s", from.ID, to.ID)
+			} else {
+				s.Type = string(model.DepRelated)
+				s.Command = fmt.Sprintf("bd dep add %s %s --type related", from.ID, to.ID)
+			}
+
+			reasons := []string{fmt.Sprintf("%.0f%% similar text", sim*100)}
+			if len(shared) > 0 {
+				reasons = append(reasons, fmt.Sprintf("%d shared files", len(shared)))
+			}
+			if mentioned {
+				reasons = append(reasons, "one mentions the other")
+			}
+			s.Reason = strings.Join(reasons, ", ")
+			suggestions = append(suggestions, s)
+		}
+	}
+
+	sort.Slice(suggestions, func(i, j int) bool {
+		a, b := suggestions[i], suggestions[j]
+		if a.Confidence != b.Confidence {
+			return a.Confidence > b.Confidence
+		}
+		if a.From != b.From {
+			return a.From < b.From
+		}
+		return a.To < b.To
+	})
+	if len(suggestions) > config.MaxSuggestions {
+		suggestions = suggestions[:config.MaxSuggestions]
+	}
+	return suggestions
+}
+
+// semanticDepDirection picks which issue should depend on the other and
+// whether the link is a blocker. An issue that mentions the other's ID
+// depends on it; a feature depends on the task, bug or chore it shares work
+// with. Anything else, including pairs involving an epic, is just related.
+func semanticDepDirection(a, b *model.Issue) (from, to *model.Issue, blocks bool) {
+	switch {
+	case mentionsID(a, b.ID) && !mentionsID(b, a.ID):
+		return a, b, true
+	case mentionsID(b, a.ID) && !mentionsID(a, b.ID):
+		return b, a, true
+	}
+	if a.IssueType != model.TypeEpic && b.IssueType != model.TypeEpic {
+		aFeature, bFeature := a.IssueType == model.TypeFeature, b.IssueType == model.TypeFeature
+		if aFeature && !bFeature {
+			return a, b, true
+		}
+		if bFeature && !aFeature {
+			return b, a, true
+		}
+	}
+	if a.ID < b.ID {
+		return a, b, false
+	}
+	return b, a, false
+}
+
+func mentionsID(issue *model.Issue, id string) bool {
+	id = strings.ToLower(id)
+	return strings.Contains(strings.ToLower(issue.Title), id) ||
+		strings.Contains(strings.ToLower(issue.Description), id)
+}
+
+func pairKey(a, b string) [2]string {
+	if a > b {
+		a, b = b, a
+	}
+	return [2]string{a, b}
+}
+
+func cosineSimilarity(a, b []float32) float64 {
+	if len(a) == 0 || len(a) != len(b) {
+		return 0
+	}
+	var dot, normA, normB float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		normA += float64(a[i]) * float64(a[i])
+		normB += float64(b[i]) * float64(b[i])
+	}
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(normA) * math.Sqrt(normB))
+}
+
+// fileOverlap returns the sorted shared paths and their Jaccard overlap
+func fileOverlap(a, b map[string]bool) ([]string, float64) {
+	if len(a) == 0 || len(b) == 0 {
+		return nil, 0
+	}
+	var shared []string
+	for p := range a {
+		if b[p] {
+			shared = append(shared, p)
+		}
+	}
+	sort.Strings(shared)
+	union := len(a) + len(b) - len(shared)
+	return shared, float64(len(shared)) / float64(union)
+}
+
+func limitPaths(paths []string, n int) []string {
+	if len(paths) > n {
+		return paths[:n]
+	}
+	return paths
+}
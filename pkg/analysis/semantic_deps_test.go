@@ -0,0 +1,100 @@
+package analysis
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestSuggestSemanticDependencies(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "feat", Title: "Export reports as CSV", Status: model.StatusOpen, IssueType: model.TypeFeature},
+		{ID: "task", Title: "Add CSV writer", Status: model.StatusOpen, IssueType: model.TypeTask},
+		{ID: "bug", Title: "Login fails", Description: "Probably needs task first", Status: model.StatusOpen, IssueType: model.TypeBug},
+		{ID: "linked", Title: "Already linked", Status: model.StatusOpen, IssueType: model.TypeTask,
+			Dependencies: []*model.Dependency{{IssueID: "linked", DependsOnID: "feat", Type: model.DepBlocks}}},
+		{ID: "done", Title: "Closed twin", Status: model.StatusClosed, IssueType: model.TypeTask},
+	}
+	vectors := map[string][]float32{
+		"feat":   {1, 0, 0},
+		"task":   {0.9, 0.1, 0},
+		"bug":    {0.6, 0.8, 0},
+		"linked": {1, 0, 0},
+		"done":   {1, 0, 0},
+	}
+	files := map[string][]string{
+		"feat": {"pkg/export/csv.go", "pkg/export/export.go"},
+		"task": {"pkg/export/csv.go"},
+	}
+
+	got := SuggestSemanticDependencies(issues, vectors, files, SemanticDepConfig{MinSimilarity: 0.5, MinConfidence: 0.3})
+
+	pairs := make(map[string]SemanticDepSuggestion)
+	for _, s := range got {
+		pairs[s.From+">"+s.To] = s
+		if s.From == "done" || s.To == "done" {
+			t.Errorf("closed issues should be skipped: %+v", s)
+		}
+		if (s.From == "linked" || s.To == "linked") && (s.From == "feat" || s.To == "feat") {
+			t.Errorf("already-linked pair suggested: %+v", s)
+		}
+	}
+
+	ft, ok := pairs["feat>task"]
+	if !ok {
+		t.Fatalf("expected feat to depend on task, got %+v", got)
+	}
+	if ft.Type != string(model.DepBlocks) || ft.Command != "bd dep add feat task" {
+		t.Errorf("feature should be blocked by its task: %+v", ft)
+	}
+	if ft.FileOverlap != 0.5 || strings.Join(ft.SharedFiles, ",") != "pkg/export/csv.go" {
+		t.Errorf("file overlap = %.2f %v, want 0.5 [pkg/export/csv.go]", ft.FileOverlap, ft.SharedFiles)
+	}
+	if got[0].From != "feat" || got[0].To != "task" {
+		t.Errorf("text and file overlap should rank first, got %+v", got[0])
+	}
+
+	// The bug mentions task, so it depends on it even without shared files
+	bt, ok := pairs["bug>task"]
+	if !ok || bt.Type != string(model.DepBlocks) || !strings.Contains(bt.Reason, "mentions") {
+		t.Errorf("expected bug to depend on task via its mention, got %+v", bt)
+	}
+}
+
+func TestSuggestSemanticDependencies_RelatedAndLimits(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "a", Status: model.StatusOpen, IssueType: model.TypeTask},
+		{ID: "b", Status: model.StatusOpen, IssueType: model.TypeTask},
+		{ID: "c", Status: model.StatusOpen, IssueType: model.TypeEpic},
+		{ID: "d", Status: model.StatusOpen, IssueType: model.TypeTask},
+	}
+	vectors := map[string][]float32{
+		"a": {1, 0},
+		"b": {1, 0},
+		"c": {1, 0},
+		"d": {0, 1}, // Unrelated
+	}
+
+	got := SuggestSemanticDependencies(issues, vectors, nil, DefaultSemanticDepConfig())
+	if len(got) != 3 {
+		t.Fatalf("expected the three a/b/c pairs, got %+v", got)
+	}
+	for _, s := range got {
+		if s.Type != string(model.DepRelated) || !strings.HasSuffix(s.Command, "--type related") {
+			t.Errorf("peer tasks and epics should only be related: %+v", s)
+		}
+		if s.Confidence != 0.7 || s.FileOverlap != 0 {
+			t.Errorf("without file history confidence is 0.7·similarity: %+v", s)
+		}
+	}
+
+	limited := SuggestSemanticDependencies(issues, vectors, nil, SemanticDepConfig{MinSimilarity: 0.5, MinConfidence: 0.5, MaxSuggestions: 1})
+	if len(limited) != 1 || limited[0].From != "a" || limited[0].To != "b" {
+		t.Errorf("MaxSuggestions should keep the first pair by ID, got %+v", limited)
+	}
+
+	if got := SuggestSemanticDependencies(issues, nil, nil, DefaultSemanticDepConfig()); len(got) != 0 {
+		t.Errorf("no vectors should mean no suggestions, got %+v", got)
+	}
+}
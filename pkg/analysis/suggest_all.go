@@ -108,8 +108,8 @@ func GenerateAllSuggestions(issues []model.Issue, config SuggestAllConfig, dataH
 		filtered = append(filtered, sug)
 	}
 
-	// Sort by confidence (highest first)
-	sort.Slice(filtered, func(i, j int) bool {
+	// Sort by confidence (highest first), keeping detector order on ties
+	sort.SliceStable(filtered, func(i, j int) bool {
 		return filtered[i].Confidence > filtered[j].Confidence
 	})
 
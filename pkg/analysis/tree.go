@@ -0,0 +1,128 @@
+package analysis
+
+import (
+	"sort"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// DependencyTree is an issue's full upstream blocker tree and downstream
+// unblock tree (--robot-tree and the TUI tree view), with the open work
+// each branch holds.
+type DependencyTree struct {
+	ID             string               `json:"id"`
+	Title          string               `json:"title"`
+	Status         string               `json:"status"`
+	EstimatedDays  float64              `json:"estimated_days"`
+	Upstream       []DependencyTreeNode `json:"upstream"`        // What it waits on, recursively
+	Downstream     []DependencyTreeNode `json:"downstream"`      // What waits on it, recursively
+	UpstreamDays   float64              `json:"upstream_days"`   // Open work before it can start
+	DownstreamDays float64              `json:"downstream_days"` // Open work waiting on it
+}
+
+// DependencyTreeNode is one issue in a dependency tree. An issue reachable
+// by several paths is expanded, and its work counted, where it first
+// appears; later appearances are marked Repeat and have no children.
+type DependencyTreeNode struct {
+	ID            string               `json:"id"`
+	Title         string               `json:"title"`
+	Status        string               `json:"status"`
+	Priority      int                  `json:"priority"`
+	EstimatedDays float64              `json:"estimated_days"` // This issue's open work; 0 once closed
+	Estimated     bool                 `json:"estimated"`      // False when the median estimate stands in
+	BranchDays    float64              `json:"branch_days"`    // Open work in this issue and its subtree
+	Repeat        bool                 `json:"repeat,omitempty"`
+	Children      []DependencyTreeNode `json:"children,omitempty"`
+}
+
+// treeMinutesPerDay converts estimates to days of work (an 8-hour day)
+const treeMinutesPerDay = 480.0
+
+// DependencyTree builds the upstream and downstream trees of issueID, or
+// returns nil if the issue is unknown. Closed blockers end their branch:
+// whatever they waited on no longer holds anything up.
+func (a *Analyzer) DependencyTree(issueID string) *DependencyTree {
+	issue, ok := a.issueMap[issueID]
+	if !ok {
+		return nil
+	}
+	median := a.computeMedianEstimatedMinutes()
+	days, _ := treeIssueDays(issue, median)
+	tree := &DependencyTree{
+		ID:            issue.ID,
+		Title:         issue.Title,
+		Status:        string(issue.Status),
+		EstimatedDays: days,
+		Upstream:      []DependencyTreeNode{},
+		Downstream:    []DependencyTreeNode{},
+	}
+
+	upstream := func(id string) []string {
+		if a.issueMap[id].Status == model.StatusClosed && id != issueID {
+			return nil
+		}
+		return a.GetBlockers(id)
+	}
+	if nodes, days := a.treeChildren(issueID, upstream, map[string]bool{issueID: true}, median); nodes != nil {
+		tree.Upstream, tree.UpstreamDays = nodes, days
+	}
+	if nodes, days := a.treeChildren(issueID, a.Dependents, map[string]bool{issueID: true}, median); nodes != nil {
+		tree.Downstream, tree.DownstreamDays = nodes, days
+	}
+	return tree
+}
+
+// treeChildren expands the issues next(id) returns, depth first, and sums
+// their branch days
+func (a *Analyzer) treeChildren(id string, next func(string) []string, seen map[string]bool, median int) ([]DependencyTreeNode, float64) {
+	ids := next(id)
+	if len(ids) == 0 {
+		return nil, 0
+	}
+	ids = append([]string(nil), ids...)
+	sort.Slice(ids, func(i, j int) bool {
+		pi, pj := a.issueMap[ids[i]].Priority, a.issueMap[ids[j]].Priority
+		if pi != pj {
+			return pi < pj
+		}
+		return ids[i] < ids[j]
+	})
+
+	nodes := make([]DependencyTreeNode, 0, len(ids))
+	total := 0.0
+	for _, childID := range ids {
+		issue := a.issueMap[childID]
+		node := DependencyTreeNode{
+			ID:       issue.ID,
+			Title:    issue.Title,
+			Status:   string(issue.Status),
+			Priority: issue.Priority,
+		}
+		node.EstimatedDays, node.Estimated = treeIssueDays(issue, median)
+		if seen[childID] {
+			node.Repeat = true
+			nodes = append(nodes, node)
+			continue
+		}
+		seen[childID] = true
+		var below float64
+		node.Children, below = a.treeChildren(childID, next, seen, median)
+		node.BranchDays = round2(node.EstimatedDays + below)
+		total += node.BranchDays
+		nodes = append(nodes, node)
+	}
+	return nodes, round2(total)
+}
+
+// treeIssueDays is the open work left in issue, from its estimate or the
+// median one, and whether it has its own estimate
+func treeIssueDays(issue model.Issue, median int) (float64, bool) {
+	minutes, ok := issue.ExplicitEstimateMinutes()
+	if issue.Status == model.StatusClosed {
+		return 0, ok
+	}
+	if !ok {
+		minutes = median
+	}
+	return round2(float64(minutes) / treeMinutesPerDay), ok
+}
@@ -0,0 +1,68 @@
+package analysis
+
+import (
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestDependencyTree(t *testing.T) {
+	blocks := func(ids ...string) []*model.Dependency {
+		deps := make([]*model.Dependency, len(ids))
+		for i, id := range ids {
+			deps[i] = &model.Dependency{DependsOnID: id, Type: model.DepBlocks}
+		}
+		return deps
+	}
+	hours := func(h int) *int { m := h * 60; return &m }
+	issues := []model.Issue{
+		{ID: "old", Title: "Done long ago", Status: model.StatusClosed},
+		{ID: "A", Title: "Schema", Status: model.StatusClosed, Priority: 3, Dependencies: blocks("old"), EstimatedMinutes: hours(8)},
+		{ID: "B", Title: "Parser", Status: model.StatusOpen, Priority: 1, EstimatedMinutes: hours(16)},
+		{ID: "C", Title: "Loader", Status: model.StatusInProgress, Priority: 2, Dependencies: blocks("B"), EstimatedMinutes: hours(4)},
+		{ID: "X", Title: "Migrate", Status: model.StatusOpen, Dependencies: blocks("A", "C", "B"), EstimatedMinutes: hours(8)},
+		{ID: "Y", Title: "Docs", Status: model.StatusOpen, Priority: 3, Dependencies: blocks("X"), EstimatedMinutes: hours(4)},
+		{ID: "Z", Title: "Release", Status: model.StatusOpen, Dependencies: blocks("X", "Y"), EstimatedMinutes: hours(8)},
+	}
+	tree := NewAnalyzer(issues).DependencyTree("X")
+	if tree == nil {
+		t.Fatal("tree of a known issue is nil")
+	}
+
+	// Upstream: B (P1) first, then C, then A; C's child B is a repeat, and
+	// the closed A is not expanded down to "old".
+	up := tree.Upstream
+	if len(up) != 3 || up[0].ID != "B" || up[1].ID != "C" || up[2].ID != "A" {
+		t.Fatalf("upstream = %+v, want B, C, A", up)
+	}
+	if len(up[1].Children) != 1 || !up[1].Children[0].Repeat || up[1].BranchDays != 0.5 {
+		t.Errorf("C = %+v, want a repeated B under it and 0.5 days", up[1])
+	}
+	if len(up[2].Children) != 0 || up[2].EstimatedDays != 0 {
+		t.Errorf("closed A = %+v, want no children and no open work", up[2])
+	}
+	if tree.UpstreamDays != 2.5 {
+		t.Errorf("upstream days = %v, want 2.5 (B counted once)", tree.UpstreamDays)
+	}
+
+	// Downstream: Z (P0) before Y (P3), and Z again under Y.
+	down := tree.Downstream
+	if len(down) != 2 || down[0].ID != "Z" || down[1].ID != "Y" {
+		t.Fatalf("downstream = %+v, want Z, Y", down)
+	}
+	if len(down[1].Children) != 1 || !down[1].Children[0].Repeat {
+		t.Errorf("Y = %+v, want Z repeated under it", down[1])
+	}
+	if tree.DownstreamDays != 1.5 {
+		t.Errorf("downstream days = %v, want 1.5", tree.DownstreamDays)
+	}
+
+	// A leaf has empty, not nil, trees so --robot-tree emits [] for them
+	if leaf := NewAnalyzer(issues).DependencyTree("old"); leaf.Upstream == nil || leaf.Downstream == nil {
+		t.Errorf("leaf tree = %+v, want empty upstream and downstream", leaf)
+	}
+
+	if NewAnalyzer(issues).DependencyTree("missing") != nil {
+		t.Error("an unknown issue should have no tree")
+	}
+}
@@ -53,6 +53,10 @@ type TopPick struct {
 	Score    float64  `json:"score"`
 	Reasons  []string `json:"reasons"`
 	Unblocks int      `json:"unblocks"` // How many items this unblocks
+
+	// Set when the pick is leased in .bv/claims.jsonl (bv claim)
+	ClaimedBy    string     `json:"claimed_by,omitempty"`
+	ClaimedUntil *time.Time `json:"claimed_until,omitempty"`
 }
 
 // Recommendation is an actionable item with full context
@@ -69,6 +73,16 @@ type Recommendation struct {
 	Reasons     []string       `json:"reasons"`
 	UnblocksIDs []string       `json:"unblocks_ids,omitempty"`
 	BlockedBy   []string       `json:"blocked_by,omitempty"`
+
+	// Namespaced scores from .bv/plugins analyzers, e.g. "security:risk"
+	PluginScores map[string]float64 `json:"plugin_scores,omitempty"`
+
+	// Set when the item is leased in .bv/claims.jsonl (bv claim)
+	ClaimedBy    string     `json:"claimed_by,omitempty"`
+	ClaimedUntil *time.Time `json:"claimed_until,omitempty"`
+
+	// Set when accept/ignore feedback materially moves this item's rank
+	FeedbackNote string `json:"feedback_note,omitempty"`
 }
 
 // QuickWin represents a low-effort, high-impact item
@@ -692,9 +706,12 @@ func buildBlockersToClear(analyzer *Analyzer, unblocksMap map[string][]string, l
 		})
 	}
 
-	// Sort by unblocks count descending
+	// Sort by unblocks count descending, then ID for a stable order
 	sort.Slice(blockers, func(i, j int) bool {
-		return len(blockers[i].unblocks) > len(blockers[j].unblocks)
+		if len(blockers[i].unblocks) != len(blockers[j].unblocks) {
+			return len(blockers[i].unblocks) > len(blockers[j].unblocks)
+		}
+		return blockers[i].id < blockers[j].id
 	})
 
 	result := make([]BlockerItem, 0, limit)
@@ -116,6 +116,10 @@ type EnhancedPriorityRecommendation struct {
 
 	// Explanation provides detailed reasoning
 	Explanation PriorityExplanation `json:"explanation"`
+
+	// FeedbackNote is set when accept/ignore feedback materially moves this
+	// item's impact rank
+	FeedbackNote string `json:"feedback_note,omitempty"`
 }
 
 // GenerateEnhancedRecommendations generates recommendations with what-if deltas
@@ -123,15 +127,29 @@ func (a *Analyzer) GenerateEnhancedRecommendations() []EnhancedPriorityRecommend
 	return a.GenerateEnhancedRecommendationsWithThresholds(DefaultThresholds())
 }
 
+// GenerateEnhancedRecommendationsAt generates recommendations with what-if
+// deltas as of a specific time
+func (a *Analyzer) GenerateEnhancedRecommendationsAt(now time.Time) []EnhancedPriorityRecommendation {
+	return a.GenerateEnhancedRecommendationsWithThresholdsAt(DefaultThresholds(), now)
+}
+
 // GenerateEnhancedRecommendationsWithThresholds generates enhanced recommendations
 func (a *Analyzer) GenerateEnhancedRecommendationsWithThresholds(thresholds RecommendationThresholds) []EnhancedPriorityRecommendation {
-	scores := a.ComputeImpactScores()
+	return a.GenerateEnhancedRecommendationsWithThresholdsAt(thresholds, time.Now())
+}
+
+// GenerateEnhancedRecommendationsWithThresholdsAt generates enhanced
+// recommendations as of a specific time
+func (a *Analyzer) GenerateEnhancedRecommendationsWithThresholdsAt(thresholds RecommendationThresholds, now time.Time) []EnhancedPriorityRecommendation {
+	scores := a.ComputeImpactScoresAt(now)
 	if len(scores) == 0 {
 		return nil
 	}
 
 	// Get basic recommendations
-	basicRecs := a.GenerateRecommendationsWithThresholds(thresholds)
+	basicRecs := a.GenerateRecommendationsWithThresholdsAt(thresholds, now)
+	stats := a.Analyze()
+	criticalPath := stats.CriticalPathScore()
 
 	// Create a map for quick lookup
 	recMap := make(map[string]*PriorityRecommendation)
@@ -140,14 +158,14 @@ func (a *Analyzer) GenerateEnhancedRecommendationsWithThresholds(thresholds Reco
 	}
 
 	var enhanced []EnhancedPriorityRecommendation
-	now := time.Now().UTC()
+	now = now.UTC()
 
 	// Enhance each score with what-if deltas
 	for _, score := range scores {
 		rec, hasRec := recMap[score.IssueID]
 
 		// Generate what-if for all scores (not just those with recommendations)
-		whatIf := a.computeWhatIfDelta(score.IssueID)
+		whatIf := a.computeWhatIfDelta(score.IssueID, criticalPath)
 		topReasons := GenerateTopReasons(score)
 
 		// Determine if caps were applied
@@ -231,12 +249,14 @@ func (a *Analyzer) TopWhatIfDeltas(n int) []WhatIfEntry {
 	}
 
 	var results []WhatIfEntry
+	stats := a.Analyze()
+	criticalPath := stats.CriticalPathScore()
 
 	for id, issue := range a.issueMap {
 		if issue.Status == model.StatusClosed {
 			continue
 		}
-		delta := a.computeWhatIfDelta(id)
+		delta := a.computeWhatIfDelta(id, criticalPath)
 		if delta == nil {
 			continue
 		}
@@ -0,0 +1,371 @@
+// Package bench times graph analysis on synthetic dependency graphs and
+// compares the timings against a stored baseline, so slow paths are caught
+// before they ship. It backs the hidden `bv bench` command and the
+// benchmarks in this package.
+package bench
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/testutil"
+)
+
+// Topology is a shape of synthetic dependency graph
+type Topology string
+
+const (
+	// Chain is one long dependency chain (deepest critical path)
+	Chain Topology = "chain"
+	// Star is a single blocker every other issue depends on (highest degree)
+	Star Topology = "star"
+	// RandomDAG is a sparse random DAG with 1-3 dependencies per issue
+	RandomDAG Topology = "random-dag"
+	// Clustered is groups of 100 tightly linked issues with a few
+	// cross-group links, like epics
+	Clustered Topology = "clustered"
+)
+
+// Topologies lists every topology, in report order
+var Topologies = []Topology{Chain, Star, RandomDAG, Clustered}
+
+// DefaultSizes are the issue counts benchmarked when none are given
+var DefaultSizes = []int{1000, 10000}
+
+// DefaultThreshold is the relative slowdown counted as a regression
+const DefaultThreshold = 0.25
+
+// DefaultFloor is the absolute slowdown below which a metric never counts
+// as regressed, so timer noise on sub-millisecond metrics is ignored
+const DefaultFloor = 5 * time.Millisecond
+
+// Metrics are the timed phases, in report order. Each is a Phase 1 or
+// Phase 2 metric of analysis.StartupProfile, plus the analyzer build, the
+// whole analysis, and the triage and advanced insights built on top of it
+// (where per-issue slow paths tend to creep in).
+var Metrics = []string{
+	"build_graph", "degree", "topo_sort",
+	"pagerank", "betweenness", "eigenvector", "hits", "critical_path", "cycles", "kcore", "slack",
+	"analysis_total", "triage", "advanced_insights",
+}
+
+// Generate builds the synthetic issues for topology t with size issues
+// (clustered sizes round down to whole clusters of 100). Generation is
+// deterministic.
+func Generate(t Topology, size int) ([]model.Issue, error) {
+	if size < 1 {
+		return nil, fmt.Errorf("invalid size %d: want at least 1 issue", size)
+	}
+	gen := testutil.New(testutil.GeneratorConfig{
+		Seed:      42,
+		IDPrefix:  "BENCH",
+		StatusMix: []model.Status{model.StatusOpen, model.StatusOpen, model.StatusInProgress, model.StatusClosed},
+	})
+	switch t {
+	case Chain:
+		return gen.ToIssues(gen.Chain(size)), nil
+	case Star:
+		return gen.ToIssues(gen.Star(size - 1)), nil
+	case RandomDAG:
+		return gen.ToIssues(gen.SparseDAG(size, 3)), nil
+	case Clustered:
+		const clusterSize = 100
+		clusters := max(1, size/clusterSize)
+		return gen.ToIssues(gen.Clustered(clusters, min(size, clusterSize), 3)), nil
+	default:
+		return nil, fmt.Errorf("unknown topology %q (want %s)", t, joinTopologies(Topologies))
+	}
+}
+
+// ParseTopologies parses a comma-separated topology list; empty means all
+func ParseTopologies(s string) ([]Topology, error) {
+	if strings.TrimSpace(s) == "" {
+		return Topologies, nil
+	}
+	var out []Topology
+	for _, part := range strings.Split(s, ",") {
+		t := Topology(strings.TrimSpace(part))
+		known := false
+		for _, k := range Topologies {
+			known = known || k == t
+		}
+		if !known {
+			return nil, fmt.Errorf("unknown topology %q (want %s)", t, joinTopologies(Topologies))
+		}
+		out = append(out, t)
+	}
+	return out, nil
+}
+
+func joinTopologies(ts []Topology) string {
+	names := make([]string, len(ts))
+	for i, t := range ts {
+		names[i] = string(t)
+	}
+	return strings.Join(names, ", ")
+}
+
+// Result is the timing of one topology at one size
+type Result struct {
+	Topology Topology `json:"topology"`
+	Size     int      `json:"size"`
+	Nodes    int      `json:"nodes"`
+	Edges    int      `json:"edges"`
+	Runs     int      `json:"runs"`
+
+	// Metrics maps each metric to its median time in milliseconds
+	Metrics map[string]float64 `json:"metrics_ms"`
+
+	// TimedOut lists metrics that hit their analysis timeout in any run
+	TimedOut []string `json:"timed_out,omitempty"`
+}
+
+// Key identifies the scenario, e.g. "chain/1000"
+func (r Result) Key() string {
+	return fmt.Sprintf("%s/%d", r.Topology, r.Size)
+}
+
+// Report is a full benchmark run, and the format baselines are stored in
+type Report struct {
+	// Version for schema compatibility
+	Version int `json:"version"`
+
+	// CreatedAt is when the run finished
+	CreatedAt time.Time `json:"created_at"`
+
+	// Environment the timings were taken in; comparisons across machines
+	// are only indicative
+	GoVersion string `json:"go_version"`
+	Platform  string `json:"platform"`
+	CPUs      int    `json:"cpus"`
+
+	Results []Result `json:"results"`
+}
+
+// CurrentVersion is the report schema version
+const CurrentVersion = 1
+
+// Run times every topology at every size, taking the median of runs
+// analyses of each
+func Run(topologies []Topology, sizes []int, runs int) (*Report, error) {
+	if runs < 1 {
+		runs = 1
+	}
+	report := &Report{
+		Version:   CurrentVersion,
+		GoVersion: runtime.Version(),
+		Platform:  runtime.GOOS + "/" + runtime.GOARCH,
+		CPUs:      runtime.NumCPU(),
+	}
+	for _, t := range topologies {
+		for _, size := range sizes {
+			issues, err := Generate(t, size)
+			if err != nil {
+				return nil, err
+			}
+			result := Measure(issues, runs)
+			result.Topology = t
+			result.Size = size
+			report.Results = append(report.Results, result)
+		}
+	}
+	report.CreatedAt = time.Now().UTC()
+	return report, nil
+}
+
+// Measure times the analysis of issues runs times and returns the median
+// of each metric. Topology and Size are left for the caller to fill in.
+func Measure(issues []model.Issue, runs int) Result {
+	if runs < 1 {
+		runs = 1
+	}
+	samples := make(map[string][]time.Duration, len(Metrics))
+	timedOut := make(map[string]bool)
+	var nodes, edges int
+
+	for i := 0; i < runs; i++ {
+		start := time.Now()
+		analyzer := analysis.NewAnalyzer(issues)
+		build := time.Since(start)
+
+		stats, profile := analyzer.AnalyzeWithProfile(analysis.ConfigForSize(len(issues), countBlockingEdges(issues)))
+		nodes, edges = profile.NodeCount, profile.EdgeCount
+
+		start = time.Now()
+		analysis.ComputeTriageFromAnalyzer(analyzer, stats, issues, analysis.TriageOptions{}, start)
+		triage := time.Since(start)
+
+		start = time.Now()
+		analyzer.GenerateAdvancedInsights(analysis.DefaultAdvancedInsightsConfig())
+		insights := time.Since(start)
+
+		for metric, d := range map[string]time.Duration{
+			"build_graph":       build,
+			"degree":            profile.Degree,
+			"topo_sort":         profile.TopoSort,
+			"pagerank":          profile.PageRank,
+			"betweenness":       profile.Betweenness,
+			"eigenvector":       profile.Eigenvector,
+			"hits":              profile.HITS,
+			"critical_path":     profile.CriticalPath,
+			"cycles":            profile.Cycles,
+			"kcore":             profile.KCore,
+			"slack":             profile.Slack,
+			"analysis_total":    profile.Total,
+			"triage":            triage,
+			"advanced_insights": insights,
+		} {
+			samples[metric] = append(samples[metric], d)
+		}
+		for metric, to := range map[string]bool{
+			"pagerank":    profile.PageRankTO,
+			"betweenness": profile.BetweennessTO,
+			"hits":        profile.HITSTO,
+			"cycles":      profile.CyclesTO,
+		} {
+			timedOut[metric] = timedOut[metric] || to
+		}
+	}
+
+	result := Result{Nodes: nodes, Edges: edges, Runs: runs, Metrics: make(map[string]float64, len(Metrics))}
+	for metric, ds := range samples {
+		result.Metrics[metric] = milliseconds(median(ds))
+	}
+	for _, metric := range Metrics {
+		if timedOut[metric] {
+			result.TimedOut = append(result.TimedOut, metric)
+		}
+	}
+	return result
+}
+
+// countBlockingEdges counts dependencies that become graph edges, for
+// choosing the size-based config the way the app does
+func countBlockingEdges(issues []model.Issue) int {
+	n := 0
+	for _, issue := range issues {
+		for _, dep := range issue.Dependencies {
+			if dep != nil && dep.Type.IsBlocking() {
+				n++
+			}
+		}
+	}
+	return n
+}
+
+func median(ds []time.Duration) time.Duration {
+	sorted := append([]time.Duration(nil), ds...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	return sorted[len(sorted)/2]
+}
+
+func milliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
+// Regression is a metric that got slower than the baseline allows
+type Regression struct {
+	Scenario   string  `json:"scenario"`
+	Metric     string  `json:"metric"`
+	BaselineMS float64 `json:"baseline_ms"`
+	CurrentMS  float64 `json:"current_ms"`
+	Ratio      float64 `json:"ratio"` // CurrentMS / BaselineMS
+	TimedOut   bool    `json:"timed_out,omitempty"`
+}
+
+func (r Regression) String() string {
+	if r.TimedOut {
+		return fmt.Sprintf("%s %s: timed out (baseline %.1fms)", r.Scenario, r.Metric, r.BaselineMS)
+	}
+	return fmt.Sprintf("%s %s: %.1fms -> %.1fms (%.2fx)", r.Scenario, r.Metric, r.BaselineMS, r.CurrentMS, r.Ratio)
+}
+
+// Compare returns the metrics of current slower than baseline by more than
+// threshold (0.25 = 25%) and more than floor in absolute terms, plus any
+// metric that now times out but did not in the baseline. Scenarios missing
+// from the baseline are skipped.
+func Compare(current, baseline *Report, threshold float64, floor time.Duration) []Regression {
+	base := make(map[string]Result, len(baseline.Results))
+	for _, r := range baseline.Results {
+		base[r.Key()] = r
+	}
+
+	var regressions []Regression
+	for _, cur := range current.Results {
+		prev, ok := base[cur.Key()]
+		if !ok {
+			continue
+		}
+		prevTimedOut := make(map[string]bool, len(prev.TimedOut))
+		for _, m := range prev.TimedOut {
+			prevTimedOut[m] = true
+		}
+		for _, metric := range Metrics {
+			was, ok := prev.Metrics[metric]
+			if !ok {
+				continue
+			}
+			now := cur.Metrics[metric]
+			timedOut := false
+			for _, m := range cur.TimedOut {
+				timedOut = timedOut || (m == metric && !prevTimedOut[m])
+			}
+			slower := now > was*(1+threshold) && now-was > milliseconds(floor)
+			if !slower && !timedOut {
+				continue
+			}
+			ratio := 0.0
+			if was > 0 {
+				ratio = now / was
+			}
+			regressions = append(regressions, Regression{
+				Scenario:   cur.Key(),
+				Metric:     metric,
+				BaselineMS: was,
+				CurrentMS:  now,
+				Ratio:      ratio,
+				TimedOut:   timedOut,
+			})
+		}
+	}
+	return regressions
+}
+
+// LoadReport reads a report (usually a baseline) from path
+func LoadReport(path string) (*Report, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading benchmark baseline: %w", err)
+	}
+	var r Report
+	if err := json.Unmarshal(data, &r); err != nil {
+		return nil, fmt.Errorf("parsing benchmark baseline %s: %w", path, err)
+	}
+	if r.Version > CurrentVersion {
+		return nil, fmt.Errorf("benchmark baseline %s has version %d, newer than supported %d", path, r.Version, CurrentVersion)
+	}
+	return &r, nil
+}
+
+// Save writes the report to path as indented JSON, creating directories
+func (r *Report) Save(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("creating baseline directory: %w", err)
+	}
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding benchmark report: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("writing benchmark baseline: %w", err)
+	}
+	return nil
+}
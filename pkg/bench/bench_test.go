@@ -0,0 +1,188 @@
+package bench
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// ============================================================================
+// Benchmarks
+// ============================================================================
+//
+// Run with: go test -run xxx -bench Analysis ./pkg/bench/
+//
+// Each scenario reports the per-metric median as "<metric>-ms" alongside
+// the usual ns/op (one full Measure).
+
+func BenchmarkAnalysis(b *testing.B) {
+	for _, topology := range Topologies {
+		for _, size := range DefaultSizes {
+			issues, err := Generate(topology, size)
+			if err != nil {
+				b.Fatal(err)
+			}
+			b.Run(fmt.Sprintf("%s/%d", topology, size), func(b *testing.B) {
+				var result Result
+				for i := 0; i < b.N; i++ {
+					result = Measure(issues, 1)
+				}
+				for _, metric := range Metrics {
+					b.ReportMetric(result.Metrics[metric], metric+"-ms")
+				}
+			})
+		}
+	}
+}
+
+// TestNoRegressions checks a fresh run against the baseline named by
+// BV_BENCH_BASELINE. It is skipped otherwise: timings are only comparable on
+// the machine that recorded the baseline.
+func TestNoRegressions(t *testing.T) {
+	path := os.Getenv("BV_BENCH_BASELINE")
+	if path == "" {
+		t.Skip("set BV_BENCH_BASELINE to a baseline from `bv bench --update-baseline`")
+	}
+	baseline, err := LoadReport(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	current, err := Run(Topologies, DefaultSizes, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, r := range Compare(current, baseline, DefaultThreshold, DefaultFloor) {
+		t.Errorf("regression: %s", r)
+	}
+}
+
+// ============================================================================
+// Unit Tests
+// ============================================================================
+
+func TestGenerate(t *testing.T) {
+	for _, topology := range Topologies {
+		issues, err := Generate(topology, 300)
+		if err != nil {
+			t.Fatalf("%s: %v", topology, err)
+		}
+		if len(issues) != 300 {
+			t.Errorf("%s: %d issues, want 300", topology, len(issues))
+		}
+		again, _ := Generate(topology, 300)
+		if dependsOn(issues) != dependsOn(again) {
+			t.Errorf("%s: generation is not deterministic", topology)
+		}
+	}
+	if _, err := Generate("mesh", 10); err == nil {
+		t.Error("unknown topology should fail")
+	}
+	if _, err := Generate(Chain, 0); err == nil {
+		t.Error("zero size should fail")
+	}
+}
+
+func dependsOn(issues []model.Issue) string {
+	var sb strings.Builder
+	for _, issue := range issues {
+		for _, dep := range issue.Dependencies {
+			fmt.Fprintf(&sb, "%s>%s ", issue.ID, dep.DependsOnID)
+		}
+	}
+	return sb.String()
+}
+
+func TestParseTopologies(t *testing.T) {
+	got, err := ParseTopologies("")
+	if err != nil || len(got) != len(Topologies) {
+		t.Errorf("empty = %v, %v; want all", got, err)
+	}
+	got, err = ParseTopologies("star, clustered")
+	if err != nil || fmt.Sprint(got) != "[star clustered]" {
+		t.Errorf("list = %v, %v", got, err)
+	}
+	if _, err := ParseTopologies("chain,mesh"); err == nil {
+		t.Error("unknown topology should fail")
+	}
+}
+
+func TestMeasure(t *testing.T) {
+	issues, _ := Generate(RandomDAG, 200)
+	r := Measure(issues, 3)
+	if r.Nodes != 200 || r.Edges == 0 || r.Runs != 3 {
+		t.Errorf("result = %d nodes, %d edges, %d runs", r.Nodes, r.Edges, r.Runs)
+	}
+	for _, metric := range Metrics {
+		if _, ok := r.Metrics[metric]; !ok {
+			t.Errorf("missing metric %s", metric)
+		}
+	}
+	if r.Metrics["analysis_total"] <= 0 {
+		t.Error("analysis_total should be positive")
+	}
+}
+
+func report(metrics map[string]float64, timedOut ...string) *Report {
+	return &Report{Version: CurrentVersion, Results: []Result{{Topology: Chain, Size: 1000, Metrics: metrics, TimedOut: timedOut}}}
+}
+
+func TestCompare(t *testing.T) {
+	baseline := report(map[string]float64{"pagerank": 100, "betweenness": 2, "hits": 50})
+
+	tests := []struct {
+		name    string
+		current *Report
+		want    []string
+	}{
+		{"unchanged", report(map[string]float64{"pagerank": 100, "betweenness": 2, "hits": 50}), nil},
+		{"within threshold", report(map[string]float64{"pagerank": 120, "betweenness": 2, "hits": 40}), nil},
+		{"slower", report(map[string]float64{"pagerank": 200, "betweenness": 2, "hits": 50}), []string{"pagerank"}},
+		{"small absolute change", report(map[string]float64{"pagerank": 100, "betweenness": 6, "hits": 50}), nil},
+		{"new timeout", report(map[string]float64{"pagerank": 100, "betweenness": 2, "hits": 50}, "hits"), []string{"hits"}},
+		{"new scenario", &Report{Results: []Result{{Topology: Star, Size: 1000, Metrics: map[string]float64{"pagerank": 900}}}}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, r := range Compare(tt.current, baseline, 0.25, 5*time.Millisecond) {
+				got = append(got, r.Metric)
+			}
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("regressions = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	// A timeout already in the baseline is not a new regression
+	if got := Compare(report(map[string]float64{"hits": 50}, "hits"), report(map[string]float64{"hits": 50}, "hits"), 0.25, 0); len(got) != 0 {
+		t.Errorf("existing timeout reported: %v", got)
+	}
+}
+
+func TestReportRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "baseline.json")
+	want := report(map[string]float64{"pagerank": 1.5})
+	want.CreatedAt = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	if err := want.Save(path); err != nil {
+		t.Fatal(err)
+	}
+	got, err := LoadReport(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Results[0].Key() != "chain/1000" || got.Results[0].Metrics["pagerank"] != 1.5 || !got.CreatedAt.Equal(want.CreatedAt) {
+		t.Errorf("round trip = %+v", got)
+	}
+
+	if err := os.WriteFile(path, []byte(`{"version": 99}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadReport(path); err == nil {
+		t.Error("newer schema version should fail")
+	}
+}
@@ -0,0 +1,215 @@
+// Package claims records short-lived leases on issues in .bv/claims.jsonl, so
+// concurrent agents and humans can see that someone has picked an item up
+// before its status flips to in_progress.
+//
+// The ledger is append-only: each line claims or releases an issue. A claim
+// lasts until its expiry, its release, or a newer claim on the same issue.
+package claims
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// Filename is the claims ledger filename inside .bv/
+const Filename = "claims.jsonl"
+
+// DefaultTTL is how long a claim lasts when no TTL is given
+const DefaultTTL = 2 * time.Hour
+
+// Event actions
+const (
+	ActionClaim   = "claim"
+	ActionRelease = "release"
+)
+
+// ErrNotClaimed is returned by Release when the issue has no active claim
+var ErrNotClaimed = errors.New("not claimed")
+
+// HeldError is returned by Acquire and Release when someone else holds the issue
+type HeldError struct {
+	Claim Claim
+}
+
+func (e *HeldError) Error() string {
+	return fmt.Sprintf("%s is claimed by %s until %s", e.Claim.IssueID, e.Claim.Actor, e.Claim.Until.Local().Format("2006-01-02 15:04"))
+}
+
+// Event is one line of the ledger
+type Event struct {
+	IssueID string    `json:"issue_id"`
+	Action  string    `json:"action"`
+	Actor   string    `json:"actor,omitempty"`
+	At      time.Time `json:"at"`
+	Until   time.Time `json:"until,omitempty"` // Expiry, for claims
+}
+
+// Claim is a lease on one issue
+type Claim struct {
+	IssueID   string    `json:"issue_id"`
+	Actor     string    `json:"actor"`
+	ClaimedAt time.Time `json:"claimed_at"`
+	Until     time.Time `json:"until"`
+}
+
+// Active reports whether the claim has not expired at now
+func (c Claim) Active(now time.Time) bool {
+	return now.Before(c.Until)
+}
+
+// Ledger is the replayed claims ledger: the latest claim per issue
+type Ledger struct {
+	claims map[string]Claim
+}
+
+// Path returns the claims ledger path for a project
+func Path(projectDir string) string {
+	return filepath.Join(projectDir, ".bv", Filename)
+}
+
+// Load replays the ledger at path. A missing file is an empty ledger;
+// malformed lines are skipped.
+func Load(path string) (*Ledger, error) {
+	ledger := &Ledger{claims: make(map[string]Claim)}
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return ledger, nil
+		}
+		return nil, fmt.Errorf("opening claims: %w", err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var e Event
+		if json.Unmarshal(scanner.Bytes(), &e) != nil || e.IssueID == "" {
+			continue
+		}
+		switch e.Action {
+		case ActionClaim:
+			ledger.claims[e.IssueID] = Claim{IssueID: e.IssueID, Actor: e.Actor, ClaimedAt: e.At, Until: e.Until}
+		case ActionRelease:
+			delete(ledger.claims, e.IssueID)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading claims: %w", err)
+	}
+	return ledger, nil
+}
+
+// Get returns the active claim on issueID at now, or nil
+func (l *Ledger) Get(issueID string, now time.Time) *Claim {
+	if l == nil {
+		return nil
+	}
+	c, ok := l.claims[issueID]
+	if !ok || !c.Active(now) {
+		return nil
+	}
+	return &c
+}
+
+// Active returns the claims still in force at now, soonest expiry first
+func (l *Ledger) Active(now time.Time) []Claim {
+	out := []Claim{}
+	if l == nil {
+		return out
+	}
+	for _, c := range l.claims {
+		if c.Active(now) {
+			out = append(out, c)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if !out[i].Until.Equal(out[j].Until) {
+			return out[i].Until.Before(out[j].Until)
+		}
+		return out[i].IssueID < out[j].IssueID
+	})
+	return out
+}
+
+// HeldByOther reports whether issueID has an active claim by someone other
+// than actor
+func (l *Ledger) HeldByOther(issueID, actor string, now time.Time) bool {
+	c := l.Get(issueID, now)
+	return c != nil && c.Actor != actor
+}
+
+// Acquire leases issueID to actor for ttl. Re-claiming your own issue extends
+// it; an active claim by someone else is a *HeldError unless force is set.
+func Acquire(path, issueID, actor string, ttl time.Duration, force bool, now time.Time) (*Claim, error) {
+	if ttl <= 0 {
+		return nil, fmt.Errorf("ttl must be positive, got %s", ttl)
+	}
+	ledger, err := Load(path)
+	if err != nil {
+		return nil, err
+	}
+	if held := ledger.Get(issueID, now); held != nil && held.Actor != actor && !force {
+		return nil, &HeldError{Claim: *held}
+	}
+	claim := Claim{IssueID: issueID, Actor: actor, ClaimedAt: now.UTC(), Until: now.Add(ttl).UTC()}
+	if err := appendEvent(path, Event{IssueID: issueID, Action: ActionClaim, Actor: actor, At: claim.ClaimedAt, Until: claim.Until}); err != nil {
+		return nil, err
+	}
+	return &claim, nil
+}
+
+// Release ends the active claim on issueID and returns it. Only its holder
+// may release it unless force is set.
+func Release(path, issueID, actor string, force bool, now time.Time) (*Claim, error) {
+	ledger, err := Load(path)
+	if err != nil {
+		return nil, err
+	}
+	held := ledger.Get(issueID, now)
+	if held == nil {
+		return nil, ErrNotClaimed
+	}
+	if held.Actor != actor && !force {
+		return nil, &HeldError{Claim: *held}
+	}
+	if err := appendEvent(path, Event{IssueID: issueID, Action: ActionRelease, Actor: actor, At: now.UTC()}); err != nil {
+		return nil, err
+	}
+	return held, nil
+}
+
+func appendEvent(path string, e Event) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("creating claims directory: %w", err)
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("opening claims: %w", err)
+	}
+	defer f.Close()
+	line, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("writing claims: %w", err)
+	}
+	return nil
+}
+
+// Describe renders c as "claimed by ana until 15:04" (with the date when it
+// is not today)
+func Describe(c Claim, now time.Time) string {
+	until := c.Until.Local()
+	layout := "15:04"
+	if y, m, d := until.Date(); y != now.Local().Year() || m != now.Local().Month() || d != now.Local().Day() {
+		layout = "2006-01-02 15:04"
+	}
+	return fmt.Sprintf("claimed by %s until %s", c.Actor, until.Format(layout))
+}
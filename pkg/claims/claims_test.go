@@ -0,0 +1,104 @@
+package claims
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestClaimRelease(t *testing.T) {
+	path := Path(t.TempDir())
+	t0 := time.Date(2025, 4, 1, 9, 0, 0, 0, time.UTC)
+
+	if _, err := Release(path, "bv-1", "alice", false, t0); !errors.Is(err, ErrNotClaimed) {
+		t.Fatalf("release with no claim: err = %v", err)
+	}
+	claim, err := Acquire(path, "bv-1", "alice", 2*time.Hour, false, t0)
+	if err != nil || !claim.Until.Equal(t0.Add(2*time.Hour)) {
+		t.Fatalf("Claim = %+v, %v", claim, err)
+	}
+
+	// Someone else can't take or release it while it's active
+	var held *HeldError
+	if _, err := Acquire(path, "bv-1", "bob", time.Hour, false, t0.Add(time.Minute)); !errors.As(err, &held) || held.Claim.Actor != "alice" {
+		t.Errorf("bob claiming a held issue: err = %v", err)
+	}
+	if _, err := Release(path, "bv-1", "bob", false, t0.Add(time.Minute)); !errors.As(err, &held) {
+		t.Errorf("bob releasing alice's claim: err = %v", err)
+	}
+
+	// The holder extends it
+	if _, err := Acquire(path, "bv-1", "alice", 3*time.Hour, false, t0.Add(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	ledger, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c := ledger.Get("bv-1", t0.Add(3*time.Hour)); c == nil || !c.Until.Equal(t0.Add(4*time.Hour)) {
+		t.Errorf("extended claim = %+v", c)
+	}
+	if !ledger.HeldByOther("bv-1", "bob", t0) || ledger.HeldByOther("bv-1", "alice", t0) {
+		t.Error("HeldByOther should be true for bob only")
+	}
+
+	// Expired claims lapse, so bob can take it
+	if ledger.Get("bv-1", t0.Add(5*time.Hour)) != nil {
+		t.Error("claim should have expired")
+	}
+	if _, err := Acquire(path, "bv-1", "bob", time.Hour, false, t0.Add(5*time.Hour)); err != nil {
+		t.Fatalf("claiming an expired issue: %v", err)
+	}
+	if _, err := Release(path, "bv-1", "bob", false, t0.Add(5*time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	if ledger, _ = Load(path); len(ledger.Active(t0.Add(5*time.Hour))) != 0 {
+		t.Errorf("active claims after release = %+v", ledger.Active(t0))
+	}
+
+	// --force overrides someone else's claim
+	if _, err := Acquire(path, "bv-2", "alice", time.Hour, false, t0); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Acquire(path, "bv-2", "bob", time.Hour, true, t0); err != nil {
+		t.Errorf("forced claim: %v", err)
+	}
+	if _, err := Acquire(path, "bv-3", "bob", 0, false, t0); err == nil {
+		t.Error("zero ttl should fail")
+	}
+}
+
+func TestLoadSkipsMalformedLines(t *testing.T) {
+	path := Path(t.TempDir())
+	if _, err := Acquire(path, "bv-1", "alice", time.Hour, false, time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.WriteString("not json\n{\"action\":\"claim\"}\n")
+	f.Close()
+
+	ledger, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if active := ledger.Active(time.Now()); len(active) != 1 || active[0].IssueID != "bv-1" {
+		t.Errorf("active = %+v", active)
+	}
+}
+
+func TestDescribe(t *testing.T) {
+	now := time.Now()
+	c := Claim{IssueID: "bv-1", Actor: "ana", Until: now.Add(time.Minute)}
+	if got := Describe(c, now); !strings.HasPrefix(got, "claimed by ana until ") || strings.Contains(got, "-") {
+		t.Errorf("same-day Describe = %q", got)
+	}
+	c.Until = now.AddDate(0, 0, 2)
+	if got := Describe(c, now); !strings.Contains(got, c.Until.Local().Format("2006-01-02")) {
+		t.Errorf("later Describe = %q", got)
+	}
+}
@@ -0,0 +1,60 @@
+// Package configdir locates bv's per-user configuration directory: theme,
+// keymap, recipes, tutorial progress, the pages wizard's answers and the
+// opt-in usage log.
+//
+// It is ~/.config/bv everywhere but Windows, where it is %AppData%\bv
+// (os.UserConfigDir). Windows installs that already keep their files in
+// ~/.config/bv go on using it until it is moved.
+package configdir
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+)
+
+// Dir returns bv's configuration directory, or "" when there is no home
+// directory to put it in.
+func Dir() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		home = ""
+	}
+	userConfig, err := os.UserConfigDir()
+	if err != nil {
+		userConfig = ""
+	}
+	return dir(runtime.GOOS, home, userConfig)
+}
+
+// Path returns the path of name in bv's configuration directory, or "" when
+// there is none.
+func Path(name string) string {
+	d := Dir()
+	if d == "" {
+		return ""
+	}
+	return filepath.Join(d, name)
+}
+
+// dir picks the directory for goos given the home and OS config
+// directories, either of which may be empty.
+func dir(goos, home, userConfig string) string {
+	var legacy string
+	if home != "" {
+		legacy = filepath.Join(home, ".config", "bv")
+	}
+	if goos != "windows" || userConfig == "" {
+		return legacy
+	}
+	native := filepath.Join(userConfig, "bv")
+	if legacy != "" && !exists(native) && exists(legacy) {
+		return legacy
+	}
+	return native
+}
+
+func exists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
@@ -0,0 +1,45 @@
+package configdir
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDir(t *testing.T) {
+	home := t.TempDir()
+	appData := filepath.Join(home, "AppData", "Roaming")
+	legacy := filepath.Join(home, ".config", "bv")
+
+	if got := dir("linux", home, appData); got != legacy {
+		t.Errorf("linux = %q, want %q", got, legacy)
+	}
+	if got := dir("darwin", home, appData); got != legacy {
+		t.Errorf("darwin = %q, want %q", got, legacy)
+	}
+	if got := dir("linux", "", appData); got != "" {
+		t.Errorf("linux without home = %q, want empty", got)
+	}
+	if got := dir("windows", home, appData); got != filepath.Join(appData, "bv") {
+		t.Errorf("windows = %q, want AppData", got)
+	}
+	if got := dir("windows", home, ""); got != legacy {
+		t.Errorf("windows without AppData = %q, want %q", got, legacy)
+	}
+
+	// An existing ~/.config/bv keeps being used on Windows...
+	if err := os.MkdirAll(legacy, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if got := dir("windows", home, appData); got != legacy {
+		t.Errorf("windows with legacy dir = %q, want %q", got, legacy)
+	}
+
+	// ...until the AppData one exists
+	if err := os.MkdirAll(filepath.Join(appData, "bv"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if got := dir("windows", home, appData); got != filepath.Join(appData, "bv") {
+		t.Errorf("windows with both dirs = %q, want AppData", got)
+	}
+}
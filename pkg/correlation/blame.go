@@ -0,0 +1,375 @@
+// Package correlation provides blame-style annotation: which beads motivated
+// a range of lines, via git blame and the commit-to-bead index.
+package correlation
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// BlameHunk is a run of consecutive lines last changed by the same commit.
+type BlameHunk struct {
+	SHA         string    `json:"sha"`
+	StartLine   int       `json:"start_line"`
+	EndLine     int       `json:"end_line"`
+	Author      string    `json:"author"`
+	AuthorEmail string    `json:"author_email"`
+	Timestamp   time.Time `json:"timestamp"`
+	Summary     string    `json:"summary"`
+}
+
+// Lines returns the number of lines in the hunk.
+func (h BlameHunk) Lines() int {
+	return h.EndLine - h.StartLine + 1
+}
+
+// BlameRange runs git blame over [start, end] of file (1-based, inclusive).
+// end <= 0 blames to the end of the file; start <= 0 starts at line 1.
+func BlameRange(repoPath, file string, start, end int) ([]BlameHunk, error) {
+	args := []string{"blame", "--porcelain"}
+	if start > 0 || end > 0 {
+		if start <= 0 {
+			start = 1
+		}
+		if end > 0 {
+			args = append(args, "-L", fmt.Sprintf("%d,%d", start, end))
+		} else {
+			args = append(args, "-L", fmt.Sprintf("%d,", start))
+		}
+	}
+	args = append(args, "--", file)
+
+	cmd := exec.Command("git", args...)
+	cmd.Dir = repoPath
+
+	out, err := cmd.Output()
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			return nil, fmt.Errorf("git blame failed: %s", strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return nil, fmt.Errorf("git blame failed: %w", err)
+	}
+
+	return parseBlamePorcelain(out)
+}
+
+// parseBlamePorcelain parses `git blame --porcelain` output into hunks,
+// merging consecutive lines that share a commit.
+func parseBlamePorcelain(data []byte) ([]BlameHunk, error) {
+	type commitMeta struct {
+		author, email, summary string
+		timestamp              time.Time
+	}
+	meta := make(map[string]*commitMeta)
+
+	var hunks []BlameHunk
+	var curSHA string
+	var curLine int
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 64*1024), gitLogMaxScanTokenSize)
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		if strings.HasPrefix(line, "\t") {
+			// Content line terminates the entry for curLine
+			m := meta[curSHA]
+			if m == nil {
+				m = &commitMeta{}
+			}
+			if n := len(hunks); n > 0 && hunks[n-1].SHA == curSHA && hunks[n-1].EndLine == curLine-1 {
+				hunks[n-1].EndLine = curLine
+				continue
+			}
+			hunks = append(hunks, BlameHunk{
+				SHA:         curSHA,
+				StartLine:   curLine,
+				EndLine:     curLine,
+				Author:      m.author,
+				AuthorEmail: m.email,
+				Timestamp:   m.timestamp,
+				Summary:     m.summary,
+			})
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) >= 3 && len(fields[0]) == 40 && isHex(fields[0]) {
+			n, err := strconv.Atoi(fields[2])
+			if err != nil {
+				return nil, fmt.Errorf("parse blame header %q: %w", line, err)
+			}
+			curSHA = fields[0]
+			curLine = n
+			if meta[curSHA] == nil {
+				meta[curSHA] = &commitMeta{}
+			}
+			continue
+		}
+
+		m := meta[curSHA]
+		if m == nil {
+			continue
+		}
+		key, value, _ := strings.Cut(line, " ")
+		switch key {
+		case "author":
+			m.author = value
+		case "author-mail":
+			m.email = strings.Trim(value, "<>")
+		case "author-time":
+			if secs, err := strconv.ParseInt(value, 10, 64); err == nil {
+				m.timestamp = time.Unix(secs, 0).UTC()
+			}
+		case "summary":
+			m.summary = value
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	// Metadata appears only on a commit's first occurrence; backfill hunks
+	for i := range hunks {
+		if m := meta[hunks[i].SHA]; m != nil {
+			hunks[i].Author = m.author
+			hunks[i].AuthorEmail = m.email
+			hunks[i].Timestamp = m.timestamp
+			hunks[i].Summary = m.summary
+		}
+	}
+
+	return hunks, nil
+}
+
+func isHex(s string) bool {
+	for _, r := range s {
+		if !strings.ContainsRune("0123456789abcdef", r) {
+			return false
+		}
+	}
+	return true
+}
+
+// LineSpan is an inclusive range of line numbers.
+type LineSpan struct {
+	Start int `json:"start"`
+	End   int `json:"end"`
+}
+
+// WhyCommit is a commit responsible for some of the annotated lines.
+type WhyCommit struct {
+	SHA       string     `json:"sha"`
+	ShortSHA  string     `json:"short_sha"`
+	Author    string     `json:"author"`
+	Timestamp time.Time  `json:"timestamp"`
+	Summary   string     `json:"summary"`
+	Lines     []LineSpan `json:"lines"`
+	BeadIDs   []string   `json:"bead_ids"`
+}
+
+// WhyBead is a bead that motivated some of the annotated lines.
+type WhyBead struct {
+	BeadID     string            `json:"bead_id"`
+	Title      string            `json:"title"`
+	Status     string            `json:"status"`
+	Lines      int               `json:"lines"` // Lines attributed to this bead
+	Commits    []string          `json:"commits"`
+	Method     CorrelationMethod `json:"method"`
+	Confidence float64           `json:"confidence"` // Highest confidence across commits
+	Link       string            `json:"link,omitempty"`
+}
+
+// WhyResult answers "which beads motivated this code?" for a line range.
+type WhyResult struct {
+	Path          string      `json:"path"`
+	StartLine     int         `json:"start_line"`
+	EndLine       int         `json:"end_line"`
+	Beads         []WhyBead   `json:"beads"`
+	Commits       []WhyCommit `json:"commits"`
+	UnlinkedLines int         `json:"unlinked_lines"` // Lines whose commit maps to no bead
+}
+
+// Why blames the line range and maps each responsible commit to beads.
+// Commits outside the history report are checked for explicit references
+// (trailers, IDs in the message) to beads the report knows about.
+func (rl *ReverseLookup) Why(file string, start, end int) (*WhyResult, error) {
+	if rl.repoPath == "" {
+		return nil, fmt.Errorf("no repo path configured")
+	}
+
+	hunks, err := BlameRange(rl.repoPath, file, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &WhyResult{
+		Path:    normalizePath(file),
+		Beads:   []WhyBead{},
+		Commits: []WhyCommit{},
+	}
+	if len(hunks) > 0 {
+		result.StartLine = hunks[0].StartLine
+		result.EndLine = hunks[len(hunks)-1].EndLine
+	}
+
+	commitIdx := make(map[string]int)
+	beadIdx := make(map[string]int)
+	fallback := make(map[string]map[string]float64) // sha -> bead -> confidence from message
+	for _, h := range hunks {
+		idx, seen := commitIdx[h.SHA]
+		if !seen {
+			ids, conf := rl.beadsForBlamedCommit(h.SHA)
+			fallback[h.SHA] = conf
+			idx = len(result.Commits)
+			commitIdx[h.SHA] = idx
+			result.Commits = append(result.Commits, WhyCommit{
+				SHA:       h.SHA,
+				ShortSHA:  shortSHA(h.SHA),
+				Author:    h.Author,
+				Timestamp: h.Timestamp,
+				Summary:   h.Summary,
+				Lines:     []LineSpan{},
+				BeadIDs:   ids,
+			})
+		}
+		wc := &result.Commits[idx]
+		wc.Lines = append(wc.Lines, LineSpan{Start: h.StartLine, End: h.EndLine})
+
+		if len(wc.BeadIDs) == 0 {
+			result.UnlinkedLines += h.Lines()
+			continue
+		}
+		for _, beadID := range wc.BeadIDs {
+			bi, ok := beadIdx[beadID]
+			if !ok {
+				bi = len(result.Beads)
+				beadIdx[beadID] = bi
+				history := rl.beads[beadID]
+				result.Beads = append(result.Beads, WhyBead{
+					BeadID:  beadID,
+					Title:   history.Title,
+					Status:  history.Status,
+					Commits: []string{},
+				})
+			}
+			wb := &result.Beads[bi]
+			wb.Lines += h.Lines()
+			if !containsString(wb.Commits, wc.ShortSHA) {
+				wb.Commits = append(wb.Commits, wc.ShortSHA)
+			}
+			method, confidence := rl.linkStrength(beadID, h.SHA)
+			if c, ok := fallback[h.SHA][beadID]; ok {
+				method, confidence = MethodExplicitID, c
+			}
+			if confidence > wb.Confidence {
+				wb.Method = method
+				wb.Confidence = confidence
+			}
+		}
+	}
+
+	sort.SliceStable(result.Beads, func(i, j int) bool {
+		if result.Beads[i].Lines != result.Beads[j].Lines {
+			return result.Beads[i].Lines > result.Beads[j].Lines
+		}
+		return result.Beads[i].BeadID < result.Beads[j].BeadID
+	})
+
+	return result, nil
+}
+
+// beadsForBlamedCommit returns the beads linked to sha. For commits the index
+// missed it falls back to explicit references in the commit message, returning
+// the confidence of each such link as the second value.
+func (rl *ReverseLookup) beadsForBlamedCommit(sha string) ([]string, map[string]float64) {
+	if ids := rl.index[sha]; len(ids) > 0 {
+		out := append([]string(nil), ids...)
+		sort.Strings(out)
+		return out, nil
+	}
+	if strings.Trim(sha, "0") == "" {
+		return []string{}, nil // Uncommitted lines
+	}
+
+	cmd := exec.Command("git", "log", "-1", "--format=%B", sha)
+	cmd.Dir = rl.repoPath
+	out, err := cmd.Output()
+	if err != nil {
+		return []string{}, nil
+	}
+
+	known := make(map[string]string, len(rl.beads))
+	for id := range rl.beads {
+		known[strings.ToLower(id)] = id
+	}
+	ids := []string{}
+	conf := make(map[string]float64)
+	matches := NewExplicitMatcher(rl.repoPath).ExtractIDsFromMessage(string(out))
+	for _, m := range matches {
+		if id, ok := known[strings.ToLower(m.ID)]; ok && !containsString(ids, id) {
+			ids = append(ids, id)
+			conf[id] = CalculateConfidence(m.MatchType, len(matches))
+		}
+	}
+	sort.Strings(ids)
+	return ids, conf
+}
+
+// linkStrength reports how the history report linked a bead to a commit.
+func (rl *ReverseLookup) linkStrength(beadID, sha string) (CorrelationMethod, float64) {
+	for _, c := range rl.details[sha] {
+		if c.BeadID == beadID {
+			return c.Method, c.Confidence
+		}
+	}
+	return "", 0
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseWhySpec parses "<path>", "<path>:<line>" or "<path>:<start>-<end>".
+// Line numbers are 1-based; 0 means "unbounded" for that side.
+func ParseWhySpec(spec string) (path string, start, end int, err error) {
+	spec = strings.TrimSpace(spec)
+	if spec == "" {
+		return "", 0, 0, fmt.Errorf("empty path")
+	}
+
+	idx := strings.LastIndex(spec, ":")
+	if idx == -1 {
+		return spec, 0, 0, nil
+	}
+	path, rangePart := spec[:idx], spec[idx+1:]
+	if rangePart == "" || rangePart[0] < '0' || rangePart[0] > '9' {
+		return spec, 0, 0, nil // Colon is part of the file name
+	}
+	if path == "" {
+		return "", 0, 0, fmt.Errorf("missing path in %q", spec)
+	}
+
+	startStr, endStr, isRange := strings.Cut(rangePart, "-")
+	if start, err = strconv.Atoi(startStr); err != nil || start < 1 {
+		return "", 0, 0, fmt.Errorf("invalid start line in %q", spec)
+	}
+	if !isRange {
+		return path, start, start, nil
+	}
+	if end, err = strconv.Atoi(endStr); err != nil || end < start {
+		return "", 0, 0, fmt.Errorf("invalid line range in %q", spec)
+	}
+	return path, start, end, nil
+}
@@ -0,0 +1,138 @@
+package correlation
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseWhySpec(t *testing.T) {
+	tests := []struct {
+		spec       string
+		path       string
+		start, end int
+		wantErr    bool
+	}{
+		{"pkg/a.go", "pkg/a.go", 0, 0, false},
+		{"pkg/a.go:12", "pkg/a.go", 12, 12, false},
+		{"pkg/a.go:10-20", "pkg/a.go", 10, 20, false},
+		{"odd:name.go", "odd:name.go", 0, 0, false},
+		{"pkg/a.go:20-10", "", 0, 0, true},
+		{"pkg/a.go:0", "", 0, 0, true},
+		{":5", "", 0, 0, true},
+		{"", "", 0, 0, true},
+	}
+	for _, tt := range tests {
+		path, start, end, err := ParseWhySpec(tt.spec)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseWhySpec(%q) err = %v, wantErr %v", tt.spec, err, tt.wantErr)
+			continue
+		}
+		if path != tt.path || start != tt.start || end != tt.end {
+			t.Errorf("ParseWhySpec(%q) = %q,%d,%d; want %q,%d,%d", tt.spec, path, start, end, tt.path, tt.start, tt.end)
+		}
+	}
+}
+
+func TestParseBlamePorcelain(t *testing.T) {
+	sha1 := "1111111111111111111111111111111111111111"
+	sha2 := "2222222222222222222222222222222222222222"
+	data := sha1 + " 1 1 2\n" +
+		"author Alice\nauthor-mail <alice@example.com>\nauthor-time 1700000000\nsummary First change\nfilename a.go\n\tline one\n" +
+		sha1 + " 2 2\n\tline two\n" +
+		sha2 + " 3 3 1\n" +
+		"author Bob\nauthor-mail <bob@example.com>\nauthor-time 1700001000\nsummary Second change\nfilename a.go\n\tline three\n" +
+		sha1 + " 4 4 1\n\tline four\n"
+
+	hunks, err := parseBlamePorcelain([]byte(data))
+	if err != nil {
+		t.Fatalf("parseBlamePorcelain: %v", err)
+	}
+	if len(hunks) != 3 {
+		t.Fatalf("expected 3 hunks, got %d: %+v", len(hunks), hunks)
+	}
+	if hunks[0].SHA != sha1 || hunks[0].StartLine != 1 || hunks[0].EndLine != 2 || hunks[0].Lines() != 2 {
+		t.Errorf("hunk 0 = %+v", hunks[0])
+	}
+	if hunks[1].Author != "Bob" || hunks[1].AuthorEmail != "bob@example.com" || hunks[1].Summary != "Second change" {
+		t.Errorf("hunk 1 metadata = %+v", hunks[1])
+	}
+	if hunks[2].SHA != sha1 || hunks[2].Author != "Alice" || hunks[2].StartLine != 4 {
+		t.Errorf("hunk 2 should reuse sha1 metadata: %+v", hunks[2])
+	}
+}
+
+func TestReverseLookupWhy(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	dir := t.TempDir()
+	git := func(args ...string) string {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(),
+			"GIT_AUTHOR_NAME=Dev", "GIT_AUTHOR_EMAIL=dev@example.com",
+			"GIT_COMMITTER_NAME=Dev", "GIT_COMMITTER_EMAIL=dev@example.com")
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+		return string(out)
+	}
+	write := func(content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, "a.go"), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	git("init", "-q")
+	write("one\ntwo\n")
+	git("add", ".")
+	git("commit", "-q", "-m", "Indexed change")
+	indexedSHA := git("rev-parse", "HEAD")[:40]
+
+	write("one\ntwo\nthree\n")
+	git("commit", "-q", "-am", "Add three\n\nCloses-Bead: bv-2")
+
+	write("one\ntwo\nthree\nfour\n")
+	git("commit", "-q", "-am", "Unrelated tweak")
+
+	report := &HistoryReport{
+		Histories: map[string]BeadHistory{
+			"bv-1": {BeadID: "bv-1", Title: "Indexed", Status: "closed",
+				Commits: []CorrelatedCommit{{BeadID: "bv-1", SHA: indexedSHA, Method: MethodCoCommitted, Confidence: 0.9}}},
+			"bv-2": {BeadID: "bv-2", Title: "Trailer only", Status: "open"},
+		},
+		CommitIndex: CommitIndex{indexedSHA: {"bv-1"}},
+	}
+
+	res, err := NewReverseLookupWithRepo(report, dir).Why("a.go", 0, 0)
+	if err != nil {
+		t.Fatalf("Why: %v", err)
+	}
+	if res.StartLine != 1 || res.EndLine != 4 {
+		t.Errorf("range = %d-%d, want 1-4", res.StartLine, res.EndLine)
+	}
+	if len(res.Commits) != 3 {
+		t.Fatalf("expected 3 blamed commits, got %+v", res.Commits)
+	}
+	if len(res.Beads) != 2 || res.Beads[0].BeadID != "bv-1" || res.Beads[0].Lines != 2 {
+		t.Fatalf("beads = %+v, want bv-1 (2 lines) first", res.Beads)
+	}
+	if res.Beads[0].Method != MethodCoCommitted || res.Beads[0].Confidence != 0.9 {
+		t.Errorf("indexed link strength = %s/%v", res.Beads[0].Method, res.Beads[0].Confidence)
+	}
+	if res.Beads[1].BeadID != "bv-2" || res.Beads[1].Confidence != TrailerConfidence {
+		t.Errorf("trailer fallback = %+v, want bv-2 at confidence 1.0", res.Beads[1])
+	}
+	if res.UnlinkedLines != 1 {
+		t.Errorf("UnlinkedLines = %d, want 1", res.UnlinkedLines)
+	}
+
+	if _, err := NewReverseLookup(report).Why("a.go", 1, 1); err == nil {
+		t.Error("expected error without repo path")
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"os/exec"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -269,12 +270,13 @@ func getGitHead(repoPath string) (string, error) {
 	return strings.TrimSpace(string(out)), nil
 }
 
-// hashBeads creates a hash of bead IDs and statuses
+// hashBeads creates a hash of bead IDs, statuses and recorded event counts
 func hashBeads(beads []BeadInfo) string {
 	h := sha256.New()
 	for _, b := range beads {
 		h.Write([]byte(b.ID))
 		h.Write([]byte(b.Status))
+		h.Write([]byte(strconv.Itoa(len(b.Events))))
 	}
 	return hex.EncodeToString(h.Sum(nil))[:12]
 }
@@ -0,0 +1,246 @@
+// Package correlation provides the code map: an inverted view of correlation
+// data that answers "which beads belong to this area of the code?"
+package correlation
+
+import (
+	"path"
+	"sort"
+	"strings"
+	"time"
+)
+
+// CodeMapOptions controls how the code map is aggregated.
+type CodeMapOptions struct {
+	Path     string // Restrict to files under this path prefix (empty = whole repo)
+	Depth    int    // Max directory depth to aggregate (0 = all levels)
+	Limit    int    // Max directories/files to return, hottest first (0 = all)
+	TopBeads int    // Max open beads listed per entry (default 3 if <= 0)
+}
+
+// CodeMapBead is a bead's footprint within one code area.
+type CodeMapBead struct {
+	BeadID    string    `json:"bead_id"`
+	Title     string    `json:"title"`
+	Status    string    `json:"status"`
+	Commits   int       `json:"commits"` // Distinct commits touching the area
+	Churn     int       `json:"churn"`   // Insertions + deletions in the area
+	Share     float64   `json:"share"`   // Fraction of the area's commits attributed to this bead
+	LastTouch time.Time `json:"last_touch"`
+}
+
+// CodeMapEntry aggregates bead activity for a directory or file.
+type CodeMapEntry struct {
+	Path        string        `json:"path"`
+	Kind        string        `json:"kind"` // "dir" or "file"
+	Files       int           `json:"files"`
+	TotalBeads  int           `json:"total_beads"`
+	OpenCount   int           `json:"open_count"`
+	ClosedCount int           `json:"closed_count"`
+	Commits     int           `json:"commits"`
+	Churn       int           `json:"churn"`
+	LastTouch   time.Time     `json:"last_touch"`
+	OpenBeads   []CodeMapBead `json:"open_beads"` // Most associated open beads first
+	BeadIDs     []string      `json:"bead_ids"`   // Every bead that touched the area, sorted
+}
+
+// CodeMapStats summarizes the code map.
+type CodeMapStats struct {
+	TotalFiles       int `json:"total_files"`
+	TotalDirectories int `json:"total_directories"`
+	FilesWithOpen    int `json:"files_with_open_beads"`
+}
+
+// CodeMap is the file-path to bead heatmap.
+type CodeMap struct {
+	Directories []CodeMapEntry `json:"directories"`
+	Files       []CodeMapEntry `json:"files"`
+	Stats       CodeMapStats   `json:"stats"`
+}
+
+// codeMapAccumulator collects per-bead activity for one code area.
+type codeMapAccumulator struct {
+	files map[string]bool
+	beads map[string]*codeMapBeadAcc
+	shas  map[string]bool
+}
+
+type codeMapBeadAcc struct {
+	ref   BeadReference
+	shas  map[string]bool
+	churn int
+}
+
+func newCodeMapAccumulator() *codeMapAccumulator {
+	return &codeMapAccumulator{
+		files: make(map[string]bool),
+		beads: make(map[string]*codeMapBeadAcc),
+		shas:  make(map[string]bool),
+	}
+}
+
+func (a *codeMapAccumulator) add(file string, ref BeadReference) {
+	a.files[file] = true
+	b := a.beads[ref.BeadID]
+	if b == nil {
+		b = &codeMapBeadAcc{ref: ref, shas: make(map[string]bool)}
+		a.beads[ref.BeadID] = b
+	}
+	for _, sha := range ref.CommitSHAs {
+		b.shas[sha] = true
+		a.shas[sha] = true
+	}
+	b.churn += ref.TotalChanges
+	if ref.LastTouch.After(b.ref.LastTouch) {
+		b.ref.LastTouch = ref.LastTouch
+	}
+}
+
+// BuildCodeMap inverts the file index into per-directory and per-file summaries.
+// Entries are ordered by churn (then commits, then path) so the hottest areas come first.
+func (fl *FileLookup) BuildCodeMap(opts CodeMapOptions) *CodeMap {
+	if opts.TopBeads <= 0 {
+		opts.TopBeads = 3
+	}
+	prefix := normalizePath(opts.Path)
+
+	fileAcc := make(map[string]*codeMapAccumulator)
+	dirAcc := make(map[string]*codeMapAccumulator)
+
+	for file, refs := range fl.index.FileToBeads {
+		if prefix != "" && file != prefix && !strings.HasPrefix(file, prefix+"/") {
+			continue
+		}
+		for _, ref := range refs {
+			// Current status/title may differ from when the commit was indexed
+			if history, ok := fl.beads[ref.BeadID]; ok {
+				ref.Status = history.Status
+				ref.Title = history.Title
+			}
+
+			if fileAcc[file] == nil {
+				fileAcc[file] = newCodeMapAccumulator()
+			}
+			fileAcc[file].add(file, ref)
+
+			for _, dir := range ancestorDirs(file, opts.Depth) {
+				if dirAcc[dir] == nil {
+					dirAcc[dir] = newCodeMapAccumulator()
+				}
+				dirAcc[dir].add(file, ref)
+			}
+		}
+	}
+
+	result := &CodeMap{
+		Directories: buildCodeMapEntries(dirAcc, "dir", opts.TopBeads),
+		Files:       buildCodeMapEntries(fileAcc, "file", opts.TopBeads),
+	}
+	result.Stats = CodeMapStats{
+		TotalFiles:       len(result.Files),
+		TotalDirectories: len(result.Directories),
+	}
+	for _, f := range result.Files {
+		if f.OpenCount > 0 {
+			result.Stats.FilesWithOpen++
+		}
+	}
+
+	if opts.Limit > 0 {
+		if len(result.Directories) > opts.Limit {
+			result.Directories = result.Directories[:opts.Limit]
+		}
+		if len(result.Files) > opts.Limit {
+			result.Files = result.Files[:opts.Limit]
+		}
+	}
+
+	return result
+}
+
+// ancestorDirs returns the directories containing file, shallowest first.
+// With maxDepth > 0 only directories at most maxDepth levels deep are returned.
+func ancestorDirs(file string, maxDepth int) []string {
+	var dirs []string
+	for dir := path.Dir(file); dir != "." && dir != "/"; dir = path.Dir(dir) {
+		if maxDepth <= 0 || strings.Count(dir, "/")+1 <= maxDepth {
+			dirs = append(dirs, dir)
+		}
+	}
+	for i, j := 0, len(dirs)-1; i < j; i, j = i+1, j-1 {
+		dirs[i], dirs[j] = dirs[j], dirs[i]
+	}
+	return dirs
+}
+
+func buildCodeMapEntries(accs map[string]*codeMapAccumulator, kind string, topBeads int) []CodeMapEntry {
+	entries := make([]CodeMapEntry, 0, len(accs))
+	for p, acc := range accs {
+		entry := CodeMapEntry{
+			Path:      p,
+			Kind:      kind,
+			Files:     len(acc.files),
+			Commits:   len(acc.shas),
+			OpenBeads: []CodeMapBead{},
+			BeadIDs:   make([]string, 0, len(acc.beads)),
+		}
+
+		for id, b := range acc.beads {
+			entry.BeadIDs = append(entry.BeadIDs, id)
+			entry.Churn += b.churn
+			if b.ref.LastTouch.After(entry.LastTouch) {
+				entry.LastTouch = b.ref.LastTouch
+			}
+			if b.ref.Status == "closed" {
+				entry.ClosedCount++
+				continue
+			}
+			entry.OpenCount++
+			share := 0.0
+			if entry.Commits > 0 {
+				share = float64(len(b.shas)) / float64(entry.Commits)
+			}
+			entry.OpenBeads = append(entry.OpenBeads, CodeMapBead{
+				BeadID:    id,
+				Title:     b.ref.Title,
+				Status:    b.ref.Status,
+				Commits:   len(b.shas),
+				Churn:     b.churn,
+				Share:     share,
+				LastTouch: b.ref.LastTouch,
+			})
+		}
+		entry.TotalBeads = len(acc.beads)
+		sort.Strings(entry.BeadIDs)
+
+		sort.Slice(entry.OpenBeads, func(i, j int) bool {
+			a, b := entry.OpenBeads[i], entry.OpenBeads[j]
+			if a.Commits != b.Commits {
+				return a.Commits > b.Commits
+			}
+			if a.Churn != b.Churn {
+				return a.Churn > b.Churn
+			}
+			if !a.LastTouch.Equal(b.LastTouch) {
+				return a.LastTouch.After(b.LastTouch)
+			}
+			return a.BeadID < b.BeadID
+		})
+		if len(entry.OpenBeads) > topBeads {
+			entry.OpenBeads = entry.OpenBeads[:topBeads]
+		}
+
+		entries = append(entries, entry)
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Churn != entries[j].Churn {
+			return entries[i].Churn > entries[j].Churn
+		}
+		if entries[i].Commits != entries[j].Commits {
+			return entries[i].Commits > entries[j].Commits
+		}
+		return entries[i].Path < entries[j].Path
+	})
+
+	return entries
+}
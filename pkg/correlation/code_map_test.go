@@ -0,0 +1,117 @@
+package correlation
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func codeMapTestReport() *HistoryReport {
+	now := time.Now()
+	return &HistoryReport{
+		Histories: map[string]BeadHistory{
+			"bv-1": {
+				BeadID: "bv-1", Title: "Auth refactor", Status: "closed",
+				Commits: []CorrelatedCommit{
+					{SHA: "a1", ShortSHA: "a1", Timestamp: now.Add(-48 * time.Hour), Files: []FileChange{
+						{Path: "pkg/auth/token.go", Insertions: 10, Deletions: 5},
+						{Path: "pkg/auth/session.go", Insertions: 20},
+					}},
+				},
+			},
+			"bv-2": {
+				BeadID: "bv-2", Title: "Token expiry", Status: "in_progress",
+				Commits: []CorrelatedCommit{
+					{SHA: "b1", ShortSHA: "b1", Timestamp: now.Add(-24 * time.Hour), Files: []FileChange{
+						{Path: "pkg/auth/token.go", Insertions: 3, Deletions: 1},
+					}},
+					{SHA: "b2", ShortSHA: "b2", Timestamp: now.Add(-12 * time.Hour), Files: []FileChange{
+						{Path: "pkg/auth/token.go", Insertions: 2},
+					}},
+				},
+			},
+			"bv-3": {
+				BeadID: "bv-3", Title: "Routes", Status: "open",
+				Commits: []CorrelatedCommit{
+					{SHA: "c1", ShortSHA: "c1", Timestamp: now.Add(-6 * time.Hour), Files: []FileChange{
+						{Path: "pkg/api/routes.go", Insertions: 100, Deletions: 20},
+						{Path: "pkg/auth/session.go", Insertions: 1},
+					}},
+				},
+			},
+		},
+	}
+}
+
+func TestBuildCodeMap(t *testing.T) {
+	cm := NewFileLookup(codeMapTestReport()).BuildCodeMap(CodeMapOptions{})
+
+	if cm.Stats.TotalFiles != 3 || cm.Stats.TotalDirectories != 3 {
+		t.Fatalf("stats = %+v, want 3 files and 3 dirs (pkg, pkg/auth, pkg/api)", cm.Stats)
+	}
+	if cm.Directories[0].Path != "pkg" {
+		t.Errorf("hottest directory = %s, want pkg", cm.Directories[0].Path)
+	}
+
+	var auth *CodeMapEntry
+	for i := range cm.Directories {
+		if cm.Directories[i].Path == "pkg/auth" {
+			auth = &cm.Directories[i]
+		}
+	}
+	if auth == nil {
+		t.Fatal("pkg/auth missing from directories")
+	}
+	if auth.Files != 2 || auth.TotalBeads != 3 || auth.OpenCount != 2 || auth.ClosedCount != 1 {
+		t.Errorf("pkg/auth counts = %+v", auth)
+	}
+	if auth.Commits != 4 || auth.Churn != 42 {
+		t.Errorf("pkg/auth commits/churn = %d/%d, want 4/42", auth.Commits, auth.Churn)
+	}
+	if !reflect.DeepEqual(auth.BeadIDs, []string{"bv-1", "bv-2", "bv-3"}) {
+		t.Errorf("pkg/auth bead ids = %v", auth.BeadIDs)
+	}
+	if len(auth.OpenBeads) != 2 || auth.OpenBeads[0].BeadID != "bv-2" {
+		t.Fatalf("pkg/auth open beads = %+v, want bv-2 first", auth.OpenBeads)
+	}
+	if auth.OpenBeads[0].Commits != 2 || auth.OpenBeads[0].Share != 0.5 {
+		t.Errorf("bv-2 footprint = %+v", auth.OpenBeads[0])
+	}
+}
+
+func TestBuildCodeMap_PathDepthAndLimits(t *testing.T) {
+	fl := NewFileLookup(codeMapTestReport())
+
+	cm := fl.BuildCodeMap(CodeMapOptions{Path: "pkg/auth/", Depth: 1, TopBeads: 1})
+	if len(cm.Files) != 2 {
+		t.Errorf("path filter: files = %d, want 2", len(cm.Files))
+	}
+	if len(cm.Directories) != 1 || cm.Directories[0].Path != "pkg" {
+		t.Errorf("depth 1: directories = %+v, want only pkg", cm.Directories)
+	}
+	for _, e := range cm.Files {
+		if len(e.OpenBeads) > 1 {
+			t.Errorf("TopBeads not applied for %s: %+v", e.Path, e.OpenBeads)
+		}
+	}
+
+	limited := fl.BuildCodeMap(CodeMapOptions{Limit: 1})
+	if len(limited.Files) != 1 || limited.Files[0].Path != "pkg/api/routes.go" {
+		t.Errorf("limit: files = %+v, want hottest routes.go", limited.Files)
+	}
+	if limited.Stats.TotalFiles != 3 {
+		t.Errorf("stats should reflect the full map, got %+v", limited.Stats)
+	}
+}
+
+func TestAncestorDirs(t *testing.T) {
+	if got := ancestorDirs("a/b/c/file.go", 0); !reflect.DeepEqual(got, []string{"a", "a/b", "a/b/c"}) {
+		t.Errorf("ancestorDirs = %v", got)
+	}
+	if got := ancestorDirs("a/b/c/file.go", 2); !reflect.DeepEqual(got, []string{"a", "a/b"}) {
+		t.Errorf("ancestorDirs depth 2 = %v", got)
+	}
+	if got := ancestorDirs("file.go", 0); len(got) != 0 {
+		t.Errorf("root file should have no dirs, got %v", got)
+	}
+}
@@ -2,12 +2,18 @@
 package correlation
 
 import (
+	"context"
 	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/tracing"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 // Correlator orchestrates the extraction and correlation of bead history data
@@ -15,6 +21,9 @@ type Correlator struct {
 	repoPath    string
 	extractor   *Extractor
 	coCommitter *CoCommitExtractor
+	explicit    *ExplicitMatcher
+	indexPath   string          // Persistent correlation index (empty = walk git every time)
+	lastSync    *IndexSyncStats // Result of the most recent index sync
 }
 
 // NewCorrelator creates a new correlator for the given repository.
@@ -26,9 +35,23 @@ func NewCorrelator(repoPath string, beadsFilePath ...string) *Correlator {
 		repoPath:    repoPath,
 		extractor:   NewExtractor(repoPath, beadsFilePath...),
 		coCommitter: NewCoCommitExtractor(repoPath),
+		explicit:    NewExplicitMatcher(repoPath),
 	}
 }
 
+// UseIndex makes GenerateReport read from a persistent correlation index at
+// path (see DefaultIndexPath), syncing only commits added since the last run.
+// If the index cannot be used the correlator falls back to walking git.
+func (c *Correlator) UseIndex(path string) {
+	c.indexPath = path
+}
+
+// LastIndexSync returns the stats of the most recent index sync, or nil if
+// the last report was generated without the index.
+func (c *Correlator) LastIndexSync() *IndexSyncStats {
+	return c.lastSync
+}
+
 // CorrelatorOptions controls how the history report is generated
 type CorrelatorOptions struct {
 	BeadID string     // Filter to single bead ID (empty = all)
@@ -38,7 +61,10 @@ type CorrelatorOptions struct {
 }
 
 // GenerateReport generates a complete history report
-func (c *Correlator) GenerateReport(beads []BeadInfo, opts CorrelatorOptions) (*HistoryReport, error) {
+func (c *Correlator) GenerateReport(beads []BeadInfo, opts CorrelatorOptions) (report *HistoryReport, err error) {
+	ctx, span := tracing.Start(context.Background(), "correlation.generate_report", attribute.Int("bv.beads", len(beads)))
+	defer func() { tracing.End(span, err) }()
+
 	// Build extract options
 	extractOpts := ExtractOptions{
 		Since:  opts.Since,
@@ -47,16 +73,12 @@ func (c *Correlator) GenerateReport(beads []BeadInfo, opts CorrelatorOptions) (*
 		BeadID: opts.BeadID,
 	}
 
-	// Extract lifecycle events from git history
-	events, err := c.extractor.Extract(extractOpts)
-	if err != nil {
-		return nil, fmt.Errorf("extracting events: %w", err)
-	}
-
-	// Extract co-committed files
-	commits, err := c.coCommitter.ExtractAllCoCommits(events)
+	_, collectSpan := tracing.Start(ctx, "correlation.collect")
+	events, commits, err := c.collect(extractOpts)
+	collectSpan.SetAttributes(attribute.Int("bv.events", len(events)), attribute.Int("bv.commits", len(commits)))
+	tracing.End(collectSpan, err)
 	if err != nil {
-		return nil, fmt.Errorf("extracting co-commits: %w", err)
+		return nil, err
 	}
 
 	// Build bead histories
@@ -97,6 +119,55 @@ func (c *Correlator) GenerateReport(beads []BeadInfo, opts CorrelatorOptions) (*
 	}, nil
 }
 
+// collect gathers lifecycle events and correlated commits, from the
+// persistent index when configured, otherwise by walking git history.
+func (c *Correlator) collect(opts ExtractOptions) ([]BeadEvent, []CorrelatedCommit, error) {
+	c.lastSync = nil
+	if c.indexPath != "" {
+		if events, commits, err := c.collectFromIndex(opts); err == nil {
+			return events, commits, nil
+		}
+		// Non-fatal: a broken or locked index must never block history
+	}
+
+	// Extract lifecycle events from git history
+	events, err := c.extractor.Extract(opts)
+	if err != nil {
+		return nil, nil, fmt.Errorf("extracting events: %w", err)
+	}
+
+	// Extract co-committed files
+	commits, err := c.coCommitter.ExtractAllCoCommits(events)
+	if err != nil {
+		return nil, nil, fmt.Errorf("extracting co-commits: %w", err)
+	}
+
+	// Add commits linked via Bead:/Closes-Bead: trailers (non-fatal on failure)
+	commits = append(commits, c.extractTrailerCommits(opts)...)
+
+	return events, commits, nil
+}
+
+// collectFromIndex syncs the index with HEAD and loads from it.
+func (c *Correlator) collectFromIndex(opts ExtractOptions) ([]BeadEvent, []CorrelatedCommit, error) {
+	ix, err := OpenIndex(c.indexPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer ix.Close()
+
+	stats, err := ix.Sync(c)
+	if err != nil {
+		return nil, nil, err
+	}
+	events, commits, err := ix.Load(opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	c.lastSync = &stats
+	return events, commits, nil
+}
+
 // findLatestCommitSHA finds the most recent commit SHA from events and commits
 func (c *Correlator) findLatestCommitSHA(events []BeadEvent, commits []CorrelatedCommit) string {
 	var latest time.Time
@@ -121,11 +192,76 @@ func (c *Correlator) findLatestCommitSHA(events []BeadEvent, commits []Correlate
 	return latestSHA
 }
 
+// extractTrailerCommits finds commits whose messages carry bead trailers.
+// Files are fetched once per commit, even when it names several beads.
+func (c *Correlator) extractTrailerCommits(opts ExtractOptions) []CorrelatedCommit {
+	if c.explicit == nil {
+		return nil
+	}
+	matches, err := c.explicit.FindTrailerCommits(opts)
+	if err != nil {
+		return nil
+	}
+
+	var commits []CorrelatedCommit
+	fileCache := make(map[string][]FileChange)
+	for _, match := range matches {
+		files, cached := fileCache[match.CommitSHA]
+		if !cached {
+			files, _ = c.coCommitter.ExtractCoCommittedFiles(BeadEvent{CommitSHA: match.CommitSHA})
+			fileCache[match.CommitSHA] = files
+		}
+		commit := c.explicit.CreateCorrelatedCommit(match, nil)
+		commit.Files = files
+		commits = append(commits, commit)
+	}
+	return commits
+}
+
 // BeadInfo is minimal bead information needed for correlation
 type BeadInfo struct {
 	ID     string
 	Title  string
 	Status string
+	Events []*model.IssueEvent // Tracker-recorded audit trail, if any
+}
+
+// TrackerEvents converts a bead's recorded status-change events into
+// lifecycle events. Non-status events (comments, field edits) are skipped.
+func TrackerEvents(beadID string, events []*model.IssueEvent) []BeadEvent {
+	var out []BeadEvent
+	for _, e := range events {
+		if e == nil || e.CreatedAt.IsZero() {
+			continue
+		}
+		var et EventType
+		to := e.ToStatus()
+		switch {
+		case e.EventType == model.EventCreated:
+			et = EventCreated
+		case to == "":
+			continue
+		case to == string(model.StatusInProgress):
+			et = EventClaimed
+		case to == string(model.StatusInReview):
+			et = EventInReview
+		case to == string(model.StatusClosed):
+			et = EventClosed
+		case e.EventType == model.EventReopened || e.OldValue == string(model.StatusClosed):
+			et = EventReopened
+		default:
+			et = EventModified
+		}
+		out = append(out, BeadEvent{
+			BeadID:    beadID,
+			EventType: et,
+			Timestamp: e.CreatedAt,
+			CommitMsg: e.Comment,
+			Author:    e.Actor,
+			Source:    EventSourceTracker,
+		})
+	}
+	return out
 }
 
 // buildHistories constructs BeadHistory for each bead
@@ -157,10 +293,25 @@ func (c *Correlator) buildHistories(beads []BeadInfo, events []BeadEvent, commit
 		}
 	}
 
+	// Recorded tracker events carry real timestamps; prefer them over
+	// lifecycle events inferred from beads-file commits
+	trackerByBead := make(map[string][]BeadEvent)
+	for _, bead := range beads {
+		if tracked := TrackerEvents(bead.ID, bead.Events); len(tracked) > 0 {
+			trackerByBead[bead.ID] = tracked
+		}
+	}
+
 	// Build complete histories
 	for beadID, history := range histories {
 		history.Events = eventsByBead[beadID]
+		if tracked, ok := trackerByBead[beadID]; ok {
+			history.Events = tracked
+		}
 		history.Commits = dedupCommits(commitsByBead[beadID])
+		sort.SliceStable(history.Commits, func(i, j int) bool {
+			return history.Commits[i].Timestamp.Before(history.Commits[j].Timestamp)
+		})
 
 		// Calculate milestones
 		history.Milestones = GetBeadMilestones(history.Events)
@@ -181,15 +332,21 @@ func (c *Correlator) buildHistories(beads []BeadInfo, events []BeadEvent, commit
 	return histories
 }
 
-// dedupCommits removes duplicate commits by SHA
+// dedupCommits removes duplicate commits by SHA.
+// The first occurrence keeps its position; a later duplicate with strictly
+// higher confidence (e.g. a trailer link for a co-committed SHA) replaces it.
 func dedupCommits(commits []CorrelatedCommit) []CorrelatedCommit {
-	seen := make(map[string]bool)
+	seen := make(map[string]int)
 	var result []CorrelatedCommit
 	for _, c := range commits {
-		if !seen[c.SHA] {
-			seen[c.SHA] = true
-			result = append(result, c)
+		if idx, ok := seen[c.SHA]; ok {
+			if c.Confidence > result[idx].Confidence {
+				result[idx] = c
+			}
+			continue
 		}
+		seen[c.SHA] = len(result)
+		result = append(result, c)
 	}
 	return result
 }
@@ -3,6 +3,8 @@ package correlation
 import (
 	"testing"
 	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
 )
 
 func TestBuildHistories_Empty(t *testing.T) {
@@ -390,3 +392,74 @@ func TestDescribeGitRange_Combined(t *testing.T) {
 		t.Errorf("unexpected result: %s", result)
 	}
 }
+
+func TestBuildHistories_PrefersTrackerEvents(t *testing.T) {
+	c := NewCorrelator("/tmp/test")
+
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	at := func(h int) time.Time { return base.Add(time.Duration(h) * time.Hour) }
+
+	beads := []BeadInfo{
+		{ID: "bv-1", Title: "Tracked", Status: "closed", Events: []*model.IssueEvent{
+			{EventType: model.EventCreated, Actor: "alice", CreatedAt: at(0)},
+			{EventType: model.EventCommented, Actor: "bob", CreatedAt: at(1)},
+			{EventType: model.EventStatusChanged, NewValue: "in_progress", Actor: "alice", CreatedAt: at(2)},
+			{EventType: model.EventStatusChanged, NewValue: string(model.StatusInReview), Actor: "alice", CreatedAt: at(10)},
+			{EventType: model.EventClosed, Actor: "carol", CreatedAt: at(12)},
+		}},
+		{ID: "bv-2", Title: "Git only", Status: "closed"},
+	}
+	// Git-inferred events would give a very different cycle time
+	events := []BeadEvent{
+		{BeadID: "bv-1", EventType: EventCreated, Timestamp: at(-100)},
+		{BeadID: "bv-1", EventType: EventClosed, Timestamp: at(100)},
+		{BeadID: "bv-2", EventType: EventCreated, Timestamp: at(0)},
+		{BeadID: "bv-2", EventType: EventClosed, Timestamp: at(5)},
+	}
+
+	histories := c.buildHistories(beads, events, nil)
+
+	h1 := histories["bv-1"]
+	if len(h1.Events) != 4 {
+		t.Fatalf("expected 4 tracker events (comment skipped), got %d", len(h1.Events))
+	}
+	for _, e := range h1.Events {
+		if e.Source != EventSourceTracker {
+			t.Errorf("event %s source = %q, want tracker", e.EventType, e.Source)
+		}
+	}
+	if h1.Milestones.InReview == nil || h1.Milestones.Closed.Author != "carol" {
+		t.Errorf("unexpected milestones: %+v", h1.Milestones)
+	}
+	ct := h1.CycleTime
+	if ct == nil || ct.ClaimToClose == nil || *ct.ClaimToClose != 10*time.Hour {
+		t.Fatalf("ClaimToClose = %v, want 10h", ct)
+	}
+	if *ct.ClaimToReview != 8*time.Hour || *ct.ReviewToClose != 2*time.Hour {
+		t.Errorf("review split = %v / %v, want 8h / 2h", *ct.ClaimToReview, *ct.ReviewToClose)
+	}
+
+	h2 := histories["bv-2"]
+	if len(h2.Events) != 2 || h2.Events[0].Source != "" {
+		t.Errorf("bead without tracker events should keep git events: %+v", h2.Events)
+	}
+}
+
+func TestTrackerEvents_Reopen(t *testing.T) {
+	now := time.Now()
+	got := TrackerEvents("bv-1", []*model.IssueEvent{
+		{EventType: model.EventStatusChanged, OldValue: "closed", NewValue: "open", CreatedAt: now},
+		{EventType: model.EventReopened, CreatedAt: now},
+		{EventType: model.EventStatusChanged, OldValue: "open", NewValue: "blocked", CreatedAt: now},
+		{EventType: model.EventClosed}, // no timestamp: skipped
+	})
+	want := []EventType{EventReopened, EventReopened, EventModified}
+	if len(got) != len(want) {
+		t.Fatalf("got %d events, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i].EventType != want[i] {
+			t.Errorf("event %d = %s, want %s", i, got[i].EventType, want[i])
+		}
+	}
+}
@@ -73,11 +73,17 @@ type ExplicitMatch struct {
 }
 
 // ExtractIDsFromMessage extracts all bead IDs from a commit message.
-// Ordering: matches are returned in the order patterns are evaluated; we also keep stable ID ordering for predictability.
+// Ordering: structured trailers (Bead:, Closes-Bead:) come first, then matches in the
+// order patterns are evaluated; we also keep stable ID ordering for predictability.
 func (m *ExplicitMatcher) ExtractIDsFromMessage(message string) []IDMatch {
 	var matches []IDMatch
 	seen := make(map[string]bool)
 
+	for _, trailer := range ParseBeadTrailers(message) {
+		seen[normalizeBeadID(trailer.ID)] = true
+		matches = append(matches, trailer)
+	}
+
 	for _, pattern := range m.patterns {
 		found := pattern.FindAllStringSubmatch(message, -1)
 		for _, match := range found {
@@ -139,6 +145,11 @@ func classifyMatch(raw string) string {
 
 // CalculateConfidence calculates confidence for an explicit match.
 func CalculateConfidence(matchType string, totalMatches int) float64 {
+	// Trailers are deliberate, structured links: no guessing involved
+	if isTrailerMatchType(matchType) {
+		return TrailerConfidence
+	}
+
 	// Base confidence for explicit ID mention
 	base := 0.90
 
@@ -336,6 +347,12 @@ func (m *ExplicitMatcher) CreateCorrelatedCommit(match ExplicitMatch, coCommitte
 	}
 
 	reason := fmt.Sprintf("Commit message explicitly references %s (%s)", match.BeadID, match.MatchType)
+	switch match.MatchType {
+	case MatchTypeTrailer:
+		reason = fmt.Sprintf("Commit carries trailer %s: %s", TrailerBead, match.BeadID)
+	case MatchTypeClosesTrailer:
+		reason = fmt.Sprintf("Commit carries trailer %s: %s", TrailerClosesBead, match.BeadID)
+	}
 
 	return CorrelatedCommit{
 		BeadID:      match.BeadID,
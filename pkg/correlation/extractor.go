@@ -20,6 +20,7 @@ type ExtractOptions struct {
 	Until  *time.Time // Only commits before this time (nil = no limit)
 	Limit  int        // Max commits to process (0 = no limit)
 	BeadID string     // Filter to single bead ID (empty = all beads)
+	Range  string     // Revision range to walk, e.g. "abc123..HEAD" (empty = all of HEAD)
 }
 
 // Extractor extracts bead lifecycle events from git history
@@ -147,6 +148,9 @@ func (e *Extractor) buildGitLogArgs(opts ExtractOptions) []string {
 	if opts.Limit > 0 {
 		args = insertBefore(args, "--", fmt.Sprintf("-n%d", opts.Limit))
 	}
+	if opts.Range != "" {
+		args = insertBefore(args, "--", opts.Range)
+	}
 
 	// Optimization: If filtering by BeadID, tell git to only show commits
 	// where this ID appears in the diff (added or removed).
@@ -449,6 +453,10 @@ func GetBeadMilestones(events []BeadEvent) BeadMilestones {
 			if milestones.Claimed == nil {
 				milestones.Claimed = event
 			}
+		case EventInReview:
+			if milestones.InReview == nil {
+				milestones.InReview = event
+			}
 		case EventClosed:
 			milestones.Closed = event // Keep latest
 		case EventReopened:
@@ -482,5 +490,15 @@ func CalculateCycleTime(milestones BeadMilestones) *CycleTime {
 		}
 	}
 
+	if milestones.InReview != nil {
+		d := milestones.Closed.Timestamp.Sub(milestones.InReview.Timestamp)
+		ct.ReviewToClose = &d
+
+		if milestones.Claimed != nil {
+			d := milestones.InReview.Timestamp.Sub(milestones.Claimed.Timestamp)
+			ct.ClaimToReview = &d
+		}
+	}
+
 	return ct
 }
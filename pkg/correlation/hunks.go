@@ -0,0 +1,68 @@
+package correlation
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ChangedFile is a file a commit left in the tree, with the first line the
+// commit changed in it: where to open an editor to see the change.
+type ChangedFile struct {
+	Path string `json:"path"`
+	Line int    `json:"line"`
+}
+
+// hunkHeaderRegex captures the new-side start line of a unified diff hunk.
+var hunkHeaderRegex = regexp.MustCompile(`^@@ -\d+(?:,\d+)? \+(\d+)(?:,\d+)? @@`)
+
+// FirstChangedLines returns the files a commit touched that still exist
+// after it, each with the first line it changed, in diff order. Merge
+// commits are diffed against their first parent; binary files are skipped.
+func FirstChangedLines(repoPath, sha string) ([]ChangedFile, error) {
+	cmd := exec.Command("git", "-c", "core.quotePath=false", "show", "-m", "--first-parent",
+		"--format=", "--unified=0", "--no-color", "--no-ext-diff", sha)
+	cmd.Dir = repoPath
+
+	out, err := cmd.Output()
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			return nil, fmt.Errorf("git show failed: %s", strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return nil, fmt.Errorf("git show failed: %w", err)
+	}
+	return parseFirstChangedLines(out), nil
+}
+
+// parseFirstChangedLines reads a unified diff, keeping the first hunk of
+// each file that exists on the new side.
+func parseFirstChangedLines(diff []byte) []ChangedFile {
+	var files []ChangedFile
+	inHeader := false // Between "diff " and the file's first hunk
+	current := ""     // New-side path of the file whose first hunk is pending
+	scanner := bufio.NewScanner(bytes.NewReader(diff))
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.HasPrefix(line, "diff "):
+			inHeader, current = true, ""
+		case inHeader && strings.HasPrefix(line, "+++ "):
+			if path, ok := strings.CutPrefix(line, "+++ b/"); ok {
+				current = path
+			}
+		case inHeader && strings.HasPrefix(line, "@@ "):
+			inHeader = false
+			if m := hunkHeaderRegex.FindStringSubmatch(line); m != nil && current != "" {
+				n, _ := strconv.Atoi(m[1])
+				// A pure deletion reports the line before it, 0 at the top
+				files = append(files, ChangedFile{Path: current, Line: max(n, 1)})
+			}
+		}
+	}
+	return files
+}
@@ -0,0 +1,82 @@
+package correlation
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseFirstChangedLines(t *testing.T) {
+	diff := `diff --git a/pkg/a.go b/pkg/a.go
+index 1..2 100644
+--- a/pkg/a.go
++++ b/pkg/a.go
+@@ -10,2 +12,3 @@ func a() {
++++ b/not/a/header
+@@ -40 +43 @@
+diff --git a/gone.txt b/gone.txt
+deleted file mode 100644
+--- a/gone.txt
++++ /dev/null
+@@ -1,3 +0,0 @@
+diff --git a/logo.png b/logo.png
+Binary files a/logo.png and b/logo.png differ
+diff --git a/README.md b/README.md
+--- a/README.md
++++ b/README.md
+@@ -1 +0,0 @@
+`
+	got := parseFirstChangedLines([]byte(diff))
+	want := []ChangedFile{{Path: "pkg/a.go", Line: 12}, {Path: "README.md", Line: 1}}
+	if len(got) != len(want) {
+		t.Fatalf("got %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("file %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestFirstChangedLines(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	dir := t.TempDir()
+	git := func(args ...string) string {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(), "GIT_AUTHOR_NAME=t", "GIT_AUTHOR_EMAIL=t@t", "GIT_COMMITTER_NAME=t", "GIT_COMMITTER_EMAIL=t@t")
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+		return strings.TrimSpace(string(out))
+	}
+	write := func(name, content string) {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	git("init", "-q")
+	write("main.go", "package main\n\nfunc main() {\n}\n")
+	write("old.txt", "bye\n")
+	git("add", ".")
+	git("commit", "-qm", "init")
+	write("main.go", "package main\n\nfunc main() {\n\tprintln(1)\n}\n")
+	git("rm", "-q", "old.txt")
+	git("commit", "-qam", "change")
+
+	files, err := FirstChangedLines(dir, git("rev-parse", "HEAD"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 1 || files[0] != (ChangedFile{Path: "main.go", Line: 4}) {
+		t.Errorf("files = %+v, want main.go:4 only", files)
+	}
+	if _, err := FirstChangedLines(dir, "0000000"); err == nil {
+		t.Error("unknown commit should fail")
+	}
+}
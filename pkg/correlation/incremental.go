@@ -312,11 +312,18 @@ func mergeReports(existing *HistoryReport, beads []BeadInfo, newEvents []BeadEve
 		}
 	}
 
-	// Update bead statuses from current beads list
+	// Update bead statuses (and recorded tracker events) from current beads list
+	tracked := make(map[string]bool)
 	for _, bead := range beads {
 		if h, exists := histories[bead.ID]; exists {
 			h.Title = bead.Title
 			h.Status = bead.Status
+			if events := TrackerEvents(bead.ID, bead.Events); len(events) > 0 {
+				tracked[bead.ID] = true
+				h.Events = events
+				h.Milestones = GetBeadMilestones(h.Events)
+				h.CycleTime = CalculateCycleTime(h.Milestones)
+			}
 			histories[bead.ID] = h
 		}
 	}
@@ -328,6 +335,9 @@ func mergeReports(existing *HistoryReport, beads []BeadInfo, newEvents []BeadEve
 	}
 
 	for beadID, events := range eventsByBead {
+		if tracked[beadID] {
+			continue // Tracker events take precedence over git-inferred ones
+		}
 		if h, exists := histories[beadID]; exists {
 			h.Events = append(h.Events, events...)
 			// Recalculate milestones
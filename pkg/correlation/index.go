@@ -0,0 +1,359 @@
+// Package correlation provides a persistent correlation index so repeated
+// history reports only process commits that are new since the last run.
+package correlation
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	_ "modernc.org/sqlite"
+)
+
+// IndexFilename is the correlation index file name under .bv/.
+const IndexFilename = "correlation.db"
+
+// indexSchemaVersion is bumped whenever stored data changes shape or meaning;
+// a mismatch forces a full rebuild.
+const indexSchemaVersion = "1"
+
+// DefaultIndexPath returns the default correlation index path for a project.
+func DefaultIndexPath(projectDir string) string {
+	return filepath.Join(projectDir, ".bv", IndexFilename)
+}
+
+// DisableIndexEnv disables the persistent correlation index when set.
+const DisableIndexEnv = "BV_NO_CORRELATION_INDEX"
+
+// NewIndexedCorrelator returns a correlator that uses the persistent index at
+// DefaultIndexPath(repoPath), unless DisableIndexEnv is set.
+func NewIndexedCorrelator(repoPath string, beadsFilePath ...string) *Correlator {
+	c := NewCorrelator(repoPath, beadsFilePath...)
+	if os.Getenv(DisableIndexEnv) == "" {
+		c.UseIndex(DefaultIndexPath(repoPath))
+	}
+	return c
+}
+
+// IndexSyncStats describes what a sync did.
+type IndexSyncStats struct {
+	Rebuilt        bool   `json:"rebuilt"`         // True if the index was (re)built from scratch
+	Reason         string `json:"reason"`          // Why a rebuild happened
+	NewEvents      int    `json:"new_events"`      // Events added by this sync
+	NewCommits     int    `json:"new_commits"`     // Correlated commits added by this sync
+	Tip            string `json:"tip"`             // HEAD the index now covers
+	PreviousTip    string `json:"previous_tip"`    // HEAD the index covered before
+	ProcessedRange string `json:"processed_range"` // Revision range walked ("" = full history)
+}
+
+// CorrelationIndex is a SQLite-backed store of extracted events and correlated
+// commits keyed by commit SHA.
+type CorrelationIndex struct {
+	db   *sql.DB
+	path string
+}
+
+const indexSchema = `
+CREATE TABLE IF NOT EXISTS meta (
+	key   TEXT PRIMARY KEY,
+	value TEXT NOT NULL
+);
+CREATE TABLE IF NOT EXISTS events (
+	seq          INTEGER PRIMARY KEY AUTOINCREMENT,
+	sha          TEXT NOT NULL,
+	bead_id      TEXT NOT NULL,
+	event_type   TEXT NOT NULL,
+	timestamp    INTEGER NOT NULL,
+	time         TEXT NOT NULL,
+	author       TEXT NOT NULL,
+	author_email TEXT NOT NULL,
+	message      TEXT NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_events_sha ON events(sha);
+CREATE TABLE IF NOT EXISTS commits (
+	seq          INTEGER PRIMARY KEY AUTOINCREMENT,
+	sha          TEXT NOT NULL,
+	bead_id      TEXT NOT NULL,
+	method       TEXT NOT NULL,
+	confidence   REAL NOT NULL,
+	reason       TEXT NOT NULL,
+	message      TEXT NOT NULL,
+	author       TEXT NOT NULL,
+	author_email TEXT NOT NULL,
+	timestamp    INTEGER NOT NULL,
+	time         TEXT NOT NULL,
+	files        TEXT NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_commits_sha ON commits(sha);
+`
+
+// OpenIndex opens (creating if needed) the correlation index at path.
+func OpenIndex(path string) (*CorrelationIndex, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("creating index directory: %w", err)
+	}
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, fmt.Errorf("opening correlation index: %w", err)
+	}
+	if _, err := db.Exec(indexSchema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("initializing correlation index: %w", err)
+	}
+	return &CorrelationIndex{db: db, path: path}, nil
+}
+
+// Close closes the underlying database.
+func (ix *CorrelationIndex) Close() error {
+	return ix.db.Close()
+}
+
+// Path returns the index file path.
+func (ix *CorrelationIndex) Path() string {
+	return ix.path
+}
+
+func (ix *CorrelationIndex) meta(key string) string {
+	var value string
+	_ = ix.db.QueryRow(`SELECT value FROM meta WHERE key = ?`, key).Scan(&value)
+	return value
+}
+
+// Sync brings the index up to date with HEAD. When the previously indexed tip
+// is an ancestor of HEAD only the new commits are walked; otherwise (first run,
+// rewritten history, different beads file, schema change) the index is rebuilt.
+func (ix *CorrelationIndex) Sync(c *Correlator) (IndexSyncStats, error) {
+	head, err := getGitHead(c.repoPath)
+	if err != nil {
+		return IndexSyncStats{}, fmt.Errorf("resolving HEAD: %w", err)
+	}
+	head = strings.TrimSpace(head)
+
+	stats := IndexSyncStats{Tip: head, PreviousTip: ix.meta("tip")}
+	beadsFile := c.extractor.primaryBeadsFile()
+
+	switch {
+	case stats.PreviousTip == "":
+		stats.Rebuilt, stats.Reason = true, "new index"
+	case ix.meta("schema_version") != indexSchemaVersion:
+		stats.Rebuilt, stats.Reason = true, "schema version changed"
+	case ix.meta("beads_file") != beadsFile:
+		stats.Rebuilt, stats.Reason = true, "beads file changed"
+	case stats.PreviousTip == head:
+		return stats, nil
+	case !isAncestor(c.repoPath, stats.PreviousTip, head):
+		stats.Rebuilt, stats.Reason = true, "history rewritten"
+	default:
+		stats.ProcessedRange = stats.PreviousTip + ".." + head
+	}
+
+	opts := ExtractOptions{Range: stats.ProcessedRange}
+	events, err := c.extractor.Extract(opts)
+	if err != nil {
+		return stats, fmt.Errorf("extracting events: %w", err)
+	}
+	commits, err := c.coCommitter.ExtractAllCoCommits(events)
+	if err != nil {
+		return stats, fmt.Errorf("extracting co-commits: %w", err)
+	}
+	commits = append(commits, c.extractTrailerCommits(opts)...)
+
+	tx, err := ix.db.Begin()
+	if err != nil {
+		return stats, fmt.Errorf("starting index transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if stats.Rebuilt {
+		for _, table := range []string{"events", "commits", "meta"} {
+			if _, err := tx.Exec(`DELETE FROM ` + table); err != nil {
+				return stats, fmt.Errorf("clearing %s: %w", table, err)
+			}
+		}
+	}
+
+	for _, e := range events {
+		if _, err := tx.Exec(`INSERT INTO events (sha, bead_id, event_type, timestamp, time, author, author_email, message) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+			e.CommitSHA, e.BeadID, string(e.EventType), e.Timestamp.Unix(), e.Timestamp.Format(time.RFC3339Nano), e.Author, e.AuthorEmail, e.CommitMsg); err != nil {
+			return stats, fmt.Errorf("storing event: %w", err)
+		}
+	}
+	// Oldest first so seq order stays chronological across incremental syncs
+	sort.SliceStable(commits, func(i, j int) bool { return commits[i].Timestamp.Before(commits[j].Timestamp) })
+	for _, cm := range commits {
+		files, err := json.Marshal(cm.Files)
+		if err != nil {
+			return stats, fmt.Errorf("encoding files: %w", err)
+		}
+		if _, err := tx.Exec(`INSERT INTO commits (sha, bead_id, method, confidence, reason, message, author, author_email, timestamp, time, files) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			cm.SHA, cm.BeadID, string(cm.Method), cm.Confidence, cm.Reason, cm.Message, cm.Author, cm.AuthorEmail, cm.Timestamp.Unix(), cm.Timestamp.Format(time.RFC3339Nano), string(files)); err != nil {
+			return stats, fmt.Errorf("storing commit: %w", err)
+		}
+	}
+
+	for key, value := range map[string]string{
+		"tip":            head,
+		"schema_version": indexSchemaVersion,
+		"beads_file":     beadsFile,
+		"updated_at":     time.Now().UTC().Format(time.RFC3339),
+	} {
+		if _, err := tx.Exec(`INSERT OR REPLACE INTO meta (key, value) VALUES (?, ?)`, key, value); err != nil {
+			return stats, fmt.Errorf("storing index metadata: %w", err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return stats, fmt.Errorf("committing index: %w", err)
+	}
+
+	stats.NewEvents = len(events)
+	stats.NewCommits = len(commits)
+	return stats, nil
+}
+
+// isAncestor reports whether ancestor is reachable from descendant.
+func isAncestor(repoPath, ancestor, descendant string) bool {
+	cmd := exec.Command("git", "merge-base", "--is-ancestor", ancestor, descendant)
+	cmd.Dir = repoPath
+	return cmd.Run() == nil
+}
+
+// Load reads events and correlated commits from the index, applying the same
+// Since/Until/Limit/BeadID semantics as a direct git walk. Limit keeps the
+// newest N beads-file commits (and, separately, the newest N trailer commits).
+func (ix *CorrelationIndex) Load(opts ExtractOptions) ([]BeadEvent, []CorrelatedCommit, error) {
+	events, err := ix.loadEvents(opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Restrict to the newest Limit distinct commits, mirroring git log -n
+	if opts.Limit > 0 {
+		keep := newestSHAs(len(events), func(i int) string { return events[len(events)-1-i].CommitSHA }, opts.Limit)
+		filtered := events[:0]
+		for _, e := range events {
+			if keep[e.CommitSHA] {
+				filtered = append(filtered, e)
+			}
+		}
+		events = filtered
+	}
+	eventSHAs := make(map[string]bool, len(events))
+	for _, e := range events {
+		eventSHAs[e.CommitSHA] = true
+	}
+
+	commits, err := ix.loadCommits(opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var trailerKeep map[string]bool
+	if opts.Limit > 0 {
+		var trailerSHAs []string
+		for i := len(commits) - 1; i >= 0; i-- {
+			if commits[i].Method == MethodExplicitID {
+				trailerSHAs = append(trailerSHAs, commits[i].SHA)
+			}
+		}
+		trailerKeep = newestSHAs(len(trailerSHAs), func(i int) string { return trailerSHAs[i] }, opts.Limit)
+	}
+
+	filtered := commits[:0]
+	for _, cm := range commits {
+		if cm.Method == MethodCoCommitted && !eventSHAs[cm.SHA] {
+			continue
+		}
+		if cm.Method == MethodExplicitID && trailerKeep != nil && !trailerKeep[cm.SHA] {
+			continue
+		}
+		filtered = append(filtered, cm)
+	}
+
+	return events, filtered, nil
+}
+
+// newestSHAs collects up to limit distinct SHAs from an iterator that yields
+// SHAs newest first.
+func newestSHAs(n int, at func(int) string, limit int) map[string]bool {
+	keep := make(map[string]bool)
+	for i := 0; i < n && len(keep) < limit; i++ {
+		keep[at(i)] = true
+	}
+	return keep
+}
+
+func (ix *CorrelationIndex) loadEvents(opts ExtractOptions) ([]BeadEvent, error) {
+	query, args := indexFilterQuery(`SELECT sha, bead_id, event_type, time, author, author_email, message FROM events`, opts)
+	rows, err := ix.db.Query(query+` ORDER BY seq`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("querying events: %w", err)
+	}
+	defer rows.Close()
+
+	var events []BeadEvent
+	for rows.Next() {
+		var e BeadEvent
+		var eventType, ts string
+		if err := rows.Scan(&e.CommitSHA, &e.BeadID, &eventType, &ts, &e.Author, &e.AuthorEmail, &e.CommitMsg); err != nil {
+			return nil, fmt.Errorf("reading event: %w", err)
+		}
+		e.EventType = EventType(eventType)
+		e.Timestamp, _ = time.Parse(time.RFC3339Nano, ts)
+		events = append(events, e)
+	}
+	return events, rows.Err()
+}
+
+func (ix *CorrelationIndex) loadCommits(opts ExtractOptions) ([]CorrelatedCommit, error) {
+	query, args := indexFilterQuery(`SELECT sha, bead_id, method, confidence, reason, message, author, author_email, time, files FROM commits`, opts)
+	rows, err := ix.db.Query(query+` ORDER BY seq`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("querying commits: %w", err)
+	}
+	defer rows.Close()
+
+	var commits []CorrelatedCommit
+	for rows.Next() {
+		var cm CorrelatedCommit
+		var method, ts, files string
+		if err := rows.Scan(&cm.SHA, &cm.BeadID, &method, &cm.Confidence, &cm.Reason, &cm.Message, &cm.Author, &cm.AuthorEmail, &ts, &files); err != nil {
+			return nil, fmt.Errorf("reading commit: %w", err)
+		}
+		cm.Method = CorrelationMethod(method)
+		cm.ShortSHA = shortSHA(cm.SHA)
+		cm.Timestamp, _ = time.Parse(time.RFC3339Nano, ts)
+		if err := json.Unmarshal([]byte(files), &cm.Files); err != nil {
+			return nil, fmt.Errorf("decoding files for %s: %w", cm.ShortSHA, err)
+		}
+		commits = append(commits, cm)
+	}
+	return commits, rows.Err()
+}
+
+// indexFilterQuery appends WHERE clauses for the time window and bead filter.
+func indexFilterQuery(base string, opts ExtractOptions) (string, []any) {
+	var clauses []string
+	var args []any
+	if opts.Since != nil {
+		clauses = append(clauses, "timestamp >= ?")
+		args = append(args, opts.Since.Unix())
+	}
+	if opts.Until != nil {
+		clauses = append(clauses, "timestamp <= ?")
+		args = append(args, opts.Until.Unix())
+	}
+	if opts.BeadID != "" {
+		clauses = append(clauses, "bead_id = ?")
+		args = append(args, opts.BeadID)
+	}
+	if len(clauses) == 0 {
+		return base, nil
+	}
+	return base + " WHERE " + strings.Join(clauses, " AND "), args
+}
@@ -0,0 +1,206 @@
+package correlation
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"testing"
+)
+
+type indexTestRepo struct {
+	t   *testing.T
+	dir string
+}
+
+func newIndexTestRepo(t *testing.T) *indexTestRepo {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	r := &indexTestRepo{t: t, dir: t.TempDir()}
+	r.git("init", "-q")
+	if err := os.MkdirAll(filepath.Join(r.dir, ".beads"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	return r
+}
+
+func (r *indexTestRepo) git(args ...string) string {
+	r.t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = r.dir
+	cmd.Env = append(os.Environ(),
+		"GIT_AUTHOR_NAME=Dev", "GIT_AUTHOR_EMAIL=dev@example.com",
+		"GIT_COMMITTER_NAME=Dev", "GIT_COMMITTER_EMAIL=dev@example.com")
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		r.t.Fatalf("git %v: %v\n%s", args, err, out)
+	}
+	return string(out)
+}
+
+func (r *indexTestRepo) commit(msg string, files map[string]string) {
+	r.t.Helper()
+	for name, content := range files {
+		path := filepath.Join(r.dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			r.t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			r.t.Fatal(err)
+		}
+	}
+	r.git("add", "-A")
+	r.git("commit", "-q", "-m", msg)
+}
+
+const (
+	indexBeadOpen       = `{"id":"bv-1","title":"Index","status":"open"}` + "\n"
+	indexBeadInProgress = `{"id":"bv-1","title":"Index","status":"in_progress"}` + "\n"
+	indexBeadClosed     = `{"id":"bv-1","title":"Index","status":"closed"}` + "\n"
+)
+
+func commitSHAs(report *HistoryReport, beadID string) []string {
+	var shas []string
+	for _, c := range report.Histories[beadID].Commits {
+		shas = append(shas, c.SHA)
+	}
+	sort.Strings(shas)
+	return shas
+}
+
+func TestCorrelationIndex_IncrementalSync(t *testing.T) {
+	r := newIndexTestRepo(t)
+	r.commit("Create bv-1", map[string]string{".beads/issues.jsonl": indexBeadOpen})
+	r.commit("Start bv-1", map[string]string{".beads/issues.jsonl": indexBeadInProgress, "pkg/a.go": "package a\n"})
+
+	ix, err := OpenIndex(DefaultIndexPath(r.dir))
+	if err != nil {
+		t.Fatalf("OpenIndex: %v", err)
+	}
+	defer ix.Close()
+
+	c := NewCorrelator(r.dir)
+	stats, err := ix.Sync(c)
+	if err != nil {
+		t.Fatalf("initial Sync: %v", err)
+	}
+	if !stats.Rebuilt || stats.Reason != "new index" || stats.NewEvents != 2 {
+		t.Fatalf("initial sync = %+v, want rebuild with 2 events", stats)
+	}
+
+	stats, err = ix.Sync(c)
+	if err != nil {
+		t.Fatalf("no-op Sync: %v", err)
+	}
+	if stats.Rebuilt || stats.NewEvents != 0 || stats.ProcessedRange != "" {
+		t.Errorf("unchanged HEAD should be a no-op, got %+v", stats)
+	}
+
+	r.commit("Close bv-1\n\nBead: bv-1", map[string]string{".beads/issues.jsonl": indexBeadClosed, "pkg/b.go": "package b\n"})
+	stats, err = ix.Sync(c)
+	if err != nil {
+		t.Fatalf("incremental Sync: %v", err)
+	}
+	if stats.Rebuilt || stats.ProcessedRange == "" || stats.NewEvents != 1 {
+		t.Fatalf("incremental sync = %+v, want 1 new event over a range", stats)
+	}
+
+	events, _, err := ix.Load(ExtractOptions{})
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	want := []EventType{EventCreated, EventClaimed, EventClosed}
+	if len(events) != len(want) {
+		t.Fatalf("loaded %d events, want %d", len(events), len(want))
+	}
+	for i, e := range events {
+		if e.EventType != want[i] {
+			t.Errorf("event %d = %s, want %s", i, e.EventType, want[i])
+		}
+	}
+
+	// Rewriting history forces a rebuild
+	r.git("commit", "-q", "--amend", "-m", "Close bv-1 (amended)")
+	stats, err = ix.Sync(c)
+	if err != nil {
+		t.Fatalf("Sync after amend: %v", err)
+	}
+	if !stats.Rebuilt || stats.Reason != "history rewritten" {
+		t.Errorf("amend should rebuild, got %+v", stats)
+	}
+}
+
+func TestCorrelator_UseIndexMatchesDirectWalk(t *testing.T) {
+	r := newIndexTestRepo(t)
+	r.commit("Create bv-1", map[string]string{".beads/issues.jsonl": indexBeadOpen})
+	r.commit("Start bv-1", map[string]string{".beads/issues.jsonl": indexBeadInProgress, "pkg/a.go": "package a\n"})
+	r.commit("Follow-up\n\nBead: bv-1", map[string]string{"pkg/c.go": "package c\n"})
+	r.commit("Close bv-1", map[string]string{".beads/issues.jsonl": indexBeadClosed, "pkg/b.go": "package b\n"})
+
+	beads := []BeadInfo{{ID: "bv-1", Title: "Index", Status: "closed"}}
+	for _, limit := range []int{0, 1} {
+		opts := CorrelatorOptions{Limit: limit}
+		direct, err := NewCorrelator(r.dir).GenerateReport(beads, opts)
+		if err != nil {
+			t.Fatalf("direct GenerateReport: %v", err)
+		}
+
+		indexed := NewCorrelator(r.dir)
+		indexed.UseIndex(DefaultIndexPath(r.dir))
+		got, err := indexed.GenerateReport(beads, opts)
+		if err != nil {
+			t.Fatalf("indexed GenerateReport: %v", err)
+		}
+		if indexed.LastIndexSync() == nil {
+			t.Fatalf("limit %d: expected report to come from the index", limit)
+		}
+
+		if d, g := commitSHAs(direct, "bv-1"), commitSHAs(got, "bv-1"); !equalStrings(d, g) {
+			t.Errorf("limit %d: commits differ\n direct:  %v\n indexed: %v", limit, d, g)
+		}
+		if len(direct.Histories["bv-1"].Events) != len(got.Histories["bv-1"].Events) {
+			t.Errorf("limit %d: events differ: direct %d, indexed %d", limit,
+				len(direct.Histories["bv-1"].Events), len(got.Histories["bv-1"].Events))
+		}
+		if direct.Stats.TotalCommits != got.Stats.TotalCommits {
+			t.Errorf("limit %d: TotalCommits direct %d, indexed %d", limit, direct.Stats.TotalCommits, got.Stats.TotalCommits)
+		}
+	}
+}
+
+func TestCorrelator_UseIndexFallsBack(t *testing.T) {
+	r := newIndexTestRepo(t)
+	r.commit("Create bv-1", map[string]string{".beads/issues.jsonl": indexBeadOpen})
+
+	// A directory where the database file should be cannot be opened
+	badPath := filepath.Join(r.dir, "not-a-db")
+	if err := os.MkdirAll(badPath, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	c := NewCorrelator(r.dir)
+	c.UseIndex(badPath)
+	report, err := c.GenerateReport([]BeadInfo{{ID: "bv-1", Status: "open"}}, CorrelatorOptions{})
+	if err != nil {
+		t.Fatalf("GenerateReport should fall back to git: %v", err)
+	}
+	if c.LastIndexSync() != nil {
+		t.Error("expected no index sync on fallback")
+	}
+	if len(report.Histories["bv-1"].Events) != 1 {
+		t.Errorf("fallback events = %d, want 1", len(report.Histories["bv-1"].Events))
+	}
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
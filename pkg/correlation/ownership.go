@@ -0,0 +1,266 @@
+package correlation
+
+import (
+	"math"
+	"path"
+	"sort"
+	"strings"
+)
+
+// OwnershipOptions controls the bus-factor analysis.
+type OwnershipOptions struct {
+	// Labels maps bead ID to its labels; commits count toward a label
+	// through the beads they are correlated with
+	Labels map[string][]string
+	// PageRank maps bead ID to its PageRank; the top quarter of open beads
+	// by PageRank are the key issues an area's owner stands between
+	PageRank map[string]float64
+	// Threshold is the top author's commit share at or above which an area
+	// is concentrated (default 0.8)
+	Threshold float64
+	// MinCommits is the fewest commits an area needs to be judged (default 3)
+	MinCommits int
+	// SubsystemDepth is how many leading directories name a subsystem
+	// (default 2)
+	SubsystemDepth int
+}
+
+// AuthorShare is one author's part of an area's commits.
+type AuthorShare struct {
+	Author  string  `json:"author"`
+	Email   string  `json:"email,omitempty"`
+	Commits int     `json:"commits"`
+	Share   float64 `json:"share"`
+}
+
+// OwnershipIssue is an open bead whose area depends on few people.
+type OwnershipIssue struct {
+	BeadID   string  `json:"bead_id"`
+	Title    string  `json:"title"`
+	PageRank float64 `json:"pagerank"`
+}
+
+// OwnershipArea is the authorship of one label or subsystem.
+type OwnershipArea struct {
+	Kind     string        `json:"kind"` // "label" or "subsystem"
+	Name     string        `json:"name"`
+	Commits  int           `json:"commits"`
+	Authors  []AuthorShare `json:"authors"` // Most commits first
+	TopShare float64       `json:"top_share"`
+	// BusFactor is the fewest authors who together made more than half
+	// of the area's commits
+	BusFactor     int              `json:"bus_factor"`
+	OpenIssues    int              `json:"open_issues"`
+	KeyOpenIssues []OwnershipIssue `json:"key_open_issues"` // Highest PageRank first
+	// Risk is the top share times the PageRank of the area's most central
+	// open issue relative to the project's most central one (0-1)
+	Risk   float64 `json:"risk"`
+	AtRisk bool    `json:"at_risk"` // Concentrated, enough commits and key open issues
+}
+
+// OwnershipReport is the bus-factor analysis of labels and subsystems.
+type OwnershipReport struct {
+	Threshold    float64         `json:"threshold"`
+	TotalCommits int             `json:"total_commits"`
+	Authors      int             `json:"authors"`
+	AtRisk       int             `json:"at_risk"`
+	Areas        []OwnershipArea `json:"areas"` // At-risk areas first, then by risk
+}
+
+// ownershipAcc collects one area's distinct commits and open beads
+type ownershipAcc struct {
+	kind, name string
+	shas       map[string]string // SHA -> author key
+	open       map[string]bool
+}
+
+// AnalyzeOwnership measures how concentrated the authorship of each label
+// and subsystem is, and flags the concentrated areas that hold high-PageRank
+// open work: the places where losing one person stalls the project.
+func (hr *HistoryReport) AnalyzeOwnership(opts OwnershipOptions) *OwnershipReport {
+	if opts.Threshold <= 0 {
+		opts.Threshold = 0.8
+	}
+	if opts.MinCommits <= 0 {
+		opts.MinCommits = 3
+	}
+	if opts.SubsystemDepth <= 0 {
+		opts.SubsystemDepth = 2
+	}
+
+	// Key open issues: the top quarter of open beads by PageRank
+	var ranks []float64
+	maxRank := 0.0
+	for id, h := range hr.Histories {
+		if h.Status != "closed" {
+			if pr, ok := opts.PageRank[id]; ok {
+				ranks = append(ranks, pr)
+				maxRank = math.Max(maxRank, pr)
+			}
+		}
+	}
+	cutoff := math.Inf(1)
+	if len(ranks) > 0 {
+		sort.Sort(sort.Reverse(sort.Float64Slice(ranks)))
+		cutoff = ranks[(len(ranks)-1)/4]
+	}
+
+	areas := make(map[string]*ownershipAcc)
+	area := func(kind, name string) *ownershipAcc {
+		key := kind + ":" + name
+		if areas[key] == nil {
+			areas[key] = &ownershipAcc{kind: kind, name: name, shas: make(map[string]string), open: make(map[string]bool)}
+		}
+		return areas[key]
+	}
+
+	names := make(map[string]AuthorShare) // Author key -> display name and email
+	allSHAs := make(map[string]bool)
+	for id, h := range hr.Histories {
+		open := h.Status != "closed"
+		for _, label := range opts.Labels[id] {
+			acc := area("label", label)
+			if open {
+				acc.open[id] = true
+			}
+			for _, c := range h.Commits {
+				acc.shas[c.SHA] = authorKey(c)
+			}
+		}
+		for _, c := range h.Commits {
+			key := authorKey(c)
+			// One name per author, whichever order histories come in
+			if known, ok := names[key]; !ok || c.Author < known.Author {
+				names[key] = AuthorShare{Author: c.Author, Email: c.AuthorEmail}
+			}
+			allSHAs[c.SHA] = true
+			for _, f := range c.Files {
+				dir := subsystemOf(f.Path, opts.SubsystemDepth)
+				if dir == "" {
+					continue
+				}
+				acc := area("subsystem", dir)
+				acc.shas[c.SHA] = key
+				if open {
+					acc.open[id] = true
+				}
+			}
+		}
+	}
+
+	report := &OwnershipReport{
+		Threshold:    opts.Threshold,
+		TotalCommits: len(allSHAs),
+		Authors:      len(names),
+		Areas:        []OwnershipArea{},
+	}
+	for _, acc := range areas {
+		if len(acc.shas) == 0 {
+			continue
+		}
+		perAuthor := make(map[string]int)
+		for _, key := range acc.shas {
+			perAuthor[key]++
+		}
+		result := OwnershipArea{
+			Kind:          acc.kind,
+			Name:          acc.name,
+			Commits:       len(acc.shas),
+			OpenIssues:    len(acc.open),
+			KeyOpenIssues: []OwnershipIssue{},
+		}
+		for key, n := range perAuthor {
+			share := names[key]
+			share.Commits = n
+			share.Share = roundShare(float64(n) / float64(result.Commits))
+			result.Authors = append(result.Authors, share)
+		}
+		sort.Slice(result.Authors, func(i, j int) bool {
+			ai, aj := result.Authors[i], result.Authors[j]
+			if ai.Commits != aj.Commits {
+				return ai.Commits > aj.Commits
+			}
+			return strings.ToLower(ai.Author) < strings.ToLower(aj.Author)
+		})
+		result.TopShare = result.Authors[0].Share
+		covered := 0
+		for _, a := range result.Authors {
+			covered += a.Commits
+			result.BusFactor++
+			if 2*covered > result.Commits {
+				break
+			}
+		}
+
+		topRank := 0.0
+		for id := range acc.open {
+			pr, ok := opts.PageRank[id]
+			if !ok {
+				continue
+			}
+			topRank = math.Max(topRank, pr)
+			if pr >= cutoff {
+				result.KeyOpenIssues = append(result.KeyOpenIssues, OwnershipIssue{BeadID: id, Title: hr.Histories[id].Title, PageRank: pr})
+			}
+		}
+		sort.Slice(result.KeyOpenIssues, func(i, j int) bool {
+			ki, kj := result.KeyOpenIssues[i], result.KeyOpenIssues[j]
+			if ki.PageRank != kj.PageRank {
+				return ki.PageRank > kj.PageRank
+			}
+			return ki.BeadID < kj.BeadID
+		})
+		if maxRank > 0 {
+			result.Risk = roundShare(result.TopShare * topRank / maxRank)
+		}
+		result.AtRisk = result.TopShare >= opts.Threshold && result.Commits >= opts.MinCommits && len(result.KeyOpenIssues) > 0
+		if result.AtRisk {
+			report.AtRisk++
+		}
+		report.Areas = append(report.Areas, result)
+	}
+
+	sort.Slice(report.Areas, func(i, j int) bool {
+		ai, aj := report.Areas[i], report.Areas[j]
+		if ai.AtRisk != aj.AtRisk {
+			return ai.AtRisk
+		}
+		if ai.Risk != aj.Risk {
+			return ai.Risk > aj.Risk
+		}
+		if ai.Commits != aj.Commits {
+			return ai.Commits > aj.Commits
+		}
+		if ai.Kind != aj.Kind {
+			return ai.Kind < aj.Kind
+		}
+		return ai.Name < aj.Name
+	})
+	return report
+}
+
+// authorKey identifies a commit's author by email, falling back to name
+func authorKey(c CorrelatedCommit) string {
+	if c.AuthorEmail != "" {
+		return strings.ToLower(c.AuthorEmail)
+	}
+	return strings.ToLower(c.Author)
+}
+
+// subsystemOf names the subsystem a file belongs to: its first depth
+// directories, or "" for files at the repository root
+func subsystemOf(file string, depth int) string {
+	dir := path.Dir(normalizePath(file))
+	if dir == "." || dir == "/" {
+		return ""
+	}
+	parts := strings.Split(dir, "/")
+	if len(parts) > depth {
+		parts = parts[:depth]
+	}
+	return strings.Join(parts, "/")
+}
+
+func roundShare(v float64) float64 {
+	return math.Round(v*100) / 100
+}
@@ -0,0 +1,110 @@
+package correlation
+
+import "testing"
+
+func ownershipTestReport() *HistoryReport {
+	commit := func(sha, author string, files ...string) CorrelatedCommit {
+		c := CorrelatedCommit{SHA: sha, Author: author, AuthorEmail: author + "@example.com"}
+		for _, f := range files {
+			c.Files = append(c.Files, FileChange{Path: f})
+		}
+		return c
+	}
+	return &HistoryReport{
+		Histories: map[string]BeadHistory{
+			"bv-1": {BeadID: "bv-1", Title: "Login flow", Status: "closed", Commits: []CorrelatedCommit{
+				commit("a1", "alice", "pkg/auth/login.go"),
+				commit("a2", "alice", "pkg/auth/login.go", "pkg/auth/oauth/google.go"),
+			}},
+			"bv-2": {BeadID: "bv-2", Title: "Token refresh", Status: "open", Commits: []CorrelatedCommit{
+				commit("a3", "alice", "pkg/auth/token.go"),
+				commit("a4", "Alice", "pkg/auth/token.go"),
+			}},
+			"bv-3": {BeadID: "bv-3", Title: "Routes", Status: "open", Commits: []CorrelatedCommit{
+				commit("b1", "bob", "pkg/api/routes.go"),
+				commit("c1", "carol", "pkg/api/routes.go"),
+				commit("d1", "dave", "pkg/api/handlers.go", "README.md"),
+			}},
+			"bv-4": {BeadID: "bv-4", Title: "Docs", Status: "open"},
+		},
+	}
+}
+
+func TestAnalyzeOwnership(t *testing.T) {
+	report := ownershipTestReport().AnalyzeOwnership(OwnershipOptions{
+		Labels:   map[string][]string{"bv-1": {"auth"}, "bv-2": {"auth"}, "bv-3": {"api"}},
+		PageRank: map[string]float64{"bv-2": 0.4, "bv-3": 0.2, "bv-4": 0.1},
+	})
+
+	if report.TotalCommits != 7 || report.Authors != 4 {
+		t.Errorf("totals = %d commits, %d authors; want 7 and 4", report.TotalCommits, report.Authors)
+	}
+	areas := make(map[string]OwnershipArea)
+	for _, a := range report.Areas {
+		areas[a.Kind+":"+a.Name] = a
+	}
+	if len(areas) != 4 {
+		t.Fatalf("areas = %v, want label:auth, label:api, subsystem:pkg/auth and subsystem:pkg/api", areas)
+	}
+
+	auth := areas["label:auth"]
+	if auth.Commits != 4 || auth.TopShare != 1 || auth.BusFactor != 1 || len(auth.Authors) != 1 {
+		t.Errorf("label:auth = %+v, want 4 commits all by alice", auth)
+	}
+	if auth.Authors[0].Author != "Alice" {
+		t.Errorf("author name = %q, want the same one for every commit from one email", auth.Authors[0].Author)
+	}
+	if !auth.AtRisk || len(auth.KeyOpenIssues) != 1 || auth.KeyOpenIssues[0].BeadID != "bv-2" || auth.Risk != 1 {
+		t.Errorf("label:auth should be at risk through bv-2; got %+v", auth)
+	}
+	if sub := areas["subsystem:pkg/auth"]; sub.Commits != 4 || !sub.AtRisk {
+		t.Errorf("subsystem:pkg/auth = %+v, want 4 commits at risk (pkg/auth/oauth folds into it)", sub)
+	}
+
+	api := areas["label:api"]
+	if api.TopShare != 0.33 || api.BusFactor != 2 || api.AtRisk {
+		t.Errorf("label:api = %+v, want a shared area with bus factor 2", api)
+	}
+	if len(api.KeyOpenIssues) != 0 || api.OpenIssues != 1 {
+		t.Errorf("bv-3 is open but outside the top quarter by PageRank; got %+v", api.KeyOpenIssues)
+	}
+
+	if report.AtRisk != 2 || !report.Areas[0].AtRisk || !report.Areas[1].AtRisk || report.Areas[2].AtRisk {
+		t.Errorf("at-risk areas should come first; got %d at risk, order %+v", report.AtRisk, report.Areas)
+	}
+}
+
+func TestAnalyzeOwnershipThresholds(t *testing.T) {
+	opts := OwnershipOptions{
+		Labels:     map[string][]string{"bv-3": {"api"}},
+		PageRank:   map[string]float64{"bv-3": 1},
+		Threshold:  0.3,
+		MinCommits: 3,
+	}
+	report := ownershipTestReport().AnalyzeOwnership(opts)
+	for _, a := range report.Areas {
+		if a.Kind == "label" && a.Name == "api" && !a.AtRisk {
+			t.Errorf("label:api with a 0.33 top share should be at risk at threshold 0.3: %+v", a)
+		}
+	}
+
+	opts.MinCommits = 10
+	if report := ownershipTestReport().AnalyzeOwnership(opts); report.AtRisk != 0 {
+		t.Errorf("no area has 10 commits, yet %d are at risk", report.AtRisk)
+	}
+}
+
+func TestSubsystemOf(t *testing.T) {
+	tests := map[string]string{
+		"README.md":                "",
+		"cmd/bv/main.go":           "cmd/bv",
+		"pkg/ui/insights.go":       "pkg/ui",
+		"pkg/ui/widgets/button.go": "pkg/ui",
+		"./pkg/ui/model.go":        "pkg/ui",
+	}
+	for file, want := range tests {
+		if got := subsystemOf(file, 2); got != want {
+			t.Errorf("subsystemOf(%q) = %q, want %q", file, got, want)
+		}
+	}
+}
@@ -135,6 +135,26 @@ func (hr *HistoryReport) FindRelatedWork(targetID string, opts RelatedWorkOption
 	return result
 }
 
+// BeadFiles maps each bead with correlated commits to the sorted,
+// normalized paths those commits touched
+func (hr *HistoryReport) BeadFiles() map[string][]string {
+	files := make(map[string][]string, len(hr.Histories))
+	for id, history := range hr.Histories {
+		seen := make(map[string]bool)
+		for _, commit := range history.Commits {
+			for _, fc := range commit.Files {
+				path := normalizePath(fc.Path)
+				if path != "" && !seen[path] {
+					seen[path] = true
+					files[id] = append(files[id], path)
+				}
+			}
+		}
+		sort.Strings(files[id])
+	}
+	return files
+}
+
 // findFileOverlap finds beads that touch the same files as the target
 func (hr *HistoryReport) findFileOverlap(targetID string, targetFiles map[string]bool, fileLookup *FileLookup, opts RelatedWorkOptions, seen map[string]bool) []RelatedWorkBead {
 	if fileLookup == nil || len(targetFiles) == 0 {
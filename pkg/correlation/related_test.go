@@ -408,6 +408,29 @@ func TestFindRelatedWork_MaxResults(t *testing.T) {
 	}
 }
 
+func TestBeadFiles(t *testing.T) {
+	report := &HistoryReport{
+		Histories: map[string]BeadHistory{
+			"bv-a": {
+				BeadID: "bv-a",
+				Commits: []CorrelatedCommit{
+					{SHA: "1", Files: []FileChange{{Path: "pkg/b.go"}, {Path: "./pkg/a.go"}}},
+					{SHA: "2", Files: []FileChange{{Path: "pkg/a.go"}}},
+				},
+			},
+			"bv-none": {BeadID: "bv-none"},
+		},
+	}
+
+	files := report.BeadFiles()
+	if got := files["bv-a"]; len(got) != 2 || got[0] != "pkg/a.go" || got[1] != "pkg/b.go" {
+		t.Errorf("bv-a files = %v, want [pkg/a.go pkg/b.go]", got)
+	}
+	if got, ok := files["bv-none"]; ok && len(got) != 0 {
+		t.Errorf("bv-none should have no files, got %v", got)
+	}
+}
+
 func TestDefaultRelatedWorkOptions(t *testing.T) {
 	opts := DefaultRelatedWorkOptions()
 
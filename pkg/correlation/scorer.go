@@ -26,7 +26,7 @@ var MethodRanges = map[CorrelationMethod]ConfidenceRange{
 	MethodExplicitID: {
 		Method: MethodExplicitID,
 		Min:    0.70,
-		Max:    0.99,
+		Max:    1.0, // Bead:/Closes-Bead: trailers are certain
 		Desc:   "Commit message explicitly references bead ID (developer intent)",
 	},
 	MethodTemporalAuthor: {
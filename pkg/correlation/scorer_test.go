@@ -21,9 +21,10 @@ func TestValidateConfidence(t *testing.T) {
 		{MethodCoCommitted, 0.84, false},
 		{MethodCoCommitted, 1.00, false},
 
-		// ExplicitID: 0.70-0.99
+		// ExplicitID: 0.70-1.0 (trailers are certain)
 		{MethodExplicitID, 0.90, true},
 		{MethodExplicitID, 0.70, true},
+		{MethodExplicitID, 1.00, true},
 		{MethodExplicitID, 0.69, false},
 
 		// TemporalAuthor: 0.20-0.85
@@ -0,0 +1,349 @@
+// Package correlation provides structured commit trailer support (Bead:, Closes-Bead:).
+package correlation
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os/exec"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Trailer keys recognized in commit messages. Trailers are the most explicit
+// way to link a commit to a bead, so matches are treated as certain.
+const (
+	TrailerBead       = "Bead"
+	TrailerClosesBead = "Closes-Bead"
+
+	// TrailerConfidence is the confidence assigned to trailer-based links.
+	TrailerConfidence = 1.0
+)
+
+// Match types produced for trailer references.
+const (
+	MatchTypeTrailer       = "trailer"
+	MatchTypeClosesTrailer = "closes_trailer"
+)
+
+// trailerLinePattern matches a "Bead: <ids>" or "Closes-Bead: <ids>" line.
+var trailerLinePattern = regexp.MustCompile(`(?im)^[ \t]*(closes-bead|bead)[ \t]*:[ \t]*(.+?)[ \t]*$`)
+
+// trailerIDPattern validates a single bead ID inside a trailer value.
+var trailerIDPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// ParseBeadTrailers extracts bead IDs from Bead:/Closes-Bead: trailers.
+// Like git interpret-trailers, only the last paragraph of the message is
+// read, and never the subject, so a "Bead:" line in the body is prose.
+// A trailer value may list several IDs separated by commas or spaces.
+// IDs are returned exactly as written; duplicates keep their first occurrence.
+func ParseBeadTrailers(message string) []IDMatch {
+	var matches []IDMatch
+	seen := make(map[string]bool)
+
+	for _, m := range trailerLinePattern.FindAllStringSubmatch(trailerBlock(message), -1) {
+		matchType := MatchTypeTrailer
+		if strings.EqualFold(m[1], TrailerClosesBead) {
+			matchType = MatchTypeClosesTrailer
+		}
+		for _, tok := range strings.FieldsFunc(m[2], func(r rune) bool { return r == ',' || r == ' ' || r == '\t' }) {
+			id := strings.TrimPrefix(tok, "#")
+			if !trailerIDPattern.MatchString(id) {
+				continue
+			}
+			key := strings.ToLower(id)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			matches = append(matches, IDMatch{
+				ID:        id,
+				MatchType: matchType,
+				RawMatch:  strings.TrimSpace(m[0]),
+			})
+		}
+	}
+
+	return matches
+}
+
+// trailerBlock returns the last paragraph of a commit message after the
+// subject, or "" when the message has no body.
+func trailerBlock(message string) string {
+	lines := strings.Split(strings.ReplaceAll(message, "\r\n", "\n"), "\n")
+	body := 0
+	for body < len(lines) && strings.TrimSpace(lines[body]) != "" {
+		body++
+	}
+	end := len(lines)
+	for end > body && strings.TrimSpace(lines[end-1]) == "" {
+		end--
+	}
+	start := end
+	for start > body && strings.TrimSpace(lines[start-1]) != "" {
+		start--
+	}
+	return strings.Join(lines[start:end], "\n")
+}
+
+// isTrailerMatchType reports whether a match type came from a structured trailer.
+func isTrailerMatchType(matchType string) bool {
+	return matchType == MatchTypeTrailer || matchType == MatchTypeClosesTrailer
+}
+
+// gitLogTrailerFormat is gitLogHeaderFormat with the full message body and a
+// record separator, since trailers live in the body rather than the subject.
+const gitLogTrailerFormat = "%H%x00%aI%x00%an%x00%ae%x00%B%x1e"
+
+// FindTrailerCommits scans git history for commits carrying bead trailers.
+// Each (commit, bead) pair yields one ExplicitMatch with TrailerConfidence.
+func (m *ExplicitMatcher) FindTrailerCommits(opts ExtractOptions) ([]ExplicitMatch, error) {
+	args := []string{
+		"log",
+		"--regexp-ignore-case",
+		"--extended-regexp",
+		"--grep=^[[:space:]]*(closes-)?bead[[:space:]]*:",
+		"--format=" + gitLogTrailerFormat,
+	}
+	if opts.Since != nil {
+		args = append(args, fmt.Sprintf("--since=%s", opts.Since.Format(time.RFC3339)))
+	}
+	if opts.Until != nil {
+		args = append(args, fmt.Sprintf("--until=%s", opts.Until.Format(time.RFC3339)))
+	}
+	if opts.Limit > 0 {
+		args = append(args, fmt.Sprintf("-n%d", opts.Limit))
+	}
+	if opts.Range != "" {
+		args = append(args, opts.Range, "--")
+	}
+
+	cmd := exec.Command("git", args...)
+	cmd.Dir = m.repoPath
+
+	out, err := cmd.Output()
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			return nil, fmt.Errorf("git log (trailers) failed: %s", string(exitErr.Stderr))
+		}
+		return nil, fmt.Errorf("git log (trailers) failed: %w", err)
+	}
+
+	return parseTrailerLog(out, opts.BeadID), nil
+}
+
+// parseTrailerLog parses gitLogTrailerFormat output into trailer matches.
+// When filterBeadID is set, only matches for that bead are returned.
+func parseTrailerLog(data []byte, filterBeadID string) []ExplicitMatch {
+	var matches []ExplicitMatch
+
+	for _, record := range bytes.Split(data, []byte{0x1e}) {
+		line := strings.TrimLeft(string(record), "\n")
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		info, err := parseCommitInfo(line)
+		if err != nil {
+			continue
+		}
+
+		for _, idMatch := range ParseBeadTrailers(info.Message) {
+			if filterBeadID != "" && !strings.EqualFold(idMatch.ID, filterBeadID) {
+				continue
+			}
+			matches = append(matches, ExplicitMatch{
+				BeadID:      idMatch.ID,
+				CommitSHA:   info.SHA,
+				Message:     commitSubject(info.Message),
+				Author:      info.Author,
+				AuthorEmail: info.AuthorEmail,
+				Timestamp:   info.Timestamp,
+				MatchType:   idMatch.MatchType,
+				Confidence:  TrailerConfidence,
+			})
+		}
+	}
+
+	return matches
+}
+
+// commitSubject returns the first line of a commit message.
+func commitSubject(message string) string {
+	if idx := strings.IndexByte(message, '\n'); idx != -1 {
+		return strings.TrimSpace(message[:idx])
+	}
+	return strings.TrimSpace(message)
+}
+
+// TrailerSuggestion is a bead the current change likely belongs to.
+type TrailerSuggestion struct {
+	BeadID       string   `json:"bead_id"`
+	Title        string   `json:"title"`
+	Status       string   `json:"status"`
+	Trailer      string   `json:"trailer"`       // Ready-to-paste trailer line
+	Score        float64  `json:"score"`         // 0.0-1.0
+	MatchedFiles []string `json:"matched_files"` // Changed files with history for this bead
+	Reason       string   `json:"reason"`
+}
+
+// TrailerSuggestionResult is the output of SuggestTrailers.
+type TrailerSuggestionResult struct {
+	Files        []string            `json:"files"`
+	Suggestions  []TrailerSuggestion `json:"suggestions"`
+	TrailerBlock string              `json:"trailer_block"` // Suggested trailers joined by newlines
+}
+
+// SuggestTrailers ranks open beads whose past commits touched the changed files.
+// Files without direct history fall back to their parent directory at reduced weight.
+// Closed beads and tracker files (.beads/, .bv/) are ignored.
+func (fl *FileLookup) SuggestTrailers(files []string, limit int) *TrailerSuggestionResult {
+	result := &TrailerSuggestionResult{
+		Files:       []string{},
+		Suggestions: []TrailerSuggestion{},
+	}
+
+	seenFiles := make(map[string]bool)
+	for _, f := range files {
+		norm := strings.TrimSpace(normalizePath(f))
+		if norm == "" || seenFiles[norm] || isExcludedPath(norm) {
+			continue
+		}
+		seenFiles[norm] = true
+		result.Files = append(result.Files, norm)
+	}
+	if len(result.Files) == 0 {
+		return result
+	}
+
+	type candidate struct {
+		ref     BeadReference
+		weight  float64
+		commits int
+		files   []string
+		viaDir  bool
+	}
+	candidates := make(map[string]*candidate)
+
+	for _, file := range result.Files {
+		weight := 1.0
+		viaDir := false
+		lookup := fl.LookupByFile(file)
+		if len(lookup.OpenBeads) == 0 {
+			if dir := path.Dir(file); dir != "." && dir != "/" {
+				lookup = fl.LookupByFile(dir)
+				weight = 0.5
+				viaDir = true
+			}
+		}
+		for _, ref := range lookup.OpenBeads {
+			c := candidates[ref.BeadID]
+			if c == nil {
+				c = &candidate{ref: ref, viaDir: true}
+				candidates[ref.BeadID] = c
+			}
+			c.weight += weight
+			c.commits += len(ref.CommitSHAs)
+			c.files = append(c.files, file)
+			c.viaDir = c.viaDir && viaDir
+		}
+	}
+
+	for _, c := range candidates {
+		overlap := c.weight / float64(len(result.Files))
+		statusBoost := 0.2
+		if c.ref.Status == "in_progress" {
+			statusBoost = 0.3
+		}
+		commitScore := float64(minInt(c.commits, 5)) / 5.0
+		score := overlap*0.6 + statusBoost + commitScore*0.1
+		if score > 1.0 {
+			score = 1.0
+		}
+
+		reason := fmt.Sprintf("%d of %d changed %s previously touched by this bead (%d %s)",
+			len(c.files), len(result.Files), pluralize(len(result.Files), "file"),
+			c.commits, pluralize(c.commits, "commit"))
+		if c.viaDir {
+			reason = fmt.Sprintf("Bead has history next to %d changed %s (same directory)",
+				len(c.files), pluralize(len(c.files), "file"))
+		}
+
+		result.Suggestions = append(result.Suggestions, TrailerSuggestion{
+			BeadID:       c.ref.BeadID,
+			Title:        c.ref.Title,
+			Status:       c.ref.Status,
+			Trailer:      TrailerBead + ": " + c.ref.BeadID,
+			Score:        score,
+			MatchedFiles: c.files,
+			Reason:       reason,
+		})
+	}
+
+	sort.Slice(result.Suggestions, func(i, j int) bool {
+		if result.Suggestions[i].Score != result.Suggestions[j].Score {
+			return result.Suggestions[i].Score > result.Suggestions[j].Score
+		}
+		return result.Suggestions[i].BeadID < result.Suggestions[j].BeadID
+	})
+	if limit > 0 && len(result.Suggestions) > limit {
+		result.Suggestions = result.Suggestions[:limit]
+	}
+
+	lines := make([]string, len(result.Suggestions))
+	for i, s := range result.Suggestions {
+		lines[i] = s.Trailer
+	}
+	result.TrailerBlock = strings.Join(lines, "\n")
+
+	return result
+}
+
+// ChangedFiles lists files changed in the repository for trailer suggestions.
+// With an empty diffSpec it uses staged changes, falling back to unstaged
+// changes when nothing is staged. Otherwise diffSpec is passed to git diff
+// (e.g. "HEAD~1" or "origin/main...HEAD"). The returned source describes
+// which set of changes was used.
+func ChangedFiles(repoPath, diffSpec string) ([]string, string, error) {
+	if diffSpec != "" {
+		files, err := gitDiffNames(repoPath, diffSpec)
+		return files, "diff " + diffSpec, err
+	}
+
+	files, err := gitDiffNames(repoPath, "--cached")
+	if err != nil {
+		return nil, "", err
+	}
+	if len(files) > 0 {
+		return files, "staged", nil
+	}
+
+	files, err = gitDiffNames(repoPath)
+	return files, "unstaged", err
+}
+
+// gitDiffNames runs git diff --name-only with the given extra arguments.
+func gitDiffNames(repoPath string, extra ...string) ([]string, error) {
+	args := append([]string{"diff", "--name-only"}, extra...)
+	cmd := exec.Command("git", args...)
+	cmd.Dir = repoPath
+
+	out, err := cmd.Output()
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			return nil, fmt.Errorf("git diff failed: %s", strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return nil, fmt.Errorf("git diff failed: %w", err)
+	}
+
+	var files []string
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			files = append(files, line)
+		}
+	}
+	return files, scanner.Err()
+}
@@ -0,0 +1,209 @@
+package correlation
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestParseBeadTrailers(t *testing.T) {
+	msg := "feat: add login\n\nLonger body mentioning bead: nothing here? no\n\nBead: bv-123\nCloses-Bead: bv-45, #bv-46\nbead: BV-123\nSigned-off-by: Dev <dev@example.com>\n"
+
+	got := ParseBeadTrailers(msg)
+	want := []struct{ id, typ string }{
+		{"bv-123", MatchTypeTrailer},
+		{"bv-45", MatchTypeClosesTrailer},
+		{"bv-46", MatchTypeClosesTrailer},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d trailers, want %d: %+v", len(got), len(want), got)
+	}
+	for i, w := range want {
+		if got[i].ID != w.id || got[i].MatchType != w.typ {
+			t.Errorf("trailer %d = %+v, want %s (%s)", i, got[i], w.id, w.typ)
+		}
+	}
+}
+
+func TestParseBeadTrailers_LastParagraphOnly(t *testing.T) {
+	msg := "Bead: bv-1 is the subject\n\nThe old code read\nBead: bv-2\nas a trailer.\n\nBead: bv-3\r\nSigned-off-by: Dev <dev@example.com>\r\n\n"
+	got := ParseBeadTrailers(msg)
+	if len(got) != 1 || got[0].ID != "bv-3" {
+		t.Errorf("got %+v, want only bv-3 from the trailer block", got)
+	}
+
+	if got := ParseBeadTrailers("Bead: bv-1"); len(got) != 0 {
+		t.Errorf("subject-only message: got %+v, want none", got)
+	}
+}
+
+func TestExtractIDsFromMessage_TrailersFirst(t *testing.T) {
+	m := NewExplicitMatcher("/tmp/test")
+	matches := m.ExtractIDsFromMessage("Fix BV-9 handling\n\nCloses-Bead: bv-7")
+	if len(matches) != 2 {
+		t.Fatalf("expected 2 matches, got %+v", matches)
+	}
+	if matches[0].ID != "bv-7" || matches[0].MatchType != MatchTypeClosesTrailer {
+		t.Errorf("first match = %+v, want closes trailer bv-7", matches[0])
+	}
+	if got := CalculateConfidence(matches[0].MatchType, len(matches)); got != TrailerConfidence {
+		t.Errorf("trailer confidence = %v, want %v", got, TrailerConfidence)
+	}
+}
+
+func TestParseTrailerLog(t *testing.T) {
+	data := []byte("aaa\x002025-01-02T10:00:00Z\x00Dev\x00dev@example.com\x00Subject one\n\nBead: bv-1\nBead: bv-2\n\x1e\n" +
+		"bbb\x002025-01-03T10:00:00Z\x00Dev\x00dev@example.com\x00No trailers here\n\x1e\n")
+
+	all := parseTrailerLog(data, "")
+	if len(all) != 2 {
+		t.Fatalf("expected 2 matches, got %+v", all)
+	}
+	if all[0].Message != "Subject one" || all[0].Confidence != 1.0 {
+		t.Errorf("unexpected match: %+v", all[0])
+	}
+
+	filtered := parseTrailerLog(data, "BV-2")
+	if len(filtered) != 1 || filtered[0].BeadID != "bv-2" {
+		t.Errorf("filtered = %+v, want only bv-2", filtered)
+	}
+}
+
+func TestDedupCommits_PrefersHigherConfidence(t *testing.T) {
+	commits := []CorrelatedCommit{
+		{SHA: "abc", Method: MethodCoCommitted, Confidence: 0.9},
+		{SHA: "def", Method: MethodCoCommitted, Confidence: 0.9},
+		{SHA: "abc", Method: MethodExplicitID, Confidence: 1.0},
+	}
+	result := dedupCommits(commits)
+	if len(result) != 2 {
+		t.Fatalf("expected 2 commits, got %d", len(result))
+	}
+	if result[0].SHA != "abc" || result[0].Method != MethodExplicitID {
+		t.Errorf("expected trailer link to replace co-commit in place, got %+v", result[0])
+	}
+}
+
+func TestSuggestTrailers(t *testing.T) {
+	now := time.Now()
+	report := &HistoryReport{
+		Histories: map[string]BeadHistory{
+			"bv-1": {
+				BeadID: "bv-1", Title: "Auth work", Status: "in_progress",
+				Commits: []CorrelatedCommit{{SHA: "a1", ShortSHA: "a1", Timestamp: now,
+					Files: []FileChange{{Path: "pkg/auth/token.go"}, {Path: "pkg/auth/session.go"}}}},
+			},
+			"bv-2": {
+				BeadID: "bv-2", Title: "API routes", Status: "open",
+				Commits: []CorrelatedCommit{{SHA: "b1", ShortSHA: "b1", Timestamp: now,
+					Files: []FileChange{{Path: "pkg/api/routes.go"}}}},
+			},
+			"bv-3": {
+				BeadID: "bv-3", Title: "Old auth", Status: "closed",
+				Commits: []CorrelatedCommit{{SHA: "c1", ShortSHA: "c1", Timestamp: now,
+					Files: []FileChange{{Path: "pkg/auth/token.go"}}}},
+			},
+		},
+	}
+
+	result := NewFileLookup(report).SuggestTrailers([]string{
+		"pkg/auth/token.go",
+		"pkg/api/new_handler.go", // no direct history: falls back to pkg/api
+		".beads/beads.jsonl",     // tracker file: ignored
+	}, 5)
+
+	if len(result.Files) != 2 {
+		t.Fatalf("expected tracker file to be dropped, files = %v", result.Files)
+	}
+	if len(result.Suggestions) != 2 {
+		t.Fatalf("expected 2 suggestions (closed bead excluded), got %+v", result.Suggestions)
+	}
+	if result.Suggestions[0].BeadID != "bv-1" || result.Suggestions[0].Trailer != "Bead: bv-1" {
+		t.Errorf("top suggestion = %+v, want bv-1", result.Suggestions[0])
+	}
+	if result.Suggestions[1].BeadID != "bv-2" {
+		t.Errorf("second suggestion = %+v, want bv-2 via directory", result.Suggestions[1])
+	}
+	if result.TrailerBlock != "Bead: bv-1\nBead: bv-2" {
+		t.Errorf("TrailerBlock = %q", result.TrailerBlock)
+	}
+
+	limited := NewFileLookup(report).SuggestTrailers([]string{"pkg/auth/token.go", "pkg/api/routes.go"}, 1)
+	if len(limited.Suggestions) != 1 {
+		t.Errorf("limit not applied: %+v", limited.Suggestions)
+	}
+}
+
+func TestFindTrailerCommitsAndChangedFiles(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	dir := t.TempDir()
+	git := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(),
+			"GIT_AUTHOR_NAME=Dev", "GIT_AUTHOR_EMAIL=dev@example.com",
+			"GIT_COMMITTER_NAME=Dev", "GIT_COMMITTER_EMAIL=dev@example.com")
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	write := func(name, content string) {
+		t.Helper()
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	git("init", "-q")
+	write("pkg/auth/token.go", "package auth\n")
+	git("add", ".")
+	git("commit", "-q", "-m", "Add token handling\n\nCloses-Bead: bv-7")
+	write("README.md", "docs\n")
+	git("add", ".")
+	git("commit", "-q", "-m", "Docs only")
+
+	matches, err := NewExplicitMatcher(dir).FindTrailerCommits(ExtractOptions{})
+	if err != nil {
+		t.Fatalf("FindTrailerCommits: %v", err)
+	}
+	if len(matches) != 1 || matches[0].BeadID != "bv-7" || matches[0].MatchType != MatchTypeClosesTrailer {
+		t.Fatalf("matches = %+v, want one closes trailer for bv-7", matches)
+	}
+
+	commits := NewCorrelator(dir).extractTrailerCommits(ExtractOptions{})
+	if len(commits) != 1 || len(commits[0].Files) != 1 || commits[0].Files[0].Path != "pkg/auth/token.go" {
+		t.Fatalf("trailer commits = %+v", commits)
+	}
+	if commits[0].Method != MethodExplicitID || commits[0].Confidence != 1.0 {
+		t.Errorf("unexpected method/confidence: %s %v", commits[0].Method, commits[0].Confidence)
+	}
+
+	// Staged changes take priority over unstaged ones
+	write("pkg/auth/token.go", "package auth\n\nvar x = 1\n")
+	write("README.md", "more docs\n")
+	git("add", "pkg/auth/token.go")
+	files, source, err := ChangedFiles(dir, "")
+	if err != nil {
+		t.Fatalf("ChangedFiles: %v", err)
+	}
+	if source != "staged" || len(files) != 1 || files[0] != "pkg/auth/token.go" {
+		t.Errorf("ChangedFiles = %v (%s), want staged token.go", files, source)
+	}
+
+	files, source, err = ChangedFiles(dir, "HEAD~1")
+	if err != nil {
+		t.Fatalf("ChangedFiles(HEAD~1): %v", err)
+	}
+	if source != "diff HEAD~1" || len(files) != 2 {
+		t.Errorf("ChangedFiles(HEAD~1) = %v (%s)", files, source)
+	}
+}
@@ -14,6 +14,8 @@ const (
 	EventCreated EventType = "created"
 	// EventClaimed indicates status changed to in_progress
 	EventClaimed EventType = "claimed"
+	// EventInReview indicates work was sent for review (tracker events only)
+	EventInReview EventType = "in_review"
 	// EventClosed indicates status changed to closed
 	EventClosed EventType = "closed"
 	// EventReopened indicates status changed FROM closed to open/in_progress
@@ -30,13 +32,18 @@ func (e EventType) String() string {
 // IsValid returns true if the event type is a recognized value
 func (e EventType) IsValid() bool {
 	switch e {
-	case EventCreated, EventClaimed, EventClosed, EventReopened, EventModified:
+	case EventCreated, EventClaimed, EventInReview, EventClosed, EventReopened, EventModified:
 		return true
 	}
 	return false
 }
 
-// BeadEvent represents a single lifecycle event for a bead, extracted from git history
+// EventSourceTracker marks events recorded by the issue tracker itself
+// rather than inferred from git history.
+const EventSourceTracker = "tracker"
+
+// BeadEvent represents a single lifecycle event for a bead, extracted from git
+// history or, when Source is EventSourceTracker, from the tracker's event log
 type BeadEvent struct {
 	BeadID      string    `json:"bead_id"`
 	EventType   EventType `json:"event_type"`
@@ -45,6 +52,7 @@ type BeadEvent struct {
 	CommitMsg   string    `json:"commit_message"`
 	Author      string    `json:"author"`
 	AuthorEmail string    `json:"author_email"`
+	Source      string    `json:"source,omitempty"` // "" = git, "tracker" = recorded event
 }
 
 // CorrelationMethod describes how a commit was linked to a bead
@@ -100,6 +108,7 @@ type CorrelatedCommit struct {
 type BeadMilestones struct {
 	Created  *BeadEvent `json:"created,omitempty"`
 	Claimed  *BeadEvent `json:"claimed,omitempty"`
+	InReview *BeadEvent `json:"in_review,omitempty"`
 	Closed   *BeadEvent `json:"closed,omitempty"`
 	Reopened *BeadEvent `json:"reopened,omitempty"` // Most recent if multiple
 }
@@ -109,6 +118,8 @@ type CycleTime struct {
 	ClaimToClose  *time.Duration `json:"claim_to_close,omitempty"`  // Time from claimed to closed
 	CreateToClose *time.Duration `json:"create_to_close,omitempty"` // Time from created to closed
 	CreateToClaim *time.Duration `json:"create_to_claim,omitempty"` // Time from created to claimed
+	ClaimToReview *time.Duration `json:"claim_to_review,omitempty"` // Time from claimed to in review
+	ReviewToClose *time.Duration `json:"review_to_close,omitempty"` // Time from in review to closed
 }
 
 // BeadHistory is the complete correlation record for a single bead
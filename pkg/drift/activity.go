@@ -0,0 +1,78 @@
+package drift
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+)
+
+// activityMinVolume is the smallest weekly count worth judging: below it a
+// single issue more or less reads as a huge swing
+const activityMinVolume = 3
+
+// activitySpikeMinZ is how far above the baseline, in standard deviations,
+// a spike must sit when the baseline varies at all
+const activitySpikeMinZ = 2.0
+
+// activityNames are the metrics as they read in alert messages
+var activityNames = map[string]string{
+	analysis.ActivityCreated: "Issue creation",
+	analysis.ActivityClosed:  "Closures",
+	analysis.ActivityBlocked: "Newly blocked issues",
+}
+
+// SetActivity attaches the daily activity series for the activity_anomaly
+// check; see analysis.ComputeActivitySeries.
+func (c *Calculator) SetActivity(series *analysis.ActivitySeries) {
+	c.activity = series
+}
+
+// checkActivityAnomalies flags weeks whose created, closed or newly blocked
+// count falls far below or rises far above the weeks before. Fewer blocked
+// issues is never a problem, so blocked only alerts on spikes.
+func (c *Calculator) checkActivityAnomalies(result *Result) {
+	if c.config.IsAlertDisabled(string(AlertActivityAnomaly)) || c.activity == nil {
+		return
+	}
+
+	now := time.Now().UTC()
+	for _, w := range c.activity.Windows(analysis.ActivityWindowDays) {
+		var severity Severity
+		var change string
+		switch {
+		case w.Mean >= activityMinVolume && -w.ChangePct >= c.config.ActivityDropInfoPct && w.Metric != analysis.ActivityBlocked:
+			severity = SeverityInfo
+			if -w.ChangePct >= c.config.ActivityDropWarningPct {
+				severity = SeverityWarning
+			}
+			change = fmt.Sprintf("dropped %.0f%%", -w.ChangePct)
+		case w.Current >= activityMinVolume && w.ChangePct >= c.config.ActivitySpikeInfoPct && (w.StdDev == 0 || w.ZScore >= activitySpikeMinZ):
+			severity = SeverityInfo
+			if w.ChangePct >= c.config.ActivitySpikeWarningPct {
+				severity = SeverityWarning
+			}
+			change = fmt.Sprintf("rose %.0f%%", w.ChangePct)
+		default:
+			continue
+		}
+
+		series := c.activity.Values(w.Metric)
+		result.Alerts = append(result.Alerts, Alert{
+			Type:     AlertActivityAnomaly,
+			Severity: severity,
+			Message: fmt.Sprintf("%s %s vs trailing %d-week mean (%d in the last %d days vs %.1f/week)",
+				activityNames[w.Metric], change, len(w.Baseline), w.Current, analysis.ActivityWindowDays, w.Mean),
+			BaselineVal: w.Mean,
+			CurrentVal:  float64(w.Current),
+			Delta:       float64(w.Current) - w.Mean,
+			Details: []string{
+				fmt.Sprintf("metric=%s", w.Metric),
+				fmt.Sprintf("baseline_weeks=%v", w.Baseline),
+				fmt.Sprintf("z_score=%.2f", w.ZScore),
+			},
+			DetectedAt: now,
+			Series:     &series,
+		})
+	}
+}
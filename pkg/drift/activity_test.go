@@ -0,0 +1,98 @@
+package drift
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/baseline"
+)
+
+// weeklySeries spreads weekly totals over days, all on each week's first day
+func weeklySeries(totals ...int) []int {
+	var days []int
+	for _, n := range totals {
+		days = append(days, n, 0, 0, 0, 0, 0, 0)
+	}
+	return days
+}
+
+func activityAlerts(t *testing.T, series *analysis.ActivitySeries, cfg *Config) []Alert {
+	t.Helper()
+	bl := &baseline.Baseline{}
+	calc := NewCalculator(bl, bl, cfg)
+	calc.SetActivity(series)
+	var alerts []Alert
+	for _, a := range calc.Calculate().Alerts {
+		if a.Type == AlertActivityAnomaly {
+			alerts = append(alerts, a)
+		}
+	}
+	return alerts
+}
+
+func TestCheckActivityAnomalies(t *testing.T) {
+	series := &analysis.ActivitySeries{
+		Start:   "2026-01-01",
+		Created: weeklySeries(4, 6, 5, 5, 6), // Steady
+		Closed:  weeklySeries(4, 6, 5, 5, 1), // Closures fell 80%
+		Blocked: weeklySeries(1, 2, 1, 2, 6), // Blocked quadrupled
+	}
+	alerts := activityAlerts(t, series, DefaultConfig())
+	if len(alerts) != 2 {
+		t.Fatalf("want a closed drought and a blocked spike, got %+v", alerts)
+	}
+
+	closed := alerts[0]
+	if closed.Severity != SeverityWarning || closed.CurrentVal != 1 || closed.BaselineVal != 5 {
+		t.Errorf("closed alert = %+v", closed)
+	}
+	if want := "Closures dropped 80% vs trailing 4-week mean (1 in the last 7 days vs 5.0/week)"; closed.Message != want {
+		t.Errorf("message = %q, want %q", closed.Message, want)
+	}
+	if closed.Series == nil || closed.Series.Metric != analysis.ActivityClosed || len(closed.Series.Values) != 35 {
+		t.Errorf("alert should carry the closed series, got %+v", closed.Series)
+	}
+
+	blocked := alerts[1]
+	if blocked.Severity != SeverityWarning || !strings.HasPrefix(blocked.Message, "Newly blocked issues rose 300%") {
+		t.Errorf("blocked alert = %+v", blocked)
+	}
+
+	// Below the warning thresholds the same changes are info
+	cfg := DefaultConfig()
+	cfg.ActivityDropWarningPct = 90
+	cfg.ActivitySpikeWarningPct = 400
+	for _, a := range activityAlerts(t, series, cfg) {
+		if a.Severity != SeverityInfo {
+			t.Errorf("%s should be info under looser thresholds", a.Message)
+		}
+	}
+
+	cfg = DefaultConfig()
+	cfg.DisabledAlerts = []string{string(AlertActivityAnomaly)}
+	if got := activityAlerts(t, series, cfg); len(got) != 0 {
+		t.Errorf("disabled check still raised %+v", got)
+	}
+}
+
+func TestCheckActivityAnomaliesQuiet(t *testing.T) {
+	// Too little volume: 1 -> 0 closures, 0 -> 2 blocked
+	series := &analysis.ActivitySeries{
+		Start:   "2026-01-01",
+		Created: weeklySeries(0, 0, 0, 0, 0),
+		Closed:  weeklySeries(1, 1, 1, 1, 0),
+		Blocked: weeklySeries(0, 0, 0, 1, 2),
+	}
+	if got := activityAlerts(t, series, DefaultConfig()); len(got) != 0 {
+		t.Errorf("low-volume changes should not alert, got %+v", got)
+	}
+	// A noisy baseline needs a spike well outside its spread
+	series.Created = weeklySeries(0, 10, 0, 10, 11)
+	if got := activityAlerts(t, series, DefaultConfig()); len(got) != 0 {
+		t.Errorf("a spike within two standard deviations should not alert, got %+v", got)
+	}
+	if got := activityAlerts(t, nil, DefaultConfig()); len(got) != 0 {
+		t.Errorf("no series should mean no alerts, got %+v", got)
+	}
+}
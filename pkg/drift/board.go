@@ -0,0 +1,131 @@
+package drift
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"gopkg.in/yaml.v3"
+)
+
+// BoardConfigFilename is the board config filename
+const BoardConfigFilename = "board.yaml"
+
+// BoardConfig holds kanban settings shared by the board view and drift
+// checks, loaded from .bv/board.yaml.
+type BoardConfig struct {
+	// WIPLimits caps how many issues each status column should hold.
+	// Statuses without a limit (or with 0) are unlimited.
+	WIPLimits map[model.Status]int `yaml:"wip_limits,omitempty" json:"wip_limits,omitempty"`
+}
+
+// BoardConfigPath returns the board config path for a project
+func BoardConfigPath(projectDir string) string {
+	return filepath.Join(projectDir, ".bv", BoardConfigFilename)
+}
+
+// LoadBoardConfig loads board configuration from .bv/board.yaml.
+// Returns an empty config (no limits) if the file doesn't exist.
+func LoadBoardConfig(projectDir string) (*BoardConfig, error) {
+	data, err := os.ReadFile(BoardConfigPath(projectDir))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &BoardConfig{}, nil
+		}
+		return nil, fmt.Errorf("reading board config: %w", err)
+	}
+
+	config := &BoardConfig{}
+	if err := yaml.Unmarshal(data, config); err != nil {
+		return nil, fmt.Errorf("parsing board config: %w", err)
+	}
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid board config: %w", err)
+	}
+	return config, nil
+}
+
+// Validate checks that every limit names a board column and is non-negative
+func (b *BoardConfig) Validate() error {
+	for status, limit := range b.WIPLimits {
+		if !status.IsValid() || status.IsTombstone() {
+			return fmt.Errorf("wip_limits: unknown status %q (use open, in_progress, in_review, blocked or closed)", status)
+		}
+		if limit < 0 {
+			return fmt.Errorf("wip_limits: %s limit must be non-negative", status)
+		}
+	}
+	return nil
+}
+
+// WIPLimit returns the limit for a status column, or 0 if it is unlimited
+func (b *BoardConfig) WIPLimit(status model.Status) int {
+	if b == nil {
+		return 0
+	}
+	return b.WIPLimits[status]
+}
+
+// SetBoardConfig attaches WIP limits for the wip_limit_exceeded check.
+// Like staleness, the check needs the issues attached with SetIssues.
+func (c *Calculator) SetBoardConfig(board *BoardConfig) {
+	c.board = board
+}
+
+// checkWIPLimits warns about status columns holding more issues than their
+// configured WIP limit.
+func (c *Calculator) checkWIPLimits(result *Result) {
+	if c.config.IsAlertDisabled(string(AlertWIPLimitExceeded)) {
+		return
+	}
+	if c.board == nil || len(c.board.WIPLimits) == 0 || len(c.issues) == 0 {
+		return
+	}
+
+	byStatus := make(map[model.Status][]string)
+	for _, issue := range c.issues {
+		byStatus[issue.Status] = append(byStatus[issue.Status], issue.ID)
+	}
+
+	statuses := make([]model.Status, 0, len(c.board.WIPLimits))
+	for status := range c.board.WIPLimits {
+		statuses = append(statuses, status)
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i] < statuses[j] })
+
+	now := time.Now().UTC()
+	for _, status := range statuses {
+		limit := c.board.WIPLimits[status]
+		ids := byStatus[status]
+		if limit <= 0 || len(ids) <= limit {
+			continue
+		}
+		sort.Strings(ids)
+		result.Alerts = append(result.Alerts, Alert{
+			Type:        AlertWIPLimitExceeded,
+			Severity:    SeverityWarning,
+			Message:     fmt.Sprintf("%s has %d issues, over its WIP limit of %d", status, len(ids), limit),
+			BaselineVal: float64(limit),
+			CurrentVal:  float64(len(ids)),
+			Delta:       float64(len(ids) - limit),
+			Details:     ids,
+			DetectedAt:  now,
+		})
+	}
+}
+
+// ExampleBoardConfig returns an example board configuration with comments
+func ExampleBoardConfig() string {
+	return `# Board view settings
+
+# WIP limits per status column. The board header turns red and
+# bv --robot-alerts / --ci-report raise wip_limit_exceeded when a
+# column holds more issues than its limit.
+wip_limits:
+  in_progress: 5
+  # blocked: 3
+`
+}
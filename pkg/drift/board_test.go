@@ -0,0 +1,153 @@
+package drift
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/baseline"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"gopkg.in/yaml.v3"
+)
+
+func writeBoardConfig(t *testing.T, dir, content string) {
+	t.Helper()
+	bvDir := filepath.Join(dir, ".bv")
+	if err := os.MkdirAll(bvDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(bvDir, BoardConfigFilename), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoadBoardConfig(t *testing.T) {
+	tmpDir := t.TempDir()
+	config, err := LoadBoardConfig(tmpDir)
+	if err != nil || len(config.WIPLimits) != 0 {
+		t.Fatalf("missing file should mean no limits, got %+v, %v", config, err)
+	}
+
+	writeBoardConfig(t, tmpDir, "wip_limits:\n  in_progress: 3\n  blocked: 2\n")
+	config, err = LoadBoardConfig(tmpDir)
+	if err != nil {
+		t.Fatalf("LoadBoardConfig failed: %v", err)
+	}
+	if config.WIPLimit(model.StatusInProgress) != 3 || config.WIPLimit(model.StatusBlocked) != 2 {
+		t.Errorf("unexpected limits: %v", config.WIPLimits)
+	}
+	if config.WIPLimit(model.StatusOpen) != 0 {
+		t.Error("unconfigured status should be unlimited")
+	}
+
+	var nilConfig *BoardConfig
+	if nilConfig.WIPLimit(model.StatusOpen) != 0 {
+		t.Error("nil config should be unlimited")
+	}
+}
+
+func TestLoadBoardConfigInvalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{"unknown status", "wip_limits:\n  doing: 3\n", `unknown status "doing"`},
+		{"negative", "wip_limits:\n  in_progress: -1\n", "non-negative"},
+		{"bad yaml", "wip_limits: [", "parsing board config"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			writeBoardConfig(t, tmpDir, tt.content)
+			_, err := LoadBoardConfig(tmpDir)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCalculatorWIPLimitExceeded(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "C", Status: model.StatusInProgress},
+		{ID: "A", Status: model.StatusInProgress},
+		{ID: "B", Status: model.StatusInProgress},
+		{ID: "D", Status: model.StatusBlocked},
+		{ID: "E", Status: model.StatusOpen},
+	}
+	bl := &baseline.Baseline{Stats: baseline.GraphStats{}}
+	current := &baseline.Baseline{Stats: baseline.GraphStats{}}
+	board := &BoardConfig{WIPLimits: map[model.Status]int{
+		model.StatusInProgress: 2,
+		model.StatusBlocked:    1, // At the limit, not over it
+		model.StatusOpen:       0, // Unlimited
+	}}
+
+	calc := NewCalculator(bl, current, DefaultConfig())
+	calc.SetIssues(issues)
+	calc.SetBoardConfig(board)
+	result := calc.Calculate()
+
+	var wip []Alert
+	for _, a := range result.Alerts {
+		if a.Type == AlertWIPLimitExceeded {
+			wip = append(wip, a)
+		}
+	}
+	if len(wip) != 1 {
+		t.Fatalf("expected 1 wip_limit_exceeded alert, got %d", len(wip))
+	}
+	a := wip[0]
+	if a.Severity != SeverityWarning {
+		t.Errorf("expected warning severity, got %s", a.Severity)
+	}
+	if a.Message != "in_progress has 3 issues, over its WIP limit of 2" {
+		t.Errorf("unexpected message %q", a.Message)
+	}
+	if strings.Join(a.Details, ",") != "A,B,C" || a.Delta != 1 {
+		t.Errorf("details = %v, delta = %v", a.Details, a.Delta)
+	}
+	if result.ExitCode() != 2 {
+		t.Errorf("exceeded WIP limit should fail CI with the warning exit code, got %d", result.ExitCode())
+	}
+
+	// Without issues or with the alert disabled, nothing is raised
+	calc = NewCalculator(bl, current, DefaultConfig())
+	calc.SetBoardConfig(board)
+	if hasAlertType(calc.Calculate(), AlertWIPLimitExceeded) {
+		t.Error("no issues attached should mean no WIP alert")
+	}
+
+	cfg := DefaultConfig()
+	cfg.DisabledAlerts = []string{string(AlertWIPLimitExceeded)}
+	calc = NewCalculator(bl, current, cfg)
+	calc.SetIssues(issues)
+	calc.SetBoardConfig(board)
+	if hasAlertType(calc.Calculate(), AlertWIPLimitExceeded) {
+		t.Error("disabled alert type should be skipped")
+	}
+}
+
+func hasAlertType(result *Result, alertType AlertType) bool {
+	for _, a := range result.Alerts {
+		if a.Type == alertType {
+			return true
+		}
+	}
+	return false
+}
+
+func TestExampleBoardConfig(t *testing.T) {
+	var config BoardConfig
+	if err := yaml.Unmarshal([]byte(ExampleBoardConfig()), &config); err != nil {
+		t.Fatalf("ExampleBoardConfig() returned invalid YAML: %v", err)
+	}
+	if err := config.Validate(); err != nil {
+		t.Errorf("ExampleBoardConfig() should be valid: %v", err)
+	}
+	if config.WIPLimit(model.StatusInProgress) == 0 {
+		t.Error("ExampleBoardConfig() should set an in_progress limit")
+	}
+}
@@ -45,6 +45,22 @@ type Config struct {
 	BlockingCascadeInfo    int `yaml:"blocking_cascade_info_threshold" json:"blocking_cascade_info_threshold"`
 	BlockingCascadeWarning int `yaml:"blocking_cascade_warning_threshold" json:"blocking_cascade_warning_threshold"`
 
+	// Activity anomaly thresholds: the last week's created, closed or
+	// newly blocked count against the mean of the four weeks before
+	ActivityDropInfoPct     float64 `yaml:"activity_drop_info_pct" json:"activity_drop_info_pct"`
+	ActivityDropWarningPct  float64 `yaml:"activity_drop_warning_pct" json:"activity_drop_warning_pct"`
+	ActivitySpikeInfoPct    float64 `yaml:"activity_spike_info_pct" json:"activity_spike_info_pct"`
+	ActivitySpikeWarningPct float64 `yaml:"activity_spike_warning_pct" json:"activity_spike_warning_pct"`
+
+	// Goal thresholds: the probability of closing a goal by its target date
+	// (.bv/goals.yaml) below which it is at risk
+	GoalWarningProbability  float64 `yaml:"goal_warning_probability" json:"goal_warning_probability"`
+	GoalCriticalProbability float64 `yaml:"goal_critical_probability" json:"goal_critical_probability"`
+
+	// Review thresholds: days an issue has waited in in_review
+	ReviewWarningDays  int `yaml:"review_warning_days" json:"review_warning_days"`
+	ReviewCriticalDays int `yaml:"review_critical_days" json:"review_critical_days"`
+
 	// Alert type enable/disable flags (bv-167)
 	// Disabled alert types will not generate alerts
 	DisabledAlerts []string `yaml:"disabled_alerts,omitempty" json:"disabled_alerts,omitempty"`
@@ -80,6 +96,14 @@ func DefaultConfig() *Config {
 		InProgressStaleMultiplier:    0.5, // In-progress thresholds are half as long
 		BlockingCascadeInfo:          3,   // Info alert when unblocks >=3
 		BlockingCascadeWarning:       5,   // Warning when unblocks >=5
+		ActivityDropInfoPct:          50,  // Info when weekly activity halves
+		ActivityDropWarningPct:       80,  // Warning when it falls 80%+
+		ActivitySpikeInfoPct:         100, // Info when weekly activity doubles
+		ActivitySpikeWarningPct:      200, // Warning when it triples
+		GoalWarningProbability:       0.5, // Warn when a goal is a coin flip or worse
+		GoalCriticalProbability:      0.2, // Critical when it is unlikely
+		ReviewWarningDays:            3,   // Warn after 3 days waiting for review
+		ReviewCriticalDays:           7,   // Critical after a week
 	}
 }
 
@@ -161,6 +185,22 @@ func (c *Config) Validate() error {
 	if c.InProgressStaleMultiplier == 0 {
 		c.InProgressStaleMultiplier = DefaultConfig().InProgressStaleMultiplier
 	}
+	if c.ActivityDropInfoPct == 0 && c.ActivityDropWarningPct == 0 {
+		c.ActivityDropInfoPct = DefaultConfig().ActivityDropInfoPct
+		c.ActivityDropWarningPct = DefaultConfig().ActivityDropWarningPct
+	}
+	if c.ActivitySpikeInfoPct == 0 && c.ActivitySpikeWarningPct == 0 {
+		c.ActivitySpikeInfoPct = DefaultConfig().ActivitySpikeInfoPct
+		c.ActivitySpikeWarningPct = DefaultConfig().ActivitySpikeWarningPct
+	}
+	if c.GoalWarningProbability == 0 && c.GoalCriticalProbability == 0 {
+		c.GoalWarningProbability = DefaultConfig().GoalWarningProbability
+		c.GoalCriticalProbability = DefaultConfig().GoalCriticalProbability
+	}
+	if c.ReviewWarningDays == 0 && c.ReviewCriticalDays == 0 {
+		c.ReviewWarningDays = DefaultConfig().ReviewWarningDays
+		c.ReviewCriticalDays = DefaultConfig().ReviewCriticalDays
+	}
 
 	if c.DensityWarningPct < 0 || c.DensityWarningPct > 1000 {
 		return fmt.Errorf("density_warning_pct must be between 0 and 1000")
@@ -201,6 +241,18 @@ func (c *Config) Validate() error {
 	if c.BlockingCascadeWarning < c.BlockingCascadeInfo {
 		return fmt.Errorf("blocking_cascade_warning_threshold must be >= blocking_cascade_info_threshold")
 	}
+	if c.ActivityDropInfoPct <= 0 || c.ActivityDropWarningPct > 100 || c.ActivityDropWarningPct < c.ActivityDropInfoPct {
+		return fmt.Errorf("activity drop thresholds must satisfy 0 < activity_drop_info_pct <= activity_drop_warning_pct <= 100")
+	}
+	if c.ActivitySpikeInfoPct <= 0 || c.ActivitySpikeWarningPct < c.ActivitySpikeInfoPct {
+		return fmt.Errorf("activity spike thresholds must satisfy 0 < activity_spike_info_pct <= activity_spike_warning_pct")
+	}
+	if c.GoalCriticalProbability < 0 || c.GoalWarningProbability > 1 || c.GoalWarningProbability < c.GoalCriticalProbability {
+		return fmt.Errorf("goal thresholds must satisfy 0 <= goal_critical_probability <= goal_warning_probability <= 1")
+	}
+	if c.ReviewWarningDays <= 0 || c.ReviewCriticalDays < c.ReviewWarningDays {
+		return fmt.Errorf("review thresholds must satisfy 0 < review_warning_days <= review_critical_days")
+	}
 	// Validate label overrides (bv-167)
 	for label, lc := range c.LabelOverrides {
 		if lc == nil {
@@ -315,6 +367,21 @@ in_progress_stale_multiplier: 0.5  # In-progress items age twice as fast
 blocking_cascade_info_threshold: 3   # Info alert if completing an issue unblocks 3+ items
 blocking_cascade_warning_threshold: 5 # Warning if unblocks 5+ items
 
+# Activity anomaly thresholds: last 7 days of created / closed / newly
+# blocked issues (from git history) vs the mean of the 4 weeks before
+activity_drop_info_pct: 50       # Info if a count falls 50%+
+activity_drop_warning_pct: 80    # Warn if it falls 80%+
+activity_spike_info_pct: 100     # Info if a count doubles
+activity_spike_warning_pct: 200  # Warn if it triples
+
+# Goal thresholds: chance of closing a goal in .bv/goals.yaml by its target
+goal_warning_probability: 0.5    # Warn below a 50% chance
+goal_critical_probability: 0.2   # Critical below a 20% chance
+
+# Review thresholds: days an issue has waited in in_review
+review_warning_days: 3           # Warn after 3 days in review
+review_critical_days: 7          # Critical after 7 days
+
 # Disable specific alert types (bv-167)
 # Uncomment to disable:
 # disabled_alerts:
@@ -38,6 +38,10 @@ const (
 	AlertHighImpactUnblock  AlertType = "high_impact_unblock"
 	AlertAbandonedClaim     AlertType = "abandoned_claim"
 	AlertPotentialDuplicate AlertType = "potential_duplicate"
+	AlertWIPLimitExceeded   AlertType = "wip_limit_exceeded"
+	AlertActivityAnomaly    AlertType = "activity_anomaly"
+	AlertGoalAtRisk         AlertType = "goal_at_risk"
+	AlertReviewOverdue      AlertType = "review_overdue"
 )
 
 // Alert represents a single drift detection alert
@@ -56,6 +60,9 @@ type Alert struct {
 	// Blocking cascade specific fields (bv-165)
 	UnblocksCount         int `json:"unblocks_count,omitempty"`
 	DownstreamPrioritySum int `json:"downstream_priority_sum,omitempty"`
+
+	// Activity anomaly specific: the metric's daily series behind the alert
+	Series *analysis.MetricSeries `json:"series,omitempty"`
 }
 
 // Result contains the complete drift analysis
@@ -78,6 +85,9 @@ type Calculator struct {
 	baseline *baseline.Baseline
 	current  *baseline.Baseline
 	issues   []model.Issue
+	board    *BoardConfig
+	activity *analysis.ActivitySeries
+	goals    []analysis.GoalForecast
 }
 
 // NewCalculator creates a drift calculator with the given baseline and current snapshot
@@ -128,6 +138,18 @@ func (c *Calculator) Calculate() *Result {
 	// Check blocking cascades (uses current issues if provided)
 	c.checkBlockingCascade(result)
 
+	// Check board WIP limits (uses current issues and board config if provided)
+	c.checkWIPLimits(result)
+
+	// Check activity spikes and droughts (uses the activity series if provided)
+	c.checkActivityAnomalies(result)
+
+	// Check goals unlikely to make their target dates (uses goal forecasts if provided)
+	c.checkGoals(result)
+
+	// Check issues waiting too long in review (uses current issues if provided)
+	c.checkReviews(result)
+
 	// Compute summary
 	for _, alert := range result.Alerts {
 		switch alert.Severity {
@@ -398,10 +420,15 @@ func (c *Calculator) checkStaleness(result *Result) {
 		return
 	}
 	now := time.Now().UTC()
+	reviewChecked := !c.config.IsAlertDisabled(string(AlertReviewOverdue))
 	for _, issue := range c.issues {
 		if issue.Status == model.StatusClosed {
 			continue
 		}
+		// Work waiting for review ages on the review_overdue clock instead
+		if issue.Status == model.StatusInReview && reviewChecked {
+			continue
+		}
 
 		lastActive := issue.UpdatedAt
 		if lastActive.IsZero() {
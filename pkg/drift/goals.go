@@ -0,0 +1,74 @@
+package drift
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+)
+
+// SetGoals attaches goal forecasts for the goal_at_risk check; see
+// analysis.ForecastGoals.
+func (c *Calculator) SetGoals(goals []analysis.GoalForecast) {
+	c.goals = goals
+}
+
+// checkGoals flags open goals whose chance of closing by the target date has
+// fallen below the configured probabilities. A goal past its target with work
+// left, or with work nobody can finish, has no chance at all.
+func (c *Calculator) checkGoals(result *Result) {
+	if c.config.IsAlertDisabled(string(AlertGoalAtRisk)) {
+		return
+	}
+
+	now := time.Now().UTC()
+	for _, g := range c.goals {
+		if g.Done || g.Total == 0 || g.Probability >= c.config.GoalWarningProbability {
+			continue
+		}
+		severity := SeverityWarning
+		if g.Probability < c.config.GoalCriticalProbability {
+			severity = SeverityCritical
+		}
+
+		var message string
+		switch {
+		case g.DaysLeft <= 0:
+			message = fmt.Sprintf("Goal %q missed its %s target with %d of %d issues open", g.Name, g.Target, g.Open, g.Total)
+		case len(g.Stuck) > 0:
+			message = fmt.Sprintf("Goal %q cannot finish: %d issues are unassignable or in a cycle", g.Name, len(g.Stuck))
+		default:
+			message = fmt.Sprintf("Goal %q has a %.0f%% chance of closing by %s (P80 %s)",
+				g.Name, g.Probability*100, g.Target, g.Completion.P80Date.Format("2006-01-02"))
+		}
+
+		details := []string{
+			fmt.Sprintf("goal=%s", g.Name),
+			fmt.Sprintf("open_issues=%d", g.Open),
+			fmt.Sprintf("days_left=%.1f", g.DaysLeft),
+		}
+		if len(g.Blockers) > 0 {
+			details = append(details, fmt.Sprintf("blockers=%s", strings.Join(g.Blockers, ",")))
+		}
+		if len(g.Stuck) > 0 {
+			details = append(details, fmt.Sprintf("stuck=%s", strings.Join(g.Stuck, ",")))
+		}
+		alert := Alert{
+			Type:        AlertGoalAtRisk,
+			Severity:    severity,
+			Message:     message,
+			BaselineVal: c.config.GoalWarningProbability,
+			CurrentVal:  g.Probability,
+			Delta:       g.Probability - c.config.GoalWarningProbability,
+			Details:     details,
+			Label:       g.Label,
+			DetectedAt:  now,
+		}
+		// Point at the work most likely to move the date
+		if len(g.NextUp) > 0 {
+			alert.IssueID = g.NextUp[0]
+		}
+		result.Alerts = append(result.Alerts, alert)
+	}
+}
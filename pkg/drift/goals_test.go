@@ -0,0 +1,69 @@
+package drift
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/baseline"
+)
+
+func goalAlerts(goals []analysis.GoalForecast, cfg *Config) []Alert {
+	bl := &baseline.Baseline{}
+	calc := NewCalculator(bl, bl, cfg)
+	calc.SetGoals(goals)
+	var alerts []Alert
+	for _, a := range calc.Calculate().Alerts {
+		if a.Type == AlertGoalAtRisk {
+			alerts = append(alerts, a)
+		}
+	}
+	return alerts
+}
+
+func TestCheckGoals(t *testing.T) {
+	p80 := &analysis.CompletionDistribution{P80Date: time.Date(2026, 12, 9, 0, 0, 0, 0, time.UTC)}
+	goal := func(name string, probability, daysLeft float64) analysis.GoalForecast {
+		return analysis.GoalForecast{
+			Goal:        analysis.Goal{Name: name, Label: "v1", Target: "2026-12-01"},
+			Total:       4,
+			Open:        3,
+			DaysLeft:    daysLeft,
+			Probability: probability,
+			Completion:  p80,
+			NextUp:      []string{"bv-7", "bv-9"},
+		}
+	}
+	shipped := goal("shipped", 1, 5)
+	shipped.Done = true
+
+	alerts := goalAlerts([]analysis.GoalForecast{
+		goal("safe", 0.9, 30),
+		goal("coin-flip", 0.35, 30),
+		goal("unlikely", 0.1, 30),
+		goal("late", 0, -2),
+		shipped,
+	}, DefaultConfig())
+	if len(alerts) != 3 {
+		t.Fatalf("want coin-flip, unlikely and late; got %+v", alerts)
+	}
+
+	if a := alerts[0]; a.Severity != SeverityWarning || a.IssueID != "bv-7" || a.Label != "v1" {
+		t.Errorf("coin-flip alert = %+v", a)
+	}
+	if want := `Goal "coin-flip" has a 35% chance of closing by 2026-12-01 (P80 2026-12-09)`; alerts[0].Message != want {
+		t.Errorf("message = %q, want %q", alerts[0].Message, want)
+	}
+	if alerts[1].Severity != SeverityCritical {
+		t.Errorf("unlikely should be critical: %+v", alerts[1])
+	}
+	if want := `Goal "late" missed its 2026-12-01 target with 3 of 4 issues open`; alerts[2].Message != want {
+		t.Errorf("message = %q, want %q", alerts[2].Message, want)
+	}
+
+	cfg := DefaultConfig()
+	cfg.DisabledAlerts = []string{string(AlertGoalAtRisk)}
+	if alerts := goalAlerts([]analysis.GoalForecast{goal("late", 0, -2)}, cfg); len(alerts) != 0 {
+		t.Errorf("disabled goal_at_risk still alerted: %+v", alerts)
+	}
+}
@@ -0,0 +1,54 @@
+package drift
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+)
+
+// checkReviews flags issues that have waited in in_review longer than the
+// configured days, oldest first. Like staleness it needs the issues attached
+// with SetIssues.
+func (c *Calculator) checkReviews(result *Result) {
+	if c.config.IsAlertDisabled(string(AlertReviewOverdue)) || len(c.issues) == 0 {
+		return
+	}
+
+	now := time.Now().UTC()
+	stats := analysis.ComputeReviewStats(c.issues, 0, now)
+	for _, item := range stats.Queue {
+		severity := Severity("")
+		switch {
+		case item.AgeDays >= float64(c.config.ReviewCriticalDays):
+			severity = SeverityCritical
+		case item.AgeDays >= float64(c.config.ReviewWarningDays):
+			severity = SeverityWarning
+		default:
+			continue
+		}
+
+		details := []string{
+			fmt.Sprintf("in_review_since=%s", item.Since.UTC().Format(time.RFC3339)),
+			fmt.Sprintf("queue_size=%d", len(stats.Queue)),
+		}
+		if item.Assignee != "" {
+			details = append(details, fmt.Sprintf("assignee=%s", item.Assignee))
+		}
+		if len(item.Labels) > 0 {
+			details = append(details, fmt.Sprintf("labels=%s", strings.Join(item.Labels, ",")))
+		}
+		result.Alerts = append(result.Alerts, Alert{
+			Type:        AlertReviewOverdue,
+			Severity:    severity,
+			Message:     fmt.Sprintf("Issue %s waiting in review for %.0f days", item.ID, item.AgeDays),
+			BaselineVal: float64(c.config.ReviewWarningDays),
+			CurrentVal:  item.AgeDays,
+			Delta:       item.AgeDays - float64(c.config.ReviewWarningDays),
+			Details:     details,
+			IssueID:     item.ID,
+			DetectedAt:  now,
+		})
+	}
+}
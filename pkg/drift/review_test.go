@@ -0,0 +1,61 @@
+package drift
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/baseline"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestCheckReviews(t *testing.T) {
+	ago := func(days int) time.Time { return time.Now().UTC().AddDate(0, 0, -days) }
+	issues := []model.Issue{
+		{ID: "bv-1", Status: model.StatusInReview, UpdatedAt: ago(1)},
+		{ID: "bv-2", Status: model.StatusInReview, UpdatedAt: ago(4), Assignee: "alice"},
+		{ID: "bv-3", Status: model.StatusInReview, UpdatedAt: ago(40)},
+		{ID: "bv-4", Status: model.StatusOpen, UpdatedAt: ago(1)},
+	}
+	run := func(cfg *Config) []Alert {
+		bl := &baseline.Baseline{}
+		calc := NewCalculator(bl, bl, cfg)
+		calc.SetIssues(issues)
+		return calc.Calculate().Alerts
+	}
+
+	alerts := run(DefaultConfig())
+	var review []Alert
+	for _, a := range alerts {
+		switch a.Type {
+		case AlertReviewOverdue:
+			review = append(review, a)
+		case AlertStaleIssue:
+			t.Errorf("issues in review should not also be stale: %+v", a)
+		}
+	}
+	if len(review) != 2 {
+		t.Fatalf("want bv-3 and bv-2 overdue, got %+v", review)
+	}
+	if review[0].IssueID != "bv-3" || review[0].Severity != SeverityCritical {
+		t.Errorf("oldest first and critical: %+v", review[0])
+	}
+	if review[1].IssueID != "bv-2" || review[1].Severity != SeverityWarning || review[1].Message != "Issue bv-2 waiting in review for 4 days" {
+		t.Errorf("bv-2 alert = %+v", review[1])
+	}
+
+	// With the check off, long reviews fall back to staleness
+	cfg := DefaultConfig()
+	cfg.DisabledAlerts = []string{string(AlertReviewOverdue)}
+	var stale int
+	for _, a := range run(cfg) {
+		if a.Type == AlertReviewOverdue {
+			t.Errorf("disabled review_overdue still alerted: %+v", a)
+		}
+		if a.Type == AlertStaleIssue && a.IssueID == "bv-3" {
+			stale++
+		}
+	}
+	if stale != 1 {
+		t.Error("bv-3 should be stale once review_overdue is disabled")
+	}
+}
@@ -0,0 +1,125 @@
+package export
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// IssueAnnotation holds the metrics bv computes for one issue. It is the
+// "bv" object of the annotated JSONL export and backs the CSV columns.
+type IssueAnnotation struct {
+	PageRank         float64               `json:"pagerank"`
+	PageRankRank     int                   `json:"pagerank_rank"`
+	Betweenness      float64               `json:"betweenness"`
+	BetweennessRank  int                   `json:"betweenness_rank"`
+	CriticalPath     float64               `json:"critical_path"`
+	CriticalPathRank int                   `json:"critical_path_rank"`
+	TriageScore      float64               `json:"triage_score,omitempty"`
+	TriageRank       int                   `json:"triage_rank,omitempty"` // 1 = best; 0 when not scored (closed)
+	Blocked          bool                  `json:"blocked"`
+	BlockedBy        []string              `json:"blocked_by,omitempty"` // Open blockers
+	Unblocks         []string              `json:"unblocks,omitempty"`   // Issues that become actionable when this closes
+	Forecast         *analysis.ETAEstimate `json:"forecast,omitempty"`   // Nil for closed issues
+}
+
+// AnnotatedIssue is one line of the annotated JSONL export: the issue as
+// bd writes it plus a "bv" object.
+type AnnotatedIssue struct {
+	model.Issue
+	BV IssueAnnotation `json:"bv"`
+}
+
+// AnnotationOptions configures metric computation.
+type AnnotationOptions struct {
+	// Agents is the parallelism used for the forecast ETA (default 1).
+	Agents int
+	// Now anchors the forecast; zero means time.Now().
+	Now time.Time
+}
+
+// ComputeIssueAnnotations runs graph analysis, triage scoring and the ETA
+// forecast over issues and returns the results keyed by issue ID.
+func ComputeIssueAnnotations(issues []model.Issue, opts AnnotationOptions) map[string]IssueAnnotation {
+	now := opts.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+
+	analyzer := analysis.NewAnalyzer(issues)
+	stats := analyzer.AnalyzeAsync(context.Background())
+	stats.WaitForPhase2()
+	pageRankRank := stats.PageRankRank()
+	betweennessRank := stats.BetweennessRank()
+	criticalPathRank := stats.CriticalPathRank()
+
+	scores := analysis.ComputeTriageScores(issues)
+	sort.SliceStable(scores, func(i, j int) bool { return scores[i].TriageScore > scores[j].TriageScore })
+
+	annotations := make(map[string]IssueAnnotation, len(issues))
+	for _, issue := range issues {
+		blockedBy := analyzer.GetOpenBlockers(issue.ID)
+		a := IssueAnnotation{
+			PageRank:         stats.GetPageRankScore(issue.ID),
+			PageRankRank:     pageRankRank[issue.ID],
+			Betweenness:      stats.GetBetweennessScore(issue.ID),
+			BetweennessRank:  betweennessRank[issue.ID],
+			CriticalPath:     stats.GetCriticalPathScore(issue.ID),
+			CriticalPathRank: criticalPathRank[issue.ID],
+			Blocked:          issue.Status != model.StatusClosed && len(blockedBy) > 0,
+			BlockedBy:        blockedBy,
+			Unblocks:         analyzer.ComputeUnblocks(issue.ID),
+		}
+		if issue.Status != model.StatusClosed {
+			if eta, err := analysis.EstimateETAForIssue(issues, stats, issue.ID, opts.Agents, now); err == nil {
+				a.Forecast = &eta
+			}
+		}
+		annotations[issue.ID] = a
+	}
+	for rank, s := range scores {
+		if a, ok := annotations[s.IssueID]; ok {
+			a.TriageScore = s.TriageScore
+			a.TriageRank = rank + 1
+			annotations[s.IssueID] = a
+		}
+	}
+	return annotations
+}
+
+// WriteAnnotatedJSONL writes issues as JSONL, one AnnotatedIssue per line,
+// in input order. The output still loads as a beads file.
+func WriteAnnotatedJSONL(w io.Writer, issues []model.Issue, opts AnnotationOptions) error {
+	annotations := ComputeIssueAnnotations(issues, opts)
+
+	bw := bufio.NewWriter(w)
+	enc := json.NewEncoder(bw)
+	enc.SetEscapeHTML(false)
+	for _, issue := range issues {
+		if err := enc.Encode(AnnotatedIssue{Issue: issue, BV: annotations[issue.ID]}); err != nil {
+			return fmt.Errorf("encode %s: %w", issue.ID, err)
+		}
+	}
+	return bw.Flush()
+}
+
+// SaveAnnotatedJSONL writes the annotated JSONL export to filename.
+func SaveAnnotatedJSONL(issues []model.Issue, filename string, opts AnnotationOptions) error {
+	f, err := os.Create(filename)
+	if err != nil {
+		return fmt.Errorf("create jsonl: %w", err)
+	}
+	if err := WriteAnnotatedJSONL(f, issues, opts); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
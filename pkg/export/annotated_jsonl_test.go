@@ -0,0 +1,101 @@
+package export
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func annotatedFixture(now time.Time) []model.Issue {
+	closed := now.Add(-time.Hour)
+	return []model.Issue{
+		{ID: "A", Title: "Root <api>", Status: model.StatusOpen, Priority: 0, IssueType: model.TypeTask, CreatedAt: now.AddDate(0, 0, -3), UpdatedAt: now},
+		{ID: "B", Title: "Needs A", Status: model.StatusOpen, Priority: 1, IssueType: model.TypeFeature, Labels: []string{"ui"}, CreatedAt: now.AddDate(0, 0, -2), UpdatedAt: now,
+			Dependencies: []*model.Dependency{{IssueID: "B", DependsOnID: "A", Type: model.DepBlocks}}},
+		{ID: "C", Title: "Done", Status: model.StatusClosed, Priority: 2, IssueType: model.TypeBug, CreatedAt: now.AddDate(0, 0, -5), UpdatedAt: closed, ClosedAt: &closed},
+	}
+}
+
+func TestComputeIssueAnnotations(t *testing.T) {
+	now := time.Date(2025, 6, 15, 12, 0, 0, 0, time.UTC)
+	got := ComputeIssueAnnotations(annotatedFixture(now), AnnotationOptions{Now: now})
+
+	a, b, c := got["A"], got["B"], got["C"]
+	if a.Blocked || len(a.Unblocks) != 1 || a.Unblocks[0] != "B" {
+		t.Errorf("A: want unblocked and unblocking B, got %+v", a)
+	}
+	if !b.Blocked || len(b.BlockedBy) != 1 || b.BlockedBy[0] != "A" {
+		t.Errorf("B: want blocked by A, got %+v", b)
+	}
+	if a.PageRankRank != 1 || a.PageRank <= b.PageRank {
+		t.Errorf("A should have the top pagerank: A=%+v B=%+v", a, b)
+	}
+	if a.TriageRank != 1 || b.TriageRank != 2 || c.TriageRank != 0 {
+		t.Errorf("triage ranks = %d, %d, %d; want 1, 2, 0", a.TriageRank, b.TriageRank, c.TriageRank)
+	}
+	if a.Forecast == nil || a.Forecast.IssueID != "A" || c.Forecast != nil {
+		t.Errorf("want forecast for open issues only: A=%v C=%v", a.Forecast, c.Forecast)
+	}
+}
+
+func TestWriteAnnotatedJSONL(t *testing.T) {
+	now := time.Date(2025, 6, 15, 12, 0, 0, 0, time.UTC)
+	issues := annotatedFixture(now)
+
+	var buf bytes.Buffer
+	if err := WriteAnnotatedJSONL(&buf, issues, AnnotationOptions{Now: now}); err != nil {
+		t.Fatal(err)
+	}
+
+	var lines []map[string]json.RawMessage
+	scanner := bufio.NewScanner(bytes.NewReader(buf.Bytes()))
+	for scanner.Scan() {
+		var line map[string]json.RawMessage
+		if err := json.Unmarshal(scanner.Bytes(), &line); err != nil {
+			t.Fatalf("invalid json line %q: %v", scanner.Text(), err)
+		}
+		lines = append(lines, line)
+	}
+	if len(lines) != 3 {
+		t.Fatalf("expected 3 lines, got %d", len(lines))
+	}
+	if string(lines[0]["id"]) != `"A"` || string(lines[0]["title"]) != `"Root <api>"` {
+		t.Errorf("issue fields not preserved: %s %s", lines[0]["id"], lines[0]["title"])
+	}
+	var bv IssueAnnotation
+	if err := json.Unmarshal(lines[1]["bv"], &bv); err != nil {
+		t.Fatalf("invalid bv object: %v", err)
+	}
+	if !bv.Blocked || bv.Forecast == nil {
+		t.Errorf("unexpected bv object for B: %s", lines[1]["bv"])
+	}
+
+	// The annotated stream still loads as a beads file
+	reloaded, err := loader.ParseIssues(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatalf("reload: %v", err)
+	}
+	if len(reloaded) != 3 || reloaded[1].ID != "B" || len(reloaded[1].Dependencies) != 1 || reloaded[1].Labels[0] != "ui" {
+		t.Errorf("round trip lost data: %+v", reloaded)
+	}
+}
+
+func TestSaveAnnotatedJSONL(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "annotated.jsonl")
+	if err := SaveAnnotatedJSONL(annotatedFixture(time.Now()), path, AnnotationOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	issues, err := loader.LoadIssuesFromFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(issues) != 3 {
+		t.Errorf("expected 3 issues, got %d", len(issues))
+	}
+}
@@ -0,0 +1,37 @@
+package export
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+)
+
+// OpenInBrowser opens a URL in the default browser.
+// Set BV_NO_BROWSER=1 to suppress browser opening (useful for tests).
+func OpenInBrowser(url string) error {
+	// Skip browser opening in test mode or when explicitly disabled
+	if os.Getenv("BV_NO_BROWSER") != "" || os.Getenv("BV_TEST_MODE") != "" {
+		return nil
+	}
+
+	args := browserCommand(runtime.GOOS, url)
+	if _, err := exec.LookPath(args[0]); err != nil {
+		return fmt.Errorf("cannot open browser: %w", err)
+	}
+	return exec.Command(args[0], args[1:]...).Start()
+}
+
+// browserCommand is the command that opens url on goos. Windows goes
+// through url.dll rather than "cmd /c start", which splits URLs at & and
+// treats a quoted first argument as the window title.
+func browserCommand(goos, url string) []string {
+	switch goos {
+	case "darwin":
+		return []string{"open", url}
+	case "windows":
+		return []string{"rundll32", "url.dll,FileProtocolHandler", url}
+	default:
+		return []string{"xdg-open", url}
+	}
+}
@@ -0,0 +1,276 @@
+package export
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/drift"
+)
+
+// CIReportInput bundles everything rendered by a CI report.
+type CIReportInput struct {
+	DataHash   string
+	SourceFile string // Path used for file/line annotations (e.g., .beads/beads.jsonl)
+
+	// Drift is the drift detection result (baseline or proactive alerts).
+	Drift *drift.Result
+	// HasBaseline reports whether Drift compares against a saved baseline.
+	HasBaseline bool
+
+	// ValidationErrors are data problems found while loading the beads file.
+	ValidationErrors []CIValidationError
+
+	// Triage provides the top picks for the job summary (optional).
+	Triage *analysis.TriageResult
+
+	// Deltas are baseline-vs-current metric changes (optional).
+	Deltas []CIMetricDelta
+}
+
+// CIValidationError is a single data problem, optionally tied to a line.
+type CIValidationError struct {
+	Line    int
+	Message string
+}
+
+// CIMetricDelta is a single baseline-vs-current metric comparison.
+type CIMetricDelta struct {
+	Name     string
+	Baseline float64
+	Current  float64
+}
+
+// CIOutputs are the step outputs exposed to downstream workflow steps.
+type CIOutputs struct {
+	HasDrift        bool
+	CriticalCount   int
+	WarningCount    int
+	ValidationCount int
+}
+
+// Outputs derives the step outputs from the report input.
+func (in CIReportInput) Outputs() CIOutputs {
+	out := CIOutputs{ValidationCount: len(in.ValidationErrors)}
+	if in.Drift != nil {
+		out.HasDrift = in.Drift.HasDrift
+		out.CriticalCount = in.Drift.CriticalCount
+		out.WarningCount = in.Drift.WarningCount
+	}
+	out.CriticalCount += len(in.ValidationErrors)
+	return out
+}
+
+// WriteGitHubAnnotations writes GitHub Actions workflow commands (::error,
+// ::warning, ::notice) for validation failures and drift alerts.
+func WriteGitHubAnnotations(w io.Writer, in CIReportInput) error {
+	for _, ve := range in.ValidationErrors {
+		props := map[string]string{"title": "Invalid bead record"}
+		if in.SourceFile != "" {
+			props["file"] = in.SourceFile
+			if ve.Line > 0 {
+				props["line"] = fmt.Sprintf("%d", ve.Line)
+			}
+		}
+		if _, err := io.WriteString(w, githubCommand("error", props, ve.Message)); err != nil {
+			return err
+		}
+	}
+
+	if in.Drift == nil {
+		return nil
+	}
+	for _, a := range in.Drift.Alerts {
+		level := "notice"
+		switch a.Severity {
+		case drift.SeverityCritical:
+			level = "error"
+		case drift.SeverityWarning:
+			level = "warning"
+		}
+		msg := a.Message
+		if a.IssueID != "" && !strings.Contains(msg, a.IssueID) {
+			msg = a.IssueID + ": " + msg
+		}
+		if len(a.Details) > 0 {
+			msg += "\n" + strings.Join(a.Details, "\n")
+		}
+		props := map[string]string{"title": "bv " + string(a.Type)}
+		if _, err := io.WriteString(w, githubCommand(level, props, msg)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GenerateCISummaryMarkdown renders the job summary markdown.
+func GenerateCISummaryMarkdown(in CIReportInput) string {
+	var sb strings.Builder
+	outputs := in.Outputs()
+
+	sb.WriteString("## bv project health\n\n")
+	status := "✅ No drift detected"
+	switch {
+	case outputs.CriticalCount > 0:
+		status = "❌ Critical problems detected"
+	case outputs.WarningCount > 0:
+		status = "⚠️ Warnings detected"
+	case outputs.HasDrift:
+		status = "ℹ️ Informational drift only"
+	}
+	sb.WriteString(fmt.Sprintf("**Status:** %s\n\n", status))
+	if in.DataHash != "" {
+		sb.WriteString(fmt.Sprintf("Data hash: `%s`\n\n", in.DataHash))
+	}
+
+	if len(in.ValidationErrors) > 0 {
+		sb.WriteString("### Validation failures\n\n")
+		sb.WriteString("| Line | Problem |\n|------|---------|\n")
+		for _, ve := range in.ValidationErrors {
+			line := "-"
+			if ve.Line > 0 {
+				line = fmt.Sprintf("%d", ve.Line)
+			}
+			sb.WriteString(fmt.Sprintf("| %s | %s |\n", line, escapeTableCell(ve.Message)))
+		}
+		sb.WriteString("\n")
+	}
+
+	if in.Drift != nil && len(in.Drift.Alerts) > 0 {
+		sb.WriteString("### Alerts\n\n")
+		sb.WriteString(fmt.Sprintf("%d critical · %d warning · %d info\n\n",
+			in.Drift.CriticalCount, in.Drift.WarningCount, in.Drift.InfoCount))
+		sb.WriteString("| Severity | Type | Message |\n|----------|------|---------|\n")
+		for _, a := range in.Drift.Alerts {
+			sb.WriteString(fmt.Sprintf("| %s | `%s` | %s |\n", a.Severity, a.Type, escapeTableCell(a.Message)))
+		}
+		sb.WriteString("\n")
+	}
+
+	if len(in.Deltas) > 0 {
+		sb.WriteString("### Metric deltas vs baseline\n\n")
+		sb.WriteString("| Metric | Baseline | Current | Δ |\n|--------|----------|---------|---|\n")
+		for _, d := range in.Deltas {
+			sb.WriteString(fmt.Sprintf("| %s | %s | %s | %s |\n",
+				d.Name, formatCINumber(d.Baseline), formatCINumber(d.Current), formatCIDelta(d.Current-d.Baseline)))
+		}
+		sb.WriteString("\n")
+	} else if !in.HasBaseline {
+		sb.WriteString("_No baseline saved; run `bv --save-baseline \"...\"` to enable metric deltas._\n\n")
+	}
+
+	if in.Triage != nil && len(in.Triage.QuickRef.TopPicks) > 0 {
+		qr := in.Triage.QuickRef
+		sb.WriteString("### Top picks\n\n")
+		sb.WriteString(fmt.Sprintf("%d open · %d actionable · %d blocked · %d in progress\n\n",
+			qr.OpenCount, qr.ActionableCount, qr.BlockedCount, qr.InProgressCount))
+		sb.WriteString("| # | ID | Title | Score | Unblocks |\n|---|----|-------|-------|----------|\n")
+		for i, p := range qr.TopPicks {
+			sb.WriteString(fmt.Sprintf("| %d | `%s` | %s | %.2f | %d |\n",
+				i+1, p.ID, escapeTableCell(p.Title), p.Score, p.Unblocks))
+		}
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+}
+
+// WriteGitHubCIReport emits workflow commands to w and, when the corresponding
+// environment variables are set, appends the job summary to $GITHUB_STEP_SUMMARY
+// and step outputs to $GITHUB_OUTPUT.
+func WriteGitHubCIReport(w io.Writer, in CIReportInput) error {
+	if err := WriteGitHubAnnotations(w, in); err != nil {
+		return fmt.Errorf("writing annotations: %w", err)
+	}
+
+	summary := GenerateCISummaryMarkdown(in)
+	if path := os.Getenv("GITHUB_STEP_SUMMARY"); path != "" {
+		if err := appendToFile(path, summary); err != nil {
+			return fmt.Errorf("writing job summary: %w", err)
+		}
+	} else {
+		// Outside of Actions, print the summary so the report is still useful locally.
+		if _, err := io.WriteString(w, "\n"+summary); err != nil {
+			return err
+		}
+	}
+
+	if path := os.Getenv("GITHUB_OUTPUT"); path != "" {
+		if err := appendToFile(path, formatGitHubOutputs(in.Outputs())); err != nil {
+			return fmt.Errorf("writing step outputs: %w", err)
+		}
+	}
+	return nil
+}
+
+func formatGitHubOutputs(o CIOutputs) string {
+	return fmt.Sprintf("has_drift=%t\ncritical_count=%d\nwarning_count=%d\nvalidation_count=%d\n",
+		o.HasDrift, o.CriticalCount, o.WarningCount, o.ValidationCount)
+}
+
+// githubCommand formats a single workflow command with escaped properties/message.
+func githubCommand(level string, props map[string]string, msg string) string {
+	var sb strings.Builder
+	sb.WriteString("::")
+	sb.WriteString(level)
+	// Fixed key order keeps output deterministic.
+	first := true
+	for _, key := range []string{"title", "file", "line"} {
+		val, ok := props[key]
+		if !ok {
+			continue
+		}
+		if first {
+			sb.WriteString(" ")
+			first = false
+		} else {
+			sb.WriteString(",")
+		}
+		sb.WriteString(key)
+		sb.WriteString("=")
+		sb.WriteString(escapeGitHubProperty(val))
+	}
+	sb.WriteString("::")
+	sb.WriteString(escapeGitHubData(msg))
+	sb.WriteString("\n")
+	return sb.String()
+}
+
+func escapeGitHubData(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A").Replace(s)
+}
+
+func escapeGitHubProperty(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C").Replace(s)
+}
+
+func escapeTableCell(s string) string {
+	s = strings.ReplaceAll(s, "|", "\\|")
+	return strings.ReplaceAll(s, "\n", " ")
+}
+
+func formatCINumber(v float64) string {
+	if v == float64(int64(v)) {
+		return fmt.Sprintf("%d", int64(v))
+	}
+	return fmt.Sprintf("%.4f", v)
+}
+
+func formatCIDelta(v float64) string {
+	if v > 0 {
+		return "+" + formatCINumber(v)
+	}
+	return formatCINumber(v)
+}
+
+func appendToFile(path, content string) error {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = f.WriteString(content)
+	return err
+}
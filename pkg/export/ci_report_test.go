@@ -0,0 +1,131 @@
+package export
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/drift"
+)
+
+func sampleCIReportInput() CIReportInput {
+	return CIReportInput{
+		DataHash:   "abc123",
+		SourceFile: ".beads/beads.jsonl",
+		Drift: &drift.Result{
+			HasDrift: true,
+			Alerts: []drift.Alert{
+				{Type: drift.AlertNewCycle, Severity: drift.SeverityCritical, Message: "1 new cycle(s) detected", Details: []string{"A → B → A"}},
+				{Type: drift.AlertStaleIssue, Severity: drift.SeverityWarning, Message: "stale for 20 days", IssueID: "bv-1"},
+				{Type: drift.AlertNodeCountChange, Severity: drift.SeverityInfo, Message: "node count grew"},
+			},
+			CriticalCount: 1,
+			WarningCount:  1,
+			InfoCount:     1,
+		},
+		HasBaseline:      true,
+		ValidationErrors: []CIValidationError{{Line: 3, Message: "skipping malformed JSON on line 3: bad, worse"}},
+		Deltas:           []CIMetricDelta{{Name: "cycle_count", Baseline: 0, Current: 1}},
+		Triage: &analysis.TriageResult{
+			QuickRef: analysis.QuickRef{
+				OpenCount:       4,
+				ActionableCount: 2,
+				TopPicks:        []analysis.TopPick{{ID: "bv-9", Title: "Fix | pipe", Score: 0.812, Unblocks: 3}},
+			},
+		},
+	}
+}
+
+func TestWriteGitHubAnnotations(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteGitHubAnnotations(&buf, sampleCIReportInput()); err != nil {
+		t.Fatalf("WriteGitHubAnnotations: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("expected 4 workflow commands, got %d:\n%s", len(lines), buf.String())
+	}
+
+	want := []string{
+		"::error title=Invalid bead record,file=.beads/beads.jsonl,line=3::skipping malformed JSON on line 3: bad, worse",
+		"::error title=bv new_cycle::1 new cycle(s) detected%0AA → B → A",
+		"::warning title=bv stale_issue::bv-1: stale for 20 days",
+		"::notice title=bv node_count_change::node count grew",
+	}
+	for i, w := range want {
+		if lines[i] != w {
+			t.Errorf("line %d:\n got  %q\n want %q", i, lines[i], w)
+		}
+	}
+}
+
+func TestGitHubCommandEscapesProperties(t *testing.T) {
+	got := githubCommand("warning", map[string]string{"title": "a:b,c"}, "50% done\r\n")
+	want := "::warning title=a%3Ab%2Cc::50%25 done%0D%0A\n"
+	if got != want {
+		t.Errorf("githubCommand = %q, want %q", got, want)
+	}
+}
+
+func TestGenerateCISummaryMarkdown(t *testing.T) {
+	md := GenerateCISummaryMarkdown(sampleCIReportInput())
+	for _, want := range []string{
+		"Critical problems detected",
+		"### Validation failures",
+		"### Alerts",
+		"| cycle_count | 0 | 1 | +1 |",
+		"`bv-9`",
+		`Fix \| pipe`,
+	} {
+		if !strings.Contains(md, want) {
+			t.Errorf("summary missing %q:\n%s", want, md)
+		}
+	}
+}
+
+func TestGenerateCISummaryMarkdown_NoBaselineHint(t *testing.T) {
+	md := GenerateCISummaryMarkdown(CIReportInput{Drift: &drift.Result{}})
+	if !strings.Contains(md, "No drift detected") {
+		t.Errorf("expected clean status, got:\n%s", md)
+	}
+	if !strings.Contains(md, "--save-baseline") {
+		t.Errorf("expected baseline hint, got:\n%s", md)
+	}
+}
+
+func TestWriteGitHubCIReport_WritesSummaryAndOutputs(t *testing.T) {
+	dir := t.TempDir()
+	summaryPath := filepath.Join(dir, "summary.md")
+	outputPath := filepath.Join(dir, "output.txt")
+	t.Setenv("GITHUB_STEP_SUMMARY", summaryPath)
+	t.Setenv("GITHUB_OUTPUT", outputPath)
+
+	var buf bytes.Buffer
+	if err := WriteGitHubCIReport(&buf, sampleCIReportInput()); err != nil {
+		t.Fatalf("WriteGitHubCIReport: %v", err)
+	}
+	if strings.Contains(buf.String(), "## bv project health") {
+		t.Error("summary should go to GITHUB_STEP_SUMMARY, not stdout")
+	}
+
+	summary, err := os.ReadFile(summaryPath)
+	if err != nil {
+		t.Fatalf("read summary: %v", err)
+	}
+	if !strings.Contains(string(summary), "## bv project health") {
+		t.Errorf("summary file missing header:\n%s", summary)
+	}
+
+	outputs, err := os.ReadFile(outputPath)
+	if err != nil {
+		t.Fatalf("read outputs: %v", err)
+	}
+	for _, want := range []string{"has_drift=true", "critical_count=2", "warning_count=1", "validation_count=1"} {
+		if !strings.Contains(string(outputs), want) {
+			t.Errorf("outputs missing %q:\n%s", want, outputs)
+		}
+	}
+}
@@ -12,7 +12,6 @@ import (
 	"os/exec"
 	"path/filepath"
 	"regexp"
-	"runtime"
 	"strings"
 )
 
@@ -446,24 +445,5 @@ func DeleteCloudflareProject(projectName string, confirm bool) error {
 // OpenCloudflareInBrowser opens the Cloudflare Pages dashboard in browser.
 // Set BV_NO_BROWSER=1 to suppress browser opening (useful for tests).
 func OpenCloudflareInBrowser(projectName string) error {
-	// Skip browser opening in test mode or when explicitly disabled
-	if os.Getenv("BV_NO_BROWSER") != "" || os.Getenv("BV_TEST_MODE") != "" {
-		return nil
-	}
-
-	url := fmt.Sprintf("https://dash.cloudflare.com/?to=/:account/pages/view/%s", projectName)
-
-	var cmd *exec.Cmd
-	switch runtime.GOOS {
-	case "darwin":
-		cmd = exec.Command("open", url)
-	case "linux":
-		cmd = exec.Command("xdg-open", url)
-	case "windows":
-		cmd = exec.Command("cmd", "/c", "start", url)
-	default:
-		return fmt.Errorf("unsupported platform: %s", runtime.GOOS)
-	}
-
-	return cmd.Start()
+	return OpenInBrowser(fmt.Sprintf("https://dash.cloudflare.com/?to=/:account/pages/view/%s", projectName))
 }
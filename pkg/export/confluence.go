@@ -0,0 +1,170 @@
+// Package export provides data export functionality for bv.
+//
+// This file implements publishing to Confluence through its REST API: the
+// page's storage-format body is replaced and its version bumped, so page
+// history keeps every published status.
+package export
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// ConfluenceTokenEnv holds the Confluence API token (Cloud) or personal
+// access token (Server/Data Center).
+const ConfluenceTokenEnv = "CONFLUENCE_API_TOKEN"
+
+// ConfluenceUserEnv holds the account email used with a Cloud API token.
+const ConfluenceUserEnv = "CONFLUENCE_USER"
+
+// ConfluencePublishConfig configures a Confluence page update.
+type ConfluencePublishConfig struct {
+	// BaseURL is the site including the context path, e.g. https://acme.atlassian.net/wiki
+	BaseURL string
+
+	// PageID is the page to update
+	PageID string
+
+	// Title renames the page when set; otherwise the current title is kept
+	Title string
+
+	// User and Token authenticate with basic auth; a Token alone is sent as a bearer token
+	User  string
+	Token string
+
+	// Markdown is the content to publish
+	Markdown string
+
+	// HTTPClient is used for API calls (default http.DefaultClient)
+	HTTPClient *http.Client
+
+	target string // Name reported in the result
+}
+
+// confluencePage is the subset of Confluence's content object bv uses.
+type confluencePage struct {
+	ID      string `json:"id"`
+	Type    string `json:"type"`
+	Title   string `json:"title"`
+	Version struct {
+		Number int `json:"number"`
+	} `json:"version"`
+	Links struct {
+		Base  string `json:"base"`
+		WebUI string `json:"webui"`
+	} `json:"_links"`
+}
+
+// PublishToConfluence replaces the body of an existing Confluence page.
+func PublishToConfluence(config ConfluencePublishConfig) (*PublishResult, error) {
+	if config.Token == "" {
+		return nil, fmt.Errorf("confluence token required - set %s", ConfluenceTokenEnv)
+	}
+	if config.BaseURL == "" || config.PageID == "" {
+		return nil, fmt.Errorf("confluence base_url and page_id are required")
+	}
+	if config.HTTPClient == nil {
+		config.HTTPClient = http.DefaultClient
+	}
+	body, err := markdownToXHTML(config.Markdown)
+	if err != nil {
+		return nil, fmt.Errorf("rendering markdown: %w", err)
+	}
+
+	pageURL := config.BaseURL + "/rest/api/content/" + url.PathEscape(config.PageID)
+	var page confluencePage
+	if err := config.do(http.MethodGet, pageURL+"?expand=version", nil, &page); err != nil {
+		return nil, fmt.Errorf("fetching confluence page %s: %w", config.PageID, err)
+	}
+
+	title := page.Title
+	if config.Title != "" {
+		title = config.Title
+	}
+	update := map[string]any{
+		"id":    config.PageID,
+		"type":  "page",
+		"title": title,
+		"version": map[string]any{
+			"number":  page.Version.Number + 1,
+			"message": "Published by bv",
+		},
+		"body": map[string]any{
+			"storage": map[string]string{
+				"value":          body,
+				"representation": "storage",
+			},
+		},
+	}
+	var updated confluencePage
+	if err := config.do(http.MethodPut, pageURL, update, &updated); err != nil {
+		return nil, fmt.Errorf("updating confluence page %s: %w", config.PageID, err)
+	}
+
+	result := &PublishResult{
+		Target:  config.target,
+		Type:    PublishConfluence,
+		PageID:  config.PageID,
+		Version: updated.Version.Number,
+	}
+	if updated.Links.WebUI != "" {
+		base := updated.Links.Base
+		if base == "" {
+			base = config.BaseURL
+		}
+		result.URL = base + updated.Links.WebUI
+	}
+	return result, nil
+}
+
+// do sends one authenticated JSON request and decodes the response into out.
+func (c ConfluencePublishConfig) do(method, endpoint string, in, out any) error {
+	var reqBody io.Reader
+	if in != nil {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		reqBody = bytes.NewReader(data)
+	}
+	req, err := http.NewRequest(method, endpoint, reqBody)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.User != "" {
+		req.SetBasicAuth(c.User, c.Token)
+	} else {
+		req.Header.Set("Authorization", "Bearer "+c.Token)
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		var apiErr struct {
+			Message string `json:"message"`
+		}
+		if json.Unmarshal(data, &apiErr) == nil && apiErr.Message != "" {
+			return fmt.Errorf("%s: %s", resp.Status, apiErr.Message)
+		}
+		return fmt.Errorf("%s", resp.Status)
+	}
+	if out == nil {
+		return nil
+	}
+	return json.Unmarshal(data, out)
+}
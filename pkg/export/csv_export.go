@@ -0,0 +1,105 @@
+package export
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// CSVColumns is the header row written by WriteIssuesCSV.
+var CSVColumns = []string{
+	"id", "title", "status", "priority", "issue_type", "assignee", "labels",
+	"created_at", "updated_at", "closed_at", "due_date", "estimated_minutes",
+	"blocked_by", "pagerank", "betweenness", "unblocks_count", "triage_score",
+	"eta_date", "eta_days", "eta_confidence",
+}
+
+// CSVExportOptions configures the spreadsheet export.
+type CSVExportOptions struct {
+	AnnotationOptions
+	// Rows are the issues to write, in order. Nil writes every issue.
+	// Metrics are always computed over the full issue set so filtering
+	// rows does not change PageRank or unblock counts.
+	Rows []model.Issue
+}
+
+// WriteIssuesCSV writes one row per issue with graph metrics, triage score
+// and forecast ETA. Closed issues have no ETA.
+func WriteIssuesCSV(w io.Writer, issues []model.Issue, opts CSVExportOptions) error {
+	rows := opts.Rows
+	if rows == nil {
+		rows = issues
+	}
+	annotations := ComputeIssueAnnotations(issues, opts.AnnotationOptions)
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write(CSVColumns); err != nil {
+		return fmt.Errorf("write csv header: %w", err)
+	}
+	for _, issue := range rows {
+		a := annotations[issue.ID]
+		record := []string{
+			issue.ID,
+			issue.Title,
+			string(issue.Status),
+			strconv.Itoa(issue.Priority),
+			string(issue.IssueType),
+			issue.Assignee,
+			strings.Join(issue.Labels, ","),
+			csvTime(&issue.CreatedAt),
+			csvTime(&issue.UpdatedAt),
+			csvTime(issue.ClosedAt),
+			csvTime(issue.DueDate),
+			"",
+			strings.Join(a.BlockedBy, ","),
+			strconv.FormatFloat(a.PageRank, 'f', 6, 64),
+			strconv.FormatFloat(a.Betweenness, 'f', 6, 64),
+			strconv.Itoa(len(a.Unblocks)),
+			"",
+			"", "", "",
+		}
+		if issue.EstimatedMinutes != nil {
+			record[11] = strconv.Itoa(*issue.EstimatedMinutes)
+		}
+		if a.TriageRank > 0 {
+			record[16] = strconv.FormatFloat(a.TriageScore, 'f', 4, 64)
+		}
+		if eta := a.Forecast; eta != nil {
+			record[17] = eta.ETADate.Format("2006-01-02")
+			record[18] = strconv.FormatFloat(eta.EstimatedDays, 'f', 1, 64)
+			record[19] = strconv.FormatFloat(eta.Confidence, 'f', 2, 64)
+		}
+		if err := cw.Write(record); err != nil {
+			return fmt.Errorf("write csv row %s: %w", issue.ID, err)
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// SaveIssuesCSV writes the CSV export to filename.
+func SaveIssuesCSV(issues []model.Issue, filename string, opts CSVExportOptions) error {
+	f, err := os.Create(filename)
+	if err != nil {
+		return fmt.Errorf("create csv: %w", err)
+	}
+	if err := WriteIssuesCSV(f, issues, opts); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// csvTime formats an optional timestamp as RFC 3339, empty when unset.
+func csvTime(t *time.Time) string {
+	if t == nil || t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,117 @@
+package export
+
+import (
+	"bytes"
+	"encoding/csv"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func csvRecords(t *testing.T, data []byte) []map[string]string {
+	t.Helper()
+	records, err := csv.NewReader(bytes.NewReader(data)).ReadAll()
+	if err != nil {
+		t.Fatalf("invalid csv: %v", err)
+	}
+	if len(records) == 0 {
+		t.Fatal("expected header row")
+	}
+	header := records[0]
+	for i, col := range CSVColumns {
+		if header[i] != col {
+			t.Fatalf("header[%d] = %q, want %q", i, header[i], col)
+		}
+	}
+	var rows []map[string]string
+	for _, rec := range records[1:] {
+		row := make(map[string]string, len(header))
+		for i, col := range header {
+			row[col] = rec[i]
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+func TestWriteIssuesCSV(t *testing.T) {
+	now := time.Date(2025, 6, 15, 12, 0, 0, 0, time.UTC)
+	closed := now.Add(-time.Hour)
+	est := 90
+	issues := []model.Issue{
+		{ID: "A", Title: "Root, with \"quotes\"", Status: model.StatusOpen, Priority: 0, IssueType: model.TypeTask, Labels: []string{"api", "db"}, EstimatedMinutes: &est, CreatedAt: now.AddDate(0, 0, -3), UpdatedAt: now},
+		{ID: "B", Title: "Needs A", Status: model.StatusBlocked, Priority: 1, IssueType: model.TypeFeature, Assignee: "sam", CreatedAt: now.AddDate(0, 0, -2), UpdatedAt: now,
+			Dependencies: []*model.Dependency{{IssueID: "B", DependsOnID: "A", Type: model.DepBlocks}}},
+		{ID: "C", Title: "Done", Status: model.StatusClosed, Priority: 2, IssueType: model.TypeBug, CreatedAt: now.AddDate(0, 0, -5), UpdatedAt: closed, ClosedAt: &closed},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteIssuesCSV(&buf, issues, CSVExportOptions{AnnotationOptions: AnnotationOptions{Now: now}}); err != nil {
+		t.Fatal(err)
+	}
+	rows := csvRecords(t, buf.Bytes())
+	if len(rows) != 3 {
+		t.Fatalf("expected 3 rows, got %d", len(rows))
+	}
+	a, b, c := rows[0], rows[1], rows[2]
+
+	if a["title"] != issues[0].Title || a["labels"] != "api,db" || a["estimated_minutes"] != "90" {
+		t.Errorf("unexpected row A: %v", a)
+	}
+	if a["unblocks_count"] != "1" || b["blocked_by"] != "A" || b["assignee"] != "sam" {
+		t.Errorf("unexpected blocking columns: A=%v B=%v", a, b)
+	}
+	if pr, err := strconv.ParseFloat(a["pagerank"], 64); err != nil || pr <= 0 {
+		t.Errorf("expected positive pagerank for A, got %q", a["pagerank"])
+	}
+	if a["triage_score"] == "" || a["eta_date"] == "" || a["eta_confidence"] == "" {
+		t.Errorf("expected triage score and ETA for open issue, got %v", a)
+	}
+	if c["closed_at"] != closed.Format(time.RFC3339) || c["eta_date"] != "" || c["triage_score"] != "" {
+		t.Errorf("closed issue should have closed_at and no ETA/triage score: %v", c)
+	}
+}
+
+func TestWriteIssuesCSV_RowsSubset(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "A", Title: "Root", Status: model.StatusOpen},
+		{ID: "B", Title: "Leaf", Status: model.StatusOpen,
+			Dependencies: []*model.Dependency{{IssueID: "B", DependsOnID: "A", Type: model.DepBlocks}}},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteIssuesCSV(&buf, issues, CSVExportOptions{Rows: issues[:1]}); err != nil {
+		t.Fatal(err)
+	}
+	rows := csvRecords(t, buf.Bytes())
+	if len(rows) != 1 || rows[0]["id"] != "A" {
+		t.Fatalf("expected only row A, got %v", rows)
+	}
+	// Metrics still reflect the full graph
+	if rows[0]["unblocks_count"] != "1" {
+		t.Errorf("unblocks_count = %q, want 1", rows[0]["unblocks_count"])
+	}
+}
+
+func TestSaveIssuesCSV(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "issues.csv")
+	issues := []model.Issue{{ID: "A", Title: "Only", Status: model.StatusOpen}}
+	if err := SaveIssuesCSV(issues, path, CSVExportOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rows := csvRecords(t, data); len(rows) != 1 {
+		t.Errorf("expected 1 row, got %d", len(rows))
+	}
+
+	if err := SaveIssuesCSV(issues, filepath.Join(t.TempDir(), "missing", "x.csv"), CSVExportOptions{}); err == nil {
+		t.Error("expected error for missing directory")
+	}
+}
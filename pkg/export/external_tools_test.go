@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"reflect"
 	"runtime"
 	"strings"
 	"testing"
@@ -95,6 +96,21 @@ func TestOpenInBrowser_NoCommandReturnsError(t *testing.T) {
 	}
 }
 
+func TestBrowserCommand(t *testing.T) {
+	url := "https://example.com/?a=1&b=2"
+	tests := map[string][]string{
+		"darwin":  {"open", url},
+		"windows": {"rundll32", "url.dll,FileProtocolHandler", url},
+		"linux":   {"xdg-open", url},
+		"freebsd": {"xdg-open", url},
+	}
+	for goos, want := range tests {
+		if got := browserCommand(goos, url); !reflect.DeepEqual(got, want) {
+			t.Errorf("browserCommand(%s) = %q, want %q", goos, got, want)
+		}
+	}
+}
+
 func TestOpenCloudflareInBrowser_NoCommandReturnsError(t *testing.T) {
 	// First verify that BV_NO_BROWSER suppresses browser opening
 	t.Setenv("BV_NO_BROWSER", "1")
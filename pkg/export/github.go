@@ -586,30 +586,6 @@ func DeleteRepository(repoFullName string, confirm bool) error {
 	return nil
 }
 
-// OpenInBrowser opens a URL in the default browser.
-// Set BV_NO_BROWSER=1 to suppress browser opening (useful for tests).
-func OpenInBrowser(url string) error {
-	// Skip browser opening in test mode or when explicitly disabled
-	if os.Getenv("BV_NO_BROWSER") != "" || os.Getenv("BV_TEST_MODE") != "" {
-		return nil
-	}
-
-	var cmd *exec.Cmd
-
-	switch runtime.GOOS {
-	case "darwin":
-		cmd = exec.Command("open", url)
-	case "linux":
-		cmd = exec.Command("xdg-open", url)
-	case "windows":
-		cmd = exec.Command("cmd", "/c", "start", url)
-	default:
-		return fmt.Errorf("unsupported platform: %s", runtime.GOOS)
-	}
-
-	return cmd.Start()
-}
-
 // SuggestRepoName generates a suggested repository name from the bundle path.
 func SuggestRepoName(bundlePath string) string {
 	// Use the directory name
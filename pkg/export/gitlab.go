@@ -0,0 +1,161 @@
+// Package export provides data export functionality for bv.
+//
+// This file implements GitLab Pages deployment. The bundle is pushed to a
+// branch of a GitLab project together with a .gitlab-ci.yml whose "pages"
+// job publishes it, so it works on gitlab.com and self-managed instances
+// without extra tooling.
+package export
+
+import (
+	"encoding/base64"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// GitLabTokenEnv is the environment variable holding a GitLab access token
+// with write_repository scope.
+const GitLabTokenEnv = "GITLAB_TOKEN"
+
+// DefaultGitLabHost is used when the project path has no host.
+const DefaultGitLabHost = "gitlab.com"
+
+// GitLabDeployConfig configures GitLab Pages deployment.
+type GitLabDeployConfig struct {
+	// Project is "group/project", optionally prefixed by a host
+	// ("gitlab.example.com/group/project")
+	Project string
+
+	// Branch is the branch to push and publish from (default: main)
+	Branch string
+
+	// Token is an access token; empty falls back to git's own credentials
+	Token string
+
+	// BundlePath is the path to the static site bundle to deploy
+	BundlePath string
+
+	// Remote overrides the push URL derived from Project (for mirrors and tests)
+	Remote string
+}
+
+// GitLabDeployResult contains the result of a deployment.
+type GitLabDeployResult struct {
+	// Project is the project path (group/project)
+	Project string
+
+	// Host is the GitLab host
+	Host string
+
+	// PagesURL is the expected Pages URL (empty for self-managed hosts)
+	PagesURL string
+
+	// Commit is the pushed commit, empty when nothing changed
+	Commit string
+}
+
+// ParseGitLabProject splits an optional host off a project path. The first
+// segment is treated as a host when it contains a dot.
+func ParseGitLabProject(project string) (host, path string) {
+	project = strings.TrimSpace(project)
+	project = strings.TrimPrefix(project, "https://")
+	project = strings.TrimSuffix(strings.Trim(project, "/"), ".git")
+	first, rest, ok := strings.Cut(project, "/")
+	if ok && strings.Contains(first, ".") {
+		return first, rest
+	}
+	return DefaultGitLabHost, project
+}
+
+// GitLabPagesURL returns the default Pages URL for a gitlab.com project,
+// or "" for self-managed hosts whose Pages domain bv can't know.
+func GitLabPagesURL(host, path string) string {
+	if host != DefaultGitLabHost {
+		return ""
+	}
+	namespace, rest, ok := strings.Cut(path, "/")
+	if !ok {
+		return ""
+	}
+	return fmt.Sprintf("https://%s.gitlab.io/%s/", strings.ToLower(namespace), rest)
+}
+
+// GenerateGitLabCI writes a .gitlab-ci.yml that publishes the bundle with
+// GitLab Pages whenever branch is updated.
+func GenerateGitLabCI(bundlePath, branch string) error {
+	content := fmt.Sprintf(`# Generated by bv. Publishes this static site with GitLab Pages.
+pages:
+  stage: deploy
+  image: alpine:latest
+  script:
+    - mkdir -p .public
+    - cp -r $(ls -A | grep -v -e '^\.public$' -e '^\.git$' -e '^\.gitlab-ci\.yml$') .public/
+    - mv .public public
+  artifacts:
+    paths:
+      - public
+  rules:
+    - if: $CI_COMMIT_BRANCH == %q
+`, branch)
+
+	if err := os.WriteFile(filepath.Join(bundlePath, ".gitlab-ci.yml"), []byte(content), 0644); err != nil {
+		return fmt.Errorf("failed to write .gitlab-ci.yml: %w", err)
+	}
+	return nil
+}
+
+// DeployToGitLabPages pushes the bundle to the project's branch, committing
+// only files that changed since the last deploy.
+func DeployToGitLabPages(config GitLabDeployConfig) (*GitLabDeployResult, error) {
+	if config.Branch == "" {
+		config.Branch = "main"
+	}
+	host, path := ParseGitLabProject(config.Project)
+	if path == "" || !strings.Contains(path, "/") {
+		return nil, fmt.Errorf("gitlab project must be group/project, got %q", config.Project)
+	}
+	if _, err := os.Stat(config.BundlePath); os.IsNotExist(err) {
+		return nil, fmt.Errorf("bundle path does not exist: %s", config.BundlePath)
+	}
+
+	fmt.Println("\n  -> Generating .gitlab-ci.yml...")
+	if err := GenerateGitLabCI(config.BundlePath, config.Branch); err != nil {
+		return nil, err
+	}
+
+	remote := config.Remote
+	if remote == "" {
+		remote = fmt.Sprintf("https://%s/%s.git", host, path)
+	}
+	// Send the token as a header rather than embedding it in the remote URL,
+	// so it never shows up in git error messages
+	var gitConfig []string
+	if config.Token != "" {
+		basic := base64.StdEncoding.EncodeToString([]byte("oauth2:" + config.Token))
+		gitConfig = append(gitConfig, "http.extraHeader=Authorization: Basic "+basic)
+	}
+
+	fmt.Printf("  -> Pushing to %s/%s (branch %s)...\n", host, path, config.Branch)
+	push, err := PushPagesBranch(config.BundlePath, PagesPushConfig{
+		Remote:    remote,
+		Branch:    config.Branch,
+		Message:   "Deploy static site via bv",
+		GitConfig: gitConfig,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("push failed: %w", err)
+	}
+	if push.Pushed {
+		fmt.Printf("  -> Pushed %d changed files, removed %d\n", len(push.Sync.Written), len(push.Sync.Removed))
+	} else {
+		fmt.Println("  -> Site unchanged; nothing to push")
+	}
+
+	return &GitLabDeployResult{
+		Project:  path,
+		Host:     host,
+		PagesURL: GitLabPagesURL(host, path),
+		Commit:   push.Commit,
+	}, nil
+}
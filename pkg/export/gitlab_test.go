@@ -0,0 +1,94 @@
+package export
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseGitLabProject(t *testing.T) {
+	tests := []struct {
+		in, host, path string
+	}{
+		{"group/project", "gitlab.com", "group/project"},
+		{"group/sub/project", "gitlab.com", "group/sub/project"},
+		{"gitlab.example.com/group/project", "gitlab.example.com", "group/project"},
+		{"https://gitlab.com/group/project.git", "gitlab.com", "group/project"},
+	}
+	for _, tt := range tests {
+		host, path := ParseGitLabProject(tt.in)
+		if host != tt.host || path != tt.path {
+			t.Errorf("ParseGitLabProject(%q) = %q, %q; want %q, %q", tt.in, host, path, tt.host, tt.path)
+		}
+	}
+}
+
+func TestGitLabPagesURL(t *testing.T) {
+	if got := GitLabPagesURL("gitlab.com", "MyGroup/issues"); got != "https://mygroup.gitlab.io/issues/" {
+		t.Errorf("GitLabPagesURL = %q", got)
+	}
+	if got := GitLabPagesURL("gitlab.example.com", "group/issues"); got != "" {
+		t.Errorf("self-managed host should have no known URL, got %q", got)
+	}
+}
+
+func TestGenerateGitLabCI(t *testing.T) {
+	dir := t.TempDir()
+	if err := GenerateGitLabCI(dir, "pages"); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, ".gitlab-ci.yml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	content := string(data)
+	for _, want := range []string{"pages:", "- public", `$CI_COMMIT_BRANCH == "pages"`} {
+		if !strings.Contains(content, want) {
+			t.Errorf(".gitlab-ci.yml missing %q:\n%s", want, content)
+		}
+	}
+}
+
+func TestDeployToGitLabPages_PushesBundleWithCI(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	remote := filepath.Join(t.TempDir(), "site.git")
+	if out, err := exec.Command("git", "init", "-q", "--bare", remote).CombinedOutput(); err != nil {
+		t.Fatalf("init bare repo: %v\n%s", err, out)
+	}
+
+	bundle := t.TempDir()
+	writePagesFiles(t, bundle, map[string]string{"index.html": "v1"})
+
+	result, err := DeployToGitLabPages(GitLabDeployConfig{
+		Project:    "group/issues",
+		BundlePath: bundle,
+		Token:      "glpat-test",
+		Remote:     remote,
+	})
+	if err != nil {
+		t.Fatalf("DeployToGitLabPages: %v", err)
+	}
+	if result.Commit == "" || result.PagesURL != "https://group.gitlab.io/issues/" {
+		t.Errorf("result = %+v", result)
+	}
+
+	out, err := exec.Command("git", "--git-dir", remote, "ls-tree", "--name-only", "main").Output()
+	if err != nil {
+		t.Fatalf("ls-tree main: %v", err)
+	}
+	for _, want := range []string{".gitlab-ci.yml", "index.html"} {
+		if !strings.Contains(string(out), want) {
+			t.Errorf("main branch missing %s:\n%s", want, out)
+		}
+	}
+}
+
+func TestDeployToGitLabPages_InvalidProject(t *testing.T) {
+	if _, err := DeployToGitLabPages(GitLabDeployConfig{Project: "issues", BundlePath: t.TempDir()}); err == nil {
+		t.Error("expected error for project without a group")
+	}
+}
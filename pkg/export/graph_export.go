@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"hash/fnv"
+	"net/url"
 	"sort"
 	"strings"
 
@@ -18,15 +19,22 @@ const (
 	GraphFormatJSON    GraphExportFormat = "json"
 	GraphFormatDOT     GraphExportFormat = "dot"
 	GraphFormatMermaid GraphExportFormat = "mermaid"
+	GraphFormatGraphML GraphExportFormat = "graphml"
+	GraphFormatGEXF    GraphExportFormat = "gexf"
 )
 
 // GraphExportConfig configures graph export behavior.
 type GraphExportConfig struct {
-	Format   GraphExportFormat // Output format (json, dot, mermaid)
+	Format   GraphExportFormat // Output format (json, dot, mermaid, graphml, gexf)
 	Label    string            // Filter to specific label
 	Root     string            // Subgraph from specific root
 	Depth    int               // Max depth for subgraph (0 = unlimited)
 	DataHash string            // Hash of input data for provenance
+
+	// Mermaid-only options
+	Cluster      string // Subgraph grouping: "label", "track", or "" for none
+	LinkTemplate string // Node click URL; "{id}" is replaced with the issue ID
+	MaxNodes     int    // Nodes per diagram before splitting (0 = default, <0 = never split)
 }
 
 // GraphExportResult contains the exported graph and metadata.
@@ -37,6 +45,7 @@ type GraphExportResult struct {
 	Edges          int               `json:"edges"`
 	FiltersApplied map[string]string `json:"filters_applied,omitempty"`
 	Explanation    GraphExplanation  `json:"explanation"`
+	Diagrams       []string          `json:"diagrams,omitempty"` // Mermaid output split into several diagrams
 	DataHash       string            `json:"data_hash,omitempty"`
 	Adjacency      *AdjacencyGraph   `json:"adjacency,omitempty"`
 }
@@ -135,13 +144,42 @@ func ExportGraph(issues []model.Issue, stats *analysis.GraphStats, config GraphE
 		}
 
 	case GraphFormatMermaid:
-		graph := generateMermaid(filteredIssues, issueIDs)
-		result.Graph = graph
+		diagrams := generateMermaid(filteredIssues, issueIDs, config)
+		result.Graph = strings.Join(diagrams, "\n")
 		result.Explanation = GraphExplanation{
 			What:        "Dependency graph in Mermaid diagram format",
 			HowToRender: "Paste into any Markdown renderer that supports Mermaid, or use mermaid.live",
 			WhenToUse:   "When you need an embeddable diagram for documentation or GitHub issues",
 		}
+		if len(diagrams) > 1 {
+			result.Diagrams = diagrams
+			result.Explanation.What = fmt.Sprintf("Dependency graph split into %d Mermaid diagrams to stay within renderer limits", len(diagrams))
+			result.Explanation.HowToRender = "Render each entry of diagrams separately; nodes marked 'see diagram N' link across diagrams"
+		}
+
+	case GraphFormatGraphML:
+		graph, err := generateGraphML(filteredIssues, issueIDs, stats)
+		if err != nil {
+			return nil, err
+		}
+		result.Graph = graph
+		result.Explanation = GraphExplanation{
+			What:        "Dependency graph in GraphML format with status, priority, pagerank and labels as node attributes",
+			HowToRender: "Save to file.graphml and open in yEd, Gephi, or Cytoscape",
+			WhenToUse:   "When you need to lay out, filter, or style a large graph in a desktop graph tool",
+		}
+
+	case GraphFormatGEXF:
+		graph, err := generateGEXF(filteredIssues, issueIDs, stats)
+		if err != nil {
+			return nil, err
+		}
+		result.Graph = graph
+		result.Explanation = GraphExplanation{
+			What:        "Dependency graph in GEXF 1.3 format with status, priority, pagerank and labels as node attributes",
+			HowToRender: "Save to file.gexf and open in Gephi (File > Open)",
+			WhenToUse:   "When you need large-scale visual analysis in Gephi (partition by status, size by pagerank)",
+		}
 
 	case GraphFormatJSON:
 		fallthrough
@@ -338,6 +376,8 @@ func dotStatusColor(status model.Status) string {
 		return "#C8E6C9" // Light green
 	case model.StatusInProgress:
 		return "#BBDEFB" // Light blue
+	case model.StatusInReview:
+		return "#E1BEE7" // Light purple
 	case model.StatusBlocked:
 		return "#FFCDD2" // Light red
 	case model.StatusClosed:
@@ -353,19 +393,38 @@ func sanitizeDOTID(id string) string {
 	return strings.ReplaceAll(id, "\"", "\\\"")
 }
 
-// generateMermaid creates a Mermaid diagram format graph.
-func generateMermaid(issues []model.Issue, issueIDs map[string]bool) string {
-	var sb strings.Builder
+// DefaultMermaidMaxNodes is the node count above which Mermaid output is split
+// into several diagrams. Mermaid's renderer slows down sharply and hits its
+// default maxTextSize well before a few hundred nodes.
+const DefaultMermaidMaxNodes = 100
 
-	sb.WriteString("graph TD\n")
+// Mermaid cluster modes for GraphExportConfig.Cluster.
+const (
+	MermaidClusterLabel = "label" // One subgraph per issue's first label
+	MermaidClusterTrack = "track" // One subgraph per connected work stream
+)
 
-	// Class definitions for styling
-	sb.WriteString("    classDef open fill:#50FA7B,stroke:#333,color:#000\n")
-	sb.WriteString("    classDef inprogress fill:#8BE9FD,stroke:#333,color:#000\n")
-	sb.WriteString("    classDef blocked fill:#FF5555,stroke:#333,color:#000\n")
-	sb.WriteString("    classDef closed fill:#6272A4,stroke:#333,color:#fff\n")
-	sb.WriteString("\n")
+// mermaidStatusClass maps a status to its Mermaid classDef name.
+func mermaidStatusClass(status model.Status) string {
+	switch status {
+	case model.StatusOpen:
+		return "open"
+	case model.StatusInProgress:
+		return "inprogress"
+	case model.StatusInReview:
+		return "inreview"
+	case model.StatusBlocked:
+		return "blocked"
+	case model.StatusClosed:
+		return "closed"
+	default:
+		return ""
+	}
+}
 
+// generateMermaid creates one or more Mermaid diagrams for the graph. More than
+// one diagram is returned only when the node count exceeds config's limit.
+func generateMermaid(issues []model.Issue, issueIDs map[string]bool, config GraphExportConfig) []string {
 	// Sort issues for deterministic output
 	sortedIssues := make([]model.Issue, len(issues))
 	copy(sortedIssues, issues)
@@ -397,69 +456,314 @@ func generateMermaid(issues []model.Issue, issueIDs map[string]bool) string {
 		return safe
 	}
 
-	// Pre-calculate all safe IDs to ensure consistency
+	// Pre-calculate all safe IDs so they are identical across split diagrams
 	for _, i := range sortedIssues {
 		getSafeID(i.ID)
 	}
 
-	// Nodes
-	for _, i := range sortedIssues {
-		safeID := getSafeID(i.ID)
-		safeTitle := sanitizeMermaidText(i.Title)
-		safeLabelID := sanitizeMermaidText(i.ID)
+	maxNodes := config.MaxNodes
+	if maxNodes == 0 {
+		maxNodes = DefaultMermaidMaxNodes
+	}
+	chunks := splitMermaidChunks(sortedIssues, issueIDs, maxNodes)
+	clusters := mermaidClusters(sortedIssues, issueIDs, config.Cluster)
+
+	// Remember which diagram each node lands in for cross-diagram stubs
+	chunkOf := make(map[string]int, len(sortedIssues))
+	for n, chunk := range chunks {
+		for _, i := range chunk {
+			chunkOf[i.ID] = n
+		}
+	}
 
-		sb.WriteString(fmt.Sprintf("    %s[\"%s<br/>%s\"]\n", safeID, safeLabelID, safeTitle))
+	diagrams := make([]string, 0, len(chunks))
+	for n, chunk := range chunks {
+		var sb strings.Builder
+		if len(chunks) > 1 {
+			sb.WriteString(fmt.Sprintf("%%%% diagram %d of %d\n", n+1, len(chunks)))
+		}
+		sb.WriteString("graph TD\n")
+
+		// Class definitions for styling
+		sb.WriteString("    classDef open fill:#50FA7B,stroke:#333,color:#000\n")
+		sb.WriteString("    classDef inprogress fill:#8BE9FD,stroke:#333,color:#000\n")
+		sb.WriteString("    classDef inreview fill:#BD93F9,stroke:#333,color:#000\n")
+		sb.WriteString("    classDef blocked fill:#FF5555,stroke:#333,color:#000\n")
+		sb.WriteString("    classDef closed fill:#6272A4,stroke:#333,color:#fff\n")
+		sb.WriteString("    classDef p0 stroke:#FF5555,stroke-width:4px\n")
+		sb.WriteString("    classDef p1 stroke:#FFB86C,stroke-width:3px\n")
+		if len(chunks) > 1 {
+			sb.WriteString("    classDef external fill:#fff,stroke:#999,stroke-dasharray:4 3,color:#666\n")
+		}
+		sb.WriteString("\n")
 
-		// Apply class based on status
-		var class string
-		switch i.Status {
-		case model.StatusOpen:
-			class = "open"
-		case model.StatusInProgress:
-			class = "inprogress"
-		case model.StatusBlocked:
-			class = "blocked"
-		case model.StatusClosed:
-			class = "closed"
+		writeNode := func(indent string, i model.Issue) {
+			sb.WriteString(fmt.Sprintf("%s%s[\"%s<br/>%s\"]\n", indent, getSafeID(i.ID),
+				sanitizeMermaidText(i.ID), sanitizeMermaidText(i.Title)))
 		}
-		sb.WriteString(fmt.Sprintf("    class %s %s\n", safeID, class))
+
+		// Nodes, grouped into subgraphs when clustering
+		if clusters == nil {
+			for _, i := range chunk {
+				writeNode("    ", i)
+			}
+		} else {
+			var loose []model.Issue
+			grouped := make(map[string][]model.Issue)
+			for _, i := range chunk {
+				key, ok := clusters.byIssue[i.ID]
+				if !ok {
+					loose = append(loose, i)
+					continue
+				}
+				grouped[key] = append(grouped[key], i)
+			}
+			for idx, key := range clusters.keys {
+				members := grouped[key]
+				if len(members) == 0 {
+					continue
+				}
+				sb.WriteString(fmt.Sprintf("    subgraph cluster_%d[\"%s\"]\n", idx+1, sanitizeMermaidText(clusters.titles[key])))
+				for _, i := range members {
+					writeNode("        ", i)
+				}
+				sb.WriteString("    end\n")
+			}
+			for _, i := range loose {
+				writeNode("    ", i)
+			}
+		}
+
+		// Status and priority styling; priority comes last so its stroke wins
+		for _, i := range chunk {
+			safeID := getSafeID(i.ID)
+			if class := mermaidStatusClass(i.Status); class != "" {
+				sb.WriteString(fmt.Sprintf("    class %s %s\n", safeID, class))
+			}
+			if i.Priority == 0 || i.Priority == 1 {
+				sb.WriteString(fmt.Sprintf("    class %s p%d\n", safeID, i.Priority))
+			}
+		}
+
+		// Clickable nodes
+		if config.LinkTemplate != "" {
+			for _, i := range chunk {
+				sb.WriteString(fmt.Sprintf("    click %s href \"%s\" _blank\n", getSafeID(i.ID), mermaidLink(config.LinkTemplate, i.ID)))
+			}
+		}
+
+		sb.WriteString("\n")
+
+		// Edges
+		stubs := make(map[string]bool)
+		for _, i := range chunk {
+			// Sort dependencies
+			deps := make([]*model.Dependency, len(i.Dependencies))
+			copy(deps, i.Dependencies)
+			sort.Slice(deps, func(a, b int) bool {
+				if deps[a] == nil {
+					return false
+				}
+				if deps[b] == nil {
+					return true
+				}
+				return deps[a].DependsOnID < deps[b].DependsOnID
+			})
+
+			for _, dep := range deps {
+				if dep == nil || !issueIDs[dep.DependsOnID] {
+					continue
+				}
+
+				safeFromID := getSafeID(i.ID)
+				safeToID := getSafeID(dep.DependsOnID)
+
+				// Targets in another diagram become stub nodes pointing there
+				if target := chunkOf[dep.DependsOnID]; target != n && !stubs[safeToID] {
+					stubs[safeToID] = true
+					sb.WriteString(fmt.Sprintf("    %s[\"%s<br/>see diagram %d\"]\n", safeToID, sanitizeMermaidText(dep.DependsOnID), target+1))
+					sb.WriteString(fmt.Sprintf("    class %s external\n", safeToID))
+				}
+
+				linkStyle := "-.->" // Dashed for related
+				if dep.Type == model.DepBlocks {
+					linkStyle = "==>" // Bold for blockers
+				}
+
+				sb.WriteString(fmt.Sprintf("    %s %s %s\n", safeFromID, linkStyle, safeToID))
+			}
+		}
+
+		diagrams = append(diagrams, sb.String())
 	}
 
-	sb.WriteString("\n")
+	return diagrams
+}
 
-	// Edges
-	for _, i := range sortedIssues {
-		// Sort dependencies
-		deps := make([]*model.Dependency, len(i.Dependencies))
-		copy(deps, i.Dependencies)
-		sort.Slice(deps, func(a, b int) bool {
-			if deps[a] == nil {
-				return false
+// mermaidLink expands a link template for an issue. "{id}" is replaced with
+// the URL-escaped issue ID; quotes are escaped so the click directive parses.
+func mermaidLink(template, id string) string {
+	link := strings.ReplaceAll(template, "{id}", url.PathEscape(id))
+	return strings.ReplaceAll(link, "\"", "%22")
+}
+
+// mermaidClusterSet assigns issues to named subgraphs.
+type mermaidClusterSet struct {
+	keys    []string          // Cluster keys in output order
+	titles  map[string]string // Cluster key -> subgraph title
+	byIssue map[string]string // Issue ID -> cluster key (absent = no subgraph)
+}
+
+// mermaidClusters groups issues by label or by connected work stream. It
+// returns nil when clustering is disabled.
+func mermaidClusters(issues []model.Issue, issueIDs map[string]bool, mode string) *mermaidClusterSet {
+	set := &mermaidClusterSet{
+		titles:  make(map[string]string),
+		byIssue: make(map[string]string),
+	}
+
+	switch strings.ToLower(mode) {
+	case MermaidClusterLabel:
+		for _, i := range issues {
+			if len(i.Labels) == 0 {
+				continue
 			}
-			if deps[b] == nil {
-				return true
+			labels := make([]string, len(i.Labels))
+			copy(labels, i.Labels)
+			sort.Strings(labels)
+			key := labels[0]
+			if _, seen := set.titles[key]; !seen {
+				set.keys = append(set.keys, key)
+				set.titles[key] = key
 			}
-			return deps[a].DependsOnID < deps[b].DependsOnID
-		})
+			set.byIssue[i.ID] = key
+		}
+		sort.Strings(set.keys)
+
+	case MermaidClusterTrack:
+		// Tracks are connected components over blocking edges, as in the
+		// execution plan; single-issue components stay outside any subgraph.
+		components := connectedComponents(issues, issueIDs)
+		for _, members := range components {
+			if len(members) < 2 {
+				continue
+			}
+			key := members[0]
+			set.keys = append(set.keys, key)
+			set.titles[key] = fmt.Sprintf("track %s (%d)", key, len(members))
+			for _, id := range members {
+				set.byIssue[id] = key
+			}
+		}
 
-		for _, dep := range deps {
-			if dep == nil || !issueIDs[dep.DependsOnID] {
+	default:
+		return nil
+	}
+
+	return set
+}
+
+// connectedComponents returns the weakly connected components of the blocking
+// graph, each sorted by ID, ordered by size (largest first) then first ID.
+func connectedComponents(issues []model.Issue, issueIDs map[string]bool) [][]string {
+	parent := make(map[string]string, len(issues))
+	var find func(x string) string
+	find = func(x string) string {
+		if parent[x] != x {
+			parent[x] = find(parent[x])
+		}
+		return parent[x]
+	}
+	for _, i := range issues {
+		parent[i.ID] = i.ID
+	}
+	for _, i := range issues {
+		for _, dep := range i.Dependencies {
+			if dep == nil || !dep.Type.IsBlocking() || !issueIDs[dep.DependsOnID] {
+				continue
+			}
+			if _, ok := parent[dep.DependsOnID]; !ok {
 				continue
 			}
+			pa, pb := find(i.ID), find(dep.DependsOnID)
+			if pa == pb {
+				continue
+			}
+			if pa < pb {
+				parent[pb] = pa
+			} else {
+				parent[pa] = pb
+			}
+		}
+	}
+
+	groups := make(map[string][]string)
+	for _, i := range issues {
+		root := find(i.ID)
+		groups[root] = append(groups[root], i.ID)
+	}
+	components := make([][]string, 0, len(groups))
+	for _, members := range groups {
+		sort.Strings(members)
+		components = append(components, members)
+	}
+	sort.Slice(components, func(a, b int) bool {
+		if len(components[a]) != len(components[b]) {
+			return len(components[a]) > len(components[b])
+		}
+		return components[a][0] < components[b][0]
+	})
+	return components
+}
 
-			safeFromID := getSafeID(i.ID)
-			safeToID := getSafeID(dep.DependsOnID)
+// splitMermaidChunks partitions issues into diagrams of at most maxNodes
+// nodes. Connected components are kept together where they fit, packed
+// first-fit largest first; oversized components are cut in ID order.
+func splitMermaidChunks(issues []model.Issue, issueIDs map[string]bool, maxNodes int) [][]model.Issue {
+	if maxNodes < 0 || len(issues) <= maxNodes {
+		return [][]model.Issue{issues}
+	}
 
-			linkStyle := "-.->" // Dashed for related
-			if dep.Type == model.DepBlocks {
-				linkStyle = "==>" // Bold for blockers
+	byID := make(map[string]model.Issue, len(issues))
+	for _, i := range issues {
+		byID[i.ID] = i
+	}
+
+	var chunks [][]model.Issue
+	for _, members := range connectedComponents(issues, issueIDs) {
+		for len(members) > maxNodes {
+			chunk := make([]model.Issue, 0, maxNodes)
+			for _, id := range members[:maxNodes] {
+				chunk = append(chunk, byID[id])
 			}
+			chunks = append(chunks, chunk)
+			members = members[maxNodes:]
+		}
 
-			sb.WriteString(fmt.Sprintf("    %s %s %s\n", safeFromID, linkStyle, safeToID))
+		placed := false
+		for c := range chunks {
+			if len(chunks[c])+len(members) <= maxNodes {
+				for _, id := range members {
+					chunks[c] = append(chunks[c], byID[id])
+				}
+				placed = true
+				break
+			}
+		}
+		if !placed {
+			chunk := make([]model.Issue, 0, len(members))
+			for _, id := range members {
+				chunk = append(chunk, byID[id])
+			}
+			chunks = append(chunks, chunk)
 		}
 	}
 
-	return sb.String()
+	// Keep node order within each diagram deterministic
+	for _, chunk := range chunks {
+		sort.Slice(chunk, func(a, b int) bool { return chunk[a].ID < chunk[b].ID })
+	}
+	return chunks
 }
 
 // generateAdjacency creates a JSON adjacency list representation.
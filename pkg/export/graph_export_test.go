@@ -2,6 +2,7 @@ package export
 
 import (
 	"encoding/json"
+	"encoding/xml"
 	"strings"
 	"testing"
 	"time"
@@ -164,6 +165,120 @@ func TestExportGraph_Mermaid(t *testing.T) {
 	}
 }
 
+func TestExportGraph_MermaidStylingAndLinks(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "bv-1", Title: "Urgent", Status: model.StatusInProgress, Priority: 0},
+		{ID: "bv-2", Title: "Review", Status: model.StatusInReview, Priority: 2},
+		{ID: "bv-3", Title: "Odd status", Status: model.Status("tombstone"), Priority: 3},
+	}
+
+	result, err := ExportGraph(issues, nil, GraphExportConfig{
+		Format:       GraphFormatMermaid,
+		LinkTemplate: `https://tracker.example.com/issues/{id}?q="x"`,
+	})
+	if err != nil {
+		t.Fatalf("ExportGraph failed: %v", err)
+	}
+
+	for _, want := range []string{
+		"class bv-1 inprogress\n    class bv-1 p0\n",
+		"class bv-2 inreview\n",
+		`click bv-1 href "https://tracker.example.com/issues/bv-1?q=%22x%22" _blank`,
+	} {
+		if !strings.Contains(result.Graph, want) {
+			t.Errorf("Mermaid output missing %q:\n%s", want, result.Graph)
+		}
+	}
+	if strings.Contains(result.Graph, "class bv-3") {
+		t.Error("Unknown statuses should not get an empty class directive")
+	}
+	if strings.Contains(result.Graph, "class bv-2 p") {
+		t.Error("Only P0/P1 should get priority styling")
+	}
+}
+
+func TestExportGraph_MermaidClusters(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "a-1", Title: "A1", Status: model.StatusOpen, Labels: []string{"ui", "api"}},
+		{ID: "a-2", Title: "A2", Status: model.StatusOpen, Labels: []string{"api"},
+			Dependencies: []*model.Dependency{{IssueID: "a-2", DependsOnID: "a-1", Type: model.DepBlocks}}},
+		{ID: "b-1", Title: "B1", Status: model.StatusOpen, Labels: []string{"ui"}},
+		{ID: "c-1", Title: "C1", Status: model.StatusOpen},
+	}
+
+	byLabel, err := ExportGraph(issues, nil, GraphExportConfig{Format: GraphFormatMermaid, Cluster: MermaidClusterLabel})
+	if err != nil {
+		t.Fatalf("ExportGraph failed: %v", err)
+	}
+	wantLabel := "    subgraph cluster_1[\"api\"]\n" +
+		"        a-1[\"a-1<br/>A1\"]\n" +
+		"        a-2[\"a-2<br/>A2\"]\n" +
+		"    end\n" +
+		"    subgraph cluster_2[\"ui\"]\n" +
+		"        b-1[\"b-1<br/>B1\"]\n" +
+		"    end\n" +
+		"    c-1[\"c-1<br/>C1\"]\n"
+	if !strings.Contains(byLabel.Graph, wantLabel) {
+		t.Errorf("Label clusters not as expected:\n%s", byLabel.Graph)
+	}
+
+	byTrack, err := ExportGraph(issues, nil, GraphExportConfig{Format: GraphFormatMermaid, Cluster: MermaidClusterTrack})
+	if err != nil {
+		t.Fatalf("ExportGraph failed: %v", err)
+	}
+	if !strings.Contains(byTrack.Graph, `subgraph cluster_1["track a-1 (2)"]`) {
+		t.Errorf("Expected a track cluster for a-1/a-2:\n%s", byTrack.Graph)
+	}
+	if strings.Count(byTrack.Graph, "subgraph") != 1 {
+		t.Errorf("Single-issue tracks should not get subgraphs:\n%s", byTrack.Graph)
+	}
+}
+
+func TestExportGraph_MermaidSplit(t *testing.T) {
+	// Two chains of three plus one chain of two, limit of four nodes
+	issues := []model.Issue{
+		{ID: "a-1", Status: model.StatusOpen},
+		{ID: "a-2", Status: model.StatusOpen, Dependencies: []*model.Dependency{{IssueID: "a-2", DependsOnID: "a-1", Type: model.DepBlocks}}},
+		{ID: "a-3", Status: model.StatusOpen, Dependencies: []*model.Dependency{{IssueID: "a-3", DependsOnID: "a-2", Type: model.DepBlocks}}},
+		{ID: "b-1", Status: model.StatusOpen},
+		{ID: "b-2", Status: model.StatusOpen, Dependencies: []*model.Dependency{{IssueID: "b-2", DependsOnID: "b-1", Type: model.DepBlocks}}},
+		{ID: "b-3", Status: model.StatusOpen, Dependencies: []*model.Dependency{
+			{IssueID: "b-3", DependsOnID: "b-2", Type: model.DepBlocks},
+			{IssueID: "b-3", DependsOnID: "a-1", Type: model.DepRelated},
+		}},
+		{ID: "c-1", Status: model.StatusOpen},
+	}
+
+	result, err := ExportGraph(issues, nil, GraphExportConfig{Format: GraphFormatMermaid, MaxNodes: 4})
+	if err != nil {
+		t.Fatalf("ExportGraph failed: %v", err)
+	}
+	if len(result.Diagrams) != 2 {
+		t.Fatalf("Expected 2 diagrams, got %d:\n%s", len(result.Diagrams), result.Graph)
+	}
+	if !strings.HasPrefix(result.Diagrams[0], "%% diagram 1 of 2\ngraph TD\n") {
+		t.Errorf("Diagram should start with its position:\n%s", result.Diagrams[0])
+	}
+	// The a chain plus the c singleton share the first diagram
+	for _, id := range []string{"a-1[", "a-2[", "a-3[", "c-1["} {
+		if !strings.Contains(result.Diagrams[0], id) {
+			t.Errorf("Diagram 1 missing %s:\n%s", id, result.Diagrams[0])
+		}
+	}
+	// The related edge to a-1 becomes a stub in the second diagram
+	if !strings.Contains(result.Diagrams[1], `a-1["a-1<br/>see diagram 1"]`) || !strings.Contains(result.Diagrams[1], "b-3 -.-> a-1") {
+		t.Errorf("Expected cross-diagram stub in diagram 2:\n%s", result.Diagrams[1])
+	}
+
+	unsplit, err := ExportGraph(issues, nil, GraphExportConfig{Format: GraphFormatMermaid, MaxNodes: -1})
+	if err != nil {
+		t.Fatalf("ExportGraph failed: %v", err)
+	}
+	if unsplit.Diagrams != nil || strings.Count(unsplit.Graph, "graph TD") != 1 {
+		t.Error("MaxNodes < 0 should never split")
+	}
+}
+
 func TestExportGraph_LabelFilter(t *testing.T) {
 	issues := []model.Issue{
 		{ID: "bv-1", Title: "API Issue", Status: model.StatusOpen, Labels: []string{"api"}},
@@ -310,3 +425,102 @@ func TestExportGraph_DeterministicOutput(t *testing.T) {
 		t.Error("DOT output should be deterministic across calls")
 	}
 }
+
+func TestExportGraph_GraphML(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "bv-1", Title: "First & <Issue>", Status: model.StatusOpen, Priority: 1, IssueType: model.TypeBug, Labels: []string{"api", "core"}},
+		{ID: "bv-2", Title: "Second Issue", Status: model.StatusClosed, Priority: 2,
+			Dependencies: []*model.Dependency{
+				{IssueID: "bv-2", DependsOnID: "bv-1", Type: model.DepBlocks},
+			},
+		},
+	}
+
+	analyzer := analysis.NewAnalyzer(issues)
+	stats := analyzer.Analyze()
+
+	result, err := ExportGraph(issues, &stats, GraphExportConfig{Format: GraphFormatGraphML})
+	if err != nil {
+		t.Fatalf("ExportGraph failed: %v", err)
+	}
+	if result.Format != "graphml" {
+		t.Errorf("Expected format 'graphml', got %s", result.Format)
+	}
+
+	var doc graphMLDoc
+	if err := xml.Unmarshal([]byte(result.Graph), &doc); err != nil {
+		t.Fatalf("GraphML is not valid XML: %v\n%s", err, result.Graph)
+	}
+	if doc.Graph.EdgeDefault != "directed" {
+		t.Errorf("Expected directed graph, got %q", doc.Graph.EdgeDefault)
+	}
+	if len(doc.Graph.Nodes) != 2 || len(doc.Graph.Edges) != 1 {
+		t.Fatalf("Expected 2 nodes and 1 edge, got %d and %d", len(doc.Graph.Nodes), len(doc.Graph.Edges))
+	}
+
+	data := make(map[string]string)
+	for _, d := range doc.Graph.Nodes[0].Data {
+		data[d.Key] = d.Value
+	}
+	if data["title"] != "First & <Issue>" || data["status"] != "open" || data["priority"] != "1" ||
+		data["issue_type"] != "bug" || data["labels"] != "api,core" {
+		t.Errorf("Unexpected node attributes: %v", data)
+	}
+	if data["pagerank"] == "" || data["pagerank"] == "0" {
+		t.Errorf("Expected pagerank attribute, got %q", data["pagerank"])
+	}
+
+	e := doc.Graph.Edges[0]
+	if e.Source != "bv-2" || e.Target != "bv-1" || e.Data[0].Value != "blocks" {
+		t.Errorf("Unexpected edge: %+v", e)
+	}
+}
+
+func TestExportGraph_GEXF(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "bv-1", Title: "First Issue", Status: model.StatusOpen, Priority: 1},
+		{ID: "bv-2", Title: "Second Issue", Status: model.StatusInProgress, Priority: 3, Labels: []string{"ui"},
+			Dependencies: []*model.Dependency{
+				{IssueID: "bv-2", DependsOnID: "bv-1", Type: model.DepRelated},
+			},
+		},
+	}
+
+	analyzer := analysis.NewAnalyzer(issues)
+	stats := analyzer.Analyze()
+
+	result, err := ExportGraph(issues, &stats, GraphExportConfig{Format: GraphFormatGEXF})
+	if err != nil {
+		t.Fatalf("ExportGraph failed: %v", err)
+	}
+	if result.Format != "gexf" {
+		t.Errorf("Expected format 'gexf', got %s", result.Format)
+	}
+	if !strings.HasPrefix(result.Graph, "<?xml") || !strings.Contains(result.Graph, `xmlns="http://gexf.net/1.3"`) {
+		t.Errorf("Expected GEXF 1.3 document header, got:\n%s", result.Graph)
+	}
+
+	var doc gexfDoc
+	if err := xml.Unmarshal([]byte(result.Graph), &doc); err != nil {
+		t.Fatalf("GEXF is not valid XML: %v", err)
+	}
+	if len(doc.Graph.Attributes) != 2 || doc.Graph.Attributes[0].Class != "node" {
+		t.Fatalf("Expected node and edge attribute declarations, got %+v", doc.Graph.Attributes)
+	}
+	if len(doc.Graph.Nodes) != 2 || len(doc.Graph.Edges) != 1 {
+		t.Fatalf("Expected 2 nodes and 1 edge, got %d and %d", len(doc.Graph.Nodes), len(doc.Graph.Edges))
+	}
+
+	n := doc.Graph.Nodes[1]
+	values := make(map[string]string)
+	for _, v := range n.AttValues {
+		values[v.For] = v.Value
+	}
+	if n.ID != "bv-2" || n.Label != "Second Issue" || values["status"] != "in_progress" ||
+		values["priority"] != "3" || values["labels"] != "ui" {
+		t.Errorf("Unexpected node: %+v", n)
+	}
+	if e := doc.Graph.Edges[0]; e.Source != "bv-2" || e.Target != "bv-1" || e.Label != "related" {
+		t.Errorf("Unexpected edge: %+v", e)
+	}
+}
@@ -0,0 +1,502 @@
+package export
+
+import (
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// Layered (Sugiyama-style) layout for static graph snapshots.
+//
+// Dependents sit above the issues they depend on, so every arrow points down
+// towards its blocker, matching `dot` with rankdir=TB. The classic four phases
+// are: break cycles by reversing DFS back edges, assign layers by longest path,
+// insert dummy vertices so edges only join adjacent layers, then order each
+// layer with barycenter sweeps before assigning coordinates.
+
+const (
+	layeredSweeps    = 12   // Barycenter down+up sweep pairs
+	layeredPositions = 8    // Coordinate refinement passes
+	layeredMaxCount  = 2000 // Skip exact crossing counts above this many segments
+)
+
+// layeredGraph is the working state of the layered layout.
+type layeredGraph struct {
+	ids    []string // Vertex index -> issue ID ("" for dummy vertices)
+	layer  []int
+	down   [][]int // Vertex -> vertices in the next layer
+	up     [][]int // Vertex -> vertices in the previous layer
+	layers [][]int // Layer -> vertices in order
+	pos    []int   // Vertex -> index within its layer
+	x      []float64
+}
+
+// layeredEdge is an original edge routed through dummy vertices. path runs
+// from the upper vertex to the lower one.
+type layeredEdge struct {
+	from, to string
+	path     []int
+	reversed bool // Drawn against layer order because it closed a cycle
+}
+
+func buildLayeredLayout(opts GraphSnapshotOptions) layoutResult {
+	const (
+		nodeWCompact    = 170.0
+		nodeHCompact    = 70.0
+		nodeWRoomy      = 190.0
+		nodeHRoomy      = 82.0
+		nodeGapCompact  = 36.0
+		nodeGapRoomy    = 56.0
+		layerGapCompact = 70.0
+		layerGapRoomy   = 100.0
+		padding         = 36.0
+		headerHeight    = 120.0
+	)
+
+	nodeW, nodeH := nodeWCompact, nodeHCompact
+	nodeGap, layerGap := nodeGapCompact, layerGapCompact
+	if strings.EqualFold(opts.Preset, "roomy") {
+		nodeW, nodeH = nodeWRoomy, nodeHRoomy
+		nodeGap, layerGap = nodeGapRoomy, layerGapRoomy
+	}
+	dummyGap := nodeGap / 2
+
+	issues := make([]model.Issue, len(opts.Issues))
+	copy(issues, opts.Issues)
+	sort.Slice(issues, func(i, j int) bool { return issues[i].ID < issues[j].ID })
+
+	g := &layeredGraph{}
+	index := make(map[string]int, len(issues))
+	for _, iss := range issues {
+		if _, dup := index[iss.ID]; dup {
+			continue
+		}
+		index[iss.ID] = len(g.ids)
+		g.ids = append(g.ids, iss.ID)
+	}
+	n := len(g.ids)
+
+	// Blocking edges only, as in the grid layout; From depends on To
+	var edges []layoutEdge
+	adj := make([][]int, n)
+	seen := make(map[[2]int]bool)
+	for _, iss := range issues {
+		for _, dep := range iss.Dependencies {
+			if dep == nil || dep.Type != model.DepBlocks {
+				continue
+			}
+			u, okU := index[iss.ID]
+			v, okV := index[dep.DependsOnID]
+			if !okU || !okV || u == v || seen[[2]int{u, v}] {
+				continue
+			}
+			seen[[2]int{u, v}] = true
+			adj[u] = append(adj[u], v)
+			edges = append(edges, layoutEdge{From: iss.ID, To: dep.DependsOnID})
+		}
+	}
+	for u := range adj {
+		sort.Ints(adj[u])
+	}
+
+	// Phase 1: reverse DFS back edges so the graph is acyclic
+	reversed := layeredBackEdges(adj)
+	dag := make([][]int, n)
+	for u, outs := range adj {
+		for _, v := range outs {
+			if reversed[[2]int{u, v}] {
+				dag[v] = append(dag[v], u)
+			} else {
+				dag[u] = append(dag[u], v)
+			}
+		}
+	}
+
+	// Phase 2: longest-path layering from the sources (nothing depends on them)
+	g.layer = layeredLongestPath(dag)
+
+	// Phase 3: dummy vertices for edges spanning more than one layer
+	g.down = make([][]int, n)
+	g.up = make([][]int, n)
+	var routed []layeredEdge
+	for _, e := range edges {
+		u, v := index[e.From], index[e.To]
+		isRev := reversed[[2]int{u, v}]
+		if isRev {
+			u, v = v, u
+		}
+		path := []int{u}
+		prev := u
+		for l := g.layer[u] + 1; l < g.layer[v]; l++ {
+			d := len(g.ids)
+			g.ids = append(g.ids, "")
+			g.layer = append(g.layer, l)
+			g.down = append(g.down, nil)
+			g.up = append(g.up, nil)
+			g.link(prev, d)
+			path = append(path, d)
+			prev = d
+		}
+		g.link(prev, v)
+		path = append(path, v)
+		routed = append(routed, layeredEdge{from: e.From, to: e.To, path: path, reversed: isRev})
+	}
+
+	// Phase 4: order vertices within layers, then assign coordinates
+	g.orderLayers()
+	g.assignX(func(v int) float64 {
+		if g.ids[v] == "" {
+			return 0
+		}
+		return nodeW
+	}, nodeGap, dummyGap)
+
+	pageRank := opts.Stats.PageRank()
+	layerY := func(l int) float64 { return padding + headerHeight + float64(l)*(nodeH+layerGap) }
+
+	minX := math.Inf(1)
+	maxX := math.Inf(-1)
+	for v := range g.ids {
+		w := 0.0
+		if g.ids[v] != "" {
+			w = nodeW
+		}
+		minX = math.Min(minX, g.x[v]-w/2)
+		maxX = math.Max(maxX, g.x[v]+w/2)
+	}
+	shift := padding - minX
+
+	titles := make(map[string]model.Issue, len(issues))
+	for _, iss := range issues {
+		titles[iss.ID] = iss
+	}
+	nodes := make([]layoutNode, 0, n)
+	for v := 0; v < n; v++ {
+		iss := titles[g.ids[v]]
+		nodes = append(nodes, layoutNode{
+			ID:       iss.ID,
+			Title:    truncate(iss.Title, 44),
+			Status:   iss.Status,
+			Level:    g.layer[v] + 1,
+			Rank:     pageRank[iss.ID],
+			X:        g.x[v] - nodeW/2 + shift,
+			Y:        layerY(g.layer[v]),
+			NodeW:    nodeW,
+			NodeH:    nodeH,
+			PageRank: pageRank[iss.ID],
+		})
+	}
+
+	edgesOut := make([]layoutEdge, 0, len(routed))
+	for _, r := range routed {
+		pts := make([]layoutPoint, 0, len(r.path)+1)
+		for i, v := range r.path {
+			x := g.x[v] + shift
+			y := layerY(g.layer[v])
+			switch {
+			case i == 0:
+				pts = append(pts, layoutPoint{x, y + nodeH})
+			case i == len(r.path)-1:
+				pts = append(pts, layoutPoint{x, y})
+			default:
+				// Dummies span the full node band so long edges run straight
+				pts = append(pts, layoutPoint{x, y}, layoutPoint{x, y + nodeH})
+			}
+		}
+		if r.reversed {
+			for i, j := 0, len(pts)-1; i < j; i, j = i+1, j-1 {
+				pts[i], pts[j] = pts[j], pts[i]
+			}
+		}
+		edgesOut = append(edgesOut, layoutEdge{From: r.from, To: r.to, Points: pts})
+	}
+
+	layers := 1
+	for _, l := range g.layer {
+		layers = max(layers, l+1)
+	}
+	width := max(int(maxX-minX+padding*2), 640)
+	height := max(int(layerY(layers-1)+nodeH+padding), 480)
+
+	title := opts.Title
+	if strings.TrimSpace(title) == "" {
+		title = "Graph Snapshot"
+	}
+
+	return layoutResult{
+		Nodes:  nodes,
+		Edges:  edgesOut,
+		Width:  width,
+		Height: height,
+		Header: headerHeight,
+		Summary: summaryInfo{
+			Title:         title,
+			DataHash:      opts.DataHash,
+			NodeCount:     len(nodes),
+			EdgeCount:     len(edgesOut),
+			TopBottleneck: topByMetric(opts.Stats.Betweenness()),
+		},
+	}
+}
+
+func (g *layeredGraph) link(u, v int) {
+	g.down[u] = append(g.down[u], v)
+	g.up[v] = append(g.up[v], u)
+}
+
+// layeredBackEdges returns the edges that close a cycle in a DFS visiting
+// vertices and successors in index order.
+func layeredBackEdges(adj [][]int) map[[2]int]bool {
+	const (
+		unvisited = iota
+		active
+		done
+	)
+	state := make([]int, len(adj))
+	back := make(map[[2]int]bool)
+
+	type frame struct{ v, next int }
+	for root := range adj {
+		if state[root] != unvisited {
+			continue
+		}
+		stack := []frame{{root, 0}}
+		state[root] = active
+		for len(stack) > 0 {
+			top := &stack[len(stack)-1]
+			if top.next == len(adj[top.v]) {
+				state[top.v] = done
+				stack = stack[:len(stack)-1]
+				continue
+			}
+			w := adj[top.v][top.next]
+			top.next++
+			switch state[w] {
+			case unvisited:
+				state[w] = active
+				stack = append(stack, frame{w, 0})
+			case active:
+				back[[2]int{top.v, w}] = true
+			}
+		}
+	}
+	return back
+}
+
+// layeredLongestPath layers an acyclic graph so every edge points to a
+// strictly lower layer and sources sit in layer 0.
+func layeredLongestPath(dag [][]int) []int {
+	indeg := make([]int, len(dag))
+	for _, outs := range dag {
+		for _, v := range outs {
+			indeg[v]++
+		}
+	}
+	var queue []int
+	for v, d := range indeg {
+		if d == 0 {
+			queue = append(queue, v)
+		}
+	}
+	layer := make([]int, len(dag))
+	for len(queue) > 0 {
+		u := queue[0]
+		queue = queue[1:]
+		for _, v := range dag[u] {
+			layer[v] = max(layer[v], layer[u]+1)
+			indeg[v]--
+			if indeg[v] == 0 {
+				queue = append(queue, v)
+			}
+		}
+	}
+	return layer
+}
+
+// orderLayers reduces edge crossings with alternating barycenter sweeps and
+// keeps the best ordering seen.
+func (g *layeredGraph) orderLayers() {
+	numLayers := 0
+	for _, l := range g.layer {
+		numLayers = max(numLayers, l+1)
+	}
+	g.layers = make([][]int, numLayers)
+
+	// Initial order: depth-first from the top layer keeps chains together
+	visited := make([]bool, len(g.ids))
+	var visit func(v int)
+	visit = func(v int) {
+		if visited[v] {
+			return
+		}
+		visited[v] = true
+		g.layers[g.layer[v]] = append(g.layers[g.layer[v]], v)
+		for _, w := range g.down[v] {
+			visit(w)
+		}
+	}
+	for v := range g.ids {
+		if len(g.up[v]) == 0 {
+			visit(v)
+		}
+	}
+	for v := range g.ids {
+		visit(v)
+	}
+	g.pos = make([]int, len(g.ids))
+	g.syncPos()
+
+	segments := 0
+	for _, d := range g.down {
+		segments += len(d)
+	}
+	countable := segments <= layeredMaxCount
+
+	best := g.snapshot()
+	bestCrossings := math.MaxInt
+	if countable {
+		bestCrossings = g.crossings()
+	}
+	for i := 0; i < layeredSweeps && bestCrossings > 0; i++ {
+		for l := 1; l < numLayers; l++ {
+			g.sortByBarycenter(l, g.up)
+		}
+		for l := numLayers - 2; l >= 0; l-- {
+			g.sortByBarycenter(l, g.down)
+		}
+		if !countable {
+			continue
+		}
+		if c := g.crossings(); c < bestCrossings {
+			bestCrossings = c
+			best = g.snapshot()
+		}
+	}
+	if countable {
+		g.layers = best
+		g.syncPos()
+	}
+}
+
+func (g *layeredGraph) syncPos() {
+	for _, vs := range g.layers {
+		for i, v := range vs {
+			g.pos[v] = i
+		}
+	}
+}
+
+func (g *layeredGraph) snapshot() [][]int {
+	out := make([][]int, len(g.layers))
+	for l, vs := range g.layers {
+		out[l] = append([]int(nil), vs...)
+	}
+	return out
+}
+
+// sortByBarycenter orders layer l by the mean position of each vertex's
+// neighbours; vertices without neighbours keep their current slot.
+func (g *layeredGraph) sortByBarycenter(l int, neighbours [][]int) {
+	vs := g.layers[l]
+	bary := make(map[int]float64, len(vs))
+	for _, v := range vs {
+		ns := neighbours[v]
+		if len(ns) == 0 {
+			bary[v] = float64(g.pos[v])
+			continue
+		}
+		sum := 0
+		for _, w := range ns {
+			sum += g.pos[w]
+		}
+		bary[v] = float64(sum) / float64(len(ns))
+	}
+	sort.SliceStable(vs, func(i, j int) bool { return bary[vs[i]] < bary[vs[j]] })
+	for i, v := range vs {
+		g.pos[v] = i
+	}
+}
+
+// crossings counts edge crossings between all adjacent layer pairs.
+func (g *layeredGraph) crossings() int {
+	total := 0
+	for _, vs := range g.layers {
+		var segs [][2]int
+		for _, v := range vs {
+			for _, w := range g.down[v] {
+				segs = append(segs, [2]int{g.pos[v], g.pos[w]})
+			}
+		}
+		for i := range segs {
+			for j := i + 1; j < len(segs); j++ {
+				a, b := segs[i], segs[j]
+				if (a[0] < b[0] && a[1] > b[1]) || (a[0] > b[0] && a[1] < b[1]) {
+					total++
+				}
+			}
+		}
+	}
+	return total
+}
+
+// assignX places vertex centres: each layer is packed left to right, then
+// repeatedly pulled towards the mean x of its neighbours while keeping order
+// and minimum spacing.
+func (g *layeredGraph) assignX(width func(v int) float64, nodeGap, dummyGap float64) {
+	g.x = make([]float64, len(g.ids))
+	sep := func(a, b int) float64 {
+		gap := nodeGap
+		if g.ids[a] == "" && g.ids[b] == "" {
+			gap = dummyGap
+		}
+		return (width(a)+width(b))/2 + gap
+	}
+
+	for _, vs := range g.layers {
+		for i, v := range vs {
+			if i > 0 {
+				g.x[v] = g.x[vs[i-1]] + sep(vs[i-1], v)
+			}
+		}
+	}
+
+	for pass := 0; pass < layeredPositions; pass++ {
+		for l := range g.layers {
+			neighbours := g.up
+			if pass%2 == 1 {
+				neighbours = g.down
+			}
+			vs := g.layers[l]
+			desired := make([]float64, len(vs))
+			for i, v := range vs {
+				desired[i] = g.x[v]
+				if ns := neighbours[v]; len(ns) > 0 {
+					sum := 0.0
+					for _, w := range ns {
+						sum += g.x[w]
+					}
+					desired[i] = sum / float64(len(ns))
+				}
+			}
+
+			// Enforce spacing left to right, then recentre on the desired mean
+			placed := make([]float64, len(vs))
+			for i := range vs {
+				placed[i] = desired[i]
+				if i > 0 {
+					placed[i] = math.Max(placed[i], placed[i-1]+sep(vs[i-1], vs[i]))
+				}
+			}
+			shift := 0.0
+			for i := range vs {
+				shift += desired[i] - placed[i]
+			}
+			shift /= float64(len(vs))
+			for i, v := range vs {
+				g.x[v] = placed[i] + shift
+			}
+		}
+	}
+}
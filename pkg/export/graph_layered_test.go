@@ -0,0 +1,152 @@
+package export
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/testutil"
+)
+
+func layeredFor(t *testing.T, issues []model.Issue) layoutResult {
+	t.Helper()
+	stats := analysis.NewAnalyzer(issues).Analyze()
+	return buildLayeredLayout(GraphSnapshotOptions{Issues: issues, Stats: &stats, DataHash: "test"})
+}
+
+func TestBuildLayeredLayout_EdgesPointDown(t *testing.T) {
+	for _, fixture := range []string{"chain_10", "star_10", "diamond_5", "complex_20"} {
+		t.Run(fixture, func(t *testing.T) {
+			issues := loadGraphFixture(t, fixture)
+			layout := layeredFor(t, issues)
+			if len(layout.Nodes) != len(issues) {
+				t.Fatalf("got %d nodes, want %d", len(layout.Nodes), len(issues))
+			}
+
+			byID := make(map[string]layoutNode, len(layout.Nodes))
+			for _, n := range layout.Nodes {
+				byID[n.ID] = n
+				if n.X < 0 || n.Y < layout.Header || n.X+n.NodeW > float64(layout.Width) || n.Y+n.NodeH > float64(layout.Height) {
+					t.Errorf("node %s at (%.0f,%.0f) outside %dx%d canvas", n.ID, n.X, n.Y, layout.Width, layout.Height)
+				}
+			}
+
+			// Acyclic fixtures: each dependent sits in a strictly higher layer
+			for _, e := range layout.Edges {
+				if byID[e.From].Level >= byID[e.To].Level {
+					t.Errorf("edge %s -> %s: level %d not above %d", e.From, e.To, byID[e.From].Level, byID[e.To].Level)
+				}
+				if len(e.Points) < 2 {
+					t.Fatalf("edge %s -> %s has no route", e.From, e.To)
+				}
+				first, last := e.Points[0], e.Points[len(e.Points)-1]
+				if first.Y != byID[e.From].Y+byID[e.From].NodeH || last.Y != byID[e.To].Y {
+					t.Errorf("edge %s -> %s should run from the bottom of %s to the top of %s", e.From, e.To, e.From, e.To)
+				}
+			}
+
+			// Nodes in the same layer never overlap
+			for i, a := range layout.Nodes {
+				for _, b := range layout.Nodes[i+1:] {
+					if a.Level == b.Level && a.X < b.X+b.NodeW && b.X < a.X+a.NodeW {
+						t.Errorf("nodes %s and %s overlap in layer %d", a.ID, b.ID, a.Level)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestBuildLayeredLayout_Cycle(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "A", Status: model.StatusOpen, Dependencies: []*model.Dependency{{DependsOnID: "B", Type: model.DepBlocks}}},
+		{ID: "B", Status: model.StatusOpen, Dependencies: []*model.Dependency{{DependsOnID: "C", Type: model.DepBlocks}}},
+		{ID: "C", Status: model.StatusOpen, Dependencies: []*model.Dependency{{DependsOnID: "A", Type: model.DepBlocks}}},
+	}
+	layout := layeredFor(t, issues)
+	if len(layout.Edges) != 3 {
+		t.Fatalf("got %d edges, want 3", len(layout.Edges))
+	}
+
+	byID := make(map[string]layoutNode)
+	for _, n := range layout.Nodes {
+		byID[n.ID] = n
+	}
+	// The back edge C -> A is drawn upwards and still ends at A
+	for _, e := range layout.Edges {
+		if e.From != "C" {
+			continue
+		}
+		last := e.Points[len(e.Points)-1]
+		if last.Y != byID["A"].Y+byID["A"].NodeH {
+			t.Errorf("back edge should end at the bottom of A, got y=%.0f", last.Y)
+		}
+	}
+}
+
+func TestLayeredGraph_RemovesCrossings(t *testing.T) {
+	// Two dependents whose blockers start out in crossing order
+	issues := []model.Issue{
+		{ID: "a", Status: model.StatusOpen, Dependencies: []*model.Dependency{{DependsOnID: "y", Type: model.DepBlocks}, {DependsOnID: "z", Type: model.DepBlocks}}},
+		{ID: "b", Status: model.StatusOpen, Dependencies: []*model.Dependency{{DependsOnID: "x", Type: model.DepBlocks}}},
+		{ID: "c", Status: model.StatusOpen, Dependencies: []*model.Dependency{{DependsOnID: "x", Type: model.DepBlocks}, {DependsOnID: "z", Type: model.DepBlocks}}},
+		{ID: "x", Status: model.StatusOpen},
+		{ID: "y", Status: model.StatusOpen},
+		{ID: "z", Status: model.StatusOpen},
+	}
+	layout := layeredFor(t, issues)
+
+	x := make(map[string]float64)
+	for _, n := range layout.Nodes {
+		x[n.ID] = n.X
+	}
+	crossings := 0
+	for i, e := range layout.Edges {
+		for _, f := range layout.Edges[i+1:] {
+			if (x[e.From]-x[f.From])*(x[e.To]-x[f.To]) < 0 {
+				crossings++
+			}
+		}
+	}
+	if crossings != 0 {
+		t.Errorf("expected a crossing-free ordering, got %d crossings", crossings)
+	}
+}
+
+func TestSaveGraphSnapshot_LayeredLayout(t *testing.T) {
+	issues := loadGraphFixture(t, "diamond_5")
+	stats := analysis.NewAnalyzer(issues).Analyze()
+	tmp := t.TempDir()
+
+	for _, name := range []string{"layered.svg", "layered.png"} {
+		if err := SaveGraphSnapshot(GraphSnapshotOptions{
+			Path:   filepath.Join(tmp, name),
+			Layout: "layered",
+			Issues: issues,
+			Stats:  &stats,
+		}); err != nil {
+			t.Fatalf("SaveGraphSnapshot(%s): %v", name, err)
+		}
+	}
+
+	err := SaveGraphSnapshot(GraphSnapshotOptions{Path: filepath.Join(tmp, "x.svg"), Layout: "radial", Issues: issues, Stats: &stats})
+	if err == nil || !strings.Contains(err.Error(), "unsupported layout") {
+		t.Errorf("expected unsupported layout error, got %v", err)
+	}
+}
+
+func TestGraphRender_GoldenLayeredSVG(t *testing.T) {
+	issues := loadGraphFixture(t, "complex_20")
+	stats := analysis.NewAnalyzer(issues).AnalyzeWithConfig(analysis.FullAnalysisConfig())
+
+	var sb strings.Builder
+	layout := buildLayeredLayout(GraphSnapshotOptions{Issues: issues, Stats: &stats, DataHash: "golden", Title: "golden"})
+	if err := renderSVGToWriter(&sb, layout); err != nil {
+		t.Fatalf("renderSVGToWriter: %v", err)
+	}
+
+	golden := testutil.NewGoldenFile(t, filepath.Join("..", "..", "testdata", "golden", "graph_render"), "complex_20.layered.svg.golden")
+	golden.Assert(sb.String())
+}
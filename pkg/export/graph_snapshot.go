@@ -24,6 +24,7 @@ type GraphSnapshotOptions struct {
 	Format   string               // "svg" or "png" (case-insensitive). If empty, inferred from Path.
 	Title    string               // Optional title rendered in summary block
 	Preset   string               // Layout preset: "compact" (default) or "roomy"
+	Layout   string               // "grid" (default, by critical-path depth) or "layered" (Sugiyama, top-down)
 	Issues   []model.Issue        // Issues to render (already filtered by recipe/workspace)
 	Stats    *analysis.GraphStats // Graph analysis used for layout/summary
 	DataHash string               // Hash of input issues for provenance
@@ -65,7 +66,15 @@ func SaveGraphSnapshot(opts GraphSnapshotOptions) error {
 		return fmt.Errorf("create parent dir: %w", err)
 	}
 
-	layout := buildLayout(opts)
+	var layout layoutResult
+	switch strings.ToLower(opts.Layout) {
+	case "", "grid":
+		layout = buildLayout(opts)
+	case "layered":
+		layout = buildLayeredLayout(opts)
+	default:
+		return fmt.Errorf("unsupported layout %q (want grid or layered)", opts.Layout)
+	}
 
 	switch format {
 	case "svg":
@@ -92,8 +101,13 @@ type layoutNode struct {
 }
 
 type layoutEdge struct {
-	From string
-	To   string
+	From   string
+	To     string
+	Points []layoutPoint // Polyline ending at To; empty means a straight side-to-side line
+}
+
+type layoutPoint struct {
+	X, Y float64
 }
 
 type layoutResult struct {
@@ -292,7 +306,7 @@ func statusColor(s model.Status) color.RGBA {
 		return colorOpen
 	case model.StatusBlocked:
 		return colorBlocked
-	case model.StatusInProgress:
+	case model.StatusInProgress, model.StatusInReview:
 		return colorInProg
 	case model.StatusClosed:
 		return colorClosed
@@ -324,12 +338,30 @@ func renderPNG(opts GraphSnapshotOptions, layout layoutResult) error {
 	dc.SetColor(colorEdge)
 	dc.SetLineWidth(2)
 	for _, e := range layout.Edges {
+		if len(e.Points) >= 2 {
+			dc.SetColor(colorEdge)
+			dc.MoveTo(e.Points[0].X, e.Points[0].Y)
+			for _, p := range e.Points[1:] {
+				dc.LineTo(p.X, p.Y)
+			}
+			dc.Stroke()
+			xs, ys := arrowHead(e.Points[len(e.Points)-2], e.Points[len(e.Points)-1])
+			dc.SetColor(colorEdgeArrow)
+			dc.NewSubPath()
+			dc.MoveTo(xs[0], ys[0])
+			dc.LineTo(xs[1], ys[1])
+			dc.LineTo(xs[2], ys[2])
+			dc.ClosePath()
+			dc.Fill()
+			continue
+		}
 		from := nodePos[e.From]
 		to := nodePos[e.To]
 		x1 := from.X + from.NodeW
 		y1 := from.Y + from.NodeH/2
 		x2 := to.X
 		y2 := to.Y + to.NodeH/2
+		dc.SetColor(colorEdge)
 		dc.DrawLine(x1, y1, x2, y2)
 		dc.Stroke()
 		drawArrow(dc, x2, y2, -8, 0)
@@ -368,6 +400,21 @@ func renderSVGToWriter(w io.Writer, layout layoutResult) error {
 	}
 
 	for _, e := range layout.Edges {
+		if len(e.Points) >= 2 {
+			xs := make([]int, len(e.Points))
+			ys := make([]int, len(e.Points))
+			for i, p := range e.Points {
+				xs[i], ys[i] = int(p.X), int(p.Y)
+			}
+			canvas.Polyline(xs, ys, fmt.Sprintf("fill:none;stroke:%s;stroke-width:2", css(colorEdge)))
+			ax, ay := arrowHead(e.Points[len(e.Points)-2], e.Points[len(e.Points)-1])
+			canvas.Polygon(
+				[]int{int(ax[0]), int(ax[1]), int(ax[2])},
+				[]int{int(ay[0]), int(ay[1]), int(ay[2])},
+				fmt.Sprintf("fill:%s", css(colorEdgeArrow)),
+			)
+			continue
+		}
 		from := nodePos[e.From]
 		to := nodePos[e.To]
 		x1 := int(from.X + from.NodeW)
@@ -424,6 +471,18 @@ func drawArrow(dc *gg.Context, x, y, dx, dy float64) {
 	dc.Fill()
 }
 
+// arrowHead returns the triangle for an arrow arriving at tip from prev.
+func arrowHead(prev, tip layoutPoint) (xs, ys []float64) {
+	dx, dy := tip.X-prev.X, tip.Y-prev.Y
+	length := math.Hypot(dx, dy)
+	if length == 0 {
+		dx, dy, length = 0, 1, 1
+	}
+	ux, uy := dx/length, dy/length
+	bx, by := tip.X-ux*8, tip.Y-uy*8
+	return []float64{tip.X, bx - uy*4, bx + uy*4}, []float64{tip.Y, by + ux*4, by - ux*4}
+}
+
 func drawSummaryBlock(dc *gg.Context, layout layoutResult) {
 	dc.SetColor(colorText)
 	dc.DrawStringAnchored(layout.Summary.Title, 32, 44, 0, 0.5)
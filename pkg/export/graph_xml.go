@@ -0,0 +1,230 @@
+package export
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// GraphML and GEXF are XML interchange formats understood by Gephi, yEd,
+// Cytoscape and NetworkX. Both writers carry the same node attributes as the
+// JSON adjacency export so large graphs can be filtered and styled in those
+// tools instead of in the terminal.
+
+// xmlNodeAttrs lists the node attributes shared by both XML formats, in order.
+var xmlNodeAttrs = []struct {
+	id, name, graphMLType, gexfType string
+}{
+	{"title", "title", "string", "string"},
+	{"status", "status", "string", "string"},
+	{"priority", "priority", "int", "integer"},
+	{"issue_type", "issue_type", "string", "string"},
+	{"pagerank", "pagerank", "double", "double"},
+	{"labels", "labels", "string", "string"},
+}
+
+// xmlNodeValues returns the attribute values for a node keyed by attribute id.
+func xmlNodeValues(n AdjacencyNode, issueType string) map[string]string {
+	return map[string]string{
+		"title":      n.Title,
+		"status":     n.Status,
+		"priority":   strconv.Itoa(n.Priority),
+		"issue_type": issueType,
+		"pagerank":   strconv.FormatFloat(n.PageRank, 'g', -1, 64),
+		"labels":     strings.Join(n.Labels, ","),
+	}
+}
+
+// issueTypes maps issue IDs to their type for attribute output.
+func issueTypes(issues []model.Issue) map[string]string {
+	types := make(map[string]string, len(issues))
+	for _, i := range issues {
+		types[i.ID] = string(i.IssueType)
+	}
+	return types
+}
+
+type graphMLDoc struct {
+	XMLName xml.Name     `xml:"graphml"`
+	XMLNS   string       `xml:"xmlns,attr"`
+	Keys    []graphMLKey `xml:"key"`
+	Graph   graphMLGraph `xml:"graph"`
+}
+
+type graphMLKey struct {
+	ID       string `xml:"id,attr"`
+	For      string `xml:"for,attr"`
+	AttrName string `xml:"attr.name,attr"`
+	AttrType string `xml:"attr.type,attr"`
+}
+
+type graphMLGraph struct {
+	ID          string        `xml:"id,attr"`
+	EdgeDefault string        `xml:"edgedefault,attr"`
+	Nodes       []graphMLNode `xml:"node"`
+	Edges       []graphMLEdge `xml:"edge"`
+}
+
+type graphMLNode struct {
+	ID   string        `xml:"id,attr"`
+	Data []graphMLData `xml:"data"`
+}
+
+type graphMLEdge struct {
+	ID     string        `xml:"id,attr"`
+	Source string        `xml:"source,attr"`
+	Target string        `xml:"target,attr"`
+	Data   []graphMLData `xml:"data"`
+}
+
+type graphMLData struct {
+	Key   string `xml:"key,attr"`
+	Value string `xml:",chardata"`
+}
+
+// generateGraphML creates a GraphML document for the dependency graph.
+func generateGraphML(issues []model.Issue, issueIDs map[string]bool, stats *analysis.GraphStats) (string, error) {
+	adj := generateAdjacency(issues, issueIDs, stats)
+	types := issueTypes(issues)
+
+	doc := graphMLDoc{
+		XMLNS: "http://graphml.graphdrawing.org/xmlns",
+		Graph: graphMLGraph{ID: "beads", EdgeDefault: "directed"},
+	}
+	for _, a := range xmlNodeAttrs {
+		doc.Keys = append(doc.Keys, graphMLKey{ID: a.id, For: "node", AttrName: a.name, AttrType: a.graphMLType})
+	}
+	doc.Keys = append(doc.Keys, graphMLKey{ID: "dep_type", For: "edge", AttrName: "type", AttrType: "string"})
+
+	for _, n := range adj.Nodes {
+		values := xmlNodeValues(n, types[n.ID])
+		node := graphMLNode{ID: n.ID}
+		for _, a := range xmlNodeAttrs {
+			node.Data = append(node.Data, graphMLData{Key: a.id, Value: values[a.id]})
+		}
+		doc.Graph.Nodes = append(doc.Graph.Nodes, node)
+	}
+	for idx, e := range adj.Edges {
+		doc.Graph.Edges = append(doc.Graph.Edges, graphMLEdge{
+			ID:     fmt.Sprintf("e%d", idx),
+			Source: e.From,
+			Target: e.To,
+			Data:   []graphMLData{{Key: "dep_type", Value: e.Type}},
+		})
+	}
+
+	return marshalXMLDoc(doc)
+}
+
+type gexfDoc struct {
+	XMLName xml.Name  `xml:"gexf"`
+	XMLNS   string    `xml:"xmlns,attr"`
+	Version string    `xml:"version,attr"`
+	Meta    gexfMeta  `xml:"meta"`
+	Graph   gexfGraph `xml:"graph"`
+}
+
+type gexfMeta struct {
+	Creator     string `xml:"creator"`
+	Description string `xml:"description"`
+}
+
+type gexfGraph struct {
+	DefaultEdgeType string           `xml:"defaultedgetype,attr"`
+	Mode            string           `xml:"mode,attr"`
+	Attributes      []gexfAttributes `xml:"attributes"`
+	Nodes           []gexfNode       `xml:"nodes>node"`
+	Edges           []gexfEdge       `xml:"edges>edge"`
+}
+
+type gexfAttributes struct {
+	Class     string          `xml:"class,attr"`
+	Attribute []gexfAttribute `xml:"attribute"`
+}
+
+type gexfAttribute struct {
+	ID    string `xml:"id,attr"`
+	Title string `xml:"title,attr"`
+	Type  string `xml:"type,attr"`
+}
+
+type gexfNode struct {
+	ID        string         `xml:"id,attr"`
+	Label     string         `xml:"label,attr"`
+	AttValues []gexfAttValue `xml:"attvalues>attvalue"`
+}
+
+type gexfEdge struct {
+	ID        string         `xml:"id,attr"`
+	Source    string         `xml:"source,attr"`
+	Target    string         `xml:"target,attr"`
+	Label     string         `xml:"label,attr"`
+	AttValues []gexfAttValue `xml:"attvalues>attvalue"`
+}
+
+type gexfAttValue struct {
+	For   string `xml:"for,attr"`
+	Value string `xml:"value,attr"`
+}
+
+// generateGEXF creates a GEXF 1.3 document for the dependency graph.
+func generateGEXF(issues []model.Issue, issueIDs map[string]bool, stats *analysis.GraphStats) (string, error) {
+	adj := generateAdjacency(issues, issueIDs, stats)
+	types := issueTypes(issues)
+
+	nodeAttrs := gexfAttributes{Class: "node"}
+	for _, a := range xmlNodeAttrs {
+		nodeAttrs.Attribute = append(nodeAttrs.Attribute, gexfAttribute{ID: a.id, Title: a.name, Type: a.gexfType})
+	}
+	edgeAttrs := gexfAttributes{
+		Class:     "edge",
+		Attribute: []gexfAttribute{{ID: "dep_type", Title: "type", Type: "string"}},
+	}
+
+	doc := gexfDoc{
+		XMLNS:   "http://gexf.net/1.3",
+		Version: "1.3",
+		Meta: gexfMeta{
+			Creator:     "bv",
+			Description: "Beads dependency graph (edge source depends on target)",
+		},
+		Graph: gexfGraph{
+			DefaultEdgeType: "directed",
+			Mode:            "static",
+			Attributes:      []gexfAttributes{nodeAttrs, edgeAttrs},
+		},
+	}
+
+	for _, n := range adj.Nodes {
+		values := xmlNodeValues(n, types[n.ID])
+		node := gexfNode{ID: n.ID, Label: n.Title}
+		for _, a := range xmlNodeAttrs {
+			node.AttValues = append(node.AttValues, gexfAttValue{For: a.id, Value: values[a.id]})
+		}
+		doc.Graph.Nodes = append(doc.Graph.Nodes, node)
+	}
+	for idx, e := range adj.Edges {
+		doc.Graph.Edges = append(doc.Graph.Edges, gexfEdge{
+			ID:        strconv.Itoa(idx),
+			Source:    e.From,
+			Target:    e.To,
+			Label:     e.Type,
+			AttValues: []gexfAttValue{{For: "dep_type", Value: e.Type}},
+		})
+	}
+
+	return marshalXMLDoc(doc)
+}
+
+// marshalXMLDoc renders an indented XML document with its declaration.
+func marshalXMLDoc(doc any) (string, error) {
+	out, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("marshal xml: %w", err)
+	}
+	return xml.Header + string(out) + "\n", nil
+}
@@ -0,0 +1,256 @@
+package export
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// icsUIDDomain makes event UIDs globally unique, scoped by project (see
+// icsDomain). UIDs only depend on the project and the issue or sprint ID, so
+// re-importing a regenerated file updates events in place instead of
+// duplicating them.
+const icsUIDDomain = "beads-viewer"
+
+// ICSExportOptions configures the calendar export.
+type ICSExportOptions struct {
+	AnnotationOptions
+	// Rows are the issues to add forecast events for. Nil means every
+	// issue. Forecasts always use the full issue set.
+	Rows []model.Issue
+	// Sprints get an event spanning their dates and, while they have open
+	// issues, a forecast completion event.
+	Sprints []model.Sprint
+	// CalendarName is shown by calendar apps that support X-WR-CALNAME.
+	CalendarName string
+	// Project scopes event UIDs, so calendars of projects with overlapping
+	// issue IDs can be imported side by side.
+	Project string
+}
+
+// ICSEvent is one all-day calendar event.
+type ICSEvent struct {
+	UID         string
+	Summary     string
+	Description string
+	Start       time.Time // First day
+	End         time.Time // Day after the last day
+	Categories  []string
+}
+
+// BuildICSEvents returns the calendar events for the export: one per sprint
+// span, one per sprint forecast completion and one per open issue's forecast
+// completion. Closed issues and sprints without dates have no events.
+func BuildICSEvents(issues []model.Issue, opts ICSExportOptions) []ICSEvent {
+	rows := opts.Rows
+	if rows == nil {
+		rows = issues
+	}
+	annotations := ComputeIssueAnnotations(issues, opts.AnnotationOptions)
+	domain := icsDomain(opts.Project)
+	byID := make(map[string]model.Issue, len(issues))
+	for _, issue := range issues {
+		byID[issue.ID] = issue
+	}
+
+	sprints := append([]model.Sprint(nil), opts.Sprints...)
+	sort.SliceStable(sprints, func(i, j int) bool {
+		if !sprints[i].StartDate.Equal(sprints[j].StartDate) {
+			return sprints[i].StartDate.Before(sprints[j].StartDate)
+		}
+		return sprints[i].ID < sprints[j].ID
+	})
+
+	var events []ICSEvent
+	for _, sprint := range sprints {
+		if !sprint.StartDate.IsZero() && !sprint.EndDate.IsZero() {
+			events = append(events, ICSEvent{
+				UID:         fmt.Sprintf("sprint-%s@%s", sprint.ID, domain),
+				Summary:     "Sprint: " + sprint.Name,
+				Description: fmt.Sprintf("Sprint %s, %d issues", sprint.ID, len(sprint.BeadIDs)),
+				Start:       icsDay(sprint.StartDate),
+				End:         icsDay(sprint.EndDate).AddDate(0, 0, 1),
+				Categories:  []string{"sprint"},
+			})
+		}
+
+		// The sprint is done when its last open issue is
+		var last time.Time
+		open := 0
+		for _, id := range sprint.BeadIDs {
+			issue, ok := byID[id]
+			if !ok || issue.Status.IsClosed() {
+				continue
+			}
+			open++
+			if eta := annotations[id].Forecast; eta != nil && eta.ETADate.After(last) {
+				last = eta.ETADate
+			}
+		}
+		if open == 0 || last.IsZero() {
+			continue
+		}
+		desc := fmt.Sprintf("Forecast completion of sprint %s: %d open issues", sprint.ID, open)
+		if !sprint.EndDate.IsZero() {
+			if slip := int(icsDay(last).Sub(icsDay(sprint.EndDate)).Hours() / 24); slip > 0 {
+				desc += fmt.Sprintf(", %d days after its %s end", slip, sprint.EndDate.Format("2006-01-02"))
+			} else {
+				desc += fmt.Sprintf(", on track for its %s end", sprint.EndDate.Format("2006-01-02"))
+			}
+		}
+		events = append(events, ICSEvent{
+			UID:         fmt.Sprintf("sprint-%s-forecast@%s", sprint.ID, domain),
+			Summary:     fmt.Sprintf("%s done (forecast)", sprint.Name),
+			Description: desc,
+			Start:       icsDay(last),
+			End:         icsDay(last).AddDate(0, 0, 1),
+			Categories:  []string{"sprint", "forecast"},
+		})
+	}
+
+	for _, issue := range rows {
+		eta := annotations[issue.ID].Forecast
+		if issue.Status.IsClosed() || eta == nil {
+			continue
+		}
+		lines := []string{
+			fmt.Sprintf("Forecast completion of %s (P%d %s, %s)", issue.ID, issue.Priority, issue.IssueType, issue.Status),
+			fmt.Sprintf("%.1f days of work left, confidence %.0f%%", eta.EstimatedDays, eta.Confidence*100),
+		}
+		if !eta.ETADateLow.IsZero() && !eta.ETADateHigh.IsZero() {
+			lines = append(lines, fmt.Sprintf("Range: %s to %s", eta.ETADateLow.Format("2006-01-02"), eta.ETADateHigh.Format("2006-01-02")))
+		}
+		if issue.Assignee != "" {
+			lines = append(lines, "Assignee: "+issue.Assignee)
+		}
+		if issue.DueDate != nil && !issue.DueDate.IsZero() {
+			due := "Due: " + issue.DueDate.Format("2006-01-02")
+			if icsDay(eta.ETADate).After(icsDay(*issue.DueDate)) {
+				due += " (forecast late)"
+			}
+			lines = append(lines, due)
+		}
+		events = append(events, ICSEvent{
+			UID:         fmt.Sprintf("issue-%s@%s", issue.ID, domain),
+			Summary:     fmt.Sprintf("%s: %s (forecast)", issue.ID, issue.Title),
+			Description: strings.Join(lines, "\n"),
+			Start:       icsDay(eta.ETADate),
+			End:         icsDay(eta.ETADate).AddDate(0, 0, 1),
+			Categories:  append([]string{"forecast"}, issue.Labels...),
+		})
+	}
+	return events
+}
+
+// WriteIssuesICS writes the export as an iCalendar (RFC 5545) file.
+func WriteIssuesICS(w io.Writer, issues []model.Issue, opts ICSExportOptions) (int, error) {
+	now := opts.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+	opts.Now = now
+	events := BuildICSEvents(issues, opts)
+	stamp := now.UTC().Format("20060102T150405Z")
+
+	bw := bufio.NewWriter(w)
+	line := func(s string) {
+		bw.WriteString(icsFold(s))
+		bw.WriteString("\r\n")
+	}
+	line("BEGIN:VCALENDAR")
+	line("VERSION:2.0")
+	line("PRODID:-//beads_viewer//bv//EN")
+	line("CALSCALE:GREGORIAN")
+	line("METHOD:PUBLISH")
+	if opts.CalendarName != "" {
+		line("X-WR-CALNAME:" + icsEscape(opts.CalendarName))
+	}
+	for _, e := range events {
+		line("BEGIN:VEVENT")
+		line("UID:" + e.UID)
+		line("DTSTAMP:" + stamp)
+		line("DTSTART;VALUE=DATE:" + e.Start.Format("20060102"))
+		line("DTEND;VALUE=DATE:" + e.End.Format("20060102"))
+		line("SUMMARY:" + icsEscape(e.Summary))
+		if e.Description != "" {
+			line("DESCRIPTION:" + icsEscape(e.Description))
+		}
+		if len(e.Categories) > 0 {
+			escaped := make([]string, len(e.Categories))
+			for i, c := range e.Categories {
+				escaped[i] = icsEscape(c)
+			}
+			line("CATEGORIES:" + strings.Join(escaped, ","))
+		}
+		line("TRANSP:TRANSPARENT") // Forecasts don't make anyone busy
+		line("END:VEVENT")
+	}
+	line("END:VCALENDAR")
+	if err := bw.Flush(); err != nil {
+		return 0, fmt.Errorf("write ics: %w", err)
+	}
+	return len(events), nil
+}
+
+// SaveIssuesICS writes the calendar export to filename and returns the
+// number of events.
+func SaveIssuesICS(issues []model.Issue, filename string, opts ICSExportOptions) (int, error) {
+	f, err := os.Create(filename)
+	if err != nil {
+		return 0, fmt.Errorf("create ics: %w", err)
+	}
+	n, err := WriteIssuesICS(f, issues, opts)
+	if err != nil {
+		f.Close()
+		return 0, err
+	}
+	return n, f.Close()
+}
+
+// icsDomain is the UID domain for a project: <project>.beads-viewer
+func icsDomain(project string) string {
+	if slug := createSlug(project); slug != "" {
+		return slug + "." + icsUIDDomain
+	}
+	return icsUIDDomain
+}
+
+// icsDay truncates t to its calendar day in its own location.
+func icsDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// icsEscape escapes a TEXT property value.
+func icsEscape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`).Replace(s)
+}
+
+// icsFold splits a content line into 75-octet lines, never inside a UTF-8
+// sequence; continuation lines start with a space.
+func icsFold(s string) string {
+	const limit = 75
+	if len(s) <= limit {
+		return s
+	}
+	var b strings.Builder
+	width := limit
+	for len(s) > width {
+		cut := width
+		for cut > 0 && !utf8.RuneStart(s[cut]) {
+			cut--
+		}
+		b.WriteString(s[:cut])
+		b.WriteString("\r\n ")
+		s = s[cut:]
+		width = limit - 1 // The leading space counts
+	}
+	b.WriteString(s)
+	return b.String()
+}
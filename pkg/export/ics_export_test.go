@@ -0,0 +1,103 @@
+package export
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestWriteIssuesICS(t *testing.T) {
+	now := time.Date(2026, 10, 15, 9, 0, 0, 0, time.UTC)
+	minutes := func(n int) *int { return &n }
+	due := time.Date(2026, 10, 15, 0, 0, 0, 0, time.UTC)
+	issues := []model.Issue{
+		{ID: "bv-1", Title: "Parser, lexer; and tests", Status: model.StatusOpen, Priority: 1, IssueType: model.TypeTask,
+			EstimatedMinutes: minutes(960), DueDate: &due, Labels: []string{"api"}},
+		{ID: "bv-2", Title: "Docs", Status: model.StatusInProgress, Priority: 2, IssueType: model.TypeTask, EstimatedMinutes: minutes(240)},
+		{ID: "bv-3", Title: "Done", Status: model.StatusClosed, Priority: 2, IssueType: model.TypeTask},
+	}
+	sprints := []model.Sprint{
+		{ID: "s-1", Name: "Sprint 1", StartDate: now.AddDate(0, 0, -3), EndDate: now.AddDate(0, 0, 4), BeadIDs: []string{"bv-1", "bv-3"}},
+		{ID: "s-0", Name: "Sprint 0", StartDate: now.AddDate(0, 0, -20), EndDate: now.AddDate(0, 0, -6), BeadIDs: []string{"bv-3"}},
+	}
+	opts := ICSExportOptions{AnnotationOptions: AnnotationOptions{Now: now}, Sprints: sprints, CalendarName: "app forecast", Project: "My App"}
+
+	var buf bytes.Buffer
+	n, err := WriteIssuesICS(&buf, issues, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	unfolded := strings.ReplaceAll(out, "\r\n ", "")
+
+	// Sprint spans, the open sprint's forecast and two open issues
+	if n != 5 || strings.Count(out, "BEGIN:VEVENT") != 5 {
+		t.Fatalf("events = %d:\n%s", n, out)
+	}
+	for _, want := range []string{
+		"BEGIN:VCALENDAR\r\nVERSION:2.0\r\n",
+		"X-WR-CALNAME:app forecast\r\n",
+		"UID:sprint-s-0@my-app.beads-viewer\r\n",
+		"UID:sprint-s-1@my-app.beads-viewer\r\nDTSTAMP:20261015T090000Z\r\nDTSTART;VALUE=DATE:20261012\r\nDTEND;VALUE=DATE:20261020\r\n",
+		"UID:sprint-s-1-forecast@my-app.beads-viewer\r\n",
+		"UID:issue-bv-1@my-app.beads-viewer\r\n",
+		`SUMMARY:bv-1: Parser\, lexer\; and tests (forecast)`,
+		`(forecast late)`,
+		"CATEGORIES:forecast,api\r\n",
+		"UID:issue-bv-2@my-app.beads-viewer\r\n",
+		"END:VCALENDAR\r\n",
+	} {
+		if !strings.Contains(unfolded, want) {
+			t.Errorf("ics missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "issue-bv-3") || strings.Contains(out, "sprint-s-0-forecast") {
+		t.Error("closed work should have no forecast events")
+	}
+	if strings.Index(out, "sprint-s-0@") > strings.Index(out, "sprint-s-1@") {
+		t.Error("sprints should be in date order")
+	}
+	for _, line := range strings.Split(out, "\r\n") {
+		if len(line) > 75 {
+			t.Errorf("line longer than 75 octets: %q", line)
+		}
+	}
+
+	// Regenerating gives the same file; selected rows limit issue events
+	var again bytes.Buffer
+	if _, err := WriteIssuesICS(&again, issues, opts); err != nil || again.String() != out {
+		t.Errorf("regenerated calendar differs (err %v)", err)
+	}
+	// Projects with the same issue IDs get distinct UIDs
+	other := opts
+	other.Project = "api"
+	if events := BuildICSEvents(issues, other); events[0].UID != "sprint-s-0@api.beads-viewer" {
+		t.Errorf("UID should be scoped by project, got %q", events[0].UID)
+	}
+	if icsDomain("") != "beads-viewer" {
+		t.Errorf("unscoped domain = %q", icsDomain(""))
+	}
+
+	opts.Rows = issues[1:2]
+	events := BuildICSEvents(issues, opts)
+	if last := events[len(events)-1]; len(events) != 4 || last.UID != "issue-bv-2@my-app.beads-viewer" {
+		t.Errorf("rows should limit issue events, got %+v", events)
+	}
+}
+
+func TestICSFold(t *testing.T) {
+	long := "DESCRIPTION:" + strings.Repeat("é", 80)
+	folded := icsFold(long)
+	if strings.ReplaceAll(folded, "\r\n ", "") != long {
+		t.Fatal("unfolding should restore the line")
+	}
+	for _, line := range strings.Split(folded, "\r\n") {
+		if len(line) > 75 || !utf8.ValidString(line) {
+			t.Errorf("bad folded line %q", line)
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package export
+
+import (
+	"fmt"
+	"html"
+	"sort"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/labels"
+)
+
+// LabelFlowSankey renders cross-label dependency flow as a Mermaid
+// sankey-beta diagram, one row per label pair weighted by issue count.
+//
+// Blocking labels sit on the left ("auth →") and blocked labels on the right
+// ("→ api"), so two labels that block each other don't form a cycle, which a
+// Sankey can't draw. Hierarchy parents are left out when their children are
+// in the flow, so the same dependency isn't drawn twice.
+func LabelFlowSankey(flow analysis.CrossLabelFlow) string {
+	var sb strings.Builder
+	sb.WriteString("sankey-beta\n")
+	for _, dep := range sankeyDependencies(flow) {
+		fmt.Fprintf(&sb, "%s,%s,%d\n",
+			sankeyField(dep.FromLabel+" →"), sankeyField("→ "+dep.ToLabel), dep.IssueCount)
+	}
+	return sb.String()
+}
+
+// LabelFlowSankeyHTML returns a standalone page rendering LabelFlowSankey
+// with the bundled Mermaid, so it opens offline
+func LabelFlowSankeyHTML(flow analysis.CrossLabelFlow, title string) (string, error) {
+	mermaidJS, err := ViewerAssetsFS.ReadFile("viewer_assets/vendor/mermaid.min.js")
+	if err != nil {
+		return "", fmt.Errorf("reading bundled mermaid: %w", err)
+	}
+	if title == "" {
+		title = "Label Dependency Flow"
+	}
+
+	deps := sankeyDependencies(flow)
+	body := `<p class="empty">No cross-label dependencies.</p>`
+	if len(deps) > 0 {
+		body = `<pre class="mermaid">` + "\n" + html.EscapeString(LabelFlowSankey(flow)) + `</pre>`
+	}
+	// Give each label on the busier side room for its name
+	from, to := make(map[string]bool), make(map[string]bool)
+	for _, dep := range deps {
+		from[dep.FromLabel] = true
+		to[dep.ToLabel] = true
+	}
+	height := 24 * max(len(from), len(to))
+	if height < 400 {
+		height = 400
+	}
+
+	var sb strings.Builder
+	sb.WriteString("<!DOCTYPE html>\n<html lang=\"en\">\n<head>\n<meta charset=\"utf-8\">\n")
+	fmt.Fprintf(&sb, "<title>%s</title>\n", html.EscapeString(title))
+	sb.WriteString(`<style>
+body { font-family: system-ui, sans-serif; margin: 2rem; background: #fff; color: #1f2937; }
+h1 { font-size: 1.25rem; }
+p.hint, p.empty { color: #6b7280; font-size: 0.875rem; }
+</style>
+</head>
+<body>
+`)
+	fmt.Fprintf(&sb, "<h1>%s</h1>\n", html.EscapeString(title))
+	fmt.Fprintf(&sb, "<p class=\"hint\">Blocking labels on the left, the labels they block on the right; band width is the number of dependencies (%d in total).</p>\n",
+		flow.TotalCrossLabelDeps)
+	sb.WriteString(body)
+	sb.WriteString("\n<script>\n")
+	sb.Write(mermaidJS)
+	sb.WriteString("\n</script>\n")
+	fmt.Fprintf(&sb, "<script>mermaid.initialize({ startOnLoad: true, sankey: { showValues: true, width: 960, height: %d } });</script>\n", height)
+	sb.WriteString("</body>\n</html>\n")
+	return sb.String(), nil
+}
+
+// sankeyDependencies returns the label pairs to draw, heaviest first
+func sankeyDependencies(flow analysis.CrossLabelFlow) []analysis.LabelDependency {
+	parent := make(map[string]bool)
+	for _, label := range flow.Labels {
+		for _, ancestor := range labels.Ancestors(label) {
+			parent[ancestor] = true
+		}
+	}
+
+	deps := make([]analysis.LabelDependency, 0, len(flow.Dependencies))
+	for _, dep := range flow.Dependencies {
+		if dep.IssueCount <= 0 || parent[dep.FromLabel] || parent[dep.ToLabel] {
+			continue
+		}
+		deps = append(deps, dep)
+	}
+	sort.SliceStable(deps, func(i, j int) bool {
+		if deps[i].IssueCount != deps[j].IssueCount {
+			return deps[i].IssueCount > deps[j].IssueCount
+		}
+		if deps[i].FromLabel != deps[j].FromLabel {
+			return deps[i].FromLabel < deps[j].FromLabel
+		}
+		return deps[i].ToLabel < deps[j].ToLabel
+	})
+	return deps
+}
+
+// sankeyField quotes a sankey-beta CSV field when it holds a comma or quote
+func sankeyField(s string) string {
+	if !strings.ContainsAny(s, ",\"") {
+		return s
+	}
+	return `"` + strings.ReplaceAll(s, `"`, `""`) + `"`
+}
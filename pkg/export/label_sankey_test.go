@@ -0,0 +1,48 @@
+package export
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+)
+
+func TestLabelFlowSankey(t *testing.T) {
+	flow := analysis.CrossLabelFlow{
+		Labels: []string{"auth", "backend", "backend/api", "ui,web"},
+		Dependencies: []analysis.LabelDependency{
+			{FromLabel: "auth", ToLabel: "backend/api", IssueCount: 1},
+			{FromLabel: "backend/api", ToLabel: "auth", IssueCount: 3},
+			{FromLabel: "backend", ToLabel: "auth", IssueCount: 3}, // Parent of backend/api
+			{FromLabel: "auth", ToLabel: "ui,web", IssueCount: 2},
+			{FromLabel: "auth", ToLabel: "backend/api", IssueCount: 0},
+		},
+		TotalCrossLabelDeps: 6,
+	}
+
+	want := "sankey-beta\n" +
+		"backend/api →,→ auth,3\n" +
+		"auth →,\"→ ui,web\",2\n" +
+		"auth →,→ backend/api,1\n"
+	if got := LabelFlowSankey(flow); got != want {
+		t.Errorf("LabelFlowSankey =\n%s\nwant\n%s", got, want)
+	}
+
+	page, err := LabelFlowSankeyHTML(flow, "Flow <test>")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range []string{"<title>Flow &lt;test&gt;</title>", `<pre class="mermaid">`, "backend/api →,→ auth,3", "mermaid.initialize"} {
+		if !strings.Contains(page, s) {
+			t.Errorf("HTML page missing %q", s)
+		}
+	}
+
+	empty, err := LabelFlowSankeyHTML(analysis.CrossLabelFlow{}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(empty, `<pre class="mermaid">`) || !strings.Contains(empty, "No cross-label dependencies") {
+		t.Error("empty flow should render a message, not a diagram")
+	}
+}
@@ -0,0 +1,90 @@
+package export
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+)
+
+// layersMermaidMaxIssues bounds the issues drawn per layer, so a roadmap
+// with hundreds of issues still fits on a slide
+const layersMermaidMaxIssues = 8
+
+// LayersMermaid renders a layer roadmap as a Mermaid flowchart: one
+// subgraph per layer, left to right, each listing its highest-priority
+// issues.
+func LayersMermaid(roadmap *analysis.LayerRoadmap) string {
+	var sb strings.Builder
+	sb.WriteString("flowchart LR\n")
+	node := 0
+	for _, l := range roadmap.Layers {
+		fmt.Fprintf(&sb, "    subgraph L%d[\"Layer %d · %s · days %s–%s\"]\n", l.Layer, l.Layer,
+			pluralIssues(l.Count), formatLayerDays(l.StartDay), formatLayerDays(l.EndDay))
+		sb.WriteString("        direction TB\n")
+		for i, item := range l.Issues {
+			if i == layersMermaidMaxIssues {
+				fmt.Fprintf(&sb, "        n%d[\"+%d more\"]\n", node, len(l.Issues)-i)
+				node++
+				break
+			}
+			fmt.Fprintf(&sb, "        n%d[\"%s<br/>%s\"]\n", node, sanitizeMermaidText(item.ID), sanitizeMermaidText(item.Title))
+			node++
+		}
+		sb.WriteString("    end\n")
+	}
+	for i := 1; i < len(roadmap.Layers); i++ {
+		fmt.Fprintf(&sb, "    L%d --> L%d\n", i, i+1)
+	}
+	return sb.String()
+}
+
+// LayersMarkdown renders a layer roadmap as a Markdown document: a summary,
+// the Mermaid diagram, then a table per layer.
+func LayersMarkdown(roadmap *analysis.LayerRoadmap) string {
+	var sb strings.Builder
+	sb.WriteString("# Roadmap\n\n")
+	agents := "1 agent"
+	if roadmap.Agents != 1 {
+		agents = fmt.Sprintf("%d agents", roadmap.Agents)
+	}
+	fmt.Fprintf(&sb, "%s in %d layers: %s days of work, about %s days with %s.\n\n",
+		pluralIssues(roadmap.TotalIssues), len(roadmap.Layers),
+		formatLayerDays(roadmap.TotalWorkDays), formatLayerDays(roadmap.TotalDurationDays), agents)
+	if len(roadmap.Layers) > 0 {
+		sb.WriteString("```mermaid\n" + LayersMermaid(roadmap) + "```\n")
+	}
+
+	for _, l := range roadmap.Layers {
+		fmt.Fprintf(&sb, "\n## Layer %d · days %s–%s\n\n", l.Layer, formatLayerDays(l.StartDay), formatLayerDays(l.EndDay))
+		fmt.Fprintf(&sb, "%s, %s days of work.\n\n", pluralIssues(l.Count), formatLayerDays(l.WorkDays))
+		sb.WriteString("| Issue | Title | Priority | Estimate | Waits on |\n")
+		sb.WriteString("|-------|-------|----------|----------|----------|\n")
+		for _, item := range l.Issues {
+			estimate := formatLayerDays(item.EstimatedDays) + "d"
+			if !item.Estimated {
+				estimate = "~" + estimate
+			}
+			fmt.Fprintf(&sb, "| %s | %s | P%d | %s | %s |\n", escapeTableCell(item.ID), escapeTableCell(item.Title),
+				item.Priority, estimate, escapeTableCell(strings.Join(item.WaitsOn, ", ")))
+		}
+	}
+
+	if len(roadmap.Unlayered) > 0 {
+		sb.WriteString("\n## Not layered\n\n")
+		fmt.Fprintf(&sb, "%s in or behind a dependency cycle: %s. Break the cycle to place them.\n",
+			pluralIssues(len(roadmap.Unlayered)), strings.Join(roadmap.Unlayered, ", "))
+	}
+	return sb.String()
+}
+
+func pluralIssues(n int) string {
+	if n == 1 {
+		return "1 issue"
+	}
+	return fmt.Sprintf("%d issues", n)
+}
+
+func formatLayerDays(d float64) string {
+	return fmt.Sprintf("%.1f", d)
+}
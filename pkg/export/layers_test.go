@@ -0,0 +1,59 @@
+package export
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+)
+
+func TestLayersRendering(t *testing.T) {
+	roadmap := &analysis.LayerRoadmap{
+		Agents: 2,
+		Layers: []analysis.RoadmapLayer{
+			{Layer: 1, Count: 1, WorkDays: 2, DurationDays: 2, EndDay: 2, Issues: []analysis.RoadmapIssue{
+				{ID: "bv-1", Title: "Schema [v2]", Priority: 1, EstimatedDays: 2, Estimated: true},
+			}},
+			{Layer: 2, Count: 1, WorkDays: 0.5, DurationDays: 0.5, StartDay: 2, EndDay: 2.5, Issues: []analysis.RoadmapIssue{
+				{ID: "bv-2", Title: "Loader | CLI", Priority: 2, EstimatedDays: 0.5, WaitsOn: []string{"bv-1"}},
+			}},
+		},
+		TotalIssues:       2,
+		TotalWorkDays:     2.5,
+		TotalDurationDays: 2.5,
+		Unlayered:         []string{"bv-8", "bv-9"},
+	}
+
+	mermaid := LayersMermaid(roadmap)
+	for _, want := range []string{"flowchart LR\n", `subgraph L1["Layer 1 · 1 issue · days 0.0–2.0"]`, `n0["bv-1<br/>Schema (v2)"]`, "L1 --> L2\n"} {
+		if !strings.Contains(mermaid, want) {
+			t.Errorf("mermaid missing %q:\n%s", want, mermaid)
+		}
+	}
+
+	md := LayersMarkdown(roadmap)
+	for _, want := range []string{
+		"2 issues in 2 layers: 2.5 days of work, about 2.5 days with 2 agents.",
+		"```mermaid\nflowchart LR",
+		"## Layer 2 · days 2.0–2.5",
+		"| bv-2 | Loader \\| CLI | P2 | ~0.5d | bv-1 |",
+		"2 issues in or behind a dependency cycle: bv-8, bv-9.",
+	} {
+		if !strings.Contains(md, want) {
+			t.Errorf("markdown missing %q:\n%s", want, md)
+		}
+	}
+}
+
+func TestLayersMermaid_CapsIssuesPerLayer(t *testing.T) {
+	layer := analysis.RoadmapLayer{Layer: 1}
+	for i := 0; i < layersMermaidMaxIssues+3; i++ {
+		layer.Issues = append(layer.Issues, analysis.RoadmapIssue{ID: fmt.Sprintf("bv-%d", i)})
+	}
+	layer.Count = len(layer.Issues)
+	out := LayersMermaid(&analysis.LayerRoadmap{Layers: []analysis.RoadmapLayer{layer}})
+	if !strings.Contains(out, `"+3 more"`) || strings.Contains(out, fmt.Sprintf("bv-%d", layersMermaidMaxIssues)) {
+		t.Errorf("a crowded layer should end in a +3 more node:\n%s", out)
+	}
+}
@@ -1,16 +1,21 @@
 package export
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
+	"path/filepath"
 	"regexp"
-	"sort"
 	"strings"
 	"time"
 	"unicode"
 
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/i18n"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/tracing"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 // Package-level compiled regex for slug creation (avoids recompilation per call)
@@ -72,21 +77,27 @@ func sanitizeMermaidText(text string) string {
 
 // GenerateMarkdown creates a comprehensive markdown report of all issues
 func GenerateMarkdown(issues []model.Issue, title string) (string, error) {
+	return generateMarkdown(issues, title, "")
+}
+
+// generateMarkdown builds the report; graphImage, when set, is a relative
+// path to a rendered dependency graph embedded above the Mermaid source.
+func generateMarkdown(issues []model.Issue, title, graphImage string) (string, error) {
 	var sb strings.Builder
 
 	// Header
 	sb.WriteString(fmt.Sprintf("# %s\n\n", title))
-	sb.WriteString(fmt.Sprintf("*Generated: %s*\n\n", time.Now().Format(time.RFC1123)))
+	sb.WriteString(i18n.Tf("*Generated: %s*\n\n", time.Now().Format(time.RFC1123)))
 
 	// Summary Statistics
-	sb.WriteString("## Summary\n\n")
+	sb.WriteString("## " + i18n.T("Summary") + "\n\n")
 
 	open, inProgress, blocked, closed := 0, 0, 0, 0
 	for _, i := range issues {
 		switch i.Status {
 		case model.StatusOpen:
 			open++
-		case model.StatusInProgress:
+		case model.StatusInProgress, model.StatusInReview:
 			inProgress++
 		case model.StatusBlocked:
 			blocked++
@@ -95,18 +106,18 @@ func GenerateMarkdown(issues []model.Issue, title string) (string, error) {
 		}
 	}
 
-	sb.WriteString("| Metric | Count |\n|--------|-------|\n")
-	sb.WriteString(fmt.Sprintf("| **Total** | %d |\n", len(issues)))
-	sb.WriteString(fmt.Sprintf("| Open | %d |\n", open))
-	sb.WriteString(fmt.Sprintf("| In Progress | %d |\n", inProgress))
-	sb.WriteString(fmt.Sprintf("| Blocked | %d |\n", blocked))
-	sb.WriteString(fmt.Sprintf("| Closed | %d |\n\n", closed))
+	sb.WriteString(tableRow("Metric", "Count") + "|--------|-------|\n")
+	sb.WriteString(fmt.Sprintf("| **%s** | %d |\n", i18n.T("Total"), len(issues)))
+	sb.WriteString(fmt.Sprintf("| %s | %d |\n", i18n.T("Open"), open))
+	sb.WriteString(fmt.Sprintf("| %s | %d |\n", i18n.T("In Progress"), inProgress))
+	sb.WriteString(fmt.Sprintf("| %s | %d |\n", i18n.T("Blocked"), blocked))
+	sb.WriteString(fmt.Sprintf("| %s | %d |\n\n", i18n.T("Closed"), closed))
 
 	// Quick Actions Section
 	sb.WriteString(generateQuickActions(issues))
 
 	// Table of Contents
-	sb.WriteString("## Table of Contents\n\n")
+	sb.WriteString("## " + i18n.T("Table of Contents") + "\n\n")
 	for _, i := range issues {
 		// Create a slug for the anchor (lowercase, hyphens for spaces)
 		slug := createSlug(i.ID)
@@ -116,7 +127,10 @@ func GenerateMarkdown(issues []model.Issue, title string) (string, error) {
 	sb.WriteString("\n---\n\n")
 
 	// Dependency Graph (Mermaid)
-	sb.WriteString("## Dependency Graph\n\n")
+	sb.WriteString("## " + i18n.T("Dependency Graph") + "\n\n")
+	if graphImage != "" {
+		sb.WriteString(fmt.Sprintf("![%s](%s)\n\n", i18n.T("Dependency graph"), graphImage))
+	}
 	sb.WriteString("```mermaid\n")
 
 	issueIDs := make(map[string]bool)
@@ -130,27 +144,35 @@ func GenerateMarkdown(issues []model.Issue, title string) (string, error) {
 	sb.WriteString("```\n\n")
 	sb.WriteString("---\n\n")
 
+	// Mentions of other issues link to their sections, which carry explicit
+	// anchors since renderers slug headings differently
+	mentions := analysis.BuildMentionIndex(issues)
+	known := func(id string) bool { return issueIDs[id] }
+	href := func(id string) string { return "#" + createSlug(id) }
+	linked := func(text string) string { return analysis.LinkMentions(text, known, href) }
+
 	// Individual Issues
 	for _, i := range issues {
 		typeIcon := getTypeEmoji(string(i.IssueType))
+		sb.WriteString(fmt.Sprintf("<a id=\"%s\"></a>\n\n", createSlug(i.ID)))
 		sb.WriteString(fmt.Sprintf("## %s %s %s\n\n", typeIcon, i.ID, i.Title))
 
 		// Metadata Table
-		sb.WriteString("| Property | Value |\n|----------|-------|\n")
-		sb.WriteString(fmt.Sprintf("| **Type** | %s %s |\n", typeIcon, i.IssueType))
-		sb.WriteString(fmt.Sprintf("| **Priority** | %s |\n", getPriorityLabel(i.Priority)))
-		sb.WriteString(fmt.Sprintf("| **Status** | %s %s |\n", getStatusEmoji(string(i.Status)), i.Status))
+		sb.WriteString(tableRow("Property", "Value") + "|----------|-------|\n")
+		sb.WriteString(fmt.Sprintf("| **%s** | %s %s |\n", i18n.T("Type"), typeIcon, i.IssueType))
+		sb.WriteString(fmt.Sprintf("| **%s** | %s |\n", i18n.T("Priority"), getPriorityLabel(i.Priority)))
+		sb.WriteString(fmt.Sprintf("| **%s** | %s %s |\n", i18n.T("Status"), getStatusEmoji(string(i.Status)), i.Status))
 		if i.Assignee != "" {
 			// Sanitize assignee: replace newlines with spaces, escape pipes
 			cleanAssignee := strings.ReplaceAll(i.Assignee, "\n", " ")
 			cleanAssignee = strings.ReplaceAll(cleanAssignee, "\r", "")
 			escapedAssignee := strings.ReplaceAll(cleanAssignee, "|", "\\|")
-			sb.WriteString(fmt.Sprintf("| **Assignee** | @%s |\n", escapedAssignee))
+			sb.WriteString(fmt.Sprintf("| **%s** | @%s |\n", i18n.T("Assignee"), escapedAssignee))
 		}
-		sb.WriteString(fmt.Sprintf("| **Created** | %s |\n", i.CreatedAt.Format("2006-01-02 15:04")))
-		sb.WriteString(fmt.Sprintf("| **Updated** | %s |\n", i.UpdatedAt.Format("2006-01-02 15:04")))
+		sb.WriteString(fmt.Sprintf("| **%s** | %s |\n", i18n.T("Created"), i.CreatedAt.Format("2006-01-02 15:04")))
+		sb.WriteString(fmt.Sprintf("| **%s** | %s |\n", i18n.T("Updated"), i.UpdatedAt.Format("2006-01-02 15:04")))
 		if i.ClosedAt != nil {
-			sb.WriteString(fmt.Sprintf("| **Closed** | %s |\n", i.ClosedAt.Format("2006-01-02 15:04")))
+			sb.WriteString(fmt.Sprintf("| **%s** | %s |\n", i18n.T("Closed"), i.ClosedAt.Format("2006-01-02 15:04")))
 		}
 		if len(i.Labels) > 0 {
 			// Escape pipe characters and sanitize newlines in labels
@@ -160,32 +182,32 @@ func GenerateMarkdown(issues []model.Issue, title string) (string, error) {
 				cleanLabel = strings.ReplaceAll(cleanLabel, "\r", "")
 				escapedLabels[idx] = strings.ReplaceAll(cleanLabel, "|", "\\|")
 			}
-			sb.WriteString(fmt.Sprintf("| **Labels** | %s |\n", strings.Join(escapedLabels, ", ")))
+			sb.WriteString(fmt.Sprintf("| **%s** | %s |\n", i18n.T("Labels"), strings.Join(escapedLabels, ", ")))
 		}
 		sb.WriteString("\n")
 
 		if i.Description != "" {
-			sb.WriteString("### Description\n\n")
-			sb.WriteString(i.Description + "\n\n")
+			sb.WriteString("### " + i18n.T("Description") + "\n\n")
+			sb.WriteString(linked(i.Description) + "\n\n")
 		}
 
 		if i.AcceptanceCriteria != "" {
-			sb.WriteString("### Acceptance Criteria\n\n")
-			sb.WriteString(i.AcceptanceCriteria + "\n\n")
+			sb.WriteString("### " + i18n.T("Acceptance Criteria") + "\n\n")
+			sb.WriteString(linked(i.AcceptanceCriteria) + "\n\n")
 		}
 
 		if i.Design != "" {
-			sb.WriteString("### Design\n\n")
-			sb.WriteString(i.Design + "\n\n")
+			sb.WriteString("### " + i18n.T("Design") + "\n\n")
+			sb.WriteString(linked(i.Design) + "\n\n")
 		}
 
 		if i.Notes != "" {
-			sb.WriteString("### Notes\n\n")
-			sb.WriteString(i.Notes + "\n\n")
+			sb.WriteString("### " + i18n.T("Notes") + "\n\n")
+			sb.WriteString(linked(i.Notes) + "\n\n")
 		}
 
 		if len(i.Dependencies) > 0 {
-			sb.WriteString("### Dependencies\n\n")
+			sb.WriteString("### " + i18n.T("Dependencies") + "\n\n")
 			for _, dep := range i.Dependencies {
 				if dep == nil {
 					continue
@@ -200,17 +222,25 @@ func GenerateMarkdown(issues []model.Issue, title string) (string, error) {
 		}
 
 		if len(i.Comments) > 0 {
-			sb.WriteString("### Comments\n\n")
+			sb.WriteString("### " + i18n.T("Comments") + "\n\n")
 			for _, c := range i.Comments {
 				if c == nil {
 					continue
 				}
-				escapedText := strings.ReplaceAll(c.Text, "\n", "\n> ")
+				escapedText := strings.ReplaceAll(linked(c.Text), "\n", "\n> ")
 				sb.WriteString(fmt.Sprintf("> **%s** (%s)\n>\n> %s\n\n",
 					c.Author, c.CreatedAt.Format("2006-01-02"), escapedText))
 			}
 		}
 
+		if from := mentions.Backlinks(i.ID); len(from) > 0 {
+			refs := make([]string, len(from))
+			for idx, id := range from {
+				refs[idx] = fmt.Sprintf("[%s](%s)", id, href(id))
+			}
+			sb.WriteString(fmt.Sprintf("**%s:** %s\n\n", i18n.T("Mentioned by"), strings.Join(refs, ", ")))
+		}
+
 		// Per-issue command snippets
 		sb.WriteString(generateIssueCommands(i))
 
@@ -220,6 +250,15 @@ func GenerateMarkdown(issues []model.Issue, title string) (string, error) {
 	return sb.String(), nil
 }
 
+// tableRow renders a Markdown table row of translated header cells
+func tableRow(cells ...string) string {
+	translated := make([]string, len(cells))
+	for i, c := range cells {
+		translated[i] = i18n.T(c)
+	}
+	return "| " + strings.Join(translated, " | ") + " |\n"
+}
+
 // createSlug creates a URL-friendly slug from an ID
 func createSlug(id string) string {
 	// Convert to lowercase and replace non-alphanumeric with hyphens
@@ -264,15 +303,15 @@ func getTypeEmoji(issueType string) string {
 func getPriorityLabel(priority int) string {
 	switch priority {
 	case 0:
-		return "🔥 Critical (P0)"
+		return "🔥 " + i18n.T("Critical") + " (P0)"
 	case 1:
-		return "⚡ High (P1)"
+		return "⚡ " + i18n.T("High") + " (P1)"
 	case 2:
-		return "🔹 Medium (P2)"
+		return "🔹 " + i18n.T("Medium") + " (P2)"
 	case 3:
-		return "☕ Low (P3)"
+		return "☕ " + i18n.T("Low") + " (P3)"
 	case 4:
-		return "💤 Backlog (P4)"
+		return "💤 " + i18n.T("Backlog") + " (P4)"
 	default:
 		return fmt.Sprintf("P%d", priority)
 	}
@@ -280,24 +319,65 @@ func getPriorityLabel(priority int) string {
 
 // SaveMarkdownToFile writes the generated markdown to a file
 func SaveMarkdownToFile(issues []model.Issue, filename string) error {
+	return SaveMarkdownToFileWithOptions(issues, filename, MarkdownExportOptions{})
+}
+
+// MarkdownExportOptions controls optional parts of the Markdown report.
+type MarkdownExportOptions struct {
+	// GraphImage renders the dependency graph with the layered layout as
+	// "svg" or "png", writes it next to the report as <name>-graph.<ext>,
+	// and embeds it. Empty keeps the Mermaid source only.
+	GraphImage string
+
+	// Template replaces the fixed report layout when set; see
+	// ResolveReportTemplate. The rendered graph image, if any, is exposed
+	// to the template as .GraphImage.
+	Template *ReportTemplate
+
+	// Sprints are exposed to templates as .Sprints and .ActiveSprint.
+	Sprints []model.Sprint
+}
+
+// SaveMarkdownToFileWithOptions writes the Markdown report with options.
+func SaveMarkdownToFileWithOptions(issues []model.Issue, filename string, opts MarkdownExportOptions) (err error) {
+	_, span := tracing.Start(context.Background(), "export.markdown", attribute.String("bv.path", filename), attribute.Int("bv.issues", len(issues)))
+	defer func() { tracing.End(span, err) }()
+
 	// Make a copy to avoid mutating the caller's slice
 	issuesCopy := make([]model.Issue, len(issues))
 	copy(issuesCopy, issues)
 
 	// Sort issues for the report: Open first, then priority, then date
-	sort.Slice(issuesCopy, func(i, j int) bool {
-		iClosed := issuesCopy[i].Status == model.StatusClosed
-		jClosed := issuesCopy[j].Status == model.StatusClosed
-		if iClosed != jClosed {
-			return !iClosed
+	sortReportIssues(issuesCopy)
+
+	graphRef := ""
+	if format := strings.ToLower(opts.GraphImage); format != "" && len(issuesCopy) > 0 {
+		if format != "svg" && format != "png" {
+			return fmt.Errorf("unsupported graph image format %q (want svg or png)", opts.GraphImage)
 		}
-		if issuesCopy[i].Priority != issuesCopy[j].Priority {
-			return issuesCopy[i].Priority < issuesCopy[j].Priority
+		base := strings.TrimSuffix(filepath.Base(filename), filepath.Ext(filename))
+		graphRef = base + "-graph." + format
+		stats := analysis.NewAnalyzer(issuesCopy).Analyze()
+		if err := SaveGraphSnapshot(GraphSnapshotOptions{
+			Path:   filepath.Join(filepath.Dir(filename), graphRef),
+			Format: format,
+			Layout: "layered",
+			Title:  "Beads Export",
+			Issues: issuesCopy,
+			Stats:  &stats,
+		}); err != nil {
+			return fmt.Errorf("render graph image: %w", err)
 		}
-		return issuesCopy[i].CreatedAt.After(issuesCopy[j].CreatedAt)
-	})
+	}
 
-	content, err := GenerateMarkdown(issuesCopy, "Beads Export")
+	var content string
+	if opts.Template != nil {
+		data := NewReportData(issuesCopy, opts.Sprints, "Beads Export", time.Now())
+		data.GraphImage = graphRef
+		content, err = RenderReportTemplate(opts.Template, data)
+	} else {
+		content, err = generateMarkdown(issuesCopy, "Beads Export", graphRef)
+	}
 	if err != nil {
 		return err
 	}
@@ -317,7 +397,7 @@ func generateQuickActions(issues []model.Issue) string {
 		switch i.Status {
 		case model.StatusOpen:
 			openIDs = append(openIDs, escapedID)
-		case model.StatusInProgress:
+		case model.StatusInProgress, model.StatusInReview:
 			inProgressIDs = append(inProgressIDs, escapedID)
 		case model.StatusBlocked:
 			blockedIDs = append(blockedIDs, escapedID)
@@ -332,34 +412,34 @@ func generateQuickActions(issues []model.Issue) string {
 		return ""
 	}
 
-	sb.WriteString("## Quick Actions\n\n")
-	sb.WriteString("Ready-to-run commands for bulk operations:\n\n")
+	sb.WriteString("## " + i18n.T("Quick Actions") + "\n\n")
+	sb.WriteString(i18n.T("Ready-to-run commands for bulk operations:") + "\n\n")
 	sb.WriteString("```bash\n")
 
 	// Close in-progress items (most common action)
 	if len(inProgressIDs) > 0 {
-		sb.WriteString("# Close all in-progress items\n")
+		sb.WriteString("# " + i18n.T("Close all in-progress items") + "\n")
 		sb.WriteString(fmt.Sprintf("bd close %s\n\n", strings.Join(inProgressIDs, " ")))
 	}
 
 	// Close open items
 	if len(openIDs) > 0 && len(openIDs) <= 10 {
-		sb.WriteString("# Close all open items\n")
+		sb.WriteString("# " + i18n.T("Close all open items") + "\n")
 		sb.WriteString(fmt.Sprintf("bd close %s\n\n", strings.Join(openIDs, " ")))
 	} else if len(openIDs) > 10 {
-		sb.WriteString(fmt.Sprintf("# Close open items (%d total, showing first 10)\n", len(openIDs)))
+		sb.WriteString("# " + i18n.Tf("Close open items (%d total, showing first 10)", len(openIDs)) + "\n")
 		sb.WriteString(fmt.Sprintf("bd close %s\n\n", strings.Join(openIDs[:10], " ")))
 	}
 
 	// Bulk priority update for high-priority items
 	if len(highPriorityIDs) > 0 {
-		sb.WriteString("# View high-priority items (P0/P1)\n")
+		sb.WriteString("# " + i18n.T("View high-priority items (P0/P1)") + "\n")
 		sb.WriteString(fmt.Sprintf("bd show %s\n\n", strings.Join(highPriorityIDs, " ")))
 	}
 
 	// Unblock blocked items
 	if len(blockedIDs) > 0 {
-		sb.WriteString("# Update blocked items to in_progress when unblocked\n")
+		sb.WriteString("# " + i18n.T("Update blocked items to in_progress when unblocked") + "\n")
 		sb.WriteString(fmt.Sprintf("bd update %s -s in_progress\n", strings.Join(blockedIDs, " ")))
 	}
 
@@ -379,30 +459,30 @@ func generateIssueCommands(issue model.Issue) string {
 
 	escapedID := shellEscape(issue.ID)
 
-	sb.WriteString("<details>\n<summary>📋 Commands</summary>\n\n")
+	sb.WriteString("<details>\n<summary>📋 " + i18n.T("Commands") + "</summary>\n\n")
 	sb.WriteString("```bash\n")
 
 	// Status transitions based on current state
 	switch issue.Status {
 	case model.StatusOpen:
-		sb.WriteString("# Start working on this issue\n")
+		sb.WriteString("# " + i18n.T("Start working on this issue") + "\n")
 		sb.WriteString(fmt.Sprintf("bd update %s -s in_progress\n\n", escapedID))
-	case model.StatusInProgress:
-		sb.WriteString("# Mark as complete\n")
+	case model.StatusInProgress, model.StatusInReview:
+		sb.WriteString("# " + i18n.T("Mark as complete") + "\n")
 		sb.WriteString(fmt.Sprintf("bd close %s\n\n", escapedID))
 	case model.StatusBlocked:
-		sb.WriteString("# Unblock and start working\n")
+		sb.WriteString("# " + i18n.T("Unblock and start working") + "\n")
 		sb.WriteString(fmt.Sprintf("bd update %s -s in_progress\n\n", escapedID))
 	}
 
 	// Common actions
-	sb.WriteString("# Add a comment\n")
+	sb.WriteString("# " + i18n.T("Add a comment") + "\n")
 	sb.WriteString(fmt.Sprintf("bd comment %s 'Your comment here'\n\n", escapedID))
 
-	sb.WriteString("# Change priority (0=Critical, 1=High, 2=Medium, 3=Low)\n")
+	sb.WriteString("# " + i18n.T("Change priority (0=Critical, 1=High, 2=Medium, 3=Low)") + "\n")
 	sb.WriteString(fmt.Sprintf("bd update %s -p 1\n\n", escapedID))
 
-	sb.WriteString("# View full details\n")
+	sb.WriteString("# " + i18n.T("View full details") + "\n")
 	sb.WriteString(fmt.Sprintf("bd show %s\n", escapedID))
 
 	sb.WriteString("```\n\n")
@@ -579,18 +659,18 @@ func GeneratePriorityBriefFromTriageJSON(triageJSON []byte, config PriorityBrief
 	var sb strings.Builder
 
 	// Header
-	sb.WriteString("# 📊 Priority Brief\n\n")
-	sb.WriteString(fmt.Sprintf("*Generated: %s*  \n", triage.Meta.GeneratedAt.Format("2006-01-02 15:04")))
-	sb.WriteString(fmt.Sprintf("*Version: %s | Issues: %d*\n\n", triage.Meta.Version, triage.Meta.IssueCount))
+	sb.WriteString("# 📊 " + i18n.T("Priority Brief") + "\n\n")
+	sb.WriteString(i18n.Tf("*Generated: %s*", triage.Meta.GeneratedAt.Format("2006-01-02 15:04")) + "  \n")
+	sb.WriteString(i18n.Tf("*Version: %s | Issues: %d*\n\n", triage.Meta.Version, triage.Meta.IssueCount))
 
 	// Data hash
 	if config.DataHash != "" {
-		sb.WriteString(fmt.Sprintf("**Hash:** `%s`\n\n", config.DataHash))
+		sb.WriteString(fmt.Sprintf("**%s:** `%s`\n\n", i18n.T("Hash"), config.DataHash))
 	}
 
 	// Summary stats
-	sb.WriteString("## 📈 Summary\n\n")
-	sb.WriteString("| Open | In Progress | Blocked | Actionable |\n")
+	sb.WriteString("## 📈 " + i18n.T("Summary") + "\n\n")
+	sb.WriteString(tableRow("Open", "In Progress", "Blocked", "Actionable"))
 	sb.WriteString("|:----:|:-----------:|:-------:|:----------:|\n")
 	sb.WriteString(fmt.Sprintf("| %d | %d | %d | %d |\n\n",
 		triage.QuickRef.OpenCount,
@@ -601,11 +681,11 @@ func GeneratePriorityBriefFromTriageJSON(triageJSON []byte, config PriorityBrief
 	sb.WriteString("---\n\n")
 
 	// Top Recommendations
-	sb.WriteString("## 🎯 Top Recommendations\n\n")
+	sb.WriteString("## 🎯 " + i18n.T("Top Recommendations") + "\n\n")
 	if len(triage.Recommendations) == 0 {
-		sb.WriteString("*No recommendations available.*\n\n")
+		sb.WriteString("*" + i18n.T("No recommendations available.") + "*\n\n")
 	} else {
-		sb.WriteString("| # | Issue | Type | P | Score | PR | BW | TI | Top Reason |\n")
+		sb.WriteString(tableRow("#", "Issue", "Type", "P", "Score", "PR", "BW", "TI", "Top Reason"))
 		sb.WriteString("|:-:|-------|:----:|:-:|:-----:|:--:|:--:|:--:|------------|\n")
 
 		limit := config.MaxRecommendations
@@ -637,11 +717,11 @@ func GeneratePriorityBriefFromTriageJSON(triageJSON []byte, config PriorityBrief
 	}
 
 	// Quick Wins
-	sb.WriteString("## ⚡ Quick Wins\n\n")
+	sb.WriteString("## ⚡ " + i18n.T("Quick Wins") + "\n\n")
 	if len(triage.QuickWins) == 0 {
-		sb.WriteString("*No quick wins identified.*\n\n")
+		sb.WriteString("*" + i18n.T("No quick wins identified.") + "*\n\n")
 	} else {
-		sb.WriteString("| Issue | Reason |\n")
+		sb.WriteString(tableRow("Issue", "Reason"))
 		sb.WriteString("|-------|--------|\n")
 
 		limit := config.MaxQuickWins
@@ -661,11 +741,11 @@ func GeneratePriorityBriefFromTriageJSON(triageJSON []byte, config PriorityBrief
 	}
 
 	// Blockers
-	sb.WriteString("## 🚧 Blockers to Clear\n\n")
+	sb.WriteString("## 🚧 " + i18n.T("Blockers to Clear") + "\n\n")
 	if len(triage.BlockersToClear) == 0 {
-		sb.WriteString("*No critical blockers.*\n\n")
+		sb.WriteString("*" + i18n.T("No critical blockers.") + "*\n\n")
 	} else {
-		sb.WriteString("| Issue | Unblocks | Ready? |\n")
+		sb.WriteString(tableRow("Issue", "Unblocks", "Ready?"))
 		sb.WriteString("|-------|:--------:|:------:|\n")
 
 		limit := config.MaxBlockers
@@ -692,16 +772,16 @@ func GeneratePriorityBriefFromTriageJSON(triageJSON []byte, config PriorityBrief
 	// Legend
 	if config.IncludeLegend {
 		sb.WriteString("---\n\n")
-		sb.WriteString("## 📖 Legend\n\n")
-		sb.WriteString("| Symbol | Meaning |\n")
+		sb.WriteString("## 📖 " + i18n.T("Legend") + "\n\n")
+		sb.WriteString(tableRow("Symbol", "Meaning"))
 		sb.WriteString("|:------:|:--------|\n")
-		sb.WriteString("| **PR** | PageRank - dependency importance |\n")
-		sb.WriteString("| **BW** | Betweenness - critical path frequency |\n")
-		sb.WriteString("| **TI** | Time-to-Impact - urgency factor |\n")
-		sb.WriteString("| █░░░ | Low (0-25%) |\n")
-		sb.WriteString("| ██░░ | Medium (25-50%) |\n")
-		sb.WriteString("| ███░ | High (50-75%) |\n")
-		sb.WriteString("| ████ | Very High (75-100%) |\n")
+		sb.WriteString("| **PR** | " + i18n.T("PageRank - dependency importance") + " |\n")
+		sb.WriteString("| **BW** | " + i18n.T("Betweenness - critical path frequency") + " |\n")
+		sb.WriteString("| **TI** | " + i18n.T("Time-to-Impact - urgency factor") + " |\n")
+		sb.WriteString("| █░░░ | " + i18n.T("Low") + " (0-25%) |\n")
+		sb.WriteString("| ██░░ | " + i18n.T("Medium") + " (25-50%) |\n")
+		sb.WriteString("| ███░ | " + i18n.T("High") + " (50-75%) |\n")
+		sb.WriteString("| ████ | " + i18n.T("Very High") + " (75-100%) |\n")
 	}
 
 	return sb.String(), nil
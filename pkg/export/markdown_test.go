@@ -11,6 +11,7 @@ import (
 	"unicode/utf8"
 
 	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/i18n"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
 )
 
@@ -415,6 +416,30 @@ func TestGenerateMarkdown_SingleIssue(t *testing.T) {
 	}
 }
 
+func TestGenerateMarkdown_Localized(t *testing.T) {
+	if err := i18n.SetLang("de"); err != nil {
+		t.Fatal(err)
+	}
+	defer i18n.SetLang(i18n.English)
+
+	issues := []model.Issue{
+		{ID: "TEST-1", Title: "Test Issue", Description: "Text", Status: model.StatusOpen, Priority: 0, IssueType: model.TypeBug},
+	}
+	md, err := GenerateMarkdown(issues, "Projekt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"## Zusammenfassung", "| **Gesamt** | 1 |", "| Eigenschaft | Wert |", "Kritisch", "### Beschreibung"} {
+		if !strings.Contains(md, want) {
+			t.Errorf("missing %q in:\n%s", want, md)
+		}
+	}
+	// Issue content is not translated
+	if !strings.Contains(md, "Test Issue") {
+		t.Error("missing issue title")
+	}
+}
+
 func TestGenerateMarkdown_WithDependencies(t *testing.T) {
 	issues := []model.Issue{
 		{
@@ -616,6 +641,31 @@ func TestGenerateMarkdown_WithAllFields(t *testing.T) {
 	}
 }
 
+func TestGenerateMarkdown_CrossReferences(t *testing.T) {
+	now := time.Now()
+	issues := []model.Issue{
+		{ID: "REF-1", Title: "Parser", Status: model.StatusOpen, Description: "Needs REF-2 first; see `REF-2`", CreatedAt: now, UpdatedAt: now},
+		{ID: "REF-2", Title: "Lexer", Status: model.StatusOpen, CreatedAt: now, UpdatedAt: now,
+			Comments: []*model.Comment{{Author: "a", Text: "blocks REF-1", CreatedAt: now}}},
+	}
+
+	md, err := GenerateMarkdown(issues, "Refs")
+	if err != nil {
+		t.Fatalf("GenerateMarkdown returned error: %v", err)
+	}
+
+	for _, want := range []string{
+		`<a id="ref-1"></a>`,
+		"Needs [REF-2](#ref-2) first; see `REF-2`",
+		"> blocks [REF-1](#ref-1)",
+		"**Mentioned by:** [REF-1](#ref-1)",
+	} {
+		if !strings.Contains(md, want) {
+			t.Errorf("missing %q", want)
+		}
+	}
+}
+
 func TestGenerateMarkdown_TableOfContents(t *testing.T) {
 	now := time.Now()
 	issues := []model.Issue{
@@ -752,6 +802,58 @@ func TestSaveMarkdownToFile_Sorting(t *testing.T) {
 	}
 }
 
+func TestSaveMarkdownToFileWithOptions_GraphImage(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	now := time.Now()
+	issues := []model.Issue{
+		{ID: "IMG-1", Title: "Base", Status: model.StatusOpen, CreatedAt: now, UpdatedAt: now},
+		{ID: "IMG-2", Title: "Dependent", Status: model.StatusOpen, CreatedAt: now, UpdatedAt: now,
+			Dependencies: []*model.Dependency{{IssueID: "IMG-2", DependsOnID: "IMG-1", Type: model.DepBlocks}}},
+	}
+
+	filePath := filepath.Join(tmpDir, "report.md")
+	if err := SaveMarkdownToFileWithOptions(issues, filePath, MarkdownExportOptions{GraphImage: "svg"}); err != nil {
+		t.Fatalf("SaveMarkdownToFileWithOptions returned error: %v", err)
+	}
+
+	svgBytes, err := os.ReadFile(filepath.Join(tmpDir, "report-graph.svg"))
+	if err != nil {
+		t.Fatalf("graph image not written: %v", err)
+	}
+	if !strings.Contains(string(svgBytes), "<svg") || !strings.Contains(string(svgBytes), "IMG-2") {
+		t.Error("graph image should be an SVG containing the issues")
+	}
+
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		t.Fatalf("Failed to read export file: %v", err)
+	}
+	md := string(content)
+	imgIdx := strings.Index(md, "![Dependency graph](report-graph.svg)")
+	mermaidIdx := strings.Index(md, "```mermaid")
+	if imgIdx == -1 || mermaidIdx == -1 || imgIdx > mermaidIdx {
+		t.Errorf("expected image embedded before the Mermaid block:\n%s", md)
+	}
+
+	if err := SaveMarkdownToFileWithOptions(issues, filePath, MarkdownExportOptions{GraphImage: "gif"}); err == nil {
+		t.Error("expected error for unsupported image format")
+	}
+
+	// Without the option no image is referenced
+	plain := filepath.Join(tmpDir, "plain.md")
+	if err := SaveMarkdownToFile(issues, plain); err != nil {
+		t.Fatalf("SaveMarkdownToFile returned error: %v", err)
+	}
+	plainContent, _ := os.ReadFile(plain)
+	if strings.Contains(string(plainContent), "![Dependency graph]") {
+		t.Error("plain export should not reference a graph image")
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, "plain-graph.svg")); !os.IsNotExist(err) {
+		t.Error("plain export should not write a graph image")
+	}
+}
+
 func TestSaveMarkdownToFile_DoesNotMutateInput(t *testing.T) {
 	tmpDir, err := os.MkdirTemp("", "bv-export-mutate-*")
 	if err != nil {
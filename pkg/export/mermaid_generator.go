@@ -23,6 +23,7 @@ func GenerateMermaidGraph(issues []model.Issue, issueIDs map[string]bool, config
 	// Class definitions for styling
 	sb.WriteString("    classDef open fill:#50FA7B,stroke:#333,color:#000\n")
 	sb.WriteString("    classDef inprogress fill:#8BE9FD,stroke:#333,color:#000\n")
+	sb.WriteString("    classDef inreview fill:#BD93F9,stroke:#333,color:#000\n")
 	sb.WriteString("    classDef blocked fill:#FF5555,stroke:#333,color:#000\n")
 	sb.WriteString("    classDef closed fill:#6272A4,stroke:#333,color:#fff\n")
 	sb.WriteString("\n")
@@ -80,6 +81,8 @@ func GenerateMermaidGraph(issues []model.Issue, issueIDs map[string]bool, config
 			class = "open"
 		case model.StatusInProgress:
 			class = "inprogress"
+		case model.StatusInReview:
+			class = "inreview"
 		case model.StatusBlocked:
 			class = "blocked"
 		case model.StatusClosed:
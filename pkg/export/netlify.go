@@ -0,0 +1,263 @@
+// Package export provides data export functionality for bv.
+//
+// This file implements Netlify deployment through the Netlify HTTP API using
+// a personal access token. It uses the file-digest deploy flow, so only files
+// Netlify doesn't already have are uploaded.
+package export
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// NetlifyAPIBase is the default Netlify API endpoint.
+const NetlifyAPIBase = "https://api.netlify.com/api/v1"
+
+// NetlifyTokenEnv is the environment variable holding the Netlify token
+// (the same one the netlify CLI reads).
+const NetlifyTokenEnv = "NETLIFY_AUTH_TOKEN"
+
+// NetlifyDeployConfig configures Netlify deployment.
+type NetlifyDeployConfig struct {
+	// Site is the site ID or domain; a bare name gets ".netlify.app" appended
+	Site string
+
+	// Token is a Netlify personal access token
+	Token string
+
+	// BundlePath is the path to the static site bundle to deploy
+	BundlePath string
+
+	// APIBase overrides the API endpoint (default NetlifyAPIBase)
+	APIBase string
+
+	// WaitTimeout bounds how long to wait for the deploy to go live (default 2m)
+	WaitTimeout time.Duration
+
+	// HTTPClient is used for API calls (default http.DefaultClient)
+	HTTPClient *http.Client
+}
+
+// NetlifyDeployResult contains the result of a deployment.
+type NetlifyDeployResult struct {
+	// DeployID is the Netlify deploy identifier
+	DeployID string
+
+	// URL is the live site URL
+	URL string
+
+	// State is the final deploy state reported by Netlify (e.g. "ready")
+	State string
+
+	// Uploaded is the number of files Netlify asked for; the rest it already had
+	Uploaded int
+
+	// Total is the number of files in the bundle
+	Total int
+}
+
+// netlifyDeploy is the subset of Netlify's deploy object bv uses.
+type netlifyDeploy struct {
+	ID           string   `json:"id"`
+	State        string   `json:"state"`
+	Required     []string `json:"required"`
+	SSLURL       string   `json:"ssl_url"`
+	URL          string   `json:"url"`
+	DeploySSLURL string   `json:"deploy_ssl_url"`
+	ErrorMessage string   `json:"error_message"`
+}
+
+// NetlifySiteID normalizes a site name to something the API accepts: IDs
+// and domains pass through, bare names become <name>.netlify.app.
+func NetlifySiteID(site string) string {
+	site = strings.TrimSpace(site)
+	site = strings.TrimPrefix(site, "https://")
+	site = strings.TrimSuffix(site, "/")
+	if site == "" || strings.Contains(site, ".") || isNetlifyUUID(site) {
+		return site
+	}
+	return site + ".netlify.app"
+}
+
+func isNetlifyUUID(s string) bool {
+	return len(s) == 36 && cfDeploymentIDRegex.MatchString(s)
+}
+
+// DeployToNetlify uploads the bundle as a new production deploy.
+func DeployToNetlify(config NetlifyDeployConfig) (*NetlifyDeployResult, error) {
+	if config.Token == "" {
+		return nil, fmt.Errorf("netlify token required - set %s", NetlifyTokenEnv)
+	}
+	site := NetlifySiteID(config.Site)
+	if site == "" {
+		return nil, fmt.Errorf("netlify site is required")
+	}
+	if config.APIBase == "" {
+		config.APIBase = NetlifyAPIBase
+	}
+	if config.WaitTimeout == 0 {
+		config.WaitTimeout = 2 * time.Minute
+	}
+	if config.HTTPClient == nil {
+		config.HTTPClient = http.DefaultClient
+	}
+	if _, err := os.Stat(config.BundlePath); os.IsNotExist(err) {
+		return nil, fmt.Errorf("bundle path does not exist: %s", config.BundlePath)
+	}
+
+	// Netlify reads the same _headers format as Cloudflare Pages
+	if err := GenerateHeadersFile(config.BundlePath); err != nil {
+		fmt.Printf("  Warning: %v\n", err)
+	}
+
+	files, err := netlifyFileDigests(config.BundlePath)
+	if err != nil {
+		return nil, fmt.Errorf("hash bundle: %w", err)
+	}
+
+	fmt.Printf("  -> Creating Netlify deploy for %s (%d files)...\n", site, len(files))
+	body, err := json.Marshal(map[string]any{"files": files})
+	if err != nil {
+		return nil, err
+	}
+	var deploy netlifyDeploy
+	if err := config.netlifyRequest(http.MethodPost, "/sites/"+url.PathEscape(site)+"/deploys", "application/json", bytes.NewReader(body), &deploy); err != nil {
+		return nil, fmt.Errorf("create deploy: %w", err)
+	}
+
+	// Upload only the files whose digests Netlify doesn't have yet
+	required := make(map[string]bool, len(deploy.Required))
+	for _, sum := range deploy.Required {
+		required[sum] = true
+	}
+	paths := make([]string, 0, len(files))
+	for p := range files {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+
+	uploaded := 0
+	for _, p := range paths {
+		if !required[files[p]] {
+			continue
+		}
+		delete(required, files[p]) // identical files only need one upload
+		data, err := os.ReadFile(filepath.Join(config.BundlePath, filepath.FromSlash(strings.TrimPrefix(p, "/"))))
+		if err != nil {
+			return nil, err
+		}
+		if err := config.netlifyRequest(http.MethodPut, "/deploys/"+url.PathEscape(deploy.ID)+"/files"+escapeNetlifyPath(p), "application/octet-stream", bytes.NewReader(data), nil); err != nil {
+			return nil, fmt.Errorf("upload %s: %w", p, err)
+		}
+		uploaded++
+	}
+	fmt.Printf("  -> Uploaded %d changed files (%d already on Netlify)\n", uploaded, len(files)-uploaded)
+
+	// Wait for Netlify to finish processing
+	deadline := time.Now().Add(config.WaitTimeout)
+	for deploy.State != "ready" && deploy.State != "error" && time.Now().Before(deadline) {
+		time.Sleep(time.Second)
+		if err := config.netlifyRequest(http.MethodGet, "/deploys/"+url.PathEscape(deploy.ID), "", nil, &deploy); err != nil {
+			return nil, fmt.Errorf("check deploy: %w", err)
+		}
+	}
+	if deploy.State == "error" {
+		return nil, fmt.Errorf("netlify deploy failed: %s", deploy.ErrorMessage)
+	}
+
+	siteURL := deploy.SSLURL
+	if siteURL == "" {
+		siteURL = deploy.URL
+	}
+	if siteURL == "" {
+		siteURL = "https://" + site
+	}
+	fmt.Println("  -> Deployment complete!")
+
+	return &NetlifyDeployResult{
+		DeployID: deploy.ID,
+		URL:      siteURL,
+		State:    deploy.State,
+		Uploaded: uploaded,
+		Total:    len(files),
+	}, nil
+}
+
+// netlifyRequest performs an authenticated API call and decodes the JSON
+// response into out (when non-nil).
+func (c NetlifyDeployConfig) netlifyRequest(method, path, contentType string, body io.Reader, out any) error {
+	req, err := http.NewRequest(method, strings.TrimSuffix(c.APIBase, "/")+path, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.Token)
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("netlify API %s: %s", resp.Status, strings.TrimSpace(string(msg)))
+	}
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// netlifyFileDigests maps "/path" to the SHA-1 Netlify uses for deduping.
+func netlifyFileDigests(dir string) (map[string]string, error) {
+	files := make(map[string]string)
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if d.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		sum := sha1.Sum(data)
+		files["/"+filepath.ToSlash(rel)] = hex.EncodeToString(sum[:])
+		return nil
+	})
+	return files, err
+}
+
+// escapeNetlifyPath escapes each segment of a "/a/b c" style path.
+func escapeNetlifyPath(p string) string {
+	segments := strings.Split(strings.TrimPrefix(p, "/"), "/")
+	for i, s := range segments {
+		segments[i] = url.PathEscape(s)
+	}
+	return "/" + strings.Join(segments, "/")
+}
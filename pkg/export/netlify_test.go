@@ -0,0 +1,140 @@
+package export
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestNetlifySiteID(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"my-issues", "my-issues.netlify.app"},
+		{"my-issues.netlify.app", "my-issues.netlify.app"},
+		{"https://issues.example.com/", "issues.example.com"},
+		{"3970e0fe-8564-4903-9a55-c5f8de49fb8b", "3970e0fe-8564-4903-9a55-c5f8de49fb8b"},
+		{"  ", ""},
+	}
+	for _, tt := range tests {
+		if got := NetlifySiteID(tt.in); got != tt.want {
+			t.Errorf("NetlifySiteID(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestEscapeNetlifyPath(t *testing.T) {
+	if got := escapeNetlifyPath("/data/my file.json"); got != "/data/my%20file.json" {
+		t.Errorf("escapeNetlifyPath = %q", got)
+	}
+}
+
+func TestDeployToNetlify_UploadsOnlyRequiredFiles(t *testing.T) {
+	bundle := t.TempDir()
+	writePagesFiles(t, bundle, map[string]string{
+		"index.html":       "<html></html>",
+		"data/meta.json":   "{}",
+		"chunks/00000.bin": "same",
+		"chunks/00001.bin": "same",
+		".git/HEAD":        "ignored",
+	})
+
+	var (
+		mu       sync.Mutex
+		digests  map[string]string
+		uploaded []string
+		polls    int
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/sites/my-issues.netlify.app/deploys":
+			var body struct {
+				Files map[string]string `json:"files"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Errorf("decode deploy body: %v", err)
+			}
+			digests = body.Files
+			// Netlify already has index.html; everything else is new
+			var required []string
+			for p, sum := range body.Files {
+				if p != "/index.html" {
+					required = append(required, sum)
+				}
+			}
+			json.NewEncoder(w).Encode(map[string]any{"id": "d1", "state": "uploading", "required": required})
+		case r.Method == http.MethodPut && strings.HasPrefix(r.URL.Path, "/deploys/d1/files/"):
+			io.Copy(io.Discard, r.Body)
+			uploaded = append(uploaded, strings.TrimPrefix(r.URL.Path, "/deploys/d1/files"))
+			w.Write([]byte("{}"))
+		case r.Method == http.MethodGet && r.URL.Path == "/deploys/d1":
+			polls++
+			json.NewEncoder(w).Encode(map[string]any{"id": "d1", "state": "ready", "ssl_url": "https://my-issues.netlify.app"})
+		default:
+			http.Error(w, "unexpected "+r.Method+" "+r.URL.Path, http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	result, err := DeployToNetlify(NetlifyDeployConfig{
+		Site:        "my-issues",
+		Token:       "secret",
+		BundlePath:  bundle,
+		APIBase:     server.URL,
+		WaitTimeout: 10 * time.Second,
+	})
+	if err != nil {
+		t.Fatalf("DeployToNetlify: %v", err)
+	}
+
+	if _, ok := digests["/_headers"]; !ok {
+		t.Errorf("expected _headers in deploy digests, got %v", digests)
+	}
+	if _, ok := digests["/.git/HEAD"]; ok {
+		t.Error(".git contents should not be deployed")
+	}
+	if digests["/chunks/00000.bin"] != digests["/chunks/00001.bin"] {
+		t.Error("identical files should share a digest")
+	}
+
+	// Identical chunks are uploaded once, index.html not at all
+	slices.Sort(uploaded)
+	want := []string{"/_headers", "/chunks/00000.bin", "/data/meta.json"}
+	if !slices.Equal(uploaded, want) {
+		t.Errorf("uploaded = %v, want %v", uploaded, want)
+	}
+	if result.Uploaded != 3 || result.Total != len(digests) {
+		t.Errorf("result = %+v", result)
+	}
+	if result.URL != "https://my-issues.netlify.app" || result.State != "ready" || polls == 0 {
+		t.Errorf("unexpected result %+v (polls=%d)", result, polls)
+	}
+}
+
+func TestDeployToNetlify_Errors(t *testing.T) {
+	if _, err := DeployToNetlify(NetlifyDeployConfig{Site: "x", BundlePath: t.TempDir()}); err == nil || !strings.Contains(err.Error(), NetlifyTokenEnv) {
+		t.Errorf("expected missing token error, got %v", err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "site not found", http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	_, err := DeployToNetlify(NetlifyDeployConfig{Site: "x", Token: "t", BundlePath: t.TempDir(), APIBase: server.URL})
+	if err == nil || !strings.Contains(err.Error(), "site not found") {
+		t.Errorf("expected API error to surface, got %v", err)
+	}
+}
@@ -0,0 +1,382 @@
+// Package export provides data export functionality for bv.
+//
+// This file implements publishing to Notion through its API. Notion has no
+// "replace page content" call, so the page's blocks are deleted and the
+// Markdown, converted to Notion blocks, is appended in their place.
+package export
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/yuin/goldmark/ast"
+	east "github.com/yuin/goldmark/extension/ast"
+	"github.com/yuin/goldmark/text"
+)
+
+// NotionAPIBase is the default Notion API endpoint.
+const NotionAPIBase = "https://api.notion.com/v1"
+
+// NotionTokenEnv holds the Notion integration token.
+const NotionTokenEnv = "NOTION_TOKEN"
+
+// NotionVersion is the API version bv speaks.
+const NotionVersion = "2022-06-28"
+
+// Notion API limits
+const (
+	notionMaxTextLen       = 2000 // Characters per rich text object
+	notionMaxBlocksPerCall = 100  // Children per append request
+)
+
+// NotionPublishConfig configures a Notion page update.
+type NotionPublishConfig struct {
+	// PageID is the page to update (with or without dashes)
+	PageID string
+
+	// Title renames the page when set
+	Title string
+
+	// Token is a Notion integration token with access to the page
+	Token string
+
+	// Markdown is the content to publish
+	Markdown string
+
+	// APIBase overrides the API endpoint (default NotionAPIBase)
+	APIBase string
+
+	// HTTPClient is used for API calls (default http.DefaultClient)
+	HTTPClient *http.Client
+
+	target string // Name reported in the result
+}
+
+// notionBlock is a block as the API takes it: {"type": t, t: content}.
+type notionBlock map[string]any
+
+// PublishToNotion replaces the content of an existing Notion page.
+func PublishToNotion(config NotionPublishConfig) (*PublishResult, error) {
+	if config.Token == "" {
+		return nil, fmt.Errorf("notion token required - set %s", NotionTokenEnv)
+	}
+	if config.PageID == "" {
+		return nil, fmt.Errorf("notion page_id is required")
+	}
+	if config.APIBase == "" {
+		config.APIBase = NotionAPIBase
+	}
+	if config.HTTPClient == nil {
+		config.HTTPClient = http.DefaultClient
+	}
+	blocks := markdownToNotionBlocks(config.Markdown)
+	pageID := url.PathEscape(config.PageID)
+
+	if config.Title != "" {
+		props := map[string]any{"properties": map[string]any{"title": map[string]any{"title": notionText(config.Title)}}}
+		if err := config.do(http.MethodPatch, "/pages/"+pageID, props, nil); err != nil {
+			return nil, fmt.Errorf("renaming notion page: %w", err)
+		}
+	}
+
+	// Collect the old blocks before deleting any, so the cursor stays valid
+	var old []string
+	cursor := ""
+	for {
+		endpoint := "/blocks/" + pageID + "/children?page_size=100"
+		if cursor != "" {
+			endpoint += "&start_cursor=" + url.QueryEscape(cursor)
+		}
+		var page struct {
+			Results []struct {
+				ID string `json:"id"`
+			} `json:"results"`
+			HasMore    bool   `json:"has_more"`
+			NextCursor string `json:"next_cursor"`
+		}
+		if err := config.do(http.MethodGet, endpoint, nil, &page); err != nil {
+			return nil, fmt.Errorf("listing notion page content: %w", err)
+		}
+		for _, b := range page.Results {
+			old = append(old, b.ID)
+		}
+		if !page.HasMore || page.NextCursor == "" {
+			break
+		}
+		cursor = page.NextCursor
+	}
+	for _, id := range old {
+		if err := config.do(http.MethodDelete, "/blocks/"+url.PathEscape(id), nil, nil); err != nil {
+			return nil, fmt.Errorf("clearing notion page: %w", err)
+		}
+	}
+
+	for start := 0; start < len(blocks); start += notionMaxBlocksPerCall {
+		end := min(start+notionMaxBlocksPerCall, len(blocks))
+		body := map[string]any{"children": blocks[start:end]}
+		if err := config.do(http.MethodPatch, "/blocks/"+pageID+"/children", body, nil); err != nil {
+			return nil, fmt.Errorf("writing notion page content: %w", err)
+		}
+	}
+
+	return &PublishResult{
+		Target: config.target,
+		Type:   PublishNotion,
+		PageID: config.PageID,
+		URL:    "https://www.notion.so/" + strings.ReplaceAll(config.PageID, "-", ""),
+		Blocks: len(blocks),
+	}, nil
+}
+
+// do sends one authenticated JSON request and decodes the response into out.
+func (c NotionPublishConfig) do(method, path string, in, out any) error {
+	var reqBody io.Reader
+	if in != nil {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		reqBody = bytes.NewReader(data)
+	}
+	req, err := http.NewRequest(method, c.APIBase+path, reqBody)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.Token)
+	req.Header.Set("Notion-Version", NotionVersion)
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		var apiErr struct {
+			Message string `json:"message"`
+		}
+		if json.Unmarshal(data, &apiErr) == nil && apiErr.Message != "" {
+			return fmt.Errorf("%s: %s", resp.Status, apiErr.Message)
+		}
+		return fmt.Errorf("%s", resp.Status)
+	}
+	if out == nil {
+		return nil
+	}
+	return json.Unmarshal(data, out)
+}
+
+// markdownToNotionBlocks converts Markdown to top-level Notion blocks.
+// Nested lists are flattened, and anything Notion has no block for (raw
+// HTML, images) is dropped.
+func markdownToNotionBlocks(markdown string) []notionBlock {
+	source := []byte(markdown)
+	doc := publishMarkdown.Parser().Parse(text.NewReader(source))
+	var blocks []notionBlock
+	for n := doc.FirstChild(); n != nil; n = n.NextSibling() {
+		blocks = appendNotionBlocks(blocks, n, source)
+	}
+	return blocks
+}
+
+func appendNotionBlocks(blocks []notionBlock, n ast.Node, source []byte) []notionBlock {
+	switch n := n.(type) {
+	case *ast.Heading:
+		level := min(n.Level, 3)
+		return append(blocks, newNotionBlock(fmt.Sprintf("heading_%d", level), notionInline(n, source)))
+	case *ast.Paragraph, *ast.TextBlock:
+		if rich := notionInline(n, source); len(rich) > 0 {
+			return append(blocks, newNotionBlock("paragraph", rich))
+		}
+	case *ast.Blockquote:
+		var rich []map[string]any
+		for c := n.FirstChild(); c != nil; c = c.NextSibling() {
+			if len(rich) > 0 {
+				rich = append(rich, notionText("\n")...)
+			}
+			rich = append(rich, notionInline(c, source)...)
+		}
+		return append(blocks, newNotionBlock("quote", rich))
+	case *ast.List:
+		kind := "bulleted_list_item"
+		if n.IsOrdered() {
+			kind = "numbered_list_item"
+		}
+		for item := n.FirstChild(); item != nil; item = item.NextSibling() {
+			for c := item.FirstChild(); c != nil; c = c.NextSibling() {
+				if list, ok := c.(*ast.List); ok {
+					blocks = appendNotionBlocks(blocks, list, source)
+					continue
+				}
+				blocks = append(blocks, newNotionBlock(kind, notionInline(c, source)))
+			}
+		}
+		return blocks
+	case *ast.FencedCodeBlock, *ast.CodeBlock:
+		var sb strings.Builder
+		lines := n.Lines()
+		for i := 0; i < lines.Len(); i++ {
+			seg := lines.At(i)
+			sb.Write(seg.Value(source))
+		}
+		lang := ""
+		if fenced, ok := n.(*ast.FencedCodeBlock); ok {
+			lang = string(fenced.Language(source))
+		}
+		b := newNotionBlock("code", notionText(strings.TrimSuffix(sb.String(), "\n")))
+		b["code"].(map[string]any)["language"] = notionLanguage(lang)
+		return append(blocks, b)
+	case *ast.ThematicBreak:
+		return append(blocks, notionBlock{"type": "divider", "divider": map[string]any{}})
+	case *east.Table:
+		var rows []notionBlock
+		width := 0
+		for row := n.FirstChild(); row != nil; row = row.NextSibling() {
+			var cells [][]map[string]any
+			for cell := row.FirstChild(); cell != nil; cell = cell.NextSibling() {
+				cells = append(cells, notionInline(cell, source))
+			}
+			width = max(width, len(cells))
+			rows = append(rows, notionBlock{"type": "table_row", "table_row": map[string]any{"cells": cells}})
+		}
+		for _, row := range rows {
+			cells := row["table_row"].(map[string]any)["cells"].([][]map[string]any)
+			for len(cells) < width {
+				cells = append(cells, []map[string]any{})
+			}
+			row["table_row"].(map[string]any)["cells"] = cells
+		}
+		return append(blocks, notionBlock{"type": "table", "table": map[string]any{
+			"table_width":       width,
+			"has_column_header": true,
+			"has_row_header":    false,
+			"children":          rows,
+		}})
+	}
+	return blocks
+}
+
+func newNotionBlock(kind string, rich []map[string]any) notionBlock {
+	if rich == nil {
+		rich = []map[string]any{}
+	}
+	return notionBlock{"type": kind, kind: map[string]any{"rich_text": rich}}
+}
+
+// notionAnnotations are the inline styles in effect while walking a node
+type notionAnnotations struct {
+	bold, italic, code, strikethrough bool
+	link                              string
+}
+
+// notionInline converts the inline children of n to rich text.
+func notionInline(n ast.Node, source []byte) []map[string]any {
+	var rich []map[string]any
+	var walk func(n ast.Node, a notionAnnotations)
+	walk = func(n ast.Node, a notionAnnotations) {
+		for c := n.FirstChild(); c != nil; c = c.NextSibling() {
+			switch c := c.(type) {
+			case *ast.Text:
+				s := string(c.Segment.Value(source))
+				if c.SoftLineBreak() || c.HardLineBreak() {
+					s += "\n"
+				}
+				rich = append(rich, notionRun(s, a)...)
+			case *ast.String:
+				rich = append(rich, notionRun(string(c.Value), a)...)
+			case *ast.CodeSpan:
+				inner := a
+				inner.code = true
+				walk(c, inner)
+			case *ast.Emphasis:
+				inner := a
+				if c.Level >= 2 {
+					inner.bold = true
+				} else {
+					inner.italic = true
+				}
+				walk(c, inner)
+			case *east.Strikethrough:
+				inner := a
+				inner.strikethrough = true
+				walk(c, inner)
+			case *ast.Link:
+				inner := a
+				if dest := string(c.Destination); strings.HasPrefix(dest, "http://") || strings.HasPrefix(dest, "https://") {
+					inner.link = dest
+				}
+				walk(c, inner)
+			case *ast.AutoLink:
+				inner := a
+				dest := string(c.URL(source))
+				if strings.HasPrefix(dest, "http://") || strings.HasPrefix(dest, "https://") {
+					inner.link = dest
+				}
+				rich = append(rich, notionRun(string(c.Label(source)), inner)...)
+			default:
+				walk(c, a)
+			}
+		}
+	}
+	walk(n, notionAnnotations{})
+	return rich
+}
+
+// notionText is plain rich text, split to fit Notion's length limit.
+func notionText(s string) []map[string]any {
+	return notionRun(s, notionAnnotations{})
+}
+
+func notionRun(s string, a notionAnnotations) []map[string]any {
+	var out []map[string]any
+	runes := []rune(s)
+	for len(runes) > 0 {
+		n := min(len(runes), notionMaxTextLen)
+		content := map[string]any{"content": string(runes[:n])}
+		if a.link != "" {
+			content["link"] = map[string]string{"url": a.link}
+		}
+		out = append(out, map[string]any{
+			"type": "text",
+			"text": content,
+			"annotations": map[string]bool{
+				"bold":          a.bold,
+				"italic":        a.italic,
+				"code":          a.code,
+				"strikethrough": a.strikethrough,
+			},
+		})
+		runes = runes[n:]
+	}
+	return out
+}
+
+// notionLanguage maps a fence language to one Notion accepts.
+func notionLanguage(lang string) string {
+	switch lang = strings.ToLower(lang); lang {
+	case "sh", "shell", "zsh":
+		return "shell"
+	case "bash", "go", "json", "yaml", "mermaid", "markdown", "python", "javascript", "typescript", "sql", "html", "css", "rust", "java":
+		return lang
+	case "yml":
+		return "yaml"
+	case "js":
+		return "javascript"
+	case "ts":
+		return "typescript"
+	case "md":
+		return "markdown"
+	}
+	return "plain text"
+}
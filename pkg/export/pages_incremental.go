@@ -0,0 +1,405 @@
+// Package export provides data export functionality for bv.
+//
+// This file implements incremental static site publishing: a content-hash
+// manifest of the export, a sync that only rewrites files whose content
+// changed, and a push mode that commits just those deltas to a gh-pages
+// style branch.
+package export
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/offline"
+)
+
+// PagesManifestFile is written at the root of every static site export.
+const PagesManifestFile = "bv-manifest.json"
+
+// PagesManifestVersion is bumped when the manifest format changes.
+const PagesManifestVersion = 1
+
+// DefaultPagesBranch is the branch --pages-push publishes to.
+const DefaultPagesBranch = "gh-pages"
+
+// PagesManifest lists every file of a static site export with its content
+// hash. It deliberately has no timestamp, so an unchanged export produces
+// an identical manifest.
+type PagesManifest struct {
+	Version int                           `json:"version"`
+	Files   map[string]PagesManifestEntry `json:"files"` // Keyed by slash-separated relative path
+}
+
+// PagesManifestEntry describes one exported file.
+type PagesManifestEntry struct {
+	SHA256 string `json:"sha256"`
+	Size   int64  `json:"size"`
+}
+
+// PagesSyncResult reports what SyncPagesDir did. Paths are slash-separated
+// and sorted.
+type PagesSyncResult struct {
+	Written   []string `json:"written"`
+	Unchanged []string `json:"unchanged"`
+	Removed   []string `json:"removed"`
+}
+
+// Changed reports whether the sync touched any file.
+func (r *PagesSyncResult) Changed() bool {
+	return len(r.Written) > 0 || len(r.Removed) > 0
+}
+
+// BuildPagesManifest hashes every regular file under dir. The manifest
+// itself and any .git directory are skipped.
+func BuildPagesManifest(dir string) (*PagesManifest, error) {
+	manifest := &PagesManifest{Version: PagesManifestVersion, Files: make(map[string]PagesManifestEntry)}
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if d.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if rel == PagesManifestFile {
+			return nil
+		}
+		entry, err := hashFile(path)
+		if err != nil {
+			return fmt.Errorf("hash %s: %w", rel, err)
+		}
+		manifest.Files[rel] = entry
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+// LoadPagesManifest reads the manifest from dir. It returns nil without an
+// error when dir has no manifest (first export, or a pre-manifest export).
+func LoadPagesManifest(dir string) (*PagesManifest, error) {
+	data, err := os.ReadFile(filepath.Join(dir, PagesManifestFile))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var manifest PagesManifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", PagesManifestFile, err)
+	}
+	return &manifest, nil
+}
+
+// SyncPagesDir brings destDir up to date with the export staged in srcDir.
+// Files are only rewritten when their content hash differs, and files the
+// previous manifest recorded but the new export no longer has (e.g. stale
+// database chunks) are removed. Files destDir has that no manifest ever
+// listed are left alone. The new manifest is written last.
+func SyncPagesDir(srcDir, destDir string) (*PagesSyncResult, error) {
+	manifest, err := BuildPagesManifest(srcDir)
+	if err != nil {
+		return nil, fmt.Errorf("build manifest: %w", err)
+	}
+	previous, err := LoadPagesManifest(destDir)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(destDir, 0755); err != nil {
+		return nil, fmt.Errorf("create output dir: %w", err)
+	}
+
+	result := &PagesSyncResult{}
+	for _, rel := range sortedManifestPaths(manifest.Files) {
+		dest := filepath.Join(destDir, filepath.FromSlash(rel))
+		if existing, err := hashFile(dest); err == nil && existing == manifest.Files[rel] {
+			result.Unchanged = append(result.Unchanged, rel)
+			continue
+		}
+		if err := copyFileAtomic(filepath.Join(srcDir, filepath.FromSlash(rel)), dest); err != nil {
+			return nil, fmt.Errorf("write %s: %w", rel, err)
+		}
+		result.Written = append(result.Written, rel)
+	}
+
+	if previous != nil {
+		for _, rel := range sortedManifestPaths(previous.Files) {
+			if _, ok := manifest.Files[rel]; ok {
+				continue
+			}
+			err := os.Remove(filepath.Join(destDir, filepath.FromSlash(rel)))
+			if err != nil && !errors.Is(err, fs.ErrNotExist) {
+				return nil, fmt.Errorf("remove stale %s: %w", rel, err)
+			}
+			result.Removed = append(result.Removed, rel)
+		}
+	}
+
+	if err := writeJSON(filepath.Join(destDir, PagesManifestFile), manifest); err != nil {
+		return nil, fmt.Errorf("write manifest: %w", err)
+	}
+	return result, nil
+}
+
+// PagesPushConfig configures PushPagesBranch.
+type PagesPushConfig struct {
+	// Remote is a git URL or path, or the name of a remote in RepoDir (default "origin")
+	Remote string
+
+	// Branch is the branch to publish to (default gh-pages)
+	Branch string
+
+	// RepoDir is the repository used to resolve a remote name (default ".")
+	RepoDir string
+
+	// Message is the commit message
+	Message string
+
+	// GitConfig holds extra "key=value" settings passed to git with -c,
+	// e.g. an http.extraHeader carrying a token
+	GitConfig []string
+}
+
+// PagesPushResult contains the result of a push.
+type PagesPushResult struct {
+	Remote string           `json:"remote"`
+	Branch string           `json:"branch"`
+	Commit string           `json:"commit,omitempty"` // Empty when nothing changed
+	Pushed bool             `json:"pushed"`
+	Sync   *PagesSyncResult `json:"sync"`
+}
+
+// PushPagesBranch publishes the export in bundlePath to a branch of the
+// remote. It shallow-fetches the branch into a scratch repository, syncs the
+// bundle over it with SyncPagesDir and commits only if something changed,
+// so each publish uploads just the changed files. The push is a plain
+// fast-forward; it never force-pushes.
+func PushPagesBranch(bundlePath string, cfg PagesPushConfig) (*PagesPushResult, error) {
+	if cfg.Branch == "" {
+		cfg.Branch = DefaultPagesBranch
+	}
+	if cfg.Remote == "" {
+		cfg.Remote = "origin"
+	}
+	if cfg.RepoDir == "" {
+		cfg.RepoDir = "."
+	}
+	if cfg.Message == "" {
+		cfg.Message = "Update static site via bv --pages-push"
+	}
+
+	remoteURL, err := resolvePagesRemote(cfg.Remote, cfg.RepoDir)
+	if err != nil {
+		return nil, err
+	}
+	if !isLocalRemote(remoteURL) {
+		if err := offline.Check("pushing to " + remoteURL); err != nil {
+			return nil, err
+		}
+	}
+
+	workDir, err := os.MkdirTemp("", "bv-pages-push-*")
+	if err != nil {
+		return nil, fmt.Errorf("create work dir: %w", err)
+	}
+	defer os.RemoveAll(workDir)
+
+	var gitArgs []string
+	for _, kv := range cfg.GitConfig {
+		gitArgs = append(gitArgs, "-c", kv)
+	}
+	git := func(args ...string) (string, error) {
+		return runPagesGit(workDir, append(gitArgs[:len(gitArgs):len(gitArgs)], args...)...)
+	}
+
+	if _, err := git("init", "-q"); err != nil {
+		return nil, err
+	}
+	if _, err := git("remote", "add", "origin", remoteURL); err != nil {
+		return nil, err
+	}
+	heads, err := git("ls-remote", "--heads", "origin", cfg.Branch)
+	if err != nil {
+		return nil, err
+	}
+	if heads == "" {
+		if _, err := git("checkout", "-q", "--orphan", cfg.Branch); err != nil {
+			return nil, err
+		}
+	} else {
+		if _, err := git("fetch", "-q", "--depth=1", "origin", cfg.Branch); err != nil {
+			return nil, err
+		}
+		if _, err := git("checkout", "-q", "-B", cfg.Branch, "FETCH_HEAD"); err != nil {
+			return nil, err
+		}
+	}
+
+	sync, err := SyncPagesDir(bundlePath, workDir)
+	if err != nil {
+		return nil, err
+	}
+	result := &PagesPushResult{Remote: remoteURL, Branch: cfg.Branch, Sync: sync}
+
+	if _, err := git("add", "-A"); err != nil {
+		return nil, err
+	}
+	if _, err := git("diff", "--cached", "--quiet"); err == nil {
+		return result, nil
+	}
+
+	commitArgs := []string{"commit", "-q", "-m", cfg.Message}
+	if email, _ := git("config", "user.email"); email == "" {
+		// CI runners often have no git identity configured
+		commitArgs = append([]string{"-c", "user.name=bv", "-c", "user.email=bv@localhost"}, commitArgs...)
+	}
+	if _, err := git(commitArgs...); err != nil {
+		return nil, err
+	}
+	if result.Commit, err = git("rev-parse", "HEAD"); err != nil {
+		return nil, err
+	}
+	if _, err := git("push", "-q", "origin", cfg.Branch); err != nil {
+		return nil, err
+	}
+	result.Pushed = true
+	return result, nil
+}
+
+// resolvePagesRemote returns remote unchanged when it already looks like a
+// URL or local path, otherwise looks it up as a remote name in repoDir.
+func resolvePagesRemote(remote, repoDir string) (string, error) {
+	if strings.Contains(remote, "://") || strings.Contains(remote, ":") || filepath.IsAbs(remote) {
+		return remote, nil
+	}
+	if info, err := os.Stat(remote); err == nil && info.IsDir() {
+		return filepath.Abs(remote)
+	}
+	url, err := runPagesGit(repoDir, "remote", "get-url", remote)
+	if err != nil {
+		return "", fmt.Errorf("resolve remote %q: %w", remote, err)
+	}
+	return url, nil
+}
+
+// isLocalRemote reports whether a git remote is on this machine: a
+// file:// URL or a path that exists
+func isLocalRemote(remoteURL string) bool {
+	if strings.HasPrefix(remoteURL, "file://") {
+		return true
+	}
+	_, err := os.Stat(remoteURL)
+	return err == nil
+}
+
+// runPagesGit runs git in dir and returns its trimmed stdout.
+func runPagesGit(dir string, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	var stderr strings.Builder
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		msg := strings.TrimSpace(stderr.String())
+		if msg == "" {
+			msg = err.Error()
+		}
+		return "", fmt.Errorf("git %s failed: %s", gitSubcommand(args), msg)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// gitSubcommand returns the first argument after any leading -c options.
+func gitSubcommand(args []string) string {
+	for i := 0; i < len(args); i++ {
+		if args[i] == "-c" {
+			i++
+			continue
+		}
+		return args[i]
+	}
+	return ""
+}
+
+func hashFile(path string) (PagesManifestEntry, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return PagesManifestEntry{}, err
+	}
+	defer f.Close()
+	h := sha256.New()
+	n, err := io.Copy(h, f)
+	if err != nil {
+		return PagesManifestEntry{}, err
+	}
+	return PagesManifestEntry{SHA256: hex.EncodeToString(h.Sum(nil)), Size: n}, nil
+}
+
+// copyFileAtomic copies src to dest via a temp file and rename, so readers
+// (a preview server, a half-finished CI job) never see a partial file.
+func copyFileAtomic(src, dest string) error {
+	if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+		return err
+	}
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	tmp, err := os.CreateTemp(filepath.Dir(dest), ".bv-tmp-*")
+	if err != nil {
+		return err
+	}
+	tmpName := tmp.Name()
+	if _, err := io.Copy(tmp, in); err != nil {
+		tmp.Close()
+		os.Remove(tmpName)
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmpName)
+		return err
+	}
+	if err := os.Chmod(tmpName, 0644); err != nil {
+		os.Remove(tmpName)
+		return err
+	}
+	if err := os.Rename(tmpName, dest); err != nil {
+		os.Remove(tmpName)
+		return err
+	}
+	return nil
+}
+
+func sortedManifestPaths(files map[string]PagesManifestEntry) []string {
+	paths := make([]string, 0, len(files))
+	for p := range files {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	return paths
+}
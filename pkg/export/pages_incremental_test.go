@@ -0,0 +1,220 @@
+package export
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/offline"
+)
+
+func writePagesFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for rel, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestBuildPagesManifest(t *testing.T) {
+	dir := t.TempDir()
+	writePagesFiles(t, dir, map[string]string{
+		"index.html":         "<html></html>",
+		"data/meta.json":     "{}",
+		PagesManifestFile:    "{}",
+		".git/HEAD":          "ref: refs/heads/gh-pages",
+		"chunks/00000.bin":   "abc",
+		"chunks/00001.bin":   "abc",
+		"vendor/sql-wasm.js": "x",
+	})
+
+	manifest, err := BuildPagesManifest(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var paths []string
+	for p := range manifest.Files {
+		paths = append(paths, p)
+	}
+	slices.Sort(paths)
+	want := []string{"chunks/00000.bin", "chunks/00001.bin", "data/meta.json", "index.html", "vendor/sql-wasm.js"}
+	if !slices.Equal(paths, want) {
+		t.Fatalf("paths = %v, want %v", paths, want)
+	}
+	a, b := manifest.Files["chunks/00000.bin"], manifest.Files["chunks/00001.bin"]
+	if a != b || a.Size != 3 || len(a.SHA256) != 64 {
+		t.Errorf("identical files should hash the same: %+v %+v", a, b)
+	}
+}
+
+func TestSyncPagesDir(t *testing.T) {
+	src, dest := t.TempDir(), t.TempDir()
+	writePagesFiles(t, src, map[string]string{
+		"index.html":       "v1",
+		"data/meta.json":   `{"n":1}`,
+		"chunks/00000.bin": "aaa",
+		"chunks/00001.bin": "bbb",
+	})
+	first, err := SyncPagesDir(src, dest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(first.Written) != 4 || len(first.Unchanged) != 0 || len(first.Removed) != 0 {
+		t.Fatalf("first sync = %+v", first)
+	}
+
+	// A file the user keeps next to the export is never touched
+	writePagesFiles(t, dest, map[string]string{"CNAME": "issues.example.com"})
+	old := time.Now().Add(-time.Hour)
+	if err := os.Chtimes(filepath.Join(dest, "index.html"), old, old); err != nil {
+		t.Fatal(err)
+	}
+
+	// Next export: meta changes, the database shrinks to one chunk
+	if err := os.Remove(filepath.Join(src, "chunks", "00001.bin")); err != nil {
+		t.Fatal(err)
+	}
+	writePagesFiles(t, src, map[string]string{"data/meta.json": `{"n":2}`})
+
+	second, err := SyncPagesDir(src, dest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(second.Written, []string{"data/meta.json"}) {
+		t.Errorf("written = %v, want [data/meta.json]", second.Written)
+	}
+	if !slices.Equal(second.Unchanged, []string{"chunks/00000.bin", "index.html"}) {
+		t.Errorf("unchanged = %v", second.Unchanged)
+	}
+	if !slices.Equal(second.Removed, []string{"chunks/00001.bin"}) {
+		t.Errorf("removed = %v, want [chunks/00001.bin]", second.Removed)
+	}
+	if !second.Changed() {
+		t.Error("expected Changed() after a write")
+	}
+
+	if info, err := os.Stat(filepath.Join(dest, "index.html")); err != nil || !info.ModTime().Equal(old) {
+		t.Errorf("unchanged index.html was rewritten")
+	}
+	if _, err := os.Stat(filepath.Join(dest, "chunks", "00001.bin")); !os.IsNotExist(err) {
+		t.Errorf("stale chunk still present: %v", err)
+	}
+	if data, err := os.ReadFile(filepath.Join(dest, "CNAME")); err != nil || string(data) != "issues.example.com" {
+		t.Errorf("untracked file was modified: %q %v", data, err)
+	}
+
+	manifest, err := LoadPagesManifest(dest)
+	if err != nil || manifest == nil {
+		t.Fatalf("load manifest: %v", err)
+	}
+	if len(manifest.Files) != 3 || manifest.Version != PagesManifestVersion {
+		t.Errorf("unexpected manifest: %+v", manifest)
+	}
+
+	third, err := SyncPagesDir(src, dest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if third.Changed() {
+		t.Errorf("re-sync of identical content changed files: %+v", third)
+	}
+}
+
+func TestLoadPagesManifest_Missing(t *testing.T) {
+	manifest, err := LoadPagesManifest(t.TempDir())
+	if err != nil || manifest != nil {
+		t.Errorf("expected nil manifest and no error, got %v, %v", manifest, err)
+	}
+}
+
+func TestPushPagesBranch(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	remote := filepath.Join(t.TempDir(), "site.git")
+	if out, err := exec.Command("git", "init", "-q", "--bare", remote).CombinedOutput(); err != nil {
+		t.Fatalf("init bare repo: %v\n%s", err, out)
+	}
+
+	bundle := t.TempDir()
+	writePagesFiles(t, bundle, map[string]string{
+		"index.html":       "v1",
+		"chunks/00000.bin": "aaa",
+		"chunks/00001.bin": "bbb",
+	})
+	if _, err := SyncPagesDir(bundle, bundle); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := PagesPushConfig{Remote: remote}
+	first, err := PushPagesBranch(bundle, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !first.Pushed || first.Branch != DefaultPagesBranch || first.Commit == "" {
+		t.Fatalf("first push = %+v", first)
+	}
+
+	// Unchanged bundle: nothing to commit
+	again, err := PushPagesBranch(bundle, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again.Pushed || again.Sync.Changed() {
+		t.Errorf("expected no-op push, got %+v", again)
+	}
+
+	// Drop a chunk and change the page; the commit carries just that delta
+	if err := os.Remove(filepath.Join(bundle, "chunks", "00001.bin")); err != nil {
+		t.Fatal(err)
+	}
+	writePagesFiles(t, bundle, map[string]string{"index.html": "v2"})
+	if _, err := SyncPagesDir(bundle, bundle); err != nil {
+		t.Fatal(err)
+	}
+	second, err := PushPagesBranch(bundle, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !second.Pushed {
+		t.Fatalf("second push = %+v", second)
+	}
+
+	out, err := exec.Command("git", "--git-dir", remote, "diff", "--name-status", first.Commit, second.Commit).Output()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "M\tbv-manifest.json\nD\tchunks/00001.bin\nM\tindex.html"
+	if got := string(out); got != want+"\n" {
+		t.Errorf("delta = %q, want %q", got, want)
+	}
+	count, err := exec.Command("git", "--git-dir", remote, "rev-list", "--count", DefaultPagesBranch).Output()
+	if err != nil || string(count) != "2\n" {
+		t.Errorf("expected 2 commits on %s, got %q (%v)", DefaultPagesBranch, count, err)
+	}
+}
+
+func TestPushPagesBranch_Offline(t *testing.T) {
+	t.Setenv(offline.EnvVar, "1")
+
+	_, err := PushPagesBranch(t.TempDir(), PagesPushConfig{Remote: "https://github.com/acme/site.git"})
+	if !errors.Is(err, offline.ErrOffline) {
+		t.Errorf("remote push offline: err = %v, want ErrOffline", err)
+	}
+
+	if !isLocalRemote(t.TempDir()) || !isLocalRemote("file:///srv/site.git") {
+		t.Error("local paths and file:// remotes are reachable offline")
+	}
+	if isLocalRemote("git@github.com:acme/site.git") {
+		t.Error("ssh remotes need the network")
+	}
+}
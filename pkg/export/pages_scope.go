@@ -0,0 +1,123 @@
+package export
+
+// This file implements access-scoped static exports: a bundle limited to the
+// issues one audience may see, whose references to everything else point at
+// opaque placeholders instead of dangling.
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// PlaceholderPrefix starts the ID of a placeholder standing in for an issue
+// outside an export's scope.
+const PlaceholderPrefix = "hidden-"
+
+// PlaceholderTitle is the title every placeholder carries.
+const PlaceholderTitle = "Hidden issue (outside this export)"
+
+// placeholderPriority is the fixed priority of every placeholder, so
+// priorities of hidden issues don't leak.
+const placeholderPriority = 2
+
+// PagesScope limits a static export to the issues one audience may see.
+// Labels match case-insensitively.
+type PagesScope struct {
+	Labels        []string // Keep only issues with one of these labels; empty keeps all
+	ExcludeLabels []string // Drop issues with any of these labels, even when in Labels
+}
+
+// IsZero reports whether the scope keeps every issue.
+func (s PagesScope) IsZero() bool {
+	return len(s.Labels) == 0 && len(s.ExcludeLabels) == 0
+}
+
+// Includes reports whether an issue is in scope.
+func (s PagesScope) Includes(issue model.Issue) bool {
+	has := func(wanted []string) bool {
+		for _, label := range issue.Labels {
+			for _, w := range wanted {
+				if strings.EqualFold(label, w) {
+					return true
+				}
+			}
+		}
+		return false
+	}
+	if has(s.ExcludeLabels) {
+		return false
+	}
+	return len(s.Labels) == 0 || has(s.Labels)
+}
+
+// ScopeIssues returns the issues in scope followed by one placeholder per
+// out-of-scope issue they depend on, and the number of placeholders. A
+// placeholder has an opaque ID (hidden-1, hidden-2, … in order of first
+// reference), a fixed title, and no labels, text or dependencies of its own,
+// so nothing about the hidden issue leaks beyond whether it is closed. An
+// open one is marked blocked: it still blocks its dependents but is never
+// recommended. Dependencies on IDs missing from issues are left as they are.
+// The input is not modified.
+func ScopeIssues(issues []model.Issue, scope PagesScope) ([]model.Issue, int) {
+	if scope.IsZero() {
+		return issues, 0
+	}
+	byID := make(map[string]*model.Issue, len(issues))
+	for i := range issues {
+		byID[issues[i].ID] = &issues[i]
+	}
+
+	var scoped, placeholders []model.Issue
+	placeholderFor := make(map[string]string)
+	for _, issue := range issues {
+		if !scope.Includes(issue) {
+			continue
+		}
+		if len(issue.Dependencies) > 0 {
+			deps := make([]*model.Dependency, 0, len(issue.Dependencies))
+			for _, dep := range issue.Dependencies {
+				hidden, ok := byID[depTarget(dep)]
+				if dep == nil || !ok || scope.Includes(*hidden) {
+					deps = append(deps, dep)
+					continue
+				}
+				id, seen := placeholderFor[hidden.ID]
+				if !seen {
+					id = fmt.Sprintf("%s%d", PlaceholderPrefix, len(placeholders)+1)
+					placeholderFor[hidden.ID] = id
+					placeholders = append(placeholders, placeholderIssue(id, *hidden))
+				}
+				redirected := *dep
+				redirected.DependsOnID = id
+				deps = append(deps, &redirected)
+			}
+			issue.Dependencies = deps
+		}
+		scoped = append(scoped, issue)
+	}
+	return append(scoped, placeholders...), len(placeholders)
+}
+
+func depTarget(dep *model.Dependency) string {
+	if dep == nil {
+		return ""
+	}
+	return dep.DependsOnID
+}
+
+// placeholderIssue builds the opaque stand-in for a hidden issue.
+func placeholderIssue(id string, hidden model.Issue) model.Issue {
+	status := model.StatusBlocked
+	if hidden.Status == model.StatusClosed {
+		status = model.StatusClosed
+	}
+	return model.Issue{
+		ID:        id,
+		Title:     PlaceholderTitle,
+		Status:    status,
+		IssueType: model.TypeTask,
+		Priority:  placeholderPriority,
+	}
+}
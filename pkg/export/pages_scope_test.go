@@ -0,0 +1,58 @@
+package export
+
+import (
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestScopeIssues(t *testing.T) {
+	blocks := func(from, to string) *model.Dependency {
+		return &model.Dependency{IssueID: from, DependsOnID: to, Type: model.DepBlocks}
+	}
+	issues := []model.Issue{
+		{ID: "api-1", Title: "API", Status: model.StatusOpen, Labels: []string{"api"},
+			Dependencies: []*model.Dependency{blocks("api-1", "sec-1"), blocks("api-1", "infra-1"), blocks("api-1", "gone")}},
+		{ID: "infra-1", Title: "Infra", Status: model.StatusOpen, Labels: []string{"Infra"},
+			Dependencies: []*model.Dependency{blocks("infra-1", "sec-1"), blocks("infra-1", "ui-1")}},
+		{ID: "sec-1", Title: "Secret fix", Status: model.StatusInProgress, Priority: 0, Labels: []string{"api", "security"},
+			Description: "CVE details"},
+		{ID: "ui-1", Title: "UI", Status: model.StatusClosed, Labels: []string{"ui"}},
+	}
+	scope := PagesScope{Labels: []string{"api", "infra"}, ExcludeLabels: []string{"security"}}
+
+	scoped, hidden := ScopeIssues(issues, scope)
+	if hidden != 2 || len(scoped) != 4 {
+		t.Fatalf("scoped %d issues with %d placeholders, want 2 kept + 2 placeholders: %+v", len(scoped), hidden, scoped)
+	}
+	if scoped[0].ID != "api-1" || scoped[1].ID != "infra-1" {
+		t.Errorf("kept issues = %s, %s", scoped[0].ID, scoped[1].ID)
+	}
+
+	api := scoped[0].Dependencies
+	if api[0].DependsOnID != "hidden-1" || api[1].DependsOnID != "infra-1" || api[2].DependsOnID != "gone" {
+		t.Errorf("api-1 deps = %s, %s, %s", api[0].DependsOnID, api[1].DependsOnID, api[2].DependsOnID)
+	}
+	infra := scoped[1].Dependencies
+	if infra[0].DependsOnID != "hidden-1" || infra[1].DependsOnID != "hidden-2" {
+		t.Errorf("infra-1 deps = %s, %s; the same hidden issue should reuse its placeholder", infra[0].DependsOnID, infra[1].DependsOnID)
+	}
+
+	secret, ui := scoped[2], scoped[3]
+	if secret.ID != "hidden-1" || secret.Title != PlaceholderTitle || secret.Description != "" || len(secret.Labels) != 0 {
+		t.Errorf("placeholder leaks the hidden issue: %+v", secret)
+	}
+	if secret.Status != model.StatusBlocked || secret.Priority != placeholderPriority {
+		t.Errorf("open hidden issue placeholder = status %s priority %d", secret.Status, secret.Priority)
+	}
+	if ui.Status != model.StatusClosed {
+		t.Errorf("closed hidden issue placeholder status = %s", ui.Status)
+	}
+
+	if issues[0].Dependencies[0].DependsOnID != "sec-1" {
+		t.Error("ScopeIssues modified its input")
+	}
+	if out, n := ScopeIssues(issues, PagesScope{}); n != 0 || len(out) != len(issues) {
+		t.Error("an empty scope should keep every issue")
+	}
+}
@@ -0,0 +1,262 @@
+// Package export provides data export functionality for bv.
+//
+// This file implements wiki publishing: pushing the Markdown report or the
+// priority brief to Confluence or Notion pages listed in .bv/publish.yaml.
+// Each run replaces the body of the same page, so the wiki always shows the
+// latest status without accumulating copies.
+package export
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/offline"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/renderer/html"
+	"gopkg.in/yaml.v3"
+)
+
+// PublishConfigFile is the publishing config inside .bv/
+const PublishConfigFile = "publish.yaml"
+
+// Publish target types
+const (
+	PublishConfluence = "confluence"
+	PublishNotion     = "notion"
+)
+
+// Publish content kinds
+const (
+	PublishContentMarkdown      = "markdown"       // The --export-md report
+	PublishContentPriorityBrief = "priority-brief" // The --priority-brief summary
+)
+
+// PublishTarget is one page to keep up to date.
+type PublishTarget struct {
+	// Name identifies the target for --publish
+	Name string `yaml:"name"`
+
+	// Type is confluence or notion
+	Type string `yaml:"type"`
+
+	// Content is markdown (default) or priority-brief
+	Content string `yaml:"content,omitempty"`
+
+	// PageID is the existing page whose body is replaced on each run
+	PageID string `yaml:"page_id"`
+
+	// Title, if set, also renames the page
+	Title string `yaml:"title,omitempty"`
+
+	// BaseURL is the Confluence site, e.g. https://acme.atlassian.net/wiki
+	BaseURL string `yaml:"base_url,omitempty"`
+
+	// User is the Confluence account email for API-token auth (default
+	// $CONFLUENCE_USER); without one the token is sent as a bearer token
+	User string `yaml:"user,omitempty"`
+}
+
+// PublishConfig is the content of .bv/publish.yaml.
+type PublishConfig struct {
+	Targets []PublishTarget `yaml:"targets"`
+}
+
+// PublishResult describes one updated page.
+type PublishResult struct {
+	Target string
+	Type   string
+	PageID string
+	URL    string // Page URL, when the API reports one
+
+	// Version is the Confluence page version after the update
+	Version int
+
+	// Blocks is the number of Notion blocks written
+	Blocks int
+}
+
+// PublishOptions carries credentials and transport for PublishTo.
+type PublishOptions struct {
+	// Tokens default to $CONFLUENCE_API_TOKEN and $NOTION_TOKEN
+	ConfluenceToken string
+	NotionToken     string
+
+	// APIBase overrides the Notion API endpoint (default NotionAPIBase)
+	NotionAPIBase string
+
+	// HTTPClient is used for API calls (default http.DefaultClient)
+	HTTPClient *http.Client
+}
+
+// PublishConfigPath returns the path of .bv/publish.yaml under projectDir.
+func PublishConfigPath(projectDir string) string {
+	return filepath.Join(projectDir, ".bv", PublishConfigFile)
+}
+
+// LoadPublishConfig reads and validates .bv/publish.yaml.
+func LoadPublishConfig(projectDir string) (*PublishConfig, error) {
+	path := PublishConfigPath(projectDir)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("no publish targets configured: %w", err)
+		}
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+	var config PublishConfig
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+
+	seen := make(map[string]bool)
+	for i := range config.Targets {
+		t := &config.Targets[i]
+		if t.Name == "" {
+			return nil, fmt.Errorf("%s: target %d has no name", path, i+1)
+		}
+		if seen[t.Name] {
+			return nil, fmt.Errorf("%s: duplicate target %q", path, t.Name)
+		}
+		seen[t.Name] = true
+		if t.Content == "" {
+			t.Content = PublishContentMarkdown
+		}
+		if t.Content != PublishContentMarkdown && t.Content != PublishContentPriorityBrief {
+			return nil, fmt.Errorf("%s: target %q: unknown content %q (want markdown or priority-brief)", path, t.Name, t.Content)
+		}
+		if t.PageID == "" {
+			return nil, fmt.Errorf("%s: target %q: page_id is required", path, t.Name)
+		}
+		switch t.Type {
+		case PublishConfluence:
+			if t.BaseURL == "" {
+				return nil, fmt.Errorf("%s: target %q: base_url is required for confluence", path, t.Name)
+			}
+			t.BaseURL = strings.TrimSuffix(t.BaseURL, "/")
+		case PublishNotion:
+		default:
+			return nil, fmt.Errorf("%s: target %q: unknown type %q (want confluence or notion)", path, t.Name, t.Type)
+		}
+	}
+	return &config, nil
+}
+
+// Select returns the targets named in names, or every target for "all".
+func (c *PublishConfig) Select(names []string) ([]PublishTarget, error) {
+	if len(names) == 1 && names[0] == "all" {
+		if len(c.Targets) == 0 {
+			return nil, fmt.Errorf("no targets in %s", PublishConfigFile)
+		}
+		return c.Targets, nil
+	}
+	var out []PublishTarget
+	for _, name := range names {
+		found := false
+		for _, t := range c.Targets {
+			if t.Name == name {
+				out = append(out, t)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("unknown publish target %q", name)
+		}
+	}
+	return out, nil
+}
+
+// RenderPublishContent renders a content kind for issues: the Markdown
+// report as --export-md writes it, or the priority brief.
+func RenderPublishContent(content string, issues []model.Issue, dataHash string) (string, error) {
+	switch content {
+	case PublishContentMarkdown, "":
+		sorted := make([]model.Issue, len(issues))
+		copy(sorted, issues)
+		sortReportIssues(sorted)
+		return GenerateMarkdown(sorted, "Beads Export")
+	case PublishContentPriorityBrief:
+		triageJSON, err := json.Marshal(analysis.ComputeTriage(issues))
+		if err != nil {
+			return "", err
+		}
+		config := DefaultPriorityBriefConfig()
+		config.DataHash = dataHash
+		return GeneratePriorityBriefFromTriageJSON(triageJSON, config)
+	default:
+		return "", fmt.Errorf("unknown publish content %q", content)
+	}
+}
+
+// PublishTo replaces the body of the target's page with markdown.
+func PublishTo(target PublishTarget, markdown string, opts PublishOptions) (*PublishResult, error) {
+	if err := offline.Check(fmt.Sprintf("publishing to %s", target.Type)); err != nil {
+		return nil, err
+	}
+	if opts.HTTPClient == nil {
+		opts.HTTPClient = http.DefaultClient
+	}
+	switch target.Type {
+	case PublishConfluence:
+		token := opts.ConfluenceToken
+		if token == "" {
+			token = os.Getenv(ConfluenceTokenEnv)
+		}
+		user := target.User
+		if user == "" {
+			user = os.Getenv(ConfluenceUserEnv)
+		}
+		return PublishToConfluence(ConfluencePublishConfig{
+			BaseURL:    target.BaseURL,
+			PageID:     target.PageID,
+			Title:      target.Title,
+			User:       user,
+			Token:      token,
+			Markdown:   markdown,
+			HTTPClient: opts.HTTPClient,
+			target:     target.Name,
+		})
+	case PublishNotion:
+		token := opts.NotionToken
+		if token == "" {
+			token = os.Getenv(NotionTokenEnv)
+		}
+		return PublishToNotion(NotionPublishConfig{
+			PageID:     target.PageID,
+			Title:      target.Title,
+			Token:      token,
+			Markdown:   markdown,
+			APIBase:    opts.NotionAPIBase,
+			HTTPClient: opts.HTTPClient,
+			target:     target.Name,
+		})
+	default:
+		return nil, fmt.Errorf("unknown publish type %q", target.Type)
+	}
+}
+
+// publishMarkdown is the Markdown dialect of bv's reports: CommonMark plus
+// GitHub tables
+var publishMarkdown = goldmark.New(
+	goldmark.WithExtensions(extension.Table, extension.Strikethrough),
+	goldmark.WithRendererOptions(html.WithXHTML()),
+)
+
+// markdownToXHTML renders Markdown as the XHTML Confluence's storage format
+// accepts.
+func markdownToXHTML(markdown string) (string, error) {
+	var buf bytes.Buffer
+	if err := publishMarkdown.Convert([]byte(markdown), &buf); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
@@ -0,0 +1,316 @@
+package export
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/offline"
+)
+
+func writePublishConfig(t *testing.T, content string) string {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, ".bv"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(PublishConfigPath(dir), []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func TestLoadPublishConfig(t *testing.T) {
+	dir := writePublishConfig(t, `targets:
+  - name: wiki
+    type: confluence
+    base_url: https://acme.atlassian.net/wiki/
+    page_id: "42"
+  - name: leads
+    type: notion
+    page_id: abc
+    content: priority-brief
+`)
+	config, err := LoadPublishConfig(dir)
+	if err != nil {
+		t.Fatalf("LoadPublishConfig: %v", err)
+	}
+	if len(config.Targets) != 2 {
+		t.Fatalf("got %d targets, want 2", len(config.Targets))
+	}
+	if got := config.Targets[0]; got.Content != PublishContentMarkdown || got.BaseURL != "https://acme.atlassian.net/wiki" {
+		t.Errorf("defaults not applied: %+v", got)
+	}
+
+	all, err := config.Select([]string{"all"})
+	if err != nil || len(all) != 2 {
+		t.Errorf("Select(all) = %v, %v", all, err)
+	}
+	one, err := config.Select([]string{"leads"})
+	if err != nil || len(one) != 1 || one[0].Type != PublishNotion {
+		t.Errorf("Select(leads) = %v, %v", one, err)
+	}
+	if _, err := config.Select([]string{"nope"}); err == nil {
+		t.Error("Select(nope) should fail")
+	}
+}
+
+func TestLoadPublishConfig_Invalid(t *testing.T) {
+	tests := map[string]string{
+		"no name":      "targets:\n  - {type: notion, page_id: a}\n",
+		"duplicate":    "targets:\n  - {name: a, type: notion, page_id: a}\n  - {name: a, type: notion, page_id: b}\n",
+		"bad type":     "targets:\n  - {name: a, type: wiki, page_id: a}\n",
+		"bad content":  "targets:\n  - {name: a, type: notion, page_id: a, content: csv}\n",
+		"no page":      "targets:\n  - {name: a, type: notion}\n",
+		"no base url":  "targets:\n  - {name: a, type: confluence, page_id: a}\n",
+		"invalid yaml": "targets: [",
+	}
+	for name, content := range tests {
+		if _, err := LoadPublishConfig(writePublishConfig(t, content)); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+
+	if _, err := LoadPublishConfig(t.TempDir()); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("missing config: got %v, want os.ErrNotExist", err)
+	}
+}
+
+func TestPublishToConfluence_BumpsVersion(t *testing.T) {
+	var put map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, pass, ok := r.BasicAuth(); !ok || user != "me@acme.com" || pass != "secret" {
+			http.Error(w, `{"message":"unauthorized"}`, http.StatusUnauthorized)
+			return
+		}
+		if r.URL.Path != "/wiki/rest/api/content/42" {
+			http.NotFound(w, r)
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			io.WriteString(w, `{"id":"42","type":"page","title":"Status","version":{"number":7}}`)
+		case http.MethodPut:
+			if err := json.NewDecoder(r.Body).Decode(&put); err != nil {
+				t.Errorf("decoding update: %v", err)
+			}
+			io.WriteString(w, `{"id":"42","version":{"number":8},"_links":{"base":"https://acme.atlassian.net/wiki","webui":"/pages/42"}}`)
+		}
+	}))
+	defer server.Close()
+
+	result, err := PublishTo(PublishTarget{
+		Name:    "wiki",
+		Type:    PublishConfluence,
+		PageID:  "42",
+		BaseURL: server.URL + "/wiki",
+		User:    "me@acme.com",
+	}, "# Report\n\n| A | B |\n|---|---|\n| 1 | 2 |\n", PublishOptions{ConfluenceToken: "secret"})
+	if err != nil {
+		t.Fatalf("PublishTo: %v", err)
+	}
+	if result.Version != 8 || result.URL != "https://acme.atlassian.net/wiki/pages/42" || result.Target != "wiki" {
+		t.Errorf("unexpected result: %+v", result)
+	}
+
+	if put["title"] != "Status" {
+		t.Errorf("title = %v, want the existing title kept", put["title"])
+	}
+	if version := put["version"].(map[string]any)["number"]; version != float64(8) {
+		t.Errorf("version = %v, want 8", version)
+	}
+	body := put["body"].(map[string]any)["storage"].(map[string]any)["value"].(string)
+	if !strings.Contains(body, "<h1>Report</h1>") || !strings.Contains(body, "<table>") {
+		t.Errorf("storage body not rendered: %s", body)
+	}
+}
+
+func TestPublishToConfluence_ReportsAPIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		io.WriteString(w, `{"message":"No content found with id 42"}`)
+	}))
+	defer server.Close()
+
+	_, err := PublishToConfluence(ConfluencePublishConfig{BaseURL: server.URL, PageID: "42", Token: "t", Markdown: "x"})
+	if err == nil || !strings.Contains(err.Error(), "No content found") {
+		t.Errorf("got %v, want the API message", err)
+	}
+
+	if _, err := PublishToConfluence(ConfluencePublishConfig{BaseURL: server.URL, PageID: "42"}); err == nil || !strings.Contains(err.Error(), ConfluenceTokenEnv) {
+		t.Errorf("missing token: got %v", err)
+	}
+}
+
+func TestPublishToNotion_ReplacesBlocks(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		deleted  []string
+		appended []notionBlock
+		renamed  bool
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		if r.Header.Get("Authorization") != "Bearer secret" || r.Header.Get("Notion-Version") != NotionVersion {
+			http.Error(w, `{"message":"unauthorized"}`, http.StatusUnauthorized)
+			return
+		}
+		switch {
+		case r.Method == http.MethodPatch && r.URL.Path == "/pages/page1":
+			renamed = true
+			io.WriteString(w, `{}`)
+		case r.Method == http.MethodGet && r.URL.Path == "/blocks/page1/children":
+			if r.URL.Query().Get("start_cursor") == "" {
+				io.WriteString(w, `{"results":[{"id":"old1"}],"has_more":true,"next_cursor":"c2"}`)
+			} else {
+				io.WriteString(w, `{"results":[{"id":"old2"}],"has_more":false}`)
+			}
+		case r.Method == http.MethodDelete:
+			deleted = append(deleted, strings.TrimPrefix(r.URL.Path, "/blocks/"))
+			io.WriteString(w, `{}`)
+		case r.Method == http.MethodPatch && r.URL.Path == "/blocks/page1/children":
+			var body struct {
+				Children []notionBlock `json:"children"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Errorf("decoding append: %v", err)
+			}
+			if len(body.Children) > notionMaxBlocksPerCall {
+				t.Errorf("appended %d blocks in one call", len(body.Children))
+			}
+			appended = append(appended, body.Children...)
+			io.WriteString(w, `{}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	var md strings.Builder
+	md.WriteString("# Brief\n\n")
+	for i := 0; i < 150; i++ {
+		md.WriteString("- item\n")
+	}
+	result, err := PublishTo(PublishTarget{Name: "leads", Type: PublishNotion, PageID: "page1", Title: "Weekly"}, md.String(),
+		PublishOptions{NotionToken: "secret", NotionAPIBase: server.URL})
+	if err != nil {
+		t.Fatalf("PublishTo: %v", err)
+	}
+	if !renamed {
+		t.Error("page title not updated")
+	}
+	if strings.Join(deleted, ",") != "old1,old2" {
+		t.Errorf("deleted = %v, want old1,old2", deleted)
+	}
+	if len(appended) != 151 || result.Blocks != 151 {
+		t.Errorf("appended %d blocks (result %d), want 151", len(appended), result.Blocks)
+	}
+	if appended[0]["type"] != "heading_1" {
+		t.Errorf("first block = %v, want heading_1", appended[0]["type"])
+	}
+}
+
+func TestMarkdownToNotionBlocks(t *testing.T) {
+	md := "## Summary\n\nSome **bold** and `code` with a [link](https://example.com) and [anchor](#x).\n\n" +
+		"1. first\n2. second\n   - nested\n\n" +
+		"> quoted\n\n---\n\n" +
+		"```mermaid\ngraph TD\n  A --> B\n```\n\n" +
+		"| ID | Title |\n|----|-------|\n| a-1 | One |\n"
+	blocks := markdownToNotionBlocks(md)
+
+	var types []string
+	for _, b := range blocks {
+		types = append(types, b["type"].(string))
+	}
+	want := "heading_2,paragraph,numbered_list_item,numbered_list_item,bulleted_list_item,quote,divider,code,table"
+	if got := strings.Join(types, ","); got != want {
+		t.Fatalf("block types = %s\nwant %s", got, want)
+	}
+
+	rich := blocks[1]["paragraph"].(map[string]any)["rich_text"].([]map[string]any)
+	var sawBold, sawCode, sawLink bool
+	for _, r := range rich {
+		ann := r["annotations"].(map[string]bool)
+		text := r["text"].(map[string]any)
+		switch text["content"] {
+		case "bold":
+			sawBold = ann["bold"]
+		case "code":
+			sawCode = ann["code"]
+		case "link":
+			sawLink = text["link"] != nil
+		case "anchor":
+			if text["link"] != nil {
+				t.Error("relative link should not be linked in Notion")
+			}
+		}
+	}
+	if !sawBold || !sawCode || !sawLink {
+		t.Errorf("annotations lost: bold=%v code=%v link=%v", sawBold, sawCode, sawLink)
+	}
+
+	code := blocks[7]["code"].(map[string]any)
+	if code["language"] != "mermaid" {
+		t.Errorf("code language = %v, want mermaid", code["language"])
+	}
+	if content := code["rich_text"].([]map[string]any)[0]["text"].(map[string]any)["content"]; content != "graph TD\n  A --> B" {
+		t.Errorf("code content = %q", content)
+	}
+
+	table := blocks[8]["table"].(map[string]any)
+	if table["table_width"] != 2 || len(table["children"].([]notionBlock)) != 2 {
+		t.Errorf("table = %+v, want 2 columns and 2 rows", table)
+	}
+}
+
+func TestNotionRunSplitsLongText(t *testing.T) {
+	runs := notionText(strings.Repeat("é", notionMaxTextLen+5))
+	if len(runs) != 2 {
+		t.Fatalf("got %d runs, want 2", len(runs))
+	}
+	if n := len([]rune(runs[0]["text"].(map[string]any)["content"].(string))); n != notionMaxTextLen {
+		t.Errorf("first run has %d characters, want %d", n, notionMaxTextLen)
+	}
+}
+
+func TestRenderPublishContent(t *testing.T) {
+	issues := []model.Issue{{ID: "a-1", Title: "One", Status: model.StatusOpen, Priority: 1, IssueType: model.TypeTask}}
+
+	md, err := RenderPublishContent(PublishContentMarkdown, issues, "")
+	if err != nil || !strings.Contains(md, "# Beads Export") || !strings.Contains(md, "a-1") {
+		t.Errorf("markdown = %q, %v", md, err)
+	}
+	brief, err := RenderPublishContent(PublishContentPriorityBrief, issues, "h1")
+	if err != nil || !strings.Contains(brief, "a-1") {
+		t.Errorf("priority brief = %q, %v", brief, err)
+	}
+	if _, err := RenderPublishContent("csv", issues, ""); err == nil {
+		t.Error("unknown content should fail")
+	}
+}
+
+func TestPublishTo_Offline(t *testing.T) {
+	t.Setenv(offline.EnvVar, "1")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("offline publish reached the server: %s %s", r.Method, r.URL)
+	}))
+	defer server.Close()
+
+	_, err := PublishTo(PublishTarget{Name: "wiki", Type: PublishConfluence, BaseURL: server.URL, PageID: "42"}, "# Status", PublishOptions{HTTPClient: server.Client()})
+	if !errors.Is(err, offline.ErrOffline) {
+		t.Errorf("err = %v, want ErrOffline", err)
+	}
+
+	if _, err := DeployBundle(&WizardConfig{DeployTarget: "netlify"}, t.TempDir(), false); !errors.Is(err, offline.ErrOffline) {
+		t.Errorf("DeployBundle offline: err = %v, want ErrOffline", err)
+	}
+}
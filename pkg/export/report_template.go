@@ -0,0 +1,357 @@
+package export
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/i18n"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+//go:embed templates/*.md.tmpl
+var builtinReportTemplatesFS embed.FS
+
+const (
+	// ReportTemplateDir holds project report templates, relative to the project root.
+	ReportTemplateDir = ".bv/templates"
+	// DefaultReportTemplate is used by --export-md when ReportTemplateDir
+	// contains report.md.tmpl and no template was requested explicitly.
+	DefaultReportTemplate = "report"
+
+	reportTemplateExt = ".md.tmpl"
+)
+
+// ReportTemplate is a resolved Markdown report template.
+type ReportTemplate struct {
+	Name   string // Template name (file name without .md.tmpl)
+	Source string // "builtin", "project", or "file"
+	Text   string
+}
+
+// BuiltinReportTemplates returns the names of the bundled report templates.
+func BuiltinReportTemplates() []string {
+	entries, err := fs.ReadDir(builtinReportTemplatesFS, "templates")
+	if err != nil {
+		return nil
+	}
+	var names []string
+	for _, e := range entries {
+		names = append(names, strings.TrimSuffix(e.Name(), reportTemplateExt))
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ResolveReportTemplate finds a report template by name. Lookup order is a
+// file path, then <projectDir>/.bv/templates/<name>.md.tmpl, then the
+// built-ins. An empty name selects the project's report.md.tmpl if one
+// exists and returns nil otherwise, meaning the fixed report should be used.
+func ResolveReportTemplate(projectDir, name string) (*ReportTemplate, error) {
+	if name == "" {
+		path := filepath.Join(projectDir, ReportTemplateDir, DefaultReportTemplate+reportTemplateExt)
+		data, err := os.ReadFile(path)
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read report template: %w", err)
+		}
+		return &ReportTemplate{Name: DefaultReportTemplate, Source: "project", Text: string(data)}, nil
+	}
+
+	if strings.ContainsRune(name, filepath.Separator) || strings.HasSuffix(name, ".tmpl") {
+		data, err := os.ReadFile(name)
+		if err != nil {
+			return nil, fmt.Errorf("read report template: %w", err)
+		}
+		base := strings.TrimSuffix(strings.TrimSuffix(filepath.Base(name), ".tmpl"), ".md")
+		return &ReportTemplate{Name: base, Source: "file", Text: string(data)}, nil
+	}
+
+	path := filepath.Join(projectDir, ReportTemplateDir, name+reportTemplateExt)
+	if data, err := os.ReadFile(path); err == nil {
+		return &ReportTemplate{Name: name, Source: "project", Text: string(data)}, nil
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("read report template: %w", err)
+	}
+
+	data, err := builtinReportTemplatesFS.ReadFile("templates/" + name + reportTemplateExt)
+	if err != nil {
+		return nil, fmt.Errorf("unknown report template %q (built-in: %s; or add %s/%s%s)",
+			name, strings.Join(BuiltinReportTemplates(), ", "), ReportTemplateDir, name, reportTemplateExt)
+	}
+	return &ReportTemplate{Name: name, Source: "builtin", Text: string(data)}, nil
+}
+
+// ReportCounts summarizes issues by status.
+type ReportCounts struct {
+	Total      int
+	Open       int
+	InProgress int
+	Blocked    int
+	Closed     int
+}
+
+// ReportData is the value report templates are executed against.
+type ReportData struct {
+	Title       string
+	GeneratedAt time.Time
+	Issues      []model.Issue // Open first, then by priority, then newest
+	Open        []model.Issue
+	InProgress  []model.Issue
+	Blocked     []model.Issue
+	Closed      []model.Issue
+	Counts      ReportCounts
+
+	Insights analysis.Insights
+	Triage   *analysis.TriageResult
+
+	Sprints      []model.Sprint
+	ActiveSprint *model.Sprint // Sprint covering GeneratedAt, if any
+	SprintIssues []model.Issue // Issues in ActiveSprint
+
+	Graph      string // Mermaid source of the dependency graph
+	GraphImage string // Relative path of a rendered graph image, when requested
+}
+
+// NewReportData builds template data, running graph analysis and triage.
+func NewReportData(issues []model.Issue, sprints []model.Sprint, title string, now time.Time) ReportData {
+	sorted := make([]model.Issue, len(issues))
+	copy(sorted, issues)
+	sortReportIssues(sorted)
+
+	data := ReportData{
+		Title:       title,
+		GeneratedAt: now,
+		Issues:      sorted,
+		Sprints:     sprints,
+	}
+	for _, i := range sorted {
+		switch i.Status {
+		case model.StatusClosed:
+			data.Closed = append(data.Closed, i)
+		case model.StatusInProgress, model.StatusInReview:
+			data.InProgress = append(data.InProgress, i)
+		case model.StatusBlocked:
+			data.Blocked = append(data.Blocked, i)
+		default:
+			data.Open = append(data.Open, i)
+		}
+	}
+	data.Counts = ReportCounts{
+		Total:      len(sorted),
+		Open:       len(data.Open),
+		InProgress: len(data.InProgress),
+		Blocked:    len(data.Blocked),
+		Closed:     len(data.Closed),
+	}
+
+	stats := analysis.NewAnalyzer(sorted).Analyze()
+	data.Insights = stats.GenerateInsights(10)
+	triage := analysis.ComputeTriage(sorted)
+	data.Triage = &triage
+
+	for idx := range sprints {
+		s := sprints[idx]
+		if !s.StartDate.IsZero() && !s.EndDate.IsZero() && !now.Before(s.StartDate) && !now.After(s.EndDate) {
+			data.ActiveSprint = &sprints[idx]
+			break
+		}
+	}
+	if data.ActiveSprint != nil {
+		inSprint := make(map[string]bool, len(data.ActiveSprint.BeadIDs))
+		for _, id := range data.ActiveSprint.BeadIDs {
+			inSprint[id] = true
+		}
+		for _, i := range sorted {
+			if inSprint[i.ID] {
+				data.SprintIssues = append(data.SprintIssues, i)
+			}
+		}
+	}
+
+	issueIDs := make(map[string]bool, len(sorted))
+	for _, i := range sorted {
+		issueIDs[i.ID] = true
+	}
+	data.Graph = GenerateMermaidGraph(sorted, issueIDs, MermaidConfig{ShowNoDependenciesNode: true})
+
+	return data
+}
+
+// ClosedWithin returns issues closed in the last n days, newest first.
+func (d ReportData) ClosedWithin(days int) []model.Issue {
+	cutoff := d.GeneratedAt.AddDate(0, 0, -days)
+	var out []model.Issue
+	for _, i := range d.Closed {
+		if i.ClosedAt != nil && !i.ClosedAt.Before(cutoff) {
+			out = append(out, i)
+		}
+	}
+	sort.SliceStable(out, func(a, b int) bool { return out[a].ClosedAt.After(*out[b].ClosedAt) })
+	return out
+}
+
+// UpdatedWithin returns non-closed issues updated in the last n days.
+func (d ReportData) UpdatedWithin(days int) []model.Issue {
+	cutoff := d.GeneratedAt.AddDate(0, 0, -days)
+	var out []model.Issue
+	for _, i := range d.Issues {
+		if i.Status != model.StatusClosed && !i.UpdatedAt.Before(cutoff) {
+			out = append(out, i)
+		}
+	}
+	return out
+}
+
+// WithLabel returns issues carrying the given label (case-insensitive).
+func (d ReportData) WithLabel(label string) []model.Issue {
+	var out []model.Issue
+	for _, i := range d.Issues {
+		for _, l := range i.Labels {
+			if strings.EqualFold(l, label) {
+				out = append(out, i)
+				break
+			}
+		}
+	}
+	return out
+}
+
+// Issue looks up an issue by ID, returning nil if absent.
+func (d ReportData) Issue(id string) *model.Issue {
+	for idx := range d.Issues {
+		if d.Issues[idx].ID == id {
+			return &d.Issues[idx]
+		}
+	}
+	return nil
+}
+
+// IssueGroup is a named list of issues, used for grouped sections.
+type IssueGroup struct {
+	Name   string
+	Issues []model.Issue
+}
+
+// reportFuncs are the helpers available inside report templates.
+var reportFuncs = template.FuncMap{
+	"statusEmoji": func(s model.Status) string { return getStatusEmoji(string(s)) },
+	"typeEmoji":   func(t model.IssueType) string { return getTypeEmoji(string(t)) },
+	"priority":    getPriorityLabel,
+	"slug":        createSlug,
+	"join":        strings.Join,
+	"lower":       strings.ToLower,
+	"upper":       strings.ToUpper,
+	"t":           i18n.T,
+	"tf":          i18n.Tf,
+	"cell":        markdownCell,
+	"truncate": func(n int, s string) string {
+		runes := []rune(s)
+		if n <= 0 || len(runes) <= n {
+			return s
+		}
+		return string(runes[:n-1]) + "…"
+	},
+	"date": func(layout string, t any) string {
+		switch v := t.(type) {
+		case time.Time:
+			if v.IsZero() {
+				return ""
+			}
+			return v.Format(layout)
+		case *time.Time:
+			if v == nil || v.IsZero() {
+				return ""
+			}
+			return v.Format(layout)
+		default:
+			return ""
+		}
+	},
+	"groupByType": groupIssuesByType,
+}
+
+// RenderReportTemplate executes a report template against data.
+func RenderReportTemplate(tmpl *ReportTemplate, data ReportData) (string, error) {
+	t, err := template.New(tmpl.Name).Funcs(reportFuncs).Option("missingkey=error").Parse(tmpl.Text)
+	if err != nil {
+		return "", fmt.Errorf("parse template %s: %w", tmpl.Name, err)
+	}
+	var sb strings.Builder
+	if err := t.Execute(&sb, data); err != nil {
+		return "", fmt.Errorf("render template %s: %w", tmpl.Name, err)
+	}
+	return sb.String(), nil
+}
+
+// groupIssuesByType groups issues into release-notes style sections.
+func groupIssuesByType(issues []model.Issue) []IssueGroup {
+	order := []struct {
+		name  string
+		types []model.IssueType
+	}{
+		{"Features", []model.IssueType{model.TypeFeature, model.TypeEpic}},
+		{"Bug Fixes", []model.IssueType{model.TypeBug}},
+		{"Tasks", []model.IssueType{model.TypeTask}},
+		{"Chores", []model.IssueType{model.TypeChore}},
+	}
+	used := make(map[string]bool)
+	var groups []IssueGroup
+	for _, o := range order {
+		var members []model.Issue
+		for _, i := range issues {
+			for _, t := range o.types {
+				if i.IssueType == t {
+					members = append(members, i)
+					used[i.ID] = true
+					break
+				}
+			}
+		}
+		if len(members) > 0 {
+			groups = append(groups, IssueGroup{Name: o.name, Issues: members})
+		}
+	}
+	var other []model.Issue
+	for _, i := range issues {
+		if !used[i.ID] {
+			other = append(other, i)
+		}
+	}
+	if len(other) > 0 {
+		groups = append(groups, IssueGroup{Name: "Other", Issues: other})
+	}
+	return groups
+}
+
+// markdownCell makes text safe for a single GFM table cell.
+func markdownCell(s string) string {
+	s = strings.ReplaceAll(s, "\r", "")
+	s = strings.ReplaceAll(s, "\n", " ")
+	return strings.ReplaceAll(s, "|", "\\|")
+}
+
+// sortReportIssues orders issues open first, then by priority, then newest.
+func sortReportIssues(issues []model.Issue) {
+	sort.SliceStable(issues, func(i, j int) bool {
+		iClosed := issues[i].Status == model.StatusClosed
+		jClosed := issues[j].Status == model.StatusClosed
+		if iClosed != jClosed {
+			return !iClosed
+		}
+		if issues[i].Priority != issues[j].Priority {
+			return issues[i].Priority < issues[j].Priority
+		}
+		return issues[i].CreatedAt.After(issues[j].CreatedAt)
+	})
+}
@@ -0,0 +1,211 @@
+package export
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func reportTemplateFixture(now time.Time) []model.Issue {
+	yesterday := now.Add(-6 * time.Hour)
+	lastWeek := now.AddDate(0, 0, -5)
+	longAgo := now.AddDate(0, -2, 0)
+	return []model.Issue{
+		{ID: "bv-1", Title: "Ship login", Status: model.StatusClosed, IssueType: model.TypeFeature, Priority: 1, CreatedAt: longAgo, UpdatedAt: yesterday, ClosedAt: &yesterday},
+		{ID: "bv-2", Title: "Fix crash | on start", Status: model.StatusClosed, IssueType: model.TypeBug, Priority: 0, CreatedAt: longAgo, UpdatedAt: lastWeek, ClosedAt: &lastWeek},
+		{ID: "bv-3", Title: "Old cleanup", Status: model.StatusClosed, IssueType: model.TypeChore, Priority: 3, CreatedAt: longAgo, UpdatedAt: longAgo, ClosedAt: &longAgo},
+		{ID: "bv-4", Title: "Write docs", Status: model.StatusInProgress, IssueType: model.TypeTask, Priority: 2, Assignee: "sam", CreatedAt: longAgo, UpdatedAt: now, Labels: []string{"docs"}},
+		{ID: "bv-5", Title: "Add search", Status: model.StatusOpen, IssueType: model.TypeFeature, Priority: 1, CreatedAt: longAgo, UpdatedAt: longAgo},
+		{ID: "bv-6", Title: "Search UI", Status: model.StatusBlocked, IssueType: model.TypeFeature, Priority: 2, CreatedAt: longAgo, UpdatedAt: longAgo,
+			Dependencies: []*model.Dependency{{IssueID: "bv-6", DependsOnID: "bv-5", Type: model.DepBlocks}}},
+	}
+}
+
+func TestResolveReportTemplate(t *testing.T) {
+	dir := t.TempDir()
+
+	// No name and no project template: use the fixed report
+	tmpl, err := ResolveReportTemplate(dir, "")
+	if err != nil || tmpl != nil {
+		t.Fatalf("expected nil template, got %v, %v", tmpl, err)
+	}
+
+	for _, name := range []string{"status", "standup", "release-notes"} {
+		tmpl, err := ResolveReportTemplate(dir, name)
+		if err != nil {
+			t.Fatalf("builtin %s: %v", name, err)
+		}
+		if tmpl.Source != "builtin" || tmpl.Text == "" {
+			t.Errorf("builtin %s: got source %q", name, tmpl.Source)
+		}
+	}
+
+	if _, err := ResolveReportTemplate(dir, "nope"); err == nil || !strings.Contains(err.Error(), "release-notes") {
+		t.Errorf("expected unknown-template error listing built-ins, got %v", err)
+	}
+
+	// Project templates override built-ins and provide the default report
+	tmplDir := filepath.Join(dir, ReportTemplateDir)
+	if err := os.MkdirAll(tmplDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(tmplDir, "status.md.tmpl"), []byte("custom status"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(tmplDir, "report.md.tmpl"), []byte("custom report"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	tmpl, err = ResolveReportTemplate(dir, "status")
+	if err != nil || tmpl.Source != "project" || tmpl.Text != "custom status" {
+		t.Errorf("project override: got %+v, %v", tmpl, err)
+	}
+	tmpl, err = ResolveReportTemplate(dir, "")
+	if err != nil || tmpl == nil || tmpl.Text != "custom report" {
+		t.Errorf("project default: got %+v, %v", tmpl, err)
+	}
+
+	// Explicit file paths
+	path := filepath.Join(dir, "weekly.md.tmpl")
+	if err := os.WriteFile(path, []byte("weekly"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	tmpl, err = ResolveReportTemplate(dir, path)
+	if err != nil || tmpl.Name != "weekly" || tmpl.Text != "weekly" {
+		t.Errorf("file path: got %+v, %v", tmpl, err)
+	}
+}
+
+func TestNewReportData(t *testing.T) {
+	now := time.Date(2025, 6, 15, 12, 0, 0, 0, time.UTC)
+	sprints := []model.Sprint{
+		{ID: "s0", Name: "Old", StartDate: now.AddDate(0, 0, -30), EndDate: now.AddDate(0, 0, -16)},
+		{ID: "s1", Name: "Current", StartDate: now.AddDate(0, 0, -3), EndDate: now.AddDate(0, 0, 11), BeadIDs: []string{"bv-4", "bv-5", "missing"}},
+	}
+	data := NewReportData(reportTemplateFixture(now), sprints, "Test", now)
+
+	if data.Counts != (ReportCounts{Total: 6, Open: 1, InProgress: 1, Blocked: 1, Closed: 3}) {
+		t.Errorf("unexpected counts %+v", data.Counts)
+	}
+	if data.Issues[len(data.Issues)-1].Status != model.StatusClosed {
+		t.Errorf("closed issues should sort last")
+	}
+	if data.ActiveSprint == nil || data.ActiveSprint.ID != "s1" {
+		t.Fatalf("expected active sprint s1, got %+v", data.ActiveSprint)
+	}
+	if len(data.SprintIssues) != 2 {
+		t.Errorf("expected 2 sprint issues, got %d", len(data.SprintIssues))
+	}
+	if data.Triage == nil || len(data.Triage.QuickRef.TopPicks) == 0 {
+		t.Errorf("expected triage top picks")
+	}
+	if !strings.Contains(data.Graph, "graph TD") {
+		t.Errorf("expected mermaid graph, got %q", data.Graph)
+	}
+
+	closed := data.ClosedWithin(7)
+	if len(closed) != 2 || closed[0].ID != "bv-1" {
+		t.Errorf("ClosedWithin(7) = %v, want [bv-1 bv-2]", closed)
+	}
+	if got := data.UpdatedWithin(1); len(got) != 1 || got[0].ID != "bv-4" {
+		t.Errorf("UpdatedWithin(1) = %v, want [bv-4]", got)
+	}
+	if got := data.WithLabel("DOCS"); len(got) != 1 || got[0].ID != "bv-4" {
+		t.Errorf("WithLabel = %v, want [bv-4]", got)
+	}
+	if data.Issue("bv-5") == nil || data.Issue("nope") != nil {
+		t.Errorf("Issue lookup mismatch")
+	}
+}
+
+func TestRenderReportTemplate_Builtins(t *testing.T) {
+	now := time.Date(2025, 6, 15, 12, 0, 0, 0, time.UTC)
+	sprints := []model.Sprint{{ID: "s1", Name: "Sprint 7", StartDate: now.AddDate(0, 0, -3), EndDate: now.AddDate(0, 0, 11), BeadIDs: []string{"bv-4"}}}
+	data := NewReportData(reportTemplateFixture(now), sprints, "Beads Export", now)
+
+	tests := []struct {
+		name     string
+		contains []string
+		excludes []string
+	}{
+		{"status", []string{"Status Report", "| 6 | 1 | 1 | 1 | 3 |", "## Sprint: Sprint 7", "## Top Picks", "bv-4** Write docs (@sam)", "```mermaid"}, nil},
+		{"standup", []string{"# Standup — Sun Jun 15, 2025", "✅ **bv-1** Ship login", "🔵 **bv-4** Write docs (@sam)", "🔴 **bv-6** Search UI"}, []string{"bv-2** Fix"}},
+		{"release-notes", []string{"## Features\n\n- Ship login (bv-1)", "## Bug Fixes\n\n- Fix crash | on start (bv-2)"}, []string{"Old cleanup", "Chores"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpl, err := ResolveReportTemplate(t.TempDir(), tt.name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			out, err := RenderReportTemplate(tmpl, data)
+			if err != nil {
+				t.Fatalf("render: %v", err)
+			}
+			for _, want := range tt.contains {
+				if !strings.Contains(out, want) {
+					t.Errorf("missing %q in:\n%s", want, out)
+				}
+			}
+			for _, unwanted := range tt.excludes {
+				if strings.Contains(out, unwanted) {
+					t.Errorf("unexpected %q in:\n%s", unwanted, out)
+				}
+			}
+		})
+	}
+}
+
+func TestRenderReportTemplate_Errors(t *testing.T) {
+	data := NewReportData(nil, nil, "Empty", time.Now())
+
+	if _, err := RenderReportTemplate(&ReportTemplate{Name: "bad", Text: "{{ .Nope"}, data); err == nil || !strings.Contains(err.Error(), "parse template bad") {
+		t.Errorf("expected parse error, got %v", err)
+	}
+	if _, err := RenderReportTemplate(&ReportTemplate{Name: "missing", Text: "{{ .Nope }}"}, data); err == nil || !strings.Contains(err.Error(), "render template missing") {
+		t.Errorf("expected render error, got %v", err)
+	}
+}
+
+func TestRenderReportTemplate_Funcs(t *testing.T) {
+	now := time.Date(2025, 6, 15, 12, 0, 0, 0, time.UTC)
+	data := NewReportData(reportTemplateFixture(now), nil, "Funcs", now)
+	text := `{{ with .Issue "bv-2" }}{{ cell .Title }}|{{ truncate 6 .Title }}|{{ priority .Priority }}|{{ slug .ID }}|{{ upper .ID }}|{{ date "01/02" .ClosedAt }}{{ end }}`
+
+	out, err := RenderReportTemplate(&ReportTemplate{Name: "funcs", Text: text}, data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `Fix crash \| on start|Fix c…|` + getPriorityLabel(0) + `|` + createSlug("bv-2") + `|BV-2|06/10`
+	if out != want {
+		t.Errorf("got %q, want %q", out, want)
+	}
+}
+
+func TestSaveMarkdownToFileWithOptions_Template(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "standup.md")
+	tmpl := &ReportTemplate{Name: "custom", Text: "{{ .Counts.Total }} issues{{ with .ActiveSprint }} in {{ .Name }}{{ end }}; graph={{ .GraphImage }}"}
+	sprints := []model.Sprint{{ID: "s1", Name: "Now", StartDate: time.Now().Add(-time.Hour), EndDate: time.Now().Add(time.Hour)}}
+
+	if err := SaveMarkdownToFileWithOptions(reportTemplateFixture(time.Now()), path, MarkdownExportOptions{
+		GraphImage: "svg",
+		Template:   tmpl,
+		Sprints:    sprints,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(content), "6 issues in Now; graph=standup-graph.svg"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "standup-graph.svg")); err != nil {
+		t.Errorf("expected graph image: %v", err)
+	}
+}
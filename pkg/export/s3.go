@@ -0,0 +1,156 @@
+// Package export provides data export functionality for bv.
+//
+// This file implements Amazon S3 (+ optional CloudFront) deployment via the
+// aws CLI. Credentials come from the usual aws CLI sources (environment,
+// profile, instance role), so nothing secret passes through bv.
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// S3DeployConfig configures S3 deployment.
+type S3DeployConfig struct {
+	// Bucket is "s3://bucket/prefix", "bucket/prefix" or just "bucket"
+	Bucket string
+
+	// CloudFrontDistribution is an optional distribution ID to invalidate
+	CloudFrontDistribution string
+
+	// BundlePath is the path to the static site bundle to deploy
+	BundlePath string
+}
+
+// S3DeployResult contains the result of a deployment.
+type S3DeployResult struct {
+	// Bucket and Prefix identify where the site was uploaded
+	Bucket string
+	Prefix string
+
+	// URL is the CloudFront URL when a distribution was given, else the S3 object URL
+	URL string
+
+	// InvalidationID is the CloudFront invalidation created, if any
+	InvalidationID string
+}
+
+// AWSStatus represents the current status of the aws CLI.
+type AWSStatus struct {
+	Installed     bool
+	Authenticated bool
+	Account       string
+	ARN           string
+}
+
+// CheckAWSStatus checks the status of the aws CLI.
+func CheckAWSStatus() (*AWSStatus, error) {
+	status := &AWSStatus{}
+
+	if _, err := exec.LookPath("aws"); err != nil {
+		return status, nil
+	}
+	status.Installed = true
+
+	output, err := exec.Command("aws", "sts", "get-caller-identity", "--output", "json").Output()
+	if err != nil {
+		return status, nil
+	}
+	var identity struct {
+		Account string `json:"Account"`
+		Arn     string `json:"Arn"`
+	}
+	if json.Unmarshal(output, &identity) == nil && identity.Account != "" {
+		status.Authenticated = true
+		status.Account = identity.Account
+		status.ARN = identity.Arn
+	}
+	return status, nil
+}
+
+// ShowAWSInstallInstructions prints aws CLI installation instructions.
+func ShowAWSInstallInstructions() {
+	fmt.Println("\naws CLI is not installed.")
+	fmt.Println("\nInstallation options:")
+	fmt.Println("  macOS:   brew install awscli")
+	fmt.Println("  Linux:   https://docs.aws.amazon.com/cli/latest/userguide/getting-started-install.html")
+	fmt.Println("")
+	fmt.Println("Then configure credentials with 'aws configure' or AWS_ACCESS_KEY_ID/AWS_SECRET_ACCESS_KEY.")
+}
+
+// ParseS3Location splits "s3://bucket/prefix" (scheme optional) into bucket
+// and a prefix that is empty or ends in "/".
+func ParseS3Location(location string) (bucket, prefix string, err error) {
+	location = strings.TrimPrefix(strings.TrimSpace(location), "s3://")
+	bucket, prefix, _ = strings.Cut(location, "/")
+	if bucket == "" {
+		return "", "", fmt.Errorf("invalid S3 location %q: missing bucket", location)
+	}
+	prefix = strings.Trim(prefix, "/")
+	if prefix != "" {
+		prefix += "/"
+	}
+	return bucket, prefix, nil
+}
+
+// DeployToS3 syncs the bundle to S3 and, when configured, invalidates the
+// CloudFront distribution in front of it. aws s3 sync only uploads files
+// whose size or timestamp changed.
+func DeployToS3(config S3DeployConfig) (*S3DeployResult, error) {
+	bucket, prefix, err := ParseS3Location(config.Bucket)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := os.Stat(config.BundlePath); os.IsNotExist(err) {
+		return nil, fmt.Errorf("bundle path does not exist: %s", config.BundlePath)
+	}
+	if _, err := exec.LookPath("aws"); err != nil {
+		ShowAWSInstallInstructions()
+		return nil, fmt.Errorf("aws CLI is required for S3 deployment")
+	}
+
+	target := "s3://" + bucket + "/" + prefix
+	fmt.Printf("\n  -> Syncing bundle to %s...\n", target)
+	cmd := exec.Command("aws", "s3", "sync", config.BundlePath, target,
+		"--exclude", ".git/*",
+		"--no-progress",
+	)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return nil, fmt.Errorf("s3 sync failed: %s", strings.TrimSpace(string(output)))
+	}
+
+	result := &S3DeployResult{
+		Bucket: bucket,
+		Prefix: prefix,
+		URL:    fmt.Sprintf("https://%s.s3.amazonaws.com/%sindex.html", bucket, prefix),
+	}
+
+	if config.CloudFrontDistribution != "" {
+		fmt.Printf("  -> Invalidating CloudFront distribution %s...\n", config.CloudFrontDistribution)
+		output, err := exec.Command("aws", "cloudfront", "create-invalidation",
+			"--distribution-id", config.CloudFrontDistribution,
+			"--paths", "/"+prefix+"*",
+			"--query", "Invalidation.Id",
+			"--output", "text",
+		).CombinedOutput()
+		if err != nil {
+			return nil, fmt.Errorf("cloudfront invalidation failed: %s", strings.TrimSpace(string(output)))
+		}
+		result.InvalidationID = strings.TrimSpace(string(output))
+
+		domain, err := exec.Command("aws", "cloudfront", "get-distribution",
+			"--id", config.CloudFrontDistribution,
+			"--query", "Distribution.DomainName",
+			"--output", "text",
+		).Output()
+		if d := strings.TrimSpace(string(domain)); err == nil && d != "" {
+			result.URL = "https://" + d + "/" + prefix
+		}
+	}
+
+	fmt.Println("  -> Deployment complete!")
+	return result, nil
+}
@@ -0,0 +1,95 @@
+package export
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestParseS3Location(t *testing.T) {
+	tests := []struct {
+		in, bucket, prefix string
+	}{
+		{"s3://my-bucket", "my-bucket", ""},
+		{"s3://my-bucket/", "my-bucket", ""},
+		{"s3://my-bucket/issues", "my-bucket", "issues/"},
+		{"my-bucket/team/issues/", "my-bucket", "team/issues/"},
+	}
+	for _, tt := range tests {
+		bucket, prefix, err := ParseS3Location(tt.in)
+		if err != nil || bucket != tt.bucket || prefix != tt.prefix {
+			t.Errorf("ParseS3Location(%q) = %q, %q, %v; want %q, %q", tt.in, bucket, prefix, err, tt.bucket, tt.prefix)
+		}
+	}
+	if _, _, err := ParseS3Location("s3://"); err == nil {
+		t.Error("expected error for missing bucket")
+	}
+}
+
+func TestDeployToS3_SyncAndInvalidate(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("shell script stubs not supported on windows in this test")
+	}
+
+	binDir := t.TempDir()
+	logFile := filepath.Join(t.TempDir(), "aws.log")
+
+	// aws stub that records its arguments
+	awsScript := `#!/bin/sh
+echo "$@" >> "$BV_TEST_AWS_LOG"
+case "${1-} ${2-}" in
+  "cloudfront create-invalidation") echo "I2J0I21PCUYOIK" ;;
+  "cloudfront get-distribution") echo "d111111abcdef8.cloudfront.net" ;;
+esac
+exit 0
+`
+	writeExecutable(t, binDir, "aws", awsScript)
+	t.Setenv("PATH", fmt.Sprintf("%s%c%s", binDir, os.PathListSeparator, os.Getenv("PATH")))
+	t.Setenv("BV_TEST_AWS_LOG", logFile)
+
+	bundle := t.TempDir()
+	result, err := DeployToS3(S3DeployConfig{
+		Bucket:                 "s3://my-bucket/issues",
+		CloudFrontDistribution: "E2QWRUHEXAMPLE",
+		BundlePath:             bundle,
+	})
+	if err != nil {
+		t.Fatalf("DeployToS3: %v", err)
+	}
+	if result.InvalidationID != "I2J0I21PCUYOIK" || result.URL != "https://d111111abcdef8.cloudfront.net/issues/" {
+		t.Errorf("result = %+v", result)
+	}
+
+	data, err := os.ReadFile(logFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	log := string(data)
+	if !strings.Contains(log, "s3 sync "+bundle+" s3://my-bucket/issues/") {
+		t.Errorf("expected s3 sync to the prefix, got:\n%s", log)
+	}
+	if strings.Contains(log, "--delete") {
+		t.Errorf("s3 sync should not delete objects, got:\n%s", log)
+	}
+	if !strings.Contains(log, "--paths /issues/*") {
+		t.Errorf("expected invalidation of the prefix, got:\n%s", log)
+	}
+}
+
+func TestDeployToS3_SyncFailureSurfaced(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("shell script stubs not supported on windows in this test")
+	}
+
+	binDir := t.TempDir()
+	writeExecutable(t, binDir, "aws", "#!/bin/sh\necho 'An error occurred (AccessDenied)' >&2\nexit 1\n")
+	t.Setenv("PATH", fmt.Sprintf("%s%c%s", binDir, os.PathListSeparator, os.Getenv("PATH")))
+
+	_, err := DeployToS3(S3DeployConfig{Bucket: "my-bucket", BundlePath: t.TempDir()})
+	if err == nil || !strings.Contains(err.Error(), "AccessDenied") {
+		t.Errorf("expected sync error to surface, got %v", err)
+	}
+}
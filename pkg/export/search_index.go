@@ -0,0 +1,130 @@
+package export
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"math"
+	"path/filepath"
+	"sort"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/search"
+)
+
+// StaticSearchIndex is the vector index shipped with the Pages bundle as
+// data/search_index.json. The viewer embeds queries in the browser, so the
+// index always uses the hash embedder (semantic_search.js reimplements it)
+// regardless of BV_SEMANTIC_EMBEDDER. Vectors are int8-quantized per row:
+// component j of row i is int8(Vectors[i*Dim+j]) * Scales[i].
+type StaticSearchIndex struct {
+	Version  int       `json:"version"`
+	Provider string    `json:"provider"`
+	Dim      int       `json:"dim"`
+	IDs      []string  `json:"ids"`
+	Scales   []float32 `json:"scales"`
+	Vectors  string    `json:"vectors"` // base64 of len(IDs)*Dim int8 values
+}
+
+// StaticSearchIndexVersion is bumped when the embedding or encoding changes.
+const StaticSearchIndexVersion = 1
+
+// BuildStaticSearchIndex embeds every issue with the hash embedder and
+// quantizes the vectors for the static site.
+func BuildStaticSearchIndex(issues []model.Issue) (StaticSearchIndex, error) {
+	embedder := search.NewHashEmbedder(search.DefaultEmbeddingDim)
+	idx := StaticSearchIndex{
+		Version:  StaticSearchIndexVersion,
+		Provider: string(embedder.Provider()),
+		Dim:      embedder.Dim(),
+	}
+
+	var docs []string
+	for _, issue := range issues {
+		if issue.ID == "" {
+			continue
+		}
+		idx.IDs = append(idx.IDs, issue.ID)
+		docs = append(docs, search.IssueDocument(issue))
+	}
+	vectors, err := embedder.Embed(context.Background(), docs)
+	if err != nil {
+		return StaticSearchIndex{}, fmt.Errorf("embed issues: %w", err)
+	}
+
+	packed := make([]byte, 0, len(vectors)*idx.Dim)
+	idx.Scales = make([]float32, len(vectors))
+	for i, vec := range vectors {
+		var maxAbs float64
+		for _, v := range vec {
+			maxAbs = max(maxAbs, math.Abs(float64(v)))
+		}
+		if maxAbs > 0 {
+			idx.Scales[i] = float32(maxAbs / 127)
+		}
+		for _, v := range vec {
+			var q int8
+			if maxAbs > 0 {
+				q = int8(math.Round(float64(v) / maxAbs * 127))
+			}
+			packed = append(packed, byte(q))
+		}
+	}
+	idx.Vectors = base64.StdEncoding.EncodeToString(packed)
+	return idx, nil
+}
+
+// Search returns the k issues most similar to query, best first, skipping
+// non-positive scores. It mirrors semanticSearch in semantic_search.js.
+func (idx StaticSearchIndex) Search(query string, k int) ([]search.SearchResult, error) {
+	packed, err := base64.StdEncoding.DecodeString(idx.Vectors)
+	if err != nil {
+		return nil, fmt.Errorf("decode vectors: %w", err)
+	}
+	if len(packed) != len(idx.IDs)*idx.Dim {
+		return nil, fmt.Errorf("vector data has %d bytes, want %d", len(packed), len(idx.IDs)*idx.Dim)
+	}
+	vecs, err := search.NewHashEmbedder(idx.Dim).Embed(context.Background(), []string{query})
+	if err != nil {
+		return nil, err
+	}
+	q := vecs[0]
+
+	var results []search.SearchResult
+	for i, id := range idx.IDs {
+		row := packed[i*idx.Dim : (i+1)*idx.Dim]
+		var dot float64
+		for j, b := range row {
+			dot += float64(int8(b)) * float64(q[j])
+		}
+		score := dot * float64(idx.Scales[i])
+		if score > 0 {
+			results = append(results, search.SearchResult{IssueID: id, Score: score})
+		}
+	}
+	sort.Slice(results, func(a, b int) bool {
+		if results[a].Score != results[b].Score {
+			return results[a].Score > results[b].Score
+		}
+		return results[a].IssueID < results[b].IssueID
+	})
+	if k > 0 && len(results) > k {
+		results = results[:k]
+	}
+	return results, nil
+}
+
+// writeSearchIndex writes data/search_index.json for client-side semantic search.
+func (e *SQLiteExporter) writeSearchIndex(dataDir string) error {
+	issues := make([]model.Issue, 0, len(e.Issues))
+	for _, issue := range e.Issues {
+		if issue != nil {
+			issues = append(issues, *issue)
+		}
+	}
+	idx, err := BuildStaticSearchIndex(issues)
+	if err != nil {
+		return err
+	}
+	return writeJSON(filepath.Join(dataDir, "search_index.json"), idx)
+}
@@ -0,0 +1,175 @@
+package export
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"math"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/search"
+)
+
+func searchIndexFixture() []model.Issue {
+	return []model.Issue{
+		{ID: "bv-1", Title: "Login page crashes on submit", Description: "Stack trace in the auth handler", Labels: []string{"auth"}},
+		{ID: "bv-2", Title: "Add dark mode", Description: "Theme toggle in settings", Labels: []string{"ui"}},
+		{ID: "bv-3", Title: "Rotate auth tokens", Description: "Tokens never expire", Labels: []string{"auth", "security"}},
+		{ID: "bv-4", Title: "Überprüfung der Daten", Description: "Unicode tokens ✓ and CamelCase"},
+		{ID: "", Title: "Missing ID is skipped"},
+	}
+}
+
+func TestBuildStaticSearchIndex(t *testing.T) {
+	issues := searchIndexFixture()
+	idx, err := BuildStaticSearchIndex(issues)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if idx.Provider != "hash" || idx.Dim != search.DefaultEmbeddingDim || idx.Version != StaticSearchIndexVersion {
+		t.Errorf("unexpected header: %+v", idx)
+	}
+	if len(idx.IDs) != 4 || len(idx.Scales) != 4 {
+		t.Fatalf("expected 4 rows, got %d ids and %d scales", len(idx.IDs), len(idx.Scales))
+	}
+	packed, err := base64.StdEncoding.DecodeString(idx.Vectors)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(packed) != 4*idx.Dim {
+		t.Fatalf("expected %d vector bytes, got %d", 4*idx.Dim, len(packed))
+	}
+
+	// Dequantized rows stay close to the float vectors
+	want, _ := search.NewHashEmbedder(idx.Dim).Embed(t.Context(), []string{search.IssueDocument(issues[0])})
+	for j, b := range packed[:idx.Dim] {
+		got := float64(int8(b)) * float64(idx.Scales[0])
+		if math.Abs(got-float64(want[0][j])) > float64(idx.Scales[0]) {
+			t.Fatalf("component %d: dequantized %f, want %f", j, got, want[0][j])
+		}
+	}
+}
+
+func TestStaticSearchIndex_Search(t *testing.T) {
+	idx, err := BuildStaticSearchIndex(searchIndexFixture())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	results, err := idx.Search("auth tokens", 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 2 || results[0].IssueID != "bv-3" || results[1].IssueID != "bv-1" {
+		t.Errorf("unexpected ranking: %+v", results)
+	}
+	if results[0].Score <= 0 || results[0].Score > 1.01 {
+		t.Errorf("score out of range: %f", results[0].Score)
+	}
+
+	results, err = idx.Search("zzzz qqqq", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, r := range results {
+		if r.Score > 0.5 {
+			t.Errorf("unrelated query matched %s with %f", r.IssueID, r.Score)
+		}
+	}
+
+	broken := idx
+	broken.Vectors = base64.StdEncoding.EncodeToString([]byte{1, 2, 3})
+	if _, err := broken.Search("auth", 1); err == nil {
+		t.Error("expected error for truncated vectors")
+	}
+}
+
+func TestExport_WritesSearchIndex(t *testing.T) {
+	tmpDir := t.TempDir()
+	issues := []*model.Issue{
+		makeTestIssue("search-1", "Flaky payment webhook", model.StatusOpen, 1, model.TypeBug),
+		makeTestIssue("search-2", "Refresh dashboard charts", model.StatusOpen, 2, model.TypeTask),
+	}
+	if err := NewSQLiteExporter(issues, nil, nil, nil).Export(tmpDir); err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(tmpDir, "data", "search_index.json"))
+	if err != nil {
+		t.Fatalf("search_index.json not written: %v", err)
+	}
+	var idx StaticSearchIndex
+	if err := json.Unmarshal(data, &idx); err != nil {
+		t.Fatalf("invalid search_index.json: %v", err)
+	}
+	results, err := idx.Search("payment webhook", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 1 || results[0].IssueID != "search-1" {
+		t.Errorf("expected search-1, got %+v", results)
+	}
+}
+
+// TestSemanticSearchJSParity runs semantic_search.js under Node (when
+// available) and checks it ranks and scores like the Go implementation.
+func TestSemanticSearchJSParity(t *testing.T) {
+	node, err := exec.LookPath("node")
+	if err != nil {
+		t.Skip("node not installed")
+	}
+
+	idx, err := BuildStaticSearchIndex(searchIndexFixture())
+	if err != nil {
+		t.Fatal(err)
+	}
+	indexPath := filepath.Join(t.TempDir(), "search_index.json")
+	if err := writeJSON(indexPath, idx); err != nil {
+		t.Fatal(err)
+	}
+	scriptPath, err := filepath.Abs(filepath.Join("viewer_assets", "semantic_search.js"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	queries := []string{"auth tokens", "LOGIN crash", "überprüfung daten", "CamelCase ✓", "dark-mode settings"}
+	script := `
+const s = require(process.argv[1]);
+s.setSemanticIndex(JSON.parse(require('fs').readFileSync(process.argv[2], 'utf8')));
+const out = {};
+for (const q of JSON.parse(process.argv[3])) out[q] = s.semanticSearch(q, 0, 0);
+console.log(JSON.stringify(out));
+`
+	queriesJSON, _ := json.Marshal(queries)
+	out, err := exec.Command(node, "-e", script, scriptPath, indexPath, string(queriesJSON)).Output()
+	if err != nil {
+		t.Fatalf("node failed: %v", err)
+	}
+	var jsResults map[string][]struct {
+		ID    string  `json:"id"`
+		Score float64 `json:"score"`
+	}
+	if err := json.Unmarshal(out, &jsResults); err != nil {
+		t.Fatalf("invalid node output %q: %v", out, err)
+	}
+
+	for _, q := range queries {
+		goResults, err := idx.Search(q, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		js := jsResults[q]
+		if len(js) != len(goResults) {
+			t.Errorf("%q: js returned %d results, go %d", q, len(js), len(goResults))
+			continue
+		}
+		for i := range js {
+			if js[i].ID != goResults[i].IssueID || math.Abs(js[i].Score-goResults[i].Score) > 1e-4 {
+				t.Errorf("%q[%d]: js %s %.5f, go %s %.5f", q, i, js[i].ID, js[i].Score, goResults[i].IssueID, goResults[i].Score)
+			}
+		}
+	}
+}
@@ -0,0 +1,316 @@
+// Package export provides data export functionality for bv.
+//
+// This file implements the --serve-pages server: a long-running host for a
+// static site bundle on an internal network, with optional basic-auth or
+// token protection, gzip and graceful shutdown.
+package export
+
+import (
+	"compress/gzip"
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// ServeTokenCookie holds the access token after a ?token= login so the
+// viewer's follow-up asset and data requests are authorized too.
+const ServeTokenCookie = "bv_token"
+
+// ServeConfig configures the pages server.
+type ServeConfig struct {
+	// BundlePath is the path to the static site bundle
+	BundlePath string
+
+	// Bind is the address to listen on (default 127.0.0.1)
+	Bind string
+
+	// Port is the port to listen on (default DefaultPreviewPort)
+	Port int
+
+	// Username and Password enable HTTP basic auth when both are set
+	Username string
+	Password string
+
+	// Token enables bearer-token auth ("Authorization: Bearer", ?token= or cookie)
+	Token string
+
+	// Gzip compresses responses for clients that accept it
+	Gzip bool
+
+	// NoCache sends no-store headers (preview mode) instead of revalidation headers
+	NoCache bool
+
+	// ShutdownTimeout bounds graceful shutdown (default 10s)
+	ShutdownTimeout time.Duration
+
+	// Quiet suppresses status messages
+	Quiet bool
+}
+
+// Addr returns the listen address.
+func (c ServeConfig) Addr() string {
+	bind := c.Bind
+	if bind == "" {
+		bind = "127.0.0.1"
+	}
+	port := c.Port
+	if port == 0 {
+		port = DefaultPreviewPort
+	}
+	return net.JoinHostPort(bind, fmt.Sprint(port))
+}
+
+// HasAuth reports whether any authentication is configured.
+func (c ServeConfig) HasAuth() bool {
+	return c.Token != "" || (c.Username != "" && c.Password != "")
+}
+
+// IsLoopback reports whether the server only listens on the local machine.
+func (c ServeConfig) IsLoopback() bool {
+	if c.Bind == "" || c.Bind == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(c.Bind)
+	return ip != nil && ip.IsLoopback()
+}
+
+// NewServeHandler returns the HTTP handler for a bundle. /healthz is always
+// reachable without credentials so load balancers can probe it.
+func NewServeHandler(config ServeConfig) (http.Handler, error) {
+	if info, err := os.Stat(config.BundlePath); err != nil || !info.IsDir() {
+		return nil, fmt.Errorf("bundle path does not exist: %s", config.BundlePath)
+	}
+	if _, err := os.Stat(filepath.Join(config.BundlePath, "index.html")); os.IsNotExist(err) {
+		return nil, fmt.Errorf("index.html not found in %s (did you run --export-pages first?)", config.BundlePath)
+	}
+	if (config.Username == "") != (config.Password == "") {
+		return nil, fmt.Errorf("basic auth needs both a username and a password")
+	}
+
+	var files http.Handler = http.FileServer(http.Dir(config.BundlePath))
+	if config.NoCache {
+		files = noCacheMiddleware(files)
+	} else {
+		files = revalidateMiddleware(files)
+	}
+	if config.Gzip {
+		files = gzipMiddleware(files)
+	}
+	files = authMiddleware(config, files)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		w.Header().Set("Cache-Control", "no-store")
+		fmt.Fprintln(w, "ok")
+	})
+	mux.Handle("/", files)
+	return mux, nil
+}
+
+// ServePages serves the bundle until ctx is cancelled or the process gets
+// SIGINT/SIGTERM, then drains in-flight requests before returning.
+func ServePages(ctx context.Context, config ServeConfig) error {
+	handler, err := NewServeHandler(config)
+	if err != nil {
+		return err
+	}
+	if config.ShutdownTimeout == 0 {
+		config.ShutdownTimeout = 10 * time.Second
+	}
+
+	listener, err := net.Listen("tcp", config.Addr())
+	if err != nil {
+		return fmt.Errorf("listen on %s: %w", config.Addr(), err)
+	}
+	server := &http.Server{
+		Handler:           handler,
+		ReadHeaderTimeout: 10 * time.Second,
+		IdleTimeout:       2 * time.Minute,
+	}
+
+	if !config.Quiet {
+		fmt.Printf("Serving %s at http://%s\n", config.BundlePath, listener.Addr())
+		switch {
+		case config.Token != "":
+			fmt.Println("Auth: token (Authorization: Bearer, ?token= or cookie)")
+		case config.HasAuth():
+			fmt.Printf("Auth: basic (user %s)\n", config.Username)
+		case !config.IsLoopback():
+			fmt.Println("Warning: no authentication configured and listening beyond localhost")
+		}
+		fmt.Println("Press Ctrl+C to stop")
+	}
+
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	errChan := make(chan error, 1)
+	go func() {
+		errChan <- server.Serve(listener)
+	}()
+
+	select {
+	case err := <-errChan:
+		if errors.Is(err, http.ErrServerClosed) {
+			return nil
+		}
+		return err
+	case <-ctx.Done():
+		if !config.Quiet {
+			fmt.Println("\nShutting down server...")
+		}
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), config.ShutdownTimeout)
+		defer cancel()
+		return server.Shutdown(shutdownCtx)
+	}
+}
+
+// authMiddleware enforces the configured token or basic auth.
+func authMiddleware(config ServeConfig, next http.Handler) http.Handler {
+	if !config.HasAuth() {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if config.Token != "" {
+			// A valid ?token= sets the cookie and redirects to the clean URL,
+			// keeping the token out of history and Referer headers
+			if q := r.URL.Query(); q.Has("token") && secureEqual(q.Get("token"), config.Token) {
+				http.SetCookie(w, &http.Cookie{
+					Name:     ServeTokenCookie,
+					Value:    config.Token,
+					Path:     "/",
+					HttpOnly: true,
+					Secure:   r.TLS != nil,
+					SameSite: http.SameSiteStrictMode,
+				})
+				q.Del("token")
+				clean := *r.URL
+				clean.RawQuery = q.Encode()
+				http.Redirect(w, r, clean.RequestURI(), http.StatusSeeOther)
+				return
+			}
+			if bearer, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); ok && secureEqual(bearer, config.Token) {
+				next.ServeHTTP(w, r)
+				return
+			}
+			if c, err := r.Cookie(ServeTokenCookie); err == nil && secureEqual(c.Value, config.Token) {
+				next.ServeHTTP(w, r)
+				return
+			}
+		}
+		if config.Username != "" {
+			if user, pass, ok := r.BasicAuth(); ok && secureEqual(user, config.Username) && secureEqual(pass, config.Password) {
+				next.ServeHTTP(w, r)
+				return
+			}
+			w.Header().Set("WWW-Authenticate", `Basic realm="bv", charset="UTF-8"`)
+		}
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+	})
+}
+
+func secureEqual(a, b string) bool {
+	return subtle.ConstantTimeCompare([]byte(a), []byte(b)) == 1
+}
+
+// revalidateMiddleware lets browsers cache files but check Last-Modified on
+// each load, so a re-export shows up without a hard refresh.
+func revalidateMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Cache-Control", "no-cache")
+		next.ServeHTTP(w, r)
+	})
+}
+
+// gzipMiddleware compresses full (non-range) responses of compressible types.
+func gzipMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Vary", "Accept-Encoding")
+		if r.Header.Get("Range") != "" || !acceptsGzip(r.Header.Get("Accept-Encoding")) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		gw := &gzipResponseWriter{ResponseWriter: w}
+		defer gw.Close()
+		next.ServeHTTP(gw, r)
+	})
+}
+
+func acceptsGzip(header string) bool {
+	for _, part := range strings.Split(header, ",") {
+		coding, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		if strings.EqualFold(strings.TrimSpace(coding), "gzip") {
+			return strings.ReplaceAll(params, " ", "") != "q=0"
+		}
+	}
+	return false
+}
+
+// compressibleType reports whether a Content-Type benefits from gzip.
+func compressibleType(contentType string) bool {
+	mediaType, _, _ := strings.Cut(contentType, ";")
+	mediaType = strings.TrimSpace(mediaType)
+	switch {
+	case strings.HasPrefix(mediaType, "text/"):
+		return true
+	case mediaType == "application/json",
+		mediaType == "application/javascript",
+		mediaType == "application/wasm",
+		mediaType == "application/octet-stream", // sqlite chunks compress well
+		mediaType == "image/svg+xml":
+		return true
+	}
+	return false
+}
+
+// gzipResponseWriter decides on the first WriteHeader whether to compress,
+// based on the status and the Content-Type the file server set.
+type gzipResponseWriter struct {
+	http.ResponseWriter
+	gz      *gzip.Writer
+	decided bool
+}
+
+func (g *gzipResponseWriter) WriteHeader(status int) {
+	if !g.decided {
+		g.decided = true
+		h := g.Header()
+		if status == http.StatusOK && h.Get("Content-Encoding") == "" && compressibleType(h.Get("Content-Type")) {
+			h.Del("Content-Length")
+			h.Set("Content-Encoding", "gzip")
+			g.gz = gzip.NewWriter(g.ResponseWriter)
+		}
+	}
+	g.ResponseWriter.WriteHeader(status)
+}
+
+func (g *gzipResponseWriter) Write(b []byte) (int, error) {
+	if !g.decided {
+		if g.Header().Get("Content-Type") == "" {
+			g.Header().Set("Content-Type", http.DetectContentType(b))
+		}
+		g.WriteHeader(http.StatusOK)
+	}
+	if g.gz != nil {
+		return g.gz.Write(b)
+	}
+	return g.ResponseWriter.Write(b)
+}
+
+// Close flushes the gzip stream, if one was started.
+func (g *gzipResponseWriter) Close() error {
+	if g.gz == nil {
+		return nil
+	}
+	return g.gz.Close()
+}
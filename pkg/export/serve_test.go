@@ -0,0 +1,227 @@
+package export
+
+import (
+	"compress/gzip"
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newServeBundle(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	writePagesFiles(t, dir, map[string]string{
+		"index.html":       "<html><body>" + strings.Repeat("issues ", 200) + "</body></html>",
+		"data/meta.json":   `{"issue_count": 3}`,
+		"chunks/00000.bin": strings.Repeat("\x00", 1024),
+		"logo.png":         "\x89PNG\r\n\x1a\n",
+	})
+	return dir
+}
+
+func TestServeConfig_Addr(t *testing.T) {
+	if got := (ServeConfig{}).Addr(); got != "127.0.0.1:9000" {
+		t.Errorf("default Addr = %q", got)
+	}
+	if got := (ServeConfig{Bind: "::", Port: 8080}).Addr(); got != "[::]:8080" {
+		t.Errorf("Addr = %q", got)
+	}
+	if !(ServeConfig{Bind: "::1"}).IsLoopback() || (ServeConfig{Bind: "0.0.0.0"}).IsLoopback() {
+		t.Error("IsLoopback misclassified bind address")
+	}
+}
+
+func TestNewServeHandler_Validation(t *testing.T) {
+	if _, err := NewServeHandler(ServeConfig{BundlePath: t.TempDir()}); err == nil {
+		t.Error("expected error for bundle without index.html")
+	}
+	if _, err := NewServeHandler(ServeConfig{BundlePath: newServeBundle(t), Username: "admin"}); err == nil {
+		t.Error("expected error for basic auth without a password")
+	}
+}
+
+func TestServeHandler_BasicAuth(t *testing.T) {
+	handler, err := NewServeHandler(ServeConfig{BundlePath: newServeBundle(t), Username: "admin", Password: "hunter2"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/data/meta.json", nil))
+	if rec.Code != http.StatusUnauthorized || !strings.HasPrefix(rec.Header().Get("WWW-Authenticate"), "Basic") {
+		t.Fatalf("unauthenticated: status %d, WWW-Authenticate %q", rec.Code, rec.Header().Get("WWW-Authenticate"))
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/data/meta.json", nil)
+	req.SetBasicAuth("admin", "wrong")
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("wrong password: status %d", rec.Code)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/data/meta.json", nil)
+	req.SetBasicAuth("admin", "hunter2")
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), "issue_count") {
+		t.Errorf("authenticated: status %d body %q", rec.Code, rec.Body.String())
+	}
+
+	// Health checks never need credentials
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	if rec.Code != http.StatusOK {
+		t.Errorf("/healthz: status %d", rec.Code)
+	}
+}
+
+func TestServeHandler_TokenAuth(t *testing.T) {
+	handler, err := NewServeHandler(ServeConfig{BundlePath: newServeBundle(t), Token: "s3cret"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("unauthenticated: status %d", rec.Code)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/data/meta.json", nil)
+	req.Header.Set("Authorization", "Bearer s3cret")
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Errorf("bearer: status %d", rec.Code)
+	}
+
+	// ?token= logs in via cookie and redirects to the clean URL
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/?token=s3cret", nil))
+	if rec.Code != http.StatusSeeOther || rec.Header().Get("Location") != "/" {
+		t.Fatalf("token login: status %d location %q", rec.Code, rec.Header().Get("Location"))
+	}
+	cookies := rec.Result().Cookies()
+	if len(cookies) != 1 || cookies[0].Name != ServeTokenCookie || !cookies[0].HttpOnly {
+		t.Fatalf("expected HttpOnly %s cookie, got %+v", ServeTokenCookie, cookies)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/data/meta.json", nil)
+	req.AddCookie(cookies[0])
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Errorf("cookie: status %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/?token=wrong", nil))
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("wrong token: status %d", rec.Code)
+	}
+}
+
+func TestServeHandler_Gzip(t *testing.T) {
+	handler, err := NewServeHandler(ServeConfig{BundlePath: newServeBundle(t), Gzip: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	get := func(path string, header http.Header) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		for k, v := range header {
+			req.Header[k] = v
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+	acceptGzip := http.Header{"Accept-Encoding": {"gzip, deflate"}}
+
+	rec := get("/", acceptGzip)
+	if rec.Header().Get("Content-Encoding") != "gzip" || rec.Header().Get("Content-Length") != "" {
+		t.Fatalf("expected gzip without Content-Length, got %v", rec.Header())
+	}
+	zr, err := gzip.NewReader(rec.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, err := io.ReadAll(zr)
+	if err != nil || !strings.HasPrefix(string(body), "<html>") {
+		t.Errorf("decompressed body = %.20q, %v", body, err)
+	}
+	if rec.Header().Get("Cache-Control") != "no-cache" {
+		t.Errorf("Cache-Control = %q", rec.Header().Get("Cache-Control"))
+	}
+
+	if rec := get("/chunks/00000.bin", acceptGzip); rec.Header().Get("Content-Encoding") != "gzip" {
+		t.Error("database chunks should be compressed")
+	}
+	if rec := get("/logo.png", acceptGzip); rec.Header().Get("Content-Encoding") != "" {
+		t.Error("images should not be compressed")
+	}
+	if rec := get("/", nil); rec.Header().Get("Content-Encoding") != "" {
+		t.Error("client without gzip support got a compressed response")
+	}
+	rangeReq := http.Header{"Accept-Encoding": {"gzip"}, "Range": {"bytes=0-9"}}
+	if rec := get("/chunks/00000.bin", rangeReq); rec.Code != http.StatusPartialContent || rec.Header().Get("Content-Encoding") != "" || rec.Body.Len() != 10 {
+		t.Errorf("range request: status %d encoding %q len %d", rec.Code, rec.Header().Get("Content-Encoding"), rec.Body.Len())
+	}
+}
+
+func TestAcceptsGzip(t *testing.T) {
+	for header, want := range map[string]bool{
+		"gzip":             true,
+		"br, gzip;q=0.8":   true,
+		"deflate":          false,
+		"gzip;q=0":         false,
+		"":                 false,
+		"identity, GZIP ,": true,
+	} {
+		if got := acceptsGzip(header); got != want {
+			t.Errorf("acceptsGzip(%q) = %v, want %v", header, got, want)
+		}
+	}
+}
+
+func TestServePages_GracefulShutdown(t *testing.T) {
+	port, err := FindAvailablePort(19000, 19100)
+	if err != nil {
+		t.Skip(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- ServePages(ctx, ServeConfig{BundlePath: newServeBundle(t), Port: port, Quiet: true})
+	}()
+
+	addr := net.JoinHostPort("127.0.0.1", strconv.Itoa(port))
+	var resp *http.Response
+	for i := 0; i < 50; i++ {
+		if resp, err = http.Get("http://" + addr + "/healthz"); err == nil {
+			break
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	if err != nil {
+		t.Fatalf("server never came up: %v", err)
+	}
+	resp.Body.Close()
+
+	cancel()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("ServePages returned %v after shutdown", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("server did not shut down")
+	}
+}
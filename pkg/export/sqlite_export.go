@@ -5,6 +5,7 @@
 package export
 
 import (
+	"context"
 	"crypto/sha256"
 	"database/sql"
 	"encoding/hex"
@@ -18,6 +19,8 @@ import (
 
 	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/tracing"
+	"go.opentelemetry.io/otel/attribute"
 
 	_ "modernc.org/sqlite"
 )
@@ -65,7 +68,10 @@ func (e *SQLiteExporter) SetGitHash(hash string) {
 }
 
 // Export writes the SQLite database and supporting files to the output directory.
-func (e *SQLiteExporter) Export(outputDir string) error {
+func (e *SQLiteExporter) Export(outputDir string) (err error) {
+	_, span := tracing.Start(context.Background(), "export.sqlite", attribute.String("bv.output_dir", outputDir), attribute.Int("bv.issues", len(e.Issues)))
+	defer func() { tracing.End(span, err) }()
+
 	// Ensure output directory exists
 	if err := os.MkdirAll(outputDir, 0755); err != nil {
 		return fmt.Errorf("create output dir: %w", err)
@@ -109,6 +115,11 @@ func (e *SQLiteExporter) Export(outputDir string) error {
 		return fmt.Errorf("insert dependencies: %w", err)
 	}
 
+	// Insert cross-references between issues
+	if err := e.insertMentions(db); err != nil {
+		return fmt.Errorf("insert mentions: %w", err)
+	}
+
 	// Insert metrics
 	if err := e.insertMetrics(db); err != nil {
 		return fmt.Errorf("insert metrics: %w", err)
@@ -163,6 +174,11 @@ func (e *SQLiteExporter) Export(outputDir string) error {
 		return fmt.Errorf("write graph layout: %w", err)
 	}
 
+	// Write quantized vectors for client-side semantic search
+	if err := e.writeSearchIndex(dataDir); err != nil {
+		return fmt.Errorf("write search index: %w", err)
+	}
+
 	// Chunk if needed
 	if err := e.chunkIfNeeded(outputDir, dbPath); err != nil {
 		return fmt.Errorf("chunk database: %w", err)
@@ -252,6 +268,40 @@ func (e *SQLiteExporter) insertDependencies(db *sql.DB) error {
 	return tx.Commit()
 }
 
+// insertMentions records which issues mention which others in their text,
+// so the viewer can render backlinks.
+func (e *SQLiteExporter) insertMentions(db *sql.DB) error {
+	issues := make([]model.Issue, 0, len(e.Issues))
+	for _, issue := range e.Issues {
+		if issue != nil {
+			issues = append(issues, *issue)
+		}
+	}
+	mentions := analysis.BuildMentionIndex(issues)
+
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.Prepare(`INSERT INTO issue_mentions (issue_id, mentioned_id) VALUES (?, ?)`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for _, issue := range issues {
+		for _, id := range mentions.Mentions(issue.ID) {
+			if _, err := stmt.Exec(issue.ID, id); err != nil {
+				return fmt.Errorf("insert mention %s->%s: %w", issue.ID, id, err)
+			}
+		}
+	}
+
+	return tx.Commit()
+}
+
 // insertMetrics inserts computed graph metrics for all issues.
 func (e *SQLiteExporter) insertMetrics(db *sql.DB) error {
 	if e.Stats == nil {
@@ -438,6 +488,18 @@ func (e *SQLiteExporter) writeRobotOutputs(dataDir string) error {
 		}
 	}
 
+	// Write cross-label flow for the dashboard's Sankey diagram
+	issues := make([]model.Issue, 0, len(e.Issues))
+	for _, issue := range e.Issues {
+		if issue != nil {
+			issues = append(issues, *issue)
+		}
+	}
+	flow := analysis.ComputeCrossLabelFlow(issues, analysis.DefaultLabelHealthConfig())
+	if err := writeJSON(filepath.Join(dataDir, "label_flow.json"), LabelFlowExport{Flow: flow, Sankey: LabelFlowSankey(flow)}); err != nil {
+		return fmt.Errorf("write label_flow.json: %w", err)
+	}
+
 	// Write export metadata
 	meta := ExportMeta{
 		Version:     "1.0.0",
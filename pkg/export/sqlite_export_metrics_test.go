@@ -204,7 +204,7 @@ func TestSQLiteExporter_writeRobotOutputs_WritesExpectedFiles(t *testing.T) {
 		t.Fatalf("writeRobotOutputs returned error: %v", err)
 	}
 
-	for _, name := range []string{"triage.json", "project_health.json", "meta.json"} {
+	for _, name := range []string{"triage.json", "project_health.json", "label_flow.json", "meta.json"} {
 		if _, err := os.Stat(filepath.Join(dataDir, name)); err != nil {
 			t.Fatalf("Expected %s to exist: %v", name, err)
 		}
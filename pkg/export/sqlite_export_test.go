@@ -366,6 +366,43 @@ func TestExport_WithLabels(t *testing.T) {
 	}
 }
 
+func TestExport_WithMentions(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	parser := makeTestIssue("ref-1", "Parser", model.StatusOpen, 1, model.TypeTask)
+	parser.Description = "Waiting on ref-2; ref-3 is unrelated"
+	lexer := makeTestIssue("ref-2", "Lexer", model.StatusOpen, 1, model.TypeTask)
+	lexer.Comments = []*model.Comment{{Text: "unblocks ref-1"}}
+
+	exp := NewSQLiteExporter([]*model.Issue{parser, lexer}, nil, nil, nil)
+	if err := exp.Export(tmpDir); err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+
+	db, err := sql.Open("sqlite", filepath.Join(tmpDir, "beads.sqlite3"))
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	rows, err := db.Query(`SELECT issue_id || '->' || mentioned_id FROM issue_mentions ORDER BY issue_id`)
+	if err != nil {
+		t.Fatalf("Query mentions failed: %v", err)
+	}
+	defer rows.Close()
+	var got []string
+	for rows.Next() {
+		var edge string
+		if err := rows.Scan(&edge); err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, edge)
+	}
+	if len(got) != 2 || got[0] != "ref-1->ref-2" || got[1] != "ref-2->ref-1" {
+		t.Errorf("mentions = %v, want [ref-1->ref-2 ref-2->ref-1]", got)
+	}
+}
+
 func TestExport_WithClosedAt(t *testing.T) {
 	tmpDir := t.TempDir()
 
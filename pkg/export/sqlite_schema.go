@@ -71,6 +71,18 @@ func createCoreTables(db *sql.DB) error {
 		return fmt.Errorf("create dependencies table: %w", err)
 	}
 
+	// Mentions table - issue IDs referenced in another issue's text
+	mentionsSQL := `
+		CREATE TABLE IF NOT EXISTS issue_mentions (
+			issue_id TEXT NOT NULL,
+			mentioned_id TEXT NOT NULL,
+			PRIMARY KEY (issue_id, mentioned_id)
+		)
+	`
+	if _, err := db.Exec(mentionsSQL); err != nil {
+		return fmt.Errorf("create issue_mentions table: %w", err)
+	}
+
 	return nil
 }
 
@@ -125,6 +137,7 @@ func createIndexes(db *sql.DB) error {
 		`CREATE INDEX IF NOT EXISTS idx_deps_issue ON dependencies(issue_id)`,
 		`CREATE INDEX IF NOT EXISTS idx_deps_depends ON dependencies(depends_on_id)`,
 		`CREATE INDEX IF NOT EXISTS idx_deps_type ON dependencies(type)`,
+		`CREATE INDEX IF NOT EXISTS idx_mentions_mentioned ON issue_mentions(mentioned_id)`,
 
 		// Metrics indexes
 		`CREATE INDEX IF NOT EXISTS idx_metrics_score ON issue_metrics(triage_score DESC)`,
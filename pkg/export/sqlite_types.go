@@ -7,6 +7,7 @@ package export
 import (
 	"time"
 
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
 )
 
@@ -66,6 +67,13 @@ type ExportMeta struct {
 	Title       string    `json:"title,omitempty"`
 }
 
+// LabelFlowExport is data/label_flow.json: cross-label dependency flow and
+// the Sankey diagram the dashboard draws from it.
+type LabelFlowExport struct {
+	Flow   analysis.CrossLabelFlow `json:"flow"`
+	Sankey string                  `json:"sankey"` // Mermaid sankey-beta source
+}
+
 // SQLiteExportConfig configures the SQLite export process.
 type SQLiteExportConfig struct {
 	// OutputDir is the directory to write export files
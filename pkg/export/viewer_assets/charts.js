@@ -4,6 +4,7 @@
  * Interactive charts for project analytics:
  * - Burndown/burnup progress chart
  * - Label dependency heatmap
+ * - Label dependency flow (Sankey, from data/label_flow.json)
  * - Priority distribution pie chart
  * - Type breakdown bar chart
  *
@@ -72,6 +73,7 @@ const chartsState = {
     priorityChart: null,
     typeChart: null,
     heatmapCanvas: null,
+    labelSankeyRendered: false,
     issues: [],
     dependencies: [],
     initialized: false
@@ -101,6 +103,7 @@ function initCharts(issues, dependencies) {
     initPriorityChart();
     initTypeChart();
     initHeatmap();
+    initLabelSankey();
 
     console.log('[bv-charts] Dashboard initialized with', issues.length, 'issues');
 }
@@ -136,6 +139,7 @@ function destroyCharts() {
         chartsState.typeChart.destroy();
         chartsState.typeChart = null;
     }
+    chartsState.labelSankeyRendered = false;
     chartsState.initialized = false;
 }
 
@@ -700,6 +704,60 @@ function updateHeatmap() {
     renderHeatmap();
 }
 
+// ============================================================================
+// LABEL DEPENDENCY FLOW (SANKEY)
+// ============================================================================
+
+/**
+ * Render the cross-label flow Sankey exported as data/label_flow.json. The
+ * diagram is computed at export time from all issues, so unlike the heatmap
+ * it is not limited to 20 labels and doesn't change with filters.
+ */
+async function initLabelSankey() {
+    const container = document.getElementById('label-sankey-container');
+    if (!container || chartsState.labelSankeyRendered) return;
+
+    const showMessage = (text) => {
+        container.innerHTML = '';
+        const p = document.createElement('p');
+        p.className = 'text-gray-500 dark:text-gray-400 text-sm';
+        p.textContent = text;
+        container.appendChild(p);
+    };
+
+    let data;
+    try {
+        const resp = await fetch('./data/label_flow.json');
+        if (!resp.ok) {
+            showMessage('No label flow data in this export');
+            return;
+        }
+        data = await resp.json();
+    } catch (err) {
+        showMessage('No label flow data in this export');
+        return;
+    }
+
+    // A diagram with no rows is just the header line
+    if (!data?.sankey || data.sankey.trim().split('\n').length < 2) {
+        showMessage('No cross-label dependencies to display');
+        return;
+    }
+    if (typeof mermaid === 'undefined') {
+        showMessage('Mermaid is not available');
+        return;
+    }
+
+    try {
+        const { svg } = await mermaid.render('label-sankey-' + Date.now(), data.sankey);
+        container.innerHTML = svg;
+        chartsState.labelSankeyRendered = true;
+    } catch (err) {
+        console.warn('[bv-charts] Label flow Sankey failed:', err);
+        showMessage('Failed to render label flow');
+    }
+}
+
 // ============================================================================
 // UTILITIES
 // ============================================================================
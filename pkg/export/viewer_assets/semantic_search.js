@@ -0,0 +1,159 @@
+/*
+ * Semantic search - client-side cosine search over data/search_index.json.
+ * Mirrors pkg/search/hash_embedder.go so queries embed exactly like the
+ * issues did at export time. No model download, no server.
+ */
+
+const SEMANTIC_SEARCH_STATE = {
+  ready: false,
+  attempted: false,
+  reason: null,
+  dim: 0,
+  ids: [],
+  scales: null,
+  vectors: null, // Int8Array, ids.length * dim
+};
+
+// Minimum cosine similarity for a result to count as a match.
+const SEMANTIC_MIN_SCORE = 0.1;
+
+const FNV_OFFSET_64 = 14695981039346656037n;
+const FNV_PRIME_64 = 1099511628211n;
+const MASK_64 = (1n << 64n) - 1n;
+const TOKEN_CHAR = /[\p{L}\p{Nd}]/u;
+
+function getSemanticSearchStatus() {
+  return {
+    ready: SEMANTIC_SEARCH_STATE.ready,
+    attempted: SEMANTIC_SEARCH_STATE.attempted,
+    reason: SEMANTIC_SEARCH_STATE.reason,
+    size: SEMANTIC_SEARCH_STATE.ids.length,
+  };
+}
+
+function addHashedToken(vec, token) {
+  // FNV-1a 64-bit over UTF-8 bytes, lowercasing ASCII only (as in Go).
+  const bytes = new TextEncoder().encode(token);
+  let h = FNV_OFFSET_64;
+  for (let b of bytes) {
+    if (b >= 0x41 && b <= 0x5a) b += 0x20;
+    h ^= BigInt(b);
+    h = (h * FNV_PRIME_64) & MASK_64;
+  }
+  const idx = Number(h % BigInt(vec.length));
+  vec[idx] += (h >> 63n) & 1n ? -1 : 1;
+}
+
+/**
+ * Embed text with the hash embedder; returns an L2-normalized Float64Array.
+ */
+function hashEmbed(text, dim) {
+  const vec = new Float64Array(dim);
+  let token = '';
+  for (const ch of String(text || '')) {
+    if (TOKEN_CHAR.test(ch)) {
+      token += ch;
+    } else if (token) {
+      addHashedToken(vec, token);
+      token = '';
+    }
+  }
+  if (token) addHashedToken(vec, token);
+
+  let sum = 0;
+  for (const v of vec) sum += v * v;
+  if (sum > 0) {
+    const scale = 1 / Math.sqrt(sum);
+    for (let i = 0; i < dim; i++) vec[i] *= scale;
+  }
+  return vec;
+}
+
+function decodeBase64(b64) {
+  if (typeof atob === 'function') {
+    const bin = atob(b64);
+    const out = new Int8Array(bin.length);
+    for (let i = 0; i < bin.length; i++) out[i] = (bin.charCodeAt(i) << 24) >> 24;
+    return out;
+  }
+  const buf = Buffer.from(b64, 'base64');
+  return new Int8Array(buf.buffer, buf.byteOffset, buf.length);
+}
+
+/**
+ * Install an already-parsed index (see StaticSearchIndex in search_index.go).
+ */
+function setSemanticIndex(index) {
+  SEMANTIC_SEARCH_STATE.attempted = true;
+  if (!index || index.provider !== 'hash' || !index.dim || !Array.isArray(index.ids)) {
+    SEMANTIC_SEARCH_STATE.reason = 'Unsupported search index';
+    return false;
+  }
+  const vectors = decodeBase64(index.vectors || '');
+  if (vectors.length !== index.ids.length * index.dim) {
+    SEMANTIC_SEARCH_STATE.reason = 'Search index is truncated';
+    return false;
+  }
+  SEMANTIC_SEARCH_STATE.dim = index.dim;
+  SEMANTIC_SEARCH_STATE.ids = index.ids;
+  SEMANTIC_SEARCH_STATE.scales = Float32Array.from(index.scales || []);
+  SEMANTIC_SEARCH_STATE.vectors = vectors;
+  SEMANTIC_SEARCH_STATE.ready = true;
+  SEMANTIC_SEARCH_STATE.reason = null;
+  return true;
+}
+
+/**
+ * Fetch data/search_index.json. Resolves false (keyword search only) when
+ * the bundle has no index.
+ */
+async function initSemanticSearch(url = 'data/search_index.json') {
+  if (SEMANTIC_SEARCH_STATE.attempted) return SEMANTIC_SEARCH_STATE.ready;
+  try {
+    const response = await fetch(url);
+    if (!response.ok) {
+      SEMANTIC_SEARCH_STATE.attempted = true;
+      SEMANTIC_SEARCH_STATE.reason = `Search index unavailable (HTTP ${response.status})`;
+      return false;
+    }
+    return setSemanticIndex(await response.json());
+  } catch (err) {
+    SEMANTIC_SEARCH_STATE.attempted = true;
+    SEMANTIC_SEARCH_STATE.reason = err?.message || 'Search index failed to load';
+    return false;
+  }
+}
+
+/**
+ * Rank issues by cosine similarity to query. Returns [{id, score}] best
+ * first (at most k when k > 0), or null when the index isn't loaded.
+ */
+function semanticSearch(query, k = 0, minScore = 0) {
+  const state = SEMANTIC_SEARCH_STATE;
+  if (!state.ready) return null;
+  const { dim, ids, scales, vectors } = state;
+  const q = hashEmbed(query, dim);
+
+  const results = [];
+  for (let i = 0; i < ids.length; i++) {
+    const base = i * dim;
+    let dot = 0;
+    for (let j = 0; j < dim; j++) {
+      if (q[j] !== 0) dot += vectors[base + j] * q[j];
+    }
+    const score = dot * scales[i];
+    if (score > minScore) results.push({ id: ids[i], score });
+  }
+  results.sort((a, b) => b.score - a.score || (a.id < b.id ? -1 : a.id > b.id ? 1 : 0));
+  return k > 0 ? results.slice(0, k) : results;
+}
+
+if (typeof window !== 'undefined') {
+  window.initSemanticSearch = initSemanticSearch;
+  window.getSemanticSearchStatus = getSemanticSearchStatus;
+  window.semanticSearch = semanticSearch;
+  window.SEMANTIC_MIN_SCORE = SEMANTIC_MIN_SCORE;
+}
+if (typeof module !== 'undefined' && module.exports) {
+  module.exports = { hashEmbed, setSemanticIndex, semanticSearch, SEMANTIC_MIN_SCORE };
+}
@@ -29,6 +29,8 @@ const DIAGNOSTICS = {
   graphWasm: false,      // bv_graph WASM loaded
   hybridWasm: false,     // hybrid scorer WASM loaded
   hybridWasmReason: null, // Reason when hybrid WASM disabled
+  semanticSearch: false, // data/search_index.json loaded
+  semanticSearchReason: null, // Reason when semantic search unavailable
   dbSource: 'unknown',   // 'network' | 'cache' | 'chunks'
   dbSizeBytes: 0,        // Database size in bytes
   issueCount: 0,         // Number of issues
@@ -1127,6 +1129,48 @@ function adjustHybridWeightsForQuery(baseWeights, term) {
   };
 }
 
+// Cap on semantic candidates passed to SQL (stays under SQLite's variable limit)
+const SEMANTIC_CANDIDATE_LIMIT = 500;
+
+/**
+ * Semantic search: rank by cosine similarity from semantic_search.js, then
+ * apply filters in SQL. Returns null when the vector index isn't loaded so
+ * callers fall back to keyword search.
+ */
+function semanticSearchRows(term, filters = {}) {
+  if (typeof window.semanticSearch !== 'function') return null;
+  const matches = window.semanticSearch(term, SEMANTIC_CANDIDATE_LIMIT, window.SEMANTIC_MIN_SCORE || 0);
+  if (!matches) return null;
+  if (matches.length === 0) return [];
+
+  const scoreByID = new Map(matches.map(m => [m.id, m.score]));
+  const { clauses, params } = buildFilterClauses(filters, 'i');
+  let sql = `
+      SELECT i.*
+      FROM issue_overview_mv i
+      WHERE i.id IN (${matches.map(() => '?').join(', ')})
+    `;
+  const queryParams = matches.map(m => m.id);
+  if (clauses.length > 0) {
+    sql += ` AND ${clauses.join(' AND ')}`;
+    queryParams.push(...params);
+  }
+
+  let rows;
+  try {
+    rows = execQuery(sql, queryParams);
+  } catch {
+    return null;
+  }
+  rows = rows
+    .map(r => ({ ...r, semantic_score: scoreByID.get(r.id) ?? 0 }))
+    .sort((a, b) => b.semantic_score - a.semantic_score);
+  if (isLikelyIssueID(term)) {
+    rows = promoteExactID(term, rows);
+  }
+  return rows;
+}
+
 function searchIssues(term, options = {}) {
   const {
     mode = 'text',
@@ -1139,6 +1183,11 @@ function searchIssues(term, options = {}) {
   const searchFilters = { ...filters };
   delete searchFilters.search;
 
+  if (mode === 'semantic') {
+    const rows = semanticSearchRows(term, searchFilters);
+    if (rows) return rows.slice(offset, offset + limit);
+  }
+
   const { clauses, params } = buildFilterClauses(searchFilters, 'i');
   const baseSQL = `
       SELECT i.*,
@@ -1211,9 +1260,15 @@ function searchIssues(term, options = {}) {
     }));
 }
 
-function countSearchIssues(term, filters = {}) {
+function countSearchIssues(term, filters = {}, mode = 'text') {
   const searchFilters = { ...filters };
   delete searchFilters.search;
+
+  if (mode === 'semantic') {
+    const rows = semanticSearchRows(term, searchFilters);
+    if (rows) return rows.length;
+  }
+
   const { clauses, params } = buildFilterClauses(searchFilters, 'i');
 
   let sql = `
@@ -1731,14 +1786,108 @@ function getIssueDependencies(id) {
   return { blocks, blockedBy };
 }
 
+// ============================================================================
+// Cross-references - issue IDs mentioned in text become permalinks
+// ============================================================================
+
+// Tokens shaped like issue IDs; mirrors mentionCandidateRegex in pkg/analysis
+const ISSUE_REF_PATTERN = /\b[A-Za-z][A-Za-z0-9_]*(?:-[A-Za-z0-9_]+)+(?:\.[0-9]+)*\b/g;
+
+// Known issue IDs, recomputed when a different database is loaded
+const ISSUE_ID_CACHE = { db: null, ids: null };
+
+function getIssueIDSet() {
+  if (ISSUE_ID_CACHE.db !== DB_STATE.db) {
+    ISSUE_ID_CACHE.db = DB_STATE.db;
+    ISSUE_ID_CACHE.ids = new Set(execQuery(`SELECT id FROM issues`).map(row => row.id));
+  }
+  return ISSUE_ID_CACHE.ids;
+}
+
+/**
+ * Resolve an ID-shaped token to a known issue ID, dropping trailing
+ * hyphenated parts so "bv-12-followup" still refers to bv-12
+ */
+function resolveIssueRef(token, ids) {
+  let candidate = token;
+  for (;;) {
+    if (ids.has(candidate)) return candidate;
+    const cut = candidate.lastIndexOf('-');
+    if (cut <= 0 || !candidate.slice(0, cut).includes('-')) return null;
+    candidate = candidate.slice(0, cut);
+  }
+}
+
+/**
+ * Turn mentions of known issue IDs in rendered HTML into permalinks
+ * (#/issue/:id), leaving code and existing links alone
+ */
+function linkifyIssueRefs(html) {
+  if (!html || !DB_STATE.db) return html;
+  const ids = getIssueIDSet();
+  const template = document.createElement('template');
+  template.innerHTML = html;
+
+  const walker = document.createTreeWalker(template.content, NodeFilter.SHOW_TEXT, {
+    acceptNode: node => node.parentElement?.closest('a, code, pre')
+      ? NodeFilter.FILTER_REJECT
+      : NodeFilter.FILTER_ACCEPT,
+  });
+  const textNodes = [];
+  while (walker.nextNode()) textNodes.push(walker.currentNode);
+
+  for (const node of textNodes) {
+    const text = node.textContent;
+    const fragment = document.createDocumentFragment();
+    let last = 0;
+    for (const match of text.matchAll(ISSUE_REF_PATTERN)) {
+      const prev = text[match.index - 1];
+      if (prev === '/' || prev === '@') continue;
+      const id = resolveIssueRef(match[0], ids);
+      if (!id) continue;
+      fragment.append(text.slice(last, match.index));
+      const link = document.createElement('a');
+      link.href = `#/issue/${encodeURIComponent(id)}`;
+      link.className = 'issue-ref';
+      link.textContent = id;
+      fragment.append(link);
+      last = match.index + id.length;
+    }
+    if (last === 0) continue;
+    fragment.append(text.slice(last));
+    node.replaceWith(fragment);
+  }
+  return template.innerHTML;
+}
+
+/**
+ * Get the issues whose text mentions an issue
+ */
+function getIssueBacklinks(id) {
+  try {
+    return execQuery(`
+      SELECT i.id, i.title, i.status FROM issue_mentions m
+      JOIN issues i ON i.id = m.issue_id
+      WHERE m.mentioned_id = ?
+      ORDER BY i.id
+    `, [id]);
+  } catch {
+    // Bundles exported before issue_mentions existed
+    return [];
+  }
+}
+
 // ============================================================================
 // URL State Sync - Shareable filtered views
 // ============================================================================
 
+// Search modes that can be restored from a shared link
+const SEARCH_MODES = ['text', 'hybrid', 'semantic'];
+
 /**
  * Serialize filters to URL search params
  */
-function filtersToURL(filters, sort, searchQuery) {
+function filtersToURL(filters, sort, searchQuery, searchMode = 'text', searchPreset = 'default') {
   const params = new URLSearchParams();
 
   if (filters.status?.length) {
@@ -1783,6 +1932,12 @@ function filtersToURL(filters, sort, searchQuery) {
 
   if (searchQuery) {
     params.set('q', searchQuery);
+    if (searchMode && searchMode !== 'text') {
+      params.set('mode', searchMode);
+      if (searchMode === 'hybrid' && searchPreset && searchPreset !== 'default') {
+        params.set('preset', searchPreset);
+      }
+    }
   }
 
   if (sort && sort !== 'priority') {
@@ -1798,7 +1953,9 @@ function filtersToURL(filters, sort, searchQuery) {
 function filtersFromURL() {
   const hash = window.location.hash;
   const queryIndex = hash.indexOf('?');
-  if (queryIndex === -1) return { filters: {}, sort: 'priority', searchQuery: '' };
+  if (queryIndex === -1) {
+    return { filters: {}, sort: 'priority', searchQuery: '', searchMode: 'text', searchPreset: 'default' };
+  }
 
   const params = new URLSearchParams(hash.slice(queryIndex + 1));
 
@@ -1841,18 +1998,23 @@ function filtersFromURL() {
     filters.isBlocking = true;
   }
 
+  const modeParam = params.get('mode');
+  const presetParam = params.get('preset');
+
   return {
     filters,
     sort: params.get('sort') || 'priority',
     searchQuery: params.get('q') || '',
+    searchMode: SEARCH_MODES.includes(modeParam) ? modeParam : 'text',
+    searchPreset: presetParam && typeof HYBRID_PRESETS !== 'undefined' && HYBRID_PRESETS[presetParam] ? presetParam : 'default',
   };
 }
 
 /**
  * Update URL with current filter state (without page reload)
  */
-function syncFiltersToURL(view, filters, sort, searchQuery) {
-  const paramString = filtersToURL(filters, sort, searchQuery);
+function syncFiltersToURL(view, filters, sort, searchQuery, searchMode, searchPreset) {
+  const paramString = filtersToURL(filters, sort, searchQuery, searchMode, searchPreset);
   const baseHash = `#/${view}`;
   const newHash = paramString ? `${baseHash}?${paramString}` : baseHash;
 
@@ -1946,20 +2108,100 @@ function navigate(path) {
 }
 
 /**
- * Navigate to issue detail
+ * Navigate to issue detail. params (from filtersToURL) preserve the list
+ * behind the modal so the link reopens the same filtered slice.
  */
-function navigateToIssue(id) {
-  navigate(`/issue/${encodeURIComponent(id)}`);
+function navigateToIssue(id, params = '') {
+  navigate(`/issue/${encodeURIComponent(id)}${params ? '?' + params : ''}`);
 }
 
 /**
  * Navigate to issues list with filters
  */
-function navigateToIssues(filters = {}, sort = 'priority', search = '') {
-  const params = filtersToURL(filters, sort, search);
+function navigateToIssues(filters = {}, sort = 'priority', search = '', searchMode = 'text', searchPreset = 'default') {
+  const params = filtersToURL(filters, sort, search, searchMode, searchPreset);
   navigate(`/issues${params ? '?' + params : ''}`);
 }
 
+/**
+ * Point the graph view URL at the selected node (or none) without adding
+ * a history entry for every click.
+ */
+function syncGraphSelectionToURL(id) {
+  const newHash = id ? `#/graph?issue=${encodeURIComponent(id)}` : '#/graph';
+  if (window.location.hash !== newHash) {
+    history.replaceState(null, '', newHash);
+  }
+}
+
+/**
+ * Copy text to the clipboard, falling back to execCommand where the async
+ * Clipboard API is unavailable (file:// pages, older browsers).
+ * @returns {Promise<boolean>} true on success
+ */
+async function copyToClipboard(text) {
+  try {
+    if (navigator.clipboard?.writeText) {
+      await navigator.clipboard.writeText(text);
+      return true;
+    }
+  } catch (err) {
+    console.warn('[Clipboard] writeText failed, trying fallback:', err);
+  }
+  const textarea = document.createElement('textarea');
+  textarea.value = text;
+  textarea.setAttribute('readonly', '');
+  textarea.style.position = 'fixed';
+  textarea.style.opacity = '0';
+  document.body.appendChild(textarea);
+  textarea.select();
+  let ok = false;
+  try {
+    ok = document.execCommand('copy');
+  } catch (err) {
+    ok = false;
+  }
+  document.body.removeChild(textarea);
+  return ok;
+}
+
+// ============================================================================
+// Saved Views - named deep links kept in localStorage
+// ============================================================================
+
+/**
+ * Storage key for saved views. Scoped by path so several exported sites on
+ * one origin (e.g. GitHub Pages) keep separate lists.
+ */
+function savedViewsKey() {
+  return `bv-saved-views:${window.location.pathname}`;
+}
+
+/**
+ * Load saved views: [{ name, hash }]
+ */
+function loadSavedViews() {
+  try {
+    const views = JSON.parse(localStorage.getItem(savedViewsKey()) || '[]');
+    return Array.isArray(views) ? views.filter(v => v && v.name && v.hash) : [];
+  } catch (err) {
+    return [];
+  }
+}
+
+/**
+ * Persist saved views
+ */
+function storeSavedViews(views) {
+  try {
+    localStorage.setItem(savedViewsKey(), JSON.stringify(views));
+    return true;
+  } catch (err) {
+    console.warn('[SavedViews] Could not save:', err);
+    return false;
+  }
+}
+
 /**
  * Navigate to dashboard
  */
@@ -2120,7 +2362,7 @@ function renderMarkdown(text) {
   if (!text) return '';
   try {
     const html = marked.parse(text);
-    return DOMPurify.sanitize(html);
+    return linkifyIssueRefs(DOMPurify.sanitize(html));
   } catch {
     return DOMPurify.sanitize(text);
   }
@@ -2233,8 +2475,12 @@ function beadsApp() {
     sort: 'priority',
     searchQuery: '',
     searchMode: 'text',
+    semanticSearchReady: false,
     searchPreset: 'default',
 
+    // Saved views (named deep links, persisted in localStorage)
+    savedViews: [],
+
     // Dashboard data
     topPicks: [],
     recentIssues: [],
@@ -2264,6 +2510,7 @@ function beadsApp() {
     forceGraphError: null,
     forceGraphModule: null,
     graphDetailNode: null, // Currently selected node for detail pane
+    graphPendingFocus: null, // Node ID from #/graph?issue= to center once the layout settles
 
     // Graph loading stages: 'init' | 'loading-data' | 'computing-metrics' | 'simulating' | null
     graphLoadingStage: null,
@@ -2326,6 +2573,15 @@ function beadsApp() {
       this.$watch('selectedIssue', updateBodyScrollLock);
       this.$watch('graphDetailNode', updateBodyScrollLock);
 
+      // Keep #/graph?issue= in step with the detail pane so the URL is shareable
+      this.$watch('graphDetailNode', (node) => {
+        if (this.view === 'graph') {
+          syncGraphSelectionToURL(node?.id);
+        }
+      });
+
+      this.savedViews = loadSavedViews();
+
       // Scroll to top on view change (respect reduced motion preference)
       this.$watch('view', (newView, oldView) => {
         if (newView !== oldView && !this.selectedIssue && !this.graphDetailNode) {
@@ -2479,6 +2735,17 @@ function beadsApp() {
               DIAGNOSTICS.hybridWasmReason = err?.message || 'Hybrid WASM init failed';
             });
         }
+        if (typeof window.initSemanticSearch === 'function') {
+          window.initSemanticSearch().then((enabled) => {
+            DIAGNOSTICS.semanticSearch = !!enabled;
+            this.semanticSearchReady = !!enabled;
+            if (!enabled) {
+              DIAGNOSTICS.semanticSearchReason = window.getSemanticSearchStatus().reason;
+            } else if (this.searchQuery && this.searchMode === 'semantic') {
+              this.loadIssues();
+            }
+          });
+        }
 
         this.topPicks = getTopPicks(5);
         this.recentIssues = getRecentIssues(10);
@@ -2580,6 +2847,13 @@ function beadsApp() {
             this.showDepGraph = false;
             this.whatIfResult = null;
             this.selectedIssue = getIssue(route.params.id);
+            if (!this.selectedIssue) {
+              showToast(`Issue not found: ${route.params.id}`, 'warning');
+            }
+            // Shared links carry the backdrop list's filters
+            if (hash.includes('?')) {
+              this.applyURLState(urlState);
+            }
             // Update nav list from current issues
             if (this.issues.length) {
               this.issueNavList = this.issues.map(i => i.id);
@@ -2592,11 +2866,7 @@ function beadsApp() {
           this.selectedIssue = null;
           this.showDepGraph = false;
           this.whatIfResult = null;
-          this.filters = { ...this.filters, ...urlState.filters };
-          this.sort = urlState.sort;
-          this.searchQuery = urlState.searchQuery;
-          this.page = 1;
-          this.loadIssues();
+          this.applyURLState(urlState);
           break;
 
         case 'insights':
@@ -2604,13 +2874,18 @@ function beadsApp() {
           this.selectedIssue = null;
           break;
 
-        case 'graph':
+        case 'graph': {
+          const focusId = route.query.get('issue');
           this.view = 'graph';
           this.selectedIssue = null;
-          this.$nextTick(() => {
-            this.initForceGraphView();
+          this.$nextTick(async () => {
+            await this.initForceGraphView();
+            if (focusId) {
+              this.focusGraphNode(focusId);
+            }
           });
           break;
+        }
 
         default:
           this.view = 'dashboard';
@@ -2618,6 +2893,41 @@ function beadsApp() {
       }
     },
 
+    /**
+     * Apply filters, sort and search parsed from the URL, then reload the list.
+     */
+    applyURLState(urlState) {
+      this.filters = { ...this.filters, ...urlState.filters };
+      this.sort = urlState.sort;
+      this.searchQuery = urlState.searchQuery;
+      this.searchMode = urlState.searchMode;
+      this.searchPreset = urlState.searchPreset;
+      this.page = 1;
+      this.loadIssues();
+    },
+
+    /**
+     * Select a node in the force graph by issue ID and open its detail pane.
+     * Centering waits for the simulation to settle (see simulationProgress).
+     */
+    focusGraphNode(id) {
+      const graph = this.forceGraphModule?.getGraph?.();
+      const node = graph?.graphData?.().nodes.find(n => n.id === id);
+      if (!node) {
+        showToast(`Issue not in graph: ${id}`, 'warning');
+        syncGraphSelectionToURL(null);
+        return;
+      }
+      this.forceGraphModule.selectNode?.(node);
+      this.graphDetailNode = node;
+      this.graphPendingFocus = id;
+      if (this.graphSimulationDone) {
+        this.forceGraphModule.focusNode?.(id);
+        this.graphPendingFocus = null;
+      }
+      setTimeout(() => this.resizeForceGraph(), 350);
+    },
+
     /**
      * Initialize (or refresh) the interactive force-graph view.
      * This is invoked when navigating to #/graph.
@@ -2726,6 +3036,10 @@ function beadsApp() {
           document.addEventListener('bv-graph:simulationProgress', (e) => {
             this.graphSimulationProgress = e.detail?.progress ?? 0;
             this.graphSimulationDone = e.detail?.done ?? false;
+            if (e.detail?.done && this.graphPendingFocus) {
+              this.forceGraphModule.focusNode?.(this.graphPendingFocus);
+              this.graphPendingFocus = null;
+            }
             if (e.detail?.done) {
               // Clear progress and stage after a short delay
               setTimeout(() => {
@@ -2884,15 +3198,15 @@ function beadsApp() {
           offset,
           filters,
         });
-        this.totalIssues = countSearchIssues(this.searchQuery, filters);
+        this.totalIssues = countSearchIssues(this.searchQuery, filters, this.searchMode);
       } else {
         this.issues = queryIssues(filters, this.sort, this.pageSize, offset);
         this.totalIssues = countIssues(filters);
       }
 
-      // Sync URL state (only on issues view)
-      if (this.view === 'issues') {
-        syncFiltersToURL('issues', this.filters, this.sort, this.searchQuery);
+      // Sync URL state (only on the issues list; #/issue/:id keeps its own hash)
+      if (this.view === 'issues' && parseRoute(window.location.hash).view !== 'issue') {
+        syncFiltersToURL('issues', this.filters, this.sort, this.searchQuery, this.searchMode, this.searchPreset);
       }
     },
 
@@ -3018,7 +3332,16 @@ function beadsApp() {
      * Show issue detail (navigates to issue route)
      */
     showIssue(id) {
-      navigateToIssue(id);
+      navigateToIssue(id, this.listURLParams());
+    },
+
+    /**
+     * URL params describing the issues list behind an open issue, or '' when
+     * the issue wasn't opened from the list.
+     */
+    listURLParams() {
+      if (this.view !== 'issues') return '';
+      return filtersToURL(this.filters, this.sort, this.searchQuery, this.searchMode, this.searchPreset);
     },
 
     /**
@@ -3033,7 +3356,7 @@ function beadsApp() {
       // Navigate back
       const currentView = this.view;
       if (currentView === 'issues') {
-        navigateToIssues(this.filters, this.sort, this.searchQuery);
+        navigateToIssues(this.filters, this.sort, this.searchQuery, this.searchMode, this.searchPreset);
       } else {
         navigate('/' + currentView);
       }
@@ -3069,7 +3392,7 @@ function beadsApp() {
       this.whatIfResult = null;
       const route = parseRoute(window.location.hash);
       if (route.view === 'issue') {
-        navigateToIssue(newId);
+        navigateToIssue(newId, this.listURLParams());
       } else {
         this.selectIssue(newId);
       }
@@ -3147,6 +3470,70 @@ function beadsApp() {
       }
     },
 
+    /**
+     * Copy a link to the current view (filters, search, selected issue or
+     * graph node are all in the hash).
+     */
+    async copyShareLink() {
+      await this.copyLink(window.location.href);
+    },
+
+    /**
+     * Copy a deep link to one issue, keeping the list it was opened from
+     */
+    async copyIssueLink(id) {
+      const params = this.listURLParams();
+      const base = window.location.href.split('#')[0];
+      await this.copyLink(`${base}#/issue/${encodeURIComponent(id)}${params ? '?' + params : ''}`);
+    },
+
+    async copyLink(url) {
+      if (await copyToClipboard(url)) {
+        showToast('Link copied to clipboard', 'success');
+      } else {
+        showToast(`Copy failed - link: ${url}`, 'warning');
+      }
+    },
+
+    /**
+     * Save the current hash under a name for quick recall
+     */
+    saveCurrentView() {
+      const hash = window.location.hash || '#/';
+      const name = window.prompt('Name this view:', this.searchQuery || 'My view');
+      if (!name || !name.trim()) return;
+
+      const trimmed = name.trim();
+      const views = this.savedViews.filter(v => v.name !== trimmed);
+      views.push({ name: trimmed, hash });
+      views.sort((a, b) => a.name.localeCompare(b.name));
+      if (storeSavedViews(views)) {
+        this.savedViews = views;
+        showToast(`Saved view "${trimmed}"`, 'success');
+      } else {
+        showToast('Could not save view (storage unavailable)', 'error');
+      }
+    },
+
+    /**
+     * Open a saved view
+     */
+    applySavedView(name) {
+      const view = this.savedViews.find(v => v.name === name);
+      if (view) {
+        navigate(view.hash);
+      }
+    },
+
+    /**
+     * Remove a saved view
+     */
+    deleteSavedView(name) {
+      const views = this.savedViews.filter(v => v.name !== name);
+      storeSavedViews(views);
+      this.savedViews = views;
+    },
+
     /**
      * Toggle dark mode
      */
@@ -3387,6 +3774,11 @@ function beadsApp() {
      */
     getIssue,
 
+    /**
+     * Issues mentioning an issue (wrapper for templates)
+     */
+    getIssueBacklinks,
+
     /**
      * Dismiss the current error modal
      */
@@ -3416,6 +3808,8 @@ window.beadsViewer = {
   countIssues,
   getIssue,
   getIssueDependencies,
+  getIssueBacklinks,
+  linkifyIssueRefs,
   getStats,
   getMeta,
   getFilterOptions,
@@ -3432,6 +3826,10 @@ window.beadsViewer = {
   navigateToIssue,
   navigateToIssues,
   navigateToDashboard,
+  syncGraphSelectionToURL,
+  copyToClipboard,
+  loadSavedViews,
+  storeSavedViews,
   goBack,
 
   // Graph Engine
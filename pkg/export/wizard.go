@@ -1,17 +1,21 @@
 // Package export provides data export functionality for bv.
 //
 // This file implements the interactive deployment wizard for --pages flag.
-// It guides users through exporting and deploying static sites to GitHub Pages.
+// It guides users through exporting and deploying static sites to GitHub
+// Pages, Cloudflare Pages, GitLab Pages, S3 or Netlify.
 package export
 
 import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"strings"
 	"time"
 
+	"github.com/Dicklesworthstone/beads_viewer/pkg/configdir"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/offline"
 	"github.com/charmbracelet/huh"
 	"golang.org/x/term"
 )
@@ -25,7 +29,7 @@ type WizardConfig struct {
 	Subtitle       string `json:"subtitle,omitempty"`
 
 	// Deployment target
-	DeployTarget string `json:"deploy_target"` // "github", "cloudflare", "local"
+	DeployTarget string `json:"deploy_target"` // "github", "cloudflare", "gitlab", "s3", "netlify", "local"
 
 	// GitHub options
 	RepoName        string `json:"repo_name,omitempty"`
@@ -36,6 +40,18 @@ type WizardConfig struct {
 	CloudflareProject string `json:"cloudflare_project,omitempty"`
 	CloudflareBranch  string `json:"cloudflare_branch,omitempty"`
 
+	// GitLab options (token comes from GITLAB_TOKEN, never saved)
+	GitLabProject string `json:"gitlab_project,omitempty"` // [host/]group/project
+	GitLabBranch  string `json:"gitlab_branch,omitempty"`
+
+	// S3 options (credentials come from the aws CLI)
+	S3Bucket               string `json:"s3_bucket,omitempty"` // s3://bucket/prefix
+	CloudFrontDistribution string `json:"cloudfront_distribution,omitempty"`
+
+	// Netlify options
+	NetlifySite  string `json:"netlify_site,omitempty"`
+	NetlifyToken string `json:"-"` // From NETLIFY_AUTH_TOKEN or the prompt; never saved
+
 	// Output path for bundle
 	OutputPath string `json:"output_path,omitempty"`
 }
@@ -102,6 +118,18 @@ func (w *Wizard) offerSavedConfig(saved *WizardConfig) (bool, error) {
 		if saved.Title != "" {
 			fmt.Printf("  Title:   %s\n", saved.Title)
 		}
+	case "gitlab":
+		fmt.Printf("  Target:  GitLab Pages\n")
+		fmt.Printf("  Project: %s\n", saved.GitLabProject)
+	case "s3":
+		fmt.Printf("  Target: Amazon S3\n")
+		fmt.Printf("  Bucket: %s\n", saved.S3Bucket)
+		if saved.CloudFrontDistribution != "" {
+			fmt.Printf("  CloudFront: %s\n", saved.CloudFrontDistribution)
+		}
+	case "netlify":
+		fmt.Printf("  Target: Netlify\n")
+		fmt.Printf("  Site:   %s\n", saved.NetlifySite)
 	case "local":
 		fmt.Printf("  Target: Local export\n")
 		fmt.Printf("  Path:   %s\n", saved.OutputPath)
@@ -201,7 +229,7 @@ func (w *Wizard) printBanner() {
 	fmt.Println("║  This wizard will:                                               ║")
 	fmt.Println("║    1. Export your issues to a static HTML bundle                 ║")
 	fmt.Println("║    2. Preview it locally                                         ║")
-	fmt.Println("║    3. Deploy to GitHub Pages, Cloudflare Pages, or export only   ║")
+	fmt.Println("║    3. Deploy to GitHub/GitLab/Cloudflare Pages, S3 or Netlify    ║")
 	fmt.Println("║                                                                  ║")
 	fmt.Println("║  Press Ctrl+C anytime to cancel                                  ║")
 	fmt.Println("╚══════════════════════════════════════════════════════════════════╝")
@@ -262,6 +290,9 @@ func (w *Wizard) collectDeployTarget() error {
 				Options(
 					huh.NewOption("GitHub Pages (create/update repository)", "github"),
 					huh.NewOption("Cloudflare Pages (requires wrangler CLI)", "cloudflare"),
+					huh.NewOption("GitLab Pages (push to a GitLab project)", "gitlab"),
+					huh.NewOption("Amazon S3 + optional CloudFront (requires aws CLI)", "s3"),
+					huh.NewOption("Netlify (requires an access token)", "netlify"),
 					huh.NewOption("Export locally only", "local"),
 				).
 				Value(&w.config.DeployTarget),
@@ -282,6 +313,12 @@ func (w *Wizard) collectTargetConfig() error {
 		return w.collectGitHubConfig()
 	case "cloudflare":
 		return w.collectCloudflareConfig()
+	case "gitlab":
+		return w.collectGitLabConfig()
+	case "s3":
+		return w.collectS3Config()
+	case "netlify":
+		return w.collectNetlifyConfig()
 	case "local":
 		return w.collectLocalConfig()
 	}
@@ -383,6 +420,123 @@ func (w *Wizard) collectCloudflareConfig() error {
 	return nil
 }
 
+func (w *Wizard) collectGitLabConfig() error {
+	fmt.Println("Step 3: GitLab Pages Configuration")
+	fmt.Println("────────────────────────────")
+
+	project := w.config.GitLabProject
+	branch := "main"
+
+	form := newForm(
+		huh.NewGroup(
+			huh.NewInput().
+				Title("GitLab project").
+				Description("group/project, or host/group/project for self-managed GitLab").
+				Value(&project).
+				Placeholder("my-group/issues-site").
+				Validate(func(s string) error {
+					if _, path := ParseGitLabProject(s); !strings.Contains(path, "/") {
+						return fmt.Errorf("use group/project")
+					}
+					return nil
+				}),
+			huh.NewInput().
+				Title("Branch name").
+				Value(&branch).
+				Placeholder("main"),
+		),
+	)
+
+	if err := form.Run(); err != nil {
+		return err
+	}
+
+	w.config.GitLabProject = strings.TrimSpace(project)
+	if branch != "" {
+		w.config.GitLabBranch = branch
+	} else {
+		w.config.GitLabBranch = "main"
+	}
+
+	fmt.Println("")
+	return nil
+}
+
+func (w *Wizard) collectS3Config() error {
+	fmt.Println("Step 3: Amazon S3 Configuration")
+	fmt.Println("────────────────────────────")
+
+	bucket := w.config.S3Bucket
+	distribution := w.config.CloudFrontDistribution
+
+	form := newForm(
+		huh.NewGroup(
+			huh.NewInput().
+				Title("S3 location").
+				Description("s3://bucket or s3://bucket/prefix").
+				Value(&bucket).
+				Placeholder("s3://my-bucket/issues").
+				Validate(func(s string) error {
+					_, _, err := ParseS3Location(s)
+					return err
+				}),
+			huh.NewInput().
+				Title("CloudFront distribution ID (optional)").
+				Description("Invalidated after each upload").
+				Value(&distribution),
+		),
+	)
+
+	if err := form.Run(); err != nil {
+		return err
+	}
+
+	w.config.S3Bucket = strings.TrimSpace(bucket)
+	w.config.CloudFrontDistribution = strings.TrimSpace(distribution)
+
+	fmt.Println("")
+	return nil
+}
+
+func (w *Wizard) collectNetlifyConfig() error {
+	fmt.Println("Step 3: Netlify Configuration")
+	fmt.Println("────────────────────────────")
+
+	site := w.config.NetlifySite
+
+	fields := []huh.Field{
+		huh.NewInput().
+			Title("Netlify site").
+			Description("Site name, domain or ID (create the site in Netlify first)").
+			Value(&site).
+			Placeholder("my-issues-site").
+			Validate(func(s string) error {
+				if strings.TrimSpace(s) == "" {
+					return fmt.Errorf("site is required")
+				}
+				return nil
+			}),
+	}
+	token := os.Getenv(NetlifyTokenEnv)
+	if token == "" {
+		fields = append(fields, huh.NewInput().
+			Title("Netlify personal access token").
+			Description("Not saved; set "+NetlifyTokenEnv+" to skip this prompt").
+			EchoMode(huh.EchoModePassword).
+			Value(&token))
+	}
+
+	if err := newForm(huh.NewGroup(fields...)).Run(); err != nil {
+		return err
+	}
+
+	w.config.NetlifySite = strings.TrimSpace(site)
+	w.config.NetlifyToken = strings.TrimSpace(token)
+
+	fmt.Println("")
+	return nil
+}
+
 func (w *Wizard) collectLocalConfig() error {
 	fmt.Println("Step 3: Local Export Configuration")
 	fmt.Println("────────────────────────────")
@@ -562,6 +716,46 @@ func (w *Wizard) checkPrerequisites() error {
 		} else {
 			fmt.Println("✓ Authenticated with Cloudflare")
 		}
+
+	case "gitlab":
+		if _, err := exec.LookPath("git"); err != nil {
+			fmt.Println("✗ git not installed")
+			return fmt.Errorf("git is required for GitLab Pages deployment")
+		}
+		fmt.Println("✓ git installed")
+		if os.Getenv(GitLabTokenEnv) != "" {
+			fmt.Printf("✓ Using token from %s\n", GitLabTokenEnv)
+		} else {
+			fmt.Printf("! %s not set; using your git credentials for GitLab\n", GitLabTokenEnv)
+		}
+
+	case "s3":
+		status, err := CheckAWSStatus()
+		if err != nil {
+			return fmt.Errorf("failed to check aws status: %w", err)
+		}
+		if !status.Installed {
+			fmt.Println("✗ aws CLI not installed")
+			ShowAWSInstallInstructions()
+			return fmt.Errorf("aws CLI is required for S3 deployment")
+		}
+		fmt.Println("✓ aws CLI installed")
+		if !status.Authenticated {
+			fmt.Println("✗ aws CLI has no working credentials")
+			fmt.Println("  Run 'aws configure' or set AWS_PROFILE / AWS_ACCESS_KEY_ID")
+			return fmt.Errorf("AWS credentials required")
+		}
+		fmt.Printf("✓ Authenticated (account %s)\n", status.Account)
+
+	case "netlify":
+		if w.config.NetlifyToken == "" {
+			w.config.NetlifyToken = os.Getenv(NetlifyTokenEnv)
+		}
+		if w.config.NetlifyToken == "" {
+			fmt.Println("✗ No Netlify token")
+			return fmt.Errorf("netlify token required - set %s", NetlifyTokenEnv)
+		}
+		fmt.Println("✓ Netlify token available")
 	}
 
 	fmt.Println("")
@@ -662,20 +856,31 @@ func (w *Wizard) PerformDeploy() (*WizardResult, error) {
 	fmt.Println("Step 7: Deploy")
 	fmt.Println("────────────────────────────")
 
+	return DeployBundle(w.config, w.bundlePath, w.isUpdate)
+}
+
+// DeployBundle deploys an exported bundle to config.DeployTarget without
+// prompting. The wizard uses it after collecting settings; --pages-deploy
+// uses it directly for CI. update allows overwriting an existing GitHub
+// repository.
+func DeployBundle(config *WizardConfig, bundlePath string, update bool) (*WizardResult, error) {
+	if err := offline.Check("deploying to " + config.DeployTarget); err != nil {
+		return nil, err
+	}
 	result := &WizardResult{
-		BundlePath:   w.bundlePath,
-		DeployTarget: w.config.DeployTarget,
+		BundlePath:   bundlePath,
+		DeployTarget: config.DeployTarget,
 	}
 
-	switch w.config.DeployTarget {
+	switch config.DeployTarget {
 	case "github":
 		deployConfig := GitHubDeployConfig{
-			RepoName:         w.config.RepoName,
-			Private:          w.config.RepoPrivate,
-			Description:      w.config.RepoDescription,
-			BundlePath:       w.bundlePath,
-			SkipConfirmation: true,           // Already confirmed in wizard prerequisites
-			ForceOverwrite:   w.isUpdate,     // Auto-overwrite when updating existing deployment
+			RepoName:         config.RepoName,
+			Private:          config.RepoPrivate,
+			Description:      config.RepoDescription,
+			BundlePath:       bundlePath,
+			SkipConfirmation: true,   // Already confirmed in wizard prerequisites
+			ForceOverwrite:   update, // Auto-overwrite when updating existing deployment
 		}
 
 		deployResult, err := DeployToGitHubPages(deployConfig)
@@ -688,9 +893,9 @@ func (w *Wizard) PerformDeploy() (*WizardResult, error) {
 
 	case "cloudflare":
 		deployConfig := CloudflareDeployConfig{
-			ProjectName:      w.config.CloudflareProject,
-			BundlePath:       w.bundlePath,
-			Branch:           w.config.CloudflareBranch,
+			ProjectName:      config.CloudflareProject,
+			BundlePath:       bundlePath,
+			Branch:           config.CloudflareBranch,
 			SkipConfirmation: true, // Already confirmed in prerequisites
 		}
 
@@ -703,9 +908,53 @@ func (w *Wizard) PerformDeploy() (*WizardResult, error) {
 		result.CloudflareURL = deployResult.URL
 		result.PagesURL = deployResult.URL
 
+	case "gitlab":
+		deployResult, err := DeployToGitLabPages(GitLabDeployConfig{
+			Project:    config.GitLabProject,
+			Branch:     config.GitLabBranch,
+			Token:      os.Getenv(GitLabTokenEnv),
+			BundlePath: bundlePath,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("deployment failed: %w", err)
+		}
+
+		result.RepoFullName = deployResult.Host + "/" + deployResult.Project
+		result.PagesURL = deployResult.PagesURL
+
+	case "s3":
+		deployResult, err := DeployToS3(S3DeployConfig{
+			Bucket:                 config.S3Bucket,
+			CloudFrontDistribution: config.CloudFrontDistribution,
+			BundlePath:             bundlePath,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("deployment failed: %w", err)
+		}
+
+		result.PagesURL = deployResult.URL
+
+	case "netlify":
+		token := config.NetlifyToken
+		if token == "" {
+			token = os.Getenv(NetlifyTokenEnv)
+		}
+		deployResult, err := DeployToNetlify(NetlifyDeployConfig{
+			Site:       config.NetlifySite,
+			Token:      token,
+			BundlePath: bundlePath,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("deployment failed: %w", err)
+		}
+
+		result.PagesURL = deployResult.URL
+
 	case "local":
-		fmt.Printf("Bundle exported to: %s\n", w.bundlePath)
-		result.BundlePath = w.bundlePath
+		fmt.Printf("Bundle exported to: %s\n", bundlePath)
+
+	default:
+		return nil, fmt.Errorf("unknown deploy target %q", config.DeployTarget)
 	}
 
 	return result, nil
@@ -728,6 +977,21 @@ func (w *Wizard) PrintSuccess(result *WizardResult) {
 		lines = append(lines, "Live site:  "+result.CloudflareURL)
 		lines = append(lines, "")
 		lines = append(lines, "Cloudflare Pages deploys are typically available immediately")
+	case "gitlab":
+		lines = append(lines, "Project:    https://"+result.RepoFullName)
+		if result.PagesURL != "" {
+			lines = append(lines, "Live site:  "+result.PagesURL)
+		} else {
+			lines = append(lines, "Live site:  see Deploy > Pages in the project")
+		}
+		lines = append(lines, "")
+		lines = append(lines, "Note: the site is live once the pages CI job finishes")
+	case "s3":
+		lines = append(lines, "Live site:  "+result.PagesURL)
+		lines = append(lines, "")
+		lines = append(lines, "Note: CloudFront invalidations can take a few minutes")
+	case "netlify":
+		lines = append(lines, "Live site:  "+result.PagesURL)
 	case "local":
 		lines = append(lines, "Bundle: "+result.BundlePath)
 		lines = append(lines, "")
@@ -783,11 +1047,7 @@ func (w *Wizard) PrintSuccess(result *WizardResult) {
 
 // WizardConfigPath returns the path to the wizard config file.
 func WizardConfigPath() string {
-	home, err := os.UserHomeDir()
-	if err != nil {
-		return ""
-	}
-	return filepath.Join(home, ".config", "bv", "pages-wizard.json")
+	return configdir.Path("pages-wizard.json")
 }
 
 // LoadWizardConfig loads previously saved wizard configuration.
@@ -109,3 +109,47 @@ exit 0
 		t.Fatalf("checkPrerequisites returned error: %v", err)
 	}
 }
+
+func TestWizard_checkPrerequisites_S3_AWSNotAuthenticated(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("shell script stubs not supported on windows in this test")
+	}
+
+	binDir := t.TempDir()
+
+	// aws stub whose sts call fails (no credentials)
+	writeExecutable(t, binDir, "aws", "#!/bin/sh\nexit 255\n")
+
+	origPath := os.Getenv("PATH")
+	t.Setenv("PATH", fmt.Sprintf("%s%c%s", binDir, os.PathListSeparator, origPath))
+
+	wizard := NewWizard("/tmp/test")
+	wizard.config.DeployTarget = "s3"
+
+	if err := wizard.checkPrerequisites(); err == nil {
+		t.Fatal("expected error when aws has no credentials")
+	}
+
+	writeExecutable(t, binDir, "aws", "#!/bin/sh\necho '{\"Account\": \"123456789012\", \"Arn\": \"arn:aws:iam::123456789012:user/ci\"}'\n")
+	if err := wizard.checkPrerequisites(); err != nil {
+		t.Fatalf("checkPrerequisites returned error: %v", err)
+	}
+}
+
+func TestWizard_checkPrerequisites_Netlify_Token(t *testing.T) {
+	t.Setenv(NetlifyTokenEnv, "")
+
+	wizard := NewWizard("/tmp/test")
+	wizard.config.DeployTarget = "netlify"
+	if err := wizard.checkPrerequisites(); err == nil {
+		t.Fatal("expected error without a Netlify token")
+	}
+
+	t.Setenv(NetlifyTokenEnv, "nfp_test")
+	if err := wizard.checkPrerequisites(); err != nil {
+		t.Fatalf("checkPrerequisites returned error: %v", err)
+	}
+	if wizard.config.NetlifyToken != "nfp_test" {
+		t.Errorf("expected token picked up from %s, got %q", NetlifyTokenEnv, wizard.config.NetlifyToken)
+	}
+}
@@ -3,6 +3,7 @@ package export
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -247,3 +248,44 @@ func TestWizard_PrintSuccess_Cloudflare(t *testing.T) {
 	// Just verify it doesn't panic
 	wizard.PrintSuccess(result)
 }
+
+func TestWizard_PrintSuccess_OtherTargets(t *testing.T) {
+	wizard := NewWizard("/tmp/test")
+
+	// Just verify they don't panic
+	wizard.PrintSuccess(&WizardResult{DeployTarget: "gitlab", RepoFullName: "gitlab.com/group/issues", PagesURL: "https://group.gitlab.io/issues/"})
+	wizard.PrintSuccess(&WizardResult{DeployTarget: "gitlab", RepoFullName: "gitlab.example.com/group/issues"})
+	wizard.PrintSuccess(&WizardResult{DeployTarget: "s3", PagesURL: "https://d111.cloudfront.net/"})
+	wizard.PrintSuccess(&WizardResult{DeployTarget: "netlify", PagesURL: "https://issues.netlify.app"})
+}
+
+func TestSaveWizardConfig_OmitsNetlifyToken(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	config := &WizardConfig{
+		DeployTarget: "netlify",
+		NetlifySite:  "issues",
+		NetlifyToken: "nfp_secret",
+	}
+	if err := SaveWizardConfig(config); err != nil {
+		t.Fatalf("SaveWizardConfig returned error: %v", err)
+	}
+	data, err := os.ReadFile(WizardConfigPath())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "nfp_secret") {
+		t.Errorf("token was written to the saved config: %s", data)
+	}
+
+	loaded, err := LoadWizardConfig()
+	if err != nil || loaded == nil || loaded.NetlifySite != "issues" {
+		t.Fatalf("LoadWizardConfig = %+v, %v", loaded, err)
+	}
+}
+
+func TestDeployBundle_UnknownTarget(t *testing.T) {
+	if _, err := DeployBundle(&WizardConfig{DeployTarget: "ftp"}, t.TempDir(), false); err == nil {
+		t.Error("expected error for unknown deploy target")
+	}
+}
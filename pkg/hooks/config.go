@@ -1,6 +1,8 @@
-// Package hooks provides a hook system for bv export automation.
-// Hooks are configured via .bv/hooks.yaml and run at specific points
-// in the export pipeline (pre-export, post-export).
+// Package hooks provides a hook system for bv automation.
+// Hooks are configured via .bv/hooks.yaml and run at specific points:
+// around exports and baseline snapshots, after a live reload, when drift
+// alerts fire, and after triage. Each hook gets its context as environment
+// variables and as a JSON payload on stdin.
 package hooks
 
 import (
@@ -21,8 +23,26 @@ const (
 	PreExport HookPhase = "pre-export"
 	// PostExport runs after export is written. Failure is logged but doesn't break export.
 	PostExport HookPhase = "post-export"
+	// PostReload runs after the TUI reloads a changed beads file.
+	PostReload HookPhase = "post-reload"
+	// OnDriftAlert runs when --check-drift finds alerts.
+	OnDriftAlert HookPhase = "on-drift-alert"
+	// PreSnapshot runs before --save-baseline writes a baseline. Failure cancels the save.
+	PreSnapshot HookPhase = "pre-snapshot"
+	// PostSnapshot runs after a baseline is saved.
+	PostSnapshot HookPhase = "post-snapshot"
+	// OnTriage runs after triage recommendations are computed.
+	OnTriage HookPhase = "on-triage"
 )
 
+// Phases lists every hook phase, in the order they appear in hooks.yaml
+var Phases = []HookPhase{PreExport, PostExport, PostReload, OnDriftAlert, PreSnapshot, PostSnapshot, OnTriage}
+
+// cancels reports whether a failing hook cancels the operation by default
+func (p HookPhase) cancels() bool {
+	return p == PreExport || p == PreSnapshot
+}
+
 // Hook defines a single hook configuration
 type Hook struct {
 	Name    string            `yaml:"name" json:"name"`                             // Human-readable name
@@ -30,6 +50,7 @@ type Hook struct {
 	Timeout time.Duration     `yaml:"timeout,omitempty" json:"timeout,omitempty"`   // Execution timeout (default: 30s)
 	Env     map[string]string `yaml:"env,omitempty" json:"env,omitempty"`           // Additional environment variables
 	OnError string            `yaml:"on_error,omitempty" json:"on_error,omitempty"` // "fail" (default for pre) or "continue" (default for post)
+	Group   string            `yaml:"group,omitempty" json:"group,omitempty"`       // Consecutive hooks in the same group run in parallel
 }
 
 // Config holds all hook configurations
@@ -39,16 +60,44 @@ type Config struct {
 
 // HooksByPhase organizes hooks by their execution phase
 type HooksByPhase struct {
-	PreExport  []Hook `yaml:"pre-export,omitempty" json:"pre-export,omitempty"`
-	PostExport []Hook `yaml:"post-export,omitempty" json:"post-export,omitempty"`
+	PreExport    []Hook `yaml:"pre-export,omitempty" json:"pre-export,omitempty"`
+	PostExport   []Hook `yaml:"post-export,omitempty" json:"post-export,omitempty"`
+	PostReload   []Hook `yaml:"post-reload,omitempty" json:"post-reload,omitempty"`
+	OnDriftAlert []Hook `yaml:"on-drift-alert,omitempty" json:"on-drift-alert,omitempty"`
+	PreSnapshot  []Hook `yaml:"pre-snapshot,omitempty" json:"pre-snapshot,omitempty"`
+	PostSnapshot []Hook `yaml:"post-snapshot,omitempty" json:"post-snapshot,omitempty"`
+	OnTriage     []Hook `yaml:"on-triage,omitempty" json:"on-triage,omitempty"`
+}
+
+// list returns the hooks of a phase, or nil for an unknown phase
+func (h *HooksByPhase) list(phase HookPhase) *[]Hook {
+	switch phase {
+	case PreExport:
+		return &h.PreExport
+	case PostExport:
+		return &h.PostExport
+	case PostReload:
+		return &h.PostReload
+	case OnDriftAlert:
+		return &h.OnDriftAlert
+	case PreSnapshot:
+		return &h.PreSnapshot
+	case PostSnapshot:
+		return &h.PostSnapshot
+	case OnTriage:
+		return &h.OnTriage
+	default:
+		return nil
+	}
 }
 
-// ExportContext contains information passed to hooks via environment variables
+// ExportContext contains information passed to export hooks via environment
+// variables, and as the data of their stdin payload
 type ExportContext struct {
-	ExportPath   string    // BV_EXPORT_PATH: Output file path
-	ExportFormat string    // BV_EXPORT_FORMAT: 'markdown' or 'json'
-	IssueCount   int       // BV_ISSUE_COUNT: Number of issues exported
-	Timestamp    time.Time // BV_TIMESTAMP: Export timestamp (RFC3339)
+	ExportPath   string    `json:"export_path"`   // BV_EXPORT_PATH: Output file path
+	ExportFormat string    `json:"export_format"` // BV_EXPORT_FORMAT: 'markdown' or 'json'
+	IssueCount   int       `json:"issue_count"`   // BV_ISSUE_COUNT: Number of issues exported
+	Timestamp    time.Time `json:"timestamp"`     // BV_TIMESTAMP: Export timestamp (RFC3339)
 }
 
 // ToEnv converts export context to environment variables
@@ -124,8 +173,10 @@ func (l *Loader) Load() error {
 
 // normalizeConfig applies defaults and validates hooks
 func (l *Loader) normalizeConfig(config *Config) {
-	config.Hooks.PreExport, l.warnings = normalizeHooks(config.Hooks.PreExport, PreExport, l.warnings)
-	config.Hooks.PostExport, l.warnings = normalizeHooks(config.Hooks.PostExport, PostExport, l.warnings)
+	for _, phase := range Phases {
+		hooks := config.Hooks.list(phase)
+		*hooks, l.warnings = normalizeHooks(*hooks, phase, l.warnings)
+	}
 }
 
 // normalizeHooks applies defaults, drops empty commands, and accumulates warnings.
@@ -141,10 +192,10 @@ func normalizeHooks(hooks []Hook, phase HookPhase, warnings []string) ([]Hook, [
 			hook.Timeout = DefaultTimeout
 		}
 		if hook.OnError == "" {
-			if phase == PreExport {
-				hook.OnError = "fail" // pre-export failures cancel export by default
+			if phase.cancels() {
+				hook.OnError = "fail" // pre-* failures cancel the operation by default
 			} else {
-				hook.OnError = "continue" // post-export failures don't break export by default
+				hook.OnError = "continue" // other failures are only reported by default
 			}
 		}
 		if hook.Name == "" {
@@ -168,7 +219,22 @@ func (l *Loader) HasHooks() bool {
 	if l.config == nil {
 		return false
 	}
-	return len(l.config.Hooks.PreExport) > 0 || len(l.config.Hooks.PostExport) > 0
+	for _, phase := range Phases {
+		if len(*l.config.Hooks.list(phase)) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// HasHooksFor returns true if any of the given phases has hooks
+func (l *Loader) HasHooksFor(phases ...HookPhase) bool {
+	for _, phase := range phases {
+		if len(l.GetHooks(phase)) > 0 {
+			return true
+		}
+	}
+	return false
 }
 
 // GetHooks returns hooks for a specific phase
@@ -177,14 +243,10 @@ func (l *Loader) GetHooks(phase HookPhase) []Hook {
 		return nil
 	}
 
-	switch phase {
-	case PreExport:
-		return l.config.Hooks.PreExport
-	case PostExport:
-		return l.config.Hooks.PostExport
-	default:
-		return nil
+	if hooks := l.config.Hooks.list(phase); hooks != nil {
+		return *hooks
 	}
+	return nil
 }
 
 // Warnings returns any warnings from loading
@@ -211,6 +273,7 @@ func (h *Hook) UnmarshalYAML(node *yaml.Node) error {
 		Timeout string            `yaml:"timeout,omitempty"`
 		Env     map[string]string `yaml:"env,omitempty"`
 		OnError string            `yaml:"on_error,omitempty"`
+		Group   string            `yaml:"group,omitempty"`
 	}
 
 	var dto hookDTO
@@ -222,6 +285,7 @@ func (h *Hook) UnmarshalYAML(node *yaml.Node) error {
 	h.Command = dto.Command
 	h.Env = dto.Env
 	h.OnError = dto.OnError
+	h.Group = dto.Group
 
 	// Parse timeout
 	if dto.Timeout != "" {
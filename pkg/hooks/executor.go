@@ -3,12 +3,14 @@ package hooks
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
 	"os/exec"
 	"runtime"
 	"sort"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -50,47 +52,91 @@ func (e *Executor) SetLogger(logger func(string)) {
 	e.logger = logger
 }
 
+// Payload is the JSON document each hook reads on stdin
+type Payload struct {
+	Phase     HookPhase `json:"phase"`
+	Timestamp time.Time `json:"timestamp"`
+	Data      any       `json:"data,omitempty"` // Phase-specific context
+}
+
 // RunPreExport executes all pre-export hooks
 // Returns error if any hook fails with on_error="fail"
 func (e *Executor) RunPreExport() error {
-	if e.config == nil {
-		return nil
-	}
-
-	for _, hook := range e.config.Hooks.PreExport {
-		e.logger(fmt.Sprintf("Running pre-export hook %q: %s", hook.Name, hook.Command))
-		result := e.runHook(hook, PreExport)
-		e.results = append(e.results, result)
-
-		if !result.Success && hook.OnError == "fail" {
-			return fmt.Errorf("pre-export hook %q failed: %w", hook.Name, result.Error)
-		}
-	}
-
-	return nil
+	return e.Run(PreExport, e.context)
 }
 
 // RunPostExport executes all post-export hooks
 // Errors are logged but don't fail (unless on_error="fail")
 func (e *Executor) RunPostExport() error {
+	return e.Run(PostExport, e.context)
+}
+
+// Run executes the hooks of a phase with data in their stdin payload.
+// Hooks run in file order, except that consecutive hooks sharing a group
+// run in parallel. Pre-export and pre-snapshot stop after the first group
+// with a failing on_error="fail" hook; other phases run every hook and
+// return the first such failure.
+func (e *Executor) Run(phase HookPhase, data any) error {
 	if e.config == nil {
 		return nil
 	}
+	hooks := e.config.Hooks.list(phase)
+	if hooks == nil || len(*hooks) == 0 {
+		return nil
+	}
+
+	timestamp := e.context.Timestamp
+	if timestamp.IsZero() {
+		timestamp = time.Now()
+	}
+	stdin, err := json.Marshal(Payload{Phase: phase, Timestamp: timestamp, Data: data})
+	if err != nil {
+		return fmt.Errorf("encoding %s payload: %w", phase, err)
+	}
 
 	var firstError error
-	for _, hook := range e.config.Hooks.PostExport {
-		e.logger(fmt.Sprintf("Running post-export hook %q: %s", hook.Name, hook.Command))
-		result := e.runHook(hook, PostExport)
-		e.results = append(e.results, result)
+	for _, group := range groupHooks(*hooks) {
+		results := make([]HookResult, len(group))
+		var wg sync.WaitGroup
+		for i, hook := range group {
+			e.logger(fmt.Sprintf("Running %s hook %q: %s", phase, hook.Name, hook.Command))
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				results[i] = e.runHook(hook, phase, stdin)
+			}()
+		}
+		wg.Wait()
+		e.results = append(e.results, results...)
 
-		if !result.Success && hook.OnError == "fail" && firstError == nil {
-			firstError = fmt.Errorf("post-export hook %q failed: %w", hook.Name, result.Error)
+		for _, result := range results {
+			if !result.Success && result.Hook.OnError == "fail" && firstError == nil {
+				firstError = fmt.Errorf("%s hook %q failed: %w", phase, result.Hook.Name, result.Error)
+			}
+		}
+		if firstError != nil && phase.cancels() {
+			return firstError
 		}
 	}
 
 	return firstError
 }
 
+// groupHooks splits hooks into batches that run one after another: each
+// ungrouped hook alone, and each run of consecutive hooks with the same
+// group together
+func groupHooks(hooks []Hook) [][]Hook {
+	var groups [][]Hook
+	for i, hook := range hooks {
+		if i > 0 && hook.Group != "" && hook.Group == hooks[i-1].Group {
+			groups[len(groups)-1] = append(groups[len(groups)-1], hook)
+			continue
+		}
+		groups = append(groups, []Hook{hook})
+	}
+	return groups
+}
+
 // getShellCommand returns the shell and flag to use for executing commands
 func getShellCommand() (string, string) {
 	if runtime.GOOS == "windows" {
@@ -99,8 +145,9 @@ func getShellCommand() (string, string) {
 	return "sh", "-c"
 }
 
-// runHook executes a single hook with timeout and environment
-func (e *Executor) runHook(hook Hook, phase HookPhase) HookResult {
+// runHook executes a single hook with timeout and environment, writing
+// stdin to its standard input
+func (e *Executor) runHook(hook Hook, phase HookPhase, stdin []byte) HookResult {
 	result := HookResult{
 		Hook:  hook,
 		Phase: phase,
@@ -123,8 +170,13 @@ func (e *Executor) runHook(hook Hook, phase HookPhase) HookResult {
 	// Build environment
 	cmd.Env = os.Environ()
 
-	// Add export context variables
-	cmd.Env = append(cmd.Env, e.context.ToEnv()...)
+	// Add phase and export context variables
+	cmd.Env = append(cmd.Env, fmt.Sprintf("BV_HOOK_PHASE=%s", phase))
+	if phase == PreExport || phase == PostExport {
+		cmd.Env = append(cmd.Env, e.context.ToEnv()...)
+	} else {
+		cmd.Env = append(cmd.Env, fmt.Sprintf("BV_TIMESTAMP=%s", time.Now().Format(time.RFC3339)))
+	}
 
 	// Add hook-specific env vars (with ${VAR} expansion from current env)
 	// Sort keys for deterministic environment order
@@ -141,7 +193,8 @@ func (e *Executor) runHook(hook Hook, phase HookPhase) HookResult {
 		cmd.Env = append(cmd.Env, fmt.Sprintf("%s=%s", key, expandedValue))
 	}
 
-	// Capture output
+	// Pass the payload and capture output
+	cmd.Stdin = bytes.NewReader(stdin)
 	var stdout, stderr bytes.Buffer
 	cmd.Stdout = &stdout
 	cmd.Stderr = &stderr
@@ -233,7 +286,7 @@ func RunHooks(projectDir string, ctx ExportContext, noHooks bool) (*Executor, er
 		return nil, fmt.Errorf("loading hooks: %w", err)
 	}
 
-	if !loader.HasHooks() {
+	if !loader.HasHooksFor(PreExport, PostExport) {
 		return nil, nil
 	}
 
@@ -1,6 +1,7 @@
 package hooks
 
 import (
+	"encoding/json"
 	"os"
 	"path/filepath"
 	"strings"
@@ -668,3 +669,102 @@ func TestExecutorLargeStderrTruncatedInSummary(t *testing.T) {
 		t.Fatalf("expected ellipsis indicating truncation")
 	}
 }
+
+func TestExecutorRunPassesPayloadOnStdin(t *testing.T) {
+	config := &Config{
+		Hooks: HooksByPhase{
+			OnTriage: []Hook{{Name: "read", Command: "cat; echo; echo \"$BV_HOOK_PHASE\"", Timeout: 5 * time.Second}},
+		},
+	}
+
+	executor := NewExecutor(config, ExportContext{})
+	if err := executor.Run(OnTriage, map[string]int{"recommendations": 3}); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	results := executor.Results()
+	if len(results) != 1 {
+		t.Fatalf("expected 1 result, got %d", len(results))
+	}
+	lines := strings.Split(results[0].Stdout, "\n")
+	if len(lines) != 2 || lines[1] != "on-triage" {
+		t.Fatalf("unexpected stdout %q", results[0].Stdout)
+	}
+	var payload struct {
+		Phase string         `json:"phase"`
+		Data  map[string]int `json:"data"`
+	}
+	if err := json.Unmarshal([]byte(lines[0]), &payload); err != nil {
+		t.Fatalf("payload is not JSON: %v", err)
+	}
+	if payload.Phase != "on-triage" || payload.Data["recommendations"] != 3 {
+		t.Errorf("unexpected payload %+v", payload)
+	}
+}
+
+func TestExecutorRunGroupsInParallel(t *testing.T) {
+	sleep := func(name, group string) Hook {
+		return Hook{Name: name, Command: "sleep 0.5", Timeout: 5 * time.Second, Group: group, OnError: "continue"}
+	}
+	config := &Config{
+		Hooks: HooksByPhase{
+			PostSnapshot: []Hook{sleep("a", "notify"), sleep("b", "notify"), sleep("c", "notify"), {Name: "last", Command: "echo done"}},
+		},
+	}
+
+	executor := NewExecutor(config, ExportContext{})
+	start := time.Now()
+	if err := executor.Run(PostSnapshot, nil); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 1200*time.Millisecond {
+		t.Errorf("grouped hooks took %v; they should run in parallel", elapsed)
+	}
+	var names []string
+	for _, r := range executor.Results() {
+		names = append(names, r.Hook.Name)
+	}
+	if strings.Join(names, ",") != "a,b,c,last" {
+		t.Errorf("results should keep file order, got %v", names)
+	}
+}
+
+func TestExecutorRunPreSnapshotCancels(t *testing.T) {
+	tmp := t.TempDir()
+	writeHooksFile(t, tmp, `
+hooks:
+  pre-snapshot:
+    - name: guard
+      command: exit 3
+    - name: after
+      command: echo after
+  on-drift-alert:
+    - name: page
+      command: exit 1
+    - name: log
+      command: echo logged
+`)
+	loader := NewLoader(WithProjectDir(tmp))
+	if err := loader.Load(); err != nil {
+		t.Fatal(err)
+	}
+	if !loader.HasHooks() || loader.HasHooksFor(PreExport, PostExport) {
+		t.Fatal("only pre-snapshot and on-drift-alert hooks are configured")
+	}
+
+	executor := NewExecutor(loader.Config(), ExportContext{})
+	err := executor.Run(PreSnapshot, nil)
+	if err == nil || !strings.Contains(err.Error(), `pre-snapshot hook "guard" failed`) {
+		t.Fatalf("expected pre-snapshot failure, got %v", err)
+	}
+	if len(executor.Results()) != 1 {
+		t.Fatalf("pre-snapshot should stop at the failing hook, got %d results", len(executor.Results()))
+	}
+
+	// Other phases default to on_error=continue
+	if err := executor.Run(OnDriftAlert, nil); err != nil {
+		t.Fatalf("on-drift-alert failures should not be returned by default: %v", err)
+	}
+	if len(executor.Results()) != 3 {
+		t.Fatalf("expected every on-drift-alert hook to run, got %d results", len(executor.Results()))
+	}
+}
@@ -0,0 +1,174 @@
+// Package i18n translates bv's user-facing text: TUI labels, the help
+// overlay and Markdown reports. English is the source language, so a
+// message is looked up by its English text in the active locale's catalog
+// and falls back to itself when the catalog has no entry. Catalogs are
+// YAML maps from English to the translation, one per locale, in locales/:
+//
+//	"Keyboard Shortcuts": "Tastenkürzel"
+//	"%d open": "%d offen"
+//
+// The locale comes from BV_LANG (de, de_DE.UTF-8 and de-DE all select de).
+package i18n
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"gopkg.in/yaml.v3"
+)
+
+// EnvVar selects the locale
+const EnvVar = "BV_LANG"
+
+// English is the source locale, which has no catalog
+const English = "en"
+
+//go:embed locales/*.yaml
+var localesFS embed.FS
+
+// catalog is one locale's translations
+type catalog struct {
+	lang     string
+	messages map[string]string
+}
+
+var (
+	active   atomic.Pointer[catalog]
+	loadOnce sync.Once
+	catalogs map[string]*catalog
+	loadErr  error
+)
+
+// loadCatalogs parses the embedded catalogs once
+func loadCatalogs() (map[string]*catalog, error) {
+	loadOnce.Do(func() {
+		catalogs = map[string]*catalog{}
+		entries, err := fs.ReadDir(localesFS, "locales")
+		if err != nil {
+			loadErr = err
+			return
+		}
+		for _, e := range entries {
+			lang := strings.TrimSuffix(e.Name(), ".yaml")
+			data, err := localesFS.ReadFile(path.Join("locales", e.Name()))
+			if err != nil {
+				loadErr = err
+				return
+			}
+			c := &catalog{lang: lang}
+			if err := yaml.Unmarshal(data, &c.messages); err != nil {
+				loadErr = fmt.Errorf("parsing locales/%s: %w", e.Name(), err)
+				return
+			}
+			catalogs[lang] = c
+		}
+	})
+	return catalogs, loadErr
+}
+
+// Available lists the selectable locales, English first
+func Available() []string {
+	all, _ := loadCatalogs()
+	langs := make([]string, 0, len(all))
+	for lang := range all {
+		langs = append(langs, lang)
+	}
+	sort.Strings(langs)
+	return append([]string{English}, langs...)
+}
+
+// Init selects the locale named by BV_LANG. An unset variable keeps
+// English; an unknown locale keeps English and is reported.
+func Init(getenv func(string) string) error {
+	value := getenv(EnvVar)
+	if value == "" {
+		return nil
+	}
+	return SetLang(value)
+}
+
+// SetLang selects a locale by name. Region and encoding suffixes are
+// ignored when there is no catalog for them: pt-BR selects pt_br if there
+// is one, else pt. "", "en" and "C" select English.
+func SetLang(name string) error {
+	all, err := loadCatalogs()
+	if err != nil {
+		return err
+	}
+	tag := strings.ToLower(strings.ReplaceAll(name, "-", "_"))
+	tag, _, _ = strings.Cut(tag, ".")
+	tag, _, _ = strings.Cut(tag, "@")
+	base, _, _ := strings.Cut(tag, "_")
+	switch {
+	case tag == "" || tag == "c" || tag == "posix" || base == English:
+		active.Store(nil)
+		return nil
+	case all[tag] != nil:
+		active.Store(all[tag])
+		return nil
+	case all[base] != nil:
+		active.Store(all[base])
+		return nil
+	}
+	return fmt.Errorf("unknown %s %q (available: %s)", EnvVar, name, strings.Join(Available(), ", "))
+}
+
+// Lang is the active locale
+func Lang() string {
+	if c := active.Load(); c != nil {
+		return c.lang
+	}
+	return English
+}
+
+// T translates msg into the active locale
+func T(msg string) string {
+	if c := active.Load(); c != nil {
+		if tr, ok := c.messages[msg]; ok && tr != "" {
+			return tr
+		}
+	}
+	return msg
+}
+
+// Tf translates a format string and formats it. The translation must keep
+// the verbs in order.
+func Tf(format string, args ...any) string {
+	return fmt.Sprintf(T(format), args...)
+}
+
+// Has reports whether lang's catalog translates msg. English has every
+// message.
+func Has(lang, msg string) bool {
+	if lang == English {
+		return true
+	}
+	all, _ := loadCatalogs()
+	c := all[lang]
+	if c == nil {
+		return false
+	}
+	_, ok := c.messages[msg]
+	return ok
+}
+
+// Messages returns the English messages of lang's catalog, sorted
+func Messages(lang string) []string {
+	all, _ := loadCatalogs()
+	c := all[lang]
+	if c == nil {
+		return nil
+	}
+	msgs := make([]string, 0, len(c.messages))
+	for msg := range c.messages {
+		msgs = append(msgs, msg)
+	}
+	sort.Strings(msgs)
+	return msgs
+}
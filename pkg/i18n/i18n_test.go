@@ -0,0 +1,166 @@
+package i18n
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestSetLang(t *testing.T) {
+	defer SetLang(English)
+
+	for _, name := range []string{"de", "DE", "de_DE.UTF-8", "de-AT", "de_CH@euro"} {
+		if err := SetLang(name); err != nil || Lang() != "de" {
+			t.Errorf("SetLang(%q) = %v, lang %q", name, err, Lang())
+		}
+	}
+	for _, name := range []string{"", "en", "en_US.UTF-8", "C", "POSIX"} {
+		if err := SetLang(name); err != nil || Lang() != English {
+			t.Errorf("SetLang(%q) = %v, lang %q", name, err, Lang())
+		}
+	}
+
+	SetLang("de")
+	err := SetLang("xx")
+	if err == nil || !strings.Contains(err.Error(), "de") {
+		t.Errorf("SetLang(xx) = %v", err)
+	}
+	if Lang() != "de" {
+		t.Errorf("failed SetLang changed the locale to %q", Lang())
+	}
+}
+
+func TestInit(t *testing.T) {
+	defer SetLang(English)
+
+	env := map[string]string{}
+	getenv := func(k string) string { return env[k] }
+	if err := Init(getenv); err != nil || Lang() != English {
+		t.Errorf("unset %s: %v, lang %q", EnvVar, err, Lang())
+	}
+	env[EnvVar] = "de_DE.UTF-8"
+	if err := Init(getenv); err != nil || Lang() != "de" {
+		t.Errorf("%s=de_DE.UTF-8: %v, lang %q", EnvVar, err, Lang())
+	}
+}
+
+func TestT(t *testing.T) {
+	defer SetLang(English)
+
+	if got := T("Keyboard Shortcuts"); got != "Keyboard Shortcuts" {
+		t.Errorf("English T = %q", got)
+	}
+	SetLang("de")
+	if got := T("Keyboard Shortcuts"); got != "Tastenkürzel" {
+		t.Errorf("German T = %q", got)
+	}
+	if got := T("not in any catalog"); got != "not in any catalog" {
+		t.Errorf("missing message = %q", got)
+	}
+	if got := Tf("unblocks %d", 3); got != "gibt 3 frei" {
+		t.Errorf("Tf = %q", got)
+	}
+}
+
+// Messages passed to T and Tf, the report templates' t and tf, the Markdown
+// tableRow helper, and the keymap's Section and Desc fields
+var (
+	goMessage       = regexp.MustCompile(`i18n\.Tf?\(("(?:[^"\\]|\\.)*")`)
+	tableRowCall    = regexp.MustCompile(`tableRow\(([^)]*)\)`)
+	goString        = regexp.MustCompile(`"(?:[^"\\]|\\.)*"`)
+	keymapMessage   = regexp.MustCompile(`(?:Section|Desc): ("(?:[^"\\]|\\.)*")`)
+	templateMessage = regexp.MustCompile(`\btf? ("[^"]*")`)
+)
+
+// TestCatalogsCoverSource checks that every catalog translates every
+// message in the source tree
+func TestCatalogsCoverSource(t *testing.T) {
+	msgs := map[string]string{}
+	add := func(path, literal string) {
+		msg, err := strconv.Unquote(literal)
+		if err != nil {
+			t.Fatalf("%s: %s: %v", path, literal, err)
+		}
+		msgs[msg] = path
+	}
+
+	root := filepath.Join("..", "..")
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if strings.HasPrefix(d.Name(), ".") && path != root {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		name := d.Name()
+		isGo := strings.HasSuffix(name, ".go") && !strings.HasSuffix(name, "_test.go")
+		isTemplate := strings.HasSuffix(name, ".tmpl")
+		if !isGo && !isTemplate {
+			return nil
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		src := string(data)
+		if isTemplate {
+			for _, m := range templateMessage.FindAllStringSubmatch(src, -1) {
+				add(path, m[1])
+			}
+			return nil
+		}
+		for _, m := range goMessage.FindAllStringSubmatch(src, -1) {
+			add(path, m[1])
+		}
+		for _, m := range tableRowCall.FindAllStringSubmatch(src, -1) {
+			for _, lit := range goString.FindAllString(m[1], -1) {
+				add(path, lit)
+			}
+		}
+		if name == "keymap.go" {
+			for _, m := range keymapMessage.FindAllStringSubmatch(src, -1) {
+				add(path, m[1])
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(msgs) < 100 {
+		t.Fatalf("found only %d messages; is the scan broken?", len(msgs))
+	}
+
+	for _, lang := range Available()[1:] {
+		for msg, path := range msgs {
+			if !Has(lang, msg) {
+				t.Errorf("%s: %q (from %s) is not translated", lang, msg, path)
+			}
+		}
+	}
+}
+
+// TestCatalogsKeepVerbs checks that translations of format strings keep
+// the verbs in order
+func TestCatalogsKeepVerbs(t *testing.T) {
+	verb := regexp.MustCompile(`%[-+# 0]*[0-9]*(?:\.[0-9]+)?[a-zA-Z%]`)
+	all, err := loadCatalogs()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for lang, c := range all {
+		for msg, tr := range c.messages {
+			want := strings.Join(verb.FindAllString(msg, -1), " ")
+			if got := strings.Join(verb.FindAllString(tr, -1), " "); got != want {
+				t.Errorf("%s: %q has verbs %q, want %q", lang, tr, got, want)
+			}
+		}
+	}
+}
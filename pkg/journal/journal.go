@@ -0,0 +1,249 @@
+// Package journal keeps an accountability log of robot invocations in
+// .bv/journal.jsonl: what was asked, against which data, and what bv
+// recommended. Summarize replays it against the current issues to show which
+// recommendations were acted on.
+package journal
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// Filename is the journal filename inside .bv/
+const Filename = "journal.jsonl"
+
+// EnvVar turns journaling on for every robot invocation when set to 1
+const EnvVar = "BV_JOURNAL"
+
+// Record is one robot invocation
+type Record struct {
+	At       time.Time         `json:"at"`
+	Command  string            `json:"command"`           // e.g. "robot-triage"
+	Filters  map[string]string `json:"filters,omitempty"` // Other flags given, e.g. label
+	DataHash string            `json:"data_hash,omitempty"`
+	Actor    string            `json:"actor,omitempty"`
+	// Top is the single recommendation the command led with; Picks are the
+	// recommended IDs in order (at most MaxPicks)
+	Top   string   `json:"top,omitempty"`
+	Picks []string `json:"picks,omitempty"`
+}
+
+// MaxPicks bounds the IDs stored per record, keeping lines compact
+const MaxPicks = 5
+
+// Outcomes of a recommendation
+const (
+	OutcomeClosed     = "closed"      // Closed after it was recommended
+	OutcomeInProgress = "in_progress" // Started after it was recommended
+	OutcomePending    = "pending"     // Unchanged since
+	OutcomeChanged    = "changed"     // Updated, but not started or closed
+	OutcomeMissing    = "missing"     // No longer in the data
+)
+
+// Summary is the --robot-journal report
+type Summary struct {
+	Invocations int            `json:"invocations"`
+	ByCommand   map[string]int `json:"by_command"`
+	First       *time.Time     `json:"first,omitempty"`
+	Last        *time.Time     `json:"last,omitempty"`
+	// Recommended counts distinct issues that led a recommendation
+	Recommended int `json:"recommended"`
+	ActedOn     int `json:"acted_on"`
+	// FollowThroughPct is ActedOn / Recommended
+	FollowThroughPct float64          `json:"follow_through_pct"`
+	Items            []Recommendation `json:"items"` // Most recently recommended first
+}
+
+// Recommendation is one issue's history as a top recommendation
+type Recommendation struct {
+	ID               string    `json:"id"`
+	Title            string    `json:"title,omitempty"`
+	Times            int       `json:"times"` // Invocations that led with it
+	FirstRecommended time.Time `json:"first_recommended"`
+	LastRecommended  time.Time `json:"last_recommended"`
+	Commands         []string  `json:"commands"`
+	Status           string    `json:"status,omitempty"` // Current status
+	Outcome          string    `json:"outcome"`
+	ActedOn          bool      `json:"acted_on"`
+	// HoursToAct is from the first recommendation to the issue's start or close
+	HoursToAct *float64 `json:"hours_to_act,omitempty"`
+}
+
+// Path returns the journal path for a project
+func Path(projectDir string) string {
+	return filepath.Join(projectDir, ".bv", Filename)
+}
+
+// Enabled reports whether BV_JOURNAL asks for journaling
+func Enabled() bool {
+	return os.Getenv(EnvVar) == "1"
+}
+
+// Append adds r to the journal at path
+func Append(path string, r Record) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("creating journal directory: %w", err)
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("opening journal: %w", err)
+	}
+	defer f.Close()
+	if len(r.Picks) > MaxPicks {
+		r.Picks = r.Picks[:MaxPicks]
+	}
+	r.At = r.At.UTC()
+	line, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("writing journal: %w", err)
+	}
+	return nil
+}
+
+// Load reads the journal at path. A missing file is an empty journal;
+// malformed lines are skipped.
+func Load(path string) ([]Record, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("opening journal: %w", err)
+	}
+	defer f.Close()
+
+	var records []Record
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var r Record
+		if json.Unmarshal(scanner.Bytes(), &r) != nil || r.Command == "" {
+			continue
+		}
+		records = append(records, r)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading journal: %w", err)
+	}
+	return records, nil
+}
+
+// Summarize replays records (optionally only those at or after since)
+// against the current issues. A recommendation counts as acted on when its
+// issue was started or closed after it was first recommended.
+func Summarize(records []Record, issues []model.Issue, since time.Time) Summary {
+	summary := Summary{ByCommand: make(map[string]int), Items: []Recommendation{}}
+	byID := make(map[string]*model.Issue, len(issues))
+	for i := range issues {
+		byID[issues[i].ID] = &issues[i]
+	}
+
+	items := make(map[string]*Recommendation)
+	for _, r := range records {
+		if r.At.Before(since) {
+			continue
+		}
+		summary.Invocations++
+		summary.ByCommand[r.Command]++
+		if summary.First == nil || r.At.Before(*summary.First) {
+			at := r.At
+			summary.First = &at
+		}
+		if summary.Last == nil || r.At.After(*summary.Last) {
+			at := r.At
+			summary.Last = &at
+		}
+		if r.Top == "" {
+			continue
+		}
+		item := items[r.Top]
+		if item == nil {
+			item = &Recommendation{ID: r.Top, FirstRecommended: r.At, LastRecommended: r.At}
+			items[r.Top] = item
+		}
+		item.Times++
+		if r.At.Before(item.FirstRecommended) {
+			item.FirstRecommended = r.At
+		}
+		if r.At.After(item.LastRecommended) {
+			item.LastRecommended = r.At
+		}
+		if !containsString(item.Commands, r.Command) {
+			item.Commands = append(item.Commands, r.Command)
+		}
+	}
+
+	for _, item := range items {
+		sort.Strings(item.Commands)
+		issue := byID[item.ID]
+		item.Outcome = outcome(issue, item.FirstRecommended)
+		if issue != nil {
+			item.Title = issue.Title
+			item.Status = string(issue.Status)
+		}
+		if item.Outcome == OutcomeClosed || item.Outcome == OutcomeInProgress {
+			item.ActedOn = true
+			summary.ActedOn++
+			actedAt := issue.UpdatedAt
+			if issue.ClosedAt != nil && item.Outcome == OutcomeClosed {
+				actedAt = *issue.ClosedAt
+			}
+			hours := math.Round(actedAt.Sub(item.FirstRecommended).Hours()*10) / 10
+			item.HoursToAct = &hours
+		}
+		summary.Items = append(summary.Items, *item)
+	}
+	summary.Recommended = len(summary.Items)
+	if summary.Recommended > 0 {
+		summary.FollowThroughPct = math.Round(float64(summary.ActedOn)/float64(summary.Recommended)*1000) / 10
+	}
+	sort.Slice(summary.Items, func(i, j int) bool {
+		a, b := summary.Items[i], summary.Items[j]
+		if !a.LastRecommended.Equal(b.LastRecommended) {
+			return a.LastRecommended.After(b.LastRecommended)
+		}
+		return a.ID < b.ID
+	})
+	return summary
+}
+
+// outcome classifies what happened to an issue after it was recommended
+func outcome(issue *model.Issue, recommended time.Time) string {
+	if issue == nil {
+		return OutcomeMissing
+	}
+	changed := issue.UpdatedAt.After(recommended)
+	if issue.ClosedAt != nil {
+		changed = changed || issue.ClosedAt.After(recommended)
+	}
+	switch {
+	case !changed:
+		return OutcomePending
+	case issue.Status.IsClosed():
+		return OutcomeClosed
+	case issue.Status == model.StatusInProgress:
+		return OutcomeInProgress
+	default:
+		return OutcomeChanged
+	}
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,81 @@
+package journal
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestAppendLoad(t *testing.T) {
+	path := Path(t.TempDir())
+	if records, err := Load(path); err != nil || records != nil {
+		t.Fatalf("missing journal: %v, %v", records, err)
+	}
+	at := time.Date(2025, 4, 1, 9, 0, 0, 0, time.UTC)
+	r := Record{At: at, Command: "robot-triage", Top: "bv-1", Picks: []string{"bv-1", "bv-2", "bv-3", "bv-4", "bv-5", "bv-6"}}
+	if err := Append(path, r); err != nil {
+		t.Fatal(err)
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.WriteString("not json\n{\"top\":\"bv-9\"}\n")
+	f.Close()
+
+	records, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 1 || records[0].Top != "bv-1" || len(records[0].Picks) != MaxPicks {
+		t.Errorf("records = %+v", records)
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	t0 := time.Date(2025, 4, 1, 9, 0, 0, 0, time.UTC)
+	closedAt := t0.Add(5 * time.Hour)
+	issues := []model.Issue{
+		{ID: "bv-1", Title: "Closed", Status: model.StatusClosed, UpdatedAt: closedAt, ClosedAt: &closedAt},
+		{ID: "bv-2", Title: "Started", Status: model.StatusInProgress, UpdatedAt: t0.Add(2 * time.Hour)},
+		{ID: "bv-3", Title: "Ignored", Status: model.StatusOpen, UpdatedAt: t0.Add(-time.Hour)},
+		{ID: "bv-4", Title: "Before", Status: model.StatusInProgress, UpdatedAt: t0.Add(-time.Hour)},
+	}
+	records := []Record{
+		{At: t0.Add(-48 * time.Hour), Command: "robot-triage", Top: "bv-4"},
+		{At: t0, Command: "robot-triage", Top: "bv-1"},
+		{At: t0.Add(time.Hour), Command: "robot-next", Top: "bv-1"},
+		{At: t0, Command: "robot-next", Top: "bv-2"},
+		{At: t0, Command: "robot-plan", Top: "bv-3"},
+		{At: t0, Command: "robot-insights"},
+		{At: t0, Command: "robot-next", Top: "bv-gone"},
+	}
+
+	s := Summarize(records, issues, t0.Add(-time.Hour))
+	if s.Invocations != 6 || s.ByCommand["robot-next"] != 3 {
+		t.Errorf("invocations = %d, by command = %v", s.Invocations, s.ByCommand)
+	}
+	if s.Recommended != 4 || s.ActedOn != 2 || s.FollowThroughPct != 50 {
+		t.Errorf("recommended %d, acted on %d, follow-through %v", s.Recommended, s.ActedOn, s.FollowThroughPct)
+	}
+	want := map[string]string{"bv-1": OutcomeClosed, "bv-2": OutcomeInProgress, "bv-3": OutcomePending, "bv-gone": OutcomeMissing}
+	for _, item := range s.Items {
+		if item.Outcome != want[item.ID] {
+			t.Errorf("%s outcome = %s, want %s", item.ID, item.Outcome, want[item.ID])
+		}
+	}
+	if s.Items[0].ID != "bv-1" || s.Items[0].Times != 2 || len(s.Items[0].Commands) != 2 {
+		t.Errorf("most recent item = %+v", s.Items[0])
+	}
+	if h := s.Items[0].HoursToAct; h == nil || *h != 5 {
+		t.Errorf("bv-1 hours to act = %v", h)
+	}
+
+	// Without a window the stale recommendation of bv-4 counts, and it was
+	// started after it was recommended
+	if s := Summarize(records, issues, time.Time{}); s.Recommended != 5 || s.ActedOn != 3 {
+		t.Errorf("unwindowed: recommended %d, acted on %d", s.Recommended, s.ActedOn)
+	}
+}
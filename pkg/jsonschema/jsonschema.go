@@ -0,0 +1,437 @@
+// Package jsonschema derives JSON Schemas (draft 2020-12) from Go types by
+// reflection, following encoding/json's field rules, so the documented shape
+// of a payload can't drift from the struct that produces it. Schema.Validate
+// checks decoded JSON against the subset of keywords generated here.
+package jsonschema
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Draft is the JSON Schema dialect every generated schema declares
+const Draft = "https://json-schema.org/draft/2020-12/schema"
+
+// Schema is one JSON Schema node. Only the keywords the generator emits are
+// modelled.
+type Schema struct {
+	Schema               string             `json:"$schema,omitempty"`
+	ID                   string             `json:"$id,omitempty"`
+	Ref                  string             `json:"$ref,omitempty"`
+	Title                string             `json:"title,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Type                 any                `json:"type,omitempty"` // string or []string
+	Format               string             `json:"format,omitempty"`
+	Const                any                `json:"const,omitempty"`
+	Properties           *Properties        `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	OneOf                []*Schema          `json:"oneOf,omitempty"`
+	Defs                 map[string]*Schema `json:"$defs,omitempty"`
+}
+
+// Properties is an object's properties in struct field order, which
+// encoding/json would otherwise sort by name
+type Properties struct {
+	Names   []string
+	Schemas map[string]*Schema
+}
+
+// Set adds or replaces a property, keeping its original position
+func (p *Properties) Set(name string, s *Schema) {
+	if p.Schemas == nil {
+		p.Schemas = make(map[string]*Schema)
+	}
+	if _, ok := p.Schemas[name]; !ok {
+		p.Names = append(p.Names, name)
+	}
+	p.Schemas[name] = s
+}
+
+// Get returns the named property's schema, or nil
+func (p *Properties) Get(name string) *Schema {
+	if p == nil {
+		return nil
+	}
+	return p.Schemas[name]
+}
+
+// MarshalJSON writes the properties as an object in field order
+func (p *Properties) MarshalJSON() ([]byte, error) {
+	var sb strings.Builder
+	sb.WriteByte('{')
+	for i, name := range p.Names {
+		if i > 0 {
+			sb.WriteByte(',')
+		}
+		key, _ := json.Marshal(name)
+		val, err := json.Marshal(p.Schemas[name])
+		if err != nil {
+			return nil, err
+		}
+		sb.Write(key)
+		sb.WriteByte(':')
+		sb.Write(val)
+	}
+	sb.WriteByte('}')
+	return []byte(sb.String()), nil
+}
+
+// UnmarshalJSON reads an object of schemas, keeping key order
+func (p *Properties) UnmarshalJSON(data []byte) error {
+	dec := json.NewDecoder(strings.NewReader(string(data)))
+	if _, err := dec.Token(); err != nil {
+		return err
+	}
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		var s Schema
+		if err := dec.Decode(&s); err != nil {
+			return err
+		}
+		p.Set(tok.(string), &s)
+	}
+	_, err := dec.Token()
+	return err
+}
+
+var (
+	timeType          = reflect.TypeOf(time.Time{})
+	durationType      = reflect.TypeOf(time.Duration(0))
+	rawMessageType    = reflect.TypeOf(json.RawMessage(nil))
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	marshalerType     = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+)
+
+// Generate returns the schema for v's type. Named struct types become $defs
+// entries referenced by $ref, which keeps recursive types finite; the root
+// type itself is inlined.
+func Generate(v any) *Schema {
+	return For(reflect.TypeOf(v))
+}
+
+// For is Generate for a reflect.Type
+func For(t reflect.Type) *Schema {
+	g := &generator{defs: make(map[string]*Schema), names: make(map[reflect.Type]string)}
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	var root *Schema
+	if t == nil {
+		root = &Schema{}
+	} else if t.Kind() == reflect.Struct && !isSpecial(t) {
+		root = g.object(t)
+	} else {
+		root = g.schema(t)
+	}
+	root.Schema = Draft
+	if len(g.defs) > 0 {
+		root.Defs = g.defs
+	}
+	return root
+}
+
+type generator struct {
+	defs  map[string]*Schema
+	names map[reflect.Type]string
+}
+
+func isSpecial(t reflect.Type) bool {
+	return t == timeType || implements(t, textMarshalerType) || implements(t, marshalerType)
+}
+
+func implements(t, iface reflect.Type) bool {
+	return t.Implements(iface) || reflect.PointerTo(t).Implements(iface)
+}
+
+func (g *generator) schema(t reflect.Type) *Schema {
+	switch {
+	case t == timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case t == durationType:
+		return &Schema{Type: "integer", Description: "nanoseconds"}
+	case t == rawMessageType, t.Kind() != reflect.Pointer && implements(t, marshalerType):
+		// Custom JSON encodings can't be derived from the Go shape
+		return &Schema{}
+	case t.Kind() != reflect.Pointer && isSpecial(t):
+		return &Schema{Type: "string"}
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		s := g.schema(t.Elem())
+		return nullable(s)
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return &Schema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: []string{"array", "null"}, Items: g.schema(t.Elem())}
+	case reflect.Array:
+		return &Schema{Type: "array", Items: g.schema(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: []string{"object", "null"}, AdditionalProperties: g.schema(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return g.object(t)
+		}
+		return &Schema{Ref: "#/$defs/" + g.define(t)}
+	default:
+		// Interfaces and anything encoding/json can't constrain statically
+		return &Schema{}
+	}
+}
+
+// nullable widens s to also accept null. References can't carry a type, so
+// they are left as is: consumers treat a missing or null object the same.
+func nullable(s *Schema) *Schema {
+	switch typ := s.Type.(type) {
+	case string:
+		s.Type = []string{typ, "null"}
+	}
+	return s
+}
+
+// define registers a named struct in $defs and returns its key
+func (g *generator) define(t reflect.Type) string {
+	if name, ok := g.names[t]; ok {
+		return name
+	}
+	name := defName(t)
+	for i := 2; g.defs[name] != nil; i++ {
+		name = defName(t) + "_" + strconv.Itoa(i)
+	}
+	g.names[t] = name
+	g.defs[name] = &Schema{} // placeholder while recursing
+	*g.defs[name] = *g.object(t)
+	return name
+}
+
+func defName(t reflect.Type) string {
+	pkg := t.PkgPath()
+	if i := strings.LastIndex(pkg, "/"); i >= 0 {
+		pkg = pkg[i+1:]
+	}
+	if pkg == "" || pkg == "main" {
+		return t.Name()
+	}
+	return pkg + "." + t.Name()
+}
+
+// object describes a struct with encoding/json's field rules: exported
+// fields only, json tag names, "-" skipped, omitempty optional, and
+// untagged embedded structs flattened into the parent
+func (g *generator) object(t reflect.Type) *Schema {
+	s := &Schema{Type: "object", Properties: &Properties{}}
+	required := make(map[string]bool)
+	g.fields(t, 0, s.Properties, make(map[string]int), required, map[reflect.Type]bool{})
+	for _, name := range s.Properties.Names {
+		if required[name] {
+			s.Required = append(s.Required, name)
+		}
+	}
+	return s
+}
+
+// fields adds t's fields to props. depth is the embedding depth; as in
+// encoding/json, a shallower field hides a deeper one of the same name.
+func (g *generator) fields(t reflect.Type, depth int, props *Properties, depths map[string]int, required map[string]bool, seen map[reflect.Type]bool) {
+	if seen[t] {
+		return
+	}
+	seen[t] = true
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		ft := f.Type
+
+		if f.Anonymous && name == "" {
+			et := ft
+			if et.Kind() == reflect.Pointer {
+				et = et.Elem()
+			}
+			if et.Kind() == reflect.Struct && !isSpecial(et) {
+				embedRequired := required
+				if ft.Kind() == reflect.Pointer {
+					// A nil embedded pointer drops all of its fields
+					embedRequired = make(map[string]bool)
+				}
+				g.fields(et, depth+1, props, depths, embedRequired, seen)
+				continue
+			}
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		if d, ok := depths[name]; ok && d < depth {
+			continue
+		}
+		depths[name] = depth
+		fs := g.schema(ft)
+		if hasOpt(opts, "string") {
+			fs = &Schema{Type: "string"}
+		}
+		props.Set(name, fs)
+		if hasOpt(opts, "omitempty") || hasOpt(opts, "omitzero") {
+			delete(required, name)
+		} else {
+			required[name] = true
+		}
+	}
+}
+
+func hasOpt(opts, want string) bool {
+	for opts != "" {
+		var opt string
+		opt, opts, _ = strings.Cut(opts, ",")
+		if opt == want {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate checks a decoded JSON value (as produced by json.Unmarshal into
+// an any) against s, covering the keywords Generate emits. It reports the
+// first mismatch with its JSON path.
+func (s *Schema) Validate(v any) error {
+	return s.validate(s, v, "$")
+}
+
+func (s *Schema) validate(root *Schema, v any, path string) error {
+	if s.Ref != "" {
+		def := root.Defs[strings.TrimPrefix(s.Ref, "#/$defs/")]
+		if def == nil {
+			return fmt.Errorf("%s: unresolved $ref %s", path, s.Ref)
+		}
+		return def.validate(root, v, path)
+	}
+	if len(s.OneOf) > 0 {
+		matched := 0
+		var firstErr error
+		for _, variant := range s.OneOf {
+			if err := variant.validate(root, v, path); err != nil {
+				if firstErr == nil {
+					firstErr = err
+				}
+				continue
+			}
+			matched++
+		}
+		if matched != 1 {
+			return fmt.Errorf("%s: matches %d of %d oneOf variants (first failure: %v)", path, matched, len(s.OneOf), firstErr)
+		}
+		return nil
+	}
+	if s.Const != nil && !reflect.DeepEqual(s.Const, v) {
+		return fmt.Errorf("%s: %v is not the constant %v", path, v, s.Const)
+	}
+	if s.Type != nil && !typeMatches(s.Type, v) {
+		return fmt.Errorf("%s: %s is not of type %v", path, jsonType(v), s.Type)
+	}
+
+	switch val := v.(type) {
+	case map[string]any:
+		for _, name := range s.Required {
+			if _, ok := val[name]; !ok {
+				return fmt.Errorf("%s: missing required property %q", path, name)
+			}
+		}
+		for name, fv := range val {
+			fs := s.Properties.Get(name)
+			if fs == nil {
+				fs = s.AdditionalProperties
+			}
+			if fs == nil {
+				continue
+			}
+			if err := fs.validate(root, fv, path+"."+name); err != nil {
+				return err
+			}
+		}
+	case []any:
+		if s.Items != nil {
+			for i, item := range val {
+				if err := s.Items.validate(root, item, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func typeMatches(want any, v any) bool {
+	got := jsonType(v)
+	matches := func(t string) bool {
+		return t == got || (t == "number" && got == "integer")
+	}
+	switch t := want.(type) {
+	case string:
+		return matches(t)
+	case []string:
+		for _, one := range t {
+			if matches(one) {
+				return true
+			}
+		}
+	case []any: // a schema read back from JSON
+		for _, one := range t {
+			if s, ok := one.(string); ok && matches(s) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func jsonType(v any) string {
+	switch val := v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		if val == math.Trunc(val) {
+			return "integer"
+		}
+		return "number"
+	case json.Number:
+		if _, err := val.Int64(); err == nil {
+			return "integer"
+		}
+		return "number"
+	case string:
+		return "string"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	}
+	return fmt.Sprintf("%T", v)
+}
@@ -0,0 +1,193 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+type status string
+
+type node struct {
+	ID       string  `json:"id"`
+	Children []*node `json:"children,omitempty"`
+}
+
+type base struct {
+	Version string `json:"version"`
+	Shadow  int    `json:"shadow"`
+}
+
+type extra struct {
+	Note string `json:"note"`
+}
+
+type payload struct {
+	base
+	*extra
+	Name     string          `json:"name"`
+	Shadow   string          `json:"shadow"`
+	Status   status          `json:"status"`
+	When     time.Time       `json:"when"`
+	Closed   *time.Time      `json:"closed,omitempty"`
+	Took     time.Duration   `json:"took"`
+	Counts   map[string]int  `json:"counts"`
+	Raw      []byte          `json:"raw"`
+	Any      interface{}     `json:"any"`
+	Tree     node            `json:"tree"`
+	Inline   struct{ X int } `json:"inline"`
+	Skipped  string          `json:"-"`
+	Quoted   int             `json:"quoted,string"`
+	Untagged float64
+	private  int
+	Rates    [2]float32        `json:"rates"`
+	Meta     map[string]*extra `json:"meta,omitempty"`
+}
+
+func TestGenerate(t *testing.T) {
+	s := Generate(payload{})
+	if s.Schema != Draft || s.Type != "object" {
+		t.Fatalf("root = %+v", s)
+	}
+
+	wantOrder := "version shadow note name status when closed took counts raw any tree inline quoted Untagged rates meta"
+	if got := strings.Join(s.Properties.Names, " "); got != wantOrder {
+		t.Errorf("properties =\n%s\nwant\n%s", got, wantOrder)
+	}
+	wantRequired := "version shadow name status when took counts raw any tree inline quoted Untagged rates"
+	if got := strings.Join(s.Required, " "); got != wantRequired {
+		t.Errorf("required =\n%s\nwant\n%s", got, wantRequired)
+	}
+
+	tests := []struct {
+		prop string
+		want string
+	}{
+		{"shadow", `{"type":"string"}`}, // the outer field hides the embedded one
+		{"status", `{"type":"string"}`},
+		{"when", `{"type":"string","format":"date-time"}`},
+		{"closed", `{"type":["string","null"],"format":"date-time"}`},
+		{"took", `{"description":"nanoseconds","type":"integer"}`},
+		{"counts", `{"type":["object","null"],"additionalProperties":{"type":"integer"}}`},
+		{"raw", `{"type":"string","format":"byte"}`},
+		{"any", `{}`},
+		{"tree", `{"$ref":"#/$defs/jsonschema.node"}`},
+		{"inline", `{"type":"object","properties":{"X":{"type":"integer"}},"required":["X"]}`},
+		{"quoted", `{"type":"string"}`},
+		{"Untagged", `{"type":"number"}`},
+		{"rates", `{"type":"array","items":{"type":"number"}}`},
+		{"meta", `{"type":["object","null"],"additionalProperties":{"$ref":"#/$defs/jsonschema.extra"}}`},
+	}
+	for _, tt := range tests {
+		got, err := json.Marshal(s.Properties.Get(tt.prop))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != tt.want {
+			t.Errorf("%s = %s, want %s", tt.prop, got, tt.want)
+		}
+	}
+
+	// Recursive types resolve through $defs
+	def := s.Defs["jsonschema.node"]
+	if def == nil {
+		t.Fatalf("missing node def; defs = %v", s.Defs)
+	}
+	if got, _ := json.Marshal(def.Properties.Get("children")); string(got) != `{"type":["array","null"],"items":{"$ref":"#/$defs/jsonschema.node"}}` {
+		t.Errorf("children = %s", got)
+	}
+}
+
+func TestGenerate_NonStruct(t *testing.T) {
+	for _, tt := range []struct {
+		v    any
+		want string
+	}{
+		{[]string{}, `{"$schema":"` + Draft + `","type":["array","null"],"items":{"type":"string"}}`},
+		{nil, `{"$schema":"` + Draft + `"}`},
+		{&node{}, ""},
+	} {
+		got, err := json.Marshal(Generate(tt.v))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if tt.want != "" && string(got) != tt.want {
+			t.Errorf("%T = %s, want %s", tt.v, got, tt.want)
+		}
+	}
+}
+
+func TestPropertiesRoundTrip(t *testing.T) {
+	s := Generate(payload{})
+	data, err := json.Marshal(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var back Schema
+	if err := json.Unmarshal(data, &back); err != nil {
+		t.Fatal(err)
+	}
+	if fmt.Sprint(back.Properties.Names) != fmt.Sprint(s.Properties.Names) {
+		t.Errorf("order lost: %v", back.Properties.Names)
+	}
+	again, _ := json.Marshal(&back)
+	if string(again) != string(data) {
+		t.Errorf("round trip changed the schema:\n%s\n%s", data, again)
+	}
+}
+
+func TestGenerate_CustomMarshaler(t *testing.T) {
+	type withProps struct {
+		Props *Properties `json:"props"`
+	}
+	got, _ := json.Marshal(Generate(withProps{}).Properties.Get("props"))
+	if string(got) != `{}` {
+		t.Errorf("json.Marshaler field = %s, want unconstrained {}", got)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	s := Generate(payload{})
+	valid := map[string]any{
+		"version": "1", "shadow": "s", "name": "n", "status": "open",
+		"when": "2025-01-01T00:00:00Z", "took": 5.0, "counts": map[string]any{"a": 1.0},
+		"raw": "AQ==", "any": []any{1.0}, "tree": map[string]any{"id": "r", "children": []any{map[string]any{"id": "c"}}},
+		"inline": map[string]any{"X": 1.0}, "quoted": "3", "Untagged": 1.5, "rates": []any{1.0, 2.5},
+	}
+	if err := s.Validate(valid); err != nil {
+		t.Fatalf("valid payload: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		mutate func(map[string]any)
+		want   string
+	}{
+		{"missing required", func(m map[string]any) { delete(m, "name") }, `missing required property "name"`},
+		{"wrong type", func(m map[string]any) { m["took"] = 1.5 }, "$.took: number is not of type integer"},
+		{"bad map value", func(m map[string]any) { m["counts"] = map[string]any{"a": "x"} }, "$.counts.a"},
+		{"bad ref", func(m map[string]any) { m["tree"] = map[string]any{"id": "r", "children": []any{map[string]any{}}} }, `$.tree.children[0]: missing required property "id"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := make(map[string]any, len(valid))
+			for k, v := range valid {
+				m[k] = v
+			}
+			tt.mutate(m)
+			if err := s.Validate(m); err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Validate = %v, want error containing %q", err, tt.want)
+			}
+		})
+	}
+
+	oneOf := &Schema{OneOf: []*Schema{{Type: "string"}, {Type: "integer"}}}
+	if err := oneOf.Validate("x"); err != nil {
+		t.Errorf("oneOf string: %v", err)
+	}
+	if err := oneOf.Validate(true); err == nil {
+		t.Error("oneOf should reject a value matching no variant")
+	}
+}
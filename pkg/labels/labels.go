@@ -0,0 +1,285 @@
+// Package labels reads .bv/labels.yaml, which keeps a project's labels
+// consistent: aliases rewrite old or informal names when issues are loaded
+// (ui → frontend), and an optional vocabulary lists the labels in use. A
+// slash makes a hierarchy: backend/api sits under backend, so label
+// analytics for backend include it.
+//
+//	aliases:
+//	  ui: frontend
+//	  api: backend/api
+//	labels:
+//	  - frontend
+//	  - backend/api
+//	  - backend/db
+package labels
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"gopkg.in/yaml.v3"
+)
+
+// Filename is the labels config filename inside .bv/
+const Filename = "labels.yaml"
+
+// Separator splits a hierarchical label into its levels
+const Separator = "/"
+
+// Config is the parsed .bv/labels.yaml
+type Config struct {
+	// Aliases maps a label to the one it should be read as. Keys match
+	// case-insensitively and also rewrite the top of a hierarchy, so with
+	// ui: frontend the label ui/forms loads as frontend/forms.
+	Aliases map[string]string `yaml:"aliases,omitempty" json:"aliases,omitempty"`
+	// Labels is the vocabulary; when empty any label is accepted
+	Labels []string `yaml:"labels,omitempty" json:"labels,omitempty"`
+
+	aliases map[string]string // Lowercased keys
+	known   map[string]bool   // Vocabulary and its ancestors
+}
+
+// Path returns the labels config path for a project
+func Path(projectDir string) string {
+	return filepath.Join(projectDir, ".bv", Filename)
+}
+
+// Load reads .bv/labels.yaml. It returns nil, and no error, when the project
+// has none.
+func Load(projectDir string) (*Config, error) {
+	data, err := os.ReadFile(Path(projectDir))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("reading labels config: %w", err)
+	}
+	return Parse(data)
+}
+
+// Parse parses and validates labels config YAML
+func Parse(data []byte) (*Config, error) {
+	c := &Config{}
+	if err := yaml.Unmarshal(data, c); err != nil {
+		return nil, fmt.Errorf("parsing labels config: %w", err)
+	}
+	if err := c.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid labels config: %w", err)
+	}
+	return c, nil
+}
+
+// Validate checks the aliases and builds the lookup tables. An alias must
+// name a real label, not another alias, so rewriting is a single step.
+func (c *Config) Validate() error {
+	c.aliases = make(map[string]string, len(c.Aliases))
+	for from, to := range c.Aliases {
+		from, to = strings.TrimSpace(from), strings.TrimSpace(to)
+		if from == "" || to == "" {
+			return fmt.Errorf("aliases: empty label in %q: %q", from, to)
+		}
+		if strings.EqualFold(from, to) {
+			return fmt.Errorf("aliases: %q is an alias of itself", from)
+		}
+		c.aliases[strings.ToLower(from)] = to
+	}
+	for from, to := range c.aliases {
+		if _, chained := c.aliases[strings.ToLower(to)]; chained {
+			return fmt.Errorf("aliases: %q maps to %q, which is itself an alias", from, to)
+		}
+	}
+
+	c.known = make(map[string]bool)
+	for _, label := range c.Labels {
+		label = strings.TrimSpace(label)
+		if label == "" {
+			continue
+		}
+		if _, aliased := c.aliases[strings.ToLower(label)]; aliased {
+			return fmt.Errorf("labels: %q is also an alias", label)
+		}
+		c.known[label] = true
+		for _, parent := range Ancestors(label) {
+			c.known[parent] = true
+		}
+	}
+	return nil
+}
+
+// Canonical returns the name label loads as: the alias target for the whole
+// label or, failing that, for its longest aliased prefix
+func (c *Config) Canonical(label string) string {
+	label = strings.TrimSpace(label)
+	if c == nil || len(c.aliases) == 0 || label == "" {
+		return label
+	}
+	if to, ok := c.aliases[strings.ToLower(label)]; ok {
+		return to
+	}
+	parts := strings.Split(label, Separator)
+	for i := len(parts) - 1; i > 0; i-- {
+		prefix := strings.Join(parts[:i], Separator)
+		if to, ok := c.aliases[strings.ToLower(prefix)]; ok {
+			return to + Separator + strings.Join(parts[i:], Separator)
+		}
+	}
+	return label
+}
+
+// Normalize rewrites labels to their canonical names, dropping empties and
+// the duplicates aliasing can create. A nil config returns labels unchanged.
+func (c *Config) Normalize(labels []string) []string {
+	if c == nil || len(labels) == 0 {
+		return labels
+	}
+	out := make([]string, 0, len(labels))
+	seen := make(map[string]bool, len(labels))
+	for _, label := range labels {
+		label = c.Canonical(label)
+		if label == "" || seen[label] {
+			continue
+		}
+		seen[label] = true
+		out = append(out, label)
+	}
+	return out
+}
+
+// Known reports whether label is in the vocabulary, or is a parent of a
+// label that is. Without a vocabulary every label is known.
+func (c *Config) Known(label string) bool {
+	if c == nil || len(c.known) == 0 {
+		return true
+	}
+	return c.known[label]
+}
+
+// Ancestors returns the parents of a hierarchical label, outermost first:
+// backend/api/v2 → backend, backend/api
+func Ancestors(label string) []string {
+	parts := strings.Split(label, Separator)
+	if len(parts) < 2 {
+		return nil
+	}
+	out := make([]string, 0, len(parts)-1)
+	for i := 1; i < len(parts); i++ {
+		parent := strings.Join(parts[:i], Separator)
+		if parent == "" {
+			continue
+		}
+		out = append(out, parent)
+	}
+	return out
+}
+
+// WithAncestors returns labels plus every parent in their hierarchies, each
+// once, so an issue tagged backend/api also counts toward backend
+func WithAncestors(labels []string) []string {
+	hierarchical := false
+	for _, label := range labels {
+		if strings.Contains(label, Separator) {
+			hierarchical = true
+			break
+		}
+	}
+	if !hierarchical {
+		return labels
+	}
+	out := make([]string, 0, len(labels)*2)
+	seen := make(map[string]bool, len(labels)*2)
+	add := func(label string) {
+		if !seen[label] {
+			seen[label] = true
+			out = append(out, label)
+		}
+	}
+	for _, label := range labels {
+		for _, parent := range Ancestors(label) {
+			add(parent)
+		}
+		add(label)
+	}
+	return out
+}
+
+// Within reports whether label is parent or sits under it in the hierarchy
+func Within(label, parent string) bool {
+	return label == parent || (parent != "" && strings.HasPrefix(label, parent+Separator))
+}
+
+// Report lists the labels in the raw data that labels.yaml rewrites or does
+// not know about
+type Report struct {
+	Configured bool           `json:"configured"` // .bv/labels.yaml exists
+	Aliased    []AliasedLabel `json:"aliased"`    // Old names still in the data
+	Unknown    []UnknownLabel `json:"unknown"`    // Outside the vocabulary, even after aliasing
+}
+
+// AliasedLabel is a raw label that loads under another name
+type AliasedLabel struct {
+	Label     string   `json:"label"`
+	Canonical string   `json:"canonical"`
+	Issues    []string `json:"issues"`
+}
+
+// UnknownLabel is a label outside the vocabulary
+type UnknownLabel struct {
+	Label  string   `json:"label"`
+	Issues []string `json:"issues"`
+}
+
+// Check compares issues with their labels as written (not yet normalized)
+// against the config
+func (c *Config) Check(issues []model.Issue) Report {
+	report := Report{Configured: c != nil, Aliased: []AliasedLabel{}, Unknown: []UnknownLabel{}}
+	if c == nil {
+		return report
+	}
+	aliased := make(map[string]*AliasedLabel)
+	unknown := make(map[string]*UnknownLabel)
+	for _, issue := range issues {
+		for _, raw := range issue.Labels {
+			raw = strings.TrimSpace(raw)
+			if raw == "" {
+				continue
+			}
+			canonical := c.Canonical(raw)
+			if canonical != raw {
+				entry := aliased[raw]
+				if entry == nil {
+					entry = &AliasedLabel{Label: raw, Canonical: canonical}
+					aliased[raw] = entry
+				}
+				entry.Issues = appendOnce(entry.Issues, issue.ID)
+			}
+			if !c.Known(canonical) {
+				entry := unknown[canonical]
+				if entry == nil {
+					entry = &UnknownLabel{Label: canonical}
+					unknown[canonical] = entry
+				}
+				entry.Issues = appendOnce(entry.Issues, issue.ID)
+			}
+		}
+	}
+	for _, entry := range aliased {
+		report.Aliased = append(report.Aliased, *entry)
+	}
+	for _, entry := range unknown {
+		report.Unknown = append(report.Unknown, *entry)
+	}
+	sort.Slice(report.Aliased, func(i, j int) bool { return report.Aliased[i].Label < report.Aliased[j].Label })
+	sort.Slice(report.Unknown, func(i, j int) bool { return report.Unknown[i].Label < report.Unknown[j].Label })
+	return report
+}
+
+func appendOnce(ids []string, id string) []string {
+	if len(ids) > 0 && ids[len(ids)-1] == id {
+		return ids
+	}
+	return append(ids, id)
+}
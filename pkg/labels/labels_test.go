@@ -0,0 +1,126 @@
+package labels
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+const testConfig = `
+aliases:
+  UI: frontend
+  api: backend/api
+labels:
+  - frontend
+  - backend/api
+  - backend/db
+`
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	if c, err := Load(dir); c != nil || err != nil {
+		t.Fatalf("missing config = %v, %v", c, err)
+	}
+	if err := os.MkdirAll(filepath.Join(dir, ".bv"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(Path(dir), []byte(testConfig), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	c, err := Load(dir)
+	if err != nil || c == nil {
+		t.Fatalf("Load = %v, %v", c, err)
+	}
+	if got := c.Canonical("ui"); got != "frontend" {
+		t.Errorf("Canonical(ui) = %q", got)
+	}
+}
+
+func TestParseRejectsBadAliases(t *testing.T) {
+	for name, yaml := range map[string]string{
+		"self":    "aliases: {ui: UI}",
+		"chained": "aliases: {ui: fe, fe: frontend}",
+		"empty":   "aliases: {ui: \"\"}",
+		"both":    "aliases: {ui: frontend}\nlabels: [ui]",
+		"syntax":  "aliases: [",
+	} {
+		if _, err := Parse([]byte(yaml)); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestCanonicalAndNormalize(t *testing.T) {
+	c, err := Parse([]byte(testConfig))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for raw, want := range map[string]string{
+		"ui":         "frontend",
+		" Ui ":       "frontend",
+		"ui/forms":   "frontend/forms",
+		"api/v2":     "backend/api/v2",
+		"backend/db": "backend/db",
+		"uix":        "uix",
+	} {
+		if got := c.Canonical(raw); got != want {
+			t.Errorf("Canonical(%q) = %q, want %q", raw, got, want)
+		}
+	}
+	if got := c.Normalize([]string{"ui", "frontend", "", "api"}); !reflect.DeepEqual(got, []string{"frontend", "backend/api"}) {
+		t.Errorf("Normalize = %v", got)
+	}
+
+	var none *Config
+	if got := none.Normalize([]string{"ui"}); !reflect.DeepEqual(got, []string{"ui"}) {
+		t.Errorf("nil config Normalize = %v", got)
+	}
+	if !none.Known("anything") {
+		t.Error("without a vocabulary every label is known")
+	}
+	if !c.Known("backend") || !c.Known("backend/api") || c.Known("backend/api/v2") || c.Known("docs") {
+		t.Error("Known should accept declared labels and their parents only")
+	}
+}
+
+func TestHierarchy(t *testing.T) {
+	if got := Ancestors("a/b/c"); !reflect.DeepEqual(got, []string{"a", "a/b"}) {
+		t.Errorf("Ancestors = %v", got)
+	}
+	if got := Ancestors("flat"); got != nil {
+		t.Errorf("Ancestors(flat) = %v", got)
+	}
+	if got := WithAncestors([]string{"backend/api", "backend/db", "ui"}); !reflect.DeepEqual(got, []string{"backend", "backend/api", "backend/db", "ui"}) {
+		t.Errorf("WithAncestors = %v", got)
+	}
+	if !Within("backend/api", "backend") || !Within("backend", "backend") || Within("backend-old", "backend") || Within("backend", "backend/api") {
+		t.Error("Within is wrong")
+	}
+}
+
+func TestCheck(t *testing.T) {
+	c, err := Parse([]byte(testConfig))
+	if err != nil {
+		t.Fatal(err)
+	}
+	issues := []model.Issue{
+		{ID: "a-1", Labels: []string{"ui", "ui"}},
+		{ID: "a-2", Labels: []string{"backend/db", "mystery"}},
+		{ID: "a-3", Labels: []string{"ui/forms"}},
+	}
+	report := c.Check(issues)
+	if !report.Configured || len(report.Aliased) != 2 || report.Aliased[0].Label != "ui" || !reflect.DeepEqual(report.Aliased[0].Issues, []string{"a-1"}) {
+		t.Errorf("aliased = %+v", report.Aliased)
+	}
+	if len(report.Unknown) != 2 || report.Unknown[0].Label != "frontend/forms" || report.Unknown[1].Label != "mystery" {
+		t.Errorf("unknown = %+v", report.Unknown)
+	}
+
+	var none *Config
+	if report := none.Check(issues); report.Configured || len(report.Unknown) != 0 {
+		t.Errorf("nil config report = %+v", report)
+	}
+}
@@ -0,0 +1,376 @@
+package loader
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// ErrEditConflict is returned when an issue record no longer matches the
+// state an edit expects, e.g. because it was changed after the edit was made.
+var ErrEditConflict = errors.New("issue changed since the edit")
+
+// LineEdit is one issue record before and after a write-back. It is enough
+// to revert or re-apply the change (see ReplaceIssueLine and Journal).
+type LineEdit struct {
+	IssueID string          `json:"issue_id"`
+	Before  json.RawMessage `json:"before"`
+	After   json.RawMessage `json:"after"`
+}
+
+// RemoveDependency deletes the dependency of issueID on dependsOnID from the
+// JSONL file at path. Only the affected line is rewritten; every other line is
+// preserved byte for byte. It returns nil if no such dependency exists.
+// The write is atomic (temp file + rename) to be safe with editors and watchers.
+func RemoveDependency(path, issueID, dependsOnID string) (*LineEdit, error) {
+	return editIssueLine(path, issueID, func(line []byte) ([]byte, bool, error) {
+		return removeDependencyFromLine(line, dependsOnID)
+	})
+}
+
+// AddComment appends comment to issueID's thread in the JSONL file at path,
+// with the same line-preserving, atomic write as RemoveDependency. The
+// comment gets the next free ID, the issue's ID and, if unset, the current
+// time. It returns nil if the issue is not in the file.
+func AddComment(path, issueID string, comment model.Comment) (*LineEdit, error) {
+	if comment.CreatedAt.IsZero() {
+		comment.CreatedAt = time.Now().UTC()
+	}
+	comment.IssueID = issueID
+	return editIssueLine(path, issueID, func(line []byte) ([]byte, bool, error) {
+		return addCommentToLine(line, comment)
+	})
+}
+
+// AddLabels adds labels to issueID in the JSONL file at path, with the same
+// line-preserving, atomic write as RemoveDependency. Labels the issue already
+// has are skipped. It returns nil if the issue is not in the file or nothing
+// was added.
+func AddLabels(path, issueID string, labels []string) (*LineEdit, error) {
+	return editIssueLine(path, issueID, func(line []byte) ([]byte, bool, error) {
+		return addLabelsToLine(line, labels)
+	})
+}
+
+// SetPriority sets issueID's priority in the JSONL file at path and stamps
+// updated_at, with the same line-preserving, atomic write as RemoveDependency.
+// It returns nil if the issue is not in the file or already has that priority.
+func SetPriority(path, issueID string, priority int, now time.Time) (*LineEdit, error) {
+	return editIssueLine(path, issueID, func(line []byte) ([]byte, bool, error) {
+		return setPriorityOnLine(line, priority, now)
+	})
+}
+
+// AppendIssue adds issue as a new line at the end of the JSONL file at path,
+// creating the file if needed. It fails if the ID is already in the file.
+// The write is atomic, like RemoveDependency.
+func AppendIssue(path string, issue model.Issue) error {
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read issues file: %w", err)
+	}
+	for _, line := range bytes.Split(data, []byte("\n")) {
+		var head struct {
+			ID string `json:"id"`
+		}
+		if json.Unmarshal(stripBOM(line), &head) == nil && head.ID == issue.ID {
+			return fmt.Errorf("issue %s already exists", issue.ID)
+		}
+	}
+
+	line, err := json.Marshal(issue)
+	if err != nil {
+		return fmt.Errorf("failed to encode issue: %w", err)
+	}
+	if len(data) > 0 && data[len(data)-1] != '\n' {
+		data = append(data, '\n')
+	}
+	data = append(append(data, line...), '\n')
+	return writeFileAtomic(path, data)
+}
+
+// ReplaceIssueLine swaps issueID's record for to, provided it still matches
+// from (compared as JSON values, so key order and spacing do not matter).
+// It returns ErrEditConflict if the record is missing or has changed.
+func ReplaceIssueLine(path, issueID string, from, to json.RawMessage) error {
+	var want any
+	if err := json.Unmarshal(from, &want); err != nil {
+		return fmt.Errorf("invalid expected record: %w", err)
+	}
+	edit, err := editIssueLine(path, issueID, func(line []byte) ([]byte, bool, error) {
+		var got any
+		if err := json.Unmarshal(line, &got); err != nil {
+			return nil, false, err
+		}
+		if !reflect.DeepEqual(got, want) {
+			return nil, false, nil
+		}
+		return to, true, nil
+	})
+	if err != nil {
+		return err
+	}
+	if edit == nil {
+		return fmt.Errorf("%s: %w", issueID, ErrEditConflict)
+	}
+	return nil
+}
+
+// editIssueLine rewrites the record for issueID with edit, leaving every
+// other line untouched. It returns nil if no line was changed.
+func editIssueLine(path, issueID string, edit func(line []byte) ([]byte, bool, error)) (*LineEdit, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read issues file: %w", err)
+	}
+
+	lines := bytes.SplitAfter(data, []byte("\n"))
+	var change *LineEdit
+	for i, raw := range lines {
+		body := bytes.TrimRight(raw, "\r\n")
+		eol := raw[len(body):]
+		var bom []byte
+		if i == 0 && len(stripBOM(body)) < len(body) {
+			bom, body = body[:3], body[3:]
+		}
+		if len(bytes.TrimSpace(body)) == 0 {
+			continue
+		}
+
+		var head struct {
+			ID string `json:"id"`
+		}
+		if json.Unmarshal(body, &head) != nil || head.ID != issueID {
+			continue
+		}
+
+		updated, ok, err := edit(body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to update %s: %w", issueID, err)
+		}
+		if !ok {
+			continue
+		}
+		if change == nil {
+			change = &LineEdit{
+				IssueID: issueID,
+				Before:  append(json.RawMessage(nil), body...),
+				After:   append(json.RawMessage(nil), updated...),
+			}
+		}
+		lines[i] = bytes.Join([][]byte{bom, updated, eol}, nil)
+	}
+	if change == nil {
+		return nil, nil
+	}
+
+	if err := writeFileAtomic(path, bytes.Join(lines, nil)); err != nil {
+		return nil, err
+	}
+	return change, nil
+}
+
+// addCommentToLine appends comment to one JSONL issue record, numbering it
+// after the highest existing comment ID. Unknown fields and the record's key
+// order are kept.
+func addCommentToLine(line []byte, comment model.Comment) ([]byte, bool, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(line, &fields); err != nil {
+		return nil, false, err
+	}
+	keys, _ := jsonKeys(line)
+	var comments []json.RawMessage
+	if raw, ok := fields["comments"]; ok && string(raw) != "null" {
+		if err := json.Unmarshal(raw, &comments); err != nil {
+			return nil, false, err
+		}
+	}
+
+	if comment.ID == 0 {
+		for _, c := range comments {
+			var existing struct {
+				ID int64 `json:"id"`
+			}
+			if json.Unmarshal(c, &existing) == nil && existing.ID > comment.ID {
+				comment.ID = existing.ID
+			}
+		}
+		comment.ID++
+	}
+	raw, err := json.Marshal(comment)
+	if err != nil {
+		return nil, false, err
+	}
+	comments = append(comments, raw)
+
+	if fields["comments"], err = json.Marshal(comments); err != nil {
+		return nil, false, err
+	}
+	out, err := marshalFields(keys, fields)
+	if err != nil {
+		return nil, false, err
+	}
+	return out, true, nil
+}
+
+// addLabelsToLine appends the labels one JSONL issue record lacks, keeping
+// existing labels in order. Unknown fields and the record's key order are
+// kept.
+func addLabelsToLine(line []byte, labels []string) ([]byte, bool, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(line, &fields); err != nil {
+		return nil, false, err
+	}
+	keys, _ := jsonKeys(line)
+	var existing []string
+	if raw, ok := fields["labels"]; ok && string(raw) != "null" {
+		if err := json.Unmarshal(raw, &existing); err != nil {
+			return nil, false, err
+		}
+	}
+
+	have := make(map[string]bool, len(existing))
+	for _, l := range existing {
+		have[l] = true
+	}
+	added := false
+	for _, l := range labels {
+		if l == "" || have[l] {
+			continue
+		}
+		have[l] = true
+		existing = append(existing, l)
+		added = true
+	}
+	if !added {
+		return nil, false, nil
+	}
+
+	var err error
+	if fields["labels"], err = json.Marshal(existing); err != nil {
+		return nil, false, err
+	}
+	out, err := marshalFields(keys, fields)
+	if err != nil {
+		return nil, false, err
+	}
+	return out, true, nil
+}
+
+// setPriorityOnLine updates one JSONL issue record's priority and updated_at.
+// Unknown fields and the record's key order are kept.
+func setPriorityOnLine(line []byte, priority int, now time.Time) ([]byte, bool, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(line, &fields); err != nil {
+		return nil, false, err
+	}
+	keys, _ := jsonKeys(line)
+	var current int
+	if raw, ok := fields["priority"]; ok {
+		if err := json.Unmarshal(raw, &current); err != nil {
+			return nil, false, err
+		}
+		if current == priority {
+			return nil, false, nil
+		}
+	}
+
+	var err error
+	if fields["priority"], err = json.Marshal(priority); err != nil {
+		return nil, false, err
+	}
+	if fields["updated_at"], err = json.Marshal(now.UTC()); err != nil {
+		return nil, false, err
+	}
+	out, err := marshalFields(keys, fields)
+	if err != nil {
+		return nil, false, err
+	}
+	return out, true, nil
+}
+
+// removeDependencyFromLine drops dependencies on dependsOnID from one JSONL
+// issue record. Unknown fields and the record's key order are kept.
+func removeDependencyFromLine(line []byte, dependsOnID string) ([]byte, bool, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(line, &fields); err != nil {
+		return nil, false, err
+	}
+	keys, _ := jsonKeys(line)
+	var deps []json.RawMessage
+	if raw, ok := fields["dependencies"]; ok {
+		if err := json.Unmarshal(raw, &deps); err != nil {
+			return nil, false, err
+		}
+	}
+
+	kept := deps[:0]
+	for _, dep := range deps {
+		var d struct {
+			DependsOnID string `json:"depends_on_id"`
+		}
+		if json.Unmarshal(dep, &d) == nil && d.DependsOnID == dependsOnID {
+			continue
+		}
+		kept = append(kept, dep)
+	}
+	if len(kept) == len(deps) {
+		return nil, false, nil
+	}
+
+	if len(kept) == 0 {
+		delete(fields, "dependencies")
+	} else {
+		raw, err := json.Marshal(kept)
+		if err != nil {
+			return nil, false, err
+		}
+		fields["dependencies"] = raw
+	}
+	out, err := marshalFields(keys, fields)
+	if err != nil {
+		return nil, false, err
+	}
+	return out, true, nil
+}
+
+// writeFileAtomic replaces path with data via a temp file in the same directory,
+// keeping the original file mode.
+func writeFileAtomic(path string, data []byte) error {
+	mode := os.FileMode(0o644)
+	if info, err := os.Stat(path); err == nil {
+		mode = info.Mode().Perm()
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temp file: %w", err)
+	}
+	tmpName := tmp.Name()
+
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmpName)
+		return fmt.Errorf("failed to write temp file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmpName)
+		return fmt.Errorf("failed to close temp file: %w", err)
+	}
+	if err := os.Chmod(tmpName, mode); err != nil {
+		_ = os.Remove(tmpName)
+		return fmt.Errorf("failed to set file mode: %w", err)
+	}
+	if err := os.Rename(tmpName, path); err != nil {
+		_ = os.Remove(tmpName)
+		return fmt.Errorf("failed to rename temp file: %w", err)
+	}
+	return nil
+}
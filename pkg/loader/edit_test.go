@@ -0,0 +1,286 @@
+package loader_test
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestRemoveDependency(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "issues.jsonl")
+	other := `{"id":"B","title":"Beta","status":"open","issue_type":"task","dependencies":[{"issue_id":"B","depends_on_id":"A","type":"blocks"}]}`
+	content := "\xEF\xBB\xBF" +
+		`{"id":"A","title":"Alpha","status":"open","issue_type":"task","dependencies":[{"issue_id":"A","depends_on_id":"B","type":"blocks"},{"issue_id":"A","depends_on_id":"C","type":"related"}],"x_custom":1}` + "\r\n" +
+		other + "\n" +
+		`{"id":"C","title":"Gamma","status":"open","issue_type":"task","dependencies":[{"issue_id":"C","depends_on_id":"B","type":"blocks"}]}` + "\n"
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	edit, err := loader.RemoveDependency(path, "A", "B")
+	if err != nil || edit == nil {
+		t.Fatalf("RemoveDependency(A, B) = %v, %v; want an edit", edit, err)
+	}
+	if edit.IssueID != "A" || !strings.Contains(string(edit.Before), `"depends_on_id":"B"`) || strings.Contains(string(edit.After), `"depends_on_id":"B"`) {
+		t.Errorf("edit record = %s -> %s", edit.Before, edit.After)
+	}
+	if strings.HasPrefix(string(edit.Before), "\xEF\xBB\xBF") {
+		t.Error("edit record should not include the BOM")
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.SplitAfter(string(data), "\n")
+	if !strings.HasPrefix(lines[0], "\xEF\xBB\xBF") || !strings.HasSuffix(lines[0], "\r\n") {
+		t.Errorf("BOM or CRLF not preserved on edited line: %q", lines[0])
+	}
+	wantA := `{"id":"A","title":"Alpha","status":"open","issue_type":"task","dependencies":[{"issue_id":"A","depends_on_id":"C","type":"related"}],"x_custom":1}`
+	if got := strings.TrimSuffix(strings.TrimPrefix(lines[0], "\xEF\xBB\xBF"), "\r\n"); got != wantA {
+		t.Errorf("edited line = %s, want %s", got, wantA)
+	}
+	if lines[1] != other+"\n" {
+		t.Errorf("untouched line changed: %q", lines[1])
+	}
+	if info, err := os.Stat(path); err == nil && info.Mode().Perm() != 0o600 {
+		t.Errorf("file mode = %v, want 0600", info.Mode().Perm())
+	}
+
+	issues, err := loader.LoadIssuesFromFile(path)
+	if err != nil || len(issues) != 3 {
+		t.Fatalf("reload: %d issues, err %v", len(issues), err)
+	}
+	if deps := issues[0].Dependencies; len(deps) != 1 || deps[0].DependsOnID != "C" {
+		t.Errorf("A dependencies after removal = %+v", deps)
+	}
+
+	// Removing the last dependency drops the field entirely
+	if edit, err := loader.RemoveDependency(path, "C", "B"); err != nil || edit == nil {
+		t.Fatalf("RemoveDependency(C, B) = %v, %v", edit, err)
+	}
+	data, _ = os.ReadFile(path)
+	if strings.Contains(strings.SplitAfter(string(data), "\n")[2], "dependencies") {
+		t.Errorf("empty dependencies field should be dropped")
+	}
+
+	// Missing edge reports no edit and leaves the file alone
+	before, _ := os.ReadFile(path)
+	if edit, err := loader.RemoveDependency(path, "A", "Z"); err != nil || edit != nil {
+		t.Errorf("RemoveDependency(A, Z) = %v, %v; want nil, nil", edit, err)
+	}
+	after, _ := os.ReadFile(path)
+	if string(before) != string(after) {
+		t.Error("file changed although nothing was removed")
+	}
+}
+
+func TestAddComment(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "issues.jsonl")
+	other := `{"id":"B","title":"Beta","status":"open","issue_type":"task"}`
+	content := `{"id":"A","title":"Alpha","status":"open","issue_type":"task","comments":[{"id":7,"issue_id":"A","author":"alice","text":"first","created_at":"2024-01-01T00:00:00Z"}],"x_custom":1}` + "\r\n" +
+		other + "\n"
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	edit, err := loader.AddComment(path, "A", model.Comment{Author: "bob", Text: "looks good\nship it"})
+	if err != nil || edit == nil {
+		t.Fatalf("AddComment(A) = %v, %v; want an edit", edit, err)
+	}
+	if edit, err := loader.AddComment(path, "B", model.Comment{Author: "carol", Text: "hi"}); err != nil || edit == nil {
+		t.Fatalf("AddComment(B) = %v, %v", edit, err)
+	}
+
+	data, _ := os.ReadFile(path)
+	lines := strings.SplitAfter(string(data), "\n")
+	if !strings.HasSuffix(lines[0], "\r\n") || !strings.Contains(lines[0], `"x_custom":1`) {
+		t.Errorf("line ending or unknown fields lost: %q", lines[0])
+	}
+	if !strings.HasPrefix(lines[0], `{"id":"A","title":"Alpha","status":"open","issue_type":"task","comments":[{"id":7,`) ||
+		!strings.HasSuffix(lines[0], `}],"x_custom":1}`+"\r\n") {
+		t.Errorf("field order not kept: %s", lines[0])
+	}
+	if !strings.HasPrefix(lines[1], `{"id":"B","title":"Beta","status":"open","issue_type":"task","comments":[`) {
+		t.Errorf("new comments field should follow the existing ones: %s", lines[1])
+	}
+
+	issues, err := loader.LoadIssuesFromFile(path)
+	if err != nil || len(issues) != 2 {
+		t.Fatalf("reload: %d issues, err %v", len(issues), err)
+	}
+	comments := issues[0].Comments
+	if len(comments) != 2 {
+		t.Fatalf("A comments = %+v", comments)
+	}
+	c := comments[1]
+	if c.ID != 8 || c.IssueID != "A" || c.Author != "bob" || c.Text != "looks good\nship it" || c.CreatedAt.IsZero() {
+		t.Errorf("appended comment = %+v", c)
+	}
+	if b := issues[1].Comments; len(b) != 1 || b[0].ID != 1 {
+		t.Errorf("first comment on B = %+v", b)
+	}
+
+	// Unknown issue reports no edit and leaves the file alone
+	before, _ := os.ReadFile(path)
+	if edit, err := loader.AddComment(path, "Z", model.Comment{Text: "x"}); err != nil || edit != nil {
+		t.Errorf("AddComment(Z) = %v, %v; want nil, nil", edit, err)
+	}
+	after, _ := os.ReadFile(path)
+	if string(before) != string(after) {
+		t.Error("file changed although no issue matched")
+	}
+}
+
+func TestAddLabels(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "issues.jsonl")
+	other := `{"id":"B","title":"Beta","status":"open","issue_type":"task"}`
+	content := `{"id":"A","title":"Alpha","status":"open","issue_type":"task","labels":["api"],"x_custom":1}` + "\r\n" +
+		other + "\n"
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	edit, err := loader.AddLabels(path, "A", []string{"api", "backend", "backend"})
+	if err != nil || edit == nil {
+		t.Fatalf("AddLabels(A) = %v, %v; want an edit", edit, err)
+	}
+	if edit, err := loader.AddLabels(path, "B", []string{"docs"}); err != nil || edit == nil {
+		t.Fatalf("AddLabels(B) = %v, %v", edit, err)
+	}
+
+	data, _ := os.ReadFile(path)
+	lines := strings.SplitAfter(string(data), "\n")
+	if !strings.HasSuffix(lines[0], "\r\n") || !strings.Contains(lines[0], `"x_custom":1`) {
+		t.Errorf("line ending or unknown fields lost: %q", lines[0])
+	}
+	if want := `{"id":"A","title":"Alpha","status":"open","issue_type":"task","labels":["api","backend"],"x_custom":1}` + "\r\n"; lines[0] != want {
+		t.Errorf("edited line = %q, want %q", lines[0], want)
+	}
+
+	issues, err := loader.LoadIssuesFromFile(path)
+	if err != nil || len(issues) != 2 {
+		t.Fatalf("reload: %d issues, err %v", len(issues), err)
+	}
+	if got := strings.Join(issues[0].Labels, ","); got != "api,backend" {
+		t.Errorf("A labels = %s, want api,backend", got)
+	}
+	if got := strings.Join(issues[1].Labels, ","); got != "docs" {
+		t.Errorf("B labels = %s, want docs", got)
+	}
+
+	// Nothing new to add reports no edit and leaves the file alone
+	before, _ := os.ReadFile(path)
+	if edit, err := loader.AddLabels(path, "A", []string{"api"}); err != nil || edit != nil {
+		t.Errorf("AddLabels(A, api) = %v, %v; want nil, nil", edit, err)
+	}
+	after, _ := os.ReadFile(path)
+	if string(before) != string(after) {
+		t.Error("file changed although no label was added")
+	}
+}
+
+func TestSetPriority(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "issues.jsonl")
+	content := `{"id":"A","title":"Alpha","status":"open","issue_type":"task","priority":1,"updated_at":"2024-01-01T00:00:00Z","x_custom":1}` + "\n" +
+		`{"id":"B","title":"Beta","status":"open","issue_type":"task","priority":3}` + "\n"
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	edit, err := loader.SetPriority(path, "A", 3, now)
+	if err != nil || edit == nil {
+		t.Fatalf("SetPriority(A) = %v, %v; want an edit", edit, err)
+	}
+	if want := `{"id":"A","title":"Alpha","status":"open","issue_type":"task","priority":3,"updated_at":"2025-03-01T12:00:00Z","x_custom":1}`; string(edit.After) != want {
+		t.Errorf("edited line = %s, want %s", edit.After, want)
+	}
+
+	issues, err := loader.LoadIssuesFromFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if issues[0].Priority != 3 || !issues[0].UpdatedAt.Equal(now) {
+		t.Errorf("A = priority %d updated %v; want 3 at %v", issues[0].Priority, issues[0].UpdatedAt, now)
+	}
+
+	// Same priority is a no-op
+	if edit, err := loader.SetPriority(path, "B", 3, now); err != nil || edit != nil {
+		t.Errorf("SetPriority(B, 3) = %v, %v; want nil, nil", edit, err)
+	}
+}
+
+func TestAppendIssue(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "issues.jsonl")
+	// No trailing newline on the last line
+	existing := `{"id":"A","title":"Alpha","status":"open","issue_type":"task"}`
+	if err := os.WriteFile(path, []byte(existing), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	issue := model.Issue{ID: "B", Title: "Beta", Status: model.StatusOpen, IssueType: model.TypeBug, Priority: 1, CreatedAt: now, UpdatedAt: now}
+	if err := loader.AppendIssue(path, issue); err != nil {
+		t.Fatalf("AppendIssue: %v", err)
+	}
+	data, _ := os.ReadFile(path)
+	if !strings.HasPrefix(string(data), existing+"\n") || !strings.HasSuffix(string(data), "}\n") {
+		t.Errorf("file = %q", data)
+	}
+	issues, err := loader.LoadIssuesFromFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(issues) != 2 || issues[1].ID != "B" || issues[1].IssueType != model.TypeBug {
+		t.Errorf("issues = %+v", issues)
+	}
+
+	if err := loader.AppendIssue(path, issue); err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Errorf("duplicate ID: err = %v", err)
+	}
+
+	// A missing file is created
+	fresh := filepath.Join(t.TempDir(), "new.jsonl")
+	if err := loader.AppendIssue(fresh, issue); err != nil {
+		t.Fatalf("AppendIssue(new file): %v", err)
+	}
+}
+
+func TestReplaceIssueLine(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "issues.jsonl")
+	original := `{"id":"A","title":"Alpha","status":"open","issue_type":"task"}`
+	if err := os.WriteFile(path, []byte(original+"\r\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	// The expected record matches regardless of key order and spacing
+	from := json.RawMessage(`{ "title": "Alpha", "id": "A", "issue_type": "task", "status": "open" }`)
+	to := json.RawMessage(`{"id":"A","title":"Alpha","status":"closed","issue_type":"task"}`)
+	if err := loader.ReplaceIssueLine(path, "A", from, to); err != nil {
+		t.Fatalf("ReplaceIssueLine: %v", err)
+	}
+	data, _ := os.ReadFile(path)
+	if string(data) != string(to)+"\r\n" {
+		t.Errorf("file = %q", data)
+	}
+
+	// Replaying against a record that moved on is a conflict
+	if err := loader.ReplaceIssueLine(path, "A", from, to); !errors.Is(err, loader.ErrEditConflict) {
+		t.Errorf("stale replace: err = %v, want ErrEditConflict", err)
+	}
+	if err := loader.ReplaceIssueLine(path, "Z", to, from); !errors.Is(err, loader.ErrEditConflict) {
+		t.Errorf("missing issue: err = %v, want ErrEditConflict", err)
+	}
+}
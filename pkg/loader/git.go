@@ -4,7 +4,9 @@ import (
 	"bufio"
 	"bytes"
 	"fmt"
+	"os"
 	"os/exec"
+	"path/filepath"
 	"strings"
 	"sync"
 	"time"
@@ -80,6 +82,41 @@ func (g *GitLoader) LoadAt(revision string) ([]model.Issue, error) {
 	return issues, nil
 }
 
+// LoadSource loads issues from either end of a diff: a JSONL file, a
+// directory holding one (directly or in .beads), a revision, or REV:PATH for
+// a JSONL file at a path in a revision (e.g. a proposed tracker file in a
+// PR branch). The returned SHA is empty for files on disk.
+func (g *GitLoader) LoadSource(source string) (issues []model.Issue, sha string, err error) {
+	if info, statErr := os.Stat(source); statErr == nil {
+		path := source
+		if info.IsDir() {
+			dir := source
+			if beadsInfo, err := os.Stat(filepath.Join(source, ".beads")); err == nil && beadsInfo.IsDir() {
+				dir = filepath.Join(source, ".beads")
+			}
+			if path, err = FindJSONLPath(dir); err != nil {
+				return nil, "", err
+			}
+		}
+		issues, err = LoadIssuesFromFile(path)
+		return issues, "", err
+	}
+
+	issues, err = g.LoadAt(source)
+	if err == nil {
+		sha, _ = g.resolveRevision(source)
+		return issues, sha, nil
+	}
+	if rev, path, ok := strings.Cut(source, ":"); ok && rev != "" && path != "" {
+		if revSHA, revErr := g.resolveRevision(rev + "^{commit}"); revErr == nil {
+			if issues, fileErr := g.loadFileFromGit(revSHA, path); fileErr == nil {
+				return issues, revSHA, nil
+			}
+		}
+	}
+	return nil, "", fmt.Errorf("%q is not a beads file, directory or revision: %w", source, err)
+}
+
 // LoadAtDate loads issues from the state at a specific date/time
 // Uses git rev-list to find the commit at or before the given time
 func (g *GitLoader) LoadAtDate(t time.Time) ([]model.Issue, error) {
@@ -92,6 +129,21 @@ func (g *GitLoader) ResolveRevision(revision string) (string, error) {
 	return g.resolveRevision(revision)
 }
 
+// RevisionTime returns the author time of the commit a revision resolves to
+func (g *GitLoader) RevisionTime(revision string) (time.Time, error) {
+	sha, err := g.resolveRevision(revision)
+	if err != nil {
+		return time.Time{}, err
+	}
+	cmd := exec.Command("git", "show", "-s", "--format=%aI", sha)
+	cmd.Dir = g.repoPath
+	out, err := cmd.Output()
+	if err != nil {
+		return time.Time{}, fmt.Errorf("reading commit time: %w", err)
+	}
+	return time.Parse(time.RFC3339, strings.TrimSpace(string(out)))
+}
+
 // ListRevisions returns commits that modified beads files
 func (g *GitLoader) ListRevisions(limit int) ([]RevisionInfo, error) {
 	args := []string{
@@ -142,6 +194,35 @@ func (g *GitLoader) ListRevisions(limit int) ([]RevisionInfo, error) {
 	return revisions, nil
 }
 
+// SnapshotsSince returns a lookup of the issues as last committed at or
+// before a time, for times from since on. It reads the commits since then
+// plus the one in force when the window opened, and returns nil when there
+// is no git history to read.
+func (g *GitLoader) SnapshotsSince(since time.Time) func(t time.Time) ([]model.Issue, bool) {
+	revisions, err := g.ListRevisions(0)
+	if err != nil || len(revisions) == 0 {
+		return nil
+	}
+	// Newest first: keep the window, plus the commit in force when it opened
+	for i, rev := range revisions {
+		if rev.Timestamp.Before(since) {
+			revisions = revisions[:i+1]
+			break
+		}
+	}
+
+	return func(t time.Time) ([]model.Issue, bool) {
+		for _, rev := range revisions {
+			if rev.Timestamp.After(t) {
+				continue
+			}
+			issues, err := g.LoadAt(rev.SHA)
+			return issues, err == nil
+		}
+		return nil, false
+	}
+}
+
 // RevisionInfo describes a git commit
 type RevisionInfo struct {
 	SHA       string    `json:"sha"`
@@ -232,7 +313,9 @@ func (g *GitLoader) loadFileFromGit(sha, path string) ([]model.Issue, error) {
 		return nil, fmt.Errorf("git show %s:%s failed: %w", sha, path, err)
 	}
 
-	return ParseIssues(bytes.NewReader(out))
+	opts := ParseOptions{}
+	opts.Labels = loadLabelConfig(g.repoPath, warningHandler(opts))
+	return ParseIssuesWithOptions(bytes.NewReader(out), opts)
 }
 
 // Cache methods
@@ -387,3 +470,110 @@ func (g *GitLoader) HasBeadsAtRevision(revision string) (bool, error) {
 
 	return false, nil
 }
+
+// RevisionsBetween returns the commits from from to to, oldest first: from
+// itself, then every later commit touching the beads files. An empty from
+// starts at the first such commit, and an empty to means HEAD.
+func (g *GitLoader) RevisionsBetween(from, to string) ([]RevisionInfo, error) {
+	if to == "" {
+		to = "HEAD"
+	}
+	toSHA, err := g.resolveRevision(to)
+	if err != nil {
+		return nil, fmt.Errorf("resolving to revision: %w", err)
+	}
+
+	rangeSpec := toSHA
+	var start []RevisionInfo
+	if from != "" {
+		fromSHA, err := g.resolveRevision(from)
+		if err != nil {
+			return nil, fmt.Errorf("resolving from revision: %w", err)
+		}
+		rangeSpec = fromSHA + ".." + toSHA
+		if start, err = g.logRevisions([]string{"-1", fromSHA}, false); err != nil {
+			return nil, err
+		}
+	}
+
+	// Newest first from git log
+	revisions, err := g.logRevisions([]string{rangeSpec}, true)
+	if err != nil {
+		return nil, err
+	}
+	revisions = append(revisions, start...)
+	for i, j := 0, len(revisions)-1; i < j; i, j = i+1, j-1 {
+		revisions[i], revisions[j] = revisions[j], revisions[i]
+	}
+	return revisions, nil
+}
+
+// WeeklyRevisions returns one commit per week from from to to, oldest first:
+// from itself, then the last commit touching the beads files in each later
+// week. An empty from starts at the first such commit, and an empty to means
+// HEAD. Weeks start on Monday, in UTC.
+func (g *GitLoader) WeeklyRevisions(from, to string) ([]RevisionInfo, error) {
+	revisions, err := g.RevisionsBetween(from, to)
+	if err != nil {
+		return nil, err
+	}
+	var weekly []RevisionInfo
+	for i, rev := range revisions {
+		keep := i == len(revisions)-1 || !weekStart(revisions[i+1].Timestamp).Equal(weekStart(rev.Timestamp))
+		if i == 0 && from != "" {
+			keep = true // The starting point, whatever its week
+		}
+		if keep {
+			weekly = append(weekly, rev)
+		}
+	}
+	return weekly, nil
+}
+
+// weekStart returns the Monday 00:00 UTC starting t's week
+func weekStart(t time.Time) time.Time {
+	t = t.UTC()
+	weekday := int(t.Weekday())
+	if weekday == 0 {
+		weekday = 7
+	}
+	y, m, d := t.Date()
+	return time.Date(y, m, d-(weekday-1), 0, 0, 0, 0, time.UTC)
+}
+
+// logRevisions runs git log over revArgs, limited to commits touching the
+// beads files when beadsOnly is set
+func (g *GitLoader) logRevisions(revArgs []string, beadsOnly bool) ([]RevisionInfo, error) {
+	args := append([]string{"log", "--format=%H|%aI|%s"}, revArgs...)
+	if beadsOnly {
+		args = append(args, "--")
+		for _, name := range PreferredJSONLNames {
+			args = append(args, ".beads/"+name)
+		}
+	}
+	cmd := exec.Command("git", args...)
+	cmd.Dir = g.repoPath
+
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("listing git history: %w", err)
+	}
+
+	var revisions []RevisionInfo
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		parts := strings.SplitN(scanner.Text(), "|", 3)
+		if len(parts) != 3 {
+			continue
+		}
+		timestamp, err := time.Parse(time.RFC3339, parts[1])
+		if err != nil {
+			continue // skip revisions with unparseable timestamps
+		}
+		revisions = append(revisions, RevisionInfo{SHA: parts[0], Timestamp: timestamp, Message: parts[2]})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("parsing git log output: %w", err)
+	}
+	return revisions, nil
+}
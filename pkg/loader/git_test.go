@@ -159,6 +159,24 @@ func TestGitLoader_ResolveRevision(t *testing.T) {
 	}
 }
 
+func TestGitLoader_RevisionTime(t *testing.T) {
+	repoDir, cleanup := setupTestGitRepo(t)
+	defer cleanup()
+
+	loader := NewGitLoader(repoDir)
+
+	head, err := loader.RevisionTime("HEAD")
+	if err != nil {
+		t.Fatalf("RevisionTime(HEAD) failed: %v", err)
+	}
+	if head.IsZero() || head.After(time.Now().Add(time.Minute)) {
+		t.Errorf("unexpected HEAD commit time %v", head)
+	}
+	if _, err := loader.RevisionTime("nonexistent-ref"); err == nil {
+		t.Error("expected an error for an unknown revision")
+	}
+}
+
 func TestGitLoader_ResolveRevision_DateString(t *testing.T) {
 	repoDir, cleanup := setupTestGitRepo(t)
 	defer cleanup()
@@ -425,3 +443,90 @@ func TestCacheExpiry(t *testing.T) {
 		t.Errorf("expected 0 valid entries after expiry, got %d", stats.ValidEntries)
 	}
 }
+
+func TestWeeklyRevisions(t *testing.T) {
+	repoDir, cleanup := setupTestGitRepo(t)
+	defer cleanup()
+
+	beadsFile := filepath.Join(repoDir, ".beads", "beads.base.jsonl")
+	commitOn := func(date, title string) {
+		line := `{"id":"ISSUE-9","title":"` + title + `","status":"open","priority":1,"issue_type":"task"}` + "\n"
+		if err := os.WriteFile(beadsFile, []byte(line), 0644); err != nil {
+			t.Fatal(err)
+		}
+		runGit(t, repoDir, "add", ".")
+		runGit(t, repoDir, "commit", "-m", title, "--date", date)
+	}
+	from := strings.TrimSpace(runGitOutput(t, repoDir, "rev-parse", "HEAD"))
+	commitOn("2030-01-07T10:00:00Z", "mon week 1") // Monday
+	commitOn("2030-01-09T10:00:00Z", "wed week 1")
+	commitOn("2030-01-15T10:00:00Z", "tue week 2")
+	runGit(t, repoDir, "commit", "--allow-empty", "-m", "no beads change", "--date", "2030-01-23T10:00:00Z")
+
+	revs, err := NewGitLoader(repoDir).WeeklyRevisions(from, "HEAD")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, r := range revs {
+		got = append(got, r.Message)
+	}
+	want := []string{"Add third issue", "wed week 1", "tue week 2"}
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("weekly revisions = %v, want %v", got, want)
+	}
+
+	between, err := NewGitLoader(repoDir).RevisionsBetween(from, "HEAD")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(between) != 4 || between[0].SHA != from || between[1].Message != "mon week 1" {
+		t.Errorf("revisions between = %+v", between)
+	}
+
+	all, err := NewGitLoader(repoDir).WeeklyRevisions("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) != 3 || all[len(all)-1].Message != "tue week 2" {
+		t.Errorf("full history weekly revisions = %+v", all)
+	}
+}
+
+func TestLoadSource(t *testing.T) {
+	repoDir, cleanup := setupTestGitRepo(t)
+	defer cleanup()
+	g := NewGitLoader(repoDir)
+
+	proposed := filepath.Join(repoDir, "proposed.jsonl")
+	if err := os.WriteFile(proposed, []byte(`{"id":"ISSUE-9","title":"Proposed","status":"open","priority":1,"issue_type":"task"}`+"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	runGit(t, repoDir, "add", ".")
+	runGit(t, repoDir, "commit", "-m", "Propose")
+
+	tests := []struct {
+		source  string
+		count   int
+		fromGit bool
+	}{
+		{source: proposed, count: 1},
+		{source: repoDir, count: 3}, // Its .beads directory
+		{source: "HEAD~1", count: 3, fromGit: true},
+		{source: "HEAD:proposed.jsonl", count: 1, fromGit: true},
+	}
+	for _, tt := range tests {
+		issues, sha, err := g.LoadSource(tt.source)
+		if err != nil {
+			t.Errorf("LoadSource(%q): %v", tt.source, err)
+			continue
+		}
+		if len(issues) != tt.count || (sha != "") != tt.fromGit {
+			t.Errorf("LoadSource(%q) = %d issues, sha %q; want %d issues, from git %v", tt.source, len(issues), sha, tt.count, tt.fromGit)
+		}
+	}
+
+	if _, _, err := g.LoadSource("no-such-ref"); err == nil {
+		t.Error("an unknown source should be an error")
+	}
+}
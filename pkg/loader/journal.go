@@ -0,0 +1,201 @@
+package loader
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// JournalFilename is the undo journal's file name inside a project's .bv directory.
+const JournalFilename = "undo.jsonl"
+
+// DefaultJournalLimit is how many edits a journal keeps when no limit is given.
+const DefaultJournalLimit = 50
+
+var (
+	// ErrNothingToUndo is returned by Journal.Undo when no edit is left to revert.
+	ErrNothingToUndo = errors.New("nothing to undo")
+	// ErrNothingToRedo is returned by Journal.Redo when no undone edit remains.
+	ErrNothingToRedo = errors.New("nothing to redo")
+)
+
+// DefaultJournalPath returns the default undo journal path for a project.
+func DefaultJournalPath(projectDir string) string {
+	return filepath.Join(projectDir, ".bv", JournalFilename)
+}
+
+// JournalEntry is one recorded write-back.
+type JournalEntry struct {
+	Time   time.Time `json:"time"`
+	File   string    `json:"file"`             // Issues file the edit was applied to
+	Action string    `json:"action"`           // Human-readable summary
+	Undone bool      `json:"undone,omitempty"` // Reverted and waiting to be redone
+	LineEdit
+}
+
+// Journal is a persistent undo/redo stack of write-backs. Entries are kept
+// oldest first; undone entries always form a suffix, so the newest applied
+// entry is the next to undo and the oldest undone one is the next to redo.
+// Every change rewrites the journal file, which lets undo survive restarts.
+type Journal struct {
+	path    string
+	limit   int
+	entries []JournalEntry
+}
+
+// OpenJournal loads the journal at path. A missing file yields an empty
+// journal; limit <= 0 means DefaultJournalLimit.
+func OpenJournal(path string, limit int) (*Journal, error) {
+	if limit <= 0 {
+		limit = DefaultJournalLimit
+	}
+	j := &Journal{path: path, limit: limit}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return j, nil
+	}
+	if err != nil {
+		return j, fmt.Errorf("failed to read undo journal: %w", err)
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		var e JournalEntry
+		if err := json.Unmarshal(line, &e); err != nil || e.IssueID == "" || e.File == "" {
+			continue // A damaged line only loses that one step
+		}
+		j.entries = append(j.entries, e)
+	}
+	if err := scanner.Err(); err != nil {
+		return j, fmt.Errorf("failed to read undo journal: %w", err)
+	}
+	j.normalize()
+	return j, nil
+}
+
+// Path returns the journal's file path.
+func (j *Journal) Path() string {
+	return j.path
+}
+
+// UndoDepth returns how many edits can be undone.
+func (j *Journal) UndoDepth() int {
+	n := 0
+	for _, e := range j.entries {
+		if !e.Undone {
+			n++
+		}
+	}
+	return n
+}
+
+// RedoDepth returns how many undone edits can be redone.
+func (j *Journal) RedoDepth() int {
+	return len(j.entries) - j.UndoDepth()
+}
+
+// Record appends an applied edit to file. Like any editor, a new edit
+// discards whatever was waiting to be redone.
+func (j *Journal) Record(file, action string, edit *LineEdit) error {
+	if edit == nil {
+		return nil
+	}
+	if abs, err := filepath.Abs(file); err == nil {
+		file = abs
+	}
+	j.entries = j.entries[:j.UndoDepth()]
+	j.entries = append(j.entries, JournalEntry{
+		Time:     time.Now().UTC(),
+		File:     file,
+		Action:   action,
+		LineEdit: *edit,
+	})
+	j.normalize()
+	return j.save()
+}
+
+// Undo reverts the most recent applied edit. If the issue has changed since,
+// the entry is dropped and an error wrapping ErrEditConflict is returned.
+func (j *Journal) Undo() (JournalEntry, error) {
+	i := j.UndoDepth() - 1
+	if i < 0 {
+		return JournalEntry{}, ErrNothingToUndo
+	}
+	e := j.entries[i]
+	if err := ReplaceIssueLine(e.File, e.IssueID, e.After, e.Before); err != nil {
+		return e, j.drop(i, err)
+	}
+	j.entries[i].Undone = true
+	return j.entries[i], j.save()
+}
+
+// Redo re-applies the most recently undone edit, with the same conflict
+// handling as Undo.
+func (j *Journal) Redo() (JournalEntry, error) {
+	i := j.UndoDepth()
+	if i >= len(j.entries) {
+		return JournalEntry{}, ErrNothingToRedo
+	}
+	e := j.entries[i]
+	if err := ReplaceIssueLine(e.File, e.IssueID, e.Before, e.After); err != nil {
+		return e, j.drop(i, err)
+	}
+	j.entries[i].Undone = false
+	return j.entries[i], j.save()
+}
+
+// drop removes an entry that can no longer be applied so it does not block
+// the rest of the stack, and returns cause (or a save error).
+func (j *Journal) drop(i int, cause error) error {
+	if !errors.Is(cause, ErrEditConflict) {
+		return cause
+	}
+	j.entries = append(j.entries[:i], j.entries[i+1:]...)
+	if err := j.save(); err != nil {
+		return errors.Join(cause, err)
+	}
+	return cause
+}
+
+// normalize restores the undone-suffix invariant after loading a hand-edited
+// file and trims the oldest entries beyond the limit.
+func (j *Journal) normalize() {
+	applied := make([]JournalEntry, 0, len(j.entries))
+	var undone []JournalEntry
+	for _, e := range j.entries {
+		if e.Undone {
+			undone = append(undone, e)
+		} else {
+			applied = append(applied, e)
+		}
+	}
+	j.entries = append(applied, undone...)
+	if extra := len(j.entries) - j.limit; extra > 0 {
+		j.entries = j.entries[extra:]
+	}
+}
+
+func (j *Journal) save() error {
+	if err := os.MkdirAll(filepath.Dir(j.path), 0o755); err != nil {
+		return fmt.Errorf("failed to create journal directory: %w", err)
+	}
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for _, e := range j.entries {
+		if err := enc.Encode(e); err != nil {
+			return fmt.Errorf("failed to encode journal entry: %w", err)
+		}
+	}
+	return writeFileAtomic(j.path, buf.Bytes())
+}
@@ -0,0 +1,188 @@
+package loader_test
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func writeJournalIssues(t *testing.T, dir string) string {
+	t.Helper()
+	path := filepath.Join(dir, ".beads", "issues.jsonl")
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	content := `{"id":"A","title":"Alpha","status":"open","issue_type":"task","dependencies":[{"issue_id":"A","depends_on_id":"B","type":"blocks"}]}` + "\n" +
+		`{"id":"B","title":"Beta","status":"open","issue_type":"task"}` + "\n"
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestJournal_UndoRedoSurvivesReopen(t *testing.T) {
+	dir := t.TempDir()
+	issues := writeJournalIssues(t, dir)
+	original := readFile(t, issues)
+	journalPath := loader.DefaultJournalPath(dir)
+
+	j, err := loader.OpenJournal(journalPath, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := j.Undo(); !errors.Is(err, loader.ErrNothingToUndo) {
+		t.Fatalf("empty journal Undo err = %v", err)
+	}
+
+	edit, err := loader.RemoveDependency(issues, "A", "B")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := j.Record(issues, "Removed A → B", edit); err != nil {
+		t.Fatal(err)
+	}
+	edit, err = loader.AddComment(issues, "B", model.Comment{Author: "bob", Text: "hi"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := j.Record(issues, "Comment on B", edit); err != nil {
+		t.Fatal(err)
+	}
+	afterBoth := readFile(t, issues)
+
+	// A fresh process sees the same history
+	j, err = loader.OpenJournal(journalPath, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if j.UndoDepth() != 2 || j.RedoDepth() != 0 {
+		t.Fatalf("depth after reopen = %d/%d", j.UndoDepth(), j.RedoDepth())
+	}
+
+	e, err := j.Undo()
+	if err != nil || e.Action != "Comment on B" {
+		t.Fatalf("Undo = %+v, %v", e, err)
+	}
+	if e, err = j.Undo(); err != nil || e.Action != "Removed A → B" {
+		t.Fatalf("second Undo = %+v, %v", e, err)
+	}
+	if got := readFile(t, issues); got != original {
+		t.Errorf("undoing everything should restore the file:\n%s\nwant:\n%s", got, original)
+	}
+
+	j, _ = loader.OpenJournal(journalPath, 0)
+	if j.RedoDepth() != 2 {
+		t.Fatalf("redo depth after reopen = %d", j.RedoDepth())
+	}
+	if e, err = j.Redo(); err != nil || e.Action != "Removed A → B" {
+		t.Fatalf("Redo = %+v, %v", e, err)
+	}
+	if e, err = j.Redo(); err != nil || e.Action != "Comment on B" {
+		t.Fatalf("second Redo = %+v, %v", e, err)
+	}
+	if got := readFile(t, issues); got != afterBoth {
+		t.Errorf("redoing everything should match the edited file:\n%s", got)
+	}
+	if _, err := j.Redo(); !errors.Is(err, loader.ErrNothingToRedo) {
+		t.Errorf("Redo past the end err = %v", err)
+	}
+}
+
+func TestJournal_NewEditClearsRedo(t *testing.T) {
+	dir := t.TempDir()
+	issues := writeJournalIssues(t, dir)
+	j, _ := loader.OpenJournal(loader.DefaultJournalPath(dir), 0)
+
+	edit, _ := loader.AddComment(issues, "A", model.Comment{Text: "one"})
+	_ = j.Record(issues, "one", edit)
+	if _, err := j.Undo(); err != nil {
+		t.Fatal(err)
+	}
+	edit, _ = loader.AddComment(issues, "A", model.Comment{Text: "two"})
+	_ = j.Record(issues, "two", edit)
+
+	if j.UndoDepth() != 1 || j.RedoDepth() != 0 {
+		t.Errorf("depth = %d/%d, want 1/0", j.UndoDepth(), j.RedoDepth())
+	}
+}
+
+func TestJournal_ConflictDropsEntry(t *testing.T) {
+	dir := t.TempDir()
+	issues := writeJournalIssues(t, dir)
+	j, _ := loader.OpenJournal(loader.DefaultJournalPath(dir), 0)
+
+	edit, _ := loader.AddComment(issues, "B", model.Comment{Text: "first"})
+	_ = j.Record(issues, "first", edit)
+	edit, _ = loader.AddComment(issues, "A", model.Comment{Text: "second"})
+	_ = j.Record(issues, "second", edit)
+
+	// Someone else edits A outside bv
+	if _, err := loader.AddComment(issues, "A", model.Comment{Text: "external"}); err != nil {
+		t.Fatal(err)
+	}
+	before := readFile(t, issues)
+
+	e, err := j.Undo()
+	if !errors.Is(err, loader.ErrEditConflict) || e.Action != "second" {
+		t.Fatalf("Undo = %+v, %v; want conflict on second", e, err)
+	}
+	if readFile(t, issues) != before {
+		t.Error("conflicting undo must not touch the file")
+	}
+	if e, err := j.Undo(); err != nil || e.Action != "first" {
+		t.Errorf("older edit should still undo, got %+v, %v", e, err)
+	}
+}
+
+func TestJournal_LimitAndDamagedLines(t *testing.T) {
+	dir := t.TempDir()
+	issues := writeJournalIssues(t, dir)
+	journalPath := loader.DefaultJournalPath(dir)
+	j, _ := loader.OpenJournal(journalPath, 20)
+
+	for i := 0; i < 25; i++ {
+		edit, err := loader.AddComment(issues, "B", model.Comment{Text: fmt.Sprint(i)})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := j.Record(issues, fmt.Sprint(i), edit); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if j.UndoDepth() != 20 {
+		t.Errorf("UndoDepth = %d, want 20", j.UndoDepth())
+	}
+
+	f, err := os.OpenFile(journalPath, os.O_APPEND|os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = f.WriteString("{not json\n")
+	_ = f.Close()
+
+	j, err = loader.OpenJournal(journalPath, 20)
+	if err != nil || j.UndoDepth() != 20 {
+		t.Fatalf("reopen with damaged line: depth %d, err %v", j.UndoDepth(), err)
+	}
+	if e, _ := j.Undo(); e.Action != "24" {
+		t.Errorf("newest entry = %q, want 24", e.Action)
+	}
+	if !strings.HasSuffix(j.Path(), filepath.Join(".bv", loader.JournalFilename)) {
+		t.Errorf("Path = %s", j.Path())
+	}
+}
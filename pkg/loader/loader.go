@@ -3,19 +3,33 @@ package loader
 import (
 	"bufio"
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
+	"io/fs"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 
+	"github.com/Dicklesworthstone/beads_viewer/pkg/labels"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/tracing"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 // BeadsDirEnvVar is the name of the environment variable for custom beads directory
 const BeadsDirEnvVar = "BEADS_DIR"
 
+// notFoundError reports missing beads data; it matches fs.ErrNotExist so
+// callers can tell it apart from unreadable data with errors.Is
+type notFoundError string
+
+func (e notFoundError) Error() string { return string(e) }
+
+func (e notFoundError) Is(target error) bool { return target == fs.ErrNotExist }
+
 // PreferredJSONLNames defines the priority order for looking up beads data files.
 var PreferredJSONLNames = []string{"issues.jsonl", "beads.jsonl", "beads.base.jsonl"}
 
@@ -93,7 +107,7 @@ func FindJSONLPathWithWarnings(beadsDir string, warnFunc func(msg string)) (stri
 	}
 
 	if len(candidates) == 0 {
-		return "", fmt.Errorf("no beads JSONL file found in %s", beadsDir)
+		return "", notFoundError("no beads JSONL file found in " + beadsDir)
 	}
 
 	// Priority order for beads files per beads upstream:
@@ -157,10 +171,35 @@ type ParseOptions struct {
 	// Lines longer than this are skipped with a warning.
 	// If 0, uses DefaultMaxBufferSize (10MB).
 	BufferSize int
+
+	// LineErrorHandler, if set, receives a structured record for every line
+	// that was skipped. It is called in addition to WarningHandler.
+	LineErrorHandler func(LineError)
+
+	// Labels rewrites labels to their canonical names (.bv/labels.yaml).
+	// When nil, LoadIssuesFromFileWithOptions looks for the project's config
+	// next to the beads directory.
+	Labels *labels.Config
+
+	// RawLabels keeps labels as written, skipping that lookup
+	RawLabels bool
+}
+
+// LineError describes a JSONL line that could not be loaded.
+type LineError struct {
+	Line    int    `json:"line"`
+	Kind    string `json:"kind"` // too_long, malformed_json, invalid_issue
+	Message string `json:"message"`
 }
 
 // LoadIssuesFromFileWithOptions reads issues from a file with custom options.
-func LoadIssuesFromFileWithOptions(path string, opts ParseOptions) ([]model.Issue, error) {
+func LoadIssuesFromFileWithOptions(path string, opts ParseOptions) (issues []model.Issue, err error) {
+	_, span := tracing.Start(context.Background(), "loader.load_issues", attribute.String("bv.path", path))
+	defer func() {
+		span.SetAttributes(attribute.Int("bv.issues", len(issues)))
+		tracing.End(span, err)
+	}()
+
 	// Check if file exists
 	if _, err := os.Stat(path); os.IsNotExist(err) {
 		return nil, fmt.Errorf("no beads issues found at %s", path)
@@ -172,6 +211,9 @@ func LoadIssuesFromFileWithOptions(path string, opts ParseOptions) ([]model.Issu
 	}
 	defer file.Close()
 
+	if opts.Labels == nil && !opts.RawLabels {
+		opts.Labels = loadLabelConfig(ProjectDirForJSONL(path), warningHandler(opts))
+	}
 	return ParseIssuesWithOptions(file, opts)
 }
 
@@ -198,15 +240,12 @@ func ParseIssuesWithOptions(r io.Reader, opts ParseOptions) ([]model.Issue, erro
 
 	reader := bufio.NewReaderSize(r, maxCapacity)
 
-	// Default warning handler prints to stderr (suppressed in robot mode).
-	warn := opts.WarningHandler
-	if warn == nil {
-		if os.Getenv("BV_ROBOT") == "1" {
-			warn = func(string) {}
-		} else {
-			warn = func(msg string) {
-				fmt.Fprintf(os.Stderr, "Warning: %s\n", msg)
-			}
+	warn := warningHandler(opts)
+
+	report := func(line int, kind, msg string) {
+		warn(msg)
+		if opts.LineErrorHandler != nil {
+			opts.LineErrorHandler(LineError{Line: line, Kind: kind, Message: msg})
 		}
 	}
 
@@ -226,7 +265,7 @@ func ParseIssuesWithOptions(r io.Reader, opts ParseOptions) ([]model.Issue, erro
 
 		if isPrefix {
 			// Line too long. Discard the rest of the line.
-			warn(fmt.Sprintf("skipping line %d: line too long (exceeds %d bytes)", lineNum, maxCapacity))
+			report(lineNum, "too_long", fmt.Sprintf("skipping line %d: line too long (exceeds %d bytes)", lineNum, maxCapacity))
 			for isPrefix {
 				_, isPrefix, err = reader.ReadLine()
 				if err != nil && err != io.EOF {
@@ -251,23 +290,116 @@ func ParseIssuesWithOptions(r io.Reader, opts ParseOptions) ([]model.Issue, erro
 		var issue model.Issue
 		if err := json.Unmarshal(line, &issue); err != nil {
 			// Skip malformed lines but warn
-			warn(fmt.Sprintf("skipping malformed JSON on line %d: %v", lineNum, err))
+			report(lineNum, "malformed_json", fmt.Sprintf("skipping malformed JSON on line %d: %v", lineNum, err))
 			continue
 		}
 
+		// Custom review statuses (review, needs_review, ...) load as in_review
+		issue.Status = issue.Status.Normalize()
+
 		// Validate issue
 		if err := issue.Validate(); err != nil {
 			// Skip invalid issues
-			warn(fmt.Sprintf("skipping invalid issue on line %d: %v", lineNum, err))
+			report(lineNum, "invalid_issue", fmt.Sprintf("skipping invalid issue on line %d: %v", lineNum, err))
 			continue
 		}
 
+		normalizeEvents(&issue)
+		if !opts.RawLabels {
+			issue.Labels = opts.Labels.Normalize(issue.Labels)
+		}
 		issues = append(issues, issue)
 	}
 
 	return issues, nil
 }
 
+// warningHandler returns opts.WarningHandler, defaulting to printing to
+// stderr (suppressed in robot mode)
+func warningHandler(opts ParseOptions) func(string) {
+	if opts.WarningHandler != nil {
+		return opts.WarningHandler
+	}
+	if os.Getenv("BV_ROBOT") == "1" {
+		return func(string) {}
+	}
+	return func(msg string) {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", msg)
+	}
+}
+
+// ProjectDirForJSONL returns the project a beads JSONL file belongs to: the
+// directory holding its beads directory, where .bv/ lives
+func ProjectDirForJSONL(path string) string {
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+	return filepath.Dir(filepath.Dir(path))
+}
+
+// loadLabelConfig reads the project's .bv/labels.yaml, warning and carrying
+// on without it if it is invalid
+func loadLabelConfig(projectDir string, warn func(string)) *labels.Config {
+	cfg, err := labels.Load(projectDir)
+	if err != nil {
+		warn(fmt.Sprintf("ignoring %s: %v", labels.Path(projectDir), err))
+		return nil
+	}
+	return cfg
+}
+
+// normalizeEvents turns the raw audit trail beads exports into a clean,
+// chronological timeline: nil entries are dropped, IssueID is filled in,
+// event types are lowercased, and status values stored as JSON (a quoted
+// string or an object with a "status" field) are reduced to the bare status.
+func normalizeEvents(issue *model.Issue) {
+	if len(issue.Events) == 0 {
+		return
+	}
+	events := issue.Events[:0]
+	for _, e := range issue.Events {
+		if e == nil {
+			continue
+		}
+		if e.IssueID == "" {
+			e.IssueID = issue.ID
+		}
+		e.EventType = model.IssueEventType(strings.ToLower(strings.TrimSpace(string(e.EventType))))
+		e.OldValue = eventStatusValue(e.OldValue)
+		e.NewValue = eventStatusValue(e.NewValue)
+		events = append(events, e)
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].CreatedAt.Before(events[j].CreatedAt)
+	})
+	issue.Events = events
+}
+
+// eventStatusValue extracts a status from an event old/new value.
+// Values that are neither plain text nor a status-bearing JSON form are
+// returned unchanged.
+func eventStatusValue(v string) string {
+	v = strings.TrimSpace(v)
+	if v == "" {
+		return ""
+	}
+	switch v[0] {
+	case '"':
+		var str string
+		if json.Unmarshal([]byte(v), &str) == nil {
+			return str
+		}
+	case '{':
+		var obj struct {
+			Status *string `json:"status"`
+		}
+		if json.Unmarshal([]byte(v), &obj) == nil && obj.Status != nil {
+			return *obj.Status
+		}
+	}
+	return v
+}
+
 // stripBOM removes the UTF-8 Byte Order Mark if present
 func stripBOM(b []byte) []byte {
 	if bytes.HasPrefix(b, []byte{0xEF, 0xBB, 0xBF}) {
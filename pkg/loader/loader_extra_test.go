@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
 )
 
 func TestParseIssuesWithOptions_LineTooLong(t *testing.T) {
@@ -51,3 +52,89 @@ func TestParseIssuesWithOptions_LineTooLong(t *testing.T) {
 		t.Errorf("Expected warning containing %q, got: %v", expectedWarning, warnings)
 	}
 }
+
+func TestParseIssuesWithOptions_LineErrorHandler(t *testing.T) {
+	input := `{"id":"A","title":"Alpha","status":"open","issue_type":"task"}
+{not json}
+{"id":"","title":"Missing ID","status":"open","issue_type":"task"}
+`
+	var lineErrs []loader.LineError
+	opts := loader.ParseOptions{
+		WarningHandler:   func(string) {},
+		LineErrorHandler: func(le loader.LineError) { lineErrs = append(lineErrs, le) },
+	}
+
+	issues, err := loader.ParseIssuesWithOptions(strings.NewReader(input), opts)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(issues) != 1 {
+		t.Fatalf("expected 1 issue, got %d", len(issues))
+	}
+	if len(lineErrs) != 2 {
+		t.Fatalf("expected 2 line errors, got %d: %+v", len(lineErrs), lineErrs)
+	}
+	if lineErrs[0].Line != 2 || lineErrs[0].Kind != "malformed_json" {
+		t.Errorf("first line error = %+v, want line 2 malformed_json", lineErrs[0])
+	}
+	if lineErrs[1].Line != 3 || lineErrs[1].Kind != "invalid_issue" {
+		t.Errorf("second line error = %+v, want line 3 invalid_issue", lineErrs[1])
+	}
+}
+
+func TestParseIssues_NormalizesEvents(t *testing.T) {
+	input := `{"id":"bv-1","title":"T","status":"closed","issue_type":"task","events":[` +
+		`{"event_type":"closed","created_at":"2025-01-05T00:00:00Z"},` +
+		`{"event_type":"Status_Changed","old_value":"{\"status\":\"open\"}","new_value":"{\"status\":\"in_progress\",\"assignee\":\"a\"}","created_at":"2025-01-02T00:00:00Z"},` +
+		`null,` +
+		`{"event_type":"created","actor":"alice","created_at":"2025-01-01T00:00:00Z"},` +
+		`{"event_type":"status_changed","new_value":"\"in_review\"","created_at":"2025-01-04T00:00:00Z"}` +
+		`]}`
+
+	issues, err := loader.ParseIssues(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ParseIssues: %v", err)
+	}
+	if len(issues) != 1 {
+		t.Fatalf("expected 1 issue, got %d", len(issues))
+	}
+	events := issues[0].Events
+	if len(events) != 4 {
+		t.Fatalf("expected 4 events (nil dropped), got %d", len(events))
+	}
+
+	wantTypes := []model.IssueEventType{model.EventCreated, model.EventStatusChanged, model.EventStatusChanged, model.EventClosed}
+	for i, e := range events {
+		if e.EventType != wantTypes[i] {
+			t.Errorf("event %d type = %q, want %q", i, e.EventType, wantTypes[i])
+		}
+		if e.IssueID != "bv-1" {
+			t.Errorf("event %d IssueID = %q, want bv-1", i, e.IssueID)
+		}
+	}
+	if events[1].OldValue != "open" || events[1].NewValue != "in_progress" {
+		t.Errorf("JSON object values not reduced: %q -> %q", events[1].OldValue, events[1].NewValue)
+	}
+	if events[2].NewValue != "in_review" {
+		t.Errorf("quoted value not unquoted: %q", events[2].NewValue)
+	}
+}
+
+func TestParseIssues_NormalizesReviewStatus(t *testing.T) {
+	input := `{"id":"bv-1","title":"A","status":"in_review","issue_type":"task"}` + "\n" +
+		`{"id":"bv-2","title":"B","status":"needs_review","issue_type":"task"}` + "\n" +
+		`{"id":"bv-3","title":"C","status":"waiting","issue_type":"task"}` + "\n"
+
+	issues, err := loader.ParseIssues(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ParseIssues: %v", err)
+	}
+	if len(issues) != 2 {
+		t.Fatalf("expected the two review issues (unknown status skipped), got %d", len(issues))
+	}
+	for _, issue := range issues {
+		if issue.Status != model.StatusInReview {
+			t.Errorf("%s status = %q, want in_review", issue.ID, issue.Status)
+		}
+	}
+}
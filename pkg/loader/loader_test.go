@@ -835,3 +835,39 @@ func TestGetBeadsDir_EnvVarEmpty_FallsBack(t *testing.T) {
 		t.Errorf("Empty BEADS_DIR should fallback: got %s, want %s", result, expected)
 	}
 }
+
+func TestLoadIssues_NormalizesLabelsFromConfig(t *testing.T) {
+	dir := t.TempDir()
+	beadsDir := filepath.Join(dir, ".beads")
+	if err := os.MkdirAll(filepath.Join(dir, ".bv"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(beadsDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	config := "aliases:\n  ui: frontend\n"
+	if err := os.WriteFile(filepath.Join(dir, ".bv", "labels.yaml"), []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+	line := `{"id":"a-1","title":"Forms","status":"open","issue_type":"task","labels":["ui/forms","UI","frontend"]}` + "\n"
+	path := filepath.Join(beadsDir, "issues.jsonl")
+	if err := os.WriteFile(path, []byte(line), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	issues, err := loader.LoadIssues(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := issues[0].Labels; len(got) != 2 || got[0] != "frontend/forms" || got[1] != "frontend" {
+		t.Errorf("normalized labels = %v", got)
+	}
+
+	raw, err := loader.LoadIssuesFromFileWithOptions(path, loader.ParseOptions{RawLabels: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := raw[0].Labels; len(got) != 3 || got[0] != "ui/forms" {
+		t.Errorf("raw labels = %v", got)
+	}
+}
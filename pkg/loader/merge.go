@@ -0,0 +1,567 @@
+package loader
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Conflict markers around an issue both sides of a merge changed in
+// incompatible ways. Git recognizes them, so a file still holding one shows
+// as conflicted.
+const (
+	conflictStart = "<<<<<<< ours"
+	conflictSep   = "======="
+	conflictEnd   = ">>>>>>> theirs"
+)
+
+// WholeIssue is the conflict field name for an issue one side deleted and
+// the other changed
+const WholeIssue = "*"
+
+// MergeConflict is an issue a merge could not reconcile
+type MergeConflict struct {
+	IssueID string   `json:"issue_id"`
+	Fields  []string `json:"fields"` // Changed differently on each side, or WholeIssue
+}
+
+// MergeResult is the outcome of MergeJSONL
+type MergeResult struct {
+	Output    []byte          // Merged JSONL, conflict blocks included
+	Merged    int             // Issues changed on both sides and merged field by field
+	Conflicts []MergeConflict // Issues left in conflict blocks
+}
+
+// jsonlRecord is one issue line of a JSONL file
+type jsonlRecord struct {
+	id     string
+	raw    []byte
+	keys   []string // Field order on the line
+	fields map[string]json.RawMessage
+}
+
+// MergeJSONL three-way merges beads JSONL files by issue ID. Issues changed
+// on one side take that side's line; issues changed on both are merged field
+// by field, with labels, dependencies and comments merged as sets and the
+// later updated_at winning. Fields changed differently on both sides, and
+// issues deleted on one side but changed on the other, are left between
+// conflict markers: ours, then theirs, each with every other field merged.
+// Issues keep ours' order, followed by those only theirs added.
+func MergeJSONL(base, ours, theirs []byte) (*MergeResult, error) {
+	baseRecs, err := parseJSONLRecords(base, "base")
+	if err != nil {
+		return nil, err
+	}
+	oursRecs, err := parseJSONLRecords(ours, "ours")
+	if err != nil {
+		return nil, err
+	}
+	theirsRecs, err := parseJSONLRecords(theirs, "theirs")
+	if err != nil {
+		return nil, err
+	}
+	baseByID, theirsByID := indexRecords(baseRecs), indexRecords(theirsRecs)
+	oursByID := indexRecords(oursRecs)
+
+	result := &MergeResult{}
+	var out bytes.Buffer
+	writeLine := func(line []byte) {
+		out.Write(line)
+		out.WriteByte('\n')
+	}
+	emit := func(b, o, t *jsonlRecord) error {
+		merged, conflict, err := mergeRecord(b, o, t)
+		if err != nil {
+			return err
+		}
+		if conflict == nil {
+			if b != nil && o != nil && t != nil && !recordEqual(o, b) && !recordEqual(t, b) && !recordEqual(o, t) {
+				result.Merged++
+			}
+			if merged != nil {
+				writeLine(merged)
+			}
+			return nil
+		}
+		result.Conflicts = append(result.Conflicts, *conflict)
+		fmt.Fprintf(&out, "%s %s [%s]\n", conflictStart, conflict.IssueID, strings.Join(conflict.Fields, " "))
+		if o != nil {
+			ourSide, _, err := resolveSide(b, o, t, false)
+			if err != nil {
+				return err
+			}
+			writeLine(ourSide)
+		}
+		writeLine([]byte(conflictSep))
+		if t != nil {
+			theirSide, _, err := resolveSide(b, o, t, true)
+			if err != nil {
+				return err
+			}
+			writeLine(theirSide)
+		}
+		writeLine([]byte(conflictEnd))
+		return nil
+	}
+
+	for _, o := range oursRecs {
+		if err := emit(baseByID[o.id], o, theirsByID[o.id]); err != nil {
+			return nil, err
+		}
+	}
+	for _, t := range theirsRecs {
+		if oursByID[t.id] != nil {
+			continue
+		}
+		if err := emit(baseByID[t.id], nil, t); err != nil {
+			return nil, err
+		}
+	}
+	result.Output = out.Bytes()
+	return result, nil
+}
+
+// mergeRecord merges one issue across the three versions, any of which may
+// be missing. It returns the merged line (nil when the issue is deleted) or
+// the conflict that prevents merging.
+func mergeRecord(b, o, t *jsonlRecord) ([]byte, *MergeConflict, error) {
+	switch {
+	case o == nil && t == nil:
+		return nil, nil, nil
+	case o == nil: // Deleted in ours, or added in theirs
+		if b != nil && !recordEqual(t, b) {
+			return nil, &MergeConflict{IssueID: t.id, Fields: []string{WholeIssue}}, nil
+		}
+		if b != nil {
+			return nil, nil, nil
+		}
+		return t.raw, nil, nil
+	case t == nil:
+		if b != nil && !recordEqual(o, b) {
+			return nil, &MergeConflict{IssueID: o.id, Fields: []string{WholeIssue}}, nil
+		}
+		if b != nil {
+			return nil, nil, nil
+		}
+		return o.raw, nil, nil
+	}
+
+	// Unchanged on one side: keep the other line byte for byte
+	switch {
+	case recordEqual(o, t), b != nil && recordEqual(t, b):
+		return o.raw, nil, nil
+	case b != nil && recordEqual(o, b):
+		return t.raw, nil, nil
+	}
+
+	merged, conflicts, err := resolveSide(b, o, t, false)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(conflicts) > 0 {
+		return nil, &MergeConflict{IssueID: o.id, Fields: conflicts}, nil
+	}
+	return merged, nil, nil
+}
+
+// resolveSide merges o and t field by field, settling each conflicting field
+// for theirs or ours. It returns the record and the conflicting fields.
+func resolveSide(b, o, t *jsonlRecord, preferTheirs bool) ([]byte, []string, error) {
+	if o == nil || t == nil {
+		side := o
+		if preferTheirs {
+			side = t
+		}
+		return side.raw, nil, nil
+	}
+	var baseFields map[string]json.RawMessage
+	if b != nil {
+		baseFields = b.fields
+	}
+
+	keys := make(map[string]bool)
+	for k := range o.fields {
+		keys[k] = true
+	}
+	for k := range t.fields {
+		keys[k] = true
+	}
+	merged := make(map[string]json.RawMessage, len(keys))
+	var conflicts []string
+	for k := range keys {
+		value, ok, err := mergeField(k, baseFields[k], o.fields[k], t.fields[k])
+		if err != nil {
+			return nil, nil, fmt.Errorf("merging %s of %s: %w", k, o.id, err)
+		}
+		if !ok {
+			conflicts = append(conflicts, k)
+			value = o.fields[k]
+			if preferTheirs {
+				value = t.fields[k]
+			}
+		}
+		if value != nil {
+			merged[k] = value
+		}
+	}
+	sort.Strings(conflicts)
+
+	// Ours' field order, then fields only theirs has
+	out, err := marshalFields(append(slices.Clone(o.keys), t.keys...), merged)
+	if err != nil {
+		return nil, nil, err
+	}
+	return out, conflicts, nil
+}
+
+// mergeField three-way merges one field; nil means absent. It returns false
+// when both sides changed the field differently.
+func mergeField(name string, b, o, t json.RawMessage) (json.RawMessage, bool, error) {
+	switch {
+	case jsonEqual(o, t), jsonEqual(t, b):
+		return o, true, nil
+	case jsonEqual(o, b):
+		return t, true, nil
+	}
+
+	switch name {
+	case "updated_at":
+		var ot, tt time.Time
+		if json.Unmarshal(o, &ot) == nil && json.Unmarshal(t, &tt) == nil {
+			if tt.After(ot) {
+				return t, true, nil
+			}
+			return o, true, nil
+		}
+	case "labels":
+		return mergeSetField(b, o, t, func(raw json.RawMessage) string { return string(raw) })
+	case "dependencies":
+		return mergeSetField(b, o, t, func(raw json.RawMessage) string {
+			var d struct {
+				DependsOnID string `json:"depends_on_id"`
+				Type        string `json:"type"`
+			}
+			_ = json.Unmarshal(raw, &d)
+			return d.DependsOnID + "\x00" + d.Type
+		})
+	case "comments":
+		return mergeSetField(b, o, t, func(raw json.RawMessage) string {
+			var c struct {
+				ID        int64  `json:"id"`
+				Author    string `json:"author"`
+				Text      string `json:"text"`
+				CreatedAt string `json:"created_at"`
+			}
+			_ = json.Unmarshal(raw, &c)
+			return fmt.Sprintf("%d\x00%s\x00%s\x00%s", c.ID, c.Author, c.CreatedAt, c.Text)
+		})
+	}
+	return nil, false, nil
+}
+
+// mergeSetField merges JSON arrays as sets of elements identified by key:
+// an element either side added is kept, one either side removed is dropped.
+// Ours' order comes first. Elements with the same key but different content
+// on each side conflict.
+func mergeSetField(b, o, t json.RawMessage, key func(json.RawMessage) string) (json.RawMessage, bool, error) {
+	var baseItems, ourItems, theirItems []json.RawMessage
+	for _, side := range []struct {
+		raw   json.RawMessage
+		items *[]json.RawMessage
+	}{{b, &baseItems}, {o, &ourItems}, {t, &theirItems}} {
+		if len(side.raw) == 0 || string(side.raw) == "null" {
+			continue
+		}
+		if err := json.Unmarshal(side.raw, side.items); err != nil {
+			return nil, false, err
+		}
+	}
+
+	byKey := func(items []json.RawMessage) map[string]json.RawMessage {
+		m := make(map[string]json.RawMessage, len(items))
+		for _, item := range items {
+			m[key(item)] = item
+		}
+		return m
+	}
+	inBase, inOurs, inTheirs := byKey(baseItems), byKey(ourItems), byKey(theirItems)
+
+	merged := []json.RawMessage{}
+	for _, item := range ourItems {
+		k := key(item)
+		theirs, both := inTheirs[k]
+		_, wasBase := inBase[k]
+		switch {
+		case both && !jsonEqual(item, theirs):
+			merged = append(merged, item)
+			if !jsonEqual(inBase[k], theirs) {
+				if !jsonEqual(inBase[k], item) {
+					return nil, false, nil
+				}
+				merged[len(merged)-1] = theirs
+			}
+		case both || !wasBase:
+			merged = append(merged, item) // Kept by both, or added by ours
+		}
+		// In base and ours only: theirs removed it
+	}
+	for _, item := range theirItems {
+		k := key(item)
+		if _, ok := inOurs[k]; ok {
+			continue
+		}
+		if _, wasBase := inBase[k]; !wasBase {
+			merged = append(merged, item) // Added by theirs
+		}
+	}
+	if len(merged) == 0 && (len(o) == 0 || len(t) == 0) {
+		return nil, true, nil // Absent on a side that removed everything
+	}
+	out, err := json.Marshal(merged)
+	if err != nil {
+		return nil, false, err
+	}
+	return out, true, nil
+}
+
+// parseJSONLRecords reads the issue lines of a JSONL file
+func parseJSONLRecords(data []byte, side string) ([]*jsonlRecord, error) {
+	var records []*jsonlRecord
+	for i, line := range bytes.Split(data, []byte("\n")) {
+		line = bytes.TrimRight(line, "\r")
+		if i == 0 {
+			line = stripBOM(line)
+		}
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+		rec := &jsonlRecord{raw: append([]byte(nil), line...)}
+		if err := json.Unmarshal(line, &rec.fields); err != nil {
+			return nil, fmt.Errorf("%s line %d: %w", side, i+1, err)
+		}
+		if err := json.Unmarshal(rec.fields["id"], &rec.id); err != nil || rec.id == "" {
+			return nil, fmt.Errorf("%s line %d: issue without an id", side, i+1)
+		}
+		rec.keys, _ = jsonKeys(line)
+		records = append(records, rec)
+	}
+	return records, nil
+}
+
+func indexRecords(records []*jsonlRecord) map[string]*jsonlRecord {
+	m := make(map[string]*jsonlRecord, len(records))
+	for _, r := range records {
+		m[r.id] = r
+	}
+	return m
+}
+
+// recordEqual compares two issue records as JSON values
+func recordEqual(a, b *jsonlRecord) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	if len(a.fields) != len(b.fields) {
+		return false
+	}
+	for k, v := range a.fields {
+		if w, ok := b.fields[k]; !ok || !jsonEqual(v, w) {
+			return false
+		}
+	}
+	return true
+}
+
+// jsonEqual compares two JSON values, nil meaning absent
+func jsonEqual(a, b json.RawMessage) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	if bytes.Equal(a, b) {
+		return true
+	}
+	var av, bv any
+	if json.Unmarshal(a, &av) != nil || json.Unmarshal(b, &bv) != nil {
+		return false
+	}
+	return reflect.DeepEqual(av, bv)
+}
+
+// ConflictBlock is one issue left between conflict markers by MergeJSONL
+type ConflictBlock struct {
+	IssueID string
+	Ours    json.RawMessage // nil when ours deleted the issue
+	Theirs  json.RawMessage // nil when theirs deleted the issue
+	Fields  []string        // Fields that differ, or WholeIssue
+}
+
+// Resolve builds the record that takes the fields in useTheirs from theirs
+// and every other field from ours. For a WholeIssue conflict it returns the
+// chosen side, nil meaning the issue is deleted.
+func (c ConflictBlock) Resolve(useTheirs map[string]bool) (json.RawMessage, error) {
+	if c.Ours == nil || c.Theirs == nil {
+		if useTheirs[WholeIssue] {
+			return c.Theirs, nil
+		}
+		return c.Ours, nil
+	}
+	var ours, theirs map[string]json.RawMessage
+	if err := json.Unmarshal(c.Ours, &ours); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(c.Theirs, &theirs); err != nil {
+		return nil, err
+	}
+	for _, field := range c.Fields {
+		if !useTheirs[field] {
+			continue
+		}
+		if value, ok := theirs[field]; ok {
+			ours[field] = value
+		} else {
+			delete(ours, field)
+		}
+	}
+	oursKeys, _ := jsonKeys(c.Ours)
+	theirsKeys, _ := jsonKeys(c.Theirs)
+	return marshalFields(append(oursKeys, theirsKeys...), ours)
+}
+
+// ParseConflictBlocks finds the conflict blocks in a JSONL file
+func ParseConflictBlocks(data []byte) ([]ConflictBlock, error) {
+	var blocks []ConflictBlock
+	err := scanConflictBlocks(data, func(block ConflictBlock) []byte {
+		blocks = append(blocks, block)
+		return nil
+	})
+	return blocks, err
+}
+
+// ResolveConflictsInFile replaces the conflict blocks of the JSONL file at
+// path with resolved, in order (nil deletes the issue), with an atomic write.
+// It fails if the file's blocks no longer match.
+func ResolveConflictsInFile(path string, resolved []json.RawMessage) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read issues file: %w", err)
+	}
+	n := 0
+	var lines [][]byte
+	err = scanConflictBlocks(data, func(ConflictBlock) []byte {
+		n++
+		if n > len(resolved) {
+			return nil
+		}
+		return resolved[n-1]
+	}, func(line []byte) { lines = append(lines, line) })
+	if err != nil {
+		return err
+	}
+	if n != len(resolved) {
+		return fmt.Errorf("%s has %d conflicts, %d resolutions: %w", path, n, len(resolved), ErrEditConflict)
+	}
+	var out bytes.Buffer
+	for _, line := range lines {
+		out.Write(line)
+		out.WriteByte('\n')
+	}
+	return writeFileAtomic(path, out.Bytes())
+}
+
+// scanConflictBlocks walks a JSONL file, calling onBlock for each conflict
+// block, whether written by MergeJSONL or by git's line merge, and emitting (to the optional emit) every other line and each
+// block's replacement, when not nil
+func scanConflictBlocks(data []byte, onBlock func(ConflictBlock) []byte, emit ...func([]byte)) error {
+	out := func(line []byte) {
+		for _, e := range emit {
+			e(line)
+		}
+	}
+	lines := bytes.Split(bytes.TrimRight(data, "\n"), []byte("\n"))
+	for i := 0; i < len(lines); i++ {
+		line := bytes.TrimRight(lines[i], "\r")
+		if !bytes.HasPrefix(line, []byte(conflictStart[:7])) {
+			out(line)
+			continue
+		}
+
+		start := i + 1
+		block := ConflictBlock{}
+		header := strings.TrimPrefix(string(line), conflictStart+" ")
+		if id, fields, ok := strings.Cut(header, " ["); ok && len(header) < len(line) {
+			block.IssueID = id
+			block.Fields = strings.Fields(strings.TrimSuffix(fields, "]"))
+		}
+		var base json.RawMessage // diff3 style base section, ignored
+		side := &block.Ours
+		for i++; ; i++ {
+			if i >= len(lines) {
+				return fmt.Errorf("line %d: conflict block without an end marker", start)
+			}
+			l := bytes.TrimRight(lines[i], "\r")
+			switch {
+			case bytes.Equal(l, []byte(conflictSep)):
+				side = &block.Theirs
+				continue
+			case bytes.HasPrefix(l, []byte("|||||||")):
+				side = &base
+				continue
+			case side == &base:
+				continue
+			case bytes.HasPrefix(l, []byte(conflictEnd[:7])):
+			case len(bytes.TrimSpace(l)) == 0:
+				continue
+			default:
+				if *side != nil {
+					return fmt.Errorf("line %d: more than one record on a side of a conflict", i+1)
+				}
+				*side = append(json.RawMessage(nil), l...)
+				continue
+			}
+			break
+		}
+		if block.IssueID == "" || len(block.Fields) == 0 {
+			block.IssueID, block.Fields = describeConflict(block.Ours, block.Theirs)
+		}
+		if replacement := onBlock(block); replacement != nil {
+			out(replacement)
+		}
+	}
+	return nil
+}
+
+// describeConflict works out the issue and differing fields of a conflict
+// block written by hand or by another tool
+func describeConflict(ours, theirs json.RawMessage) (string, []string) {
+	var o, t map[string]json.RawMessage
+	_ = json.Unmarshal(ours, &o)
+	_ = json.Unmarshal(theirs, &t)
+	var id string
+	for _, rec := range []map[string]json.RawMessage{o, t} {
+		if id == "" && rec != nil {
+			_ = json.Unmarshal(rec["id"], &id)
+		}
+	}
+	if o == nil || t == nil {
+		return id, []string{WholeIssue}
+	}
+	var fields []string
+	for k := range o {
+		if !jsonEqual(o[k], t[k]) {
+			fields = append(fields, k)
+		}
+	}
+	for k := range t {
+		if _, ok := o[k]; !ok {
+			fields = append(fields, k)
+		}
+	}
+	sort.Strings(fields)
+	return id, fields
+}
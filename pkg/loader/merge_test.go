@@ -0,0 +1,173 @@
+package loader_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
+)
+
+func TestMergeJSONL(t *testing.T) {
+	base := `{"id":"A","title":"Alpha","status":"open","priority":2,"labels":["api"],"updated_at":"2026-01-01T00:00:00Z"}
+{"id":"B","title":"Beta","status":"open","priority":1}
+{"id":"C","title":"Gamma","status":"open"}
+{"id":"D","title":"Delta","status":"open"}
+`
+	ours := `{"id":"A","title":"Alpha","status":"in_progress","priority":2,"labels":["api","infra"],"updated_at":"2026-01-02T00:00:00Z"}
+{"id":"B","title":"Beta","status":"closed","priority":1}
+{"id":"C","title":"Gamma","status":"open"}
+{"id":"D","title":"Delta","status":"blocked"}
+{"id":"E","title":"Ours new"}
+`
+	theirs := `{"id":"A","title":"Alpha v2","status":"open","priority":2,"labels":["infra-old","api"],"updated_at":"2026-01-03T00:00:00Z","x_custom":true}
+{"id":"B","title":"Beta","status":"open","priority":0}
+{"id":"D","title":"Delta","status":"open","assignee":"kim"}
+{"id":"F","title":"Theirs new"}
+`
+	result, err := loader.MergeJSONL([]byte(base), []byte(ours), []byte(theirs))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Conflicts) != 0 {
+		t.Fatalf("unexpected conflicts: %+v\n%s", result.Conflicts, result.Output)
+	}
+	if result.Merged != 3 {
+		t.Errorf("merged = %d, want 3 (A, B, D)", result.Merged)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(string(result.Output), "\n"), "\n")
+	var ids []string
+	records := make(map[string]map[string]any)
+	for _, line := range lines {
+		var rec map[string]any
+		if err := json.Unmarshal([]byte(line), &rec); err != nil {
+			t.Fatalf("bad output line %q: %v", line, err)
+		}
+		id := rec["id"].(string)
+		ids = append(ids, id)
+		records[id] = rec
+	}
+	if got := strings.Join(ids, ","); got != "A,B,D,E,F" {
+		t.Errorf("issue order = %s, want A,B,D,E,F (C deleted by theirs)", got)
+	}
+
+	a := records["A"]
+	if a["title"] != "Alpha v2" || a["status"] != "in_progress" || a["x_custom"] != true {
+		t.Errorf("A fields not merged: %v", a)
+	}
+	if a["updated_at"] != "2026-01-03T00:00:00Z" {
+		t.Errorf("A updated_at = %v, want the later one", a["updated_at"])
+	}
+	if labels, _ := json.Marshal(a["labels"]); string(labels) != `["api","infra","infra-old"]` {
+		t.Errorf("A labels = %s", labels)
+	}
+	if b := records["B"]; b["status"] != "closed" || b["priority"] != float64(0) {
+		t.Errorf("B = %v", b)
+	}
+	if d := records["D"]; d["status"] != "blocked" || d["assignee"] != "kim" {
+		t.Errorf("D = %v", d)
+	}
+	// Merged lines keep ours' field order, then fields only theirs has
+	if lines[0] != `{"id":"A","title":"Alpha v2","status":"in_progress","priority":2,"labels":["api","infra","infra-old"],"updated_at":"2026-01-03T00:00:00Z","x_custom":true}` {
+		t.Errorf("merged A reordered: %s", lines[0])
+	}
+	if lines[2] != `{"id":"D","title":"Delta","status":"blocked","assignee":"kim"}` {
+		t.Errorf("merged D reordered: %s", lines[2])
+	}
+	if lines[3] != `{"id":"E","title":"Ours new"}` {
+		t.Errorf("unchanged line rewritten: %q", lines[3])
+	}
+}
+
+func TestMergeJSONL_Conflicts(t *testing.T) {
+	base := `{"id":"A","title":"Alpha","status":"open","priority":2,"issue_type":"task"}
+{"id":"B","title":"Beta","status":"open"}
+`
+	ours := `{"id":"A","title":"Alpha","status":"closed","priority":1,"issue_type":"task"}
+{"id":"B","title":"Beta","status":"closed"}
+`
+	theirs := `{"id":"A","title":"Alpha!","status":"in_progress","priority":1,"issue_type":"task"}
+`
+	result, err := loader.MergeJSONL([]byte(base), []byte(ours), []byte(theirs))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Conflicts) != 2 {
+		t.Fatalf("conflicts = %+v, want A and B", result.Conflicts)
+	}
+	if c := result.Conflicts[0]; c.IssueID != "A" || strings.Join(c.Fields, ",") != "status" {
+		t.Errorf("A conflict = %+v, want only status", c)
+	}
+	if c := result.Conflicts[1]; c.IssueID != "B" || c.Fields[0] != loader.WholeIssue {
+		t.Errorf("B conflict = %+v, want a delete/modify conflict", c)
+	}
+	out := string(result.Output)
+	if !strings.Contains(out, "<<<<<<< ours A [status]\n") || !strings.Contains(out, ">>>>>>> theirs\n") {
+		t.Errorf("missing conflict markers:\n%s", out)
+	}
+
+	blocks, err := loader.ParseConflictBlocks(result.Output)
+	if err != nil || len(blocks) != 2 {
+		t.Fatalf("ParseConflictBlocks = %+v, %v", blocks, err)
+	}
+	if blocks[1].IssueID != "B" || blocks[1].Theirs != nil {
+		t.Errorf("B block = %+v, want theirs deleted", blocks[1])
+	}
+
+	// Both sides of A carry the auto-merged title
+	resolvedA, err := blocks[0].Resolve(map[string]bool{"status": true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var a map[string]any
+	_ = json.Unmarshal(resolvedA, &a)
+	if a["status"] != "in_progress" || a["title"] != "Alpha!" || a["priority"] != float64(1) {
+		t.Errorf("resolved A = %v", a)
+	}
+	if string(resolvedA) != `{"id":"A","title":"Alpha!","status":"in_progress","priority":1,"issue_type":"task"}` {
+		t.Errorf("resolved A reordered: %s", resolvedA)
+	}
+	if deleted, _ := blocks[1].Resolve(map[string]bool{loader.WholeIssue: true}); deleted != nil {
+		t.Errorf("taking theirs for B should delete it, got %s", deleted)
+	}
+
+	path := filepath.Join(t.TempDir(), "issues.jsonl")
+	if err := os.WriteFile(path, result.Output, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := loader.ResolveConflictsInFile(path, []json.RawMessage{resolvedA}); err == nil {
+		t.Error("too few resolutions should be rejected")
+	}
+	if err := loader.ResolveConflictsInFile(path, []json.RawMessage{resolvedA, nil}); err != nil {
+		t.Fatal(err)
+	}
+	issues, err := loader.LoadIssuesFromFile(path)
+	if err != nil || len(issues) != 1 || issues[0].Title != "Alpha!" {
+		t.Errorf("resolved file = %+v, %v", issues, err)
+	}
+}
+
+func TestParseConflictBlocks_GitMarkers(t *testing.T) {
+	data := `{"id":"A","title":"Alpha"}
+<<<<<<< HEAD
+{"id":"B","title":"Ours","status":"open"}
+||||||| base
+{"id":"B","title":"Base","status":"open"}
+=======
+{"id":"B","title":"Theirs","status":"open"}
+>>>>>>> feature
+`
+	blocks, err := loader.ParseConflictBlocks([]byte(data))
+	if err != nil || len(blocks) != 1 {
+		t.Fatalf("ParseConflictBlocks = %+v, %v", blocks, err)
+	}
+	if b := blocks[0]; b.IssueID != "B" || strings.Join(b.Fields, ",") != "title" {
+		t.Errorf("block = %+v, want B differing in title", b)
+	}
+	if _, err := loader.ParseConflictBlocks([]byte("<<<<<<< HEAD\n{}\n")); err == nil {
+		t.Error("an unterminated block should be an error")
+	}
+}
@@ -0,0 +1,264 @@
+package loader
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// GraphFixKind classifies a dependency repair
+type GraphFixKind string
+
+const (
+	// FixDangling drops a dependency on an ID that is not in the file
+	FixDangling GraphFixKind = "dangling"
+	// FixSelfEdge drops a dependency of an issue on itself
+	FixSelfEdge GraphFixKind = "self_edge"
+	// FixDuplicate drops a repeat of an earlier dependency with the same
+	// target and type
+	FixDuplicate GraphFixKind = "duplicate"
+	// FixInverted reverses a blocking edge from a closed issue to an open
+	// one: a closed issue cannot really have been blocked by open work, so
+	// the edge was most likely recorded the wrong way round
+	FixInverted GraphFixKind = "inverted"
+)
+
+// GraphFix is one dependency repair
+type GraphFix struct {
+	Kind        GraphFixKind         `json:"kind"`
+	IssueID     string               `json:"issue_id"`
+	DependsOnID string               `json:"depends_on_id"`
+	Type        model.DependencyType `json:"type,omitempty"`
+}
+
+// String describes the fix for humans
+func (f GraphFix) String() string {
+	switch f.Kind {
+	case FixDangling:
+		return fmt.Sprintf("%s: dropped dependency on missing %s", f.IssueID, f.DependsOnID)
+	case FixSelfEdge:
+		return fmt.Sprintf("%s: dropped dependency on itself", f.IssueID)
+	case FixDuplicate:
+		return fmt.Sprintf("%s: dropped duplicate dependency on %s", f.IssueID, f.DependsOnID)
+	case FixInverted:
+		return fmt.Sprintf("%s (closed) blocked by %s (open): reversed so %s depends on %s", f.IssueID, f.DependsOnID, f.DependsOnID, f.IssueID)
+	}
+	return fmt.Sprintf("%s: %s %s", f.IssueID, f.Kind, f.DependsOnID)
+}
+
+// GraphRepairOptions controls RepairGraph
+type GraphRepairOptions struct {
+	// ReverseInverted also reverses inverted edges (see FixInverted). They
+	// are always reported; this decides whether they are changed.
+	ReverseInverted bool
+	// DryRun reports the fixes without writing anything
+	DryRun bool
+	// Now stamps the backup name and reversed edges (default time.Now)
+	Now time.Time
+}
+
+// GraphRepairReport lists what RepairGraph changed (or would change)
+type GraphRepairReport struct {
+	Fixes []GraphFix `json:"fixes"`
+	// Inverted lists inverted edges left alone because ReverseInverted was off
+	Inverted      []GraphFix `json:"inverted,omitempty"`
+	IssuesChanged int        `json:"issues_changed"`
+	BackupPath    string     `json:"backup_path,omitempty"`
+}
+
+// RepairGraph fixes dependency data problems in the JSONL file at path:
+// dependencies on IDs that do not exist, self-edges and duplicate edges are
+// dropped, and with opts.ReverseInverted inverted edges are reversed. Only
+// the affected lines are rewritten. Unless opts.DryRun is set or there is
+// nothing to fix, the original file is first copied to
+// <path>.backup-<timestamp>, which FindJSONLPath ignores.
+func RepairGraph(path string, opts GraphRepairOptions) (*GraphRepairReport, error) {
+	if opts.Now.IsZero() {
+		opts.Now = time.Now()
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read issues file: %w", err)
+	}
+
+	type record struct {
+		index  int // Line index
+		id     string
+		keys   []string // Field order on the line
+		fields map[string]json.RawMessage
+		deps   []json.RawMessage
+		closed bool
+		dirty  bool
+	}
+	lines := bytes.SplitAfter(data, []byte("\n"))
+	var records []*record
+	byID := make(map[string]*record)
+	for i, raw := range lines {
+		body := bytes.TrimRight(raw, "\r\n")
+		if i == 0 {
+			body = stripBOM(body)
+		}
+		if len(bytes.TrimSpace(body)) == 0 {
+			continue
+		}
+		var fields map[string]json.RawMessage
+		if json.Unmarshal(body, &fields) != nil {
+			continue // Malformed lines are the loader's concern, not ours
+		}
+		var head struct {
+			ID     string       `json:"id"`
+			Status model.Status `json:"status"`
+		}
+		if json.Unmarshal(body, &head) != nil || head.ID == "" {
+			continue
+		}
+		keys, _ := jsonKeys(body)
+		rec := &record{index: i, id: head.ID, keys: keys, fields: fields, closed: head.Status.IsClosed()}
+		if raw, ok := fields["dependencies"]; ok && string(raw) != "null" {
+			if err := json.Unmarshal(raw, &rec.deps); err != nil {
+				return nil, fmt.Errorf("failed to parse dependencies of %s: %w", head.ID, err)
+			}
+		}
+		records = append(records, rec)
+		if _, dup := byID[head.ID]; !dup {
+			byID[head.ID] = rec
+		}
+	}
+
+	report := &GraphRepairReport{}
+	type reversal struct {
+		from string
+		dep  json.RawMessage
+	}
+	var reversals []reversal
+	for _, rec := range records {
+		seen := make(map[string]bool)
+		kept := rec.deps[:0:0]
+		for _, raw := range rec.deps {
+			var dep struct {
+				DependsOnID string               `json:"depends_on_id"`
+				Type        model.DependencyType `json:"type"`
+			}
+			if json.Unmarshal(raw, &dep) != nil {
+				kept = append(kept, raw)
+				continue
+			}
+			fix := GraphFix{IssueID: rec.id, DependsOnID: dep.DependsOnID, Type: dep.Type}
+			target, exists := byID[dep.DependsOnID]
+			key := dep.DependsOnID + "\x00" + string(normalizeDepType(dep.Type))
+			switch {
+			case dep.DependsOnID == rec.id:
+				fix.Kind = FixSelfEdge
+			case !exists:
+				fix.Kind = FixDangling
+			case seen[key]:
+				fix.Kind = FixDuplicate
+			case dep.Type.IsBlocking() && rec.closed && !target.closed:
+				fix.Kind = FixInverted
+				if !opts.ReverseInverted {
+					report.Inverted = append(report.Inverted, fix)
+					seen[key] = true
+					kept = append(kept, raw)
+					continue
+				}
+				reversals = append(reversals, reversal{from: rec.id, dep: raw})
+			default:
+				seen[key] = true
+				kept = append(kept, raw)
+				continue
+			}
+			report.Fixes = append(report.Fixes, fix)
+			rec.dirty = true
+		}
+		rec.deps = kept
+	}
+
+	// Reversed edges move to the open issue, unless it already has one
+	for _, r := range reversals {
+		var dep map[string]json.RawMessage
+		if err := json.Unmarshal(r.dep, &dep); err != nil {
+			return nil, fmt.Errorf("failed to reverse dependency of %s: %w", r.from, err)
+		}
+		keys, _ := jsonKeys(r.dep)
+		var toID string
+		_ = json.Unmarshal(dep["depends_on_id"], &toID)
+		target := byID[toID]
+		if hasDependencyOn(target.deps, r.from) {
+			continue
+		}
+		dep["issue_id"], _ = json.Marshal(toID)
+		dep["depends_on_id"], _ = json.Marshal(r.from)
+		dep["created_at"], _ = json.Marshal(opts.Now.UTC())
+		reversed, err := marshalFields(keys, dep)
+		if err != nil {
+			return nil, err
+		}
+		target.deps = append(target.deps, reversed)
+		target.dirty = true
+	}
+
+	for _, rec := range records {
+		if !rec.dirty {
+			continue
+		}
+		report.IssuesChanged++
+		if len(rec.deps) == 0 {
+			delete(rec.fields, "dependencies")
+		} else {
+			raw, err := json.Marshal(rec.deps)
+			if err != nil {
+				return nil, err
+			}
+			rec.fields["dependencies"] = raw
+		}
+		updated, err := marshalFields(rec.keys, rec.fields)
+		if err != nil {
+			return nil, err
+		}
+		raw := lines[rec.index]
+		body := bytes.TrimRight(raw, "\r\n")
+		var bom []byte
+		if rec.index == 0 && len(stripBOM(body)) < len(body) {
+			bom = body[:3]
+		}
+		lines[rec.index] = bytes.Join([][]byte{bom, updated, raw[len(body):]}, nil)
+	}
+
+	if opts.DryRun || report.IssuesChanged == 0 {
+		return report, nil
+	}
+
+	report.BackupPath = fmt.Sprintf("%s.backup-%s", path, opts.Now.UTC().Format("20060102-150405"))
+	if err := os.WriteFile(report.BackupPath, data, 0o600); err != nil {
+		return nil, fmt.Errorf("failed to write backup: %w", err)
+	}
+	if err := writeFileAtomic(path, bytes.Join(lines, nil)); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// normalizeDepType maps the legacy empty type to blocks, so the two count as
+// the same edge
+func normalizeDepType(t model.DependencyType) model.DependencyType {
+	if t == "" {
+		return model.DepBlocks
+	}
+	return t
+}
+
+func hasDependencyOn(deps []json.RawMessage, id string) bool {
+	for _, raw := range deps {
+		var dep struct {
+			DependsOnID string `json:"depends_on_id"`
+		}
+		if json.Unmarshal(raw, &dep) == nil && dep.DependsOnID == id {
+			return true
+		}
+	}
+	return false
+}
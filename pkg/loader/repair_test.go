@@ -0,0 +1,136 @@
+package loader
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+const brokenGraph = `{"id":"a","title":"A","status":"open","issue_type":"task","dependencies":[{"issue_id":"a","depends_on_id":"ghost","type":"blocks"},{"issue_id":"a","depends_on_id":"a","type":"blocks"},{"issue_id":"a","depends_on_id":"b","type":"blocks"},{"issue_id":"a","depends_on_id":"b"}]}
+{"id":"b","title":"B","status":"open","issue_type":"task"}
+{"id":"done","title":"Done","status":"closed","issue_type":"task","dependencies":[{"issue_id":"done","depends_on_id":"b","type":"blocks"},{"issue_id":"done","depends_on_id":"a","type":"related"}]}
+{"id":"ok","title":"Fine","status":"open","issue_type":"task","dependencies":[{"issue_id":"ok","depends_on_id":"done","type":"blocks"}]}
+`
+
+func writeBrokenGraph(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "issues.jsonl")
+	if err := os.WriteFile(path, []byte(brokenGraph), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func fixSummary(fixes []GraphFix) string {
+	var parts []string
+	for _, f := range fixes {
+		parts = append(parts, string(f.Kind)+":"+f.IssueID+">"+f.DependsOnID)
+	}
+	return strings.Join(parts, ",")
+}
+
+func TestRepairGraph_DryRun(t *testing.T) {
+	path := writeBrokenGraph(t)
+	report, err := RepairGraph(path, GraphRepairOptions{DryRun: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := fixSummary(report.Fixes); got != "dangling:a>ghost,self_edge:a>a,duplicate:a>b" {
+		t.Errorf("fixes = %s", got)
+	}
+	if got := fixSummary(report.Inverted); got != "inverted:done>b" {
+		t.Errorf("inverted = %s", got)
+	}
+	if report.IssuesChanged != 1 || report.BackupPath != "" {
+		t.Errorf("report = %+v", report)
+	}
+	data, _ := os.ReadFile(path)
+	if string(data) != brokenGraph {
+		t.Error("dry run must not modify the file")
+	}
+}
+
+func TestRepairGraph_Apply(t *testing.T) {
+	path := writeBrokenGraph(t)
+	now := time.Date(2025, 3, 4, 5, 6, 7, 0, time.UTC)
+	report, err := RepairGraph(path, GraphRepairOptions{Now: now})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.BackupPath != path+".backup-20250304-050607" {
+		t.Errorf("backup path = %s", report.BackupPath)
+	}
+	if backup, _ := os.ReadFile(report.BackupPath); string(backup) != brokenGraph {
+		t.Error("backup should hold the original file")
+	}
+	if found, err := FindJSONLPath(filepath.Dir(path)); err != nil || found != path {
+		t.Errorf("the backup must not be picked up as the beads file, found %s (%v)", found, err)
+	}
+
+	issues, err := LoadIssuesFromFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	a := issues[0]
+	if len(a.Dependencies) != 1 || a.Dependencies[0].DependsOnID != "b" {
+		t.Errorf("a should keep one dependency on b, got %+v", a.Dependencies)
+	}
+	// Inverted edges are left alone unless requested
+	if len(issues[2].Dependencies) != 2 {
+		t.Errorf("done dependencies = %+v", issues[2].Dependencies)
+	}
+
+	// The repaired line keeps bd's field order; every other line is untouched
+	lines := strings.Split(brokenGraph, "\n")
+	data, _ := os.ReadFile(path)
+	repaired := `{"id":"a","title":"A","status":"open","issue_type":"task","dependencies":[{"issue_id":"a","depends_on_id":"b","type":"blocks"}]}`
+	if first := strings.Split(string(data), "\n")[0]; first != repaired {
+		t.Errorf("repaired line = %s\nwant %s", first, repaired)
+	}
+	for i, line := range strings.Split(string(data), "\n") {
+		if i > 0 && line != lines[i] {
+			t.Errorf("line %d should be untouched:\n%s", i+1, line)
+		}
+	}
+
+	// Nothing left to fix: no second backup
+	again, err := RepairGraph(path, GraphRepairOptions{Now: now.Add(time.Hour)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(again.Fixes) != 0 || again.BackupPath != "" {
+		t.Errorf("second run should be a no-op, got %+v", again)
+	}
+}
+
+func TestRepairGraph_ReverseInverted(t *testing.T) {
+	path := writeBrokenGraph(t)
+	report, err := RepairGraph(path, GraphRepairOptions{ReverseInverted: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := fixSummary(report.Fixes); got != "dangling:a>ghost,self_edge:a>a,duplicate:a>b,inverted:done>b" {
+		t.Errorf("fixes = %s", got)
+	}
+	if len(report.Inverted) != 0 || report.IssuesChanged != 3 {
+		t.Errorf("report = %+v", report)
+	}
+
+	issues, err := LoadIssuesFromFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, done := issues[1], issues[2]
+	if len(b.Dependencies) != 1 || b.Dependencies[0].DependsOnID != "done" || b.Dependencies[0].IssueID != "b" {
+		t.Errorf("b should now depend on done, got %+v", b.Dependencies)
+	}
+	if len(done.Dependencies) != 1 || done.Dependencies[0].DependsOnID != "a" {
+		t.Errorf("done should keep only its related link, got %+v", done.Dependencies)
+	}
+	data, _ := os.ReadFile(path)
+	if line := strings.Split(string(data), "\n")[1]; !strings.HasPrefix(line, `{"id":"b","title":"B","status":"open","issue_type":"task","dependencies":[{"issue_id":"b","depends_on_id":"done","type":"blocks","created_at":`) {
+		t.Errorf("reversed edge should keep the field order, got %s", line)
+	}
+}
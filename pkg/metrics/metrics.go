@@ -0,0 +1,232 @@
+// Package metrics publishes project health as Prometheus metrics, either
+// served for scraping (--metrics-listen) or written for node_exporter's
+// textfile collector (--metrics-textfile).
+//
+// The exposition format is simple enough to write by hand, which keeps the
+// Prometheus client library out of the dependency tree.
+package metrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// WorkdayMinutes converts the critical path to days, matching the 8 hour
+// workday --robot-capacity uses
+const WorkdayMinutes = 8 * 60
+
+// LabelMetrics are the per-label gauges
+type LabelMetrics struct {
+	Label   string
+	Health  int // Composite label health, 0-100
+	Open    int
+	Blocked int
+}
+
+// Snapshot is one reading of the project's health
+type Snapshot struct {
+	OpenIssues       int
+	InProgressIssues int
+	BlockedIssues    int // Open issues waiting on an open blocker, or marked blocked
+	ActionableIssues int
+	ClosedIssues     int
+	Cycles           int
+	CriticalPathDays float64
+	ByPriority       map[int]int // Open issues per priority
+	Labels           []LabelMetrics
+}
+
+// Collect computes a snapshot of issues as of now
+func Collect(issues []model.Issue, now time.Time) Snapshot {
+	analyzer := analysis.NewAnalyzer(issues)
+	stats := analyzer.Analyze()
+
+	s := Snapshot{ByPriority: make(map[int]int)}
+	actionable := make(map[string]bool)
+	for _, issue := range analyzer.GetActionableIssues() {
+		actionable[issue.ID] = true
+	}
+	for _, issue := range issues {
+		switch {
+		case issue.Status.IsTombstone():
+			continue
+		case issue.Status.IsClosed():
+			s.ClosedIssues++
+			continue
+		}
+		s.OpenIssues++
+		s.ByPriority[issue.Priority]++
+		if issue.Status == model.StatusInProgress {
+			s.InProgressIssues++
+		}
+		if issue.Status == model.StatusBlocked || !actionable[issue.ID] {
+			s.BlockedIssues++
+		} else {
+			s.ActionableIssues++
+		}
+	}
+	s.Cycles = len(stats.Cycles())
+
+	_, minutes := analysis.CriticalPathMinutes(issues, &stats)
+	s.CriticalPathDays = float64(minutes) / WorkdayMinutes
+
+	health := analysis.ComputeAllLabelHealth(issues, analysis.DefaultLabelHealthConfig(), now, &stats)
+	for _, lh := range health.Labels {
+		s.Labels = append(s.Labels, LabelMetrics{Label: lh.Label, Health: lh.Health, Open: lh.OpenCount, Blocked: lh.Blocked})
+	}
+	sort.Slice(s.Labels, func(i, j int) bool { return s.Labels[i].Label < s.Labels[j].Label })
+	return s
+}
+
+// WriteTo writes s in the Prometheus text exposition format
+func (s Snapshot) WriteTo(w io.Writer) (int64, error) {
+	var b strings.Builder
+	gauge := func(name, help string, samples ...string) {
+		fmt.Fprintf(&b, "# HELP %s %s\n# TYPE %s gauge\n", name, help, name)
+		for _, sample := range samples {
+			b.WriteString(sample)
+			b.WriteByte('\n')
+		}
+	}
+	sample := func(name string, value float64) string {
+		return fmt.Sprintf("%s %g", name, value)
+	}
+
+	gauge("bv_open_issues", "Issues not closed.", sample("bv_open_issues", float64(s.OpenIssues)))
+	gauge("bv_in_progress_issues", "Issues in progress.", sample("bv_in_progress_issues", float64(s.InProgressIssues)))
+	gauge("bv_blocked_issues", "Open issues waiting on an open blocker or marked blocked.", sample("bv_blocked_issues", float64(s.BlockedIssues)))
+	gauge("bv_actionable_issues", "Open issues ready to work on.", sample("bv_actionable_issues", float64(s.ActionableIssues)))
+	gauge("bv_closed_issues", "Closed issues.", sample("bv_closed_issues", float64(s.ClosedIssues)))
+	gauge("bv_cycles", "Dependency cycles.", sample("bv_cycles", float64(s.Cycles)))
+	gauge("bv_critical_path_days", "Estimated workdays along the longest chain of blocking dependencies.", sample("bv_critical_path_days", math.Round(s.CriticalPathDays*100)/100))
+
+	priorities := make([]int, 0, len(s.ByPriority))
+	for p := range s.ByPriority {
+		priorities = append(priorities, p)
+	}
+	sort.Ints(priorities)
+	var byPriority []string
+	for _, p := range priorities {
+		byPriority = append(byPriority, fmt.Sprintf("bv_open_issues_by_priority{priority=\"%d\"} %d", p, s.ByPriority[p]))
+	}
+	gauge("bv_open_issues_by_priority", "Open issues per priority.", byPriority...)
+
+	var health, open, blocked []string
+	for _, l := range s.Labels {
+		label := escapeLabelValue(l.Label)
+		health = append(health, fmt.Sprintf("bv_label_health{label=\"%s\"} %d", label, l.Health))
+		open = append(open, fmt.Sprintf("bv_label_open_issues{label=\"%s\"} %d", label, l.Open))
+		blocked = append(blocked, fmt.Sprintf("bv_label_blocked_issues{label=\"%s\"} %d", label, l.Blocked))
+	}
+	gauge("bv_label_health", "Composite label health score, 0-100.", health...)
+	gauge("bv_label_open_issues", "Open issues per label.", open...)
+	gauge("bv_label_blocked_issues", "Blocked issues per label.", blocked...)
+
+	n, err := io.WriteString(w, b.String())
+	return int64(n), err
+}
+
+// escapeLabelValue escapes a label value per the exposition format
+func escapeLabelValue(v string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(v)
+}
+
+// WriteTextfile writes s to path atomically, as the textfile collector
+// requires (it may read the file at any moment)
+func WriteTextfile(path string, s Snapshot) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("creating metrics file: %w", err)
+	}
+	tmpName := tmp.Name()
+	if _, err := s.WriteTo(tmp); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmpName)
+		return fmt.Errorf("writing metrics file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmpName)
+		return fmt.Errorf("writing metrics file: %w", err)
+	}
+	if err := os.Chmod(tmpName, 0o644); err != nil {
+		_ = os.Remove(tmpName)
+		return fmt.Errorf("writing metrics file: %w", err)
+	}
+	if err := os.Rename(tmpName, path); err != nil {
+		_ = os.Remove(tmpName)
+		return fmt.Errorf("writing metrics file: %w", err)
+	}
+	return nil
+}
+
+// Handler serves /metrics, loading issues afresh on every scrape so the
+// gauges follow the beads file
+func Handler(load func() ([]model.Issue, error)) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/metrics", func(w http.ResponseWriter, r *http.Request) {
+		issues, err := load()
+		if err != nil {
+			http.Error(w, "loading issues: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		_, _ = Collect(issues, time.Now()).WriteTo(w)
+	})
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/" {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprintln(w, "bv metrics exporter: see /metrics")
+	})
+	return mux
+}
+
+// Serve listens on addr until interrupted
+func Serve(ctx context.Context, addr string, handler http.Handler) error {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("listen on %s: %w", addr, err)
+	}
+	server := &http.Server{
+		Handler:           handler,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	fmt.Printf("Serving metrics at http://%s/metrics\n", listener.Addr())
+	fmt.Println("Press Ctrl+C to stop")
+
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	errChan := make(chan error, 1)
+	go func() {
+		errChan <- server.Serve(listener)
+	}()
+
+	select {
+	case err := <-errChan:
+		if errors.Is(err, http.ErrServerClosed) {
+			return nil
+		}
+		return err
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		return server.Shutdown(shutdownCtx)
+	}
+}
@@ -0,0 +1,110 @@
+package metrics
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func metricsTestIssues() []model.Issue {
+	minutes := func(n int) *int { return &n }
+	blocks := func(id string) []*model.Dependency {
+		return []*model.Dependency{{DependsOnID: id, Type: model.DepBlocks}}
+	}
+	return []model.Issue{
+		{ID: "a", Status: model.StatusInProgress, Priority: 1, Labels: []string{"api"}, EstimatedMinutes: minutes(480)},
+		{ID: "b", Status: model.StatusOpen, Priority: 1, Labels: []string{"api"}, EstimatedMinutes: minutes(240), Dependencies: blocks("a")},
+		{ID: "c", Status: model.StatusBlocked, Priority: 2, Labels: []string{`we"ird`}, EstimatedMinutes: minutes(60)},
+		{ID: "d", Status: model.StatusClosed, Priority: 1, Labels: []string{"api"}},
+		{ID: "e", Status: model.StatusTombstone},
+	}
+}
+
+func TestCollect(t *testing.T) {
+	s := Collect(metricsTestIssues(), time.Now())
+	if s.OpenIssues != 3 || s.InProgressIssues != 1 || s.ClosedIssues != 1 {
+		t.Errorf("counts = %+v", s)
+	}
+	if s.BlockedIssues != 2 || s.ActionableIssues != 1 {
+		t.Errorf("blocked %d / actionable %d, want 2 / 1", s.BlockedIssues, s.ActionableIssues)
+	}
+	if s.ByPriority[1] != 2 || s.ByPriority[2] != 1 {
+		t.Errorf("by priority = %v", s.ByPriority)
+	}
+	if s.CriticalPathDays != 1.5 {
+		t.Errorf("critical path = %v days, want 1.5 (a then b)", s.CriticalPathDays)
+	}
+	if len(s.Labels) != 2 || s.Labels[0].Label != "api" || s.Labels[0].Open != 2 {
+		t.Errorf("labels = %+v", s.Labels)
+	}
+}
+
+func TestSnapshotWriteTo(t *testing.T) {
+	var buf bytes.Buffer
+	if _, err := Collect(metricsTestIssues(), time.Now()).WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		"# TYPE bv_open_issues gauge\nbv_open_issues 3\n",
+		"bv_blocked_issues 2\n",
+		"bv_actionable_issues 1\n",
+		"bv_cycles 0\n",
+		"bv_critical_path_days 1.5\n",
+		`bv_open_issues_by_priority{priority="1"} 2`,
+		`bv_label_open_issues{label="api"} 2`,
+		`bv_label_health{label="we\"ird"}`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("missing %q in:\n%s", want, out)
+		}
+	}
+}
+
+func TestWriteTextfile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bv.prom")
+	if err := WriteTextfile(path, Collect(metricsTestIssues(), time.Now())); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil || !strings.Contains(string(data), "bv_open_issues 3") {
+		t.Errorf("textfile = %q, %v", data, err)
+	}
+	matches, _ := filepath.Glob(path + ".tmp-*")
+	if len(matches) != 0 {
+		t.Errorf("temp files left behind: %v", matches)
+	}
+}
+
+func TestHandler(t *testing.T) {
+	calls := 0
+	handler := Handler(func() ([]model.Issue, error) {
+		calls++
+		return metricsTestIssues(), nil
+	})
+	for range 2 {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+		if rec.Code != http.StatusOK || !strings.HasPrefix(rec.Header().Get("Content-Type"), "text/plain; version=0.0.4") {
+			t.Fatalf("status %d, content type %q", rec.Code, rec.Header().Get("Content-Type"))
+		}
+	}
+	if calls != 2 {
+		t.Errorf("issues loaded %d times, want once per scrape", calls)
+	}
+
+	failing := Handler(func() ([]model.Issue, error) { return nil, errors.New("boom") })
+	rec := httptest.NewRecorder()
+	failing.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("load failure status = %d", rec.Code)
+	}
+}
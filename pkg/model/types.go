@@ -2,6 +2,7 @@ package model
 
 import (
 	"fmt"
+	"strings"
 	"time"
 )
 
@@ -19,6 +20,7 @@ type Issue struct {
 	IssueType          IssueType     `json:"issue_type"`
 	Assignee           string        `json:"assignee,omitempty"`
 	EstimatedMinutes   *int          `json:"estimated_minutes,omitempty"`
+	EstimatedPoints    *float64      `json:"estimated_points,omitempty"`
 	CreatedAt          time.Time     `json:"created_at"`
 	UpdatedAt          time.Time     `json:"updated_at"`
 	DueDate            *time.Time    `json:"due_date,omitempty"`
@@ -31,6 +33,7 @@ type Issue struct {
 	Labels             []string      `json:"labels,omitempty"`
 	Dependencies       []*Dependency `json:"dependencies,omitempty"`
 	Comments           []*Comment    `json:"comments,omitempty"`
+	Events             []*IssueEvent `json:"events,omitempty"`
 	SourceRepo         string        `json:"source_repo,omitempty"`
 }
 
@@ -42,6 +45,10 @@ func (i Issue) Clone() Issue {
 		v := *i.EstimatedMinutes
 		clone.EstimatedMinutes = &v
 	}
+	if i.EstimatedPoints != nil {
+		v := *i.EstimatedPoints
+		clone.EstimatedPoints = &v
+	}
 	if i.ClosedAt != nil {
 		v := *i.ClosedAt
 		clone.ClosedAt = &v
@@ -88,6 +95,16 @@ func (i Issue) Clone() Issue {
 		}
 	}
 
+	if i.Events != nil {
+		clone.Events = make([]*IssueEvent, len(i.Events))
+		for idx, event := range i.Events {
+			if event != nil {
+				v := *event
+				clone.Events[idx] = &v
+			}
+		}
+	}
+
 	return clone
 }
 
@@ -111,13 +128,32 @@ func (i *Issue) Validate() error {
 	return nil
 }
 
+// MinutesPerPoint converts story points to minutes for issues estimated
+// only in points (one point is half a working day).
+const MinutesPerPoint = 240
+
+// ExplicitEstimateMinutes returns the issue's own estimate in minutes,
+// preferring estimated_minutes over estimated_points. The second result is
+// false when the issue has no positive estimate of either kind.
+func (i *Issue) ExplicitEstimateMinutes() (int, bool) {
+	if i.EstimatedMinutes != nil && *i.EstimatedMinutes > 0 {
+		return *i.EstimatedMinutes, true
+	}
+	if i.EstimatedPoints != nil && *i.EstimatedPoints > 0 {
+		return max(int(*i.EstimatedPoints*MinutesPerPoint+0.5), 1), true
+	}
+	return 0, false
+}
+
 // Status represents the current state of an issue
 type Status string
 
 const (
 	StatusOpen       Status = "open"
 	StatusInProgress Status = "in_progress"
+	StatusInReview   Status = "in_review"
 	StatusBlocked    Status = "blocked"
+	StatusDeferred   Status = "deferred" // Put off for later; still blocks its dependents
 	StatusClosed     Status = "closed"
 	StatusTombstone  Status = "tombstone"
 )
@@ -125,20 +161,37 @@ const (
 // IsValid returns true if the status is a recognized value
 func (s Status) IsValid() bool {
 	switch s {
-	case StatusOpen, StatusInProgress, StatusBlocked, StatusClosed, StatusTombstone:
+	case StatusOpen, StatusInProgress, StatusInReview, StatusBlocked, StatusDeferred, StatusClosed, StatusTombstone:
 		return true
 	}
 	return false
 }
 
+// reviewStatuses are the spellings trackers and custom beads workflows use
+// for work waiting on review
+var reviewStatuses = map[Status]bool{
+	"review": true, "in-review": true, "inreview": true, "reviewing": true,
+	"needs_review": true, "needs-review": true, "pending_review": true,
+	"awaiting_review": true, "ready_for_review": true, "code_review": true,
+}
+
+// Normalize maps custom review statuses (review, needs_review, ...) to
+// StatusInReview and leaves every other status as is
+func (s Status) Normalize() Status {
+	if reviewStatuses[Status(strings.ToLower(string(s)))] {
+		return StatusInReview
+	}
+	return s
+}
+
 // IsClosed returns true if the status represents a closed state
 func (s Status) IsClosed() bool {
 	return s == StatusClosed
 }
 
-// IsOpen returns true if the status represents an active (open or in_progress) state
+// IsOpen returns true if the status represents an active (open, in_progress or in_review) state
 func (s Status) IsOpen() bool {
-	return s == StatusOpen || s == StatusInProgress
+	return s == StatusOpen || s == StatusInProgress || s == StatusInReview
 }
 
 // IsTombstone returns true if the status represents a permanently deleted/archived state
@@ -221,6 +274,98 @@ type Comment struct {
 	CreatedAt time.Time `json:"created_at"`
 }
 
+// IssueEventType categorizes an entry in an issue's audit trail
+type IssueEventType string
+
+const (
+	EventCreated       IssueEventType = "created"
+	EventUpdated       IssueEventType = "updated"
+	EventStatusChanged IssueEventType = "status_changed"
+	EventCommented     IssueEventType = "commented"
+	EventClosed        IssueEventType = "closed"
+	EventReopened      IssueEventType = "reopened"
+)
+
+// IssueEvent is a single audit-trail entry recorded by beads
+type IssueEvent struct {
+	ID        int64          `json:"id,omitempty"`
+	IssueID   string         `json:"issue_id,omitempty"`
+	EventType IssueEventType `json:"event_type"`
+	Actor     string         `json:"actor,omitempty"`
+	OldValue  string         `json:"old_value,omitempty"`
+	NewValue  string         `json:"new_value,omitempty"`
+	Comment   string         `json:"comment,omitempty"`
+	CreatedAt time.Time      `json:"created_at"`
+}
+
+// ToStatus returns the status the issue moved into with this event, or ""
+// if the event is not a status transition.
+func (e IssueEvent) ToStatus() string {
+	switch e.EventType {
+	case EventCreated:
+		if e.NewValue != "" {
+			return e.NewValue
+		}
+		return string(StatusOpen)
+	case EventClosed:
+		return string(StatusClosed)
+	case EventReopened:
+		if e.NewValue != "" {
+			return e.NewValue
+		}
+		return string(StatusOpen)
+	case EventStatusChanged:
+		return string(Status(e.NewValue).Normalize())
+	}
+	return ""
+}
+
+// IssueTimeline holds lifecycle milestones taken from recorded events
+type IssueTimeline struct {
+	Opened   *time.Time `json:"opened,omitempty"`
+	Claimed  *time.Time `json:"claimed,omitempty"`
+	InReview *time.Time `json:"in_review,omitempty"`
+	Closed   *time.Time `json:"closed,omitempty"`
+}
+
+// Timeline derives opened→claimed→in_review→closed milestones from Events
+// (which the loader keeps in chronological order). Opened and Closed fall back
+// to CreatedAt and ClosedAt when no matching event was recorded. Claimed and
+// InReview are the first such transitions; Closed is the latest close.
+func (i *Issue) Timeline() IssueTimeline {
+	var tl IssueTimeline
+	for _, e := range i.Events {
+		if e == nil || e.CreatedAt.IsZero() {
+			continue
+		}
+		at := e.CreatedAt
+		if e.EventType == EventCreated && tl.Opened == nil {
+			tl.Opened = &at
+		}
+		switch e.ToStatus() {
+		case string(StatusInProgress):
+			if tl.Claimed == nil {
+				tl.Claimed = &at
+			}
+		case string(StatusInReview):
+			if tl.InReview == nil {
+				tl.InReview = &at
+			}
+		case string(StatusClosed):
+			tl.Closed = &at
+		}
+	}
+	if tl.Opened == nil && !i.CreatedAt.IsZero() {
+		v := i.CreatedAt
+		tl.Opened = &v
+	}
+	if tl.Closed == nil && i.ClosedAt != nil {
+		v := *i.ClosedAt
+		tl.Closed = &v
+	}
+	return tl
+}
+
 // Sprint represents a time-boxed period of work
 type Sprint struct {
 	ID             string    `json:"id"`
@@ -15,7 +15,9 @@ func TestStatus_IsValid(t *testing.T) {
 	}{
 		{"Open", StatusOpen, true},
 		{"InProgress", StatusInProgress, true},
+		{"InReview", StatusInReview, true},
 		{"Blocked", StatusBlocked, true},
+		{"Deferred", StatusDeferred, true},
 		{"Closed", StatusClosed, true},
 		{"Invalid", "unknown", false},
 		{"Empty", "", false},
@@ -57,7 +59,9 @@ func TestStatus_IsOpen(t *testing.T) {
 	}{
 		{"Open", StatusOpen, true},
 		{"InProgress", StatusInProgress, true},
+		{"InReview", StatusInReview, true},
 		{"Blocked", StatusBlocked, false},
+		{"Deferred", StatusDeferred, false},
 		{"Closed", StatusClosed, false},
 	}
 	for _, tt := range tests {
@@ -69,6 +73,25 @@ func TestStatus_IsOpen(t *testing.T) {
 	}
 }
 
+func TestStatus_Normalize(t *testing.T) {
+	tests := []struct {
+		status Status
+		want   Status
+	}{
+		{"in_review", StatusInReview},
+		{"review", StatusInReview},
+		{"Needs-Review", StatusInReview},
+		{"pending_review", StatusInReview},
+		{"in_progress", StatusInProgress},
+		{"unknown", "unknown"},
+	}
+	for _, tt := range tests {
+		if got := tt.status.Normalize(); got != tt.want {
+			t.Errorf("Status(%q).Normalize() = %q, want %q", tt.status, got, tt.want)
+		}
+	}
+}
+
 func TestIssueType_IsValid(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -580,6 +603,9 @@ func TestIssue_Clone(t *testing.T) {
 		Comments: []*Comment{
 			{ID: 1, IssueID: "TEST-1", Author: "user", Text: "comment"},
 		},
+		Events: []*IssueEvent{
+			{ID: 1, IssueID: "TEST-1", EventType: EventStatusChanged, NewValue: "in_progress"},
+		},
 	}
 
 	clone := original.Clone()
@@ -641,6 +667,14 @@ func TestIssue_Clone(t *testing.T) {
 	if clone.Comments[0] == original.Comments[0] {
 		t.Errorf("Comments[0] should be a new pointer")
 	}
+
+	// Verify Events are deep copied
+	if len(clone.Events) != 1 {
+		t.Errorf("Events length mismatch")
+	}
+	if clone.Events[0] == original.Events[0] {
+		t.Errorf("Events[0] should be a new pointer")
+	}
 }
 
 func TestIssue_Clone_NilFields(t *testing.T) {
@@ -669,3 +703,98 @@ func TestIssue_Clone_NilFields(t *testing.T) {
 		t.Errorf("Comments should be nil")
 	}
 }
+
+func TestIssue_ExplicitEstimateMinutes(t *testing.T) {
+	minutes := func(n int) *int { return &n }
+	points := func(n float64) *float64 { return &n }
+	tests := []struct {
+		name    string
+		issue   Issue
+		want    int
+		wantSet bool
+	}{
+		{"none", Issue{}, 0, false},
+		{"minutes", Issue{EstimatedMinutes: minutes(90)}, 90, true},
+		{"points", Issue{EstimatedPoints: points(2)}, 2 * MinutesPerPoint, true},
+		{"minutes win over points", Issue{EstimatedMinutes: minutes(30), EstimatedPoints: points(3)}, 30, true},
+		{"zero minutes fall back to points", Issue{EstimatedMinutes: minutes(0), EstimatedPoints: points(0.5)}, 120, true},
+		{"tiny points round up to a minute", Issue{EstimatedPoints: points(0.001)}, 1, true},
+		{"zero points", Issue{EstimatedPoints: points(0)}, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tt.issue.ExplicitEstimateMinutes()
+			if got != tt.want || ok != tt.wantSet {
+				t.Errorf("ExplicitEstimateMinutes() = %d, %v; want %d, %v", got, ok, tt.want, tt.wantSet)
+			}
+		})
+	}
+
+	original := Issue{EstimatedPoints: points(3)}
+	clone := original.Clone()
+	*clone.EstimatedPoints = 5
+	if *original.EstimatedPoints != 3 {
+		t.Error("Clone should deep copy EstimatedPoints")
+	}
+}
+
+func TestIssue_Timeline(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2025, 1, d, 0, 0, 0, 0, time.UTC) }
+	closedAt := day(20)
+
+	issue := Issue{
+		ID:        "bv-1",
+		CreatedAt: day(1),
+		ClosedAt:  &closedAt,
+		Events: []*IssueEvent{
+			{EventType: EventCreated, CreatedAt: day(2)},
+			{EventType: EventStatusChanged, OldValue: "open", NewValue: "in_progress", CreatedAt: day(3)},
+			{EventType: EventCommented, CreatedAt: day(4)},
+			{EventType: EventStatusChanged, NewValue: string(StatusInReview), CreatedAt: day(5)},
+			{EventType: EventClosed, CreatedAt: day(6)},
+			{EventType: EventReopened, CreatedAt: day(7)},
+			{EventType: EventStatusChanged, NewValue: "in_progress", CreatedAt: day(8)},
+			{EventType: EventClosed, CreatedAt: day(9)},
+		},
+	}
+
+	tl := issue.Timeline()
+	check := func(name string, got *time.Time, want time.Time) {
+		t.Helper()
+		if got == nil || !got.Equal(want) {
+			t.Errorf("%s = %v, want %v", name, got, want)
+		}
+	}
+	check("Opened", tl.Opened, day(2))
+	check("Claimed", tl.Claimed, day(3))
+	check("InReview", tl.InReview, day(5))
+	check("Closed", tl.Closed, day(9))
+
+	// Without events, fall back to stored timestamps
+	bare := Issue{ID: "bv-2", CreatedAt: day(1), ClosedAt: &closedAt}
+	tl = bare.Timeline()
+	check("fallback Opened", tl.Opened, day(1))
+	check("fallback Closed", tl.Closed, closedAt)
+	if tl.Claimed != nil || tl.InReview != nil {
+		t.Errorf("expected no claim/review milestones without events, got %+v", tl)
+	}
+}
+
+func TestIssueEvent_ToStatus(t *testing.T) {
+	tests := []struct {
+		event IssueEvent
+		want  string
+	}{
+		{IssueEvent{EventType: EventCreated}, "open"},
+		{IssueEvent{EventType: EventCreated, NewValue: "in_progress"}, "in_progress"},
+		{IssueEvent{EventType: EventStatusChanged, NewValue: "blocked"}, "blocked"},
+		{IssueEvent{EventType: EventClosed}, "closed"},
+		{IssueEvent{EventType: EventReopened}, "open"},
+		{IssueEvent{EventType: EventCommented, NewValue: "x"}, ""},
+	}
+	for _, tt := range tests {
+		if got := tt.event.ToStatus(); got != tt.want {
+			t.Errorf("%s/%q ToStatus() = %q, want %q", tt.event.EventType, tt.event.NewValue, got, tt.want)
+		}
+	}
+}
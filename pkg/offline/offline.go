@@ -0,0 +1,38 @@
+// Package offline is bv's switch for air-gapped environments. With
+// --offline or BV_OFFLINE set, bv makes no network calls: the update check
+// is skipped, semantic search uses the local embedder, and features that
+// can't work without the network (updates, Pages deploys and pushes,
+// Confluence/Notion publishing) fail with ErrOffline instead of trying.
+package offline
+
+import (
+	"errors"
+	"fmt"
+	"os"
+)
+
+// EnvVar turns offline mode on when set to anything but "" or "0"
+const EnvVar = "BV_OFFLINE"
+
+// ErrOffline is returned by features that need the network
+var ErrOffline = errors.New("network access is disabled (--offline or " + EnvVar + ")")
+
+// Enabled reports whether offline mode is on
+func Enabled() bool {
+	v := os.Getenv(EnvVar)
+	return v != "" && v != "0"
+}
+
+// Enable turns offline mode on for bv and the commands it runs (hooks,
+// plugins, git)
+func Enable() {
+	os.Setenv(EnvVar, "1")
+}
+
+// Check returns an error naming feature when offline mode is on
+func Check(feature string) error {
+	if Enabled() {
+		return fmt.Errorf("%s needs the network: %w", feature, ErrOffline)
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+package offline
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestEnabled(t *testing.T) {
+	for value, want := range map[string]bool{"": false, "0": false, "1": true, "true": true} {
+		t.Setenv(EnvVar, value)
+		if got := Enabled(); got != want {
+			t.Errorf("%s=%q: Enabled() = %v, want %v", EnvVar, value, got, want)
+		}
+	}
+
+	t.Setenv(EnvVar, "")
+	Enable()
+	if !Enabled() {
+		t.Error("Enable() did not turn offline mode on")
+	}
+}
+
+func TestCheck(t *testing.T) {
+	t.Setenv(EnvVar, "")
+	if err := Check("updating bv"); err != nil {
+		t.Fatalf("online: %v", err)
+	}
+
+	t.Setenv(EnvVar, "1")
+	err := Check("updating bv")
+	if !errors.Is(err, ErrOffline) || !strings.HasPrefix(err.Error(), "updating bv needs the network") {
+		t.Errorf("offline: %v", err)
+	}
+}
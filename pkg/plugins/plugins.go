@@ -0,0 +1,223 @@
+// Package plugins runs custom analyzers: executables in .bv/plugins/ that
+// read the issues as JSON on stdin and answer with extra per-issue scores and
+// alerts on stdout.
+//
+// Everything a plugin contributes is namespaced with its name, so score
+// "risk" from plugin "security" becomes "security:risk", and alert type
+// "cve" becomes "security:cve". A plugin that fails, times out or answers
+// with invalid JSON is reported in Result.Errors; it never fails the run.
+package plugins
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// ProtocolVersion is sent in every request; plugins should reject versions
+// they do not know
+const ProtocolVersion = 1
+
+// DefaultTimeout bounds each plugin run
+const DefaultTimeout = 10 * time.Second
+
+// Plugin is one executable in the plugins directory
+type Plugin struct {
+	Name string `json:"name"` // File name without extension; the namespace prefix
+	Path string `json:"path"`
+	dir  string // Project root, the working directory of the run
+}
+
+// Request is the JSON document a plugin reads on stdin
+type Request struct {
+	Protocol int           `json:"protocol"`
+	Context  string        `json:"context"` // The output being built: "insights" or "triage"
+	Issues   []model.Issue `json:"issues"`
+}
+
+// Response is the JSON document a plugin writes on stdout
+type Response struct {
+	Scores map[string]map[string]float64 `json:"scores,omitempty"` // Issue ID -> metric -> value
+	Alerts []analysis.Alert              `json:"alerts,omitempty"`
+}
+
+// Error records a plugin that contributed nothing
+type Error struct {
+	Plugin  string `json:"plugin"`
+	Message string `json:"message"`
+}
+
+// Result merges the responses of every plugin
+type Result struct {
+	Ran    []string                      `json:"ran"`
+	Scores map[string]map[string]float64 `json:"scores,omitempty"` // Issue ID -> namespaced metric -> value
+	Alerts []analysis.Alert              `json:"alerts,omitempty"` // Types are namespaced
+	Errors []Error                       `json:"errors,omitempty"`
+}
+
+// Dir returns the plugins directory of a project
+func Dir(projectDir string) string {
+	return filepath.Join(projectDir, ".bv", "plugins")
+}
+
+// Discover lists the executables in the project's plugins directory, by
+// name. A missing directory means no plugins.
+func Discover(projectDir string) ([]Plugin, error) {
+	entries, err := os.ReadDir(Dir(projectDir))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("reading plugins directory: %w", err)
+	}
+
+	var plugins []Plugin
+	seen := make(map[string]string)
+	for _, entry := range entries {
+		if strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		path := filepath.Join(Dir(projectDir), entry.Name())
+		info, err := os.Stat(path) // Follows symlinks
+		if err != nil || !info.Mode().IsRegular() || info.Mode().Perm()&0o111 == 0 {
+			continue
+		}
+		name := strings.TrimSuffix(entry.Name(), filepath.Ext(entry.Name()))
+		if other, dup := seen[name]; dup {
+			return nil, fmt.Errorf("plugins %s and %s share the name %q", other, entry.Name(), name)
+		}
+		seen[name] = entry.Name()
+		plugins = append(plugins, Plugin{Name: name, Path: path, dir: projectDir})
+	}
+	sort.Slice(plugins, func(i, j int) bool { return plugins[i].Name < plugins[j].Name })
+	return plugins, nil
+}
+
+// Run runs every plugin concurrently, each bounded by timeout (DefaultTimeout
+// if zero), and merges their answers. Scores for issues not in issues are
+// dropped.
+func Run(ctx context.Context, plugins []Plugin, reqContext string, issues []model.Issue, timeout time.Duration) Result {
+	result := Result{Ran: []string{}}
+	if len(plugins) == 0 {
+		return result
+	}
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	if issues == nil {
+		issues = []model.Issue{}
+	}
+	input, err := json.Marshal(Request{Protocol: ProtocolVersion, Context: reqContext, Issues: issues})
+	if err != nil {
+		for _, p := range plugins {
+			result.Errors = append(result.Errors, Error{Plugin: p.Name, Message: err.Error()})
+		}
+		return result
+	}
+
+	responses := make([]*Response, len(plugins))
+	errs := make([]error, len(plugins))
+	var wg sync.WaitGroup
+	for i, p := range plugins {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			responses[i], errs[i] = runOne(ctx, p, input, timeout)
+		}()
+	}
+	wg.Wait()
+
+	known := make(map[string]bool, len(issues))
+	for _, issue := range issues {
+		known[issue.ID] = true
+	}
+	for i, p := range plugins {
+		result.Ran = append(result.Ran, p.Name)
+		if errs[i] != nil {
+			result.Errors = append(result.Errors, Error{Plugin: p.Name, Message: errs[i].Error()})
+			continue
+		}
+		result.merge(p.Name, responses[i], known)
+	}
+	return result
+}
+
+// runOne runs a single plugin and decodes its answer
+func runOne(ctx context.Context, p Plugin, input []byte, timeout time.Duration) (*Response, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, p.Path)
+	cmd.Dir = p.dir
+	cmd.WaitDelay = time.Second // Children left holding stdout don't outlive the timeout by much
+	cmd.Stdin = bytes.NewReader(input)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return nil, fmt.Errorf("timeout after %v", timeout)
+		}
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("%w: %s", err, firstLine(msg))
+		}
+		return nil, err
+	}
+
+	var resp Response
+	if err := json.Unmarshal(stdout.Bytes(), &resp); err != nil {
+		return nil, fmt.Errorf("invalid response: %w", err)
+	}
+	return &resp, nil
+}
+
+// merge adds one plugin's answer under its namespace
+func (r *Result) merge(name string, resp *Response, known map[string]bool) {
+	for id, metrics := range resp.Scores {
+		if !known[id] {
+			continue
+		}
+		for metric, value := range metrics {
+			if metric == "" || math.IsNaN(value) || math.IsInf(value, 0) {
+				continue
+			}
+			if r.Scores == nil {
+				r.Scores = make(map[string]map[string]float64)
+			}
+			if r.Scores[id] == nil {
+				r.Scores[id] = make(map[string]float64)
+			}
+			r.Scores[id][name+":"+metric] = value
+		}
+	}
+	for _, alert := range resp.Alerts {
+		if alert.Message == "" {
+			continue
+		}
+		alert.Type = name + ":" + cmp.Or(alert.Type, "alert")
+		alert.Severity = cmp.Or(alert.Severity, "info")
+		r.Alerts = append(r.Alerts, alert)
+	}
+}
+
+func firstLine(s string) string {
+	if i := strings.IndexByte(s, '\n'); i >= 0 {
+		return s[:i]
+	}
+	return s
+}
@@ -0,0 +1,91 @@
+package plugins
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func writePlugin(t *testing.T, projectDir, name, script string, mode os.FileMode) {
+	t.Helper()
+	if err := os.MkdirAll(Dir(projectDir), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(Dir(projectDir), name), []byte("#!/bin/sh\n"+script+"\n"), mode); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDiscover(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("plugins are shell scripts")
+	}
+	dir := t.TempDir()
+	if found, err := Discover(dir); err != nil || found != nil {
+		t.Fatalf("no plugins dir = %v, %v", found, err)
+	}
+
+	writePlugin(t, dir, "security.sh", "true", 0o755)
+	writePlugin(t, dir, "cost", "true", 0o755)
+	writePlugin(t, dir, "README.md", "", 0o644)
+	writePlugin(t, dir, ".hidden", "true", 0o755)
+
+	found, err := Discover(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(found) != 2 || found[0].Name != "cost" || found[1].Name != "security" {
+		t.Fatalf("Discover = %+v", found)
+	}
+
+	writePlugin(t, dir, "security.py", "true", 0o755)
+	if _, err := Discover(dir); err == nil || !strings.Contains(err.Error(), `share the name "security"`) {
+		t.Errorf("duplicate names should be an error, got %v", err)
+	}
+}
+
+func TestRunMergesNamespacedAnswers(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("plugins are shell scripts")
+	}
+	dir := t.TempDir()
+	// Answers only if it was sent the issues
+	writePlugin(t, dir, "security", `grep -q '"id":"A-1"' && echo '{"scores":{"A-1":{"risk":0.9},"GONE":{"risk":1}},"alerts":[{"type":"cve","severity":"warning","message":"old openssl","issue_id":"A-1"},{"message":"untyped"}]}'`, 0o755)
+	writePlugin(t, dir, "broken", `echo 'boom' >&2; exit 2`, 0o755)
+	writePlugin(t, dir, "garbage", `echo 'not json'`, 0o755)
+	writePlugin(t, dir, "slow", `sleep 5`, 0o755)
+
+	found, err := Discover(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	issues := []model.Issue{{ID: "A-1", Title: "One", Status: model.StatusOpen}}
+	result := Run(context.Background(), found, "triage", issues, 500*time.Millisecond)
+
+	if strings.Join(result.Ran, ",") != "broken,garbage,security,slow" {
+		t.Errorf("Ran = %v", result.Ran)
+	}
+	if got := result.Scores["A-1"]["security:risk"]; got != 0.9 {
+		t.Errorf("security:risk = %v, want 0.9 (scores %v)", got, result.Scores)
+	}
+	if _, ok := result.Scores["GONE"]; ok {
+		t.Error("scores for unknown issues should be dropped")
+	}
+	if len(result.Alerts) != 2 || result.Alerts[0].Type != "security:cve" || result.Alerts[1].Type != "security:alert" || result.Alerts[1].Severity != "info" {
+		t.Errorf("Alerts = %+v", result.Alerts)
+	}
+
+	errs := make(map[string]string)
+	for _, e := range result.Errors {
+		errs[e.Plugin] = e.Message
+	}
+	if !strings.Contains(errs["broken"], "boom") || !strings.Contains(errs["garbage"], "invalid response") || !strings.Contains(errs["slow"], "timeout") {
+		t.Errorf("Errors = %+v", result.Errors)
+	}
+}
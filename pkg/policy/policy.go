@@ -0,0 +1,444 @@
+// Package policy implements auto-triage policies for stale issues.
+//
+// Policies are configured in .bv/policies.yaml. Each policy pairs conditions
+// (no update in N days, blocked by a closed issue, no inbound references)
+// with an action (mark for closure, de-prioritize, ping the assignee).
+// Evaluate produces a dry-run report; Apply writes the changes to the beads
+// file.
+package policy
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"gopkg.in/yaml.v3"
+)
+
+// ConfigFilename is the policies config filename
+const ConfigFilename = "policies.yaml"
+
+// Action is what a policy does to a matching issue
+type Action string
+
+const (
+	// ActionMarkForClosure adds a label (default "close-candidate") so a
+	// human can confirm the close
+	ActionMarkForClosure Action = "mark_for_closure"
+	// ActionDeprioritize lowers the priority to a floor (default P3)
+	ActionDeprioritize Action = "deprioritize"
+	// ActionPing comments on the issue mentioning its assignee
+	ActionPing Action = "ping"
+)
+
+// Defaults for action parameters
+const (
+	DefaultClosureLabel = "close-candidate"
+	DefaultPriority     = 3
+	DefaultPingMessage  = "is this still being worked on?"
+)
+
+// Conditions select the issues a policy applies to. All set conditions must
+// hold; closed and tombstoned issues never match.
+type Conditions struct {
+	// NoUpdateDays matches issues not updated in at least this many days
+	NoUpdateDays int `yaml:"no_update_days,omitempty" json:"no_update_days,omitempty"`
+	// BlockedByClosed matches issues with a blocking dependency on a closed issue
+	BlockedByClosed bool `yaml:"blocked_by_closed,omitempty" json:"blocked_by_closed,omitempty"`
+	// NoInboundRefs matches issues no other issue depends on or mentions
+	NoInboundRefs bool `yaml:"no_inbound_refs,omitempty" json:"no_inbound_refs,omitempty"`
+	// Status limits the policy to these statuses (default: any open status)
+	Status []model.Status `yaml:"status,omitempty" json:"status,omitempty"`
+	// Labels limits the policy to issues carrying any of these labels
+	Labels []string `yaml:"labels,omitempty" json:"labels,omitempty"`
+}
+
+// Policy is one named rule
+type Policy struct {
+	Name   string     `yaml:"name" json:"name"`
+	When   Conditions `yaml:"when" json:"when"`
+	Action Action     `yaml:"action" json:"action"`
+	// Label is added by mark_for_closure
+	Label string `yaml:"label,omitempty" json:"label,omitempty"`
+	// Priority is the floor deprioritize lowers to (0-4, higher is less urgent)
+	Priority *int `yaml:"priority,omitempty" json:"priority,omitempty"`
+	// Message is the ping comment, after the @assignee mention
+	Message string `yaml:"message,omitempty" json:"message,omitempty"`
+}
+
+// Config is the contents of .bv/policies.yaml
+type Config struct {
+	Policies []Policy `yaml:"policies" json:"policies"`
+}
+
+// ConfigPath returns the policies config path for a project
+func ConfigPath(projectDir string) string {
+	return filepath.Join(projectDir, ".bv", ConfigFilename)
+}
+
+// LoadConfig loads .bv/policies.yaml.
+// Returns an empty config (no policies) if the file doesn't exist.
+func LoadConfig(projectDir string) (*Config, error) {
+	data, err := os.ReadFile(ConfigPath(projectDir))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &Config{}, nil
+		}
+		return nil, fmt.Errorf("reading policies config: %w", err)
+	}
+
+	config := &Config{}
+	if err := yaml.Unmarshal(data, config); err != nil {
+		return nil, fmt.Errorf("parsing policies config: %w", err)
+	}
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid policies config: %w", err)
+	}
+	return config, nil
+}
+
+// Validate checks every policy and fills in action defaults
+func (c *Config) Validate() error {
+	seen := make(map[string]bool)
+	for i := range c.Policies {
+		p := &c.Policies[i]
+		if p.Name == "" {
+			p.Name = fmt.Sprintf("policy-%d", i+1)
+		}
+		if seen[p.Name] {
+			return fmt.Errorf("duplicate policy name %q", p.Name)
+		}
+		seen[p.Name] = true
+
+		w := p.When
+		if w.NoUpdateDays < 0 {
+			return fmt.Errorf("%s: no_update_days must be non-negative", p.Name)
+		}
+		if w.NoUpdateDays == 0 && !w.BlockedByClosed && !w.NoInboundRefs {
+			return fmt.Errorf("%s: needs at least one of no_update_days, blocked_by_closed or no_inbound_refs", p.Name)
+		}
+		for _, s := range w.Status {
+			if !s.IsValid() || s.IsClosed() || s.IsTombstone() {
+				return fmt.Errorf("%s: status %q is not an open status", p.Name, s)
+			}
+		}
+
+		switch p.Action {
+		case ActionMarkForClosure:
+			if p.Label == "" {
+				p.Label = DefaultClosureLabel
+			}
+		case ActionDeprioritize:
+			if p.Priority == nil {
+				floor := DefaultPriority
+				p.Priority = &floor
+			}
+			if *p.Priority < 0 || *p.Priority > 4 {
+				return fmt.Errorf("%s: priority must be between 0 and 4", p.Name)
+			}
+		case ActionPing:
+			if p.Message == "" {
+				p.Message = DefaultPingMessage
+			}
+		default:
+			return fmt.Errorf("%s: unknown action %q (use mark_for_closure, deprioritize or ping)", p.Name, p.Action)
+		}
+	}
+	return nil
+}
+
+// ExampleConfig returns a commented policies.yaml to start from
+func ExampleConfig() string {
+	return `# Auto-triage policies for bv --robot-policies
+policies:
+  # Flag long-idle issues nothing depends on for a human to close
+  - name: close-abandoned
+    when:
+      no_update_days: 90
+      no_inbound_refs: true
+    action: mark_for_closure
+    label: close-candidate
+
+  # Nudge assignees of stalled in-progress work
+  - name: nudge-stalled
+    when:
+      no_update_days: 14
+      status: [in_progress]
+    action: ping
+    message: "is this still being worked on?"
+
+  # Issues waiting on already-closed blockers are likely forgotten
+  - name: stale-blockers
+    when:
+      blocked_by_closed: true
+      no_update_days: 30
+    action: deprioritize
+    priority: 3
+`
+}
+
+// Match is one policy action planned (or applied) for one issue
+type Match struct {
+	Policy  string   `json:"policy"`
+	IssueID string   `json:"issue_id"`
+	Title   string   `json:"title"`
+	Action  Action   `json:"action"`
+	Reasons []string `json:"reasons"`
+	Change  string   `json:"change"`  // Human-readable, e.g. "priority P1 → P3"
+	Command string   `json:"command"` // bd equivalent
+	Applied bool     `json:"applied,omitempty"`
+	Error   string   `json:"error,omitempty"`
+
+	label    string
+	priority int
+	comment  string
+}
+
+// Evaluate returns the actions every policy would take, in policy order then
+// by issue ID. Actions that are already in effect (label present, priority
+// at or below the floor, assignee pinged since the last update) are left out,
+// so repeated runs are idempotent.
+func Evaluate(config *Config, issues []model.Issue, now time.Time) []Match {
+	if config == nil || len(config.Policies) == 0 {
+		return nil
+	}
+
+	closed := make(map[string]bool)
+	inbound := make(map[string]bool)
+	for i := range issues {
+		issue := &issues[i]
+		if issue.Status.IsClosed() || issue.Status.IsTombstone() {
+			closed[issue.ID] = true
+		}
+		for _, dep := range issue.Dependencies {
+			if dep != nil && dep.DependsOnID != issue.ID {
+				inbound[dep.DependsOnID] = true
+			}
+		}
+	}
+	mentioned := mentionedIDs(issues)
+
+	var matches []Match
+	for _, p := range config.Policies {
+		var policyMatches []Match
+		for i := range issues {
+			issue := &issues[i]
+			if closed[issue.ID] {
+				continue
+			}
+			reasons, ok := p.When.match(issue, now, closed, inbound[issue.ID] || mentioned[issue.ID])
+			if !ok {
+				continue
+			}
+			if m, ok := plan(p, issue, reasons); ok {
+				policyMatches = append(policyMatches, m)
+			}
+		}
+		sort.Slice(policyMatches, func(i, j int) bool { return policyMatches[i].IssueID < policyMatches[j].IssueID })
+		matches = append(matches, policyMatches...)
+	}
+	return matches
+}
+
+// match reports whether issue meets every condition, with a reason for each
+func (w Conditions) match(issue *model.Issue, now time.Time, closed map[string]bool, referenced bool) ([]string, bool) {
+	if len(w.Status) > 0 && !containsStatus(w.Status, issue.Status) {
+		return nil, false
+	}
+	if len(w.Labels) > 0 && !hasAnyLabel(issue.Labels, w.Labels) {
+		return nil, false
+	}
+
+	var reasons []string
+	if w.NoUpdateDays > 0 {
+		last := issue.UpdatedAt
+		if last.IsZero() {
+			last = issue.CreatedAt
+		}
+		if last.IsZero() {
+			return nil, false
+		}
+		idle := int(now.Sub(last).Hours() / 24)
+		if idle < w.NoUpdateDays {
+			return nil, false
+		}
+		reasons = append(reasons, fmt.Sprintf("no update in %d days", idle))
+	}
+	if w.BlockedByClosed {
+		var blockers []string
+		for _, dep := range issue.Dependencies {
+			if dep != nil && dep.Type.IsBlocking() && closed[dep.DependsOnID] {
+				blockers = append(blockers, dep.DependsOnID)
+			}
+		}
+		if len(blockers) == 0 {
+			return nil, false
+		}
+		sort.Strings(blockers)
+		reasons = append(reasons, "blocked by closed "+strings.Join(blockers, ", "))
+	}
+	if w.NoInboundRefs {
+		if referenced {
+			return nil, false
+		}
+		reasons = append(reasons, "no other issue depends on or mentions it")
+	}
+	return reasons, true
+}
+
+// plan builds the change a policy makes to issue, or false if the change is
+// already in effect or impossible (e.g. pinging an unassigned issue)
+func plan(p Policy, issue *model.Issue, reasons []string) (Match, bool) {
+	m := Match{Policy: p.Name, IssueID: issue.ID, Title: issue.Title, Action: p.Action, Reasons: reasons}
+	switch p.Action {
+	case ActionMarkForClosure:
+		for _, l := range issue.Labels {
+			if l == p.Label {
+				return Match{}, false
+			}
+		}
+		m.label = p.Label
+		m.Change = "add label " + p.Label
+		m.Command = fmt.Sprintf("bd update %s --add-label=%s", issue.ID, p.Label)
+	case ActionDeprioritize:
+		if issue.Priority >= *p.Priority {
+			return Match{}, false
+		}
+		m.priority = *p.Priority
+		m.Change = fmt.Sprintf("priority P%d → P%d", issue.Priority, *p.Priority)
+		m.Command = fmt.Sprintf("bd update %s --priority=%d", issue.ID, *p.Priority)
+	case ActionPing:
+		assignee := strings.TrimSpace(issue.Assignee)
+		if assignee == "" || pingedSinceUpdate(issue, p.Name) {
+			return Match{}, false
+		}
+		m.comment = fmt.Sprintf("@%s %s %s", assignee, p.Message, pingMarker(p.Name))
+		m.Change = "comment mentioning @" + assignee
+		m.Command = fmt.Sprintf("bd comment %s %s", issue.ID, strconv.Quote(m.comment))
+	}
+	return m, true
+}
+
+// pingMarker tags ping comments so later runs can tell they were sent
+func pingMarker(policy string) string {
+	return "(policy: " + policy + ")"
+}
+
+// pingedSinceUpdate reports whether policy already pinged issue after its
+// last update. Comments do not bump updated_at, so one ping per idle spell.
+func pingedSinceUpdate(issue *model.Issue, policy string) bool {
+	marker := pingMarker(policy)
+	for _, c := range issue.Comments {
+		if c != nil && strings.Contains(c.Text, marker) && !c.CreatedAt.Before(issue.UpdatedAt) {
+			return true
+		}
+	}
+	return false
+}
+
+// mentionedIDs returns the issue IDs that appear in another issue's title,
+// description, notes or comments
+func mentionedIDs(issues []model.Issue) map[string]bool {
+	byLower := make(map[string]string, len(issues))
+	for i := range issues {
+		byLower[strings.ToLower(issues[i].ID)] = issues[i].ID
+	}
+	isIDChar := func(r rune) bool {
+		return r == '-' || r == '_' || r == '.' || (r >= '0' && r <= '9') || (r >= 'a' && r <= 'z')
+	}
+
+	mentioned := make(map[string]bool)
+	for i := range issues {
+		issue := &issues[i]
+		texts := []string{issue.Title, issue.Description, issue.Notes}
+		for _, c := range issue.Comments {
+			if c != nil {
+				texts = append(texts, c.Text)
+			}
+		}
+		self := strings.ToLower(issue.ID)
+		for _, text := range texts {
+			for _, token := range strings.FieldsFunc(strings.ToLower(text), func(r rune) bool { return !isIDChar(r) }) {
+				token = strings.TrimRight(token, ".")
+				if id, ok := byLower[token]; ok && token != self {
+					mentioned[id] = true
+				}
+			}
+		}
+	}
+	return mentioned
+}
+
+func containsStatus(statuses []model.Status, s model.Status) bool {
+	for _, want := range statuses {
+		if want == s {
+			return true
+		}
+	}
+	return false
+}
+
+func hasAnyLabel(labels, want []string) bool {
+	for _, l := range labels {
+		for _, w := range want {
+			if strings.EqualFold(l, w) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Apply writes each planned change to the beads file at path and returns the
+// matches with Applied or Error set. Successful edits are recorded in journal
+// (if non-nil) so they can be undone from the TUI. A failed edit does not
+// stop the others.
+func Apply(path string, matches []Match, journal *loader.Journal, now time.Time) []Match {
+	applied := make([]Match, len(matches))
+	for i, m := range matches {
+		var edit *loader.LineEdit
+		var err error
+		switch m.Action {
+		case ActionMarkForClosure:
+			edit, err = loader.AddLabels(path, m.IssueID, []string{m.label})
+		case ActionDeprioritize:
+			edit, err = loader.SetPriority(path, m.IssueID, m.priority, now)
+		case ActionPing:
+			edit, err = loader.AddComment(path, m.IssueID, model.Comment{
+				Author:    pingAuthor(),
+				Text:      m.comment,
+				CreatedAt: now.UTC(),
+			})
+		}
+		switch {
+		case err != nil:
+			m.Error = err.Error()
+		case edit == nil:
+			m.Error = "issue not found or already changed"
+		default:
+			m.Applied = true
+			if journal != nil {
+				if err := journal.Record(path, fmt.Sprintf("Policy %s: %s on %s", m.Policy, m.Change, m.IssueID), edit); err != nil {
+					m.Error = "applied, but not recorded for undo: " + err.Error()
+				}
+			}
+		}
+		applied[i] = m
+	}
+	return applied
+}
+
+// pingAuthor is the comment author for pings, like comments added in the TUI
+func pingAuthor() string {
+	if actor := analysis.CurrentActor(); actor != "" {
+		return actor
+	}
+	return "bv"
+}
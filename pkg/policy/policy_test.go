@@ -0,0 +1,239 @@
+package policy
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func writeConfig(t *testing.T, content string) string {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, ".bv"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(ConfigPath(dir), []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func TestLoadConfig_Missing(t *testing.T) {
+	cfg, err := LoadConfig(t.TempDir())
+	if err != nil {
+		t.Fatalf("missing config should not be an error: %v", err)
+	}
+	if len(cfg.Policies) != 0 {
+		t.Errorf("expected no policies, got %+v", cfg.Policies)
+	}
+}
+
+func TestLoadConfig_ExampleAndDefaults(t *testing.T) {
+	cfg, err := LoadConfig(writeConfig(t, ExampleConfig()))
+	if err != nil {
+		t.Fatalf("example config should load: %v", err)
+	}
+	if len(cfg.Policies) != 3 {
+		t.Fatalf("expected 3 example policies, got %d", len(cfg.Policies))
+	}
+
+	cfg, err = LoadConfig(writeConfig(t, `policies:
+  - when: {no_update_days: 30}
+    action: mark_for_closure
+  - when: {no_update_days: 30}
+    action: deprioritize
+  - when: {no_update_days: 30}
+    action: ping
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := cfg.Policies
+	if p[0].Name != "policy-1" || p[0].Label != DefaultClosureLabel {
+		t.Errorf("closure defaults not applied: %+v", p[0])
+	}
+	if p[1].Priority == nil || *p[1].Priority != DefaultPriority {
+		t.Errorf("priority default not applied: %+v", p[1])
+	}
+	if p[2].Message != DefaultPingMessage {
+		t.Errorf("ping message default not applied: %+v", p[2])
+	}
+}
+
+func TestLoadConfig_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{"bad yaml", "policies: [", "parsing"},
+		{"no conditions", "policies:\n  - {name: x, action: ping}\n", "needs at least one of"},
+		{"unknown action", "policies:\n  - {name: x, when: {no_update_days: 1}, action: delete}\n", "unknown action"},
+		{"bad priority", "policies:\n  - {name: x, when: {no_update_days: 1}, action: deprioritize, priority: 9}\n", "priority"},
+		{"closed status", "policies:\n  - {name: x, when: {status: [closed]}, action: ping}\n", "closed"},
+		{"duplicate name", "policies:\n  - {name: x, when: {no_update_days: 1}, action: ping}\n  - {name: x, when: {no_update_days: 2}, action: ping}\n", "duplicate"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadConfig(writeConfig(t, tt.content))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func intPtr(n int) *int { return &n }
+
+func TestEvaluate(t *testing.T) {
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	old := now.AddDate(0, 0, -100)
+	recent := now.AddDate(0, 0, -2)
+	issues := []model.Issue{
+		{ID: "idle", Title: "Idle", Status: model.StatusOpen, UpdatedAt: old},
+		{ID: "idle-labeled", Status: model.StatusOpen, UpdatedAt: old, Labels: []string{"close-candidate"}},
+		{ID: "idle-referenced", Status: model.StatusOpen, UpdatedAt: old},
+		{ID: "idle-mentioned", Status: model.StatusOpen, UpdatedAt: old},
+		{ID: "fresh", Status: model.StatusOpen, UpdatedAt: recent, Description: "see idle-mentioned."},
+		{ID: "closed-old", Status: model.StatusClosed, UpdatedAt: old},
+		{ID: "waiting", Status: model.StatusBlocked, Priority: 1, UpdatedAt: recent,
+			Dependencies: []*model.Dependency{
+				{IssueID: "waiting", DependsOnID: "closed-old", Type: model.DepBlocks},
+				{IssueID: "waiting", DependsOnID: "idle-referenced", Type: model.DepRelated},
+			}},
+		{ID: "wip", Status: model.StatusInProgress, Assignee: "alice", UpdatedAt: old},
+		{ID: "wip-unassigned", Status: model.StatusInProgress, UpdatedAt: old},
+	}
+	cfg := &Config{Policies: []Policy{
+		{Name: "close-abandoned", When: Conditions{NoUpdateDays: 90, NoInboundRefs: true}, Action: ActionMarkForClosure},
+		{Name: "stale-blockers", When: Conditions{BlockedByClosed: true}, Action: ActionDeprioritize, Priority: intPtr(3)},
+		{Name: "nudge", When: Conditions{NoUpdateDays: 14, Status: []model.Status{model.StatusInProgress}}, Action: ActionPing},
+	}}
+	if err := cfg.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	matches := Evaluate(cfg, issues, now)
+	var got []string
+	for _, m := range matches {
+		got = append(got, m.Policy+":"+m.IssueID)
+	}
+	want := "close-abandoned:idle,close-abandoned:wip,close-abandoned:wip-unassigned,stale-blockers:waiting,nudge:wip"
+	if strings.Join(got, ",") != want {
+		t.Fatalf("matches = %s\nwant      %s", strings.Join(got, ","), want)
+	}
+
+	idle := matches[0]
+	if idle.Command != "bd update idle --add-label=close-candidate" || idle.Change != "add label close-candidate" {
+		t.Errorf("closure plan = %+v", idle)
+	}
+	if strings.Join(idle.Reasons, "; ") != "no update in 100 days; no other issue depends on or mentions it" {
+		t.Errorf("reasons = %v", idle.Reasons)
+	}
+	waiting := matches[3]
+	if waiting.Change != "priority P1 → P3" || waiting.Command != "bd update waiting --priority=3" {
+		t.Errorf("deprioritize plan = %+v", waiting)
+	}
+	if len(waiting.Reasons) != 1 || waiting.Reasons[0] != "blocked by closed closed-old" {
+		t.Errorf("reasons = %v", waiting.Reasons)
+	}
+	ping := matches[4]
+	if ping.comment != "@alice "+DefaultPingMessage+" (policy: nudge)" || !strings.HasPrefix(ping.Command, `bd comment wip "@alice`) {
+		t.Errorf("ping plan = %+v (comment %q)", ping, ping.comment)
+	}
+}
+
+func TestEvaluate_Idempotent(t *testing.T) {
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	updated := now.AddDate(0, 0, -30)
+	issues := []model.Issue{
+		{ID: "low", Status: model.StatusOpen, Priority: 4, UpdatedAt: updated},
+		{ID: "pinged", Status: model.StatusOpen, Priority: 3, Assignee: "bob", UpdatedAt: updated,
+			Comments: []*model.Comment{{Text: "@bob still on it? (policy: nudge)", CreatedAt: updated.Add(time.Hour)}}},
+		{ID: "pinged-before-update", Status: model.StatusOpen, Priority: 3, Assignee: "bob", UpdatedAt: updated,
+			Comments: []*model.Comment{{Text: "@bob still on it? (policy: nudge)", CreatedAt: updated.Add(-time.Hour)}}},
+	}
+	cfg := &Config{Policies: []Policy{
+		{Name: "demote", When: Conditions{NoUpdateDays: 7}, Action: ActionDeprioritize},
+		{Name: "nudge", When: Conditions{NoUpdateDays: 7}, Action: ActionPing},
+	}}
+	if err := cfg.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	matches := Evaluate(cfg, issues, now)
+	if len(matches) != 1 || matches[0].IssueID != "pinged-before-update" || matches[0].Action != ActionPing {
+		t.Errorf("only the issue updated since its last ping should match, got %+v", matches)
+	}
+	if got := Evaluate(&Config{}, issues, now); len(got) != 0 {
+		t.Errorf("empty config should match nothing, got %+v", got)
+	}
+}
+
+func TestApply(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "issues.jsonl")
+	content := `{"id":"a","title":"Idle","status":"open","priority":1,"issue_type":"task","assignee":"alice","updated_at":"2025-01-01T00:00:00Z"}` + "\n" +
+		`{"id":"b","title":"Other","status":"open","priority":2,"issue_type":"task","updated_at":"2025-01-01T00:00:00Z"}` + "\n"
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	issues, err := loader.LoadIssuesFromFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	journal, err := loader.OpenJournal(filepath.Join(t.TempDir(), "journal.json"), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	cfg := &Config{Policies: []Policy{
+		{Name: "close", When: Conditions{NoUpdateDays: 90}, Action: ActionMarkForClosure},
+		{Name: "demote", When: Conditions{NoUpdateDays: 90}, Action: ActionDeprioritize},
+		{Name: "nudge", When: Conditions{NoUpdateDays: 90}, Action: ActionPing},
+	}}
+	if err := cfg.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	planned := Evaluate(cfg, issues, now)
+	if len(planned) != 5 {
+		t.Fatalf("expected 5 planned actions, got %+v", planned)
+	}
+
+	// An issue that is not in the file reports an error without stopping the rest
+	planned = append(planned, Match{Policy: "close", IssueID: "missing", Action: ActionMarkForClosure, label: "x"})
+	applied := Apply(path, planned, journal, now)
+	for _, m := range applied[:5] {
+		if !m.Applied || m.Error != "" {
+			t.Errorf("expected %s/%s applied, got %+v", m.Policy, m.IssueID, m)
+		}
+	}
+	if last := applied[5]; last.Applied || last.Error == "" {
+		t.Errorf("missing issue should report an error, got %+v", last)
+	}
+	if journal.UndoDepth() != 5 {
+		t.Errorf("journal depth = %d, want 5", journal.UndoDepth())
+	}
+
+	reloaded, err := loader.LoadIssuesFromFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	a := reloaded[0]
+	if strings.Join(a.Labels, ",") != DefaultClosureLabel || a.Priority != DefaultPriority {
+		t.Errorf("a = labels %v priority %d", a.Labels, a.Priority)
+	}
+	if len(a.Comments) != 1 || !strings.HasPrefix(a.Comments[0].Text, "@alice ") {
+		t.Errorf("a comments = %+v", a.Comments)
+	}
+
+	// A second run has nothing left to do
+	if again := Evaluate(cfg, reloaded, now); len(again) != 0 {
+		t.Errorf("second run should be a no-op, got %+v", again)
+	}
+}
@@ -0,0 +1,256 @@
+// Package provenance records what produced a robot payload or export
+// artifact (data hash, git commit, bv version, flags) and signs it with an
+// SSH or minisign key, so automation downstream can check that a payload is
+// authentic and still matches the data.
+package provenance
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// EnvVar embeds provenance in every robot payload when set to 1
+const EnvVar = "BV_PROVENANCE"
+
+// SignKeyEnvVar names the signing key when --sign is not given
+const SignKeyEnvVar = "BV_SIGN_KEY"
+
+// Namespace is the ssh-keygen signature namespace, so a bv signature can't
+// be passed off as one made for another purpose
+const Namespace = "bv"
+
+// SidecarSuffix names the provenance file written next to an export
+const SidecarSuffix = ".provenance.json"
+
+// DirManifest is the provenance file written inside an exported directory
+const DirManifest = "provenance.json"
+
+// SignatureSuffix names a detached signature
+const SignatureSuffix = ".sig"
+
+// Provenance records how a payload or artifact was produced
+type Provenance struct {
+	DataHash  string            `json:"data_hash,omitempty"`
+	GitCommit string            `json:"git_commit,omitempty"`
+	GitDirty  bool              `json:"git_dirty,omitempty"` // Uncommitted changes under .beads
+	Version   string            `json:"bv_version"`
+	Flags     map[string]string `json:"flags,omitempty"`
+	// Files maps each file of an export artifact, relative to it, to its
+	// SHA-256
+	Files map[string]string `json:"files,omitempty"`
+}
+
+// Enabled reports whether BV_PROVENANCE requests provenance
+func Enabled() bool {
+	return os.Getenv(EnvVar) == "1"
+}
+
+// GitState returns the commit checked out in dir and whether .beads has
+// uncommitted changes. Outside a repository both are empty.
+func GitState(dir string) (commit string, dirty bool) {
+	out, err := exec.Command("git", "-C", dir, "rev-parse", "HEAD").Output()
+	if err != nil {
+		return "", false
+	}
+	commit = strings.TrimSpace(string(out))
+	status, err := exec.Command("git", "-C", dir, "status", "--porcelain", "--", ".beads").Output()
+	return commit, err == nil && len(bytes.TrimSpace(status)) > 0
+}
+
+// HashFiles returns the SHA-256 of path, or of every file under it when it
+// is a directory, keyed by slash-separated path relative to it. Provenance
+// files and signatures are skipped.
+func HashFiles(path string) (map[string]string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	hashes := make(map[string]string)
+	if !info.IsDir() {
+		sum, err := hashFile(path)
+		if err != nil {
+			return nil, err
+		}
+		hashes[filepath.Base(path)] = sum
+		return hashes, nil
+	}
+	err = filepath.WalkDir(path, func(p string, d os.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(path, p)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if rel == DirManifest || rel == DirManifest+SignatureSuffix {
+			return nil
+		}
+		sum, err := hashFile(p)
+		if err != nil {
+			return err
+		}
+		hashes[rel] = sum
+		return nil
+	})
+	return hashes, err
+}
+
+func hashFile(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// CheckFiles compares the files of an artifact against recorded hashes and
+// returns the files that are missing, changed or unrecorded
+func CheckFiles(path string, recorded map[string]string) ([]string, error) {
+	current, err := HashFiles(path)
+	if err != nil {
+		return nil, err
+	}
+	var problems []string
+	for name, sum := range recorded {
+		switch got, ok := current[name]; {
+		case !ok:
+			problems = append(problems, name+": missing")
+		case got != sum:
+			problems = append(problems, name+": changed")
+		}
+	}
+	for name := range current {
+		if _, ok := recorded[name]; !ok {
+			problems = append(problems, name+": not in provenance")
+		}
+	}
+	sort.Strings(problems)
+	return problems, nil
+}
+
+// KeyKind is the signature scheme of a key file
+type KeyKind string
+
+const (
+	KeySSH      KeyKind = "ssh"
+	KeyMinisign KeyKind = "minisign"
+)
+
+// DetectKey tells an SSH key from a minisign key by its contents. Both
+// private and public keys are recognized.
+func DetectKey(path string) (KeyKind, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("reading key: %w", err)
+	}
+	text := strings.TrimSpace(string(data))
+	switch {
+	case strings.HasPrefix(text, "untrusted comment:"), strings.HasPrefix(text, "RW"):
+		return KeyMinisign, nil
+	case strings.HasPrefix(text, "-----BEGIN"), strings.HasPrefix(text, "ssh-"),
+		strings.HasPrefix(text, "ecdsa-"), strings.HasPrefix(text, "sk-"):
+		return KeySSH, nil
+	}
+	return "", fmt.Errorf("%s is neither an SSH nor a minisign key", path)
+}
+
+// Sign returns a detached signature of data made with the private key at
+// keyPath: ssh-keygen -Y sign for SSH keys (a public key works when its
+// private half is in the agent), minisign -S for minisign keys. Passphrase
+// prompts go to the terminal.
+func Sign(data []byte, keyPath string) ([]byte, error) {
+	kind, err := DetectKey(keyPath)
+	if err != nil {
+		return nil, err
+	}
+	if kind == KeySSH {
+		cmd := exec.Command("ssh-keygen", "-q", "-Y", "sign", "-n", Namespace, "-f", keyPath)
+		cmd.Stdin = bytes.NewReader(data)
+		return runSigner(cmd)
+	}
+
+	dir, err := os.MkdirTemp("", "bv-sign-*")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+	msg, sig := filepath.Join(dir, "payload"), filepath.Join(dir, "payload.minisig")
+	if err := os.WriteFile(msg, data, 0o600); err != nil {
+		return nil, err
+	}
+	cmd := exec.Command("minisign", "-S", "-s", keyPath, "-m", msg, "-x", sig)
+	cmd.Stdin = os.Stdin
+	if _, err := runSigner(cmd); err != nil {
+		return nil, err
+	}
+	return os.ReadFile(sig)
+}
+
+// Verify checks a detached signature of data against the public key at
+// pubKeyPath
+func Verify(data, sig []byte, pubKeyPath string) error {
+	kind, err := DetectKey(pubKeyPath)
+	if err != nil {
+		return err
+	}
+	dir, err := os.MkdirTemp("", "bv-verify-*")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+	sigPath := filepath.Join(dir, "payload.sig")
+	if err := os.WriteFile(sigPath, sig, 0o600); err != nil {
+		return err
+	}
+
+	if kind == KeySSH {
+		pub, err := os.ReadFile(pubKeyPath)
+		if err != nil {
+			return err
+		}
+		signers := filepath.Join(dir, "allowed_signers")
+		line := Namespace + " " + strings.TrimSpace(string(pub)) + "\n"
+		if err := os.WriteFile(signers, []byte(line), 0o600); err != nil {
+			return err
+		}
+		cmd := exec.Command("ssh-keygen", "-Y", "verify", "-f", signers, "-I", Namespace, "-n", Namespace, "-s", sigPath)
+		cmd.Stdin = bytes.NewReader(data)
+		_, err = runSigner(cmd)
+		return err
+	}
+
+	msg := filepath.Join(dir, "payload")
+	if err := os.WriteFile(msg, data, 0o600); err != nil {
+		return err
+	}
+	_, err = runSigner(exec.Command("minisign", "-V", "-q", "-p", pubKeyPath, "-m", msg, "-x", sigPath))
+	return err
+}
+
+// runSigner runs a signing tool, folding its stderr into the error
+func runSigner(cmd *exec.Cmd) ([]byte, error) {
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("%s: %w: %s", filepath.Base(cmd.Path), err, msg)
+		}
+		return nil, fmt.Errorf("%s: %w", filepath.Base(cmd.Path), err)
+	}
+	return out, nil
+}
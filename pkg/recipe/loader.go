@@ -7,6 +7,7 @@ import (
 	"path/filepath"
 	"sort"
 
+	"github.com/Dicklesworthstone/beads_viewer/pkg/configdir"
 	"gopkg.in/yaml.v3"
 )
 
@@ -64,10 +65,7 @@ func NewLoader(opts ...LoaderOption) *Loader {
 
 	// Set defaults
 	if l.userPath == "" {
-		home, err := os.UserHomeDir()
-		if err == nil {
-			l.userPath = filepath.Join(home, ".config", "bv", "recipes.yaml")
-		}
+		l.userPath = configdir.Path("recipes.yaml")
 	}
 
 	if l.projectDir == "" {
@@ -0,0 +1,304 @@
+// Package scaffold creates new issues from templates.
+//
+// Templates are YAML files in .bv/templates/, one per template and named
+// after the file; they override the built-in bug, feature, task and chore
+// templates. A template fills in the fields bv's analysis relies on (type,
+// priority, labels, estimate) plus description and acceptance-criteria
+// skeletons, and links the new issue to its epic.
+package scaffold
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed defaults/*.yaml
+var builtinFS embed.FS
+
+// Epic settings with special meaning; anything else is an epic ID
+const (
+	// EpicAuto links to the open epic sharing the most labels (the default)
+	EpicAuto = "auto"
+	// EpicNone never links to an epic
+	EpicNone = "none"
+)
+
+// Template describes the issue a template creates
+type Template struct {
+	Name        string          `yaml:"-" json:"name"`
+	Description string          `yaml:"description" json:"description"` // What the template is for
+	IssueType   model.IssueType `yaml:"issue_type" json:"issue_type"`
+	Priority    *int            `yaml:"priority,omitempty" json:"priority,omitempty"`
+	Labels      []string        `yaml:"labels,omitempty" json:"labels,omitempty"`
+	TitlePrefix string          `yaml:"title_prefix,omitempty" json:"title_prefix,omitempty"`
+	// Body, Design and AcceptanceCriteria are skeletons to fill in
+	Body               string   `yaml:"body,omitempty" json:"body,omitempty"`
+	Design             string   `yaml:"design,omitempty" json:"design,omitempty"`
+	AcceptanceCriteria []string `yaml:"acceptance_criteria,omitempty" json:"acceptance_criteria,omitempty"`
+	EstimatedMinutes   *int     `yaml:"estimated_minutes,omitempty" json:"estimated_minutes,omitempty"`
+	// Epic is an epic ID, "auto" (default) or "none"
+	Epic   string `yaml:"epic,omitempty" json:"epic,omitempty"`
+	Source string `yaml:"-" json:"source"` // "builtin" or the template file
+}
+
+// TemplatesDir returns the project template directory
+func TemplatesDir(projectDir string) string {
+	return filepath.Join(projectDir, ".bv", "templates")
+}
+
+// LoadTemplates returns the built-in templates overridden by the project's,
+// keyed by name. An invalid project template is an error rather than being
+// skipped, so a typo does not silently fall back to a built-in.
+func LoadTemplates(projectDir string) (map[string]*Template, error) {
+	templates := make(map[string]*Template)
+	builtins, err := fs.Glob(builtinFS, "defaults/*.yaml")
+	if err != nil {
+		return nil, err
+	}
+	for _, path := range builtins {
+		data, err := builtinFS.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		t, err := parseTemplate(templateName(path), "builtin", data)
+		if err != nil {
+			return nil, fmt.Errorf("builtin template: %w", err)
+		}
+		templates[t.Name] = t
+	}
+
+	if projectDir == "" {
+		return templates, nil
+	}
+	paths, err := filepath.Glob(filepath.Join(TemplatesDir(projectDir), "*.yaml"))
+	if err != nil {
+		return nil, err
+	}
+	ymlPaths, _ := filepath.Glob(filepath.Join(TemplatesDir(projectDir), "*.yml"))
+	for _, path := range append(paths, ymlPaths...) {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("reading template: %w", err)
+		}
+		t, err := parseTemplate(templateName(path), path, data)
+		if err != nil {
+			return nil, err
+		}
+		templates[t.Name] = t
+	}
+	return templates, nil
+}
+
+// Names returns the template names in order
+func Names(templates map[string]*Template) []string {
+	names := make([]string, 0, len(templates))
+	for name := range templates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func templateName(path string) string {
+	return strings.TrimSuffix(strings.TrimSuffix(filepath.Base(path), ".yaml"), ".yml")
+}
+
+func parseTemplate(name, source string, data []byte) (*Template, error) {
+	var t Template
+	if err := yaml.Unmarshal(data, &t); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", source, err)
+	}
+	t.Name, t.Source = name, source
+	if t.IssueType == "" {
+		t.IssueType = model.TypeTask
+	}
+	if !t.IssueType.IsValid() {
+		return nil, fmt.Errorf("%s: invalid issue_type %q", source, t.IssueType)
+	}
+	if t.Priority != nil && (*t.Priority < 0 || *t.Priority > 4) {
+		return nil, fmt.Errorf("%s: priority must be 0-4, got %d", source, *t.Priority)
+	}
+	if t.Epic == "" {
+		t.Epic = EpicAuto
+	}
+	return &t, nil
+}
+
+// Options are the per-issue inputs to New
+type Options struct {
+	Title    string
+	Assignee string
+	// Epic overrides the template's epic setting when non-empty
+	Epic string
+	// Actor is recorded as the creator of the epic link
+	Actor string
+	// Prefix is the ID prefix; default is the one most existing issues use
+	Prefix string
+	Now    time.Time
+}
+
+// ErrNoTitle is returned by New without a title
+var ErrNoTitle = errors.New("a title is required")
+
+// New builds an open issue from tmpl with an ID not used by issues, linked
+// (parent-child) to the template's epic. Epics themselves are never linked.
+func New(tmpl *Template, issues []model.Issue, opts Options) (model.Issue, error) {
+	title := strings.TrimSpace(opts.Title)
+	if title == "" {
+		return model.Issue{}, ErrNoTitle
+	}
+	if opts.Now.IsZero() {
+		opts.Now = time.Now()
+	}
+	now := opts.Now.UTC()
+	prefix := opts.Prefix
+	if prefix == "" {
+		prefix = IDPrefix(issues)
+	}
+
+	issue := model.Issue{
+		ID:          NewID(prefix, issues),
+		Title:       tmpl.TitlePrefix + title,
+		Description: tmpl.Body,
+		Design:      tmpl.Design,
+		Status:      model.StatusOpen,
+		Priority:    2,
+		IssueType:   tmpl.IssueType,
+		Assignee:    opts.Assignee,
+		Labels:      append([]string(nil), tmpl.Labels...),
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if tmpl.Priority != nil {
+		issue.Priority = *tmpl.Priority
+	}
+	if tmpl.EstimatedMinutes != nil {
+		minutes := *tmpl.EstimatedMinutes
+		issue.EstimatedMinutes = &minutes
+	}
+	if len(tmpl.AcceptanceCriteria) > 0 {
+		lines := make([]string, len(tmpl.AcceptanceCriteria))
+		for i, c := range tmpl.AcceptanceCriteria {
+			lines[i] = "- [ ] " + c
+		}
+		issue.AcceptanceCriteria = strings.Join(lines, "\n")
+	}
+
+	epic := tmpl.Epic
+	if opts.Epic != "" {
+		epic = opts.Epic
+	}
+	if issue.IssueType != model.TypeEpic {
+		epicID, err := resolveEpic(epic, issue.Labels, issues)
+		if err != nil {
+			return model.Issue{}, err
+		}
+		if epicID != "" {
+			issue.Dependencies = []*model.Dependency{{
+				IssueID:     issue.ID,
+				DependsOnID: epicID,
+				Type:        model.DepParentChild,
+				CreatedAt:   now,
+				CreatedBy:   opts.Actor,
+			}}
+		}
+	}
+	return issue, nil
+}
+
+// resolveEpic returns the epic to link to, or "" for none. "auto" picks the
+// open epic sharing the most labels; a tie links nothing rather than guess.
+func resolveEpic(epic string, labels []string, issues []model.Issue) (string, error) {
+	switch epic {
+	case EpicNone, "":
+		return "", nil
+	case EpicAuto:
+	default:
+		for i := range issues {
+			if issues[i].ID == epic {
+				return epic, nil
+			}
+		}
+		return "", fmt.Errorf("epic %s not found", epic)
+	}
+
+	want := make(map[string]bool, len(labels))
+	for _, l := range labels {
+		want[strings.ToLower(l)] = true
+	}
+	best, bestShared, tied := "", 0, false
+	for i := range issues {
+		e := &issues[i]
+		if e.IssueType != model.TypeEpic || e.Status.IsClosed() || e.Status.IsTombstone() {
+			continue
+		}
+		shared := 0
+		for _, l := range e.Labels {
+			if want[strings.ToLower(l)] {
+				shared++
+			}
+		}
+		switch {
+		case shared == 0 || shared < bestShared:
+		case shared == bestShared:
+			tied = true
+		default:
+			best, bestShared, tied = e.ID, shared, false
+		}
+	}
+	if tied {
+		return "", nil
+	}
+	return best, nil
+}
+
+// IDPrefix returns the ID prefix most issues use ("bv" for "bv-12"),
+// falling back to "bd"
+func IDPrefix(issues []model.Issue) string {
+	counts := make(map[string]int)
+	for i := range issues {
+		if idx := strings.LastIndex(issues[i].ID, "-"); idx > 0 {
+			counts[issues[i].ID[:idx]]++
+		}
+	}
+	best, bestCount := "bd", 0
+	for prefix, n := range counts {
+		if n > bestCount || (n == bestCount && prefix < best) {
+			best, bestCount = prefix, n
+		}
+	}
+	return best
+}
+
+const idAlphabet = "0123456789abcdefghijklmnopqrstuvwxyz"
+
+// NewID returns prefix-xxxx with a random base-36 suffix, in the style of bd
+// hash IDs, that no issue uses yet. The suffix grows if short ones collide.
+func NewID(prefix string, issues []model.Issue) string {
+	taken := make(map[string]bool, len(issues))
+	for i := range issues {
+		taken[issues[i].ID] = true
+	}
+	for length := 4; ; length++ {
+		for range 20 {
+			suffix := make([]byte, length)
+			for i := range suffix {
+				suffix[i] = idAlphabet[rand.IntN(len(idAlphabet))]
+			}
+			if id := prefix + "-" + string(suffix); !taken[id] {
+				return id
+			}
+		}
+	}
+}
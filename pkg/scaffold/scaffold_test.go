@@ -0,0 +1,157 @@
+package scaffold
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestLoadTemplates_Builtin(t *testing.T) {
+	templates, err := LoadTemplates(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(Names(templates), ","); got != "bug,chore,feature,task" {
+		t.Fatalf("builtin templates = %s", got)
+	}
+	bug := templates["bug"]
+	if bug.IssueType != model.TypeBug || bug.Priority == nil || *bug.Priority != 1 || bug.Source != "builtin" {
+		t.Errorf("bug template = %+v", bug)
+	}
+	if bug.Epic != EpicAuto || templates["chore"].Epic != EpicNone {
+		t.Errorf("epic defaults: bug %q chore %q", bug.Epic, templates["chore"].Epic)
+	}
+}
+
+func TestLoadTemplates_ProjectOverrides(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(TemplatesDir(dir), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	write := func(name, content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(TemplatesDir(dir), name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("bug.yaml", "issue_type: bug\npriority: 0\nlabels: [bug, triage]\n")
+	write("spike.yml", "description: Timeboxed research\nestimated_minutes: 240\n")
+
+	templates, err := LoadTemplates(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bug := templates["bug"]; *bug.Priority != 0 || !strings.HasSuffix(bug.Source, "bug.yaml") {
+		t.Errorf("project bug template should win, got %+v", bug)
+	}
+	if spike := templates["spike"]; spike == nil || spike.IssueType != model.TypeTask {
+		t.Errorf("spike should default to a task, got %+v", spike)
+	}
+
+	write("broken.yaml", "issue_type: story\n")
+	if _, err := LoadTemplates(dir); err == nil || !strings.Contains(err.Error(), "invalid issue_type") {
+		t.Errorf("invalid template: err = %v", err)
+	}
+}
+
+func TestNew(t *testing.T) {
+	templates, err := LoadTemplates("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	issues := []model.Issue{
+		{ID: "bv-1", Status: model.StatusOpen, IssueType: model.TypeTask},
+		{ID: "bv-2", Status: model.StatusOpen, IssueType: model.TypeEpic, Labels: []string{"bug", "ux"}},
+		{ID: "bv-3", Status: model.StatusOpen, IssueType: model.TypeEpic, Labels: []string{"api"}},
+		{ID: "bv-4", Status: model.StatusClosed, IssueType: model.TypeEpic, Labels: []string{"bug"}},
+		{ID: "other-1", Status: model.StatusOpen, IssueType: model.TypeTask},
+	}
+	now := time.Date(2025, 5, 1, 9, 0, 0, 0, time.UTC)
+
+	issue, err := New(templates["bug"], issues, Options{Title: " Crash on save ", Assignee: "alice", Actor: "alice", Now: now})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !regexp.MustCompile(`^bv-[0-9a-z]{4}$`).MatchString(issue.ID) {
+		t.Errorf("ID = %s, want the majority prefix and a 4-char suffix", issue.ID)
+	}
+	if issue.Title != "Crash on save" || issue.Status != model.StatusOpen || issue.IssueType != model.TypeBug || issue.Priority != 1 {
+		t.Errorf("issue = %+v", issue)
+	}
+	if issue.EstimatedMinutes == nil || *issue.EstimatedMinutes != 120 || !issue.CreatedAt.Equal(now) {
+		t.Errorf("estimate/created = %v %v", issue.EstimatedMinutes, issue.CreatedAt)
+	}
+	if !strings.HasPrefix(issue.AcceptanceCriteria, "- [ ] Root cause") || !strings.Contains(issue.Description, "Steps to reproduce") {
+		t.Errorf("skeletons missing:\n%s\n%s", issue.Description, issue.AcceptanceCriteria)
+	}
+	if err := issue.Validate(); err != nil {
+		t.Errorf("generated issue should be valid: %v", err)
+	}
+
+	// Auto-linked to the open epic sharing its "bug" label
+	if len(issue.Dependencies) != 1 {
+		t.Fatalf("dependencies = %+v", issue.Dependencies)
+	}
+	dep := issue.Dependencies[0]
+	if dep.DependsOnID != "bv-2" || dep.Type != model.DepParentChild || dep.IssueID != issue.ID || dep.CreatedBy != "alice" {
+		t.Errorf("epic link = %+v", dep)
+	}
+
+	// Explicit epic, no epic, unknown epic
+	if issue, _ := New(templates["bug"], issues, Options{Title: "x", Epic: "bv-3"}); len(issue.Dependencies) != 1 || issue.Dependencies[0].DependsOnID != "bv-3" {
+		t.Errorf("explicit epic: %+v", issue.Dependencies)
+	}
+	if issue, _ := New(templates["bug"], issues, Options{Title: "x", Epic: EpicNone}); len(issue.Dependencies) != 0 {
+		t.Errorf("epic none: %+v", issue.Dependencies)
+	}
+	if _, err := New(templates["bug"], issues, Options{Title: "x", Epic: "bv-404"}); err == nil {
+		t.Error("unknown epic should be an error")
+	}
+	// Tasks carry no labels, so nothing to match an epic on
+	if issue, _ := New(templates["task"], issues, Options{Title: "x"}); len(issue.Dependencies) != 0 {
+		t.Errorf("unlabeled task should not be linked: %+v", issue.Dependencies)
+	}
+
+	if _, err := New(templates["bug"], issues, Options{Title: "  "}); !errors.Is(err, ErrNoTitle) {
+		t.Errorf("blank title: err = %v", err)
+	}
+}
+
+func TestResolveEpic_TieLinksNothing(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "e1", Status: model.StatusOpen, IssueType: model.TypeEpic, Labels: []string{"api"}},
+		{ID: "e2", Status: model.StatusOpen, IssueType: model.TypeEpic, Labels: []string{"API"}},
+	}
+	if got, err := resolveEpic(EpicAuto, []string{"api"}, issues); err != nil || got != "" {
+		t.Errorf("tie should link nothing, got %q %v", got, err)
+	}
+	if got, _ := resolveEpic(EpicAuto, []string{"api", "db"}, append(issues,
+		model.Issue{ID: "e3", Status: model.StatusOpen, IssueType: model.TypeEpic, Labels: []string{"api", "db"}})); got != "e3" {
+		t.Errorf("best overlap should win, got %q", got)
+	}
+}
+
+func TestIDPrefixAndNewID(t *testing.T) {
+	if got := IDPrefix(nil); got != "bd" {
+		t.Errorf("empty project prefix = %s", got)
+	}
+	issues := []model.Issue{{ID: "my-app-1"}, {ID: "my-app-2"}, {ID: "x-1"}}
+	if got := IDPrefix(issues); got != "my-app" {
+		t.Errorf("prefix = %s, want my-app", got)
+	}
+	seen := make(map[string]bool)
+	for range 50 {
+		id := NewID("p", issues)
+		if seen[id] {
+			t.Fatalf("duplicate ID %s", id)
+		}
+		seen[id] = true
+		issues = append(issues, model.Issue{ID: id})
+	}
+}
@@ -4,8 +4,12 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/offline"
+	"gopkg.in/yaml.v3"
 )
 
 // EmbeddingConfigFromEnv reads semantic embedding configuration from environment variables.
@@ -14,6 +18,9 @@ import (
 //   - BV_SEMANTIC_EMBEDDER: embedding provider (default: "hash")
 //   - BV_SEMANTIC_MODEL: model identifier (provider-specific, optional)
 //   - BV_SEMANTIC_DIM: embedding dimension (default: DefaultEmbeddingDim)
+//
+// In offline mode (BV_OFFLINE) the provider is always "hash": the others
+// call hosted APIs or download models.
 func EmbeddingConfigFromEnv() EmbeddingConfig {
 	provider := strings.ToLower(strings.TrimSpace(os.Getenv(EnvSemanticEmbedder)))
 	cfg := EmbeddingConfig{
@@ -28,6 +35,10 @@ func EmbeddingConfigFromEnv() EmbeddingConfig {
 	if cfg.Provider == "" {
 		cfg.Provider = ProviderHash
 	}
+	if offline.Enabled() && cfg.Provider != ProviderHash {
+		cfg.Provider = ProviderHash
+		cfg.Model = ""
+	}
 	return cfg.Normalized()
 }
 
@@ -148,3 +159,44 @@ func isWeightKey(key string) bool {
 		return false
 	}
 }
+
+// ProjectConfigFilename is the per-project search config filename
+const ProjectConfigFilename = "search.yaml"
+
+// ProjectConfig holds per-project search settings from .bv/search.yaml.
+type ProjectConfig struct {
+	Duplicates DuplicatesConfig `yaml:"duplicates" json:"duplicates"`
+}
+
+// DuplicatesConfig tunes near-duplicate detection (--robot-duplicates and
+// the detail view badge).
+type DuplicatesConfig struct {
+	// Threshold is the minimum combined similarity (0-1) to flag a pair;
+	// 0 uses the detector default.
+	Threshold float64 `yaml:"threshold,omitempty" json:"threshold,omitempty"`
+}
+
+// ProjectConfigPath returns the search config path for a project
+func ProjectConfigPath(projectDir string) string {
+	return filepath.Join(projectDir, ".bv", ProjectConfigFilename)
+}
+
+// LoadProjectConfig loads .bv/search.yaml.
+// Returns an empty config if the file doesn't exist.
+func LoadProjectConfig(projectDir string) (ProjectConfig, error) {
+	var cfg ProjectConfig
+	data, err := os.ReadFile(ProjectConfigPath(projectDir))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return cfg, nil
+		}
+		return cfg, fmt.Errorf("reading search config: %w", err)
+	}
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return ProjectConfig{}, fmt.Errorf("parsing search config: %w", err)
+	}
+	if t := cfg.Duplicates.Threshold; t < 0 || t > 1 {
+		return ProjectConfig{}, fmt.Errorf("invalid search config: duplicates.threshold must be between 0 and 1, got %v", t)
+	}
+	return cfg, nil
+}
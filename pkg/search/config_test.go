@@ -2,8 +2,11 @@ package search
 
 import (
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/offline"
 )
 
 // =============================================================================
@@ -492,3 +495,56 @@ func TestDefaultEmbeddingDim(t *testing.T) {
 func (p Provider) String() string {
 	return string(p)
 }
+
+// =============================================================================
+// LoadProjectConfig Tests
+// =============================================================================
+
+func TestLoadProjectConfig(t *testing.T) {
+	dir := t.TempDir()
+
+	cfg, err := LoadProjectConfig(dir)
+	if err != nil || cfg.Duplicates.Threshold != 0 {
+		t.Fatalf("missing file should give an empty config, got %+v, %v", cfg, err)
+	}
+
+	if err := os.MkdirAll(filepath.Join(dir, ".bv"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	write := func(content string) {
+		t.Helper()
+		if err := os.WriteFile(ProjectConfigPath(dir), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	write("duplicates:\n  threshold: 0.85\n")
+	cfg, err = LoadProjectConfig(dir)
+	if err != nil {
+		t.Fatalf("LoadProjectConfig failed: %v", err)
+	}
+	if cfg.Duplicates.Threshold != 0.85 {
+		t.Errorf("threshold = %v, want 0.85", cfg.Duplicates.Threshold)
+	}
+
+	write("duplicates:\n  threshold: 1.5\n")
+	if _, err := LoadProjectConfig(dir); err == nil || !strings.Contains(err.Error(), "threshold") {
+		t.Errorf("out-of-range threshold should fail, got %v", err)
+	}
+
+	write("duplicates: [")
+	if _, err := LoadProjectConfig(dir); err == nil || !strings.Contains(err.Error(), "parsing search config") {
+		t.Errorf("bad YAML should fail, got %v", err)
+	}
+}
+
+func TestEmbeddingConfigFromEnv_OfflineForcesHash(t *testing.T) {
+	t.Setenv(EnvSemanticEmbedder, string(ProviderOpenAI))
+	t.Setenv(EnvSemanticModel, "text-embedding-3-small")
+	t.Setenv(offline.EnvVar, "1")
+
+	cfg := EmbeddingConfigFromEnv()
+	if cfg.Provider != ProviderHash || cfg.Model != "" {
+		t.Errorf("offline config = %+v, want the hash provider", cfg)
+	}
+}
@@ -1,6 +1,8 @@
 package search
 
 import (
+	"context"
+	"sort"
 	"strings"
 
 	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
@@ -45,3 +47,28 @@ func DocumentsFromIssues(issues []model.Issue) map[string]string {
 	}
 	return docs
 }
+
+// EmbedIssues embeds issue documents in memory, without touching the on-disk
+// index, and returns ID->vector. Useful for one-off similarity passes.
+func EmbedIssues(ctx context.Context, embedder Embedder, issues []model.Issue) (map[string][]float32, error) {
+	docs := DocumentsFromIssues(issues)
+	ids := make([]string, 0, len(docs))
+	for id := range docs {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	texts := make([]string, len(ids))
+	for i, id := range ids {
+		texts[i] = docs[id]
+	}
+
+	vecs, err := embedder.Embed(ctx, texts)
+	if err != nil {
+		return nil, err
+	}
+	vectors := make(map[string][]float32, len(ids))
+	for i, id := range ids {
+		vectors[id] = vecs[i]
+	}
+	return vectors, nil
+}
@@ -1,6 +1,7 @@
 package search
 
 import (
+	"context"
 	"testing"
 
 	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
@@ -325,3 +326,32 @@ func TestIssueDocument_PreservesContent(t *testing.T) {
 		t.Errorf("Content not preserved correctly:\ngot: %q\nwant: %q", result, expected)
 	}
 }
+
+// =============================================================================
+// EmbedIssues Tests
+// =============================================================================
+
+func TestEmbedIssues(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "a", Title: "Fix login"},
+		{ID: "b", Title: "Fix login"},
+		{ID: "c", Title: "Unrelated export work"},
+		{Title: "No ID"},
+	}
+	vectors, err := EmbedIssues(context.Background(), NewHashEmbedder(64), issues)
+	if err != nil {
+		t.Fatalf("EmbedIssues failed: %v", err)
+	}
+	if len(vectors) != 3 {
+		t.Fatalf("expected 3 vectors, got %d", len(vectors))
+	}
+	for id, vec := range vectors {
+		if len(vec) != 64 {
+			t.Errorf("vector %s has dim %d, want 64", id, len(vec))
+		}
+	}
+	// Same title but different IDs: close, not identical
+	if dotFloat32(vectors["a"], vectors["b"]) <= dotFloat32(vectors["a"], vectors["c"]) {
+		t.Error("issues with the same title should be closer than unrelated ones")
+	}
+}
@@ -450,6 +450,81 @@ func (g *Generator) Ladder(length int) GraphFixture {
 	}
 }
 
+// SparseDAG creates a random DAG where each node depends on up to
+// maxDeps distinct earlier nodes, so generation stays linear in size even
+// for tens of thousands of nodes (RandomDAG is quadratic).
+func (g *Generator) SparseDAG(size, maxDeps int) GraphFixture {
+	nodes := make([]string, size)
+	var edges [][2]int
+
+	for i := 0; i < size; i++ {
+		nodes[i] = fmt.Sprintf("n%d", i)
+		if i == 0 || maxDeps < 1 {
+			continue
+		}
+		seen := make(map[int]bool)
+		for d := g.rng.Intn(maxDeps) + 1; d > 0; d-- {
+			j := g.rng.Intn(i)
+			if !seen[j] {
+				seen[j] = true
+				edges = append(edges, [2]int{i, j}) // i depends on earlier j
+			}
+		}
+	}
+
+	return GraphFixture{
+		Description: fmt.Sprintf("Sparse random DAG with %d nodes, up to %d deps each (%d edges)", size, maxDeps, len(edges)),
+		Nodes:       nodes,
+		Edges:       edges,
+		Properties: Properties{
+			HasCycles:   false,
+			IsConnected: false, // May or may not be connected
+		},
+	}
+}
+
+// Clustered creates clusters of densely linked nodes (like epics or
+// teams) with a few links between clusters. Within a cluster each node
+// depends on up to 3 earlier nodes of the same cluster; every cluster after
+// the first has crossLinks nodes depending on random earlier clusters.
+// Properties: DAG
+func (g *Generator) Clustered(clusters, clusterSize, crossLinks int) GraphFixture {
+	size := clusters * clusterSize
+	nodes := make([]string, size)
+	var edges [][2]int
+
+	for c := 0; c < clusters; c++ {
+		base := c * clusterSize
+		for k := 0; k < clusterSize; k++ {
+			i := base + k
+			nodes[i] = fmt.Sprintf("c%d_%d", c, k)
+			seen := make(map[int]bool)
+			for d := 0; d < 3 && k > 0; d++ {
+				j := base + g.rng.Intn(k)
+				if !seen[j] {
+					seen[j] = true
+					edges = append(edges, [2]int{i, j})
+				}
+			}
+		}
+		for x := 0; x < crossLinks && c > 0; x++ {
+			from := base + g.rng.Intn(clusterSize)
+			to := g.rng.Intn(base) // Any node of an earlier cluster
+			edges = append(edges, [2]int{from, to})
+		}
+	}
+
+	return GraphFixture{
+		Description: fmt.Sprintf("%d clusters of %d nodes with %d cross-cluster links each", clusters, clusterSize, crossLinks),
+		Nodes:       nodes,
+		Edges:       edges,
+		Properties: Properties{
+			HasCycles:   false,
+			IsConnected: false, // Clusters may stay unlinked when crossLinks is 0
+		},
+	}
+}
+
 // ============================================================================
 // Issue Generators (convert graph fixtures to model.Issue slices)
 // ============================================================================
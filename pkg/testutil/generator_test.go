@@ -297,6 +297,48 @@ func TestRandomDAG(t *testing.T) {
 	}
 }
 
+func TestSparseDAG(t *testing.T) {
+	gf := NewDefault().SparseDAG(1000, 3)
+
+	if len(gf.Nodes) != 1000 {
+		t.Errorf("SparseDAG nodes = %d, want 1000", len(gf.Nodes))
+	}
+	// Every node but the first has 1-3 dependencies
+	if len(gf.Edges) < 999 || len(gf.Edges) > 3*999 {
+		t.Errorf("SparseDAG edges = %d, want 999..2997", len(gf.Edges))
+	}
+	seen := make(map[[2]int]bool)
+	for _, e := range gf.Edges {
+		if e[0] <= e[1] {
+			t.Errorf("SparseDAG has invalid edge [%d,%d] (should point to an earlier node)", e[0], e[1])
+		}
+		if seen[e] {
+			t.Errorf("SparseDAG has duplicate edge %v", e)
+		}
+		seen[e] = true
+	}
+}
+
+func TestClustered(t *testing.T) {
+	gf := NewDefault().Clustered(4, 25, 2)
+
+	if len(gf.Nodes) != 100 {
+		t.Errorf("Clustered nodes = %d, want 100", len(gf.Nodes))
+	}
+	cross := 0
+	for _, e := range gf.Edges {
+		if e[0] <= e[1] {
+			t.Errorf("Clustered has invalid edge [%d,%d] (should point to an earlier node)", e[0], e[1])
+		}
+		if e[0]/25 != e[1]/25 {
+			cross++
+		}
+	}
+	if cross != 3*2 {
+		t.Errorf("Clustered cross-cluster edges = %d, want 6", cross)
+	}
+}
+
 func TestBipartite(t *testing.T) {
 	gen := NewDefault()
 	gf := gen.Bipartite(3, 2)
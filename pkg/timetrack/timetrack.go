@@ -0,0 +1,220 @@
+// Package timetrack records work sessions on issues in .bv/time.jsonl and
+// aggregates them into actual minutes per issue.
+//
+// The log is append-only: each line is a start or stop event. Each actor has
+// at most one running session; starting another stops the current one.
+package timetrack
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Filename is the time log filename inside .bv/
+const Filename = "time.jsonl"
+
+// Event actions
+const (
+	ActionStart = "start"
+	ActionStop  = "stop"
+)
+
+// ErrNotTracking is returned by Stop when the actor has no running session
+var ErrNotTracking = errors.New("no running session")
+
+// Event is one line of the time log
+type Event struct {
+	IssueID string    `json:"issue_id"`
+	Action  string    `json:"action"`
+	At      time.Time `json:"at"`
+	Actor   string    `json:"actor,omitempty"`
+}
+
+// Session is a span of work on one issue. End is zero while it is running.
+type Session struct {
+	IssueID string    `json:"issue_id"`
+	Actor   string    `json:"actor,omitempty"`
+	Start   time.Time `json:"start"`
+	End     time.Time `json:"end,omitempty"`
+}
+
+// Running reports whether the session has not been stopped
+func (s Session) Running() bool {
+	return s.End.IsZero()
+}
+
+// Duration is the session length, up to now while it is running
+func (s Session) Duration(now time.Time) time.Duration {
+	end := s.End
+	if s.Running() {
+		end = now
+	}
+	if end.Before(s.Start) {
+		return 0
+	}
+	return end.Sub(s.Start)
+}
+
+// Log is the replayed time log
+type Log struct {
+	Sessions []Session
+}
+
+// Path returns the time log path for a project
+func Path(projectDir string) string {
+	return filepath.Join(projectDir, ".bv", Filename)
+}
+
+// Load replays the time log at path. A missing file is an empty log;
+// malformed lines and stops without a matching start are skipped.
+func Load(path string) (*Log, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &Log{}, nil
+		}
+		return nil, fmt.Errorf("opening time log: %w", err)
+	}
+	defer f.Close()
+
+	log := &Log{}
+	running := make(map[string]int) // actor -> index of running session
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var e Event
+		if json.Unmarshal(scanner.Bytes(), &e) != nil || e.IssueID == "" {
+			continue
+		}
+		log.apply(e, running)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading time log: %w", err)
+	}
+	return log, nil
+}
+
+func (l *Log) apply(e Event, running map[string]int) {
+	if i, ok := running[e.Actor]; ok {
+		l.Sessions[i].End = e.At
+		delete(running, e.Actor)
+	}
+	if e.Action == ActionStart {
+		running[e.Actor] = len(l.Sessions)
+		l.Sessions = append(l.Sessions, Session{IssueID: e.IssueID, Actor: e.Actor, Start: e.At})
+	}
+}
+
+// Active returns actor's running session, or nil
+func (l *Log) Active(actor string) *Session {
+	for i := len(l.Sessions) - 1; i >= 0; i-- {
+		if s := l.Sessions[i]; s.Actor == actor && s.Running() {
+			return &s
+		}
+	}
+	return nil
+}
+
+// ActualMinutes sums finished sessions per issue. Running sessions are left
+// out so calibration only sees completed work.
+func (l *Log) ActualMinutes() map[string]int {
+	totals := make(map[string]time.Duration)
+	for _, s := range l.Sessions {
+		if !s.Running() {
+			totals[s.IssueID] += s.Duration(s.End)
+		}
+	}
+	minutes := make(map[string]int, len(totals))
+	for id, d := range totals {
+		minutes[id] = int(math.Round(d.Minutes()))
+	}
+	return minutes
+}
+
+// Tracked is the total time on issueID, including running sessions up to now
+func (l *Log) Tracked(issueID string, now time.Time) time.Duration {
+	var total time.Duration
+	for _, s := range l.Sessions {
+		if s.IssueID == issueID {
+			total += s.Duration(now)
+		}
+	}
+	return total
+}
+
+// Start begins a session on issueID for actor. A running session on another
+// issue is stopped first and returned; starting the issue already being
+// tracked is an error.
+func Start(path, issueID, actor string, now time.Time) (*Session, error) {
+	log, err := Load(path)
+	if err != nil {
+		return nil, err
+	}
+	current := log.Active(actor)
+	if current != nil && current.IssueID == issueID {
+		return nil, fmt.Errorf("already tracking %s since %s", issueID, current.Start.Local().Format("15:04"))
+	}
+	var events []Event
+	if current != nil {
+		current.End = now
+		events = append(events, Event{IssueID: current.IssueID, Action: ActionStop, At: now, Actor: actor})
+	}
+	events = append(events, Event{IssueID: issueID, Action: ActionStart, At: now, Actor: actor})
+	if err := appendEvents(path, events...); err != nil {
+		return nil, err
+	}
+	return current, nil
+}
+
+// Stop ends actor's running session and returns it, or ErrNotTracking
+func Stop(path, actor string, now time.Time) (*Session, error) {
+	log, err := Load(path)
+	if err != nil {
+		return nil, err
+	}
+	current := log.Active(actor)
+	if current == nil {
+		return nil, ErrNotTracking
+	}
+	if err := appendEvents(path, Event{IssueID: current.IssueID, Action: ActionStop, At: now, Actor: actor}); err != nil {
+		return nil, err
+	}
+	current.End = now
+	return current, nil
+}
+
+func appendEvents(path string, events ...Event) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("creating time log directory: %w", err)
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("opening time log: %w", err)
+	}
+	defer f.Close()
+	for _, e := range events {
+		e.At = e.At.UTC()
+		line, err := json.Marshal(e)
+		if err != nil {
+			return err
+		}
+		if _, err := f.Write(append(line, '\n')); err != nil {
+			return fmt.Errorf("writing time log: %w", err)
+		}
+	}
+	return nil
+}
+
+// FormatDuration renders d as "1h05m" or "25m"
+func FormatDuration(d time.Duration) string {
+	minutes := int(d.Round(time.Minute).Minutes())
+	if minutes < 60 {
+		return fmt.Sprintf("%dm", minutes)
+	}
+	return fmt.Sprintf("%dh%02dm", minutes/60, minutes%60)
+}
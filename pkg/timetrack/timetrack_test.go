@@ -0,0 +1,103 @@
+package timetrack
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestStartStop(t *testing.T) {
+	path := Path(t.TempDir())
+	t0 := time.Date(2025, 4, 1, 9, 0, 0, 0, time.UTC)
+
+	if _, err := Stop(path, "alice", t0); !errors.Is(err, ErrNotTracking) {
+		t.Fatalf("stop with nothing running: err = %v", err)
+	}
+	if stopped, err := Start(path, "bv-1", "alice", t0); err != nil || stopped != nil {
+		t.Fatalf("Start = %v, %v", stopped, err)
+	}
+	if _, err := Start(path, "bv-1", "alice", t0.Add(time.Minute)); err == nil {
+		t.Error("starting the tracked issue again should fail")
+	}
+
+	// Another actor's session is independent
+	if _, err := Start(path, "bv-9", "bob", t0.Add(5*time.Minute)); err != nil {
+		t.Fatal(err)
+	}
+
+	// Switching issues stops the running session
+	stopped, err := Start(path, "bv-2", "alice", t0.Add(30*time.Minute))
+	if err != nil || stopped == nil || stopped.IssueID != "bv-1" || stopped.Duration(time.Time{}) != 30*time.Minute {
+		t.Fatalf("switch: stopped = %+v, err = %v", stopped, err)
+	}
+	stopped, err = Stop(path, "alice", t0.Add(75*time.Minute))
+	if err != nil || stopped.IssueID != "bv-2" {
+		t.Fatalf("Stop = %+v, %v", stopped, err)
+	}
+
+	log, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(log.Sessions) != 3 {
+		t.Fatalf("sessions = %+v", log.Sessions)
+	}
+	if log.Active("alice") != nil {
+		t.Error("alice should have no running session")
+	}
+	if active := log.Active("bob"); active == nil || active.IssueID != "bv-9" {
+		t.Errorf("bob's session = %+v", active)
+	}
+
+	actual := log.ActualMinutes()
+	if actual["bv-1"] != 30 || actual["bv-2"] != 45 {
+		t.Errorf("actual minutes = %v", actual)
+	}
+	if _, ok := actual["bv-9"]; ok {
+		t.Error("running sessions must not count as actuals")
+	}
+	if got := log.Tracked("bv-9", t0.Add(65*time.Minute)); got != time.Hour {
+		t.Errorf("tracked bv-9 = %v, want 1h including the running session", got)
+	}
+}
+
+func TestLoad_MissingAndMalformed(t *testing.T) {
+	dir := t.TempDir()
+	log, err := Load(filepath.Join(dir, "none.jsonl"))
+	if err != nil || len(log.Sessions) != 0 {
+		t.Fatalf("missing log = %+v, %v", log, err)
+	}
+
+	path := filepath.Join(dir, "time.jsonl")
+	content := `not json
+{"issue_id":"a","action":"stop","at":"2025-01-01T09:00:00Z"}
+{"issue_id":"a","action":"start","at":"2025-01-01T10:00:00Z"}
+{"issue_id":"a","action":"stop","at":"2025-01-01T10:20:00Z"}
+`
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	log, err = Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := log.ActualMinutes()["a"]; got != 20 {
+		t.Errorf("actual = %d, want 20 (stray stop and bad line skipped)", got)
+	}
+}
+
+func TestFormatDuration(t *testing.T) {
+	tests := map[time.Duration]string{
+		0:                             "0m",
+		25 * time.Minute:              "25m",
+		65 * time.Minute:              "1h05m",
+		10*time.Hour + 29*time.Second: "10h00m",
+	}
+	for d, want := range tests {
+		if got := FormatDuration(d); got != want {
+			t.Errorf("FormatDuration(%v) = %s, want %s", d, got, want)
+		}
+	}
+}
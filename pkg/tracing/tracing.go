@@ -0,0 +1,186 @@
+// Package tracing emits OpenTelemetry spans for bv's expensive phases:
+// loading, graph analysis, history correlation and export. It is off (and
+// costs next to nothing) unless the environment turns it on:
+//
+//	OTEL_EXPORTER_OTLP_ENDPOINT=http://localhost:4318 bv   # OTLP/HTTP collector
+//	BV_TRACE_FILE=trace.json bv                            # spans as JSON lines in a file
+//	OTEL_TRACES_EXPORTER=console bv                        # spans as JSON on stderr
+//
+// The other standard OTEL_EXPORTER_OTLP_* variables (headers, traces endpoint,
+// timeout) are honored by the OTLP exporter, and OTEL_TRACES_EXPORTER=none
+// turns tracing off. The OTLP exporter is refused in offline mode
+// (BV_OFFLINE).
+//
+// bv exits through os.Exit in many places, so spans are exported as soon as
+// they end rather than batched. Every span of one run shares a trace ID.
+package tracing
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/offline"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TracerName identifies bv's spans
+const TracerName = "github.com/Dicklesworthstone/beads_viewer"
+
+// TraceFileEnvVar names a file to write spans to, no collector needed
+const TraceFileEnvVar = "BV_TRACE_FILE"
+
+// Start begins a span named name. Instrumented code calls it whether or not
+// tracing is on; without a provider from Init the span is a no-op.
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return otel.Tracer(TracerName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// Record emits a span for work that already ran from start to end, for
+// code that times itself and would otherwise need a span on every return
+// path
+func Record(ctx context.Context, name string, start, end time.Time, attrs ...attribute.KeyValue) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	_, span := otel.Tracer(TracerName).Start(ctx, name, trace.WithTimestamp(start), trace.WithAttributes(attrs...))
+	span.End(trace.WithTimestamp(end))
+}
+
+// End ends span, marking it failed if err is non-nil
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// Init installs a tracer provider if the environment asks for one or local
+// exporters are given (the usage log's phase timings). The returned
+// shutdown flushes and closes the exporters; it is a no-op when tracing is
+// off. serviceVersion is reported as service.version.
+func Init(ctx context.Context, serviceVersion string, local ...sdktrace.SpanExporter) (shutdown func(context.Context) error, err error) {
+	noop := func(context.Context) error { return nil }
+	// A broken environment exporter still reports its error, but doesn't
+	// stop the local ones
+	exporter, closer, envErr := exporterFromEnv(ctx, os.Getenv)
+	if exporter == nil && len(local) == 0 {
+		return noop, envErr
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewSchemaless(
+		attribute.String("service.name", serviceName()),
+		attribute.String("service.version", serviceVersion),
+	))
+	if err != nil {
+		res = resource.Default()
+	}
+	opts := []sdktrace.TracerProviderOption{
+		sdktrace.WithResource(res),
+		sdktrace.WithIDGenerator(newRunIDGenerator()),
+	}
+	if exporter != nil {
+		opts = append(opts, sdktrace.WithSyncer(exporter))
+	}
+	for _, e := range local {
+		opts = append(opts, sdktrace.WithSyncer(e))
+	}
+	provider := sdktrace.NewTracerProvider(opts...)
+	otel.SetTracerProvider(provider)
+
+	return func(ctx context.Context) error {
+		err := provider.Shutdown(ctx)
+		if closer != nil {
+			if cerr := closer.Close(); err == nil {
+				err = cerr
+			}
+		}
+		return err
+	}, envErr
+}
+
+func serviceName() string {
+	if name := strings.TrimSpace(os.Getenv("OTEL_SERVICE_NAME")); name != "" {
+		return name
+	}
+	return "bv"
+}
+
+// exporterFromEnv picks the span exporter. A nil exporter means tracing is
+// off; the closer, if any, is closed on shutdown.
+func exporterFromEnv(ctx context.Context, getenv func(string) string) (sdktrace.SpanExporter, io.Closer, error) {
+	if path := strings.TrimSpace(getenv(TraceFileEnvVar)); path != "" {
+		f, err := os.Create(path)
+		if err != nil {
+			return nil, nil, fmt.Errorf("opening %s: %w", TraceFileEnvVar, err)
+		}
+		exporter, err := stdouttrace.New(stdouttrace.WithWriter(f))
+		if err != nil {
+			_ = f.Close()
+			return nil, nil, err
+		}
+		return exporter, f, nil
+	}
+
+	kind := strings.ToLower(strings.TrimSpace(getenv("OTEL_TRACES_EXPORTER")))
+	if kind == "" && (getenv("OTEL_EXPORTER_OTLP_ENDPOINT") != "" || getenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT") != "") {
+		kind = "otlp"
+	}
+	switch kind {
+	case "", "none":
+		return nil, nil, nil
+	case "console":
+		exporter, err := stdouttrace.New(stdouttrace.WithWriter(os.Stderr))
+		return exporter, nil, err
+	case "otlp":
+		if err := offline.Check("OTLP trace export"); err != nil {
+			return nil, nil, err
+		}
+		exporter, err := otlptracehttp.New(ctx)
+		if err != nil {
+			return nil, nil, fmt.Errorf("creating OTLP exporter: %w", err)
+		}
+		return exporter, nil, nil
+	default:
+		return nil, nil, fmt.Errorf("unsupported OTEL_TRACES_EXPORTER %q (want otlp, console or none)", kind)
+	}
+}
+
+// runIDGenerator gives every root span of the process the same trace ID,
+// so a run's loading, analysis and export spans show up as one trace even
+// though there is no process-wide parent span (it could never be ended
+// before os.Exit).
+type runIDGenerator struct {
+	traceID trace.TraceID
+}
+
+func newRunIDGenerator() *runIDGenerator {
+	g := &runIDGenerator{}
+	_, _ = rand.Read(g.traceID[:])
+	return g
+}
+
+func (g *runIDGenerator) NewIDs(ctx context.Context) (trace.TraceID, trace.SpanID) {
+	return g.traceID, g.NewSpanID(ctx, g.traceID)
+}
+
+func (g *runIDGenerator) NewSpanID(context.Context, trace.TraceID) trace.SpanID {
+	var id trace.SpanID
+	_, _ = rand.Read(id[:])
+	return id
+}
@@ -0,0 +1,117 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/offline"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestExporterFromEnv(t *testing.T) {
+	ctx := context.Background()
+	env := func(vars map[string]string) func(string) string {
+		return func(key string) string { return vars[key] }
+	}
+
+	for _, vars := range []map[string]string{
+		{},
+		{"OTEL_TRACES_EXPORTER": "none"},
+		{"OTEL_TRACES_EXPORTER": "none", "OTEL_EXPORTER_OTLP_ENDPOINT": "http://localhost:4318"},
+	} {
+		exporter, _, err := exporterFromEnv(ctx, env(vars))
+		if err != nil || exporter != nil {
+			t.Errorf("%v: exporter = %v, err = %v; want tracing off", vars, exporter, err)
+		}
+	}
+
+	if _, _, err := exporterFromEnv(ctx, env(map[string]string{"OTEL_TRACES_EXPORTER": "zipkin"})); err == nil {
+		t.Error("unsupported exporter should fail")
+	}
+
+	exporter, _, err := exporterFromEnv(ctx, env(map[string]string{"OTEL_EXPORTER_OTLP_ENDPOINT": "http://localhost:4318"}))
+	if err != nil || exporter == nil {
+		t.Errorf("OTLP endpoint should enable the OTLP exporter: %v, %v", exporter, err)
+	}
+
+	t.Setenv(offline.EnvVar, "1")
+	_, _, err = exporterFromEnv(ctx, env(map[string]string{"OTEL_EXPORTER_OTLP_ENDPOINT": "http://localhost:4318"}))
+	if !errors.Is(err, offline.ErrOffline) {
+		t.Errorf("OTLP export offline: err = %v, want ErrOffline", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "trace.json")
+	exporter, closer, err := exporterFromEnv(ctx, env(map[string]string{TraceFileEnvVar: path, "OTEL_TRACES_EXPORTER": "none"}))
+	if err != nil || exporter == nil || closer == nil {
+		t.Fatalf("trace file: exporter = %v, closer = %v, err = %v", exporter, closer, err)
+	}
+	_ = closer.Close()
+}
+
+func TestSpansShareRunTraceID(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithSpanProcessor(recorder),
+		sdktrace.WithIDGenerator(newRunIDGenerator()),
+	)
+	prev := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	t.Cleanup(func() { otel.SetTracerProvider(prev) })
+
+	ctx, span := Start(context.Background(), "loader.load_issues")
+	End(span, errors.New("boom"))
+	start := time.Now()
+	Record(ctx, "analysis.pagerank", start, start.Add(time.Second))
+	_, span = Start(context.Background(), "export.sqlite")
+	End(span, nil)
+
+	spans := recorder.Ended()
+	if len(spans) != 3 {
+		t.Fatalf("ended spans = %d, want 3", len(spans))
+	}
+	for _, s := range spans[1:] {
+		if s.SpanContext().TraceID() != spans[0].SpanContext().TraceID() {
+			t.Errorf("%s has trace %s, want %s", s.Name(), s.SpanContext().TraceID(), spans[0].SpanContext().TraceID())
+		}
+	}
+	if spans[0].Status().Code != codes.Error || len(spans[0].Events()) == 0 {
+		t.Errorf("failed span status = %+v, events = %d", spans[0].Status(), len(spans[0].Events()))
+	}
+	if spans[1].Parent().SpanID() != spans[0].SpanContext().SpanID() {
+		t.Error("recorded span should be a child of the span in ctx")
+	}
+	if got := spans[1].EndTime().Sub(spans[1].StartTime()); got != time.Second {
+		t.Errorf("recorded span lasted %v, want 1s", got)
+	}
+	if spans[2].Status().Code == codes.Error {
+		t.Error("span ended without error should not be failed")
+	}
+}
+
+func TestInit_LocalExporterWithoutEnvironment(t *testing.T) {
+	for _, env := range []string{TraceFileEnvVar, "OTEL_TRACES_EXPORTER", "OTEL_EXPORTER_OTLP_ENDPOINT", "OTEL_EXPORTER_OTLP_TRACES_ENDPOINT"} {
+		t.Setenv(env, "")
+	}
+	prev := otel.GetTracerProvider()
+	t.Cleanup(func() { otel.SetTracerProvider(prev) })
+
+	local := tracetest.NewInMemoryExporter()
+	shutdown, err := Init(context.Background(), "test", local)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, span := Start(context.Background(), "loader.load_issues")
+	End(span, nil)
+	if spans := local.GetSpans(); len(spans) != 1 || spans[0].Name != "loader.load_issues" {
+		t.Errorf("local exporter got %+v", spans)
+	}
+	if err := shutdown(context.Background()); err != nil {
+		t.Error(err)
+	}
+}
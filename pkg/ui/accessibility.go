@@ -0,0 +1,73 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// SetNoColor drops every color and text attribute, for --no-color and
+// NO_COLOR. It is global: lipgloss and markdown styles are package-wide.
+func (m *Model) SetNoColor() {
+	noColorMarkdown = true
+	lipgloss.SetColorProfile(termenv.Ascii)
+	if m.theme.Renderer != nil {
+		m.theme.Renderer.SetColorProfile(termenv.Ascii)
+	}
+	// Rebuild the markdown renderer, which picks its profile up front
+	m.SetTheme(m.theme)
+}
+
+// SetPlain switches to the screen-reader friendly rendering: list rows read
+// as sentences without icons or column badges, and box-drawing characters
+// are blanked out of every view.
+func (m *Model) SetPlain(on bool) {
+	m.plain = on
+	m.updateListDelegate()
+}
+
+// renderPlain renders a list row as "bv-12: Title (bug, P1, open, @alice)",
+// prefixed with "> " when selected.
+func (d IssueDelegate) renderPlain(i IssueItem, selected bool, width int) string {
+	prefix := "  "
+	if selected {
+		prefix = "> "
+	}
+	details := []string{string(i.Issue.IssueType), fmt.Sprintf("P%d", i.Issue.Priority), string(i.Issue.Status)}
+	if i.Issue.Assignee != "" {
+		details = append(details, "@"+i.Issue.Assignee)
+	}
+	switch {
+	case i.IsQuickWin:
+		details = append(details, "quick win")
+	case i.UnblocksCount > 0:
+		details = append(details, fmt.Sprintf("unblocks %d", i.UnblocksCount))
+	}
+	if d.ShowSearchScores && i.SearchScoreSet {
+		details = append(details, fmt.Sprintf("score %.2f", i.SearchScore))
+	}
+	if d.ShowPriorityHints && d.PriorityHints != nil {
+		if hint, ok := d.PriorityHints[i.Issue.ID]; ok && hint.Direction != "" {
+			details = append(details, "priority "+hint.Direction)
+		}
+	}
+	if len(i.Issue.Comments) > 0 {
+		details = append(details, fmt.Sprintf("%d comments", len(i.Issue.Comments)))
+	}
+
+	row := fmt.Sprintf("%s%s: %s (%s)", prefix, i.Issue.ID, i.Issue.Title, strings.Join(details, ", "))
+	return truncateRunesHelper(row, width, "…")
+}
+
+// stripBoxDrawing blanks box-drawing and block characters (borders, rules,
+// sparklines) that screen readers would otherwise read out one by one.
+func stripBoxDrawing(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= 0x2500 && r <= 0x259F {
+			return ' '
+		}
+		return r
+	}, s)
+}
@@ -0,0 +1,80 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+func TestModel_SetNoColor(t *testing.T) {
+	prev := lipgloss.ColorProfile()
+	t.Cleanup(func() {
+		lipgloss.SetColorProfile(prev)
+		noColorMarkdown = false
+	})
+	lipgloss.SetColorProfile(termenv.TrueColor)
+
+	issues := []model.Issue{{ID: "bv-1", Title: "One", Status: model.StatusOpen, IssueType: model.TypeBug}}
+	m := NewModel(issues, nil, "")
+	m.theme.Renderer.SetColorProfile(termenv.TrueColor)
+	m.SetTheme(m.theme)
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 100, Height: 20})
+	m = updated.(Model)
+	if !strings.Contains(m.View(), "\x1b[") {
+		t.Fatal("expected styled output before --no-color")
+	}
+
+	m.SetNoColor()
+	if view := m.View(); strings.Contains(view, "\x1b[") {
+		t.Errorf("escape sequences with --no-color:\n%q", view)
+	}
+}
+
+func TestModel_PlainView(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "bv-1", Title: "Fix login", Status: model.StatusOpen, IssueType: model.TypeBug, Priority: 1, Assignee: "alice"},
+		{ID: "bv-2", Title: "Docs", Status: model.StatusInProgress, IssueType: model.TypeTask, Priority: 3},
+	}
+	m := NewModel(issues, nil, "")
+	m.SetPlain(true)
+	for _, width := range []int{80, 160} { // Single list, then split view
+		updated, _ := m.Update(tea.WindowSizeMsg{Width: width, Height: 20})
+		m = updated.(Model)
+		view := m.View()
+		if strings.ContainsAny(view, "│─╭╮╰╯┃━▸") {
+			t.Errorf("width %d: box drawing in plain view:\n%s", width, view)
+		}
+		if !strings.Contains(view, "> bv-1: Fix login (bug, P1, open, @alice") {
+			t.Errorf("width %d: missing plain row:\n%s", width, view)
+		}
+		if strings.Contains(view, "TYPE PRI STATUS") {
+			t.Errorf("width %d: column header in plain view", width)
+		}
+	}
+}
+
+func TestIssueDelegate_RenderPlain(t *testing.T) {
+	d := IssueDelegate{ShowSearchScores: true}
+	item := IssueItem{
+		Issue:          model.Issue{ID: "bv-7", Title: "Schema", Status: model.StatusBlocked, IssueType: model.TypeFeature, Priority: 0},
+		UnblocksCount:  3,
+		SearchScore:    0.5,
+		SearchScoreSet: true,
+	}
+	if got, want := d.renderPlain(item, true, 100), "> bv-7: Schema (feature, P0, blocked, unblocks 3, score 0.50)"; got != want {
+		t.Errorf("renderPlain = %q, want %q", got, want)
+	}
+	if got := d.renderPlain(item, false, 20); got != "  bv-7: Schema (fea…" {
+		t.Errorf("truncated = %q", got)
+	}
+}
+
+func TestStripBoxDrawing(t *testing.T) {
+	if got := stripBoxDrawing("╭─╮\n│a│ ▁▂█"); got != "   \n a     " {
+		t.Errorf("stripBoxDrawing = %q", got)
+	}
+}
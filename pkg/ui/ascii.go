@@ -0,0 +1,147 @@
+package ui
+
+import (
+	"cmp"
+	"runtime"
+	"strings"
+	"unicode"
+
+	"github.com/mattn/go-runewidth"
+)
+
+// ASCII mode swaps every glyph bv draws (borders, bars, arrows, emoji
+// badges) for ASCII once a frame is rendered, so one table covers the
+// board, graph, sprint burndown and footer alike. Each replacement is as
+// wide as the glyph it stands for, keeping the layout lipgloss measured.
+
+// asciiGlyphs are the replacements for glyphs bv uses
+var asciiGlyphs = map[rune]string{
+	// Box drawing not covered by asciiBoxDrawing's classes
+	'╌': "-", '┄': "-", '┈': "-", '═': "=",
+	'╎': "|", '┆': "|", '┊': "|", '║': "|",
+
+	// Bars, shades and sparklines, lowest to highest
+	'▁': "_", '▂': "_", '▃': ".", '▄': "-", '▅': "-", '▆': "=", '▇': "=", '█': "#",
+	'▀': "^", '▌': "|", '▐': "|", '░': ".", '▒': ":", '▓': "%",
+
+	// Arrows and pointers
+	'←': "<", '→': ">", '↑': "^", '↓': "v", '↕': "|", '↔': "-",
+	'↩': "<", '↪': ">", '↳': ">", '↶': "<", '↷': ">", '↺': "o", '↻': "o",
+	'⬆': "^", '⬇': "v", '▲': "^", '▼': "v", '▾': "v", '▶': ">", '▸': ">", '►': ">", '◀': "<",
+
+	// Shapes and status dots
+	'■': "#", '◆': "*", '◈': "x", '◉': "@", '○': "o", '●': "*", '◐': "(", '★': "*",
+	'①': "1", '②': "2", '③': "3", '④': "4",
+
+	// Punctuation and math
+	'·': ".", '•': "*", '…': ".", '—': "-", '–': "-", '×': "x",
+	'‘': "'", '’': "'", '“': "\"", '”': "\"",
+	'≠': "#", '≤': "<", '≥': ">", '∅': "0", '⊕': "+", '⌀': "0",
+	'Σ': "S", 'λ': "l", 'μ': "u", 'ρ': "p", 'σ': "s",
+	'✓': "v", '✎': "e", 'ℹ': "i", '⏎': "<", '⌨': "K", '⚠': "!",
+
+	// Spinner frames
+	'⠋': "-", '⠙': "\\", '⠹': "|", '⠸': "/", '⠼': "-", '⠴': "\\", '⠦': "|", '⠧': "/", '⠇': "-", '⠏': "\\",
+
+	// Emoji: issue types
+	'🐛': "bg", '✨': "ft", '📋': "tk", '📝': "tk", '🚀': "ep", '🎯': "ep", '🧹': "ch", '🔧': "ch", '📄': "--",
+
+	// Emoji: priorities
+	'🔥': "!!", '⚡': "! ", '🔹': "- ", '📌': "- ", '☕': "_ ", '💤': "zz",
+
+	// Emoji: statuses and verdicts
+	'🟢': "o ", '🔵': "@ ", '🟡': "~ ", '🟠': "~ ", '🔴': "x ", '🟣': "o ", '⚫': "* ", '⚪': "o ",
+	'✅': "ok", '❌': "X ", '⛔': "X ", '🚫': "X ", '❓': "? ",
+	'⭐': "* ", '🔓': "u+", '🔒': "lk", '🔗': "<>", '📦': "[]", '📍': "* ",
+
+	// Emoji: section headers and badges
+	'📊': "##", '📈': "/^", '📉': "\\v", '💬': "\"\"", '📎': "@ ", '🔍': "? ", '🔎': "? ",
+	'💡': "i ", '🏷': "# ", '👤': "@ ", '⏱': "t ", '⏳': "t ", '⏸': "||", '🍅': "t ",
+	'🔀': "<>", '🔁': "<>", '🔄': "<>", '🌐': "ww", '📁': "[]", '📂': "[]", '📅': "dt",
+	'🆕': "nw", '🧠': "ai", '🧪': "ts", '🧭': "->", '🚦': "!!", '🚧': "!!", '🔔': "! ",
+}
+
+// asciiBoxDrawing classifies box-drawing characters (U+2500-U+257F) by
+// the lines they draw: horizontal ones become "-", vertical ones "|", and
+// corners, tees and crosses "+".
+func asciiBoxDrawing(r rune) string {
+	switch r {
+	case '─', '━', '╴', '╶', '╸', '╺', '╼', '╾':
+		return "-"
+	case '│', '┃', '╵', '╷', '╹', '╻', '╽', '╿':
+		return "|"
+	}
+	return "+"
+}
+
+// toASCII rewrites s with ASCII glyphs. Letters and digits stay, so issue
+// text in other scripts is untouched; other symbols without an entry
+// become "?".
+func toASCII(s string) string {
+	var sb strings.Builder
+	sb.Grow(len(s))
+	for _, r := range s {
+		if r < 0x80 {
+			sb.WriteRune(r)
+			continue
+		}
+		width := runewidth.RuneWidth(r)
+		if repl, ok := asciiGlyphs[r]; ok {
+			sb.WriteString(fitWidth(repl, width))
+			continue
+		}
+		switch {
+		case r == '\uFE0F' || r == '\u200D': // Emoji presentation, joiners
+		case r >= 0x2500 && r <= 0x257F:
+			sb.WriteString(asciiBoxDrawing(r))
+		case unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.Is(unicode.Mn, r):
+			sb.WriteRune(r)
+		case unicode.IsSpace(r):
+			sb.WriteString(strings.Repeat(" ", max(width, 1)))
+		default:
+			sb.WriteString(strings.Repeat("?", width))
+		}
+	}
+	return sb.String()
+}
+
+// fitWidth pads or cuts an ASCII replacement to width cells
+func fitWidth(s string, width int) string {
+	if len(s) >= width {
+		return s[:width]
+	}
+	return s + strings.Repeat(" ", width-len(s))
+}
+
+// DetectASCII reports whether the terminal probably can't draw bv's
+// glyphs: a locale that isn't UTF-8, or a console without emoji fonts.
+func DetectASCII(getenv func(string) string) bool {
+	return detectASCII(runtime.GOOS, getenv)
+}
+
+func detectASCII(goos string, getenv func(string) string) bool {
+	switch getenv("TERM") {
+	case "linux", "dumb", "vt100", "vt102", "vt220", "cons25":
+		return true
+	}
+	if goos == "windows" {
+		// The console host's raster fonts have no emoji or braille; Windows
+		// Terminal, ConEmu and mintty-style terminals announce themselves
+		return getenv("WT_SESSION") == "" && getenv("TERM_PROGRAM") == "" &&
+			getenv("ConEmuANSI") != "ON" && getenv("TERM") == ""
+	}
+	locale := strings.ToLower(cmp.Or(getenv("LC_ALL"), getenv("LC_CTYPE"), getenv("LANG")))
+	if locale == "" {
+		return false
+	}
+	return !strings.Contains(locale, "utf-8") && !strings.Contains(locale, "utf8")
+}
+
+// SetASCII switches ASCII-only rendering on or off. Inline image previews
+// need Unicode placeholders, so they are turned off with it.
+func (m *Model) SetASCII(on bool) {
+	m.ascii = on
+	if on {
+		m.imageProtocol = imageProtocolNone
+	}
+}
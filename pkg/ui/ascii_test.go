@@ -0,0 +1,117 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+func isASCII(s string) bool {
+	for _, r := range s {
+		if r >= 0x80 {
+			return false
+		}
+	}
+	return true
+}
+
+func TestToASCII(t *testing.T) {
+	tests := map[string]string{
+		"╭──╮\n│ok│\n╰──╯":   "+--+\n|ok|\n+--+",
+		"🐛 P1 → bv-2":        "bg P1 > bv-2",
+		"▁▃▅█ 50%":           "_.-# 50%",
+		"⚠️ drift":           "! drift",
+		"○2 ◉1 ◈0 ●4":        "o2 @1 x0 *4",
+		"Café über 東京":       "Café über 東京", // Letters stay
+		"☂ umbrella":         "? umbrella",   // Unknown symbols keep their width
+		"\x1b[1m✅\x1b[0m ok": "\x1b[1mok\x1b[0m ok",
+	}
+	for in, want := range tests {
+		if got := toASCII(in); got != want {
+			t.Errorf("toASCII(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestToASCII_KeepsWidth(t *testing.T) {
+	for r, repl := range asciiGlyphs {
+		if !isASCII(repl) {
+			t.Errorf("%q maps to non-ASCII %q", r, repl)
+		}
+		if got, want := lipgloss.Width(toASCII(string(r))), lipgloss.Width(string(r)); got != want {
+			t.Errorf("%q is %d cells wide, its replacement %d", r, want, got)
+		}
+	}
+}
+
+func TestDetectASCII(t *testing.T) {
+	tests := []struct {
+		env  map[string]string
+		want bool
+	}{
+		{map[string]string{}, false},
+		{map[string]string{"LANG": "en_US.UTF-8", "TERM": "xterm-256color"}, false},
+		{map[string]string{"LANG": "de_DE.utf8"}, false},
+		{map[string]string{"LANG": "C"}, true},
+		{map[string]string{"LANG": "en_US.UTF-8", "LC_ALL": "POSIX"}, true},
+		{map[string]string{"LANG": "en_US.ISO-8859-1", "LC_CTYPE": "en_US.UTF-8"}, false},
+		{map[string]string{"LANG": "en_US.UTF-8", "TERM": "linux"}, true},
+	}
+	for _, tt := range tests {
+		if got := detectASCII("linux", func(k string) string { return tt.env[k] }); got != tt.want {
+			t.Errorf("detectASCII(linux, %v) = %v, want %v", tt.env, got, tt.want)
+		}
+	}
+
+	windows := []struct {
+		env  map[string]string
+		want bool
+	}{
+		{map[string]string{}, true}, // conhost
+		{map[string]string{"WT_SESSION": "3f1c"}, false},
+		{map[string]string{"ConEmuANSI": "ON"}, false},
+		{map[string]string{"TERM": "xterm-256color"}, false}, // mintty, Git Bash
+		{map[string]string{"TERM_PROGRAM": "vscode"}, false},
+	}
+	for _, tt := range windows {
+		if got := detectASCII("windows", func(k string) string { return tt.env[k] }); got != tt.want {
+			t.Errorf("detectASCII(windows, %v) = %v, want %v", tt.env, got, tt.want)
+		}
+	}
+}
+
+func TestModel_ASCIIViews(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "bv-1", Title: "Schema", Status: model.StatusOpen, IssueType: model.TypeBug, Priority: 1, Labels: []string{"api"}},
+		{ID: "bv-2", Title: "API", Status: model.StatusBlocked, IssueType: model.TypeFeature,
+			Dependencies: []*model.Dependency{{IssueID: "bv-2", DependsOnID: "bv-1", Type: model.DepBlocks}}},
+	}
+	m := NewModel(issues, nil, "")
+	m.SetASCII(true)
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 160, Height: 30})
+	m = updated.(Model)
+
+	check := func(name string) {
+		t.Helper()
+		view := m.View()
+		if !isASCII(view) {
+			t.Errorf("%s view is not ASCII:\n%s", name, view)
+		}
+		for i, line := range strings.Split(view, "\n") {
+			if w := lipgloss.Width(line); w > 160 {
+				t.Errorf("%s view line %d is %d cells wide", name, i, w)
+			}
+		}
+	}
+	check("list")
+	for _, key := range []string{"b", "g", "i"} { // Board, graph, insights
+		updated, _ = m.Update(keyMsgFromString(key))
+		m = updated.(Model)
+		check(key)
+		updated, _ = m.Update(keyMsgFromString(key))
+		m = updated.(Model)
+	}
+}
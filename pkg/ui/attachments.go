@@ -0,0 +1,254 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// attachmentLinkRegex matches markdown links and images, capturing the bang,
+// the link text and the target (optionally in <>, optionally with a title).
+var attachmentLinkRegex = regexp.MustCompile(`(!?)\[([^\]\n]*)\]\(\s*<?([^)\s>]+)>?(?:\s+"[^"\n]*")?\s*\)`)
+
+// attachmentBareRegex matches bare URLs and paths outside markdown links.
+// They only count when they carry an attachment extension.
+var attachmentBareRegex = regexp.MustCompile(`(?:https?://|file://|~/|\.{0,2}/)?[\w.~%+-]+(?:/[\w.~%+-]+)*\.[A-Za-z0-9]+`)
+
+// attachmentCodeRegex matches fenced and inline code, which never holds
+// attachments.
+var attachmentCodeRegex = regexp.MustCompile("(?s)```.*?```|`[^`\n]*`")
+
+// attachmentExts are the file extensions treated as attachments when a link
+// or bare reference points at a URL rather than a local file.
+var attachmentExts = map[string]bool{
+	".png": true, ".jpg": true, ".jpeg": true, ".gif": true, ".webp": true, ".bmp": true, ".svg": true,
+	".pdf": true, ".txt": true, ".log": true, ".csv": true, ".json": true, ".har": true,
+	".patch": true, ".diff": true, ".zip": true, ".gz": true, ".tgz": true,
+	".mp4": true, ".mov": true, ".webm": true,
+}
+
+// previewableExts are the image formats bv can decode for inline previews.
+var previewableExts = map[string]bool{
+	".png": true, ".jpg": true, ".jpeg": true, ".gif": true, ".webp": true, ".bmp": true,
+}
+
+// attachmentsDir is where files dropped next to the beads database are
+// picked up as attachments of an issue: .beads/attachments/<issue-id>/.
+const attachmentsDir = "attachments"
+
+// attachment is a file or URL referenced by an issue.
+type attachment struct {
+	Name    string // Link text, or the file name
+	Target  string // The path or URL as written
+	Path    string // Resolved local path; "" for remote URLs
+	Missing bool   // Local file that doesn't exist
+}
+
+// IsImage reports whether the attachment is a local image bv can preview.
+func (a attachment) IsImage() bool {
+	return a.Path != "" && !a.Missing && previewableExts[strings.ToLower(filepath.Ext(a.Path))]
+}
+
+// issueAttachments collects the attachments of an issue: images and file
+// links in its text, bare URLs and paths with an attachment extension, and
+// the files in .beads/attachments/<id>/. Relative paths resolve against
+// projectDir. Each file or URL is listed once, in order of appearance.
+func issueAttachments(issue model.Issue, projectDir string) []attachment {
+	var atts []attachment
+	seen := make(map[string]bool)
+	add := func(name, target string, requireExt bool) {
+		a, ok := resolveAttachment(name, target, projectDir)
+		if !ok || (requireExt && !attachmentExts[strings.ToLower(filepath.Ext(a.Target))]) {
+			return
+		}
+		key := a.Path
+		if key == "" {
+			key = a.Target
+		}
+		if seen[key] {
+			return
+		}
+		seen[key] = true
+		atts = append(atts, a)
+	}
+
+	for _, text := range analysis.MentionText(&issue) {
+		text = attachmentCodeRegex.ReplaceAllString(text, "")
+		last := 0
+		for _, m := range attachmentLinkRegex.FindAllStringSubmatchIndex(text, -1) {
+			scanBareAttachments(text[last:m[0]], projectDir, add)
+			last = m[1]
+			image, label, target := m[3] > m[2], text[m[4]:m[5]], text[m[6]:m[7]]
+			// Links to web pages aren't attachments; links to files are
+			add(label, target, !image && isRemoteTarget(target))
+		}
+		scanBareAttachments(text[last:], projectDir, add)
+	}
+
+	if projectDir != "" {
+		dir := filepath.Join(projectDir, ".beads", attachmentsDir, issue.ID)
+		if entries, err := os.ReadDir(dir); err == nil {
+			sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+			for _, e := range entries {
+				if e.Type().IsRegular() {
+					add(e.Name(), filepath.Join(dir, e.Name()), false)
+				}
+			}
+		}
+	}
+	return atts
+}
+
+// scanBareAttachments adds the URLs and paths with an attachment extension
+// found in plain text. Bare local paths only count when the file exists, so
+// prose like "see main.log" doesn't list a missing file.
+func scanBareAttachments(text, projectDir string, add func(name, target string, requireExt bool)) {
+	for _, loc := range attachmentBareRegex.FindAllStringIndex(text, -1) {
+		target := strings.TrimRight(text[loc[0]:loc[1]], ".")
+		if !attachmentExts[strings.ToLower(filepath.Ext(target))] {
+			continue
+		}
+		if !isRemoteTarget(target) {
+			if a, ok := resolveAttachment("", target, projectDir); !ok || a.Missing {
+				continue
+			}
+		}
+		add("", target, true)
+	}
+}
+
+// resolveAttachment turns a link target into an attachment. Anchors and
+// non-file schemes (mailto:, #section) are not attachments.
+func resolveAttachment(name, target, projectDir string) (attachment, bool) {
+	target = strings.TrimSpace(target)
+	if target == "" || strings.HasPrefix(target, "#") {
+		return attachment{}, false
+	}
+	a := attachment{Name: name, Target: target}
+	lower := strings.ToLower(target)
+	switch {
+	case strings.HasPrefix(lower, "http://"), strings.HasPrefix(lower, "https://"):
+		if a.Name == "" {
+			a.Name = filepath.Base(strings.SplitN(target, "?", 2)[0])
+		}
+		return a, true
+	case strings.HasPrefix(lower, "file://"):
+		a.Path = target[len("file://"):]
+	case strings.Contains(target, ":") && !filepath.IsAbs(target):
+		return attachment{}, false
+	case strings.HasPrefix(target, "~/"):
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return attachment{}, false
+		}
+		a.Path = filepath.Join(home, target[2:])
+	case filepath.IsAbs(target):
+		a.Path = target
+	default:
+		a.Path = filepath.Join(projectDir, filepath.FromSlash(target))
+	}
+	if a.Name == "" {
+		a.Name = filepath.Base(a.Path)
+	}
+	if _, err := os.Stat(a.Path); err != nil {
+		a.Missing = true
+	}
+	return a, true
+}
+
+func isRemoteTarget(target string) bool {
+	lower := strings.ToLower(target)
+	return strings.HasPrefix(lower, "http://") || strings.HasPrefix(lower, "https://")
+}
+
+// renderAttachmentsMD renders the numbered attachment list for the detail
+// viewport. Returns "" when there are none.
+func renderAttachmentsMD(atts []attachment) string {
+	if len(atts) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("### 📎 Attachments (%d)\n", len(atts)))
+	for i, a := range atts {
+		line := fmt.Sprintf("%d. `%s`", i+1, a.Target)
+		if a.Name != "" && a.Name != filepath.Base(a.Target) {
+			line += " " + a.Name
+		}
+		if a.Missing {
+			line += " _(missing)_"
+		}
+		sb.WriteString(line + "\n")
+	}
+	if len(atts) == 1 {
+		sb.WriteString("\n_A opens it with the system viewer._\n\n")
+	} else {
+		sb.WriteString("\n_A then 1-9 opens one with the system viewer._\n\n")
+	}
+	return sb.String()
+}
+
+// selectedAttachments returns the attachments of the selected issue.
+func (m Model) selectedAttachments() []attachment {
+	item, ok := m.list.SelectedItem().(IssueItem)
+	if !ok {
+		return nil
+	}
+	return issueAttachments(item.Issue, m.workDir)
+}
+
+// openAttachmentKey handles A: it opens the only attachment of the selected
+// issue, or waits for the number of the one to open.
+func (m Model) openAttachmentKey() Model {
+	atts := m.selectedAttachments()
+	switch len(atts) {
+	case 0:
+		m.statusMsg = "No attachments on this issue"
+		m.statusIsError = true
+	case 1:
+		m = m.openAttachment(atts[0])
+	default:
+		m.pendingAttachment = true
+		m.statusMsg = fmt.Sprintf("A… 1-%d: open attachment · esc: cancel", min(len(atts), 9))
+		m.statusIsError = false
+	}
+	return m
+}
+
+// openAttachmentNumber opens the n-th (1-based) attachment of the selected
+// issue after A.
+func (m Model) openAttachmentNumber(n int) Model {
+	atts := m.selectedAttachments()
+	if n < 1 || n > len(atts) {
+		m.statusMsg = fmt.Sprintf("No attachment %d", n)
+		m.statusIsError = true
+		return m
+	}
+	return m.openAttachment(atts[n-1])
+}
+
+// openAttachment hands an attachment to the system viewer.
+func (m Model) openAttachment(a attachment) Model {
+	if a.Missing {
+		m.statusMsg = fmt.Sprintf("Attachment not found: %s", a.Path)
+		m.statusIsError = true
+		return m
+	}
+	target := a.Path
+	if target == "" {
+		target = a.Target
+	}
+	if err := openBrowserURL(target); err != nil {
+		m.statusMsg = fmt.Sprintf("Could not open %s: %v", a.Name, err)
+		m.statusIsError = true
+		return m
+	}
+	m.statusMsg = fmt.Sprintf("📎 Opened %s", a.Name)
+	m.statusIsError = false
+	return m
+}
@@ -0,0 +1,187 @@
+package ui
+
+import (
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+)
+
+func writeTestPNG(t *testing.T, path string, w, h int) {
+	t.Helper()
+	img := image.NewNRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.SetNRGBA(x, y, color.NRGBA{R: uint8(x * 255 / w), G: 128, B: uint8(y * 255 / h), A: 255})
+		}
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if err := png.Encode(f, img); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestIssueAttachments(t *testing.T) {
+	dir := t.TempDir()
+	writeTestPNG(t, filepath.Join(dir, "shots", "crash.png"), 16, 8)
+	writeTestPNG(t, filepath.Join(dir, ".beads", "attachments", "bv-1", "trace.png"), 4, 4)
+	if err := os.WriteFile(filepath.Join(dir, "server.log"), []byte("boom"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	issue := model.Issue{
+		ID: "bv-1",
+		Description: "Crash: ![dialog](shots/crash.png)\nSee [the docs](https://example.com/docs) " +
+			"and [spec](https://example.com/spec.pdf), [gone](old/missing.txt).\n" +
+			"Raw log at server.log, not at other.log. Bare https://cdn.example.com/a/b.gif too.\n" +
+			"```\n![skip](shots/code.png)\n```\n[top](#top) [mail](mailto:a@b.c)",
+		Comments: []*model.Comment{{Text: "again ![x](shots/crash.png)"}},
+	}
+	atts := issueAttachments(issue, dir)
+
+	var targets []string
+	for _, a := range atts {
+		targets = append(targets, a.Target)
+	}
+	want := []string{"shots/crash.png", "https://example.com/spec.pdf", "old/missing.txt", "server.log",
+		"https://cdn.example.com/a/b.gif", filepath.Join(dir, ".beads", "attachments", "bv-1", "trace.png")}
+	if strings.Join(targets, " ") != strings.Join(want, " ") {
+		t.Fatalf("targets = %v\nwant      %v", targets, want)
+	}
+
+	crash, spec, missing := atts[0], atts[1], atts[2]
+	if crash.Name != "dialog" || crash.Path != filepath.Join(dir, "shots", "crash.png") || crash.Missing || !crash.IsImage() {
+		t.Errorf("crash = %+v", crash)
+	}
+	if spec.Path != "" || spec.Name != "spec" || spec.IsImage() {
+		t.Errorf("remote attachment = %+v", spec)
+	}
+	if !missing.Missing || missing.IsImage() {
+		t.Errorf("missing attachment = %+v", missing)
+	}
+
+	md := renderAttachmentsMD(atts)
+	for _, want := range []string{"Attachments (6)", "1. `shots/crash.png` dialog", "_(missing)_", "A then 1-9"} {
+		if !strings.Contains(md, want) {
+			t.Errorf("attachments markdown missing %q:\n%s", want, md)
+		}
+	}
+	if renderAttachmentsMD(nil) != "" {
+		t.Error("no attachments should render nothing")
+	}
+}
+
+func TestDetectImageProtocol(t *testing.T) {
+	tests := []struct {
+		env  map[string]string
+		want imageProtocol
+	}{
+		{map[string]string{"TERM": "xterm-256color"}, imageProtocolNone},
+		{map[string]string{"TERM": "xterm-kitty"}, imageProtocolKitty},
+		{map[string]string{"KITTY_WINDOW_ID": "1", "TERM": "screen"}, imageProtocolKitty},
+		{map[string]string{"TERM": "foot"}, imageProtocolBlocks},
+		{map[string]string{"TERM_PROGRAM": "WezTerm"}, imageProtocolBlocks},
+		{map[string]string{"TERM": "xterm-kitty", "BV_INLINE_IMAGES": "off"}, imageProtocolNone},
+		{map[string]string{"BV_INLINE_IMAGES": "sixel"}, imageProtocolBlocks},
+	}
+	for _, tt := range tests {
+		if got := detectImageProtocol(func(k string) string { return tt.env[k] }); got != tt.want {
+			t.Errorf("%v: got %d, want %d", tt.env, got, tt.want)
+		}
+	}
+}
+
+func TestImagePreviewRendering(t *testing.T) {
+	if cols, rows := previewCells(320, 160, 40, 12); cols != 40 || rows != 10 {
+		t.Errorf("previewCells(320x160) = %dx%d, want 40x10", cols, rows)
+	}
+	if cols, rows := previewCells(100, 1000, 40, 12); rows != 12 || cols != 2 {
+		t.Errorf("previewCells(tall) = %dx%d, want 2x12", cols, rows)
+	}
+	if cols, rows := previewCells(16, 8, 40, 12); cols != 2 || rows != 1 {
+		t.Errorf("small images shouldn't be blown up: %dx%d", cols, rows)
+	}
+
+	img := image.NewNRGBA(image.Rect(0, 0, 30, 20))
+	blocks := renderHalfBlocks(img, 6, 3)
+	lines := strings.Split(strings.TrimSuffix(blocks, "\n"), "\n")
+	if len(lines) != 3 || ansi.StringWidth(lines[0]) != 6 {
+		t.Errorf("half blocks = %d lines of width %d, want 3 of 6", len(lines), ansi.StringWidth(lines[0]))
+	}
+
+	kitty, err := renderKittyImage(img, 0x010203, 5, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines = strings.Split(strings.TrimSuffix(kitty, "\n"), "\n")
+	if len(lines) != 2 || !strings.HasPrefix(lines[0], "\x1b_Ga=T,U=1,f=100,q=2,i=66051,c=5,r=2,") {
+		t.Fatalf("kitty preview = %q", kitty)
+	}
+	for _, line := range lines {
+		if ansi.StringWidth(line) != 5 || !strings.Contains(line, "\x1b[38;2;1;2;3m") {
+			t.Errorf("placeholder row %q should be 5 cells in the image ID's color", line)
+		}
+	}
+	if !strings.Contains(lines[1], string([]rune{kittyPlaceholder, kittyRowDiacritics[1], kittyRowDiacritics[0]})) {
+		t.Error("second row should start with row 1, column 0")
+	}
+}
+
+func TestModel_Attachments(t *testing.T) {
+	t.Setenv("BV_NO_BROWSER", "1")
+	dir := t.TempDir()
+	writeTestPNG(t, filepath.Join(dir, "shot.png"), 64, 32)
+	issues := []model.Issue{
+		{ID: "bv-1", Title: "One", Status: model.StatusOpen, IssueType: model.TypeTask, Description: "![s](shot.png)"},
+		{ID: "bv-2", Title: "Two", Status: model.StatusOpen, IssueType: model.TypeTask,
+			Description: "![s](shot.png) and [log](gone.log)"},
+	}
+	m := NewModel(issues, nil, filepath.Join(dir, ".beads", "beads.jsonl"))
+	m.imageProtocol = imageProtocolBlocks
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 160, Height: 60})
+	m = updated.(Model)
+	press := func(key string) {
+		updated, _ := m.Update(keyMsgFromString(key))
+		m = updated.(Model)
+	}
+
+	m.list.Select(0)
+	m.updateViewportContent()
+	if view := m.viewport.View(); !strings.Contains(ansi.Strip(view), "Attachments (1)") || !strings.Contains(view, "▀") {
+		t.Errorf("detail should list the attachment and preview it:\n%s", view)
+	}
+	press("A")
+	if m.statusMsg != "📎 Opened s" {
+		t.Errorf("A with one attachment: status %q", m.statusMsg)
+	}
+
+	m.list.Select(1)
+	m.updateViewportContent()
+	press("A")
+	if !m.pendingAttachment {
+		t.Fatal("A with several attachments should wait for a number")
+	}
+	press("2")
+	if m.pendingAttachment || !m.statusIsError || !strings.Contains(m.statusMsg, "not found") {
+		t.Errorf("opening the missing attachment: %q", m.statusMsg)
+	}
+	press("A")
+	press("esc")
+	if m.pendingAttachment {
+		t.Error("esc should cancel")
+	}
+}
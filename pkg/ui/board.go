@@ -6,6 +6,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/Dicklesworthstone/beads_viewer/pkg/drift"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
 
 	"github.com/charmbracelet/bubbles/viewport"
@@ -15,16 +16,28 @@ import (
 
 // BoardModel represents the Kanban board view with adaptive columns
 type BoardModel struct {
-	columns      [4][]model.Issue
-	activeColIdx []int  // Indices of non-empty columns (for navigation)
-	focusedCol   int    // Index into activeColIdx
-	selectedRow  [4]int // Store selection for each column
+	columns      [boardColumnCount][]model.Issue
+	activeColIdx []int                 // Indices of non-empty columns (for navigation)
+	focusedCol   int                   // Index into activeColIdx
+	selectedRow  [boardColumnCount]int // Store selection for each column
 	theme        Theme
 
 	// Swimlane grouping mode (bv-wjs0)
 	swimLaneMode SwimLaneMode
 	allIssues    []model.Issue // Store all issues for re-grouping on mode change
 
+	// Horizontal lanes across the columns (label, assignee or track)
+	laneGrouping   LaneGrouping
+	lanes          []boardLane
+	laneOf         map[string]int    // Issue ID -> lane index
+	laneCursor     int               // Lane that z folds
+	laneSyncID     string            // Selection the lane cursor last followed
+	collapsedLanes map[string]bool   // Folded lane keys
+	trackOf        map[string]string // Issue ID -> execution-plan track
+
+	// Per-status WIP limits from .bv/board.yaml (nil = no limits)
+	wipLimits *drift.BoardConfig
+
 	// Reverse dependency index: maps issue ID -> slice of issue IDs it blocks (bv-1daf)
 	blocksIndex map[string][]string
 
@@ -60,7 +73,7 @@ type BoardModel struct {
 
 // searchMatch holds info about a matching card (bv-yg39)
 type searchMatch struct {
-	col int // Column index (0-3)
+	col int // Column index (0-4)
 	row int // Row index within column
 }
 
@@ -70,8 +83,16 @@ const (
 	ColInProgress = 1
 	ColBlocked    = 2
 	ColClosed     = 3
+	// ColInReview holds in_review issues in status mode. It is shown between
+	// In Progress and Blocked, and only when it has cards unless empty
+	// columns are forced on, so boards without a review step look the same.
+	ColInReview = 4
 )
 
+// boardColumnCount is the number of column slots; priority and type modes
+// use the first four
+const boardColumnCount = 5
+
 // SwimLaneMode determines how cards are grouped into columns (bv-wjs0)
 type SwimLaneMode int
 
@@ -165,7 +186,10 @@ func (b *BoardModel) updateActiveColumns() {
 	showEmpty := b.shouldShowEmptyColumns()
 
 	b.activeColIdx = nil
-	for i := 0; i < 4; i++ {
+	for _, i := range b.columnOrder() {
+		if i == ColInReview && len(b.columns[i]) == 0 && b.showEmptyColumns == nil {
+			continue
+		}
 		if len(b.columns[i]) > 0 || showEmpty {
 			b.activeColIdx = append(b.activeColIdx, i)
 		}
@@ -183,6 +207,14 @@ func (b *BoardModel) updateActiveColumns() {
 	}
 }
 
+// columnOrder returns the column indices of the current mode in display order
+func (b *BoardModel) columnOrder() []int {
+	if b.swimLaneMode == SwimByStatus {
+		return []int{ColOpen, ColInProgress, ColInReview, ColBlocked, ColClosed}
+	}
+	return []int{0, 1, 2, 3}
+}
+
 // shouldShowEmptyColumns returns whether empty columns should be visible (bv-tf6j)
 func (b *BoardModel) shouldShowEmptyColumns() bool {
 	// Explicit override takes precedence
@@ -222,7 +254,10 @@ func (b *BoardModel) GetEmptyColumnVisibilityMode() string {
 // HiddenColumnCount returns the number of empty columns currently hidden (bv-tf6j)
 func (b *BoardModel) HiddenColumnCount() int {
 	hidden := 0
-	for i := 0; i < 4; i++ {
+	for _, i := range b.columnOrder() {
+		if i == ColInReview && b.showEmptyColumns == nil {
+			continue // Not a hidden column, just no review step
+		}
 		if len(b.columns[i]) == 0 {
 			// Check if this column is in activeColIdx
 			found := false
@@ -255,9 +290,9 @@ func buildBlocksIndex(issues []model.Issue) map[string][]string {
 	return index
 }
 
-// groupIssuesByMode distributes issues into 4 columns based on swimlane mode (bv-wjs0)
-func groupIssuesByMode(issues []model.Issue, mode SwimLaneMode) [4][]model.Issue {
-	var cols [4][]model.Issue
+// groupIssuesByMode distributes issues into columns based on swimlane mode (bv-wjs0)
+func groupIssuesByMode(issues []model.Issue, mode SwimLaneMode) [boardColumnCount][]model.Issue {
+	var cols [boardColumnCount][]model.Issue
 
 	for _, issue := range issues {
 		var colIdx int
@@ -269,6 +304,8 @@ func groupIssuesByMode(issues []model.Issue, mode SwimLaneMode) [4][]model.Issue
 				colIdx = 0
 			case model.StatusInProgress:
 				colIdx = 1
+			case model.StatusInReview:
+				colIdx = ColInReview
 			case model.StatusBlocked:
 				colIdx = 2
 			case model.StatusClosed:
@@ -307,7 +344,7 @@ func groupIssuesByMode(issues []model.Issue, mode SwimLaneMode) [4][]model.Issue
 	}
 
 	// Sort each column
-	for i := 0; i < 4; i++ {
+	for i := range cols {
 		sortIssuesByPriorityAndDate(cols[i])
 	}
 
@@ -341,10 +378,10 @@ func (b *BoardModel) CycleSwimLaneMode() {
 
 // regroupIssues rebuilds columns based on current swimlane mode (bv-wjs0)
 func (b *BoardModel) regroupIssues() {
-	b.columns = groupIssuesByMode(b.allIssues, b.swimLaneMode)
+	b.rebuildColumns()
 
 	// Reset selection to avoid out-of-bounds
-	for i := 0; i < 4; i++ {
+	for i := range b.columns {
 		if b.selectedRow[i] >= len(b.columns[i]) {
 			if len(b.columns[i]) > 0 {
 				b.selectedRow[i] = len(b.columns[i]) - 1
@@ -363,14 +400,14 @@ func (b *BoardModel) regroupIssues() {
 func (b *BoardModel) getColumnHeaders() ([]string, []string) {
 	switch b.swimLaneMode {
 	case SwimByPriority:
-		return []string{"P0 CRITICAL", "P1 HIGH", "P2 MEDIUM", "P3+ OTHER"},
-			[]string{"🔥", "⚡", "🔹", "💤"}
+		return []string{"P0 CRITICAL", "P1 HIGH", "P2 MEDIUM", "P3+ OTHER", ""},
+			[]string{"🔥", "⚡", "🔹", "💤", ""}
 	case SwimByType:
-		return []string{"BUG", "FEATURE", "TASK", "EPIC"},
-			[]string{"🐛", "✨", "📋", "🎯"}
+		return []string{"BUG", "FEATURE", "TASK", "EPIC", ""},
+			[]string{"🐛", "✨", "📋", "🎯", ""}
 	default: // SwimByStatus
-		return []string{"OPEN", "IN PROGRESS", "BLOCKED", "CLOSED"},
-			[]string{"📋", "🔄", "🚫", "✅"}
+		return []string{"OPEN", "IN PROGRESS", "BLOCKED", "CLOSED", "IN REVIEW"},
+			[]string{"📋", "🔄", "🚫", "✅", "👀"}
 	}
 }
 
@@ -412,8 +449,8 @@ func (b *BoardModel) SetIssues(issues []model.Issue) {
 	// Store all issues for regrouping on mode change (bv-wjs0)
 	b.allIssues = issues
 
-	// Group by current swimlane mode (bv-wjs0)
-	b.columns = groupIssuesByMode(issues, b.swimLaneMode)
+	// Group by current swimlane mode (bv-wjs0) and lanes
+	b.rebuildColumns()
 
 	b.blocksIndex = buildBlocksIndex(issues) // Rebuild reverse dependency index (bv-1daf)
 
@@ -430,7 +467,7 @@ func (b *BoardModel) SetIssues(issues []model.Issue) {
 	b.lastDetailID = ""
 
 	// Sanitize selection to prevent out-of-bounds
-	for i := 0; i < 4; i++ {
+	for i := range b.columns {
 		if b.selectedRow[i] >= len(b.columns[i]) {
 			if len(b.columns[i]) > 0 {
 				b.selectedRow[i] = len(b.columns[i]) - 1
@@ -443,7 +480,7 @@ func (b *BoardModel) SetIssues(issues []model.Issue) {
 	b.updateActiveColumns()
 }
 
-// actualFocusedCol returns the actual column index (0-3) being focused
+// actualFocusedCol returns the actual column index (0-4) being focused
 func (b *BoardModel) actualFocusedCol() int {
 	if len(b.activeColIdx) == 0 {
 		return 0
@@ -525,9 +562,9 @@ func (b *BoardModel) PageUp(visibleRows int) {
 // Enhanced Navigation (bv-yg39)
 // ═══════════════════════════════════════════════════════════════════════════
 
-// JumpToColumn jumps directly to a specific column (1-4 maps to 0-3)
+// JumpToColumn jumps directly to a specific column (keys 1-5 map to 0-4)
 func (b *BoardModel) JumpToColumn(colIdx int) {
-	if colIdx < 0 || colIdx > 3 {
+	if colIdx < 0 || colIdx >= boardColumnCount {
 		return
 	}
 	for i, activeCol := range b.activeColIdx {
@@ -757,7 +794,7 @@ func (b *BoardModel) SelectedIssue() *model.Issue {
 
 // ColumnCount returns the number of issues in a column
 func (b *BoardModel) ColumnCount(col int) int {
-	if col >= 0 && col < 4 {
+	if col >= 0 && col < boardColumnCount {
 		return len(b.columns[col])
 	}
 	return 0
@@ -766,7 +803,7 @@ func (b *BoardModel) ColumnCount(col int) int {
 // TotalCount returns the total number of issues across all columns
 func (b *BoardModel) TotalCount() int {
 	total := 0
-	for i := 0; i < 4; i++ {
+	for i := range b.columns {
 		total += len(b.columns[i])
 	}
 	return total
@@ -885,7 +922,8 @@ func (b BoardModel) View(width, height int) string {
 			{Light: "#7b1fa2", Dark: "#ce93d8"}, // Epic - purple
 		}
 	default: // SwimByStatus
-		columnColors = []lipgloss.AdaptiveColor{t.Open, t.InProgress, t.Blocked, t.Closed}
+		// Review is still active work
+		columnColors = []lipgloss.AdaptiveColor{t.Open, t.InProgress, t.Blocked, t.Closed, t.InProgress}
 	}
 
 	var renderedCols []string
@@ -903,7 +941,7 @@ func (b BoardModel) View(width, height int) string {
 		// - Medium (100-140): Count + P0/P1 counts
 		// - Wide (>140): Full stats including oldest age
 		var headerText string
-		baseHeader := fmt.Sprintf("%s %s (%d)", columnEmoji[colIdx], columnTitles[colIdx], issueCount)
+		baseHeader := fmt.Sprintf("%s %s %s", columnEmoji[colIdx], columnTitles[colIdx], b.columnCountText(colIdx, issueCount))
 
 		if width < 100 {
 			// Narrow: just the base header
@@ -953,7 +991,12 @@ func (b BoardModel) View(width, height int) string {
 			Bold(true).
 			Padding(0, 1)
 
-		if isFocused {
+		if b.OverWIPLimit(colIdx, issueCount) {
+			// Over the WIP limit: red regardless of focus
+			headerStyle = headerStyle.
+				Background(t.Blocked).
+				Foreground(lipgloss.AdaptiveColor{Light: "#FFFFFF", Dark: "#1a1a1a"})
+		} else if isFocused {
 			headerStyle = headerStyle.
 				Background(columnColors[colIdx]).
 				Foreground(lipgloss.AdaptiveColor{Light: "#FFFFFF", Dark: "#1a1a1a"})
@@ -1053,6 +1096,9 @@ func (b BoardModel) View(width, height int) string {
 
 	// Join columns with gaps
 	columnsView := lipgloss.JoinHorizontal(lipgloss.Top, renderedCols...)
+	if b.laneGrouping != LaneNone {
+		columnsView = b.renderLanes(boardWidth, height-2, columnColors)
+	}
 
 	// Build title bar with swimlane mode and hidden column indicator (bv-tf6j)
 	titleBar := b.renderTitleBar(boardWidth, t)
@@ -1074,6 +1120,9 @@ func (b BoardModel) renderTitleBar(width int, t Theme) string {
 	// Build title: "BOARD [by: Status]" or "BOARD [by: Priority] [+2 hidden]"
 	modeName := b.GetSwimLaneModeName()
 	title := fmt.Sprintf("BOARD [by: %s]", modeName)
+	if b.laneGrouping != LaneNone {
+		title = fmt.Sprintf("%s [lanes: %s]", title, b.GetLaneGroupingName())
+	}
 
 	// Add hidden column indicator if columns are hidden
 	hiddenCount := b.HiddenColumnCount()
@@ -1092,6 +1141,42 @@ func (b BoardModel) renderTitleBar(width int, t Theme) string {
 	return titleStyle.Render(title)
 }
 
+// SetWIPLimits sets the per-status WIP limits shown in the column headers
+func (b *BoardModel) SetWIPLimits(config *drift.BoardConfig) {
+	b.wipLimits = config
+}
+
+// boardColumnStatus maps status-mode column indices to their status
+var boardColumnStatus = [boardColumnCount]model.Status{model.StatusOpen, model.StatusInProgress, model.StatusBlocked, model.StatusClosed, model.StatusInReview}
+
+// WIPLimit returns the WIP limit for a column, or 0 if it has none.
+// Limits only apply when the board is grouped by status.
+func (b BoardModel) WIPLimit(colIdx int) int {
+	if b.swimLaneMode != SwimByStatus || colIdx < 0 || colIdx >= len(boardColumnStatus) {
+		return 0
+	}
+	return b.wipLimits.WIPLimit(boardColumnStatus[colIdx])
+}
+
+// OverWIPLimit reports whether a column holding count cards exceeds its limit
+func (b BoardModel) OverWIPLimit(colIdx, count int) bool {
+	limit := b.WIPLimit(colIdx)
+	return limit > 0 && count > limit
+}
+
+// columnCountText renders a column's card count: "(7)", or "(7/5 ⚠)" with a
+// WIP limit that has been exceeded
+func (b BoardModel) columnCountText(colIdx, count int) string {
+	limit := b.WIPLimit(colIdx)
+	switch {
+	case limit <= 0:
+		return fmt.Sprintf("(%d)", count)
+	case count > limit:
+		return fmt.Sprintf("(%d/%d ⚠)", count, limit)
+	}
+	return fmt.Sprintf("(%d/%d)", count, limit)
+}
+
 // getAgeColor returns a color based on issue age (bv-1daf)
 // green (<7d), yellow (7-30d), red (>30d stale)
 func getAgeColor(t time.Time) lipgloss.TerminalColor {
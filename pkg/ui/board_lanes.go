@@ -0,0 +1,408 @@
+package ui
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// LaneGrouping splits the board into horizontal swimlanes that run across
+// the columns, so large boards can be read and folded one group at a time.
+type LaneGrouping int
+
+const (
+	LaneNone       LaneGrouping = iota // Default: one flat lane
+	LaneByLabel                        // First label of each issue
+	LaneByAssignee                     // Assignee
+	LaneByTrack                        // Execution-plan track (work stream)
+)
+
+// LaneGroupingCount is the total number of lane groupings for cycling
+const LaneGroupingCount = 4
+
+// boardLane is one swimlane: its cards per column, in column order.
+type boardLane struct {
+	Key     string // Group value; "" collects issues without one
+	Columns [boardColumnCount][]model.Issue
+	Total   int
+	WIP     int // Cards in progress
+}
+
+// Title returns the lane's display name.
+func (l boardLane) Title(g LaneGrouping) string {
+	if l.Key != "" {
+		return l.Key
+	}
+	switch g {
+	case LaneByAssignee:
+		return "(unassigned)"
+	case LaneByTrack:
+		return "(no track)"
+	default:
+		return "(no label)"
+	}
+}
+
+// GetLaneGroupingName returns the display name for the current lane grouping
+func (b *BoardModel) GetLaneGroupingName() string {
+	switch b.laneGrouping {
+	case LaneByLabel:
+		return "Label"
+	case LaneByAssignee:
+		return "Assignee"
+	case LaneByTrack:
+		return "Track"
+	default:
+		return "None"
+	}
+}
+
+// GetLaneGrouping returns the current lane grouping
+func (b *BoardModel) GetLaneGrouping() LaneGrouping {
+	return b.laneGrouping
+}
+
+// SetTracks sets the execution-plan track of each issue, used when lanes
+// are grouped by track.
+func (b *BoardModel) SetTracks(trackOf map[string]string) {
+	b.trackOf = trackOf
+	if b.laneGrouping == LaneByTrack {
+		b.rebuildLanes()
+		b.syncLaneCursor()
+	}
+}
+
+// HasTracks reports whether track membership has been provided
+func (b *BoardModel) HasTracks() bool {
+	return b.trackOf != nil
+}
+
+// CycleLaneGrouping switches to the next lane grouping. Folded lanes are
+// reset since lane keys differ between groupings.
+func (b *BoardModel) CycleLaneGrouping() {
+	b.laneGrouping = LaneGrouping((int(b.laneGrouping) + 1) % LaneGroupingCount)
+	b.collapsedLanes = nil
+	b.laneCursor = 0
+	b.laneSyncID = ""
+	b.rebuildLanes()
+	b.syncLaneCursor()
+}
+
+// LaneCount returns the number of lanes (0 when lanes are off)
+func (b *BoardModel) LaneCount() int {
+	return len(b.lanes)
+}
+
+// CurrentLane returns the display name of the lane under the lane cursor
+func (b *BoardModel) CurrentLane() string {
+	if b.laneCursor >= len(b.lanes) {
+		return ""
+	}
+	return b.lanes[b.laneCursor].Title(b.laneGrouping)
+}
+
+// IsLaneCollapsed reports whether the lane under the lane cursor is folded
+func (b *BoardModel) IsLaneCollapsed() bool {
+	return b.laneCursor < len(b.lanes) && b.collapsedLanes[b.lanes[b.laneCursor].Key]
+}
+
+// NextLane moves the lane cursor down and selects the lane's first card
+func (b *BoardModel) NextLane() {
+	if b.laneCursor < len(b.lanes)-1 {
+		b.laneCursor++
+		b.selectLaneStart()
+	}
+}
+
+// PrevLane moves the lane cursor up and selects the lane's first card
+func (b *BoardModel) PrevLane() {
+	if b.laneCursor > 0 {
+		b.laneCursor--
+		b.selectLaneStart()
+	}
+}
+
+// ToggleLane folds or unfolds the lane under the lane cursor
+func (b *BoardModel) ToggleLane() {
+	if b.laneCursor >= len(b.lanes) {
+		return
+	}
+	key := b.lanes[b.laneCursor].Key
+	if b.collapsedLanes == nil {
+		b.collapsedLanes = make(map[string]bool)
+	}
+	b.collapsedLanes[key] = !b.collapsedLanes[key]
+	b.rebuildLanes()
+	b.selectLaneStart()
+}
+
+// ToggleAllLanes folds every lane, or unfolds them all if all are folded
+func (b *BoardModel) ToggleAllLanes() {
+	if len(b.lanes) == 0 {
+		return
+	}
+	allCollapsed := true
+	for _, lane := range b.lanes {
+		if !b.collapsedLanes[lane.Key] {
+			allCollapsed = false
+			break
+		}
+	}
+	b.collapsedLanes = make(map[string]bool)
+	if !allCollapsed {
+		for _, lane := range b.lanes {
+			b.collapsedLanes[lane.Key] = true
+		}
+	}
+	b.rebuildLanes()
+	b.selectLaneStart()
+}
+
+// laneKey returns the lane an issue belongs to for the current grouping.
+func (b *BoardModel) laneKey(issue model.Issue) string {
+	switch b.laneGrouping {
+	case LaneByLabel:
+		if len(issue.Labels) > 0 {
+			return issue.Labels[0]
+		}
+	case LaneByAssignee:
+		return issue.Assignee
+	case LaneByTrack:
+		return b.trackOf[issue.ID]
+	}
+	return ""
+}
+
+// rebuildColumns groups issues into columns and, when lanes are on, into
+// lanes. The navigable columns then hold the cards of every unfolded lane
+// in lane order, so moving down a column walks from lane to lane.
+func (b *BoardModel) rebuildColumns() {
+	cols := groupIssuesByMode(b.allIssues, b.swimLaneMode)
+	b.lanes = nil
+	b.laneOf = nil
+	if b.laneGrouping == LaneNone {
+		b.columns = cols
+		return
+	}
+
+	byKey := make(map[string]*boardLane)
+	for c := range cols {
+		for _, issue := range cols[c] {
+			key := b.laneKey(issue)
+			lane, ok := byKey[key]
+			if !ok {
+				lane = &boardLane{Key: key}
+				byKey[key] = lane
+			}
+			lane.Columns[c] = append(lane.Columns[c], issue)
+			lane.Total++
+			if issue.Status == model.StatusInProgress {
+				lane.WIP++
+			}
+		}
+	}
+	for _, lane := range byKey {
+		b.lanes = append(b.lanes, *lane)
+	}
+	// Named lanes first; track IDs sort by length so track-Z precedes track-AA
+	slices.SortFunc(b.lanes, func(x, y boardLane) int {
+		if (x.Key == "") != (y.Key == "") {
+			if x.Key == "" {
+				return 1
+			}
+			return -1
+		}
+		if b.laneGrouping == LaneByTrack {
+			if c := cmp.Compare(len(x.Key), len(y.Key)); c != 0 {
+				return c
+			}
+		}
+		return strings.Compare(x.Key, y.Key)
+	})
+
+	b.columns = [boardColumnCount][]model.Issue{}
+	b.laneOf = make(map[string]int)
+	for i, lane := range b.lanes {
+		for c := range lane.Columns {
+			for _, issue := range lane.Columns[c] {
+				b.laneOf[issue.ID] = i
+			}
+			if !b.collapsedLanes[lane.Key] {
+				b.columns[c] = append(b.columns[c], lane.Columns[c]...)
+			}
+		}
+	}
+	if b.laneCursor >= len(b.lanes) {
+		b.laneCursor = max(len(b.lanes)-1, 0)
+	}
+}
+
+// rebuildLanes regroups after a lane change, keeping selections in range.
+func (b *BoardModel) rebuildLanes() {
+	b.rebuildColumns()
+	b.clampSelection()
+	b.updateActiveColumns()
+	b.CancelSearch()    // Card positions changed
+	b.lastDetailID = "" // Force detail panel refresh
+}
+
+// clampSelection keeps each column's selected row within its cards.
+func (b *BoardModel) clampSelection() {
+	for i := range b.columns {
+		b.selectedRow[i] = max(min(b.selectedRow[i], len(b.columns[i])-1), 0)
+	}
+}
+
+// laneOffset returns where a lane's cards start in a navigable column.
+func (b *BoardModel) laneOffset(lane, col int) int {
+	offset := 0
+	for i := 0; i < lane; i++ {
+		if !b.collapsedLanes[b.lanes[i].Key] {
+			offset += len(b.lanes[i].Columns[col])
+		}
+	}
+	return offset
+}
+
+// selectLaneStart selects the first card of the cursor's lane in the
+// focused column, if the lane is unfolded and has one there.
+func (b *BoardModel) selectLaneStart() {
+	if b.laneCursor < len(b.lanes) {
+		lane := b.lanes[b.laneCursor]
+		col := b.actualFocusedCol()
+		if !b.collapsedLanes[lane.Key] && len(lane.Columns[col]) > 0 {
+			b.selectedRow[col] = b.laneOffset(b.laneCursor, col)
+		}
+	}
+	b.laneSyncID = ""
+	if sel := b.SelectedIssue(); sel != nil {
+		b.laneSyncID = sel.ID
+	}
+}
+
+// syncLaneCursor moves the lane cursor to the selected card's lane after
+// the selection changed by card navigation.
+func (b *BoardModel) syncLaneCursor() {
+	sel := b.SelectedIssue()
+	if sel == nil || sel.ID == b.laneSyncID {
+		return
+	}
+	b.laneSyncID = sel.ID
+	if i, ok := b.laneOf[sel.ID]; ok {
+		b.laneCursor = i
+	}
+}
+
+// renderLanes renders the board as swimlanes: a shared column header, then
+// one band per lane with a compact line per card. Folded lanes show only
+// their header with per-column counts.
+func (b BoardModel) renderLanes(width, height int, columnColors []lipgloss.AdaptiveColor) string {
+	t := b.theme
+	numCols := len(b.activeColIdx)
+	colWidth := max((width-2)/numCols, 12)
+	columnTitles, columnEmoji := b.getColumnHeaders()
+
+	var headers []string
+	for i, colIdx := range b.activeColIdx {
+		total := 0
+		for _, lane := range b.lanes {
+			total += len(lane.Columns[colIdx])
+		}
+		style := t.Renderer.NewStyle().
+			Width(colWidth).
+			Bold(true).
+			Foreground(columnColors[colIdx])
+		if b.OverWIPLimit(colIdx, total) {
+			style = style.
+				Background(t.Blocked).
+				Foreground(lipgloss.AdaptiveColor{Light: "#FFFFFF", Dark: "#1a1a1a"})
+		} else if i == b.focusedCol {
+			style = style.
+				Background(columnColors[colIdx]).
+				Foreground(lipgloss.AdaptiveColor{Light: "#FFFFFF", Dark: "#1a1a1a"})
+		}
+		headers = append(headers, style.Render(truncateRunesHelper(fmt.Sprintf(" %s %s %s", columnEmoji[colIdx], columnTitles[colIdx], b.columnCountText(colIdx, total)), colWidth, "…")))
+	}
+	header := "  " + strings.Join(headers, "")
+
+	laneStyle := t.Renderer.NewStyle().Bold(true).Foreground(t.Primary)
+	cursorLaneStyle := laneStyle.Background(t.Highlight)
+	dimStyle := t.Renderer.NewStyle().Foreground(t.Secondary)
+	cardStyle := t.Renderer.NewStyle().Foreground(t.Base.GetForeground())
+	selectedStyle := cardStyle.Background(t.Highlight).Foreground(t.Primary).Bold(true)
+	matchStyle := cardStyle.Foreground(lipgloss.AdaptiveColor{Light: "#1565c0", Dark: "#64b5f6"})
+
+	var lines []string
+	target := 0 // Line to keep in view: the selected card, else the cursor lane
+	focusedCol := b.actualFocusedCol()
+	for li, lane := range b.lanes {
+		collapsed := b.collapsedLanes[lane.Key]
+		fold := "▾"
+		if collapsed {
+			fold = "▸"
+		}
+		title := fmt.Sprintf("%s %s · %d", fold, lane.Title(b.laneGrouping), lane.Total)
+		if lane.WIP > 0 {
+			title += fmt.Sprintf(" · WIP %d", lane.WIP)
+		}
+		if collapsed {
+			counts := make([]string, len(b.activeColIdx))
+			for i, colIdx := range b.activeColIdx {
+				counts[i] = fmt.Sprint(len(lane.Columns[colIdx]))
+			}
+			title += "  [" + strings.Join(counts, " │ ") + "]"
+		}
+		style := laneStyle
+		if li == b.laneCursor {
+			style = cursorLaneStyle
+			target = len(lines)
+		}
+		lines = append(lines, style.Width(width).Render(truncateRunesHelper(title, width, "…")))
+		if collapsed {
+			continue
+		}
+
+		rows := 0
+		for _, colIdx := range b.activeColIdx {
+			rows = max(rows, len(lane.Columns[colIdx]))
+		}
+		for r := 0; r < rows; r++ {
+			var cells []string
+			for _, colIdx := range b.activeColIdx {
+				if r >= len(lane.Columns[colIdx]) {
+					cells = append(cells, strings.Repeat(" ", colWidth))
+					continue
+				}
+				issue := lane.Columns[colIdx][r]
+				row := b.laneOffset(li, colIdx) + r
+				text := truncateRunesHelper(fmt.Sprintf("%s %s %s", formatPriority(issue.Priority), issue.ID, issue.Title), colWidth-1, "…")
+				style := cardStyle
+				switch {
+				case colIdx == focusedCol && row == b.selectedRow[colIdx]:
+					style = selectedStyle
+					target = len(lines)
+				case b.IsSearchMatch(colIdx, row):
+					style = matchStyle
+				}
+				cells = append(cells, style.Width(colWidth).Render(text))
+			}
+			lines = append(lines, "  "+strings.Join(cells, ""))
+		}
+	}
+
+	visible := max(height-1, 1)
+	start := 0
+	if target >= visible {
+		start = target - visible + 1
+	}
+	end := min(start+visible, len(lines))
+	body := strings.Join(lines[start:end], "\n")
+	if len(lines) > visible {
+		body += "\n" + dimStyle.Italic(true).Render(fmt.Sprintf("  ↕ %d-%d of %d lines", start+1, end, len(lines)))
+	}
+	return header + "\n" + body
+}
@@ -0,0 +1,147 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func laneTestIssues() []model.Issue {
+	return []model.Issue{
+		{ID: "api-1", Title: "Auth", Status: model.StatusOpen, Labels: []string{"backend"}, Assignee: "ana"},
+		{ID: "api-2", Title: "Rate limit", Status: model.StatusInProgress, Labels: []string{"backend", "infra"}, Assignee: "ana"},
+		{ID: "ui-1", Title: "Login form", Status: model.StatusOpen, Labels: []string{"frontend"}, Assignee: "bo"},
+		{ID: "ui-2", Title: "Dark mode", Status: model.StatusInProgress, Labels: []string{"frontend"}},
+		{ID: "misc", Title: "Cleanup", Status: model.StatusOpen},
+	}
+}
+
+func TestBoardLanes_ByLabel(t *testing.T) {
+	b := NewBoardModel(laneTestIssues(), newTestTheme())
+	b.CycleLaneGrouping()
+	if b.GetLaneGrouping() != LaneByLabel || b.LaneCount() != 3 {
+		t.Fatalf("grouping %s with %d lanes, want Label with 3", b.GetLaneGroupingName(), b.LaneCount())
+	}
+
+	// Named lanes sorted, unlabeled last; an issue goes in its first label's lane
+	var keys []string
+	for _, lane := range b.lanes {
+		keys = append(keys, lane.Title(b.laneGrouping))
+	}
+	if strings.Join(keys, ",") != "backend,frontend,(no label)" {
+		t.Errorf("lanes = %v", keys)
+	}
+	if b.lanes[0].Total != 2 || b.lanes[0].WIP != 1 {
+		t.Errorf("backend lane total %d WIP %d, want 2 and 1", b.lanes[0].Total, b.lanes[0].WIP)
+	}
+
+	// The open column lists cards lane by lane
+	var open []string
+	for _, issue := range b.columns[ColOpen] {
+		open = append(open, issue.ID)
+	}
+	if strings.Join(open, ",") != "api-1,ui-1,misc" {
+		t.Errorf("open column = %v", open)
+	}
+
+	view := b.View(140, 30)
+	for _, want := range []string{"[lanes: Label]", "▾ backend · 2 · WIP 1", "▾ (no label) · 1", "api-2 Rate limit"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view missing %q", want)
+		}
+	}
+}
+
+func TestBoardLanes_FoldAndJump(t *testing.T) {
+	b := NewBoardModel(laneTestIssues(), newTestTheme())
+	b.CycleLaneGrouping()
+
+	b.NextLane()
+	if b.CurrentLane() != "frontend" || b.SelectedIssue().ID != "ui-1" {
+		t.Fatalf("J should jump to the frontend lane's first card, got %s / %s", b.CurrentLane(), b.SelectedIssue().ID)
+	}
+
+	b.ToggleLane()
+	if !b.IsLaneCollapsed() || b.CurrentLane() != "frontend" {
+		t.Fatal("z should fold the cursor lane and keep the cursor on it")
+	}
+	for _, issue := range b.columns[ColOpen] {
+		if issue.ID == "ui-1" {
+			t.Fatal("folded lane cards should not be navigable")
+		}
+	}
+	if view := b.View(140, 30); !strings.Contains(view, "▸ frontend · 2 · WIP 1  [1 │ 1 │ 0 │ 0]") {
+		t.Errorf("folded lane should show per-column counts:\n%s", view)
+	}
+
+	b.ToggleLane()
+	if b.IsLaneCollapsed() || b.SelectedIssue().ID != "ui-1" {
+		t.Error("z again should unfold the lane and select its first card")
+	}
+
+	b.ToggleAllLanes()
+	if len(b.columns[ColOpen])+len(b.columns[ColInProgress]) != 0 {
+		t.Error("Z should fold every lane")
+	}
+	b.ToggleAllLanes()
+	if len(b.columns[ColOpen]) != 3 {
+		t.Error("Z again should unfold every lane")
+	}
+}
+
+func TestBoardLanes_FollowSelection(t *testing.T) {
+	b := NewBoardModel(laneTestIssues(), newTestTheme())
+	b.CycleLaneGrouping()
+	b.MoveDown()
+	b.syncLaneCursor()
+	if b.CurrentLane() != "frontend" {
+		t.Errorf("moving into another lane should move the lane cursor, got %s", b.CurrentLane())
+	}
+
+	// Filtering keeps the grouping
+	b.SetIssues(laneTestIssues()[:2])
+	if b.GetLaneGrouping() != LaneByLabel || b.LaneCount() != 1 {
+		t.Errorf("after filtering: %s with %d lanes", b.GetLaneGroupingName(), b.LaneCount())
+	}
+}
+
+func TestBoardLanes_KeysAndTracks(t *testing.T) {
+	issues := laneTestIssues()
+	issues[1].Dependencies = []*model.Dependency{{IssueID: "api-2", DependsOnID: "api-1", Type: model.DepBlocks}}
+	m := NewModel(issues, nil, "")
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 140, Height: 40})
+	m = updated.(Model)
+
+	m = pressKeys(t, m, "b", "v")
+	if m.board.GetLaneGrouping() != LaneByLabel || !strings.Contains(m.statusMsg, "Lanes: Label (3 lanes") {
+		t.Fatalf("v should group lanes by label, status %q", m.statusMsg)
+	}
+	m = pressKeys(t, m, "v")
+	if m.board.GetLaneGroupingName() != "Assignee" || m.board.LaneCount() != 3 {
+		t.Fatalf("second v: %s with %d lanes", m.board.GetLaneGroupingName(), m.board.LaneCount())
+	}
+
+	m = pressKeys(t, m, "v")
+	if m.board.GetLaneGrouping() != LaneByTrack || !m.board.HasTracks() {
+		t.Fatal("third v should group by execution-plan track")
+	}
+	// api-1 and api-2 form one work stream; the other three are separate
+	if m.board.LaneCount() != 4 || m.board.laneOf["api-1"] != m.board.laneOf["api-2"] {
+		t.Errorf("track lanes = %d, laneOf = %v", m.board.LaneCount(), m.board.laneOf)
+	}
+
+	m = pressKeys(t, m, "z")
+	if !m.board.IsLaneCollapsed() || !strings.Contains(m.statusMsg, "folded") {
+		t.Errorf("z should fold the lane, status %q", m.statusMsg)
+	}
+
+	m = pressKeys(t, m, "v")
+	if m.board.GetLaneGrouping() != LaneNone || m.board.LaneCount() != 0 {
+		t.Error("fourth v should turn lanes off")
+	}
+	if len(m.board.columns[ColOpen]) != 3 {
+		t.Error("turning lanes off should show every card again")
+	}
+}
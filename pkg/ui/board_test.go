@@ -7,6 +7,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/Dicklesworthstone/beads_viewer/pkg/drift"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/ui"
 
@@ -1801,3 +1802,79 @@ func TestInlineCardExpansion_ShowsDescription(t *testing.T) {
 		t.Error("Expanded card should show description content")
 	}
 }
+
+// TestBoardWIPLimits verifies limited column headers show the limit and flag overflow
+func TestBoardWIPLimits(t *testing.T) {
+	theme := createTheme()
+	issues := []model.Issue{
+		{ID: "wip-1", Title: "One", Status: model.StatusInProgress},
+		{ID: "wip-2", Title: "Two", Status: model.StatusInProgress},
+		{ID: "wip-3", Title: "Three", Status: model.StatusInProgress},
+		{ID: "blk-1", Title: "Stuck", Status: model.StatusBlocked},
+	}
+	b := ui.NewBoardModel(issues, theme)
+	b.SetWIPLimits(&drift.BoardConfig{WIPLimits: map[model.Status]int{
+		model.StatusInProgress: 2,
+		model.StatusBlocked:    3,
+	}})
+
+	if b.WIPLimit(ui.ColInProgress) != 2 || !b.OverWIPLimit(ui.ColInProgress, 3) {
+		t.Fatal("in_progress should be over its limit of 2")
+	}
+	if b.OverWIPLimit(ui.ColBlocked, 1) || b.WIPLimit(ui.ColOpen) != 0 {
+		t.Error("blocked is under its limit and open is unlimited")
+	}
+
+	output := b.View(160, 40)
+	if !strings.Contains(output, "(3/2 ⚠)") {
+		t.Error("over-limit column header should show count/limit with a warning")
+	}
+	if !strings.Contains(output, "(1/3)") {
+		t.Error("under-limit column header should show count/limit")
+	}
+
+	// Limits only apply to status columns
+	b.CycleSwimLaneMode()
+	if b.WIPLimit(ui.ColInProgress) != 0 {
+		t.Error("priority columns should have no WIP limit")
+	}
+}
+
+// TestInReviewColumn verifies in_review issues get their own column, shown
+// between In Progress and Blocked only when it has cards
+func TestInReviewColumn(t *testing.T) {
+	theme := createTheme()
+
+	issues := []model.Issue{
+		{ID: "1", Status: model.StatusOpen, Priority: 1},
+		{ID: "2", Status: model.StatusInProgress, Priority: 1},
+		{ID: "3", Status: model.StatusBlocked, Priority: 1},
+	}
+	b := ui.NewBoardModel(issues, theme)
+	if b.ColumnCount(ui.ColInReview) != 0 || b.HiddenColumnCount() != 0 {
+		t.Fatalf("empty review column should be left out quietly, hidden = %d", b.HiddenColumnCount())
+	}
+	if view := b.View(160, 30); strings.Contains(view, "IN REVIEW") {
+		t.Error("board without review cards should not show the In Review column")
+	}
+
+	issues = append(issues, model.Issue{ID: "4", Status: model.StatusInReview, Priority: 1})
+	b.SetIssues(issues)
+	if b.ColumnCount(ui.ColInReview) != 1 {
+		t.Fatalf("expected 1 in In Review column, got %d", b.ColumnCount(ui.ColInReview))
+	}
+	if view := b.View(160, 30); !strings.Contains(view, "IN REVIEW") {
+		t.Error("expected an In Review column header")
+	}
+
+	// Moving right from In Progress lands on In Review, then Blocked
+	b.JumpToColumn(ui.ColInProgress)
+	b.MoveRight()
+	if sel := b.SelectedIssue(); sel == nil || sel.ID != "4" {
+		t.Errorf("expected ID 4 right of In Progress, got %v", sel)
+	}
+	b.MoveRight()
+	if sel := b.SelectedIssue(); sel == nil || sel.ID != "3" {
+		t.Errorf("expected ID 3 right of In Review, got %v", sel)
+	}
+}
@@ -0,0 +1,129 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/correlation"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// busFactorReport runs the ownership analysis (the TUI side of
+// --robot-bus-factor) over the loaded git history, or returns nil while the
+// history is still loading or failed to load.
+func (m Model) busFactorReport() *correlation.OwnershipReport {
+	report := m.historyView.report
+	if report == nil {
+		return nil
+	}
+	labels := make(map[string][]string, len(m.issues))
+	for _, issue := range m.issues {
+		labels[issue.ID] = issue.Labels
+	}
+	opts := correlation.OwnershipOptions{Labels: labels}
+	if m.analysis != nil {
+		opts.PageRank = m.analysis.PageRank()
+	}
+	return report.AnalyzeOwnership(opts)
+}
+
+// renderBusFactorPanel renders labels and subsystems by ownership
+// concentration, at-risk areas first
+func (m *InsightsModel) renderBusFactorPanel(width, height int, t Theme) string {
+	info := metricDescriptions[PanelBusFactor]
+	isFocused := m.focusedPanel == PanelBusFactor
+
+	borderColor := t.Secondary
+	if isFocused {
+		borderColor = t.Primary
+	}
+	panelStyle := t.Renderer.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(borderColor).
+		Width(width).
+		Height(height).
+		Padding(0, 1)
+
+	titleStyle := t.Renderer.NewStyle().Bold(true)
+	if isFocused {
+		titleStyle = titleStyle.Foreground(t.Primary)
+	} else {
+		titleStyle = titleStyle.Foreground(t.Secondary)
+	}
+	subtitleStyle := t.Renderer.NewStyle().Foreground(t.Subtext).Italic(true)
+
+	var areas []correlation.OwnershipArea
+	if m.ownership != nil {
+		areas = m.ownership.Areas
+	}
+	var lines []string
+	lines = append(lines, titleStyle.Render(fmt.Sprintf("%s %s (%d)", info.Icon, info.Title, len(areas))))
+	lines = append(lines, subtitleStyle.Render(info.ShortDesc))
+	if m.showExplanations {
+		lines = append(lines, m.renderMarkdownExplanation(info.WhatIs, width-4))
+	}
+
+	if m.ownership == nil {
+		lines = append(lines, subtitleStyle.Render("Waiting for git history"))
+		return panelStyle.Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+	}
+	if len(areas) == 0 {
+		lines = append(lines, subtitleStyle.Render("No commits linked to issues"))
+		return panelStyle.Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+	}
+
+	selectedIdx := m.selectedIndex[PanelBusFactor]
+	visibleRows := height - 4
+	if m.showExplanations {
+		visibleRows -= 2
+	}
+	visibleRows = max(visibleRows, 3)
+	startIdx := m.scrollOffset[PanelBusFactor]
+	if selectedIdx >= startIdx+visibleRows {
+		startIdx = selectedIdx - visibleRows + 1
+	}
+	if selectedIdx < startIdx {
+		startIdx = selectedIdx
+	}
+	m.scrollOffset[PanelBusFactor] = startIdx
+	endIdx := min(startIdx+visibleRows, len(areas))
+
+	for i := startIdx; i < endIdx; i++ {
+		a := areas[i]
+		isSelected := isFocused && i == selectedIdx
+
+		share := fmt.Sprintf("%s %.0f%%", a.Authors[0].Author, a.TopShare*100)
+		style := t.Renderer.NewStyle().Foreground(t.Open)
+		name := a.Name
+		if a.Kind == "label" {
+			name = "label:" + name
+		}
+		switch {
+		case a.AtRisk:
+			name = "⚠ " + name
+			style = t.Renderer.NewStyle().Foreground(t.Blocked)
+		case a.BusFactor == 1:
+			style = t.Renderer.NewStyle().Foreground(t.InProgress)
+		}
+
+		prefix := "  "
+		if isSelected {
+			style = style.Bold(true)
+			prefix = t.Renderer.NewStyle().Foreground(t.Primary).Bold(true).Render("▸ ")
+		}
+		share = truncateRunesHelper(share, max((width-4)/2, 8), "…")
+		name = truncateRunesHelper(name, max(width-4-lipgloss.Width(share)-1, 4), "…")
+		gap := strings.Repeat(" ", max(width-4-lipgloss.Width(name)-lipgloss.Width(share), 1))
+		lines = append(lines, prefix+style.Render(name+gap+share))
+	}
+
+	if len(areas) > visibleRows {
+		scrollStyle := t.Renderer.NewStyle().
+			Foreground(t.Subtext).
+			Align(lipgloss.Center).
+			Width(width - 4)
+		lines = append(lines, scrollStyle.Render(fmt.Sprintf("↕ %d/%d", selectedIdx+1, len(areas))))
+	}
+
+	return panelStyle.Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+}
@@ -0,0 +1,29 @@
+package ui
+
+import (
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/claims"
+)
+
+// loadClaims reads the project's claims ledger for the detail view. A broken
+// ledger just leaves claims out.
+func (m *Model) loadClaims() {
+	m.claims = nil
+	if m.workDir == "" {
+		return
+	}
+	if ledger, err := claims.Load(claims.Path(m.workDir)); err == nil {
+		m.claims = ledger
+	}
+}
+
+// renderClaimMD renders the active claim on issueID for the detail viewport.
+// Returns "" when nobody holds it.
+func (m Model) renderClaimMD(issueID string, now time.Time) string {
+	c := m.claims.Get(issueID, now)
+	if c == nil {
+		return ""
+	}
+	return "**🔒 " + claims.Describe(*c, now) + "**\n\n"
+}
@@ -0,0 +1,31 @@
+package ui
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/claims"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestModel_RenderClaimMD(t *testing.T) {
+	projectDir := t.TempDir()
+	m := NewModel([]model.Issue{{ID: "a", Title: "First", Status: model.StatusOpen}}, nil, filepath.Join(projectDir, ".beads", "issues.jsonl"))
+	now := time.Now()
+	if _, err := claims.Acquire(claims.Path(projectDir), "a", "ana", time.Hour, false, now); err != nil {
+		t.Fatal(err)
+	}
+
+	if out := m.renderClaimMD("a", now); out != "" {
+		t.Errorf("claims aren't loaded yet, got %q", out)
+	}
+	m.loadClaims()
+	if out := m.renderClaimMD("a", now); !strings.Contains(out, "claimed by ana until") {
+		t.Errorf("detail should show the claim, got %q", out)
+	}
+	if out := m.renderClaimMD("a", now.Add(2*time.Hour)); out != "" {
+		t.Errorf("expired claim should not render, got %q", out)
+	}
+}
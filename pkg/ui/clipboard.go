@@ -0,0 +1,125 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/atotto/clipboard"
+	"github.com/aymanbagabas/go-osc52/v2"
+	"golang.org/x/term"
+)
+
+// osc52MaxBytes caps what is sent over OSC52. Terminals silently drop
+// larger sequences; xterm's default limit of 100 000 bytes of base64 holds
+// about 75 KB of text.
+const osc52MaxBytes = 75000
+
+// clipboardVia says how a copy reached the clipboard.
+type clipboardVia int
+
+const (
+	// clipboardSystem is the local clipboard tool: pbcopy, xclip, xsel,
+	// wl-copy or clip.exe.
+	clipboardSystem clipboardVia = iota
+	// clipboardOSC52 asks the terminal to set its clipboard with an escape
+	// sequence, which reaches the user's machine over SSH and in headless
+	// sessions with no clipboard tool.
+	clipboardOSC52
+)
+
+// clipboardEnv is what a copy consults, so tests can stand in for the
+// environment, the clipboard tools and the terminal.
+type clipboardEnv struct {
+	getenv   func(string) string
+	system   func(string) error
+	terminal io.Writer // nil when stdout is not a terminal
+}
+
+func defaultClipboardEnv() clipboardEnv {
+	env := clipboardEnv{getenv: os.Getenv, system: clipboard.WriteAll}
+	if term.IsTerminal(int(os.Stdout.Fd())) {
+		env.terminal = os.Stdout
+	}
+	return env
+}
+
+// copyToClipboard copies text with the system clipboard, falling back to
+// OSC52. Over SSH OSC52 goes first, since the remote machine's clipboard is
+// not the user's. BV_CLIPBOARD=system|osc52 forces one method.
+func copyToClipboard(text string) (clipboardVia, error) {
+	return defaultClipboardEnv().copy(text)
+}
+
+func (env clipboardEnv) copy(text string) (clipboardVia, error) {
+	order := []clipboardVia{clipboardSystem, clipboardOSC52}
+	switch strings.ToLower(env.getenv("BV_CLIPBOARD")) {
+	case "system":
+		order = order[:1]
+	case "osc52":
+		order = order[1:]
+	default:
+		if env.getenv("SSH_TTY") != "" || env.getenv("SSH_CONNECTION") != "" {
+			order = []clipboardVia{clipboardOSC52, clipboardSystem}
+		}
+	}
+
+	var errs []error
+	for _, via := range order {
+		var err error
+		if via == clipboardSystem {
+			err = env.system(text)
+		} else {
+			err = env.osc52(text)
+		}
+		if err == nil {
+			return via, nil
+		}
+		errs = append(errs, err)
+	}
+	return order[0], errors.Join(errs...)
+}
+
+// osc52 writes text to the terminal clipboard, wrapped for tmux or screen
+// so the multiplexer passes it on to the outer terminal. tmux needs
+// allow-passthrough (tmux 3.3+) or set-clipboard for it to get through.
+func (env clipboardEnv) osc52(text string) error {
+	if env.terminal == nil {
+		return errors.New("OSC52: output is not a terminal")
+	}
+	if t := env.getenv("TERM"); t == "dumb" || t == "linux" {
+		return fmt.Errorf("OSC52: not supported by TERM=%s", t)
+	}
+	if len(text) > osc52MaxBytes {
+		return fmt.Errorf("OSC52: %d bytes is too large for the terminal clipboard", len(text))
+	}
+
+	seq := osc52.New(text)
+	switch {
+	case env.getenv("TMUX") != "":
+		seq = seq.Tmux()
+	case env.getenv("STY") != "":
+		seq = seq.Screen()
+	}
+	_, err := seq.WriteTo(env.terminal)
+	return err
+}
+
+// copyWithStatus copies text and reports the outcome in the status bar,
+// naming what was copied. Returns false when nothing could be copied.
+func (m *Model) copyWithStatus(text, what string) bool {
+	via, err := copyToClipboard(text)
+	if err != nil {
+		m.statusMsg = fmt.Sprintf("❌ Clipboard unavailable: %v", strings.ReplaceAll(err.Error(), "\n", "; "))
+		m.statusIsError = true
+		return false
+	}
+	m.statusMsg = fmt.Sprintf("📋 Copied %s to clipboard", what)
+	if via == clipboardOSC52 {
+		m.statusMsg += " (via terminal, OSC52)"
+	}
+	m.statusIsError = false
+	return true
+}
@@ -0,0 +1,57 @@
+package ui
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestClipboardEnv_Copy(t *testing.T) {
+	noTool := errors.New("no clipboard utilities available")
+	newEnv := func(vars map[string]string, systemErr error) (clipboardEnv, *bytes.Buffer, *[]string) {
+		var tty bytes.Buffer
+		var system []string
+		return clipboardEnv{
+			getenv: func(k string) string { return vars[k] },
+			system: func(s string) error {
+				system = append(system, s)
+				return systemErr
+			},
+			terminal: &tty,
+		}, &tty, &system
+	}
+
+	env, tty, system := newEnv(map[string]string{"TERM": "xterm"}, nil)
+	if via, err := env.copy("bv-1"); err != nil || via != clipboardSystem || tty.Len() != 0 || len(*system) != 1 {
+		t.Errorf("local copy: via %d err %v, tty %q", via, err, tty.String())
+	}
+
+	env, tty, _ = newEnv(map[string]string{"TERM": "xterm"}, noTool)
+	if via, err := env.copy("bv-1"); err != nil || via != clipboardOSC52 || tty.String() != "\x1b]52;c;YnYtMQ==\x07" {
+		t.Errorf("fallback: via %d err %v, tty %q", via, err, tty.String())
+	}
+
+	env, tty, system = newEnv(map[string]string{"SSH_TTY": "/dev/pts/1", "TMUX": "/tmp/tmux"}, nil)
+	if via, err := env.copy("bv-1"); err != nil || via != clipboardOSC52 || len(*system) != 0 ||
+		!strings.HasPrefix(tty.String(), "\x1bPtmux;\x1b\x1b]52;c;") {
+		t.Errorf("ssh in tmux: via %d err %v, system %v, tty %q", via, err, *system, tty.String())
+	}
+
+	env, tty, _ = newEnv(map[string]string{"BV_CLIPBOARD": "system"}, noTool)
+	if _, err := env.copy("bv-1"); err == nil || tty.Len() != 0 {
+		t.Errorf("BV_CLIPBOARD=system should not fall back: err %v, tty %q", err, tty.String())
+	}
+
+	env, _, _ = newEnv(map[string]string{"TERM": "xterm"}, noTool)
+	env.terminal = nil
+	_, err := env.copy("bv-1")
+	if err == nil || !strings.Contains(err.Error(), "no clipboard utilities") || !strings.Contains(err.Error(), "not a terminal") {
+		t.Errorf("nothing available should explain both failures, got %v", err)
+	}
+
+	env, tty, _ = newEnv(map[string]string{"BV_CLIPBOARD": "osc52"}, nil)
+	if _, err := env.copy(strings.Repeat("x", osc52MaxBytes+1)); err == nil || tty.Len() != 0 {
+		t.Errorf("oversized OSC52 copy should fail without writing: %v", err)
+	}
+}
@@ -0,0 +1,341 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/i18n"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// PaletteCommand is one entry in the command palette. Commands either replay
+// a key binding (Key) so they behave exactly like pressing it, or run a
+// function for things that have no single key (recipes, labels, themes).
+type PaletteCommand struct {
+	Title string // Searchable title, e.g. "Views: Kanban board"
+	Hint  string // Key binding or detail shown on the right
+	Key   string // Key to replay, if set
+	run   func(Model) Model
+}
+
+// CommandPaletteModel is a fuzzy-searchable list of every TUI action
+type CommandPaletteModel struct {
+	commands      []PaletteCommand
+	filtered      []PaletteCommand
+	input         textinput.Model
+	selectedIndex int
+	width         int
+	height        int
+	theme         Theme
+}
+
+// NewCommandPaletteModel creates an empty command palette
+func NewCommandPaletteModel(theme Theme) CommandPaletteModel {
+	ti := textinput.New()
+	ti.Placeholder = "type a command..."
+	ti.CharLimit = 80
+	ti.Width = 40
+	ti.Prompt = "> "
+
+	return CommandPaletteModel{
+		input: ti,
+		theme: theme,
+	}
+}
+
+// SetCommands replaces the available commands and clears the query
+func (p *CommandPaletteModel) SetCommands(commands []PaletteCommand) {
+	p.commands = commands
+	p.input.SetValue("")
+	p.input.Focus()
+	p.selectedIndex = 0
+	p.filter()
+}
+
+// SetSize updates the palette dimensions
+func (p *CommandPaletteModel) SetSize(width, height int) {
+	p.width = width
+	p.height = height
+}
+
+// MoveUp moves selection up
+func (p *CommandPaletteModel) MoveUp() {
+	if p.selectedIndex > 0 {
+		p.selectedIndex--
+	}
+}
+
+// MoveDown moves selection down
+func (p *CommandPaletteModel) MoveDown() {
+	if p.selectedIndex < len(p.filtered)-1 {
+		p.selectedIndex++
+	}
+}
+
+// Selected returns the highlighted command
+func (p *CommandPaletteModel) Selected() (PaletteCommand, bool) {
+	if p.selectedIndex < 0 || p.selectedIndex >= len(p.filtered) {
+		return PaletteCommand{}, false
+	}
+	return p.filtered[p.selectedIndex], true
+}
+
+// UpdateInput processes a key message for the query input
+func (p *CommandPaletteModel) UpdateInput(msg tea.Msg) {
+	p.input, _ = p.input.Update(msg)
+	p.selectedIndex = 0
+	p.filter()
+}
+
+// FilteredCount returns the number of matching commands
+func (p *CommandPaletteModel) FilteredCount() int {
+	return len(p.filtered)
+}
+
+// filter ranks commands against the query with the label picker's fuzzy
+// scoring; ties keep catalog order so related commands stay together.
+func (p *CommandPaletteModel) filter() {
+	query := strings.TrimSpace(p.input.Value())
+	if query == "" {
+		p.filtered = p.commands
+		return
+	}
+
+	type scored struct {
+		cmd   PaletteCommand
+		score int
+	}
+	var matches []scored
+	for _, c := range p.commands {
+		if score := fuzzyScore(c.Title, query); score > 0 {
+			matches = append(matches, scored{c, score})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].score > matches[j].score
+	})
+
+	p.filtered = make([]PaletteCommand, len(matches))
+	for i, match := range matches {
+		p.filtered[i] = match.cmd
+	}
+	if p.selectedIndex >= len(p.filtered) {
+		p.selectedIndex = max(len(p.filtered)-1, 0)
+	}
+}
+
+// View renders the command palette overlay
+func (p *CommandPaletteModel) View() string {
+	t := p.theme
+	width, height := p.width, p.height
+	if width == 0 {
+		width = 80
+	}
+	if height == 0 {
+		height = 24
+	}
+
+	boxWidth := min(64, width-4)
+	maxVisible := max(min(12, height-10), 3)
+
+	var lines []string
+	titleStyle := t.Renderer.NewStyle().
+		Foreground(t.Primary).
+		Bold(true)
+	lines = append(lines, titleStyle.Render("Command Palette"), "")
+
+	inputStyle := t.Renderer.NewStyle().
+		Border(lipgloss.NormalBorder()).
+		BorderForeground(t.Secondary).
+		Padding(0, 1).
+		Width(boxWidth - 6)
+	lines = append(lines, inputStyle.Render(p.input.View()), "")
+
+	if len(p.filtered) == 0 {
+		dimStyle := t.Renderer.NewStyle().
+			Foreground(t.Secondary).
+			Italic(true)
+		lines = append(lines, dimStyle.Render("  No matching commands"))
+	} else {
+		start := 0
+		if p.selectedIndex >= maxVisible {
+			start = p.selectedIndex - maxVisible + 1
+		}
+		end := min(start+maxVisible, len(p.filtered))
+
+		hintStyle := t.Renderer.NewStyle().Foreground(t.Secondary)
+		for i := start; i < end; i++ {
+			c := p.filtered[i]
+			itemStyle := t.Renderer.NewStyle().Foreground(t.Base.GetForeground())
+			prefix := "  "
+			if i == p.selectedIndex {
+				itemStyle = itemStyle.Foreground(t.Primary).Bold(true)
+				prefix = "> "
+			}
+
+			hint := truncateRunesHelper(c.Hint, 18, "…")
+			titleWidth := max(boxWidth-8-lipgloss.Width(hint)-1, 10)
+			title := truncateRunesHelper(c.Title, titleWidth, "…")
+			gap := max(boxWidth-8-lipgloss.Width(prefix+title)-lipgloss.Width(hint), 1)
+			lines = append(lines, itemStyle.Render(prefix+title)+strings.Repeat(" ", gap)+hintStyle.Render(hint))
+		}
+
+		if len(p.filtered) > maxVisible {
+			countStyle := t.Renderer.NewStyle().
+				Foreground(t.Secondary).
+				Italic(true)
+			lines = append(lines, "", countStyle.Render(fmt.Sprintf("  (%d/%d)", p.selectedIndex+1, len(p.filtered))))
+		}
+	}
+
+	footerStyle := t.Renderer.NewStyle().
+		Foreground(t.Secondary).
+		Italic(true)
+	lines = append(lines, "", footerStyle.Render("↑/↓: navigate | enter: run | esc: cancel"))
+
+	boxStyle := t.Renderer.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(t.Primary).
+		Padding(1, 2).
+		Width(boxWidth)
+
+	return lipgloss.Place(
+		width,
+		height,
+		lipgloss.Center,
+		lipgloss.Center,
+		boxStyle.Render(strings.Join(lines, "\n")),
+	)
+}
+
+// paletteSkipActions are keymap actions that make no sense as commands.
+var paletteSkipActions = map[string]bool{
+	"palette":    true,
+	"quit":       true,
+	"force_quit": true,
+}
+
+// paletteCommands lists every command available from the view the palette
+// was opened in: key actions for that scope and the global ones, then
+// recipes, labels and themes.
+func (m Model) paletteCommands(scope KeyScope) []PaletteCommand {
+	var commands []PaletteCommand
+
+	for _, a := range KeyActions() {
+		if a.Section == "Navigation" || a.HelpRow != "" || paletteSkipActions[a.ID] {
+			continue // Movement keys are not worth a palette entry
+		}
+		if a.Scope != ScopeGlobal && a.Scope != scope {
+			continue
+		}
+		keys := m.keymap.Keys(a.ID)
+		if len(keys) == 0 {
+			continue // Unbound by the user
+		}
+		hints := make([]string, len(keys))
+		for i, k := range keys {
+			hints[i] = DisplayKey(k)
+		}
+		commands = append(commands, PaletteCommand{
+			Title: i18n.T(a.Section) + ": " + i18n.T(a.Desc),
+			Hint:  strings.Join(hints, "/"),
+			Key:   keys[0],
+		})
+	}
+
+	for _, r := range m.recipeLoader.List() {
+		commands = append(commands, PaletteCommand{
+			Title: "Recipe: " + r.Name,
+			Hint:  r.Description,
+			run: func(m Model) Model {
+				m.activeRecipe = &r
+				m.applyRecipe(&r)
+				m.focused = focusList
+				return m
+			},
+		})
+	}
+
+	if len(m.issues) > 0 {
+		labels := analysis.ExtractLabels(m.issues)
+		counts := extractLabelCounts(labels.Stats)
+		for _, label := range sortLabelsByCountDesc(labels.Labels, counts) {
+			commands = append(commands, PaletteCommand{
+				Title: "Filter label: " + label,
+				Hint:  fmt.Sprintf("%d issues", counts[label]),
+				run: func(m Model) Model {
+					m.filterByLabel(label)
+					m.focused = focusList
+					return m
+				},
+			})
+		}
+	}
+
+	for _, name := range m.themes.Names() {
+		hint := ""
+		if name == m.theme.Name {
+			hint = "current"
+		}
+		commands = append(commands, PaletteCommand{
+			Title: "Theme: " + name,
+			Hint:  hint,
+			run: func(m Model) Model {
+				t, err := m.themes.Theme(m.theme.Renderer, name)
+				if err != nil {
+					m.statusMsg = fmt.Sprintf("Theme: %v", err)
+					m.statusIsError = true
+					return m
+				}
+				m.SetTheme(t)
+				m.statusMsg = "Theme: " + name
+				return m
+			},
+		})
+	}
+	return commands
+}
+
+// openCommandPalette shows the palette with the commands for the current view.
+func (m Model) openCommandPalette() Model {
+	m.paletteReturnFocus = m.focused
+	m.commandPalette.SetCommands(m.paletteCommands(KeyScopeForContext(m.CurrentContext())))
+	m.commandPalette.SetSize(m.width, m.height-1)
+	m.showCommandPalette = true
+	m.focused = focusCommandPalette
+	return m
+}
+
+// handleCommandPaletteKeys handles keyboard input while the palette is open.
+// Running a key command replays that key against the view the palette was
+// opened from, so it may return a command of its own.
+func (m Model) handleCommandPaletteKeys(msg tea.KeyMsg) (Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.showCommandPalette = false
+		m.focused = m.paletteReturnFocus
+	case "up", "ctrl+p", "ctrl+k":
+		m.commandPalette.MoveUp()
+	case "down", "ctrl+n", "ctrl+j":
+		m.commandPalette.MoveDown()
+	case "enter":
+		selected, ok := m.commandPalette.Selected()
+		m.showCommandPalette = false
+		m.focused = m.paletteReturnFocus
+		if !ok {
+			return m, nil
+		}
+		if selected.run != nil {
+			return selected.run(m), nil
+		}
+		updated, cmd := m.Update(keyMsgFromString(selected.Key))
+		return updated.(Model), cmd
+	default:
+		m.commandPalette.UpdateInput(msg)
+	}
+	return m, nil
+}
@@ -0,0 +1,140 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func newPaletteTestModel(t *testing.T) Model {
+	t.Helper()
+	issues := []model.Issue{
+		{ID: "1", Title: "One", Status: model.StatusOpen, Labels: []string{"backend"}},
+		{ID: "2", Title: "Two", Status: model.StatusOpen, Labels: []string{"frontend", "backend"}},
+	}
+	m := NewModel(issues, nil, "")
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 140, Height: 40})
+	return updated.(Model)
+}
+
+func paletteTitles(p CommandPaletteModel) []string {
+	titles := make([]string, len(p.filtered))
+	for i, c := range p.filtered {
+		titles[i] = c.Title
+	}
+	return titles
+}
+
+func typeInPalette(t *testing.T, m Model, text string) Model {
+	t.Helper()
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(text)})
+	return updated.(Model)
+}
+
+func TestCommandPalette_RunsKeyAction(t *testing.T) {
+	m := newPaletteTestModel(t)
+	updated, _ := m.Update(keyMsgFromString("ctrl+p"))
+	m = updated.(Model)
+	if !m.showCommandPalette || m.CurrentContext() != ContextCommandPalette {
+		t.Fatalf("ctrl+p should open the palette (context %s)", m.CurrentContext())
+	}
+	if !strings.Contains(m.View(), "Command Palette") {
+		t.Error("palette overlay not rendered")
+	}
+
+	// Letters are search input, not shortcuts
+	m = typeInPalette(t, m, "kanban")
+	if m.isBoardView {
+		t.Fatal("typing in the palette should not trigger view keys")
+	}
+	if got := paletteTitles(m.commandPalette); len(got) == 0 || got[0] != "Views: Kanban board" {
+		t.Fatalf("best match = %v", got)
+	}
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(Model)
+	if m.showCommandPalette || !m.isBoardView || m.focused != focusBoard {
+		t.Errorf("running the command should open the board (palette %v, board %v)", m.showCommandPalette, m.isBoardView)
+	}
+}
+
+func TestCommandPalette_FollowsCustomKeymap(t *testing.T) {
+	m := newPaletteTestModel(t)
+	km := DefaultKeymap()
+	km.apply(KeymapFile{Keys: map[string]keyList{"view.board": {"z"}}}, "user")
+	km.resolveConflicts()
+	m.SetKeymap(km)
+
+	m = m.openCommandPalette()
+	m = typeInPalette(t, m, "kanban")
+	if c, _ := m.commandPalette.Selected(); c.Hint != "z" {
+		t.Errorf("hint should show the remapped key, got %q", c.Hint)
+	}
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(Model)
+	if !m.isBoardView {
+		t.Error("palette should run the action through its remapped key")
+	}
+}
+
+func TestCommandPalette_LabelRecipeAndTheme(t *testing.T) {
+	snapshotThemeColors(t)
+	m := newPaletteTestModel(t)
+	run := func(query string) {
+		t.Helper()
+		m = m.openCommandPalette()
+		m = typeInPalette(t, m, query)
+		updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+		m = updated.(Model)
+	}
+
+	run("filter label: frontend")
+	if m.currentFilter != "label:frontend" || len(m.list.Items()) != 1 {
+		t.Errorf("label command: filter %q, %d items", m.currentFilter, len(m.list.Items()))
+	}
+
+	run("recipe: triage")
+	if m.activeRecipe == nil || m.activeRecipe.Name != "triage" {
+		t.Errorf("recipe command: active recipe %v", m.activeRecipe)
+	}
+
+	run("theme: solarized")
+	if m.theme.Name != "solarized" {
+		t.Errorf("theme command: theme %q", m.theme.Name)
+	}
+}
+
+func TestCommandPalette_ScopeAndCancel(t *testing.T) {
+	m := newPaletteTestModel(t)
+	updated, _ := m.Update(keyMsgFromString("g"))
+	m = updated.(Model)
+
+	m = m.openCommandPalette()
+	titles := strings.Join(paletteTitles(m.commandPalette), "\n")
+	if !strings.Contains(titles, "Graph View: Cycle-break wizard") {
+		t.Error("graph commands missing in the graph view")
+	}
+	for _, unwanted := range []string{"Filters & Sort: Open issues", "Navigation: Move down", "Global: Command palette", "Global: Force quit"} {
+		if strings.Contains(titles, unwanted) {
+			t.Errorf("%q should not be offered here", unwanted)
+		}
+	}
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = updated.(Model)
+	if m.showCommandPalette || m.focused != focusGraph {
+		t.Errorf("esc should close the palette and return to the graph, focus %v", m.focused)
+	}
+
+	m = m.openCommandPalette()
+	m = typeInPalette(t, m, "zzzzqqq")
+	if m.commandPalette.FilteredCount() != 0 || !strings.Contains(m.commandPalette.View(), "No matching commands") {
+		t.Error("expected the empty state")
+	}
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if updated.(Model).showCommandPalette {
+		t.Error("enter with no match should just close the palette")
+	}
+}
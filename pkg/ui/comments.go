@@ -0,0 +1,230 @@
+package ui
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// renderCommentThreadMD renders an issue's comments as a chronological thread
+// for the detail viewport. Returns "" when there are no comments.
+func renderCommentThreadMD(comments []*model.Comment) string {
+	if len(comments) == 0 {
+		return ""
+	}
+
+	thread := make([]*model.Comment, 0, len(comments))
+	authors := make(map[string]bool)
+	for _, c := range comments {
+		if c == nil {
+			continue
+		}
+		thread = append(thread, c)
+		authors[commentAuthor(c)] = true
+	}
+	if len(thread) == 0 {
+		return ""
+	}
+	// Stable so comments sharing a timestamp keep their file order
+	sort.SliceStable(thread, func(i, j int) bool {
+		return thread[i].CreatedAt.Before(thread[j].CreatedAt)
+	})
+
+	var sb strings.Builder
+	participants := "participant"
+	if len(authors) != 1 {
+		participants += "s"
+	}
+	sb.WriteString(fmt.Sprintf("### 💬 Comments (%d · %d %s)\n\n", len(thread), len(authors), participants))
+	for i, c := range thread {
+		header := "**" + commentAuthor(c) + "**"
+		if !c.CreatedAt.IsZero() {
+			header += fmt.Sprintf(" · %s · _%s_", FormatTimeRel(c.CreatedAt), c.CreatedAt.Local().Format("2006-01-02 15:04"))
+		}
+		sb.WriteString("> " + header + "\n>\n")
+		sb.WriteString("> " + strings.ReplaceAll(strings.TrimRight(c.Text, "\n"), "\n", "\n> ") + "\n\n")
+		if i < len(thread)-1 {
+			sb.WriteString("\n")
+		}
+	}
+	return sb.String()
+}
+
+func commentAuthor(c *model.Comment) string {
+	if c.Author == "" {
+		return "unknown"
+	}
+	return c.Author
+}
+
+// CommentCommand returns the bd command that adds a comment to an issue.
+func CommentCommand(issueID, text string) string {
+	return fmt.Sprintf("bd comment %s %s", issueID, strconv.Quote(text))
+}
+
+// commentAuthorName picks the author recorded for comments added from the TUI.
+func commentAuthorName() string {
+	if actor := analysis.CurrentActor(); actor != "" {
+		return actor
+	}
+	return "bv"
+}
+
+func newCommentInput(theme Theme) textinput.Model {
+	ci := textinput.New()
+	ci.Placeholder = "Write a comment..."
+	ci.CharLimit = 2000
+	ci.Width = 60
+	ci.Prompt = "💬 "
+	ci.PromptStyle = lipgloss.NewStyle().Foreground(theme.Primary).Bold(true)
+	ci.TextStyle = lipgloss.NewStyle().Foreground(theme.Base.GetForeground())
+	return ci
+}
+
+// openCommentPrompt shows the comment input for the selected issue.
+func (m Model) openCommentPrompt() Model {
+	item, ok := m.list.SelectedItem().(IssueItem)
+	if !ok {
+		return m
+	}
+	m.commentIssueID = item.Issue.ID
+	m.commentReturnFocus = m.focused
+	m.showCommentPrompt = true
+	m.commentInput.SetValue("")
+	m.commentInput.Focus()
+	m.focused = focusCommentInput
+	return m
+}
+
+// handleCommentInputKeys handles keyboard input for the comment prompt
+func (m Model) handleCommentInputKeys(msg tea.KeyMsg) Model {
+	switch msg.String() {
+	case "enter":
+		text := strings.TrimSpace(m.commentInput.Value())
+		m = m.closeCommentPrompt()
+		if text == "" {
+			m.statusMsg = "Empty comment discarded"
+			m.statusIsError = false
+			return m
+		}
+		m = m.addComment(m.commentIssueID, text)
+	case "esc":
+		m = m.closeCommentPrompt()
+	default:
+		m.commentInput, _ = m.commentInput.Update(msg)
+	}
+	return m
+}
+
+func (m Model) closeCommentPrompt() Model {
+	m.showCommentPrompt = false
+	m.commentInput.Blur()
+	m.focused = m.commentReturnFocus
+	return m
+}
+
+// addComment appends a comment to the issue in the beads file and shows it
+// immediately; the file watcher reload then picks up the persisted copy.
+func (m Model) addComment(issueID, text string) Model {
+	if m.beadsPath == "" {
+		m.statusMsg = "No single data file to edit; run: " + CommentCommand(issueID, text)
+		m.statusIsError = true
+		return m
+	}
+
+	comment := model.Comment{
+		IssueID:   issueID,
+		Author:    commentAuthorName(),
+		Text:      text,
+		CreatedAt: time.Now().UTC(),
+	}
+	edit, err := loader.AddComment(m.beadsPath, issueID, comment)
+	switch {
+	case err != nil:
+		m.statusMsg = "Failed to add comment: " + err.Error()
+		m.statusIsError = true
+		return m
+	case edit == nil:
+		m.statusMsg = fmt.Sprintf("Issue %s not found in %s", issueID, filepath.Base(m.beadsPath))
+		m.statusIsError = true
+		return m
+	}
+
+	var comments []*model.Comment
+	if issue, ok := m.issueMap[issueID]; ok {
+		issue.Comments = append(issue.Comments, &comment)
+		comments = issue.Comments
+	}
+	for i, it := range m.list.Items() {
+		if item, ok := it.(IssueItem); ok && item.Issue.ID == issueID {
+			if comments == nil {
+				comments = append(item.Issue.Comments, &comment)
+			}
+			item.Issue.Comments = comments
+			m.list.SetItem(i, item)
+			break
+		}
+	}
+	m.updateViewportContent()
+
+	m.statusMsg = fmt.Sprintf("✓ Comment added to %s in %s", issueID, filepath.Base(m.beadsPath))
+	m.statusIsError = false
+	return m.recordEdit("Comment on "+issueID, edit)
+}
+
+// renderCommentPrompt renders the comment input overlay
+func (m Model) renderCommentPrompt() string {
+	t := m.theme
+
+	boxStyle := t.Renderer.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(t.Primary).
+		Padding(1, 3)
+
+	titleStyle := t.Renderer.NewStyle().
+		Foreground(t.Primary).
+		Bold(true)
+
+	subtitleStyle := t.Renderer.NewStyle().
+		Foreground(t.Subtext).
+		Italic(true)
+
+	keyStyle := t.Renderer.NewStyle().
+		Foreground(t.Primary).
+		Bold(true)
+
+	textStyle := t.Renderer.NewStyle().
+		Foreground(t.Base.GetForeground())
+
+	subtitle := m.commentIssueID
+	if issue, ok := m.issueMap[m.commentIssueID]; ok {
+		subtitle += " · " + truncateRunesHelper(issue.Title, 50, "…")
+		if n := len(issue.Comments); n > 0 {
+			subtitle += fmt.Sprintf(" · %d existing", n)
+		}
+	}
+
+	content := titleStyle.Render("💬 Add Comment") + "\n" +
+		subtitleStyle.Render(subtitle) + "\n\n" +
+		m.commentInput.View() + "\n\n" +
+		textStyle.Render("Press ") + keyStyle.Render("Enter") + textStyle.Render(" to post, ") +
+		keyStyle.Render("Esc") + textStyle.Render(" to cancel")
+
+	return lipgloss.Place(
+		m.width,
+		m.height-1,
+		lipgloss.Center,
+		lipgloss.Center,
+		boxStyle.Render(content),
+	)
+}
@@ -0,0 +1,128 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestRenderCommentThreadMD(t *testing.T) {
+	if got := renderCommentThreadMD(nil); got != "" {
+		t.Errorf("no comments should render nothing, got %q", got)
+	}
+
+	base := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	out := renderCommentThreadMD([]*model.Comment{
+		{Author: "bob", Text: "second", CreatedAt: base.Add(time.Hour)},
+		nil,
+		{Author: "alice", Text: "first\nline two", CreatedAt: base},
+		{Text: "anonymous", CreatedAt: base.Add(2 * time.Hour)},
+	})
+
+	if !strings.Contains(out, "Comments (3 · 3 participants)") {
+		t.Errorf("missing thread header:\n%s", out)
+	}
+	first, second, third := strings.Index(out, "first"), strings.Index(out, "second"), strings.Index(out, "anonymous")
+	if first < 0 || !(first < second && second < third) {
+		t.Errorf("comments not in chronological order:\n%s", out)
+	}
+	if !strings.Contains(out, "> line two") {
+		t.Errorf("multi-line comment should stay quoted:\n%s", out)
+	}
+	if !strings.Contains(out, "**unknown**") {
+		t.Errorf("missing author placeholder:\n%s", out)
+	}
+
+	single := renderCommentThreadMD([]*model.Comment{{Author: "a", Text: "x"}})
+	if !strings.Contains(single, "(1 · 1 participant)") {
+		t.Errorf("singular header wrong:\n%s", single)
+	}
+}
+
+func TestCommentCommand(t *testing.T) {
+	if got := CommentCommand("bv-1", `say "hi"`); got != `bd comment bv-1 "say \"hi\""` {
+		t.Errorf("CommentCommand = %s", got)
+	}
+}
+
+func TestModel_AddCommentWritesBeadsFile(t *testing.T) {
+	t.Setenv("BD_ACTOR", "reviewer")
+	path := filepath.Join(t.TempDir(), "issues.jsonl")
+	if err := os.WriteFile(path, []byte(`{"id":"bv-1","title":"One","status":"open","issue_type":"task"}`+"\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	issues, err := loader.LoadIssuesFromFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m := NewModel(issues, nil, path)
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 140, Height: 40})
+	m = updated.(Model)
+	press := func(msg tea.KeyMsg) {
+		updated, _ := m.Update(msg)
+		m = updated.(Model)
+	}
+
+	press(keyMsgFromString("M"))
+	if !m.showCommentPrompt || m.focused != focusCommentInput || m.CurrentContext() != ContextCommentInput {
+		t.Fatalf("M should open the comment prompt (focus %v, context %s)", m.focused, m.CurrentContext())
+	}
+	if !strings.Contains(m.View(), "Add Comment") {
+		t.Error("prompt overlay not rendered")
+	}
+
+	// Letters go to the input, not to global shortcuts
+	press(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("bump priority")})
+	if m.isBoardView {
+		t.Fatal("typing b in the prompt should not open the board")
+	}
+	press(tea.KeyMsg{Type: tea.KeyEnter})
+
+	if m.showCommentPrompt || m.focused != focusList {
+		t.Fatalf("prompt should close and restore list focus, got %v", m.focused)
+	}
+	if m.statusIsError || !strings.Contains(m.statusMsg, "Comment added") {
+		t.Fatalf("status = %q", m.statusMsg)
+	}
+	if item := m.list.SelectedItem().(IssueItem); len(item.Issue.Comments) != 1 {
+		t.Errorf("list item not updated: %+v", item.Issue.Comments)
+	}
+
+	reloaded, err := loader.LoadIssuesFromFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := reloaded[0].Comments
+	if len(c) != 1 || c[0].Text != "bump priority" || c[0].Author != "reviewer" || c[0].ID != 1 {
+		t.Errorf("persisted comments = %+v", c)
+	}
+
+	// Esc cancels without writing
+	press(keyMsgFromString("M"))
+	press(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("nope")})
+	press(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.showCommentPrompt {
+		t.Fatal("esc should close the prompt")
+	}
+	if reloaded, _ := loader.LoadIssuesFromFile(path); len(reloaded[0].Comments) != 1 {
+		t.Error("cancelled comment was written")
+	}
+}
+
+func TestModel_AddCommentWithoutBeadsFile(t *testing.T) {
+	m := NewModel([]model.Issue{{ID: "bv-2", Title: "Two", Status: model.StatusOpen}}, nil, "")
+	m = m.openCommentPrompt()
+	m.commentInput.SetValue("hello")
+	m = m.handleCommentInputKeys(tea.KeyMsg{Type: tea.KeyEnter})
+
+	if !m.statusIsError || !strings.Contains(m.statusMsg, `bd comment bv-2 "hello"`) {
+		t.Errorf("status should suggest the bd command, got %q", m.statusMsg)
+	}
+}
@@ -0,0 +1,217 @@
+package ui
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ConflictResolver steps through the conflict blocks a merge left in a beads
+// JSONL file and lets the user take ours or theirs for each conflicting
+// field, then writes the resolved file. It runs as its own program.
+type ConflictResolver struct {
+	path    string
+	blocks  []loader.ConflictBlock
+	fields  []map[string]json.RawMessage // Per block: ours' fields
+	theirs  []map[string]json.RawMessage // Per block: theirs' fields
+	choices []map[string]bool            // Per block: fields taken from theirs
+	block   int
+	cursor  int
+	saved   bool
+	err     error
+	width   int
+	height  int
+}
+
+// NewConflictResolver creates a resolver over the conflict blocks of the
+// file at path, every field defaulting to ours
+func NewConflictResolver(path string, blocks []loader.ConflictBlock) ConflictResolver {
+	r := ConflictResolver{path: path, blocks: blocks, width: 100, height: 30}
+	for _, b := range blocks {
+		var ours, theirs map[string]json.RawMessage
+		_ = json.Unmarshal(b.Ours, &ours)
+		_ = json.Unmarshal(b.Theirs, &theirs)
+		r.fields = append(r.fields, ours)
+		r.theirs = append(r.theirs, theirs)
+		r.choices = append(r.choices, make(map[string]bool))
+	}
+	return r
+}
+
+// Saved reports whether the resolved file was written
+func (r ConflictResolver) Saved() bool {
+	return r.saved
+}
+
+// Err returns the error that stopped the last write, if any
+func (r ConflictResolver) Err() error {
+	return r.err
+}
+
+// Init implements tea.Model
+func (r ConflictResolver) Init() tea.Cmd {
+	return nil
+}
+
+// Update implements tea.Model
+func (r ConflictResolver) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		r.width, r.height = msg.Width, msg.Height
+	case tea.KeyMsg:
+		if len(r.blocks) == 0 {
+			return r, tea.Quit
+		}
+		fields := r.blocks[r.block].Fields
+		switch msg.String() {
+		case "j", "down":
+			if r.cursor < len(fields)-1 {
+				r.cursor++
+			}
+		case "k", "up":
+			if r.cursor > 0 {
+				r.cursor--
+			}
+		case "n", "tab":
+			r.goTo(r.block + 1)
+		case "p", "shift+tab":
+			r.goTo(r.block - 1)
+		case "o", "h", "left":
+			r.choices[r.block][fields[r.cursor]] = false
+		case "t", "l", "right":
+			r.choices[r.block][fields[r.cursor]] = true
+		case " ":
+			r.choices[r.block][fields[r.cursor]] = !r.choices[r.block][fields[r.cursor]]
+		case "O", "T":
+			for _, f := range fields {
+				r.choices[r.block][f] = msg.String() == "T"
+			}
+		case "w":
+			if r.err = r.write(); r.err == nil {
+				r.saved = true
+				return r, tea.Quit
+			}
+		case "q", "esc", "ctrl+c":
+			return r, tea.Quit
+		}
+	}
+	return r, nil
+}
+
+func (r *ConflictResolver) goTo(block int) {
+	if block >= 0 && block < len(r.blocks) {
+		r.block, r.cursor = block, 0
+	}
+}
+
+// Resolved returns each block's record under the current choices, nil for
+// an issue that ends up deleted
+func (r ConflictResolver) Resolved() ([]json.RawMessage, error) {
+	resolved := make([]json.RawMessage, len(r.blocks))
+	for i, b := range r.blocks {
+		rec, err := b.Resolve(r.choices[i])
+		if err != nil {
+			return nil, fmt.Errorf("resolving %s: %w", b.IssueID, err)
+		}
+		resolved[i] = rec
+	}
+	return resolved, nil
+}
+
+func (r ConflictResolver) write() error {
+	resolved, err := r.Resolved()
+	if err != nil {
+		return err
+	}
+	return loader.ResolveConflictsInFile(r.path, resolved)
+}
+
+// View implements tea.Model
+func (r ConflictResolver) View() string {
+	titleStyle := lipgloss.NewStyle().Foreground(ColorPrimary).Bold(true)
+	mutedStyle := lipgloss.NewStyle().Foreground(ColorMuted)
+	if len(r.blocks) == 0 {
+		return titleStyle.Render("No merge conflicts in "+r.path) + "\n" + mutedStyle.Render("Press any key to exit") + "\n"
+	}
+
+	var sb strings.Builder
+	b := r.blocks[r.block]
+	sb.WriteString(titleStyle.Render(fmt.Sprintf("Merge conflict %d/%d · %s", r.block+1, len(r.blocks), b.IssueID)))
+	if title := r.title(); title != "" {
+		sb.WriteString(" " + lipgloss.NewStyle().Foreground(ColorText).Render(title))
+	}
+	sb.WriteString("\n" + mutedStyle.Render(r.path) + "\n\n")
+
+	colWidth := (r.width - 20) / 2
+	if colWidth < 12 {
+		colWidth = 12
+	}
+	nameStyle := lipgloss.NewStyle().Width(16)
+	pickStyle := lipgloss.NewStyle().Foreground(ColorSuccess).Bold(true).Width(colWidth)
+	otherStyle := lipgloss.NewStyle().Foreground(ColorMuted).Width(colWidth)
+	sb.WriteString("  " + nameStyle.Render("field") + " " +
+		lipgloss.NewStyle().Bold(true).Width(colWidth).Render("ours") + " " +
+		lipgloss.NewStyle().Bold(true).Width(colWidth).Render("theirs") + "\n")
+
+	for i, field := range b.Fields {
+		ours, theirs := r.sideValues(field)
+		oursCell, theirsCell := pickStyle, otherStyle
+		if r.choices[r.block][field] {
+			oursCell, theirsCell = otherStyle, pickStyle
+		}
+		cursor := "  "
+		if i == r.cursor {
+			cursor = lipgloss.NewStyle().Foreground(ColorPrimary).Render("▸ ")
+		}
+		name := field
+		if field == loader.WholeIssue {
+			name = "(issue)"
+		}
+		sb.WriteString(cursor + nameStyle.Render(truncateRunesHelper(name, 16, "…")) + " " +
+			oursCell.Render(truncateRunesHelper(ours, colWidth, "…")) + " " +
+			theirsCell.Render(truncateRunesHelper(theirs, colWidth, "…")) + "\n")
+	}
+
+	if r.err != nil {
+		sb.WriteString("\n" + lipgloss.NewStyle().Foreground(ColorDanger).Render("Error: "+r.err.Error()) + "\n")
+	}
+	sb.WriteString("\n" + mutedStyle.Render("j/k field • o/t take ours/theirs • space toggle • O/T whole issue • n/p conflict • w write • q quit") + "\n")
+	return sb.String()
+}
+
+// sideValues renders a conflicting field's value on each side
+func (r ConflictResolver) sideValues(field string) (string, string) {
+	b := r.blocks[r.block]
+	if field == loader.WholeIssue {
+		describe := func(raw json.RawMessage) string {
+			if raw == nil {
+				return "deleted"
+			}
+			return "kept (changed)"
+		}
+		return describe(b.Ours), describe(b.Theirs)
+	}
+	value := func(fields map[string]json.RawMessage) string {
+		raw, ok := fields[field]
+		if !ok {
+			return "(unset)"
+		}
+		return string(raw)
+	}
+	return value(r.fields[r.block]), value(r.theirs[r.block])
+}
+
+// title returns the issue title on either side of the current block
+func (r ConflictResolver) title() string {
+	for _, fields := range []map[string]json.RawMessage{r.fields[r.block], r.theirs[r.block]} {
+		var title string
+		if fields != nil && json.Unmarshal(fields["title"], &title) == nil && title != "" {
+			return title
+		}
+	}
+	return ""
+}
@@ -0,0 +1,72 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestConflictResolver_WritesChoices(t *testing.T) {
+	data := `{"id":"A","title":"Alpha","status":"open","issue_type":"task"}
+<<<<<<< ours B [priority status]
+{"id":"B","title":"Beta","status":"closed","priority":1,"issue_type":"task"}
+=======
+{"id":"B","title":"Beta","status":"in_progress","priority":3,"issue_type":"task"}
+>>>>>>> theirs
+<<<<<<< ours C [*]
+{"id":"C","title":"Gamma","status":"blocked","issue_type":"task"}
+=======
+>>>>>>> theirs
+`
+	path := filepath.Join(t.TempDir(), "issues.jsonl")
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	blocks, err := loader.ParseConflictBlocks([]byte(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var m tea.Model = NewConflictResolver(path, blocks)
+	view := m.View()
+	for _, s := range []string{"Merge conflict 1/2", "B", "Beta", "priority", "status"} {
+		if !strings.Contains(view, s) {
+			t.Errorf("view missing %q:\n%s", s, view)
+		}
+	}
+
+	// Take theirs for status (second field), keep ours for priority, then
+	// take theirs (deleted) for C
+	for _, key := range []string{"j", "t", "n", "T", "w"} {
+		m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)})
+	}
+	r := m.(ConflictResolver)
+	if r.Err() != nil || !r.Saved() {
+		t.Fatalf("write failed: %v", r.Err())
+	}
+
+	issues, err := loader.LoadIssuesFromFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(issues) != 2 || issues[0].ID != "A" || issues[1].ID != "B" {
+		t.Fatalf("resolved issues = %+v, want A and B", issues)
+	}
+	if b := issues[1]; b.Status != "in_progress" || b.Priority != 1 {
+		t.Errorf("B = status %s priority %d, want theirs' status and ours' priority", b.Status, b.Priority)
+	}
+}
+
+func TestConflictResolver_NoConflicts(t *testing.T) {
+	r := NewConflictResolver("issues.jsonl", nil)
+	if !strings.Contains(r.View(), "No merge conflicts") {
+		t.Errorf("view = %q", r.View())
+	}
+	if _, cmd := r.Update(tea.KeyMsg{Type: tea.KeyEnter}); cmd == nil {
+		t.Error("any key should quit when there is nothing to resolve")
+	}
+}
@@ -17,6 +17,14 @@ const (
 	ContextLabelDrilldown    Context = "label-drilldown"
 	ContextLabelGraphAnalysis Context = "label-graph-analysis"
 	ContextTimeTravelInput   Context = "time-travel-input"
+	ContextCommentInput      Context = "comment-input"
+	ContextCommandPalette    Context = "command-palette"
+	ContextLayoutPicker      Context = "layout-picker"
+	ContextChangeLog         Context = "change-log"
+	ContextMyWork            Context = "my-work"
+	ContextDependencyTree    Context = "dependency-tree"
+	ContextGoals             Context = "goals"
+	ContextSandbox           Context = "sandbox"
 	ContextAlerts            Context = "alerts"
 	ContextRepoPicker        Context = "repo-picker"
 	ContextAgentPrompt       Context = "agent-prompt"
@@ -101,11 +109,51 @@ func (m Model) CurrentContext() Context {
 		return ContextTimeTravelInput
 	}
 
+	// Comment input prompt
+	if m.showCommentPrompt {
+		return ContextCommentInput
+	}
+
+	// Command palette
+	if m.showCommandPalette {
+		return ContextCommandPalette
+	}
+
+	// Split layout picker
+	if m.showLayoutPicker {
+		return ContextLayoutPicker
+	}
+
 	// Alerts panel
 	if m.showAlertsPanel {
 		return ContextAlerts
 	}
 
+	// Reload change log
+	if m.showChangeLog {
+		return ContextChangeLog
+	}
+
+	// Personal queue
+	if m.showMyWork {
+		return ContextMyWork
+	}
+
+	// Dependency tree of the selected issue
+	if m.showDepTree {
+		return ContextDependencyTree
+	}
+
+	// Goals panel
+	if m.showGoals {
+		return ContextGoals
+	}
+
+	// Simulation sandbox
+	if m.showSandbox {
+		return ContextSandbox
+	}
+
 	// Repo picker overlay (workspace mode)
 	if m.showRepoPicker {
 		return ContextRepoPicker
@@ -132,6 +180,16 @@ func (m Model) CurrentContext() Context {
 		return ContextLabelDashboard
 	}
 
+	// Pinned split layout: the focused pane decides
+	if m.layoutActive() {
+		switch m.focused {
+		case focusGraph:
+			return ContextGraph
+		case focusBoard:
+			return ContextBoard
+		}
+	}
+
 	// Graph view
 	if m.isGraphView {
 		return ContextGraph
@@ -197,6 +255,14 @@ func (c Context) Description() string {
 		ContextLabelDrilldown:     "Label drilldown",
 		ContextLabelGraphAnalysis: "Label graph analysis",
 		ContextTimeTravelInput:    "Time-travel input",
+		ContextCommentInput:       "Comment input",
+		ContextCommandPalette:     "Command palette",
+		ContextLayoutPicker:       "Layout picker",
+		ContextChangeLog:          "Change log",
+		ContextMyWork:             "My work",
+		ContextDependencyTree:     "Dependency tree",
+		ContextGoals:              "Goals",
+		ContextSandbox:            "Simulation sandbox",
 		ContextAlerts:             "Alerts panel",
 		ContextRepoPicker:         "Repo picker",
 		ContextAgentPrompt:        "Agent prompt",
@@ -228,7 +294,8 @@ func (c Context) IsOverlay() bool {
 	case ContextLabelPicker, ContextRecipePicker, ContextHelp, ContextQuitConfirm,
 		ContextLabelHealthDetail, ContextLabelDrilldown, ContextLabelGraphAnalysis,
 		ContextTimeTravelInput, ContextAlerts, ContextRepoPicker, ContextAgentPrompt,
-		ContextCassSession:
+		ContextCassSession, ContextCommentInput, ContextCommandPalette, ContextLayoutPicker,
+		ContextChangeLog, ContextMyWork, ContextDependencyTree, ContextGoals, ContextSandbox:
 		return true
 	}
 	return false
@@ -274,6 +341,14 @@ func (c Context) TutorialPages() []int {
 		ContextLabelDrilldown:     {11},          // Labels
 		ContextLabelGraphAnalysis: {6, 11},       // Graph, Labels
 		ContextTimeTravelInput:    {10},          // Time-Travel
+		ContextCommentInput:       {4},           // Detail View
+		ContextCommandPalette:     {13},          // Keyboard Reference
+		ContextLayoutPicker:       {4, 2},        // Detail View, List View
+		ContextChangeLog:          {2},           // List View
+		ContextMyWork:             {9},           // Actionable View
+		ContextDependencyTree:     {6, 4},        // Graph View, Detail View
+		ContextGoals:              {9},           // Actionable View
+		ContextSandbox:            {9, 6},        // Actionable View, Graph View
 		ContextQuitConfirm:        {1},           // Navigation basics
 		ContextCassSession:        {8},           // History (cass integrates with history)
 	}
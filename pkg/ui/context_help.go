@@ -99,6 +99,7 @@ const contextHelpList = `## List View
   o         Open issues only
   c         Closed issues only
   r         Ready (no blockers)
+  I         In review
   /         Fuzzy search
   Ctrl+S    Semantic search (AI)
   H         Hybrid ranking
@@ -122,6 +123,7 @@ const contextHelpGraph = `## Graph View
   h/l       Navigate siblings
   Enter     View selected issue
   f         Focus on subgraph
+  B         Cycle-break wizard
   Esc       Exit to list
 
 **Understanding the Graph**
@@ -136,7 +138,7 @@ const contextHelpBoard = `## Board View
 **Navigation**
   h/l       Move between columns
   j/k       Move within column
-  1-4       Jump to column by number
+  1-5       Jump to column by number (5: In Review)
   H/L       Jump to first/last column
   gg/G      Go to top/bottom of column
 
@@ -209,7 +211,8 @@ const contextHelpHistory = `## History View
 
 **Actions**
   y         Copy commit SHA
-  o         Open commit in browser
+  o         Open changed files in editor
+  O         Open commit in browser
   Esc       Return to list`
 
 const contextHelpDetail = `## Detail View
@@ -251,6 +254,7 @@ const contextHelpFilter = `## Filter Mode
   o         Open only
   c         Closed only
   r         Ready (no blockers)
+  I         In review
   a         All (clear filter)
 
 **Search**
@@ -258,6 +258,7 @@ func TestContext_IsOverlay(t *testing.T) {
 		ContextLabelPicker, ContextRecipePicker, ContextHelp, ContextQuitConfirm,
 		ContextLabelHealthDetail, ContextLabelDrilldown, ContextLabelGraphAnalysis,
 		ContextTimeTravelInput, ContextAlerts, ContextRepoPicker, ContextAgentPrompt,
+		ContextCommentInput, ContextCommandPalette, ContextLayoutPicker, ContextChangeLog,
 	}
 
 	for _, c := range overlays {
@@ -81,7 +81,7 @@ func TestHandleListKeysFiltersAndTimeTravelPrompt(t *testing.T) {
 	issues := []model.Issue{
 		{ID: "1", Title: "One", Status: model.StatusOpen},
 		{ID: "2", Title: "Two", Status: model.StatusOpen},
-		{ID: "3", Title: "Three", Status: model.StatusOpen},
+		{ID: "3", Title: "Three", Status: model.StatusInReview},
 	}
 	m := NewModel(issues, nil, "")
 	m.height = 30
@@ -101,6 +101,11 @@ func TestHandleListKeysFiltersAndTimeTravelPrompt(t *testing.T) {
 	if m.currentFilter != "ready" {
 		t.Fatalf("expected filter 'ready', got %s", m.currentFilter)
 	}
+	m = m.handleListKeys(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("I")})
+	if m.currentFilter != "review" || len(m.list.Items()) != 1 {
+		t.Fatalf("expected filter 'review' with 1 issue, got %s with %d", m.currentFilter, len(m.list.Items()))
+	}
+	m = m.handleListKeys(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("a")})
 
 	// Paging up/down
 	m.list.Select(0)
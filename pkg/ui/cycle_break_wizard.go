@@ -0,0 +1,244 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// CycleBreakAction is a request from the cycle-break wizard to its parent.
+type CycleBreakAction int
+
+const (
+	CycleBreakNone  CycleBreakAction = iota
+	CycleBreakApply                  // Remove the selected edge from the data file
+	CycleBreakCopy                   // Copy the bd command for the selected edge
+	CycleBreakClose                  // Dismiss the wizard
+)
+
+// CycleBreakWizard walks through each detected dependency cycle and lets the
+// user pick which edge to remove.
+type CycleBreakWizard struct {
+	steps    []analysis.CycleBreakStep
+	titles   map[string]string
+	step     int            // Current cycle
+	cursor   int            // Selected candidate within the cycle
+	resolved map[int]string // Step index -> how it was resolved
+	action   CycleBreakAction
+	theme    Theme
+	width    int
+	height   int
+}
+
+// NewCycleBreakWizard creates a wizard over the given plan.
+func NewCycleBreakWizard(steps []analysis.CycleBreakStep, issues []model.Issue, theme Theme) CycleBreakWizard {
+	titles := make(map[string]string, len(issues))
+	for _, issue := range issues {
+		titles[issue.ID] = issue.Title
+	}
+	return CycleBreakWizard{
+		steps:    steps,
+		titles:   titles,
+		resolved: make(map[int]string),
+		theme:    theme,
+		width:    76,
+		height:   24,
+	}
+}
+
+// Update handles input for the wizard.
+func (w CycleBreakWizard) Update(msg tea.Msg) (CycleBreakWizard, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return w, nil
+	}
+	switch keyMsg.String() {
+	case "j", "down":
+		if step := w.current(); step != nil && w.cursor < len(step.Candidates)-1 {
+			w.cursor++
+		}
+	case "k", "up":
+		if w.cursor > 0 {
+			w.cursor--
+		}
+	case "n", "l", "right", "tab":
+		w.goTo(w.step + 1)
+	case "p", "h", "left", "shift+tab":
+		w.goTo(w.step - 1)
+	case "s":
+		if w.current() != nil && w.resolved[w.step] == "" {
+			w.resolved[w.step] = "skipped"
+		}
+		w.advance()
+	case "enter", "a":
+		if _, ok := w.Selected(); ok && w.resolved[w.step] == "" {
+			w.action = CycleBreakApply
+		}
+	case "y":
+		if _, ok := w.Selected(); ok {
+			w.action = CycleBreakCopy
+		}
+	case "esc", "q", "B":
+		w.action = CycleBreakClose
+	}
+	return w, nil
+}
+
+// TakeAction returns the pending action and clears it.
+func (w *CycleBreakWizard) TakeAction() CycleBreakAction {
+	action := w.action
+	w.action = CycleBreakNone
+	return action
+}
+
+// Selected returns the highlighted candidate edge.
+func (w CycleBreakWizard) Selected() (analysis.CycleBreakCandidate, bool) {
+	step := w.current()
+	if step == nil || w.cursor >= len(step.Candidates) {
+		return analysis.CycleBreakCandidate{}, false
+	}
+	return step.Candidates[w.cursor], true
+}
+
+// MarkResolved records how the current cycle was handled and moves on to the
+// next unresolved one.
+func (w *CycleBreakWizard) MarkResolved(note string) {
+	if w.current() == nil {
+		return
+	}
+	w.resolved[w.step] = note
+	w.advance()
+}
+
+// Done reports whether every cycle has been resolved or skipped.
+func (w CycleBreakWizard) Done() bool {
+	return len(w.resolved) >= len(w.steps)
+}
+
+// SetSize sets the wizard dimensions based on terminal size.
+func (w *CycleBreakWizard) SetSize(width, height int) {
+	w.width = min(max(width-8, 40), 90)
+	w.height = height
+}
+
+// CycleBreakCommand returns the bd command that removes a candidate edge.
+func CycleBreakCommand(c analysis.CycleBreakCandidate) string {
+	return fmt.Sprintf("bd dep remove %s %s", c.From, c.To)
+}
+
+func (w CycleBreakWizard) current() *analysis.CycleBreakStep {
+	if w.step < 0 || w.step >= len(w.steps) {
+		return nil
+	}
+	return &w.steps[w.step]
+}
+
+func (w *CycleBreakWizard) goTo(step int) {
+	if step < 0 || step >= len(w.steps) || step == w.step {
+		return
+	}
+	w.step = step
+	w.cursor = 0
+}
+
+// advance moves to the next unresolved cycle, wrapping around.
+func (w *CycleBreakWizard) advance() {
+	for i := 1; i < len(w.steps); i++ {
+		next := (w.step + i) % len(w.steps)
+		if w.resolved[next] == "" {
+			w.goTo(next)
+			return
+		}
+	}
+}
+
+// View renders the wizard.
+func (w CycleBreakWizard) View() string {
+	t := w.theme
+	r := t.Renderer
+
+	modalStyle := r.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(t.Primary).
+		Padding(1, 2).
+		Width(w.width)
+	headerStyle := r.NewStyle().Bold(true).Foreground(t.Primary)
+	mutedStyle := r.NewStyle().Foreground(t.Subtext)
+	selectedStyle := r.NewStyle().Bold(true).Foreground(t.Primary)
+	doneStyle := r.NewStyle().Foreground(t.Open)
+	footerStyle := r.NewStyle().Foreground(t.Subtext).Italic(true)
+
+	var b strings.Builder
+	step := w.current()
+	if step == nil {
+		b.WriteString(headerStyle.Render("🔁 Cycle Break Wizard"))
+		b.WriteString("\n\n")
+		b.WriteString(mutedStyle.Render("No dependency cycles detected."))
+		b.WriteString("\n\n")
+		b.WriteString(footerStyle.Render("[Esc] Close"))
+		return modalStyle.Render(b.String())
+	}
+
+	b.WriteString(headerStyle.Render("🔁 Cycle Break Wizard"))
+	b.WriteString(mutedStyle.Render(fmt.Sprintf("  cycle %d of %d · %d resolved", w.step+1, len(w.steps), len(w.resolved))))
+	b.WriteString("\n\n")
+
+	// The cycle itself: each issue depends on the next
+	b.WriteString(mutedStyle.Render("Each issue depends on the next:"))
+	b.WriteString("\n")
+	innerWidth := w.width - 8
+	for _, id := range step.Cycle {
+		line := fmt.Sprintf("  %s  %s", id, w.titles[id])
+		b.WriteString(truncateRunesHelper(line, innerWidth, "…"))
+		b.WriteString("\n")
+	}
+	b.WriteString(mutedStyle.Render(fmt.Sprintf("  ↺ back to %s", step.Cycle[0])))
+	b.WriteString("\n\n")
+
+	if note := w.resolved[w.step]; note != "" {
+		b.WriteString(doneStyle.Render("✓ " + note))
+		b.WriteString("\n\n")
+	}
+
+	b.WriteString(mutedStyle.Render("Remove one dependency (best first):"))
+	b.WriteString("\n")
+	for i, c := range step.Candidates {
+		prefix := "  "
+		style := r.NewStyle()
+		if i == w.cursor {
+			prefix = "▸ "
+			style = selectedStyle
+		}
+		edge := fmt.Sprintf("%s%s → %s", prefix, c.From, c.To)
+		impact := fmt.Sprintf("breaks %d · %d left · %d collateral", c.CyclesBroken, c.RemainingCycles, c.Collateral)
+		b.WriteString(style.Render(truncateRunesHelper(edge, innerWidth/2, "…")))
+		b.WriteString("  ")
+		b.WriteString(mutedStyle.Render(impact))
+		b.WriteString("\n")
+		if i == w.cursor {
+			b.WriteString(mutedStyle.Render("    " + truncateRunesHelper(c.Rationale, innerWidth-4, "…")))
+			b.WriteString("\n")
+			b.WriteString(mutedStyle.Render("    $ " + CycleBreakCommand(c)))
+			b.WriteString("\n")
+		}
+	}
+	b.WriteString("\n")
+
+	b.WriteString(footerStyle.Render("[j/k] Edge  [⏎] Remove from file  [y] Copy bd cmd  [s] Skip  [n/p] Cycle  [Esc] Close"))
+	return modalStyle.Render(b.String())
+}
+
+// CenterModal returns the wizard view centered in the given dimensions.
+func (w CycleBreakWizard) CenterModal(termWidth, termHeight int) string {
+	modal := w.View()
+	padTop := max((termHeight-lipgloss.Height(modal))/2, 0)
+	padLeft := max((termWidth-lipgloss.Width(modal))/2, 0)
+	return w.theme.Renderer.NewStyle().
+		MarginTop(padTop).
+		MarginLeft(padLeft).
+		Render(modal)
+}
@@ -0,0 +1,106 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func runeKey(s string) tea.KeyMsg {
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)}
+}
+
+func TestCycleBreakWizardNavigation(t *testing.T) {
+	steps := []analysis.CycleBreakStep{
+		{Cycle: []string{"A", "B"}, Candidates: []analysis.CycleBreakCandidate{{From: "A", To: "B"}, {From: "B", To: "A"}}},
+		{Cycle: []string{"C", "D"}, Candidates: []analysis.CycleBreakCandidate{{From: "C", To: "D"}}},
+	}
+	w := NewCycleBreakWizard(steps, nil, DefaultTheme(nil))
+
+	w, _ = w.Update(runeKey("j"))
+	if c, _ := w.Selected(); c.From != "B" {
+		t.Fatalf("after j selected %s -> %s, want B -> A", c.From, c.To)
+	}
+	w, _ = w.Update(runeKey("y"))
+	if w.TakeAction() != CycleBreakCopy || w.TakeAction() != CycleBreakNone {
+		t.Error("y should request a copy once")
+	}
+
+	w.MarkResolved("Removed B → A")
+	if c, _ := w.Selected(); c.From != "C" {
+		t.Fatalf("MarkResolved should advance to the next cycle, selected %s", c.From)
+	}
+	if w.Done() {
+		t.Error("wizard should not be done with one cycle left")
+	}
+	w, _ = w.Update(runeKey("s"))
+	if !w.Done() {
+		t.Error("skipping the last cycle should finish the wizard")
+	}
+
+	w, _ = w.Update(runeKey("p"))
+	w, _ = w.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if w.TakeAction() != CycleBreakNone {
+		t.Error("resolved cycles should not be applied again")
+	}
+	if !strings.Contains(w.View(), "Removed B → A") {
+		t.Error("view should show how the cycle was resolved")
+	}
+
+	w, _ = w.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if w.TakeAction() != CycleBreakClose {
+		t.Error("esc should close the wizard")
+	}
+}
+
+func TestGraphCycleBreakAppliesRemoval(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "issues.jsonl")
+	data := `{"id":"A","title":"Alpha","status":"open","issue_type":"task","dependencies":[{"issue_id":"A","depends_on_id":"B","type":"blocks"}]}` + "\n" +
+		`{"id":"B","title":"Beta","status":"open","issue_type":"task","dependencies":[{"issue_id":"B","depends_on_id":"A","type":"blocks"}]}` + "\n"
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	issues := []model.Issue{
+		{ID: "A", Title: "Alpha", Status: model.StatusOpen, Dependencies: []*model.Dependency{{IssueID: "A", DependsOnID: "B", Type: model.DepBlocks}}},
+		{ID: "B", Title: "Beta", Status: model.StatusOpen, Dependencies: []*model.Dependency{{IssueID: "B", DependsOnID: "A", Type: model.DepBlocks}}},
+	}
+
+	m := NewModel(issues, nil, path)
+	defer m.Stop()
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 140, Height: 40})
+	m = updated.(Model)
+
+	updated, _ = m.Update(runeKey("g"))
+	m = updated.(Model)
+	updated, _ = m.Update(runeKey("B"))
+	m = updated.(Model)
+	if !m.showCycleBreakWizard || m.focused != focusCycleBreak {
+		t.Fatal("B in graph view should open the cycle-break wizard")
+	}
+
+	want, _ := m.cycleBreakWizard.Selected()
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(Model)
+	if m.statusIsError {
+		t.Fatalf("apply failed: %s", m.statusMsg)
+	}
+	if m.showCycleBreakWizard || m.focused != focusGraph {
+		t.Error("wizard should close once every cycle is resolved")
+	}
+
+	written, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, line := range strings.Split(strings.TrimSpace(string(written)), "\n") {
+		if strings.Contains(line, `"id":"`+want.From+`"`) && strings.Contains(line, `"depends_on_id":"`+want.To+`"`) {
+			t.Errorf("dependency %s -> %s still in file: %s", want.From, want.To, line)
+		}
+	}
+}
@@ -19,6 +19,7 @@ type IssueDelegate struct {
 	PriorityHints     map[string]*analysis.PriorityRecommendation
 	WorkspaceMode     bool // When true, shows repo prefix badges
 	ShowSearchScores  bool // Show semantic/hybrid score badge when search is active
+	Plain             bool // Rows as plain sentences for screen readers
 }
 
 func (d IssueDelegate) Height() int {
@@ -49,6 +50,11 @@ func (d IssueDelegate) Render(w io.Writer, m list.Model, index int, listItem lis
 
 	isSelected := index == m.Index()
 
+	if d.Plain {
+		fmt.Fprint(w, d.renderPlain(i, isSelected, width))
+		return
+	}
+
 	// ══════════════════════════════════════════════════════════════════════════
 	// POLISHED ROW LAYOUT - Stripe-level visual hierarchy
 	// Layout: [sel] [type] [prio-badge] [status-badge] [ID] [title...] [meta]
@@ -234,9 +240,9 @@ func (d IssueDelegate) Render(w io.Writer, m list.Model, index int, listItem lis
 	if i.IsQuickWin {
 		triageIndicator = t.Renderer.NewStyle().Foreground(lipgloss.Color("#FFD700")).Render("⭐")
 	} else if i.IsBlocker && i.UnblocksCount > 0 {
-		triageIndicator = t.Renderer.NewStyle().Foreground(lipgloss.Color("#50FA7B")).Render(fmt.Sprintf("🔓%d", i.UnblocksCount))
+		triageIndicator = t.Renderer.NewStyle().Foreground(t.Open).Render(fmt.Sprintf("🔓%d", i.UnblocksCount))
 	} else if i.UnblocksCount > 0 {
-		triageIndicator = t.Renderer.NewStyle().Foreground(lipgloss.Color("#6272A4")).Render(fmt.Sprintf("↪%d", i.UnblocksCount))
+		triageIndicator = t.Renderer.NewStyle().Foreground(t.Muted).Render(fmt.Sprintf("↪%d", i.UnblocksCount))
 	}
 	if triageIndicator != "" {
 		leftSide.WriteString(triageIndicator)
@@ -0,0 +1,176 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// depTreeRow is one line of the dependency tree view: a section header or
+// an issue under its tree connectors
+type depTreeRow struct {
+	header string
+	prefix string
+	node   analysis.DependencyTreeNode
+}
+
+// openDependencyTree shows the upstream blocker tree and downstream unblock
+// tree of the selected issue (the TUI side of --robot-tree).
+func (m Model) openDependencyTree() Model {
+	item, ok := m.list.SelectedItem().(IssueItem)
+	if !ok {
+		return m
+	}
+	analyzer := m.analyzer
+	if analyzer == nil {
+		analyzer = analysis.NewAnalyzer(m.issues)
+	}
+	tree := analyzer.DependencyTree(item.Issue.ID)
+	if tree == nil {
+		m.statusMsg = fmt.Sprintf("%s is not in the dependency graph", item.Issue.ID)
+		m.statusIsError = true
+		return m
+	}
+	m.depTree = tree
+	m.depTreeRows = dependencyTreeRows(tree)
+	m.depTreeCursor = 0
+	m.moveDepTreeCursor(1)
+	m.showDepTree = true
+	return m
+}
+
+// dependencyTreeRows flattens both trees into display rows
+func dependencyTreeRows(tree *analysis.DependencyTree) []depTreeRow {
+	var rows []depTreeRow
+	var walk func(nodes []analysis.DependencyTreeNode, indent string)
+	walk = func(nodes []analysis.DependencyTreeNode, indent string) {
+		for i, n := range nodes {
+			branch, next := "├─ ", "│  "
+			if i == len(nodes)-1 {
+				branch, next = "└─ ", "   "
+			}
+			rows = append(rows, depTreeRow{prefix: indent + branch, node: n})
+			walk(n.Children, indent+next)
+		}
+	}
+
+	rows = append(rows, depTreeRow{header: fmt.Sprintf("⬆ Waits on · %s open", formatTreeDays(tree.UpstreamDays))})
+	if len(tree.Upstream) == 0 {
+		rows = append(rows, depTreeRow{header: "  Nothing: ready to start"})
+	}
+	walk(tree.Upstream, "  ")
+	rows = append(rows, depTreeRow{header: ""}, depTreeRow{header: fmt.Sprintf("⬇ Unblocks · %s waiting", formatTreeDays(tree.DownstreamDays))})
+	if len(tree.Downstream) == 0 {
+		rows = append(rows, depTreeRow{header: "  Nothing waits on it"})
+	}
+	walk(tree.Downstream, "  ")
+	return rows
+}
+
+// moveDepTreeCursor moves the cursor by step to the next issue row, staying
+// put when there is none that way
+func (m *Model) moveDepTreeCursor(step int) {
+	for i := m.depTreeCursor + step; i >= 0 && i < len(m.depTreeRows); i += step {
+		if m.depTreeRows[i].node.ID != "" {
+			m.depTreeCursor = i
+			return
+		}
+	}
+}
+
+// handleDependencyTreeKeys handles keys while the dependency tree is open.
+func (m Model) handleDependencyTreeKeys(msg tea.KeyMsg) (Model, tea.Cmd) {
+	switch msg.String() {
+	case "j", "down":
+		m.moveDepTreeCursor(1)
+	case "k", "up":
+		m.moveDepTreeCursor(-1)
+	case "g", "home":
+		m.depTreeCursor = -1
+		m.moveDepTreeCursor(1)
+	case "G", "end":
+		m.depTreeCursor = len(m.depTreeRows)
+		m.moveDepTreeCursor(-1)
+	case "enter":
+		if m.depTreeCursor >= len(m.depTreeRows) || m.depTreeRows[m.depTreeCursor].node.ID == "" {
+			break
+		}
+		m.showDepTree = false
+		m = m.jumpToMention(m.depTreeRows[m.depTreeCursor].node.ID)
+		if !m.statusIsError {
+			m.focused = focusDetail
+			if !m.isSplitView {
+				m.showDetails = true
+			}
+		}
+	case "esc", "q", "D":
+		m.showDepTree = false
+	case "ctrl+c":
+		return m, tea.Quit
+	}
+	return m, nil
+}
+
+// renderDependencyTree renders the tree view: each issue colored by status
+// with its own estimate and the open work in its branch.
+func (m Model) renderDependencyTree() string {
+	t := m.theme
+	width := min(110, m.width-4)
+	tree := m.depTree
+
+	titleStyle := t.Renderer.NewStyle().Bold(true).Foreground(t.Primary)
+	headerStyle := t.Renderer.NewStyle().Bold(true).Foreground(t.Secondary)
+	dimStyle := t.Renderer.NewStyle().Foreground(t.Secondary).Italic(true)
+
+	title := fmt.Sprintf("🌳 Dependency tree · %s %s", tree.ID, truncateRunesHelper(tree.Title, width-30, "…"))
+	lines := make([]string, 0, len(m.depTreeRows))
+	cursorLine := 0
+	for i, row := range m.depTreeRows {
+		if row.node.ID == "" {
+			lines = append(lines, headerStyle.Render(row.header))
+			continue
+		}
+		n := row.node
+		days := fmt.Sprintf("%s · branch %s", formatTreeDays(n.EstimatedDays), formatTreeDays(n.BranchDays))
+		if !n.Estimated && n.EstimatedDays > 0 {
+			days = "~" + days
+		}
+		if n.Repeat {
+			days = "↺ shown above"
+		}
+		label := truncateRunesHelper(fmt.Sprintf("%s %s", n.ID, n.Title), width-lipgloss.Width(row.prefix)-lipgloss.Width(days)-16, "…")
+		style := t.Renderer.NewStyle().Foreground(t.GetStatusColor(n.Status))
+		if i == m.depTreeCursor {
+			cursorLine = len(lines)
+			style = style.Bold(true).Background(t.Highlight)
+		}
+		line := dimStyle.Render(row.prefix) + style.Render(fmt.Sprintf("%s %s", GetStatusIcon(n.Status), label)) + "  " + dimStyle.Render(days)
+		lines = append(lines, line)
+	}
+
+	maxVisible := max(m.height-12, 4)
+	start := 0
+	if cursorLine+2 > maxVisible {
+		start = cursorLine + 2 - maxVisible
+	}
+	end := min(start+maxVisible, len(lines))
+	body := strings.Join(lines[start:end], "\n")
+
+	footer := "j/k: move | enter: open | esc: close   (~ = median estimate)"
+	content := titleStyle.Render(title) + "\n\n" + body + "\n\n" + dimStyle.Render(footer)
+	box := t.Renderer.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(t.Primary).
+		Padding(1, 2).
+		Width(width).
+		Render(content)
+
+	return lipgloss.Place(m.width, m.height-1, lipgloss.Center, lipgloss.Center, box)
+}
+
+func formatTreeDays(d float64) string {
+	return fmt.Sprintf("%.1fd", d)
+}
@@ -0,0 +1,52 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestDependencyTreeView(t *testing.T) {
+	blocks := func(id string) []*model.Dependency {
+		return []*model.Dependency{{DependsOnID: id, Type: model.DepBlocks}}
+	}
+	issues := []model.Issue{
+		{ID: "bv-1", Title: "Schema", Status: model.StatusInProgress, Priority: 1},
+		{ID: "bv-2", Title: "Parser", Status: model.StatusOpen, Priority: 1, Dependencies: blocks("bv-1")},
+		{ID: "bv-3", Title: "Loader", Status: model.StatusOpen, Priority: 2, Dependencies: blocks("bv-2")},
+	}
+	m := NewModel(issues, nil, "")
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m = updated.(Model).jumpToMention("bv-2")
+
+	m = pressKeys(t, m, "D")
+	if !m.showDepTree || m.CurrentContext() != ContextDependencyTree {
+		t.Fatalf("D should open the dependency tree (context %s)", m.CurrentContext())
+	}
+	view := m.View()
+	for _, want := range []string{"Dependency tree · bv-2", "⬆ Waits on", "└─ ", "bv-1 Schema", "⬇ Unblocks", "bv-3 Loader", "branch"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("tree view missing %q", want)
+		}
+	}
+
+	// The cursor starts on the first issue; j moves past the header to the
+	// downstream one, which enter opens
+	m = pressKeys(t, m, "j", "enter")
+	if m.showDepTree {
+		t.Fatal("enter should close the tree")
+	}
+	if item, ok := m.list.SelectedItem().(IssueItem); !ok || item.Issue.ID != "bv-3" {
+		t.Errorf("enter should select bv-3, got %v", m.list.SelectedItem())
+	}
+	if m.focused != focusDetail {
+		t.Errorf("enter should focus the detail pane, got %v", m.focused)
+	}
+
+	m = pressKeys(t, m, "D", "esc")
+	if m.showDepTree {
+		t.Error("esc should close the tree")
+	}
+}
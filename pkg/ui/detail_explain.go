@@ -0,0 +1,164 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// explainMaxEvents is how many of the latest events the explanation lists
+const explainMaxEvents = 5
+
+// toggleExplainDetail switches the detail pane between the issue and the
+// explanation of its ranking (the TUI side of --robot-explain).
+func (m Model) toggleExplainDetail() Model {
+	m.detailExplain = !m.detailExplain
+	m.explained = nil
+	if m.detailExplain {
+		m.detailRaw = false
+		m.statusMsg = "Explanation (E for the issue)"
+	} else {
+		m.statusMsg = "Issue details (E to explain)"
+	}
+	m.statusIsError = false
+	m.viewport.GotoTop()
+	m.updateViewportContent()
+	return m
+}
+
+// explanationFor returns the explanation of id, computing it only when the
+// selection has moved to another issue.
+func (m *Model) explanationFor(id string) (*analysis.IssueExplanation, error) {
+	if m.explained != nil && m.explained.ID == id {
+		return m.explained, nil
+	}
+	exp, err := analysis.ExplainIssue(m.issues, id, analysis.ExplainOptions{Now: time.Now()})
+	if err != nil {
+		return nil, err
+	}
+	m.explained = exp
+	return exp, nil
+}
+
+// renderExplainDetail lays out an explanation as plain text wrapped to
+// width.
+func renderExplainDetail(exp *analysis.IssueExplanation, width int) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("%s %s\n", exp.ID, exp.Title))
+	sb.WriteString("(why it ranks where it does; press E for the issue)\n")
+	section := func(title string) {
+		sb.WriteString("\n── " + title + " ──\n")
+	}
+
+	rec := exp.Recommendation
+	section("Recommendation")
+	switch {
+	case rec.Recommended:
+		sb.WriteString(fmt.Sprintf("Recommended: #%d of %d (triage picks the top %d), score %.3f\n", rec.Rank, rec.Of, rec.Cutoff, rec.Score))
+	case rec.Rank > 0:
+		sb.WriteString(fmt.Sprintf("Not recommended: #%d of %d, score %.3f (the top %d need %.3f)\n", rec.Rank, rec.Of, rec.Score, rec.Cutoff, rec.CutoffScore))
+	default:
+		sb.WriteString("Not recommended\n")
+	}
+	for _, r := range rec.WhyNot {
+		sb.WriteString("  ✗ " + r + "\n")
+	}
+	for _, r := range rec.Reasons {
+		sb.WriteString("  • " + r + "\n")
+	}
+	if rec.Action != "" {
+		sb.WriteString("Next: " + rec.Action + "\n")
+	}
+
+	section("Scores (value, percentile)")
+	nameWidth := 0
+	for _, s := range exp.Scores {
+		nameWidth = max(nameWidth, len(s.Name))
+	}
+	for _, s := range exp.Scores {
+		sb.WriteString(fmt.Sprintf("  %-*s %8.3f  p%-5.0f of %d\n", nameWidth, s.Name, s.Value, s.Percentile, s.Of))
+	}
+
+	section("Blockers")
+	if b := exp.Blockers; b == nil || !b.IsBlocked {
+		sb.WriteString("None: ready to work on\n")
+	} else {
+		for _, e := range b.Chain[1:] {
+			marker := ""
+			if e.IsRoot {
+				marker = " ← start here"
+			}
+			sb.WriteString(fmt.Sprintf("  %s%s %s [%s]%s\n", strings.Repeat("  ", e.Depth-1), e.ID, e.Title, e.Status, marker))
+		}
+		if b.HasCycle {
+			sb.WriteString("  ⚠ Cycle: " + strings.Join(b.CycleIDs, ", ") + "\n")
+		}
+	}
+
+	section("Unblocks")
+	if len(exp.Unblocks.Tree) == 0 {
+		sb.WriteString("Nothing waits on it alone\n")
+	} else {
+		sb.WriteString(fmt.Sprintf("%d directly, %d in all once the cascade runs\n", len(exp.Unblocks.Direct), exp.Unblocks.Transitive))
+		var walk func(nodes []analysis.UnblockNode, depth int)
+		walk = func(nodes []analysis.UnblockNode, depth int) {
+			for _, n := range nodes {
+				sb.WriteString(fmt.Sprintf("  %s%s %s\n", strings.Repeat("  ", depth), n.ID, n.Title))
+				walk(n.Children, depth+1)
+			}
+		}
+		walk(exp.Unblocks.Tree, 0)
+	}
+
+	if f := exp.Forecast; f != nil {
+		section("Forecast")
+		sb.WriteString(fmt.Sprintf("ETA %s (%s – %s), %.1f days of work, %.0f%% confidence\n",
+			f.ETADate.Format("2006-01-02"), f.ETADateLow.Format("2006-01-02"), f.ETADateHigh.Format("2006-01-02"),
+			f.EstimatedDays, f.Confidence*100))
+	}
+
+	h := exp.History
+	section("History")
+	sb.WriteString(fmt.Sprintf("Created %s (%d days ago), updated %d days ago, %d comments\n",
+		h.CreatedAt.Local().Format("2006-01-02"), h.AgeDays, h.DaysSinceUpdate, h.Comments))
+	if h.ClosedAt != nil {
+		sb.WriteString("Closed " + h.ClosedAt.Local().Format("2006-01-02") + "\n")
+	}
+	events := h.Events
+	if len(events) > explainMaxEvents {
+		events = events[len(events)-explainMaxEvents:]
+	}
+	for _, e := range events {
+		if e != nil {
+			sb.WriteString("  " + explainEventLine(e) + "\n")
+		}
+	}
+
+	section("Similar issues")
+	if len(exp.Similar) == 0 {
+		sb.WriteString("None found\n")
+	}
+	for _, s := range exp.Similar {
+		sb.WriteString(fmt.Sprintf("  %s %s [%s] %.0f%% alike\n", s.ID, s.Title, s.Status, s.Similarity*100))
+	}
+
+	if width <= 0 {
+		return sb.String()
+	}
+	return lipgloss.NewStyle().Width(width).Render(sb.String())
+}
+
+func explainEventLine(e *model.IssueEvent) string {
+	line := e.CreatedAt.Local().Format("2006-01-02") + " " + string(e.EventType)
+	if e.OldValue != "" || e.NewValue != "" {
+		line += fmt.Sprintf(" %s → %s", e.OldValue, e.NewValue)
+	}
+	if e.Actor != "" {
+		line += " by " + e.Actor
+	}
+	return line
+}
@@ -0,0 +1,39 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestModel_ToggleExplainDetail(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "bv-1", Title: "Parser rewrite", Status: model.StatusOpen, IssueType: model.TypeTask, Priority: 1},
+		{ID: "bv-2", Title: "Use the new parser", Status: model.StatusOpen, IssueType: model.TypeTask, Priority: 2,
+			Dependencies: []*model.Dependency{{DependsOnID: "bv-1", Type: model.DepBlocks}}},
+	}
+	m := NewModel(issues, nil, "")
+	updated, _ := m.Update(keyMsgFromString("E"))
+	m = updated.(Model)
+	if !m.detailExplain || m.explained == nil {
+		t.Fatal("E should switch to the explanation")
+	}
+	view := ansi.Strip(m.viewport.View())
+	for _, want := range []string{"── Recommendation ──", "── Scores", "── Unblocks ──"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("explanation missing %q:\n%s", want, view)
+		}
+	}
+
+	updated, _ = m.Update(keyMsgFromString("v"))
+	if m = updated.(Model); m.detailExplain || !m.detailRaw {
+		t.Error("v should leave the explanation for the raw view")
+	}
+	updated, _ = m.Update(keyMsgFromString("E"))
+	updated, _ = updated.(Model).Update(keyMsgFromString("E"))
+	if m = updated.(Model); m.detailExplain || strings.Contains(ansi.Strip(m.viewport.View()), "── Recommendation ──") {
+		t.Error("second E should return to the issue")
+	}
+}
@@ -0,0 +1,97 @@
+package ui
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// taskItemRegex matches a GFM task list item and captures its check mark.
+var taskItemRegex = regexp.MustCompile(`^\s*(?:[-*+]|\d+[.)])\s+\[([ xX])\]\s`)
+
+// taskProgress counts the task list items in markdown, skipping code fences.
+func taskProgress(markdown string) (done, total int) {
+	inFence := false
+	for _, line := range strings.Split(markdown, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			continue
+		}
+		if m := taskItemRegex.FindStringSubmatch(line); m != nil {
+			total++
+			if m[1] != " " {
+				done++
+			}
+		}
+	}
+	return done, total
+}
+
+// detailSectionMD renders one free-text section of the detail view, with the
+// checklist progress in its heading when the text has task items.
+func detailSectionMD(title, text string) string {
+	if text == "" {
+		return ""
+	}
+	if done, total := taskProgress(text); total > 0 {
+		title += fmt.Sprintf(" (%d/%d done)", done, total)
+	}
+	return "### " + title + "\n" + text + "\n\n"
+}
+
+// toggleRawDetail switches the detail pane between rendered markdown and
+// the raw source, which agent-written checklists and tables are easier to
+// copy from.
+func (m Model) toggleRawDetail() Model {
+	m.detailRaw = !m.detailRaw
+	m.detailExplain = false
+	m.viewport.GotoTop()
+	m.updateViewportContent()
+	if m.detailRaw {
+		m.statusMsg = "Raw markdown (v for rendered)"
+	} else {
+		m.statusMsg = "Rendered markdown (v for raw)"
+	}
+	m.statusIsError = false
+	return m
+}
+
+// renderRawDetail shows an issue's free text exactly as written, wrapped to
+// width, for reading or copying the markdown source the rendered view hides.
+func renderRawDetail(item model.Issue, width int) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("%s %s\n", item.ID, item.Title))
+	sb.WriteString("(raw markdown; press v for the rendered view)\n")
+	section := func(title, text string) {
+		if text == "" {
+			return
+		}
+		sb.WriteString("\n── " + title + " ──\n")
+		sb.WriteString(strings.TrimRight(text, "\n") + "\n")
+	}
+	section("Description", item.Description)
+	section("Design Notes", item.Design)
+	section("Acceptance Criteria", item.AcceptanceCriteria)
+	section("Notes", item.Notes)
+	for _, c := range item.Comments {
+		if c == nil {
+			continue
+		}
+		title := "Comment by " + commentAuthor(c)
+		if !c.CreatedAt.IsZero() {
+			title += " · " + c.CreatedAt.Local().Format("2006-01-02 15:04")
+		}
+		section(title, c.Text)
+	}
+	if width <= 0 {
+		return sb.String()
+	}
+	return lipgloss.NewStyle().Width(width).Render(sb.String())
+}
@@ -0,0 +1,65 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestTaskProgress(t *testing.T) {
+	md := "- [x] parse\n- [ ] lex\n* [X] emit\n1. [ ] ship\n```\n- [ ] not a task\n```\n- plain item"
+	if done, total := taskProgress(md); done != 2 || total != 4 {
+		t.Errorf("taskProgress = %d/%d, want 2/4", done, total)
+	}
+	if got := detailSectionMD("Acceptance Criteria", md); !strings.HasPrefix(got, "### Acceptance Criteria (2/4 done)\n") {
+		t.Errorf("section heading = %q", strings.SplitN(got, "\n", 2)[0])
+	}
+	if got := detailSectionMD("Notes", "no tasks"); !strings.HasPrefix(got, "### Notes\n") {
+		t.Errorf("section without tasks = %q", got)
+	}
+	if detailSectionMD("Notes", "") != "" {
+		t.Error("empty text should render nothing")
+	}
+}
+
+func TestMarkdownRenderer_GFM(t *testing.T) {
+	mr := NewMarkdownRendererWithTheme(80, DefaultTheme(lipgloss.DefaultRenderer()))
+	out, err := mr.Render("- [x] done\n- [ ] todo\n\n| Field | Value |\n|---|---|\n| status | open |\n\n```go\nfunc main() {}\n```\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	plain := ansi.Strip(out)
+	for _, want := range []string{"[✓] done", "[ ] todo", "│", "status", "func main() {}"} {
+		if !strings.Contains(plain, want) {
+			t.Errorf("rendered output missing %q:\n%s", want, plain)
+		}
+	}
+}
+
+func TestModel_ToggleRawDetail(t *testing.T) {
+	issues := []model.Issue{{
+		ID: "bv-1", Title: "Checklist", Status: model.StatusOpen, IssueType: model.TypeTask,
+		Description: "## Steps\n- [x] **write** it\n- [ ] test it",
+		Comments:    []*model.Comment{{Author: "agent", Text: "| a | b |"}},
+	}}
+	m := NewModel(issues, nil, "")
+	updated, _ := m.Update(keyMsgFromString("v"))
+	m = updated.(Model)
+	if !m.detailRaw {
+		t.Fatal("v should switch to the raw view")
+	}
+	raw := m.viewport.View()
+	for _, want := range []string{"## Steps", "- [x] **write** it", "Comment by agent", "| a | b |"} {
+		if !strings.Contains(raw, want) {
+			t.Errorf("raw view missing %q:\n%s", want, raw)
+		}
+	}
+
+	updated, _ = m.Update(keyMsgFromString("v"))
+	if m = updated.(Model); m.detailRaw || strings.Contains(ansi.Strip(m.viewport.View()), "**write**") {
+		t.Error("second v should return to the rendered view")
+	}
+}
@@ -0,0 +1,203 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/correlation"
+	tea "github.com/charmbracelet/bubbletea"
+	"gopkg.in/yaml.v3"
+)
+
+// defaultEditorMaxFiles caps how many files of one commit are opened.
+const defaultEditorMaxFiles = 10
+
+// EditorConfig is .bv/editor.yaml: how to open a file at a line, used by
+// the history view to jump from a commit to the code it changed.
+//
+//	command: code --goto {file}:{line}:{column}
+//	terminal: false
+//	max_files: 10
+type EditorConfig struct {
+	// Command opens one file. {file}, {line} and {column} are filled in;
+	// the rest is split on spaces.
+	Command string `yaml:"command"`
+	// Terminal editors (vim, helix, …) get bv's terminal until they exit,
+	// one file after another. Others are started in the background.
+	Terminal bool `yaml:"terminal"`
+	// MaxFiles caps how many files of one commit are opened (default 10).
+	MaxFiles int `yaml:"max_files"`
+}
+
+// editorTemplate is how a known editor opens a file at a line.
+type editorTemplate struct {
+	args     string
+	terminal bool
+}
+
+// knownEditors maps editor executables to their goto-line arguments.
+var knownEditors = map[string]editorTemplate{
+	"code":          {"--goto {file}:{line}:{column}", false},
+	"code-insiders": {"--goto {file}:{line}:{column}", false},
+	"codium":        {"--goto {file}:{line}:{column}", false},
+	"cursor":        {"--goto {file}:{line}:{column}", false},
+	"windsurf":      {"--goto {file}:{line}:{column}", false},
+	"subl":          {"{file}:{line}:{column}", false},
+	"zed":           {"{file}:{line}:{column}", false},
+	"idea":          {"--line {line} {file}", false},
+	"goland":        {"--line {line} {file}", false},
+	"pycharm":       {"--line {line} {file}", false},
+	"webstorm":      {"--line {line} {file}", false},
+	"vim":           {"+{line} {file}", true},
+	"vi":            {"+{line} {file}", true},
+	"nvim":          {"+{line} {file}", true},
+	"nano":          {"+{line},{column} {file}", true},
+	"emacs":         {"+{line}:{column} {file}", true},
+	"kak":           {"+{line}:{column} {file}", true},
+	"hx":            {"{file}:{line}:{column}", true},
+	"helix":         {"{file}:{line}:{column}", true},
+	"micro":         {"{file}:{line}:{column}", true},
+}
+
+// LoadEditorConfig reads .bv/editor.yaml from projectDir. Without one, or
+// when it sets no command, the command comes from $EDITOR or $VISUAL, then
+// VS Code if it is installed. Unknown editors get the file alone and are
+// assumed to need the terminal.
+func LoadEditorConfig(projectDir string, getenv func(string) string) (EditorConfig, error) {
+	var cfg EditorConfig
+	if projectDir != "" {
+		path := filepath.Join(projectDir, ".bv", "editor.yaml")
+		data, err := os.ReadFile(path)
+		if err != nil && !os.IsNotExist(err) {
+			return cfg, err
+		}
+		if err := yaml.Unmarshal(data, &cfg); err != nil {
+			return cfg, fmt.Errorf("parsing %s: %w", path, err)
+		}
+	}
+	if cfg.MaxFiles <= 0 {
+		cfg.MaxFiles = defaultEditorMaxFiles
+	}
+	if strings.TrimSpace(cfg.Command) != "" {
+		return cfg, nil
+	}
+
+	editor := getenv("EDITOR")
+	if editor == "" {
+		editor = getenv("VISUAL")
+	}
+	if editor == "" {
+		if _, err := exec.LookPath("code"); err != nil {
+			return cfg, fmt.Errorf("no editor configured: set $EDITOR or add .bv/editor.yaml")
+		}
+		editor = "code"
+	}
+	tmpl, ok := knownEditors[filepath.Base(strings.Fields(editor)[0])]
+	if !ok {
+		tmpl = editorTemplate{args: "{file}", terminal: true}
+	}
+	cfg.Command = editor + " " + tmpl.args
+	cfg.Terminal = tmpl.terminal
+	return cfg, nil
+}
+
+// Args returns the command line that opens file at line.
+func (c EditorConfig) Args(file string, line int) []string {
+	replacer := strings.NewReplacer("{file}", file, "{line}", strconv.Itoa(line), "{column}", "1")
+	fields := strings.Fields(c.Command)
+	args := make([]string, len(fields))
+	for i, f := range fields {
+		args[i] = replacer.Replace(f)
+	}
+	return args
+}
+
+// editorFinishedMsg reports a terminal editor exiting.
+type editorFinishedMsg struct {
+	err error
+}
+
+// selectedHistoryCommitSHA returns the commit under the cursor in either
+// history mode, or "".
+func (m Model) selectedHistoryCommitSHA() string {
+	if m.historyView.IsGitMode() {
+		if commit := m.historyView.SelectedGitCommit(); commit != nil {
+			return commit.SHA
+		}
+	} else if commit := m.historyView.SelectedCommit(); commit != nil {
+		return commit.SHA
+	}
+	return ""
+}
+
+// openCommitInEditor opens the files the selected commit touched, each at
+// the first line it changed.
+func (m Model) openCommitInEditor() (Model, tea.Cmd) {
+	sha := m.selectedHistoryCommitSHA()
+	if sha == "" {
+		m.statusMsg = "❌ No commit selected"
+		m.statusIsError = true
+		return m, nil
+	}
+	repo := m.workDir
+	if repo == "" {
+		repo = "."
+	}
+	cfg, err := LoadEditorConfig(m.workDir, os.Getenv)
+	if err != nil {
+		m.statusMsg = fmt.Sprintf("❌ %v", err)
+		m.statusIsError = true
+		return m, nil
+	}
+	files, err := correlation.FirstChangedLines(repo, sha)
+	if err != nil {
+		m.statusMsg = fmt.Sprintf("❌ %v", err)
+		m.statusIsError = true
+		return m, nil
+	}
+	if len(files) == 0 {
+		m.statusMsg = fmt.Sprintf("No files left to open from %.7s", sha)
+		m.statusIsError = true
+		return m, nil
+	}
+	skipped := 0
+	if len(files) > cfg.MaxFiles {
+		skipped = len(files) - cfg.MaxFiles
+		files = files[:cfg.MaxFiles]
+	}
+
+	editor := filepath.Base(strings.Fields(cfg.Command)[0])
+	m.statusMsg = fmt.Sprintf("📝 Opened %d file(s) from %.7s in %s", len(files), sha, editor)
+	if skipped > 0 {
+		m.statusMsg += fmt.Sprintf(" (%d more not opened, see max_files)", skipped)
+	}
+	m.statusIsError = false
+
+	if cfg.Terminal {
+		cmds := make([]tea.Cmd, len(files))
+		for i, f := range files {
+			cmd := editorCommand(cfg, repo, f)
+			cmds[i] = tea.ExecProcess(cmd, func(err error) tea.Msg { return editorFinishedMsg{err: err} })
+		}
+		return m, tea.Sequence(cmds...)
+	}
+	for _, f := range files {
+		if err := editorCommand(cfg, repo, f).Start(); err != nil {
+			m.statusMsg = fmt.Sprintf("❌ Failed to open editor: %v", err)
+			m.statusIsError = true
+			break
+		}
+	}
+	return m, nil
+}
+
+func editorCommand(cfg EditorConfig, repo string, f correlation.ChangedFile) *exec.Cmd {
+	args := cfg.Args(f.Path, f.Line)
+	cmd := exec.Command(args[0], args[1:]...)
+	cmd.Dir = repo
+	return cmd
+}
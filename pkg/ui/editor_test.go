@@ -0,0 +1,55 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestLoadEditorConfig(t *testing.T) {
+	env := func(vars map[string]string) func(string) string {
+		return func(k string) string { return vars[k] }
+	}
+
+	cfg, err := LoadEditorConfig("", env(map[string]string{"EDITOR": "nvim", "VISUAL": "code"}))
+	if err != nil || !cfg.Terminal || cfg.MaxFiles != defaultEditorMaxFiles {
+		t.Fatalf("nvim: %+v, %v", cfg, err)
+	}
+	if got := cfg.Args("docs/my notes.md", 42); !slices.Equal(got, []string{"nvim", "+42", "docs/my notes.md"}) {
+		t.Errorf("nvim args = %q", got)
+	}
+
+	cfg, _ = LoadEditorConfig("", env(map[string]string{"VISUAL": "/usr/bin/code --wait"}))
+	if got := cfg.Args("a.go", 7); cfg.Terminal || !slices.Equal(got, []string{"/usr/bin/code", "--wait", "--goto", "a.go:7:1"}) {
+		t.Errorf("code: terminal=%v args %q", cfg.Terminal, got)
+	}
+
+	cfg, _ = LoadEditorConfig("", env(map[string]string{"EDITOR": "ed"}))
+	if got := cfg.Args("a.go", 7); !cfg.Terminal || !slices.Equal(got, []string{"ed", "a.go"}) {
+		t.Errorf("unknown editor: terminal=%v args %q", cfg.Terminal, got)
+	}
+
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, ".bv"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	yaml := "command: idea --line {line} {file}\nmax_files: 3\n"
+	if err := os.WriteFile(filepath.Join(dir, ".bv", "editor.yaml"), []byte(yaml), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err = LoadEditorConfig(dir, env(map[string]string{"EDITOR": "vim"}))
+	if err != nil || cfg.Terminal || cfg.MaxFiles != 3 {
+		t.Fatalf("editor.yaml: %+v, %v", cfg, err)
+	}
+	if got := cfg.Args("x.go", 9); !slices.Equal(got, []string{"idea", "--line", "9", "x.go"}) {
+		t.Errorf("editor.yaml args = %q", got)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, ".bv", "editor.yaml"), []byte("command: [oops"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadEditorConfig(dir, env(nil)); err == nil {
+		t.Error("malformed editor.yaml should fail")
+	}
+}
@@ -0,0 +1,190 @@
+package ui
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/timetrack"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// DefaultFocusDuration is the length of a focus session unless
+// BV_FOCUS_MINUTES says otherwise
+const DefaultFocusDuration = 25 * time.Minute
+
+// focusBell receives the terminal bell when a focus session ends
+var focusBell io.Writer = os.Stderr
+
+// focusTimer is a running focus (pomodoro) session on a claimed issue
+type focusTimer struct {
+	IssueID  string
+	Start    time.Time
+	Duration time.Duration
+	// Logged reports whether the timer started the issue's work session in
+	// the time log, and so should stop it
+	Logged bool
+}
+
+// Remaining is the time left at now, never negative
+func (f *focusTimer) Remaining(now time.Time) time.Duration {
+	left := f.Duration - now.Sub(f.Start)
+	if left < 0 {
+		return 0
+	}
+	return left
+}
+
+// focusTickMsg advances the focus timer started at Start
+type focusTickMsg struct {
+	Start time.Time
+}
+
+func focusTickCmd(start time.Time) tea.Cmd {
+	return tea.Tick(time.Second, func(time.Time) tea.Msg {
+		return focusTickMsg{Start: start}
+	})
+}
+
+func ringFocusBell() tea.Msg {
+	fmt.Fprint(focusBell, "\a")
+	return nil
+}
+
+// focusDuration reads BV_FOCUS_MINUTES, falling back to DefaultFocusDuration
+func focusDuration() time.Duration {
+	if v := os.Getenv("BV_FOCUS_MINUTES"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n > 0 {
+			return time.Duration(n) * time.Minute
+		}
+	}
+	return DefaultFocusDuration
+}
+
+// toggleFocusTimer starts a focus session on the selected issue, which must
+// be claimed (in progress), or cancels the running one. The session is
+// logged to the time log like `bv track`.
+func (m Model) toggleFocusTimer() (Model, tea.Cmd) {
+	now := time.Now()
+	if m.focusTimer != nil {
+		issueID := m.focusTimer.IssueID
+		elapsed := now.Sub(m.focusTimer.Start)
+		var err error
+		m, err = m.stopFocusLogging(now)
+		m.focusTimer = nil
+		if err != nil {
+			m.statusMsg = "Failed to log focus session: " + err.Error()
+			m.statusIsError = true
+			return m, nil
+		}
+		m.statusMsg = fmt.Sprintf("🍅 Focus timer on %s cancelled after %s", issueID, timetrack.FormatDuration(elapsed))
+		m.statusIsError = false
+		return m, nil
+	}
+
+	item, ok := m.list.SelectedItem().(IssueItem)
+	if !ok {
+		return m, nil
+	}
+	issueID := item.Issue.ID
+	if item.Issue.Status != model.StatusInProgress {
+		m.statusMsg = fmt.Sprintf("Claim %s before focusing on it: bd update %s --status=in_progress", issueID, issueID)
+		m.statusIsError = true
+		return m, nil
+	}
+
+	timer := &focusTimer{IssueID: issueID, Start: now, Duration: focusDuration()}
+	if m.workDir != "" {
+		actor := analysis.CurrentActor()
+		m.loadTimeLog()
+		if m.timeLog == nil {
+			m.statusMsg = "Failed to read time log; focus timer not started"
+			m.statusIsError = true
+			return m, nil
+		}
+		if active := m.timeLog.Active(actor); active == nil || active.IssueID != issueID {
+			if _, err := timetrack.Start(timetrack.Path(m.workDir), issueID, actor, now); err != nil {
+				m.statusMsg = "Failed to start focus timer: " + err.Error()
+				m.statusIsError = true
+				return m, nil
+			}
+			timer.Logged = true
+			m.loadTimeLog()
+		}
+	}
+	m.focusTimer = timer
+	m.statusMsg = fmt.Sprintf("🍅 Focusing on %s for %s (P to cancel)", issueID, timetrack.FormatDuration(timer.Duration))
+	m.statusIsError = false
+	m.updateViewportContent()
+	return m, focusTickCmd(timer.Start)
+}
+
+// handleFocusTick ends the focus session once its time is up: the work
+// session is logged and the terminal bell rings.
+func (m Model) handleFocusTick(msg focusTickMsg, now time.Time) (Model, tea.Cmd) {
+	if m.focusTimer == nil || !m.focusTimer.Start.Equal(msg.Start) {
+		return m, nil // Cancelled or replaced
+	}
+	if m.focusTimer.Remaining(now) > 0 {
+		return m, focusTickCmd(msg.Start)
+	}
+	issueID := m.focusTimer.IssueID
+	duration := m.focusTimer.Duration
+	logged := m.focusTimer.Logged
+	m, err := m.stopFocusLogging(now)
+	m.focusTimer = nil
+	switch {
+	case err != nil:
+		m.statusMsg = fmt.Sprintf("🍅 Focus session on %s done, but logging it failed: %v", issueID, err)
+		m.statusIsError = true
+	case logged:
+		m.statusMsg = fmt.Sprintf("🍅 Focus session on %s done, %s logged - take a break", issueID, timetrack.FormatDuration(duration))
+		m.statusIsError = false
+	default:
+		m.statusMsg = fmt.Sprintf("🍅 Focus session on %s done (%s) - take a break", issueID, timetrack.FormatDuration(duration))
+		m.statusIsError = false
+	}
+	return m, ringFocusBell
+}
+
+// stopFocusLogging stops the work session the focus timer started, if it is
+// still the one running
+func (m Model) stopFocusLogging(now time.Time) (Model, error) {
+	if m.focusTimer == nil || !m.focusTimer.Logged || m.workDir == "" {
+		return m, nil
+	}
+	path := timetrack.Path(m.workDir)
+	actor := analysis.CurrentActor()
+	log, err := timetrack.Load(path)
+	if err != nil {
+		return m, err
+	}
+	if active := log.Active(actor); active == nil || active.IssueID != m.focusTimer.IssueID {
+		return m, nil // Stopped or switched elsewhere (W, bv track)
+	}
+	if _, err := timetrack.Stop(path, actor, now); err != nil {
+		return m, err
+	}
+	m.loadTimeLog()
+	m.updateViewportContent()
+	return m, nil
+}
+
+// renderFocusSection is the footer widget for a running focus timer
+func (m Model) renderFocusSection(now time.Time) string {
+	if m.focusTimer == nil {
+		return ""
+	}
+	left := m.focusTimer.Remaining(now).Round(time.Second)
+	style := lipgloss.NewStyle().
+		Background(ColorPrioHighBg).
+		Foreground(ColorWarning).
+		Bold(true).
+		Padding(0, 1)
+	return style.Render(fmt.Sprintf("🍅 %s %02d:%02d", m.focusTimer.IssueID, int(left.Minutes()), int(left.Seconds())%60))
+}
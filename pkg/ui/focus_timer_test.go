@@ -0,0 +1,104 @@
+package ui
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/timetrack"
+)
+
+func newFocusTestModel(t *testing.T, beadsPath string) Model {
+	t.Helper()
+	issues := []model.Issue{
+		{ID: "claimed", Title: "Claimed", Status: model.StatusInProgress},
+		{ID: "open", Title: "Open", Status: model.StatusOpen},
+	}
+	return NewModel(issues, nil, beadsPath)
+}
+
+func selectIssueByID(m *Model, id string) {
+	for i, it := range m.list.Items() {
+		if it.(IssueItem).Issue.ID == id {
+			m.list.Select(i)
+		}
+	}
+}
+
+func TestFocusTimer_RequiresClaimedIssue(t *testing.T) {
+	m := newFocusTestModel(t, "")
+	selectIssueByID(&m, "open")
+	m, cmd := m.toggleFocusTimer()
+	if cmd != nil || m.focusTimer != nil {
+		t.Fatal("an unclaimed issue should not start a focus timer")
+	}
+	if !m.statusIsError || !strings.Contains(m.statusMsg, "bd update open --status=in_progress") {
+		t.Errorf("status = %q", m.statusMsg)
+	}
+}
+
+func TestFocusTimer_CompletesAndLogs(t *testing.T) {
+	var bell bytes.Buffer
+	oldBell := focusBell
+	focusBell = &bell
+	t.Cleanup(func() { focusBell = oldBell })
+	t.Setenv("BV_FOCUS_MINUTES", "10")
+
+	projectDir := t.TempDir()
+	m := newFocusTestModel(t, filepath.Join(projectDir, ".beads", "issues.jsonl"))
+	selectIssueByID(&m, "claimed")
+	m, cmd := m.toggleFocusTimer()
+	if cmd == nil || m.focusTimer == nil || m.focusTimer.Duration != 10*time.Minute || !m.focusTimer.Logged {
+		t.Fatalf("timer = %+v, status %q", m.focusTimer, m.statusMsg)
+	}
+	start := m.focusTimer.Start
+	if footer := m.renderFocusSection(start.Add(90 * time.Second)); !strings.Contains(footer, "🍅 claimed 08:30") {
+		t.Errorf("footer = %q", footer)
+	}
+
+	// Ticks from an older timer are ignored; ticks before the end keep going
+	if _, cmd := m.handleFocusTick(focusTickMsg{Start: start.Add(-time.Hour)}, start); cmd != nil {
+		t.Error("stale tick should not reschedule")
+	}
+	if m2, cmd := m.handleFocusTick(focusTickMsg{Start: start}, start.Add(time.Minute)); cmd == nil || m2.focusTimer == nil {
+		t.Error("running timer should keep ticking")
+	}
+
+	m, cmd = m.handleFocusTick(focusTickMsg{Start: start}, start.Add(10*time.Minute))
+	if m.focusTimer != nil || cmd == nil {
+		t.Fatal("timer should finish and ring")
+	}
+	cmd()
+	if bell.String() != "\a" {
+		t.Errorf("bell = %q", bell.String())
+	}
+	if !strings.Contains(m.statusMsg, "10m logged") {
+		t.Errorf("status = %q", m.statusMsg)
+	}
+
+	log, err := timetrack.Load(timetrack.Path(projectDir))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := log.ActualMinutes()["claimed"]; len(log.Sessions) != 1 || got != 10 {
+		t.Errorf("logged %d min in %+v, want one 10m session", got, log.Sessions)
+	}
+}
+
+func TestFocusTimer_Cancel(t *testing.T) {
+	projectDir := t.TempDir()
+	m := newFocusTestModel(t, filepath.Join(projectDir, ".beads", "issues.jsonl"))
+	selectIssueByID(&m, "claimed")
+	m, _ = m.toggleFocusTimer()
+	m, cmd := m.toggleFocusTimer()
+	if cmd != nil || m.focusTimer != nil || !strings.Contains(m.statusMsg, "cancelled") {
+		t.Fatalf("cancel: timer %+v, status %q", m.focusTimer, m.statusMsg)
+	}
+	log, err := timetrack.Load(timetrack.Path(projectDir))
+	if err != nil || len(log.Sessions) != 1 || log.Sessions[0].Running() {
+		t.Errorf("cancelling should stop the logged session: %+v, %v", log, err)
+	}
+}
@@ -0,0 +1,149 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/drift"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// openGoals forecasts the goals in .bv/goals.yaml and shows the goals panel
+// (the TUI side of --robot-goals).
+func (m Model) openGoals() Model {
+	config, err := analysis.LoadGoals(m.workDir)
+	if err != nil {
+		m.statusMsg = err.Error()
+		m.statusIsError = true
+		return m
+	}
+	if len(config.Goals) == 0 {
+		m.statusMsg = "No goals: add them to " + analysis.GoalsPath(m.workDir)
+		m.statusIsError = false
+		return m
+	}
+	threshold := drift.DefaultConfig().GoalWarningProbability
+	if driftConfig, err := drift.LoadConfig(m.workDir); err == nil {
+		threshold = driftConfig.GoalWarningProbability
+	}
+	m.goals = analysis.ForecastGoals(m.issues, m.analysis, config, nil, analysis.CapacitySimOptions{}, time.Now())
+	m.goalsThreshold = threshold
+	m.goalsCursor = 0
+	m.showGoals = true
+	return m
+}
+
+// handleGoalsKeys handles keys while the goals panel is open.
+func (m Model) handleGoalsKeys(msg tea.KeyMsg) (Model, tea.Cmd) {
+	switch msg.String() {
+	case "j", "down":
+		if m.goalsCursor < len(m.goals)-1 {
+			m.goalsCursor++
+		}
+	case "k", "up":
+		if m.goalsCursor > 0 {
+			m.goalsCursor--
+		}
+	case "enter":
+		// Open the goal's most critical ready issue
+		if m.goalsCursor >= len(m.goals) || len(m.goals[m.goalsCursor].NextUp) == 0 {
+			break
+		}
+		m.showGoals = false
+		m = m.jumpToMention(m.goals[m.goalsCursor].NextUp[0])
+		if !m.statusIsError {
+			m.focused = focusDetail
+			if !m.isSplitView {
+				m.showDetails = true
+			}
+		}
+	case "esc", "q", "%":
+		m.showGoals = false
+	case "ctrl+c":
+		return m, tea.Quit
+	}
+	return m, nil
+}
+
+// renderGoals renders one block per goal: its chance of making the target
+// date as a bar, the projected dates and the issue to start on.
+func (m Model) renderGoals() string {
+	t := m.theme
+	width := min(100, m.width-4)
+
+	titleStyle := t.Renderer.NewStyle().Bold(true).Foreground(t.Primary)
+	dimStyle := t.Renderer.NewStyle().Foreground(t.Secondary).Italic(true)
+
+	var blocks []string
+	cursorBlock := 0
+	for i, g := range m.goals {
+		nameStyle := t.Renderer.NewStyle().Bold(true)
+		status := fmt.Sprintf("%3.0f%%", g.Probability*100)
+		switch {
+		case g.Done:
+			nameStyle = nameStyle.Foreground(t.Closed)
+			status = "done"
+		case g.Total == 0:
+			nameStyle = nameStyle.Foreground(t.Secondary)
+			status = "no issues"
+		case g.Probability < m.goalsThreshold:
+			nameStyle = nameStyle.Foreground(t.Blocked)
+		default:
+			nameStyle = nameStyle.Foreground(t.Open)
+		}
+		prefix := "  "
+		if i == m.goalsCursor {
+			cursorBlock = len(blocks)
+			prefix = t.Renderer.NewStyle().Foreground(t.Primary).Bold(true).Render("▸ ")
+			nameStyle = nameStyle.Background(t.Highlight)
+		}
+
+		target := "by " + g.Target
+		if g.DaysLeft > 0 {
+			target += fmt.Sprintf(" (%.0fd left)", g.DaysLeft)
+		} else {
+			target += " (past)"
+		}
+		name := truncateRunesHelper(g.Name, max(width-lipgloss.Width(target)-lipgloss.Width(status)-24, 8), "…")
+		head := prefix + nameStyle.Render(name) + "  " + RenderMiniBar(g.Probability, 12, t) + " " + status + "  " + dimStyle.Render(target)
+
+		var facts []string
+		facts = append(facts, fmt.Sprintf("%d/%d open", g.Open, g.Total))
+		if len(g.Blockers) > 0 {
+			facts = append(facts, fmt.Sprintf("%d outside blockers", len(g.Blockers)))
+		}
+		if g.Completion != nil {
+			facts = append(facts, fmt.Sprintf("P50 %s · P80 %s", g.Completion.P50Date.Format("2006-01-02"), g.Completion.P80Date.Format("2006-01-02")))
+		}
+		switch {
+		case len(g.Stuck) > 0:
+			facts = append(facts, fmt.Sprintf("stuck: %s", strings.Join(g.Stuck, ", ")))
+		case len(g.NextUp) > 0:
+			facts = append(facts, "next: "+g.NextUp[0])
+		}
+		detail := "    " + dimStyle.Render(truncateRunesHelper(strings.Join(facts, " · "), width-8, "…"))
+		blocks = append(blocks, head+"\n"+detail)
+	}
+
+	maxVisible := max((m.height-12)/3, 2)
+	start := 0
+	if cursorBlock+1 > maxVisible {
+		start = cursorBlock + 1 - maxVisible
+	}
+	end := min(start+maxVisible, len(blocks))
+	body := strings.Join(blocks[start:end], "\n\n")
+
+	footer := fmt.Sprintf("j/k: move | enter: open next issue | esc: close   (at risk below %.0f%%)", m.goalsThreshold*100)
+	content := titleStyle.Render("🎯 Goals") + "\n\n" + body + "\n\n" + dimStyle.Render(footer)
+	box := t.Renderer.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(t.Primary).
+		Padding(1, 2).
+		Width(width).
+		Render(content)
+
+	return lipgloss.Place(m.width, m.height-1, lipgloss.Center, lipgloss.Center, box)
+}
@@ -0,0 +1,63 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestGoalsPanel(t *testing.T) {
+	minutes := func(n int) *int { return &n }
+	issues := []model.Issue{
+		{ID: "bv-1", Title: "Schema", Status: model.StatusOpen, Priority: 1, Labels: []string{"v1"}, EstimatedMinutes: minutes(240)},
+		{ID: "bv-2", Title: "Parser", Status: model.StatusOpen, Priority: 1, Labels: []string{"v1"}, EstimatedMinutes: minutes(240),
+			Dependencies: []*model.Dependency{{DependsOnID: "bv-1", Type: model.DepBlocks}}},
+		{ID: "bv-3", Title: "Docs", Status: model.StatusClosed, Priority: 2},
+	}
+	m := NewModel(issues, nil, "")
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m = updated.(Model)
+
+	m.workDir = t.TempDir()
+	m = pressKeys(t, m, "%")
+	if m.showGoals || !strings.Contains(m.statusMsg, "No goals") {
+		t.Fatalf("without goals.yaml %% should only point at the file, got %q", m.statusMsg)
+	}
+
+	if err := os.MkdirAll(filepath.Join(m.workDir, ".bv"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	goals := "goals:\n  - name: Launch\n    label: v1\n    target: 2999-01-01\n  - name: Docs\n    issues: [bv-3]\n    target: 2000-01-01\n"
+	if err := os.WriteFile(analysis.GoalsPath(m.workDir), []byte(goals), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	m = pressKeys(t, m, "%")
+	if !m.showGoals || m.CurrentContext() != ContextGoals {
+		t.Fatalf("%% should open the goals panel (context %s)", m.CurrentContext())
+	}
+	view := m.View()
+	for _, want := range []string{"Goals", "Launch", "100%", "2/2 open", "next: bv-1", "Docs", "done"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("goals panel missing %q", want)
+		}
+	}
+
+	// enter opens the goal's next issue
+	m = pressKeys(t, m, "enter")
+	if m.showGoals {
+		t.Fatal("enter should close the goals panel")
+	}
+	if item, ok := m.list.SelectedItem().(IssueItem); !ok || item.Issue.ID != "bv-1" {
+		t.Errorf("enter should select bv-1, got %v", m.list.SelectedItem())
+	}
+
+	m = pressKeys(t, m, "%", "esc")
+	if m.showGoals {
+		t.Error("esc should close the goals panel")
+	}
+}
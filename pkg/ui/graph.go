@@ -29,6 +29,9 @@ type GraphModel struct {
 	// Flat list for navigation
 	sortedIDs []string
 
+	// Communities of the graph, detected on first use after a rebuild
+	clusters *analysis.Clustering
+
 	// Precomputed rankings for all metrics (id -> rank, 1-indexed)
 	rankPageRank     map[string]int
 	rankBetweenness  map[string]int
@@ -91,6 +94,7 @@ func (g *GraphModel) rebuildGraph() {
 	g.blockers = make(map[string][]string, size)
 	g.dependents = make(map[string][]string, size)
 	g.sortedIDs = make([]string, 0, size)
+	g.clusters = nil
 
 	for i := range g.issues {
 		issue := &g.issues[i]
@@ -358,23 +362,24 @@ func (g *GraphModel) renderNodeList(width, height int, t Theme) string {
 
 		isSelected := i == g.selectedIdx
 		statusIcon := getStatusIcon(issue.Status)
-		maxIDLen := width - 4
+		maxIDLen := width - 6
 		displayID := smartTruncateID(id, maxIDLen)
 		line := fmt.Sprintf("%s %s", statusIcon, displayID)
 
+		// The dot before each node shows its cluster
 		var style lipgloss.Style
 		if isSelected {
 			style = t.Renderer.NewStyle().
 				Bold(true).
 				Foreground(t.Primary).
 				Background(t.Highlight).
-				Width(width)
+				Width(width - 2)
 		} else {
 			style = t.Renderer.NewStyle().
 				Foreground(getStatusColor(issue.Status, t)).
-				Width(width)
+				Width(width - 2)
 		}
-		lines = append(lines, style.Render(line))
+		lines = append(lines, g.clusterSwatch(id, t)+" "+style.Render(line))
 	}
 
 	if len(g.sortedIDs) > visibleItems {
@@ -410,6 +415,7 @@ func (g *GraphModel) renderVisualGraph(id string, issue *model.Issue, width, hei
 	// EGO NODE (selected issue) - prominent center box
 	// ═══════════════════════════════════════════════════════════════════════
 	sections = append(sections, g.renderEgoNode(id, issue, width, t))
+	sections = append(sections, g.renderClusterLine(id, width, t))
 
 	// ═══════════════════════════════════════════════════════════════════════
 	// DEPENDENTS SECTION (what depends on this issue)
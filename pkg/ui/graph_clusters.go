@@ -0,0 +1,68 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// clusterColors tells clusters apart in the graph view; past eight they
+// repeat, which matters little since the largest clusters come first
+var clusterColors = []lipgloss.AdaptiveColor{
+	{Light: "#7D56F4", Dark: "#BD93F9"}, // Purple
+	{Light: "#D6336C", Dark: "#FF79C6"}, // Pink
+	{Light: "#0B7285", Dark: "#8BE9FD"}, // Cyan
+	{Light: "#2B8A3E", Dark: "#50FA7B"}, // Green
+	{Light: "#D9480F", Dark: "#FFB86C"}, // Orange
+	{Light: "#A08400", Dark: "#F1FA8C"}, // Yellow
+	{Light: "#1864AB", Dark: "#74C0FC"}, // Blue
+	{Light: "#862E9C", Dark: "#E599F7"}, // Violet
+}
+
+// clusterColor returns the color of a cluster ID (1-based)
+func clusterColor(id int) lipgloss.AdaptiveColor {
+	return clusterColors[(id-1)%len(clusterColors)]
+}
+
+// clustering returns the communities of the graph's issues, detecting them
+// the first time the view needs them after a rebuild.
+func (g *GraphModel) clustering() *analysis.Clustering {
+	if g.clusters == nil {
+		g.clusters = analysis.NewAnalyzer(g.issues).Clusters()
+	}
+	return g.clusters
+}
+
+// clusterSwatch is a dot in the color of id's cluster, or a blank when it
+// has none
+func (g *GraphModel) clusterSwatch(id string, t Theme) string {
+	c, ok := g.clustering().Assignments[id]
+	if !ok {
+		return " "
+	}
+	return t.Renderer.NewStyle().Foreground(clusterColor(c)).Render("●")
+}
+
+// renderClusterLine describes the cluster the selected issue belongs to.
+func (g *GraphModel) renderClusterLine(id string, width int, t Theme) string {
+	clusters := g.clustering()
+	c, ok := clusters.Assignments[id]
+	if !ok {
+		return t.Renderer.NewStyle().Foreground(t.Secondary).Italic(true).
+			Width(width).Align(lipgloss.Center).Render("○ No cluster: no blocking links")
+	}
+	cluster := clusters.Clusters[c-1]
+	parts := []string{
+		fmt.Sprintf("● Cluster %d of %d", cluster.ID, len(clusters.Clusters)),
+		fmt.Sprintf("%d issues (%d open)", cluster.Size, cluster.Open),
+		"hub " + cluster.Hub,
+	}
+	if len(cluster.Labels) > 0 {
+		parts = append(parts, strings.Join(cluster.Labels, ", "))
+	}
+	line := truncateRunesHelper(strings.Join(parts, " · "), width, "…")
+	return t.Renderer.NewStyle().Foreground(clusterColor(c)).Bold(true).
+		Width(width).Align(lipgloss.Center).Render(line)
+}
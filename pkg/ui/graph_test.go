@@ -2,6 +2,7 @@ package ui_test
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
@@ -885,3 +886,25 @@ func TestGraphModelReferenceToSelf(t *testing.T) {
 		t.Error("Expected non-empty view")
 	}
 }
+
+// TestGraphModelClusters checks the selected issue's cluster is described
+// and unlinked issues get none
+func TestGraphModelClusters(t *testing.T) {
+	theme := createTheme()
+	issues := []model.Issue{
+		{ID: "A", Title: "Schema", Status: model.StatusOpen, Labels: []string{"db"}},
+		{ID: "B", Title: "Migrate", Status: model.StatusOpen, Labels: []string{"db"},
+			Dependencies: []*model.Dependency{{DependsOnID: "A", Type: model.DepBlocks}}},
+		{ID: "C", Title: "Loose end", Status: model.StatusOpen},
+	}
+	g := ui.NewGraphModel(issues, nil, theme)
+
+	g.SelectByID("B")
+	if out := g.View(140, 40); !strings.Contains(out, "Cluster 1 of 1 · 2 issues (2 open) · hub A · db") {
+		t.Errorf("graph view should describe B's cluster:\n%s", out)
+	}
+	g.SelectByID("C")
+	if out := g.View(140, 40); !strings.Contains(out, "No cluster") {
+		t.Errorf("graph view should say C has no cluster:\n%s", out)
+	}
+}
@@ -225,6 +225,8 @@ func GetStatusIcon(s string) string {
 		return "🟢"
 	case "in_progress":
 		return "🔵"
+	case "in_review":
+		return "🟣"
 	case "blocked":
 		return "🔴"
 	case "closed":
@@ -1389,6 +1389,15 @@ func (h *HistoryModel) buildTimeline(hist correlation.BeadHistory) []TimelineEnt
 			EventType: "claimed",
 		})
 	}
+	if hist.Milestones.InReview != nil {
+		entries = append(entries, TimelineEntry{
+			Timestamp: hist.Milestones.InReview.Timestamp,
+			EntryType: timelineEntryEvent,
+			Label:     "◐ In Review",
+			Detail:    fmt.Sprintf("by %s", hist.Milestones.InReview.Author),
+			EventType: "in_review",
+		})
+	}
 	if hist.Milestones.Reopened != nil {
 		entries = append(entries, TimelineEntry{
 			Timestamp: hist.Milestones.Reopened.Timestamp,
@@ -1563,7 +1572,7 @@ func (h *HistoryModel) renderTimelinePanel(width, height int) string {
 				switch entry.EventType {
 				case "created":
 					eventColor = t.Secondary
-				case "claimed":
+				case "claimed", "in_review":
 					eventColor = t.InProgress
 				case "closed":
 					eventColor = t.Closed
@@ -2410,7 +2419,7 @@ func (h *HistoryModel) renderDetailPanel(width, height int) string {
 
 	// Navigation hint (bv-xf4p: added o and g keys)
 	hintStyle := t.Renderer.NewStyle().Foreground(t.Muted).Italic(true)
-	lines = append(lines, hintStyle.Render("J/K:nav  y:copy  o:open  g:graph"))
+	lines = append(lines, hintStyle.Render("J/K:nav  y:copy  o:edit  O:web  g:graph"))
 
 	content := strings.Join(lines, "\n")
 	return panelStyle.Render(content)
@@ -2862,6 +2871,8 @@ func eventTypeIcon(et correlation.EventType) string {
 		return "🆕"
 	case correlation.EventClaimed:
 		return "👤"
+	case correlation.EventInReview:
+		return "👀"
 	case correlation.EventClosed:
 		return "✓"
 	case correlation.EventReopened:
@@ -2880,6 +2891,8 @@ func eventTypeColor(et correlation.EventType, t Theme) lipgloss.TerminalColor {
 		return t.Primary // new items get primary highlight
 	case correlation.EventClaimed:
 		return t.InProgress // claimed = in progress
+	case correlation.EventInReview:
+		return t.InProgress // review is still active work
 	case correlation.EventClosed:
 		return t.Open // closed = success/green
 	case correlation.EventReopened:
@@ -2898,6 +2911,8 @@ func eventTypeLabel(et correlation.EventType) string {
 		return "Created"
 	case correlation.EventClaimed:
 		return "Claimed"
+	case correlation.EventInReview:
+		return "In Review"
 	case correlation.EventClosed:
 		return "Closed"
 	case correlation.EventReopened:
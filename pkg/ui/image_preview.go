@@ -0,0 +1,237 @@
+package ui
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"hash/fnv"
+	"image"
+	"image/color"
+	_ "image/gif"  // Register GIF for attachment previews
+	_ "image/jpeg" // Register JPEG for attachment previews
+	"image/png"
+	"os"
+	"strings"
+
+	_ "golang.org/x/image/bmp" // Register BMP for attachment previews
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp" // Register WebP for attachment previews
+)
+
+// imageProtocol is how the terminal can show attachment previews inline.
+type imageProtocol int
+
+const (
+	imageProtocolNone imageProtocol = iota
+	// imageProtocolKitty places images with the kitty graphics protocol's
+	// Unicode placeholders: the image lives in ordinary text cells, so it
+	// scrolls and repaints with the detail pane like any other line.
+	imageProtocolKitty
+	// imageProtocolBlocks draws images with truecolor half blocks. Sixel
+	// terminals get this: a sixel image is painted at the cursor outside the
+	// text grid, where the next frame of a full-screen UI draws over it.
+	imageProtocolBlocks
+)
+
+const (
+	maxInlineImages    = 3        // Previews per issue; the rest are only listed
+	maxPreviewCols     = 40       // Preview size in cells
+	maxPreviewRows     = 12       // Must not exceed len(kittyRowDiacritics)
+	maxPreviewFileSize = 16 << 20 // Larger files are listed but not decoded
+	kittyChunkSize     = 4096     // Max base64 payload per graphics command
+	kittyPixelsPerCol  = 10       // Resolution of the copy sent to the terminal
+	kittyPlaceholder   = '\U0010EEEE'
+)
+
+// kittyRowDiacritics are the combining marks kitty reads as row and column
+// numbers 0, 1, 2, … of a placeholder cell.
+var kittyRowDiacritics = []rune{
+	0x0305, 0x030D, 0x030E, 0x0310, 0x0312, 0x033D, 0x033E, 0x033F,
+	0x0346, 0x034A, 0x034B, 0x034C, 0x0350, 0x0351, 0x0352, 0x0357,
+}
+
+// detectImageProtocol picks the inline image protocol from the environment.
+// BV_INLINE_IMAGES=off|kitty|sixel overrides detection.
+func detectImageProtocol(getenv func(string) string) imageProtocol {
+	switch strings.ToLower(getenv("BV_INLINE_IMAGES")) {
+	case "off", "none", "0", "false":
+		return imageProtocolNone
+	case "kitty":
+		return imageProtocolKitty
+	case "sixel", "blocks":
+		return imageProtocolBlocks
+	}
+	term, program := getenv("TERM"), getenv("TERM_PROGRAM")
+	switch {
+	case getenv("KITTY_WINDOW_ID") != "", term == "xterm-kitty", term == "xterm-ghostty", program == "ghostty":
+		return imageProtocolKitty
+	case strings.Contains(term, "sixel"), strings.HasPrefix(term, "foot"), strings.HasPrefix(term, "mlterm"),
+		program == "WezTerm", program == "iTerm.app", program == "mintty":
+		return imageProtocolBlocks
+	}
+	return imageProtocolNone
+}
+
+// renderAttachmentPreviews renders inline previews of the first few image
+// attachments, or "" when the terminal can't show images.
+func (m *Model) renderAttachmentPreviews(atts []attachment) string {
+	if m.imageProtocol == imageProtocolNone {
+		return ""
+	}
+	maxCols := min(maxPreviewCols, m.viewport.Width-4)
+	if maxCols < 4 {
+		return ""
+	}
+	var sb strings.Builder
+	shown := 0
+	for i, a := range atts {
+		if !a.IsImage() || shown == maxInlineImages {
+			continue
+		}
+		preview, ok := m.imagePreview(a.Path, maxCols)
+		if !ok {
+			continue
+		}
+		shown++
+		sb.WriteString(fmt.Sprintf("  %d. %s\n", i+1, a.Name))
+		for _, line := range strings.SplitAfter(strings.TrimSuffix(preview, "\n"), "\n") {
+			sb.WriteString("  " + line)
+		}
+		sb.WriteString("\n\n")
+	}
+	return sb.String()
+}
+
+// imagePreview renders one image file, cached by path, size and mtime since
+// the detail pane is redrawn on every selection change.
+func (m *Model) imagePreview(path string, maxCols int) (string, bool) {
+	info, err := os.Stat(path)
+	if err != nil || info.Size() > maxPreviewFileSize {
+		return "", false
+	}
+	key := fmt.Sprintf("%s|%d|%d|%d|%d", path, info.Size(), info.ModTime().UnixNano(), maxCols, m.imageProtocol)
+	if preview, ok := m.imagePreviews[key]; ok {
+		return preview, preview != ""
+	}
+
+	preview := ""
+	if img, err := decodeImageFile(path); err == nil {
+		b := img.Bounds()
+		cols, rows := previewCells(b.Dx(), b.Dy(), maxCols, maxPreviewRows)
+		switch m.imageProtocol {
+		case imageProtocolKitty:
+			preview, _ = renderKittyImage(img, kittyImageID(key), cols, rows)
+		case imageProtocolBlocks:
+			preview = renderHalfBlocks(img, cols, rows)
+		}
+	}
+	if m.imagePreviews != nil {
+		m.imagePreviews[key] = preview
+	}
+	return preview, preview != ""
+}
+
+func decodeImageFile(path string) (image.Image, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	img, _, err := image.Decode(f)
+	return img, err
+}
+
+// previewCells sizes a preview in terminal cells, which are about twice as
+// tall as they are wide. Small images aren't blown up past ~8 pixels a cell.
+func previewCells(width, height, maxCols, maxRows int) (cols, rows int) {
+	if width <= 0 || height <= 0 {
+		return 1, 1
+	}
+	cols = max(1, min(maxCols, (width+7)/8))
+	rows = max(1, (cols*height+width)/(2*width))
+	if rows > maxRows {
+		rows = maxRows
+		cols = max(1, min(maxCols, 2*rows*width/height))
+	}
+	return cols, rows
+}
+
+// scaleImage resamples img to w×h pixels.
+func scaleImage(img image.Image, w, h int) *image.NRGBA {
+	dst := image.NewNRGBA(image.Rect(0, 0, w, h))
+	draw.ApproxBiLinear.Scale(dst, dst.Bounds(), img, img.Bounds(), draw.Src, nil)
+	return dst
+}
+
+// renderHalfBlocks draws img in cols×rows cells, two pixels per cell: the
+// upper half block in the top pixel's color over the bottom pixel's.
+func renderHalfBlocks(img image.Image, cols, rows int) string {
+	px := scaleImage(img, cols, rows*2)
+	var sb strings.Builder
+	for y := 0; y < rows; y++ {
+		for x := 0; x < cols; x++ {
+			top, bottom := opaque(px.NRGBAAt(x, 2*y)), opaque(px.NRGBAAt(x, 2*y+1))
+			fmt.Fprintf(&sb, "\x1b[38;2;%d;%d;%dm\x1b[48;2;%d;%d;%dm▀", top.R, top.G, top.B, bottom.R, bottom.G, bottom.B)
+		}
+		sb.WriteString("\x1b[0m\n")
+	}
+	return sb.String()
+}
+
+// opaque composites a pixel over black.
+func opaque(c color.NRGBA) color.NRGBA {
+	a := uint16(c.A)
+	return color.NRGBA{R: uint8(uint16(c.R) * a / 255), G: uint8(uint16(c.G) * a / 255), B: uint8(uint16(c.B) * a / 255), A: 255}
+}
+
+// kittyImageID derives a stable 24-bit image ID, which placeholder cells
+// carry as their foreground color.
+func kittyImageID(key string) uint32 {
+	h := fnv.New32a()
+	h.Write([]byte(key))
+	if id := h.Sum32() & 0xFFFFFF; id != 0 {
+		return id
+	}
+	return 1
+}
+
+// renderKittyImage transmits img with a virtual placement of cols×rows
+// cells, followed by the placeholder cells that show it. The transmission
+// rides on the first row as a zero-width escape sequence.
+func renderKittyImage(img image.Image, id uint32, cols, rows int) (string, error) {
+	b := img.Bounds()
+	w := min(b.Dx(), cols*kittyPixelsPerCol)
+	h := max(1, b.Dy()*w/b.Dx())
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, scaleImage(img, w, h)); err != nil {
+		return "", err
+	}
+	payload := base64.StdEncoding.EncodeToString(buf.Bytes())
+
+	var sb strings.Builder
+	for i := 0; i < len(payload); i += kittyChunkSize {
+		end := min(i+kittyChunkSize, len(payload))
+		more := 0
+		if end < len(payload) {
+			more = 1
+		}
+		if i == 0 {
+			fmt.Fprintf(&sb, "\x1b_Ga=T,U=1,f=100,q=2,i=%d,c=%d,r=%d,m=%d;%s\x1b\\", id, cols, rows, more, payload[i:end])
+		} else {
+			fmt.Fprintf(&sb, "\x1b_Gm=%d;%s\x1b\\", more, payload[i:end])
+		}
+	}
+
+	// Only the first cell of a row needs its row and column; the rest of
+	// the row continues from it
+	fg := fmt.Sprintf("\x1b[38;2;%d;%d;%dm", id>>16&0xFF, id>>8&0xFF, id&0xFF)
+	rest := strings.Repeat(string(kittyPlaceholder), cols-1)
+	for r := 0; r < rows; r++ {
+		sb.WriteString(fg)
+		sb.WriteRune(kittyPlaceholder)
+		sb.WriteRune(kittyRowDiacritics[r])
+		sb.WriteRune(kittyRowDiacritics[0])
+		sb.WriteString(rest + "\x1b[39m\n")
+	}
+	return sb.String(), nil
+}
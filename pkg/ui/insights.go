@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/correlation"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
 
 	"github.com/charmbracelet/bubbles/viewport"
@@ -24,8 +25,10 @@ const (
 	PanelArticulation
 	PanelSlack
 	PanelCycles
-	PanelPriority // Agent-first priority recommendations
-	PanelCount    // Sentinel for wrapping
+	PanelPriority  // Agent-first priority recommendations
+	PanelBusFactor // Ownership concentration per label and subsystem
+	PanelQueues    // Queueing-theory load per label
+	PanelCount     // Sentinel for wrapping
 )
 
 // MetricInfo contains explanation for each metric
@@ -130,6 +133,24 @@ var metricDescriptions = map[MetricPanel]MetricInfo{
 		HowToUse:    "**Work top to bottom.** High scores = high impact. Check unblocks count.",
 		FormulaHint: "`Score = Σ(PageRank + Betweenness + BlockerRatio + ...)`",
 	},
+	PanelBusFactor: {
+		Icon:        "🚌",
+		Title:       "Bus Factor",
+		ShortDesc:   "Ownership Concentration",
+		WhatIs:      "Labels and subsystems where **one author** made most of the commits.",
+		WhyUseful:   "If that person is away, the *key open issues* there stall with nobody who knows the code.",
+		HowToUse:    "**Pair on or hand over** the listed issues before they become urgent. ⚠ marks areas at risk.",
+		FormulaHint: "`top share = author commits / area commits`; bus factor = fewest authors covering > 50%",
+	},
+	PanelQueues: {
+		Icon:        "🚦",
+		Title:       "Queues",
+		ShortDesc:   "Utilization & Wait",
+		WhatIs:      "Each label as a **queue**: issues arrive when created and are served claim-to-close.",
+		WhyUseful:   "The most utilized queue is the *system constraint*; work piles up there first.",
+		HowToUse:    "**Add capacity or cut intake** at the constraint. ρ ≥ 1 means its backlog grows.",
+		FormulaHint: "`ρ = λ / (c·μ)`, wait from Erlang C (M/M/c)",
+	},
 }
 
 // InsightsModel is an interactive insights dashboard
@@ -144,6 +165,12 @@ type InsightsModel struct {
 	// Priority triage data (bv-91)
 	topPicks []analysis.TopPick
 
+	// Queue analysis (per-label utilization and wait)
+	queues analysis.QueueAnalysis
+
+	// Ownership concentration; nil until git history has loaded
+	ownership *correlation.OwnershipReport
+
 	// Priority radar data (bv-93) - full recommendations with breakdown
 	recommendations    []analysis.Recommendation
 	recommendationMap  map[string]*analysis.Recommendation // ID -> Recommendation for quick lookup
@@ -229,6 +256,16 @@ func (m *InsightsModel) SetTopPicks(picks []analysis.TopPick) {
 	m.topPicks = picks
 }
 
+// SetQueues sets the queueing-theory analysis for the queues panel
+func (m *InsightsModel) SetQueues(queues analysis.QueueAnalysis) {
+	m.queues = queues
+}
+
+// SetOwnership sets the bus-factor analysis for the bus factor panel
+func (m *InsightsModel) SetOwnership(report *correlation.OwnershipReport) {
+	m.ownership = report
+}
+
 // SetRecommendations sets the full recommendations with breakdown data (bv-93)
 func (m *InsightsModel) SetRecommendations(recs []analysis.Recommendation, dataHash string) {
 	m.recommendations = recs
@@ -569,6 +606,13 @@ func (m *InsightsModel) currentPanelItemCount() int {
 		return len(m.insights.Cycles)
 	case PanelPriority:
 		return len(m.topPicks)
+	case PanelBusFactor:
+		if m.ownership == nil {
+			return 0
+		}
+		return len(m.ownership.Areas)
+	case PanelQueues:
+		return len(m.queues.Queues)
 	default:
 		return 0
 	}
@@ -622,6 +666,15 @@ func (m *InsightsModel) SelectedIssueID() string {
 		return ""
 	}
 
+	// For the bus factor panel, return the selected area's most central key issue
+	if m.focusedPanel == PanelBusFactor {
+		idx := m.selectedIndex[PanelBusFactor]
+		if m.ownership != nil && idx >= 0 && idx < len(m.ownership.Areas) && len(m.ownership.Areas[idx].KeyOpenIssues) > 0 {
+			return m.ownership.Areas[idx].KeyOpenIssues[0].BeadID
+		}
+		return ""
+	}
+
 	// For other panels, return selected item's ID
 	items := m.getPanelItems(m.focusedPanel)
 	idx := m.selectedIndex[m.focusedPanel]
@@ -701,12 +754,16 @@ func (m *InsightsModel) View() string {
 	row3 := lipgloss.JoinHorizontal(lipgloss.Top, panels[6], panels[7], panels[8])
 	// Priority panel spans full width for prominence (bv-91)
 	// Toggle between priority list and heatmap view (bv-95)
+	// The bus factor and queues panels take the last two columns beside it
 	var row4 string
 	if m.showHeatmap {
-		row4 = m.renderHeatmapPanel(mainWidth-2, rowHeight, t)
+		row4 = m.renderHeatmapPanel(mainWidth-2*colWidth-6, rowHeight, t)
 	} else {
-		row4 = m.renderPriorityPanel(mainWidth-2, rowHeight, t)
+		row4 = m.renderPriorityPanel(mainWidth-2*colWidth-6, rowHeight, t)
 	}
+	row4 = lipgloss.JoinHorizontal(lipgloss.Top, row4,
+		m.renderBusFactorPanel(colWidth, rowHeight, t),
+		m.renderQueuesPanel(colWidth, rowHeight, t))
 
 	mainContent := lipgloss.JoinVertical(lipgloss.Left, row1, row2, row3, row4)
 
@@ -1178,6 +1235,104 @@ func (m *InsightsModel) renderPriorityPanel(width, height int, t Theme) string {
 	return panelStyle.Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
 }
 
+// renderQueuesPanel renders per-label queue utilization, marking the constraint
+func (m *InsightsModel) renderQueuesPanel(width, height int, t Theme) string {
+	info := metricDescriptions[PanelQueues]
+	isFocused := m.focusedPanel == PanelQueues
+	queues := m.queues.Queues
+
+	borderColor := t.Secondary
+	if isFocused {
+		borderColor = t.Primary
+	}
+	panelStyle := t.Renderer.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(borderColor).
+		Width(width).
+		Height(height).
+		Padding(0, 1)
+
+	titleStyle := t.Renderer.NewStyle().Bold(true)
+	if isFocused {
+		titleStyle = titleStyle.Foreground(t.Primary)
+	} else {
+		titleStyle = titleStyle.Foreground(t.Secondary)
+	}
+	subtitleStyle := t.Renderer.NewStyle().Foreground(t.Subtext).Italic(true)
+
+	var lines []string
+	lines = append(lines, titleStyle.Render(fmt.Sprintf("%s %s (%d)", info.Icon, info.Title, len(queues))))
+	lines = append(lines, subtitleStyle.Render(info.ShortDesc))
+	if m.showExplanations {
+		lines = append(lines, m.renderMarkdownExplanation(info.WhatIs, width-4))
+	}
+
+	if len(queues) == 0 {
+		lines = append(lines, subtitleStyle.Render(fmt.Sprintf("No label activity in the last %d days", m.queues.WindowDays)))
+		return panelStyle.Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+	}
+
+	selectedIdx := m.selectedIndex[PanelQueues]
+	visibleRows := height - 4
+	if m.showExplanations {
+		visibleRows -= 2
+	}
+	visibleRows = max(visibleRows, 3)
+	startIdx := m.scrollOffset[PanelQueues]
+	if selectedIdx >= startIdx+visibleRows {
+		startIdx = selectedIdx - visibleRows + 1
+	}
+	if selectedIdx < startIdx {
+		startIdx = selectedIdx
+	}
+	m.scrollOffset[PanelQueues] = startIdx
+	endIdx := min(startIdx+visibleRows, len(queues))
+
+	for i := startIdx; i < endIdx; i++ {
+		q := queues[i]
+		isSelected := isFocused && i == selectedIdx
+
+		var load string
+		style := t.Renderer.NewStyle().Foreground(t.Open)
+		switch {
+		case q.Insufficient:
+			load = "no data"
+			style = subtitleStyle
+		case !q.Stable:
+			load = fmt.Sprintf("ρ%.2f +%.1f/d", q.Utilization, q.BacklogGrowthPerDay)
+			style = t.Renderer.NewStyle().Foreground(t.Blocked)
+		default:
+			load = fmt.Sprintf("ρ%.2f %.1fd", q.Utilization, *q.ExpectedWaitDays)
+			if q.Utilization >= 0.8 {
+				style = t.Renderer.NewStyle().Foreground(t.InProgress)
+			}
+		}
+
+		prefix := "  "
+		if isSelected {
+			style = style.Bold(true)
+			prefix = t.Renderer.NewStyle().Foreground(t.Primary).Bold(true).Render("▸ ")
+		}
+		name := q.Name
+		if name == m.queues.Constraint {
+			name = "⛔ " + name
+		}
+		name = truncateRunesHelper(name, max(width-4-lipgloss.Width(load)-1, 4), "…")
+		gap := strings.Repeat(" ", max(width-4-lipgloss.Width(name)-lipgloss.Width(load), 1))
+		lines = append(lines, prefix+style.Render(name+gap+load))
+	}
+
+	if len(queues) > visibleRows {
+		scrollStyle := t.Renderer.NewStyle().
+			Foreground(t.Subtext).
+			Align(lipgloss.Center).
+			Width(width - 4)
+		lines = append(lines, scrollStyle.Render(fmt.Sprintf("↕ %d/%d", selectedIdx+1, len(queues))))
+	}
+
+	return panelStyle.Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+}
+
 // renderMiniBar renders a compact progress bar for metric visualization (bv-93)
 // label: 2-char label (e.g., "PR", "BW", "TI")
 // value: normalized 0.0-1.0
@@ -1,9 +1,11 @@
 package ui_test
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/correlation"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/ui"
 )
@@ -581,3 +583,74 @@ func TestInsightsModelScrolling(t *testing.T) {
 		_ = m.View()
 	}
 }
+
+// TestInsightsModelQueuesPanel verifies the queues panel renders the constraint and load
+func TestInsightsModelQueuesPanel(t *testing.T) {
+	theme := createTheme()
+	m := ui.NewInsightsModel(createTestInsights(), createTestIssueMap(), theme)
+	m.SetSize(160, 50)
+
+	wait := 0.4
+	m.SetQueues(analysis.QueueAnalysis{
+		WindowDays: 90,
+		Queues: []analysis.QueueStats{
+			{Name: "backend", Utilization: 1.5, BacklogGrowthPerDay: 0.3},
+			{Name: "docs", Utilization: 0.2, Stable: true, ExpectedWaitDays: &wait},
+			{Name: "ux", Insufficient: true},
+		},
+		Constraint: "backend",
+	})
+
+	// Queues is the last panel, one step back from the first
+	m.PrevPanel()
+	if id := m.SelectedIssueID(); id != "" {
+		t.Errorf("queues are not issues, got selected ID %q", id)
+	}
+
+	view := m.View()
+	for _, want := range []string{"Queues (3)", "⛔", "backend", "ρ1.50 +0.3/d", "ρ0.20 0.4d", "no data"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("queues panel missing %q", want)
+		}
+	}
+}
+
+// TestInsightsModelBusFactorPanel verifies the bus factor panel marks at-risk
+// areas and selects their key issue
+func TestInsightsModelBusFactorPanel(t *testing.T) {
+	theme := createTheme()
+	m := ui.NewInsightsModel(createTestInsights(), createTestIssueMap(), theme)
+	m.SetSize(160, 50)
+
+	if view := m.View(); !strings.Contains(view, "Waiting for git history") {
+		t.Error("bus factor panel should say it is waiting before history loads")
+	}
+
+	m.SetOwnership(&correlation.OwnershipReport{
+		Areas: []correlation.OwnershipArea{
+			{Kind: "label", Name: "auth", TopShare: 1, BusFactor: 1, AtRisk: true,
+				Authors:       []correlation.AuthorShare{{Author: "alice", Commits: 4, Share: 1}},
+				KeyOpenIssues: []correlation.OwnershipIssue{{BeadID: "bottleneck-1", PageRank: 0.4}}},
+			{Kind: "subsystem", Name: "pkg/api", TopShare: 0.5, BusFactor: 1,
+				Authors: []correlation.AuthorShare{{Author: "bob", Commits: 2, Share: 0.5}}},
+		},
+	})
+
+	// Bus factor sits two steps back from the first panel, before queues
+	m.PrevPanel()
+	m.PrevPanel()
+	if id := m.SelectedIssueID(); id != "bottleneck-1" {
+		t.Errorf("selected ID = %q, want the at-risk area's key issue", id)
+	}
+	m.MoveDown()
+	if id := m.SelectedIssueID(); id != "" {
+		t.Errorf("an area without key issues selects nothing, got %q", id)
+	}
+
+	view := m.View()
+	for _, want := range []string{"Bus Factor (2)", "⚠ label:auth", "alice 100%", "pkg/api", "bob 50%"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("bus factor panel missing %q", want)
+		}
+	}
+}
@@ -0,0 +1,657 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/configdir"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/i18n"
+	tea "github.com/charmbracelet/bubbletea"
+	"gopkg.in/yaml.v3"
+)
+
+// KeyScope is where a key action is active. Global actions work in every
+// view; the others only while their view has focus and take precedence
+// over global actions there.
+type KeyScope string
+
+const (
+	ScopeGlobal   KeyScope = "global"
+	ScopeList     KeyScope = "list"
+	ScopeBoard    KeyScope = "board"
+	ScopeGraph    KeyScope = "graph"
+	ScopeInsights KeyScope = "insights"
+	ScopeHistory  KeyScope = "history"
+)
+
+// KeyAction is a remappable TUI action. The first default key is the one
+// the key handlers match on; remapped keys are translated to it.
+type KeyAction struct {
+	ID      string
+	Scope   KeyScope
+	Keys    []string // Default keys
+	Section string   // Help overlay panel
+	Desc    string
+	HelpRow string // Actions sharing a HelpRow are shown as one help line
+}
+
+// defaultKeyActions lists every remappable action in help overlay order.
+var defaultKeyActions = []KeyAction{
+	// Navigation
+	{ID: "nav.down", Scope: ScopeList, Keys: []string{"j", "down"}, Section: "Navigation", Desc: "Move down"},
+	{ID: "nav.up", Scope: ScopeList, Keys: []string{"k", "up"}, Section: "Navigation", Desc: "Move up"},
+	{ID: "nav.last", Scope: ScopeList, Keys: []string{"G", "end"}, Section: "Navigation", Desc: "Go to last"},
+	{ID: "nav.page_down", Scope: ScopeList, Keys: []string{"ctrl+d"}, Section: "Navigation", Desc: "Page down"},
+	{ID: "nav.page_up", Scope: ScopeList, Keys: []string{"ctrl+u"}, Section: "Navigation", Desc: "Page up"},
+	{ID: "focus.switch", Scope: ScopeGlobal, Keys: []string{"tab"}, Section: "Navigation", Desc: "Switch focus"},
+	{ID: "nav.details", Scope: ScopeList, Keys: []string{"enter"}, Section: "Navigation", Desc: "View details"},
+	{ID: "back", Scope: ScopeGlobal, Keys: []string{"esc"}, Section: "Navigation", Desc: "Back / close"},
+
+	// Views
+	{ID: "view.board", Scope: ScopeGlobal, Keys: []string{"b"}, Section: "Views", Desc: "Kanban board"},
+	{ID: "view.graph", Scope: ScopeGlobal, Keys: []string{"g"}, Section: "Views", Desc: "Graph view"},
+	{ID: "view.insights", Scope: ScopeGlobal, Keys: []string{"i"}, Section: "Views", Desc: "Insights"},
+	{ID: "view.history", Scope: ScopeGlobal, Keys: []string{"h"}, Section: "Views", Desc: "History view"},
+	{ID: "view.actionable", Scope: ScopeGlobal, Keys: []string{"a"}, Section: "Views", Desc: "Actionable"},
+	{ID: "view.flow_matrix", Scope: ScopeGlobal, Keys: []string{"f"}, Section: "Views", Desc: "Flow matrix"},
+	{ID: "view.labels", Scope: ScopeGlobal, Keys: []string{"[", "f3"}, Section: "Views", Desc: "Label dashboard"},
+	{ID: "view.attention", Scope: ScopeGlobal, Keys: []string{"]", "f4"}, Section: "Views", Desc: "Attention view"},
+	{ID: "layout.pick", Scope: ScopeGlobal, Keys: []string{"|"}, Section: "Views", Desc: "Pin split layout"},
+	{ID: "layout.narrow", Scope: ScopeGlobal, Keys: []string{"<"}, Section: "Views", Desc: "Resize split", HelpRow: "resize"},
+	{ID: "layout.widen", Scope: ScopeGlobal, Keys: []string{">"}, Section: "Views", Desc: "Resize split", HelpRow: "resize"},
+
+	// Global
+	{ID: "help", Scope: ScopeGlobal, Keys: []string{"?", "f1"}, Section: "Global", Desc: "This help"},
+	{ID: "tutorial", Scope: ScopeGlobal, Keys: []string{"`"}, Section: "Global", Desc: "Tutorial"},
+	{ID: "shortcuts", Scope: ScopeGlobal, Keys: []string{";", "f2"}, Section: "Global", Desc: "Shortcuts bar"},
+	{ID: "alerts", Scope: ScopeGlobal, Keys: []string{"!"}, Section: "Global", Desc: "Alerts panel"},
+	{ID: "changelog", Scope: ScopeGlobal, Keys: []string{"N"}, Section: "Global", Desc: "Reload change log"},
+	{ID: "my_work", Scope: ScopeGlobal, Keys: []string{"@"}, Section: "Global", Desc: "My work queue"},
+	{ID: "goals", Scope: ScopeGlobal, Keys: []string{"%"}, Section: "Global", Desc: "Goals"},
+	{ID: "sandbox", Scope: ScopeGlobal, Keys: []string{"X"}, Section: "Global", Desc: "Simulation sandbox"},
+	{ID: "recipes", Scope: ScopeGlobal, Keys: []string{"'", "f5"}, Section: "Global", Desc: "Recipes"},
+	{ID: "repos", Scope: ScopeGlobal, Keys: []string{"w"}, Section: "Global", Desc: "Repo picker"},
+	{ID: "theme.cycle", Scope: ScopeGlobal, Keys: []string{"ctrl+t"}, Section: "Global", Desc: "Cycle theme"},
+	{ID: "palette", Scope: ScopeGlobal, Keys: []string{"ctrl+p"}, Section: "Global", Desc: "Command palette"},
+	{ID: "quit", Scope: ScopeGlobal, Keys: []string{"q"}, Section: "Global", Desc: "Back / Quit"},
+	{ID: "force_quit", Scope: ScopeGlobal, Keys: []string{"ctrl+c"}, Section: "Global", Desc: "Force quit"},
+
+	// Filters & Sort
+	{ID: "search", Scope: ScopeList, Keys: []string{"/"}, Section: "Filters & Sort", Desc: "Fuzzy search"},
+	{ID: "search.semantic", Scope: ScopeList, Keys: []string{"ctrl+s"}, Section: "Filters & Sort", Desc: "Semantic search"},
+	{ID: "search.hybrid", Scope: ScopeList, Keys: []string{"H"}, Section: "Filters & Sort", Desc: "Hybrid ranking"},
+	{ID: "search.hybrid_preset", Scope: ScopeList, Keys: []string{"alt+h"}, Section: "Filters & Sort", Desc: "Hybrid preset"},
+	{ID: "filter.open", Scope: ScopeList, Keys: []string{"o"}, Section: "Filters & Sort", Desc: "Open issues"},
+	{ID: "filter.closed", Scope: ScopeList, Keys: []string{"c"}, Section: "Filters & Sort", Desc: "Closed issues"},
+	{ID: "filter.ready", Scope: ScopeList, Keys: []string{"r"}, Section: "Filters & Sort", Desc: "Ready (unblocked)"},
+	{ID: "filter.review", Scope: ScopeList, Keys: []string{"I"}, Section: "Filters & Sort", Desc: "In review"},
+	{ID: "filter.label", Scope: ScopeGlobal, Keys: []string{"l"}, Section: "Filters & Sort", Desc: "Filter by label"},
+	{ID: "sort.cycle", Scope: ScopeList, Keys: []string{"s"}, Section: "Filters & Sort", Desc: "Cycle sort"},
+	{ID: "sort.triage", Scope: ScopeList, Keys: []string{"S"}, Section: "Filters & Sort", Desc: "Triage sort"},
+
+	// Board
+	{ID: "board.left", Scope: ScopeBoard, Keys: []string{"h", "left"}, Section: "Board", Desc: "Move between cards", HelpRow: "move"},
+	{ID: "board.down", Scope: ScopeBoard, Keys: []string{"j", "down"}, Section: "Board", Desc: "Move between cards", HelpRow: "move"},
+	{ID: "board.up", Scope: ScopeBoard, Keys: []string{"k", "up"}, Section: "Board", Desc: "Move between cards", HelpRow: "move"},
+	{ID: "board.right", Scope: ScopeBoard, Keys: []string{"l", "right"}, Section: "Board", Desc: "Move between cards", HelpRow: "move"},
+	{ID: "board.search", Scope: ScopeBoard, Keys: []string{"/"}, Section: "Board", Desc: "Search cards"},
+	{ID: "board.next_match", Scope: ScopeBoard, Keys: []string{"n"}, Section: "Board", Desc: "Next match"},
+	{ID: "board.prev_match", Scope: ScopeBoard, Keys: []string{"N"}, Section: "Board", Desc: "Previous match"},
+	{ID: "board.open", Scope: ScopeBoard, Keys: []string{"enter"}, Section: "Board", Desc: "Open card"},
+	{ID: "board.lanes", Scope: ScopeBoard, Keys: []string{"v"}, Section: "Board", Desc: "Lanes: label/assignee/track"},
+	{ID: "board.next_lane", Scope: ScopeBoard, Keys: []string{"J"}, Section: "Board", Desc: "Jump between lanes", HelpRow: "lanes"},
+	{ID: "board.prev_lane", Scope: ScopeBoard, Keys: []string{"K"}, Section: "Board", Desc: "Jump between lanes", HelpRow: "lanes"},
+	{ID: "board.fold_lane", Scope: ScopeBoard, Keys: []string{"z"}, Section: "Board", Desc: "Fold lane"},
+	{ID: "board.fold_all", Scope: ScopeBoard, Keys: []string{"Z"}, Section: "Board", Desc: "Fold all lanes"},
+
+	// Graph View
+	{ID: "graph.left", Scope: ScopeGraph, Keys: []string{"h", "left"}, Section: "Graph View", Desc: "Navigate nodes", HelpRow: "navigate"},
+	{ID: "graph.down", Scope: ScopeGraph, Keys: []string{"j", "down"}, Section: "Graph View", Desc: "Navigate nodes", HelpRow: "navigate"},
+	{ID: "graph.up", Scope: ScopeGraph, Keys: []string{"k", "up"}, Section: "Graph View", Desc: "Navigate nodes", HelpRow: "navigate"},
+	{ID: "graph.right", Scope: ScopeGraph, Keys: []string{"l", "right"}, Section: "Graph View", Desc: "Navigate nodes", HelpRow: "navigate"},
+	{ID: "graph.scroll_left", Scope: ScopeGraph, Keys: []string{"H"}, Section: "Graph View", Desc: "Scroll left/right", HelpRow: "scroll-x"},
+	{ID: "graph.scroll_right", Scope: ScopeGraph, Keys: []string{"L"}, Section: "Graph View", Desc: "Scroll left/right", HelpRow: "scroll-x"},
+	{ID: "graph.page_up", Scope: ScopeGraph, Keys: []string{"pgup", "ctrl+u"}, Section: "Graph View", Desc: "Scroll up/down", HelpRow: "scroll-y"},
+	{ID: "graph.page_down", Scope: ScopeGraph, Keys: []string{"pgdown", "ctrl+d"}, Section: "Graph View", Desc: "Scroll up/down", HelpRow: "scroll-y"},
+	{ID: "graph.open", Scope: ScopeGraph, Keys: []string{"enter"}, Section: "Graph View", Desc: "Jump to issue"},
+	{ID: "graph.cycle_break", Scope: ScopeGraph, Keys: []string{"B"}, Section: "Graph View", Desc: "Cycle-break wizard"},
+
+	// Insights
+	{ID: "insights.prev_panel", Scope: ScopeInsights, Keys: []string{"h", "left"}, Section: "Insights", Desc: "Switch panels", HelpRow: "panels"},
+	{ID: "insights.next_panel", Scope: ScopeInsights, Keys: []string{"l", "right", "tab"}, Section: "Insights", Desc: "Switch panels", HelpRow: "panels"},
+	{ID: "insights.down", Scope: ScopeInsights, Keys: []string{"j", "down"}, Section: "Insights", Desc: "Navigate items", HelpRow: "items"},
+	{ID: "insights.up", Scope: ScopeInsights, Keys: []string{"k", "up"}, Section: "Insights", Desc: "Navigate items", HelpRow: "items"},
+	{ID: "insights.explain", Scope: ScopeInsights, Keys: []string{"e"}, Section: "Insights", Desc: "Explanations"},
+	{ID: "insights.calc", Scope: ScopeInsights, Keys: []string{"x"}, Section: "Insights", Desc: "Calc details"},
+	{ID: "insights.heatmap", Scope: ScopeInsights, Keys: []string{"m"}, Section: "Insights", Desc: "Toggle heatmap"},
+	{ID: "insights.open", Scope: ScopeInsights, Keys: []string{"enter"}, Section: "Insights", Desc: "Jump to issue"},
+
+	// History
+	{ID: "history.down", Scope: ScopeHistory, Keys: []string{"j", "down"}, Section: "History", Desc: "Navigate beads", HelpRow: "beads"},
+	{ID: "history.up", Scope: ScopeHistory, Keys: []string{"k", "up"}, Section: "History", Desc: "Navigate beads", HelpRow: "beads"},
+	{ID: "history.next_commit", Scope: ScopeHistory, Keys: []string{"J"}, Section: "History", Desc: "Navigate commits", HelpRow: "commits"},
+	{ID: "history.prev_commit", Scope: ScopeHistory, Keys: []string{"K"}, Section: "History", Desc: "Navigate commits", HelpRow: "commits"},
+	{ID: "history.focus", Scope: ScopeHistory, Keys: []string{"tab"}, Section: "History", Desc: "Toggle focus"},
+	{ID: "history.copy_sha", Scope: ScopeHistory, Keys: []string{"y"}, Section: "History", Desc: "Copy SHA"},
+	{ID: "history.edit_files", Scope: ScopeHistory, Keys: []string{"o"}, Section: "History", Desc: "Open changed files in editor"},
+	{ID: "history.open_web", Scope: ScopeHistory, Keys: []string{"O"}, Section: "History", Desc: "Open commit in browser"},
+	{ID: "history.confidence", Scope: ScopeHistory, Keys: []string{"c"}, Section: "History", Desc: "Confidence filter"},
+
+	// Actions
+	{ID: "priority_hints", Scope: ScopeGlobal, Keys: []string{"p"}, Section: "Actions", Desc: "Priority hints"},
+	{ID: "time_travel", Scope: ScopeList, Keys: []string{"t"}, Section: "Actions", Desc: "Time-travel"},
+	{ID: "time_travel.quick", Scope: ScopeList, Keys: []string{"T"}, Section: "Actions", Desc: "Quick time-travel"},
+	{ID: "time_travel.playback", Scope: ScopeList, Keys: []string{"R"}, Section: "Actions", Desc: "Play back weekly history"},
+	{ID: "export.markdown", Scope: ScopeGlobal, Keys: []string{"x"}, Section: "Actions", Desc: "Export markdown"},
+	{ID: "copy", Scope: ScopeList, Keys: []string{"C"}, Section: "Actions", Desc: "Copy to clipboard"},
+	{ID: "open_editor", Scope: ScopeList, Keys: []string{"O"}, Section: "Actions", Desc: "Open in editor"},
+	{ID: "comment.add", Scope: ScopeList, Keys: []string{"M"}, Section: "Actions", Desc: "Add comment"},
+	{ID: "labels.apply", Scope: ScopeList, Keys: []string{"L"}, Section: "Actions", Desc: "Apply suggested labels"},
+	{ID: "time.toggle", Scope: ScopeList, Keys: []string{"W"}, Section: "Actions", Desc: "Start/stop work timer"},
+	{ID: "detail.raw", Scope: ScopeList, Keys: []string{"v"}, Section: "Actions", Desc: "Raw/rendered markdown"},
+	{ID: "detail.explain", Scope: ScopeList, Keys: []string{"E"}, Section: "Actions", Desc: "Explain ranking"},
+	{ID: "detail.tree", Scope: ScopeList, Keys: []string{"D"}, Section: "Actions", Desc: "Dependency tree"},
+	{ID: "attachment.open", Scope: ScopeList, Keys: []string{"A"}, Section: "Actions", Desc: "Open attachment"},
+	{ID: "time.focus", Scope: ScopeList, Keys: []string{"P"}, Section: "Actions", Desc: "Focus timer on claimed issue"},
+	{ID: "edit.undo", Scope: ScopeGlobal, Keys: []string{"u"}, Section: "Actions", Desc: "Undo last edit"},
+	{ID: "edit.redo", Scope: ScopeGlobal, Keys: []string{"ctrl+r"}, Section: "Actions", Desc: "Redo edit"},
+}
+
+// KeyActions returns the catalog of remappable actions.
+func KeyActions() []KeyAction {
+	out := make([]KeyAction, len(defaultKeyActions))
+	copy(out, defaultKeyActions)
+	return out
+}
+
+// KeymapFile is the structure of keymap.yaml. Each entry maps an action ID
+// to one key or a list of keys, replacing that action's defaults:
+//
+//	keys:
+//	  view.board: B
+//	  nav.down: [j, down, n]
+type KeymapFile struct {
+	Keys map[string]keyList `yaml:"keys"`
+}
+
+// keyList accepts either a single key or a list of keys.
+type keyList []string
+
+func (k *keyList) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		*k = keyList{node.Value}
+		return nil
+	}
+	var keys []string
+	if err := node.Decode(&keys); err != nil {
+		return err
+	}
+	*k = keys
+	return nil
+}
+
+// KeyConflict is a key bound to two actions that can be active at once.
+type KeyConflict struct {
+	Key     string
+	Scope   KeyScope
+	Actions [2]string
+}
+
+func (c KeyConflict) String() string {
+	return fmt.Sprintf("%q is bound to both %s and %s (%s)", c.Key, c.Actions[0], c.Actions[1], c.Scope)
+}
+
+// Keymap is the active key configuration: the defaults plus any overrides
+// from the user and project keymap files.
+type Keymap struct {
+	keys      map[string][]string // action ID -> effective keys
+	sources   map[string]string   // action ID -> "user" / "project" for overridden actions
+	bound     map[KeyScope]map[string]int
+	unbound   map[KeyScope]map[string]bool // default keys freed by a remap
+	Conflicts []KeyConflict
+	Warnings  []string
+}
+
+// DefaultKeymap returns the built-in key bindings.
+func DefaultKeymap() *Keymap {
+	km := &Keymap{keys: make(map[string][]string), sources: make(map[string]string)}
+	for _, a := range defaultKeyActions {
+		km.keys[a.ID] = a.Keys
+	}
+	km.rebuild()
+	return km
+}
+
+// DefaultKeymapUserPath returns keymap.yaml in the user config directory
+// (~/.config/bv, %AppData%\bv on Windows).
+func DefaultKeymapUserPath() string {
+	return configdir.Path("keymap.yaml")
+}
+
+// LoadKeymap merges the built-in bindings with the user keymap (userPath)
+// and the project keymap (<projectDir>/.bv/keymap.yaml), project last.
+// Missing files are fine; unreadable or invalid files are returned as errors
+// alongside a usable keymap.
+func LoadKeymap(userPath, projectDir string) (*Keymap, error) {
+	km := DefaultKeymap()
+	var errs []string
+	if userPath != "" {
+		if err := km.loadFile(userPath, "user"); err != nil && !os.IsNotExist(err) {
+			errs = append(errs, fmt.Sprintf("user keymap: %v", err))
+		}
+	}
+	if projectDir != "" {
+		if err := km.loadFile(filepath.Join(projectDir, ".bv", "keymap.yaml"), "project"); err != nil && !os.IsNotExist(err) {
+			errs = append(errs, fmt.Sprintf("project keymap: %v", err))
+		}
+	}
+	km.resolveConflicts()
+	if len(errs) > 0 {
+		return km, fmt.Errorf("%s", strings.Join(errs, "; "))
+	}
+	return km, nil
+}
+
+func (km *Keymap) loadFile(path, source string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	var file KeymapFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return fmt.Errorf("parsing %s: %w", path, err)
+	}
+	return km.apply(file, source)
+}
+
+// apply overrides bindings from a parsed keymap file. Unknown actions and
+// key names are reported as warnings and skipped.
+func (km *Keymap) apply(file KeymapFile, source string) error {
+	ids := make([]string, 0, len(file.Keys))
+	for id := range file.Keys {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	for _, id := range ids {
+		if _, ok := km.keys[id]; !ok {
+			km.Warnings = append(km.Warnings, fmt.Sprintf("%s keymap: unknown action %q", source, id))
+			continue
+		}
+		var keys []string
+		for _, k := range file.Keys[id] {
+			k = normalizeKeyName(k)
+			if !validKeyName(k) {
+				km.Warnings = append(km.Warnings, fmt.Sprintf("%s keymap: %s: invalid key %q", source, id, k))
+				continue
+			}
+			keys = append(keys, k)
+		}
+		if len(keys) == 0 && len(file.Keys[id]) > 0 {
+			continue
+		}
+		km.keys[id] = keys
+		km.sources[id] = source
+	}
+	km.rebuild()
+	return nil
+}
+
+// resolveConflicts reverts overridden actions that clash with another
+// action in the same scope, recording each conflict. A binding in a view
+// scope that shadows a global action is allowed but noted as a warning.
+func (km *Keymap) resolveConflicts() {
+	for {
+		conflicts := km.findConflicts()
+		reverted := false
+		for _, c := range conflicts {
+			km.Conflicts = append(km.Conflicts, c)
+			// Revert one side; when both were remapped, the later action's
+			for i := len(c.Actions) - 1; i >= 0; i-- {
+				if _, ok := km.sources[c.Actions[i]]; ok {
+					km.keys[c.Actions[i]] = defaultKeysFor(c.Actions[i])
+					delete(km.sources, c.Actions[i])
+					reverted = true
+					break
+				}
+			}
+		}
+		km.rebuild()
+		if !reverted {
+			break
+		}
+	}
+
+	for _, a := range defaultKeyActions {
+		if a.Scope == ScopeGlobal {
+			continue
+		}
+		for _, k := range km.keys[a.ID] {
+			idx, ok := km.bound[ScopeGlobal][k]
+			if !ok {
+				continue
+			}
+			global := defaultKeyActions[idx]
+			_, scopedOverride := km.sources[a.ID]
+			_, globalOverride := km.sources[global.ID]
+			if scopedOverride || globalOverride {
+				km.Warnings = append(km.Warnings, fmt.Sprintf("%q runs %s in the %s view, shadowing %s", k, a.ID, a.Scope, global.ID))
+			}
+		}
+	}
+}
+
+// findConflicts returns same-scope clashes that involve an override.
+func (km *Keymap) findConflicts() []KeyConflict {
+	var conflicts []KeyConflict
+	seen := make(map[KeyScope]map[string]string)
+	for _, a := range defaultKeyActions {
+		if seen[a.Scope] == nil {
+			seen[a.Scope] = make(map[string]string)
+		}
+		for _, k := range km.keys[a.ID] {
+			other, ok := seen[a.Scope][k]
+			if !ok {
+				seen[a.Scope][k] = a.ID
+				continue
+			}
+			if other == a.ID {
+				continue
+			}
+			_, o1 := km.sources[other]
+			_, o2 := km.sources[a.ID]
+			if o1 || o2 {
+				conflicts = append(conflicts, KeyConflict{Key: k, Scope: a.Scope, Actions: [2]string{other, a.ID}})
+			}
+		}
+	}
+	return conflicts
+}
+
+// rebuild recomputes the key lookup tables from the effective bindings.
+func (km *Keymap) rebuild() {
+	km.bound = make(map[KeyScope]map[string]int)
+	km.unbound = make(map[KeyScope]map[string]bool)
+	for i, a := range defaultKeyActions {
+		if km.bound[a.Scope] == nil {
+			km.bound[a.Scope] = make(map[string]int)
+			km.unbound[a.Scope] = make(map[string]bool)
+		}
+		for _, k := range km.keys[a.ID] {
+			if _, dup := km.bound[a.Scope][k]; !dup {
+				km.bound[a.Scope][k] = i
+			}
+		}
+	}
+	for _, a := range defaultKeyActions {
+		for _, k := range a.Keys {
+			if _, ok := km.bound[a.Scope][k]; !ok {
+				km.unbound[a.Scope][k] = true
+			}
+		}
+	}
+}
+
+// IsCustomized reports whether any binding differs from the defaults.
+func (km *Keymap) IsCustomized() bool {
+	return km != nil && len(km.sources) > 0
+}
+
+// Keys returns the effective keys for an action.
+func (km *Keymap) Keys(id string) []string {
+	if km == nil {
+		return defaultKeysFor(id)
+	}
+	return km.keys[id]
+}
+
+// Source returns where an action's binding came from: "default", "user"
+// or "project".
+func (km *Keymap) Source(id string) string {
+	if km != nil {
+		if s, ok := km.sources[id]; ok {
+			return s
+		}
+	}
+	return "default"
+}
+
+// Translate maps a pressed key to the key the handlers expect in scope.
+// Keys that a remap freed are swallowed (ok=false); keys the keymap doesn't
+// know about pass through unchanged.
+func (km *Keymap) Translate(scope KeyScope, key string) (string, bool) {
+	if !km.IsCustomized() {
+		return key, true
+	}
+	scopes := []KeyScope{ScopeGlobal}
+	if scope != ScopeGlobal && scope != "" {
+		scopes = []KeyScope{scope, ScopeGlobal}
+	}
+	for _, s := range scopes {
+		if idx, ok := km.bound[s][key]; ok {
+			return defaultKeyActions[idx].Keys[0], true
+		}
+	}
+	if key == "ctrl+c" {
+		return key, true // Quitting always works
+	}
+	for _, s := range scopes {
+		if km.unbound[s][key] {
+			return "", false
+		}
+	}
+	return key, true
+}
+
+// TranslateKeyMsg applies Translate to a key message.
+func (km *Keymap) TranslateKeyMsg(scope KeyScope, msg tea.KeyMsg) (tea.KeyMsg, bool) {
+	if !km.IsCustomized() || msg.Paste {
+		return msg, true
+	}
+	key := msg.String()
+	translated, ok := km.Translate(scope, key)
+	if !ok {
+		return msg, false
+	}
+	if translated == key {
+		return msg, true
+	}
+	return keyMsgFromString(translated), true
+}
+
+// KeyScopeForContext maps a UI context to the keymap scope active in it.
+// Text-entry contexts and modal overlays return "" (no translation).
+func KeyScopeForContext(ctx Context) KeyScope {
+	switch ctx {
+	case ContextList, ContextSplit, ContextDetail, ContextTimeTravel:
+		return ScopeList
+	case ContextBoard:
+		return ScopeBoard
+	case ContextGraph:
+		return ScopeGraph
+	case ContextInsights:
+		return ScopeInsights
+	case ContextHistory:
+		return ScopeHistory
+	case ContextActionable, ContextSprint, ContextFlowMatrix, ContextLabelDashboard, ContextAttention, ContextHelp:
+		return ScopeGlobal
+	}
+	return ""
+}
+
+// HelpEntry is one line of the generated help overlay.
+type HelpEntry struct {
+	Key  string
+	Desc string
+}
+
+// HelpSection is a titled group of help entries.
+type HelpSection struct {
+	ID      string // Untranslated title
+	Title   string
+	Entries []HelpEntry
+}
+
+// HelpSections renders the active bindings as help overlay sections, in
+// catalog order and the active locale. Actions sharing a HelpRow collapse
+// into one line.
+func (km *Keymap) HelpSections() []HelpSection {
+	var sections []HelpSection
+	index := make(map[string]int)
+	type rowRef struct{ section, entry int }
+	rows := make(map[string]rowRef)
+	rowKeys := make(map[rowRef][]string)
+
+	for _, a := range defaultKeyActions {
+		si, ok := index[a.Section]
+		if !ok {
+			si = len(sections)
+			index[a.Section] = si
+			sections = append(sections, HelpSection{ID: a.Section, Title: i18n.T(a.Section)})
+		}
+		keys := km.Keys(a.ID)
+		if len(keys) == 0 {
+			continue
+		}
+		if a.HelpRow != "" {
+			id := a.Section + "/" + a.HelpRow
+			ref, ok := rows[id]
+			if !ok {
+				ref = rowRef{si, len(sections[si].Entries)}
+				rows[id] = ref
+				sections[si].Entries = append(sections[si].Entries, HelpEntry{Desc: i18n.T(a.Desc)})
+			}
+			rowKeys[ref] = append(rowKeys[ref], DisplayKey(keys[0]))
+			continue
+		}
+		shown := keys
+		if len(shown) > 2 {
+			shown = shown[:2]
+		}
+		labels := make([]string, len(shown))
+		for i, k := range shown {
+			labels[i] = DisplayKey(k)
+		}
+		sections[si].Entries = append(sections[si].Entries, HelpEntry{Key: strings.Join(labels, "/"), Desc: i18n.T(a.Desc)})
+	}
+
+	// Single characters run together ("hjkl"); anything longer gets "/"
+	for ref, keys := range rowKeys {
+		sep := ""
+		for _, k := range keys {
+			if utf8.RuneCountInString(k) != 1 {
+				sep = "/"
+			}
+		}
+		sections[ref.section].Entries[ref.entry].Key = strings.Join(keys, sep)
+	}
+	return sections
+}
+
+// DisplayKey formats a key name for help text.
+func DisplayKey(key string) string {
+	switch key {
+	case "up":
+		return "↑"
+	case "down":
+		return "↓"
+	case "left":
+		return "←"
+	case "right":
+		return "→"
+	case "pgup":
+		return "PgUp"
+	case "pgdown":
+		return "PgDn"
+	case " ":
+		return "Space"
+	}
+	for _, mod := range []string{"ctrl+", "alt+", "shift+"} {
+		if rest, ok := strings.CutPrefix(key, mod); ok {
+			return strings.ToUpper(mod[:1]) + mod[1:] + rest
+		}
+	}
+	if utf8.RuneCountInString(key) > 1 {
+		return strings.ToUpper(key[:1]) + key[1:]
+	}
+	return key
+}
+
+// namedKeys maps bubbletea key names ("enter", "ctrl+d", "f1") to types.
+var namedKeys = func() map[string]tea.KeyType {
+	names := make(map[string]tea.KeyType)
+	for k := tea.KeyType(-256); k < 256; k++ {
+		if s := k.String(); s != "" && k != tea.KeyRunes {
+			names[s] = k
+		}
+	}
+	return names
+}()
+
+// normalizeKeyName accepts a few common spellings for key names.
+func normalizeKeyName(key string) string {
+	key = strings.TrimSpace(key)
+	if utf8.RuneCountInString(key) == 1 {
+		return key
+	}
+	lower := strings.ToLower(key)
+	switch lower {
+	case "return":
+		return "enter"
+	case "escape":
+		return "esc"
+	case "space", "spacebar":
+		return " "
+	case "pageup":
+		return "pgup"
+	case "pagedown":
+		return "pgdown"
+	}
+	// Keep the case of the final character in "alt+H"; ctrl chords are
+	// case-insensitive in terminals
+	if i := strings.LastIndex(lower, "+"); i >= 0 && strings.HasPrefix(lower, "alt+") &&
+		!strings.HasPrefix(lower, "alt+ctrl+") && utf8.RuneCountInString(key[i+1:]) == 1 {
+		return lower[:i+1] + key[i+1:]
+	}
+	return lower
+}
+
+// validKeyName reports whether bubbletea can produce key as a KeyMsg string.
+func validKeyName(key string) bool {
+	if key == "" {
+		return false
+	}
+	if _, ok := namedKeys[key]; ok {
+		return true
+	}
+	if rest, ok := strings.CutPrefix(key, "alt+"); ok {
+		return validKeyName(rest)
+	}
+	return utf8.RuneCountInString(key) == 1
+}
+
+// keyMsgFromString builds the KeyMsg whose String() is key.
+func keyMsgFromString(key string) tea.KeyMsg {
+	if rest, ok := strings.CutPrefix(key, "alt+"); ok && rest != "" {
+		msg := keyMsgFromString(rest)
+		msg.Alt = true
+		return msg
+	}
+	if t, ok := namedKeys[key]; ok {
+		return tea.KeyMsg{Type: t}
+	}
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}
+}
+
+func defaultKeysFor(id string) []string {
+	for _, a := range defaultKeyActions {
+		if a.ID == id {
+			return a.Keys
+		}
+	}
+	return nil
+}
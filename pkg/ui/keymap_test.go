@@ -0,0 +1,307 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/i18n"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func writeKeymapFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDefaultKeymap_NoConflictsAndValidKeys(t *testing.T) {
+	seen := make(map[KeyScope]map[string]string)
+	ids := make(map[string]bool)
+	for _, a := range KeyActions() {
+		if ids[a.ID] {
+			t.Errorf("duplicate action ID %q", a.ID)
+		}
+		ids[a.ID] = true
+		if seen[a.Scope] == nil {
+			seen[a.Scope] = make(map[string]string)
+		}
+		for _, k := range a.Keys {
+			if other, ok := seen[a.Scope][k]; ok {
+				t.Errorf("%q bound to %s and %s in %s", k, other, a.ID, a.Scope)
+			}
+			seen[a.Scope][k] = a.ID
+			if !validKeyName(k) {
+				t.Errorf("%s: invalid default key %q", a.ID, k)
+			}
+			if got := keyMsgFromString(k).String(); got != k {
+				t.Errorf("keyMsgFromString(%q).String() = %q", k, got)
+			}
+		}
+	}
+
+	km := DefaultKeymap()
+	if km.IsCustomized() || len(km.Conflicts) > 0 || len(km.Warnings) > 0 {
+		t.Errorf("default keymap should be clean: %+v %+v", km.Conflicts, km.Warnings)
+	}
+	if got, ok := km.Translate(ScopeList, "j"); !ok || got != "j" {
+		t.Errorf("default Translate(j) = %q, %v", got, ok)
+	}
+}
+
+func TestLoadKeymap_UserThenProject(t *testing.T) {
+	dir := t.TempDir()
+	userPath := filepath.Join(dir, "user", "keymap.yaml")
+	projectDir := filepath.Join(dir, "project")
+
+	writeKeymapFile(t, userPath, "keys:\n  view.board: z\n  nav.down: [j, n]\n")
+	writeKeymapFile(t, filepath.Join(projectDir, ".bv", "keymap.yaml"), "keys:\n  view.board: Z\n")
+
+	km, err := LoadKeymap(userPath, projectDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := km.Keys("view.board"); !slices.Equal(got, []string{"Z"}) || km.Source("view.board") != "project" {
+		t.Errorf("view.board = %v from %s, want [Z] from project", got, km.Source("view.board"))
+	}
+	if got := km.Keys("nav.down"); !slices.Equal(got, []string{"j", "n"}) || km.Source("nav.down") != "user" {
+		t.Errorf("nav.down = %v from %s", got, km.Source("nav.down"))
+	}
+	if km.Source("view.graph") != "default" {
+		t.Errorf("untouched action source = %s", km.Source("view.graph"))
+	}
+
+	// Missing files are not an error
+	if _, err := LoadKeymap(filepath.Join(dir, "missing.yaml"), t.TempDir()); err != nil {
+		t.Errorf("missing files: %v", err)
+	}
+
+	writeKeymapFile(t, userPath, "keys: [not, a, map]\n")
+	if km, err := LoadKeymap(userPath, ""); err == nil || km == nil {
+		t.Errorf("expected parse error with a usable keymap, got %v, %v", km, err)
+	}
+}
+
+func TestKeymap_TranslateAndSwallow(t *testing.T) {
+	km := DefaultKeymap()
+	km.apply(KeymapFile{Keys: map[string]keyList{
+		"view.history": {"y"},
+		"nav.down":     {"n", "down"},
+		"nav.up":       {"e", "up"},
+	}}, "user")
+	km.resolveConflicts()
+
+	tests := []struct {
+		scope KeyScope
+		key   string
+		want  string
+		ok    bool
+	}{
+		{ScopeList, "y", "h", true},    // remapped global action
+		{ScopeList, "h", "", false},    // freed default key is swallowed
+		{ScopeGraph, "h", "h", true},   // still graph.left in the graph view
+		{ScopeList, "n", "j", true},    // list navigation
+		{ScopeList, "j", "", false},    // freed
+		{ScopeBoard, "j", "j", true},   // board keeps its own j
+		{ScopeList, "down", "j", true}, // canonical key for the action
+		{ScopeList, "e", "k", true},    // second remap
+		{ScopeList, "ctrl+c", "ctrl+c", true},
+		{ScopeList, "V", "V", true}, // not in the catalog: passes through
+	}
+	for _, tt := range tests {
+		got, ok := km.Translate(tt.scope, tt.key)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("Translate(%s, %q) = %q, %v; want %q, %v", tt.scope, tt.key, got, ok, tt.want, tt.ok)
+		}
+	}
+
+	msg, ok := km.TranslateKeyMsg(ScopeList, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	if !ok || msg.String() != "h" {
+		t.Errorf("TranslateKeyMsg(y) = %q, %v", msg.String(), ok)
+	}
+	pasted := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y"), Paste: true}
+	if msg, ok := km.TranslateKeyMsg(ScopeList, pasted); !ok || msg.String() != pasted.String() {
+		t.Error("pasted text should never be translated")
+	}
+}
+
+func TestKeymap_SwapKeys(t *testing.T) {
+	km := DefaultKeymap()
+	km.apply(KeymapFile{Keys: map[string]keyList{
+		"nav.down": {"k"},
+		"nav.up":   {"j"},
+	}}, "user")
+	km.resolveConflicts()
+
+	if len(km.Conflicts) > 0 {
+		t.Fatalf("swapping two actions should not conflict: %v", km.Conflicts)
+	}
+	if got, _ := km.Translate(ScopeList, "k"); got != "j" {
+		t.Errorf("k -> %q, want j", got)
+	}
+	if got, _ := km.Translate(ScopeList, "j"); got != "k" {
+		t.Errorf("j -> %q, want k", got)
+	}
+}
+
+func TestKeymap_ConflictsRevertOverride(t *testing.T) {
+	km := DefaultKeymap()
+	km.apply(KeymapFile{Keys: map[string]keyList{
+		"view.board": {"g"}, // clashes with view.graph
+	}}, "project")
+	km.resolveConflicts()
+
+	if len(km.Conflicts) != 1 {
+		t.Fatalf("conflicts = %v", km.Conflicts)
+	}
+	c := km.Conflicts[0]
+	if c.Key != "g" || c.Scope != ScopeGlobal || !strings.Contains(c.String(), "view.board") {
+		t.Errorf("unexpected conflict %v", c)
+	}
+	if got := km.Keys("view.board"); !slices.Equal(got, []string{"b"}) {
+		t.Errorf("conflicting override should revert to default, got %v", got)
+	}
+	if got, _ := km.Translate(ScopeList, "g"); got != "g" {
+		t.Errorf("g should still open the graph, got %q", got)
+	}
+}
+
+func TestKeymap_Warnings(t *testing.T) {
+	km := DefaultKeymap()
+	km.apply(KeymapFile{Keys: map[string]keyList{
+		"no.such.action": {"x"},
+		"view.graph":     {"ctrl+zz"},
+		"view.board":     {"B"}, // shadowed by graph.cycle_break in the graph view
+	}}, "user")
+	km.resolveConflicts()
+
+	joined := strings.Join(km.Warnings, "\n")
+	for _, want := range []string{`unknown action "no.such.action"`, `invalid key "ctrl+zz"`, "shadowing view.board"} {
+		if !strings.Contains(joined, want) {
+			t.Errorf("warnings missing %q:\n%s", want, joined)
+		}
+	}
+	if got := km.Keys("view.graph"); !slices.Equal(got, []string{"g"}) {
+		t.Errorf("invalid override should leave defaults, got %v", got)
+	}
+}
+
+func TestNormalizeKeyName(t *testing.T) {
+	for in, want := range map[string]string{
+		"Enter":  "enter",
+		"Escape": "esc",
+		"Ctrl+D": "ctrl+d",
+		"alt+H":  "alt+H",
+		"space":  " ",
+		"G":      "G",
+		"PageUp": "pgup",
+	} {
+		if got := normalizeKeyName(in); got != want {
+			t.Errorf("normalizeKeyName(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestKeymap_HelpSectionsReflectRemap(t *testing.T) {
+	km := DefaultKeymap()
+	km.apply(KeymapFile{Keys: map[string]keyList{"view.board": {"z"}}}, "user")
+	km.resolveConflicts()
+
+	var views, graph *HelpSection
+	sections := km.HelpSections()
+	for i := range sections {
+		switch sections[i].Title {
+		case "Views":
+			views = &sections[i]
+		case "Graph View":
+			graph = &sections[i]
+		}
+	}
+	if views == nil || graph == nil {
+		t.Fatalf("missing sections in %+v", sections)
+	}
+	if views.Entries[0] != (HelpEntry{Key: "z", Desc: "Kanban board"}) {
+		t.Errorf("board entry = %+v", views.Entries[0])
+	}
+	if graph.Entries[0] != (HelpEntry{Key: "hjkl", Desc: "Navigate nodes"}) {
+		t.Errorf("graph navigation row = %+v", graph.Entries[0])
+	}
+	if graph.Entries[2].Key != "PgUp/PgDn" {
+		t.Errorf("graph scroll row = %+v", graph.Entries[2])
+	}
+}
+
+func TestKeymap_HelpSectionsLocalized(t *testing.T) {
+	if err := i18n.SetLang("de"); err != nil {
+		t.Fatal(err)
+	}
+	defer i18n.SetLang(i18n.English)
+
+	for _, s := range DefaultKeymap().HelpSections() {
+		if s.ID != "Views" {
+			continue
+		}
+		if s.Title != "Ansichten" || s.Entries[0].Desc != "Kanban-Board" {
+			t.Errorf("views section = %+v", s)
+		}
+		return
+	}
+	t.Fatal("no Views section")
+}
+
+func TestModel_CustomKeymap(t *testing.T) {
+	issues := []model.Issue{{ID: "1", Title: "One", Status: model.StatusOpen}}
+	m := NewModel(issues, nil, "")
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 140, Height: 40})
+	m = updated.(Model)
+
+	km := DefaultKeymap()
+	km.apply(KeymapFile{Keys: map[string]keyList{"view.board": {"Q"}}}, "user")
+	km.resolveConflicts()
+	m.SetKeymap(km)
+
+	press := func(key string) {
+		updated, _ := m.Update(keyMsgFromString(key))
+		m = updated.(Model)
+	}
+
+	press("b")
+	if m.isBoardView {
+		t.Fatal("b should no longer open the board")
+	}
+	press("Q")
+	if !m.isBoardView {
+		t.Fatal("Q should open the board")
+	}
+	press("Q")
+	if m.isBoardView {
+		t.Fatal("Q should toggle the board off")
+	}
+
+	press("?")
+	if !m.showHelp {
+		t.Fatal("expected help overlay")
+	}
+	if out := m.renderHelpOverlay(); !strings.Contains(out, "custom keymap") {
+		t.Error("help overlay should note the custom keymap")
+	}
+}
+
+func TestModel_SetKeymapReportsConflicts(t *testing.T) {
+	m := NewModel(nil, nil, "")
+	km := DefaultKeymap()
+	km.apply(KeymapFile{Keys: map[string]keyList{"view.board": {"g"}, "bogus": {"x"}}}, "user")
+	km.resolveConflicts()
+	m.SetKeymap(km)
+
+	if !m.statusIsError || !strings.Contains(m.statusMsg, "view.board") || !strings.Contains(m.statusMsg, "+1 more") {
+		t.Errorf("status = %q (error=%v)", m.statusMsg, m.statusIsError)
+	}
+}
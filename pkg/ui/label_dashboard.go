@@ -4,15 +4,48 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 )
 
+// LabelTrendsReadyMsg is sent when the label dashboard's trend history has
+// been computed in the background
+type LabelTrendsReadyMsg struct {
+	Stats  *analysis.GraphStats // The stats the trends were computed for, to detect stale messages
+	Trends map[string]*analysis.LabelTrend
+}
+
+// LabelTrendsCmd computes 30-day closed and open counts per label. Past open
+// counts come from the beads file as committed at the end of each day when
+// workDir is a git repository, and are rebuilt from issue timestamps
+// otherwise.
+func LabelTrendsCmd(issues []model.Issue, workDir string, stats *analysis.GraphStats) tea.Cmd {
+	return func() tea.Msg {
+		now := time.Now()
+		since := now.AddDate(0, 0, -analysis.DefaultLabelTrendDays)
+		trends := analysis.ComputeLabelTrends(issues, analysis.DefaultLabelTrendDays, now, gitLabelSnapshots(workDir, since))
+		return LabelTrendsReadyMsg{Stats: stats, Trends: trends}
+	}
+}
+
+// gitLabelSnapshots looks up the issues as last committed before a time,
+// from since on. It returns nil when there is no git history to read.
+func gitLabelSnapshots(workDir string, since time.Time) analysis.LabelSnapshotFunc {
+	if workDir == "" {
+		return nil
+	}
+	return loader.NewGitLoader(workDir).SnapshotsSince(since)
+}
+
 // LabelDashboardModel renders a lightweight table of label health
 type LabelDashboardModel struct {
 	labels       []analysis.LabelHealth
+	trends       map[string]*analysis.LabelTrend // 30-day history; nil until loaded
 	cursor       int
 	scrollOffset int // Index of the first visible row
 	width        int
@@ -29,6 +62,11 @@ func (m *LabelDashboardModel) SetSize(width, height int) {
 	m.height = height
 }
 
+// SetTrends sets the per-label history shown in the trend columns
+func (m *LabelDashboardModel) SetTrends(trends map[string]*analysis.LabelTrend) {
+	m.trends = trends
+}
+
 func (m *LabelDashboardModel) SetData(labels []analysis.LabelHealth) {
 	m.labels = labels
 	// Sort by health level (critical first), then blocked desc, then health asc, then name
@@ -114,7 +152,7 @@ func (m LabelDashboardModel) View() string {
 		return "No labels found"
 	}
 
-	headers := []string{"Label", "Health", "Blocked", "Velocity 7d/30d", "Stale"}
+	headers := []string{"Label", "Health", "Blocked", "Velocity 7d/30d", "Stale", "Closed 30d", "Open 30d"}
 	widths := m.computeColumnWidths(headers)
 
 	var b strings.Builder
@@ -155,7 +193,79 @@ func (m LabelDashboardModel) getRowCells(lh analysis.LabelHealth) []string {
 		m.renderBlockedCell(lh),
 		fmt.Sprintf("%d/%d", lh.Velocity.ClosedLast7Days, lh.Velocity.ClosedLast30Days),
 		fmt.Sprintf("%d", lh.Freshness.StaleCount),
+		m.renderTrendCell(lh.Label, true),
+		m.renderTrendCell(lh.Label, false),
+	}
+}
+
+// trendWidth is the number of sparkline cells for the terminal width; each
+// covers one or more days of the 30-day window
+func (m LabelDashboardModel) trendWidth() int {
+	switch {
+	case m.width == 0 || m.width >= 140:
+		return analysis.DefaultLabelTrendDays
+	case m.width >= 100:
+		return analysis.DefaultLabelTrendDays / 2
+	default:
+		return analysis.DefaultLabelTrendDays / 3
+	}
+}
+
+// renderTrendCell draws a label's daily closed (velocity) or open counts as
+// a sparkline, scaled to the label's own peak
+func (m LabelDashboardModel) renderTrendCell(label string, closed bool) string {
+	if m.trends == nil {
+		return "…"
+	}
+	trend := m.trends[label]
+	if trend == nil {
+		return strings.Repeat(" ", m.trendWidth())
+	}
+	values := bucketTrend(trend.Open, m.trendWidth(), false)
+	style := m.theme.Base.Foreground(m.theme.Feature)
+	if closed {
+		values = bucketTrend(trend.Closed, m.trendWidth(), true)
+		style = m.theme.Base.Foreground(m.theme.Open)
+	}
+	peak := 0
+	for _, v := range values {
+		if v > peak {
+			peak = v
+		}
+	}
+	if peak == 0 {
+		return m.theme.Base.Foreground(m.theme.Secondary).Render(strings.Repeat("▁", len(values)))
+	}
+	return style.Render(buildSparkline(values, peak))
+}
+
+// bucketTrend folds daily values into n buckets, oldest first: closures are
+// summed, open counts take the bucket's last day
+func bucketTrend(days []int, n int, sum bool) []int {
+	if n <= 0 || len(days) <= n {
+		return days
+	}
+	size := (len(days) + n - 1) / n
+	out := make([]int, 0, n)
+	// Align buckets to the newest day so the last cell is always current
+	for end := len(days); end > 0; end -= size {
+		start := end - size
+		if start < 0 {
+			start = 0
+		}
+		v := days[end-1]
+		if sum {
+			v = 0
+			for _, d := range days[start:end] {
+				v += d
+			}
+		}
+		out = append(out, v)
+	}
+	for i, j := 0, len(out)-1; i < j; i, j = i+1, j-1 {
+		out[i], out[j] = out[j], out[i]
 	}
+	return out
 }
 
 func (m LabelDashboardModel) computeColumnWidths(headers []string) []int {
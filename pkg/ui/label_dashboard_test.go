@@ -2,6 +2,7 @@ package ui
 
 import (
 	"os"
+	"reflect"
 	"testing"
 
 	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
@@ -594,3 +595,39 @@ func containsHelper(s, substr string) bool {
 	}
 	return false
 }
+
+func TestLabelDashboardModel_TrendColumns(t *testing.T) {
+	m := NewLabelDashboardModel(Theme{})
+	m.SetSize(160, 10)
+	m.SetData([]analysis.LabelHealth{{Label: "api", HealthLevel: analysis.HealthLevelHealthy, Health: 80}})
+
+	if view := m.View(); !contains(view, "Closed 30d") || !contains(view, "…") {
+		t.Errorf("trend columns should show a placeholder until loaded:\n%s", view)
+	}
+
+	closed := make([]int, analysis.DefaultLabelTrendDays)
+	open := make([]int, analysis.DefaultLabelTrendDays)
+	for i := range open {
+		open[i] = i + 1
+	}
+	closed[len(closed)-1] = 4
+	m.SetTrends(map[string]*analysis.LabelTrend{"api": {Label: "api", Closed: closed, Open: open}})
+
+	view := m.View()
+	if contains(view, "…") || !contains(view, "█") {
+		t.Errorf("trend sparklines missing:\n%s", view)
+	}
+}
+
+func TestBucketTrend(t *testing.T) {
+	days := []int{1, 2, 3, 4, 5, 6, 7}
+	if got := bucketTrend(days, 3, true); !reflect.DeepEqual(got, []int{1, 9, 18}) {
+		t.Errorf("summed buckets = %v", got)
+	}
+	if got := bucketTrend(days, 3, false); !reflect.DeepEqual(got, []int{1, 4, 7}) {
+		t.Errorf("open buckets should take their newest day, got %v", got)
+	}
+	if got := bucketTrend(days, 10, true); !reflect.DeepEqual(got, days) {
+		t.Errorf("no bucketing needed, got %v", got)
+	}
+}
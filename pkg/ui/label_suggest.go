@@ -0,0 +1,101 @@
+package ui
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// LabelSuggestionCommand is the bd command equivalent of applying labels
+// from the TUI, for syncing the change or applying it by hand.
+func LabelSuggestionCommand(issueID string, labels []string) string {
+	var sb strings.Builder
+	sb.WriteString("bd update " + issueID)
+	for _, l := range labels {
+		sb.WriteString(" --add-label=" + l)
+	}
+	return sb.String()
+}
+
+// suggestedLabels predicts labels for an open, unlabeled issue from the
+// classifier trained on the labeled ones. Returns nil for anything else.
+func (m Model) suggestedLabels(issue model.Issue) []analysis.LabelPrediction {
+	if m.labelClassifier == nil || len(issue.Labels) > 0 || issue.Status.IsClosed() || issue.Status.IsTombstone() {
+		return nil
+	}
+	return m.labelClassifier.Predict(issue, analysis.DefaultLabelClassifierConfig())
+}
+
+// renderLabelSuggestionsMD renders label predictions for the detail viewport.
+// Returns "" when there are none.
+func renderLabelSuggestionsMD(predictions []analysis.LabelPrediction) string {
+	if len(predictions) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	sb.WriteString("### 🏷️ Suggested Labels\n")
+	for _, p := range predictions {
+		sb.WriteString(fmt.Sprintf("- **%s** %.0f%% (like %s)\n", p.Label, p.Confidence*100, strings.Join(p.Neighbors, ", ")))
+	}
+	sb.WriteString("\n_Press L to apply._\n\n")
+	return sb.String()
+}
+
+// applySuggestedLabels adds the selected issue's suggested labels to the
+// beads file, recording the edit for undo.
+func (m Model) applySuggestedLabels() Model {
+	item, ok := m.list.SelectedItem().(IssueItem)
+	if !ok {
+		return m
+	}
+	issueID := item.Issue.ID
+	predictions := m.suggestedLabels(item.Issue)
+	if len(predictions) == 0 {
+		m.statusMsg = "No label suggestions for " + issueID
+		m.statusIsError = false
+		return m
+	}
+	labels := make([]string, len(predictions))
+	for i, p := range predictions {
+		labels[i] = p.Label
+	}
+	command := LabelSuggestionCommand(issueID, labels)
+	if m.beadsPath == "" {
+		m.statusMsg = "No single data file to edit; run: " + command
+		m.statusIsError = true
+		return m
+	}
+
+	edit, err := loader.AddLabels(m.beadsPath, issueID, labels)
+	switch {
+	case err != nil:
+		m.statusMsg = "Failed to add labels: " + err.Error()
+		m.statusIsError = true
+		return m
+	case edit == nil:
+		m.statusMsg = fmt.Sprintf("Issue %s not found in %s", issueID, filepath.Base(m.beadsPath))
+		m.statusIsError = true
+		return m
+	}
+
+	if issue, ok := m.issueMap[issueID]; ok {
+		issue.Labels = append(issue.Labels, labels...)
+	}
+	for i, it := range m.list.Items() {
+		if listItem, ok := it.(IssueItem); ok && listItem.Issue.ID == issueID {
+			listItem.Issue.Labels = append(append([]string(nil), listItem.Issue.Labels...), labels...)
+			m.list.SetItem(i, listItem)
+			break
+		}
+	}
+	m.updateViewportContent()
+
+	action := fmt.Sprintf("Labeled %s: %s", issueID, strings.Join(labels, ", "))
+	m.statusMsg = fmt.Sprintf("✓ %s (sync with: %s)", action, command)
+	m.statusIsError = false
+	return m.recordEdit(action, edit)
+}
@@ -0,0 +1,114 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestLabelSuggestionCommand(t *testing.T) {
+	if got := LabelSuggestionCommand("bv-1", []string{"api", "backend"}); got != "bd update bv-1 --add-label=api --add-label=backend" {
+		t.Errorf("LabelSuggestionCommand = %s", got)
+	}
+}
+
+func TestRenderLabelSuggestionsMD(t *testing.T) {
+	if got := renderLabelSuggestionsMD(nil); got != "" {
+		t.Errorf("no suggestions should render nothing, got %q", got)
+	}
+	out := renderLabelSuggestionsMD([]analysis.LabelPrediction{
+		{Label: "database", Confidence: 0.83, Neighbors: []string{"db-1", "db-2"}},
+	})
+	for _, want := range []string{"Suggested Labels", "**database** 83% (like db-1, db-2)", "Press L"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("missing %q in:\n%s", want, out)
+		}
+	}
+}
+
+func TestModel_ApplySuggestedLabels(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "issues.jsonl")
+	content := `{"id":"db-1","title":"Postgres migration fails on upgrade","status":"closed","issue_type":"task","labels":["database"]}` + "\n" +
+		`{"id":"db-2","title":"Postgres connection pool exhausted","status":"open","issue_type":"task","labels":["database"]}` + "\n" +
+		`{"id":"ui-1","title":"Settings page layout broken","status":"open","issue_type":"task","labels":["frontend"]}` + "\n" +
+		`{"id":"new","title":"Postgres migration for orders","status":"open","issue_type":"task"}` + "\n"
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	issues, err := loader.LoadIssuesFromFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m := NewModel(issues, nil, path)
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 140, Height: 40})
+	updated, _ = updated.(Model).Update(Phase2ReadyMsg{Stats: updated.(Model).analysis})
+	m = updated.(Model)
+	selectIssue := func(id string) {
+		for i, it := range m.list.Items() {
+			if it.(IssueItem).Issue.ID == id {
+				m.list.Select(i)
+			}
+		}
+		m.updateViewportContent()
+	}
+
+	selected := func() model.Issue { return m.list.SelectedItem().(IssueItem).Issue }
+
+	selectIssue("new")
+	if preds := m.suggestedLabels(selected()); len(preds) != 1 || preds[0].Label != "database" {
+		t.Fatalf("expected database suggested for new, got %+v", preds)
+	}
+
+	updated, _ = m.Update(keyMsgFromString("L"))
+	m = updated.(Model)
+	if m.statusIsError || !strings.Contains(m.statusMsg, "Labeled new: database") || !strings.Contains(m.statusMsg, "--add-label=database") {
+		t.Fatalf("status = %q", m.statusMsg)
+	}
+	if labels := selected().Labels; strings.Join(labels, ",") != "database" {
+		t.Errorf("list item labels = %v", labels)
+	}
+	if preds := m.suggestedLabels(selected()); len(preds) != 0 {
+		t.Errorf("suggestions should disappear once the issue is labeled, got %+v", preds)
+	}
+
+	reloaded, err := loader.LoadIssuesFromFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(reloaded[3].Labels, ","); got != "database" {
+		t.Errorf("persisted labels = %s, want database", got)
+	}
+
+	// Labeled issues have nothing to apply
+	selectIssue("ui-1")
+	updated, _ = m.Update(keyMsgFromString("L"))
+	if m = updated.(Model); !strings.Contains(m.statusMsg, "No label suggestions") {
+		t.Errorf("status = %q", m.statusMsg)
+	}
+}
+
+func TestModel_ApplySuggestedLabelsWithoutBeadsFile(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "a", Title: "Postgres connection pool exhausted", Status: model.StatusOpen, Labels: []string{"database"}},
+		{ID: "b", Title: "Postgres connection leak", Status: model.StatusOpen},
+	}
+	m := NewModel(issues, nil, "")
+	updated, _ := m.Update(Phase2ReadyMsg{Stats: m.analysis})
+	m = updated.(Model)
+	for i, it := range m.list.Items() {
+		if it.(IssueItem).Issue.ID == "b" {
+			m.list.Select(i)
+		}
+	}
+	m = m.applySuggestedLabels()
+	if !m.statusIsError || !strings.Contains(m.statusMsg, "bd update b --add-label=database") {
+		t.Errorf("status should suggest the bd command, got %q", m.statusMsg)
+	}
+}
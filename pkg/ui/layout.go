@@ -0,0 +1,430 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"gopkg.in/yaml.v3"
+)
+
+// PanelKind names a panel that can be pinned in the split layout.
+type PanelKind string
+
+const (
+	PanelList     PanelKind = "list"
+	PanelDetail   PanelKind = "detail"
+	PanelBoard    PanelKind = "board"
+	PanelGraph    PanelKind = "graph"
+	PanelInsights PanelKind = "insights"
+)
+
+// layoutPanels is every pinnable panel in picker order.
+var layoutPanels = []PanelKind{PanelList, PanelDetail, PanelBoard, PanelGraph, PanelInsights}
+
+const (
+	// DefaultSplitRatio is the left panel's share of the width.
+	DefaultSplitRatio = 0.4
+	// MinPinnedLayoutWidth is the narrowest terminal a pinned layout is shown in;
+	// the automatic list/detail split still needs SplitViewThreshold columns.
+	MinPinnedLayoutWidth = 60
+
+	minSplitRatio  = 0.2
+	maxSplitRatio  = 0.8
+	splitRatioStep = 0.05
+)
+
+// Layout is the split-view arrangement saved per project in .bv/layout.yaml.
+// With no panels pinned the classic list/detail split is used.
+type Layout struct {
+	Left  PanelKind `yaml:"left,omitempty"`
+	Right PanelKind `yaml:"right,omitempty"`
+	Ratio float64   `yaml:"ratio,omitempty"` // Share of the width given to the left panel
+}
+
+// Pinned reports whether the user chose the two panels.
+func (l Layout) Pinned() bool {
+	return l.Left != "" && l.Right != ""
+}
+
+// Has reports whether p is one of the pinned panels.
+func (l Layout) Has(p PanelKind) bool {
+	return l.Pinned() && (l.Left == p || l.Right == p)
+}
+
+// SplitRatio returns the left panel's share of the width, clamped to a usable range.
+func (l Layout) SplitRatio() float64 {
+	if l.Ratio == 0 {
+		return DefaultSplitRatio
+	}
+	return min(max(l.Ratio, minSplitRatio), maxSplitRatio)
+}
+
+// Validate checks the panel names and ratio.
+func (l Layout) Validate() error {
+	if (l.Left == "") != (l.Right == "") {
+		return fmt.Errorf("layout needs both left and right panels, or neither")
+	}
+	for _, p := range []PanelKind{l.Left, l.Right} {
+		if p != "" && !slices.Contains(layoutPanels, p) {
+			return fmt.Errorf("unknown panel %q (want one of %s)", p, panelNames())
+		}
+	}
+	if l.Pinned() && l.Left == l.Right {
+		return fmt.Errorf("left and right panels are both %q", l.Left)
+	}
+	if l.Ratio != 0 && (l.Ratio < minSplitRatio || l.Ratio > maxSplitRatio) {
+		return fmt.Errorf("ratio %.2f out of range %.1f-%.1f", l.Ratio, minSplitRatio, maxSplitRatio)
+	}
+	return nil
+}
+
+func panelNames() string {
+	names := make([]string, len(layoutPanels))
+	for i, p := range layoutPanels {
+		names[i] = string(p)
+	}
+	return strings.Join(names, ", ")
+}
+
+// DefaultLayoutPath returns the layout file path for a project.
+func DefaultLayoutPath(projectDir string) string {
+	return filepath.Join(projectDir, ".bv", "layout.yaml")
+}
+
+// LoadLayout reads the project's saved layout. A missing file yields the
+// automatic layout; an invalid one is returned as an error alongside it.
+func LoadLayout(projectDir string) (Layout, error) {
+	data, err := os.ReadFile(DefaultLayoutPath(projectDir))
+	if os.IsNotExist(err) {
+		return Layout{}, nil
+	}
+	if err != nil {
+		return Layout{}, fmt.Errorf("reading layout: %w", err)
+	}
+	var l Layout
+	if err := yaml.Unmarshal(data, &l); err != nil {
+		return Layout{}, fmt.Errorf("parsing %s: %w", DefaultLayoutPath(projectDir), err)
+	}
+	if err := l.Validate(); err != nil {
+		return Layout{}, fmt.Errorf("%s: %w", DefaultLayoutPath(projectDir), err)
+	}
+	return l, nil
+}
+
+// SaveLayout writes l to the project's layout file.
+func SaveLayout(projectDir string, l Layout) error {
+	path := DefaultLayoutPath(projectDir)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("creating %s: %w", filepath.Dir(path), err)
+	}
+	data, err := yaml.Marshal(l)
+	if err != nil {
+		return fmt.Errorf("encoding layout: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("writing layout: %w", err)
+	}
+	return nil
+}
+
+// paneFocus is the focus value that drives keys for a panel.
+func paneFocus(p PanelKind) focus {
+	switch p {
+	case PanelDetail:
+		return focusDetail
+	case PanelBoard:
+		return focusBoard
+	case PanelGraph:
+		return focusGraph
+	case PanelInsights:
+		return focusInsights
+	}
+	return focusList
+}
+
+// SetLayout applies a layout; projectDir is where later changes are saved
+// ("" keeps them for this session only).
+func (m *Model) SetLayout(l Layout, projectDir string) {
+	m.layout = l
+	m.layoutDir = projectDir
+	if m.ready {
+		m.resizePanes()
+		if m.layoutActive() {
+			m.focused = paneFocus(l.Left)
+		}
+	}
+}
+
+// layoutActive reports whether a pinned layout is on screen: it is pinned,
+// the terminal is wide enough and no full-screen view is covering it.
+func (m Model) layoutActive() bool {
+	if !m.layout.Pinned() || m.width < MinPinnedLayoutWidth {
+		return false
+	}
+	if m.isGraphView || m.isBoardView || m.isActionableView || m.isHistoryView || m.isSprintView {
+		return false
+	}
+	switch m.focused {
+	case focusFlowMatrix, focusLabelDashboard:
+		return false
+	case focusInsights:
+		return m.layout.Has(PanelInsights)
+	}
+	return !(m.showDetails && !m.isSplitView)
+}
+
+// splitPanes returns the panels shown side by side.
+func (m Model) splitPanes() (PanelKind, PanelKind) {
+	if m.layout.Pinned() {
+		return m.layout.Left, m.layout.Right
+	}
+	return PanelList, PanelDetail
+}
+
+// paneWidths returns the inner widths of the two panes. Each pane has
+// borders(2)+padding(2) = 4 columns of overhead.
+func (m Model) paneWidths() (int, int) {
+	availWidth := max(m.width-8, 10)
+	left := int(float64(availWidth) * m.layout.SplitRatio())
+	return left, availWidth - left
+}
+
+// resizePanes sizes the list and detail viewport for the current layout.
+func (m *Model) resizePanes() {
+	bodyHeight := max(m.height-1, 5) // keep 1 row for footer
+	left, right := m.splitPanes()
+	if m.layout.Pinned() {
+		m.isSplitView = m.width >= MinPinnedLayoutWidth && m.layout.Has(PanelList) && m.layout.Has(PanelDetail)
+	} else {
+		m.isSplitView = m.width > SplitViewThreshold
+	}
+
+	listWidth, detailWidth := m.width, m.width
+	listHeight := max(bodyHeight-2, 3)
+	detailHeight := bodyHeight - 1
+	if m.width >= MinPinnedLayoutWidth && (m.isSplitView || m.layout.Pinned()) {
+		leftWidth, rightWidth := m.paneWidths()
+		widths := map[PanelKind]int{left: leftWidth, right: rightWidth}
+		if w, ok := widths[PanelList]; ok {
+			// The list pane fits header (1) + page line (1) inside a border (2)
+			listWidth, listHeight = w, max(bodyHeight-4, 3)
+		}
+		if w, ok := widths[PanelDetail]; ok {
+			detailWidth, detailHeight = w, bodyHeight-2 // Account for border
+		}
+	}
+
+	m.list.SetSize(listWidth, listHeight)
+	m.viewport = viewport.New(detailWidth, detailHeight)
+	m.renderer.SetWidthWithTheme(detailWidth, m.theme)
+}
+
+// focusNextPane moves focus to the other pane of the pinned layout.
+func (m Model) focusNextPane() Model {
+	left, right := m.splitPanes()
+	if m.focused == paneFocus(left) {
+		m.focused = paneFocus(right)
+	} else {
+		m.focused = paneFocus(left)
+	}
+	if m.focused == focusDetail {
+		m.updateViewportContent()
+	}
+	return m
+}
+
+// adjustSplitRatio widens (delta > 0) or narrows the left pane and saves it.
+func (m Model) adjustSplitRatio(delta float64) Model {
+	if !m.isSplitView && !m.layoutActive() {
+		m.statusMsg = "No split view at this width; pin panels with |"
+		m.statusIsError = false
+		return m
+	}
+	ratio := min(max(m.layout.SplitRatio()+delta, minSplitRatio), maxSplitRatio)
+	m.layout.Ratio = float64(int(ratio*100+0.5)) / 100 // Avoid float drift in the file
+	m.resizePanes()
+	m.updateViewportContent()
+	left := int(m.layout.Ratio * 100)
+	m.statusMsg = fmt.Sprintf("Split %d%% / %d%%", left, 100-left)
+	m.statusIsError = false
+	return m.saveLayout()
+}
+
+// saveLayout persists the layout for the project, reporting failures only.
+func (m Model) saveLayout() Model {
+	if m.layoutDir == "" {
+		return m
+	}
+	if err := SaveLayout(m.layoutDir, m.layout); err != nil {
+		m.statusMsg = "Layout not saved: " + err.Error()
+		m.statusIsError = true
+	}
+	return m
+}
+
+// renderPane renders one panel of the split view at the given inner width.
+func (m Model) renderPane(p PanelKind, innerWidth, height int) string {
+	style := PanelStyle
+	if m.focused == paneFocus(p) {
+		style = FocusedPanelStyle
+	}
+
+	var content string
+	switch p {
+	case PanelList:
+		content = m.renderListPanelContent(innerWidth, height)
+	case PanelDetail:
+		content = m.viewport.View()
+	case PanelBoard:
+		content = m.board.View(innerWidth, height-2)
+	case PanelGraph:
+		content = m.graphView.View(innerWidth, height-2)
+	case PanelInsights:
+		panel := m.insightsPanel
+		panel.SetSize(innerWidth, height-2)
+		content = panel.View()
+	}
+
+	// Panel Width: Inner + 2 (Padding). Border adds another 2.
+	// Use MaxHeight to ensure content doesn't overflow
+	return style.
+		Width(innerWidth + 2).
+		Height(height).
+		MaxHeight(height).
+		Render(content)
+}
+
+// LayoutPickerModel chooses the two panels of a pinned split layout
+type LayoutPickerModel struct {
+	column int    // 0 = left, 1 = right
+	cursor [2]int // Selected panel index per column
+	theme  Theme
+}
+
+// NewLayoutPickerModel creates a picker starting from the current layout
+func NewLayoutPickerModel(current Layout, theme Theme) LayoutPickerModel {
+	p := LayoutPickerModel{theme: theme, cursor: [2]int{0, 1}}
+	if current.Pinned() {
+		p.cursor = [2]int{slices.Index(layoutPanels, current.Left), slices.Index(layoutPanels, current.Right)}
+	}
+	return p
+}
+
+// Layout returns the layout the picker currently describes
+func (p LayoutPickerModel) Layout() Layout {
+	return Layout{Left: layoutPanels[p.cursor[0]], Right: layoutPanels[p.cursor[1]]}
+}
+
+// View renders the picker overlay
+func (p LayoutPickerModel) View(width, height int) string {
+	t := p.theme
+
+	titleStyle := t.Renderer.NewStyle().Foreground(t.Primary).Bold(true)
+	headerStyle := t.Renderer.NewStyle().Foreground(t.Secondary).Bold(true).Width(16)
+	itemStyle := t.Renderer.NewStyle().Foreground(t.Base.GetForeground()).Width(16)
+	selectedStyle := itemStyle.Foreground(t.Primary).Bold(true)
+	dimStyle := t.Renderer.NewStyle().Foreground(t.Secondary).Italic(true)
+
+	columns := make([]string, 2)
+	for c, title := range []string{"Left", "Right"} {
+		if c == p.column {
+			title = "▸ " + title
+		} else {
+			title = "  " + title
+		}
+		lines := []string{headerStyle.Render(title)}
+		for i, panel := range layoutPanels {
+			style, prefix := itemStyle, "  "
+			if i == p.cursor[c] {
+				style, prefix = selectedStyle, "> "
+			}
+			lines = append(lines, style.Render(prefix+string(panel)))
+		}
+		columns[c] = strings.Join(lines, "\n")
+	}
+
+	l := p.Layout()
+	preview := fmt.Sprintf("%s │ %s", l.Left, l.Right)
+	if l.Left == l.Right {
+		preview = "pick two different panels"
+	}
+
+	content := titleStyle.Render("Split Layout") + "\n\n" +
+		lipgloss.JoinHorizontal(lipgloss.Top, columns[0], columns[1]) + "\n\n" +
+		dimStyle.Render(preview) + "\n\n" +
+		dimStyle.Render("h/l: column | j/k: panel | enter: pin") + "\n" +
+		dimStyle.Render("x: automatic list/detail | esc: cancel")
+
+	box := t.Renderer.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(t.Primary).
+		Padding(1, 3).
+		Render(content)
+
+	return lipgloss.Place(width, height, lipgloss.Center, lipgloss.Center, box)
+}
+
+// openLayoutPicker shows the layout picker.
+func (m Model) openLayoutPicker() Model {
+	m.layoutPicker = NewLayoutPickerModel(m.layout, m.theme)
+	m.layoutReturnFocus = m.focused
+	m.showLayoutPicker = true
+	m.focused = focusLayoutPicker
+	return m
+}
+
+// handleLayoutPickerKeys handles keyboard input for the layout picker
+func (m Model) handleLayoutPickerKeys(msg tea.KeyMsg) Model {
+	p := &m.layoutPicker
+	switch msg.String() {
+	case "h", "left":
+		p.column = 0
+	case "l", "right":
+		p.column = 1
+	case "tab":
+		p.column = 1 - p.column
+	case "j", "down":
+		p.cursor[p.column] = min(p.cursor[p.column]+1, len(layoutPanels)-1)
+	case "k", "up":
+		p.cursor[p.column] = max(p.cursor[p.column]-1, 0)
+	case "esc", "q":
+		m.showLayoutPicker = false
+		m.focused = m.layoutReturnFocus
+	case "x":
+		m.showLayoutPicker = false
+		m.layout.Left, m.layout.Right = "", ""
+		m.focused = focusList
+		m.resizePanes()
+		m.updateViewportContent()
+		m.statusMsg = "Layout: automatic list/detail split"
+		m.statusIsError = false
+		m = m.saveLayout()
+	case "enter":
+		chosen := p.Layout()
+		if chosen.Left == chosen.Right {
+			m.statusMsg = "Pick two different panels"
+			m.statusIsError = true
+			return m
+		}
+		m.showLayoutPicker = false
+		m.layout.Left, m.layout.Right = chosen.Left, chosen.Right
+		m.isGraphView, m.isBoardView, m.isActionableView, m.isHistoryView = false, false, false, false
+		m.showDetails = false
+		m.resizePanes()
+		m.focused = paneFocus(chosen.Left)
+		m.updateViewportContent()
+		m.statusMsg = fmt.Sprintf("Layout: %s │ %s (tab switches panes, </> resize)", chosen.Left, chosen.Right)
+		m.statusIsError = false
+		if m.width < MinPinnedLayoutWidth {
+			m.statusMsg += fmt.Sprintf("; needs %d+ columns", MinPinnedLayoutWidth)
+		}
+		m = m.saveLayout()
+	}
+	return m
+}
@@ -0,0 +1,208 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func newLayoutTestModel(t *testing.T, width int, l Layout, dir string) Model {
+	t.Helper()
+	issues := []model.Issue{
+		{ID: "A-1", Title: "Alpha", Status: model.StatusOpen},
+		{ID: "A-2", Title: "Beta", Status: model.StatusOpen, Dependencies: []*model.Dependency{{IssueID: "A-2", DependsOnID: "A-1", Type: model.DepBlocks}}},
+	}
+	m := NewModel(issues, nil, "")
+	m.SetLayout(l, dir)
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: width, Height: 30})
+	return updated.(Model)
+}
+
+func pressKeys(t *testing.T, m Model, keys ...string) Model {
+	t.Helper()
+	for _, k := range keys {
+		updated, _ := m.Update(keyMsgFromString(k))
+		m = updated.(Model)
+	}
+	return m
+}
+
+func TestLayoutValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		layout  Layout
+		wantErr string
+	}{
+		{"automatic", Layout{}, ""},
+		{"pinned", Layout{Left: PanelBoard, Right: PanelInsights, Ratio: 0.5}, ""},
+		{"one side", Layout{Left: PanelList}, "both left and right"},
+		{"unknown", Layout{Left: PanelList, Right: "chart"}, `unknown panel "chart"`},
+		{"same panel", Layout{Left: PanelGraph, Right: PanelGraph}, "both"},
+		{"ratio", Layout{Left: PanelList, Right: PanelGraph, Ratio: 0.95}, "out of range"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.layout.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoadSaveLayout(t *testing.T) {
+	dir := t.TempDir()
+	l, err := LoadLayout(dir)
+	if err != nil || l.Pinned() {
+		t.Fatalf("missing file should give the automatic layout, got %+v, %v", l, err)
+	}
+
+	want := Layout{Left: PanelList, Right: PanelGraph, Ratio: 0.45}
+	if err := SaveLayout(dir, want); err != nil {
+		t.Fatalf("SaveLayout: %v", err)
+	}
+	got, err := LoadLayout(dir)
+	if err != nil || got != want {
+		t.Fatalf("round trip = %+v, %v; want %+v", got, err, want)
+	}
+
+	if err := os.WriteFile(DefaultLayoutPath(dir), []byte("left: list\nright: list\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadLayout(dir); err == nil || !strings.Contains(err.Error(), filepath.Join(".bv", "layout.yaml")) {
+		t.Errorf("invalid layout should name the file, got %v", err)
+	}
+}
+
+func TestLayoutPicker_PinsPanels(t *testing.T) {
+	dir := t.TempDir()
+	m := newLayoutTestModel(t, 140, Layout{}, dir)
+
+	m = pressKeys(t, m, "|")
+	if !m.showLayoutPicker || m.CurrentContext() != ContextLayoutPicker {
+		t.Fatalf("| should open the layout picker (context %s)", m.CurrentContext())
+	}
+	if !strings.Contains(m.View(), "Split Layout") {
+		t.Error("picker overlay not rendered")
+	}
+
+	// Left stays on list; move the right column from detail down to graph
+	m = pressKeys(t, m, "l", "j", "j", "enter")
+	if m.showLayoutPicker {
+		t.Fatal("enter should close the picker")
+	}
+	if m.layout.Left != PanelList || m.layout.Right != PanelGraph {
+		t.Fatalf("layout = %+v, want list/graph", m.layout)
+	}
+	if !m.layoutActive() || m.focused != focusList {
+		t.Fatalf("pinned layout should be active with the list focused (focus %v)", m.focused)
+	}
+
+	saved, err := LoadLayout(dir)
+	if err != nil || saved.Left != PanelList || saved.Right != PanelGraph {
+		t.Errorf("layout not persisted: %+v, %v", saved, err)
+	}
+
+	m = pressKeys(t, m, "tab")
+	if m.focused != focusGraph || m.CurrentContext() != ContextGraph {
+		t.Errorf("tab should focus the graph pane (focus %v, context %s)", m.focused, m.CurrentContext())
+	}
+	m = pressKeys(t, m, "tab")
+	if m.focused != focusList {
+		t.Errorf("tab should return to the list pane, got %v", m.focused)
+	}
+}
+
+func TestLayoutPicker_CancelAndReset(t *testing.T) {
+	m := newLayoutTestModel(t, 140, Layout{Left: PanelBoard, Right: PanelDetail}, "")
+
+	m = pressKeys(t, m, "|", "j", "esc")
+	if m.showLayoutPicker || m.layout.Left != PanelBoard {
+		t.Fatalf("esc should keep the layout, got %+v", m.layout)
+	}
+
+	m = pressKeys(t, m, "|", "x")
+	if m.layout.Pinned() || m.layoutActive() {
+		t.Fatalf("x should return to the automatic split, got %+v", m.layout)
+	}
+	if !m.isSplitView {
+		t.Error("automatic split should be back above the threshold")
+	}
+}
+
+func TestLayout_ResizeRatio(t *testing.T) {
+	dir := t.TempDir()
+	m := newLayoutTestModel(t, 140, Layout{Left: PanelList, Right: PanelDetail}, dir)
+	leftBefore, _ := m.paneWidths()
+
+	m = pressKeys(t, m, ">", ">")
+	if m.layout.Ratio != 0.5 {
+		t.Fatalf("ratio = %v, want 0.5", m.layout.Ratio)
+	}
+	if left, _ := m.paneWidths(); left <= leftBefore {
+		t.Errorf("left pane should widen: %d -> %d", leftBefore, left)
+	}
+	if m.list.Width() != func() int { l, _ := m.paneWidths(); return l }() {
+		t.Errorf("list width %d not resized", m.list.Width())
+	}
+
+	for range 20 {
+		m = pressKeys(t, m, "<")
+	}
+	if m.layout.Ratio != minSplitRatio {
+		t.Errorf("ratio should clamp at %v, got %v", minSplitRatio, m.layout.Ratio)
+	}
+
+	saved, err := LoadLayout(dir)
+	if err != nil || saved.Ratio != minSplitRatio {
+		t.Errorf("ratio not persisted: %+v, %v", saved, err)
+	}
+}
+
+func TestLayout_AutomaticSplitUnchanged(t *testing.T) {
+	m := newLayoutTestModel(t, 140, Layout{}, "")
+	if !m.isSplitView || m.layoutActive() {
+		t.Fatalf("automatic split expected above %d columns", SplitViewThreshold)
+	}
+
+	m = newLayoutTestModel(t, 80, Layout{}, "")
+	if m.isSplitView {
+		t.Errorf("no automatic split at 80 columns")
+	}
+}
+
+func TestLayout_PinnedBelowSplitThreshold(t *testing.T) {
+	m := newLayoutTestModel(t, 80, Layout{Left: PanelList, Right: PanelGraph}, "")
+	if !m.layoutActive() {
+		t.Fatal("pinned layout should show at 80 columns")
+	}
+	view := m.View()
+	if !strings.Contains(view, "TITLE") || !strings.Contains(view, "A-1") {
+		t.Error("list pane missing from pinned view")
+	}
+
+	// Full-screen views still take over, and the pins return afterwards
+	m = pressKeys(t, m, "b")
+	if m.layoutActive() {
+		t.Error("board view should cover the pinned layout")
+	}
+	m = pressKeys(t, m, "b")
+	if !m.layoutActive() {
+		t.Error("leaving the board should restore the pinned layout")
+	}
+
+	m = newLayoutTestModel(t, 50, Layout{Left: PanelList, Right: PanelGraph}, "")
+	if m.layoutActive() {
+		t.Errorf("pinned layout needs %d columns", MinPinnedLayoutWidth)
+	}
+}
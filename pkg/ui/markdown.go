@@ -3,7 +3,9 @@ package ui
 import (
 	"github.com/charmbracelet/glamour"
 	"github.com/charmbracelet/glamour/ansi"
+	"github.com/charmbracelet/glamour/styles"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
 )
 
 // MarkdownRenderer provides theme-aware markdown rendering using glamour.
@@ -16,6 +18,33 @@ type MarkdownRenderer struct {
 	useTheme  bool   // true if created with NewMarkdownRendererWithTheme
 }
 
+// noColorMarkdown is set by Model.SetNoColor
+var noColorMarkdown bool
+
+// rendererOptions are the glamour options shared by every style: word wrap
+// and code-fence highlighting at the terminal's full color depth, so theme
+// colors in code blocks aren't rounded to the 256-color palette. After
+// SetNoColor, markdown renders as plain text.
+func rendererOptions(width int) []glamour.TermRendererOption {
+	profile := lipgloss.ColorProfile()
+	formatter := "terminal256"
+	switch profile {
+	case termenv.TrueColor:
+		formatter = "terminal16m"
+	case termenv.Ascii:
+		formatter = "noop"
+	}
+	opts := []glamour.TermRendererOption{
+		glamour.WithWordWrap(width),
+		glamour.WithChromaFormatter(formatter),
+	}
+	if noColorMarkdown {
+		// The color-free style: other styles still emit bold and italics
+		opts = append(opts, glamour.WithStyles(styles.NoTTYStyleConfig), glamour.WithColorProfile(termenv.Ascii))
+	}
+	return opts
+}
+
 // NewMarkdownRenderer creates a new markdown renderer using built-in styles.
 // It uses Dracula style for dark terminals and a light style for light terminals.
 // Prefer NewMarkdownRendererWithTheme for consistent styling with the bv Theme.
@@ -31,7 +60,7 @@ func NewMarkdownRenderer(width int) *MarkdownRenderer {
 
 	renderer, _ := glamour.NewTermRenderer(
 		glamour.WithStylePath(styleName),
-		glamour.WithWordWrap(width),
+		glamour.WithOptions(rendererOptions(width)...),
 	)
 
 	return &MarkdownRenderer{
@@ -46,12 +75,12 @@ func NewMarkdownRenderer(width int) *MarkdownRenderer {
 // NewMarkdownRendererWithTheme creates a markdown renderer using custom colors
 // that match the provided Theme for visual consistency.
 func NewMarkdownRendererWithTheme(width int, theme Theme) *MarkdownRenderer {
-	isDark := lipgloss.HasDarkBackground()
+	isDark := theme.isDarkOr(lipgloss.HasDarkBackground)
 	styleConfig := buildStyleFromTheme(theme, isDark)
 
 	renderer, err := glamour.NewTermRenderer(
 		glamour.WithStyles(styleConfig),
-		glamour.WithWordWrap(width),
+		glamour.WithOptions(rendererOptions(width)...),
 	)
 	if err != nil {
 		// Fall back to built-in style if custom theme fails
@@ -63,7 +92,7 @@ func NewMarkdownRendererWithTheme(width int, theme Theme) *MarkdownRenderer {
 		}
 		renderer, _ = glamour.NewTermRenderer(
 			glamour.WithStylePath(styleName),
-			glamour.WithWordWrap(width),
+			glamour.WithOptions(rendererOptions(width)...),
 		)
 	}
 
@@ -97,7 +126,7 @@ func (mr *MarkdownRenderer) SetWidth(width int) {
 		styleConfig := buildStyleFromTheme(*mr.theme, mr.isDark)
 		if r, err := glamour.NewTermRenderer(
 			glamour.WithStyles(styleConfig),
-			glamour.WithWordWrap(width),
+			glamour.WithOptions(rendererOptions(width)...),
 		); err == nil {
 			mr.renderer = r
 			mr.width = width
@@ -115,7 +144,7 @@ func (mr *MarkdownRenderer) SetWidth(width int) {
 
 	if r, err := glamour.NewTermRenderer(
 		glamour.WithStylePath(styleName),
-		glamour.WithWordWrap(width),
+		glamour.WithOptions(rendererOptions(width)...),
 	); err == nil {
 		mr.renderer = r
 		mr.width = width
@@ -131,24 +160,26 @@ func (mr *MarkdownRenderer) SetWidthWithTheme(width int, theme Theme) {
 		return
 	}
 
-	// Allow recreation even if width is the same (theme might have changed)
-	styleConfig := buildStyleFromTheme(theme, mr.isDark)
+	// Allow recreation even if width is the same (theme might have changed).
+	// Themes pinned to dark or light override the detected background.
+	isDark := theme.isDarkOr(lipgloss.HasDarkBackground)
+	styleConfig := buildStyleFromTheme(theme, isDark)
 
 	r, err := glamour.NewTermRenderer(
 		glamour.WithStyles(styleConfig),
-		glamour.WithWordWrap(width),
+		glamour.WithOptions(rendererOptions(width)...),
 	)
 	if err != nil {
 		// Fall back to built-in style if custom theme fails
 		var styleName string
-		if mr.isDark {
+		if isDark {
 			styleName = "dracula"
 		} else {
 			styleName = "light"
 		}
 		r, _ = glamour.NewTermRenderer(
 			glamour.WithStylePath(styleName),
-			glamour.WithWordWrap(width),
+			glamour.WithOptions(rendererOptions(width)...),
 		)
 	}
 	if r != nil {
@@ -156,6 +187,7 @@ func (mr *MarkdownRenderer) SetWidthWithTheme(width int, theme Theme) {
 		mr.width = width
 		mr.theme = &theme
 		mr.useTheme = true
+		mr.isDark = isDark
 	}
 }
 
@@ -0,0 +1,80 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// linkMentionsMD turns references to other issues in the detail markdown
+// into links, which glamour renders as highlighted IDs. selfID is skipped.
+func (m Model) linkMentionsMD(md, selfID string) string {
+	return analysis.LinkMentions(md, m.isMentionTarget(selfID), func(id string) string { return "#" + id })
+}
+
+// isMentionTarget reports which IDs count as references to another issue
+// while viewing selfID.
+func (m Model) isMentionTarget(selfID string) func(id string) bool {
+	return func(id string) bool {
+		_, ok := m.issueMap[id]
+		return ok && id != selfID
+	}
+}
+
+// renderBacklinksMD renders the issues whose text mentions issueID for the
+// detail viewport. Returns "" when there are none.
+func (m Model) renderBacklinksMD(issueID string) string {
+	from := m.mentions.Backlinks(issueID)
+	if len(from) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("### ↩ Mentioned By (%d)\n", len(from)))
+	for _, id := range from {
+		if issue, ok := m.issueMap[id]; ok {
+			sb.WriteString(fmt.Sprintf("- %s %s %s\n", GetStatusIcon(string(issue.Status)), id, issue.Title))
+		}
+	}
+	sb.WriteString("\n_gd jumps to the topmost issue ID on screen._\n\n")
+	return sb.String()
+}
+
+// visibleMention returns the topmost issue ID showing in the detail
+// viewport, other than the selected issue's own, or "".
+func (m Model) visibleMention() string {
+	item, ok := m.list.SelectedItem().(IssueItem)
+	if !ok {
+		return ""
+	}
+	known := m.isMentionTarget(item.Issue.ID)
+	for _, line := range strings.Split(ansi.Strip(m.viewport.View()), "\n") {
+		if refs := analysis.FindMentions(line, known); len(refs) > 0 {
+			return refs[0].ID
+		}
+	}
+	return ""
+}
+
+// jumpToMention selects the issue with the given ID in the list and shows
+// its details, like following a link.
+func (m Model) jumpToMention(id string) Model {
+	from := ""
+	if item, ok := m.list.SelectedItem().(IssueItem); ok {
+		from = item.Issue.ID
+	}
+	for i, item := range m.list.Items() {
+		if issueItem, ok := item.(IssueItem); ok && issueItem.Issue.ID == id {
+			m.list.Select(i)
+			m.viewport.GotoTop()
+			m.updateViewportContent()
+			m.statusMsg = fmt.Sprintf("↪ %s (from %s)", id, from)
+			m.statusIsError = false
+			return m
+		}
+	}
+	m.statusMsg = fmt.Sprintf("%s is hidden by the current filter", id)
+	m.statusIsError = true
+	return m
+}
@@ -0,0 +1,67 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestModel_MentionsAndGotoReference(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "bv-1", Title: "Parser", Status: model.StatusOpen, IssueType: model.TypeTask, Description: "Needs bv-2 first."},
+		{ID: "bv-2", Title: "Lexer", Status: model.StatusOpen, IssueType: model.TypeTask},
+		{ID: "bv-3", Title: "Docs", Status: model.StatusOpen, IssueType: model.TypeTask,
+			Comments: []*model.Comment{{Author: "a", Text: "same as bv-2"}}},
+	}
+	m := NewModel(issues, nil, "")
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 160, Height: 60})
+	m = updated.(Model)
+	selectIssue := func(id string) {
+		for i, it := range m.list.Items() {
+			if it.(IssueItem).Issue.ID == id {
+				m.list.Select(i)
+			}
+		}
+		m.viewport.GotoTop()
+		m.updateViewportContent()
+	}
+	selected := func() string { return m.list.SelectedItem().(IssueItem).Issue.ID }
+
+	selectIssue("bv-2")
+	if got := m.renderBacklinksMD("bv-2"); !strings.Contains(got, "Mentioned By (2)") ||
+		!strings.Contains(got, "bv-1 Parser") || !strings.Contains(got, "bv-3 Docs") {
+		t.Errorf("backlinks for bv-2:\n%s", got)
+	}
+	if got := m.linkMentionsMD("bv-2 needs bv-1", "bv-2"); got != "bv-2 needs [bv-1](#bv-1)" {
+		t.Errorf("linkMentionsMD = %q", got)
+	}
+
+	selectIssue("bv-1")
+	m.focused = focusDetail
+	if got := m.visibleMention(); got != "bv-2" {
+		t.Fatalf("visibleMention = %q, want bv-2", got)
+	}
+	updated, _ = m.Update(keyMsgFromString("g"))
+	m = updated.(Model)
+	if !m.pendingGoto || m.isGraphView {
+		t.Fatalf("g with a reference on screen should wait for d (pending=%v graph=%v)", m.pendingGoto, m.isGraphView)
+	}
+	updated, _ = m.Update(keyMsgFromString("d"))
+	m = updated.(Model)
+	if selected() != "bv-2" || m.statusIsError {
+		t.Errorf("gd selected %s, status %q", selected(), m.statusMsg)
+	}
+
+	// gg still toggles the graph view
+	selectIssue("bv-1")
+	m.focused = focusDetail
+	for _, k := range []string{"g", "g"} {
+		updated, _ = m.Update(keyMsgFromString(k))
+		m = updated.(Model)
+	}
+	if !m.isGraphView {
+		t.Error("gg should toggle the graph view")
+	}
+}
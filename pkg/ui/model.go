@@ -13,19 +13,23 @@ import (
 
 	"github.com/Dicklesworthstone/beads_viewer/pkg/agents"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/claims"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/baseline"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/cass"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/correlation"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/drift"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/export"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/hooks"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/i18n"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/recipe"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/search"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/timetrack"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/updater"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/usage"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/watcher"
 
-	"github.com/atotto/clipboard"
 	"github.com/charmbracelet/bubbles/list"
 	"github.com/charmbracelet/bubbles/textinput"
 	"github.com/charmbracelet/bubbles/viewport"
@@ -59,12 +63,16 @@ const (
 	focusHistory
 	focusAttention
 	focusLabelPicker
-	focusSprint      // Sprint dashboard view (bv-161)
-	focusAgentPrompt // AGENTS.md integration prompt (bv-i8dk)
-	focusFlowMatrix  // Cross-label flow matrix view
-	focusTutorial    // Interactive tutorial (bv-8y31)
-	focusCassModal   // Cass session preview modal (bv-5bqh)
-	focusUpdateModal // Self-update modal (bv-182)
+	focusSprint         // Sprint dashboard view (bv-161)
+	focusAgentPrompt    // AGENTS.md integration prompt (bv-i8dk)
+	focusFlowMatrix     // Cross-label flow matrix view
+	focusTutorial       // Interactive tutorial (bv-8y31)
+	focusCassModal      // Cass session preview modal (bv-5bqh)
+	focusUpdateModal    // Self-update modal (bv-182)
+	focusCycleBreak     // Cycle-break wizard over the graph view
+	focusCommentInput   // Comment prompt for the selected issue
+	focusCommandPalette // Command palette overlay (ctrl+p)
+	focusLayoutPicker   // Split layout picker (|)
 )
 
 // SortMode represents the current list sorting mode (bv-3ita)
@@ -237,10 +245,11 @@ func LoadHistoryCmd(issues []model.Issue, beadsPath string) tea.Cmd {
 				ID:     issue.ID,
 				Title:  issue.Title,
 				Status: string(issue.Status),
+				Events: issue.Events,
 			}
 		}
 
-		correlator := correlation.NewCorrelator(repoPath, beadsPath)
+		correlator := correlation.NewIndexedCorrelator(repoPath, beadsPath)
 		opts := correlation.CorrelatorOptions{
 			Limit: 500, // Reasonable limit for TUI performance
 		}
@@ -250,6 +259,34 @@ func LoadHistoryCmd(issues []model.Issue, beadsPath string) tea.Cmd {
 	}
 }
 
+// DuplicatesReadyMsg is sent when background near-duplicate detection completes
+type DuplicatesReadyMsg struct {
+	Stats   *analysis.GraphStats // The stats the scan ran for, to detect stale messages
+	ByIssue map[string][]string  // issueID -> possible duplicate IDs, best first
+}
+
+// FindDuplicatesCmd scores near-duplicates in the background for the detail
+// view badge. Issues are embedded in memory; if the embedder is unavailable
+// the scan falls back to titles and labels.
+func FindDuplicatesCmd(issues []model.Issue, projectDir string, stats *analysis.GraphStats) tea.Cmd {
+	return func() tea.Msg {
+		config := analysis.DefaultNearDuplicateConfig()
+		if searchCfg, err := search.LoadProjectConfig(projectDir); err == nil && searchCfg.Duplicates.Threshold > 0 {
+			config.Threshold = searchCfg.Duplicates.Threshold
+		}
+
+		var vectors map[string][]float32
+		if embedder, err := search.NewEmbedderFromConfig(search.EmbeddingConfigFromEnv()); err == nil {
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			vectors, _ = search.EmbedIssues(ctx, embedder, issues)
+			cancel()
+		}
+
+		pairs := analysis.FindNearDuplicates(issues, vectors, config)
+		return DuplicatesReadyMsg{Stats: stats, ByIssue: analysis.NearDuplicatesByIssue(pairs)}
+	}
+}
+
 // Model is the main Bubble Tea model for the beads viewer
 type Model struct {
 	// Data
@@ -272,6 +309,10 @@ type Model struct {
 	insightsPanel      InsightsModel
 	flowMatrix         FlowMatrixModel // Cross-label flow matrix
 	theme              Theme
+	themes             *ThemeSet          // Loaded theme.yaml palettes for the theme switcher
+	keymap             *Keymap            // Active key bindings (nil means defaults)
+	boardConfig        *drift.BoardConfig // WIP limits from .bv/board.yaml
+	hookConfig         *hooks.Config      // post-reload hooks from .bv/hooks.yaml
 
 	// Update State
 	updateAvailable bool
@@ -306,6 +347,7 @@ type Model struct {
 	showShortcutsSidebar     bool // bv-3qi5 toggleable shortcuts sidebar
 	labelHealthCached        bool
 	labelHealthCache         analysis.LabelAnalysisResult
+	labelTrendsRequested     bool // LabelTrendsCmd started for the current data
 	attentionCached          bool
 	attentionCache           analysis.LabelAttentionResult
 
@@ -334,10 +376,27 @@ type Model struct {
 	countReady   int
 	countBlocked int
 	countClosed  int
+	readiness    *analysis.ReadinessIndex // Per-issue state behind the counts, updated incrementally on reload
 
 	// Priority hints
 	showPriorityHints bool
 	priorityHints     map[string]*analysis.PriorityRecommendation // issueID -> recommendation
+	duplicates        map[string][]string                         // issueID -> possible duplicate IDs
+	mentions          *analysis.MentionIndex                      // Issue IDs referenced in each issue's text, both ways
+	pendingGoto       bool                                        // g pressed in the detail pane; d follows a reference
+	detailRaw         bool                                        // Detail pane shows free text as raw markdown (v)
+	detailExplain     bool                                        // Detail pane shows the issue's explanation (E)
+	explained         *analysis.IssueExplanation                  // Cached explanation of the selected issue
+	plain             bool                                        // Screen-reader mode: sentence rows, no box drawing (--plain)
+	ascii             bool                                        // Glyphs swapped for ASCII at the end of View (--ascii)
+	usage             *usageTracker                               // Local usage log tallies; nil unless `bv stats enable`
+	pendingAttachment bool                                        // A pressed with several attachments; a digit picks one
+	imageProtocol     imageProtocol                               // How attachment images preview inline, if at all
+	imagePreviews     map[string]string                           // Rendered image previews by file and size
+	labelClassifier   *analysis.LabelClassifier                   // Label suggestions for unlabeled issues
+	timeLog           *timetrack.Log                              // Work sessions from .bv/time.jsonl
+	claims            *claims.Ledger                              // Leases from .bv/claims.jsonl (bv claim)
+	focusTimer        *focusTimer                                 // Running focus session, nil when idle
 
 	// Triage insights (bv-151)
 	triageScores  map[string]float64                // issueID -> triage score
@@ -356,6 +415,18 @@ type Model struct {
 	showLabelPicker bool
 	labelPicker     LabelPickerModel
 
+	// Command palette (ctrl+p)
+	showCommandPalette bool
+	commandPalette     CommandPaletteModel
+	paletteReturnFocus focus // Focus to restore when the palette closes
+
+	// Pinned split layout (|, <, >)
+	layout            Layout
+	layoutDir         string // Project dir the layout is saved to
+	showLayoutPicker  bool
+	layoutPicker      LayoutPickerModel
+	layoutReturnFocus focus
+
 	// Repo picker (workspace mode)
 	showRepoPicker bool
 	repoPicker     RepoPickerModel
@@ -368,10 +439,23 @@ type Model struct {
 	closedIssueIDs   map[string]bool // Issues in diff.ClosedIssues
 	modifiedIssueIDs map[string]bool // Issues in diff.ModifiedIssues
 
+	// Time-travel playback (weekly snapshots stepping through the views)
+	playback        PlaybackModel
+	playbackLoading bool
+
 	// Time-travel input prompt
 	timeTravelInput      textinput.Model
 	showTimeTravelPrompt bool
 
+	// Comment input prompt
+	commentInput       textinput.Model
+	showCommentPrompt  bool
+	commentIssueID     string
+	commentReturnFocus focus // Focus to restore when the prompt closes
+
+	// Undo/redo journal for write-backs, opened on first use
+	journal *loader.Journal
+
 	// Status message (for temporary feedback)
 	statusMsg     string
 	statusIsError bool
@@ -391,6 +475,38 @@ type Model struct {
 	alertsCursor    int
 	dismissedAlerts map[string]bool
 
+	// Change log of live reloads (N)
+	reloadLog       []ReloadChanges // Newest first
+	showChangeLog   bool
+	changeLogScroll int
+
+	// Personal queue (@)
+	myAssignee   string // Whose work the view shows ("" = unknown)
+	showMyWork   bool
+	myQueue      analysis.MyQueue
+	myWorkCursor int
+
+	// Dependency tree view (D)
+	showDepTree   bool
+	depTree       *analysis.DependencyTree
+	depTreeRows   []depTreeRow
+	depTreeCursor int
+
+	// Goals panel (%)
+	showGoals      bool
+	goals          []analysis.GoalForecast
+	goalsThreshold float64 // Probability below which a goal is at risk
+	goalsCursor    int
+
+	// Simulation sandbox (X)
+	showSandbox     bool
+	sandboxRows     []string // Open issue IDs, by priority
+	sandboxCursor   int
+	sandboxChanges  []analysis.SandboxChange
+	sandboxEdgeFrom string // Issue picked with b, waiting for the issue it will wait on
+	sandboxBefore   analysis.SandboxMetrics
+	sandboxDiff     analysis.SandboxDiff
+
 	// Sprint view (bv-161)
 	sprints        []model.Sprint
 	selectedSprint *model.Sprint
@@ -414,6 +530,10 @@ type Model struct {
 	// Self-update modal (bv-182)
 	showUpdateModal bool
 	updateModal     UpdateModal
+
+	// Cycle-break wizard (graph view, key B)
+	showCycleBreakWizard bool
+	cycleBreakWizard     CycleBreakWizard
 }
 
 // labelCount is a simple label->count pair for display
@@ -548,6 +668,7 @@ func (m *Model) updateListDelegate() {
 		PriorityHints:     m.priorityHints,
 		WorkspaceMode:     m.workspaceMode,
 		ShowSearchScores:  m.shouldShowSearchScores(),
+		Plain:             m.plain,
 	})
 }
 
@@ -672,35 +793,7 @@ func NewModel(issues []model.Issue, activeRecipe *recipe.Recipe, beadsPath strin
 	}
 
 	// Compute stats
-	cOpen, cReady, cBlocked, cClosed := 0, 0, 0, 0
-	for i := range issues {
-		issue := &issues[i]
-		if issue.Status == model.StatusClosed {
-			cClosed++
-			continue
-		}
-
-		cOpen++
-		if issue.Status == model.StatusBlocked {
-			cBlocked++
-			continue
-		}
-
-		// Check if blocked by open dependencies
-		isBlocked := false
-		for _, dep := range issue.Dependencies {
-			if dep == nil || !dep.Type.IsBlocking() {
-				continue
-			}
-			if blocker, exists := issueMap[dep.DependsOnID]; exists && blocker.Status != model.StatusClosed {
-				isBlocked = true
-				break
-			}
-		}
-		if !isBlocked {
-			cReady++
-		}
-	}
+	readiness := analysis.NewReadinessIndex(issueMap)
 
 	// Theme
 	theme := DefaultTheme(lipgloss.NewRenderer(os.Stdout))
@@ -864,6 +957,9 @@ func NewModel(issues []model.Issue, activeRecipe *recipe.Recipe, beadsPath strin
 	return Model{
 		issues:                 issues,
 		issueMap:               issueMap,
+		mentions:               analysis.BuildMentionIndex(issues),
+		imageProtocol:          detectImageProtocol(os.Getenv),
+		imagePreviews:          make(map[string]string),
 		analyzer:               analyzer,
 		analysis:               graphStats,
 		beadsPath:              beadsPath,
@@ -890,10 +986,11 @@ func NewModel(issues []model.Issue, activeRecipe *recipe.Recipe, beadsPath strin
 		ready:               true,
 		width:               defaultWidth,
 		height:              defaultHeight,
-		countOpen:           cOpen,
-		countReady:          cReady,
-		countBlocked:        cBlocked,
-		countClosed:         cClosed,
+		countOpen:           readiness.Open,
+		countReady:          readiness.Ready,
+		countBlocked:        readiness.Blocked,
+		countClosed:         readiness.Closed,
+		readiness:           readiness,
 		priorityHints:       priorityHints,
 		showPriorityHints:   false, // Off by default, toggle with 'p'
 		triageScores:        triageScores,
@@ -905,8 +1002,10 @@ func NewModel(issues []model.Issue, activeRecipe *recipe.Recipe, beadsPath strin
 		recipePicker:        recipePicker,
 		activeRecipe:        activeRecipe,
 		labelPicker:         labelPicker,
+		commandPalette:      NewCommandPaletteModel(theme),
 		labelDrilldownCache: make(map[string][]model.Issue),
 		timeTravelInput:     ti,
+		commentInput:        newCommentInput(theme),
 		statusMsg:           initialStatus,
 		statusIsError:       initialStatusErr,
 		historyLoading:      len(issues) > 0, // Will be loaded in Init()
@@ -916,6 +1015,8 @@ func NewModel(issues []model.Issue, activeRecipe *recipe.Recipe, beadsPath strin
 		alertsWarning:   alertsWarning,
 		alertsInfo:      alertsInfo,
 		dismissedAlerts: make(map[string]bool),
+		// Personal queue defaults to the local bd actor
+		myAssignee: analysis.CurrentActor(),
 		// Sprint view (bv-161)
 		sprints: sprints,
 		// AGENTS.md integration (bv-i8dk) - workDir derived from beadsPath
@@ -1070,6 +1171,18 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		}
 
+	case focusTickMsg:
+		var cmd tea.Cmd
+		m, cmd = m.handleFocusTick(msg, time.Now())
+		return m, cmd
+
+	case editorFinishedMsg:
+		if msg.err != nil {
+			m.statusMsg = fmt.Sprintf("❌ Editor: %v", msg.err)
+			m.statusIsError = true
+		}
+		return m, nil
+
 	case semanticDebounceTickMsg:
 		// Debounce timer expired - check if we should trigger semantic computation
 		if m.semanticSearchEnabled && m.semanticSearch != nil && m.list.FilterState() != list.Unfiltered {
@@ -1101,6 +1214,8 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// Set full recommendations with breakdown for priority radar (bv-93)
 		dataHash := fmt.Sprintf("v%s@%s#%d", triage.Meta.Version, triage.Meta.GeneratedAt.Format("15:04:05"), triage.Meta.IssueCount)
 		m.insightsPanel.SetRecommendations(triage.Recommendations, dataHash)
+		m.insightsPanel.SetQueues(analysis.AnalyzeQueues(m.issues, m.analyzer, analysis.QueueOptions{}, time.Now()))
+		m.insightsPanel.SetOwnership(m.busFactorReport())
 
 		// Generate priority recommendations now that Phase 2 is ready
 		recommendations := m.analyzer.GenerateRecommendations()
@@ -1109,6 +1224,14 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.priorityHints[recommendations[i].IssueID] = &recommendations[i]
 		}
 
+		// Learn labels from labeled issues to suggest them for unlabeled ones
+		m.labelClassifier = analysis.TrainLabelClassifier(m.issues)
+		m.loadTimeLog()
+		m.loadClaims()
+
+		// Scan for near-duplicates on a copy, since a re-sort below reorders m.issues
+		cmds = append(cmds, FindDuplicatesCmd(append([]model.Issue(nil), m.issues...), m.workDir, m.analysis))
+
 		// Refresh alerts now that full Phase 2 metrics (cycles, etc.) are available
 		m.alerts, m.alertsCritical, m.alertsWarning, m.alertsInfo = computeAlerts(m.issues, m.analysis, m.analyzer)
 
@@ -1120,6 +1243,9 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.labelHealthCached = true
 			m.labelDashboard.SetData(m.labelHealthCache.Labels)
 			m.statusMsg = fmt.Sprintf("Labels: %d total • critical %d • warning %d", m.labelHealthCache.TotalLabels, m.labelHealthCache.CriticalCount, m.labelHealthCache.WarningCount)
+			if cmd := m.requestLabelTrends(); cmd != nil {
+				cmds = append(cmds, cmd)
+			}
 		}
 
 		// Re-sort issues if sorting by Phase 2 metrics (impact/pagerank)
@@ -1154,6 +1280,42 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.applyFilter()
 		}
 
+	case LabelTrendsReadyMsg:
+		// Ignore trends from before a file reload
+		if msg.Stats != m.analysis {
+			return m, nil
+		}
+		m.labelDashboard.SetTrends(msg.Trends)
+
+	case PlaybackLoadedMsg:
+		m.playbackLoading = false
+		if msg.Err != nil {
+			m.statusMsg = fmt.Sprintf("❌ Playback failed: %v", msg.Err)
+			m.statusIsError = true
+			return m, nil
+		}
+		m.playback = NewPlaybackModel(msg.Frames)
+		m.statusMsg = ""
+		m.showPlaybackFrame()
+		return m, m.playback.Tick()
+
+	case playbackTickMsg:
+		if m.playback.Active() && m.playback.Advance(msg) {
+			m.showPlaybackFrame()
+			return m, m.playback.Tick()
+		}
+		return m, nil
+
+	case DuplicatesReadyMsg:
+		// Ignore scans from before a file reload
+		if msg.Stats != m.analysis {
+			return m, nil
+		}
+		m.duplicates = msg.ByIssue
+		if m.isSplitView || m.showDetails {
+			m.updateViewportContent()
+		}
+
 	case HistoryLoadedMsg:
 		// Background history loading completed
 		m.historyLoading = false
@@ -1164,12 +1326,20 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		} else if msg.Report != nil {
 			m.historyView = NewHistoryModel(msg.Report, m.theme)
 			m.historyView.SetSize(m.width, m.height-1)
+			m.insightsPanel.SetOwnership(m.busFactorReport())
 			// Refresh detail pane if visible
 			if m.isSplitView || m.showDetails {
 				m.updateViewportContent()
 			}
 		}
 
+	case ReloadHooksDoneMsg:
+		// Only failures are worth interrupting for; the reload status stays otherwise
+		if msg.Failed > 0 {
+			m.statusMsg = fmt.Sprintf("post-reload hooks: %d of %d failed (%s)", msg.Failed, msg.Total, msg.FirstError)
+			m.statusIsError = true
+		}
+
 	case AgentFileCheckMsg:
 		// AGENTS.md integration check (bv-i8dk)
 		if msg.ShouldPrompt && msg.FilePath != "" {
@@ -1200,6 +1370,8 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.closedIssueIDs = nil
 			m.modifiedIssueIDs = nil
 		}
+		m.playback = PlaybackModel{}
+		m.playbackLoading = false
 
 		// Reload issues from disk
 		// Use custom warning handler to prevent stderr pollution during TUI render (bv-fix)
@@ -1240,13 +1412,27 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return newIssues[i].CreatedAt.After(newIssues[j].CreatedAt)
 		})
 
-		// Recompute analysis (async Phase 1/Phase 2) with caching
+		// Diff against what was on screen before replacing it
+		changes := diffReload(m.issues, newIssues)
+
+		// Recompute analysis (async Phase 1/Phase 2) with caching. When the
+		// blocking graph is unchanged (edits to status, text, priority...),
+		// every graph metric carries over and nothing is recomputed.
+		delta := analysis.DiffForReload(m.issues, newIssues)
+		prevAnalyzer := m.analyzer
 		m.issues = newIssues
-		cachedAnalyzer := analysis.NewCachedAnalyzer(newIssues, nil)
-		m.analyzer = cachedAnalyzer.Analyzer
-		m.analysis = cachedAnalyzer.AnalyzeAsync(context.Background())
-		cacheHit := cachedAnalyzer.WasCacheHit()
+		m.explained = nil
+		cacheHit := false
+		if updated, stats, ok := m.analyzer.UpdateForReload(m.analysis, newIssues, delta); ok {
+			m.analyzer, m.analysis = updated, stats
+		} else {
+			cachedAnalyzer := analysis.NewCachedAnalyzer(newIssues, nil)
+			m.analyzer = cachedAnalyzer.Analyzer
+			m.analysis = cachedAnalyzer.AnalyzeAsync(context.Background())
+			cacheHit = cachedAnalyzer.WasCacheHit()
+		}
 		m.labelHealthCached = false
+		m.labelTrendsRequested = false
 		m.attentionCached = false
 
 		// Rebuild lookup map
@@ -1254,37 +1440,18 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		for i := range m.issues {
 			m.issueMap[m.issues[i].ID] = &m.issues[i]
 		}
+		m.mentions = analysis.BuildMentionIndex(m.issues)
 
 		// Clear stale priority hints (will be repopulated after Phase 2)
 		m.priorityHints = make(map[string]*analysis.PriorityRecommendation)
 
-		// Recompute stats
-		m.countOpen, m.countReady, m.countBlocked, m.countClosed = 0, 0, 0, 0
-		for i := range m.issues {
-			issue := &m.issues[i]
-			if issue.Status == model.StatusClosed {
-				m.countClosed++
-				continue
-			}
-			m.countOpen++
-			if issue.Status == model.StatusBlocked {
-				m.countBlocked++
-				continue
-			}
-			isBlocked := false
-			for _, dep := range issue.Dependencies {
-				if dep == nil || !dep.Type.IsBlocking() {
-					continue
-				}
-				if blocker, exists := m.issueMap[dep.DependsOnID]; exists && blocker.Status != model.StatusClosed {
-					isBlocked = true
-					break
-				}
-			}
-			if !isBlocked {
-				m.countReady++
-			}
+		// Recompute stats, reclassifying only the issues the reload touched
+		if m.readiness == nil {
+			m.readiness = analysis.NewReadinessIndex(m.issueMap)
+		} else {
+			m.readiness.Update(m.issueMap, analysis.AffectedByReload(prevAnalyzer, m.analyzer, delta))
 		}
+		m.countOpen, m.countReady, m.countBlocked, m.countClosed = m.readiness.Open, m.readiness.Ready, m.readiness.Blocked, m.readiness.Closed
 
 		// Recompute alerts for refreshed dataset
 		m.alerts, m.alertsCritical, m.alertsWarning, m.alertsInfo = computeAlerts(m.issues, m.analysis, m.analyzer)
@@ -1337,6 +1504,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		// Generate priority recommendations now that Phase 2 is ready
 		m.board = NewBoardModel(m.issues, m.theme)
+		m.board.SetWIPLimits(m.boardConfig)
 
 		// Re-apply recipe filter if active
 		if m.activeRecipe != nil {
@@ -1373,10 +1541,13 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			cmds = append(cmds, BuildSemanticIndexCmd(m.issues))
 		}
 
+		if m.hookConfig != nil && len(m.hookConfig.Hooks.PostReload) > 0 {
+			cmds = append(cmds, RunReloadHooksCmd(m.hookConfig, m.beadsPath, changes))
+		}
+
+		m.statusMsg = m.recordReload(changes)
 		if cacheHit {
-			m.statusMsg = fmt.Sprintf("Reloaded %d issues (cached)", len(newIssues))
-		} else {
-			m.statusMsg = fmt.Sprintf("Reloaded %d issues", len(newIssues))
+			m.statusMsg += " (cached)"
 		}
 		if len(reloadWarnings) > 0 {
 			m.statusMsg += fmt.Sprintf(" (%d warnings)", len(reloadWarnings))
@@ -1384,6 +1555,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.statusIsError = false
 		// Invalidate label-derived caches
 		m.labelHealthCached = false
+		m.labelTrendsRequested = false
 		m.labelDrilldownCache = make(map[string][]model.Issue)
 		m.updateViewportContent()
 
@@ -1399,6 +1571,22 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.statusMsg = ""
 		m.statusIsError = false
 
+		// Translate remapped keys to the keys the handlers below expect
+		keyCtx := m.CurrentContext()
+		if keyCtx == ContextFilter && m.list.FilterState() == list.FilterApplied {
+			keyCtx = ContextList // Typing is over; list keys apply again
+		}
+		if scope := KeyScopeForContext(keyCtx); scope != "" {
+			translated, ok := m.keymap.TranslateKeyMsg(scope, msg)
+			if !ok {
+				return m, nil
+			}
+			msg = translated
+			if m.usage != nil {
+				m.usage.pressed(scope, msg.String())
+			}
+		}
+
 		// Handle AGENTS.md prompt modal (bv-i8dk)
 		if m.showAgentPrompt {
 			m.agentPromptModal, cmd = m.agentPromptModal.Update(msg)
@@ -1447,6 +1635,30 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, tea.Batch(cmds...)
 		}
 
+		// Handle cycle-break wizard
+		if m.showCycleBreakWizard {
+			m.cycleBreakWizard, cmd = m.cycleBreakWizard.Update(msg)
+			cmds = append(cmds, cmd)
+
+			switch m.cycleBreakWizard.TakeAction() {
+			case CycleBreakApply:
+				m = m.applyCycleBreak()
+			case CycleBreakCopy:
+				if c, ok := m.cycleBreakWizard.Selected(); ok {
+					if _, err := copyToClipboard(CycleBreakCommand(c)); err != nil {
+						m.statusMsg = "Clipboard unavailable: " + CycleBreakCommand(c)
+						m.statusIsError = true
+					} else {
+						m.statusMsg = "Copied: " + CycleBreakCommand(c)
+					}
+				}
+			case CycleBreakClose:
+				m.showCycleBreakWizard = false
+				m.focused = focusGraph
+			}
+			return m, tea.Batch(cmds...)
+		}
+
 		// Handle self-update modal (bv-182)
 		if m.showUpdateModal {
 			m.updateModal, cmd = m.updateModal.Update(msg)
@@ -1644,6 +1856,43 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 
+		// Handle change log pane if open
+		if m.showChangeLog {
+			switch msg.String() {
+			case "j", "down":
+				m.changeLogScroll++
+			case "k", "up":
+				m.changeLogScroll = max(m.changeLogScroll-1, 0)
+			case "g", "home":
+				m.changeLogScroll = 0
+			case "esc", "q", "N":
+				m.showChangeLog = false
+			case "ctrl+c":
+				return m, tea.Quit
+			}
+			return m, nil
+		}
+
+		// Handle my-work view if open
+		if m.showMyWork {
+			return m.handleMyWorkKeys(msg)
+		}
+
+		// Handle dependency tree view if open
+		if m.showDepTree {
+			return m.handleDependencyTreeKeys(msg)
+		}
+
+		// Handle goals panel if open
+		if m.showGoals {
+			return m.handleGoalsKeys(msg)
+		}
+
+		// Handle the simulation sandbox if open
+		if m.showSandbox {
+			return m.handleSandboxKeys(msg)
+		}
+
 		// Handle repo picker overlay (workspace mode) before global keys (esc/q/etc.)
 		if m.showRepoPicker {
 			if msg.String() == "ctrl+c" {
@@ -1855,9 +2104,63 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m = m.handleTimeTravelInputKeys(msg)
 			return m, nil
 		}
+		if m.focused == focusCommentInput {
+			if msg.String() == "ctrl+c" {
+				return m, tea.Quit
+			}
+			m = m.handleCommentInputKeys(msg)
+			return m, nil
+		}
+		if m.focused == focusCommandPalette {
+			if msg.String() == "ctrl+c" {
+				return m, tea.Quit
+			}
+			return m.handleCommandPaletteKeys(msg)
+		}
+		if m.focused == focusLayoutPicker {
+			if msg.String() == "ctrl+c" {
+				return m, tea.Quit
+			}
+			m = m.handleLayoutPickerKeys(msg)
+			return m, nil
+		}
+		if m.playback.Active() {
+			if handled, cmd := m.handlePlaybackKeys(msg); handled {
+				return m, cmd
+			}
+		}
 
 		// Handle keys when not filtering
 		if m.list.FilterState() != list.Filtering {
+			// gd in the detail pane follows the topmost issue ID on screen;
+			// g followed by anything else is handled as usual (gg toggles
+			// the graph view)
+			if m.pendingGoto {
+				m.pendingGoto = false
+				if msg.String() == "d" {
+					if id := m.visibleMention(); id != "" {
+						m = m.jumpToMention(id)
+					}
+					return m, nil
+				}
+			} else if m.pendingAttachment {
+				// A with several attachments waits for the number to open
+				m.pendingAttachment = false
+				if key := msg.String(); len(key) == 1 && key >= "1" && key <= "9" {
+					m = m.openAttachmentNumber(int(key[0] - '0'))
+					return m, nil
+				} else if key == "esc" {
+					return m, nil
+				}
+			} else if msg.String() == "g" && m.focused == focusDetail {
+				if id := m.visibleMention(); id != "" {
+					m.pendingGoto = true
+					m.statusMsg = fmt.Sprintf("g… d: go to %s · g: graph view", id)
+					m.statusIsError = false
+					return m, nil
+				}
+			}
+
 			switch msg.String() {
 			case "ctrl+c":
 				return m, tea.Quit
@@ -1954,6 +2257,10 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return m, nil
 
 			case "tab":
+				if m.layoutActive() {
+					m = m.focusNextPane()
+					return m, nil
+				}
 				if m.isSplitView && !m.isBoardView {
 					if m.focused == focusList {
 						m.focused = focusDetail
@@ -2028,6 +2335,8 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 						// Set full recommendations with breakdown for priority radar (bv-93)
 						dataHash := fmt.Sprintf("v%s@%s#%d", triage.Meta.Version, triage.Meta.GeneratedAt.Format("15:04:05"), triage.Meta.IssueCount)
 						m.insightsPanel.SetRecommendations(triage.Recommendations, dataHash)
+						m.insightsPanel.SetQueues(analysis.AnalyzeQueues(m.issues, m.analyzer, analysis.QueueOptions{}, time.Now()))
+						m.insightsPanel.SetOwnership(m.busFactorReport())
 						panelHeight := m.height - 2
 						if panelHeight < 3 {
 							panelHeight = 3
@@ -2037,6 +2346,64 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				}
 				return m, nil
 
+			case "ctrl+t":
+				m.cycleTheme()
+				return m, nil
+
+			case "ctrl+p":
+				// Pickers use ctrl+p to move up
+				if m.pickerFocused() {
+					break
+				}
+				m = m.openCommandPalette()
+				return m, nil
+
+			case "N":
+				// Board search uses N for the previous match
+				if m.pickerFocused() || m.focused == focusBoard {
+					break
+				}
+				m.showChangeLog = true
+				m.changeLogScroll = 0
+				return m, nil
+
+			case "@":
+				if m.pickerFocused() {
+					break
+				}
+				m = m.openMyWork()
+				return m, nil
+
+			case "|":
+				if m.pickerFocused() {
+					break
+				}
+				m = m.openLayoutPicker()
+				return m, nil
+
+			case "<", ">":
+				if m.pickerFocused() {
+					break
+				}
+				delta := splitRatioStep
+				if msg.String() == "<" {
+					delta = -delta
+				}
+				m = m.adjustSplitRatio(delta)
+				return m, nil
+
+			case "u", "ctrl+r":
+				// Pickers take typed input; let them have the key
+				if m.pickerFocused() {
+					break
+				}
+				if msg.String() == "u" {
+					m = m.undoEdit()
+				} else {
+					m = m.redoEdit()
+				}
+				return m, nil
+
 			case "p":
 				// Toggle priority hints
 				m.showPriorityHints = !m.showPriorityHints
@@ -2093,7 +2460,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.labelDashboard.SetSize(m.width, m.height-1)
 				m.statusMsg = fmt.Sprintf("Labels: %d total • critical %d • warning %d", m.labelHealthCache.TotalLabels, m.labelHealthCache.CriticalCount, m.labelHealthCache.WarningCount)
 				m.statusIsError = false
-				return m, nil
+				return m, m.requestLabelTrends()
 
 			case "]", "f4":
 				// Attention view: compute attention scores (cached) and render as text
@@ -2156,6 +2523,16 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				}
 				return m, nil
 
+			case "%":
+				// Goals and their chance of making the target date
+				m = m.openGoals()
+				return m, nil
+
+			case "X":
+				// What-if sandbox over the plan, forecast and critical path
+				m = m.openSandbox()
+				return m, nil
+
 			case "'", "f5":
 				// Toggle recipe picker overlay
 				m.showRecipePicker = !m.showRecipePicker
@@ -2263,6 +2640,11 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m = m.handleActionableKeys(msg)
 
 			case focusHistory:
+				if msg.String() == "o" && !m.historyView.IsSearchActive() && !m.historyView.FileTreeHasFocus() {
+					m, cmd = m.openCommitInEditor()
+					cmds = append(cmds, cmd)
+					break
+				}
 				m = m.handleHistoryKeys(msg)
 
 			case focusSprint:
@@ -2272,9 +2654,54 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m = m.handleFlowMatrixKeys(msg)
 
 			case focusList:
+				if msg.String() == "P" {
+					// Focus timer needs its tick command, which handleListKeys can't return
+					m, cmd = m.toggleFocusTimer()
+					cmds = append(cmds, cmd)
+					break
+				}
+				if msg.String() == "R" {
+					// Playback loads in the background
+					m, cmd = m.startPlayback()
+					cmds = append(cmds, cmd)
+					break
+				}
 				m = m.handleListKeys(msg)
 
 			case focusDetail:
+				if msg.String() == "M" {
+					m = m.openCommentPrompt()
+					break
+				}
+				if msg.String() == "L" {
+					m = m.applySuggestedLabels()
+					break
+				}
+				if msg.String() == "W" {
+					m = m.toggleTimeTracking()
+					break
+				}
+				if msg.String() == "v" {
+					m = m.toggleRawDetail()
+					break
+				}
+				if msg.String() == "E" {
+					m = m.toggleExplainDetail()
+					break
+				}
+				if msg.String() == "D" {
+					m = m.openDependencyTree()
+					break
+				}
+				if msg.String() == "A" {
+					m = m.openAttachmentKey()
+					break
+				}
+				if msg.String() == "P" {
+					m, cmd = m.toggleFocusTimer()
+					cmds = append(cmds, cmd)
+					break
+				}
 				m.viewport, cmd = m.viewport.Update(msg)
 				cmds = append(cmds, cmd)
 			}
@@ -2342,45 +2769,13 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
-		m.isSplitView = msg.Width > SplitViewThreshold
 		m.ready = true
 		bodyHeight := m.height - 1 // keep 1 row for footer
 		if bodyHeight < 5 {
 			bodyHeight = 5
 		}
 
-		if m.isSplitView {
-			// Calculate dimensions accounting for 2 panels with borders(2)+padding(2) = 4 overhead each
-			// Total overhead = 8
-			availWidth := msg.Width - 8
-			if availWidth < 10 {
-				availWidth = 10
-			}
-
-			listInnerWidth := int(float64(availWidth) * 0.4)
-			detailInnerWidth := availWidth - listInnerWidth
-
-			// listHeight fits header (1) + page line (1) inside a panel with Border (2)
-			listHeight := bodyHeight - 4
-			if listHeight < 3 {
-				listHeight = 3
-			}
-
-			m.list.SetSize(listInnerWidth, listHeight)
-			m.viewport = viewport.New(detailInnerWidth, bodyHeight-2) // Account for border
-
-			m.renderer.SetWidthWithTheme(detailInnerWidth, m.theme)
-		} else {
-			listHeight := bodyHeight - 2
-			if listHeight < 3 {
-				listHeight = 3
-			}
-			m.list.SetSize(msg.Width, listHeight)
-			m.viewport = viewport.New(msg.Width, bodyHeight-1)
-
-			// Update renderer for full width
-			m.renderer.SetWidthWithTheme(msg.Width, m.theme)
-		}
+		m.resizePanes()
 
 		m.updateListDelegate()
 
@@ -2419,6 +2814,12 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	if m.isSplitView && m.focused == focusList {
 		m.updateViewportContent()
 	}
+	// Keep a pinned graph pane on the selected issue
+	if m.focused == focusList && m.layout.Has(PanelGraph) && m.layoutActive() {
+		if item, ok := m.list.SelectedItem().(IssueItem); ok {
+			m.graphView.SelectByID(item.Issue.ID)
+		}
+	}
 
 	// Trigger async semantic computation if needed (debounced)
 	if m.semanticSearchEnabled && m.semanticSearch != nil && m.list.FilterState() != list.Unfiltered {
@@ -2511,6 +2912,8 @@ func (m Model) handleBoardKeys(msg tea.KeyMsg) Model {
 		m.board.JumpToColumn(ColBlocked)
 	case "4":
 		m.board.JumpToColumn(ColClosed)
+	case "5":
+		m.board.JumpToColumn(ColInReview)
 	case "H":
 		m.board.JumpToFirstColumn()
 	case "L":
@@ -2541,13 +2944,7 @@ func (m Model) handleBoardKeys(msg tea.KeyMsg) Model {
 	// Copy ID to clipboard (bv-yg39)
 	case "y":
 		if selected := m.board.SelectedIssue(); selected != nil {
-			if err := clipboard.WriteAll(selected.ID); err != nil {
-				m.statusMsg = fmt.Sprintf("❌ Clipboard error: %v", err)
-				m.statusIsError = true
-			} else {
-				m.statusMsg = fmt.Sprintf("📋 Copied %s to clipboard", selected.ID)
-				m.statusIsError = false
-			}
+			m.copyWithStatus(selected.ID, selected.ID)
 		}
 
 	// Global filter keys (bv-naov) - consistent with list view
@@ -2574,6 +2971,35 @@ func (m Model) handleBoardKeys(msg tea.KeyMsg) Model {
 		m.statusMsg = fmt.Sprintf("🔀 Swimlane: %s", modeName)
 		m.statusIsError = false
 
+	// Horizontal lanes: grouping, lane jumps and folding
+	case "v":
+		if m.board.GetLaneGrouping() == LaneByAssignee && !m.board.HasTracks() && m.analyzer != nil {
+			m.board.SetTracks(m.analyzer.GetTrackMembership()) // Next grouping is by track
+		}
+		m.board.CycleLaneGrouping()
+		if m.board.GetLaneGrouping() == LaneNone {
+			m.statusMsg = "🏊 Lanes: off"
+		} else {
+			m.statusMsg = fmt.Sprintf("🏊 Lanes: %s (%d lanes, z folds, J/K jump)", m.board.GetLaneGroupingName(), m.board.LaneCount())
+		}
+		m.statusIsError = false
+	case "J":
+		m.board.NextLane()
+	case "K":
+		m.board.PrevLane()
+	case "z":
+		m.board.ToggleLane()
+		if lane := m.board.CurrentLane(); lane != "" {
+			state := "expanded"
+			if m.board.IsLaneCollapsed() {
+				state = "folded"
+			}
+			m.statusMsg = fmt.Sprintf("🏊 Lane %s %s", lane, state)
+			m.statusIsError = false
+		}
+	case "Z":
+		m.board.ToggleAllLanes()
+
 	// Empty column visibility toggle (bv-tf6j)
 	case "e":
 		m.board.ToggleEmptyColumns()
@@ -2629,6 +3055,7 @@ func (m Model) handleBoardKeys(msg tea.KeyMsg) Model {
 			m.updateViewportContent()
 		}
 	}
+	m.board.syncLaneCursor()
 	return m
 }
 
@@ -2651,6 +3078,16 @@ func (m Model) handleGraphKeys(msg tea.KeyMsg) Model {
 		m.graphView.ScrollLeft()
 	case "L":
 		m.graphView.ScrollRight()
+	case "B":
+		steps := m.analyzer.CycleBreakPlan()
+		if len(steps) == 0 {
+			m.statusMsg = "No dependency cycles detected"
+			return m
+		}
+		m.cycleBreakWizard = NewCycleBreakWizard(steps, m.issues, m.theme)
+		m.cycleBreakWizard.SetSize(m.width, m.height)
+		m.showCycleBreakWizard = true
+		m.focused = focusCycleBreak
 	case "enter":
 		if selected := m.graphView.SelectedIssue(); selected != nil {
 			// Find and select in list
@@ -2675,6 +3112,39 @@ func (m Model) handleGraphKeys(msg tea.KeyMsg) Model {
 	return m
 }
 
+// applyCycleBreak removes the wizard's selected dependency from the beads file.
+// The file watcher reloads the data, which recomputes cycles everywhere else.
+func (m Model) applyCycleBreak() Model {
+	c, ok := m.cycleBreakWizard.Selected()
+	if !ok {
+		return m
+	}
+	if m.beadsPath == "" {
+		m.statusMsg = "No single data file to edit; run: " + CycleBreakCommand(c)
+		m.statusIsError = true
+		return m
+	}
+	edit, err := loader.RemoveDependency(m.beadsPath, c.From, c.To)
+	switch {
+	case err != nil:
+		m.statusMsg = "Failed to remove dependency: " + err.Error()
+		m.statusIsError = true
+	case edit == nil:
+		m.statusMsg = fmt.Sprintf("Dependency %s → %s not found in %s", c.From, c.To, filepath.Base(m.beadsPath))
+		m.statusIsError = true
+	default:
+		action := fmt.Sprintf("Removed %s → %s", c.From, c.To)
+		m.statusMsg = fmt.Sprintf("✓ %s (sync with: %s)", action, CycleBreakCommand(c))
+		m = m.recordEdit(action, edit)
+		m.cycleBreakWizard.MarkResolved(action)
+		if m.cycleBreakWizard.Done() {
+			m.showCycleBreakWizard = false
+			m.focused = focusGraph
+		}
+	}
+	return m
+}
+
 // handleActionableKeys handles keyboard input when actionable view is focused
 func (m Model) handleActionableKeys(msg tea.KeyMsg) Model {
 	switch msg.String() {
@@ -2878,13 +3348,7 @@ func (m Model) handleHistoryKeys(msg tea.KeyMsg) Model {
 			}
 		}
 		if sha != "" {
-			if err := clipboard.WriteAll(sha); err != nil {
-				m.statusMsg = fmt.Sprintf("❌ Clipboard error: %v", err)
-				m.statusIsError = true
-			} else {
-				m.statusMsg = fmt.Sprintf("📋 Copied %s to clipboard", shortSHA)
-				m.statusIsError = false
-			}
+			m.copyWithStatus(sha, shortSHA)
 		} else {
 			m.statusMsg = "❌ No commit selected"
 			m.statusIsError = true
@@ -2910,7 +3374,7 @@ func (m Model) handleHistoryKeys(msg tea.KeyMsg) Model {
 			m.statusMsg = "📁 File tree hidden"
 		}
 		m.statusIsError = false
-	case "o":
+	case "O":
 		// Open commit in browser (bv-xf4p)
 		var sha string
 		if m.historyView.IsGitMode() {
@@ -3027,23 +3491,7 @@ func gitRemoteToWebURL(remote string) string {
 // openBrowserURL opens a URL in the default browser (bv-xf4p)
 // Set BV_NO_BROWSER=1 to suppress browser opening (useful for tests).
 func openBrowserURL(url string) error {
-	// Skip browser opening in test mode or when explicitly disabled
-	if os.Getenv("BV_NO_BROWSER") != "" || os.Getenv("BV_TEST_MODE") != "" {
-		return nil
-	}
-
-	var cmd *exec.Cmd
-	switch runtime.GOOS {
-	case "darwin":
-		cmd = exec.Command("open", url)
-	case "linux":
-		cmd = exec.Command("xdg-open", url)
-	case "windows":
-		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
-	default:
-		return fmt.Errorf("unsupported platform: %s", runtime.GOOS)
-	}
-	return cmd.Start()
+	return export.OpenInBrowser(url)
 }
 
 // handleFlowMatrixKeys handles keyboard input when flow matrix view is focused
@@ -3170,10 +3618,7 @@ func (m Model) handleLabelPickerKeys(msg tea.KeyMsg) Model {
 		m.labelPicker.MoveUp()
 	case "enter":
 		if selected := m.labelPicker.SelectedLabel(); selected != "" {
-			m.currentFilter = "label:" + selected
-			m.applyFilter()
-			m.statusMsg = fmt.Sprintf("Filtered by label: %s", selected)
-			m.statusIsError = false
+			m.filterByLabel(selected)
 		}
 		m.showLabelPicker = false
 		m.focused = focusList
@@ -3184,6 +3629,14 @@ func (m Model) handleLabelPickerKeys(msg tea.KeyMsg) Model {
 	return m
 }
 
+// filterByLabel narrows the list to issues carrying label.
+func (m *Model) filterByLabel(label string) {
+	m.currentFilter = "label:" + label
+	m.applyFilter()
+	m.statusMsg = fmt.Sprintf("Filtered by label: %s", label)
+	m.statusIsError = false
+}
+
 // handleInsightsKeys handles keyboard input when insights panel is focused
 func (m Model) handleInsightsKeys(msg tea.KeyMsg) Model {
 	switch msg.String() {
@@ -3282,6 +3735,9 @@ func (m Model) handleListKeys(msg tea.KeyMsg) Model {
 	case "r":
 		m.currentFilter = "ready"
 		m.applyFilter()
+	case "I":
+		m.currentFilter = "review"
+		m.applyFilter()
 	case "a":
 		m.currentFilter = "all"
 		m.applyFilter()
@@ -3309,6 +3765,27 @@ func (m Model) handleListKeys(msg tea.KeyMsg) Model {
 	case "O":
 		// Open beads.jsonl in editor
 		m.openInEditor()
+	case "M":
+		// Add a comment to the selected issue
+		m = m.openCommentPrompt()
+	case "L":
+		// Apply suggested labels to the selected unlabeled issue
+		m = m.applySuggestedLabels()
+	case "W":
+		// Start or stop the work timer on the selected issue
+		m = m.toggleTimeTracking()
+	case "v":
+		// Switch the detail pane between rendered and raw markdown
+		m = m.toggleRawDetail()
+	case "E":
+		// Switch the detail pane to why the issue ranks where it does
+		m = m.toggleExplainDetail()
+	case "D":
+		// Show the blocker and unblock trees of the selected issue
+		m = m.openDependencyTree()
+	case "A":
+		// Open an attachment of the selected issue with the system viewer
+		m = m.openAttachmentKey()
 	case "h":
 		// Toggle history view
 		if !m.isHistoryView {
@@ -3451,6 +3928,9 @@ func (m Model) View() string {
 	if !m.ready {
 		return "Initializing..."
 	}
+	if m.usage != nil {
+		defer m.usage.rendered(m.CurrentContext(), time.Now())
+	}
 
 	var body string
 
@@ -3466,6 +3946,8 @@ func (m Model) View() string {
 	} else if m.showUpdateModal {
 		// Self-update modal (bv-182)
 		body = m.updateModal.CenterModal(m.width, m.height-1)
+	} else if m.showCycleBreakWizard {
+		body = m.cycleBreakWizard.CenterModal(m.width, m.height-1)
 	} else if m.showLabelHealthDetail && m.labelHealthDetail != nil {
 		body = m.renderLabelHealthDetail(*m.labelHealthDetail)
 	} else if m.showLabelGraphAnalysis && m.labelGraphAnalysisResult != nil {
@@ -3474,12 +3956,28 @@ func (m Model) View() string {
 		body = m.renderLabelDrilldown()
 	} else if m.showAlertsPanel {
 		body = m.renderAlertsPanel()
+	} else if m.showChangeLog {
+		body = m.renderChangeLog()
+	} else if m.showMyWork {
+		body = m.renderMyWork()
+	} else if m.showDepTree {
+		body = m.renderDependencyTree()
+	} else if m.showGoals {
+		body = m.renderGoals()
+	} else if m.showSandbox {
+		body = m.renderSandbox()
 	} else if m.showTimeTravelPrompt {
 		body = m.renderTimeTravelPrompt()
+	} else if m.showCommentPrompt {
+		body = m.renderCommentPrompt()
 	} else if m.showRecipePicker {
 		body = m.recipePicker.View()
 	} else if m.showRepoPicker {
 		body = m.repoPicker.View()
+	} else if m.showCommandPalette {
+		body = m.commandPalette.View()
+	} else if m.showLayoutPicker {
+		body = m.layoutPicker.View(m.width, m.height-1)
 	} else if m.showLabelPicker {
 		body = m.labelPicker.View()
 	} else if m.showHelp {
@@ -3487,6 +3985,8 @@ func (m Model) View() string {
 	} else if m.showTutorial {
 		// Interactive tutorial (bv-8y31) - full screen overlay
 		body = m.tutorialModel.View()
+	} else if m.layoutActive() {
+		body = m.renderSplitView()
 	} else if m.focused == focusInsights {
 		m.insightsPanel.SetSize(m.width, m.height-1)
 		body = m.insightsPanel.View()
@@ -3537,7 +4037,14 @@ func (m Model) View() string {
 		Height(m.height).
 		MaxHeight(m.height)
 
-	return finalStyle.Render(lipgloss.JoinVertical(lipgloss.Left, body, footer))
+	view := finalStyle.Render(lipgloss.JoinVertical(lipgloss.Left, body, footer))
+	if m.plain {
+		view = stripBoxDrawing(view)
+	}
+	if m.ascii {
+		view = toASCII(view)
+	}
+	return view
 }
 
 func (m Model) renderQuitConfirm() string {
@@ -3597,6 +4104,9 @@ func (m Model) renderListWithHeader() string {
 		headerText = "  REPO TYPE PRI STATUS      ID                               TITLE"
 	}
 	header := headerStyle.Render(headerText)
+	if m.plain {
+		header = ""
+	}
 
 	// Page info
 	totalItems := len(m.list.Items())
@@ -3657,21 +4167,19 @@ func (m Model) renderListWithHeader() string {
 }
 
 func (m Model) renderSplitView() string {
-	t := m.theme
-
-	var listStyle, detailStyle lipgloss.Style
+	left, right := m.splitPanes()
+	leftWidth, rightWidth := m.paneWidths()
+	panelHeight := m.height - 1
 
-	if m.focused == focusList {
-		listStyle = FocusedPanelStyle
-		detailStyle = PanelStyle
-	} else {
-		listStyle = PanelStyle
-		detailStyle = FocusedPanelStyle
-	}
+	return lipgloss.JoinHorizontal(lipgloss.Top,
+		m.renderPane(left, leftWidth, panelHeight),
+		m.renderPane(right, rightWidth, panelHeight))
+}
 
-	// m.list.Width() is the inner width (set in Update)
-	listInnerWidth := m.list.Width()
-	panelHeight := m.height - 1
+// renderListPanelContent renders the list with its column header and page
+// indicator for a split-view pane.
+func (m Model) renderListPanelContent(listInnerWidth, panelHeight int) string {
+	t := m.theme
 
 	// Create header row for list
 	headerStyle := t.Renderer.NewStyle().
@@ -3681,6 +4189,9 @@ func (m Model) renderSplitView() string {
 		Width(listInnerWidth)
 
 	header := headerStyle.Render("  TYPE PRI STATUS      ID                     TITLE")
+	if m.plain {
+		header = ""
+	}
 
 	// Page info for list
 	totalItems := len(m.list.Items())
@@ -3716,24 +4227,12 @@ func (m Model) renderSplitView() string {
 	pageLine := pageStyle.Render(pageInfo)
 
 	// Combine header + list + page indicator
-	listContent := lipgloss.JoinVertical(lipgloss.Left, header, m.list.View(), pageLine)
-
-	// List Panel Width: Inner + 2 (Padding). Border adds another 2.
-	// Use MaxHeight to ensure content doesn't overflow
-	listView := listStyle.
-		Width(listInnerWidth + 2).
-		Height(panelHeight).
-		MaxHeight(panelHeight).
-		Render(listContent)
-
-	// Detail Panel Width: Inner + 2 (Padding). Border adds another 2.
-	detailView := detailStyle.
-		Width(m.viewport.Width + 2).
-		Height(panelHeight).
-		MaxHeight(panelHeight).
-		Render(m.viewport.View())
-
-	return lipgloss.JoinHorizontal(lipgloss.Top, listView, detailView)
+	return lipgloss.JoinVertical(lipgloss.Left, header, m.list.View(), pageLine)
+}
+
+// pickerFocused reports whether a picker that takes typed input has focus.
+func (m Model) pickerFocused() bool {
+	return m.focused == focusLabelPicker || m.focused == focusRecipePicker || m.focused == focusRepoPicker
 }
 
 func (m *Model) renderHelpOverlay() string {
@@ -3809,95 +4308,25 @@ func (m *Model) renderHelpOverlay() string {
 		return panelStyle.Render(content.String())
 	}
 
-	// Define all sections
-	navSection := []struct{ key, desc string }{
-		{"j / ↓", "Move down"},
-		{"k / ↑", "Move up"},
-		{"G/end", "Go to last"},
-		{"Ctrl+d", "Page down"},
-		{"Ctrl+u", "Page up"},
-		{"Tab", "Switch focus"},
-		{"Enter", "View details"},
-		{"Esc", "Back / close"},
-	}
-
-	viewsSection := []struct{ key, desc string }{
-		{"b", "Kanban board"},
-		{"g", "Graph view"},
-		{"i", "Insights"},
-		{"h", "History view"},
-		{"a", "Actionable"},
-		{"f", "Flow matrix"},
-		{"[", "Label dashboard"},
-		{"]", "Attention view"},
-	}
-
-	globalSection := []struct{ key, desc string }{
-		{"?", "This help"},
-		{";", "Shortcuts bar"},
-		{"!", "Alerts panel"},
-		{"'", "Recipes"},
-		{"w", "Repo picker"},
-		{"q", "Back / Quit"},
-		{"Ctrl+c", "Force quit"},
-	}
-
-	filterSection := []struct{ key, desc string }{
-		{"/", "Fuzzy search"},
-		{"Ctrl+S", "Semantic search"},
-		{"H", "Hybrid ranking"},
-		{"Alt+H", "Hybrid preset"},
-		{"o", "Open issues"},
-		{"c", "Closed issues"},
-		{"r", "Ready (unblocked)"},
-		{"l", "Filter by label"},
-		{"s", "Cycle sort"},
-		{"S", "Triage sort"},
-	}
-
-	graphSection := []struct{ key, desc string }{
-		{"hjkl", "Navigate nodes"},
-		{"H/L", "Scroll left/right"},
-		{"PgUp/Dn", "Scroll up/down"},
-		{"Enter", "Jump to issue"},
-	}
-
-	insightsSection := []struct{ key, desc string }{
-		{"h/l/Tab", "Switch panels"},
-		{"j/k", "Navigate items"},
-		{"e", "Explanations"},
-		{"x", "Calc details"},
-		{"m", "Toggle heatmap"},
-		{"Enter", "Jump to issue"},
-	}
-
-	historySection := []struct{ key, desc string }{
-		{"j/k", "Navigate beads"},
-		{"J/K", "Navigate commits"},
-		{"Tab", "Toggle focus"},
-		{"y", "Copy SHA"},
-		{"c", "Confidence filter"},
-	}
-
-	actionsSection := []struct{ key, desc string }{
-		{"p", "Priority hints"},
-		{"t", "Time-travel"},
-		{"T", "Quick time-travel"},
-		{"x", "Export markdown"},
-		{"C", "Copy to clipboard"},
-		{"O", "Open in editor"},
-	}
-
-	// Build panels
-	panels := []string{
-		renderPanel("Navigation", "🧭", 0, navSection),
-		renderPanel("Views", "👁", 1, viewsSection),
-		renderPanel("Global", "🌐", 2, globalSection),
-		renderPanel("Filters & Sort", "🔍", 3, filterSection),
-		renderPanel("Graph View", "📊", 4, graphSection),
-		renderPanel("Insights", "💡", 5, insightsSection),
-		renderPanel("History", "📜", 0, historySection),
-		renderPanel("Actions", "⚡", 1, actionsSection),
+	// Build panels from the active keymap so remapped keys show up here
+	icons := map[string]string{
+		"Navigation":     "🧭",
+		"Views":          "👁",
+		"Global":         "🌐",
+		"Filters & Sort": "🔍",
+		"Board":          "📋",
+		"Graph View":     "📊",
+		"Insights":       "💡",
+		"History":        "📜",
+		"Actions":        "⚡",
+	}
+	var panels []string
+	for i, section := range m.keymap.HelpSections() {
+		shortcuts := make([]struct{ key, desc string }, len(section.Entries))
+		for j, e := range section.Entries {
+			shortcuts[j] = struct{ key, desc string }{e.Key, e.Desc}
+		}
+		panels = append(panels, renderPanel(section.Title, icons[section.ID], i, shortcuts))
 	}
 
 	// Arrange panels into columns
@@ -3931,8 +4360,17 @@ func (m *Model) renderHelpOverlay() string {
 		Foreground(t.Secondary).
 		Italic(true)
 
-	title := titleStyle.Render("⌨️  Keyboard Shortcuts")
-	subtitle := subtitleStyle.Render("Space: Tutorial │ ? or Esc to close")
+	title := titleStyle.Render("⌨️  " + i18n.T("Keyboard Shortcuts"))
+	helpKeys := m.keymap.Keys("help")
+	helpKey := "?"
+	if len(helpKeys) > 0 {
+		helpKey = DisplayKey(helpKeys[0])
+	}
+	subtitleText := i18n.Tf("Space: Tutorial │ %s or Esc to close", helpKey)
+	if m.keymap.IsCustomized() {
+		subtitleText += " │ " + i18n.T("custom keymap")
+	}
+	subtitle := subtitleStyle.Render(subtitleText)
 	titleBar := lipgloss.JoinHorizontal(lipgloss.Center, title, "  ", subtitle)
 
 	// Combine title and body
@@ -4441,34 +4879,42 @@ func (m *Model) renderFooter() string {
 		return lipgloss.JoinHorizontal(lipgloss.Bottom, msgSection, filler)
 	}
 
+	// Playback replaces the status bar with its scrub bar
+	if m.playback.Active() {
+		return m.playback.FooterView(m.width)
+	}
+
 	// ─────────────────────────────────────────────────────────────────────────
 	// FILTER BADGE - Current view/filter state + quick hint for label dashboard
 	// ─────────────────────────────────────────────────────────────────────────
 	var filterTxt string
 	var filterIcon string
 	if m.focused == focusLabelDashboard {
-		filterTxt = "LABELS: j/k nav • h detail • d drilldown • enter filter"
+		filterTxt = i18n.T("LABELS: j/k nav • h detail • d drilldown • enter filter")
 		filterIcon = "🏷️"
 	} else if m.showLabelGraphAnalysis && m.labelGraphAnalysisResult != nil {
-		filterTxt = fmt.Sprintf("GRAPH %s: esc/q/g close", m.labelGraphAnalysisResult.Label)
+		filterTxt = i18n.Tf("GRAPH %s: esc/q/g close", m.labelGraphAnalysisResult.Label)
 		filterIcon = "📊"
 	} else if m.showLabelDrilldown && m.labelDrilldownLabel != "" {
-		filterTxt = fmt.Sprintf("LABEL %s: enter filter • g graph • esc/q/d close", m.labelDrilldownLabel)
+		filterTxt = i18n.Tf("LABEL %s: enter filter • g graph • esc/q/d close", m.labelDrilldownLabel)
 		filterIcon = "🏷️"
 	} else {
 		switch m.currentFilter {
 		case "all":
-			filterTxt = "ALL"
+			filterTxt = i18n.T("ALL")
 			filterIcon = "📋"
 		case "open":
-			filterTxt = "OPEN"
+			filterTxt = i18n.T("OPEN")
 			filterIcon = "📂"
 		case "closed":
-			filterTxt = "CLOSED"
+			filterTxt = i18n.T("CLOSED")
 			filterIcon = "✅"
 		case "ready":
-			filterTxt = "READY"
+			filterTxt = i18n.T("READY")
 			filterIcon = "🚀"
+		case "review":
+			filterTxt = i18n.T("IN REVIEW")
+			filterIcon = "👀"
 		default:
 			if strings.HasPrefix(m.currentFilter, "recipe:") {
 				filterTxt = strings.ToUpper(m.currentFilter[7:])
@@ -4551,7 +4997,7 @@ func (m *Model) renderFooter() string {
 				Foreground(ColorMuted).
 				Background(ColorBgDark).
 				Padding(0, 1).
-				Render(fmt.Sprintf("%s1-4:col • o/c/r:filter • L:labels • /:search • ?:help", filterInfo))
+				Render(fmt.Sprintf("%s1-5:col • o/c/r:filter • L:labels • /:search • ?:help", filterInfo))
 		}
 	} else if m.showAttentionView {
 		labelHint = lipgloss.NewStyle().
@@ -4672,6 +5118,11 @@ func (m *Model) renderFooter() string {
 		sessionSection = sessionStyle.Render(fmt.Sprintf("📎%s", countStr))
 	}
 
+	// ─────────────────────────────────────────────────────────────────────────
+	// FOCUS TIMER - Countdown for the claimed issue being focused on
+	// ─────────────────────────────────────────────────────────────────────────
+	focusSection := m.renderFocusSection(time.Now())
+
 	// ─────────────────────────────────────────────────────────────────────────
 	// WORKSPACE BADGE - Multi-repo mode indicator
 	// ─────────────────────────────────────────────────────────────────────────
@@ -4719,13 +5170,17 @@ func (m *Model) renderFooter() string {
 		keyHints = append(keyHints, keyStyle.Render("j/k")+" nav", keyStyle.Render("space")+" toggle", keyStyle.Render("⏎")+" apply", keyStyle.Render("esc")+" cancel")
 	} else if m.showLabelPicker {
 		keyHints = append(keyHints, "type to filter", keyStyle.Render("j/k")+" nav", keyStyle.Render("⏎")+" apply", keyStyle.Render("esc")+" cancel")
+	} else if m.showLayoutPicker {
+		keyHints = append(keyHints, keyStyle.Render("h/l")+" column", keyStyle.Render("j/k")+" panel", keyStyle.Render("⏎")+" pin", keyStyle.Render("esc")+" cancel")
+	} else if m.showCommandPalette {
+		keyHints = append(keyHints, "type to search", keyStyle.Render("↑/↓")+" nav", keyStyle.Render("⏎")+" run", keyStyle.Render("esc")+" cancel")
 	} else if m.focused == focusInsights {
 		keyHints = append(keyHints, keyStyle.Render("h/l")+" panels", keyStyle.Render("e")+" explain", keyStyle.Render("⏎")+" jump", keyStyle.Render("?")+" help")
 		keyHints = append(keyHints, keyStyle.Render("A")+" attention", keyStyle.Render("F")+" flow")
 	} else if m.focused == focusFlowMatrix {
 		keyHints = append(keyHints, keyStyle.Render("j/k")+" nav", keyStyle.Render("tab")+" panel", keyStyle.Render("⏎")+" drill", keyStyle.Render("esc")+" back", keyStyle.Render("f")+" close")
 	} else if m.isGraphView {
-		keyHints = append(keyHints, keyStyle.Render("hjkl")+" nav", keyStyle.Render("H/L")+" scroll", keyStyle.Render("⏎")+" view", keyStyle.Render("g")+" list")
+		keyHints = append(keyHints, keyStyle.Render("hjkl")+" nav", keyStyle.Render("H/L")+" scroll", keyStyle.Render("⏎")+" view", keyStyle.Render("B")+" break cycles", keyStyle.Render("g")+" list")
 	} else if m.isBoardView {
 		keyHints = append(keyHints, keyStyle.Render("hjkl")+" nav", keyStyle.Render("G")+" bottom", keyStyle.Render("⏎")+" view", keyStyle.Render("b")+" list")
 	} else if m.isActionableView {
@@ -4746,6 +5201,8 @@ func (m *Model) renderFooter() string {
 		}
 	} else if m.showTimeTravelPrompt {
 		keyHints = append(keyHints, keyStyle.Render("⏎")+" compare", keyStyle.Render("esc")+" cancel")
+	} else if m.showCommentPrompt {
+		keyHints = append(keyHints, keyStyle.Render("⏎")+" post", keyStyle.Render("esc")+" cancel")
 	} else {
 		if m.timeTravelMode {
 			keyHints = append(keyHints, keyStyle.Render("t")+" exit diff", keyStyle.Render("C")+" copy", keyStyle.Render("abgi")+" views", keyStyle.Render("?")+" help")
@@ -4790,6 +5247,9 @@ func (m *Model) renderFooter() string {
 	if sessionSection != "" {
 		leftWidth += lipgloss.Width(sessionSection) + 1
 	}
+	if focusSection != "" {
+		leftWidth += lipgloss.Width(focusSection) + 1
+	}
 	if workspaceSection != "" {
 		leftWidth += lipgloss.Width(workspaceSection) + 1
 	}
@@ -4823,6 +5283,9 @@ func (m *Model) renderFooter() string {
 	if sessionSection != "" {
 		parts = append(parts, sessionSection)
 	}
+	if focusSection != "" {
+		parts = append(parts, focusSection)
+	}
 	if workspaceSection != "" {
 		parts = append(parts, workspaceSection)
 	}
@@ -4911,6 +5374,8 @@ func (m *Model) applyFilter() {
 			include = issue.Status != model.StatusClosed
 		case "closed":
 			include = issue.Status == model.StatusClosed
+		case "review":
+			include = issue.Status == model.StatusInReview
 		case "ready":
 			// Ready = Open/InProgress AND NO Open Blockers
 			if issue.Status != model.StatusClosed && issue.Status != model.StatusBlocked {
@@ -5209,7 +5674,7 @@ func (m *Model) applyRecipe(r *recipe.Recipe) {
 func (m *Model) updateViewportContent() {
 	selectedItem := m.list.SelectedItem()
 	if selectedItem == nil {
-		m.viewport.SetContent("No issues selected")
+		m.viewport.SetContent(i18n.T("No issues selected"))
 		return
 	}
 
@@ -5221,17 +5686,32 @@ func (m *Model) updateViewportContent() {
 	}
 	item := issueItem.Issue
 
+	if m.detailExplain {
+		exp, err := m.explanationFor(item.ID)
+		if err != nil {
+			m.viewport.SetContent("Error: " + err.Error())
+			return
+		}
+		m.viewport.SetContent(renderExplainDetail(exp, m.viewport.Width))
+		return
+	}
+	if m.detailRaw {
+		m.viewport.SetContent(renderRawDetail(item, m.viewport.Width))
+		return
+	}
+
 	var sb strings.Builder
 
 	if m.updateAvailable {
-		sb.WriteString(fmt.Sprintf("⭐ **Update Available:** [%s](%s)\n\n", m.updateTag, m.updateURL))
+		sb.WriteString(fmt.Sprintf("⭐ **%s:** [%s](%s)\n\n", i18n.T("Update Available"), m.updateTag, m.updateURL))
 	}
 
 	// Title Block
 	sb.WriteString(fmt.Sprintf("# %s %s\n", GetTypeIconMD(string(item.IssueType)), item.Title))
 
 	// Meta Table
-	sb.WriteString("| ID | Status | Priority | Assignee | Created |\n|---|---|---|---|---|\n")
+	sb.WriteString(fmt.Sprintf("| %s | %s | %s | %s | %s |\n|---|---|---|---|---|\n",
+		i18n.T("ID"), i18n.T("Status"), i18n.T("Priority"), i18n.T("Assignee"), i18n.T("Created")))
 	sb.WriteString(fmt.Sprintf("| **%s** | **%s** | %s | @%s | %s |\n\n",
 		item.ID,
 		strings.ToUpper(string(item.Status)),
@@ -5242,12 +5722,27 @@ func (m *Model) updateViewportContent() {
 
 	// Labels (bv-f103 fix: display labels in detail view)
 	if len(item.Labels) > 0 {
-		sb.WriteString(fmt.Sprintf("**Labels:** %s\n\n", strings.Join(item.Labels, ", ")))
+		sb.WriteString(fmt.Sprintf("**%s:** %s\n\n", i18n.T("Labels"), strings.Join(item.Labels, ", ")))
+	}
+
+	sb.WriteString(m.renderClaimMD(item.ID, time.Now()))
+	sb.WriteString(m.renderTimeTrackedMD(item.ID, time.Now()))
+
+	// Label suggestions for unlabeled issues
+	sb.WriteString(renderLabelSuggestionsMD(m.suggestedLabels(item)))
+
+	// Possible duplicates from the background near-duplicate scan
+	if dups := m.duplicates[item.ID]; len(dups) > 0 {
+		if len(dups) == 1 {
+			sb.WriteString(i18n.Tf("**⚠ %d possible duplicate:** %s\n\n", len(dups), dups[0]))
+		} else {
+			sb.WriteString(i18n.Tf("**⚠ %d possible duplicates:** %s\n\n", len(dups), strings.Join(dups, ", ")))
+		}
 	}
 
 	// Triage Insights (bv-151)
 	if issueItem.TriageScore > 0 || issueItem.TriageReason != "" || issueItem.UnblocksCount > 0 || issueItem.IsQuickWin || issueItem.IsBlocker {
-		sb.WriteString("### 🎯 Triage Insights\n")
+		sb.WriteString("### 🎯 " + i18n.T("Triage Insights") + "\n")
 
 		// Score with visual indicator
 		scoreIcon := "🔵"
@@ -5256,29 +5751,29 @@ func (m *Model) updateViewportContent() {
 		} else if issueItem.TriageScore >= 0.4 {
 			scoreIcon = "🟠"
 		}
-		sb.WriteString(fmt.Sprintf("- **Triage Score:** %s %.2f/1.00\n", scoreIcon, issueItem.TriageScore))
+		sb.WriteString(fmt.Sprintf("- **%s:** %s %.2f/1.00\n", i18n.T("Triage Score"), scoreIcon, issueItem.TriageScore))
 
 		// Special flags
 		if issueItem.IsQuickWin {
-			sb.WriteString("- **⭐ Quick Win** — Low effort, high impact opportunity\n")
+			sb.WriteString(fmt.Sprintf("- **⭐ %s** — %s\n", i18n.T("Quick Win"), i18n.T("Low effort, high impact opportunity")))
 		}
 		if issueItem.IsBlocker {
-			sb.WriteString("- **🔴 Critical Blocker** — Completing this unblocks significant downstream work\n")
+			sb.WriteString(fmt.Sprintf("- **🔴 %s** — %s\n", i18n.T("Critical Blocker"), i18n.T("Completing this unblocks significant downstream work")))
 		}
 
 		// Unblocks count
 		if issueItem.UnblocksCount > 0 {
-			sb.WriteString(fmt.Sprintf("- **🔓 Unblocks:** %d downstream items when completed\n", issueItem.UnblocksCount))
+			sb.WriteString(i18n.Tf("- **🔓 Unblocks:** %d downstream items when completed\n", issueItem.UnblocksCount))
 		}
 
 		// Primary reason
 		if issueItem.TriageReason != "" {
-			sb.WriteString(fmt.Sprintf("- **Primary Reason:** %s\n", issueItem.TriageReason))
+			sb.WriteString(fmt.Sprintf("- **%s:** %s\n", i18n.T("Primary Reason"), issueItem.TriageReason))
 		}
 
 		// All reasons (if multiple)
 		if len(issueItem.TriageReasons) > 1 {
-			sb.WriteString("- **All Reasons:**\n")
+			sb.WriteString("- **" + i18n.T("All Reasons") + ":**\n")
 			for _, reason := range issueItem.TriageReasons {
 				sb.WriteString(fmt.Sprintf("  - %s\n", reason))
 			}
@@ -5289,11 +5784,11 @@ func (m *Model) updateViewportContent() {
 
 	// Search Scores (hybrid mode)
 	if m.semanticSearchEnabled && m.semanticHybridEnabled && issueItem.SearchScoreSet && m.list.FilterState() != list.Unfiltered {
-		sb.WriteString("### 🔎 Search Scores\n")
-		sb.WriteString(fmt.Sprintf("- **Hybrid Score:** %.3f\n", issueItem.SearchScore))
-		sb.WriteString(fmt.Sprintf("- **Text Score:** %.3f\n", issueItem.SearchTextScore))
+		sb.WriteString("### 🔎 " + i18n.T("Search Scores") + "\n")
+		sb.WriteString(fmt.Sprintf("- **%s:** %.3f\n", i18n.T("Hybrid Score"), issueItem.SearchScore))
+		sb.WriteString(fmt.Sprintf("- **%s:** %.3f\n", i18n.T("Text Score"), issueItem.SearchTextScore))
 		if len(issueItem.SearchComponents) > 0 {
-			sb.WriteString("- **Components:**\n")
+			sb.WriteString("- **" + i18n.T("Components") + ":**\n")
 			order := []string{"pagerank", "status", "impact", "priority", "recency"}
 			for _, key := range order {
 				if val, ok := issueItem.SearchComponents[key]; ok {
@@ -5312,33 +5807,27 @@ func (m *Model) updateViewportContent() {
 	hub := m.analysis.GetHubScore(item.ID)
 	auth := m.analysis.GetAuthorityScore(item.ID)
 
-	sb.WriteString("### Graph Analysis\n")
-	sb.WriteString(fmt.Sprintf("- **Impact Depth**: %.0f (downstream chain length)\n", imp))
-	sb.WriteString(fmt.Sprintf("- **Centrality**: PR %.4f • BW %.4f • EV %.4f\n", pr, bt, ev))
-	sb.WriteString(fmt.Sprintf("- **Flow Role**: Hub %.4f • Authority %.4f\n\n", hub, auth))
-
-	// Description
-	if item.Description != "" {
-		sb.WriteString("### Description\n")
-		sb.WriteString(item.Description + "\n\n")
-	}
-
-	// Design Notes
-	if item.Design != "" {
-		sb.WriteString("### Design Notes\n")
-		sb.WriteString(item.Design + "\n\n")
-	}
+	sb.WriteString("### " + i18n.T("Graph Analysis") + "\n")
+	sb.WriteString(i18n.Tf("- **Impact Depth**: %.0f (downstream chain length)\n", imp))
+	sb.WriteString(i18n.Tf("- **Centrality**: PR %.4f • BW %.4f • EV %.4f\n", pr, bt, ev))
+	sb.WriteString(i18n.Tf("- **Flow Role**: Hub %.4f • Authority %.4f\n\n", hub, auth))
 
-	// Acceptance Criteria
-	if item.AcceptanceCriteria != "" {
-		sb.WriteString("### Acceptance Criteria\n")
-		sb.WriteString(item.AcceptanceCriteria + "\n\n")
-	}
+	// Free text: description, design notes, acceptance criteria, notes
+	sb.WriteString(detailSectionMD(i18n.T("Description"), item.Description))
+	sb.WriteString(detailSectionMD(i18n.T("Design Notes"), item.Design))
+	sb.WriteString(detailSectionMD(i18n.T("Acceptance Criteria"), item.AcceptanceCriteria))
+	sb.WriteString(detailSectionMD(i18n.T("Notes"), item.Notes))
 
-	// Notes
-	if item.Notes != "" {
-		sb.WriteString("### Notes\n")
-		sb.WriteString(item.Notes + "\n\n")
+	// Attachments, previewed inline on terminals that can show images. The
+	// previews bypass glamour, so the markdown so far is rendered on its own
+	var head string
+	atts := issueAttachments(item, m.workDir)
+	sb.WriteString(renderAttachmentsMD(atts))
+	if previews := m.renderAttachmentPreviews(atts); previews != "" {
+		if rendered, err := m.renderer.Render(m.linkMentionsMD(sb.String(), item.ID)); err == nil {
+			head = rendered + previews
+			sb.Reset()
+		}
 	}
 
 	// Dependency Graph (Tree)
@@ -5349,15 +5838,10 @@ func (m *Model) updateViewportContent() {
 	}
 
 	// Comments
-	if len(item.Comments) > 0 {
-		sb.WriteString(fmt.Sprintf("### Comments (%d)\n", len(item.Comments)))
-		for _, comment := range item.Comments {
-			sb.WriteString(fmt.Sprintf("> **%s** (%s)\n> \n> %s\n\n",
-				comment.Author,
-				FormatTimeRel(comment.CreatedAt),
-				strings.ReplaceAll(comment.Text, "\n", "\n> ")))
-		}
-	}
+	sb.WriteString(renderCommentThreadMD(item.Comments))
+
+	// Issues whose text mentions this one
+	sb.WriteString(m.renderBacklinksMD(item.ID))
 
 	// History Section (if data is loaded)
 	if m.historyView.HasReport() {
@@ -5367,11 +5851,11 @@ func (m *Model) updateViewportContent() {
 		}
 	}
 
-	rendered, err := m.renderer.Render(sb.String())
+	rendered, err := m.renderer.Render(m.linkMentionsMD(sb.String(), item.ID))
 	if err != nil {
 		m.viewport.SetContent(fmt.Sprintf("Error rendering markdown: %v", err))
 	} else {
-		m.viewport.SetContent(rendered)
+		m.viewport.SetContent(head + rendered)
 	}
 }
 
@@ -5383,11 +5867,11 @@ func (m *Model) renderBeadHistoryMD(beadID string) string {
 	}
 
 	var sb strings.Builder
-	sb.WriteString("### 📜 History\n\n")
+	sb.WriteString("### 📜 " + i18n.T("History") + "\n\n")
 
 	// Lifecycle milestones from events
 	if len(hist.Events) > 0 {
-		sb.WriteString("**Lifecycle:**\n")
+		sb.WriteString("**" + i18n.T("Lifecycle") + ":**\n")
 		for _, event := range hist.Events {
 			icon := getEventIcon(event.EventType)
 			sb.WriteString(fmt.Sprintf("- %s **%s** %s by %s\n",
@@ -5401,7 +5885,7 @@ func (m *Model) renderBeadHistoryMD(beadID string) string {
 	}
 
 	// Correlated commits
-	sb.WriteString(fmt.Sprintf("**Related Commits (%d):**\n", len(hist.Commits)))
+	sb.WriteString(i18n.Tf("**Related Commits (%d):**\n", len(hist.Commits)))
 	for i, commit := range hist.Commits {
 		if i >= 5 {
 			sb.WriteString(fmt.Sprintf("  ... and %d more commits\n", len(hist.Commits)-5))
@@ -5442,6 +5926,8 @@ func getEventIcon(eventType correlation.EventType) string {
 		return "🟢"
 	case correlation.EventClaimed:
 		return "🔵"
+	case correlation.EventInReview:
+		return "🟣"
 	case correlation.EventClosed:
 		return "⚫"
 	case correlation.EventReopened:
@@ -5505,6 +5991,106 @@ func (m Model) FilteredIssues() []model.Issue {
 	return issues
 }
 
+// SetKeymap installs custom key bindings. Conflicts and warnings found
+// while loading are surfaced in the status bar.
+func (m *Model) SetKeymap(km *Keymap) {
+	m.keymap = km
+	if km == nil {
+		return
+	}
+	if n := len(km.Conflicts) + len(km.Warnings); n > 0 {
+		first := ""
+		if len(km.Conflicts) > 0 {
+			first = km.Conflicts[0].String()
+		} else {
+			first = km.Warnings[0]
+		}
+		m.statusMsg = fmt.Sprintf("Keymap: %s", first)
+		if n > 1 {
+			m.statusMsg += fmt.Sprintf(" (+%d more; run bv --keymap-check)", n-1)
+		}
+		m.statusIsError = true
+	}
+}
+
+// SetHooks installs the hook configuration whose post-reload hooks run after
+// each live reload
+func (m *Model) SetHooks(config *hooks.Config) {
+	m.hookConfig = config
+}
+
+// SetBoardConfig applies the board's per-status WIP limits
+func (m *Model) SetBoardConfig(config *drift.BoardConfig) {
+	m.boardConfig = config
+	m.board.SetWIPLimits(config)
+}
+
+// SetThemes installs the loaded themes and switches to name, or to the
+// set's active theme when name is empty.
+func (m *Model) SetThemes(set *ThemeSet, name string) error {
+	m.themes = set
+	t, err := set.Theme(m.theme.Renderer, name)
+	if err != nil {
+		return err
+	}
+	m.SetTheme(t)
+	return nil
+}
+
+// SetTheme re-themes the model and every sub-view.
+func (m *Model) SetTheme(t Theme) {
+	m.theme = t
+	applyThemeColors(t)
+
+	m.updateListDelegate()
+	m.list.Styles.FilterPrompt = lipgloss.NewStyle().Foreground(t.Primary)
+	m.list.Styles.FilterCursor = lipgloss.NewStyle().Foreground(t.Primary)
+	m.timeTravelInput.PromptStyle = lipgloss.NewStyle().Foreground(t.Primary).Bold(true)
+	m.timeTravelInput.TextStyle = lipgloss.NewStyle().Foreground(t.Base.GetForeground())
+	m.commentInput.PromptStyle = lipgloss.NewStyle().Foreground(t.Primary).Bold(true)
+	m.commentInput.TextStyle = lipgloss.NewStyle().Foreground(t.Base.GetForeground())
+	if m.renderer != nil {
+		m.renderer.SetWidthWithTheme(m.renderer.width, t)
+		if m.list.SelectedItem() != nil {
+			m.updateViewportContent()
+		}
+	}
+
+	m.board.theme = t
+	m.labelDashboard.theme = t
+	m.velocityComparison.theme = t
+	m.shortcutsSidebar.theme = t
+	m.graphView.theme = t
+	m.insightsPanel.theme = t
+	m.flowMatrix.theme = t
+	m.actionableView.theme = t
+	m.historyView.theme = t
+	m.recipePicker.theme = t
+	m.labelPicker.theme = t
+	m.commandPalette.theme = t
+	m.layoutPicker.theme = t
+	m.repoPicker.theme = t
+	m.tutorialModel.theme = t
+	m.agentPromptModal.theme = t
+	m.cassModal.theme = t
+	m.updateModal.theme = t
+	m.cycleBreakWizard.theme = t
+}
+
+// cycleTheme switches to the next theme in switcher order.
+func (m *Model) cycleTheme() {
+	name := m.themes.NextThemeName(m.theme.Name)
+	t, err := m.themes.Theme(m.theme.Renderer, name)
+	if err != nil {
+		m.statusMsg = fmt.Sprintf("Theme: %v", err)
+		m.statusIsError = true
+		return
+	}
+	m.SetTheme(t)
+	m.statusMsg = fmt.Sprintf("Theme: %s", name)
+	m.statusIsError = false
+}
+
 // EnableWorkspaceMode configures the model for workspace (multi-repo) view
 func (m *Model) EnableWorkspaceMode(info WorkspaceInfo) {
 	m.workspaceMode = info.Enabled
@@ -5544,11 +6130,12 @@ func (m *Model) enterHistoryView() {
 			ID:     issue.ID,
 			Title:  issue.Title,
 			Status: string(issue.Status),
+			Events: issue.Events,
 		}
 	}
 
 	// Load correlation data
-	correlator := correlation.NewCorrelator(cwd, m.beadsPath)
+	correlator := correlation.NewIndexedCorrelator(cwd, m.beadsPath)
 	opts := correlation.CorrelatorOptions{
 		Limit: 500, // Reasonable limit for TUI performance
 	}
@@ -5570,6 +6157,17 @@ func (m *Model) enterHistoryView() {
 	m.statusIsError = false
 }
 
+// requestLabelTrends starts computing the label dashboard's trend columns,
+// once per load of the data
+func (m *Model) requestLabelTrends() tea.Cmd {
+	if m.labelTrendsRequested {
+		return nil
+	}
+	m.labelTrendsRequested = true
+	m.labelDashboard.SetTrends(nil)
+	return LabelTrendsCmd(append([]model.Issue(nil), m.issues...), m.workDir, m.analysis)
+}
+
 // enterTimeTravelMode loads historical data and computes diff
 func (m *Model) enterTimeTravelMode(revision string) {
 	cwd, err := os.Getwd()
@@ -5638,6 +6236,93 @@ func (m *Model) enterTimeTravelMode(revision string) {
 	m.rebuildListWithDiffInfo()
 }
 
+// startPlayback loads weekly snapshots of the beads file from git for
+// playback, or stops a running playback
+func (m Model) startPlayback() (Model, tea.Cmd) {
+	if m.playback.Active() {
+		m.exitPlayback()
+		return m, nil
+	}
+	if m.playbackLoading {
+		return m, nil
+	}
+	cwd, err := os.Getwd()
+	if err != nil {
+		m.statusMsg = "❌ Playback failed: cannot get working directory"
+		m.statusIsError = true
+		return m, nil
+	}
+	if _, err := loader.NewGitLoader(cwd).ResolveRevision("HEAD"); err != nil {
+		m.statusMsg = "❌ Playback requires a git repository"
+		m.statusIsError = true
+		return m, nil
+	}
+	if m.timeTravelMode {
+		m.exitTimeTravelMode()
+	}
+	m.playbackLoading = true
+	m.statusMsg = "⏱️ Loading weekly history for playback..."
+	m.statusIsError = false
+	return m, LoadPlaybackCmd(cwd)
+}
+
+// handlePlaybackKeys drives a running playback. Navigation and view keys
+// pass through so the frame can be explored; keys that would rebuild the
+// views from current data are swallowed.
+func (m *Model) handlePlaybackKeys(msg tea.KeyMsg) (bool, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return true, tea.Quit
+	case " ":
+		if m.playback.TogglePlay() {
+			m.showPlaybackFrame()
+		}
+		return true, m.playback.Tick()
+	case "left", "h":
+		if m.playback.Step(-1) {
+			m.showPlaybackFrame()
+		}
+		return true, nil
+	case "right", "l":
+		if m.playback.Step(1) {
+			m.showPlaybackFrame()
+		}
+		return true, nil
+	case "esc", "q", "R":
+		m.exitPlayback()
+		return true, nil
+	case "j", "k", "up", "down", "G", "end", "home", "pgup", "pgdown", "ctrl+d", "ctrl+u",
+		"enter", "tab", "b", "g", "?", "f1":
+		return false, nil
+	}
+	return true, nil
+}
+
+// showPlaybackFrame puts the current playback frame's issues in the list,
+// board and graph
+func (m *Model) showPlaybackFrame() {
+	frame := m.playback.Current()
+	items := make([]list.Item, len(frame.Issues))
+	for i, issue := range frame.Issues {
+		items[i] = IssueItem{Issue: issue, RepoPrefix: ExtractRepoPrefix(issue.ID)}
+	}
+	m.list.SetItems(items)
+	m.board.SetIssues(frame.Issues)
+	m.graphView.SetIssues(frame.Issues, nil)
+	if len(items) > 0 && m.list.Index() >= len(items) {
+		m.list.Select(0)
+	}
+	m.updateViewportContent()
+}
+
+// exitPlayback stops a playback and restores the current data
+func (m *Model) exitPlayback() {
+	m.playback = PlaybackModel{}
+	m.statusMsg = "⏱️ Playback stopped"
+	m.statusIsError = false
+	m.rebuildListWithDiffInfo()
+}
+
 // exitTimeTravelMode clears time-travel state
 func (m *Model) exitTimeTravelMode() {
 	m.timeTravelMode = false
@@ -5810,16 +6495,7 @@ func (m *Model) copyIssueToClipboard() {
 		}
 	}
 
-	// Copy to clipboard
-	err := clipboard.WriteAll(sb.String())
-	if err != nil {
-		m.statusMsg = fmt.Sprintf("❌ Clipboard error: %v", err)
-		m.statusIsError = true
-		return
-	}
-
-	m.statusMsg = fmt.Sprintf("📋 Copied %s to clipboard", issue.ID)
-	m.statusIsError = false
+	m.copyWithStatus(sb.String(), issue.ID)
 }
 
 // showCassSessionModal shows the cass session preview modal for the selected issue (bv-5bqh)
@@ -6000,6 +6676,9 @@ func (m *Model) Stop() {
 	if m.watcher != nil {
 		m.watcher.Stop()
 	}
+	if m.usage != nil {
+		_ = usage.Record(m.usage.path, m.usage.events(time.Now())...)
+	}
 }
 
 // clearAttentionOverlay hides the attention overlay and clears its rendered text.
@@ -6055,6 +6734,12 @@ func computeAlerts(issues []model.Issue, stats *analysis.GraphStats, analyzer *a
 
 	calc := drift.NewCalculator(bl, cur, driftConfig)
 	calc.SetIssues(issues)
+	if boardConfig, err := drift.LoadBoardConfig(projectDir); err == nil {
+		calc.SetBoardConfig(boardConfig)
+	}
+	if goals, err := analysis.LoadGoals(projectDir); err == nil && len(goals.Goals) > 0 {
+		calc.SetGoals(analysis.ForecastGoals(issues, stats, goals, nil, analysis.CapacitySimOptions{}, time.Now()))
+	}
 	result := calc.Calculate()
 
 	critical, warning, info := 0, 0, 0
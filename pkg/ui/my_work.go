@@ -0,0 +1,151 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// SetAssignee sets whose queue the my-work view (@) shows. "" hides it
+// behind a hint to set BD_ACTOR or --assignee.
+func (m *Model) SetAssignee(name string) {
+	m.myAssignee = name
+}
+
+// openMyWork computes the assignee's personal queue and shows it.
+func (m Model) openMyWork() Model {
+	m.myQueue = analysis.MyQueue{Assignee: m.myAssignee}
+	if m.myAssignee != "" && m.analyzer != nil && m.analysis != nil {
+		m.myQueue = analysis.ComputeMyQueue(m.analyzer, m.analysis, m.issues, m.myAssignee, analysis.MyQueueOptions{}, time.Now())
+	}
+	m.showMyWork = true
+	m.myWorkCursor = 0
+	return m
+}
+
+// handleMyWorkKeys handles keys while the my-work view is open.
+func (m Model) handleMyWorkKeys(msg tea.KeyMsg) (Model, tea.Cmd) {
+	switch msg.String() {
+	case "j", "down":
+		if m.myWorkCursor < len(m.myQueue.Items)-1 {
+			m.myWorkCursor++
+		}
+	case "k", "up":
+		if m.myWorkCursor > 0 {
+			m.myWorkCursor--
+		}
+	case "g", "home":
+		m.myWorkCursor = 0
+	case "G", "end":
+		m.myWorkCursor = max(len(m.myQueue.Items)-1, 0)
+	case "enter":
+		if m.myWorkCursor >= len(m.myQueue.Items) {
+			break
+		}
+		id := m.myQueue.Items[m.myWorkCursor].ID
+		found := false
+		for i, item := range m.list.Items() {
+			if issueItem, ok := item.(IssueItem); ok && issueItem.Issue.ID == id {
+				m.list.Select(i)
+				found = true
+				break
+			}
+		}
+		m.showMyWork = false
+		if !found {
+			m.statusMsg = fmt.Sprintf("%s is hidden by the current filter", id)
+			m.statusIsError = true
+			break
+		}
+		m.focused = focusDetail
+		if !m.isSplitView {
+			m.showDetails = true
+			m.viewport.GotoTop()
+		}
+		m.updateViewportContent()
+	case "esc", "q", "@":
+		m.showMyWork = false
+	case "ctrl+c":
+		return m, tea.Quit
+	}
+	return m, nil
+}
+
+// renderMyWork renders the my-work view: the assignee's in-progress issues,
+// what to pick up next and what is about to free up.
+func (m Model) renderMyWork() string {
+	t := m.theme
+	width := min(96, m.width-4)
+	q := m.myQueue
+
+	titleStyle := t.Renderer.NewStyle().Bold(true).Foreground(t.Primary)
+	headerStyle := t.Renderer.NewStyle().Bold(true).Foreground(t.Secondary)
+	textStyle := t.Renderer.NewStyle().Foreground(t.Base.GetForeground())
+	selectedStyle := textStyle.Bold(true).Background(t.Highlight)
+	dimStyle := t.Renderer.NewStyle().Foreground(t.Secondary).Italic(true)
+
+	title := "👤 My Work"
+	if q.Assignee != "" {
+		title = fmt.Sprintf("👤 My Work · %s", q.Assignee)
+	}
+
+	var lines []string
+	cursorLine := 0
+	switch {
+	case q.Assignee == "":
+		lines = append(lines, dimStyle.Render("Who are you? Set BD_ACTOR or start bv with --assignee NAME."))
+	case len(q.Items) == 0:
+		lines = append(lines, dimStyle.Render("Nothing assigned to you or waiting in your areas."))
+	default:
+		if len(q.Labels) > 0 {
+			lines = append(lines, dimStyle.Render("Your labels: "+strings.Join(q.Labels, ", ")), "")
+		}
+		headers := map[analysis.QueueSection]string{
+			analysis.QueueInProgress: fmt.Sprintf("▶ In progress (%d)", q.InProgressCount),
+			analysis.QueueNext:       fmt.Sprintf("★ Next up (%d)", q.NextCount),
+			analysis.QueueUpcoming:   fmt.Sprintf("⏳ Upcoming unblocks (%d)", q.UpcomingCount),
+		}
+		var section analysis.QueueSection
+		for i, item := range q.Items {
+			if item.Section != section {
+				if section != "" {
+					lines = append(lines, "")
+				}
+				section = item.Section
+				lines = append(lines, headerStyle.Render(headers[section]))
+			}
+			line := truncateRunesHelper(fmt.Sprintf("  %s P%d %s", item.ID, item.Priority, item.Title), width-8, "…")
+			reason := "      " + truncateRunesHelper(fmt.Sprintf("%.2f · %s", item.Score, item.Reason), width-14, "…")
+			if i == m.myWorkCursor {
+				cursorLine = len(lines)
+				lines = append(lines, selectedStyle.Render(line))
+			} else {
+				lines = append(lines, textStyle.Render(line))
+			}
+			lines = append(lines, dimStyle.Render(reason))
+		}
+	}
+
+	maxVisible := max(m.height-12, 4)
+	start := 0
+	if cursorLine+2 > maxVisible {
+		start = cursorLine + 2 - maxVisible
+	}
+	end := min(start+maxVisible, len(lines))
+	body := strings.Join(lines[start:end], "\n")
+
+	footer := "j/k: move | enter: open | esc: close"
+	content := titleStyle.Render(title) + "\n\n" + body + "\n\n" + dimStyle.Render(footer)
+	box := t.Renderer.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(t.Primary).
+		Padding(1, 2).
+		Width(width).
+		Render(content)
+
+	return lipgloss.Place(m.width, m.height-1, lipgloss.Center, lipgloss.Center, box)
+}
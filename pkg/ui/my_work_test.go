@@ -0,0 +1,67 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func newMyWorkTestModel(t *testing.T, assignee string) Model {
+	t.Helper()
+	issues := []model.Issue{
+		{ID: "api-1", Title: "Auth", Status: model.StatusInProgress, Assignee: "ana", Labels: []string{"backend"}},
+		{ID: "api-2", Title: "Rate limit", Status: model.StatusOpen, Labels: []string{"backend"}},
+		{ID: "api-3", Title: "Quotas", Status: model.StatusOpen, Assignee: "ana",
+			Dependencies: []*model.Dependency{{IssueID: "api-3", DependsOnID: "api-2", Type: model.DepBlocks}}},
+		{ID: "ui-1", Title: "Login form", Status: model.StatusOpen, Assignee: "bo"},
+	}
+	m := NewModel(issues, nil, "")
+	m.SetAssignee(assignee)
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	return updated.(Model)
+}
+
+func TestMyWorkView(t *testing.T) {
+	m := newMyWorkTestModel(t, "ana")
+	m = pressKeys(t, m, "@")
+	if !m.showMyWork || m.CurrentContext() != ContextMyWork {
+		t.Fatalf("@ should open my work (context %s)", m.CurrentContext())
+	}
+
+	view := m.View()
+	for _, want := range []string{"My Work · ana", "▶ In progress (1)", "★ Next up (1)", "⏳ Upcoming unblocks (1)", "api-2", "Frees up when api-2 closes"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("my work view missing %q", want)
+		}
+	}
+	if strings.Contains(view, "ui-1") {
+		t.Error("someone else's issue should not be in my queue")
+	}
+
+	// The cursor walks the queue in order; enter opens the issue
+	m = pressKeys(t, m, "j", "enter")
+	if m.showMyWork {
+		t.Fatal("enter should close my work")
+	}
+	if item, ok := m.list.SelectedItem().(IssueItem); !ok || item.Issue.ID != "api-2" {
+		t.Errorf("enter should select api-2, got %v", m.list.SelectedItem())
+	}
+	if m.focused != focusDetail {
+		t.Errorf("enter should focus the detail pane, got %v", m.focused)
+	}
+
+	m = pressKeys(t, m, "esc", "@", "esc")
+	if m.showMyWork {
+		t.Error("esc should close my work")
+	}
+}
+
+func TestMyWorkView_UnknownAssignee(t *testing.T) {
+	m := newMyWorkTestModel(t, "")
+	m = pressKeys(t, m, "@")
+	if !strings.Contains(m.View(), "Set BD_ACTOR or start bv with --assignee") {
+		t.Error("unknown assignee should explain how to set one")
+	}
+}
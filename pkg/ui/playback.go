@@ -0,0 +1,227 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// playbackInterval is how long each week shows while playing
+const playbackInterval = 1200 * time.Millisecond
+
+// playbackMaxFrames caps a playback at the most recent weeks of history
+const playbackMaxFrames = 26
+
+// PlaybackFrame is one week of a time-travel playback: the issues as
+// committed at the week's last commit, and their counts
+type PlaybackFrame struct {
+	analysis.ReplayFrame
+	Issues []model.Issue
+}
+
+// PlaybackLoadedMsg carries the frames of a playback, oldest first
+type PlaybackLoadedMsg struct {
+	Frames []PlaybackFrame
+	Err    error
+}
+
+// playbackTickMsg advances a running playback. seq ties it to one run, so
+// ticks left over from before a pause or step are dropped.
+type playbackTickMsg struct {
+	seq int
+}
+
+// LoadPlaybackCmd loads the beads file at the last commit of each of the
+// most recent weeks of git history
+func LoadPlaybackCmd(repoPath string) tea.Cmd {
+	return func() tea.Msg {
+		gitLoader := loader.NewGitLoader(repoPath)
+		revisions, err := gitLoader.WeeklyRevisions("", "HEAD")
+		if err != nil {
+			return PlaybackLoadedMsg{Err: err}
+		}
+		if len(revisions) > playbackMaxFrames {
+			revisions = revisions[len(revisions)-playbackMaxFrames:]
+		}
+
+		var frames []PlaybackFrame
+		for _, rev := range revisions {
+			issues, err := gitLoader.LoadAt(rev.SHA)
+			if err != nil {
+				continue // No readable beads file that week
+			}
+			frames = append(frames, PlaybackFrame{
+				ReplayFrame: analysis.NewReplayFrame(issues, rev.SHA, rev.Timestamp, rev.Message),
+				Issues:      issues,
+			})
+		}
+		if len(frames) == 0 {
+			return PlaybackLoadedMsg{Err: fmt.Errorf("no beads history to play back")}
+		}
+		return PlaybackLoadedMsg{Frames: frames}
+	}
+}
+
+// PlaybackModel steps through weekly snapshots of the tracker, either
+// automatically or one week at a time
+type PlaybackModel struct {
+	frames  []PlaybackFrame
+	index   int
+	playing bool
+	seq     int
+}
+
+// NewPlaybackModel starts a playback at the oldest frame, playing
+func NewPlaybackModel(frames []PlaybackFrame) PlaybackModel {
+	return PlaybackModel{frames: frames, playing: len(frames) > 1}
+}
+
+// Active reports whether a playback is loaded
+func (p PlaybackModel) Active() bool {
+	return len(p.frames) > 0
+}
+
+// Current returns the frame on screen
+func (p PlaybackModel) Current() PlaybackFrame {
+	return p.frames[p.index]
+}
+
+// Playing reports whether the playback advances on its own
+func (p PlaybackModel) Playing() bool {
+	return p.playing
+}
+
+// Tick schedules the next automatic step
+func (p PlaybackModel) Tick() tea.Cmd {
+	if !p.playing {
+		return nil
+	}
+	seq := p.seq
+	return tea.Tick(playbackInterval, func(time.Time) tea.Msg {
+		return playbackTickMsg{seq: seq}
+	})
+}
+
+// Advance handles a tick, moving to the next frame. It reports whether the
+// frame changed; the playback pauses on the last frame.
+func (p *PlaybackModel) Advance(msg playbackTickMsg) bool {
+	if !p.playing || msg.seq != p.seq {
+		return false
+	}
+	if p.index >= len(p.frames)-1 {
+		p.playing = false
+		return false
+	}
+	p.index++
+	if p.index == len(p.frames)-1 {
+		p.playing = false
+	}
+	return true
+}
+
+// Step moves delta frames and pauses, reporting whether the frame changed
+func (p *PlaybackModel) Step(delta int) bool {
+	p.pause()
+	next := p.index + delta
+	if next < 0 || next >= len(p.frames) {
+		return false
+	}
+	p.index = next
+	return true
+}
+
+// TogglePlay pauses or resumes, restarting from the oldest frame when
+// resumed at the end
+func (p *PlaybackModel) TogglePlay() (restarted bool) {
+	if p.playing {
+		p.pause()
+		return false
+	}
+	p.seq++
+	p.playing = true
+	if p.index == len(p.frames)-1 {
+		p.index = 0
+		return true
+	}
+	return false
+}
+
+func (p *PlaybackModel) pause() {
+	p.playing = false
+	p.seq++
+}
+
+// FooterView renders the scrub bar: the frame's week and counts, and open
+// and blocked sparklines across all frames with the current one marked
+func (p PlaybackModel) FooterView(width int) string {
+	frame := p.Current()
+
+	state := "⏸"
+	if p.playing {
+		state = "▶"
+	}
+	headStyle := lipgloss.NewStyle().Background(ColorPrioHighBg).Foreground(ColorWarning).Bold(true).Padding(0, 1)
+	head := headStyle.Render(fmt.Sprintf("%s %s %s %d/%d", state, frame.Timestamp.Format("2006-01-02"),
+		shortSHA(frame.Revision), p.index+1, len(p.frames)))
+
+	open := make([]int, len(p.frames))
+	blocked := make([]int, len(p.frames))
+	for i, f := range p.frames {
+		open[i] = f.Open
+		blocked[i] = f.Blocked
+	}
+	counts := lipgloss.NewStyle().Foreground(ColorText).Padding(0, 1).Render(
+		fmt.Sprintf("open %s %d  blocked %s %d  ready %d  cycles %d",
+			p.scrubLine(open, ColorStatusOpen), frame.Open,
+			p.scrubLine(blocked, ColorWarning), frame.Blocked,
+			frame.Actionable, frame.Cycles))
+	keys := lipgloss.NewStyle().Foreground(ColorSubtext).Padding(0, 1).Render("space play/pause • ←/→ step • esc exit")
+
+	line := head + counts
+	if lipgloss.Width(line)+lipgloss.Width(keys) <= width {
+		line += strings.Repeat(" ", width-lipgloss.Width(line)-lipgloss.Width(keys)) + keys
+	}
+	return lipgloss.NewStyle().MaxWidth(width).Render(line)
+}
+
+// scrubLine draws values as a sparkline, the current frame's cell highlighted
+func (p PlaybackModel) scrubLine(values []int, color lipgloss.TerminalColor) string {
+	peak := 0
+	for _, v := range values {
+		if v > peak {
+			peak = v
+		}
+	}
+	cells := []rune(buildSparkline(values, peak))
+	if peak == 0 {
+		cells = []rune(strings.Repeat("▁", len(values)))
+	}
+	var sb strings.Builder
+	base := lipgloss.NewStyle().Foreground(color)
+	current := lipgloss.NewStyle().Foreground(ColorBg).Background(color)
+	for i, c := range cells {
+		if c == ' ' {
+			c = '▁'
+		}
+		if i == p.index {
+			sb.WriteString(current.Render(string(c)))
+		} else {
+			sb.WriteString(base.Render(string(c)))
+		}
+	}
+	return sb.String()
+}
+
+// shortSHA abbreviates a commit SHA for display
+func shortSHA(sha string) string {
+	if len(sha) > 7 {
+		return sha[:7]
+	}
+	return sha
+}
@@ -0,0 +1,94 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func testPlaybackFrames() []PlaybackFrame {
+	week := func(n int) time.Time { return time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC).AddDate(0, 0, 7*n) }
+	var frames []PlaybackFrame
+	for n, open := range []int{1, 3, 2} {
+		issues := make([]model.Issue, open)
+		for i := range issues {
+			issues[i] = model.Issue{ID: string(rune('A' + i)), Title: "t", Status: model.StatusOpen}
+		}
+		frames = append(frames, PlaybackFrame{
+			ReplayFrame: analysis.NewReplayFrame(issues, "abcdef0123456789", week(n), ""),
+			Issues:      issues,
+		})
+	}
+	return frames
+}
+
+func TestPlaybackModel_AdvanceAndStep(t *testing.T) {
+	p := NewPlaybackModel(testPlaybackFrames())
+	if !p.Active() || !p.Playing() || p.Current().Open != 1 {
+		t.Fatalf("playback should start playing at the oldest frame")
+	}
+
+	stale := playbackTickMsg{seq: p.seq - 1}
+	if p.Advance(stale) {
+		t.Error("a stale tick should not advance")
+	}
+	if !p.Advance(playbackTickMsg{seq: p.seq}) || p.Current().Open != 3 {
+		t.Error("a tick should advance to the next week")
+	}
+	if !p.Advance(playbackTickMsg{seq: p.seq}) || p.Playing() {
+		t.Error("playback should pause on the last week")
+	}
+
+	if !p.Step(-1) || p.Current().Open != 3 {
+		t.Error("stepping back should show the previous week")
+	}
+	if p.Step(-5) {
+		t.Error("stepping past the start should not move")
+	}
+	p.Step(1)
+	if !p.TogglePlay() || p.index != 0 || !p.Playing() {
+		t.Error("resuming at the end should restart from the oldest week")
+	}
+}
+
+func TestPlaybackModel_FooterView(t *testing.T) {
+	p := NewPlaybackModel(testPlaybackFrames())
+	p.Step(1)
+	footer := p.FooterView(160)
+	for _, s := range []string{"⏸", "2026-03-09", "abcdef0", "2/3", "open", "blocked"} {
+		if !strings.Contains(footer, s) {
+			t.Errorf("footer missing %q: %s", s, footer)
+		}
+	}
+}
+
+func TestModel_PlaybackShowsFrames(t *testing.T) {
+	m := NewModel([]model.Issue{{ID: "X", Title: "now", Status: model.StatusOpen}}, nil, "")
+	updated, cmd := m.Update(PlaybackLoadedMsg{Frames: testPlaybackFrames()})
+	m = updated.(Model)
+	if cmd == nil {
+		t.Error("a loaded playback should schedule its first tick")
+	}
+	if got := len(m.list.Items()); got != 1 {
+		t.Errorf("list shows %d issues, want the oldest week's 1", got)
+	}
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRight})
+	m = updated.(Model)
+	if got := len(m.list.Items()); got != 3 {
+		t.Errorf("after stepping, list shows %d issues, want 3", got)
+	}
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = updated.(Model)
+	if m.playback.Active() {
+		t.Fatal("esc should stop playback")
+	}
+	if items := m.list.Items(); len(items) != 1 || items[0].(IssueItem).Issue.ID != "X" {
+		t.Errorf("stopping playback should restore current issues, got %v", items)
+	}
+}
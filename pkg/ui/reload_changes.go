@@ -0,0 +1,303 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/hooks"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// maxReloadLog is how many reloads the change log pane remembers.
+const maxReloadLog = 20
+
+// ReloadChanges is what one live reload changed compared with the issues
+// that were on screen before it.
+type ReloadChanges struct {
+	Time      time.Time
+	Total     int // Issues after the reload
+	Diff      *analysis.SnapshotDiff
+	Unblocked []string // Issues whose last open blocker went away
+	Blocked   []string // Issues that gained an open blocker
+}
+
+// diffReload compares the issues before and after a reload. It skips the
+// graph metrics a full snapshot would compute, so it is cheap enough to run
+// on every file change.
+func diffReload(before, after []model.Issue) ReloadChanges {
+	c := ReloadChanges{
+		Time:  time.Now(),
+		Total: len(after),
+		Diff:  analysis.CompareSnapshots(&analysis.Snapshot{Issues: before}, &analysis.Snapshot{Issues: after}),
+	}
+
+	wasBlocked := blockedIssueIDs(before)
+	isBlocked := blockedIssueIDs(after)
+	for i := range after {
+		issue := &after[i]
+		if issue.Status == model.StatusClosed {
+			continue
+		}
+		_, existed := wasBlocked[issue.ID]
+		switch {
+		case wasBlocked[issue.ID] && !isBlocked[issue.ID]:
+			c.Unblocked = append(c.Unblocked, issue.ID)
+		case existed && !wasBlocked[issue.ID] && isBlocked[issue.ID]:
+			c.Blocked = append(c.Blocked, issue.ID)
+		}
+	}
+	sort.Strings(c.Unblocked)
+	sort.Strings(c.Blocked)
+	return c
+}
+
+// blockedIssueIDs maps every open issue ID to whether it is blocked, either
+// explicitly or by an open blocking dependency.
+func blockedIssueIDs(issues []model.Issue) map[string]bool {
+	status := make(map[string]model.Status, len(issues))
+	for i := range issues {
+		status[issues[i].ID] = issues[i].Status
+	}
+
+	blocked := make(map[string]bool, len(issues))
+	for i := range issues {
+		issue := &issues[i]
+		if issue.Status == model.StatusClosed {
+			continue
+		}
+		isBlocked := issue.Status == model.StatusBlocked
+		for _, dep := range issue.Dependencies {
+			if isBlocked {
+				break
+			}
+			if dep == nil || !dep.Type.IsBlocking() {
+				continue
+			}
+			if s, exists := status[dep.DependsOnID]; exists && s != model.StatusClosed {
+				isBlocked = true
+			}
+		}
+		blocked[issue.ID] = isBlocked
+	}
+	return blocked
+}
+
+// Empty reports whether the reload changed nothing visible.
+func (c ReloadChanges) Empty() bool {
+	return c.Diff.Summary.TotalChanges == 0 && len(c.Unblocked) == 0 && len(c.Blocked) == 0
+}
+
+// Summary returns a one-line description such as
+// "+2 new, 1 closed, bv-87 now unblocked".
+func (c ReloadChanges) Summary() string {
+	d := c.Diff
+	var parts []string
+	if n := len(d.NewIssues); n > 0 {
+		parts = append(parts, fmt.Sprintf("+%d new", n))
+	}
+	if n := len(d.ClosedIssues); n > 0 {
+		parts = append(parts, fmt.Sprintf("%d closed", n))
+	}
+	if n := len(d.ReopenedIssues); n > 0 {
+		parts = append(parts, fmt.Sprintf("%d reopened", n))
+	}
+	if n := len(d.ModifiedIssues); n > 0 {
+		parts = append(parts, fmt.Sprintf("%d changed", n))
+	}
+	if n := len(d.RemovedIssues); n > 0 {
+		parts = append(parts, fmt.Sprintf("-%d removed", n))
+	}
+	parts = append(parts, describeIDs(c.Unblocked, "now unblocked")...)
+	parts = append(parts, describeIDs(c.Blocked, "now blocked")...)
+	if len(parts) == 0 {
+		return "no changes"
+	}
+	return strings.Join(parts, ", ")
+}
+
+// describeIDs names a single issue ("bv-87 now unblocked") and counts several.
+func describeIDs(ids []string, what string) []string {
+	switch len(ids) {
+	case 0:
+		return nil
+	case 1:
+		return []string{ids[0] + " " + what}
+	}
+	return []string{fmt.Sprintf("%d %s", len(ids), what)}
+}
+
+// Lines lists every change, one issue per line, for the change log pane.
+func (c ReloadChanges) Lines() []string {
+	d := c.Diff
+	var lines []string
+	for _, issue := range d.NewIssues {
+		lines = append(lines, fmt.Sprintf("+ %s %s", issue.ID, issue.Title))
+	}
+	for _, issue := range d.ClosedIssues {
+		lines = append(lines, fmt.Sprintf("✓ %s closed: %s", issue.ID, issue.Title))
+	}
+	for _, issue := range d.ReopenedIssues {
+		lines = append(lines, fmt.Sprintf("↺ %s reopened: %s", issue.ID, issue.Title))
+	}
+	for _, mod := range d.ModifiedIssues {
+		fields := make([]string, 0, len(mod.Changes))
+		for _, ch := range mod.Changes {
+			if ch.Field == "title" || ch.Field == "description" {
+				fields = append(fields, ch.Field)
+				continue
+			}
+			fields = append(fields, fmt.Sprintf("%s %s→%s", ch.Field, displayChangeValue(ch.OldValue), displayChangeValue(ch.NewValue)))
+		}
+		lines = append(lines, fmt.Sprintf("~ %s %s", mod.IssueID, strings.Join(fields, ", ")))
+	}
+	for _, issue := range d.RemovedIssues {
+		lines = append(lines, fmt.Sprintf("- %s removed: %s", issue.ID, issue.Title))
+	}
+	for _, id := range c.Unblocked {
+		lines = append(lines, fmt.Sprintf("🔓 %s now unblocked", id))
+	}
+	for _, id := range c.Blocked {
+		lines = append(lines, fmt.Sprintf("⛔ %s now blocked", id))
+	}
+	return lines
+}
+
+func displayChangeValue(v string) string {
+	if v == "" {
+		return "∅"
+	}
+	return truncateRunesHelper(v, 24, "…")
+}
+
+// recordReload remembers a reload's changes for the change log pane and
+// returns the status line announcing it.
+func (m *Model) recordReload(c ReloadChanges) string {
+	if c.Empty() {
+		return fmt.Sprintf("Reloaded %d issues (no changes)", c.Total)
+	}
+	m.reloadLog = append([]ReloadChanges{c}, m.reloadLog...)
+	if len(m.reloadLog) > maxReloadLog {
+		m.reloadLog = m.reloadLog[:maxReloadLog]
+	}
+	key := "N"
+	if keys := m.keymap.Keys("changelog"); len(keys) > 0 {
+		key = DisplayKey(keys[0])
+	}
+	return fmt.Sprintf("↻ %s · %s: change log", c.Summary(), key)
+}
+
+// renderChangeLog renders the change log pane: recent reloads, newest first.
+func (m Model) renderChangeLog() string {
+	t := m.theme
+	width := min(90, m.width-4)
+
+	titleStyle := t.Renderer.NewStyle().Bold(true).Foreground(t.Primary)
+	headerStyle := t.Renderer.NewStyle().Bold(true).Foreground(t.Secondary)
+	textStyle := t.Renderer.NewStyle().Foreground(t.Base.GetForeground())
+	dimStyle := t.Renderer.NewStyle().Foreground(t.Secondary).Italic(true)
+
+	var lines []string
+	if len(m.reloadLog) == 0 {
+		lines = append(lines, dimStyle.Render("No changes since bv started. Edits made with bd or"),
+			dimStyle.Render("by teammates show up here as the file reloads."))
+	}
+	for i, c := range m.reloadLog {
+		if i > 0 {
+			lines = append(lines, "")
+		}
+		lines = append(lines, headerStyle.Render(fmt.Sprintf("%s · %s", c.Time.Format("15:04:05"), c.Summary())))
+		for _, line := range c.Lines() {
+			lines = append(lines, textStyle.Render("  "+truncateRunesHelper(line, width-8, "…")))
+		}
+	}
+
+	maxVisible := max(m.height-12, 3)
+	start := min(m.changeLogScroll, max(len(lines)-maxVisible, 0))
+	end := min(start+maxVisible, len(lines))
+	body := strings.Join(lines[start:end], "\n")
+
+	footer := "j/k: scroll | esc: close"
+	if len(lines) > maxVisible {
+		footer = fmt.Sprintf("(%d-%d of %d lines) %s", start+1, end, len(lines), footer)
+	}
+
+	content := titleStyle.Render("↻ Change Log") + "\n\n" + body + "\n\n" + dimStyle.Render(footer)
+	box := t.Renderer.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(t.Primary).
+		Padding(1, 2).
+		Width(width).
+		Render(content)
+
+	return lipgloss.Place(m.width, m.height-1, lipgloss.Center, lipgloss.Center, box)
+}
+
+// ReloadHooksDoneMsg reports the post-reload hooks that ran after a reload.
+type ReloadHooksDoneMsg struct {
+	Total      int
+	Failed     int
+	FirstError string
+}
+
+// reloadHookPayload is the stdin data of post-reload hooks.
+type reloadHookPayload struct {
+	BeadsPath string   `json:"beads_path"`
+	Total     int      `json:"total"`
+	New       []string `json:"new"`
+	Closed    []string `json:"closed"`
+	Reopened  []string `json:"reopened"`
+	Modified  []string `json:"modified"`
+	Removed   []string `json:"removed"`
+	Unblocked []string `json:"unblocked"`
+	Blocked   []string `json:"blocked"`
+}
+
+func newReloadHookPayload(beadsPath string, c ReloadChanges) reloadHookPayload {
+	ids := func(issues []model.Issue) []string {
+		out := make([]string, 0, len(issues))
+		for i := range issues {
+			out = append(out, issues[i].ID)
+		}
+		return out
+	}
+	modified := make([]string, 0, len(c.Diff.ModifiedIssues))
+	for _, mod := range c.Diff.ModifiedIssues {
+		modified = append(modified, mod.IssueID)
+	}
+	return reloadHookPayload{
+		BeadsPath: beadsPath,
+		Total:     c.Total,
+		New:       ids(c.Diff.NewIssues),
+		Closed:    ids(c.Diff.ClosedIssues),
+		Reopened:  ids(c.Diff.ReopenedIssues),
+		Modified:  modified,
+		Removed:   ids(c.Diff.RemovedIssues),
+		Unblocked: append([]string{}, c.Unblocked...),
+		Blocked:   append([]string{}, c.Blocked...),
+	}
+}
+
+// RunReloadHooksCmd runs the post-reload hooks in the background with the
+// reload's changes as their payload, so slow hooks never stall the TUI.
+func RunReloadHooksCmd(config *hooks.Config, beadsPath string, c ReloadChanges) tea.Cmd {
+	payload := newReloadHookPayload(beadsPath, c)
+	return func() tea.Msg {
+		executor := hooks.NewExecutor(config, hooks.ExportContext{})
+		_ = executor.Run(hooks.PostReload, payload)
+		msg := ReloadHooksDoneMsg{Total: len(executor.Results())}
+		for _, r := range executor.Results() {
+			if !r.Success {
+				if msg.Failed == 0 {
+					msg.FirstError = fmt.Sprintf("%s: %v", r.Hook.Name, r.Error)
+				}
+				msg.Failed++
+			}
+		}
+		return msg
+	}
+}
@@ -0,0 +1,213 @@
+package ui
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/hooks"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func blocks(id, on string) []*model.Dependency {
+	return []*model.Dependency{{IssueID: id, DependsOnID: on, Type: model.DepBlocks}}
+}
+
+func TestDiffReload(t *testing.T) {
+	before := []model.Issue{
+		{ID: "bv-1", Title: "Blocker", Status: model.StatusOpen},
+		{ID: "bv-2", Title: "Waiting", Status: model.StatusOpen, Dependencies: blocks("bv-2", "bv-1")},
+		{ID: "bv-3", Title: "Free", Status: model.StatusOpen, Priority: 2},
+		{ID: "bv-4", Title: "Gone", Status: model.StatusOpen},
+	}
+	after := []model.Issue{
+		{ID: "bv-1", Title: "Blocker", Status: model.StatusClosed},
+		{ID: "bv-2", Title: "Waiting", Status: model.StatusOpen, Dependencies: blocks("bv-2", "bv-1")},
+		{ID: "bv-3", Title: "Free", Status: model.StatusOpen, Priority: 0, Dependencies: blocks("bv-3", "bv-5")},
+		{ID: "bv-5", Title: "New work", Status: model.StatusOpen},
+		{ID: "bv-6", Title: "More work", Status: model.StatusOpen},
+	}
+
+	c := diffReload(before, after)
+	if c.Empty() {
+		t.Fatal("expected changes")
+	}
+	if !slices.Equal(c.Unblocked, []string{"bv-2"}) {
+		t.Errorf("Unblocked = %v, want [bv-2]", c.Unblocked)
+	}
+	if !slices.Equal(c.Blocked, []string{"bv-3"}) {
+		t.Errorf("Blocked = %v, want [bv-3] (new issues are not 'now blocked')", c.Blocked)
+	}
+
+	want := "+2 new, 1 closed, 1 changed, -1 removed, bv-2 now unblocked, bv-3 now blocked"
+	if got := c.Summary(); got != want {
+		t.Errorf("Summary = %q\nwant      %q", got, want)
+	}
+
+	lines := strings.Join(c.Lines(), "\n")
+	for _, want := range []string{"+ bv-5 New work", "✓ bv-1 closed", "~ bv-3 priority P2→P0", "- bv-4 removed", "🔓 bv-2 now unblocked"} {
+		if !strings.Contains(lines, want) {
+			t.Errorf("Lines missing %q:\n%s", want, lines)
+		}
+	}
+}
+
+func TestDiffReload_NoChanges(t *testing.T) {
+	issues := []model.Issue{{ID: "bv-1", Title: "Same", Status: model.StatusOpen}}
+	c := diffReload(issues, slices.Clone(issues))
+	if !c.Empty() || c.Summary() != "no changes" {
+		t.Errorf("identical reload should be empty, got %q", c.Summary())
+	}
+}
+
+func TestReloadNotificationAndChangeLog(t *testing.T) {
+	beads := filepath.Join(t.TempDir(), "beads.jsonl")
+	write := func(data string) {
+		t.Helper()
+		if err := os.WriteFile(beads, []byte(data), 0o644); err != nil {
+			t.Fatalf("write beads: %v", err)
+		}
+	}
+	write(`{"id":"bv-1","title":"Blocker","status":"open","issue_type":"task"}
+{"id":"bv-2","title":"Waiting","status":"open","issue_type":"task","dependencies":[{"issue_id":"bv-2","depends_on_id":"bv-1","type":"blocks"}]}
+`)
+	m := NewModel(nil, nil, beads)
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m = updated.(Model)
+	updated, _ = m.Update(FileChangedMsg{})
+	m = updated.(Model)
+	if len(m.reloadLog) != 1 || !strings.HasPrefix(m.statusMsg, "↻ +2 new") {
+		t.Fatalf("first load into an empty model should report the new issues, got %q", m.statusMsg)
+	}
+
+	// An unchanged reload says so and adds nothing to the log
+	updated, _ = m.Update(FileChangedMsg{})
+	m = updated.(Model)
+	if len(m.reloadLog) != 1 || !strings.Contains(m.statusMsg, "no changes") {
+		t.Fatalf("unchanged reload: log %d, status %q", len(m.reloadLog), m.statusMsg)
+	}
+
+	write(`{"id":"bv-1","title":"Blocker","status":"closed","issue_type":"task"}
+{"id":"bv-2","title":"Waiting","status":"open","issue_type":"task","dependencies":[{"issue_id":"bv-2","depends_on_id":"bv-1","type":"blocks"}]}
+{"id":"bv-3","title":"Fresh","status":"open","issue_type":"task"}
+`)
+	updated, _ = m.Update(FileChangedMsg{})
+	m = updated.(Model)
+	want := "↻ +1 new, 1 closed, bv-2 now unblocked · N: change log"
+	if m.statusMsg != want {
+		t.Fatalf("status = %q, want %q", m.statusMsg, want)
+	}
+
+	m = pressKeys(t, m, "N")
+	if !m.showChangeLog || m.CurrentContext() != ContextChangeLog {
+		t.Fatalf("N should open the change log (context %s)", m.CurrentContext())
+	}
+	view := m.View()
+	for _, want := range []string{"Change Log", "✓ bv-1 closed", "+ bv-3 Fresh", "bv-2 now unblocked"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("change log missing %q", want)
+		}
+	}
+	if strings.Index(view, "+ bv-3 Fresh") > strings.Index(view, "+ bv-1 Blocker") {
+		t.Error("newest reload should be listed first")
+	}
+
+	m = pressKeys(t, m, "esc")
+	if m.showChangeLog {
+		t.Error("esc should close the change log")
+	}
+}
+
+func TestChangeLogKeyLeavesBoardSearch(t *testing.T) {
+	m := newPaletteTestModel(t)
+	m = pressKeys(t, m, "b", "N")
+	if m.showChangeLog {
+		t.Error("N on the board is previous match, not the change log")
+	}
+}
+
+func TestReloadReusesMetricsWhenGraphUnchanged(t *testing.T) {
+	beads := filepath.Join(t.TempDir(), "beads.jsonl")
+	write := func(data string) {
+		t.Helper()
+		if err := os.WriteFile(beads, []byte(data), 0o644); err != nil {
+			t.Fatalf("write beads: %v", err)
+		}
+	}
+	write(`{"id":"bv-1","title":"Blocker","status":"open","issue_type":"task"}
+{"id":"bv-2","title":"Waiting","status":"open","issue_type":"task","dependencies":[{"issue_id":"bv-2","depends_on_id":"bv-1","type":"blocks"}]}
+`)
+	m := NewModel(nil, nil, beads)
+	updated, _ := m.Update(FileChangedMsg{})
+	m = updated.(Model)
+	stats := m.analysis
+	if m.countOpen != 2 || m.countReady != 1 {
+		t.Fatalf("counts after first load: open %d ready %d", m.countOpen, m.countReady)
+	}
+
+	// Closing the blocker leaves the graph alone: metrics carry over
+	write(`{"id":"bv-1","title":"Blocker","status":"closed","issue_type":"task"}
+{"id":"bv-2","title":"Waiting","status":"open","issue_type":"task","dependencies":[{"issue_id":"bv-2","depends_on_id":"bv-1","type":"blocks"}]}
+`)
+	updated, _ = m.Update(FileChangedMsg{})
+	m = updated.(Model)
+	if m.analysis != stats {
+		t.Error("status-only reload should reuse the graph metrics")
+	}
+	if m.countOpen != 1 || m.countReady != 1 || m.countClosed != 1 {
+		t.Errorf("counts after closing the blocker: open %d ready %d closed %d", m.countOpen, m.countReady, m.countClosed)
+	}
+	if got := m.analyzer.GetIssue("bv-1"); got == nil || got.Status != model.StatusClosed {
+		t.Errorf("analyzer still has the old issue data: %+v", got)
+	}
+
+	// A new issue changes the graph: full re-analysis
+	write(`{"id":"bv-1","title":"Blocker","status":"closed","issue_type":"task"}
+{"id":"bv-2","title":"Waiting","status":"open","issue_type":"task","dependencies":[{"issue_id":"bv-2","depends_on_id":"bv-1","type":"blocks"}]}
+{"id":"bv-3","title":"Fresh","status":"open","issue_type":"task","dependencies":[{"issue_id":"bv-3","depends_on_id":"bv-2","type":"blocks"}]}
+`)
+	updated, _ = m.Update(FileChangedMsg{})
+	m = updated.(Model)
+	if m.analysis == stats {
+		t.Error("adding an issue should re-analyze")
+	}
+	if m.countOpen != 2 || m.countReady != 1 {
+		t.Errorf("counts after adding bv-3: open %d ready %d", m.countOpen, m.countReady)
+	}
+}
+
+func TestRunReloadHooksCmd(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "payload.json")
+	config := &hooks.Config{Hooks: hooks.HooksByPhase{PostReload: []hooks.Hook{
+		{Name: "save", Command: "cat > " + out, Group: "g"},
+		{Name: "broken", Command: "exit 1", Group: "g", OnError: "continue"},
+	}}}
+	before := []model.Issue{{ID: "bv-1", Title: "Old", Status: model.StatusOpen}}
+	after := []model.Issue{{ID: "bv-1", Title: "Old", Status: model.StatusClosed}, {ID: "bv-2", Title: "New", Status: model.StatusOpen}}
+
+	msg := RunReloadHooksCmd(config, "/p/.beads/issues.jsonl", diffReload(before, after))()
+	done, ok := msg.(ReloadHooksDoneMsg)
+	if !ok || done.Total != 2 || done.Failed != 1 || !strings.HasPrefix(done.FirstError, "broken:") {
+		t.Fatalf("unexpected msg %#v", msg)
+	}
+
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var payload struct {
+		Phase string            `json:"phase"`
+		Data  reloadHookPayload `json:"data"`
+	}
+	if err := json.Unmarshal(data, &payload); err != nil {
+		t.Fatalf("payload %s: %v", data, err)
+	}
+	if payload.Phase != "post-reload" || payload.Data.Total != 2 ||
+		!slices.Equal(payload.Data.New, []string{"bv-2"}) || !slices.Equal(payload.Data.Closed, []string{"bv-1"}) {
+		t.Errorf("unexpected payload %+v", payload)
+	}
+}
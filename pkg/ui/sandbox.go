@@ -0,0 +1,349 @@
+package ui
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// SandboxScriptPath is where w in the sandbox writes the bd script.
+func SandboxScriptPath(workDir string) string {
+	return filepath.Join(workDir, ".bv", "sandbox.sh")
+}
+
+// openSandbox shows the simulation sandbox: hypothetical closes, deferrals
+// and dependencies, with the plan, forecast and critical path recomputed
+// after every change. Changes are kept until reset or the program exits; the
+// data file is never touched.
+func (m Model) openSandbox() Model {
+	issueByID := make(map[string]*model.Issue, len(m.issues))
+	m.sandboxRows = m.sandboxRows[:0]
+	for i := range m.issues {
+		issue := &m.issues[i]
+		issueByID[issue.ID] = issue
+		if !issue.Status.IsClosed() && !issue.Status.IsTombstone() {
+			m.sandboxRows = append(m.sandboxRows, issue.ID)
+		}
+	}
+	if len(m.sandboxRows) == 0 {
+		m.statusMsg = "Sandbox: no open issues to play with"
+		m.statusIsError = false
+		return m
+	}
+	slices.SortStableFunc(m.sandboxRows, func(a, b string) int {
+		return cmp.Or(cmp.Compare(issueByID[a].Priority, issueByID[b].Priority), cmp.Compare(a, b))
+	})
+
+	// Drop changes to issues that are gone or closed since the last visit
+	m.sandboxChanges = slices.DeleteFunc(m.sandboxChanges, func(c analysis.SandboxChange) bool {
+		return !slices.Contains(m.sandboxRows, c.IssueID) || (c.DependsOnID != "" && issueByID[c.DependsOnID] == nil)
+	})
+
+	m.sandboxCursor = 0
+	if item, ok := m.list.SelectedItem().(IssueItem); ok {
+		if i := slices.Index(m.sandboxRows, item.Issue.ID); i >= 0 {
+			m.sandboxCursor = i
+		}
+	}
+	m.sandboxEdgeFrom = ""
+	m.sandboxBefore = analysis.ComputeSandboxMetrics(m.issues, 1, time.Now())
+	m = m.recomputeSandbox()
+	m.showSandbox = true
+	return m
+}
+
+// recomputeSandbox reruns the analysis over the issues with the changes made.
+func (m Model) recomputeSandbox() Model {
+	m.sandboxDiff = analysis.SimulateSandbox(m.issues, m.sandboxBefore, m.sandboxChanges, 1, time.Now())
+	return m
+}
+
+// toggleSandboxStatus closes or defers the selected issue, or takes back that
+// change if it was already made. An issue is either closed or deferred.
+func (m Model) toggleSandboxStatus(kind analysis.SandboxChangeKind) Model {
+	id := m.sandboxRows[m.sandboxCursor]
+	had := slices.ContainsFunc(m.sandboxChanges, func(c analysis.SandboxChange) bool { return c.Kind == kind && c.IssueID == id })
+	m.sandboxChanges = slices.DeleteFunc(m.sandboxChanges, func(c analysis.SandboxChange) bool {
+		return c.IssueID == id && (c.Kind == analysis.SandboxClose || c.Kind == analysis.SandboxDefer)
+	})
+	if !had {
+		m.sandboxChanges = append(m.sandboxChanges, analysis.SandboxChange{Kind: kind, IssueID: id})
+	}
+	return m.recomputeSandbox()
+}
+
+// addSandboxEdge makes the issue picked with b wait on the selected issue.
+func (m Model) addSandboxEdge() Model {
+	from, to := m.sandboxEdgeFrom, m.sandboxRows[m.sandboxCursor]
+	m.sandboxEdgeFrom = ""
+	if from == to {
+		m.statusMsg = "Sandbox: an issue cannot wait on itself"
+		m.statusIsError = true
+		return m
+	}
+	if issue := m.issueMap[from]; issue != nil {
+		for _, dep := range issue.Dependencies {
+			if dep != nil && dep.Type.IsBlocking() && dep.DependsOnID == to {
+				m.statusMsg = fmt.Sprintf("Sandbox: %s already waits on %s", from, to)
+				m.statusIsError = true
+				return m
+			}
+		}
+	}
+	change := analysis.SandboxChange{Kind: analysis.SandboxAddDep, IssueID: from, DependsOnID: to}
+	if !slices.Contains(m.sandboxChanges, change) {
+		m.sandboxChanges = append(m.sandboxChanges, change)
+	}
+	m.statusMsg = "Sandbox: " + change.String()
+	m.statusIsError = false
+	return m.recomputeSandbox()
+}
+
+// handleSandboxKeys handles keys while the sandbox is open.
+func (m Model) handleSandboxKeys(msg tea.KeyMsg) (Model, tea.Cmd) {
+	switch msg.String() {
+	case "j", "down":
+		if m.sandboxCursor < len(m.sandboxRows)-1 {
+			m.sandboxCursor++
+		}
+	case "k", "up":
+		if m.sandboxCursor > 0 {
+			m.sandboxCursor--
+		}
+	case "home":
+		m.sandboxCursor = 0
+	case "G", "end":
+		m.sandboxCursor = len(m.sandboxRows) - 1
+	case "c":
+		m = m.toggleSandboxStatus(analysis.SandboxClose)
+	case "d":
+		m = m.toggleSandboxStatus(analysis.SandboxDefer)
+	case "b", "enter":
+		if m.sandboxEdgeFrom == "" {
+			if msg.String() == "b" {
+				m.sandboxEdgeFrom = m.sandboxRows[m.sandboxCursor]
+				m.statusMsg = fmt.Sprintf("Sandbox: pick the issue %s should wait on, then b or enter (esc cancels)", m.sandboxEdgeFrom)
+				m.statusIsError = false
+			}
+			break
+		}
+		m = m.addSandboxEdge()
+	case "u":
+		if len(m.sandboxChanges) > 0 {
+			m.sandboxChanges = m.sandboxChanges[:len(m.sandboxChanges)-1]
+			m = m.recomputeSandbox()
+		}
+	case "r":
+		m.sandboxChanges = nil
+		m.sandboxEdgeFrom = ""
+		m = m.recomputeSandbox()
+	case "y":
+		if len(m.sandboxChanges) == 0 {
+			break
+		}
+		script := analysis.SandboxScript(m.sandboxChanges, time.Now())
+		if _, err := copyToClipboard(script); err != nil {
+			m.statusMsg = fmt.Sprintf("Clipboard unavailable; press w to write %s instead", SandboxScriptPath(m.workDir))
+			m.statusIsError = true
+		} else {
+			m.statusMsg = fmt.Sprintf("Copied bd script (%d changes)", len(m.sandboxChanges))
+			m.statusIsError = false
+		}
+	case "w":
+		if len(m.sandboxChanges) == 0 {
+			break
+		}
+		path := SandboxScriptPath(m.workDir)
+		script := analysis.SandboxScript(m.sandboxChanges, time.Now())
+		err := os.MkdirAll(filepath.Dir(path), 0o755)
+		if err == nil {
+			err = os.WriteFile(path, []byte(script), 0o755)
+		}
+		if err != nil {
+			m.statusMsg = "Writing sandbox script: " + err.Error()
+			m.statusIsError = true
+		} else {
+			m.statusMsg = fmt.Sprintf("Wrote %d bd commands to %s", len(m.sandboxChanges), path)
+			m.statusIsError = false
+		}
+	case "esc":
+		if m.sandboxEdgeFrom != "" {
+			m.sandboxEdgeFrom = ""
+			m.statusMsg = ""
+			break
+		}
+		m.showSandbox = false
+	case "q", "X":
+		m.showSandbox = false
+	case "ctrl+c":
+		return m, tea.Quit
+	}
+	return m, nil
+}
+
+// renderSandbox renders the open issues with their hypothetical changes next
+// to what the changes do to the plan, critical path and forecast.
+func (m Model) renderSandbox() string {
+	t := m.theme
+	width := min(120, m.width-4)
+	innerWidth := width - 6
+
+	titleStyle := t.Renderer.NewStyle().Bold(true).Foreground(t.Primary)
+	dimStyle := t.Renderer.NewStyle().Foreground(t.Secondary).Italic(true)
+	labelStyle := t.Renderer.NewStyle().Foreground(t.Subtext)
+	goodStyle := t.Renderer.NewStyle().Foreground(t.Open)
+	badStyle := t.Renderer.NewStyle().Foreground(t.Blocked)
+
+	d := m.sandboxDiff
+	changeOf := make(map[string]analysis.SandboxChangeKind)
+	for _, c := range m.sandboxChanges {
+		if c.Kind != analysis.SandboxAddDep {
+			changeOf[c.IssueID] = c.Kind
+		}
+	}
+
+	// Issue list
+	listWidth := innerWidth
+	if innerWidth >= 90 {
+		listWidth = innerWidth * 2 / 5
+	}
+	maxRows := max(m.height-14, 3)
+	start := 0
+	if m.sandboxCursor >= maxRows {
+		start = m.sandboxCursor - maxRows + 1
+	}
+	end := min(start+maxRows, len(m.sandboxRows))
+	var rows []string
+	for i := start; i < end; i++ {
+		id := m.sandboxRows[i]
+		issue := m.issueMap[id]
+		tag, style := "", t.Renderer.NewStyle()
+		switch {
+		case changeOf[id] == analysis.SandboxClose:
+			tag, style = "closed", t.Renderer.NewStyle().Foreground(t.Closed).Strikethrough(true)
+		case changeOf[id] == analysis.SandboxDefer:
+			tag, style = "deferred", t.Renderer.NewStyle().Foreground(t.Secondary)
+		case slices.Contains(d.Unblocked, id):
+			tag, style = "unblocked", goodStyle
+		case slices.Contains(d.NewlyBlocked, id):
+			tag, style = "blocked", badStyle
+		}
+		if id == m.sandboxEdgeFrom {
+			tag = "waits on…"
+		}
+		prefix := "  "
+		if i == m.sandboxCursor {
+			prefix = t.Renderer.NewStyle().Foreground(t.Primary).Bold(true).Render("▸ ")
+			style = style.Background(t.Highlight)
+		}
+		title := ""
+		if issue != nil {
+			title = fmt.Sprintf("P%d %s", issue.Priority, issue.Title)
+		}
+		suffix := ""
+		if tag != "" {
+			suffix = " [" + tag + "]"
+		}
+		text := truncateRunesHelper(id+"  "+title, max(listWidth-lipgloss.Width(suffix)-2, 8), "…")
+		rows = append(rows, prefix+style.Render(text)+dimStyle.Render(suffix))
+	}
+	list := strings.Join(rows, "\n")
+	if len(m.sandboxRows) > maxRows {
+		list += "\n" + dimStyle.Render(fmt.Sprintf("  %d-%d of %d", start+1, end, len(m.sandboxRows)))
+	}
+
+	// Impact of the changes
+	delta := func(before, after float64, unit string, lowerIsBetter bool) string {
+		s := fmt.Sprintf("%g%s → %g%s", before, unit, after, unit)
+		diff := after - before
+		if diff == 0 {
+			return s
+		}
+		text := fmt.Sprintf(" (%+g%s)", diff, unit)
+		if (diff < 0) == lowerIsBetter {
+			return s + goodStyle.Render(text)
+		}
+		return s + badStyle.Render(text)
+	}
+	ids := func(list []string) string {
+		if len(list) == 0 {
+			return "–"
+		}
+		return strings.Join(list, ", ")
+	}
+	b, a := d.Before, d.After
+	impact := []string{
+		labelStyle.Render("Actionable  ") + delta(float64(b.Actionable), float64(a.Actionable), "", false),
+		labelStyle.Render("Blocked     ") + delta(float64(b.Blocked), float64(a.Blocked), "", true),
+		labelStyle.Render("Deferred    ") + fmt.Sprintf("%d → %d issues parked", b.Parked, a.Parked),
+		labelStyle.Render("Top pick    ") + cmp.Or(b.TopPick, "–") + " → " + cmp.Or(a.TopPick, "–"),
+		labelStyle.Render("Critical    ") + delta(b.CriticalPathDays, a.CriticalPathDays, "d", true),
+		labelStyle.Render("            ") + dimStyle.Render(ids(a.CriticalPath)),
+		labelStyle.Render("Forecast    ") + fmt.Sprintf("P50 %s → %s", b.Forecast.P50Date.Format("2006-01-02"), a.Forecast.P50Date.Format("2006-01-02")) +
+			" " + delta(round1(b.Forecast.P50), round1(a.Forecast.P50), "d", true),
+		labelStyle.Render("            ") + fmt.Sprintf("P80 %s → %s", b.Forecast.P80Date.Format("2006-01-02"), a.Forecast.P80Date.Format("2006-01-02")),
+		"",
+		labelStyle.Render("Unblocked   ") + goodStyle.Render(ids(d.Unblocked)),
+		labelStyle.Render("Now blocked ") + badStyle.Render(ids(d.NewlyBlocked)),
+	}
+	for _, cycle := range d.NewCycles {
+		impact = append(impact, labelStyle.Render("New cycle   ")+badStyle.Render(strings.Join(cycle, " → ")))
+	}
+	if d.ResolvedCycles > 0 {
+		impact = append(impact, labelStyle.Render("Cycles      ")+goodStyle.Render(fmt.Sprintf("%d resolved", d.ResolvedCycles)))
+	}
+	if len(a.Stuck) > 0 {
+		impact = append(impact, labelStyle.Render("Stuck       ")+badStyle.Render(ids(a.Stuck)))
+	}
+	impact = append(impact, "", titleStyle.Render(fmt.Sprintf("Changes (%d)", len(m.sandboxChanges))))
+	if len(m.sandboxChanges) == 0 {
+		impact = append(impact, dimStyle.Render("None yet: c close · d defer · b add a dependency"))
+	}
+	for i, c := range m.sandboxChanges {
+		impact = append(impact, fmt.Sprintf("%d. %s", i+1, c.String()))
+	}
+	impactWidth := innerWidth
+	if listWidth < innerWidth {
+		impactWidth = innerWidth - listWidth - 3
+	}
+	for i, line := range impact {
+		if lipgloss.Width(line) > impactWidth {
+			impact[i] = truncateRunesHelper(line, impactWidth, "…")
+		}
+	}
+	impactBlock := strings.Join(impact, "\n")
+
+	var body string
+	if listWidth < innerWidth {
+		left := t.Renderer.NewStyle().Width(listWidth).Render(list)
+		body = lipgloss.JoinHorizontal(lipgloss.Top, left, "   ", impactBlock)
+	} else {
+		body = list + "\n\n" + impactBlock
+	}
+
+	footer := "j/k: move | c: close | d: defer | b: add dependency | u: undo | r: reset | y: copy bd script | w: write " + SandboxScriptPath(".") + " | esc: leave"
+	content := titleStyle.Render("🧪 Sandbox") + dimStyle.Render("  what-if changes, never written to the beads file") + "\n\n" +
+		body + "\n\n" + dimStyle.Render(truncateRunesHelper(footer, innerWidth, "…"))
+	box := t.Renderer.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(t.Primary).
+		Padding(1, 2).
+		Width(width).
+		Render(content)
+
+	return lipgloss.Place(m.width, m.height-1, lipgloss.Center, lipgloss.Center, box)
+}
+
+func round1(v float64) float64 {
+	return math.Round(v*10) / 10
+}
@@ -0,0 +1,80 @@
+package ui
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestSandbox(t *testing.T) {
+	minutes := func(n int) *int { return &n }
+	issues := []model.Issue{
+		{ID: "bv-1", Title: "Schema", Status: model.StatusOpen, Priority: 1, EstimatedMinutes: minutes(480)},
+		{ID: "bv-2", Title: "Parser", Status: model.StatusOpen, Priority: 2, EstimatedMinutes: minutes(480),
+			Dependencies: []*model.Dependency{{DependsOnID: "bv-1", Type: model.DepBlocks}}},
+		{ID: "bv-3", Title: "Docs", Status: model.StatusOpen, Priority: 3, EstimatedMinutes: minutes(240)},
+		{ID: "bv-4", Title: "Old", Status: model.StatusClosed, Priority: 1},
+	}
+	m := NewModel(issues, nil, "")
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 140, Height: 40})
+	m = updated.(Model)
+	m.workDir = t.TempDir()
+
+	m = pressKeys(t, m, "X")
+	if !m.showSandbox || m.CurrentContext() != ContextSandbox {
+		t.Fatalf("X should open the sandbox (context %s)", m.CurrentContext())
+	}
+	if len(m.sandboxRows) != 3 || m.sandboxRows[0] != "bv-1" {
+		t.Fatalf("rows = %v, want the open issues by priority", m.sandboxRows)
+	}
+
+	// Closing bv-1 unblocks bv-2
+	m.sandboxCursor = 0
+	m = pressKeys(t, m, "c")
+	if len(m.sandboxDiff.Unblocked) != 1 || m.sandboxDiff.Unblocked[0] != "bv-2" {
+		t.Fatalf("unblocked = %v", m.sandboxDiff.Unblocked)
+	}
+	if m.issues[0].Status != model.StatusOpen {
+		t.Fatal("the sandbox must not change the loaded issues")
+	}
+
+	// bv-3 waits on bv-2: pick bv-3 with b, then bv-2
+	m = pressKeys(t, m, "j", "j", "b", "k", "enter")
+	if len(m.sandboxChanges) != 2 || m.sandboxChanges[1].String() != "bv-3 waits on bv-2" {
+		t.Fatalf("changes = %v", m.sandboxChanges)
+	}
+	view := m.View()
+	for _, want := range []string{"Sandbox", "[closed]", "Critical", "Forecast", "1. close bv-1", "2. bv-3 waits on bv-2"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("sandbox view missing %q", want)
+		}
+	}
+
+	// u takes back the edge; d swaps the close for a deferral
+	m = pressKeys(t, m, "u", "home", "d")
+	if len(m.sandboxChanges) != 1 || m.sandboxChanges[0].String() != "defer bv-1" {
+		t.Fatalf("changes after undo and defer = %v", m.sandboxChanges)
+	}
+	if m.sandboxDiff.After.Parked != 2 {
+		t.Errorf("deferring bv-1 should park bv-1 and bv-2, got %d", m.sandboxDiff.After.Parked)
+	}
+
+	m = pressKeys(t, m, "w")
+	script, err := os.ReadFile(SandboxScriptPath(m.workDir))
+	if err != nil || !strings.Contains(string(script), "bd update 'bv-1' --status=deferred") {
+		t.Fatalf("script = %q, %v", script, err)
+	}
+
+	// Leaving keeps the changes for the next visit
+	m = pressKeys(t, m, "esc")
+	if m.showSandbox {
+		t.Fatal("esc should leave the sandbox")
+	}
+	m = pressKeys(t, m, "X", "r")
+	if len(m.sandboxChanges) != 0 || len(m.sandboxDiff.Unblocked) != 0 {
+		t.Errorf("r should reset the sandbox, got %v", m.sandboxChanges)
+	}
+}
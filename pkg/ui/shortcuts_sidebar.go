@@ -121,6 +121,7 @@ func (s *ShortcutsSidebar) allSections() []shortcutSection {
 				{"H/L", "Scroll ←/→"},
 				{"PgUp/Dn", "Scroll ↑/↓"},
 				{"Enter", "Jump to issue"},
+				{"B", "Break cycles"},
 			},
 		},
 		{
@@ -146,7 +147,8 @@ func (s *ShortcutsSidebar) allSections() []shortcutSection {
 				{"J/K", "Detail ↓/↑"},
 				{"Tab", "Focus toggle"},
 				{"y", "Copy SHA"},
-				{"o", "Open in browser"},
+				{"o", "Edit changed files"},
+				{"O", "Open in browser"},
 				{"g", "Graph view"},
 				{"c", "Cycle filter"},
 			},
@@ -169,6 +171,7 @@ func (s *ShortcutsSidebar) allSections() []shortcutSection {
 				{"o", "Open only"},
 				{"c", "Closed only"},
 				{"r", "Ready (no blocks)"},
+				{"I", "In review"},
 				{"l", "Label picker"},
 				{"/", "Search"},
 			},
@@ -133,8 +133,12 @@ func RenderStatusBadge(status string) string {
 		fg, bg, label = ColorStatusOpen, ColorStatusOpenBg, "OPEN"
 	case "in_progress":
 		fg, bg, label = ColorStatusInProgress, ColorStatusInProgressBg, "PROG"
+	case "in_review":
+		fg, bg, label = ColorStatusInProgress, ColorStatusInProgressBg, "REVW"
 	case "blocked":
 		fg, bg, label = ColorStatusBlocked, ColorStatusBlockedBg, "BLKD"
+	case "deferred":
+		fg, bg, label = ColorMuted, ColorBgSubtle, "DEFR"
 	case "closed":
 		fg, bg, label = ColorStatusClosed, ColorStatusClosedBg, "DONE"
 	default:
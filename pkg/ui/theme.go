@@ -1,17 +1,43 @@
 package ui
 
 import (
+	"bytes"
+	"cmp"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/configdir"
 	"github.com/charmbracelet/lipgloss"
+	"gopkg.in/yaml.v3"
 )
 
 type Theme struct {
 	Renderer *lipgloss.Renderer
 
+	// Name is the built-in or custom palette this theme was built from
+	Name string
+
+	// mode pins the theme to "dark" or "light"; empty follows the terminal
+	mode string
+
 	// Colors
 	Primary   lipgloss.AdaptiveColor
 	Secondary lipgloss.AdaptiveColor
 	Subtext   lipgloss.AdaptiveColor
 
+	// Surfaces
+	Text       lipgloss.AdaptiveColor
+	Background lipgloss.AdaptiveColor
+	Surface    lipgloss.AdaptiveColor
+
 	// Status
 	Open       lipgloss.AdaptiveColor
 	InProgress lipgloss.AdaptiveColor
@@ -37,34 +63,260 @@ type Theme struct {
 	Header   lipgloss.Style
 }
 
+// ThemeColor is a palette entry. In YAML it is either a single color used on
+// both backgrounds ("#BD93F9", "212") or a {light, dark} pair.
+type ThemeColor struct {
+	Light string `yaml:"light"`
+	Dark  string `yaml:"dark"`
+}
+
+func (c *ThemeColor) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		c.Light, c.Dark = node.Value, node.Value
+		return nil
+	}
+	type plain ThemeColor
+	var p plain
+	if err := node.Decode(&p); err != nil {
+		return err
+	}
+	// A pair with one side set uses it for both
+	*c = ThemeColor{Light: cmp.Or(p.Light, p.Dark), Dark: cmp.Or(p.Dark, p.Light)}
+	return nil
+}
+
+func (c ThemeColor) adaptive() lipgloss.AdaptiveColor {
+	return lipgloss.AdaptiveColor{Light: c.Light, Dark: c.Dark}
+}
+
+// ThemePalette holds the colors a theme is built from.
+type ThemePalette struct {
+	Primary    ThemeColor `yaml:"primary"`
+	Secondary  ThemeColor `yaml:"secondary"`
+	Subtext    ThemeColor `yaml:"subtext"`
+	Text       ThemeColor `yaml:"text"`
+	Background ThemeColor `yaml:"background"`
+	Surface    ThemeColor `yaml:"surface"`
+	HeaderText ThemeColor `yaml:"header_text"`
+
+	Open       ThemeColor `yaml:"open"`
+	InProgress ThemeColor `yaml:"in_progress"`
+	Blocked    ThemeColor `yaml:"blocked"`
+	Closed     ThemeColor `yaml:"closed"`
+
+	Bug     ThemeColor `yaml:"bug"`
+	Feature ThemeColor `yaml:"feature"`
+	Task    ThemeColor `yaml:"task"`
+	Epic    ThemeColor `yaml:"epic"`
+	Chore   ThemeColor `yaml:"chore"`
+
+	Border    ThemeColor `yaml:"border"`
+	Highlight ThemeColor `yaml:"highlight"`
+	Muted     ThemeColor `yaml:"muted"`
+}
+
+// fields returns the palette entries by YAML name, in declaration order.
+func (p *ThemePalette) fields() []struct {
+	name  string
+	color *ThemeColor
+} {
+	return []struct {
+		name  string
+		color *ThemeColor
+	}{
+		{"primary", &p.Primary}, {"secondary", &p.Secondary}, {"subtext", &p.Subtext},
+		{"text", &p.Text}, {"background", &p.Background}, {"surface", &p.Surface},
+		{"header_text", &p.HeaderText},
+		{"open", &p.Open}, {"in_progress", &p.InProgress}, {"blocked", &p.Blocked}, {"closed", &p.Closed},
+		{"bug", &p.Bug}, {"feature", &p.Feature}, {"task", &p.Task}, {"epic", &p.Epic}, {"chore", &p.Chore},
+		{"border", &p.Border}, {"highlight", &p.Highlight}, {"muted", &p.Muted},
+	}
+}
+
+// overlay returns p with every color set in o replacing p's.
+func (p ThemePalette) overlay(o ThemePalette) ThemePalette {
+	dst := p.fields()
+	for i, f := range o.fields() {
+		if f.color.Light != "" || f.color.Dark != "" {
+			*dst[i].color = *f.color
+		}
+	}
+	return p
+}
+
+// pin returns the palette with every color fixed to its dark or light side.
+func (p ThemePalette) pin(dark bool) ThemePalette {
+	for _, f := range p.fields() {
+		if dark {
+			f.color.Light = f.color.Dark
+		} else {
+			f.color.Dark = f.color.Light
+		}
+	}
+	return p
+}
+
+var themeColorPattern = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+// validate reports colors that are neither hex nor ANSI 0-255 codes.
+func (p ThemePalette) validate() error {
+	var bad []string
+	for _, f := range p.fields() {
+		for _, v := range []string{f.color.Light, f.color.Dark} {
+			if v == "" || themeColorPattern.MatchString(v) {
+				continue
+			}
+			if n, err := strconv.Atoi(v); err == nil && n >= 0 && n <= 255 {
+				continue
+			}
+			bad = append(bad, fmt.Sprintf("%s: %q", f.name, v))
+		}
+	}
+	if len(bad) > 0 {
+		return fmt.Errorf("invalid colors (want #RRGGBB or 0-255): %s", strings.Join(bad, ", "))
+	}
+	return nil
+}
+
+// builtinTheme is a named palette shipped with bv.
+type builtinTheme struct {
+	mode    string // "dark"/"light" pins the palette; empty adapts
+	palette ThemePalette
+}
+
+// draculaPalette is the Dracula / Light Mode equivalent used by the default theme.
+// Light mode colors improved for WCAG AA compliance (bv-3fcg)
+var draculaPalette = ThemePalette{
+	Primary:    ThemeColor{Light: "#6B47D9", Dark: "#BD93F9"}, // Purple (darker for contrast)
+	Secondary:  ThemeColor{Light: "#555555", Dark: "#6272A4"}, // Gray
+	Subtext:    ThemeColor{Light: "#666666", Dark: "#BFBFBF"}, // Dim (was #999999, now ~6:1)
+	Text:       ThemeColor{Light: "#000000", Dark: "#F8F8F2"},
+	Background: ThemeColor{Light: "#FAFAFA", Dark: "#282A36"},
+	Surface:    ThemeColor{Light: "#EEEEEE", Dark: "#363949"},
+	HeaderText: ThemeColor{Light: "#FFFFFF", Dark: "#282A36"},
+
+	Open:       ThemeColor{Light: "#007700", Dark: "#50FA7B"}, // Green (was #00A800, now ~4.6:1)
+	InProgress: ThemeColor{Light: "#006080", Dark: "#8BE9FD"}, // Cyan (darker for contrast)
+	Blocked:    ThemeColor{Light: "#CC0000", Dark: "#FF5555"}, // Red (slightly adjusted)
+	Closed:     ThemeColor{Light: "#555555", Dark: "#6272A4"}, // Gray
+
+	Bug:     ThemeColor{Light: "#CC0000", Dark: "#FF5555"}, // Red
+	Feature: ThemeColor{Light: "#B06800", Dark: "#FFB86C"}, // Orange (darker for contrast)
+	Epic:    ThemeColor{Light: "#6B47D9", Dark: "#BD93F9"}, // Purple (darker)
+	Task:    ThemeColor{Light: "#808000", Dark: "#F1FA8C"}, // Yellow/olive (darker for contrast)
+	Chore:   ThemeColor{Light: "#006080", Dark: "#8BE9FD"}, // Cyan (darker)
+
+	Border:    ThemeColor{Light: "#AAAAAA", Dark: "#44475A"}, // Border (was #DDDDDD)
+	Highlight: ThemeColor{Light: "#E0E0E0", Dark: "#44475A"}, // Slightly darker
+	Muted:     ThemeColor{Light: "#555555", Dark: "#6272A4"}, // Dimmed text (was #888888, now ~7:1)
+}
+
+// solarizedPalette follows Ethan Schoonover's Solarized light/dark.
+var solarizedPalette = ThemePalette{
+	Primary:    ThemeColor{Light: "#6C71C4", Dark: "#6C71C4"}, // violet
+	Secondary:  ThemeColor{Light: "#657B83", Dark: "#586E75"},
+	Subtext:    ThemeColor{Light: "#586E75", Dark: "#93A1A1"},
+	Text:       ThemeColor{Light: "#073642", Dark: "#EEE8D5"},
+	Background: ThemeColor{Light: "#FDF6E3", Dark: "#002B36"},
+	Surface:    ThemeColor{Light: "#EEE8D5", Dark: "#073642"},
+	HeaderText: ThemeColor{Light: "#FDF6E3", Dark: "#002B36"},
+
+	Open:       ThemeColor{Light: "#859900", Dark: "#859900"}, // green
+	InProgress: ThemeColor{Light: "#2AA198", Dark: "#2AA198"}, // cyan
+	Blocked:    ThemeColor{Light: "#DC322F", Dark: "#DC322F"}, // red
+	Closed:     ThemeColor{Light: "#93A1A1", Dark: "#586E75"},
+
+	Bug:     ThemeColor{Light: "#DC322F", Dark: "#DC322F"},
+	Feature: ThemeColor{Light: "#CB4B16", Dark: "#CB4B16"}, // orange
+	Task:    ThemeColor{Light: "#B58900", Dark: "#B58900"}, // yellow
+	Epic:    ThemeColor{Light: "#D33682", Dark: "#D33682"}, // magenta
+	Chore:   ThemeColor{Light: "#268BD2", Dark: "#268BD2"}, // blue
+
+	Border:    ThemeColor{Light: "#93A1A1", Dark: "#586E75"},
+	Highlight: ThemeColor{Light: "#EEE8D5", Dark: "#073642"},
+	Muted:     ThemeColor{Light: "#839496", Dark: "#657B83"},
+}
+
+// highContrastPalette keeps every foreground at 7:1 or better against the background.
+var highContrastPalette = ThemePalette{
+	Primary:    ThemeColor{Light: "#0000B0", Dark: "#FFFF00"},
+	Secondary:  ThemeColor{Light: "#333333", Dark: "#D0D0D0"},
+	Subtext:    ThemeColor{Light: "#222222", Dark: "#E0E0E0"},
+	Text:       ThemeColor{Light: "#000000", Dark: "#FFFFFF"},
+	Background: ThemeColor{Light: "#FFFFFF", Dark: "#000000"},
+	Surface:    ThemeColor{Light: "#F0F0F0", Dark: "#1A1A1A"},
+	HeaderText: ThemeColor{Light: "#FFFFFF", Dark: "#000000"},
+
+	Open:       ThemeColor{Light: "#006400", Dark: "#00FF00"},
+	InProgress: ThemeColor{Light: "#00008B", Dark: "#00FFFF"},
+	Blocked:    ThemeColor{Light: "#B00000", Dark: "#FF4040"},
+	Closed:     ThemeColor{Light: "#444444", Dark: "#B0B0B0"},
+
+	Bug:     ThemeColor{Light: "#B00000", Dark: "#FF4040"},
+	Feature: ThemeColor{Light: "#8B4500", Dark: "#FFA500"},
+	Task:    ThemeColor{Light: "#5C5C00", Dark: "#FFFF80"},
+	Epic:    ThemeColor{Light: "#6A0DAD", Dark: "#FF80FF"},
+	Chore:   ThemeColor{Light: "#00008B", Dark: "#80FFFF"},
+
+	Border:    ThemeColor{Light: "#000000", Dark: "#FFFFFF"},
+	Highlight: ThemeColor{Light: "#D0D0FF", Dark: "#303060"},
+	Muted:     ThemeColor{Light: "#333333", Dark: "#C0C0C0"},
+}
+
+// builtinThemes are selectable by name; "auto" is the default and follows
+// the terminal background.
+var builtinThemes = map[string]builtinTheme{
+	"auto":          {palette: draculaPalette},
+	"dark":          {mode: "dark", palette: draculaPalette.pin(true)},
+	"light":         {mode: "light", palette: draculaPalette.pin(false)},
+	"solarized":     {palette: solarizedPalette},
+	"high-contrast": {palette: highContrastPalette},
+}
+
+// builtinThemeOrder is the cycling order of the runtime theme switcher.
+var builtinThemeOrder = []string{"auto", "dark", "light", "solarized", "high-contrast"}
+
 // DefaultTheme returns the standard Dracula-inspired theme (adaptive)
 func DefaultTheme(r *lipgloss.Renderer) Theme {
+	return NewTheme(r, "auto", "", draculaPalette)
+}
+
+// NewTheme builds a theme from a palette. mode "dark" or "light" pins the
+// theme to that background; empty follows the terminal.
+func NewTheme(r *lipgloss.Renderer, name, mode string, p ThemePalette) Theme {
+	if r == nil {
+		r = lipgloss.DefaultRenderer()
+	}
 	t := Theme{
 		Renderer: r,
+		Name:     name,
+		mode:     mode,
+
+		Primary:   p.Primary.adaptive(),
+		Secondary: p.Secondary.adaptive(),
+		Subtext:   p.Subtext.adaptive(),
 
-		// Dracula / Light Mode equivalent
-		// Light mode colors improved for WCAG AA compliance (bv-3fcg)
-		Primary:   lipgloss.AdaptiveColor{Light: "#6B47D9", Dark: "#BD93F9"}, // Purple (darker for contrast)
-		Secondary: lipgloss.AdaptiveColor{Light: "#555555", Dark: "#6272A4"}, // Gray
-		Subtext:   lipgloss.AdaptiveColor{Light: "#666666", Dark: "#BFBFBF"}, // Dim (was #999999, now ~6:1)
+		Text:       p.Text.adaptive(),
+		Background: p.Background.adaptive(),
+		Surface:    p.Surface.adaptive(),
 
-		Open:       lipgloss.AdaptiveColor{Light: "#007700", Dark: "#50FA7B"}, // Green (was #00A800, now ~4.6:1)
-		InProgress: lipgloss.AdaptiveColor{Light: "#006080", Dark: "#8BE9FD"}, // Cyan (darker for contrast)
-		Blocked:    lipgloss.AdaptiveColor{Light: "#CC0000", Dark: "#FF5555"}, // Red (slightly adjusted)
-		Closed:     lipgloss.AdaptiveColor{Light: "#555555", Dark: "#6272A4"}, // Gray
+		Open:       p.Open.adaptive(),
+		InProgress: p.InProgress.adaptive(),
+		Blocked:    p.Blocked.adaptive(),
+		Closed:     p.Closed.adaptive(),
 
-		Bug:     lipgloss.AdaptiveColor{Light: "#CC0000", Dark: "#FF5555"}, // Red
-		Feature: lipgloss.AdaptiveColor{Light: "#B06800", Dark: "#FFB86C"}, // Orange (darker for contrast)
-		Epic:    lipgloss.AdaptiveColor{Light: "#6B47D9", Dark: "#BD93F9"}, // Purple (darker)
-		Task:    lipgloss.AdaptiveColor{Light: "#808000", Dark: "#F1FA8C"}, // Yellow/olive (darker for contrast)
-		Chore:   lipgloss.AdaptiveColor{Light: "#006080", Dark: "#8BE9FD"}, // Cyan (darker)
+		Bug:     p.Bug.adaptive(),
+		Feature: p.Feature.adaptive(),
+		Epic:    p.Epic.adaptive(),
+		Task:    p.Task.adaptive(),
+		Chore:   p.Chore.adaptive(),
 
-		Border:    lipgloss.AdaptiveColor{Light: "#AAAAAA", Dark: "#44475A"}, // Border (was #DDDDDD)
-		Highlight: lipgloss.AdaptiveColor{Light: "#E0E0E0", Dark: "#44475A"}, // Slightly darker
-		Muted:     lipgloss.AdaptiveColor{Light: "#555555", Dark: "#6272A4"}, // Dimmed text (was #888888, now ~7:1)
+		Border:    p.Border.adaptive(),
+		Highlight: p.Highlight.adaptive(),
+		Muted:     p.Muted.adaptive(),
 	}
 
-	t.Base = r.NewStyle().Foreground(lipgloss.AdaptiveColor{Light: "#000000", Dark: "#F8F8F2"})
+	t.Base = r.NewStyle().Foreground(t.Text)
 
 	t.Selected = r.NewStyle().
 		Background(t.Highlight).
@@ -75,18 +327,302 @@ func DefaultTheme(r *lipgloss.Renderer) Theme {
 
 	t.Header = r.NewStyle().
 		Background(t.Primary).
-		Foreground(lipgloss.AdaptiveColor{Light: "#FFFFFF", Dark: "#282A36"}).
+		Foreground(p.HeaderText.adaptive()).
 		Bold(true).
 		Padding(0, 1)
 
 	return t
 }
 
+// IsDark reports whether the theme renders for a dark background: pinned
+// themes say so directly, adaptive ones ask the terminal.
+func (t Theme) IsDark() bool {
+	return t.isDarkOr(func() bool {
+		if t.Renderer == nil {
+			return lipgloss.HasDarkBackground()
+		}
+		return t.Renderer.HasDarkBackground()
+	})
+}
+
+func (t Theme) isDarkOr(detect func() bool) bool {
+	switch t.mode {
+	case "dark":
+		return true
+	case "light":
+		return false
+	}
+	return detect()
+}
+
+// resolve picks the side of an adaptive color this theme renders with.
+func (t Theme) resolve(c lipgloss.AdaptiveColor, dark bool) lipgloss.Color {
+	if dark {
+		return lipgloss.Color(c.Dark)
+	}
+	return lipgloss.Color(c.Light)
+}
+
+// ThemeFile is the format of theme.yaml.
+type ThemeFile struct {
+	// Theme selects a built-in or custom theme by name
+	Theme string `yaml:"theme"`
+
+	// Colors overrides individual colors of whichever theme is in use
+	Colors ThemePalette `yaml:"colors"`
+
+	// Themes defines custom named palettes
+	Themes map[string]CustomTheme `yaml:"themes"`
+}
+
+// CustomTheme is a user-defined palette layered on a built-in theme.
+type CustomTheme struct {
+	Base   string       `yaml:"base"`
+	Colors ThemePalette `yaml:"colors"`
+}
+
+// ThemeSet is every selectable theme after loading user and project
+// theme files, plus which one is active.
+type ThemeSet struct {
+	Active  string
+	custom  map[string]CustomTheme
+	colors  ThemePalette // top-level overrides applied to the active theme
+	Sources []string     // theme files that were loaded
+}
+
+// DefaultThemeUserPath returns theme.yaml in the user config directory
+// (~/.config/bv, %AppData%\bv on Windows).
+func DefaultThemeUserPath() string {
+	return configdir.Path("theme.yaml")
+}
+
+// LoadThemes reads the user theme file (userPath) and then the project file
+// (<projectDir>/.bv/theme.yaml); later files override the theme name and
+// colors of earlier ones. Missing files are fine; invalid files are returned
+// as errors alongside a usable set.
+func LoadThemes(userPath, projectDir string) (*ThemeSet, error) {
+	set := &ThemeSet{Active: "auto", custom: make(map[string]CustomTheme)}
+	var errs []string
+	if userPath != "" {
+		if err := set.loadFile(userPath); err != nil && !os.IsNotExist(err) {
+			errs = append(errs, fmt.Sprintf("user theme: %v", err))
+		}
+	}
+	if projectDir != "" {
+		if err := set.loadFile(filepath.Join(projectDir, ".bv", "theme.yaml")); err != nil && !os.IsNotExist(err) {
+			errs = append(errs, fmt.Sprintf("project theme: %v", err))
+		}
+	}
+	if _, err := set.palette(set.Active); err != nil {
+		errs = append(errs, err.Error())
+		set.Active = "auto"
+	}
+	if len(errs) > 0 {
+		return set, fmt.Errorf("%s", strings.Join(errs, "; "))
+	}
+	return set, nil
+}
+
+func (s *ThemeSet) loadFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	var file ThemeFile
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&file); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("parsing %s: %w", path, err)
+	}
+	if err := file.Colors.validate(); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	for name, ct := range file.Themes {
+		if _, ok := builtinThemes[name]; ok {
+			return fmt.Errorf("%s: custom theme %q shadows a built-in theme", path, name)
+		}
+		if err := ct.Colors.validate(); err != nil {
+			return fmt.Errorf("%s: theme %q: %w", path, name, err)
+		}
+		s.custom[name] = ct
+	}
+	if file.Theme != "" {
+		s.Active = strings.ToLower(file.Theme)
+	}
+	s.colors = s.colors.overlay(file.Colors)
+	s.Sources = append(s.Sources, path)
+	return nil
+}
+
+// Names lists the built-in themes in switcher order, then custom themes
+// alphabetically.
+func (s *ThemeSet) Names() []string {
+	names := append([]string(nil), builtinThemeOrder...)
+	if s == nil {
+		return names
+	}
+	var custom []string
+	for name := range s.custom {
+		custom = append(custom, name)
+	}
+	sort.Strings(custom)
+	return append(names, custom...)
+}
+
+// palette returns the mode and colors of a named theme.
+func (s *ThemeSet) palette(name string) (builtinTheme, error) {
+	if b, ok := builtinThemes[name]; ok {
+		return b, nil
+	}
+	if s != nil {
+		if ct, ok := s.custom[name]; ok {
+			baseName := cmp.Or(strings.ToLower(ct.Base), "auto")
+			base, ok := builtinThemes[baseName]
+			if !ok {
+				return builtinTheme{}, fmt.Errorf("theme %q: unknown base %q", name, ct.Base)
+			}
+			p := ct.Colors
+			if base.mode != "" {
+				p = p.pin(base.mode == "dark")
+			}
+			return builtinTheme{mode: base.mode, palette: base.palette.overlay(p)}, nil
+		}
+	}
+	return builtinTheme{}, fmt.Errorf("unknown theme %q (available: %s)", name, strings.Join(s.Names(), ", "))
+}
+
+// Theme builds the named theme (the set's active theme when name is empty);
+// the set's top-level color overrides apply to whichever theme is built.
+func (s *ThemeSet) Theme(r *lipgloss.Renderer, name string) (Theme, error) {
+	if name == "" && s != nil {
+		name = s.Active
+	}
+	name = strings.ToLower(cmp.Or(name, "auto"))
+	b, err := s.palette(name)
+	if err != nil {
+		return Theme{}, err
+	}
+	p := b.palette
+	if s != nil {
+		overrides := s.colors
+		if b.mode != "" {
+			overrides = overrides.pin(b.mode == "dark")
+		}
+		p = p.overlay(overrides)
+	}
+	return NewTheme(r, name, b.mode, p), nil
+}
+
+// NextThemeName returns the theme after current in switcher order.
+func (s *ThemeSet) NextThemeName(current string) string {
+	names := s.Names()
+	for i, n := range names {
+		if n == current {
+			return names[(i+1)%len(names)]
+		}
+	}
+	return names[0]
+}
+
+// mixHex blends fg into bg (weight 0-1 of fg). Non-hex colors (ANSI codes)
+// can't be blended, so fallback is returned for them.
+func mixHex(fg, bg lipgloss.Color, weight float64, fallback lipgloss.Color) lipgloss.Color {
+	f, ok1 := parseHex(string(fg))
+	b, ok2 := parseHex(string(bg))
+	if !ok1 || !ok2 {
+		return fallback
+	}
+	var out [3]int
+	for i := range out {
+		out[i] = int(math.Round(float64(f[i])*weight + float64(b[i])*(1-weight)))
+	}
+	return lipgloss.Color(fmt.Sprintf("#%02X%02X%02X", out[0], out[1], out[2]))
+}
+
+func parseHex(s string) ([3]int, bool) {
+	if !themeColorPattern.MatchString(s) {
+		return [3]int{}, false
+	}
+	s = s[1:]
+	if len(s) == 3 {
+		s = string([]byte{s[0], s[0], s[1], s[1], s[2], s[2]})
+	}
+	var rgb [3]int
+	for i := range rgb {
+		v, err := strconv.ParseUint(s[i*2:i*2+2], 16, 8)
+		if err != nil {
+			return [3]int{}, false
+		}
+		rgb[i] = int(v)
+	}
+	return rgb, true
+}
+
+// applyThemeColors points the package-level design tokens (ColorBg,
+// ColorStatusOpen, PanelStyle, ...) used by the board, graph, insights and
+// other views at the theme's colors for the current background.
+func applyThemeColors(t Theme) {
+	dark := t.IsDark()
+	c := func(ac lipgloss.AdaptiveColor) lipgloss.Color { return t.resolve(ac, dark) }
+	black := lipgloss.Color("#000000")
+
+	ColorBg = c(t.Background)
+	if dark {
+		ColorBgDark = mixHex(black, ColorBg, 0.25, ColorBg)
+	} else {
+		ColorBgDark = mixHex(black, ColorBg, 0.05, ColorBg)
+	}
+	ColorBgSubtle = c(t.Surface)
+	ColorBgHighlight = c(t.Highlight)
+	ColorText = c(t.Text)
+	ColorSubtext = c(t.Subtext)
+	ColorMuted = c(t.Muted)
+
+	ColorPrimary = c(t.Primary)
+	ColorSecondary = c(t.Secondary)
+	ColorInfo = c(t.InProgress)
+	ColorSuccess = c(t.Open)
+	ColorWarning = c(t.Feature)
+	ColorDanger = c(t.Blocked)
+
+	// Badge backgrounds are the foreground washed into the background
+	badgeBg := func(fg lipgloss.Color) lipgloss.Color {
+		return mixHex(fg, ColorBgDark, 0.2, ColorBgSubtle)
+	}
+
+	ColorStatusOpen = c(t.Open)
+	ColorStatusInProgress = c(t.InProgress)
+	ColorStatusBlocked = c(t.Blocked)
+	ColorStatusClosed = c(t.Closed)
+	ColorStatusOpenBg = badgeBg(ColorStatusOpen)
+	ColorStatusInProgressBg = badgeBg(ColorStatusInProgress)
+	ColorStatusBlockedBg = badgeBg(ColorStatusBlocked)
+	ColorStatusClosedBg = badgeBg(ColorStatusClosed)
+
+	ColorPrioCritical = c(t.Blocked)
+	ColorPrioHigh = c(t.Feature)
+	ColorPrioMedium = c(t.Task)
+	ColorPrioLow = c(t.Open)
+	ColorPrioCriticalBg = badgeBg(ColorPrioCritical)
+	ColorPrioHighBg = badgeBg(ColorPrioHigh)
+	ColorPrioMediumBg = badgeBg(ColorPrioMedium)
+	ColorPrioLowBg = badgeBg(ColorPrioLow)
+
+	ColorTypeBug = c(t.Bug)
+	ColorTypeFeature = c(t.Feature)
+	ColorTypeTask = c(t.Task)
+	ColorTypeEpic = c(t.Epic)
+	ColorTypeChore = c(t.Chore)
+
+	PanelStyle = PanelStyle.BorderForeground(c(t.Border))
+	FocusedPanelStyle = FocusedPanelStyle.BorderForeground(ColorPrimary)
+}
+
 func (t Theme) GetStatusColor(s string) lipgloss.AdaptiveColor {
 	switch s {
 	case "open":
 		return t.Open
-	case "in_progress":
+	case "in_progress", "in_review":
 		return t.InProgress
 	case "blocked":
 		return t.Blocked
@@ -115,4 +651,3 @@ func (t Theme) GetTypeIcon(typ string) (string, lipgloss.AdaptiveColor) {
 		return "•", t.Subtext
 	}
 }
-
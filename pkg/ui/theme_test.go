@@ -1,8 +1,12 @@
 package ui
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 )
 
@@ -77,3 +81,205 @@ func TestGetTypeIcon(t *testing.T) {
 		}
 	}
 }
+
+// snapshotThemeColors restores the package design tokens after a test that
+// applies a theme.
+func snapshotThemeColors(t *testing.T) {
+	t.Helper()
+	vars := []*lipgloss.Color{
+		&ColorBg, &ColorBgDark, &ColorBgSubtle, &ColorBgHighlight, &ColorText, &ColorSubtext, &ColorMuted,
+		&ColorPrimary, &ColorSecondary, &ColorInfo, &ColorSuccess, &ColorWarning, &ColorDanger,
+		&ColorStatusOpen, &ColorStatusInProgress, &ColorStatusBlocked, &ColorStatusClosed,
+		&ColorStatusOpenBg, &ColorStatusInProgressBg, &ColorStatusBlockedBg, &ColorStatusClosedBg,
+		&ColorPrioCritical, &ColorPrioHigh, &ColorPrioMedium, &ColorPrioLow,
+		&ColorPrioCriticalBg, &ColorPrioHighBg, &ColorPrioMediumBg, &ColorPrioLowBg,
+		&ColorTypeBug, &ColorTypeFeature, &ColorTypeTask, &ColorTypeEpic, &ColorTypeChore,
+	}
+	saved := make([]lipgloss.Color, len(vars))
+	for i, v := range vars {
+		saved[i] = *v
+	}
+	panel, focused := PanelStyle, FocusedPanelStyle
+	t.Cleanup(func() {
+		for i, v := range vars {
+			*v = saved[i]
+		}
+		PanelStyle, FocusedPanelStyle = panel, focused
+	})
+}
+
+func writeThemeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestBuiltinThemes(t *testing.T) {
+	renderer := lipgloss.NewRenderer(nil)
+	for _, name := range builtinThemeOrder {
+		b := builtinThemes[name]
+		if err := b.palette.validate(); err != nil {
+			t.Errorf("%s: %v", name, err)
+		}
+		for _, f := range b.palette.fields() {
+			if f.color.Light == "" || f.color.Dark == "" {
+				t.Errorf("%s: %s is not set", name, f.name)
+			}
+		}
+		theme, err := (*ThemeSet)(nil).Theme(renderer, name)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if theme.Name != name {
+			t.Errorf("Theme(%q).Name = %q", name, theme.Name)
+		}
+	}
+
+	dark, _ := (*ThemeSet)(nil).Theme(renderer, "dark")
+	light, _ := (*ThemeSet)(nil).Theme(renderer, "light")
+	if !dark.IsDark() || light.IsDark() {
+		t.Error("dark/light themes should pin their background")
+	}
+	if dark.Primary.Light != dark.Primary.Dark || light.Primary.Light != "#6B47D9" {
+		t.Errorf("pinned colors: dark %v, light %v", dark.Primary, light.Primary)
+	}
+	if DefaultTheme(renderer).Primary != (lipgloss.AdaptiveColor{Light: "#6B47D9", Dark: "#BD93F9"}) {
+		t.Error("default theme should stay adaptive")
+	}
+}
+
+func TestLoadThemes_UserThenProject(t *testing.T) {
+	dir := t.TempDir()
+	userPath := filepath.Join(dir, "user", "theme.yaml")
+	projectDir := filepath.Join(dir, "project")
+
+	writeThemeFile(t, userPath, `
+theme: solarized
+colors:
+  primary: "#111111"
+themes:
+  ocean:
+    base: dark
+    colors:
+      open: {light: "#00AA00", dark: "#00D787"}
+`)
+	writeThemeFile(t, filepath.Join(projectDir, ".bv", "theme.yaml"), "theme: ocean\n")
+
+	set, err := LoadThemes(userPath, projectDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if set.Active != "ocean" || len(set.Sources) != 2 {
+		t.Fatalf("Active = %q, Sources = %v", set.Active, set.Sources)
+	}
+	theme, err := set.Theme(lipgloss.NewRenderer(nil), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if theme.Name != "ocean" || !theme.IsDark() {
+		t.Errorf("theme = %s (dark=%v)", theme.Name, theme.IsDark())
+	}
+	// Custom colors pin to the base theme's side; unset ones come from the base
+	if theme.Open != (lipgloss.AdaptiveColor{Light: "#00D787", Dark: "#00D787"}) {
+		t.Errorf("Open = %v", theme.Open)
+	}
+	if theme.Primary.Dark != "#111111" || theme.Blocked.Dark != "#FF5555" {
+		t.Errorf("Primary = %v, Blocked = %v", theme.Primary, theme.Blocked)
+	}
+
+	names := set.Names()
+	if names[len(names)-1] != "ocean" || set.NextThemeName("ocean") != "auto" || set.NextThemeName("auto") != "dark" {
+		t.Errorf("switcher order = %v", names)
+	}
+
+	// Missing files fall back to auto
+	set, err = LoadThemes(filepath.Join(dir, "missing.yaml"), t.TempDir())
+	if err != nil || set.Active != "auto" {
+		t.Errorf("missing files: %v, %q", err, set.Active)
+	}
+}
+
+func TestLoadThemes_Errors(t *testing.T) {
+	tests := map[string]string{
+		"unknown field":   "colours:\n  primary: red\n",
+		"invalid color":   "colors:\n  primary: red\n",
+		"shadows builtin": "themes:\n  dark:\n    colors: {primary: \"#000\"}\n",
+		"unknown theme":   "theme: nope\n",
+		"unknown base":    "theme: x\nthemes:\n  x:\n    base: nope\n",
+	}
+	for name, content := range tests {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "theme.yaml")
+			writeThemeFile(t, path, content)
+			set, err := LoadThemes(path, "")
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			if set == nil || set.Active != "auto" && name != "invalid color" {
+				t.Errorf("expected a usable set falling back to auto, got %+v", set)
+			}
+		})
+	}
+}
+
+func TestMixHex(t *testing.T) {
+	if got := mixHex("#000000", "#282A36", 0.25, ""); got != "#1E2029" {
+		t.Errorf("mixHex = %q", got)
+	}
+	if got := mixHex("#FFF", "#000", 0.5, ""); got != "#808080" {
+		t.Errorf("short hex mix = %q", got)
+	}
+	if got := mixHex("212", "#000000", 0.5, "fallback"); got != "fallback" {
+		t.Errorf("ANSI colors should use the fallback, got %q", got)
+	}
+}
+
+func TestApplyThemeColors(t *testing.T) {
+	snapshotThemeColors(t)
+	theme, err := (*ThemeSet)(nil).Theme(lipgloss.NewRenderer(nil), "light")
+	if err != nil {
+		t.Fatal(err)
+	}
+	applyThemeColors(theme)
+
+	if ColorBg != "#FAFAFA" || ColorStatusOpen != "#007700" || ColorPrioMedium != "#808000" || ColorTypeEpic != "#6B47D9" {
+		t.Errorf("tokens not themed: bg %s open %s prio %s epic %s", ColorBg, ColorStatusOpen, ColorPrioMedium, ColorTypeEpic)
+	}
+	if ColorStatusOpenBg == ColorStatusBlockedBg || ColorStatusOpenBg == ColorBg {
+		t.Errorf("badge backgrounds should be tinted per status, got %s", ColorStatusOpenBg)
+	}
+	if PanelStyle.GetBorderTopForeground() != lipgloss.Color("#AAAAAA") {
+		t.Errorf("panel border = %v", PanelStyle.GetBorderTopForeground())
+	}
+}
+
+func TestModel_ThemeSwitcher(t *testing.T) {
+	snapshotThemeColors(t)
+	m := NewModel([]model.Issue{{ID: "1", Title: "One", Status: model.StatusOpen}}, nil, "")
+	if err := m.SetThemes(nil, "solarized"); err != nil {
+		t.Fatal(err)
+	}
+	if m.theme.Name != "solarized" || m.board.theme.Name != "solarized" || m.graphView.theme.Name != "solarized" || m.insightsPanel.theme.Name != "solarized" {
+		t.Fatal("all views should share the selected theme")
+	}
+	if err := m.SetThemes(nil, "nope"); err == nil {
+		t.Error("expected error for unknown theme")
+	}
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyCtrlT})
+	m = updated.(Model)
+	if m.theme.Name != "high-contrast" || m.board.theme.Name != "high-contrast" {
+		t.Errorf("ctrl+t should switch to high-contrast, got %s", m.theme.Name)
+	}
+	if m.statusMsg != "Theme: high-contrast" {
+		t.Errorf("status = %q", m.statusMsg)
+	}
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyCtrlT})
+	if updated.(Model).theme.Name != "auto" {
+		t.Error("switcher should wrap around to auto")
+	}
+}
@@ -0,0 +1,91 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/timetrack"
+)
+
+// loadTimeLog reads the project's time log for the detail view. A broken
+// log just leaves tracked time out.
+func (m *Model) loadTimeLog() {
+	m.timeLog = nil
+	if m.workDir == "" {
+		return
+	}
+	if log, err := timetrack.Load(timetrack.Path(m.workDir)); err == nil {
+		m.timeLog = log
+	}
+}
+
+// renderTimeTrackedMD renders the time tracked on issueID for the detail
+// viewport. Returns "" when there is none.
+func (m Model) renderTimeTrackedMD(issueID string, now time.Time) string {
+	if m.timeLog == nil {
+		return ""
+	}
+	tracked := m.timeLog.Tracked(issueID, now)
+	active := m.timeLog.Active(analysis.CurrentActor())
+	running := active != nil && active.IssueID == issueID
+	if tracked == 0 && !running {
+		return ""
+	}
+	s := "**⏱ Time tracked:** " + timetrack.FormatDuration(tracked)
+	if running {
+		s += " (timer running; W to stop)"
+	}
+	return s + "\n\n"
+}
+
+// toggleTimeTracking starts a work session on the selected issue, or stops
+// it if that issue is the one being tracked (like `bv track start/stop`).
+func (m Model) toggleTimeTracking() Model {
+	item, ok := m.list.SelectedItem().(IssueItem)
+	if !ok {
+		return m
+	}
+	issueID := item.Issue.ID
+	if m.workDir == "" {
+		m.statusMsg = "No project directory to record time in; run: bv track start " + issueID
+		m.statusIsError = true
+		return m
+	}
+
+	path := timetrack.Path(m.workDir)
+	actor := analysis.CurrentActor()
+	now := time.Now()
+	m.loadTimeLog()
+	if m.timeLog != nil {
+		if active := m.timeLog.Active(actor); active != nil && active.IssueID == issueID {
+			stopped, err := timetrack.Stop(path, actor, now)
+			if err != nil {
+				m.statusMsg = "Failed to stop timer: " + err.Error()
+				m.statusIsError = true
+				return m
+			}
+			m.loadTimeLog()
+			m.statusMsg = fmt.Sprintf("⏱ Stopped %s after %s (total %s)", issueID,
+				timetrack.FormatDuration(stopped.Duration(now)), timetrack.FormatDuration(m.timeLog.Tracked(issueID, now)))
+			m.statusIsError = false
+			m.updateViewportContent()
+			return m
+		}
+	}
+
+	stopped, err := timetrack.Start(path, issueID, actor, now)
+	if err != nil {
+		m.statusMsg = "Failed to start timer: " + err.Error()
+		m.statusIsError = true
+		return m
+	}
+	m.loadTimeLog()
+	m.statusMsg = "⏱ Tracking " + issueID
+	if stopped != nil {
+		m.statusMsg += fmt.Sprintf(" (stopped %s after %s)", stopped.IssueID, timetrack.FormatDuration(stopped.Duration(now)))
+	}
+	m.statusIsError = false
+	m.updateViewportContent()
+	return m
+}
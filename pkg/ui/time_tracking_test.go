@@ -0,0 +1,67 @@
+package ui
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/timetrack"
+)
+
+func TestModel_ToggleTimeTracking(t *testing.T) {
+	projectDir := t.TempDir()
+	beadsPath := filepath.Join(projectDir, ".beads", "issues.jsonl")
+	issues := []model.Issue{
+		{ID: "a", Title: "First", Status: model.StatusOpen},
+		{ID: "b", Title: "Second", Status: model.StatusOpen},
+	}
+	m := NewModel(issues, nil, beadsPath)
+	selectIssue := func(id string) {
+		for i, it := range m.list.Items() {
+			if it.(IssueItem).Issue.ID == id {
+				m.list.Select(i)
+			}
+		}
+	}
+
+	selectIssue("a")
+	m = m.toggleTimeTracking()
+	if m.statusIsError || m.statusMsg != "⏱ Tracking a" {
+		t.Fatalf("status = %q", m.statusMsg)
+	}
+	if out := m.renderTimeTrackedMD("a", time.Now()); !strings.Contains(out, "timer running") {
+		t.Errorf("detail should show the running timer, got %q", out)
+	}
+
+	// Starting another issue stops the first
+	selectIssue("b")
+	m = m.toggleTimeTracking()
+	if !strings.HasPrefix(m.statusMsg, "⏱ Tracking b (stopped a after") {
+		t.Errorf("status = %q", m.statusMsg)
+	}
+	m = m.toggleTimeTracking()
+	if m.statusIsError || !strings.HasPrefix(m.statusMsg, "⏱ Stopped b after") {
+		t.Errorf("status = %q", m.statusMsg)
+	}
+
+	log, err := timetrack.Load(timetrack.Path(projectDir))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(log.Sessions) != 2 || log.Sessions[0].IssueID != "a" || log.Sessions[1].Running() {
+		t.Errorf("sessions = %+v", log.Sessions)
+	}
+}
+
+func TestModel_ToggleTimeTrackingWithoutBeadsFile(t *testing.T) {
+	m := NewModel([]model.Issue{{ID: "a", Title: "First", Status: model.StatusOpen}}, nil, "")
+	m = m.toggleTimeTracking()
+	if !m.statusIsError || !strings.Contains(m.statusMsg, "bv track start a") {
+		t.Errorf("status should suggest bv track, got %q", m.statusMsg)
+	}
+	if out := m.renderTimeTrackedMD("a", time.Now()); out != "" {
+		t.Errorf("no time log should render nothing, got %q", out)
+	}
+}
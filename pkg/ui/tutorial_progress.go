@@ -6,6 +6,8 @@ import (
 	"path/filepath"
 	"sync"
 	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/configdir"
 )
 
 // TutorialProgress tracks which tutorial pages have been viewed.
@@ -46,11 +48,7 @@ func GetTutorialProgressManager() *tutorialProgressManager {
 
 // TutorialProgressPath returns the path to the tutorial progress config file.
 func TutorialProgressPath() string {
-	home, err := os.UserHomeDir()
-	if err != nil {
-		return ""
-	}
-	return filepath.Join(home, ".config", "bv", "tutorial-progress.json")
+	return configdir.Path("tutorial-progress.json")
 }
 
 // Load reads tutorial progress from disk.
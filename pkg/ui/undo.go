@@ -0,0 +1,103 @@
+package ui
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// editJournal returns the project's undo journal, opening it on first use.
+// It returns nil when there is no single project to journal for (e.g. in
+// workspace mode).
+func (m *Model) editJournal() (*loader.Journal, error) {
+	if m.journal != nil {
+		return m.journal, nil
+	}
+	if m.workDir == "" || m.workspaceMode {
+		return nil, nil
+	}
+	j, err := loader.OpenJournal(loader.DefaultJournalPath(m.workDir), 0)
+	m.journal = j
+	return j, err
+}
+
+// recordEdit adds a successful write-back to the undo journal. A journal
+// failure never undoes the edit itself; it is only reported.
+func (m Model) recordEdit(action string, edit *loader.LineEdit) Model {
+	j, err := m.editJournal()
+	if err == nil && j != nil {
+		err = j.Record(m.beadsPath, action, edit)
+	}
+	if err != nil {
+		m.statusMsg += " (undo unavailable: " + err.Error() + ")"
+	}
+	return m
+}
+
+// undoEdit reverts the most recent write-back recorded in the journal.
+func (m Model) undoEdit() Model {
+	return m.stepJournal("undo", "↶ Undid", (*loader.Journal).Undo, loader.ErrNothingToUndo,
+		func(e loader.JournalEntry) json.RawMessage { return e.Before })
+}
+
+// redoEdit re-applies the most recently undone write-back.
+func (m Model) redoEdit() Model {
+	return m.stepJournal("redo", "↷ Redid", (*loader.Journal).Redo, loader.ErrNothingToRedo,
+		func(e loader.JournalEntry) json.RawMessage { return e.After })
+}
+
+// stepJournal runs one undo or redo step and reports it in the status bar;
+// result picks the record the issue ends up with.
+func (m Model) stepJournal(op, done string, step func(*loader.Journal) (loader.JournalEntry, error), empty error, result func(loader.JournalEntry) json.RawMessage) Model {
+	j, err := m.editJournal()
+	if err != nil {
+		m.statusMsg = "Undo journal unavailable: " + err.Error()
+		m.statusIsError = true
+		return m
+	}
+	var e loader.JournalEntry
+	if j == nil {
+		err = empty
+	} else {
+		e, err = step(j)
+	}
+	switch {
+	case errors.Is(err, empty):
+		m.statusMsg = "Nothing to " + op
+		m.statusIsError = false
+	case errors.Is(err, loader.ErrEditConflict):
+		m.statusMsg = fmt.Sprintf("Can't %s %q: %s changed since; dropped from history", op, e.Action, e.IssueID)
+		m.statusIsError = true
+	case err != nil:
+		m.statusMsg = fmt.Sprintf("Failed to %s: %v", op, err)
+		m.statusIsError = true
+	default:
+		m.applyIssueRecord(result(e))
+		m.statusMsg = fmt.Sprintf("%s: %s (%d undo · %d redo left)", done, e.Action, j.UndoDepth(), j.RedoDepth())
+		m.statusIsError = false
+	}
+	return m
+}
+
+// applyIssueRecord shows a rewritten issue record right away; the file
+// watcher reload then refreshes everything derived from it.
+func (m *Model) applyIssueRecord(raw json.RawMessage) {
+	var issue model.Issue
+	if json.Unmarshal(raw, &issue) != nil || issue.ID == "" {
+		return
+	}
+	if existing, ok := m.issueMap[issue.ID]; ok {
+		*existing = issue
+	}
+	for i, it := range m.list.Items() {
+		if item, ok := it.(IssueItem); ok && item.Issue.ID == issue.ID {
+			item.Issue = issue
+			m.list.SetItem(i, item)
+			break
+		}
+	}
+	m.updateViewportContent()
+}
@@ -0,0 +1,92 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestModel_UndoRedoComment(t *testing.T) {
+	project := t.TempDir()
+	path := filepath.Join(project, ".beads", "issues.jsonl")
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	original := `{"id":"bv-1","title":"One","status":"open","issue_type":"task"}` + "\n"
+	if err := os.WriteFile(path, []byte(original), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	issues, err := loader.LoadIssuesFromFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m := NewModel(issues, nil, path)
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 140, Height: 40})
+	m = updated.(Model)
+	press := func(key string) {
+		updated, _ := m.Update(keyMsgFromString(key))
+		m = updated.(Model)
+	}
+	comments := func() int {
+		return len(m.list.SelectedItem().(IssueItem).Issue.Comments)
+	}
+
+	press("u")
+	if m.statusMsg != "Nothing to undo" {
+		t.Fatalf("status = %q", m.statusMsg)
+	}
+
+	m = m.addComment("bv-1", "oops")
+	if comments() != 1 {
+		t.Fatal("comment not shown")
+	}
+
+	press("u")
+	if m.statusIsError || !strings.Contains(m.statusMsg, "Undid: Comment on bv-1") {
+		t.Fatalf("undo status = %q", m.statusMsg)
+	}
+	if data, _ := os.ReadFile(path); string(data) != original {
+		t.Errorf("undo should restore the record, got %s", data)
+	}
+	if comments() != 0 || len(m.issueMap["bv-1"].Comments) != 0 {
+		t.Error("undo should refresh the in-memory issue")
+	}
+
+	// The journal lives in the project's .bv directory and survives a restart
+	if _, err := os.Stat(loader.DefaultJournalPath(project)); err != nil {
+		t.Fatalf("journal not written: %v", err)
+	}
+	m = NewModel(issues, nil, path)
+	press("ctrl+r")
+	if !strings.Contains(m.statusMsg, "Redid: Comment on bv-1") {
+		t.Fatalf("redo status = %q", m.statusMsg)
+	}
+	if reloaded, _ := loader.LoadIssuesFromFile(path); len(reloaded[0].Comments) != 1 {
+		t.Error("redo should re-apply the comment")
+	}
+	if comments() != 1 {
+		t.Error("redo should refresh the in-memory issue")
+	}
+}
+
+func TestModel_UndoKeyGoesToPickers(t *testing.T) {
+	m := NewModel([]model.Issue{{ID: "1", Title: "One", Status: model.StatusOpen, Labels: []string{"ux"}}}, nil, "")
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 140, Height: 40})
+	m = updated.(Model)
+	updated, _ = m.Update(keyMsgFromString("l"))
+	m = updated.(Model)
+	if m.focused != focusLabelPicker {
+		t.Fatalf("expected label picker, focus %v", m.focused)
+	}
+	updated, _ = m.Update(keyMsgFromString("u"))
+	m = updated.(Model)
+	if strings.Contains(m.statusMsg, "undo") {
+		t.Errorf("u in the label picker should not trigger undo: %q", m.statusMsg)
+	}
+}
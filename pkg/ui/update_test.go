@@ -3,9 +3,11 @@ package ui
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/correlation"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
 	"github.com/charmbracelet/bubbles/list"
 )
@@ -32,6 +34,41 @@ func TestModelUpdatePhase2AndFileChanged(t *testing.T) {
 	}
 }
 
+func TestDuplicatesReadyMsgShowsBadge(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "A", Title: "Login page crashes on submit", Status: model.StatusOpen},
+		{ID: "B", Title: "Login page crashes on submit", Status: model.StatusOpen},
+		{ID: "C", Title: "Export reports to CSV", Status: model.StatusOpen},
+	}
+	m := NewModel(issues, nil, "")
+	m.width, m.height = 120, 40
+	m.isSplitView = true
+
+	msg := FindDuplicatesCmd(issues, t.TempDir(), m.analysis)().(DuplicatesReadyMsg)
+	if got := msg.ByIssue["A"]; len(got) != 1 || got[0] != "B" {
+		t.Fatalf("expected A to duplicate B, got %v", msg.ByIssue)
+	}
+	if len(msg.ByIssue["C"]) != 0 {
+		t.Errorf("C should have no duplicates, got %v", msg.ByIssue["C"])
+	}
+
+	// Stale scans (from before a reload) are ignored
+	stale := msg
+	stale.Stats = nil
+	updated, _ := m.Update(stale)
+	if updated.(Model).duplicates != nil {
+		t.Fatal("stale duplicates message should be ignored")
+	}
+
+	updated, _ = m.Update(msg)
+	m2 := updated.(Model)
+	m2.list.Select(0)
+	m2.updateViewportContent()
+	if view := m2.viewport.View(); !strings.Contains(view, "1 possible duplicate") {
+		t.Errorf("detail view missing duplicate badge:\n%s", view)
+	}
+}
+
 type badItem struct{}
 
 func (badItem) Title() string       { return "bad" }
@@ -76,6 +113,8 @@ func TestInsightsCurrentPanelItemCount(t *testing.T) {
 	}
 	m := NewInsightsModel(ins, map[string]*model.Issue{}, DefaultTheme(nil))
 	m.SetTopPicks([]analysis.TopPick{{ID: "P1", Score: 1.0}})
+	m.SetQueues(analysis.QueueAnalysis{Queues: []analysis.QueueStats{{Name: "Q"}}})
+	m.SetOwnership(&correlation.OwnershipReport{Areas: []correlation.OwnershipArea{{Name: "B"}}})
 	counts := []int{m.currentPanelItemCount()}
 	for i := 0; i < int(PanelCount)-1; i++ {
 		m.NextPanel()
@@ -0,0 +1,122 @@
+package ui
+
+import (
+	"slices"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/usage"
+)
+
+// usageTracker tallies, for the local usage log, the views visited and the
+// time spent in each, frame render times and the key actions used. It
+// keeps counts in memory and writes one event per name when bv exits.
+type usageTracker struct {
+	path    string
+	view    Context
+	since   time.Time
+	views   map[Context]*usage.Event
+	renders map[Context]*usage.Event
+	actions map[string]int
+}
+
+// SetUsageLog turns on usage tracking, written to the usage log at path by
+// Stop.
+func (m *Model) SetUsageLog(path string) {
+	m.usage = &usageTracker{
+		path:    path,
+		views:   map[Context]*usage.Event{},
+		renders: map[Context]*usage.Event{},
+		actions: map[string]int{},
+	}
+}
+
+// rendered counts a frame of view that started at start, and the switch to
+// view if it differs from the last frame's.
+func (u *usageTracker) rendered(view Context, start time.Time) {
+	now := time.Now()
+	if view != u.view {
+		u.leave(start)
+		u.view, u.since = view, start
+		tally(u.views, view, usage.KindView).Count++
+	}
+	r := tally(u.renders, view, usage.KindRender)
+	ms := usage.Millis(now.Sub(start))
+	r.Count++
+	r.MS += ms
+	r.MaxMS = max(r.MaxMS, ms)
+}
+
+// leave adds the time spent in the current view up to now
+func (u *usageTracker) leave(now time.Time) {
+	if u.view != "" {
+		u.views[u.view].MS += usage.Millis(now.Sub(u.since))
+	}
+}
+
+// pressed counts the key action key stands for in scope, if any
+func (u *usageTracker) pressed(scope KeyScope, key string) {
+	if id, ok := defaultActionForKey(scope, key); ok {
+		u.actions[id]++
+	}
+}
+
+// events flushes the tallies as usage events stamped now
+func (u *usageTracker) events(now time.Time) []usage.Event {
+	u.leave(now)
+	u.view = ""
+	var events []usage.Event
+	for _, tallies := range []map[Context]*usage.Event{u.views, u.renders} {
+		for _, e := range tallies {
+			e.At = now
+			events = append(events, *e)
+		}
+	}
+	for id, n := range u.actions {
+		events = append(events, usage.Event{At: now, Kind: usage.KindAction, Name: id, Count: n})
+	}
+	clear(u.views)
+	clear(u.renders)
+	clear(u.actions)
+	return events
+}
+
+func tally(m map[Context]*usage.Event, view Context, kind string) *usage.Event {
+	e := m[view]
+	if e == nil {
+		e = &usage.Event{Kind: kind, Name: string(view)}
+		m[view] = e
+	}
+	return e
+}
+
+// defaultActionForKey finds the action a handler key (a remapped key
+// already translated to its default) triggers in scope, scope-specific
+// actions first.
+func defaultActionForKey(scope KeyScope, key string) (string, bool) {
+	for _, s := range []KeyScope{scope, ScopeGlobal} {
+		for _, a := range defaultKeyActions {
+			if a.Scope == s && slices.Contains(a.Keys, key) {
+				return a.ID, true
+			}
+		}
+	}
+	return "", false
+}
+
+// UsageViews lists the views the usage log tracks by name, for finding the
+// ones never opened.
+func UsageViews() []string {
+	views := []Context{
+		ContextList, ContextDetail, ContextSplit, ContextBoard, ContextGraph, ContextInsights,
+		ContextFlowMatrix, ContextActionable, ContextHistory, ContextSprint, ContextLabelDashboard,
+		ContextAttention, ContextTimeTravel, ContextFilter, ContextHelp, ContextCommandPalette,
+		ContextRecipePicker, ContextLabelPicker, ContextMyWork, ContextAlerts, ContextChangeLog,
+		ContextRepoPicker, ContextLayoutPicker, ContextDependencyTree, ContextGoals,
+		ContextSandbox,
+	}
+	names := make([]string, len(views))
+	for i, v := range views {
+		names[i] = string(v)
+	}
+	return names
+}
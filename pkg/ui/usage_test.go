@@ -0,0 +1,69 @@
+package ui
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/usage"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestDefaultActionForKey(t *testing.T) {
+	tests := []struct {
+		scope KeyScope
+		key   string
+		want  string
+	}{
+		{ScopeList, "j", "nav.down"},
+		{ScopeList, "b", "view.board"},
+		{ScopeGlobal, "b", "view.board"},
+		{ScopeList, "ctrl+x", ""},
+	}
+	for _, tt := range tests {
+		if got, _ := defaultActionForKey(tt.scope, tt.key); got != tt.want {
+			t.Errorf("defaultActionForKey(%s, %q) = %q, want %q", tt.scope, tt.key, got, tt.want)
+		}
+	}
+}
+
+func TestModel_UsageLog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), usage.Filename)
+	if err := usage.Enable(path); err != nil {
+		t.Fatal(err)
+	}
+	issues := []model.Issue{{ID: "bv-1", Title: "One", Status: model.StatusOpen, IssueType: model.TypeTask}}
+	m := NewModel(issues, nil, "")
+	m.SetUsageLog(path)
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 100, Height: 20})
+	m = updated.(Model)
+	m.View()
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'b'}})
+	m = updated.(Model)
+	m.View()
+	m.View()
+	m.Stop()
+
+	events, err := usage.Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sum := usage.Summarize(events)
+	counts := map[string]int{}
+	for kind, stats := range sum.Kinds {
+		for _, s := range stats {
+			counts[kind+"/"+s.Name] = s.Count
+		}
+	}
+	if counts["view/list"] != 1 || counts["view/board"] != 1 {
+		t.Errorf("views = %v", counts)
+	}
+	if counts["render/board"] != 2 || counts["action/view.board"] != 1 {
+		t.Errorf("renders and actions = %v", counts)
+	}
+	for _, e := range events {
+		if e.Name == "bv-1" || e.Name == "One" {
+			t.Errorf("issue data in the usage log: %+v", e)
+		}
+	}
+}
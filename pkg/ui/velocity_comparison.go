@@ -8,8 +8,6 @@ import (
 
 	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
-
-	"github.com/charmbracelet/lipgloss"
 )
 
 // VelocityComparisonModel shows side-by-side velocity comparison for all labels
@@ -255,7 +253,7 @@ func (m *VelocityComparisonModel) View() string {
 				rowStyle = rowStyle.
 					Foreground(t.Primary).
 					Bold(true).
-					Background(lipgloss.Color("#333"))
+					Background(t.Highlight)
 			}
 
 			// Truncate label if needed
@@ -268,13 +266,13 @@ func (m *VelocityComparisonModel) View() string {
 			trendStyle := t.Renderer.NewStyle()
 			switch row.Trend {
 			case "accelerating":
-				trendStyle = trendStyle.Foreground(lipgloss.Color("#00ff00"))
+				trendStyle = trendStyle.Foreground(t.Open)
 			case "decelerating":
-				trendStyle = trendStyle.Foreground(lipgloss.Color("#ff6666"))
+				trendStyle = trendStyle.Foreground(t.Blocked)
 			case "stable":
 				trendStyle = trendStyle.Foreground(t.Secondary)
 			case "erratic":
-				trendStyle = trendStyle.Foreground(lipgloss.Color("#ffaa00"))
+				trendStyle = trendStyle.Foreground(t.Feature)
 			default:
 				trendStyle = trendStyle.Foreground(t.Secondary)
 			}
@@ -285,7 +283,7 @@ func (m *VelocityComparisonModel) View() string {
 			}
 
 			// Format sparkline with color gradient
-			sparkStyle := t.Renderer.NewStyle().Foreground(lipgloss.Color("#88aaff"))
+			sparkStyle := t.Renderer.NewStyle().Foreground(t.InProgress)
 
 			// Build row string
 			rowText := fmt.Sprintf("%-*s %*d %*d %*d %*d %*.1f ",
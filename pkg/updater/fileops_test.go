@@ -102,3 +102,62 @@ func TestRollback_NoBackup(t *testing.T) {
 		t.Fatalf("unexpected error: %v", err)
 	}
 }
+
+func TestInstallBinary(t *testing.T) {
+	for _, goos := range []string{"linux", "windows"} {
+		t.Run(goos, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			newPath := filepath.Join(tmpDir, "download", "bv-new")
+			binaryPath := filepath.Join(tmpDir, "bin", "bv")
+			if err := os.MkdirAll(filepath.Dir(newPath), 0o755); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.MkdirAll(filepath.Dir(binaryPath), 0o755); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(newPath, []byte("new"), 0o755); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(binaryPath, []byte("old"), 0o755); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := installBinary(newPath, binaryPath, goos); err != nil {
+				t.Fatalf("installBinary: %v", err)
+			}
+			if got, _ := os.ReadFile(binaryPath); string(got) != "new" {
+				t.Errorf("binary = %q, want new", got)
+			}
+			if _, err := os.Stat(binaryPath + ".new"); !os.IsNotExist(err) {
+				t.Errorf("staged binary left behind: %v", err)
+			}
+
+			// Only Windows moves the old binary aside
+			got, err := os.ReadFile(getOldBinaryPath(binaryPath))
+			if goos == "windows" && string(got) != "old" {
+				t.Errorf("old binary = %q, %v; want old", got, err)
+			}
+			if goos != "windows" && !os.IsNotExist(err) {
+				t.Errorf("old binary kept on %s: %v", goos, err)
+			}
+		})
+	}
+}
+
+func TestInstallBinary_MissingSourceKeepsBinary(t *testing.T) {
+	tmpDir := t.TempDir()
+	binaryPath := filepath.Join(tmpDir, "bv")
+	if err := os.WriteFile(binaryPath, []byte("old"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := installBinary(filepath.Join(tmpDir, "missing"), binaryPath, "windows"); err == nil {
+		t.Fatal("expected an error for a missing new binary")
+	}
+	if got, _ := os.ReadFile(binaryPath); string(got) != "old" {
+		t.Errorf("binary = %q, want old", got)
+	}
+	if _, err := os.Stat(binaryPath + ".new"); !os.IsNotExist(err) {
+		t.Errorf("staged binary left behind: %v", err)
+	}
+}
@@ -269,3 +269,21 @@ func TestRelease_FindPlatformAsset_NoAssets(t *testing.T) {
 		t.Errorf("expected nil for empty assets, got %+v", asset)
 	}
 }
+
+func TestPerformUpdate_RequiresChecksums(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("archive"))
+	}))
+	defer server.Close()
+
+	assetName := getAssetName("v99.0.0")
+	release := &Release{
+		TagName: "v99.0.0",
+		Assets:  []Asset{{Name: assetName, BrowserDownloadURL: server.URL + "/" + assetName}},
+	}
+
+	_, err := PerformUpdate(release, true)
+	if err == nil || !strings.Contains(err.Error(), "no checksums.txt") {
+		t.Fatalf("expected a missing-checksums error, got %v", err)
+	}
+}
@@ -89,3 +89,39 @@ func TestCheckForUpdates_Network(t *testing.T) {
 		})
 	}
 }
+
+func TestGetRelease_Channels(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/releases/latest":
+			w.Write([]byte(`{"tag_name": "v1.4.0"}`))
+		case "/releases":
+			w.Write([]byte(`[
+				{"tag_name": "v1.6.0-rc.1", "draft": true},
+				{"tag_name": "v1.5.0-beta.2", "prerelease": true},
+				{"tag_name": "v1.5.0-beta.10", "prerelease": true},
+				{"tag_name": "v1.4.0"}
+			]`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	stable, err := getRelease(server.Client(), server.URL, ChannelStable)
+	if err != nil || stable.TagName != "v1.4.0" {
+		t.Fatalf("stable = %+v, %v", stable, err)
+	}
+
+	beta, err := getRelease(server.Client(), server.URL, ChannelBeta)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if beta.TagName != "v1.5.0-beta.10" || !beta.Prerelease {
+		t.Errorf("beta = %+v, want v1.5.0-beta.10 (drafts skipped)", beta)
+	}
+
+	if _, err := getRelease(server.Client(), server.URL, "nightly"); err == nil {
+		t.Error("expected an error for an unknown channel")
+	}
+}
@@ -0,0 +1,59 @@
+package updater
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	osExec "os/exec"
+	"strings"
+)
+
+// signatureVerifier checks a detached signature over the release's
+// checksums file with minisign or cosign. Signatures are checked only when
+// a public key is configured; the checksums then vouch for the archive.
+type signatureVerifier struct {
+	tool   string // command that checks the signature
+	env    string // variable holding the public key
+	key    string // public key, or a path to one
+	suffix string // signature asset name, after checksums.txt
+}
+
+// signatureVerifiers returns a verifier for each configured key:
+// BV_UPDATE_MINISIGN_KEY (a key or key file) expects checksums.txt.minisig,
+// BV_UPDATE_COSIGN_KEY (a key file, URL or KMS URI) checksums.txt.sig.
+func signatureVerifiers(getenv func(string) string) []signatureVerifier {
+	var verifiers []signatureVerifier
+	if key := strings.TrimSpace(getenv("BV_UPDATE_MINISIGN_KEY")); key != "" {
+		verifiers = append(verifiers, signatureVerifier{tool: "minisign", env: "BV_UPDATE_MINISIGN_KEY", key: key, suffix: ".minisig"})
+	}
+	if key := strings.TrimSpace(getenv("BV_UPDATE_COSIGN_KEY")); key != "" {
+		verifiers = append(verifiers, signatureVerifier{tool: "cosign", env: "BV_UPDATE_COSIGN_KEY", key: key, suffix: ".sig"})
+	}
+	return verifiers
+}
+
+// args are the tool's arguments for checking sigPath against filePath
+func (v signatureVerifier) args(filePath, sigPath string) []string {
+	switch v.tool {
+	case "minisign":
+		keyFlag := "-P"
+		if _, err := os.Stat(v.key); err == nil {
+			keyFlag = "-p"
+		}
+		return []string{"-V", "-q", "-m", filePath, "-x", sigPath, keyFlag, v.key}
+	case "cosign":
+		return []string{"verify-blob", "--key", v.key, "--signature", sigPath, filePath}
+	}
+	return nil
+}
+
+// verify checks sigPath against filePath, running the tool with run
+func (v signatureVerifier) verify(filePath, sigPath string, run func(string, ...string) error) error {
+	if err := run(v.tool, v.args(filePath, sigPath)...); err != nil {
+		if errors.Is(err, osExec.ErrNotFound) {
+			return fmt.Errorf("%s is set but %s is not installed", v.env, v.tool)
+		}
+		return fmt.Errorf("%s signature verification failed: %w", v.tool, err)
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package updater
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	osExec "os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSignatureVerifiers(t *testing.T) {
+	if got := signatureVerifiers(func(string) string { return "" }); len(got) != 0 {
+		t.Fatalf("no keys: %+v", got)
+	}
+
+	env := map[string]string{
+		"BV_UPDATE_MINISIGN_KEY": " RWQf6LRCGA9i53mlYecO4IzT51TGPpvWucNSCh1CBM0QTaLn73Y7GFO3 ",
+		"BV_UPDATE_COSIGN_KEY":   "cosign.pub",
+	}
+	got := signatureVerifiers(func(k string) string { return env[k] })
+	if len(got) != 2 || got[0].tool != "minisign" || got[1].tool != "cosign" {
+		t.Fatalf("verifiers = %+v", got)
+	}
+
+	minisign := got[0].args("checksums.txt", "checksums.txt.minisig")
+	want := []string{"-V", "-q", "-m", "checksums.txt", "-x", "checksums.txt.minisig", "-P", "RWQf6LRCGA9i53mlYecO4IzT51TGPpvWucNSCh1CBM0QTaLn73Y7GFO3"}
+	if !reflect.DeepEqual(minisign, want) {
+		t.Errorf("minisign args = %q", minisign)
+	}
+	cosign := got[1].args("checksums.txt", "checksums.txt.sig")
+	want = []string{"verify-blob", "--key", "cosign.pub", "--signature", "checksums.txt.sig", "checksums.txt"}
+	if !reflect.DeepEqual(cosign, want) {
+		t.Errorf("cosign args = %q", cosign)
+	}
+}
+
+func TestSignatureVerifier_MinisignKeyFile(t *testing.T) {
+	keyFile := filepath.Join(t.TempDir(), "bv.pub")
+	if err := os.WriteFile(keyFile, []byte("untrusted comment: key\nRWQ...\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	v := signatureVerifiers(func(k string) string {
+		if k == "BV_UPDATE_MINISIGN_KEY" {
+			return keyFile
+		}
+		return ""
+	})[0]
+	if args := v.args("f", "s"); args[len(args)-2] != "-p" {
+		t.Errorf("key file should use -p: %q", args)
+	}
+}
+
+func TestSignatureVerifier_Verify(t *testing.T) {
+	v := signatureVerifier{tool: "minisign", env: "BV_UPDATE_MINISIGN_KEY", key: "RWQ", suffix: ".minisig"}
+
+	var ran []string
+	ok := func(name string, args ...string) error {
+		ran = append([]string{name}, args...)
+		return nil
+	}
+	if err := v.verify("checksums.txt", "checksums.txt.minisig", ok); err != nil {
+		t.Fatalf("verify: %v", err)
+	}
+	if ran[0] != "minisign" {
+		t.Errorf("ran %q", ran)
+	}
+
+	bad := func(string, ...string) error { return fmt.Errorf("exit status 1") }
+	if err := v.verify("checksums.txt", "checksums.txt.minisig", bad); err == nil || !strings.Contains(err.Error(), "signature verification failed") {
+		t.Errorf("bad signature: %v", err)
+	}
+
+	missing := func(name string, _ ...string) error {
+		return &osExec.Error{Name: name, Err: osExec.ErrNotFound}
+	}
+	err := v.verify("checksums.txt", "checksums.txt.minisig", missing)
+	if err == nil || !strings.Contains(err.Error(), "minisign is not installed") {
+		t.Errorf("missing tool: %v", err)
+	}
+	if errors.Is(err, osExec.ErrNotFound) {
+		t.Error("missing tool error should explain rather than wrap")
+	}
+}
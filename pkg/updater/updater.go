@@ -17,6 +17,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/Dicklesworthstone/beads_viewer/pkg/offline"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/version"
 )
 
@@ -26,11 +27,27 @@ const (
 	baseURL   = "https://api.github.com/repos/" + repoOwner + "/" + repoName
 )
 
+// Release channels for --channel
+const (
+	ChannelStable = "stable" // the latest release
+	ChannelBeta   = "beta"   // the newest release, pre-releases included
+)
+
+// Channels lists the release channels
+var Channels = []string{ChannelStable, ChannelBeta}
+
 // Release represents a GitHub release
 type Release struct {
-	TagName string  `json:"tag_name"`
-	HTMLURL string  `json:"html_url"`
-	Assets  []Asset `json:"assets"`
+	TagName    string  `json:"tag_name"`
+	HTMLURL    string  `json:"html_url"`
+	Prerelease bool    `json:"prerelease"`
+	Draft      bool    `json:"draft"`
+	Assets     []Asset `json:"assets"`
+}
+
+// IsNewer reports whether the release is newer than the running version
+func (r *Release) IsNewer() bool {
+	return compareVersions(r.TagName, version.Version) > 0
 }
 
 // Asset represents a release asset (binary, checksum file, etc.)
@@ -53,6 +70,9 @@ type UpdateResult struct {
 // CheckForUpdates queries GitHub for the latest release.
 // Returns the new version tag if an update is available, empty string otherwise.
 func CheckForUpdates() (string, string, error) {
+	if offline.Enabled() {
+		return "", "", nil
+	}
 	// Set a short timeout to avoid blocking startup for too long
 	client := &http.Client{
 		Timeout: 2 * time.Second,
@@ -311,29 +331,72 @@ func compareVersions(v1, v2 string) int {
 
 // GetLatestRelease fetches full release info including assets
 func GetLatestRelease() (*Release, error) {
+	return GetRelease(ChannelStable)
+}
+
+// GetRelease fetches the newest release on a channel: the latest stable
+// release, or for ChannelBeta the newest release including pre-releases.
+func GetRelease(channel string) (*Release, error) {
+	if err := offline.Check("checking for bv updates"); err != nil {
+		return nil, err
+	}
 	client := &http.Client{Timeout: 30 * time.Second}
-	req, err := http.NewRequest(http.MethodGet, baseURL+"/releases/latest", nil)
+	return getRelease(client, baseURL, channel)
+}
+
+func getRelease(client *http.Client, apiURL, channel string) (*Release, error) {
+	switch channel {
+	case ChannelStable, "":
+		var rel Release
+		if err := getJSON(client, apiURL+"/releases/latest", &rel); err != nil {
+			return nil, err
+		}
+		return &rel, nil
+
+	case ChannelBeta:
+		var releases []Release
+		if err := getJSON(client, apiURL+"/releases?per_page=30", &releases); err != nil {
+			return nil, err
+		}
+		var newest *Release
+		for i := range releases {
+			if releases[i].Draft {
+				continue
+			}
+			if newest == nil || compareVersions(releases[i].TagName, newest.TagName) > 0 {
+				newest = &releases[i]
+			}
+		}
+		if newest == nil {
+			return nil, fmt.Errorf("no releases found")
+		}
+		return newest, nil
+	}
+	return nil, fmt.Errorf("unknown channel %q (want %s)", channel, strings.Join(Channels, " or "))
+}
+
+// getJSON fetches a GitHub API URL and decodes the response into v
+func getJSON(client *http.Client, url string, v any) error {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	req.Header.Set("User-Agent", "beads-viewer-updater")
 
 	resp, err := client.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch release info: %w", err)
+		return fmt.Errorf("failed to fetch release info: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("github api returned status: %s", resp.Status)
+		return fmt.Errorf("github api returned status: %s", resp.Status)
 	}
 
-	var rel Release
-	if err := json.NewDecoder(resp.Body).Decode(&rel); err != nil {
-		return nil, fmt.Errorf("failed to parse release info: %w", err)
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("failed to parse release info: %w", err)
 	}
-
-	return &rel, nil
+	return nil
 }
 
 // getAssetName returns the expected asset name for the current platform
@@ -357,8 +420,13 @@ func (r *Release) FindPlatformAsset() *Asset {
 
 // FindChecksumAsset finds the checksums file
 func (r *Release) FindChecksumAsset() *Asset {
+	return r.findAsset("checksums.txt")
+}
+
+// findAsset finds an asset by name
+func (r *Release) findAsset(name string) *Asset {
 	for i := range r.Assets {
-		if r.Assets[i].Name == "checksums.txt" {
+		if r.Assets[i].Name == name {
 			return &r.Assets[i]
 		}
 	}
@@ -521,9 +589,19 @@ func GetBackupPath(binaryPath string) string {
 	return binaryPath + ".backup"
 }
 
+// getOldBinaryPath returns where a replaced binary that was still running
+// is moved on Windows
+func getOldBinaryPath(binaryPath string) string {
+	return binaryPath + ".old"
+}
+
 // PerformUpdate downloads and installs a new version of bv
 // Returns an UpdateResult with details about the operation
 func PerformUpdate(release *Release, skipConfirm bool) (*UpdateResult, error) {
+	if err := offline.Check("updating bv"); err != nil {
+		return nil, err
+	}
+
 	result := &UpdateResult{
 		OldVersion: version.Version,
 		NewVersion: release.TagName,
@@ -553,12 +631,19 @@ func PerformUpdate(release *Release, skipConfirm bool) (*UpdateResult, error) {
 	testFile := filepath.Join(binaryDir, ".bv-update-test")
 	if f, err := os.Create(testFile); err != nil {
 		result.RequireRoot = true
-		return nil, fmt.Errorf("no write permission to %s (try running with sudo)", binaryDir)
+		hint := "try running with sudo"
+		if runtime.GOOS == "windows" {
+			hint = "try an Administrator prompt"
+		}
+		return nil, fmt.Errorf("no write permission to %s (%s)", binaryDir, hint)
 	} else {
 		f.Close()
 		os.Remove(testFile)
 	}
 
+	// The binary an earlier update replaced on Windows is no longer running
+	os.Remove(getOldBinaryPath(binaryPath))
+
 	// Create temp directory for download
 	tmpDir, err := os.MkdirTemp("", "bv-update-*")
 	if err != nil {
@@ -573,30 +658,47 @@ func PerformUpdate(release *Release, skipConfirm bool) (*UpdateResult, error) {
 		return nil, fmt.Errorf("download failed: %w", err)
 	}
 
-	// Download and verify checksum
+	// Verify the archive against the published checksums, whose signature
+	// is checked first when a signing key is configured
 	checksumAsset := release.FindChecksumAsset()
-	if checksumAsset != nil {
-		checksumPath := filepath.Join(tmpDir, "checksums.txt")
-		if err := downloadFile(checksumAsset.BrowserDownloadURL, checksumPath, checksumAsset.Size); err != nil {
-			return nil, fmt.Errorf("checksum download failed: %w", err)
-		}
+	if checksumAsset == nil {
+		return nil, fmt.Errorf("release %s publishes no checksums.txt; refusing to install an unverified binary", release.TagName)
+	}
+	checksumPath := filepath.Join(tmpDir, checksumAsset.Name)
+	if err := downloadFile(checksumAsset.BrowserDownloadURL, checksumPath, checksumAsset.Size); err != nil {
+		return nil, fmt.Errorf("checksum download failed: %w", err)
+	}
 
-		checksums, err := parseChecksums(checksumPath)
-		if err != nil {
-			return nil, fmt.Errorf("failed to parse checksums: %w", err)
+	for _, v := range signatureVerifiers(os.Getenv) {
+		sigAsset := release.findAsset(checksumAsset.Name + v.suffix)
+		if sigAsset == nil {
+			return nil, fmt.Errorf("%s is set but release %s has no %s%s", v.env, release.TagName, checksumAsset.Name, v.suffix)
 		}
-
-		expectedHash, ok := checksums[asset.Name]
-		if !ok {
-			return nil, fmt.Errorf("no checksum found for %s", asset.Name)
+		sigPath := filepath.Join(tmpDir, sigAsset.Name)
+		if err := downloadFile(sigAsset.BrowserDownloadURL, sigPath, sigAsset.Size); err != nil {
+			return nil, fmt.Errorf("signature download failed: %w", err)
 		}
-
-		fmt.Println("Verifying checksum...")
-		if err := verifyChecksum(archivePath, expectedHash); err != nil {
-			return nil, fmt.Errorf("checksum verification failed: %w", err)
+		fmt.Printf("Verifying %s signature...\n", v.tool)
+		if err := v.verify(checksumPath, sigPath, runCommand); err != nil {
+			return nil, err
 		}
 	}
 
+	checksums, err := parseChecksums(checksumPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse checksums: %w", err)
+	}
+
+	expectedHash, ok := checksums[asset.Name]
+	if !ok {
+		return nil, fmt.Errorf("no checksum found for %s", asset.Name)
+	}
+
+	fmt.Println("Verifying checksum...")
+	if err := verifyChecksum(archivePath, expectedHash); err != nil {
+		return nil, fmt.Errorf("checksum verification failed: %w", err)
+	}
+
 	// Extract binary to temp location
 	newBinaryPath := filepath.Join(tmpDir, "bv-new")
 	if runtime.GOOS == "windows" {
@@ -623,15 +725,9 @@ func PerformUpdate(release *Release, skipConfirm bool) (*UpdateResult, error) {
 
 	// Replace binary
 	fmt.Println("Installing new version...")
-	if err := os.Rename(newBinaryPath, binaryPath); err != nil {
-		// On some systems, rename across filesystems doesn't work
-		if err := copyFile(newBinaryPath, binaryPath); err != nil {
-			// Restore from backup
-			if restoreErr := copyFile(backupPath, binaryPath); restoreErr != nil {
-				return nil, fmt.Errorf("installation failed: %w (restore also failed: %v; manual recovery: cp %s %s)", err, restoreErr, backupPath, binaryPath)
-			}
-			return nil, fmt.Errorf("installation failed (restored from backup): %w", err)
-		}
+	if err := installBinary(newBinaryPath, binaryPath, runtime.GOOS); err != nil {
+		// installBinary leaves the old binary in place when it fails
+		return nil, fmt.Errorf("installation failed: %w", err)
 	}
 
 	// Ensure executable permissions
@@ -668,6 +764,41 @@ func copyFile(src, dst string) error {
 	return err
 }
 
+// installBinary replaces binaryPath with newPath. The new binary is staged
+// next to the old one first, so the swap is a rename within one directory
+// and a failure leaves the old binary in place. Windows refuses to
+// overwrite a running executable but lets it be renamed, so there the old
+// binary is moved aside to getOldBinaryPath, which the next update or
+// rollback removes.
+func installBinary(newPath, binaryPath, goos string) error {
+	staged := binaryPath + ".new"
+	if err := copyFile(newPath, staged); err != nil {
+		os.Remove(staged)
+		return fmt.Errorf("staging new binary: %w", err)
+	}
+
+	if goos == "windows" {
+		oldPath := getOldBinaryPath(binaryPath)
+		os.Remove(oldPath)
+		if err := os.Rename(binaryPath, oldPath); err != nil && !os.IsNotExist(err) {
+			os.Remove(staged)
+			return fmt.Errorf("moving running binary aside: %w", err)
+		}
+		if err := os.Rename(staged, binaryPath); err != nil {
+			os.Rename(oldPath, binaryPath)
+			os.Remove(staged)
+			return err
+		}
+		return nil
+	}
+
+	if err := os.Rename(staged, binaryPath); err != nil {
+		os.Remove(staged)
+		return err
+	}
+	return nil
+}
+
 // runCommand executes a command and returns any error
 func runCommand(name string, args ...string) error {
 	cmd := osExec.Command(name, args...)
@@ -686,8 +817,10 @@ func Rollback() error {
 		return fmt.Errorf("no backup found at %s", backupPath)
 	}
 
+	os.Remove(getOldBinaryPath(binaryPath))
+
 	fmt.Printf("Rolling back from backup at %s...\n", backupPath)
-	if err := copyFile(backupPath, binaryPath); err != nil {
+	if err := installBinary(backupPath, binaryPath, runtime.GOOS); err != nil {
 		return fmt.Errorf("rollback failed: %w", err)
 	}
 
@@ -0,0 +1,41 @@
+package usage
+
+import (
+	"context"
+	"time"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// PhaseExporter is a span exporter that records each traced phase's name
+// and duration in the usage log, so bv stats can time loading and analysis
+// without any collector. Span attributes are dropped.
+type PhaseExporter struct {
+	Path string
+}
+
+// ExportSpans implements sdktrace.SpanExporter. Write errors are dropped:
+// the usage log never gets in the way of a run.
+func (p PhaseExporter) ExportSpans(_ context.Context, spans []sdktrace.ReadOnlySpan) error {
+	events := make([]Event, 0, len(spans))
+	for _, s := range spans {
+		events = append(events, Event{
+			At:   s.StartTime(),
+			Kind: KindPhase,
+			Name: s.Name(),
+			MS:   Millis(s.EndTime().Sub(s.StartTime())),
+		})
+	}
+	_ = Record(p.Path, events...)
+	return nil
+}
+
+// Shutdown implements sdktrace.SpanExporter
+func (PhaseExporter) Shutdown(context.Context) error {
+	return nil
+}
+
+// Millis converts d to fractional milliseconds
+func Millis(d time.Duration) float64 {
+	return float64(d.Microseconds()) / 1000
+}
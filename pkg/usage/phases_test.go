@@ -0,0 +1,28 @@
+package usage
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+func TestPhaseExporter(t *testing.T) {
+	path := filepath.Join(t.TempDir(), Filename)
+	if err := Enable(path); err != nil {
+		t.Fatal(err)
+	}
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(PhaseExporter{Path: path}))
+	_, span := provider.Tracer("test").Start(context.Background(), "loader.load_issues")
+	span.End()
+	_ = provider.Shutdown(context.Background())
+
+	events, err := Load(path)
+	if err != nil || len(events) != 1 {
+		t.Fatalf("events = %+v, %v", events, err)
+	}
+	if e := events[0]; e.Kind != KindPhase || e.Name != "loader.load_issues" || e.MS < 0 {
+		t.Errorf("event = %+v", e)
+	}
+}
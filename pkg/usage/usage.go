@@ -0,0 +1,249 @@
+// Package usage keeps an opt-in, local-only log of how bv is used: which
+// commands, flags, views and key actions run, and how long the expensive
+// phases and TUI frames take. `bv stats` summarizes it to surface unused
+// features and slow spots.
+//
+// Nothing here talks to the network. The log is usage.jsonl in bv's config
+// directory, it is only ever read by bv stats, and it records names and
+// durations only: never paths, issue IDs, titles or flag values. Logging
+// is on while the file exists; `bv stats enable` creates it and
+// `bv stats disable` deletes it.
+package usage
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/configdir"
+)
+
+// Filename is the usage log filename inside bv's config directory
+const Filename = "usage.jsonl"
+
+// MaxBytes caps the log; past it the oldest half is dropped
+const MaxBytes = 1 << 20
+
+// Event kinds
+const (
+	KindCommand = "command" // A subcommand, or "bv" for a flags-only run
+	KindFlag    = "flag"    // A flag that was set, by name
+	KindView    = "view"    // A TUI view: visits and time spent in it
+	KindAction  = "action"  // A TUI key action, by keymap ID
+	KindRender  = "render"  // TUI frames rendered in a view and their time
+	KindPhase   = "phase"   // A traced phase such as loading or analysis
+)
+
+// Event is one line of the usage log. Count defaults to 1; MS is the total
+// duration of all Count occurrences and MaxMS the longest one.
+type Event struct {
+	At    time.Time `json:"at"`
+	Kind  string    `json:"kind"`
+	Name  string    `json:"name"`
+	Count int       `json:"n,omitempty"`
+	MS    float64   `json:"ms,omitempty"`
+	MaxMS float64   `json:"max_ms,omitempty"`
+}
+
+// Path returns the usage log path, or "" when there is no config directory
+func Path() string {
+	return configdir.Path(Filename)
+}
+
+// Enabled reports whether logging to path is on
+func Enabled(path string) bool {
+	if path == "" {
+		return false
+	}
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// Enable turns logging on by creating an empty log at path. An existing log
+// is kept.
+func Enable(path string) error {
+	if path == "" {
+		return errors.New("no config directory for the usage log")
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("creating usage log directory: %w", err)
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("creating usage log: %w", err)
+	}
+	return f.Close()
+}
+
+// Disable turns logging off and deletes everything recorded
+func Disable(path string) error {
+	if path == "" {
+		return nil
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("deleting usage log: %w", err)
+	}
+	return nil
+}
+
+// Record appends events to the log at path. It does nothing while logging
+// is off: the file is never created here, so a disable always sticks.
+func Record(path string, events ...Event) error {
+	if !Enabled(path) || len(events) == 0 {
+		return nil
+	}
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for _, e := range events {
+		if err := enc.Encode(e); err != nil {
+			return fmt.Errorf("encoding usage event: %w", err)
+		}
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("opening usage log: %w", err)
+	}
+	_, err = f.Write(buf.Bytes())
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return fmt.Errorf("writing usage log: %w", err)
+	}
+	return trim(path)
+}
+
+// trim drops the oldest half of the log once it outgrows MaxBytes
+func trim(path string) error {
+	info, err := os.Stat(path)
+	if err != nil || info.Size() <= MaxBytes {
+		return nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("reading usage log: %w", err)
+	}
+	keep := data[len(data)-MaxBytes/2:]
+	if i := bytes.IndexByte(keep, '\n'); i >= 0 {
+		keep = keep[i+1:]
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, keep, 0o600); err != nil {
+		return fmt.Errorf("trimming usage log: %w", err)
+	}
+	return os.Rename(tmp, path)
+}
+
+// Load reads the usage log at path. A missing file is an empty log;
+// malformed lines are skipped.
+func Load(path string) ([]Event, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("opening usage log: %w", err)
+	}
+	defer f.Close()
+
+	var events []Event
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var e Event
+		if json.Unmarshal(scanner.Bytes(), &e) != nil || e.Kind == "" || e.Name == "" {
+			continue
+		}
+		events = append(events, e)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading usage log: %w", err)
+	}
+	return events, nil
+}
+
+// Stat aggregates the events of one kind and name
+type Stat struct {
+	Name  string  `json:"name"`
+	Count int     `json:"count"`
+	MS    float64 `json:"total_ms,omitempty"`
+	MaxMS float64 `json:"max_ms,omitempty"`
+}
+
+// AvgMS is the mean duration of one occurrence
+func (s Stat) AvgMS() float64 {
+	if s.Count == 0 {
+		return 0
+	}
+	return s.MS / float64(s.Count)
+}
+
+// Summary is the usage log aggregated per kind, each list most used first
+// (phases and renders slowest first)
+type Summary struct {
+	Since  time.Time         `json:"since,omitempty"`
+	Events int               `json:"events"`
+	Kinds  map[string][]Stat `json:"kinds"`
+}
+
+// Summarize aggregates events
+func Summarize(events []Event) Summary {
+	sum := Summary{Events: len(events), Kinds: map[string][]Stat{}}
+	byKind := map[string]map[string]*Stat{}
+	for _, e := range events {
+		if sum.Since.IsZero() || (!e.At.IsZero() && e.At.Before(sum.Since)) {
+			sum.Since = e.At
+		}
+		if byKind[e.Kind] == nil {
+			byKind[e.Kind] = map[string]*Stat{}
+		}
+		s := byKind[e.Kind][e.Name]
+		if s == nil {
+			s = &Stat{Name: e.Name}
+			byKind[e.Kind][e.Name] = s
+		}
+		s.Count += max(e.Count, 1)
+		s.MS += e.MS
+		s.MaxMS = max(s.MaxMS, e.MaxMS, e.MS/float64(max(e.Count, 1)))
+	}
+	for kind, stats := range byKind {
+		list := make([]Stat, 0, len(stats))
+		for _, s := range stats {
+			list = append(list, *s)
+		}
+		slowest := kind == KindPhase || kind == KindRender
+		sort.Slice(list, func(i, j int) bool {
+			a, b := list[i], list[j]
+			if slowest && a.MaxMS != b.MaxMS {
+				return a.MaxMS > b.MaxMS
+			}
+			if a.Count != b.Count {
+				return a.Count > b.Count
+			}
+			return a.Name < b.Name
+		})
+		sum.Kinds[kind] = list
+	}
+	return sum
+}
+
+// Unused returns the names in known that never occur as kind
+func (s Summary) Unused(kind string, known []string) []string {
+	seen := map[string]bool{}
+	for _, st := range s.Kinds[kind] {
+		seen[st.Name] = true
+	}
+	var unused []string
+	for _, name := range known {
+		if !seen[name] {
+			unused = append(unused, name)
+			seen[name] = true
+		}
+	}
+	return unused
+}
@@ -0,0 +1,107 @@
+package usage
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRecord_OnlyWhileEnabled(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bv", Filename)
+	at := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	if err := Record(path, Event{At: at, Kind: KindCommand, Name: "triage"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Fatalf("disabled log was created: %v", err)
+	}
+
+	if err := Enable(path); err != nil {
+		t.Fatal(err)
+	}
+	if err := Record(path, Event{At: at, Kind: KindCommand, Name: "triage"}, Event{At: at, Kind: KindFlag, Name: "robot-triage"}); err != nil {
+		t.Fatal(err)
+	}
+	f, _ := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0)
+	f.WriteString("not json\n{\"kind\":\"view\"}\n")
+	f.Close()
+
+	events, err := Load(path)
+	if err != nil || len(events) != 2 || events[1].Name != "robot-triage" || !events[0].At.Equal(at) {
+		t.Fatalf("events = %+v, %v", events, err)
+	}
+
+	if err := Disable(path); err != nil {
+		t.Fatal(err)
+	}
+	if Enabled(path) {
+		t.Error("still enabled after Disable")
+	}
+	if events, err := Load(path); err != nil || len(events) != 0 {
+		t.Errorf("after Disable = %+v, %v", events, err)
+	}
+}
+
+func TestRecord_TrimsOldEvents(t *testing.T) {
+	path := filepath.Join(t.TempDir(), Filename)
+	if err := Enable(path); err != nil {
+		t.Fatal(err)
+	}
+	long := strings.Repeat("x", 1000)
+	for i := 0; i < MaxBytes/1000+10; i++ {
+		if err := Record(path, Event{Kind: KindCommand, Name: long}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	info, err := os.Stat(path)
+	if err != nil || info.Size() > MaxBytes {
+		t.Fatalf("size = %v, %v", info.Size(), err)
+	}
+	events, err := Load(path)
+	if err != nil || len(events) == 0 {
+		t.Fatalf("events after trim = %d, %v", len(events), err)
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	day1 := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	day2 := day1.AddDate(0, 0, 1)
+	sum := Summarize([]Event{
+		{At: day2, Kind: KindCommand, Name: "triage"},
+		{At: day1, Kind: KindCommand, Name: "plan"},
+		{At: day2, Kind: KindCommand, Name: "triage"},
+		{At: day2, Kind: KindAction, Name: "view.board", Count: 5},
+		{At: day2, Kind: KindPhase, Name: "loader.load_issues", MS: 10},
+		{At: day2, Kind: KindPhase, Name: "loader.load_issues", MS: 30},
+		{At: day2, Kind: KindPhase, Name: "analysis.pagerank", MS: 50},
+		{At: day2, Kind: KindRender, Name: "board", Count: 4, MS: 20, MaxMS: 12},
+	})
+
+	if sum.Events != 8 || !sum.Since.Equal(day1) {
+		t.Errorf("events %d since %v", sum.Events, sum.Since)
+	}
+	if got := sum.Kinds[KindCommand]; len(got) != 2 || got[0] != (Stat{Name: "triage", Count: 2}) {
+		t.Errorf("commands = %+v", got)
+	}
+	if got := sum.Kinds[KindAction]; len(got) != 1 || got[0].Count != 5 {
+		t.Errorf("actions = %+v", got)
+	}
+	phases := sum.Kinds[KindPhase]
+	if len(phases) != 2 || phases[0].Name != "analysis.pagerank" {
+		t.Fatalf("phases not slowest first: %+v", phases)
+	}
+	if load := phases[1]; load.Count != 2 || load.AvgMS() != 20 || load.MaxMS != 30 {
+		t.Errorf("load phase = %+v", load)
+	}
+	if r := sum.Kinds[KindRender][0]; r.AvgMS() != 5 || r.MaxMS != 12 {
+		t.Errorf("render = %+v", r)
+	}
+
+	if got := sum.Unused(KindCommand, []string{"triage", "next", "plan", "insights"}); !reflect.DeepEqual(got, []string{"next", "insights"}) {
+		t.Errorf("unused = %v", got)
+	}
+}
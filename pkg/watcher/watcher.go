@@ -5,6 +5,8 @@ import (
 	"errors"
 	"os"
 	"path/filepath"
+	"runtime"
+	"strings"
 	"sync"
 	"time"
 
@@ -238,14 +240,16 @@ func (w *Watcher) watchFsnotify() {
 			}
 
 			// Only care about events for our specific file
-			eventFile := filepath.Base(event.Name)
-			if eventFile != targetFile {
+			if !sameFileName(filepath.Base(event.Name), targetFile) {
 				continue
 			}
 
 			switch {
 			case event.Op&fsnotify.Remove != 0:
-				w.onError(ErrFileRemoved)
+				// Atomic replaces show up as a remove followed by a create
+				// on some platforms (Windows), so wait for things to settle
+				// before calling the file gone
+				w.debouncer.Trigger(w.checkRemoved)
 
 			case event.Op&(fsnotify.Write|fsnotify.Create|fsnotify.Rename) != 0:
 				w.debouncer.Trigger(w.notifyChange)
@@ -304,6 +308,25 @@ func (w *Watcher) watchPolling() {
 	}
 }
 
+// checkRemoved reports the file as removed if it is still missing once
+// events have settled, and as changed if it came back.
+func (w *Watcher) checkRemoved() {
+	if _, err := os.Stat(w.path); os.IsNotExist(err) {
+		w.onError(ErrFileRemoved)
+		return
+	}
+	w.notifyChange()
+}
+
+// sameFileName compares file names the way the platform's file system does:
+// case-insensitively on Windows.
+func sameFileName(a, b string) bool {
+	if runtime.GOOS == "windows" {
+		return strings.EqualFold(a, b)
+	}
+	return a == b
+}
+
 // notifyChange invokes the onChange callback and signals the change channel.
 func (w *Watcher) notifyChange() {
 	w.mu.RLock()
@@ -252,6 +252,67 @@ func TestWatcher_FileRemoved(t *testing.T) {
 	}
 }
 
+func TestWatcher_RemoveAndRecreateIsAChange(t *testing.T) {
+	tmpDir := t.TempDir()
+	tmpFile := filepath.Join(tmpDir, "test.jsonl")
+
+	if err := os.WriteFile(tmpFile, []byte("initial"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var (
+		mu       sync.Mutex
+		changed  bool
+		gotError error
+	)
+
+	w, err := NewWatcher(tmpFile,
+		WithDebounceDuration(50*time.Millisecond),
+		WithOnChange(func() {
+			mu.Lock()
+			changed = true
+			mu.Unlock()
+		}),
+		WithOnError(func(err error) {
+			mu.Lock()
+			gotError = err
+			mu.Unlock()
+		}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := w.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer w.Stop()
+	if w.IsPolling() {
+		t.Skip("fsnotify unavailable")
+	}
+
+	time.Sleep(50 * time.Millisecond)
+
+	// How atomic replaces look on Windows
+	if err := os.Remove(tmpFile); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(tmpFile, []byte("replaced"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	time.Sleep(200 * time.Millisecond)
+
+	mu.Lock()
+	defer mu.Unlock()
+	if gotError != nil {
+		t.Errorf("unexpected error: %v", gotError)
+	}
+	if !changed {
+		t.Error("expected a change notification")
+	}
+}
+
 func TestWatcher_StartStop(t *testing.T) {
 	tmpDir := t.TempDir()
 	tmpFile := filepath.Join(tmpDir, "test.jsonl")
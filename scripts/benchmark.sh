@@ -4,6 +4,8 @@
 #   ./scripts/benchmark.sh          # Run all benchmarks
 #   ./scripts/benchmark.sh baseline # Save as baseline
 #   ./scripts/benchmark.sh compare  # Compare against baseline
+#   ./scripts/benchmark.sh scale    # 10k/50k issue scale tiers
+#   ./scripts/benchmark.sh regress  # Per-metric check against analysis baseline
 
 set -e
 
@@ -60,6 +62,24 @@ run_quick() {
             -benchmem -count=1 "${BENCH_PACKAGES[@]}" 2>&1 | tee "$CURRENT_FILE"
 }
 
+# Scale tier benchmarks (10k-50k issues, with heap usage)
+run_scale() {
+    echo "Running scale tier benchmarks..."
+    go test -run=xxx -bench='BenchmarkScale_' -benchtime=3x -count=1 ./pkg/analysis/ 2>&1 | tee "$CURRENT_FILE"
+}
+
+# Per-metric regression check against benchmarks/analysis-baseline.json
+# (records the baseline on first run)
+run_regress() {
+    local baseline="$BENCHMARK_DIR/analysis-baseline.json"
+    if [ ! -f "$baseline" ]; then
+        echo "No analysis baseline; recording $baseline"
+        go run ./cmd/bv bench --baseline "$baseline" --update-baseline
+        return
+    fi
+    go run ./cmd/bv bench --baseline "$baseline"
+}
+
 case "${1:-run}" in
     baseline)
         save_baseline
@@ -70,6 +90,12 @@ case "${1:-run}" in
     quick)
         run_quick
         ;;
+    scale)
+        run_scale
+        ;;
+    regress)
+        run_regress
+        ;;
     run|*)
         run_benchmarks
         ;;
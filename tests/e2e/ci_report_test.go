@@ -0,0 +1,87 @@
+package main_test
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCIReportGitHub(t *testing.T) {
+	bv := buildBvBinary(t)
+	envDir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(envDir, ".beads"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	healthy := `{"id":"A","title":"Task A","status":"open","priority":1,"issue_type":"task"}
+{"id":"B","title":"Task B","status":"open","priority":1,"issue_type":"task","dependencies":[{"depends_on_id":"A","type":"blocks"}]}
+`
+	beadsPath := filepath.Join(envDir, ".beads", "beads.jsonl")
+	if err := os.WriteFile(beadsPath, []byte(healthy), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	summaryPath := filepath.Join(envDir, "summary.md")
+	outputPath := filepath.Join(envDir, "outputs.txt")
+	run := func() (string, error) {
+		cmd := exec.Command(bv, "--ci-report", "github")
+		cmd.Dir = envDir
+		cmd.Env = append(os.Environ(),
+			"GITHUB_STEP_SUMMARY="+summaryPath,
+			"GITHUB_OUTPUT="+outputPath,
+		)
+		out, err := cmd.Output()
+		return string(out), err
+	}
+
+	// Healthy graph: succeeds and reports no critical problems.
+	if out, err := run(); err != nil {
+		t.Fatalf("--ci-report on healthy graph failed: %v\n%s", err, out)
+	}
+	outputs, err := os.ReadFile(outputPath)
+	if err != nil {
+		t.Fatalf("read outputs: %v", err)
+	}
+	if !strings.Contains(string(outputs), "critical_count=0") {
+		t.Errorf("expected critical_count=0, got:\n%s", outputs)
+	}
+	summary, err := os.ReadFile(summaryPath)
+	if err != nil {
+		t.Fatalf("read summary: %v", err)
+	}
+	if !strings.Contains(string(summary), "Top picks") {
+		t.Errorf("expected top picks in job summary, got:\n%s", summary)
+	}
+
+	// Introduce a cycle and a malformed line: expect annotations and exit 1.
+	broken := `{"id":"A","title":"Task A","status":"open","priority":1,"issue_type":"task","dependencies":[{"depends_on_id":"B","type":"blocks"}]}
+{"id":"B","title":"Task B","status":"open","priority":1,"issue_type":"task","dependencies":[{"depends_on_id":"A","type":"blocks"}]}
+{not json}
+`
+	if err := os.WriteFile(beadsPath, []byte(broken), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	_ = os.Remove(outputPath)
+
+	out, err := run()
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) || exitErr.ExitCode() != 1 {
+		t.Fatalf("expected exit code 1, got err=%v\n%s", err, out)
+	}
+	if !strings.Contains(out, "::error title=Invalid bead record,file=.beads/beads.jsonl,line=3::") {
+		t.Errorf("missing validation annotation:\n%s", out)
+	}
+	if !strings.Contains(out, "::error title=bv new_cycle::") {
+		t.Errorf("missing cycle annotation:\n%s", out)
+	}
+	outputs, err = os.ReadFile(outputPath)
+	if err != nil {
+		t.Fatalf("read outputs: %v", err)
+	}
+	if !strings.Contains(string(outputs), "has_drift=true") {
+		t.Errorf("expected has_drift=true, got:\n%s", outputs)
+	}
+}
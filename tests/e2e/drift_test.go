@@ -386,10 +386,10 @@ func TestDriftErrorHandling(t *testing.T) {
 	if err == nil {
 		t.Error("Expected error when baseline missing, got success")
 	} else {
-		// Expect exit code 1
+		// Expect exit code 3 (data not found)
 		exitErr, ok := err.(*exec.ExitError)
-		if !ok || exitErr.ExitCode() != 1 {
-			t.Errorf("Expected exit code 1, got %v", err)
+		if !ok || exitErr.ExitCode() != 3 {
+			t.Errorf("Expected exit code 3, got %v", err)
 		}
 		output := string(out)
 		if !strings.Contains(output, "No baseline found") {
@@ -5,7 +5,9 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 )
 
 // Export Incremental Updates E2E Tests (bv-2ino)
@@ -705,6 +707,111 @@ func TestExportIncremental_MultipleReexports(t *testing.T) {
 	}
 }
 
+// TestExportIncremental_ManifestSkipsUnchangedFiles verifies re-exports only
+// rewrite files whose content changed and record hashes in bv-manifest.json.
+func TestExportIncremental_ManifestSkipsUnchangedFiles(t *testing.T) {
+	bv := buildBvBinary(t)
+	stageViewerAssets(t, bv)
+
+	repoDir := t.TempDir()
+	beadsPath := filepath.Join(repoDir, ".beads")
+	if err := os.MkdirAll(beadsPath, 0o755); err != nil {
+		t.Fatalf("mkdir .beads: %v", err)
+	}
+	exportDir := filepath.Join(repoDir, "bv-pages")
+
+	issueData := `{"id": "delta-1", "title": "Delta Export", "status": "open", "priority": 1, "issue_type": "task"}`
+	if err := os.WriteFile(filepath.Join(beadsPath, "issues.jsonl"), []byte(issueData), 0o644); err != nil {
+		t.Fatalf("write issues.jsonl: %v", err)
+	}
+
+	runExportPages(t, bv, repoDir, exportDir)
+
+	var manifest struct {
+		Files map[string]struct {
+			SHA256 string `json:"sha256"`
+		} `json:"files"`
+	}
+	data, err := os.ReadFile(filepath.Join(exportDir, "bv-manifest.json"))
+	if err != nil {
+		t.Fatalf("read manifest: %v", err)
+	}
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		t.Fatalf("parse manifest: %v", err)
+	}
+	for _, f := range []string{"index.html", "viewer.js", "beads.sqlite3", "data/meta.json"} {
+		if manifest.Files[f].SHA256 == "" {
+			t.Errorf("manifest missing %s", f)
+		}
+	}
+
+	// Backdate a static asset; an unchanged re-export must not rewrite it
+	viewerPath := filepath.Join(exportDir, "viewer.js")
+	old := time.Now().Add(-time.Hour).Truncate(time.Second)
+	if err := os.Chtimes(viewerPath, old, old); err != nil {
+		t.Fatalf("chtimes: %v", err)
+	}
+
+	cmd := exec.Command(bv, "--export-pages", exportDir)
+	cmd.Dir = repoDir
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("--export-pages failed: %v\n%s", err, out)
+	}
+	if !strings.Contains(string(out), "unchanged") {
+		t.Errorf("expected sync summary in output:\n%s", out)
+	}
+	info, err := os.Stat(viewerPath)
+	if err != nil {
+		t.Fatalf("stat viewer.js: %v", err)
+	}
+	if !info.ModTime().Equal(old) {
+		t.Errorf("viewer.js rewritten although unchanged (mtime %v, want %v)", info.ModTime(), old)
+	}
+}
+
+// TestExportIncremental_PagesPush verifies --pages-push publishes to a
+// gh-pages branch and skips the commit when nothing changed.
+func TestExportIncremental_PagesPush(t *testing.T) {
+	bv := buildBvBinary(t)
+	stageViewerAssets(t, bv)
+
+	repoDir := t.TempDir()
+	beadsPath := filepath.Join(repoDir, ".beads")
+	if err := os.MkdirAll(beadsPath, 0o755); err != nil {
+		t.Fatalf("mkdir .beads: %v", err)
+	}
+	exportDir := filepath.Join(repoDir, "bv-pages")
+	remote := filepath.Join(t.TempDir(), "pages.git")
+	if out, err := exec.Command("git", "init", "-q", "--bare", remote).CombinedOutput(); err != nil {
+		t.Fatalf("init bare remote: %v\n%s", err, out)
+	}
+
+	issueData := `{"id": "push-1", "title": "Pushed Issue", "status": "open", "priority": 1, "issue_type": "task"}`
+	if err := os.WriteFile(filepath.Join(beadsPath, "issues.jsonl"), []byte(issueData), 0o644); err != nil {
+		t.Fatalf("write issues.jsonl: %v", err)
+	}
+
+	runExportPages(t, bv, repoDir, exportDir, "--pages-push", "--pages-remote", remote)
+
+	ls, err := exec.Command("git", "--git-dir", remote, "ls-tree", "--name-only", "gh-pages").Output()
+	if err != nil {
+		t.Fatalf("ls-tree gh-pages: %v", err)
+	}
+	for _, f := range []string{"index.html", "beads.sqlite3", "bv-manifest.json"} {
+		if !strings.Contains(string(ls), f+"\n") {
+			t.Errorf("gh-pages missing %s; tree:\n%s", f, ls)
+		}
+	}
+
+	// --pages-push without --export-pages is rejected
+	cmd := exec.Command(bv, "--pages-push")
+	cmd.Dir = repoDir
+	if out, err := cmd.CombinedOutput(); err == nil || !strings.Contains(string(out), "requires --export-pages") {
+		t.Errorf("expected usage error, got err=%v\n%s", err, out)
+	}
+}
+
 // =============================================================================
 // Test Helpers
 // =============================================================================
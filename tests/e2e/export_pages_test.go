@@ -54,6 +54,8 @@ func TestExportPages_IncludesHistoryAndRunsHooks(t *testing.T) {
 		filepath.Join(exportDir, "beads.sqlite3"),
 		filepath.Join(exportDir, "beads.sqlite3.config.json"),
 		filepath.Join(exportDir, "hybrid_scorer.js"),
+		filepath.Join(exportDir, "semantic_search.js"),
+		filepath.Join(exportDir, "data", "search_index.json"),
 		filepath.Join(exportDir, "wasm_loader.js"),
 		filepath.Join(exportDir, "data", "meta.json"),
 		filepath.Join(exportDir, "data", "triage.json"),
@@ -530,6 +532,22 @@ func TestExportPages_DependencyGraph(t *testing.T) {
 		t.Fatalf("--export-pages failed: %v\n%s", err, out)
 	}
 
+	// README embeds a pre-rendered graph image
+	svgBytes, err := os.ReadFile(filepath.Join(exportDir, "graph.svg"))
+	if err != nil {
+		t.Fatalf("graph.svg not written: %v", err)
+	}
+	if !strings.Contains(string(svgBytes), "<svg") {
+		t.Fatalf("graph.svg is not an SVG document")
+	}
+	readme, err := os.ReadFile(filepath.Join(exportDir, "README.md"))
+	if err != nil {
+		t.Fatalf("README.md not written: %v", err)
+	}
+	if !strings.Contains(string(readme), "![Dependency graph](graph.svg)") {
+		t.Errorf("README.md should embed graph.svg:\n%s", readme)
+	}
+
 	// Triage should show blocked issues
 	triagePath := filepath.Join(exportDir, "data", "triage.json")
 	triageBytes, err := os.ReadFile(triagePath)
@@ -1276,6 +1294,55 @@ func TestExportPages_DetailPaneMarkup(t *testing.T) {
 	}
 }
 
+// TestExportPages_DeepLinkMarkup verifies the copy-link and saved-view controls
+// are wired up and the viewer encodes selection state in the hash.
+func TestExportPages_DeepLinkMarkup(t *testing.T) {
+	bv := buildBvBinary(t)
+	stageViewerAssets(t, bv)
+
+	repoDir := createSimpleRepo(t, 3)
+	exportDir := filepath.Join(repoDir, "bv-pages")
+
+	cmd := exec.Command(bv, "--export-pages", exportDir)
+	cmd.Dir = repoDir
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("--export-pages failed: %v\n%s", err, out)
+	}
+
+	htmlBytes, err := os.ReadFile(filepath.Join(exportDir, "index.html"))
+	if err != nil {
+		t.Fatalf("read index.html: %v", err)
+	}
+	html := string(htmlBytes)
+	for _, marker := range []string{
+		`@click="copyShareLink()"`,
+		`@click="copyIssueLink(selectedIssue.id)"`,
+		`@click="saveCurrentView()"`,
+		"applySavedView(",
+	} {
+		if !strings.Contains(html, marker) {
+			t.Errorf("index.html missing deep link marker: %s", marker)
+		}
+	}
+
+	jsBytes, err := os.ReadFile(filepath.Join(exportDir, "viewer.js"))
+	if err != nil {
+		t.Fatalf("read viewer.js: %v", err)
+	}
+	viewerJS := string(jsBytes)
+	for _, marker := range []string{
+		"function copyToClipboard",
+		"function syncGraphSelectionToURL",
+		"#/graph?issue=",
+		"params.set('mode'",
+		"focusGraphNode(",
+	} {
+		if !strings.Contains(viewerJS, marker) {
+			t.Errorf("viewer.js missing deep link marker: %s", marker)
+		}
+	}
+}
+
 // TestExportPages_GraphLayoutStructure verifies graph_layout.json has correct structure
 func TestExportPages_GraphLayoutStructure(t *testing.T) {
 	bv := buildBvBinary(t)
@@ -83,6 +83,8 @@ func TestRobotGraph_DOTAndMermaid(t *testing.T) {
 	}{
 		{name: "dot", graphFormat: "dot", wantFormat: "dot", wantSubstr: "digraph"},
 		{name: "mermaid", graphFormat: "mermaid", wantFormat: "mermaid", wantSubstr: "graph"},
+		{name: "graphml", graphFormat: "graphml", wantFormat: "graphml", wantSubstr: `<edge id="e0" source="B" target="A">`},
+		{name: "gexf", graphFormat: "gexf", wantFormat: "gexf", wantSubstr: `<node id="A" label="Root">`},
 	} {
 		t.Run(tt.name, func(t *testing.T) {
 			cmd := exec.Command(bv, "--robot-graph", "--graph-format="+tt.graphFormat)
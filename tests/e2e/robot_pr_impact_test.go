@@ -0,0 +1,68 @@
+package main_test
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRobotPRImpactAgainstBase(t *testing.T) {
+	bv := buildBvBinary(t)
+	repoDir, priorRev := initGitRepo(t)
+
+	// Working tree change: B now depends on A, so B becomes blocked.
+	head := `{"id":"A","title":"Alpha","status":"open","priority":1,"issue_type":"task"}
+{"id":"B","title":"Beta","status":"open","priority":2,"issue_type":"task","dependencies":[{"issue_id":"B","depends_on_id":"A","type":"blocks"}]}
+`
+	if err := os.WriteFile(filepath.Join(repoDir, ".beads", "beads.jsonl"), []byte(head), 0o644); err != nil {
+		t.Fatalf("write beads: %v", err)
+	}
+
+	cmd := exec.Command(bv, "--robot-pr-impact", "--base", "HEAD")
+	cmd.Dir = repoDir
+	out, err := cmd.Output()
+	if err != nil {
+		t.Fatalf("--robot-pr-impact failed: %v\n%s", err, out)
+	}
+
+	var payload struct {
+		Base             string `json:"base"`
+		ResolvedRevision string `json:"resolved_revision"`
+		Impact           struct {
+			Diff struct {
+				FromTimestamp time.Time `json:"from_timestamp"`
+			} `json:"diff"`
+			NewlyBlocked []struct {
+				ID        string   `json:"id"`
+				BlockedBy []string `json:"blocked_by"`
+			} `json:"newly_blocked"`
+			Verdict string `json:"verdict"`
+		} `json:"impact"`
+		CommentMarkdown string `json:"comment_markdown"`
+	}
+	if err := json.Unmarshal(out, &payload); err != nil {
+		t.Fatalf("json decode: %v\nout=%s", err, out)
+	}
+	if payload.Base != "HEAD" {
+		t.Errorf("base = %q, want HEAD", payload.Base)
+	}
+	if payload.ResolvedRevision == "" || payload.ResolvedRevision == priorRev {
+		t.Errorf("resolved_revision should be HEAD's SHA, got %q", payload.ResolvedRevision)
+	}
+	if payload.Impact.Diff.FromTimestamp.IsZero() {
+		t.Error("impact.diff.from_timestamp should be the base commit's time")
+	}
+	if len(payload.Impact.NewlyBlocked) != 1 || payload.Impact.NewlyBlocked[0].ID != "B" {
+		t.Fatalf("expected B newly blocked, got %+v", payload.Impact.NewlyBlocked)
+	}
+	if payload.Impact.Verdict == "ok" {
+		t.Errorf("verdict should flag review, got ok")
+	}
+	if !strings.Contains(payload.CommentMarkdown, "Newly blocked") {
+		t.Errorf("comment_markdown missing newly blocked section:\n%s", payload.CommentMarkdown)
+	}
+}
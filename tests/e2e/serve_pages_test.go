@@ -0,0 +1,94 @@
+package main_test
+
+import (
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// TestServePages_TokenAuthAndShutdown serves an export with token auth and
+// verifies it stops cleanly on SIGTERM.
+func TestServePages_TokenAuthAndShutdown(t *testing.T) {
+	bv := buildBvBinary(t)
+	stageViewerAssets(t, bv)
+
+	repoDir := createSimpleRepo(t, 3)
+	exportDir := filepath.Join(repoDir, "bv-pages")
+	runExportPages(t, bv, repoDir, exportDir)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	port := listener.Addr().(*net.TCPAddr).Port
+	listener.Close()
+
+	cmd := exec.Command(bv, "--serve-pages", exportDir, "--serve-port", itoa(port))
+	cmd.Dir = repoDir
+	cmd.Env = append(os.Environ(), "BV_SERVE_TOKEN=e2e-token")
+	var out strings.Builder
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+	if err := cmd.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer cmd.Process.Kill()
+
+	base := "http://127.0.0.1:" + itoa(port)
+	var resp *http.Response
+	for i := 0; i < 100; i++ {
+		if resp, err = http.Get(base + "/healthz"); err == nil {
+			break
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	if err != nil {
+		t.Fatalf("server never came up: %v\n%s", err, out.String())
+	}
+	resp.Body.Close()
+
+	resp, err = http.Get(base + "/data/meta.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("unauthenticated request: status %d, want 401", resp.StatusCode)
+	}
+
+	req, _ := http.NewRequest(http.MethodGet, base+"/data/meta.json", nil)
+	req.Header.Set("Authorization", "Bearer e2e-token")
+	resp, err = http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || !strings.Contains(string(body), "issue_count") {
+		t.Errorf("authenticated request: status %d body %.80q", resp.StatusCode, body)
+	}
+
+	if err := cmd.Process.Signal(syscall.SIGTERM); err != nil {
+		t.Fatal(err)
+	}
+	done := make(chan error, 1)
+	go func() { done <- cmd.Wait() }()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("bv exited with %v after SIGTERM\n%s", err, out.String())
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("bv did not shut down after SIGTERM")
+	}
+	if !strings.Contains(out.String(), "Shutting down") {
+		t.Errorf("expected shutdown message, got:\n%s", out.String())
+	}
+}
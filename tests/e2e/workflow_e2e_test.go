@@ -1,6 +1,7 @@
 package main_test
 
 import (
+	"encoding/csv"
 	"encoding/json"
 	"os"
 	"os/exec"
@@ -524,6 +525,178 @@ func TestWorkflow_ExportPipeline(t *testing.T) {
 	}
 }
 
+// TestWorkflow_ExportTemplate tests --export-template with built-in and project templates
+func TestWorkflow_ExportTemplate(t *testing.T) {
+	bv := buildBvBinary(t)
+	projectDir := t.TempDir()
+	beadsDir := filepath.Join(projectDir, ".beads")
+	if err := os.MkdirAll(beadsDir, 0755); err != nil {
+		t.Fatalf("mkdir failed: %v", err)
+	}
+
+	issues := `{"id": "PROJ-1", "title": "Feature A", "status": "open", "priority": 1, "issue_type": "feature"}
+{"id": "PROJ-2", "title": "Bug Fix", "status": "in_progress", "priority": 0, "issue_type": "bug", "assignee": "sam"}`
+	if err := os.WriteFile(filepath.Join(beadsDir, "beads.jsonl"), []byte(issues), 0644); err != nil {
+		t.Fatalf("write failed: %v", err)
+	}
+
+	// Built-in template
+	mdPath := filepath.Join(projectDir, "standup.md")
+	cmd := exec.Command(bv, "--export-md", mdPath, "--export-template", "standup")
+	cmd.Dir = projectDir
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("export-template failed: %v\n%s", err, out)
+	}
+	content, err := os.ReadFile(mdPath)
+	if err != nil {
+		t.Fatalf("failed to read markdown: %v", err)
+	}
+	if !strings.Contains(string(content), "# Standup") || !strings.Contains(string(content), "**PROJ-2** Bug Fix (@sam)") {
+		t.Errorf("unexpected standup output:\n%s", content)
+	}
+
+	// Project report.md.tmpl replaces the default report
+	tmplDir := filepath.Join(projectDir, ".bv", "templates")
+	if err := os.MkdirAll(tmplDir, 0755); err != nil {
+		t.Fatalf("mkdir failed: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(tmplDir, "report.md.tmpl"), []byte("{{ .Counts.Total }} issues, {{ .Counts.InProgress }} in progress\n"), 0644); err != nil {
+		t.Fatalf("write failed: %v", err)
+	}
+	mdPath = filepath.Join(projectDir, "report.md")
+	cmd = exec.Command(bv, "--export-md", mdPath)
+	cmd.Dir = projectDir
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("export-md failed: %v\n%s", err, out)
+	}
+	content, err = os.ReadFile(mdPath)
+	if err != nil {
+		t.Fatalf("failed to read markdown: %v", err)
+	}
+	if string(content) != "2 issues, 1 in progress\n" {
+		t.Errorf("project template not used, got:\n%s", content)
+	}
+
+	// Unknown templates fail
+	cmd = exec.Command(bv, "--export-md", mdPath, "--export-template", "missing")
+	cmd.Dir = projectDir
+	out, err := cmd.CombinedOutput()
+	if err == nil || !strings.Contains(string(out), "unknown report template") {
+		t.Errorf("expected unknown template error, got err=%v\n%s", err, out)
+	}
+}
+
+// TestWorkflow_ExportCSV tests --export-csv with and without a recipe
+func TestWorkflow_ExportCSV(t *testing.T) {
+	bv := buildBvBinary(t)
+	projectDir := t.TempDir()
+	beadsDir := filepath.Join(projectDir, ".beads")
+	if err := os.MkdirAll(beadsDir, 0755); err != nil {
+		t.Fatalf("mkdir failed: %v", err)
+	}
+
+	issues := `{"id": "PROJ-1", "title": "Root", "status": "open", "priority": 1, "issue_type": "task"}
+{"id": "PROJ-2", "title": "Needs root", "status": "open", "priority": 2, "issue_type": "feature", "dependencies": [{"issue_id": "PROJ-2", "depends_on_id": "PROJ-1", "type": "blocks"}]}
+{"id": "PROJ-3", "title": "Done", "status": "closed", "priority": 2, "issue_type": "bug"}`
+	if err := os.WriteFile(filepath.Join(beadsDir, "beads.jsonl"), []byte(issues), 0644); err != nil {
+		t.Fatalf("write failed: %v", err)
+	}
+
+	readCSV := func(args ...string) [][]string {
+		t.Helper()
+		csvPath := filepath.Join(projectDir, "issues.csv")
+		cmd := exec.Command(bv, append([]string{"--export-csv", csvPath}, args...)...)
+		cmd.Dir = projectDir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("export-csv failed: %v\n%s", err, out)
+		}
+		f, err := os.Open(csvPath)
+		if err != nil {
+			t.Fatalf("open csv: %v", err)
+		}
+		defer f.Close()
+		records, err := csv.NewReader(f).ReadAll()
+		if err != nil {
+			t.Fatalf("invalid csv: %v", err)
+		}
+		return records
+	}
+
+	records := readCSV()
+	if len(records) != 4 {
+		t.Fatalf("expected header + 3 rows, got %d", len(records))
+	}
+	if records[0][0] != "id" || records[0][14] != "betweenness" {
+		t.Errorf("unexpected header: %v", records[0])
+	}
+
+	// The actionable recipe drops blocked and closed issues
+	records = readCSV("--recipe", "actionable")
+	if len(records) != 2 || records[1][0] != "PROJ-1" {
+		t.Errorf("expected only PROJ-1 with actionable recipe, got %v", records)
+	}
+}
+
+// TestWorkflow_ExportAnnotatedJSONL tests that --export-annotated-jsonl enriches each line and round-trips
+func TestWorkflow_ExportAnnotatedJSONL(t *testing.T) {
+	bv := buildBvBinary(t)
+	projectDir := t.TempDir()
+	beadsDir := filepath.Join(projectDir, ".beads")
+	if err := os.MkdirAll(beadsDir, 0755); err != nil {
+		t.Fatalf("mkdir failed: %v", err)
+	}
+
+	issues := `{"id": "PROJ-1", "title": "Root", "status": "open", "priority": 1, "issue_type": "task"}
+{"id": "PROJ-2", "title": "Needs root", "status": "open", "priority": 2, "issue_type": "feature", "dependencies": [{"issue_id": "PROJ-2", "depends_on_id": "PROJ-1", "type": "blocks"}]}`
+	if err := os.WriteFile(filepath.Join(beadsDir, "beads.jsonl"), []byte(issues), 0644); err != nil {
+		t.Fatalf("write failed: %v", err)
+	}
+
+	cmd := exec.Command(bv, "--export-annotated-jsonl", "-")
+	cmd.Dir = projectDir
+	out, err := cmd.Output()
+	if err != nil {
+		t.Fatalf("export-annotated-jsonl failed: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(string(out)), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 lines, got %d:\n%s", len(lines), out)
+	}
+	var second struct {
+		ID string `json:"id"`
+		BV struct {
+			Blocked   bool     `json:"blocked"`
+			BlockedBy []string `json:"blocked_by"`
+			Forecast  *struct {
+				ETADate string `json:"eta_date"`
+			} `json:"forecast"`
+		} `json:"bv"`
+	}
+	if err := json.Unmarshal([]byte(lines[1]), &second); err != nil {
+		t.Fatalf("invalid JSON line: %v\n%s", err, lines[1])
+	}
+	if second.ID != "PROJ-2" || !second.BV.Blocked || len(second.BV.BlockedBy) != 1 || second.BV.Forecast == nil {
+		t.Errorf("unexpected annotation: %+v", second)
+	}
+
+	// The annotated file is itself a valid beads file
+	annotatedDir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(annotatedDir, ".beads"), 0755); err != nil {
+		t.Fatalf("mkdir failed: %v", err)
+	}
+	cmd = exec.Command(bv, "--export-annotated-jsonl", filepath.Join(annotatedDir, ".beads", "beads.jsonl"))
+	cmd.Dir = projectDir
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("export to file failed: %v\n%s", err, out)
+	}
+	cmd = exec.Command(bv, "--robot-triage")
+	cmd.Dir = annotatedDir
+	if out, err := cmd.Output(); err != nil || !json.Valid(out) {
+		t.Errorf("robot-triage on annotated file failed: %v\n%s", err, out)
+	}
+}
+
 // TestWorkflow_StateTransitions tests state changes are detected correctly
 func TestWorkflow_StateTransitions(t *testing.T) {
 	bv := buildBvBinary(t)